@@ -0,0 +1,361 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package evict
+
+import (
+	"container/list"
+	"sync"
+)
+
+// arcItem 是 ARC 中的条目，记录其所在的链表。
+type arcItem[K comparable, V any] struct {
+	*entry[K, V]
+	// owner 是条目所在的链表，为 t1、t2、b1 或 b2 之一。
+	owner *list.List
+}
+
+// ARC 是按自适应替换缓存（Adaptive Replacement Cache）策略驱逐条目的容器。
+//
+// ARC 把只访问过一次的条目放在 T1，访问过多次的条目放在 T2，并分别在 B1、B2 中记录最近从 T1、T2 驱逐的键
+// （只保留键，不保留值）。写入命中 B1 时增大 T1 的目标大小，命中 B2 时减小，从而在最近访问与频繁访问之间
+// 自适应调整，兼顾 LRU 对突发访问的响应和 LFU 对扫描的抵抗。所有方法都可以并发调用。
+type ARC[K comparable, V any] struct {
+	mu       sync.Mutex
+	capacity int
+	options  options[K, V]
+	// p 是 T1 的目标大小。
+	p int
+	// t1 保存只访问过一次的条目，t2 保存访问过多次的条目，最近访问的在前。
+	t1, t2 *list.List
+	// b1、b2 分别保存最近从 t1、t2 驱逐的键，最近驱逐的在前。
+	b1, b2 *list.List
+	items  map[K]*list.Element
+}
+
+// NewARC 创建容量为 capacity 的 ARC。
+//
+// 除 capacity 个条目外，ARC 最多额外记录 capacity 个已驱逐的键。
+//
+// 参数：
+//   - capacity: 最多保存的条目数，必须为正。
+//   - opts: 函数式选项，例如 WithTTL 与 WithOnEvict。
+//
+// 返回：
+//   - *ARC[K, V]: 新创建的容器。
+//   - error: capacity 不是正数时返回 ErrInvalidCapacity。
+func NewARC[K comparable, V any](capacity int, opts ...Option[K, V]) (*ARC[K, V], error) {
+	o, err := newOptions(capacity, opts)
+	if nil != err {
+		return nil, err
+	}
+	return &ARC[K, V]{
+		capacity: capacity,
+		options:  o,
+		t1:       list.New(),
+		t2:       list.New(),
+		b1:       list.New(),
+		b2:       list.New(),
+		items:    make(map[K]*list.Element, 2*capacity),
+	}, nil
+}
+
+// Get 返回键对应的值，并将其移动到 T2 的最前面。
+//
+// 参数：
+//   - key: 要读取的键。
+//
+// 返回：
+//   - V: 键对应的值；不存在、已过期或只记录在 B1、B2 中时为零值。
+//   - bool: 键是否存在且未过期。
+func (c *ARC[K, V]) Get(key K) (V, bool) {
+	return c.get(key, true)
+}
+
+// Peek 返回键对应的值，不改变驱逐顺序。
+//
+// 参数：
+//   - key: 要读取的键。
+//
+// 返回：
+//   - V: 键对应的值；不存在、已过期或只记录在 B1、B2 中时为零值。
+//   - bool: 键是否存在且未过期。
+func (c *ARC[K, V]) Peek(key K) (V, bool) {
+	return c.get(key, false)
+}
+
+// get 读取键对应的值，过期条目会被清理。
+//
+// 参数：
+//   - key: 要读取的键。
+//   - touch: 是否将条目移动到 T2 的最前面。
+//
+// 返回：
+//   - V: 键对应的值；不存在或已过期时为零值。
+//   - bool: 键是否存在且未过期。
+func (c *ARC[K, V]) get(key K, touch bool) (V, bool) {
+	ev := c.options.collector()
+	defer ev.notify()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var zero V
+	elem, ok := c.items[key]
+	if !ok || !c.live(elem) {
+		return zero, false
+	}
+	item := elem.Value.(*arcItem[K, V])
+	if item.expired(now()) {
+		c.remove(elem, ReasonExpired, ev)
+		return zero, false
+	}
+	if touch {
+		c.move(elem, c.t2)
+	}
+	return item.value, true
+}
+
+// Set 写入键值，容量已满时按 ARC 策略驱逐一个条目。
+//
+// 参数：
+//   - key: 要写入的键。
+//   - value: 要写入的值。
+func (c *ARC[K, V]) Set(key K, value V) {
+	ev := c.options.collector()
+	defer ev.notify()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.items[key]; ok {
+		item := elem.Value.(*arcItem[K, V])
+		switch item.owner {
+		case c.b1:
+			// 最近从 T1 驱逐的键再次写入，说明 T1 偏小。
+			c.p = min(c.p+max(c.b2.Len()/c.b1.Len(), 1), c.capacity)
+			c.replace(false, ev)
+		case c.b2:
+			// 最近从 T2 驱逐的键再次写入，说明 T2 偏小。
+			c.p = max(c.p-max(c.b1.Len()/c.b2.Len(), 1), 0)
+			c.replace(true, ev)
+		}
+		item.value = value
+		c.options.touch(item.entry)
+		c.move(elem, c.t2)
+		return
+	}
+
+	if l1 := c.t1.Len() + c.b1.Len(); l1 == c.capacity {
+		if c.t1.Len() < c.capacity {
+			c.remove(c.b1.Back(), 0, ev)
+			c.replace(false, ev)
+		} else {
+			c.remove(c.t1.Back(), c.reason(c.t1.Back(), ReasonCapacity), ev)
+		}
+	} else if total := l1 + c.t2.Len() + c.b2.Len(); total >= c.capacity {
+		if total >= 2*c.capacity {
+			c.remove(c.b2.Back(), 0, ev)
+		}
+		c.replace(false, ev)
+	}
+
+	item := &arcItem[K, V]{entry: c.options.newEntry(key, value), owner: c.t1}
+	c.items[key] = c.t1.PushFront(item)
+}
+
+// Remove 删除键对应的条目，同时清除 B1、B2 中对该键的记录。
+//
+// 参数：
+//   - key: 要删除的键。
+//
+// 返回：
+//   - bool: 键是否存在且未过期。
+func (c *ARC[K, V]) Remove(key K) bool {
+	ev := c.options.collector()
+	defer ev.notify()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.items[key]
+	if !ok {
+		return false
+	}
+	if !c.live(elem) {
+		c.remove(elem, 0, ev)
+		return false
+	}
+	reason := c.reason(elem, ReasonRemoved)
+	c.remove(elem, reason, ev)
+	return ReasonRemoved == reason
+}
+
+// Len 返回未过期的条目数，过期的条目会在此时清理，不包含 B1、B2 中记录的键。
+//
+// 返回：
+//   - int: 未过期的条目数。
+func (c *ARC[K, V]) Len() int {
+	ev := c.options.collector()
+	defer ev.notify()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.removeExpired(ev)
+	return c.t1.Len() + c.t2.Len()
+}
+
+// Cap 返回容器容量。
+//
+// 返回：
+//   - int: 容器最多保存的条目数。
+func (c *ARC[K, V]) Cap() int {
+	return c.capacity
+}
+
+// Keys 返回未过期的键，先列出 T2 中的键，再列出 T1 中的键，各自最近访问的在前。
+//
+// 返回：
+//   - []K: 未过期的键。
+func (c *ARC[K, V]) Keys() []K {
+	ev := c.options.collector()
+	defer ev.notify()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.removeExpired(ev)
+	keys := make([]K, 0, c.t1.Len()+c.t2.Len())
+	for _, l := range []*list.List{c.t2, c.t1} {
+		for elem := l.Front(); nil != elem; elem = elem.Next() {
+			keys = append(keys, elem.Value.(*arcItem[K, V]).key)
+		}
+	}
+	return keys
+}
+
+// Purge 删除全部条目和 B1、B2 中记录的键，并对每个未过期的条目以 ReasonRemoved 调用驱逐回调。
+func (c *ARC[K, V]) Purge() {
+	ev := c.options.collector()
+	defer ev.notify()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t := now()
+	for _, l := range []*list.List{c.t1, c.t2} {
+		for elem := l.Front(); nil != elem; elem = elem.Next() {
+			if item := elem.Value.(*arcItem[K, V]); !item.expired(t) {
+				ev.add(item.entry, ReasonRemoved)
+			}
+		}
+	}
+	c.t1.Init()
+	c.t2.Init()
+	c.b1.Init()
+	c.b2.Init()
+	clear(c.items)
+	c.p = 0
+}
+
+// replace 在条目数达到容量时把 T1 或 T2 中最久未访问的条目降级到 B1 或 B2，调用方必须持有锁。
+//
+// 参数：
+//   - inB2: 本次写入的键是否记录在 B2 中。
+//   - ev: 收集离开条目的收集器。
+func (c *ARC[K, V]) replace(inB2 bool, ev *evictions[K, V]) {
+	// 删除或过期清理后条目数可能低于容量，此时无需驱逐。
+	if c.t1.Len()+c.t2.Len() < c.capacity {
+		return
+	}
+	from, to := c.t2, c.b2
+	if t1 := c.t1.Len(); t1 > 0 && (t1 > c.p || (inB2 && t1 == c.p) || 0 == c.t2.Len()) {
+		from, to = c.t1, c.b1
+	}
+
+	elem := from.Back()
+	if ReasonExpired == c.reason(elem, ReasonCapacity) {
+		c.remove(elem, ReasonExpired, ev)
+		return
+	}
+	item := elem.Value.(*arcItem[K, V])
+	ev.add(item.entry, ReasonCapacity)
+	// B1、B2 只记录键，释放值的引用。
+	var zero V
+	item.value = zero
+	c.move(elem, to)
+}
+
+// live 判断元素是否位于 T1 或 T2 中，调用方必须持有锁。
+//
+// 参数：
+//   - elem: 要判断的元素。
+//
+// 返回：
+//   - bool: 元素保存着值时返回 true，只记录键时返回 false。
+func (c *ARC[K, V]) live(elem *list.Element) bool {
+	owner := elem.Value.(*arcItem[K, V]).owner
+	return owner == c.t1 || owner == c.t2
+}
+
+// reason 返回元素离开容器的原因，调用方必须持有锁。
+//
+// 参数：
+//   - elem: T1 或 T2 中的元素。
+//   - fallback: 元素未过期时的离开原因。
+//
+// 返回：
+//   - EvictReason: 元素已过期时返回 ReasonExpired，否则返回 fallback。
+func (c *ARC[K, V]) reason(elem *list.Element, fallback EvictReason) EvictReason {
+	if elem.Value.(*arcItem[K, V]).expired(now()) {
+		return ReasonExpired
+	}
+	return fallback
+}
+
+// move 把元素移动到目标链表的最前面，调用方必须持有锁。
+//
+// 参数：
+//   - elem: 要移动的元素。
+//   - to: 目标链表。
+func (c *ARC[K, V]) move(elem *list.Element, to *list.List) {
+	item := elem.Value.(*arcItem[K, V])
+	if item.owner == to {
+		to.MoveToFront(elem)
+		return
+	}
+	item.owner.Remove(elem)
+	item.owner = to
+	c.items[item.key] = to.PushFront(item)
+}
+
+// removeExpired 清理 T1、T2 中全部过期条目，调用方必须持有锁。
+//
+// 参数：
+//   - ev: 收集离开条目的收集器。
+func (c *ARC[K, V]) removeExpired(ev *evictions[K, V]) {
+	if c.options.ttl <= 0 {
+		return
+	}
+	t := now()
+	for _, l := range []*list.List{c.t1, c.t2} {
+		for elem := l.Front(); nil != elem; {
+			next := elem.Next()
+			if elem.Value.(*arcItem[K, V]).expired(t) {
+				c.remove(elem, ReasonExpired, ev)
+			}
+			elem = next
+		}
+	}
+}
+
+// remove 从容器中彻底删除元素，调用方必须持有锁。
+//
+// 参数：
+//   - elem: 要删除的元素。
+//   - reason: 离开原因；删除 B1、B2 中的记录时传入 0，不触发回调。
+//   - ev: 收集离开条目的收集器。
+func (c *ARC[K, V]) remove(elem *list.Element, reason EvictReason, ev *evictions[K, V]) {
+	item := elem.Value.(*arcItem[K, V])
+	item.owner.Remove(elem)
+	delete(c.items, item.key)
+	if 0 != reason {
+		ev.add(item.entry, reason)
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package evict
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestARC_Eviction 验证 ARC 在最近访问与频繁访问之间的驱逐行为。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestARC_Eviction(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		act         func(c *ARC[string, int])
+		wantKeys    []string
+		wantP       int
+	}{
+		{
+			name:        "success/recency",
+			description: "验证只访问一次的条目按写入顺序驱逐。",
+			act: func(c *ARC[string, int]) {
+				for i := 0; i < 4; i++ {
+					c.Set(fmt.Sprintf("k%d", i), i)
+				}
+			},
+			wantKeys: []string{"k3", "k2", "k1"},
+		},
+		{
+			name:        "success/scan-resistance",
+			description: "验证访问过多次的条目进入 T2，一次性扫描大量新键不会驱逐它们。",
+			act: func(c *ARC[string, int]) {
+				c.Set("hot1", 1)
+				c.Set("hot2", 2)
+				c.Get("hot1")
+				c.Get("hot2")
+				for i := 0; i < 20; i++ {
+					c.Set(fmt.Sprintf("scan%d", i), i)
+				}
+			},
+			wantKeys: []string{"hot2", "hot1", "scan19"},
+		},
+		{
+			name:        "success/ghost-hit-b1",
+			description: "验证写入最近从 T1 驱逐到 B1 的键会增大 T1 的目标大小，并把该键放入 T2。",
+			act: func(c *ARC[string, int]) {
+				c.Set("a", 1)
+				c.Set("b", 2)
+				c.Get("a")
+				c.Set("c", 3)
+				// T1 为 c、b，T2 为 a，写入 d 时 T1 超过目标大小，b 被驱逐到 B1。
+				c.Set("d", 4)
+				c.Set("b", 20)
+			},
+			wantKeys: []string{"b", "a", "d"},
+			wantP:    1,
+		},
+		{
+			name:        "success/ghost-hit-b2",
+			description: "验证写入最近从 T2 驱逐到 B2 的键会减小 T1 的目标大小。",
+			act: func(c *ARC[string, int]) {
+				c.Set("a", 1)
+				c.Set("b", 2)
+				c.Get("a")
+				c.Set("c", 3)
+				c.Set("d", 4)
+				// b 命中 B1，p 增大到 1，c 被驱逐到 B1。
+				c.Set("b", 20)
+				// T1 只有 d，未超过目标大小，写入 e 时从 T2 驱逐 a 到 B2。
+				c.Set("e", 5)
+				c.Set("a", 10)
+			},
+			wantKeys: []string{"a", "b", "e"},
+			wantP:    0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			c, err := NewARC[string, int](3)
+			require.NoError(t, err)
+			tt.act(c)
+			assert.Equal(t, tt.wantKeys, c.Keys())
+			assert.Equal(t, tt.wantP, c.p)
+			assert.LessOrEqual(t, c.b1.Len()+c.b2.Len(), c.Cap())
+			assert.Len(t, c.items, c.t1.Len()+c.t2.Len()+c.b1.Len()+c.b2.Len())
+		})
+	}
+}
+
+// TestARC_GhostRemove 验证 Remove 会清除 B1、B2 中的记录，且记录中的键不可读取。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestARC_GhostRemove(t *testing.T) {
+	t.Log("验证被驱逐到 B1 的键不可读取，Remove 返回 false 并清除记录，再次写入按新键处理且不调整目标大小。")
+
+	c, err := NewARC[string, int](2)
+	require.NoError(t, err)
+	c.Set("a", 1)
+	c.Get("a")
+	c.Set("b", 2)
+	c.Set("c", 3)
+
+	_, ok := c.Get("b")
+	assert.False(t, ok)
+	assert.Equal(t, 1, c.b1.Len())
+	assert.False(t, c.Remove("b"))
+	assert.Zero(t, c.b1.Len())
+
+	c.Set("b", 4)
+	assert.Zero(t, c.p)
+	assert.Equal(t, []string{"a", "b"}, c.Keys())
+}
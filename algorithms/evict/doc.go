@@ -0,0 +1,13 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+// Package evict 提供容量有限、按策略驱逐条目的泛型内存容器。
+//
+// NewLRUMap、NewLFUMap 与 NewARC 分别创建按最近最少使用、最不经常使用和自适应替换策略驱逐条目的容器，
+// 三者都实现 Map 接口，所有方法都可以并发调用。WithTTL 为条目设置存活时间，过期条目在访问时惰性清理，
+// 不启动后台 goroutine；WithOnEvict 在条目因容量、过期或显式删除离开容器时回调，回调在释放锁之后执行。
+//
+// 与 cache 包相比，这些容器不做准入控制、不估算成本，条目数严格不超过容量，适合按连接或按请求创建的小型
+// 有界映射，例如 net/message 的会话状态或 http 钩子中的去重表。
+package evict
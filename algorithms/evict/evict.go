@@ -0,0 +1,267 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package evict
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	// ErrInvalidCapacity 表示容器容量不是正数。
+	ErrInvalidCapacity = errors.New("evict: capacity must be positive")
+
+	// now 返回当前时间，测试中可替换以控制过期判断。
+	now = time.Now
+)
+
+const (
+	// ReasonCapacity 表示条目因容量不足被驱逐。
+	ReasonCapacity EvictReason = iota + 1
+	// ReasonExpired 表示条目超过 TTL 被清理。
+	ReasonExpired
+	// ReasonRemoved 表示条目被 Remove 或 Purge 显式删除。
+	ReasonRemoved
+)
+
+type (
+	// EvictReason 表示条目离开容器的原因。
+	EvictReason int
+
+	// EvictFunc 定义条目离开容器时的回调函数。
+	//
+	// 回调在释放容器内部锁之后、触发操作返回之前同步执行，可以安全地再次访问同一容器。
+	//
+	// 参数：
+	//   - key: 条目的键。
+	//   - value: 条目的值。
+	//   - reason: 条目离开容器的原因。
+	EvictFunc[K comparable, V any] func(key K, value V, reason EvictReason)
+
+	// Option 定义修改容器行为的函数式选项。
+	//
+	// 参数：
+	//   - *options[K, V]: 待修改的容器配置，构造函数在应用选项时传入非 nil 指针。
+	Option[K comparable, V any] func(*options[K, V])
+
+	// Map 定义容量有限、按策略驱逐条目的键值容器。
+	//
+	// LRUMap、LFUMap 与 ARC 均实现该接口，且所有方法都可以并发调用。
+	Map[K comparable, V any] interface {
+		// Get 返回键对应的值，并记录一次访问。
+		//
+		// 参数：
+		//   - key: 要读取的键。
+		//
+		// 返回：
+		//   - V: 键对应的值；不存在或已过期时为零值。
+		//   - bool: 键是否存在且未过期。
+		Get(key K) (V, bool)
+
+		// Peek 返回键对应的值，但不记录访问，不影响驱逐顺序。
+		//
+		// 参数：
+		//   - key: 要读取的键。
+		//
+		// 返回：
+		//   - V: 键对应的值；不存在或已过期时为零值。
+		//   - bool: 键是否存在且未过期。
+		Peek(key K) (V, bool)
+
+		// Set 写入键值，并记录一次访问；容量已满时按策略驱逐一个条目。
+		//
+		// 参数：
+		//   - key: 要写入的键。
+		//   - value: 要写入的值。
+		Set(key K, value V)
+
+		// Remove 删除键对应的条目。
+		//
+		// 参数：
+		//   - key: 要删除的键。
+		//
+		// 返回：
+		//   - bool: 键是否存在且未过期。
+		Remove(key K) bool
+
+		// Len 返回未过期的条目数，过期的条目会在此时清理。
+		//
+		// 返回：
+		//   - int: 未过期的条目数。
+		Len() int
+
+		// Cap 返回容器容量。
+		//
+		// 返回：
+		//   - int: 容器最多保存的条目数。
+		Cap() int
+
+		// Keys 返回未过期的键，按驱逐优先级从低到高排列，即最先被驱逐的键在最后。
+		//
+		// 返回：
+		//   - []K: 未过期的键。
+		Keys() []K
+
+		// Purge 删除全部条目，并对每个未过期的条目以 ReasonRemoved 调用驱逐回调。
+		Purge()
+	}
+
+	// options 是容器的可选配置。
+	options[K comparable, V any] struct {
+		// ttl 是条目写入后的存活时间，非正值表示永不过期。
+		ttl time.Duration
+		// onEvict 是条目离开容器时的回调，为 nil 时不回调。
+		onEvict EvictFunc[K, V]
+	}
+
+	// entry 是容器中的条目。
+	entry[K comparable, V any] struct {
+		key   K
+		value V
+		// expireAt 是条目的过期时间，零值表示永不过期。
+		expireAt time.Time
+	}
+
+	// evicted 是等待回调的已离开条目。
+	evicted[K comparable, V any] struct {
+		key    K
+		value  V
+		reason EvictReason
+	}
+
+	// evictions 收集一次操作中离开容器的条目，在释放锁后统一回调。
+	evictions[K comparable, V any] struct {
+		onEvict EvictFunc[K, V]
+		items   []evicted[K, V]
+	}
+)
+
+// String 返回驱逐原因的名称。
+//
+// 返回：
+//   - string: capacity、expired、removed 或 unknown。
+func (r EvictReason) String() string {
+	switch r {
+	case ReasonCapacity:
+		return "capacity"
+	case ReasonExpired:
+		return "expired"
+	case ReasonRemoved:
+		return "removed"
+	default:
+		return "unknown"
+	}
+}
+
+// WithTTL 设置条目写入后的存活时间。
+//
+// 过期条目在访问、Len、Keys 或写入时惰性清理，不使用后台 goroutine。再次 Set 同一个键会重新计算过期时间。
+//
+// 参数：
+//   - ttl: 存活时间，非正值表示永不过期。
+//
+// 返回：
+//   - Option[K, V]: 应用于容器构造函数的函数式选项。
+func WithTTL[K comparable, V any](ttl time.Duration) Option[K, V] {
+	return func(o *options[K, V]) {
+		o.ttl = ttl
+	}
+}
+
+// WithOnEvict 设置条目离开容器时的回调。
+//
+// 参数：
+//   - fn: 驱逐回调；为 nil 时不回调。
+//
+// 返回：
+//   - Option[K, V]: 应用于容器构造函数的函数式选项。
+func WithOnEvict[K comparable, V any](fn EvictFunc[K, V]) Option[K, V] {
+	return func(o *options[K, V]) {
+		o.onEvict = fn
+	}
+}
+
+// newOptions 校验容量并应用选项。
+//
+// 参数：
+//   - capacity: 容器容量。
+//   - opts: 函数式选项，nil 选项会被跳过。
+//
+// 返回：
+//   - options[K, V]: 应用选项后的配置。
+//   - error: capacity 不是正数时返回 ErrInvalidCapacity。
+func newOptions[K comparable, V any](capacity int, opts []Option[K, V]) (options[K, V], error) {
+	var o options[K, V]
+	if capacity <= 0 {
+		return o, ErrInvalidCapacity
+	}
+	for _, opt := range opts {
+		if nil != opt {
+			opt(&o)
+		}
+	}
+	return o, nil
+}
+
+// newEntry 创建按配置计算过期时间的条目。
+//
+// 参数：
+//   - key: 条目的键。
+//   - value: 条目的值。
+//
+// 返回：
+//   - *entry[K, V]: 新条目。
+func (o *options[K, V]) newEntry(key K, value V) *entry[K, V] {
+	e := &entry[K, V]{key: key, value: value}
+	o.touch(e)
+	return e
+}
+
+// touch 按配置重新计算条目的过期时间。
+//
+// 参数：
+//   - e: 要更新的条目。
+func (o *options[K, V]) touch(e *entry[K, V]) {
+	if o.ttl > 0 {
+		e.expireAt = now().Add(o.ttl)
+	}
+}
+
+// collector 返回收集本次操作离开条目的收集器。
+//
+// 返回：
+//   - *evictions[K, V]: 未设置回调时不收集条目的收集器。
+func (o *options[K, V]) collector() *evictions[K, V] {
+	return &evictions[K, V]{onEvict: o.onEvict}
+}
+
+// expired 判断条目是否已过期。
+//
+// 参数：
+//   - t: 判断使用的当前时间。
+//
+// 返回：
+//   - bool: 条目设置了过期时间且已到期时返回 true。
+func (e *entry[K, V]) expired(t time.Time) bool {
+	return !e.expireAt.IsZero() && !t.Before(e.expireAt)
+}
+
+// add 记录一个离开容器的条目。
+//
+// 参数：
+//   - e: 离开容器的条目。
+//   - reason: 离开原因。
+func (ev *evictions[K, V]) add(e *entry[K, V], reason EvictReason) {
+	if nil != ev.onEvict {
+		ev.items = append(ev.items, evicted[K, V]{key: e.key, value: e.value, reason: reason})
+	}
+}
+
+// notify 对收集到的条目依次调用回调，调用方必须已经释放容器锁。
+func (ev *evictions[K, V]) notify() {
+	for _, item := range ev.items {
+		ev.onEvict(item.key, item.value, item.reason)
+	}
+}
@@ -0,0 +1,271 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package evict
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// evictRecord 是驱逐回调收到的一次调用。
+type evictRecord struct {
+	key    string
+	value  int
+	reason EvictReason
+}
+
+// evictRecorder 记录驱逐回调收到的全部调用。
+type evictRecorder struct {
+	mu      sync.Mutex
+	records []evictRecord
+}
+
+// onEvict 作为驱逐回调保存收到的调用。
+func (r *evictRecorder) onEvict(key string, value int, reason EvictReason) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.records = append(r.records, evictRecord{key: key, value: value, reason: reason})
+}
+
+// snapshot 返回已收到的调用并清空记录。
+func (r *evictRecorder) snapshot() []evictRecord {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	records := r.records
+	r.records = nil
+	return records
+}
+
+// fakeClock 把包级 now 替换为可手动推进的时钟，测试结束后恢复。
+//
+// 参数：
+//   - t: 测试上下文，用于注册清理函数。
+//
+// 返回：
+//   - func(time.Duration): 推进时钟的函数。
+func fakeClock(t *testing.T) func(time.Duration) {
+	t.Helper()
+
+	var mu sync.Mutex
+	current := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	original := now
+	now = func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return current
+	}
+	t.Cleanup(func() { now = original })
+	return func(d time.Duration) {
+		mu.Lock()
+		defer mu.Unlock()
+		current = current.Add(d)
+	}
+}
+
+// mapConstructors 返回以相同选项创建三种容器的构造函数，用于验证共同行为。
+var mapConstructors = []struct {
+	name string
+	new  func(capacity int, opts ...Option[string, int]) (Map[string, int], error)
+}{
+	{name: "lru", new: func(capacity int, opts ...Option[string, int]) (Map[string, int], error) {
+		return NewLRUMap(capacity, opts...)
+	}},
+	{name: "lfu", new: func(capacity int, opts ...Option[string, int]) (Map[string, int], error) {
+		return NewLFUMap(capacity, opts...)
+	}},
+	{name: "arc", new: func(capacity int, opts ...Option[string, int]) (Map[string, int], error) {
+		return NewARC(capacity, opts...)
+	}},
+}
+
+// TestMap_Common 验证三种容器共同的读写、容量、TTL 与回调行为。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestMap_Common(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		run         func(t *testing.T, newMap func(int, ...Option[string, int]) (Map[string, int], error))
+	}{
+		{
+			name:        "error/invalid-capacity",
+			description: "验证容量不是正数时返回 ErrInvalidCapacity。",
+			run: func(t *testing.T, newMap func(int, ...Option[string, int]) (Map[string, int], error)) {
+				_, err := newMap(0)
+				assert.ErrorIs(t, err, ErrInvalidCapacity)
+			},
+		},
+		{
+			name:        "success/get-set-remove",
+			description: "验证写入、覆盖、读取、Peek 与删除，nil 选项会被跳过。",
+			run: func(t *testing.T, newMap func(int, ...Option[string, int]) (Map[string, int], error)) {
+				m, err := newMap(2, nil)
+				require.NoError(t, err)
+				assert.Equal(t, 2, m.Cap())
+
+				m.Set("a", 1)
+				m.Set("a", 2)
+				v, ok := m.Get("a")
+				assert.True(t, ok)
+				assert.Equal(t, 2, v)
+				v, ok = m.Peek("a")
+				assert.True(t, ok)
+				assert.Equal(t, 2, v)
+				_, ok = m.Get("missing")
+				assert.False(t, ok)
+				assert.Equal(t, 1, m.Len())
+
+				assert.True(t, m.Remove("a"))
+				assert.False(t, m.Remove("a"))
+				assert.Zero(t, m.Len())
+			},
+		},
+		{
+			name:        "boundary/capacity",
+			description: "验证条目数不超过容量，超出时以 ReasonCapacity 回调被驱逐的条目。",
+			run: func(t *testing.T, newMap func(int, ...Option[string, int]) (Map[string, int], error)) {
+				recorder := &evictRecorder{}
+				m, err := newMap(3, WithOnEvict(recorder.onEvict))
+				require.NoError(t, err)
+				for i := 0; i < 10; i++ {
+					m.Set(fmt.Sprintf("k%d", i), i)
+					assert.LessOrEqual(t, m.Len(), 3)
+				}
+				assert.Equal(t, 3, m.Len())
+				assert.Len(t, m.Keys(), 3)
+
+				records := recorder.snapshot()
+				require.Len(t, records, 7)
+				for _, record := range records {
+					assert.Equal(t, ReasonCapacity, record.reason)
+					_, ok := m.Peek(record.key)
+					assert.False(t, ok)
+				}
+			},
+		},
+		{
+			name:        "success/ttl",
+			description: "验证条目超过 TTL 后不可读取，并以 ReasonExpired 回调；再次写入会重新计算过期时间。",
+			run: func(t *testing.T, newMap func(int, ...Option[string, int]) (Map[string, int], error)) {
+				advance := fakeClock(t)
+				recorder := &evictRecorder{}
+				m, err := newMap(4, WithTTL[string, int](time.Minute), WithOnEvict(recorder.onEvict))
+				require.NoError(t, err)
+
+				m.Set("a", 1)
+				m.Set("b", 2)
+				advance(30 * time.Second)
+				m.Set("b", 3)
+				advance(30 * time.Second)
+
+				_, ok := m.Get("a")
+				assert.False(t, ok)
+				v, ok := m.Get("b")
+				assert.True(t, ok)
+				assert.Equal(t, 3, v)
+				assert.Equal(t, []evictRecord{{key: "a", value: 1, reason: ReasonExpired}}, recorder.snapshot())
+
+				advance(time.Minute)
+				assert.Zero(t, m.Len())
+				assert.Empty(t, m.Keys())
+				assert.Equal(t, []evictRecord{{key: "b", value: 3, reason: ReasonExpired}}, recorder.snapshot())
+			},
+		},
+		{
+			name:        "success/purge",
+			description: "验证 Purge 删除全部条目，并以 ReasonRemoved 回调；Remove 同样以 ReasonRemoved 回调。",
+			run: func(t *testing.T, newMap func(int, ...Option[string, int]) (Map[string, int], error)) {
+				recorder := &evictRecorder{}
+				m, err := newMap(4, WithOnEvict(recorder.onEvict))
+				require.NoError(t, err)
+				m.Set("a", 1)
+				m.Set("b", 2)
+				m.Set("c", 3)
+				require.True(t, m.Remove("c"))
+				assert.Equal(t, []evictRecord{{key: "c", value: 3, reason: ReasonRemoved}}, recorder.snapshot())
+
+				m.Purge()
+				assert.Zero(t, m.Len())
+				assert.ElementsMatch(t, []evictRecord{
+					{key: "a", value: 1, reason: ReasonRemoved},
+					{key: "b", value: 2, reason: ReasonRemoved},
+				}, recorder.snapshot())
+
+				m.Set("d", 4)
+				assert.Equal(t, []string{"d"}, m.Keys())
+			},
+		},
+		{
+			name:        "success/reentrant-callback",
+			description: "验证回调在释放锁之后执行，可以再次访问同一容器。",
+			run: func(t *testing.T, newMap func(int, ...Option[string, int]) (Map[string, int], error)) {
+				var m Map[string, int]
+				lens := make([]int, 0)
+				m, err := newMap(1, WithOnEvict(func(string, int, EvictReason) {
+					lens = append(lens, m.Len())
+				}))
+				require.NoError(t, err)
+				m.Set("a", 1)
+				m.Set("b", 2)
+				assert.Equal(t, []int{1}, lens)
+			},
+		},
+		{
+			name:        "concurrency/parallel-access",
+			description: "验证并发读写不会破坏容量约束。",
+			run: func(t *testing.T, newMap func(int, ...Option[string, int]) (Map[string, int], error)) {
+				m, err := newMap(16)
+				require.NoError(t, err)
+
+				var wg sync.WaitGroup
+				for g := 0; g < 8; g++ {
+					wg.Add(1)
+					go func(g int) {
+						defer wg.Done()
+						for i := 0; i < 500; i++ {
+							key := fmt.Sprintf("k%d", (g*31+i)%40)
+							m.Set(key, i)
+							m.Get(key)
+							if 0 == i%7 {
+								m.Remove(key)
+							}
+						}
+					}(g)
+				}
+				wg.Wait()
+				assert.LessOrEqual(t, m.Len(), 16)
+				assert.Len(t, m.Keys(), m.Len())
+			},
+		},
+	}
+
+	for _, c := range mapConstructors {
+		for _, tt := range tests {
+			t.Run(c.name+"/"+tt.name, func(t *testing.T) {
+				t.Log(tt.description)
+				tt.run(t, c.new)
+			})
+		}
+	}
+}
+
+// TestEvictReason_String 验证驱逐原因的名称。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestEvictReason_String(t *testing.T) {
+	t.Log("验证已知原因返回对应名称，未知原因返回 unknown。")
+
+	assert.Equal(t, "capacity", ReasonCapacity.String())
+	assert.Equal(t, "expired", ReasonExpired.String())
+	assert.Equal(t, "removed", ReasonRemoved.String())
+	assert.Equal(t, "unknown", EvictReason(0).String())
+}
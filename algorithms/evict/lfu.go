@@ -0,0 +1,339 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package evict
+
+import (
+	"container/list"
+	"slices"
+	"sync"
+)
+
+// lfuItem 是 LFUMap 中带访问次数的条目。
+type lfuItem[K comparable, V any] struct {
+	*entry[K, V]
+	// freq 是条目的访问次数。
+	freq int
+	// elem 是条目在 freq 对应链表中的元素。
+	elem *list.Element
+}
+
+// LFUMap 是按最不经常使用（LFU）策略驱逐条目的容器。
+//
+// 每次 Get 或 Set 使条目的访问次数加一，容量已满时驱逐访问次数最少的条目；次数相同时驱逐其中最久未访问的条目。
+// 读写的时间复杂度均为 O(1)。所有方法都可以并发调用。
+type LFUMap[K comparable, V any] struct {
+	mu       sync.Mutex
+	capacity int
+	options  options[K, V]
+	items    map[K]*lfuItem[K, V]
+	// freqs 按访问次数分组保存条目，每组内最近访问的在前。
+	freqs map[int]*list.List
+	// minFreq 是当前最小的访问次数，对应分组为空时在驱逐前重新计算。
+	minFreq int
+}
+
+// NewLFUMap 创建容量为 capacity 的 LFUMap。
+//
+// 参数：
+//   - capacity: 最多保存的条目数，必须为正。
+//   - opts: 函数式选项，例如 WithTTL 与 WithOnEvict。
+//
+// 返回：
+//   - *LFUMap[K, V]: 新创建的容器。
+//   - error: capacity 不是正数时返回 ErrInvalidCapacity。
+func NewLFUMap[K comparable, V any](capacity int, opts ...Option[K, V]) (*LFUMap[K, V], error) {
+	o, err := newOptions(capacity, opts)
+	if nil != err {
+		return nil, err
+	}
+	return &LFUMap[K, V]{
+		capacity: capacity,
+		options:  o,
+		items:    make(map[K]*lfuItem[K, V], capacity),
+		freqs:    make(map[int]*list.List),
+	}, nil
+}
+
+// Get 返回键对应的值，并使其访问次数加一。
+//
+// 参数：
+//   - key: 要读取的键。
+//
+// 返回：
+//   - V: 键对应的值；不存在或已过期时为零值。
+//   - bool: 键是否存在且未过期。
+func (m *LFUMap[K, V]) Get(key K) (V, bool) {
+	return m.get(key, true)
+}
+
+// Peek 返回键对应的值，不改变访问次数。
+//
+// 参数：
+//   - key: 要读取的键。
+//
+// 返回：
+//   - V: 键对应的值；不存在或已过期时为零值。
+//   - bool: 键是否存在且未过期。
+func (m *LFUMap[K, V]) Peek(key K) (V, bool) {
+	return m.get(key, false)
+}
+
+// Frequency 返回键当前的访问次数。
+//
+// 参数：
+//   - key: 要查询的键。
+//
+// 返回：
+//   - int: 访问次数；键不存在或已过期时返回 0。
+func (m *LFUMap[K, V]) Frequency(key K) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if item, ok := m.items[key]; ok && !item.expired(now()) {
+		return item.freq
+	}
+	return 0
+}
+
+// get 读取键对应的值，过期条目会被清理。
+//
+// 参数：
+//   - key: 要读取的键。
+//   - touch: 是否使访问次数加一。
+//
+// 返回：
+//   - V: 键对应的值；不存在或已过期时为零值。
+//   - bool: 键是否存在且未过期。
+func (m *LFUMap[K, V]) get(key K, touch bool) (V, bool) {
+	ev := m.options.collector()
+	defer ev.notify()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var zero V
+	item, ok := m.items[key]
+	if !ok {
+		return zero, false
+	}
+	if item.expired(now()) {
+		m.removeItem(item, ReasonExpired, ev)
+		return zero, false
+	}
+	if touch {
+		m.increment(item)
+	}
+	return item.value, true
+}
+
+// Set 写入键值并使其访问次数加一，容量已满时驱逐访问次数最少的条目。
+//
+// 参数：
+//   - key: 要写入的键。
+//   - value: 要写入的值。
+func (m *LFUMap[K, V]) Set(key K, value V) {
+	ev := m.options.collector()
+	defer ev.notify()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if item, ok := m.items[key]; ok {
+		item.value = value
+		m.options.touch(item.entry)
+		m.increment(item)
+		return
+	}
+
+	if len(m.items) >= m.capacity {
+		victim := m.victim()
+		reason := ReasonCapacity
+		if victim.expired(now()) {
+			reason = ReasonExpired
+		}
+		m.removeItem(victim, reason, ev)
+	}
+	item := &lfuItem[K, V]{entry: m.options.newEntry(key, value), freq: 1}
+	item.elem = m.bucket(1).PushFront(item)
+	m.items[key] = item
+	m.minFreq = 1
+}
+
+// Remove 删除键对应的条目。
+//
+// 参数：
+//   - key: 要删除的键。
+//
+// 返回：
+//   - bool: 键是否存在且未过期。
+func (m *LFUMap[K, V]) Remove(key K) bool {
+	ev := m.options.collector()
+	defer ev.notify()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	item, ok := m.items[key]
+	if !ok {
+		return false
+	}
+	if item.expired(now()) {
+		m.removeItem(item, ReasonExpired, ev)
+		return false
+	}
+	m.removeItem(item, ReasonRemoved, ev)
+	return true
+}
+
+// Len 返回未过期的条目数，过期的条目会在此时清理。
+//
+// 返回：
+//   - int: 未过期的条目数。
+func (m *LFUMap[K, V]) Len() int {
+	ev := m.options.collector()
+	defer ev.notify()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.removeExpired(ev)
+	return len(m.items)
+}
+
+// Cap 返回容器容量。
+//
+// 返回：
+//   - int: 容器最多保存的条目数。
+func (m *LFUMap[K, V]) Cap() int {
+	return m.capacity
+}
+
+// Keys 返回未过期的键，访问次数多的在前，次数相同时最近访问的在前。
+//
+// 返回：
+//   - []K: 未过期的键。
+func (m *LFUMap[K, V]) Keys() []K {
+	ev := m.options.collector()
+	defer ev.notify()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.removeExpired(ev)
+	freqs := make([]int, 0, len(m.freqs))
+	for freq := range m.freqs {
+		freqs = append(freqs, freq)
+	}
+	slices.Sort(freqs)
+
+	keys := make([]K, 0, len(m.items))
+	for i := len(freqs) - 1; i >= 0; i-- {
+		for elem := m.freqs[freqs[i]].Front(); nil != elem; elem = elem.Next() {
+			keys = append(keys, elem.Value.(*lfuItem[K, V]).key)
+		}
+	}
+	return keys
+}
+
+// Purge 删除全部条目，并对每个未过期的条目以 ReasonRemoved 调用驱逐回调。
+func (m *LFUMap[K, V]) Purge() {
+	ev := m.options.collector()
+	defer ev.notify()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	t := now()
+	for _, item := range m.items {
+		if !item.expired(t) {
+			ev.add(item.entry, ReasonRemoved)
+		}
+	}
+	clear(m.items)
+	clear(m.freqs)
+	m.minFreq = 0
+}
+
+// bucket 返回访问次数 freq 对应的链表，不存在时创建。
+//
+// 参数：
+//   - freq: 访问次数。
+//
+// 返回：
+//   - *list.List: 对应的链表。
+func (m *LFUMap[K, V]) bucket(freq int) *list.List {
+	l, ok := m.freqs[freq]
+	if !ok {
+		l = list.New()
+		m.freqs[freq] = l
+	}
+	return l
+}
+
+// increment 使条目访问次数加一并移动到新分组的最前面，调用方必须持有锁。
+//
+// 参数：
+//   - item: 要更新的条目。
+func (m *LFUMap[K, V]) increment(item *lfuItem[K, V]) {
+	m.unlink(item)
+	if m.minFreq == item.freq && nil == m.freqs[item.freq] {
+		m.minFreq++
+	}
+	item.freq++
+	item.elem = m.bucket(item.freq).PushFront(item)
+}
+
+// victim 返回下一个要驱逐的条目，调用方必须持有锁且容器非空。
+//
+// 返回：
+//   - *lfuItem[K, V]: 访问次数最少的分组中最久未访问的条目。
+func (m *LFUMap[K, V]) victim() *lfuItem[K, V] {
+	l, ok := m.freqs[m.minFreq]
+	if !ok {
+		// 删除条目可能清空最小分组，此时重新计算最小访问次数。
+		m.minFreq = 0
+		for freq := range m.freqs {
+			if 0 == m.minFreq || freq < m.minFreq {
+				m.minFreq = freq
+			}
+		}
+		l = m.freqs[m.minFreq]
+	}
+	return l.Back().Value.(*lfuItem[K, V])
+}
+
+// removeExpired 清理全部过期条目，调用方必须持有锁。
+//
+// 参数：
+//   - ev: 收集离开条目的收集器。
+func (m *LFUMap[K, V]) removeExpired(ev *evictions[K, V]) {
+	if m.options.ttl <= 0 {
+		return
+	}
+	t := now()
+	for _, item := range m.items {
+		if item.expired(t) {
+			m.removeItem(item, ReasonExpired, ev)
+		}
+	}
+}
+
+// removeItem 删除条目并记录离开原因，调用方必须持有锁。
+//
+// 参数：
+//   - item: 要删除的条目。
+//   - reason: 离开原因。
+//   - ev: 收集离开条目的收集器。
+func (m *LFUMap[K, V]) removeItem(item *lfuItem[K, V], reason EvictReason, ev *evictions[K, V]) {
+	m.unlink(item)
+	delete(m.items, item.key)
+	ev.add(item.entry, reason)
+}
+
+// unlink 把条目从所在分组中移除，分组为空时删除分组，调用方必须持有锁。
+//
+// 参数：
+//   - item: 要移除的条目。
+func (m *LFUMap[K, V]) unlink(item *lfuItem[K, V]) {
+	l := m.freqs[item.freq]
+	l.Remove(item.elem)
+	if 0 == l.Len() {
+		delete(m.freqs, item.freq)
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package evict
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestLFUMap_Eviction 验证 LFUMap 驱逐访问次数最少的条目，次数相同时驱逐最久未访问的条目。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestLFUMap_Eviction(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		act         func(m *LFUMap[string, int])
+		wantKeys    []string
+		wantFreq    map[string]int
+	}{
+		{
+			name:        "success/least-frequent-evicted",
+			description: "验证访问次数最少的条目被驱逐，即使它最近刚被访问。",
+			act: func(m *LFUMap[string, int]) {
+				m.Set("a", 1)
+				m.Set("b", 2)
+				m.Set("c", 3)
+				m.Get("a")
+				m.Get("a")
+				m.Get("b")
+				m.Set("d", 4)
+			},
+			wantKeys: []string{"a", "b", "d"},
+			wantFreq: map[string]int{"a": 3, "b": 2, "c": 0, "d": 1},
+		},
+		{
+			name:        "success/tie-broken-by-recency",
+			description: "验证访问次数相同时驱逐最久未访问的条目。",
+			act: func(m *LFUMap[string, int]) {
+				m.Set("a", 1)
+				m.Set("b", 2)
+				m.Set("c", 3)
+				m.Set("d", 4)
+			},
+			wantKeys: []string{"d", "c", "b"},
+			wantFreq: map[string]int{"a": 0, "b": 1},
+		},
+		{
+			name:        "success/peek-does-not-count",
+			description: "验证 Peek 不增加访问次数，覆盖写入会增加访问次数。",
+			act: func(m *LFUMap[string, int]) {
+				m.Set("a", 1)
+				m.Set("b", 2)
+				m.Set("c", 3)
+				m.Peek("a")
+				m.Set("b", 20)
+				m.Set("d", 4)
+			},
+			wantKeys: []string{"b", "d", "c"},
+			wantFreq: map[string]int{"a": 0, "b": 2},
+		},
+		{
+			name:        "boundary/min-frequency-removed",
+			description: "验证删除条目并重新写入后，最小访问次数随访问更新，驱逐仍选择访问次数最少的条目。",
+			act: func(m *LFUMap[string, int]) {
+				m.Set("a", 1)
+				m.Get("a")
+				m.Set("b", 2)
+				m.Get("b")
+				m.Get("b")
+				m.Set("c", 3)
+				m.Remove("c")
+				m.Set("c", 3)
+				m.Get("c")
+				m.Get("c")
+				m.Get("c")
+				m.Set("d", 4)
+				m.Set("e", 5)
+			},
+			wantKeys: []string{"c", "b", "e"},
+			wantFreq: map[string]int{"a": 0, "d": 0, "e": 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			m, err := NewLFUMap[string, int](3)
+			require.NoError(t, err)
+			tt.act(m)
+			assert.Equal(t, tt.wantKeys, m.Keys())
+			for key, freq := range tt.wantFreq {
+				assert.Equal(t, freq, m.Frequency(key), key)
+			}
+		})
+	}
+}
@@ -0,0 +1,241 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package evict
+
+import (
+	"container/list"
+	"sync"
+)
+
+// LRUMap 是按最近最少使用（LRU）策略驱逐条目的容器。
+//
+// 容量已满时写入新键会驱逐最久未被 Get 或 Set 访问的条目。所有方法都可以并发调用。
+type LRUMap[K comparable, V any] struct {
+	mu       sync.Mutex
+	capacity int
+	options  options[K, V]
+	// ll 按访问时间排列条目，最近访问的在前。
+	ll    *list.List
+	items map[K]*list.Element
+}
+
+// NewLRUMap 创建容量为 capacity 的 LRUMap。
+//
+// 参数：
+//   - capacity: 最多保存的条目数，必须为正。
+//   - opts: 函数式选项，例如 WithTTL 与 WithOnEvict。
+//
+// 返回：
+//   - *LRUMap[K, V]: 新创建的容器。
+//   - error: capacity 不是正数时返回 ErrInvalidCapacity。
+func NewLRUMap[K comparable, V any](capacity int, opts ...Option[K, V]) (*LRUMap[K, V], error) {
+	o, err := newOptions(capacity, opts)
+	if nil != err {
+		return nil, err
+	}
+	return &LRUMap[K, V]{
+		capacity: capacity,
+		options:  o,
+		ll:       list.New(),
+		items:    make(map[K]*list.Element, capacity),
+	}, nil
+}
+
+// Get 返回键对应的值，并将其标记为最近使用。
+//
+// 参数：
+//   - key: 要读取的键。
+//
+// 返回：
+//   - V: 键对应的值；不存在或已过期时为零值。
+//   - bool: 键是否存在且未过期。
+func (m *LRUMap[K, V]) Get(key K) (V, bool) {
+	return m.get(key, true)
+}
+
+// Peek 返回键对应的值，不改变驱逐顺序。
+//
+// 参数：
+//   - key: 要读取的键。
+//
+// 返回：
+//   - V: 键对应的值；不存在或已过期时为零值。
+//   - bool: 键是否存在且未过期。
+func (m *LRUMap[K, V]) Peek(key K) (V, bool) {
+	return m.get(key, false)
+}
+
+// get 读取键对应的值，过期条目会被清理。
+//
+// 参数：
+//   - key: 要读取的键。
+//   - touch: 是否将条目标记为最近使用。
+//
+// 返回：
+//   - V: 键对应的值；不存在或已过期时为零值。
+//   - bool: 键是否存在且未过期。
+func (m *LRUMap[K, V]) get(key K, touch bool) (V, bool) {
+	ev := m.options.collector()
+	defer ev.notify()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var zero V
+	elem, ok := m.items[key]
+	if !ok {
+		return zero, false
+	}
+	e := elem.Value.(*entry[K, V])
+	if e.expired(now()) {
+		m.removeElement(elem, ReasonExpired, ev)
+		return zero, false
+	}
+	if touch {
+		m.ll.MoveToFront(elem)
+	}
+	return e.value, true
+}
+
+// Set 写入键值并将其标记为最近使用，容量已满时驱逐最久未使用的条目。
+//
+// 参数：
+//   - key: 要写入的键。
+//   - value: 要写入的值。
+func (m *LRUMap[K, V]) Set(key K, value V) {
+	ev := m.options.collector()
+	defer ev.notify()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if elem, ok := m.items[key]; ok {
+		e := elem.Value.(*entry[K, V])
+		e.value = value
+		m.options.touch(e)
+		m.ll.MoveToFront(elem)
+		return
+	}
+
+	if m.ll.Len() >= m.capacity {
+		back := m.ll.Back()
+		reason := ReasonCapacity
+		if back.Value.(*entry[K, V]).expired(now()) {
+			reason = ReasonExpired
+		}
+		m.removeElement(back, reason, ev)
+	}
+	m.items[key] = m.ll.PushFront(m.options.newEntry(key, value))
+}
+
+// Remove 删除键对应的条目。
+//
+// 参数：
+//   - key: 要删除的键。
+//
+// 返回：
+//   - bool: 键是否存在且未过期。
+func (m *LRUMap[K, V]) Remove(key K) bool {
+	ev := m.options.collector()
+	defer ev.notify()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	elem, ok := m.items[key]
+	if !ok {
+		return false
+	}
+	if elem.Value.(*entry[K, V]).expired(now()) {
+		m.removeElement(elem, ReasonExpired, ev)
+		return false
+	}
+	m.removeElement(elem, ReasonRemoved, ev)
+	return true
+}
+
+// Len 返回未过期的条目数，过期的条目会在此时清理。
+//
+// 返回：
+//   - int: 未过期的条目数。
+func (m *LRUMap[K, V]) Len() int {
+	ev := m.options.collector()
+	defer ev.notify()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.removeExpired(ev)
+	return m.ll.Len()
+}
+
+// Cap 返回容器容量。
+//
+// 返回：
+//   - int: 容器最多保存的条目数。
+func (m *LRUMap[K, V]) Cap() int {
+	return m.capacity
+}
+
+// Keys 返回未过期的键，最近使用的在前。
+//
+// 返回：
+//   - []K: 未过期的键。
+func (m *LRUMap[K, V]) Keys() []K {
+	ev := m.options.collector()
+	defer ev.notify()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.removeExpired(ev)
+	keys := make([]K, 0, m.ll.Len())
+	for elem := m.ll.Front(); nil != elem; elem = elem.Next() {
+		keys = append(keys, elem.Value.(*entry[K, V]).key)
+	}
+	return keys
+}
+
+// Purge 删除全部条目，并对每个未过期的条目以 ReasonRemoved 调用驱逐回调。
+func (m *LRUMap[K, V]) Purge() {
+	ev := m.options.collector()
+	defer ev.notify()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	t := now()
+	for elem := m.ll.Front(); nil != elem; elem = elem.Next() {
+		if e := elem.Value.(*entry[K, V]); !e.expired(t) {
+			ev.add(e, ReasonRemoved)
+		}
+	}
+	m.ll.Init()
+	clear(m.items)
+}
+
+// removeExpired 清理全部过期条目，调用方必须持有锁。
+//
+// 参数：
+//   - ev: 收集离开条目的收集器。
+func (m *LRUMap[K, V]) removeExpired(ev *evictions[K, V]) {
+	if m.options.ttl <= 0 {
+		return
+	}
+	t := now()
+	for elem := m.ll.Front(); nil != elem; {
+		next := elem.Next()
+		if elem.Value.(*entry[K, V]).expired(t) {
+			m.removeElement(elem, ReasonExpired, ev)
+		}
+		elem = next
+	}
+}
+
+// removeElement 删除条目并记录离开原因，调用方必须持有锁。
+//
+// 参数：
+//   - elem: 要删除的链表元素。
+//   - reason: 离开原因。
+//   - ev: 收集离开条目的收集器。
+func (m *LRUMap[K, V]) removeElement(elem *list.Element, reason EvictReason, ev *evictions[K, V]) {
+	e := m.ll.Remove(elem).(*entry[K, V])
+	delete(m.items, e.key)
+	ev.add(e, reason)
+}
@@ -0,0 +1,84 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package evict
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestLRUMap_Eviction 验证 LRUMap 驱逐最久未访问的条目。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestLRUMap_Eviction(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		act         func(m *LRUMap[string, int])
+		wantKeys    []string
+	}{
+		{
+			name:        "success/oldest-evicted",
+			description: "验证未被访问时按写入顺序驱逐。",
+			act: func(m *LRUMap[string, int]) {
+				m.Set("a", 1)
+				m.Set("b", 2)
+				m.Set("c", 3)
+				m.Set("d", 4)
+			},
+			wantKeys: []string{"d", "c", "b"},
+		},
+		{
+			name:        "success/get-refreshes",
+			description: "验证 Get 使条目变为最近使用，驱逐次久未访问的条目。",
+			act: func(m *LRUMap[string, int]) {
+				m.Set("a", 1)
+				m.Set("b", 2)
+				m.Set("c", 3)
+				m.Get("a")
+				m.Set("d", 4)
+			},
+			wantKeys: []string{"d", "a", "c"},
+		},
+		{
+			name:        "success/peek-does-not-refresh",
+			description: "验证 Peek 不改变驱逐顺序。",
+			act: func(m *LRUMap[string, int]) {
+				m.Set("a", 1)
+				m.Set("b", 2)
+				m.Set("c", 3)
+				m.Peek("a")
+				m.Set("d", 4)
+			},
+			wantKeys: []string{"d", "c", "b"},
+		},
+		{
+			name:        "success/set-refreshes",
+			description: "验证覆盖已有的键使其变为最近使用且不驱逐其它条目。",
+			act: func(m *LRUMap[string, int]) {
+				m.Set("a", 1)
+				m.Set("b", 2)
+				m.Set("c", 3)
+				m.Set("a", 10)
+				m.Set("d", 4)
+			},
+			wantKeys: []string{"d", "a", "c"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			m, err := NewLRUMap[string, int](3)
+			require.NoError(t, err)
+			tt.act(m)
+			assert.Equal(t, tt.wantKeys, m.Keys())
+		})
+	}
+}
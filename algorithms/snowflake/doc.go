@@ -8,6 +8,14 @@
 // 节点分配唯一的 nodeid，并在调整 Epoch 或位宽配置后重新创建节点，确保生成与解析
 // 使用同一组位布局。
 //
+// GenerateBatch 在一次加锁中预留整段序列号，用于批量写入；Node.Decompose 和 Node.TimeOf
+// 按节点创建时的布局拆解 ID，包级 Decompose、TimeOf 则使用当前全局配置。ValidateEpochConfig
+// 用于在创建节点前校验 Epoch 和位宽配置。
+//
+// WithLayout 为单个节点选择位布局而不修改全局配置：LayoutDefault 为经典的 41/10/12 布局，LayoutJSSafe
+// 为总长 53 位的 41/5/7 布局，生成的 ID 可被 JavaScript Number 精确表示；也可以自定义 Layout，
+// NewNode 会在创建时校验位分配与 Epoch。位布局较短时各编码结果随数值自动变短。
+//
 // 生成的 ID 支持十进制、Base2、z-base-32、Base36、Base58、Base64 以及字节表示，
 // 并可通过对应的 Parse* 函数恢复。Time、Node 和 Step 等字段提取方法保留用于兼容旧
 // 版本，它们依赖当前的全局位宽配置。
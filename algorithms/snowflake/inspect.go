@@ -0,0 +1,108 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package snowflake
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	// maxLayoutBits 表示节点编号和序列号可共享的比特总数。
+	maxLayoutBits = 22
+	// idBits 表示 ID 可使用的比特数，最高位保留为符号位。
+	idBits = 63
+)
+
+var (
+	// ErrInvalidBitLayout 表示 NodeBits 与 StepBits 的总和超过 22，或 Layout 的位分配不合法。
+	ErrInvalidBitLayout = errors.New("invalid bit layout")
+	// ErrEpochInFuture 表示 Epoch 晚于当前时间，生成的时间分量将为负数。
+	ErrEpochInFuture = errors.New("epoch in future")
+	// ErrEpochExhausted 表示当前时间距 Epoch 已超出时间分量可表示的范围。
+	ErrEpochExhausted = errors.New("epoch exhausted")
+)
+
+type (
+	// Components 表示 Snowflake ID 拆解后的各个分量。
+	Components struct {
+		// Timestamp 是 ID 生成时的毫秒时间戳，已加上 Epoch。
+		Timestamp int64
+		// Node 是生成 ID 的节点编号。
+		Node int64
+		// Step 是同一毫秒内的序列号。
+		Step int64
+	}
+)
+
+// Time 返回 Timestamp 对应的时间。
+//
+// 参数：无。
+//
+// 返回：
+//   - time.Time: 毫秒精度的生成时间。
+func (c Components) Time() time.Time {
+	return time.UnixMilli(c.Timestamp)
+}
+
+// Decompose 按当前全局 Epoch、NodeBits 和 StepBits 拆解 ID。
+//
+// 与已弃用的 ID.Time、ID.Node 和 ID.Step 不同，Decompose 直接读取当前的全局配置，
+// 不依赖 NewNode 计算的包级派生变量；已持有节点时优先使用 Node.Decompose，
+// 以保证解析与生成使用同一组位布局。
+//
+// 参数：
+//   - id: 待拆解的 ID。
+//
+// 返回：
+//   - Components: ID 的时间戳、节点编号和序列号。
+func Decompose(id ID) Components {
+	stepMask := int64(-1 ^ (-1 << StepBits))
+	nodeMax := int64(-1 ^ (-1 << NodeBits))
+	return Components{
+		Timestamp: (int64(id) >> (NodeBits + StepBits)) + Epoch,
+		Node:      (int64(id) >> StepBits) & nodeMax,
+		Step:      int64(id) & stepMask,
+	}
+}
+
+// TimeOf 按当前全局 Epoch 和位宽配置返回 ID 的生成时间。
+//
+// 参数：
+//   - id: 待解析的 ID。
+//
+// 返回：
+//   - time.Time: ID 中时间分量对应的时间，精度为毫秒。
+func TimeOf(id ID) time.Time {
+	return Decompose(id).Time()
+}
+
+// ValidateEpochConfig 校验当前全局 Epoch、NodeBits 和 StepBits 配置。
+//
+// 建议在修改全局配置后、调用 NewNode 前执行，以便尽早发现 Epoch 配置错误（例如误用秒级时间戳）
+// 或时间分量即将耗尽的情况。
+//
+// 参数：无。
+//
+// 返回：
+//   - error: 位宽总和超过 22 时返回 ErrInvalidBitLayout；Epoch 晚于当前时间时返回 ErrEpochInFuture；
+//     当前时间超出时间分量可表示范围时返回 ErrEpochExhausted。错误均可通过 errors.Is 判断。
+func ValidateEpochConfig() error {
+	if NodeBits+StepBits > maxLayoutBits {
+		return fmt.Errorf("%w: node bits %d + step bits %d exceeds %d", ErrInvalidBitLayout, NodeBits, StepBits, maxLayoutBits)
+	}
+
+	now := time.Now().UnixMilli()
+	if Epoch > now {
+		return fmt.Errorf("%w: epoch %d is after now %d", ErrEpochInFuture, Epoch, now)
+	}
+
+	maxElapsed := int64(1)<<(idBits-NodeBits-StepBits) - 1
+	if now-Epoch > maxElapsed {
+		return fmt.Errorf("%w: %d ms elapsed since epoch exceeds %d", ErrEpochExhausted, now-Epoch, maxElapsed)
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package snowflake
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// jsSafeBits 表示 JavaScript Number 可精确表示的整数比特数，最大安全整数为 2^53-1。
+	jsSafeBits = 53
+)
+
+var (
+	// LayoutDefault 是经典的 41/10/12 位布局：约 69 年时间范围、1024 个节点、每毫秒 4096 个序列号。
+	LayoutDefault = Layout{TimeBits: 41, NodeBits: 10, StepBits: 12}
+
+	// LayoutJSSafe 是总长 53 位的 41/5/7 位布局，ID 不超过 JavaScript 的 Number.MAX_SAFE_INTEGER，
+	// 浏览器可直接以数字处理而不丢失精度：约 69 年时间范围、32 个节点、每毫秒 128 个序列号。
+	LayoutJSSafe = Layout{TimeBits: 41, NodeBits: 5, StepBits: 7}
+)
+
+type (
+	// Layout 表示 ID 中时间戳、节点编号和序列号各自占用的比特数，由高位到低位依次排列。
+	//
+	// 三者之和不能超过 63，最高位保留为符号位；总和小于 63 时高位补零，ID 的取值上限随之降低，
+	// 十进制、Base58、Base64 等编码结果随数值自动变短，无需额外配置。
+	Layout struct {
+		// TimeBits 是毫秒时间戳占用的比特数，决定自 Epoch 起可使用的时间范围。
+		TimeBits uint8
+		// NodeBits 是节点编号占用的比特数，可以为 0 表示单节点部署。
+		NodeBits uint8
+		// StepBits 是同一毫秒内序列号占用的比特数。
+		StepBits uint8
+	}
+
+	// NodeOption 定义了 NewNode 的配置选项函数类型。
+	NodeOption func(*nodeOptions)
+
+	// nodeOptions 包含了创建节点时的可选配置。
+	nodeOptions struct {
+		// layout 是节点使用的位布局，为 nil 时使用全局 NodeBits 与 StepBits。
+		layout *Layout
+	}
+)
+
+// WithLayout 为节点指定位布局，取代全局 NodeBits 和 StepBits。
+//
+// NewNode 会在创建时校验位布局，并确认当前时间距 Epoch 的间隔能被时间分量表示。使用 WithLayout 创建的节点
+// 不会修改包级派生变量，已弃用的 ID.Time、ID.Node、ID.Step 以及包级 Decompose、TimeOf 无法正确解析其 ID，
+// 应使用 Node.Decompose 和 Node.TimeOf。
+//
+// 参数：
+//   - layout: 位布局，可使用 LayoutDefault、LayoutJSSafe 或自定义分配。
+//
+// 返回：
+//   - NodeOption: 可传给 NewNode 的配置选项。
+func WithLayout(layout Layout) NodeOption {
+	return func(o *nodeOptions) {
+		o.layout = &layout
+	}
+}
+
+// globalLayout 返回当前全局 NodeBits 与 StepBits 对应的位布局，时间戳占用剩余的全部比特。
+//
+// 参数：无。
+//
+// 返回：
+//   - Layout: 与全局配置等价的位布局；NodeBits 与 StepBits 之和超过 63 时 TimeBits 为 0。
+func globalLayout() Layout {
+	l := Layout{NodeBits: NodeBits, StepBits: StepBits}
+	if NodeBits+StepBits < idBits {
+		l.TimeBits = idBits - NodeBits - StepBits
+	}
+	return l
+}
+
+// Validate 校验位布局。
+//
+// 参数：无。
+//
+// 返回：
+//   - error: TimeBits 或 StepBits 为 0，或三者之和超过 63 时返回包装了 ErrInvalidBitLayout 的错误。
+func (l Layout) Validate() error {
+	if 0 == l.TimeBits || 0 == l.StepBits {
+		return fmt.Errorf("%w: time bits %d and step bits %d must be positive", ErrInvalidBitLayout, l.TimeBits, l.StepBits)
+	}
+	if total := int(l.TimeBits) + int(l.NodeBits) + int(l.StepBits); total > idBits {
+		return fmt.Errorf("%w: time bits %d + node bits %d + step bits %d exceeds %d", ErrInvalidBitLayout, l.TimeBits, l.NodeBits, l.StepBits, idBits)
+	}
+	return nil
+}
+
+// Bits 返回位布局占用的总比特数。
+//
+// 参数：无。
+//
+// 返回：
+//   - uint8: TimeBits、NodeBits 与 StepBits 之和。
+func (l Layout) Bits() uint8 {
+	return l.TimeBits + l.NodeBits + l.StepBits
+}
+
+// JSSafe 判断该位布局生成的 ID 是否都能被 JavaScript Number 精确表示。
+//
+// 参数：无。
+//
+// 返回：
+//   - bool: 总比特数不超过 53 时返回 true。
+func (l Layout) JSSafe() bool {
+	return int(l.TimeBits)+int(l.NodeBits)+int(l.StepBits) <= jsSafeBits
+}
+
+// MaxNode 返回该位布局可表示的最大节点编号。
+//
+// 参数：无。
+//
+// 返回：
+//   - int64: 节点编号的最大值；NodeBits 为 0 时为 0。
+func (l Layout) MaxNode() int64 {
+	return -1 ^ (-1 << l.NodeBits)
+}
+
+// MaxID 返回该位布局可生成的最大 ID。
+//
+// 参数：无。
+//
+// 返回：
+//   - ID: 各分量均取最大值时的 ID。
+func (l Layout) MaxID() ID {
+	return ID(-1 ^ (-1 << l.Bits()))
+}
+
+// Lifetime 返回该位布局的时间分量自 Epoch 起可覆盖的时长。
+//
+// 参数：无。
+//
+// 返回：
+//   - time.Duration: 时间分量最大值对应的时长；超出 time.Duration 表示范围时返回其最大值。
+func (l Layout) Lifetime() time.Duration {
+	maxElapsed := int64(-1 ^ (-1 << l.TimeBits))
+	if maxElapsed > int64(1<<63-1)/int64(time.Millisecond) {
+		return time.Duration(1<<63 - 1)
+	}
+	return time.Duration(maxElapsed) * time.Millisecond
+}
+
+// validateEpoch 校验 Epoch 到当前时间的间隔能被时间分量表示。
+//
+// 参数：
+//   - epoch: 起始时间戳，单位为毫秒。
+//   - now: 当前时间戳，单位为毫秒。
+//
+// 返回：
+//   - error: Epoch 晚于当前时间时返回 ErrEpochInFuture；间隔超出时间分量范围时返回 ErrEpochExhausted。
+func (l Layout) validateEpoch(epoch, now int64) error {
+	if epoch > now {
+		return fmt.Errorf("%w: epoch %d is after now %d", ErrEpochInFuture, epoch, now)
+	}
+	maxElapsed := int64(-1 ^ (-1 << l.TimeBits))
+	if now-epoch > maxElapsed {
+		return fmt.Errorf("%w: %d ms elapsed since epoch exceeds %d", ErrEpochExhausted, now-epoch, maxElapsed)
+	}
+	return nil
+}
@@ -0,0 +1,218 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package snowflake
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestLayout_Validate 验证位布局的合法性校验与派生取值。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestLayout_Validate(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		giveLayout  Layout
+		wantErr     error
+		wantJSSafe  bool
+		wantMaxNode int64
+	}{
+		{
+			name:        "success/default",
+			description: "验证经典 41/10/12 布局合法且超出 JavaScript 安全整数范围。",
+			giveLayout:  LayoutDefault,
+			wantMaxNode: 1023,
+		},
+		{
+			name:        "success/js-safe",
+			description: "验证 53 位布局合法且在 JavaScript 安全整数范围内。",
+			giveLayout:  LayoutJSSafe,
+			wantJSSafe:  true,
+			wantMaxNode: 31,
+		},
+		{
+			name:        "boundary/single-node",
+			description: "验证节点位宽可以为 0，此时只允许节点编号 0。",
+			giveLayout:  Layout{TimeBits: 41, StepBits: 12},
+			wantJSSafe:  true,
+			wantMaxNode: 0,
+		},
+		{
+			name:        "boundary/full-63-bits",
+			description: "验证三者之和恰好为 63 时合法。",
+			giveLayout:  Layout{TimeBits: 43, NodeBits: 8, StepBits: 12},
+			wantMaxNode: 255,
+		},
+		{
+			name:        "error/exceeds-63-bits",
+			description: "验证三者之和超过 63 时返回 ErrInvalidBitLayout。",
+			giveLayout:  Layout{TimeBits: 42, NodeBits: 10, StepBits: 12},
+			wantErr:     ErrInvalidBitLayout,
+		},
+		{
+			name:        "error/zero-time-bits",
+			description: "验证时间位宽为 0 时返回 ErrInvalidBitLayout。",
+			giveLayout:  Layout{NodeBits: 10, StepBits: 12},
+			wantErr:     ErrInvalidBitLayout,
+		},
+		{
+			name:        "error/zero-step-bits",
+			description: "验证序列号位宽为 0 时返回 ErrInvalidBitLayout。",
+			giveLayout:  Layout{TimeBits: 41, NodeBits: 10},
+			wantErr:     ErrInvalidBitLayout,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			err := tt.giveLayout.Validate()
+			if nil != tt.wantErr {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantJSSafe, tt.giveLayout.JSSafe())
+			assert.Equal(t, tt.wantMaxNode, tt.giveLayout.MaxNode())
+			assert.Equal(t, ID(int64(1)<<tt.giveLayout.Bits()-1), tt.giveLayout.MaxID())
+		})
+	}
+
+	assert.Equal(t, ID(1<<53-1), LayoutJSSafe.MaxID(), "JS 安全布局的最大 ID 等于 Number.MAX_SAFE_INTEGER")
+	assert.Equal(t, time.Duration(1<<41-1)*time.Millisecond, LayoutDefault.Lifetime())
+	assert.Equal(t, time.Duration(math.MaxInt64), Layout{TimeBits: 63}.Lifetime())
+}
+
+// TestNewNode_WithLayout 验证按节点选择位布局时的生成、拆解、编码和创建校验。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestNewNode_WithLayout(t *testing.T) {
+	now := time.Now().UnixMilli()
+
+	tests := []struct {
+		name        string
+		description string
+		giveEpoch   int64
+		giveLayout  Layout
+		giveNodeID  int64
+		wantErr     error
+		wantErrText string
+	}{
+		{
+			name:        "success/js-safe",
+			description: "验证 JS 安全布局生成的 ID 不超过 2^53-1，并按布局拆解。",
+			giveEpoch:   1740515125000,
+			giveLayout:  LayoutJSSafe,
+			giveNodeID:  31,
+		},
+		{
+			name:        "success/custom",
+			description: "验证自定义位分配按布局生成与拆解。",
+			giveEpoch:   1740515125000,
+			giveLayout:  Layout{TimeBits: 40, NodeBits: 3, StepBits: 4},
+			giveNodeID:  6,
+		},
+		{
+			name:        "error/node-out-of-range",
+			description: "验证节点编号超出布局范围时返回错误。",
+			giveEpoch:   1740515125000,
+			giveLayout:  LayoutJSSafe,
+			giveNodeID:  32,
+			wantErrText: "Node number must be between 0 and 31",
+		},
+		{
+			name:        "error/invalid-layout",
+			description: "验证非法布局在创建节点时被拒绝。",
+			giveEpoch:   1740515125000,
+			giveLayout:  Layout{TimeBits: 50, NodeBits: 10, StepBits: 12},
+			wantErr:     ErrInvalidBitLayout,
+		},
+		{
+			name:        "error/epoch-exhausted",
+			description: "验证时间位宽无法覆盖 Epoch 至今的间隔时返回 ErrEpochExhausted。",
+			giveEpoch:   1740515125000,
+			giveLayout:  Layout{TimeBits: 20, NodeBits: 5, StepBits: 7},
+			wantErr:     ErrEpochExhausted,
+		},
+		{
+			name:        "error/epoch-in-future",
+			description: "验证 Epoch 晚于当前时间时返回 ErrEpochInFuture。",
+			giveEpoch:   now + int64(time.Hour/time.Millisecond),
+			giveLayout:  LayoutJSSafe,
+			wantErr:     ErrEpochInFuture,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+			configureSnowflakeGlobals(t, tt.giveEpoch, 10, 12)
+
+			n, err := NewNode(tt.giveNodeID, WithLayout(tt.giveLayout))
+			if nil != tt.wantErr {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			if "" != tt.wantErrText {
+				require.EqualError(t, err, tt.wantErrText)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.giveLayout, n.Layout())
+
+			before := time.Now().Add(-time.Millisecond)
+			ids := append(n.GenerateBatch(1<<tt.giveLayout.StepBits+1), n.Generate())
+			after := time.Now().Add(time.Millisecond)
+			for i, id := range ids {
+				assert.LessOrEqual(t, id, tt.giveLayout.MaxID())
+				if i > 0 {
+					assert.Greater(t, id, ids[i-1])
+				}
+				parts := n.Decompose(id)
+				assert.Equal(t, tt.giveNodeID, parts.Node)
+				assert.WithinRange(t, n.TimeOf(id), before, after)
+
+				// 编码长度随取值变化，解析后与原值一致。
+				parsed, err := ParseBase58([]byte(id.Base58()))
+				require.NoError(t, err)
+				assert.Equal(t, id, parsed)
+				parsed, err = ParseBase64(id.Base64())
+				require.NoError(t, err)
+				assert.Equal(t, id, parsed)
+			}
+
+			// 指定布局的节点不修改包级派生变量。
+			assert.Equal(t, int64(1023), nodeMax)
+		})
+	}
+}
+
+// TestNewNode_Layout_Global 验证未指定布局时节点的布局与全局配置一致。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestNewNode_Layout_Global(t *testing.T) {
+	configureSnowflakeGlobals(t, 1740515125000, 10, 12)
+	assert.Equal(t, Layout{TimeBits: 41, NodeBits: 10, StepBits: 12}, newTestNode(t, 1).Layout())
+
+	configureSnowflakeGlobals(t, 1740515125000, 5, 7)
+	assert.Equal(t, Layout{TimeBits: 51, NodeBits: 5, StepBits: 7}, newTestNode(t, 1).Layout())
+
+	// JS 安全布局的 ID 比默认布局的编码更短。
+	js, err := NewNode(1, WithLayout(LayoutJSSafe))
+	require.NoError(t, err)
+	def, err := NewNode(1, WithLayout(LayoutDefault))
+	require.NoError(t, err)
+	assert.Less(t, len(js.Generate().String()), len(def.Generate().String()))
+}
@@ -12,6 +12,8 @@ import (
 	"strconv"
 	"sync"
 	"time"
+
+	kitbytes "github.com/fsyyft-go/kit/bytes"
 )
 
 var (
@@ -37,18 +39,11 @@ var (
 	nodeShift            = StepBits              // 节点编号左移位数。
 )
 
-const (
-	// encodeBase32Map 定义了 z-base-32 字符集，用于 Base32 编码。
-	encodeBase32Map = "ybndrfg8ejkmcpqxot1uwisza345h769"
-	// encodeBase58Map 定义了 Base58 字符集，用于 Base58 编码。
-	encodeBase58Map = "123456789abcdefghijkmnopqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ"
-)
-
 var (
-	// decodeBase32Map 用于 Base32 解码，将字符映射为索引。
-	decodeBase32Map [256]byte
-	// decodeBase58Map 用于 Base58 解码，将字符映射为索引。
-	decodeBase58Map [256]byte
+	// base32Alphabet 是 z-base-32 字符表，用于 Base32 编码。
+	base32Alphabet = kitbytes.MustNewAlphabet(kitbytes.AlphabetZBase32)
+	// base58Alphabet 是 Flickr Base58 字符表，用于 Base58 编码。
+	base58Alphabet = kitbytes.MustNewAlphabet(kitbytes.AlphabetBase58Flickr)
 	// ErrInvalidBase58 表示 Base58 解析遇到未定义字符。
 	// ParseBase58 返回该错误时结果值为 -1，调用方可使用 errors.Is 判断该错误。
 	ErrInvalidBase58 = errors.New("invalid base58")
@@ -72,28 +67,6 @@ func (j JSONSyntaxError) Error() string {
 	return fmt.Sprintf("invalid snowflake ID %q", string(j.original))
 }
 
-// init 初始化 Base58 和 Base32 的解码映射表，加速解码过程。
-//
-// 参数：无。
-func init() {
-	// 初始化 Base58 解码表，所有字符默认无效。
-	for i := 0; i < len(decodeBase58Map); i++ {
-		decodeBase58Map[i] = 0xFF
-	}
-	// 填充 Base58 有效字符的索引。
-	for i := 0; i < len(encodeBase58Map); i++ {
-		decodeBase58Map[encodeBase58Map[i]] = byte(i)
-	}
-	// 初始化 Base32 解码表，所有字符默认无效。
-	for i := 0; i < len(decodeBase32Map); i++ {
-		decodeBase32Map[i] = 0xFF
-	}
-	// 填充 Base32 有效字符的索引。
-	for i := 0; i < len(encodeBase32Map); i++ {
-		decodeBase32Map[encodeBase32Map[i]] = byte(i)
-	}
-}
-
 type (
 	// Node 定义生成 Snowflake ID 的节点能力。
 	Node interface {
@@ -104,58 +77,115 @@ type (
 		// 返回：
 		//   - ID: 由当前节点生成的唯一 ID。
 		Generate() ID
+
+		// GenerateBatch 在一次加锁中连续预留序列号，生成 count 个单调递增的 ID，适用于批量写入场景。
+		//
+		// 参数：
+		//   - count: 需要生成的 ID 数量；小于等于 0 时返回 nil。
+		//
+		// 返回：
+		//   - []ID: 按生成顺序排列的 ID。
+		GenerateBatch(count int) []ID
+
+		// Decompose 按节点创建时的位布局拆解 ID。
+		//
+		// 参数：
+		//   - id: 待拆解的 ID。
+		//
+		// 返回：
+		//   - Components: ID 的时间戳、节点编号和序列号。
+		Decompose(id ID) Components
+
+		// TimeOf 按节点创建时的 Epoch 和位布局返回 ID 的生成时间。
+		//
+		// 参数：
+		//   - id: 待解析的 ID。
+		//
+		// 返回：
+		//   - time.Time: ID 中时间分量对应的时间，精度为毫秒。
+		TimeOf(id ID) time.Time
+
+		// Layout 返回节点创建时确定的位布局。
+		//
+		// 参数：无。
+		//
+		// 返回：
+		//   - Layout: 节点使用的位布局；未使用 WithLayout 时 TimeBits 为 63 减去全局 NodeBits 与 StepBits。
+		Layout() Layout
 	}
 	// node 实现 Node 接口，保存生成 Snowflake ID 所需的位布局和运行状态。
 	node struct {
-		mu    sync.Mutex // 互斥锁，保证同一节点并发生成 ID 时的状态安全。
-		epoch time.Time  // 起始时间，保留单调时钟信息。
-		time  int64      // 上一次生成 ID 的时间戳，单位为毫秒。
-		node  int64      // 当前节点编号。
-		step  int64      // 当前毫秒内的序列号。
-
-		nodeMax   int64 // 节点编号最大值。
-		nodeMask  int64 // 节点掩码。
-		stepMask  int64 // 序列号掩码。
-		timeShift uint8 // 时间戳左移位数。
-		nodeShift uint8 // 节点编号左移位数。
+		mu      sync.Mutex // 互斥锁，保证同一节点并发生成 ID 时的状态安全。
+		epoch   time.Time  // 起始时间，保留单调时钟信息。
+		epochMs int64      // 起始时间的毫秒时间戳，用于解析 ID。
+		time    int64      // 上一次生成 ID 的时间戳，单位为毫秒。
+		node    int64      // 当前节点编号。
+		step    int64      // 当前毫秒内的序列号。
+
+		layout    Layout // 节点使用的位布局。
+		nodeMax   int64  // 节点编号最大值。
+		nodeMask  int64  // 节点掩码。
+		stepMask  int64  // 序列号掩码。
+		timeShift uint8  // 时间戳左移位数。
+		nodeShift uint8  // 节点编号左移位数。
 	}
 	// ID 表示 Snowflake 生成的唯一标识。
 	ID int64
 )
 
-// NewNode 创建一个使用当前全局位布局的 Snowflake 节点。
+// NewNode 创建一个 Snowflake 节点。
 //
-// NewNode 会按当前 Epoch、NodeBits 和 StepBits 初始化节点，并为兼容旧版本组件
-// 解析方法重新计算包级派生掩码。调用方应为每个节点分配唯一的 nodeid，并避免在节点
-// 创建后修改全局位宽配置。
+// 未指定 WithLayout 时，NewNode 按当前 Epoch、NodeBits 和 StepBits 初始化节点，并为兼容旧版本组件
+// 解析方法重新计算包级派生掩码；指定 WithLayout 时使用该位布局，并在创建时校验布局与 Epoch。
+// 调用方应为每个节点分配唯一的 nodeid，并避免在节点创建后修改全局配置。
 //
 // 参数：
-//   - nodeid: 节点编号，必须位于 0 到当前 NodeBits 可表示的最大值之间。
+//   - nodeid: 节点编号，必须位于 0 到位布局可表示的最大节点编号之间。
+//   - opts: 可选的节点配置，例如 WithLayout(LayoutJSSafe)。
 //
 // 返回：
 //   - Node: 初始化完成的节点，可用于并发生成 ID。
-//   - error: NodeBits 与 StepBits 总和超过 22，或 nodeid 超出可用范围时返回错误。
-func NewNode(nodeid int64) (Node, error) {
+//   - error: 未指定 WithLayout 且 NodeBits 与 StepBits 总和超过 22，或 nodeid 超出可用范围时返回错误；
+//     指定 WithLayout 时还可能返回包装了 ErrInvalidBitLayout、ErrEpochInFuture 或 ErrEpochExhausted 的错误。
+func NewNode(nodeid int64, opts ...NodeOption) (Node, error) {
+	var options nodeOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
 
-	if NodeBits+StepBits > 22 {
-		return nil, errors.New("remember, you have a total 22 bits to share between Node/Step")
+	var layout Layout
+	if nil != options.layout {
+		layout = *options.layout
+		if err := layout.Validate(); nil != err {
+			return nil, err
+		}
+		if err := layout.validateEpoch(Epoch, time.Now().UnixMilli()); nil != err {
+			return nil, err
+		}
+	} else {
+		if NodeBits+StepBits > maxLayoutBits {
+			return nil, errors.New("remember, you have a total 22 bits to share between Node/Step")
+		}
+		// 重新计算全局变量，兼容旧版本，未来将移除。
+		mu.Lock()
+		nodeMax = -1 ^ (-1 << NodeBits)
+		nodeMask = nodeMax << StepBits
+		stepMask = -1 ^ (-1 << StepBits)
+		timeShift = NodeBits + StepBits
+		nodeShift = StepBits
+		mu.Unlock()
+		layout = globalLayout()
 	}
-	// 重新计算全局变量，兼容旧版本，未来将移除。
-	mu.Lock()
-	nodeMax = -1 ^ (-1 << NodeBits)
-	nodeMask = nodeMax << StepBits
-	stepMask = -1 ^ (-1 << StepBits)
-	timeShift = NodeBits + StepBits
-	nodeShift = StepBits
-	mu.Unlock()
 
 	n := node{}
 	n.node = nodeid
-	n.nodeMax = -1 ^ (-1 << NodeBits)
-	n.nodeMask = n.nodeMax << StepBits
-	n.stepMask = -1 ^ (-1 << StepBits)
-	n.timeShift = NodeBits + StepBits
-	n.nodeShift = StepBits
+	n.epochMs = Epoch
+	n.layout = layout
+	n.nodeMax = layout.MaxNode()
+	n.nodeMask = n.nodeMax << layout.StepBits
+	n.stepMask = -1 ^ (-1 << layout.StepBits)
+	n.timeShift = layout.NodeBits + layout.StepBits
+	n.nodeShift = layout.StepBits
 
 	if n.node < 0 || n.node > n.nodeMax {
 		return nil, errors.New("Node number must be between 0 and " + strconv.FormatInt(n.nodeMax, 10))
@@ -207,6 +237,94 @@ func (n *node) Generate() ID {
 	return r
 }
 
+// GenerateBatch 在一次加锁中连续预留序列号，生成 count 个单调递增的 ID。
+//
+// 每个毫秒内剩余的序列号会被整段预留，只在序列号耗尽时读取时钟并等待到下一毫秒，
+// 因此比循环调用 Generate 的加锁和取时开销更低。批量生成期间其它 Generate 调用会被阻塞。
+//
+// 参数：
+//   - count: 需要生成的 ID 数量；小于等于 0 时返回 nil。
+//
+// 返回：
+//   - []ID: 按生成顺序排列的 ID。
+func (n *node) GenerateBatch(count int) []ID {
+	if count <= 0 {
+		return nil
+	}
+
+	ids := make([]ID, 0, count)
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	for len(ids) < count {
+		now := time.Since(n.epoch).Milliseconds()
+
+		var step int64
+		if now == n.time {
+			step = n.step + 1
+			if step > n.stepMask {
+				// 当前毫秒内序列号已耗尽，等待到下一毫秒。
+				for now <= n.time {
+					now = time.Since(n.epoch).Milliseconds()
+				}
+				step = 0
+			}
+		}
+
+		// 预留当前毫秒内剩余的序列号。
+		last := step + int64(count-len(ids)) - 1
+		if last > n.stepMask {
+			last = n.stepMask
+		}
+		base := now<<n.timeShift | n.node<<n.nodeShift
+		for s := step; s <= last; s++ {
+			ids = append(ids, ID(base|s))
+		}
+
+		n.time = now
+		n.step = last
+	}
+
+	return ids
+}
+
+// Decompose 按节点创建时的位布局拆解 ID。
+//
+// 参数：
+//   - id: 待拆解的 ID。
+//
+// 返回：
+//   - Components: ID 的时间戳、节点编号和序列号。
+func (n *node) Decompose(id ID) Components {
+	return Components{
+		Timestamp: (int64(id) >> n.timeShift) + n.epochMs,
+		Node:      (int64(id) & n.nodeMask) >> n.nodeShift,
+		Step:      int64(id) & n.stepMask,
+	}
+}
+
+// TimeOf 按节点创建时的 Epoch 和位布局返回 ID 的生成时间。
+//
+// 参数：
+//   - id: 待解析的 ID。
+//
+// 返回：
+//   - time.Time: ID 中时间分量对应的时间，精度为毫秒。
+func (n *node) TimeOf(id ID) time.Time {
+	return time.UnixMilli(n.Decompose(id).Timestamp)
+}
+
+// Layout 返回节点创建时确定的位布局。
+//
+// 参数：无。
+//
+// 返回：
+//   - Layout: 节点使用的位布局。
+func (n *node) Layout() Layout {
+	return n.layout
+}
+
 // Int64 返回当前 ID 的 int64 表示。
 //
 // 参数：无。
@@ -246,24 +364,7 @@ func (f ID) Base2() string {
 // 返回：
 //   - string: 使用 z-base-32 编码的 ID 字符串。
 func (f ID) Base32() string {
-
-	if f < 32 {
-		return string(encodeBase32Map[f])
-	}
-
-	b := make([]byte, 0, 12)
-	for f >= 32 {
-		b = append(b, encodeBase32Map[f%32])
-		f /= 32
-	}
-	b = append(b, encodeBase32Map[f])
-
-	// 反转字节顺序，得到正确编码。
-	for x, y := 0, len(b)-1; x < y; x, y = x+1, y-1 {
-		b[x], b[y] = b[y], b[x]
-	}
-
-	return string(b)
+	return base32Alphabet.EncodeUint64(uint64(f))
 }
 
 // Base36 返回当前 ID 的 base36 编码字符串。
@@ -285,24 +386,7 @@ func (f ID) Base36() string {
 // 返回：
 //   - string: 使用 Base58 编码的 ID 字符串。
 func (f ID) Base58() string {
-
-	if f < 58 {
-		return string(encodeBase58Map[f])
-	}
-
-	b := make([]byte, 0, 11)
-	for f >= 58 {
-		b = append(b, encodeBase58Map[f%58])
-		f /= 58
-	}
-	b = append(b, encodeBase58Map[f])
-
-	// 反转字节顺序，得到正确编码。
-	for x, y := 0, len(b)-1; x < y; x, y = x+1, y-1 {
-		b[x], b[y] = b[y], b[x]
-	}
-
-	return string(b)
+	return base58Alphabet.EncodeUint64(uint64(f))
 }
 
 // Base64 返回当前 ID 十进制字节表示的 base64 编码字符串。
@@ -459,26 +543,16 @@ func ParseBase2(id string) (ID, error) {
 
 // ParseBase32 将 z-base-32 字节切片解析为 ID。
 //
-// ParseBase32 使用本包内置的 z-base-32 字符表，不执行 int64 溢出检测。
+// ParseBase32 使用 z-base-32 字符表，超出 int64 但未超出 uint64 范围的值按补码回绕，不单独检测。
 //
 // 参数：
 //   - b: 待解析的 z-base-32 编码字节切片。
 //
 // 返回：
-//   - ID: 解析成功时得到的 ID；遇到非法字符时返回 -1。
-//   - error: b 包含未定义字符时返回 ErrInvalidBase32，调用方可使用 errors.Is 判断。
+//   - ID: 解析成功时得到的 ID；解析失败时返回 -1。
+//   - error: b 包含未定义字符时返回 ErrInvalidBase32，超出 uint64 范围时返回包装了 kitbytes.ErrValueOverflow 的错误，调用方可使用 errors.Is 判断。
 func ParseBase32(b []byte) (ID, error) {
-
-	var id int64
-
-	for i := range b {
-		if decodeBase32Map[b[i]] == 0xFF {
-			return -1, ErrInvalidBase32
-		}
-		id = id*32 + int64(decodeBase32Map[b[i]])
-	}
-
-	return ID(id), nil
+	return parseAlphabet(base32Alphabet, b, ErrInvalidBase32)
 }
 
 // ParseBase36 将 base36 字符串解析为 ID。
@@ -496,25 +570,35 @@ func ParseBase36(id string) (ID, error) {
 
 // ParseBase58 将 Base58 字节切片解析为 ID。
 //
-// ParseBase58 使用本包内置字符表，不执行 int64 溢出检测。
+// ParseBase58 使用 Flickr Base58 字符表，超出 int64 但未超出 uint64 范围的值按补码回绕，不单独检测。
 //
 // 参数：
 //   - b: 待解析的 Base58 编码字节切片。
 //
 // 返回：
-//   - ID: 解析成功时得到的 ID；遇到非法字符时返回 -1。
-//   - error: b 包含未定义字符时返回 ErrInvalidBase58，调用方可使用 errors.Is 判断。
+//   - ID: 解析成功时得到的 ID；解析失败时返回 -1。
+//   - error: b 包含未定义字符时返回 ErrInvalidBase58，超出 uint64 范围时返回包装了 kitbytes.ErrValueOverflow 的错误，调用方可使用 errors.Is 判断。
 func ParseBase58(b []byte) (ID, error) {
+	return parseAlphabet(base58Alphabet, b, ErrInvalidBase58)
+}
 
-	var id int64
-
-	for i := range b {
-		if decodeBase58Map[b[i]] == 0xFF {
-			return -1, ErrInvalidBase58
-		}
-		id = id*58 + int64(decodeBase58Map[b[i]])
+// parseAlphabet 使用字符表将字节切片解析为 ID。
+//
+// 参数：
+//   - alphabet: 编码使用的字符表。
+//   - b: 待解析的编码字节切片。
+//   - errInvalid: 遇到未定义字符时返回的错误。
+//
+// 返回：
+//   - ID: 解析成功时得到的 ID；解析失败时返回 -1。
+//   - error: b 包含未定义字符时返回 errInvalid；超出 uint64 范围时返回包装了 kitbytes.ErrValueOverflow 的错误。
+func parseAlphabet(alphabet *kitbytes.Alphabet, b []byte, errInvalid error) (ID, error) {
+	id, err := alphabet.DecodeUint64(b)
+	if errors.Is(err, kitbytes.ErrInvalidCharacter) {
+		return -1, errInvalid
+	} else if nil != err {
+		return -1, err
 	}
-
 	return ID(id), nil
 }
 
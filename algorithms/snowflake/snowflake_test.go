@@ -700,6 +700,161 @@ func TestID_IntBytes_BigEndian(t *testing.T) {
 	}
 }
 
+// TestNode_GenerateBatch 验证批量生成的 ID 唯一、单调递增，并在序列号耗尽时跨越毫秒。
+//
+// 该测试使用较小的序列位宽，使一次批量生成必然跨越多个毫秒，并与单个 Generate 交替调用验证状态衔接。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestNode_GenerateBatch(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		giveCount   int
+		wantLen     int
+	}{
+		{
+			name:        "boundary/zero",
+			description: "验证数量为零时返回 nil。",
+			giveCount:   0,
+			wantLen:     0,
+		},
+		{
+			name:        "boundary/negative",
+			description: "验证数量为负数时返回 nil。",
+			giveCount:   -1,
+			wantLen:     0,
+		},
+		{
+			name:        "success/within-millisecond",
+			description: "验证小批量生成在序列号容量内完成。",
+			giveCount:   3,
+			wantLen:     3,
+		},
+		{
+			name:        "success/across-milliseconds",
+			description: "验证批量数量超过单毫秒序列号容量时跨越多个毫秒。",
+			giveCount:   20,
+			wantLen:     20,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+			configureSnowflakeGlobals(t, 1740515125000, 10, 2)
+			n := newTestNode(t, 5)
+
+			first := n.Generate()
+			ids := n.GenerateBatch(tt.giveCount)
+			last := n.Generate()
+
+			require.Len(t, ids, tt.wantLen)
+			all := append(append([]ID{first}, ids...), last)
+			for i := 1; i < len(all); i++ {
+				assert.Greater(t, all[i].Int64(), all[i-1].Int64())
+				parts := n.Decompose(all[i])
+				assert.Equal(t, int64(5), parts.Node)
+				assert.LessOrEqual(t, parts.Step, int64(3))
+			}
+		})
+	}
+}
+
+// TestDecompose_AndTimeOf 验证节点方法与包级函数按位布局拆解 ID。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestDecompose_AndTimeOf(t *testing.T) {
+	configureSnowflakeGlobals(t, 1740515125000, 8, 6)
+	n := newTestNode(t, 200)
+
+	before := time.Now().Add(-time.Millisecond)
+	id := n.Generate()
+	after := time.Now().Add(time.Millisecond)
+
+	parts := n.Decompose(id)
+	assert.Equal(t, int64(200), parts.Node)
+	assert.Zero(t, parts.Step)
+	assert.Equal(t, parts, Decompose(id))
+	assert.WithinRange(t, n.TimeOf(id), before, after)
+	assert.Equal(t, n.TimeOf(id), TimeOf(id))
+	assert.Equal(t, parts.Timestamp, parts.Time().UnixMilli())
+
+	composed := ID((int64(42) << 14) | (int64(3) << 6) | 7)
+	assert.Equal(t, Components{Timestamp: 1740515125042, Node: 3, Step: 7}, n.Decompose(composed))
+
+	// 修改全局配置后，节点仍按创建时的布局解析。
+	configureSnowflakeGlobals(t, 0, 10, 12)
+	assert.Equal(t, parts, n.Decompose(id))
+	assert.NotEqual(t, parts, Decompose(id))
+}
+
+// TestValidateEpochConfig 验证全局 Epoch 与位宽配置校验。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestValidateEpochConfig(t *testing.T) {
+	now := time.Now().UnixMilli()
+
+	tests := []struct {
+		name         string
+		description  string
+		giveEpoch    int64
+		giveNodeBits uint8
+		giveStepBits uint8
+		wantErr      error
+	}{
+		{
+			name:         "success/default",
+			description:  "验证默认配置通过校验。",
+			giveEpoch:    1740515125000,
+			giveNodeBits: 10,
+			giveStepBits: 12,
+		},
+		{
+			name:         "error/bit-layout",
+			description:  "验证位宽总和超过二十二位时返回 ErrInvalidBitLayout。",
+			giveEpoch:    1740515125000,
+			giveNodeBits: 12,
+			giveStepBits: 12,
+			wantErr:      ErrInvalidBitLayout,
+		},
+		{
+			name:         "error/future-epoch",
+			description:  "验证 Epoch 晚于当前时间时返回 ErrEpochInFuture。",
+			giveEpoch:    now + time.Hour.Milliseconds(),
+			giveNodeBits: 10,
+			giveStepBits: 12,
+			wantErr:      ErrEpochInFuture,
+		},
+		{
+			name:         "error/exhausted",
+			description:  "验证时间分量无法表示当前时间时返回 ErrEpochExhausted。",
+			giveEpoch:    -(int64(1) << 41) * 2,
+			giveNodeBits: 10,
+			giveStepBits: 12,
+			wantErr:      ErrEpochExhausted,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+			configureSnowflakeGlobals(t, tt.giveEpoch, tt.giveNodeBits, tt.giveStepBits)
+
+			err := ValidateEpochConfig()
+			if nil == tt.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
 // newTestNode 创建用于测试的 Snowflake 节点。
 //
 // 该辅助函数集中处理 NewNode 的前置断言，确保调用方只在节点成功初始化后继续测试。
@@ -0,0 +1,371 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package bytes
+
+import (
+	"fmt"
+	"iter"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+const (
+	// wordBits 是 BitSet 每个存储字的位数。
+	wordBits = 64
+)
+
+type (
+	// BitSet 是按需增长的位集合，适合表示权限掩码、特性开关等稀疏程度不高的整数集合。
+	//
+	// 零值是可直接使用的空集合。Set 超出当前容量时自动扩容，Test、Clear 超出容量时视为未设置。
+	// Bytes 与 MarshalBinary 使用与 Redis SETBIT/GETBIT 相同的位序（第 0 位为首字节的最高位），
+	// 可以直接与 Redis 位图互相转换。BitSet 不是并发安全的，并发读写需要调用方加锁。
+	BitSet struct {
+		// words 按小端存储位，第 i 位位于 words[i/64] 的第 i%64 位。
+		words []uint64
+	}
+)
+
+// NewBitSet 创建预留容量的空位集合。
+//
+// 参数：
+//   - capacity: 预期的最大位数，仅用于预分配，超出后仍会自动扩容。
+//
+// 返回：
+//   - *BitSet: 空位集合。
+func NewBitSet(capacity uint) *BitSet {
+	return &BitSet{words: make([]uint64, 0, wordsFor(capacity))}
+}
+
+// BitSetFromBytes 从 Redis 位序的字节创建位集合，是 Bytes 的逆操作。
+//
+// 参数：
+//   - data: 位图字节，第 i 位为 data[i/8] 的第 7-i%8 位。
+//
+// 返回：
+//   - *BitSet: 位集合，不引用 data。
+func BitSetFromBytes(data []byte) *BitSet {
+	b := &BitSet{words: make([]uint64, wordsFor(uint(len(data))*8))}
+	for i, v := range data {
+		b.words[i/8] |= uint64(bits.Reverse8(v)) << (8 * (i % 8))
+	}
+	b.trim()
+	return b
+}
+
+// Set 设置第 i 位，必要时扩容。
+//
+// 参数：
+//   - i: 位序号。
+//
+// 返回：
+//   - *BitSet: 接收者本身，便于链式调用。
+func (b *BitSet) Set(i uint) *BitSet {
+	if n := i/wordBits + 1; uint(len(b.words)) < n {
+		b.words = append(b.words, make([]uint64, n-uint(len(b.words)))...)
+	}
+	b.words[i/wordBits] |= 1 << (i % wordBits)
+	return b
+}
+
+// Clear 清除第 i 位。
+//
+// 参数：
+//   - i: 位序号，超出容量时不做任何操作。
+//
+// 返回：
+//   - *BitSet: 接收者本身，便于链式调用。
+func (b *BitSet) Clear(i uint) *BitSet {
+	if i/wordBits < uint(len(b.words)) {
+		b.words[i/wordBits] &^= 1 << (i % wordBits)
+	}
+	return b
+}
+
+// Test 判断第 i 位是否已设置。
+//
+// 参数：
+//   - i: 位序号。
+//
+// 返回：
+//   - bool: 已设置时返回 true；超出容量时返回 false。
+func (b *BitSet) Test(i uint) bool {
+	if i/wordBits >= uint(len(b.words)) {
+		return false
+	}
+	return 0 != b.words[i/wordBits]&(1<<(i%wordBits))
+}
+
+// Count 返回已设置的位数。
+//
+// 参数：无。
+//
+// 返回：
+//   - int: 已设置的位数。
+func (b *BitSet) Count() int {
+	count := 0
+	for _, w := range b.words {
+		count += bits.OnesCount64(w)
+	}
+	return count
+}
+
+// Len 返回最高已设置位的序号加 1。
+//
+// 参数：无。
+//
+// 返回：
+//   - uint: 最高已设置位的序号加 1；空集合返回 0。
+func (b *BitSet) Len() uint {
+	for i := len(b.words) - 1; i >= 0; i-- {
+		if 0 != b.words[i] {
+			return uint(i)*wordBits + uint(bits.Len64(b.words[i]))
+		}
+	}
+	return 0
+}
+
+// IsEmpty 判断是否没有任何已设置的位。
+//
+// 参数：无。
+//
+// 返回：
+//   - bool: 没有已设置的位时返回 true。
+func (b *BitSet) IsEmpty() bool {
+	return 0 == b.Len()
+}
+
+// Reset 清除所有位并保留已分配的容量。
+//
+// 参数：无。
+func (b *BitSet) Reset() {
+	clear(b.words)
+	b.words = b.words[:0]
+}
+
+// Clone 返回位集合的副本。
+//
+// 参数：无。
+//
+// 返回：
+//   - *BitSet: 与接收者互不影响的副本。
+func (b *BitSet) Clone() *BitSet {
+	c := &BitSet{words: append([]uint64(nil), b.words...)}
+	c.trim()
+	return c
+}
+
+// Equal 判断两个位集合是否包含相同的位，与容量无关。
+//
+// 参数：
+//   - other: 另一个位集合，nil 视为空集合。
+//
+// 返回：
+//   - bool: 包含相同的位时返回 true。
+func (b *BitSet) Equal(other *BitSet) bool {
+	return b.Xor(other).IsEmpty()
+}
+
+// Iterate 按从小到大的顺序迭代已设置的位序号。
+//
+// 迭代期间修改位集合的结果未定义。
+//
+// 参数：无。
+//
+// 返回：
+//   - iter.Seq[uint]: 已设置的位序号。
+func (b *BitSet) Iterate() iter.Seq[uint] {
+	return func(yield func(uint) bool) {
+		for i, w := range b.words {
+			for 0 != w {
+				bit := uint(bits.TrailingZeros64(w))
+				if !yield(uint(i)*wordBits + bit) {
+					return
+				}
+				w &= w - 1
+			}
+		}
+	}
+}
+
+// And 返回两个位集合的交集。
+//
+// 参数：
+//   - other: 另一个位集合，nil 视为空集合。
+//
+// 返回：
+//   - *BitSet: 新的位集合，不修改接收者与 other。
+func (b *BitSet) And(other *BitSet) *BitSet {
+	return b.combine(other, func(x, y uint64) uint64 { return x & y })
+}
+
+// Or 返回两个位集合的并集。
+//
+// 参数：
+//   - other: 另一个位集合，nil 视为空集合。
+//
+// 返回：
+//   - *BitSet: 新的位集合，不修改接收者与 other。
+func (b *BitSet) Or(other *BitSet) *BitSet {
+	return b.combine(other, func(x, y uint64) uint64 { return x | y })
+}
+
+// Xor 返回两个位集合的对称差。
+//
+// 参数：
+//   - other: 另一个位集合，nil 视为空集合。
+//
+// 返回：
+//   - *BitSet: 新的位集合，不修改接收者与 other。
+func (b *BitSet) Xor(other *BitSet) *BitSet {
+	return b.combine(other, func(x, y uint64) uint64 { return x ^ y })
+}
+
+// AndNot 返回在接收者中设置而在 other 中未设置的位，常用于撤销权限。
+//
+// 参数：
+//   - other: 另一个位集合，nil 视为空集合。
+//
+// 返回：
+//   - *BitSet: 新的位集合，不修改接收者与 other。
+func (b *BitSet) AndNot(other *BitSet) *BitSet {
+	return b.combine(other, func(x, y uint64) uint64 { return x &^ y })
+}
+
+// Bytes 返回 Redis 位序的紧凑字节表示，末尾的全零字节会被省略。
+//
+// 参数：无。
+//
+// 返回：
+//   - []byte: 第 i 位为结果第 i/8 字节的第 7-i%8 位；空集合返回空切片。
+func (b *BitSet) Bytes() []byte {
+	data := make([]byte, (b.Len()+7)/8)
+	for i := range data {
+		data[i] = bits.Reverse8(byte(b.words[i/8] >> (8 * (i % 8))))
+	}
+	return data
+}
+
+// MarshalBinary 实现 encoding.BinaryMarshaler，结果与 Bytes 相同。
+//
+// 参数：无。
+//
+// 返回：
+//   - []byte: 紧凑字节表示。
+//   - error: 始终为 nil。
+func (b *BitSet) MarshalBinary() ([]byte, error) {
+	return b.Bytes(), nil
+}
+
+// UnmarshalBinary 实现 encoding.BinaryUnmarshaler，替换接收者的全部内容。
+//
+// 参数：
+//   - data: MarshalBinary 或 Redis GET 返回的字节。
+//
+// 返回：
+//   - error: 始终为 nil。
+func (b *BitSet) UnmarshalBinary(data []byte) error {
+	*b = *BitSetFromBytes(data)
+	return nil
+}
+
+// MarshalText 实现 encoding.TextMarshaler，以无填充的 URL 安全 Base64 编码紧凑字节表示。
+//
+// 编码结果可以直接放入 JSON、Cookie 或 URL 参数，json.Marshal 也会使用该表示。
+//
+// 参数：无。
+//
+// 返回：
+//   - []byte: 文本表示；空集合为空字符串。
+//   - error: 始终为 nil。
+func (b *BitSet) MarshalText() ([]byte, error) {
+	return []byte(EncodeBase64URL(b.Bytes())), nil
+}
+
+// UnmarshalText 实现 encoding.TextUnmarshaler，替换接收者的全部内容。
+//
+// 参数：
+//   - text: MarshalText 生成的文本。
+//
+// 返回：
+//   - error: 文本不是合法的 URL 安全 Base64 时返回错误，此时接收者保持不变。
+func (b *BitSet) UnmarshalText(text []byte) error {
+	data, err := DecodeBase64URL(string(text))
+	if nil != err {
+		return fmt.Errorf("解码位集合失败：%w", err)
+	}
+	return b.UnmarshalBinary(data)
+}
+
+// String 返回已设置位序号的可读表示，便于调试。
+//
+// 参数：无。
+//
+// 返回：
+//   - string: 形如 "{1 3 5}" 的字符串。
+func (b *BitSet) String() string {
+	var sb strings.Builder
+	sb.WriteByte('{')
+	for i := range b.Iterate() {
+		if sb.Len() > 1 {
+			sb.WriteByte(' ')
+		}
+		sb.WriteString(strconv.FormatUint(uint64(i), 10))
+	}
+	sb.WriteByte('}')
+	return sb.String()
+}
+
+// combine 按字对两个位集合执行位运算。
+//
+// 参数：
+//   - other: 另一个位集合，nil 视为空集合。
+//   - op: 对同一位置的两个字执行的运算，缺失的字视为 0。
+//
+// 返回：
+//   - *BitSet: 运算结果。
+func (b *BitSet) combine(other *BitSet, op func(x, y uint64) uint64) *BitSet {
+	var words []uint64
+	if nil != other {
+		words = other.words
+	}
+	n := max(len(b.words), len(words))
+	result := &BitSet{words: make([]uint64, n)}
+	for i := range n {
+		var x, y uint64
+		if i < len(b.words) {
+			x = b.words[i]
+		}
+		if i < len(words) {
+			y = words[i]
+		}
+		result.words[i] = op(x, y)
+	}
+	result.trim()
+	return result
+}
+
+// trim 去除末尾的全零字。
+//
+// 参数：无。
+func (b *BitSet) trim() {
+	n := len(b.words)
+	for n > 0 && 0 == b.words[n-1] {
+		n--
+	}
+	b.words = b.words[:n]
+}
+
+// wordsFor 返回容纳 n 位所需的字数。
+//
+// 参数：
+//   - n: 位数。
+//
+// 返回：
+//   - int: 字数。
+func wordsFor(n uint) int {
+	return int((n + wordBits - 1) / wordBits)
+}
@@ -0,0 +1,147 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package bytes
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// bitSetOf 创建设置了指定位的位集合。
+//
+// 参数：
+//   - indexes: 需要设置的位序号。
+//
+// 返回：
+//   - *BitSet: 位集合。
+func bitSetOf(indexes ...uint) *BitSet {
+	b := &BitSet{}
+	for _, i := range indexes {
+		b.Set(i)
+	}
+	return b
+}
+
+// TestBitSet_Basic 验证设置、清除、判断与计数。
+func TestBitSet_Basic(t *testing.T) {
+	var b BitSet
+	assert.True(t, b.IsEmpty())
+	assert.False(t, b.Test(1000))
+
+	b.Set(0).Set(63).Set(64).Set(1000)
+	assert.True(t, b.Test(0))
+	assert.True(t, b.Test(63))
+	assert.True(t, b.Test(64))
+	assert.True(t, b.Test(1000))
+	assert.False(t, b.Test(1))
+	assert.Equal(t, 4, b.Count())
+	assert.Equal(t, uint(1001), b.Len())
+	assert.Equal(t, "{0 63 64 1000}", b.String())
+
+	b.Clear(1000).Clear(5000)
+	assert.False(t, b.Test(1000))
+	assert.Equal(t, uint(65), b.Len())
+	assert.Equal(t, []uint{0, 63, 64}, slices.Collect(b.Iterate()))
+
+	clone := b.Clone()
+	b.Reset()
+	assert.True(t, b.IsEmpty())
+	assert.Equal(t, 3, clone.Count())
+	assert.Equal(t, "{}", b.String())
+
+	// 提前结束迭代。
+	for i := range clone.Iterate() {
+		assert.Equal(t, uint(0), i)
+		break
+	}
+}
+
+// TestBitSet_Operations 验证交集、并集、对称差与差集。
+func TestBitSet_Operations(t *testing.T) {
+	a := bitSetOf(1, 2, 3, 100)
+	b := bitSetOf(2, 3, 4)
+
+	tests := []struct {
+		name        string
+		description string
+		got         *BitSet
+		want        []uint
+	}{
+		{name: "and", description: "验证交集。", got: a.And(b), want: []uint{2, 3}},
+		{name: "or", description: "验证并集。", got: a.Or(b), want: []uint{1, 2, 3, 4, 100}},
+		{name: "xor", description: "验证对称差。", got: a.Xor(b), want: []uint{1, 4, 100}},
+		{name: "and-not", description: "验证差集。", got: a.AndNot(b), want: []uint{1, 100}},
+		{name: "nil", description: "验证 nil 视为空集合。", got: a.And(nil), want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			assert.Equal(t, tt.want, slices.Collect(tt.got.Iterate()))
+		})
+	}
+
+	// 运算不修改操作数。
+	assert.Equal(t, "{1 2 3 100}", a.String())
+	assert.Equal(t, "{2 3 4}", b.String())
+
+	// 容量不同但内容相同的集合相等。
+	assert.True(t, bitSetOf(5).Equal(NewBitSet(1024).Set(5)))
+	assert.False(t, a.Equal(b))
+}
+
+// TestBitSet_Serialization 验证 Redis 位序的字节表示与文本表示。
+func TestBitSet_Serialization(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		bits        []uint
+		want        []byte
+	}{
+		{name: "empty", description: "验证空集合编码为空字节。", bits: nil, want: []byte{}},
+		{name: "msb-first", description: "验证第 0 位为首字节的最高位，与 Redis SETBIT 一致。", bits: []uint{0}, want: []byte{0x80}},
+		{name: "redis", description: "验证 SETBIT 1、2、7、8 后 GET 的结果。", bits: []uint{1, 2, 7, 8}, want: []byte{0x61, 0x80}},
+		{name: "word-boundary", description: "验证跨越存储字边界的位。", bits: []uint{63, 64}, want: []byte{0, 0, 0, 0, 0, 0, 0, 0x01, 0x80}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			b := bitSetOf(tt.bits...)
+			data, err := b.MarshalBinary()
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, data)
+
+			var decoded BitSet
+			require.NoError(t, decoded.UnmarshalBinary(append(data, 0, 0)))
+			assert.True(t, b.Equal(&decoded))
+			assert.Equal(t, tt.bits, slices.Collect(decoded.Iterate()))
+		})
+	}
+
+	t.Run("json", func(t *testing.T) {
+		t.Log("验证 JSON 使用 URL 安全 Base64 文本表示。")
+
+		type permissions struct {
+			Mask *BitSet `json:"mask"`
+		}
+		data, err := json.Marshal(permissions{Mask: bitSetOf(1, 2, 7, 8)})
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"mask":"YYA"}`, string(data))
+
+		var decoded permissions
+		require.NoError(t, json.Unmarshal(data, &decoded))
+		assert.Equal(t, "{1 2 7 8}", decoded.Mask.String())
+
+		var b BitSet
+		assert.Error(t, b.UnmarshalText([]byte("!!")))
+	})
+}
@@ -0,0 +1,248 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package bytes
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/bits"
+)
+
+const (
+	// chunkWindowSize 是寻找分块边界时使用的 Buzhash 窗口大小。
+	chunkWindowSize = 64
+)
+
+var (
+	// chunkMinSizeDefault 是默认的最小分块大小。
+	chunkMinSizeDefault = 256 << 10
+	// chunkAvgSizeDefault 是默认的平均分块大小。
+	chunkAvgSizeDefault = 1 << 20
+	// chunkMaxSizeDefault 是默认的最大分块大小。
+	chunkMaxSizeDefault = 4 << 20
+)
+
+var (
+	// ErrInvalidChunkSize 表示分块大小配置不满足 0 < min <= avg <= max。
+	ErrInvalidChunkSize = errors.New("分块大小无效")
+)
+
+type (
+	// ChunkerOption 定义修改分块配置的函数。
+	//
+	// 参数：
+	//   - c: 待修改的分块配置。
+	ChunkerOption func(c *chunkConfig)
+
+	// chunkConfig 保存内容定义分块的配置。
+	chunkConfig struct {
+		// minSize 是最小分块大小，最后一个分块除外。
+		minSize int
+		// avgSize 是期望的平均分块大小。
+		avgSize int
+		// maxSize 是最大分块大小。
+		maxSize int
+		// mask 是判断分块边界的哈希掩码。
+		mask uint32
+	}
+
+	// Chunk 表示内容定义分块产生的一个分块。
+	Chunk struct {
+		// Offset 是分块在输入流中的起始偏移。
+		Offset int64
+		// Data 是分块内容，为独立副本，调用方可以自由持有和修改。
+		Data []byte
+	}
+
+	// Chunker 从 io.Reader 中按内容定义分块（CDC）切出大小可变的分块。
+	//
+	// 分块边界由滑动窗口内容的 Buzhash 值决定，而不是固定偏移，因此在数据中插入或删除字节
+	// 只会影响附近的分块，其余分块保持不变，适合去重上传和增量同步。Chunker 不是并发安全的。
+	Chunker struct {
+		// r 是被分块的数据源。
+		r io.Reader
+		// cfg 是分块配置。
+		cfg chunkConfig
+		// hash 是寻找边界使用的滚动哈希。
+		hash *Buzhash
+		// buf 缓存已读取但尚未切出的数据，容量为 maxSize。
+		buf []byte
+		// start 和 end 标记 buf 中尚未切出的数据范围。
+		start, end int
+		// offset 是下一个分块在输入流中的偏移。
+		offset int64
+		// eof 表示数据源已读取完毕。
+		eof bool
+	}
+)
+
+// WithChunkSize 设置分块的最小、平均和最大大小。
+//
+// 除最后一个分块外，所有分块大小都在 [min, max] 范围内；avg 决定边界出现的概率，
+// 实际平均大小约为 min 加上 avg-min 向下取整到 2 的幂。默认值为 256KiB、1MiB 和 4MiB。
+//
+// 参数：
+//   - min: 最小分块大小，单位为字节。
+//   - avg: 期望的平均分块大小，单位为字节。
+//   - max: 最大分块大小，单位为字节。
+//
+// 返回：
+//   - ChunkerOption: 应用于 [NewChunker] 和 [Split] 的分块大小配置项。
+func WithChunkSize(min, avg, max int) ChunkerOption {
+	return func(c *chunkConfig) {
+		c.minSize = min
+		c.avgSize = avg
+		c.maxSize = max
+	}
+}
+
+// NewChunker 创建从 r 读取数据的内容定义分块器。
+//
+// 参数：
+//   - r: 被分块的数据源。
+//   - opts: 分块大小等可选配置。
+//
+// 返回：
+//   - *Chunker: 分块器，通过 Next 依次获取分块。
+//   - error: 分块大小配置无效时返回包装了 ErrInvalidChunkSize 的错误。
+func NewChunker(r io.Reader, opts ...ChunkerOption) (*Chunker, error) {
+	cfg, err := newChunkConfig(opts)
+	if nil != err {
+		return nil, err
+	}
+	return &Chunker{
+		r:    r,
+		cfg:  cfg,
+		hash: NewBuzhash(chunkWindowSize),
+		buf:  make([]byte, cfg.maxSize),
+	}, nil
+}
+
+// Next 返回下一个分块。
+//
+// 参数：无。
+//
+// 返回：
+//   - Chunk: 下一个分块。
+//   - error: 数据读取完毕时返回 io.EOF；读取数据源失败时返回该错误，已缓存但未切出的数据不再返回。
+func (c *Chunker) Next() (Chunk, error) {
+	if err := c.fill(); nil != err {
+		return Chunk{}, err
+	}
+	if c.start == c.end {
+		return Chunk{}, io.EOF
+	}
+
+	n := c.cfg.cut(c.hash, c.buf[c.start:c.end])
+	chunk := Chunk{
+		Offset: c.offset,
+		Data:   append([]byte(nil), c.buf[c.start:c.start+n]...),
+	}
+	c.start += n
+	c.offset += int64(n)
+	return chunk, nil
+}
+
+// fill 在数据源未读完时把缓存补满到 maxSize。
+//
+// 参数：无。
+//
+// 返回：
+//   - error: 读取数据源失败时返回该错误；读到末尾不视为错误。
+func (c *Chunker) fill() error {
+	if c.eof || c.end-c.start >= c.cfg.maxSize {
+		return nil
+	}
+	if c.start > 0 {
+		c.end = copy(c.buf, c.buf[c.start:c.end])
+		c.start = 0
+	}
+	n, err := io.ReadFull(c.r, c.buf[c.end:])
+	c.end += n
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		c.eof = true
+		return nil
+	}
+	return err
+}
+
+// Split 按内容定义分块切分 data。
+//
+// 返回的分块是 data 的子切片，不会复制数据；相同的数据和配置总是得到相同的分块边界。
+//
+// 参数：
+//   - data: 待切分的数据。
+//   - opts: 分块大小等可选配置。
+//
+// 返回：
+//   - [][]byte: 按顺序排列的分块；data 为空时返回 nil。
+//   - error: 分块大小配置无效时返回包装了 ErrInvalidChunkSize 的错误。
+func Split(data []byte, opts ...ChunkerOption) ([][]byte, error) {
+	cfg, err := newChunkConfig(opts)
+	if nil != err {
+		return nil, err
+	}
+	hash := NewBuzhash(chunkWindowSize)
+	var chunks [][]byte
+	for len(data) > 0 {
+		n := cfg.cut(hash, data)
+		chunks = append(chunks, data[:n:n])
+		data = data[n:]
+	}
+	return chunks, nil
+}
+
+// newChunkConfig 应用分块配置项并校验结果。
+//
+// 参数：
+//   - opts: 分块配置项。
+//
+// 返回：
+//   - chunkConfig: 校验后的分块配置。
+//   - error: 不满足 0 < min <= avg <= max 时返回包装了 ErrInvalidChunkSize 的错误。
+func newChunkConfig(opts []ChunkerOption) (chunkConfig, error) {
+	cfg := chunkConfig{
+		minSize: chunkMinSizeDefault,
+		avgSize: chunkAvgSizeDefault,
+		maxSize: chunkMaxSizeDefault,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.minSize <= 0 || cfg.minSize > cfg.avgSize || cfg.avgSize > cfg.maxSize {
+		return chunkConfig{}, fmt.Errorf("%w：min=%d，avg=%d，max=%d", ErrInvalidChunkSize, cfg.minSize, cfg.avgSize, cfg.maxSize)
+	}
+	// 最小分块之后，每个位置以 1/2^n 的概率成为边界，期望再前进 2^n 个字节。
+	if spread := cfg.avgSize - cfg.minSize; spread > 0 {
+		cfg.mask = 1<<(bits.Len(uint(spread))-1) - 1
+	}
+	return cfg, nil
+}
+
+// cut 返回 data 中第一个分块的长度。
+//
+// 只在最小分块长度之后寻找边界，并从边界前一个窗口开始计算滚动哈希，保证每个候选边界的
+// 哈希只取决于其前 chunkWindowSize 个字节。
+//
+// 参数：
+//   - hash: 寻找边界使用的滚动哈希，会被重置。
+//   - data: 待切分的数据，不能为空。
+//
+// 返回：
+//   - int: 第一个分块的长度，范围为 [1, maxSize]。
+func (c chunkConfig) cut(hash *Buzhash, data []byte) int {
+	limit := min(len(data), c.maxSize)
+	if limit <= c.minSize {
+		return limit
+	}
+	hash.Reset()
+	for i := max(c.minSize-hash.Window(), 0); i < limit; i++ {
+		if sum := hash.Roll(data[i]); i+1 >= c.minSize && 0 == sum&c.mask {
+			return i + 1
+		}
+	}
+	return limit
+}
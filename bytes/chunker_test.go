@@ -0,0 +1,207 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package bytes
+
+import (
+	stdbytes "bytes"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newChunkTestData 生成确定性的伪随机测试数据。
+//
+// 参数：
+//   - size: 数据长度。
+//   - seed: 随机种子。
+//
+// 返回：
+//   - []byte: 伪随机数据。
+func newChunkTestData(size int, seed uint64) []byte {
+	rng := rand.New(rand.NewPCG(seed, seed))
+	data := make([]byte, size)
+	for i := range data {
+		data[i] = byte(rng.Uint32())
+	}
+	return data
+}
+
+// TestSplit_Bounds 验证分块大小落在配置范围内且拼接后与原数据一致。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestSplit_Bounds(t *testing.T) {
+	data := newChunkTestData(1<<20, 7)
+
+	tests := []struct {
+		name        string
+		description string
+		giveData    []byte
+		giveMin     int
+		giveAvg     int
+		giveMax     int
+		wantFixed   bool
+	}{
+		{name: "success/variable-size", description: "验证平均大小大于最小大小时产生大小可变的分块。", giveData: data, giveMin: 1 << 10, giveAvg: 4 << 10, giveMax: 16 << 10},
+		{name: "boundary/fixed-size", description: "验证最小、平均、最大大小相同时退化为固定大小分块。", giveData: data, giveMin: 4 << 10, giveAvg: 4 << 10, giveMax: 4 << 10, wantFixed: true},
+		{name: "boundary/short-data", description: "验证数据短于最小大小时只产生一个分块。", giveData: data[:100], giveMin: 1 << 10, giveAvg: 4 << 10, giveMax: 16 << 10},
+		{name: "boundary/empty", description: "验证空数据不产生分块。", giveData: nil, giveMin: 1 << 10, giveAvg: 4 << 10, giveMax: 16 << 10},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			chunks, err := Split(tt.giveData, WithChunkSize(tt.giveMin, tt.giveAvg, tt.giveMax))
+			require.NoError(t, err)
+
+			if 0 == len(tt.giveData) {
+				assert.Empty(t, chunks)
+				return
+			}
+			sizes := make(map[int]int)
+			for i, chunk := range chunks {
+				sizes[len(chunk)]++
+				assert.LessOrEqual(t, len(chunk), tt.giveMax)
+				if i < len(chunks)-1 {
+					assert.GreaterOrEqual(t, len(chunk), tt.giveMin)
+				}
+			}
+			assert.Equal(t, tt.giveData, stdbytes.Join(chunks, nil))
+			if tt.wantFixed {
+				assert.Len(t, sizes, 1)
+			} else if len(chunks) > 1 {
+				assert.Greater(t, len(sizes), 1)
+				avg := len(tt.giveData) / len(chunks)
+				assert.InDelta(t, tt.giveAvg, avg, float64(tt.giveAvg)/2)
+			}
+		})
+	}
+}
+
+// TestSplit_EditLocality 验证在数据中插入字节只影响附近的分块，其余分块保持不变。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestSplit_EditLocality(t *testing.T) {
+	data := newChunkTestData(1<<20, 11)
+	edited := append(append(append([]byte(nil), data[:len(data)/2]...), "inserted"...), data[len(data)/2:]...)
+	opt := WithChunkSize(1<<10, 4<<10, 16<<10)
+
+	original, err := Split(data, opt)
+	require.NoError(t, err)
+	changed, err := Split(edited, opt)
+	require.NoError(t, err)
+
+	seen := make(map[string]struct{}, len(original))
+	for _, chunk := range original {
+		seen[string(chunk)] = struct{}{}
+	}
+	shared := 0
+	for _, chunk := range changed {
+		if _, ok := seen[string(chunk)]; ok {
+			shared++
+		}
+	}
+	assert.GreaterOrEqual(t, shared, len(changed)-3)
+}
+
+// TestChunker_Next 验证流式分块器与 Split 的分块结果一致，且不受读取粒度影响。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestChunker_Next(t *testing.T) {
+	data := newChunkTestData(256<<10, 13)
+	opt := WithChunkSize(512, 2<<10, 8<<10)
+	want, err := Split(data, opt)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		description string
+		giveReader  func() io.Reader
+	}{
+		{name: "success/full-reader", description: "验证一次性读取的数据源。", giveReader: func() io.Reader { return stdbytes.NewReader(data) }},
+		{name: "success/one-byte-reader", description: "验证每次只返回一个字节的数据源。", giveReader: func() io.Reader { return iotest.OneByteReader(stdbytes.NewReader(data)) }},
+		{name: "success/data-err-reader", description: "验证最后一次读取同时返回数据和 io.EOF 的数据源。", giveReader: func() io.Reader { return iotest.DataErrReader(stdbytes.NewReader(data)) }},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			chunker, err := NewChunker(tt.giveReader(), opt)
+			require.NoError(t, err)
+
+			var offset int64
+			var got [][]byte
+			for {
+				chunk, err := chunker.Next()
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				require.NoError(t, err)
+				assert.Equal(t, offset, chunk.Offset)
+				offset += int64(len(chunk.Data))
+				got = append(got, chunk.Data)
+			}
+			assert.Equal(t, want, got)
+
+			_, err = chunker.Next()
+			assert.ErrorIs(t, err, io.EOF)
+		})
+	}
+}
+
+// TestChunker_Errors 验证分块大小校验和数据源错误传播。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestChunker_Errors(t *testing.T) {
+	readErr := errors.New("read failed")
+
+	tests := []struct {
+		name        string
+		description string
+		giveReader  io.Reader
+		giveOpts    []ChunkerOption
+		wantNewErr  error
+		wantNextErr error
+	}{
+		{name: "error/zero-min", description: "验证最小大小为 0 时返回配置错误。", giveOpts: []ChunkerOption{WithChunkSize(0, 4, 8)}, wantNewErr: ErrInvalidChunkSize},
+		{name: "error/min-above-avg", description: "验证最小大小大于平均大小时返回配置错误。", giveOpts: []ChunkerOption{WithChunkSize(8, 4, 16)}, wantNewErr: ErrInvalidChunkSize},
+		{name: "error/avg-above-max", description: "验证平均大小大于最大大小时返回配置错误。", giveOpts: []ChunkerOption{WithChunkSize(2, 16, 8)}, wantNewErr: ErrInvalidChunkSize},
+		{name: "error/reader-error", description: "验证数据源读取失败时返回该错误。", giveReader: iotest.ErrReader(readErr), wantNextErr: readErr},
+		{name: "boundary/empty-reader", description: "验证空数据源直接返回 io.EOF。", giveReader: stdbytes.NewReader(nil), wantNextErr: io.EOF},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			chunker, err := NewChunker(tt.giveReader, tt.giveOpts...)
+			if nil != tt.wantNewErr {
+				assert.ErrorIs(t, err, tt.wantNewErr)
+				assert.Nil(t, chunker)
+
+				_, err = Split([]byte("data"), tt.giveOpts...)
+				assert.ErrorIs(t, err, tt.wantNewErr)
+				return
+			}
+			require.NoError(t, err)
+
+			_, err = chunker.Next()
+			assert.ErrorIs(t, err, tt.wantNextErr)
+		})
+	}
+}
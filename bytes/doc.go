@@ -2,9 +2,22 @@
 //
 // Licensed under the MIT License. See LICENSE file in the project root for full license information.
 
-// Package bytes 提供基于 crypto/rand 的随机字节生成工具。
+// Package bytes 提供基于 crypto/rand 的随机字节生成工具和内容定义分块工具。
 //
 // 本包面向需要密码学安全随机原始字节的场景，例如 nonce、IV、salt 或 token
 // 原始材料生成。GenerateNonce 会按请求长度读取随机源；长度合法性、随机源错误、
-// 协议要求的唯一性、重放防护和结果编码由调用方在使用处处理。
+// 协议要求的唯一性、重放防护和结果编码由调用方在使用处处理。GenerateRandomString 从指定字符集中
+// 均匀选取字符生成随机字符串，内置字母数字、十六进制和去除易混淆字符的字符集；GenerateToken 返回
+// URL 安全 Base64 编码的随机令牌。
+//
+// Buzhash 是按滑动窗口计算的滚动哈希；NewChunker 与 Split 基于它实现内容定义分块（CDC），
+// 在最小、平均和最大分块大小约束下切出大小可变的分块。分块边界只取决于附近的内容，
+// 数据局部修改后大部分分块保持不变，便于按分块摘要去重上传。
+//
+// EncodeBase58、EncodeBase32NoPad、EncodeBase64URL 及对应的解码函数提供常用的文本编码；
+// Alphabet 支持自定义字符表的任意进制编码，snowflake 与 otp 包复用这些实现。
+//
+// BitSet 是按需增长的位集合，提供 Set、Clear、Test、Count、Iterate 以及 And、Or、Xor、AndNot 运算，
+// 适合表示权限掩码；Bytes 与 MarshalBinary 使用与 Redis SETBIT 相同的位序（最高位在前），可直接与 Redis
+// 位图互相转换，MarshalText 以 URL 安全 Base64 输出，便于放入 JSON。
 package bytes
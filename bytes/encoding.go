@@ -0,0 +1,327 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package bytes
+
+import (
+	"encoding/base32"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+	"strings"
+)
+
+const (
+	// AlphabetBase58Bitcoin 是比特币使用的 Base58 字符表，EncodeBase58 与 DecodeBase58 使用该字符表。
+	AlphabetBase58Bitcoin = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+	// AlphabetBase58Flickr 是 Flickr 短链接使用的 Base58 字符表，小写字母排在大写字母之前。
+	AlphabetBase58Flickr = "123456789abcdefghijkmnopqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ"
+	// AlphabetZBase32 是 z-base-32 字符表，按人工识读与输入的便利性排列。
+	AlphabetZBase32 = "ybndrfg8ejkmcpqxot1uwisza345h769"
+)
+
+var (
+	// ErrInvalidAlphabet 表示字符表长度不在 2 到 255 之间或包含重复字符。
+	//
+	// 调用方可以使用 errors.Is 判断该错误。
+	ErrInvalidAlphabet = errors.New("字符表不合法。")
+	// ErrInvalidCharacter 表示待解码内容包含字符表之外的字符。
+	//
+	// 调用方可以使用 errors.Is 判断该错误。
+	ErrInvalidCharacter = errors.New("包含字符表之外的字符。")
+	// ErrValueOverflow 表示解码结果超出 uint64 范围。
+	//
+	// 调用方可以使用 errors.Is 判断该错误。
+	ErrValueOverflow = errors.New("解码结果超出 uint64 范围。")
+)
+
+var (
+	// base58Bitcoin 是 EncodeBase58 与 DecodeBase58 使用的字符表。
+	base58Bitcoin = MustNewAlphabet(AlphabetBase58Bitcoin)
+	// base32NoPad 是无填充的 RFC 4648 标准 Base32 编码。
+	base32NoPad = base32.StdEncoding.WithPadding(base32.NoPadding)
+)
+
+type (
+	// Alphabet 是按位值排列的编码字符表，提供任意进制的整数编码与大数字节编码。
+	//
+	// Alphabet 创建后只读，可以并发使用。
+	Alphabet struct {
+		// chars 是按位值排列的字符。
+		chars string
+		// decode 将字符映射为位值，0xFF 表示未定义字符。
+		decode [256]byte
+	}
+)
+
+// NewAlphabet 创建编码字符表，进制为字符表长度。
+//
+// 参数：
+//   - chars: 按位值排列的单字节字符，长度必须在 2 到 255 之间且不能重复。
+//
+// 返回：
+//   - *Alphabet: 创建的字符表。
+//   - error: 长度不合法或包含重复字符时返回包装了 ErrInvalidAlphabet 的错误。
+func NewAlphabet(chars string) (*Alphabet, error) {
+	if len(chars) < 2 || len(chars) > 255 {
+		return nil, fmt.Errorf("%w: 长度 %d 不在 2 到 255 之间", ErrInvalidAlphabet, len(chars))
+	}
+	a := &Alphabet{chars: chars}
+	for i := range a.decode {
+		a.decode[i] = 0xFF
+	}
+	for i := 0; i < len(chars); i++ {
+		if 0xFF != a.decode[chars[i]] {
+			return nil, fmt.Errorf("%w: 字符 %q 重复", ErrInvalidAlphabet, chars[i])
+		}
+		a.decode[chars[i]] = byte(i)
+	}
+	return a, nil
+}
+
+// MustNewAlphabet 创建编码字符表，字符表不合法时 panic，适合初始化包级变量。
+//
+// 参数：
+//   - chars: 按位值排列的单字节字符。
+//
+// 返回：
+//   - *Alphabet: 创建的字符表。
+func MustNewAlphabet(chars string) *Alphabet {
+	a, err := NewAlphabet(chars)
+	if nil != err {
+		panic(err)
+	}
+	return a
+}
+
+// Base 返回字符表的进制。
+//
+// 参数：无。
+//
+// 返回：
+//   - int: 字符表长度。
+func (a *Alphabet) Base() int {
+	return len(a.chars)
+}
+
+// String 返回字符表的字符。
+//
+// 参数：无。
+//
+// 返回：
+//   - string: 按位值排列的字符。
+func (a *Alphabet) String() string {
+	return a.chars
+}
+
+// EncodeUint64 将无符号整数编码为该进制的字符串，高位在前。
+//
+// 参数：
+//   - v: 待编码的整数。
+//
+// 返回：
+//   - string: 编码结果；v 为 0 时为字符表首字符。
+func (a *Alphabet) EncodeUint64(v uint64) string {
+	base := uint64(len(a.chars))
+	if v < base {
+		return string(a.chars[v])
+	}
+
+	var buf [64]byte
+	i := len(buf)
+	for v > 0 {
+		i--
+		buf[i] = a.chars[v%base]
+		v /= base
+	}
+	return string(buf[i:])
+}
+
+// DecodeUint64 将 EncodeUint64 的结果解码为无符号整数。
+//
+// 参数：
+//   - s: 待解码的字节切片；为空时结果为 0。
+//
+// 返回：
+//   - uint64: 解码结果。
+//   - error: 包含未定义字符时返回包装了 ErrInvalidCharacter 的错误，超出 uint64 范围时返回包装了 ErrValueOverflow 的错误。
+func (a *Alphabet) DecodeUint64(s []byte) (uint64, error) {
+	base := uint64(len(a.chars))
+	var v uint64
+	for i, c := range s {
+		d := a.decode[c]
+		if 0xFF == d {
+			return 0, fmt.Errorf("%w: 第 %d 个字节 %q", ErrInvalidCharacter, i, c)
+		}
+		if v > (math.MaxUint64-uint64(d))/base {
+			return 0, fmt.Errorf("%w: %q", ErrValueOverflow, s)
+		}
+		v = v*base + uint64(d)
+	}
+	return v, nil
+}
+
+// Encode 将字节切片视为大端大整数编码为该进制的字符串。
+//
+// 与 Base58 的通行做法一致，每个前导 0 字节编码为一个字符表首字符，因此编码可以无损还原前导 0。
+//
+// 参数：
+//   - src: 待编码的字节切片。
+//
+// 返回：
+//   - string: 编码结果；src 为空时返回空字符串。
+func (a *Alphabet) Encode(src []byte) string {
+	zeros := 0
+	for zeros < len(src) && 0 == src[zeros] {
+		zeros++
+	}
+
+	base := len(a.chars)
+	// 每个输出字符至少承载 floor(log2(base)) 位，据此估算输出长度的上限。
+	size := (len(src)-zeros)*8/(bits.Len(uint(base))-1) + 1
+	digits := make([]byte, size)
+	high := size - 1
+	for _, b := range src[zeros:] {
+		carry := int(b)
+		j := size - 1
+		for ; j > high || 0 != carry; j-- {
+			carry += 256 * int(digits[j])
+			digits[j] = byte(carry % base)
+			carry /= base
+		}
+		high = j
+	}
+
+	start := 0
+	for start < size && 0 == digits[start] {
+		start++
+	}
+	var sb strings.Builder
+	sb.Grow(zeros + size - start)
+	for i := 0; i < zeros; i++ {
+		sb.WriteByte(a.chars[0])
+	}
+	for _, d := range digits[start:] {
+		sb.WriteByte(a.chars[d])
+	}
+	return sb.String()
+}
+
+// Decode 将 Encode 的结果解码为字节切片。
+//
+// 参数：
+//   - s: 待解码的字符串。
+//
+// 返回：
+//   - []byte: 解码结果；s 为空时返回非 nil 的空切片。
+//   - error: 包含未定义字符时返回包装了 ErrInvalidCharacter 的错误。
+func (a *Alphabet) Decode(s string) ([]byte, error) {
+	zeros := 0
+	for zeros < len(s) && a.chars[0] == s[zeros] {
+		zeros++
+	}
+
+	base := len(a.chars)
+	// 每个输入字符最多承载 ceil(log2(base)) 位，据此估算输出长度的上限。
+	size := (len(s)-zeros)*bits.Len(uint(base-1))/8 + 1
+	out := make([]byte, size)
+	high := size - 1
+	for i := zeros; i < len(s); i++ {
+		d := a.decode[s[i]]
+		if 0xFF == d {
+			return nil, fmt.Errorf("%w: 第 %d 个字节 %q", ErrInvalidCharacter, i, s[i])
+		}
+		carry := int(d)
+		j := size - 1
+		for ; j > high || 0 != carry; j-- {
+			carry += base * int(out[j])
+			out[j] = byte(carry % 256)
+			carry /= 256
+		}
+		high = j
+	}
+
+	start := 0
+	for start < size && 0 == out[start] {
+		start++
+	}
+	result := make([]byte, zeros+size-start)
+	copy(result[zeros:], out[start:])
+	return result, nil
+}
+
+// EncodeBase58 使用比特币 Base58 字符表编码字节切片。
+//
+// 参数：
+//   - src: 待编码的字节切片。
+//
+// 返回：
+//   - string: 编码结果，前导 0 字节编码为字符 "1"。
+func EncodeBase58(src []byte) string {
+	return base58Bitcoin.Encode(src)
+}
+
+// DecodeBase58 解码比特币 Base58 字符表编码的字符串。
+//
+// 参数：
+//   - s: 待解码的字符串。
+//
+// 返回：
+//   - []byte: 解码结果。
+//   - error: 包含字符表之外的字符时返回包装了 ErrInvalidCharacter 的错误。
+func DecodeBase58(s string) ([]byte, error) {
+	return base58Bitcoin.Decode(s)
+}
+
+// EncodeBase32NoPad 使用 RFC 4648 标准字符表编码字节切片，不输出填充字符。
+//
+// 常用于 TOTP 密钥等需要人工输入的场景。
+//
+// 参数：
+//   - src: 待编码的字节切片。
+//
+// 返回：
+//   - string: 大写的 Base32 编码结果。
+func EncodeBase32NoPad(src []byte) string {
+	return base32NoPad.EncodeToString(src)
+}
+
+// DecodeBase32NoPad 解码 RFC 4648 标准字符表、无填充的 Base32 字符串。
+//
+// 参数：
+//   - s: 待解码的字符串，必须为大写且不含填充字符与空白。
+//
+// 返回：
+//   - []byte: 解码结果。
+//   - error: 编码不合法时返回 base32.CorruptInputError。
+func DecodeBase32NoPad(s string) ([]byte, error) {
+	return base32NoPad.DecodeString(s)
+}
+
+// EncodeBase64URL 使用 URL 安全字符表编码字节切片，不输出填充字符。
+//
+// 结果可以直接放入 URL 路径、查询参数或 JWT 等场景。
+//
+// 参数：
+//   - src: 待编码的字节切片。
+//
+// 返回：
+//   - string: 使用 "-" 与 "_" 替代 "+" 与 "/" 的 Base64 编码结果。
+func EncodeBase64URL(src []byte) string {
+	return base64.RawURLEncoding.EncodeToString(src)
+}
+
+// DecodeBase64URL 解码 URL 安全字符表的 Base64 字符串。
+//
+// 参数：
+//   - s: 待解码的字符串，末尾的 "=" 填充字符可有可无。
+//
+// 返回：
+//   - []byte: 解码结果。
+//   - error: 编码不合法时返回 base64.CorruptInputError。
+func DecodeBase64URL(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+}
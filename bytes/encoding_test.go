@@ -0,0 +1,164 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package bytes
+
+import (
+	"crypto/rand"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNewAlphabet 验证字符表的校验。
+func TestNewAlphabet(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		give        string
+		wantErr     bool
+	}{
+		{name: "success/base58", description: "验证比特币 Base58 字符表。", give: AlphabetBase58Bitcoin},
+		{name: "success/binary", description: "验证最小的二进制字符表。", give: "01"},
+		{name: "error/short", description: "验证长度不足。", give: "0", wantErr: true},
+		{name: "error/duplicate", description: "验证重复字符。", give: "0120", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			a, err := NewAlphabet(tt.give)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidAlphabet)
+				assert.Panics(t, func() { MustNewAlphabet(tt.give) })
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, len(tt.give), a.Base())
+			assert.Equal(t, tt.give, a.String())
+		})
+	}
+}
+
+// TestAlphabet_Uint64 验证整数编码与解码。
+func TestAlphabet_Uint64(t *testing.T) {
+	flickr := MustNewAlphabet(AlphabetBase58Flickr)
+	zbase32 := MustNewAlphabet(AlphabetZBase32)
+
+	tests := []struct {
+		name        string
+		description string
+		alphabet    *Alphabet
+		give        uint64
+		want        string
+	}{
+		{name: "success/zero", description: "验证零值编码为首字符。", alphabet: flickr, give: 0, want: "1"},
+		{name: "success/single", description: "验证小于进制的最大值为单字符。", alphabet: flickr, give: 57, want: "Z"},
+		{name: "success/carry", description: "验证在进制处进位。", alphabet: flickr, give: 58, want: "21"},
+		{name: "success/zbase32", description: "验证 z-base-32 进位。", alphabet: zbase32, give: 32, want: "by"},
+		{name: "success/max", description: "验证 uint64 最大值往返。", alphabet: zbase32, give: math.MaxUint64, want: zbase32.EncodeUint64(math.MaxUint64)},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			assert.Equal(t, tt.want, tt.alphabet.EncodeUint64(tt.give))
+			got, err := tt.alphabet.DecodeUint64([]byte(tt.want))
+			require.NoError(t, err)
+			assert.Equal(t, tt.give, got)
+		})
+	}
+
+	t.Run("error", func(t *testing.T) {
+		t.Log("验证非法字符与溢出。")
+
+		_, err := flickr.DecodeUint64([]byte("10"))
+		assert.ErrorIs(t, err, ErrInvalidCharacter)
+		_, err = flickr.DecodeUint64([]byte(flickr.EncodeUint64(math.MaxUint64) + "1"))
+		assert.ErrorIs(t, err, ErrValueOverflow)
+	})
+}
+
+// TestBase58 验证比特币 Base58 编码。
+func TestBase58(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		give        []byte
+		want        string
+	}{
+		{name: "success/empty", description: "验证空输入。", give: []byte{}, want: ""},
+		{name: "success/hello", description: "验证通用测试向量。", give: []byte("Hello World!"), want: "2NEpo7TZRRrLZSi2U"},
+		{name: "success/leading-zeros", description: "验证前导 0 字节编码为字符 1。", give: []byte{0, 0, 0x28, 0x7f, 0xb4, 0xcd}, want: "11233QC4"},
+		{name: "success/zeros", description: "验证全 0 输入。", give: []byte{0, 0}, want: "11"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			assert.Equal(t, tt.want, EncodeBase58(tt.give))
+			got, err := DecodeBase58(tt.want)
+			require.NoError(t, err)
+			assert.Equal(t, tt.give, got)
+		})
+	}
+
+	t.Run("round-trip", func(t *testing.T) {
+		t.Log("验证随机输入往返。")
+
+		for n := 0; n < 64; n++ {
+			src := make([]byte, n)
+			_, err := rand.Read(src)
+			require.NoError(t, err)
+			if n > 2 {
+				src[0] = 0
+			}
+			got, err := DecodeBase58(EncodeBase58(src))
+			require.NoError(t, err)
+			assert.Equal(t, src, got)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		t.Log("验证字符表之外的字符。")
+
+		_, err := DecodeBase58("0OIl")
+		assert.ErrorIs(t, err, ErrInvalidCharacter)
+	})
+}
+
+// TestBase32NoPad 验证无填充 Base32 编码。
+func TestBase32NoPad(t *testing.T) {
+	assert.Equal(t, "MZXW6YQ", EncodeBase32NoPad([]byte("foob")))
+	got, err := DecodeBase32NoPad("MZXW6YQ")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("foob"), got)
+
+	_, err = DecodeBase32NoPad("MZXW6YQ=")
+	assert.Error(t, err)
+}
+
+// TestBase64URL 验证 URL 安全 Base64 编码。
+func TestBase64URL(t *testing.T) {
+	src := []byte{0xfb, 0xff, 0xfe}
+	assert.Equal(t, "-__-", EncodeBase64URL(src))
+	assert.Equal(t, "_w", EncodeBase64URL([]byte{0xff}))
+
+	for _, s := range []string{"_w", "_w=="} {
+		got, err := DecodeBase64URL(s)
+		require.NoError(t, err)
+		assert.Equal(t, []byte{0xff}, got)
+	}
+
+	_, err := DecodeBase64URL("+/")
+	assert.Error(t, err)
+}
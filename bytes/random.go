@@ -6,10 +6,27 @@ package bytes
 
 import (
 	"crypto/rand"
+	"errors"
 	"fmt"
 	"io"
 )
 
+const (
+	// CharsetAlphanumeric 是数字与大小写字母组成的字符集。
+	CharsetAlphanumeric = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	// CharsetHex 是小写十六进制字符集。
+	CharsetHex = "0123456789abcdef"
+	// CharsetHumanSafe 是去掉易混淆字符 0、O、1、l、I 后的数字与字母字符集，适合需要人工识读或输入的邀请码、兑换码。
+	CharsetHumanSafe = "23456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+)
+
+var (
+	// ErrInvalidCharset 表示字符集长度不在 2 到 128 之间或包含非 ASCII 字符。
+	//
+	// 调用方可以使用 errors.Is 判断该错误。
+	ErrInvalidCharset = errors.New("字符集不合法。")
+)
+
 // GenerateNonce 返回指定长度的密码学安全随机字节切片。
 //
 // length 必须大于等于 0。返回值可用作 nonce、IV、salt 或 token 的原始随机材料；
@@ -41,3 +58,70 @@ func GenerateNonce(length int) ([]byte, error) {
 	// 返回缓冲区和读取结果；调用方必须先检查 err 再使用 nonce。
 	return nonce, err
 }
+
+// GenerateRandomString 返回由 charset 中字符组成的指定长度密码学安全随机字符串。
+//
+// 每个字符从 charset 中均匀选取：随机字节超出 charset 长度整数倍的部分会被丢弃重读，避免取模带来的偏差。
+// charset 中重复的字符会提高该字符出现的概率。
+//
+// 参数：
+//   - n: 字符串长度，必须大于等于 0；为 0 时返回空字符串。
+//   - charset: 候选字符，按字节处理，长度必须在 2 到 128 之间且只能包含 ASCII 字符；
+//     可使用 CharsetAlphanumeric、CharsetHex、CharsetHumanSafe。
+//
+// 返回：
+//   - string: 生成的随机字符串；出错时为空字符串。
+//   - error: n 为负数时返回参数错误；charset 不合法时返回包装了 ErrInvalidCharset 的错误；
+//     随机源失败时返回 io.ReadFull 产生的错误。
+func GenerateRandomString(n int, charset string) (string, error) {
+	if n < 0 {
+		return "", fmt.Errorf("长度不能为负数：%d", n)
+	}
+	if len(charset) < 2 || len(charset) > 128 {
+		return "", fmt.Errorf("%w: 长度 %d 不在 2 到 128 之间", ErrInvalidCharset, len(charset))
+	}
+	for i := 0; i < len(charset); i++ {
+		if charset[i] >= 0x80 {
+			return "", fmt.Errorf("%w: 包含非 ASCII 字符", ErrInvalidCharset)
+		}
+	}
+
+	// limit 是 charset 长度不超过 256 的最大整数倍，大于等于 limit 的随机字节会被丢弃。
+	limit := 256 - 256%len(charset)
+	result := make([]byte, 0, n)
+	// 按丢弃概率多读一些，大多数情况下一次读取即可。
+	buf := make([]byte, n+n/4+1)
+	for len(result) < n {
+		if _, err := io.ReadFull(rand.Reader, buf); nil != err {
+			return "", err
+		}
+		for _, b := range buf {
+			if int(b) >= limit {
+				continue
+			}
+			result = append(result, charset[int(b)%len(charset)])
+			if len(result) == n {
+				break
+			}
+		}
+	}
+	return string(result), nil
+}
+
+// GenerateToken 返回由 n 个密码学安全随机字节编码而成的 URL 安全令牌。
+//
+// 令牌使用 EncodeBase64URL 编码，不含填充字符，可以直接放入 URL、Cookie 或请求头；长度为 ceil(n*4/3)。
+//
+// 参数：
+//   - n: 随机字节数，必须大于等于 0；令牌的熵为 n*8 位，通常使用 16 或 32。
+//
+// 返回：
+//   - string: 生成的令牌；出错时为空字符串。
+//   - error: n 为负数时返回参数错误；随机源失败时返回 io.ReadFull 产生的错误。
+func GenerateToken(n int) (string, error) {
+	raw, err := GenerateNonce(n)
+	if nil != err {
+		return "", err
+	}
+	return EncodeBase64URL(raw), nil
+}
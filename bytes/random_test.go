@@ -2,7 +2,7 @@
 //
 // Licensed under the MIT License. See LICENSE file in the project root for full license information.
 
-// Package bytes 单元测试覆盖 GenerateNonce 的长度校验、随机源读取和错误传播行为。
+// Package bytes 单元测试覆盖 GenerateNonce、GenerateRandomString 和 GenerateToken 的长度校验、随机源读取和错误传播行为。
 package bytes
 
 import (
@@ -10,6 +10,7 @@ import (
 	"crypto/rand"
 	"errors"
 	"io"
+	"strings"
 	"testing"
 	"testing/iotest"
 
@@ -173,6 +174,152 @@ func TestGenerateNonce_RandomReaderErrors(t *testing.T) {
 	}
 }
 
+// TestGenerateRandomString 验证 GenerateRandomString 的参数校验与字符集约束。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestGenerateRandomString(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		giveLength  int
+		giveCharset string
+		wantErr     bool
+		wantErrIs   error
+	}{
+		{name: "success/alphanumeric", description: "验证使用字母数字字符集生成指定长度的字符串。", giveLength: 64, giveCharset: CharsetAlphanumeric},
+		{name: "success/hex", description: "验证使用十六进制字符集生成指定长度的字符串。", giveLength: 32, giveCharset: CharsetHex},
+		{name: "success/human-safe", description: "验证人工易读字符集不包含易混淆字符。", giveLength: 256, giveCharset: CharsetHumanSafe},
+		{name: "boundary/zero-length", description: "验证长度为 0 时返回空字符串。", giveLength: 0, giveCharset: CharsetHex},
+		{name: "error/negative-length", description: "验证拒绝负数长度。", giveLength: -1, giveCharset: CharsetHex, wantErr: true},
+		{name: "error/short-charset", description: "验证拒绝少于 2 个字符的字符集。", giveLength: 8, giveCharset: "a", wantErr: true, wantErrIs: ErrInvalidCharset},
+		{name: "error/non-ascii-charset", description: "验证拒绝包含非 ASCII 字符的字符集。", giveLength: 8, giveCharset: "ab中", wantErr: true, wantErrIs: ErrInvalidCharset},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			got, err := GenerateRandomString(tt.giveLength, tt.giveCharset)
+			if tt.wantErr {
+				require.Error(t, err)
+				if nil != tt.wantErrIs {
+					assert.ErrorIs(t, err, tt.wantErrIs)
+				}
+				assert.Empty(t, got)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Len(t, got, tt.giveLength)
+			for _, c := range got {
+				assert.True(t, strings.ContainsRune(tt.giveCharset, c), "字符 %q 不在字符集中", c)
+			}
+		})
+	}
+
+	assert.NotContains(t, CharsetHumanSafe, "0")
+	assert.NotContains(t, CharsetHumanSafe, "O")
+	assert.NotContains(t, CharsetHumanSafe, "1")
+	assert.NotContains(t, CharsetHumanSafe, "l")
+	assert.NotContains(t, CharsetHumanSafe, "I")
+}
+
+// TestGenerateRandomString_RandomReader 验证 GenerateRandomString 丢弃会造成取模偏差的随机字节，并透传随机源错误。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestGenerateRandomString_RandomReader(t *testing.T) {
+	giveReadErr := errors.New("random reader failed")
+	tests := []struct {
+		name           string
+		description    string
+		giveRandomData io.Reader
+		wantString     string
+		wantErrIs      error
+	}{
+		{
+			name:           "success/rejects-biased-bytes",
+			description:    "验证字符集长度为 3 时丢弃 255，其余字节按取模映射为字符。",
+			giveRandomData: stdbytes.NewReader([]byte{0xff, 0x00, 0x01, 0x05}),
+			wantString:     "abc",
+		},
+		{
+			name:           "success/reads-again-after-rejection",
+			description:    "验证一次读取被丢弃的字节过多时继续读取。",
+			giveRandomData: stdbytes.NewReader([]byte{0xff, 0xff, 0xff, 0x00, 0x04, 0x02, 0xff, 0xff}),
+			wantString:     "abc",
+		},
+		{
+			name:           "error/reader-failure",
+			description:    "验证随机源失败时透传底层错误。",
+			giveRandomData: iotest.ErrReader(giveReadErr),
+			wantErrIs:      giveReadErr,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+			withRandReader(t, tt.giveRandomData)
+
+			got, err := GenerateRandomString(3, "abc")
+			if nil != tt.wantErrIs {
+				assert.ErrorIs(t, err, tt.wantErrIs)
+				assert.Empty(t, got)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantString, got)
+		})
+	}
+}
+
+// TestGenerateToken 验证 GenerateToken 输出 URL 安全且可解码为指定字节数的令牌。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestGenerateToken(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		giveLength  int
+		wantLen     int
+		wantErr     bool
+	}{
+		{name: "success/32-bytes", description: "验证 32 字节令牌编码为 43 个字符。", giveLength: 32, wantLen: 43},
+		{name: "success/16-bytes", description: "验证 16 字节令牌编码为 22 个字符。", giveLength: 16, wantLen: 22},
+		{name: "boundary/zero-length", description: "验证长度为 0 时返回空令牌。", giveLength: 0, wantLen: 0},
+		{name: "error/negative-length", description: "验证拒绝负数长度。", giveLength: -1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			got, err := GenerateToken(tt.giveLength)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Empty(t, got)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Len(t, got, tt.wantLen)
+			assert.NotContains(t, got, "+")
+			assert.NotContains(t, got, "/")
+			assert.NotContains(t, got, "=")
+			raw, err := DecodeBase64URL(got)
+			require.NoError(t, err)
+			assert.Len(t, raw, tt.giveLength)
+		})
+	}
+}
+
 // BenchmarkGenerateNonce 度量 GenerateNonce 在常见 nonce 长度下的生成开销。
 //
 // 该基准覆盖 16、32 和 64 字节长度，帮助观察真实随机源在不同输出规模下的性能表现。
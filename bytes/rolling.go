@@ -0,0 +1,127 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package bytes
+
+import (
+	"math/bits"
+)
+
+const (
+	// buzhashSeed 是生成 Buzhash 置换表的固定种子。
+	// 置换表必须在不同进程和版本之间保持一致，否则相同内容会切出不同的分块，失去去重效果。
+	buzhashSeed uint64 = 0x6b69742d62757a68
+)
+
+var (
+	// buzhashTable 是 Buzhash 使用的字节到 32 位随机值的置换表。
+	buzhashTable = newBuzhashTable(buzhashSeed)
+)
+
+// Buzhash 是基于循环移位和异或的滚动哈希，对最近 window 个字节计算哈希值。
+//
+// 每输入一个字节的开销为常数，适合在数据流上逐字节滑动窗口寻找内容定义的分块边界。
+// Buzhash 不是并发安全的，也不适合用作密码学哈希。
+type Buzhash struct {
+	// window 是滑动窗口大小。
+	window int
+	// ring 保存窗口内的字节，用于移出最早的字节。
+	ring []byte
+	// pos 是下一次写入 ring 的位置。
+	pos int
+	// filled 表示窗口是否已经填满。
+	filled bool
+	// outRotate 是移出字节时需要的循环移位位数。
+	outRotate int
+	// sum 是当前窗口的哈希值。
+	sum uint32
+}
+
+// NewBuzhash 创建一个窗口大小为 window 的 Buzhash。
+//
+// 参数：
+//   - window: 滑动窗口大小，单位为字节；小于等于 0 时按 1 处理。
+//
+// 返回：
+//   - *Buzhash: 初始状态的滚动哈希。
+func NewBuzhash(window int) *Buzhash {
+	window = max(window, 1)
+	return &Buzhash{
+		window:    window,
+		ring:      make([]byte, window),
+		outRotate: window % 32,
+	}
+}
+
+// Roll 将一个字节滑入窗口，并在窗口已满时移出最早的字节。
+//
+// 参数：
+//   - b: 滑入窗口的字节。
+//
+// 返回：
+//   - uint32: 滑入后窗口的哈希值。
+func (h *Buzhash) Roll(b byte) uint32 {
+	h.sum = bits.RotateLeft32(h.sum, 1) ^ buzhashTable[b]
+	if h.filled {
+		h.sum ^= bits.RotateLeft32(buzhashTable[h.ring[h.pos]], h.outRotate)
+	}
+	h.ring[h.pos] = b
+	h.pos++
+	if h.pos == h.window {
+		h.pos = 0
+		h.filled = true
+	}
+	return h.sum
+}
+
+// Sum32 返回当前窗口的哈希值。
+//
+// 参数：无。
+//
+// 返回：
+//   - uint32: 当前窗口的哈希值；窗口未满时为已输入字节的哈希值。
+func (h *Buzhash) Sum32() uint32 {
+	return h.sum
+}
+
+// Window 返回滑动窗口大小。
+//
+// 参数：无。
+//
+// 返回：
+//   - int: 滑动窗口大小，单位为字节。
+func (h *Buzhash) Window() int {
+	return h.window
+}
+
+// Reset 清空窗口和哈希值，恢复到初始状态。
+//
+// 参数：无。
+func (h *Buzhash) Reset() {
+	clear(h.ring)
+	h.pos = 0
+	h.filled = false
+	h.sum = 0
+}
+
+// newBuzhashTable 使用 splitmix64 从固定种子生成置换表。
+//
+// 参数：
+//   - seed: 生成置换表的种子。
+//
+// 返回：
+//   - [256]uint32: 每个字节对应的 32 位随机值。
+func newBuzhashTable(seed uint64) [256]uint32 {
+	var table [256]uint32
+	state := seed
+	for i := range table {
+		state += 0x9e3779b97f4a7c15
+		z := state
+		z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+		z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+		z ^= z >> 31
+		table[i] = uint32(z >> 32)
+	}
+	return table
+}
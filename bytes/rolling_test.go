@@ -0,0 +1,103 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package bytes
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestBuzhash_Roll 验证滚动哈希只取决于窗口内的最近字节。
+//
+// 该测试覆盖窗口大小小于、等于和大于 32 的情况，确保移出字节时的循环移位正确。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestBuzhash_Roll(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		giveWindow  int
+		wantWindow  int
+	}{
+		{name: "boundary/window-1", description: "验证窗口为 1 时哈希只取决于最后一个字节。", giveWindow: 1, wantWindow: 1},
+		{name: "success/window-16", description: "验证窗口小于 32 时的滚动结果。", giveWindow: 16, wantWindow: 16},
+		{name: "success/window-32", description: "验证窗口等于 32 时移出字节不需要额外移位。", giveWindow: 32, wantWindow: 32},
+		{name: "success/window-48", description: "验证窗口大于 32 时的滚动结果。", giveWindow: 48, wantWindow: 48},
+		{name: "boundary/non-positive-window", description: "验证非正窗口按 1 处理。", giveWindow: 0, wantWindow: 1},
+	}
+
+	data := make([]byte, 512)
+	rng := rand.New(rand.NewPCG(1, 2))
+	for i := range data {
+		data[i] = byte(rng.Uint32())
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			rolling := NewBuzhash(tt.giveWindow)
+			assert.Equal(t, tt.wantWindow, rolling.Window())
+			for i, b := range data {
+				got := rolling.Roll(b)
+				assert.Equal(t, got, rolling.Sum32())
+				if i+1 < tt.wantWindow {
+					continue
+				}
+				fresh := NewBuzhash(tt.wantWindow)
+				var want uint32
+				for _, wb := range data[i+1-tt.wantWindow : i+1] {
+					want = fresh.Roll(wb)
+				}
+				if !assert.Equal(t, want, got, "position=%d", i) {
+					return
+				}
+			}
+		})
+	}
+}
+
+// TestBuzhash_Reset 验证 Reset 后的哈希与新建实例一致。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestBuzhash_Reset(t *testing.T) {
+	h := NewBuzhash(4)
+	for _, b := range []byte("some earlier content") {
+		h.Roll(b)
+	}
+	h.Reset()
+	assert.Zero(t, h.Sum32())
+
+	fresh := NewBuzhash(4)
+	for _, b := range []byte("abc") {
+		assert.Equal(t, fresh.Roll(b), h.Roll(b))
+	}
+}
+
+// TestBuzhash_StableTable 验证置换表由固定种子生成，保证不同进程间的分块边界一致。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestBuzhash_StableTable(t *testing.T) {
+	assert.Equal(t, newBuzhashTable(buzhashSeed), buzhashTable)
+
+	seen := make(map[uint32]struct{}, len(buzhashTable))
+	for _, v := range buzhashTable {
+		seen[v] = struct{}{}
+	}
+	assert.Len(t, seen, len(buzhashTable))
+
+	h := NewBuzhash(64)
+	for _, b := range []byte("fsyyft-go kit") {
+		h.Roll(b)
+	}
+	// 固定的哈希值确保置换表和滚动算法的改动会被发现。
+	assert.Equal(t, uint32(0x67b0cedf), h.Sum32())
+}
@@ -15,6 +15,8 @@ const (
 	maxCost int64 = 1 << 30
 	// bufferItems 定义了默认的写入操作缓冲大小，默认为 64。
 	bufferItems int64 = 64
+	// maxPinned 定义默认可同时固定的缓存键数量上限，默认为 1024。
+	maxPinned = 1024
 )
 
 // 缓存的默认配置。
@@ -81,6 +83,14 @@ type Cache interface {
 	// 参数：无。
 	Clear()
 
+	// Stats 返回缓存的命中、未命中、驱逐与成本等累计统计。
+	//
+	// 参数：无。
+	//
+	// 返回：
+	//   - CacheStats: 累计统计；实现无法统计的字段为 0。
+	Stats() CacheStats
+
 	// Close 关闭缓存并释放相关资源。
 	//
 	// Close 不应与其它缓存操作并发调用，关闭后的缓存实例也不应继续用于读写操作。
@@ -105,16 +115,54 @@ type CacheOptions struct {
 
 	// MaxCost 指定缓存允许的最大成本。
 	//
-	// 本包内置实现写入每个缓存项时传入成本 1，但未设置 IgnoreInternalCost，底层可能计入内部存储成本，
-	// 因此该值不能作为严格最大条目数。该值应使用正值；0 会导致当前 Ristretto 初始化失败。
+	// 本包内置实现的 Set 和 SetWithTTL 写入每个缓存项时传入成本 1；TypedCache 可通过 WithCostFunc 或
+	// WithEstimatedCost 按值计算成本，此时 MaxCost 可以按字节数设置以约束内存占用。未设置 IgnoreInternalCost 时
+	// 底层还会计入内部存储成本，因此该值不能作为严格最大条目数。该值应使用正值；0 会导致当前 Ristretto 初始化失败。
 	MaxCost int64
 
 	// BufferItems 指定 Ristretto 读写缓冲使用的条目数量。
 	//
 	// 该值应使用正值；0 会导致当前 Ristretto 初始化失败。较大的缓冲区可能提升并发性能，但会增加内存使用。
 	BufferItems int64
+
+	// IgnoreInternalCost 指定是否忽略 Ristretto 为每个缓存项计入的内部存储成本。
+	//
+	// 为 true 时 MaxCost 只约束写入时传入的成本之和，适用于成本已完整反映条目开销的场景。
+	IgnoreInternalCost bool
+
+	// OnEvict 在缓存项因容量不足被驱逐、过期被清理或执行 Clear 时调用，为 nil 时不回调。
+	//
+	// 回调在 Ristretto 的内部 goroutine 中同步执行，应避免阻塞或再次写入同一缓存；Clear 触发的回调中 cost 为 0。
+	OnEvict ItemFunc
+
+	// OnReject 在写入请求被 TinyLFU 准入策略拒绝或因成本超过 MaxCost 被丢弃时调用，为 nil 时不回调。
+	//
+	// 回调在 Ristretto 的内部 goroutine 中同步执行，应避免阻塞或再次写入同一缓存。
+	OnReject ItemFunc
+
+	// TTLTickerInterval 指定清理过期缓存项的周期，小于等于 0 时使用 Ristretto 的默认周期。
+	//
+	// Ristretto 以秒为单位处理该配置，不足 1 秒的部分向上取整。
+	TTLTickerInterval time.Duration
+
+	// MaxPinned 指定可同时固定的缓存键数量上限，小于等于 0 时禁止固定。
+	//
+	// 固定的缓存项不受 MaxCost 约束，该上限用于避免固定集合无限增长。
+	MaxPinned int
+
+	// Metrics 指定是否记录命中、未命中、驱逐与成本统计，为 false 时 Stats 返回全 0。
+	//
+	// 统计会带来少量读写开销；NewCache 默认开启。
+	Metrics bool
 }
 
+// ItemFunc 定义缓存项离开缓存或被拒绝写入时的回调函数。
+//
+// 参数：
+//   - value: 缓存项的值。
+//   - cost: 缓存项写入时的成本。
+type ItemFunc func(value interface{}, cost int64)
+
 // Option 定义修改 CacheOptions 的函数式选项。
 //
 // 参数：
@@ -128,6 +176,9 @@ type Option func(*CacheOptions)
 type TypedCache[T any] struct {
 	// cache 是底层缓存实现，必须由 AsTypedCache 注入非 nil 值。
 	cache Cache
+
+	// costFunc 计算写入成本，为 nil 时每次写入成本为 1。
+	costFunc CostFunc[T]
 }
 
 // WithNumCounters 设置缓存跟踪访问频率使用的计数器数量。
@@ -170,13 +221,82 @@ func WithBufferItems(bufferItems int64) Option {
 	}
 }
 
+// WithIgnoreInternalCost 设置是否忽略 Ristretto 为每个缓存项计入的内部存储成本。
+//
+// 参数：
+//   - ignore: 为 true 时 MaxCost 只约束写入时传入的成本之和。
+//
+// 返回：
+//   - Option: 应用于 CacheOptions.IgnoreInternalCost 的函数式选项。
+func WithIgnoreInternalCost(ignore bool) Option {
+	return func(opts *CacheOptions) {
+		opts.IgnoreInternalCost = ignore
+	}
+}
+
+// WithOnEvict 设置缓存项被驱逐、过期清理或执行 Clear 时的回调。
+//
+// 参数：
+//   - fn: 回调函数，为 nil 时不回调；在 Ristretto 内部 goroutine 中同步执行，应避免阻塞。
+//
+// 返回：
+//   - Option: 应用于 CacheOptions.OnEvict 的函数式选项。
+func WithOnEvict(fn ItemFunc) Option {
+	return func(opts *CacheOptions) {
+		opts.OnEvict = fn
+	}
+}
+
+// WithOnReject 设置写入请求被准入策略拒绝时的回调。
+//
+// 频繁触发说明新写入的键访问频率低于被驱逐候选项，可结合命中率调整 NumCounters 与 MaxCost。
+//
+// 参数：
+//   - fn: 回调函数，为 nil 时不回调；在 Ristretto 内部 goroutine 中同步执行，应避免阻塞。
+//
+// 返回：
+//   - Option: 应用于 CacheOptions.OnReject 的函数式选项。
+func WithOnReject(fn ItemFunc) Option {
+	return func(opts *CacheOptions) {
+		opts.OnReject = fn
+	}
+}
+
+// WithTTLTickerInterval 设置清理过期缓存项的周期。
+//
+// 较短的周期使过期缓存项更早释放容量，但会增加清理开销。
+//
+// 参数：
+//   - interval: 清理周期，小于等于 0 时使用 Ristretto 的默认周期；不足 1 秒的部分向上取整。
+//
+// 返回：
+//   - Option: 应用于 CacheOptions.TTLTickerInterval 的函数式选项。
+func WithTTLTickerInterval(interval time.Duration) Option {
+	return func(opts *CacheOptions) {
+		opts.TTLTickerInterval = interval
+	}
+}
+
+// WithMetrics 设置是否记录命中、未命中、驱逐与成本统计。
+//
+// 参数：
+//   - enabled: 为 true 时记录统计，默认开启；对性能极度敏感且不需要监控时可以关闭。
+//
+// 返回：
+//   - Option: 应用于 CacheOptions.Metrics 的函数式选项。
+func WithMetrics(enabled bool) Option {
+	return func(opts *CacheOptions) {
+		opts.Metrics = enabled
+	}
+}
+
 // NewCache 使用当前内置的 Ristretto 后端创建独立缓存实例。
 //
-// 未提供 Option 时会使用包内默认的 NumCounters、MaxCost 和 BufferItems。多个 Option 会按传入顺序应用，
+// 未提供 Option 时会使用包内默认的 NumCounters、MaxCost、BufferItems 和 MaxPinned，并开启统计。多个 Option 会按传入顺序应用，
 // 后传入的选项可以覆盖先前写入的同一字段。调用方在实例不再使用时应调用 Close。
 //
 // 参数：
-//   - options: 可选配置项；为空时使用默认的 NumCounters、MaxCost 和 BufferItems。
+//   - options: 可选配置项；为空时使用默认的 NumCounters、MaxCost、BufferItems 和 MaxPinned，并开启统计。
 //
 // 返回：
 //   - Cache: 创建成功后的缓存实例。
@@ -187,6 +307,8 @@ func NewCache(options ...Option) (Cache, error) {
 		NumCounters: numCounters,
 		MaxCost:     maxCost,
 		BufferItems: bufferItems,
+		MaxPinned:   maxPinned,
+		Metrics:     true,
 	}
 
 	// 应用自定义选项
@@ -201,16 +323,22 @@ func NewCache(options ...Option) (Cache, error) {
 // AsTypedCache 将已有 Cache 包装为类型安全缓存。
 //
 // 包装器不复制数据，也不改变底层缓存的关闭责任；类型安全读取仅在取出的值可断言为 T 时命中。
+// 未提供 TypedOption 时每次写入成本为 1。
 //
 // 参数：
 //   - cache: 待包装的底层缓存实例，调用方应保证其非 nil。
+//   - options: 可选配置项，例如 WithCostFunc 或 WithEstimatedCost。
 //
 // 返回：
 //   - *TypedCache[T]: 与 cache 共享存储和生命周期的类型安全缓存包装器。
-func AsTypedCache[T any](cache Cache) *TypedCache[T] {
-	return &TypedCache[T]{
+func AsTypedCache[T any](cache Cache, options ...TypedOption[T]) *TypedCache[T] {
+	tc := &TypedCache[T]{
 		cache: cache,
 	}
+	for _, option := range options {
+		option(tc)
+	}
+	return tc
 }
 
 // Get 获取 key 对应的 T 类型缓存值。
@@ -250,6 +378,8 @@ func (tc *TypedCache[T]) GetWithTTL(key interface{}) (value T, exists bool, rema
 
 // Set 写入永不过期的 T 类型缓存值。
 //
+// 设置了成本函数且底层 Cache 实现 CostSetter 时，按成本函数的结果写入；否则委托底层 Cache.Set。
+//
 // 参数：
 //   - key: 待写入的缓存键，具体可接受类型由底层 Cache 决定。
 //   - value: 待缓存的 T 类型值。
@@ -257,11 +387,13 @@ func (tc *TypedCache[T]) GetWithTTL(key interface{}) (value T, exists bool, rema
 // 返回：
 //   - bool: 底层 Cache 接受或排队该写入请求时返回 true；是否保证最终保留由底层实现决定。
 func (tc *TypedCache[T]) Set(key interface{}, value T) bool {
-	return tc.cache.Set(key, value)
+	return tc.SetWithTTL(key, value, 0)
 }
 
 // SetWithTTL 写入带过期时间的 T 类型缓存值。
 //
+// 设置了成本函数且底层 Cache 实现 CostSetter 时，按成本函数的结果写入；否则委托底层 Cache.SetWithTTL。
+//
 // 参数：
 //   - key: 待写入的缓存键，具体可接受类型由底层 Cache 决定。
 //   - value: 待缓存的 T 类型值。
@@ -270,6 +402,42 @@ func (tc *TypedCache[T]) Set(key interface{}, value T) bool {
 // 返回：
 //   - bool: 底层 Cache 接受或排队该写入请求时返回 true；是否保证最终保留由底层实现决定。
 func (tc *TypedCache[T]) SetWithTTL(key interface{}, value T, ttl time.Duration) bool {
+	if nil == tc.costFunc {
+		if ttl <= 0 {
+			return tc.cache.Set(key, value)
+		}
+		return tc.cache.SetWithTTL(key, value, ttl)
+	}
+	return tc.SetWithCostTTL(key, value, tc.costOf(value), ttl)
+}
+
+// SetWithCost 使用指定成本写入永不过期的 T 类型缓存值，覆盖成本函数的计算结果。
+//
+// 参数：
+//   - key: 待写入的缓存键，具体可接受类型由底层 Cache 决定。
+//   - value: 待缓存的 T 类型值。
+//   - cost: 缓存项成本；小于等于 0 时按 1 处理。底层 Cache 未实现 CostSetter 时忽略该参数。
+//
+// 返回：
+//   - bool: 底层 Cache 接受或排队该写入请求时返回 true；是否保证最终保留由底层实现决定。
+func (tc *TypedCache[T]) SetWithCost(key interface{}, value T, cost int64) bool {
+	return tc.SetWithCostTTL(key, value, cost, 0)
+}
+
+// SetWithCostTTL 使用指定成本写入带过期时间的 T 类型缓存值，覆盖成本函数的计算结果。
+//
+// 参数：
+//   - key: 待写入的缓存键，具体可接受类型由底层 Cache 决定。
+//   - value: 待缓存的 T 类型值。
+//   - cost: 缓存项成本；小于等于 0 时按 1 处理。底层 Cache 未实现 CostSetter 时忽略该参数。
+//   - ttl: 缓存有效期；ttl 小于等于 0 时表示永不过期。
+//
+// 返回：
+//   - bool: 底层 Cache 接受或排队该写入请求时返回 true；是否保证最终保留由底层实现决定。
+func (tc *TypedCache[T]) SetWithCostTTL(key interface{}, value T, cost int64, ttl time.Duration) bool {
+	if cs, ok := tc.cache.(CostSetter); ok {
+		return cs.SetWithCost(key, value, normalizeCost(cost), ttl)
+	}
 	return tc.cache.SetWithTTL(key, value, ttl)
 }
 
@@ -290,6 +458,18 @@ func (tc *TypedCache[T]) Clear() {
 	tc.cache.Clear()
 }
 
+// Stats 返回底层 Cache 的累计统计。
+//
+// 类型不匹配按未命中返回的读取在底层 Cache 中仍计为命中。
+//
+// 参数：无。
+//
+// 返回：
+//   - CacheStats: 底层 Cache 的累计统计。
+func (tc *TypedCache[T]) Stats() CacheStats {
+	return tc.cache.Stats()
+}
+
 // Close 关闭底层 Cache 并释放相关资源。
 //
 // Close 不会修改 TypedCache 自身状态，不应与其它缓存操作并发调用；关闭后的包装器不应继续用于读写操作。
@@ -39,6 +39,12 @@ func TestCacheOptionsApply(t *testing.T) {
 			option:      WithBufferItems(32),
 			want:        CacheOptions{BufferItems: 32},
 		},
+		{
+			name:        "metrics",
+			description: "WithMetrics 应只覆盖 Metrics 字段。",
+			option:      WithMetrics(true),
+			want:        CacheOptions{Metrics: true},
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,294 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package cache
+
+import (
+	"reflect"
+	"time"
+)
+
+var (
+	// 断言 ristrettoCache 实现 CostSetter 接口。
+	_ CostSetter = (*ristrettoCache)(nil)
+)
+
+type (
+	// CostSetter 定义支持按条目指定成本写入的缓存扩展接口。
+	//
+	// Cache 接口本身不包含成本参数，以保持对已有实现的兼容；内置 Ristretto 实现额外实现本接口。
+	// TypedCache 写入时会检测底层 Cache 是否实现 CostSetter，未实现时忽略成本并回退到 SetWithTTL。
+	CostSetter interface {
+		// SetWithCost 写入带成本和过期时间的缓存值。
+		//
+		// 参数：
+		//   - key: 待写入的缓存键，具体可接受类型由实现决定。
+		//   - value: 待缓存的值。
+		//   - cost: 缓存项成本，与 CacheOptions.MaxCost 使用相同单位；小于等于 0 时按 1 处理。
+		//   - ttl: 缓存有效期；ttl 小于等于 0 时表示永不过期。
+		//
+		// 返回：
+		//   - bool: 底层实现接受或排队该写入请求时返回 true；是否最终保留由具体实现决定。
+		SetWithCost(key interface{}, value interface{}, cost int64, ttl time.Duration) bool
+	}
+
+	// Sizer 定义可以自行报告内存占用的值。
+	//
+	// EstimateSize 遇到实现 Sizer 的值时直接使用 CacheSize 的结果，不再反射遍历；适用于反射估算不准确
+	// 或开销过大的类型，例如持有大块外部内存或对象池引用的结构体。CacheSize 不应以自身为参数调用
+	// EstimateSize，否则会无限递归。
+	Sizer interface {
+		// CacheSize 返回值的近似内存占用字节数。
+		//
+		// 参数：无。
+		//
+		// 返回：
+		//   - int64: 近似字节数。
+		CacheSize() int64
+	}
+
+	// CostFunc 定义根据缓存值计算写入成本的函数。
+	//
+	// 参数：
+	//   - value: 待写入的 T 类型缓存值。
+	//
+	// 返回：
+	//   - int64: 缓存项成本，与 CacheOptions.MaxCost 使用相同单位；小于等于 0 时按 1 处理。
+	CostFunc[T any] func(value T) int64
+
+	// TypedOption 定义修改 TypedCache 行为的函数式选项。
+	//
+	// 参数：
+	//   - *TypedCache[T]: 待修改的类型安全缓存，AsTypedCache 在应用选项时传入非 nil 指针。
+	TypedOption[T any] func(*TypedCache[T])
+)
+
+// WithCostFunc 设置 TypedCache 写入时使用的成本函数。
+//
+// 未设置成本函数时，TypedCache 的每次写入成本为 1；配合以字节为单位的 MaxCost 使用时，应设置成本函数
+// 或使用 WithEstimatedCost，使缓存容量真正约束内存占用。
+//
+// 参数：
+//   - fn: 成本函数；为 nil 时恢复默认成本 1。
+//
+// 返回：
+//   - TypedOption[T]: 应用于 AsTypedCache 的函数式选项。
+func WithCostFunc[T any](fn CostFunc[T]) TypedOption[T] {
+	return func(tc *TypedCache[T]) {
+		tc.costFunc = fn
+	}
+}
+
+// WithEstimatedCost 使 TypedCache 写入时使用 EstimateSize 估算的字节数作为成本。
+//
+// 返回：
+//   - TypedOption[T]: 应用于 AsTypedCache 的函数式选项。
+func WithEstimatedCost[T any]() TypedOption[T] {
+	return WithCostFunc(func(value T) int64 {
+		return EstimateSize(value)
+	})
+}
+
+// EstimateSize 使用反射估算值的近似内存占用字节数。
+//
+// 估算结果包含值本身的大小，以及通过指针、字符串、切片、映射和接口可达的数据大小；同一指针、切片或映射
+// 底层数据只计算一次，因此可以处理循环引用。映射的哈希桶开销、通道缓冲和函数闭包不计入，结果应视为下限
+// 而非精确值。值或其可导出的嵌套值实现 Sizer 时直接使用 CacheSize 的结果。
+//
+// 参数：
+//   - value: 待估算的值；为 nil 时返回 0。
+//
+// 返回：
+//   - int64: 近似字节数。
+func EstimateSize(value interface{}) int64 {
+	if nil == value {
+		return 0
+	}
+	return estimateValue(reflect.ValueOf(value), make(map[uintptr]struct{}))
+}
+
+// estimateValue 返回 v 本身的大小加上其间接引用数据的大小。
+//
+// 参数：
+//   - v: 待估算的反射值。
+//   - seen: 已计算过的底层数据地址。
+//
+// 返回：
+//   - int64: 近似字节数。
+func estimateValue(v reflect.Value, seen map[uintptr]struct{}) int64 {
+	if size, ok := sizerSize(v); ok {
+		return size
+	}
+	return int64(v.Type().Size()) + estimateIndirect(v, seen)
+}
+
+// estimateIndirect 返回 v 通过引用可达、但不包含在 v 本身大小中的数据大小。
+//
+// 参数：
+//   - v: 待估算的反射值。
+//   - seen: 已计算过的底层数据地址。
+//
+// 返回：
+//   - int64: 近似字节数。
+func estimateIndirect(v reflect.Value, seen map[uintptr]struct{}) int64 {
+	switch v.Kind() {
+	case reflect.String:
+		return int64(v.Len())
+	case reflect.Ptr:
+		if v.IsNil() || markSeen(v.Pointer(), seen) {
+			return 0
+		}
+		return estimateValue(v.Elem(), seen)
+	case reflect.Interface:
+		if v.IsNil() {
+			return 0
+		}
+		return estimateValue(v.Elem(), seen)
+	case reflect.Slice:
+		if v.IsNil() || markSeen(v.Pointer(), seen) {
+			return 0
+		}
+		size := int64(v.Cap()) * int64(v.Type().Elem().Size())
+		if isFlat(v.Type().Elem()) {
+			return size
+		}
+		for i := 0; i < v.Len(); i++ {
+			size += indirectOf(v.Index(i), seen)
+		}
+		return size
+	case reflect.Array:
+		if isFlat(v.Type().Elem()) {
+			return 0
+		}
+		var size int64
+		for i := 0; i < v.Len(); i++ {
+			size += indirectOf(v.Index(i), seen)
+		}
+		return size
+	case reflect.Struct:
+		var size int64
+		for i := 0; i < v.NumField(); i++ {
+			size += indirectOf(v.Field(i), seen)
+		}
+		return size
+	case reflect.Map:
+		if v.IsNil() || markSeen(v.Pointer(), seen) {
+			return 0
+		}
+		var size int64
+		iter := v.MapRange()
+		for iter.Next() {
+			size += estimateValue(iter.Key(), seen) + estimateValue(iter.Value(), seen)
+		}
+		return size
+	default:
+		return 0
+	}
+}
+
+// indirectOf 返回内联存储的 v 在自身大小之外的占用。
+//
+// 切片元素、数组元素和结构体字段的自身大小已计入外层，v 实现 Sizer 时返回 CacheSize 超出自身大小的部分。
+//
+// 参数：
+//   - v: 内联存储的反射值。
+//   - seen: 已计算过的底层数据地址。
+//
+// 返回：
+//   - int64: 近似字节数。
+func indirectOf(v reflect.Value, seen map[uintptr]struct{}) int64 {
+	if size, ok := sizerSize(v); ok {
+		if extra := size - int64(v.Type().Size()); extra > 0 {
+			return extra
+		}
+		return 0
+	}
+	return estimateIndirect(v, seen)
+}
+
+// sizerSize 在 v 实现 Sizer 时返回其报告的大小。
+//
+// 参数：
+//   - v: 待检查的反射值。
+//
+// 返回：
+//   - int64: Sizer 报告的字节数。
+//   - bool: v 可访问且实现 Sizer 时为 true；nil 指针不会被调用。
+func sizerSize(v reflect.Value) (int64, bool) {
+	if !v.IsValid() || !v.CanInterface() {
+		return 0, false
+	}
+	if (reflect.Ptr == v.Kind() || reflect.Interface == v.Kind()) && v.IsNil() {
+		return 0, false
+	}
+	if s, ok := v.Interface().(Sizer); ok {
+		return s.CacheSize(), true
+	}
+	return 0, false
+}
+
+// markSeen 记录底层数据地址，并返回该地址此前是否已经记录。
+//
+// 参数：
+//   - ptr: 底层数据地址。
+//   - seen: 已计算过的底层数据地址。
+//
+// 返回：
+//   - bool: 地址已经记录过时为 true。
+func markSeen(ptr uintptr, seen map[uintptr]struct{}) bool {
+	if _, ok := seen[ptr]; ok {
+		return true
+	}
+	seen[ptr] = struct{}{}
+	return false
+}
+
+// isFlat 判断类型的值是否不包含任何间接引用数据。
+//
+// 参数：
+//   - t: 待判断的类型。
+//
+// 返回：
+//   - bool: 布尔、数值类型以及仅由这些类型组成的数组时为 true。
+func isFlat(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return true
+	case reflect.Array:
+		return isFlat(t.Elem())
+	default:
+		return false
+	}
+}
+
+// costOf 返回写入 value 时使用的成本。
+//
+// 参数：
+//   - value: 待写入的缓存值。
+//
+// 返回：
+//   - int64: 未设置成本函数时为 1；成本函数返回非正值时按 1 处理。
+func (tc *TypedCache[T]) costOf(value T) int64 {
+	if nil == tc.costFunc {
+		return 1
+	}
+	return normalizeCost(tc.costFunc(value))
+}
+
+// normalizeCost 将非正成本归一化为 1。
+//
+// 参数：
+//   - cost: 原始成本。
+//
+// 返回：
+//   - int64: cost 大于 0 时原样返回，否则返回 1。
+func normalizeCost(cost int64) int64 {
+	if cost <= 0 {
+		return 1
+	}
+	return cost
+}
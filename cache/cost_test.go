@@ -0,0 +1,187 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package cache
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fixedSizer 是报告固定大小的 Sizer 实现。
+type fixedSizer struct {
+	payload []byte
+}
+
+// CacheSize 返回固定大小。
+func (fixedSizer) CacheSize() int64 {
+	return 42
+}
+
+// sizeNode 是可以构成循环引用的链表节点。
+type sizeNode struct {
+	name string
+	next *sizeNode
+}
+
+// plainCache 只暴露 Cache 接口方法，用于验证未实现 CostSetter 时的回退行为。
+type plainCache struct {
+	Cache
+}
+
+// TestEstimateSize 验证反射估算覆盖常见类型、循环引用和 Sizer。
+func TestEstimateSize(t *testing.T) {
+	cyclic := &sizeNode{name: "ab"}
+	cyclic.next = cyclic
+
+	tests := []struct {
+		name        string
+		description string
+		give        interface{}
+		want        int64
+	}{
+		{
+			name:        "boundary/nil",
+			description: "nil 值估算为 0。",
+			give:        nil,
+			want:        0,
+		},
+		{
+			name:        "success/int",
+			description: "数值类型只计算自身大小。",
+			give:        int64(1),
+			want:        8,
+		},
+		{
+			name:        "success/string",
+			description: "字符串计算头部和内容长度。",
+			give:        "abc",
+			want:        16 + 3,
+		},
+		{
+			name:        "success/byte-slice",
+			description: "切片按容量计算底层数组大小。",
+			give:        make([]byte, 2, 10),
+			want:        24 + 10,
+		},
+		{
+			name:        "success/string-slice",
+			description: "切片元素引用的字符串内容计入结果。",
+			give:        []string{"ab", "cde"},
+			want:        24 + 2*16 + 5,
+		},
+		{
+			name:        "success/map",
+			description: "映射计算键值大小，不计入哈希桶开销。",
+			give:        map[string]int64{"k": 1},
+			want:        8 + 16 + 1 + 8,
+		},
+		{
+			name:        "success/cyclic-pointer",
+			description: "循环引用的指针只计算一次。",
+			give:        cyclic,
+			want:        8 + 24 + 2,
+		},
+		{
+			name:        "success/sizer",
+			description: "实现 Sizer 的值直接使用 CacheSize。",
+			give:        fixedSizer{payload: make([]byte, 1024)},
+			want:        42,
+		},
+		{
+			name:        "success/nested-sizer",
+			description: "切片元素实现 Sizer 时以 CacheSize 作为元素的完整占用。",
+			give:        []fixedSizer{{}, {}},
+			want:        24 + 2*42,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+			assert.Equal(t, tt.want, EstimateSize(tt.give))
+		})
+	}
+}
+
+// TestTypedCacheCost 验证成本函数和单条成本覆盖会约束缓存容量。
+func TestTypedCacheCost(t *testing.T) {
+	base, err := NewCache(
+		WithNumCounters(1_000),
+		WithMaxCost(100),
+		WithBufferItems(64),
+		WithIgnoreInternalCost(true),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, base.Close())
+	})
+
+	tc := AsTypedCache[string](base, WithCostFunc(func(v string) int64 {
+		return int64(len(v))
+	}))
+	large := strings.Repeat("x", 200)
+
+	tests := []struct {
+		name        string
+		description string
+		set         func() bool
+		key         string
+		wantExists  bool
+	}{
+		{
+			name:        "success/small-value",
+			description: "成本未超过容量的值可以写入。",
+			set:         func() bool { return tc.Set("small", "value") },
+			key:         "small",
+			wantExists:  true,
+		},
+		{
+			name:        "error/large-value",
+			description: "成本函数结果超过 MaxCost 的值不会被保留。",
+			set:         func() bool { return tc.Set("large", large) },
+			key:         "large",
+			wantExists:  false,
+		},
+		{
+			name:        "success/override-cost",
+			description: "SetWithCost 覆盖成本函数，使大值以较小成本写入。",
+			set:         func() bool { return tc.SetWithCost("override", large, 1) },
+			key:         "override",
+			wantExists:  true,
+		},
+		{
+			name:        "error/override-too-large",
+			description: "SetWithCostTTL 指定超过容量的成本时不会被保留。",
+			set:         func() bool { return tc.SetWithCostTTL("override-large", "v", 1_000, 0) },
+			key:         "override-large",
+			wantExists:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+			tt.set()
+			_, exists := tc.Get(tt.key)
+			assert.Equal(t, tt.wantExists, exists)
+		})
+	}
+}
+
+// TestTypedCacheCostFallback 验证底层 Cache 未实现 CostSetter 时忽略成本正常写入。
+func TestTypedCacheCostFallback(t *testing.T) {
+	tc := AsTypedCache[string](plainCache{Cache: newTestCache(t)}, WithEstimatedCost[string]())
+
+	require.True(t, tc.SetWithCost("fallback", "value", 1_000))
+	got, exists := tc.Get("fallback")
+	require.True(t, exists)
+	assert.Equal(t, "value", got)
+	assert.Equal(t, int64(16+5), tc.costOf("value"))
+}
@@ -10,7 +10,28 @@
 // Ristretto 参数；调用方在实例不再使用时应调用 Close 释放底层资源。SetWithTTL 的非正 ttl 表示永不过期，
 // GetWithTTL 使用 -1 表示永不过期，使用 0 表示键不存在或已过期。
 //
-// AsTypedCache 在现有 Cache 上提供泛型类型断言包装，类型不匹配时按未命中处理。InitCache、Get、Set 等
+// AsTypedCache 在现有 Cache 上提供泛型类型断言包装，类型不匹配时按未命中处理。默认每次写入成本为 1；
+// 通过 WithCostFunc 或 WithEstimatedCost 可以按值计算成本，配合 WithIgnoreInternalCost 和以字节为单位的
+// MaxCost 约束内存占用，SetWithCost 和 SetWithCostTTL 用于覆盖单条写入的成本。InitCache、Get、Set 等
 // 包级函数操作进程内默认缓存；默认缓存由 sync.Once 控制只初始化一次，首次调用使用的 Option 会固定为后续
 // 全局访问配置，首次初始化失败后也不会自动重试。
+//
+// 内置实现还实现了 Pinner：Pin 固定的键不参与准入与驱逐，数量受 WithMaxPinned 限制，PinStats 报告固定值的
+// 估算内存占用。WithOnEvict、WithOnReject 与 WithTTLTickerInterval 用于观察和调整 Ristretto 的准入与驱逐行为。
+//
+// GetOrLoad 与 TypedCache.GetOrLoad 在未命中时调用加载函数并写入缓存，同一个缓存实例上同一个键的并发未命中
+// 只调用一次加载函数，适合包装数据库查询。
+//
+// NewLoadingCache 在已有 Cache 上创建 LoadingCache：未命中时调用加载函数并合并同一个键的并发加载，
+// 每个刷新周期内被访问过的键会在后台提前重新加载，适用于配置、字典等数据量小的热点数据。
+//
+// NewNamespace 在共享缓存上创建命名空间视图：键带命名空间前缀，WithNamespaceTTL、WithNamespaceMaxEntries 与
+// WithNamespaceAdmission 为每个命名空间设置默认 TTL、缓存项数量上限与准入策略，达到上限时只淘汰本命名空间
+// 最久未访问的缓存项，避免某个模块挤占其它模块的热点数据。
+//
+// NewTieredCache 把本地缓存与远程 Redis 组成两级缓存：读取先访问本地缓存，未命中时读取 Redis 并回填；写入与删除
+// 作用于 Redis 后通过 pub/sub 广播失效消息，其它实例据此删除本地副本，使多实例部署的本地缓存保持一致。
+//
+// Cache.Stats 返回命中、未命中、驱逐与成本等累计统计，内置实现的统计可通过 WithMetrics 关闭；
+// NewPrometheusCollector 把统计导出为 Prometheus 指标，采集器需要由调用方注册。
 package cache
@@ -0,0 +1,132 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package cache
+
+import (
+	"reflect"
+	"time"
+
+	kitflight "github.com/fsyyft-go/kit/internal/flight"
+)
+
+var (
+	// ErrLoaderPanicked 表示合并加载时执行加载函数的调用发生了 panic，等待同一结果的其它调用收到该错误。
+	//
+	// GetOrLoad、LoadingCache 与 database/sql 的 CachedQuery 使用同一个错误值。
+	ErrLoaderPanicked = kitflight.ErrPanicked
+)
+
+var (
+	// flights 合并进程内所有缓存实例上同一个键的并发加载，以缓存实例、键与结果类型区分。
+	flights kitflight.Group[flightKey, interface{}]
+)
+
+type (
+	// flightKey 标识一次可合并的加载。
+	flightKey struct {
+		// cache 是加载结果写入的缓存实例，为 nil 表示包级默认缓存未初始化。
+		cache Cache
+		// key 是缓存键，[]byte 键已转换为 string。
+		key interface{}
+		// typ 是调用方期望的结果类型，使类型化与非类型化的加载互不共享结果。
+		typ reflect.Type
+	}
+)
+
+// GetOrLoad 从包级默认缓存中获取 key 对应的值，未命中时调用 loader 加载并写入默认缓存。
+//
+// 同一个键的并发未命中只调用一次 loader，其它调用等待并共享结果；loader 返回错误时不写入缓存，等待者收到同一个
+// 错误。默认缓存未初始化时仍合并并发加载，但不缓存结果。需要在独立缓存实例上使用时，可以调用
+// AsTypedCache[interface{}](c).GetOrLoad。
+//
+// 参数：
+//   - key: 缓存键，具体可接受类型由默认缓存实现决定。
+//   - ttl: 加载结果的有效期；ttl 小于等于 0 时表示永不过期。
+//   - loader: 加载函数，例如包装一次数据库查询。
+//
+// 返回：
+//   - interface{}: 缓存值或加载得到的值。
+//   - error: loader 返回的错误；执行 loader 的调用 panic 时，等待者收到 ErrLoaderPanicked。
+func GetOrLoad(key interface{}, ttl time.Duration, loader func() (interface{}, error)) (interface{}, error) {
+	c := defaultCache
+	get := func() (interface{}, bool) {
+		if nil == c {
+			return nil, false
+		}
+		return c.Get(key)
+	}
+	store := func(value interface{}) {
+		if nil != c {
+			c.SetWithTTL(key, value, ttl)
+		}
+	}
+	return getOrLoad[interface{}](c, key, get, loader, store)
+}
+
+// GetOrLoad 获取 key 对应的 T 类型缓存值，未命中时调用 loader 加载并写入缓存。
+//
+// 同一个底层 Cache 上同一个键的并发未命中只调用一次 loader，即使调用来自不同的 TypedCache 包装器；其它调用等待并
+// 共享结果。loader 返回错误时不写入缓存，等待者收到同一个错误。写入遵循 SetWithTTL 的成本规则。
+//
+// 参数：
+//   - key: 缓存键，具体可接受类型由底层 Cache 决定。
+//   - ttl: 加载结果的有效期；ttl 小于等于 0 时表示永不过期。
+//   - loader: 加载函数，例如包装一次数据库查询。
+//
+// 返回：
+//   - T: 缓存值或加载得到的值；加载失败时返回 T 的零值。
+//   - error: loader 返回的错误；执行 loader 的调用 panic 时，等待者收到 ErrLoaderPanicked。
+func (tc *TypedCache[T]) GetOrLoad(key interface{}, ttl time.Duration, loader func() (T, error)) (T, error) {
+	get := func() (T, bool) {
+		return tc.Get(key)
+	}
+	store := func(value T) {
+		tc.SetWithTTL(key, value, ttl)
+	}
+	return getOrLoad(tc.cache, key, get, loader, store)
+}
+
+// getOrLoad 读取缓存，未命中时合并同一个键的并发加载。
+//
+// 参数：
+//   - c: 缓存实例，用于区分不同实例上的同名键。
+//   - key: 缓存键。
+//   - get: 读取缓存的函数。
+//   - loader: 加载函数。
+//   - store: 写入加载结果的函数。
+//
+// 返回：
+//   - T: 缓存值或加载得到的值。
+//   - error: 加载错误。
+func getOrLoad[T any](c Cache, key interface{}, get func() (T, bool), loader func() (T, error), store func(T)) (T, error) {
+	if value, ok := get(); ok {
+		return value, nil
+	}
+
+	fk := flightKey{cache: c, key: key, typ: reflect.TypeFor[T]()}
+	if b, ok := key.([]byte); ok {
+		// []byte 不可作为 map 键；Ristretto 对 []byte 与 string 键使用相同的哈希。
+		fk.key = string(b)
+	}
+
+	value, err := flights.Do(fk, func() (interface{}, error) {
+		// 上一次加载可能在本次读取之后、登记之前完成并写入了缓存。
+		if value, ok := get(); ok {
+			return value, nil
+		}
+		value, err := loader()
+		if nil == err {
+			store(value)
+		}
+		return value, err
+	})
+	if nil != err {
+		var zero T
+		return zero, err
+	}
+	// T 为接口类型时加载结果可能为 nil，不能直接断言。
+	typed, _ := value.(T)
+	return typed, nil
+}
@@ -0,0 +1,132 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package cache
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestTypedCache_GetOrLoad 验证类型安全的 GetOrLoad 合并并发加载、缓存结果且不缓存错误。
+func TestTypedCache_GetOrLoad(t *testing.T) {
+	c, err := NewCache(WithNumCounters(1000), WithMaxCost(1000))
+	require.NoError(t, err)
+	defer c.Close() //nolint:errcheck
+
+	t.Run("success/dedup", func(t *testing.T) {
+		t.Log("验证同一个键的并发未命中只调用一次加载函数，结果写入缓存。")
+
+		users := AsTypedCache[string](c)
+		var calls atomic.Int64
+		release := make(chan struct{})
+		loader := func() (string, error) {
+			calls.Add(1)
+			<-release
+			return "alice", nil
+		}
+
+		var wg sync.WaitGroup
+		results := make([]string, 16)
+		for i := range results {
+			wg.Go(func() {
+				// 不同的包装器共享同一个底层缓存时同样合并。
+				value, err := AsTypedCache[string](c).GetOrLoad("user:1", time.Minute, loader)
+				assert.NoError(t, err)
+				results[i] = value
+			})
+		}
+		time.Sleep(20 * time.Millisecond)
+		close(release)
+		wg.Wait()
+
+		assert.Equal(t, int64(1), calls.Load())
+		for _, value := range results {
+			assert.Equal(t, "alice", value)
+		}
+		value, ok, ttl := users.GetWithTTL("user:1")
+		assert.True(t, ok)
+		assert.Equal(t, "alice", value)
+		assert.Greater(t, ttl, 50*time.Second)
+
+		// 命中缓存时不再调用加载函数。
+		value, err := users.GetOrLoad("user:1", time.Minute, loader)
+		require.NoError(t, err)
+		assert.Equal(t, "alice", value)
+		assert.Equal(t, int64(1), calls.Load())
+	})
+
+	t.Run("error/not-cached", func(t *testing.T) {
+		t.Log("验证加载失败时返回错误且不写入缓存，下一次调用重新加载。")
+
+		counts := AsTypedCache[int](c)
+		wantErr := errors.New("db down")
+		value, err := counts.GetOrLoad("count", 0, func() (int, error) { return 7, wantErr })
+		assert.ErrorIs(t, err, wantErr)
+		assert.Zero(t, value)
+		_, ok := counts.Get("count")
+		assert.False(t, ok)
+
+		value, err = counts.GetOrLoad([]byte("count"), 0, func() (int, error) { return 8, nil })
+		require.NoError(t, err)
+		assert.Equal(t, 8, value)
+	})
+
+	t.Run("error/panic", func(t *testing.T) {
+		t.Log("验证加载函数 panic 时向调用方传播，等待者收到 ErrLoaderPanicked。")
+
+		tc := AsTypedCache[string](c)
+		started := make(chan struct{})
+		waiter := make(chan error, 1)
+		go func() {
+			<-started
+			_, err := tc.GetOrLoad("panic", 0, func() (string, error) { return "unused", nil })
+			waiter <- err
+		}()
+
+		assert.Panics(t, func() {
+			_, _ = tc.GetOrLoad("panic", 0, func() (string, error) {
+				close(started)
+				// 等待 waiter 加入本次加载。
+				time.Sleep(50 * time.Millisecond)
+				panic("boom")
+			})
+		}, "panic 应传播给执行加载函数的调用方")
+		assert.ErrorIs(t, <-waiter, ErrLoaderPanicked)
+	})
+}
+
+// TestGetOrLoad 验证包级 GetOrLoad 合并并发加载，并支持 nil 加载结果。
+//
+// 默认缓存可能已被其它测试关闭，因此只断言合并行为，不断言缓存命中。
+func TestGetOrLoad(t *testing.T) {
+	_ = InitCache()
+
+	var calls atomic.Int64
+	release := make(chan struct{})
+	loader := func() (interface{}, error) {
+		calls.Add(1)
+		<-release
+		return nil, nil
+	}
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Go(func() {
+			value, err := GetOrLoad("getorload:nil", 0, loader)
+			assert.NoError(t, err)
+			assert.Nil(t, value)
+		})
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	assert.Equal(t, int64(1), calls.Load())
+}
@@ -0,0 +1,314 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	kitflight "github.com/fsyyft-go/kit/internal/flight"
+)
+
+var (
+	// ErrInvalidRefreshInterval 表示 LoadingCache 的刷新周期不是正值。
+	ErrInvalidRefreshInterval = errors.New("cache: refresh interval must be positive")
+
+	// ErrNilLoader 表示创建 LoadingCache 时未提供加载函数。
+	ErrNilLoader = errors.New("cache: loader is nil")
+
+	// ErrLoadingCacheClosed 表示 LoadingCache 已关闭。
+	ErrLoadingCacheClosed = errors.New("cache: loading cache closed")
+)
+
+type (
+	// LoaderFunc 定义 LoadingCache 加载缓存值的函数。
+	//
+	// 参数：
+	//   - ctx: 控制加载过程的上下文；后台刷新时该上下文在 LoadingCache 关闭后取消。
+	//   - key: 待加载的缓存键。
+	//
+	// 返回：
+	//   - T: 加载得到的缓存值。
+	//   - error: 加载失败时返回错误，此时不会写入缓存。
+	LoaderFunc[T any] func(ctx context.Context, key interface{}) (T, error)
+
+	// LoadingOption 定义修改 LoadingCache 行为的函数式选项。
+	//
+	// 参数：
+	//   - *loadingOptions: 待修改的配置，NewLoadingCache 在应用选项时传入非 nil 指针。
+	LoadingOption func(*loadingOptions)
+
+	// loadingOptions 是 LoadingCache 的配置。
+	loadingOptions struct {
+		// ttl 是写入缓存时使用的有效期，小于等于 0 表示永不过期。
+		ttl time.Duration
+		// refreshTimeout 是后台刷新单个键的超时时间，小于等于 0 表示不设置。
+		refreshTimeout time.Duration
+		// onRefreshError 在后台刷新失败时调用，为 nil 时忽略错误。
+		onRefreshError func(key interface{}, err error)
+	}
+
+	// LoadingCache 是通过加载函数读取缓存值，并在后台按固定周期提前刷新热点键的类型安全缓存。
+	//
+	// Get 未命中时调用加载函数并写入缓存，同一个键的并发未命中只调用一次加载函数。每个刷新周期内被 Get 访问过的键
+	// 视为热点键，会在周期结束时重新加载并写回缓存，使其在过期之前就已更新（refresh-ahead）；一个周期内未被访问的键
+	// 不再刷新，按 TTL 自然过期。默认 TTL 为刷新周期的 2 倍，持续被访问的键因此不会过期，也不会在读取时等待加载。
+	// 刷新失败时保留旧值直到其过期。
+	//
+	// LoadingCache 在每个周期重新加载所有热点键，适用于配置、字典等数据量小且访问集中的场景；键数量大时应改用
+	// 按需加载。零值 LoadingCache 不可直接使用，调用方应通过 NewLoadingCache 创建，并在不再使用时调用 Close。
+	LoadingCache[T any] struct {
+		// cache 是存放缓存值的类型安全包装。
+		cache *TypedCache[T]
+		// loader 是加载缓存值的函数。
+		loader LoaderFunc[T]
+		// options 是 LoadingCache 的配置。
+		options loadingOptions
+
+		// mu 保护 hot。
+		mu sync.Mutex
+		// hot 记录当前刷新周期内被访问过的键。
+		hot map[interface{}]struct{}
+		// flights 合并同一个键的并发加载。
+		flights kitflight.Group[interface{}, T]
+
+		// ctx 是后台刷新使用的上下文，Close 时取消。
+		ctx context.Context
+		// cancel 取消 ctx。
+		cancel context.CancelFunc
+		// done 在后台刷新 goroutine 退出后关闭。
+		done chan struct{}
+		// closeOnce 保证 Close 只执行一次。
+		closeOnce sync.Once
+	}
+)
+
+// WithLoadingTTL 设置 LoadingCache 写入缓存时使用的有效期。
+//
+// TTL 应大于刷新周期，否则热点键会在刷新之前过期，读取时需要同步等待加载。
+//
+// 参数：
+//   - ttl: 缓存有效期，小于等于 0 时表示永不过期；默认值为刷新周期的 2 倍。
+//
+// 返回：
+//   - LoadingOption: 应用于 NewLoadingCache 的函数式选项。
+func WithLoadingTTL(ttl time.Duration) LoadingOption {
+	return func(opts *loadingOptions) {
+		opts.ttl = ttl
+	}
+}
+
+// WithLoadingRefreshTimeout 设置后台刷新单个键的超时时间。
+//
+// 参数：
+//   - timeout: 超时时间，小于等于 0 时不设置；该设置不影响 Get 未命中时的加载。
+//
+// 返回：
+//   - LoadingOption: 应用于 NewLoadingCache 的函数式选项。
+func WithLoadingRefreshTimeout(timeout time.Duration) LoadingOption {
+	return func(opts *loadingOptions) {
+		opts.refreshTimeout = timeout
+	}
+}
+
+// WithLoadingOnRefreshError 设置后台刷新失败时的回调。
+//
+// 参数：
+//   - fn: 回调函数，参数为刷新失败的键和加载函数返回的错误；为 nil 时忽略错误。回调在后台刷新 goroutine 中同步执行。
+//
+// 返回：
+//   - LoadingOption: 应用于 NewLoadingCache 的函数式选项。
+func WithLoadingOnRefreshError(fn func(key interface{}, err error)) LoadingOption {
+	return func(opts *loadingOptions) {
+		opts.onRefreshError = fn
+	}
+}
+
+// NewLoadingCache 在已有 Cache 上创建带后台刷新的加载缓存。
+//
+// LoadingCache 与 cache 共享存储，不改变 cache 的关闭责任；创建后立即启动后台刷新 goroutine。
+//
+// 参数：
+//   - cache: 存放缓存值的底层缓存实例，调用方应保证其非 nil。
+//   - loader: 加载缓存值的函数。
+//   - refreshInterval: 刷新周期，必须为正值。
+//   - options: 可选配置项，例如 WithLoadingTTL、WithLoadingRefreshTimeout 和 WithLoadingOnRefreshError。
+//
+// 返回：
+//   - *LoadingCache[T]: 创建成功的加载缓存。
+//   - error: loader 为 nil 时返回 ErrNilLoader，refreshInterval 不是正值时返回 ErrInvalidRefreshInterval。
+func NewLoadingCache[T any](cache Cache, loader LoaderFunc[T], refreshInterval time.Duration, options ...LoadingOption) (*LoadingCache[T], error) {
+	if nil == loader {
+		return nil, ErrNilLoader
+	}
+	if refreshInterval <= 0 {
+		return nil, ErrInvalidRefreshInterval
+	}
+
+	opts := loadingOptions{
+		ttl: 2 * refreshInterval,
+	}
+	for _, option := range options {
+		option(&opts)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	lc := &LoadingCache[T]{
+		cache:   AsTypedCache[T](cache),
+		loader:  loader,
+		options: opts,
+		hot:     make(map[interface{}]struct{}),
+		ctx:     ctx,
+		cancel:  cancel,
+		done:    make(chan struct{}),
+	}
+	go lc.refreshLoop(refreshInterval)
+
+	return lc, nil
+}
+
+// Get 获取 key 对应的缓存值，未命中时调用加载函数加载并写入缓存。
+//
+// 每次调用都会把 key 记为当前周期的热点键。同一个键的并发未命中只调用一次加载函数，其它调用等待并共享结果。
+//
+// 参数：
+//   - ctx: 控制加载过程的上下文，仅在未命中且由本次调用执行加载时传给加载函数。
+//   - key: 待查询的缓存键，具体可接受类型由底层 Cache 决定。
+//
+// 返回：
+//   - T: 缓存值或加载得到的值；加载失败时返回 T 的零值。
+//   - error: LoadingCache 已关闭时返回 ErrLoadingCacheClosed；加载失败时返回加载函数的错误；执行加载函数的调用
+//     panic 时，panic 继续向该调用方传播，等待者收到 ErrLoaderPanicked。
+func (lc *LoadingCache[T]) Get(ctx context.Context, key interface{}) (T, error) {
+	var zero T
+	if nil != lc.ctx.Err() {
+		return zero, ErrLoadingCacheClosed
+	}
+
+	lc.mu.Lock()
+	lc.hot[key] = struct{}{}
+	lc.mu.Unlock()
+
+	if value, ok := lc.cache.Get(key); ok {
+		return value, nil
+	}
+	return lc.load(ctx, key)
+}
+
+// Refresh 立即重新加载 key 并写入缓存，不等待下一个刷新周期。
+//
+// 参数：
+//   - ctx: 控制加载过程的上下文。
+//   - key: 待刷新的缓存键。
+//
+// 返回：
+//   - error: LoadingCache 已关闭时返回 ErrLoadingCacheClosed；加载失败时返回加载函数的错误，原有缓存值保持不变。
+func (lc *LoadingCache[T]) Refresh(ctx context.Context, key interface{}) error {
+	if nil != lc.ctx.Err() {
+		return ErrLoadingCacheClosed
+	}
+	_, err := lc.load(ctx, key)
+	return err
+}
+
+// Invalidate 删除 key 对应的缓存值，并停止在当前周期刷新该键。
+//
+// 参数：
+//   - key: 待删除的缓存键；key 不存在时该操作无效果。
+func (lc *LoadingCache[T]) Invalidate(key interface{}) {
+	lc.mu.Lock()
+	delete(lc.hot, key)
+	lc.mu.Unlock()
+
+	lc.cache.Delete(key)
+}
+
+// Close 停止后台刷新并等待正在进行的刷新结束。
+//
+// Close 不关闭底层 Cache；关闭后 Get 和 Refresh 返回 ErrLoadingCacheClosed。重复调用 Close 无效果。
+//
+// 参数：无。
+func (lc *LoadingCache[T]) Close() {
+	lc.closeOnce.Do(func() {
+		lc.cancel()
+		<-lc.done
+	})
+}
+
+// load 调用加载函数并写入缓存，合并同一个键的并发加载。
+//
+// 参数：
+//   - ctx: 传给加载函数的上下文。
+//   - key: 待加载的缓存键。
+//
+// 返回：
+//   - T: 加载得到的值。
+//   - error: 加载函数返回的错误；执行加载函数的调用 panic 时，等待者收到 ErrLoaderPanicked。
+func (lc *LoadingCache[T]) load(ctx context.Context, key interface{}) (T, error) {
+	return lc.flights.Do(key, func() (T, error) {
+		value, err := lc.loader(ctx, key)
+		if nil == err {
+			lc.cache.SetWithTTL(key, value, lc.options.ttl)
+		}
+		return value, err
+	})
+}
+
+// refreshLoop 每个刷新周期重新加载一次热点键，直到 LoadingCache 关闭。
+//
+// 参数：
+//   - interval: 刷新周期。
+func (lc *LoadingCache[T]) refreshLoop(interval time.Duration) {
+	defer close(lc.done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-lc.ctx.Done():
+			return
+		case <-ticker.C:
+			lc.refreshHot()
+		}
+	}
+}
+
+// refreshHot 取出当前周期的热点键并逐个重新加载。
+func (lc *LoadingCache[T]) refreshHot() {
+	lc.mu.Lock()
+	keys := lc.hot
+	lc.hot = make(map[interface{}]struct{}, len(keys))
+	lc.mu.Unlock()
+
+	for key := range keys {
+		if nil != lc.ctx.Err() {
+			return
+		}
+		if err := lc.refreshKey(key); nil != err && nil != lc.options.onRefreshError {
+			lc.options.onRefreshError(key, err)
+		}
+	}
+}
+
+// refreshKey 使用后台上下文重新加载单个键。
+//
+// 参数：
+//   - key: 待刷新的缓存键。
+//
+// 返回：
+//   - error: 加载函数返回的错误。
+func (lc *LoadingCache[T]) refreshKey(key interface{}) error {
+	ctx := lc.ctx
+	if lc.options.refreshTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, lc.options.refreshTimeout)
+		defer cancel()
+	}
+	_, err := lc.load(ctx, key)
+	return err
+}
@@ -0,0 +1,243 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingLoader 是记录调用次数的测试加载函数。
+type countingLoader struct {
+	// calls 按键记录调用次数。
+	calls sync.Map
+	// version 是每次加载递增的版本号，写入加载结果。
+	version atomic.Int64
+	// fail 为 true 时加载失败。
+	fail atomic.Bool
+	// delay 是每次加载的耗时。
+	delay time.Duration
+}
+
+// load 实现 LoaderFunc，返回 "<key>@<version>"。
+//
+// 参数：
+//   - ctx: 加载上下文。
+//   - key: 待加载的键。
+//
+// 返回：
+//   - string: 加载结果。
+//   - error: fail 为 true 时返回错误。
+func (l *countingLoader) load(ctx context.Context, key interface{}) (string, error) {
+	counter, _ := l.calls.LoadOrStore(key, new(atomic.Int64))
+	counter.(*atomic.Int64).Add(1)
+	if l.delay > 0 {
+		time.Sleep(l.delay)
+	}
+	if l.fail.Load() {
+		return "", errors.New("load failed")
+	}
+	return fmt.Sprintf("%v@%d", key, l.version.Add(1)), nil
+}
+
+// count 返回键的加载次数。
+//
+// 参数：
+//   - key: 待查询的键。
+//
+// 返回：
+//   - int64: 加载次数。
+func (l *countingLoader) count(key interface{}) int64 {
+	if counter, ok := l.calls.Load(key); ok {
+		return counter.(*atomic.Int64).Load()
+	}
+	return 0
+}
+
+// newLoadingTestCache 创建测试使用的 LoadingCache，测试结束时自动关闭。
+//
+// 参数：
+//   - t: 测试上下文。
+//   - loader: 加载函数。
+//   - interval: 刷新周期。
+//   - options: 追加的配置项。
+//
+// 返回：
+//   - *LoadingCache[string]: 加载缓存实例。
+func newLoadingTestCache(t *testing.T, loader LoaderFunc[string], interval time.Duration, options ...LoadingOption) *LoadingCache[string] {
+	t.Helper()
+
+	c, err := NewCache(WithNumCounters(1000), WithMaxCost(1000))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = c.Close() })
+
+	lc, err := NewLoadingCache[string](c, loader, interval, options...)
+	require.NoError(t, err)
+	t.Cleanup(lc.Close)
+	return lc
+}
+
+// TestNewLoadingCache 验证构造参数校验。
+func TestNewLoadingCache(t *testing.T) {
+	loader := (&countingLoader{}).load
+
+	tests := []struct {
+		name        string
+		description string
+		loader      LoaderFunc[string]
+		interval    time.Duration
+		wantErr     error
+	}{
+		{name: "success", description: "验证提供加载函数和正刷新周期时创建成功。", loader: loader, interval: time.Hour},
+		{name: "error/nil-loader", description: "验证未提供加载函数时返回 ErrNilLoader。", interval: time.Hour, wantErr: ErrNilLoader},
+		{name: "error/zero-interval", description: "验证刷新周期为 0 时返回 ErrInvalidRefreshInterval。", loader: loader, wantErr: ErrInvalidRefreshInterval},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			c, err := NewCache()
+			require.NoError(t, err)
+			defer c.Close() //nolint:errcheck
+
+			lc, err := NewLoadingCache[string](c, tt.loader, tt.interval)
+			if nil != tt.wantErr {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, lc)
+				return
+			}
+			require.NoError(t, err)
+			lc.Close()
+		})
+	}
+}
+
+// TestLoadingCache_Get 验证未命中时加载并写入缓存，并发未命中只加载一次。
+func TestLoadingCache_Get(t *testing.T) {
+	loader := &countingLoader{delay: 20 * time.Millisecond}
+	lc := newLoadingTestCache(t, loader.load, time.Hour)
+
+	var wg sync.WaitGroup
+	results := make([]string, 8)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			value, err := lc.Get(context.Background(), "dict")
+			assert.NoError(t, err)
+			results[i] = value
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(t, int64(1), loader.count("dict"))
+	for _, value := range results {
+		assert.Equal(t, "dict@1", value)
+	}
+
+	// 命中时不再调用加载函数。
+	value, err := lc.Get(context.Background(), "dict")
+	require.NoError(t, err)
+	assert.Equal(t, "dict@1", value)
+	assert.Equal(t, int64(1), loader.count("dict"))
+
+	// 加载失败时返回错误且不写入缓存。
+	loader.fail.Store(true)
+	_, err = lc.Get(context.Background(), "missing")
+	assert.Error(t, err)
+	loader.fail.Store(false)
+	value, err = lc.Get(context.Background(), "missing")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), loader.count("missing"))
+	assert.Equal(t, "missing@2", value)
+}
+
+// TestLoadingCache_RefreshAhead 验证刷新周期内访问过的键在后台刷新，未访问的键不刷新。
+func TestLoadingCache_RefreshAhead(t *testing.T) {
+	loader := &countingLoader{}
+	lc := newLoadingTestCache(t, loader.load, 30*time.Millisecond)
+
+	_, err := lc.Get(context.Background(), "hot")
+	require.NoError(t, err)
+	_, err = lc.Get(context.Background(), "cold")
+	require.NoError(t, err)
+
+	// 持续访问 hot，使其每个周期都被刷新。
+	deadline := time.Now().Add(200 * time.Millisecond)
+	for time.Now().Before(deadline) {
+		_, err = lc.Get(context.Background(), "hot")
+		require.NoError(t, err)
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	assert.GreaterOrEqual(t, loader.count("hot"), int64(3))
+	// cold 只在首个周期被刷新一次。
+	assert.LessOrEqual(t, loader.count("cold"), int64(2))
+}
+
+// TestLoadingCache_RefreshError 验证后台刷新失败时保留旧值并回调错误。
+func TestLoadingCache_RefreshError(t *testing.T) {
+	loader := &countingLoader{}
+	failures := make(chan interface{}, 16)
+	lc := newLoadingTestCache(t, loader.load, 20*time.Millisecond,
+		WithLoadingTTL(time.Hour),
+		WithLoadingRefreshTimeout(time.Second),
+		WithLoadingOnRefreshError(func(key interface{}, err error) {
+			select {
+			case failures <- key:
+			default:
+			}
+		}),
+	)
+
+	value, err := lc.Get(context.Background(), "config")
+	require.NoError(t, err)
+	loader.fail.Store(true)
+
+	select {
+	case key := <-failures:
+		assert.Equal(t, "config", key)
+	case <-time.After(time.Second):
+		require.Fail(t, "未在超时时间内收到刷新失败回调")
+	}
+
+	stale, err := lc.Get(context.Background(), "config")
+	require.NoError(t, err)
+	assert.Equal(t, value, stale)
+	assert.Error(t, lc.Refresh(context.Background(), "config"))
+}
+
+// TestLoadingCache_InvalidateAndClose 验证 Invalidate 删除缓存值，Close 后拒绝读取。
+func TestLoadingCache_InvalidateAndClose(t *testing.T) {
+	loader := &countingLoader{}
+	lc := newLoadingTestCache(t, loader.load, time.Hour)
+
+	_, err := lc.Get(context.Background(), "k")
+	require.NoError(t, err)
+	lc.Invalidate("k")
+	value, err := lc.Get(context.Background(), "k")
+	require.NoError(t, err)
+	assert.Equal(t, "k@2", value)
+
+	require.NoError(t, lc.Refresh(context.Background(), "k"))
+	value, err = lc.Get(context.Background(), "k")
+	require.NoError(t, err)
+	assert.Equal(t, "k@3", value)
+
+	lc.Close()
+	lc.Close()
+	_, err = lc.Get(context.Background(), "k")
+	assert.ErrorIs(t, err, ErrLoadingCacheClosed)
+	assert.ErrorIs(t, lc.Refresh(context.Background(), "k"), ErrLoadingCacheClosed)
+}
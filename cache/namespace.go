@@ -0,0 +1,514 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package cache
+
+import (
+	"container/list"
+	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	// 断言 Namespace 实现 Cache 与 CostSetter 接口。
+	_ Cache      = (*Namespace)(nil)
+	_ CostSetter = (*Namespace)(nil)
+)
+
+type (
+	// AdmissionFunc 定义命名空间的准入策略，在写入共享缓存之前调用。
+	//
+	// 参数：
+	//   - key: 调用方传入的缓存键，不含命名空间前缀。
+	//   - value: 待写入的缓存值。
+	//   - cost: 已归一化的写入成本。
+	//
+	// 返回：
+	//   - bool: 允许写入时为 true；为 false 时写入被拒绝，计入 NamespaceStats.Rejected。
+	AdmissionFunc func(key interface{}, value interface{}, cost int64) bool
+
+	// NamespacePolicy 定义命名空间在共享缓存中的默认 TTL、容量与准入策略。
+	NamespacePolicy struct {
+		// DefaultTTL 是写入未指定 ttl（小于等于 0）时使用的有效期，小于等于 0 表示永不过期。
+		DefaultTTL time.Duration
+
+		// MaxEntries 是命名空间最多持有的缓存项数量，小于等于 0 表示不限制。
+		//
+		// 达到上限后写入新键会先删除本命名空间最久未访问的缓存项，不会驱逐其它命名空间的缓存项。
+		MaxEntries int
+
+		// Admission 是写入前调用的准入策略，为 nil 时全部准入。
+		Admission AdmissionFunc
+	}
+
+	// NamespaceOption 定义修改 NamespacePolicy 的函数式选项。
+	//
+	// 参数：
+	//   - *NamespacePolicy: 待修改的策略，NewNamespace 在应用选项时传入非 nil 指针。
+	NamespaceOption func(*NamespacePolicy)
+
+	// NamespaceStats 描述命名空间的统计信息。
+	NamespaceStats struct {
+		// Entries 是命名空间当前跟踪的缓存项数量，可能包含已被共享缓存驱逐但尚未被访问发现的键。
+		Entries int
+		// MaxEntries 是命名空间的缓存项数量上限，0 表示不限制。
+		MaxEntries int
+		// Rejected 是被准入策略拒绝的写入次数。
+		Rejected uint64
+		// Evicted 是因达到 MaxEntries 而删除的本命名空间缓存项数量。
+		Evicted uint64
+	}
+
+	// Namespace 是共享底层 Cache 的命名空间视图。
+	//
+	// 命名空间为键加上名称前缀，不同命名空间的同名键互不影响；每个命名空间按自己的 NamespacePolicy 设置默认 TTL、
+	// 限制缓存项数量并执行准入策略，使某个模块大量写入时只会淘汰自身的缓存项，而不会挤占其它模块的热点数据。
+	// 共享缓存仍可能因 MaxCost 不足按全局准入与驱逐策略淘汰缓存项，因此各命名空间 MaxEntries 之和应在共享缓存的
+	// 容量之内。
+	//
+	// 命名空间支持的键类型与 Ristretto 相同（string、[]byte、byte、int、int32、int64、uint32、uint64），其它类型会 panic。
+	// Namespace 的方法可以并发调用。零值 Namespace 不可直接使用，调用方应通过 NewNamespace 创建。
+	Namespace struct {
+		// cache 是共享的底层缓存。
+		cache Cache
+		// name 是命名空间名称。
+		name string
+		// policy 是命名空间策略。
+		policy NamespacePolicy
+
+		// mu 保护 entries 与 order。
+		mu sync.Mutex
+		// entries 以带前缀的键索引 order 中的元素。
+		entries map[string]*list.Element
+		// order 按访问顺序保存 *namespaceEntry，最近访问的位于头部。
+		order *list.List
+
+		// rejected 是被准入策略拒绝的写入次数。
+		rejected atomic.Uint64
+		// evicted 是因达到 MaxEntries 而删除的缓存项数量。
+		evicted atomic.Uint64
+		// hits 是读取命中的次数。
+		hits atomic.Uint64
+		// misses 是读取未命中的次数。
+		misses atomic.Uint64
+		// added 是新写入的键数量。
+		added atomic.Uint64
+		// costAdded 是写入成功的缓存项成本之和。
+		costAdded atomic.Uint64
+	}
+
+	// namespaceEntry 是命名空间跟踪的缓存项。
+	namespaceEntry struct {
+		// key 是带前缀的缓存键。
+		key string
+		// expireAt 是过期时间，零值表示永不过期。
+		expireAt time.Time
+	}
+)
+
+// WithNamespaceTTL 设置命名空间的默认 TTL。
+//
+// 参数：
+//   - ttl: 写入未指定 ttl 时使用的有效期，小于等于 0 表示永不过期。
+//
+// 返回：
+//   - NamespaceOption: 应用于 NamespacePolicy.DefaultTTL 的函数式选项。
+func WithNamespaceTTL(ttl time.Duration) NamespaceOption {
+	return func(p *NamespacePolicy) {
+		p.DefaultTTL = ttl
+	}
+}
+
+// WithNamespaceMaxEntries 设置命名空间最多持有的缓存项数量。
+//
+// 参数：
+//   - maxEntries: 缓存项数量上限，小于等于 0 表示不限制。
+//
+// 返回：
+//   - NamespaceOption: 应用于 NamespacePolicy.MaxEntries 的函数式选项。
+func WithNamespaceMaxEntries(maxEntries int) NamespaceOption {
+	return func(p *NamespacePolicy) {
+		p.MaxEntries = maxEntries
+	}
+}
+
+// WithNamespaceAdmission 设置命名空间的准入策略。
+//
+// 参数：
+//   - fn: 准入策略，为 nil 时全部准入。
+//
+// 返回：
+//   - NamespaceOption: 应用于 NamespacePolicy.Admission 的函数式选项。
+func WithNamespaceAdmission(fn AdmissionFunc) NamespaceOption {
+	return func(p *NamespacePolicy) {
+		p.Admission = fn
+	}
+}
+
+// AdmitMaxCost 返回拒绝单条成本超过 maxCost 的写入的准入策略。
+//
+// 配合 WithCostFunc 或 WithEstimatedCost 使用，可以防止某个模块写入超大缓存值。
+//
+// 参数：
+//   - maxCost: 单条写入允许的最大成本。
+//
+// 返回：
+//   - AdmissionFunc: 成本不超过 maxCost 时准入的策略。
+func AdmitMaxCost(maxCost int64) AdmissionFunc {
+	return func(_ interface{}, _ interface{}, cost int64) bool {
+		return cost <= maxCost
+	}
+}
+
+// NewNamespace 在共享缓存上创建命名空间视图。
+//
+// 命名空间不复制数据，也不改变底层缓存的关闭责任；同一个共享缓存上的命名空间名称应互不相同。
+//
+// 参数：
+//   - cache: 共享的底层缓存，调用方应保证其非 nil。
+//   - name: 命名空间名称，用作键前缀。
+//   - options: 可选配置项，例如 WithNamespaceTTL、WithNamespaceMaxEntries 与 WithNamespaceAdmission。
+//
+// 返回：
+//   - *Namespace: 命名空间视图。
+func NewNamespace(cache Cache, name string, options ...NamespaceOption) *Namespace {
+	ns := &Namespace{
+		cache:   cache,
+		name:    name,
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+	}
+	for _, option := range options {
+		option(&ns.policy)
+	}
+	return ns
+}
+
+// Name 返回命名空间名称。
+//
+// 参数：无。
+//
+// 返回：
+//   - string: 命名空间名称。
+func (ns *Namespace) Name() string {
+	return ns.name
+}
+
+// Policy 返回命名空间策略。
+//
+// 参数：无。
+//
+// 返回：
+//   - NamespacePolicy: 创建时应用选项后的策略。
+func (ns *Namespace) Policy() NamespacePolicy {
+	return ns.policy
+}
+
+// Get 获取命名空间内 key 对应的缓存值。
+//
+// 参数：
+//   - key: 待查询的缓存键。
+//
+// 返回：
+//   - value: 命中且未过期时返回缓存值；未命中或已过期时返回 nil。
+//   - exists: key 存在且未过期时为 true。
+func (ns *Namespace) Get(key interface{}) (interface{}, bool) {
+	nk := ns.key(key)
+	value, ok := ns.cache.Get(nk)
+	ns.touch(nk, ok)
+	return value, ok
+}
+
+// GetWithTTL 获取命名空间内 key 对应的缓存值及剩余过期时间。
+//
+// 参数：
+//   - key: 待查询的缓存键。
+//
+// 返回：
+//   - value: 命中且未过期时返回缓存值；未命中或已过期时返回 nil。
+//   - exists: key 存在且未过期时为 true。
+//   - remainingTTL: 剩余过期时间，0 表示 key 不存在或已过期，-1 表示永不过期，正值表示实际剩余时间。
+func (ns *Namespace) GetWithTTL(key interface{}) (interface{}, bool, time.Duration) {
+	nk := ns.key(key)
+	value, ok, ttl := ns.cache.GetWithTTL(nk)
+	ns.touch(nk, ok)
+	return value, ok, ttl
+}
+
+// Set 按命名空间的默认 TTL 写入缓存值。
+//
+// 参数：
+//   - key: 待写入的缓存键。
+//   - value: 待缓存的值。
+//
+// 返回：
+//   - bool: 准入策略允许且底层缓存接受该写入时返回 true。
+func (ns *Namespace) Set(key interface{}, value interface{}) bool {
+	return ns.SetWithCost(key, value, 1, 0)
+}
+
+// SetWithTTL 写入带过期时间的缓存值。
+//
+// 参数：
+//   - key: 待写入的缓存键。
+//   - value: 待缓存的值。
+//   - ttl: 缓存有效期；小于等于 0 时使用命名空间的默认 TTL。
+//
+// 返回：
+//   - bool: 准入策略允许且底层缓存接受该写入时返回 true。
+func (ns *Namespace) SetWithTTL(key interface{}, value interface{}, ttl time.Duration) bool {
+	return ns.SetWithCost(key, value, 1, ttl)
+}
+
+// SetWithCost 实现 CostSetter 接口，写入带成本和过期时间的缓存值。
+//
+// 底层缓存未实现 CostSetter 时忽略成本。
+//
+// 参数：
+//   - key: 待写入的缓存键。
+//   - value: 待缓存的值。
+//   - cost: 缓存项成本；小于等于 0 时按 1 处理。
+//   - ttl: 缓存有效期；小于等于 0 时使用命名空间的默认 TTL。
+//
+// 返回：
+//   - bool: 准入策略允许且底层缓存接受该写入时返回 true。
+func (ns *Namespace) SetWithCost(key interface{}, value interface{}, cost int64, ttl time.Duration) bool {
+	nk := ns.key(key)
+	cost = normalizeCost(cost)
+	if ttl <= 0 {
+		ttl = ns.policy.DefaultTTL
+	}
+	if nil != ns.policy.Admission && !ns.policy.Admission(key, value, cost) {
+		ns.rejected.Add(1)
+		return false
+	}
+
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+
+	now := time.Now()
+	if _, ok := ns.entries[nk]; !ok && ns.policy.MaxEntries > 0 {
+		for len(ns.entries) >= ns.policy.MaxEntries {
+			ns.evictOldestLocked(now)
+		}
+	}
+
+	var ok bool
+	if cs, isCostSetter := ns.cache.(CostSetter); isCostSetter {
+		ok = cs.SetWithCost(nk, value, cost, ttl)
+	} else if ttl > 0 {
+		ok = ns.cache.SetWithTTL(nk, value, ttl)
+	} else {
+		ok = ns.cache.Set(nk, value)
+	}
+	if !ok {
+		ns.removeLocked(nk)
+		return false
+	}
+
+	var expireAt time.Time
+	if ttl > 0 {
+		expireAt = now.Add(ttl)
+	}
+	ns.costAdded.Add(uint64(cost))
+	if elem, exists := ns.entries[nk]; exists {
+		elem.Value.(*namespaceEntry).expireAt = expireAt
+		ns.order.MoveToFront(elem)
+	} else {
+		ns.added.Add(1)
+		ns.entries[nk] = ns.order.PushFront(&namespaceEntry{key: nk, expireAt: expireAt})
+	}
+	return true
+}
+
+// Delete 删除命名空间内 key 对应的缓存项。
+//
+// 参数：
+//   - key: 待删除的缓存键；key 不存在时该操作无效果。
+func (ns *Namespace) Delete(key interface{}) {
+	nk := ns.key(key)
+
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+
+	ns.cache.Delete(nk)
+	ns.removeLocked(nk)
+}
+
+// Clear 删除命名空间跟踪的所有缓存项，不影响共享缓存中其它命名空间的缓存项。
+//
+// 参数：无。
+func (ns *Namespace) Clear() {
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+
+	for nk := range ns.entries {
+		ns.cache.Delete(nk)
+	}
+	ns.entries = make(map[string]*list.Element)
+	ns.order.Init()
+}
+
+// Close 实现 Cache 接口；命名空间不拥有共享缓存，Close 不做任何操作。
+//
+// 共享缓存由创建者负责关闭。
+//
+// 参数：无。
+//
+// 返回：
+//   - error: 始终为 nil。
+func (ns *Namespace) Close() error {
+	return nil
+}
+
+// Stats 实现 Cache 接口，返回本命名空间的累计读写统计。
+//
+// Evictions 与 Rejections 分别对应达到 MaxEntries 的淘汰与准入策略的拒绝；共享缓存自身的驱逐不计入，
+// 其 CostEvicted 始终为 0。与 Ristretto 实现不同，Clear 不会重置命名空间的统计。
+//
+// 参数：无。
+//
+// 返回：
+//   - CacheStats: 命名空间的累计统计。
+func (ns *Namespace) Stats() CacheStats {
+	return CacheStats{
+		Hits:       ns.hits.Load(),
+		Misses:     ns.misses.Load(),
+		KeysAdded:  ns.added.Load(),
+		Evictions:  ns.evicted.Load(),
+		Rejections: ns.rejected.Load(),
+		CostAdded:  ns.costAdded.Load(),
+	}
+}
+
+// NamespaceStats 返回命名空间的容量与策略统计信息。
+//
+// 统计前会移除已过期的跟踪记录。
+//
+// 参数：无。
+//
+// 返回：
+//   - NamespaceStats: 命名空间的统计信息。
+func (ns *Namespace) NamespaceStats() NamespaceStats {
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+
+	now := time.Now()
+	for elem := ns.order.Back(); nil != elem; {
+		prev := elem.Prev()
+		if e := elem.Value.(*namespaceEntry); e.expired(now) {
+			ns.removeLocked(e.key)
+		}
+		elem = prev
+	}
+
+	maxEntries := ns.policy.MaxEntries
+	if maxEntries < 0 {
+		maxEntries = 0
+	}
+	return NamespaceStats{
+		Entries:    len(ns.entries),
+		MaxEntries: maxEntries,
+		Rejected:   ns.rejected.Load(),
+		Evicted:    ns.evicted.Load(),
+	}
+}
+
+// touch 在读取后记录命中统计并维护访问顺序，未命中的键不再跟踪。
+//
+// 参数：
+//   - nk: 带前缀的缓存键。
+//   - hit: 读取是否命中。
+func (ns *Namespace) touch(nk string, hit bool) {
+	if hit {
+		ns.hits.Add(1)
+	} else {
+		ns.misses.Add(1)
+	}
+
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+
+	if hit {
+		if elem, ok := ns.entries[nk]; ok {
+			ns.order.MoveToFront(elem)
+		}
+		return
+	}
+	ns.removeLocked(nk)
+}
+
+// evictOldestLocked 删除最久未访问的缓存项，调用方需持有 mu 且 order 非空。
+//
+// 已过期的缓存项直接移除，不计入 NamespaceStats.Evicted。
+//
+// 参数：
+//   - now: 当前时间。
+func (ns *Namespace) evictOldestLocked(now time.Time) {
+	e := ns.order.Back().Value.(*namespaceEntry)
+	ns.cache.Delete(e.key)
+	ns.removeLocked(e.key)
+	if !e.expired(now) {
+		ns.evicted.Add(1)
+	}
+}
+
+// removeLocked 停止跟踪带前缀的缓存键，调用方需持有 mu。
+//
+// 参数：
+//   - nk: 带前缀的缓存键。
+func (ns *Namespace) removeLocked(nk string) {
+	if elem, ok := ns.entries[nk]; ok {
+		ns.order.Remove(elem)
+		delete(ns.entries, nk)
+	}
+}
+
+// key 返回带命名空间前缀的缓存键。
+//
+// 前缀之后附加类型标记，使不同类型的同值键（例如 1 与 "1"）互不冲突。
+//
+// 参数：
+//   - key: 调用方传入的缓存键。
+//
+// 返回：
+//   - string: 带前缀的缓存键。
+func (ns *Namespace) key(key interface{}) string {
+	prefix := ns.name + "\x00"
+	switch k := key.(type) {
+	case string:
+		return prefix + "s" + k
+	case []byte:
+		return prefix + "b" + string(k)
+	case byte:
+		return prefix + "u" + strconv.FormatUint(uint64(k), 10)
+	case int:
+		return prefix + "i" + strconv.FormatInt(int64(k), 10)
+	case int32:
+		return prefix + "i" + strconv.FormatInt(int64(k), 10)
+	case int64:
+		return prefix + "i" + strconv.FormatInt(k, 10)
+	case uint32:
+		return prefix + "u" + strconv.FormatUint(uint64(k), 10)
+	case uint64:
+		return prefix + "u" + strconv.FormatUint(k, 10)
+	default:
+		// 与 Ristretto 对不支持的键类型的处理保持一致。
+		panic(fmt.Sprintf("cache: namespace key type %T not supported", key))
+	}
+}
+
+// expired 判断缓存项在指定时刻是否已过期。
+//
+// 参数：
+//   - now: 当前时间。
+//
+// 返回：
+//   - bool: 设置了过期时间且已到期时为 true。
+func (e *namespaceEntry) expired(now time.Time) bool {
+	return !e.expireAt.IsZero() && !now.Before(e.expireAt)
+}
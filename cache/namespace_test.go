@@ -0,0 +1,189 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package cache
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newNamespaceTestCache 创建命名空间测试使用的共享缓存。
+//
+// 参数：
+//   - t: 测试上下文。
+//
+// 返回：
+//   - Cache: 缓存实例，测试结束时自动关闭。
+func newNamespaceTestCache(t *testing.T) Cache {
+	t.Helper()
+
+	c, err := NewCache(WithNumCounters(1000), WithMaxCost(1000), WithIgnoreInternalCost(true))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = c.Close() })
+	return c
+}
+
+// TestNamespace_Isolation 验证不同命名空间的同名键互不影响。
+func TestNamespace_Isolation(t *testing.T) {
+	shared := newNamespaceTestCache(t)
+	users := NewNamespace(shared, "users")
+	orders := NewNamespace(shared, "orders")
+
+	require.True(t, users.Set("1", "alice"))
+	require.True(t, orders.Set("1", "order-1"))
+	require.True(t, users.Set(1, "int-key"))
+
+	value, ok := users.Get("1")
+	require.True(t, ok)
+	assert.Equal(t, "alice", value)
+	value, ok = users.Get(1)
+	require.True(t, ok)
+	assert.Equal(t, "int-key", value)
+	value, ok = orders.Get("1")
+	require.True(t, ok)
+	assert.Equal(t, "order-1", value)
+	_, ok = shared.Get("1")
+	assert.False(t, ok)
+
+	users.Clear()
+	_, ok = users.Get("1")
+	assert.False(t, ok)
+	_, ok = orders.Get("1")
+	assert.True(t, ok)
+
+	orders.Delete("1")
+	_, ok = orders.Get("1")
+	assert.False(t, ok)
+	assert.NoError(t, orders.Close())
+	assert.Panics(t, func() { orders.Get(1.5) })
+}
+
+// TestNamespace_Policy 验证命名空间的默认 TTL、容量上限与准入策略。
+func TestNamespace_Policy(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		run         func(t *testing.T, shared Cache)
+	}{
+		{
+			name:        "success/default-ttl",
+			description: "未指定 ttl 的写入使用命名空间默认 TTL，显式 ttl 优先。",
+			run: func(t *testing.T, shared Cache) {
+				ns := NewNamespace(shared, "session", WithNamespaceTTL(time.Minute))
+				require.True(t, ns.Set("a", 1))
+				require.True(t, ns.SetWithTTL("b", 2, time.Hour))
+
+				_, ok, ttl := ns.GetWithTTL("a")
+				require.True(t, ok)
+				assert.InDelta(t, time.Minute, ttl, float64(5*time.Second))
+				_, ok, ttl = ns.GetWithTTL("b")
+				require.True(t, ok)
+				assert.InDelta(t, time.Hour, ttl, float64(5*time.Second))
+
+				plain := NewNamespace(shared, "plain")
+				require.True(t, plain.Set("a", 1))
+				_, ok, ttl = plain.GetWithTTL("a")
+				require.True(t, ok)
+				assert.Equal(t, time.Duration(-1), ttl)
+			},
+		},
+		{
+			name:        "success/max-entries",
+			description: "达到上限后只淘汰本命名空间最久未访问的缓存项，其它命名空间不受影响。",
+			run: func(t *testing.T, shared Cache) {
+				hot := NewNamespace(shared, "hot")
+				require.True(t, hot.Set("k", "hot"))
+				noisy := NewNamespace(shared, "noisy", WithNamespaceMaxEntries(3))
+
+				for i := 0; i < 3; i++ {
+					require.True(t, noisy.Set(i, i))
+				}
+				// 访问 0 使其成为最近访问的键，随后写入淘汰 1 与 2。
+				_, ok := noisy.Get(0)
+				require.True(t, ok)
+				for i := 3; i < 5; i++ {
+					require.True(t, noisy.Set(i, i))
+				}
+
+				for i, want := range []bool{true, false, false, true, true} {
+					_, ok := noisy.Get(i)
+					assert.Equal(t, want, ok, "key %d", i)
+				}
+				value, ok := hot.Get("k")
+				require.True(t, ok)
+				assert.Equal(t, "hot", value)
+
+				// 覆盖已有键不触发淘汰。
+				require.True(t, noisy.Set(4, "v2"))
+				assert.Equal(t, NamespaceStats{Entries: 3, MaxEntries: 3, Evicted: 2}, noisy.NamespaceStats())
+			},
+		},
+		{
+			name:        "success/expired-first",
+			description: "已过期的缓存项被淘汰时不计入 Evicted，统计时移除过期记录。",
+			run: func(t *testing.T, shared Cache) {
+				ns := NewNamespace(shared, "short", WithNamespaceMaxEntries(1), WithNamespaceTTL(10*time.Millisecond))
+				require.True(t, ns.Set("a", 1))
+				time.Sleep(20 * time.Millisecond)
+				require.True(t, ns.Set("b", 2))
+				assert.Equal(t, uint64(0), ns.NamespaceStats().Evicted)
+
+				time.Sleep(20 * time.Millisecond)
+				assert.Equal(t, 0, ns.NamespaceStats().Entries)
+			},
+		},
+		{
+			name:        "error/admission",
+			description: "准入策略拒绝的写入不进入共享缓存，并计入 Rejected。",
+			run: func(t *testing.T, shared Cache) {
+				ns := NewNamespace(shared, "blobs", WithNamespaceAdmission(AdmitMaxCost(10)))
+				typed := AsTypedCache(Cache(ns), WithCostFunc(func(v string) int64 { return int64(len(v)) }))
+
+				assert.True(t, typed.Set("small", "ok"))
+				assert.False(t, typed.Set("large", "this value is too large"))
+				_, ok := typed.Get("large")
+				assert.False(t, ok)
+				value, ok := typed.Get("small")
+				require.True(t, ok)
+				assert.Equal(t, "ok", value)
+				assert.Equal(t, uint64(1), ns.NamespaceStats().Rejected)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+			tt.run(t, newNamespaceTestCache(t))
+		})
+	}
+}
+
+// TestNamespace_Concurrent 验证并发写入时命名空间的缓存项数量不超过上限。
+func TestNamespace_Concurrent(t *testing.T) {
+	ns := NewNamespace(newNamespaceTestCache(t), "concurrent", WithNamespaceMaxEntries(10))
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Go(func() {
+			for i := 0; i < 100; i++ {
+				key := fmt.Sprintf("%d-%d", g, i)
+				ns.Set(key, i)
+				ns.Get(key)
+			}
+		})
+	}
+	wg.Wait()
+
+	stats := ns.NamespaceStats()
+	assert.LessOrEqual(t, stats.Entries, 10)
+	assert.Equal(t, "concurrent", ns.Name())
+	assert.Equal(t, 10, ns.Policy().MaxEntries)
+}
@@ -0,0 +1,436 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package cache
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/dgraph-io/ristretto/z"
+)
+
+var (
+	// ErrPinLimitExceeded 表示固定的缓存键数量已达到 CacheOptions.MaxPinned 上限。
+	ErrPinLimitExceeded = errors.New("cache: pinned key limit exceeded")
+
+	// ErrPinUnsupported 表示底层 Cache 未实现 Pinner 接口。
+	ErrPinUnsupported = errors.New("cache: pin not supported")
+
+	// 断言 ristrettoCache 实现 Pinner 接口。
+	_ Pinner = (*ristrettoCache)(nil)
+)
+
+type (
+	// Pinner 定义支持固定缓存项的缓存扩展接口。
+	//
+	// 固定的缓存键不参与准入与驱逐：其值保存在容量之外，写入不会被准入策略拒绝，也不会因 MaxCost 不足被驱逐，
+	// 适用于签名密钥等必须常驻内存的热点数据。固定状态属于键而不是值，Delete 与 Clear 只移除值，键仍保持固定；
+	// TTL 对固定的缓存项仍然生效。
+	Pinner interface {
+		// Pin 固定缓存键。
+		//
+		// 键已存在值时，该值连同剩余 TTL 一起转入固定集合；之后对该键的写入都直接进入固定集合。
+		// 重复固定同一个键不会重复计数。
+		//
+		// 参数：
+		//   - key: 待固定的缓存键。
+		//
+		// 返回：
+		//   - error: 固定键数量已达上限时返回 ErrPinLimitExceeded。
+		Pin(key interface{}) error
+
+		// Unpin 取消固定缓存键。
+		//
+		// 键持有未过期的值时，该值按剩余 TTL 写回普通缓存，此后重新受准入策略与驱逐约束，可能被立即拒绝。
+		//
+		// 参数：
+		//   - key: 待取消固定的缓存键。
+		//
+		// 返回：
+		//   - bool: key 此前处于固定状态时为 true。
+		Unpin(key interface{}) bool
+
+		// Pinned 判断缓存键是否处于固定状态。
+		//
+		// 参数：
+		//   - key: 待判断的缓存键。
+		//
+		// 返回：
+		//   - bool: key 处于固定状态时为 true。
+		Pinned(key interface{}) bool
+
+		// PinStats 返回固定集合的统计信息。
+		//
+		// 参数：无。
+		//
+		// 返回：
+		//   - PinStats: 固定键数量、持有值的数量及其估算内存占用。
+		PinStats() PinStats
+	}
+
+	// PinStats 描述固定集合的统计信息。
+	PinStats struct {
+		// Keys 是处于固定状态的键数量。
+		Keys int
+		// Values 是持有未过期值的固定键数量。
+		Values int
+		// Bytes 是固定值按 EstimateSize 估算的内存占用字节数之和。
+		Bytes int64
+		// MaxKeys 是固定键数量上限。
+		MaxKeys int
+	}
+
+	// pinSet 保存固定的缓存项。
+	pinSet struct {
+		// mu 保护 entries 与 bytes；普通写入持有读锁，使 Pin 与写入互斥。
+		mu sync.RWMutex
+		// entries 以 Ristretto 的键哈希为索引保存固定的缓存项。
+		entries map[[2]uint64]*pinnedEntry
+		// bytes 是所有固定值的估算内存占用。
+		bytes int64
+		// max 是固定键数量上限。
+		max int
+		// count 是固定键数量，用于在没有固定键时跳过加锁。
+		count atomic.Int64
+	}
+
+	// pinnedEntry 是一个固定的缓存键及其值。
+	pinnedEntry struct {
+		// value 是缓存值，has 为 false 时无意义。
+		value interface{}
+		// has 表示当前是否持有值。
+		has bool
+		// cost 是写入时的成本，取消固定写回普通缓存时使用。
+		cost int64
+		// expireAt 是过期时间，零值表示永不过期。
+		expireAt time.Time
+		// size 是值的估算内存占用。
+		size int64
+	}
+)
+
+// WithMaxPinned 设置可同时固定的缓存键数量上限。
+//
+// 参数：
+//   - maxPinned: 固定键数量上限，小于等于 0 时禁止固定；默认值为 1024。
+//
+// 返回：
+//   - Option: 应用于 CacheOptions.MaxPinned 的函数式选项。
+func WithMaxPinned(maxPinned int) Option {
+	return func(opts *CacheOptions) {
+		opts.MaxPinned = maxPinned
+	}
+}
+
+// Pin 固定 key 对应的缓存项。
+//
+// 底层 Cache 未实现 Pinner 时返回 ErrPinUnsupported。
+//
+// 参数：
+//   - key: 待固定的缓存键，具体可接受类型由底层 Cache 决定。
+//
+// 返回：
+//   - error: 固定键数量已达上限或底层 Cache 不支持固定时返回错误。
+func (tc *TypedCache[T]) Pin(key interface{}) error {
+	if p, ok := tc.cache.(Pinner); ok {
+		return p.Pin(key)
+	}
+	return ErrPinUnsupported
+}
+
+// Unpin 取消固定 key 对应的缓存项。
+//
+// 参数：
+//   - key: 待取消固定的缓存键，具体可接受类型由底层 Cache 决定。
+//
+// 返回：
+//   - bool: key 此前处于固定状态时为 true；底层 Cache 不支持固定时始终为 false。
+func (tc *TypedCache[T]) Unpin(key interface{}) bool {
+	if p, ok := tc.cache.(Pinner); ok {
+		return p.Unpin(key)
+	}
+	return false
+}
+
+// Pin 实现 Pinner 接口的缓存键固定方法。
+//
+// 参数：
+//   - key: 待固定的缓存键，具体可接受类型遵循 Ristretto 的键约束。
+//
+// 返回：
+//   - error: 固定键数量已达 CacheOptions.MaxPinned 上限时返回 ErrPinLimitExceeded。
+func (c *ristrettoCache) Pin(key interface{}) error {
+	h := pinKey(key)
+
+	c.pins.mu.Lock()
+	defer c.pins.mu.Unlock()
+
+	if _, ok := c.pins.entries[h]; ok {
+		return nil
+	}
+	if len(c.pins.entries) >= c.pins.max {
+		return ErrPinLimitExceeded
+	}
+
+	e := &pinnedEntry{}
+	// 持有写锁期间普通写入被阻塞，转移过程中不会丢失并发写入的值。
+	if value, ok := c.cache.Get(key); ok {
+		if ttl, ok := c.cache.GetTTL(key); ok {
+			// Ristretto 不提供查询成本的接口，转入的值按成本 1 记录。
+			c.pins.store(e, value, 1, ttl)
+		}
+		c.cache.Del(key)
+	}
+	c.pins.entries[h] = e
+	c.pins.count.Add(1)
+	return nil
+}
+
+// Unpin 实现 Pinner 接口的缓存键取消固定方法。
+//
+// 参数：
+//   - key: 待取消固定的缓存键，具体可接受类型遵循 Ristretto 的键约束。
+//
+// 返回：
+//   - bool: key 此前处于固定状态时为 true。
+func (c *ristrettoCache) Unpin(key interface{}) bool {
+	h := pinKey(key)
+
+	c.pins.mu.Lock()
+	defer c.pins.mu.Unlock()
+
+	e, ok := c.pins.entries[h]
+	if !ok {
+		return false
+	}
+	delete(c.pins.entries, h)
+	c.pins.count.Add(-1)
+	c.pins.bytes -= e.size
+
+	if value, ok, ttl := e.get(time.Now()); ok {
+		if ttl < 0 {
+			ttl = 0
+		}
+		c.setRistretto(key, value, e.cost, ttl)
+	}
+	return true
+}
+
+// Pinned 实现 Pinner 接口的缓存键固定状态查询方法。
+//
+// 参数：
+//   - key: 待判断的缓存键，具体可接受类型遵循 Ristretto 的键约束。
+//
+// 返回：
+//   - bool: key 处于固定状态时为 true。
+func (c *ristrettoCache) Pinned(key interface{}) bool {
+	if 0 == c.pins.count.Load() {
+		return false
+	}
+
+	c.pins.mu.RLock()
+	defer c.pins.mu.RUnlock()
+
+	return c.pins.pinnedLocked(key)
+}
+
+// PinStats 实现 Pinner 接口的固定集合统计方法。
+//
+// 统计前会移除已过期的固定值，使 Bytes 只反映仍然有效的值。
+//
+// 参数：无。
+//
+// 返回：
+//   - PinStats: 固定集合的统计信息。
+func (c *ristrettoCache) PinStats() PinStats {
+	c.pins.mu.Lock()
+	defer c.pins.mu.Unlock()
+
+	now := time.Now()
+	stats := PinStats{
+		Keys:    len(c.pins.entries),
+		MaxKeys: c.pins.max,
+	}
+	for _, e := range c.pins.entries {
+		if !e.has {
+			continue
+		}
+		if _, ok, _ := e.get(now); !ok {
+			c.pins.bytes -= e.size
+			*e = pinnedEntry{}
+			continue
+		}
+		stats.Values++
+	}
+	stats.Bytes = c.pins.bytes
+	return stats
+}
+
+// newPinSet 创建固定集合。
+//
+// 参数：
+//   - max: 固定键数量上限。
+//
+// 返回：
+//   - *pinSet: 空的固定集合。
+func newPinSet(max int) *pinSet {
+	return &pinSet{
+		entries: make(map[[2]uint64]*pinnedEntry),
+		max:     max,
+	}
+}
+
+// get 读取固定的缓存项。
+//
+// 参数：
+//   - key: 缓存键。
+//
+// 返回：
+//   - value: 持有未过期的值时返回该值。
+//   - exists: 持有未过期的值时为 true。
+//   - ttl: 剩余过期时间，-1 表示永不过期。
+//   - pinned: key 处于固定状态时为 true，此时调用方不应再查询普通缓存。
+func (s *pinSet) get(key interface{}) (value interface{}, exists bool, ttl time.Duration, pinned bool) {
+	if 0 == s.count.Load() {
+		return nil, false, 0, false
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	e, ok := s.entries[pinKey(key)]
+	if !ok {
+		return nil, false, 0, false
+	}
+	value, exists, ttl = e.get(time.Now())
+	return value, exists, ttl, true
+}
+
+// pinnedLocked 判断 key 是否处于固定状态，调用方需持有读锁或写锁。
+//
+// 参数：
+//   - key: 缓存键。
+//
+// 返回：
+//   - bool: key 处于固定状态时为 true。
+func (s *pinSet) pinnedLocked(key interface{}) bool {
+	if 0 == len(s.entries) {
+		return false
+	}
+	_, ok := s.entries[pinKey(key)]
+	return ok
+}
+
+// set 在 key 处于固定状态时写入固定集合。
+//
+// 参数：
+//   - key: 缓存键。
+//   - value: 缓存值。
+//   - cost: 已归一化的成本。
+//   - ttl: 缓存有效期，小于等于 0 表示永不过期。
+//
+// 返回：
+//   - bool: key 处于固定状态并已写入时为 true。
+func (s *pinSet) set(key interface{}, value interface{}, cost int64, ttl time.Duration) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.entries[pinKey(key)]
+	if !ok {
+		return false
+	}
+	s.store(e, value, cost, ttl)
+	return true
+}
+
+// remove 移除固定键持有的值，键保持固定状态。
+//
+// 参数：
+//   - key: 缓存键。
+func (s *pinSet) remove(key interface{}) {
+	if 0 == s.count.Load() {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e, ok := s.entries[pinKey(key)]; ok {
+		s.bytes -= e.size
+		*e = pinnedEntry{}
+	}
+}
+
+// clear 移除所有固定键持有的值，键保持固定状态。
+//
+// 参数：无。
+func (s *pinSet) clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, e := range s.entries {
+		*e = pinnedEntry{}
+	}
+	s.bytes = 0
+}
+
+// store 更新固定缓存项的值并维护内存统计，调用方需持有写锁。
+//
+// 参数：
+//   - e: 固定缓存项。
+//   - value: 缓存值。
+//   - cost: 已归一化的成本。
+//   - ttl: 缓存有效期，小于等于 0 表示永不过期。
+func (s *pinSet) store(e *pinnedEntry, value interface{}, cost int64, ttl time.Duration) {
+	size := EstimateSize(value)
+	s.bytes += size - e.size
+
+	*e = pinnedEntry{
+		value: value,
+		has:   true,
+		cost:  cost,
+		size:  size,
+	}
+	if ttl > 0 {
+		e.expireAt = time.Now().Add(ttl)
+	}
+}
+
+// get 返回固定缓存项在指定时刻的值。
+//
+// 参数：
+//   - now: 当前时间。
+//
+// 返回：
+//   - interface{}: 持有未过期的值时返回该值，否则返回 nil。
+//   - bool: 持有未过期的值时为 true。
+//   - time.Duration: 剩余过期时间，-1 表示永不过期，不存在或已过期时为 0。
+func (e *pinnedEntry) get(now time.Time) (interface{}, bool, time.Duration) {
+	if !e.has {
+		return nil, false, 0
+	}
+	if e.expireAt.IsZero() {
+		return e.value, true, -1
+	}
+	ttl := e.expireAt.Sub(now)
+	if ttl <= 0 {
+		return nil, false, 0
+	}
+	return e.value, true, ttl
+}
+
+// pinKey 使用与 Ristretto 相同的哈希算法计算固定集合的索引。
+//
+// 参数：
+//   - key: 缓存键，不支持的类型会与 Ristretto 一样 panic。
+//
+// 返回：
+//   - [2]uint64: 键哈希与冲突校验哈希。
+func pinKey(key interface{}) [2]uint64 {
+	h, conflict := z.KeyToHash(key)
+	return [2]uint64{h, conflict}
+}
@@ -0,0 +1,322 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package cache
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newPinTestCache 创建容量很小的缓存，便于触发驱逐。
+//
+// 参数：
+//   - t: 测试上下文。
+//   - options: 追加的配置项。
+//
+// 返回：
+//   - *ristrettoCache: 缓存实例，测试结束时自动关闭。
+func newPinTestCache(t *testing.T, options ...Option) *ristrettoCache {
+	t.Helper()
+
+	c, err := NewCache(append([]Option{
+		WithNumCounters(1000),
+		WithMaxCost(10),
+		WithIgnoreInternalCost(true),
+	}, options...)...)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = c.Close() })
+	return c.(*ristrettoCache)
+}
+
+// TestRistrettoCache_PinSurvivesEviction 验证固定的缓存项不会因容量不足被驱逐或拒绝。
+func TestRistrettoCache_PinSurvivesEviction(t *testing.T) {
+	c := newPinTestCache(t)
+
+	require.True(t, c.SetWithCost("signing-key", "secret", 5, 0))
+	require.NoError(t, c.Pin("signing-key"))
+	// 成本超过 MaxCost 的写入在固定后仍然保留。
+	require.True(t, c.SetWithCost("jwks", "keys", 100, 0))
+	require.NoError(t, c.Pin("jwks"))
+	assert.True(t, c.SetWithCost("jwks", "keys-v2", 100, 0))
+
+	for i := 0; i < 200; i++ {
+		c.SetWithCost(fmt.Sprintf("k%d", i), i, 10, 0)
+		c.Get(fmt.Sprintf("k%d", i))
+	}
+
+	value, ok := c.Get("signing-key")
+	require.True(t, ok)
+	assert.Equal(t, "secret", value)
+	value, ok = c.Get("jwks")
+	require.True(t, ok)
+	assert.Equal(t, "keys-v2", value)
+}
+
+// TestRistrettoCache_Pin 验证固定状态在各类操作下的表现。
+func TestRistrettoCache_Pin(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		run         func(t *testing.T, c *ristrettoCache)
+	}{
+		{
+			name:        "success/pin-before-set",
+			description: "先固定再写入时，写入直接进入固定集合。",
+			run: func(t *testing.T, c *ristrettoCache) {
+				require.NoError(t, c.Pin("k"))
+				assert.True(t, c.Pinned("k"))
+				_, ok := c.Get("k")
+				assert.False(t, ok)
+
+				c.Set("k", 1)
+				value, ok, ttl := c.GetWithTTL("k")
+				require.True(t, ok)
+				assert.Equal(t, 1, value)
+				assert.Equal(t, time.Duration(-1), ttl)
+			},
+		},
+		{
+			name:        "success/pin-keeps-ttl",
+			description: "转入固定集合的值保留剩余 TTL，过期后按未命中处理。",
+			run: func(t *testing.T, c *ristrettoCache) {
+				c.SetWithTTL("k", "v", time.Hour)
+				require.NoError(t, c.Pin("k"))
+				_, ok, ttl := c.GetWithTTL("k")
+				require.True(t, ok)
+				assert.InDelta(t, time.Hour, ttl, float64(time.Minute))
+
+				c.SetWithTTL("k", "v", 20*time.Millisecond)
+				time.Sleep(40 * time.Millisecond)
+				_, ok = c.Get("k")
+				assert.False(t, ok)
+				assert.Equal(t, PinStats{Keys: 1, MaxKeys: maxPinned}, c.PinStats())
+			},
+		},
+		{
+			name:        "success/delete-and-clear-keep-pin",
+			description: "Delete 与 Clear 只移除值，键仍处于固定状态。",
+			run: func(t *testing.T, c *ristrettoCache) {
+				require.NoError(t, c.Pin("k"))
+				c.Set("k", "v")
+				c.Delete("k")
+				_, ok := c.Get("k")
+				assert.False(t, ok)
+				assert.True(t, c.Pinned("k"))
+
+				c.Set("k", "v")
+				c.Clear()
+				_, ok = c.Get("k")
+				assert.False(t, ok)
+				assert.True(t, c.Pinned("k"))
+				assert.Equal(t, int64(0), c.PinStats().Bytes)
+			},
+		},
+		{
+			name:        "success/unpin-writes-back",
+			description: "取消固定后值写回普通缓存。",
+			run: func(t *testing.T, c *ristrettoCache) {
+				require.NoError(t, c.Pin("k"))
+				c.SetWithCost("k", "v", 2, 0)
+				assert.True(t, c.Unpin("k"))
+				assert.False(t, c.Pinned("k"))
+				assert.False(t, c.Unpin("k"))
+
+				value, ok := c.Get("k")
+				require.True(t, ok)
+				assert.Equal(t, "v", value)
+			},
+		},
+		{
+			name:        "boundary/repeat-pin",
+			description: "重复固定同一个键不会重复计数。",
+			run: func(t *testing.T, c *ristrettoCache) {
+				require.NoError(t, c.Pin("k"))
+				require.NoError(t, c.Pin("k"))
+				assert.Equal(t, 1, c.PinStats().Keys)
+			},
+		},
+		{
+			name:        "boundary/unpinned-key",
+			description: "未固定的键走普通缓存，Unpin 返回 false。",
+			run: func(t *testing.T, c *ristrettoCache) {
+				c.Set("k", "v")
+				assert.False(t, c.Pinned("k"))
+				assert.False(t, c.Unpin("k"))
+				value, ok := c.Get("k")
+				require.True(t, ok)
+				assert.Equal(t, "v", value)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			tt.run(t, newPinTestCache(t))
+		})
+	}
+}
+
+// TestRistrettoCache_PinLimit 验证固定键数量上限。
+func TestRistrettoCache_PinLimit(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		maxPinned   int
+		wantPinned  int
+	}{
+		{
+			name:        "success/within-limit",
+			description: "上限内的键都可以固定。",
+			maxPinned:   2,
+			wantPinned:  2,
+		},
+		{
+			name:        "error/disabled",
+			description: "上限小于等于 0 时禁止固定。",
+			maxPinned:   0,
+			wantPinned:  0,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			c := newPinTestCache(t, WithMaxPinned(tt.maxPinned))
+			for i := 0; i < tt.wantPinned; i++ {
+				require.NoError(t, c.Pin(i))
+			}
+			assert.ErrorIs(t, c.Pin("extra"), ErrPinLimitExceeded)
+
+			// 取消固定后释放名额。
+			if tt.wantPinned > 0 {
+				require.True(t, c.Unpin(0))
+				assert.NoError(t, c.Pin("extra"))
+			}
+			assert.Equal(t, tt.maxPinned, c.PinStats().MaxKeys)
+		})
+	}
+}
+
+// TestRistrettoCache_PinStats 验证固定值的内存统计。
+func TestRistrettoCache_PinStats(t *testing.T) {
+	c := newPinTestCache(t)
+
+	require.NoError(t, c.Pin("a"))
+	require.NoError(t, c.Pin("b"))
+	c.Set("a", make([]byte, 100))
+	assert.Equal(t, PinStats{Keys: 2, Values: 1, Bytes: EstimateSize(make([]byte, 100)), MaxKeys: maxPinned}, c.PinStats())
+
+	c.Set("a", "x")
+	c.Set("b", "yz")
+	assert.Equal(t, EstimateSize("x")+EstimateSize("yz"), c.PinStats().Bytes)
+
+	c.Unpin("a")
+	assert.Equal(t, PinStats{Keys: 1, Values: 1, Bytes: EstimateSize("yz"), MaxKeys: maxPinned}, c.PinStats())
+}
+
+// TestRistrettoCache_PinConcurrent 验证固定与读写并发执行时没有数据竞争，固定期间的写入不会丢失。
+func TestRistrettoCache_PinConcurrent(t *testing.T) {
+	c := newPinTestCache(t, WithMaxCost(1<<20))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				c.Set(i, j)
+				c.Get(i)
+			}
+		}(i)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				_ = c.Pin(i)
+				c.PinStats()
+				c.Unpin(i)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	require.NoError(t, c.Pin("final"))
+	c.Set("final", 1)
+	value, ok := c.Get("final")
+	require.True(t, ok)
+	assert.Equal(t, 1, value)
+}
+
+// TestTypedCache_Pin 验证 TypedCache 按底层 Cache 的能力委托固定操作。
+func TestTypedCache_Pin(t *testing.T) {
+	c := newPinTestCache(t)
+	tc := AsTypedCache[string](c)
+	require.NoError(t, tc.Pin("k"))
+	tc.SetWithCost("k", "v", 100)
+	value, ok := tc.Get("k")
+	require.True(t, ok)
+	assert.Equal(t, "v", value)
+	assert.True(t, tc.Unpin("k"))
+
+	plain := AsTypedCache[string](plainCache{Cache: c})
+	assert.ErrorIs(t, plain.Pin("k"), ErrPinUnsupported)
+	assert.False(t, plain.Unpin("k"))
+}
+
+// TestNewCache_AdmissionCallbacks 验证准入拒绝与驱逐回调。
+func TestNewCache_AdmissionCallbacks(t *testing.T) {
+	var rejected, evicted atomic.Int64
+	c := newPinTestCache(t,
+		WithOnReject(func(value interface{}, cost int64) {
+			rejected.Add(1)
+		}),
+		WithOnEvict(func(value interface{}, cost int64) {
+			evicted.Add(1)
+		}),
+		WithTTLTickerInterval(500*time.Millisecond),
+	)
+
+	c.SetWithCost("too-large", "v", 100, 0)
+	assert.Equal(t, int64(1), rejected.Load())
+
+	c.SetWithCost("a", "v", 3, 0)
+	c.SetWithCost("b", "v", 3, 0)
+	c.Clear()
+	assert.Equal(t, int64(2), evicted.Load())
+}
+
+// TestTickerSeconds 验证过期清理周期的秒数转换。
+func TestTickerSeconds(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		give        time.Duration
+		want        int64
+	}{
+		{name: "boundary/zero", description: "非正值交由 Ristretto 使用默认周期。", give: 0, want: 0},
+		{name: "boundary/sub-second", description: "不足 1 秒向上取整。", give: 10 * time.Millisecond, want: 1},
+		{name: "success/seconds", description: "整秒原样转换。", give: 3 * time.Second, want: 3},
+		{name: "success/round-up", description: "非整秒向上取整。", give: 1500 * time.Millisecond, want: 2},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			assert.Equal(t, tt.want, tickerSeconds(tt.give))
+		})
+	}
+}
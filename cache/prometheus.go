@@ -0,0 +1,146 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package cache
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// namespace 定义 Prometheus 指标命名空间。
+	namespace = "kit_cache"
+	// subsystem 定义 Prometheus 指标子系统名称。
+	subsystem = "store"
+	// collectorNameDefault 是未设置名称时 name 标签的取值。
+	collectorNameDefault = "default"
+)
+
+var (
+	// 断言 prometheusCollector 实现 prometheus.Collector 接口。
+	_ prometheus.Collector = (*prometheusCollector)(nil)
+)
+
+type (
+	// CollectorOption 定义修改 Prometheus 采集器的函数式选项。
+	//
+	// 参数：
+	//   - *collectorOptions: 待修改的配置，NewPrometheusCollector 在应用选项时传入非 nil 指针。
+	CollectorOption func(*collectorOptions)
+
+	// collectorOptions 是 Prometheus 采集器的配置。
+	collectorOptions struct {
+		// name 是 name 标签的取值。
+		name string
+	}
+
+	// prometheusCollector 在每次采集时读取 Cache.Stats 并生成指标。
+	prometheusCollector struct {
+		// cache 是被采集的缓存。
+		cache Cache
+
+		// hits、misses 等是各项统计对应的指标描述。
+		hits        *prometheus.Desc
+		misses      *prometheus.Desc
+		keysAdded   *prometheus.Desc
+		evictions   *prometheus.Desc
+		rejections  *prometheus.Desc
+		costAdded   *prometheus.Desc
+		costEvicted *prometheus.Desc
+		hitRatio    *prometheus.Desc
+	}
+)
+
+// WithCollectorName 设置采集器输出指标的 name 标签。
+//
+// 同一个 Registerer 注册多个缓存的采集器时，各采集器必须使用不同的名称。
+//
+// 参数：
+//   - name: 缓存名称，默认值为 "default"；为空时保持默认值。
+//
+// 返回：
+//   - CollectorOption: 应用于 NewPrometheusCollector 的函数式选项。
+func WithCollectorName(name string) CollectorOption {
+	return func(opts *collectorOptions) {
+		if "" != name {
+			opts.name = name
+		}
+	}
+}
+
+// NewPrometheusCollector 创建把缓存统计导出为 Prometheus 指标的采集器。
+//
+// 采集器不会自动注册，调用方需要通过 prometheus.MustRegister 注册到所用的 Registerer。每次采集时调用 c.Stats，
+// 输出以下指标，均带 name 标签：
+//   - kit_cache_store_hits_total、kit_cache_store_misses_total：读取命中与未命中次数。
+//   - kit_cache_store_keys_added_total：新写入的键数量。
+//   - kit_cache_store_evictions_total：被驱逐或过期清理的键数量。
+//   - kit_cache_store_rejections_total：被准入策略拒绝或丢弃的写入次数。
+//   - kit_cache_store_cost_added_total、kit_cache_store_cost_evicted_total：写入与驱逐的成本之和。
+//   - kit_cache_store_hit_ratio：读取命中率。
+//
+// Ristretto 实现的统计在 Clear 时重置，对应的计数器随之回落，Prometheus 的 rate 与 increase 会将其视为计数器重置。
+//
+// 参数：
+//   - c: 被采集的缓存，调用方应保证其非 nil。
+//   - options: 可选配置项，例如 WithCollectorName。
+//
+// 返回：
+//   - prometheus.Collector: 缓存统计采集器。
+func NewPrometheusCollector(c Cache, options ...CollectorOption) prometheus.Collector {
+	opts := collectorOptions{name: collectorNameDefault}
+	for _, option := range options {
+		option(&opts)
+	}
+
+	labels := prometheus.Labels{"name": opts.name}
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, name), help, nil, labels)
+	}
+	return &prometheusCollector{
+		cache:       c,
+		hits:        desc("hits_total", "number of cache reads that found a value."),
+		misses:      desc("misses_total", "number of cache reads that found no value."),
+		keysAdded:   desc("keys_added_total", "number of new keys written to the cache."),
+		evictions:   desc("evictions_total", "number of keys evicted or expired from the cache."),
+		rejections:  desc("rejections_total", "number of writes rejected or dropped by the cache."),
+		costAdded:   desc("cost_added_total", "sum of the cost of items written to the cache."),
+		costEvicted: desc("cost_evicted_total", "sum of the cost of items evicted from the cache."),
+		hitRatio:    desc("hit_ratio", "ratio of cache reads that found a value."),
+	}
+}
+
+// Describe 实现 prometheus.Collector，输出全部指标描述。
+//
+// 参数：
+//   - ch: 接收指标描述的通道。
+func (pc *prometheusCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- pc.hits
+	ch <- pc.misses
+	ch <- pc.keysAdded
+	ch <- pc.evictions
+	ch <- pc.rejections
+	ch <- pc.costAdded
+	ch <- pc.costEvicted
+	ch <- pc.hitRatio
+}
+
+// Collect 实现 prometheus.Collector，读取缓存统计并输出指标。
+//
+// 参数：
+//   - ch: 接收指标的通道。
+func (pc *prometheusCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := pc.cache.Stats()
+	counter := func(desc *prometheus.Desc, value uint64) {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.CounterValue, float64(value))
+	}
+	counter(pc.hits, stats.Hits)
+	counter(pc.misses, stats.Misses)
+	counter(pc.keysAdded, stats.KeysAdded)
+	counter(pc.evictions, stats.Evictions)
+	counter(pc.rejections, stats.Rejections)
+	counter(pc.costAdded, stats.CostAdded)
+	counter(pc.costEvicted, stats.CostEvicted)
+	ch <- prometheus.MustNewConstMetric(pc.hitRatio, prometheus.GaugeValue, stats.HitRatio())
+}
@@ -0,0 +1,51 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package cache
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNewPrometheusCollector 验证采集器按 Cache.Stats 输出带 name 标签的指标。
+func TestNewPrometheusCollector(t *testing.T) {
+	shared, err := NewCache(WithNumCounters(1000), WithMaxCost(1000))
+	require.NoError(t, err)
+	defer shared.Close() //nolint:errcheck
+
+	ns := NewNamespace(shared, "users")
+	ns.Set("a", 1)
+	ns.Get("a")
+	ns.Get("a")
+	ns.Get("a")
+	ns.Get("missing")
+
+	registry := prometheus.NewPedanticRegistry()
+	require.NoError(t, registry.Register(NewPrometheusCollector(ns, WithCollectorName("users"))))
+	// 不同名称的采集器可以注册到同一个 Registerer。
+	require.NoError(t, registry.Register(NewPrometheusCollector(shared)))
+
+	expected := `
+# HELP kit_cache_store_hit_ratio ratio of cache reads that found a value.
+# TYPE kit_cache_store_hit_ratio gauge
+kit_cache_store_hit_ratio{name="users"} 0.75
+# HELP kit_cache_store_hits_total number of cache reads that found a value.
+# TYPE kit_cache_store_hits_total counter
+kit_cache_store_hits_total{name="users"} 3
+# HELP kit_cache_store_misses_total number of cache reads that found no value.
+# TYPE kit_cache_store_misses_total counter
+kit_cache_store_misses_total{name="users"} 1
+`
+	assert.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(expected+`kit_cache_store_hits_total{name="default"} 3
+kit_cache_store_misses_total{name="default"} 1
+kit_cache_store_hit_ratio{name="default"} 0.75
+`), "kit_cache_store_hits_total", "kit_cache_store_misses_total", "kit_cache_store_hit_ratio"))
+	assert.Equal(t, 16, testutil.CollectAndCount(registry))
+}
@@ -5,6 +5,7 @@
 package cache
 
 import (
+	"math"
 	"time"
 
 	"github.com/dgraph-io/ristretto"
@@ -15,9 +16,13 @@ import (
 // ristrettoCache 的常规 Get、GetWithTTL、Set、SetWithTTL 和 Delete 继承 Ristretto 的并发能力。Clear 非原子，
 // 调用方应避免将其与读写操作并发执行；Close 后不得继续使用缓存，且不应与其它操作并发调用。写入方法会在
 // Set 或 SetWithTTL 后调用 Wait，确保返回前写入请求已从缓冲中处理，但不保证该缓存项最终通过准入策略并被保留。
+// 通过 Pin 固定的键保存在 Ristretto 之外，读写时优先访问固定集合。
 type ristrettoCache struct {
 	// cache 是底层 Ristretto 缓存实例，由 newRistrettoCache 创建并由 Close 释放。
 	cache *ristretto.Cache
+
+	// pins 是不参与准入与驱逐的固定集合。
+	pins *pinSet
 }
 
 // Get 获取 key 对应的缓存值。
@@ -29,6 +34,9 @@ type ristrettoCache struct {
 //   - value: 命中且未过期时返回缓存值；未命中或已过期时返回 nil。
 //   - exists: key 存在且未过期时为 true。
 func (c *ristrettoCache) Get(key interface{}) (interface{}, bool) {
+	if value, exists, _, pinned := c.pins.get(key); pinned {
+		return value, exists
+	}
 	return c.cache.Get(key)
 }
 
@@ -44,6 +52,10 @@ func (c *ristrettoCache) Get(key interface{}) (interface{}, bool) {
 //   - exists: key 存在、未过期且 TTL 查询成功时为 true。
 //   - remainingTTL: 剩余过期时间，0 表示 key 不存在或已过期，-1 表示永不过期，正值表示实际剩余时间。
 func (c *ristrettoCache) GetWithTTL(key interface{}) (interface{}, bool, time.Duration) {
+	if value, exists, ttl, pinned := c.pins.get(key); pinned {
+		return value, exists, ttl
+	}
+
 	value, exists := c.cache.Get(key)
 	if !exists {
 		return nil, false, 0
@@ -81,8 +93,8 @@ func normalizeRistrettoTTL(value interface{}, exists bool, ttl time.Duration) (i
 
 // Set 写入永不过期的缓存值。
 //
-// 本实现写入时传入成本 1，但未设置 IgnoreInternalCost，Ristretto 可能计入内部存储成本；
-// 因此 CacheOptions.MaxCost 表示底层成本容量，不能作为严格最大条目数。
+// 本实现写入时传入成本 1；未设置 IgnoreInternalCost 时 Ristretto 还会计入内部存储成本，
+// 因此 CacheOptions.MaxCost 表示底层成本容量，不能作为严格最大条目数。需要按值大小计成本时使用 SetWithCost。
 //
 // 参数：
 //   - key: 待写入的缓存键，具体可接受类型遵循 Ristretto 的键约束。
@@ -91,16 +103,13 @@ func normalizeRistrettoTTL(value interface{}, exists bool, ttl time.Duration) (i
 // 返回：
 //   - bool: Ristretto 未立即丢弃并将该写入请求排入缓冲时返回 true；返回 true 后仍可能被准入策略拒绝。
 func (c *ristrettoCache) Set(key interface{}, value interface{}) bool {
-	ok := c.cache.Set(key, value, 1)
-	// 等待缓冲写入请求被处理；是否通过准入策略并最终可被 Get 命中仍由 Ristretto 决定。
-	c.cache.Wait()
-	return ok
+	return c.SetWithCost(key, value, 1, 0)
 }
 
 // SetWithTTL 写入带过期时间的缓存值。
 //
 // ttl 小于等于 0 时使用永不过期写入；ttl 大于 0 时使用 Ristretto 的 TTL 写入。本实现写入时传入成本 1，
-// 但未设置 IgnoreInternalCost，Ristretto 可能计入内部存储成本；因此 CacheOptions.MaxCost 表示底层成本容量，
+// 未设置 IgnoreInternalCost 时 Ristretto 还会计入内部存储成本；因此 CacheOptions.MaxCost 表示底层成本容量，
 // 不能作为严格最大条目数。
 //
 // 参数：
@@ -111,13 +120,60 @@ func (c *ristrettoCache) Set(key interface{}, value interface{}) bool {
 // 返回：
 //   - bool: Ristretto 未立即丢弃并将该写入请求排入缓冲时返回 true；返回 true 后仍可能被准入策略拒绝。
 func (c *ristrettoCache) SetWithTTL(key interface{}, value interface{}, ttl time.Duration) bool {
+	return c.SetWithCost(key, value, 1, ttl)
+}
+
+// SetWithCost 写入带成本和过期时间的缓存值。
+//
+// ttl 小于等于 0 时使用永不过期写入；ttl 大于 0 时使用 Ristretto 的 TTL 写入。cost 与 CacheOptions.MaxCost
+// 使用相同单位，成本超过剩余容量时 Ristretto 会按准入策略驱逐其它缓存项或拒绝本次写入。
+// key 处于固定状态时直接写入固定集合，不经过准入策略，始终返回 true。
+//
+// 参数：
+//   - key: 待写入的缓存键，具体可接受类型遵循 Ristretto 的键约束。
+//   - value: 待缓存的值，可以为任意 Ristretto 支持保存的类型。
+//   - cost: 缓存项成本；小于等于 0 时按 1 处理。
+//   - ttl: 缓存有效期；ttl 小于等于 0 时表示永不过期。
+//
+// 返回：
+//   - bool: Ristretto 未立即丢弃并将该写入请求排入缓冲时返回 true；返回 true 后仍可能被准入策略拒绝。
+func (c *ristrettoCache) SetWithCost(key interface{}, value interface{}, cost int64, ttl time.Duration) bool {
+	cost = normalizeCost(cost)
+
+	// 写入 Ristretto 期间持有固定集合的读锁，避免与 Pin 交错导致写入的值落在固定集合之外。
+	c.pins.mu.RLock()
+	if !c.pins.pinnedLocked(key) {
+		ok := c.setRistretto(key, value, cost, ttl)
+		c.pins.mu.RUnlock()
+		return ok
+	}
+	c.pins.mu.RUnlock()
+
+	if c.pins.set(key, value, cost, ttl) {
+		return true
+	}
+	// 释放读锁后 key 已被取消固定，重新按普通缓存写入。
+	return c.SetWithCost(key, value, cost, ttl)
+}
+
+// setRistretto 将缓存项写入 Ristretto 并等待写入请求被处理。
+//
+// 参数：
+//   - key: 待写入的缓存键。
+//   - value: 待缓存的值。
+//   - cost: 已归一化的缓存项成本。
+//   - ttl: 缓存有效期；ttl 小于等于 0 时表示永不过期。
+//
+// 返回：
+//   - bool: Ristretto 未立即丢弃并将该写入请求排入缓冲时返回 true。
+func (c *ristrettoCache) setRistretto(key interface{}, value interface{}, cost int64, ttl time.Duration) bool {
 	var ok bool
 	// 如果 ttl <= 0，则表示永不过期。
 	if ttl <= 0 {
-		ok = c.cache.Set(key, value, 1)
+		ok = c.cache.Set(key, value, cost)
 	} else {
 		// 设置带正 TTL 的缓存项，返回值仍只表示写入请求是否进入缓冲。
-		ok = c.cache.SetWithTTL(key, value, 1, ttl)
+		ok = c.cache.SetWithTTL(key, value, cost, ttl)
 	}
 	// 等待缓冲写入请求被处理；是否通过准入策略并最终可被 Get 命中仍由 Ristretto 决定。
 	c.cache.Wait()
@@ -126,18 +182,23 @@ func (c *ristrettoCache) SetWithTTL(key interface{}, value interface{}, ttl time
 
 // Delete 删除 key 对应的缓存项。
 //
+// key 处于固定状态时只移除其值，键仍保持固定。
+//
 // 参数：
 //   - key: 待删除的缓存键，具体可接受类型遵循 Ristretto 的键约束；key 不存在时该操作无效果。
 func (c *ristrettoCache) Delete(key interface{}) {
+	c.pins.remove(key)
 	c.cache.Del(key)
 }
 
 // Clear 清空当前 Ristretto 缓存中的所有缓存项。
 //
 // Ristretto 的 Clear 非原子；调用方应避免将其与 Get、Set、Delete 等读写操作并发执行。
+// 固定的键保持固定状态，但其值同样被移除。
 //
 // 参数：无。
 func (c *ristrettoCache) Clear() {
+	c.pins.clear()
 	c.cache.Clear()
 }
 
@@ -164,16 +225,46 @@ func (c *ristrettoCache) Close() error {
 //   - Cache: 创建成功后的 Ristretto 缓存实现。
 //   - error: Ristretto 初始化失败时返回错误，通常由无效配置触发。
 func newRistrettoCache(options CacheOptions) (Cache, error) {
-	cache, err := ristretto.NewCache(&ristretto.Config{
-		NumCounters: options.NumCounters,
-		MaxCost:     options.MaxCost,
-		BufferItems: options.BufferItems,
-	})
+	config := &ristretto.Config{
+		NumCounters:            options.NumCounters,
+		MaxCost:                options.MaxCost,
+		BufferItems:            options.BufferItems,
+		IgnoreInternalCost:     options.IgnoreInternalCost,
+		TtlTickerDurationInSec: tickerSeconds(options.TTLTickerInterval),
+		Metrics:                options.Metrics,
+	}
+	if fn := options.OnEvict; nil != fn {
+		config.OnEvict = func(item *ristretto.Item) {
+			fn(item.Value, item.Cost)
+		}
+	}
+	if fn := options.OnReject; nil != fn {
+		config.OnReject = func(item *ristretto.Item) {
+			fn(item.Value, item.Cost)
+		}
+	}
+
+	cache, err := ristretto.NewCache(config)
 	if nil != err {
 		return nil, err
 	}
 
 	return &ristrettoCache{
 		cache: cache,
+		pins:  newPinSet(options.MaxPinned),
 	}, nil
 }
+
+// tickerSeconds 将过期清理周期转换为 Ristretto 使用的秒数。
+//
+// 参数：
+//   - interval: 清理周期。
+//
+// 返回：
+//   - int64: 向上取整的秒数；interval 小于等于 0 时返回 0，由 Ristretto 使用默认周期。
+func tickerSeconds(interval time.Duration) int64 {
+	if interval <= 0 {
+		return 0
+	}
+	return int64(math.Ceil(interval.Seconds()))
+}
@@ -0,0 +1,63 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package cache
+
+type (
+	// CacheStats 描述缓存自创建或最近一次 Clear 以来的累计统计。
+	//
+	// 各字段由具体实现尽力提供，实现无法统计的字段保持为 0。内置 Ristretto 实现的统计由 WithMetrics 控制，
+	// 关闭时全部字段为 0；统计在底层异步更新，写入后立即读取的结果可能尚未包含本次写入。
+	CacheStats struct {
+		// Hits 是读取命中的次数。
+		Hits uint64
+		// Misses 是读取未命中的次数。
+		Misses uint64
+		// KeysAdded 是新写入的键数量，更新已存在的键不计入。
+		KeysAdded uint64
+		// Evictions 是因容量不足被驱逐或过期被清理的键数量。
+		Evictions uint64
+		// Rejections 是被准入策略拒绝或因缓冲已满被丢弃的写入次数。
+		Rejections uint64
+		// CostAdded 是写入缓存项的成本之和。
+		CostAdded uint64
+		// CostEvicted 是被驱逐或清理的缓存项的成本之和。
+		CostEvicted uint64
+	}
+)
+
+// HitRatio 返回读取命中率。
+//
+// 参数：无。
+//
+// 返回：
+//   - float64: Hits / (Hits + Misses)；没有读取时返回 0。
+func (s CacheStats) HitRatio() float64 {
+	total := s.Hits + s.Misses
+	if 0 == total {
+		return 0
+	}
+	return float64(s.Hits) / float64(total)
+}
+
+// Stats 返回 Ristretto 的累计统计。
+//
+// 固定集合中的键不经过 Ristretto，对其读取不计入 Hits 与 Misses。Clear 会重置统计。
+//
+// 参数：无。
+//
+// 返回：
+//   - CacheStats: 累计统计；创建时关闭了 WithMetrics 时全部字段为 0。
+func (c *ristrettoCache) Stats() CacheStats {
+	m := c.cache.Metrics
+	return CacheStats{
+		Hits:        m.Hits(),
+		Misses:      m.Misses(),
+		KeysAdded:   m.KeysAdded(),
+		Evictions:   m.KeysEvicted(),
+		Rejections:  m.SetsRejected() + m.SetsDropped(),
+		CostAdded:   m.CostAdded(),
+		CostEvicted: m.CostEvicted(),
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package cache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCacheStats_HitRatio 验证命中率的计算。
+func TestCacheStats_HitRatio(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		stats       CacheStats
+		want        float64
+	}{
+		{name: "empty", description: "验证没有读取时命中率为 0。", stats: CacheStats{}, want: 0},
+		{name: "all-hits", description: "验证全部命中时命中率为 1。", stats: CacheStats{Hits: 4}, want: 1},
+		{name: "mixed", description: "验证命中率为命中次数除以读取次数。", stats: CacheStats{Hits: 3, Misses: 1}, want: 0.75},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			assert.InDelta(t, tt.want, tt.stats.HitRatio(), 1e-9)
+		})
+	}
+}
+
+// TestStats 验证 Ristretto 实现、TypedCache 与 Namespace 的统计。
+func TestStats(t *testing.T) {
+	t.Run("success/ristretto", func(t *testing.T) {
+		t.Log("验证 Ristretto 实现默认记录命中、未命中与写入，Clear 重置统计。")
+
+		c, err := NewCache(WithNumCounters(1000), WithMaxCost(1000))
+		require.NoError(t, err)
+		defer c.Close() //nolint:errcheck
+
+		require.True(t, c.Set("a", 1))
+		c.Get("a")
+		c.Get("a")
+		c.Get("missing")
+
+		stats := AsTypedCache[int](c).Stats()
+		assert.Equal(t, uint64(2), stats.Hits)
+		assert.Equal(t, uint64(1), stats.Misses)
+		assert.Equal(t, uint64(1), stats.KeysAdded)
+		assert.Positive(t, stats.CostAdded)
+
+		c.Clear()
+		assert.Equal(t, CacheStats{}, c.Stats())
+	})
+
+	t.Run("success/disabled", func(t *testing.T) {
+		t.Log("验证关闭统计时 Stats 返回零值。")
+
+		c, err := NewCache(WithNumCounters(1000), WithMaxCost(1000), WithMetrics(false))
+		require.NoError(t, err)
+		defer c.Close() //nolint:errcheck
+
+		c.Set("a", 1)
+		c.Get("a")
+		assert.Equal(t, CacheStats{}, c.Stats())
+	})
+
+	t.Run("success/namespace", func(t *testing.T) {
+		t.Log("验证命名空间只统计自身的读写、淘汰与拒绝。")
+
+		shared, err := NewCache(WithNumCounters(1000), WithMaxCost(1000))
+		require.NoError(t, err)
+		defer shared.Close() //nolint:errcheck
+
+		ns := NewNamespace(shared, "ns",
+			WithNamespaceMaxEntries(1),
+			WithNamespaceAdmission(AdmitMaxCost(5)),
+		)
+		other := NewNamespace(shared, "other")
+		require.True(t, ns.SetWithCost("a", 1, 2, 0))
+		require.True(t, ns.SetWithCost("a", 1, 2, 0))
+		require.True(t, ns.Set("b", 2))
+		assert.False(t, ns.SetWithCost("c", 3, 10, 0))
+		ns.Get("b")
+		ns.Get("a")
+		other.Get("b")
+
+		assert.Equal(t, CacheStats{
+			Hits:       1,
+			Misses:     1,
+			KeysAdded:  2,
+			Evictions:  1,
+			Rejections: 1,
+			CostAdded:  5,
+		}, ns.Stats())
+		assert.Equal(t, CacheStats{Misses: 1}, other.Stats())
+	})
+}
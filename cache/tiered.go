@@ -0,0 +1,850 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package cache
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+const (
+	// tieredKeyPrefixDefault 是远程缓存键的默认前缀。
+	tieredKeyPrefixDefault = "cache:"
+	// tieredChannelDefault 是默认的失效广播频道。
+	tieredChannelDefault = "cache:invalidate"
+	// tieredTimeoutDefault 是单次远程操作的默认超时时间。
+	tieredTimeoutDefault = time.Second
+	// tieredScanCount 是 Clear 每次 SCAN 的建议数量。
+	tieredScanCount = 1000
+)
+
+var (
+	// ErrNilTier 表示创建 TieredCache 时本地缓存或远程客户端为 nil。
+	ErrNilTier = errors.New("cache: tiered cache layer is nil")
+)
+
+var (
+	// tieredRetryDefault 是失效订阅断开后重新接收前的等待时间。
+	tieredRetryDefault = time.Second
+
+	// 断言 TieredCache 实现 Cache 接口。
+	_ Cache = (*TieredCache)(nil)
+	// 断言 go-redis 的单机与集群客户端满足 RemoteClient 接口。
+	_ RemoteClient = (*goredis.Client)(nil)
+	_ RemoteClient = (*goredis.ClusterClient)(nil)
+	// 断言 go-redis 的集群客户端满足 masterIterator 接口。
+	_ masterIterator = (*goredis.ClusterClient)(nil)
+)
+
+type (
+	// RemoteClient 定义 TieredCache 访问远程 Redis 所需的最小能力。
+	//
+	// *goredis.Client、*goredis.ClusterClient 与 database/redis 包的 Redis 接口均满足该接口。
+	RemoteClient interface {
+		// Do 执行任意 Redis 命令。
+		//
+		// 参数：
+		//   - ctx: 控制命令执行生命周期的上下文。
+		//   - args: 命令名称及其参数。
+		//
+		// 返回：
+		//   - *goredis.Cmd: 命令结果，执行错误由 Err 方法承载。
+		Do(ctx context.Context, args ...interface{}) *goredis.Cmd
+
+		// Subscribe 订阅一个或多个频道。
+		//
+		// 参数：
+		//   - ctx: 控制订阅创建过程的上下文。
+		//   - channels: 要订阅的频道名称。
+		//
+		// 返回：
+		//   - *goredis.PubSub: 发布订阅连接，TieredCache 关闭时负责关闭。
+		Subscribe(ctx context.Context, channels ...string) *goredis.PubSub
+	}
+
+	// masterIterator 由集群客户端实现，Clear 据此在每个主节点上分别执行 SCAN。
+	masterIterator interface {
+		// ForEachMaster 并发地在每个主节点上执行 fn。
+		//
+		// 参数：
+		//   - ctx: 控制集群状态加载的上下文。
+		//   - fn: 在单个主节点上执行的函数。
+		//
+		// 返回：
+		//   - error: 任一 fn 返回的错误。
+		ForEachMaster(ctx context.Context, fn func(ctx context.Context, client *goredis.Client) error) error
+	}
+
+	// Codec 定义 TieredCache 在远程缓存中保存值时使用的编解码器。
+	Codec interface {
+		// Marshal 将缓存值编码为字节。
+		//
+		// 参数：
+		//   - value: 待编码的缓存值。
+		//
+		// 返回：
+		//   - []byte: 编码结果。
+		//   - error: 值无法编码时返回错误，此时写入失败。
+		Marshal(value interface{}) ([]byte, error)
+
+		// Unmarshal 将远程缓存中的字节解码为缓存值。
+		//
+		// 参数：
+		//   - data: 远程缓存中保存的字节。
+		//
+		// 返回：
+		//   - interface{}: 解码得到的缓存值。
+		//   - error: 数据无法解码时返回错误，此时按未命中处理。
+		Unmarshal(data []byte) (interface{}, error)
+	}
+
+	// TieredOption 定义修改 TieredCache 行为的函数式选项。
+	//
+	// 参数：
+	//   - *tieredOptions: 待修改的配置，NewTieredCache 在应用选项时传入非 nil 指针。
+	TieredOption func(*tieredOptions)
+
+	// tieredOptions 是 TieredCache 的配置。
+	tieredOptions struct {
+		// keyPrefix 是远程缓存键的前缀。
+		keyPrefix string
+		// channel 是失效广播频道。
+		channel string
+		// codec 是远程缓存值的编解码器。
+		codec Codec
+		// localTTL 是本地缓存项的最长有效期，小于等于 0 表示与远程一致。
+		localTTL time.Duration
+		// timeout 是单次远程操作的超时时间。
+		timeout time.Duration
+		// onError 在远程操作或编解码失败时调用，为 nil 时忽略错误。
+		onError func(err error)
+	}
+
+	// TieredCache 是由本地缓存与远程 Redis 组成的两级缓存。
+	//
+	// 读取时先访问本地缓存，未命中时读取 Redis 并按 Redis 的剩余 TTL 回填本地缓存；写入与删除先作用于 Redis，
+	// 再更新本地缓存，并在失效频道上广播键名，其它实例收到广播后删除各自的本地副本，使多实例部署的本地缓存保持一致。
+	// 失效订阅未就绪或断开期间不读写本地缓存，重新订阅时清空本地缓存，避免使用断连期间错过广播的旧值。
+	//
+	// 远程缓存键为前缀加上键的字符串形式，支持 string、[]byte、byte、int、int32、int64、uint32、uint64 类型的键，
+	// 其它类型会 panic；不同类型的同值键（例如 1 与 "1"）对应同一个远程键。本地缓存必须由 TieredCache 独占。
+	// TieredCache 的方法可以并发调用。零值 TieredCache 不可直接使用，调用方应通过 NewTieredCache 创建。
+	TieredCache struct {
+		// local 是本地缓存，以远程缓存键为键。
+		local Cache
+		// remote 是远程 Redis 客户端。
+		remote RemoteClient
+		// options 是 TieredCache 的配置。
+		options tieredOptions
+		// source 是本实例的标识，用于忽略自身发出的广播。
+		source string
+
+		// pubsub 是订阅失效频道的连接。
+		pubsub *goredis.PubSub
+		// ready 表示失效订阅已生效，可以读写本地缓存。
+		ready atomic.Bool
+		// seq 在每次本地失效时递增，用于丢弃读取期间发生失效的回填。
+		seq atomic.Uint64
+		// hits 是本地或远程命中的读取次数。
+		hits atomic.Uint64
+		// misses 是本地与远程均未命中的读取次数。
+		misses atomic.Uint64
+
+		// cancel 结束接收循环。
+		cancel context.CancelFunc
+		// done 在接收循环退出时关闭。
+		done chan struct{}
+		// closeOnce 保证 Close 只执行一次。
+		closeOnce sync.Once
+	}
+
+	// tieredMessage 是失效频道上广播的消息。
+	tieredMessage struct {
+		// Source 是发出广播的实例标识。
+		Source string `json:"source"`
+		// Key 是失效的远程缓存键，All 为 true 时为空。
+		Key string `json:"key,omitempty"`
+		// All 表示清空全部本地缓存。
+		All bool `json:"all,omitempty"`
+	}
+
+	// jsonCodec 使用 encoding/json 编解码 T 类型的值。
+	jsonCodec[T any] struct{}
+)
+
+// JSONCodec 返回使用 encoding/json 编解码的 Codec，解码结果的类型为 T。
+//
+// 默认编解码器为 JSONCodec[interface{}]()，结构体会被解码为 map[string]interface{}；配合 TypedCache[T] 使用时，
+// 应通过 WithTieredCodec(JSONCodec[T]()) 使回填的值能够断言为 T。
+//
+// 参数：无。
+//
+// 返回：
+//   - Codec: JSON 编解码器。
+func JSONCodec[T any]() Codec {
+	return jsonCodec[T]{}
+}
+
+// Marshal 将缓存值编码为 JSON。
+//
+// 参数：
+//   - value: 待编码的缓存值。
+//
+// 返回：
+//   - []byte: JSON 字节。
+//   - error: 值无法编码为 JSON 时返回错误。
+func (jsonCodec[T]) Marshal(value interface{}) ([]byte, error) {
+	return json.Marshal(value)
+}
+
+// Unmarshal 将 JSON 解码为 T 类型的值。
+//
+// 参数：
+//   - data: JSON 字节。
+//
+// 返回：
+//   - interface{}: T 类型的值。
+//   - error: 数据无法解码为 T 时返回错误。
+func (jsonCodec[T]) Unmarshal(data []byte) (interface{}, error) {
+	var value T
+	if err := json.Unmarshal(data, &value); nil != err {
+		return nil, err
+	}
+	return value, nil
+}
+
+// WithTieredKeyPrefix 设置远程缓存键的前缀。
+//
+// Clear 会删除远程缓存中所有带该前缀的键，多个 TieredCache 共用一个 Redis 时应使用不同的前缀。
+//
+// 参数：
+//   - prefix: 键前缀，默认值为 "cache:"。
+//
+// 返回：
+//   - TieredOption: 应用于 NewTieredCache 的函数式选项。
+func WithTieredKeyPrefix(prefix string) TieredOption {
+	return func(opts *tieredOptions) {
+		opts.keyPrefix = prefix
+	}
+}
+
+// WithTieredChannel 设置失效广播频道。
+//
+// 参数：
+//   - channel: 频道名称，默认值为 "cache:invalidate"；共享同一份远程数据的实例必须使用相同的频道。
+//
+// 返回：
+//   - TieredOption: 应用于 NewTieredCache 的函数式选项。
+func WithTieredChannel(channel string) TieredOption {
+	return func(opts *tieredOptions) {
+		opts.channel = channel
+	}
+}
+
+// WithTieredCodec 设置远程缓存值的编解码器。
+//
+// 参数：
+//   - codec: 编解码器，为 nil 时使用默认的 JSONCodec[interface{}]()。
+//
+// 返回：
+//   - TieredOption: 应用于 NewTieredCache 的函数式选项。
+func WithTieredCodec(codec Codec) TieredOption {
+	return func(opts *tieredOptions) {
+		opts.codec = codec
+	}
+}
+
+// WithTieredLocalTTL 设置本地缓存项的最长有效期。
+//
+// 本地缓存项的有效期取该值与远程剩余 TTL 中较短的一个，用于限制广播丢失时本地旧值的存活时间。
+//
+// 参数：
+//   - ttl: 最长有效期，小于等于 0 时与远程缓存一致。
+//
+// 返回：
+//   - TieredOption: 应用于 NewTieredCache 的函数式选项。
+func WithTieredLocalTTL(ttl time.Duration) TieredOption {
+	return func(opts *tieredOptions) {
+		opts.localTTL = ttl
+	}
+}
+
+// WithTieredTimeout 设置单次远程操作的超时时间。
+//
+// Clear 分批删除远程缓存项，超时时间作用于每一批 SCAN 与删除。
+//
+// 参数：
+//   - timeout: 超时时间，小于等于 0 时使用默认值 1 秒。
+//
+// 返回：
+//   - TieredOption: 应用于 NewTieredCache 的函数式选项。
+func WithTieredTimeout(timeout time.Duration) TieredOption {
+	return func(opts *tieredOptions) {
+		opts.timeout = timeout
+	}
+}
+
+// WithTieredOnError 设置远程操作或编解码失败时的回调。
+//
+// Cache 接口的方法不返回错误，远程读取失败按未命中处理、写入失败返回 false，通过该回调可以记录或统计这些错误。
+//
+// 参数：
+//   - fn: 回调函数，为 nil 时忽略错误；通常在调用 TieredCache 方法的 goroutine 中同步执行，失效广播无法解析时
+//     在接收广播的 goroutine 中执行。
+//
+// 返回：
+//   - TieredOption: 应用于 NewTieredCache 的函数式选项。
+func WithTieredOnError(fn func(err error)) TieredOption {
+	return func(opts *tieredOptions) {
+		opts.onError = fn
+	}
+}
+
+// NewTieredCache 创建由本地缓存与远程 Redis 组成的两级缓存，并启动失效广播的接收循环。
+//
+// TieredCache 不拥有 local 与 remote，Close 只停止失效订阅，二者由创建者负责关闭。
+//
+// 参数：
+//   - local: 本地缓存，例如 NewCache 创建的 Ristretto 缓存；必须由 TieredCache 独占，重新订阅时会被整体清空。
+//   - remote: 远程 Redis 客户端。
+//   - options: 可选配置项，例如 WithTieredKeyPrefix、WithTieredCodec 和 WithTieredLocalTTL。
+//
+// 返回：
+//   - *TieredCache: 创建成功的两级缓存。
+//   - error: local 或 remote 为 nil 时返回 ErrNilTier。
+func NewTieredCache(local Cache, remote RemoteClient, options ...TieredOption) (*TieredCache, error) {
+	if nil == local || nil == remote {
+		return nil, ErrNilTier
+	}
+
+	opts := tieredOptions{
+		keyPrefix: tieredKeyPrefixDefault,
+		channel:   tieredChannelDefault,
+	}
+	for _, option := range options {
+		option(&opts)
+	}
+	if nil == opts.codec {
+		opts.codec = JSONCodec[interface{}]()
+	}
+	if opts.timeout <= 0 {
+		opts.timeout = tieredTimeoutDefault
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	tc := &TieredCache{
+		local:   local,
+		remote:  remote,
+		options: opts,
+		source:  newTieredSource(),
+		pubsub:  remote.Subscribe(ctx),
+		cancel:  cancel,
+		done:    make(chan struct{}),
+	}
+	go tc.receive(ctx)
+
+	return tc, nil
+}
+
+// Get 获取 key 对应的缓存值，本地未命中时读取远程缓存并回填本地。
+//
+// 参数：
+//   - key: 待查询的缓存键。
+//
+// 返回：
+//   - value: 命中且未过期时返回缓存值；未命中、已过期或远程读取失败时返回 nil。
+//   - exists: key 存在且未过期时为 true。
+func (tc *TieredCache) Get(key interface{}) (interface{}, bool) {
+	value, exists, _ := tc.GetWithTTL(key)
+	return value, exists
+}
+
+// GetWithTTL 获取 key 对应的缓存值及剩余过期时间，本地未命中时读取远程缓存并回填本地。
+//
+// 本地命中时返回本地缓存项的剩余时间，设置了 WithTieredLocalTTL 时可能短于远程剩余时间。
+//
+// 参数：
+//   - key: 待查询的缓存键。
+//
+// 返回：
+//   - value: 命中且未过期时返回缓存值；未命中、已过期或远程读取失败时返回 nil。
+//   - exists: key 存在且未过期时为 true。
+//   - remainingTTL: 剩余过期时间，0 表示 key 不存在或已过期，-1 表示永不过期，正值表示实际剩余时间。
+func (tc *TieredCache) GetWithTTL(key interface{}) (interface{}, bool, time.Duration) {
+	rk := tc.key(key)
+	ready := tc.ready.Load()
+	if ready {
+		if value, exists, ttl := tc.local.GetWithTTL(rk); exists {
+			tc.hits.Add(1)
+			return value, true, ttl
+		}
+	}
+
+	seq := tc.seq.Load()
+	value, ttl, err := tc.fetch(rk)
+	if nil != err {
+		if !errors.Is(err, goredis.Nil) {
+			tc.report(err)
+		}
+		tc.misses.Add(1)
+		return nil, false, 0
+	}
+	tc.hits.Add(1)
+
+	// 读取期间收到失效广播或订阅断开时不回填，避免写入旧值。
+	if ready && tc.ready.Load() && seq == tc.seq.Load() {
+		tc.local.SetWithTTL(rk, value, tc.localTTL(ttl))
+	}
+	return value, true, ttl
+}
+
+// Set 写入永不过期的缓存值。
+//
+// 参数：
+//   - key: 待写入的缓存键。
+//   - value: 待缓存的值，必须能够被编解码器编码。
+//
+// 返回：
+//   - bool: 远程写入成功时返回 true；编码或远程写入失败时返回 false。
+func (tc *TieredCache) Set(key interface{}, value interface{}) bool {
+	return tc.SetWithTTL(key, value, 0)
+}
+
+// SetWithTTL 写入带过期时间的缓存值，并广播失效消息使其它实例删除本地副本。
+//
+// 参数：
+//   - key: 待写入的缓存键。
+//   - value: 待缓存的值，必须能够被编解码器编码。
+//   - ttl: 缓存有效期；ttl 小于等于 0 时表示永不过期。
+//
+// 返回：
+//   - bool: 远程写入成功时返回 true；编码或远程写入失败时返回 false，此时本地缓存中的旧值同样被删除。
+func (tc *TieredCache) SetWithTTL(key interface{}, value interface{}, ttl time.Duration) bool {
+	rk := tc.key(key)
+	data, err := tc.options.codec.Marshal(value)
+	if nil != err {
+		tc.report(fmt.Errorf("cache: tiered encode %q: %w", rk, err))
+		return false
+	}
+
+	args := []interface{}{"SET", rk, data}
+	if ttl > 0 {
+		args = append(args, "PX", max(ttl.Milliseconds(), 1))
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), tc.options.timeout)
+	defer cancel()
+	err = tc.remote.Do(ctx, args...).Err()
+
+	tc.invalidate(rk)
+	if nil != err {
+		tc.report(fmt.Errorf("cache: tiered set %q: %w", rk, err))
+		return false
+	}
+	if tc.ready.Load() {
+		tc.local.SetWithTTL(rk, value, tc.localTTL(ttlOrForever(ttl)))
+	}
+	tc.publish(ctx, tieredMessage{Key: rk})
+	return true
+}
+
+// Delete 删除 key 对应的缓存项，并广播失效消息使其它实例删除本地副本。
+//
+// 参数：
+//   - key: 待删除的缓存键；key 不存在时该操作无效果。
+func (tc *TieredCache) Delete(key interface{}) {
+	rk := tc.key(key)
+	ctx, cancel := context.WithTimeout(context.Background(), tc.options.timeout)
+	defer cancel()
+
+	if err := tc.remote.Do(ctx, "DEL", rk).Err(); nil != err {
+		tc.report(fmt.Errorf("cache: tiered delete %q: %w", rk, err))
+	}
+	tc.invalidate(rk)
+	tc.publish(ctx, tieredMessage{Key: rk})
+}
+
+// Clear 删除远程缓存中所有带键前缀的缓存项并清空本地缓存，同时广播使其它实例清空本地缓存。
+//
+// 远程删除通过 SCAN 分批执行，不是原子操作；执行期间其它实例写入的缓存项可能被保留或删除。远程客户端为
+// *goredis.ClusterClient 时在每个主节点上分别执行 SCAN，并逐个删除键以避免跨槽错误。WithTieredTimeout 限制的是
+// 每一批 SCAN 与删除的耗时，而不是整个 Clear 的耗时。
+//
+// 参数：无。
+func (tc *TieredCache) Clear() {
+	if err := tc.clearRemote(); nil != err {
+		tc.report(fmt.Errorf("cache: tiered clear: %w", err))
+	}
+	tc.invalidateAll()
+
+	ctx, cancel := context.WithTimeout(context.Background(), tc.options.timeout)
+	defer cancel()
+	tc.publish(ctx, tieredMessage{All: true})
+}
+
+// Stats 返回两级缓存的累计统计。
+//
+// Hits 与 Misses 以两级缓存整体计算：本地或远程命中计为命中，两级均未命中或远程读取失败计为未命中。
+// 其余字段取自本地缓存的 Stats，反映本地缓存的写入、驱逐与成本。
+//
+// 参数：无。
+//
+// 返回：
+//   - CacheStats: 累计统计。
+func (tc *TieredCache) Stats() CacheStats {
+	stats := tc.local.Stats()
+	stats.Hits = tc.hits.Load()
+	stats.Misses = tc.misses.Load()
+	return stats
+}
+
+// Close 停止失效订阅并等待接收循环退出。
+//
+// Close 不关闭本地缓存与远程客户端；关闭后的 TieredCache 不应继续使用。重复调用 Close 无效果。
+//
+// 参数：无。
+//
+// 返回：
+//   - error: 关闭订阅连接失败时返回错误。
+func (tc *TieredCache) Close() error {
+	var err error
+	tc.closeOnce.Do(func() {
+		tc.cancel()
+		err = tc.pubsub.Close()
+		<-tc.done
+		tc.ready.Store(false)
+	})
+	return err
+}
+
+// fetch 从远程缓存读取并解码缓存值及剩余过期时间。
+//
+// 参数：
+//   - rk: 远程缓存键。
+//
+// 返回：
+//   - interface{}: 解码得到的缓存值。
+//   - time.Duration: 剩余过期时间，-1 表示永不过期。
+//   - error: 键不存在时返回 goredis.Nil；远程读取或解码失败时返回错误。
+func (tc *TieredCache) fetch(rk string) (interface{}, time.Duration, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), tc.options.timeout)
+	defer cancel()
+
+	data, err := tc.remote.Do(ctx, "GET", rk).Text()
+	if nil != err {
+		if errors.Is(err, goredis.Nil) {
+			return nil, 0, err
+		}
+		return nil, 0, fmt.Errorf("cache: tiered get %q: %w", rk, err)
+	}
+	value, err := tc.options.codec.Unmarshal([]byte(data))
+	if nil != err {
+		return nil, 0, fmt.Errorf("cache: tiered decode %q: %w", rk, err)
+	}
+
+	ms, err := tc.remote.Do(ctx, "PTTL", rk).Int64()
+	switch {
+	case nil != err:
+		return nil, 0, fmt.Errorf("cache: tiered pttl %q: %w", rk, err)
+	case -2 == ms:
+		// GET 与 PTTL 之间键已过期或被删除。
+		return nil, 0, goredis.Nil
+	case ms < 0:
+		return value, -1, nil
+	default:
+		return value, time.Duration(ms) * time.Millisecond, nil
+	}
+}
+
+// clearRemote 删除所有带键前缀的远程缓存项。
+//
+// 集群客户端的 SCAN 只作用于单个节点，因此在每个主节点上分别扫描；同一批键可能属于不同的槽，逐个删除。
+//
+// 参数：无。
+//
+// 返回：
+//   - error: SCAN 或 DEL 失败时返回错误。
+func (tc *TieredCache) clearRemote() error {
+	if cluster, ok := tc.remote.(masterIterator); ok {
+		return cluster.ForEachMaster(context.Background(), func(_ context.Context, node *goredis.Client) error {
+			return tc.clearNode(node, true)
+		})
+	}
+	return tc.clearNode(tc.remote, false)
+}
+
+// clearNode 在单个节点上使用 SCAN 分批删除带键前缀的缓存项，每一批使用独立的超时。
+//
+// 参数：
+//   - node: 执行 SCAN 的节点。
+//   - perKey: 为 true 时逐个删除键，删除命令经 tc.remote 按键路由；为 false 时每批使用一条 DEL。
+//
+// 返回：
+//   - error: SCAN 或 DEL 失败时返回错误。
+func (tc *TieredCache) clearNode(node RemoteClient, perKey bool) error {
+	pattern := escapeGlob(tc.options.keyPrefix) + "*"
+	cursor := "0"
+	for {
+		next, err := tc.clearBatch(node, cursor, pattern, perKey)
+		if nil != err {
+			return err
+		}
+		if "0" == next || "" == next {
+			return nil
+		}
+		cursor = next
+	}
+}
+
+// clearBatch 执行一次 SCAN 并删除本批返回的键。
+//
+// 参数：
+//   - node: 执行 SCAN 的节点。
+//   - cursor: SCAN 游标。
+//   - pattern: 键匹配模式。
+//   - perKey: 为 true 时逐个删除键。
+//
+// 返回：
+//   - string: 下一次 SCAN 的游标，为 "0" 时扫描结束。
+//   - error: SCAN 或 DEL 失败时返回错误。
+func (tc *TieredCache) clearBatch(node RemoteClient, cursor, pattern string, perKey bool) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), tc.options.timeout)
+	defer cancel()
+
+	reply, err := node.Do(ctx, "SCAN", cursor, "MATCH", pattern, "COUNT", tieredScanCount).Slice()
+	if nil != err {
+		return "", err
+	}
+	if 2 != len(reply) {
+		return "", fmt.Errorf("unexpected SCAN reply %v", reply)
+	}
+	next, _ := reply[0].(string)
+	keys, _ := reply[1].([]interface{})
+	switch {
+	case 0 == len(keys):
+	case perKey:
+		for _, key := range keys {
+			if err := tc.remote.Do(ctx, "DEL", key).Err(); nil != err {
+				return "", err
+			}
+		}
+	default:
+		if err := node.Do(ctx, append([]interface{}{"DEL"}, keys...)...).Err(); nil != err {
+			return "", err
+		}
+	}
+	return next, nil
+}
+
+// receive 循环接收失效广播，直到 ctx 结束。
+//
+// 参数：
+//   - ctx: 接收循环的生命周期。
+func (tc *TieredCache) receive(ctx context.Context) {
+	defer close(tc.done)
+
+	if err := tc.pubsub.Subscribe(ctx, tc.options.channel); nil != err {
+		tc.reset()
+	}
+	for {
+		msg, err := tc.pubsub.Receive(ctx)
+		if nil != err {
+			tc.reset()
+			if nil != ctx.Err() {
+				return
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(tieredRetryDefault):
+			}
+			continue
+		}
+		tc.handle(msg)
+	}
+}
+
+// handle 处理订阅连接收到的单条消息。
+//
+// 参数：
+//   - msg: PubSub.Receive 返回的消息。
+func (tc *TieredCache) handle(msg interface{}) {
+	switch m := msg.(type) {
+	case *goredis.Subscription:
+		if "subscribe" == m.Kind && tc.options.channel == m.Channel {
+			// 订阅生效之前可能错过了广播。
+			tc.invalidateAll()
+			tc.ready.Store(true)
+		}
+	case *goredis.Message:
+		if tc.options.channel != m.Channel {
+			return
+		}
+		var message tieredMessage
+		if err := json.Unmarshal([]byte(m.Payload), &message); nil != err {
+			tc.report(fmt.Errorf("cache: tiered invalidation message: %w", err))
+			return
+		}
+		if tc.source == message.Source {
+			return
+		}
+		if message.All {
+			tc.invalidateAll()
+			return
+		}
+		tc.invalidate(message.Key)
+	}
+}
+
+// publish 广播失效消息。
+//
+// 参数：
+//   - ctx: 控制命令执行生命周期的上下文。
+//   - message: 待广播的消息，Source 由本方法填充。
+func (tc *TieredCache) publish(ctx context.Context, message tieredMessage) {
+	message.Source = tc.source
+	payload, err := json.Marshal(message)
+	if nil == err {
+		err = tc.remote.Do(ctx, "PUBLISH", tc.options.channel, payload).Err()
+	}
+	if nil != err {
+		tc.report(fmt.Errorf("cache: tiered publish: %w", err))
+	}
+}
+
+// reset 在订阅连接不可用时停止使用本地缓存并清空已有内容。
+func (tc *TieredCache) reset() {
+	tc.ready.Store(false)
+	tc.invalidateAll()
+}
+
+// invalidate 删除单个本地缓存项。
+//
+// 参数：
+//   - rk: 远程缓存键。
+func (tc *TieredCache) invalidate(rk string) {
+	tc.seq.Add(1)
+	tc.local.Delete(rk)
+}
+
+// invalidateAll 清空本地缓存。
+func (tc *TieredCache) invalidateAll() {
+	tc.seq.Add(1)
+	tc.local.Clear()
+}
+
+// localTTL 计算本地缓存项的有效期。
+//
+// 参数：
+//   - remoteTTL: 远程剩余过期时间，-1 表示永不过期。
+//
+// 返回：
+//   - time.Duration: 本地缓存项的有效期，0 表示永不过期。
+func (tc *TieredCache) localTTL(remoteTTL time.Duration) time.Duration {
+	limit := tc.options.localTTL
+	switch {
+	case remoteTTL < 0:
+		return max(limit, 0)
+	case limit > 0:
+		return min(limit, remoteTTL)
+	default:
+		return remoteTTL
+	}
+}
+
+// report 调用错误回调。
+//
+// 参数：
+//   - err: 远程操作或编解码错误。
+func (tc *TieredCache) report(err error) {
+	if nil != tc.options.onError {
+		tc.options.onError(err)
+	}
+}
+
+// key 返回带前缀的远程缓存键。
+//
+// 参数：
+//   - key: 调用方传入的缓存键。
+//
+// 返回：
+//   - string: 远程缓存键。
+func (tc *TieredCache) key(key interface{}) string {
+	prefix := tc.options.keyPrefix
+	switch k := key.(type) {
+	case string:
+		return prefix + k
+	case []byte:
+		return prefix + string(k)
+	case byte:
+		return prefix + strconv.FormatUint(uint64(k), 10)
+	case int:
+		return prefix + strconv.FormatInt(int64(k), 10)
+	case int32:
+		return prefix + strconv.FormatInt(int64(k), 10)
+	case int64:
+		return prefix + strconv.FormatInt(k, 10)
+	case uint32:
+		return prefix + strconv.FormatUint(uint64(k), 10)
+	case uint64:
+		return prefix + strconv.FormatUint(k, 10)
+	default:
+		// 与 Ristretto 对不支持的键类型的处理保持一致。
+		panic(fmt.Sprintf("cache: tiered key type %T not supported", key))
+	}
+}
+
+// ttlOrForever 将写入时的 ttl 转换为远程剩余时间的表示。
+//
+// 参数：
+//   - ttl: 写入时的有效期，小于等于 0 表示永不过期。
+//
+// 返回：
+//   - time.Duration: ttl 为正时原样返回，否则返回 -1。
+func ttlOrForever(ttl time.Duration) time.Duration {
+	if ttl <= 0 {
+		return -1
+	}
+	return ttl
+}
+
+// escapeGlob 转义 Redis glob 模式中的特殊字符。
+//
+// 参数：
+//   - s: 原始字符串。
+//
+// 返回：
+//   - string: 可以在 MATCH 模式中按字面匹配的字符串。
+func escapeGlob(s string) string {
+	var sb strings.Builder
+	for _, r := range s {
+		switch r {
+		case '*', '?', '[', ']', '\\':
+			sb.WriteByte('\\')
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// newTieredSource 生成实例标识。
+//
+// 返回：
+//   - string: 16 位十六进制随机字符串。
+func newTieredSource() string {
+	buf := make([]byte, 8)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
@@ -0,0 +1,657 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package cache
+
+import (
+	"bufio"
+	"context"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type (
+	// fakeRedis 是实现 TieredCache 所需命令子集的内存 RESP 服务。
+	fakeRedis struct {
+		mu sync.Mutex
+		// kv 保存键值。
+		kv map[string]string
+		// expireAt 保存键的过期时间。
+		expireAt map[string]time.Time
+		// subscribers 按频道保存订阅连接。
+		subscribers map[string][]*fakeConn
+		// commands 按命令名统计调用次数。
+		commands map[string]int
+		// fail 为 true 时所有数据命令返回错误。
+		fail bool
+		// cluster 为 true 时模拟集群节点，多键 DEL 返回 CROSSSLOT 错误。
+		cluster bool
+		// scanPage 是 SCAN 每批返回的键数量，0 表示一次返回全部。
+		scanPage int
+		// delay 是每条数据命令的处理耗时。
+		delay time.Duration
+	}
+
+	// fakeConn 是串行化写入的服务端连接。
+	fakeConn struct {
+		mu   sync.Mutex
+		conn net.Conn
+	}
+
+	// user 是测试用的结构体值。
+	user struct {
+		Name string `json:"name"`
+		Age  int    `json:"age"`
+	}
+)
+
+// newFakeRedis 创建内存 RESP 服务。
+//
+// 返回：
+//   - *fakeRedis: 内存 RESP 服务。
+func newFakeRedis() *fakeRedis {
+	return &fakeRedis{
+		kv:          make(map[string]string),
+		expireAt:    make(map[string]time.Time),
+		subscribers: make(map[string][]*fakeConn),
+		commands:    make(map[string]int),
+	}
+}
+
+// client 创建连接到内存 RESP 服务的客户端。
+//
+// 参数：
+//   - t: 测试上下文，测试结束时关闭客户端。
+//
+// 返回：
+//   - *goredis.Client: Redis 客户端。
+func (s *fakeRedis) client(t *testing.T) *goredis.Client {
+	t.Helper()
+
+	client := goredis.NewClient(&goredis.Options{
+		Addr:            "fake.redis:6379",
+		Protocol:        2,
+		DisableIdentity: true,
+		MaxRetries:      -1,
+		Dialer: func(context.Context, string, string) (net.Conn, error) {
+			clientConn, serverConn := net.Pipe()
+			go s.serve(&fakeConn{conn: serverConn})
+			return clientConn, nil
+		},
+	})
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+	return client
+}
+
+// len 返回未过期的键数量。
+//
+// 返回：
+//   - int: 键数量。
+func (s *fakeRedis) len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.expire()
+	return len(s.kv)
+}
+
+// newFakeCluster 创建由多个内存 RESP 服务组成的集群客户端，哈希槽平均分配给各节点。
+//
+// 参数：
+//   - t: 测试上下文，测试结束时关闭客户端。
+//   - nodes: 集群主节点。
+//
+// 返回：
+//   - *goredis.ClusterClient: 集群客户端。
+func newFakeCluster(t *testing.T, nodes ...*fakeRedis) *goredis.ClusterClient {
+	t.Helper()
+
+	const slots = 16384
+	servers := make(map[string]*fakeRedis, len(nodes))
+	clusterSlots := make([]goredis.ClusterSlot, 0, len(nodes))
+	for i, node := range nodes {
+		node.cluster = true
+		addr := "fake.redis:" + strconv.Itoa(7000+i)
+		servers[addr] = node
+		clusterSlots = append(clusterSlots, goredis.ClusterSlot{
+			Start: i * slots / len(nodes),
+			End:   (i+1)*slots/len(nodes) - 1,
+			Nodes: []goredis.ClusterNode{{Addr: addr}},
+		})
+	}
+
+	client := goredis.NewClusterClient(&goredis.ClusterOptions{
+		ClusterSlots: func(context.Context) ([]goredis.ClusterSlot, error) {
+			return clusterSlots, nil
+		},
+		Protocol:        2,
+		DisableIdentity: true,
+		MaxRetries:      -1,
+		Dialer: func(_ context.Context, _ string, addr string) (net.Conn, error) {
+			clientConn, serverConn := net.Pipe()
+			go servers[addr].serve(&fakeConn{conn: serverConn})
+			return clientConn, nil
+		},
+	})
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+	return client
+}
+
+// count 返回命令的调用次数。
+//
+// 参数：
+//   - name: 命令名。
+//
+// 返回：
+//   - int: 调用次数。
+func (s *fakeRedis) count(name string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.commands[name]
+}
+
+// setFail 设置数据命令是否返回错误。
+//
+// 参数：
+//   - fail: 为 true 时数据命令返回错误。
+func (s *fakeRedis) setFail(fail bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fail = fail
+}
+
+// serve 循环处理单条连接上的命令。
+//
+// 参数：
+//   - c: 服务端连接。
+func (s *fakeRedis) serve(c *fakeConn) {
+	defer func() {
+		_ = c.conn.Close()
+	}()
+
+	reader := bufio.NewReader(c.conn)
+	for {
+		args, err := readCommand(reader)
+		if nil != err {
+			return
+		}
+		c.write(s.handle(c, args))
+	}
+}
+
+// handle 执行单条命令并返回 RESP 编码的响应。
+//
+// 参数：
+//   - c: 发出命令的连接，SUBSCRIBE 时登记为订阅者。
+//   - args: 命令及参数。
+//
+// 返回：
+//   - string: RESP 编码的响应。
+func (s *fakeRedis) handle(c *fakeConn, args []string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	command := strings.ToUpper(args[0])
+	s.commands[command]++
+	s.expire()
+	if s.delay > 0 && "SUBSCRIBE" != command && "HELLO" != command {
+		time.Sleep(s.delay)
+	}
+
+	switch command {
+	case "COMMAND":
+		// 集群客户端读取命令信息失败时按默认规则路由。
+		return "*0\r\n"
+	case "HELLO":
+		return "*4\r\n" + bulk("server") + bulk("fake") + bulk("proto") + ":2\r\n"
+	case "SUBSCRIBE":
+		var sb strings.Builder
+		for i, channel := range args[1:] {
+			s.subscribers[channel] = append(s.subscribers[channel], c)
+			sb.WriteString("*3\r\n" + bulk("subscribe") + bulk(channel) + ":" + strconv.Itoa(i+1) + "\r\n")
+		}
+		return sb.String()
+	case "PUBLISH":
+		message := "*3\r\n" + bulk("message") + bulk(args[1]) + bulk(args[2])
+		for _, sub := range s.subscribers[args[1]] {
+			go sub.write(message)
+		}
+		return ":" + strconv.Itoa(len(s.subscribers[args[1]])) + "\r\n"
+	case "GET", "SET", "DEL", "PTTL", "SCAN":
+		if s.fail {
+			return "-ERR injected failure\r\n"
+		}
+	default:
+		return "+OK\r\n"
+	}
+
+	switch command {
+	case "GET":
+		value, ok := s.kv[args[1]]
+		if !ok {
+			return "$-1\r\n"
+		}
+		return bulk(value)
+	case "SET":
+		s.kv[args[1]] = args[2]
+		delete(s.expireAt, args[1])
+		if len(args) == 5 && "PX" == strings.ToUpper(args[3]) {
+			ms, _ := strconv.ParseInt(args[4], 10, 64)
+			s.expireAt[args[1]] = time.Now().Add(time.Duration(ms) * time.Millisecond)
+		}
+		return "+OK\r\n"
+	case "DEL":
+		if s.cluster && len(args) > 2 {
+			return "-CROSSSLOT Keys in request don't hash to the same slot\r\n"
+		}
+		n := 0
+		for _, key := range args[1:] {
+			if _, ok := s.kv[key]; ok {
+				n++
+			}
+			delete(s.kv, key)
+			delete(s.expireAt, key)
+		}
+		return ":" + strconv.Itoa(n) + "\r\n"
+	case "PTTL":
+		if _, ok := s.kv[args[1]]; !ok {
+			return ":-2\r\n"
+		}
+		at, ok := s.expireAt[args[1]]
+		if !ok {
+			return ":-1\r\n"
+		}
+		return ":" + strconv.FormatInt(time.Until(at).Milliseconds(), 10) + "\r\n"
+	default:
+		// SCAN 按键名顺序返回，游标是上一批最后一个键的十六进制编码，扫描期间删除键不影响后续批次。
+		// scanPage 为 0 时一次返回全部匹配的键。
+		after, _ := hex.DecodeString(args[1])
+		var matched []string
+		for key := range s.kv {
+			if ok, _ := path.Match(args[3], key); ok && ("0" == args[1] || key > string(after)) {
+				matched = append(matched, key)
+			}
+		}
+		sort.Strings(matched)
+		next := "0"
+		if s.scanPage > 0 && len(matched) > s.scanPage {
+			matched = matched[:s.scanPage]
+			next = hex.EncodeToString([]byte(matched[len(matched)-1]))
+		}
+		var keys strings.Builder
+		for _, key := range matched {
+			keys.WriteString(bulk(key))
+		}
+		return "*2\r\n" + bulk(next) + "*" + strconv.Itoa(len(matched)) + "\r\n" + keys.String()
+	}
+}
+
+// expire 删除已过期的键，调用方必须持有 s.mu。
+func (s *fakeRedis) expire() {
+	now := time.Now()
+	for key, at := range s.expireAt {
+		if !now.Before(at) {
+			delete(s.kv, key)
+			delete(s.expireAt, key)
+		}
+	}
+}
+
+// write 串行写入 RESP 数据。
+//
+// 参数：
+//   - data: RESP 编码的数据。
+func (c *fakeConn) write(data string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, _ = io.WriteString(c.conn, data)
+}
+
+// readCommand 读取一条 RESP 数组命令。
+//
+// 参数：
+//   - r: 连接读取器。
+//
+// 返回：
+//   - []string: 命令及参数。
+//   - error: 读取失败或格式错误时返回错误。
+func readCommand(r *bufio.Reader) ([]string, error) {
+	line, err := r.ReadString('\n')
+	if nil != err {
+		return nil, err
+	}
+	if !strings.HasPrefix(line, "*") {
+		return nil, errors.New("expect array")
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(line[1:]))
+	if nil != err || n <= 0 {
+		return nil, errors.New("invalid array length")
+	}
+	args := make([]string, n)
+	for i := range args {
+		line, err = r.ReadString('\n')
+		if nil != err {
+			return nil, err
+		}
+		size, err := strconv.Atoi(strings.TrimSpace(line[1:]))
+		if nil != err {
+			return nil, err
+		}
+		buf := make([]byte, size+2)
+		if _, err := io.ReadFull(r, buf); nil != err {
+			return nil, err
+		}
+		args[i] = string(buf[:size])
+	}
+	return args, nil
+}
+
+// bulk 返回 RESP 批量字符串。
+//
+// 参数：
+//   - s: 字符串内容。
+//
+// 返回：
+//   - string: RESP 编码的批量字符串。
+func bulk(s string) string {
+	return "$" + strconv.Itoa(len(s)) + "\r\n" + s + "\r\n"
+}
+
+// newTestTieredCache 创建连接到内存 RESP 服务且失效订阅已就绪的 TieredCache。
+//
+// 参数：
+//   - t: 测试上下文，测试结束时关闭缓存。
+//   - server: 内存 RESP 服务。
+//   - options: TieredCache 选项。
+//
+// 返回：
+//   - *TieredCache: 两级缓存。
+//   - Cache: 本地缓存。
+func newTestTieredCache(t *testing.T, server *fakeRedis, options ...TieredOption) (*TieredCache, Cache) {
+	t.Helper()
+	return newTestTieredCacheWith(t, server.client(t), options...)
+}
+
+// newTestTieredCacheWith 创建使用指定远程客户端且失效订阅已就绪的 TieredCache。
+//
+// 参数：
+//   - t: 测试上下文，测试结束时关闭缓存。
+//   - remote: 远程客户端。
+//   - options: TieredCache 选项。
+//
+// 返回：
+//   - *TieredCache: 两级缓存。
+//   - Cache: 本地缓存。
+func newTestTieredCacheWith(t *testing.T, remote RemoteClient, options ...TieredOption) (*TieredCache, Cache) {
+	t.Helper()
+
+	local, err := NewCache(WithNumCounters(1000), WithMaxCost(1000))
+	require.NoError(t, err)
+	tc, err := NewTieredCache(local, remote, options...)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = tc.Close()
+		_ = local.Close()
+	})
+	require.Eventually(t, tc.ready.Load, time.Second, 5*time.Millisecond)
+	return tc, local
+}
+
+// TestTieredCache_ReadThrough 验证本地未命中时读取 Redis 并回填本地缓存。
+func TestTieredCache_ReadThrough(t *testing.T) {
+	server := newFakeRedis()
+	writer, _ := newTestTieredCache(t, server)
+	reader, local := newTestTieredCache(t, server)
+
+	seq := reader.seq.Load()
+	require.True(t, writer.SetWithTTL("user:1", "alice", time.Minute))
+	// 等待 reader 处理写入广播，避免广播与回填交错导致本次读取不回填。
+	require.Eventually(t, func() bool { return reader.seq.Load() > seq }, time.Second, 5*time.Millisecond)
+	_, ok := local.Get("cache:user:1")
+	require.False(t, ok)
+
+	value, ok, ttl := reader.GetWithTTL("user:1")
+	require.True(t, ok)
+	assert.Equal(t, "alice", value)
+	assert.Greater(t, ttl, 50*time.Second)
+
+	// 回填后直接命中本地缓存，不再访问 Redis。
+	gets := server.count("GET")
+	value, ok = reader.Get("user:1")
+	require.True(t, ok)
+	assert.Equal(t, "alice", value)
+	assert.Equal(t, gets, server.count("GET"))
+	_, ok, ttl = local.GetWithTTL("cache:user:1")
+	assert.True(t, ok)
+	assert.Greater(t, ttl, 50*time.Second)
+
+	_, ok = reader.Get("user:missing")
+	assert.False(t, ok)
+}
+
+// TestTieredCache_Invalidation 验证写入、删除与清空通过广播使其它实例的本地副本失效。
+func TestTieredCache_Invalidation(t *testing.T) {
+	server := newFakeRedis()
+	a, _ := newTestTieredCache(t, server)
+	b, local := newTestTieredCache(t, server)
+	localHas := func(key string) func() bool {
+		return func() bool {
+			_, ok := local.Get(key)
+			return ok
+		}
+	}
+
+	tests := []struct {
+		name        string
+		description string
+		mutate      func()
+		want        interface{}
+	}{
+		{
+			name:        "set",
+			description: "验证其它实例写入后本地副本被删除，再次读取得到新值。",
+			mutate:      func() { a.Set(int64(1), "v2") },
+			want:        "v2",
+		},
+		{
+			name:        "delete",
+			description: "验证其它实例删除后本地副本被删除。",
+			mutate:      func() { a.Delete(int64(1)) },
+		},
+		{
+			name:        "clear",
+			description: "验证其它实例清空后本地缓存与 Redis 中带前缀的键被清空。",
+			mutate:      func() { a.Clear() },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			require.True(t, b.Set(int64(1), "v1"))
+			require.True(t, localHas("cache:1")())
+
+			tt.mutate()
+			require.Eventually(t, func() bool { return !localHas("cache:1")() }, time.Second, 5*time.Millisecond)
+
+			value, ok := b.Get(int64(1))
+			assert.Equal(t, nil != tt.want, ok)
+			assert.Equal(t, tt.want, value)
+		})
+	}
+
+	// 自身发出的广播不会删除刚写入的本地副本。
+	require.True(t, b.Set("self", "v"))
+	time.Sleep(20 * time.Millisecond)
+	assert.True(t, localHas("cache:self")())
+}
+
+// TestTieredCache_Options 验证编解码器、本地 TTL 上限、键前缀与错误回调。
+func TestTieredCache_Options(t *testing.T) {
+	server := newFakeRedis()
+	var errs []error
+	var mu sync.Mutex
+	options := []TieredOption{
+		WithTieredKeyPrefix("users:"),
+		WithTieredChannel("users:invalidate"),
+		WithTieredCodec(JSONCodec[user]()),
+		WithTieredLocalTTL(time.Second),
+		WithTieredTimeout(100 * time.Millisecond),
+		WithTieredOnError(func(err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			errs = append(errs, err)
+		}),
+	}
+	writer, _ := newTestTieredCache(t, server, options...)
+	reader, local := newTestTieredCache(t, server, options...)
+
+	seq := reader.seq.Load()
+	require.True(t, writer.Set("1", user{Name: "alice", Age: 30}))
+	require.Eventually(t, func() bool { return reader.seq.Load() > seq }, time.Second, 5*time.Millisecond)
+	users := AsTypedCache[user](reader)
+	got, ok := users.Get("1")
+	require.True(t, ok)
+	assert.Equal(t, user{Name: "alice", Age: 30}, got)
+	_, ok, ttl := local.GetWithTTL("users:1")
+	require.True(t, ok)
+	assert.LessOrEqual(t, ttl, time.Second)
+
+	// 编码失败与远程失败都通过回调报告，写入返回 false，读取按未命中处理。
+	assert.False(t, writer.Set("bad", make(chan int)))
+	server.setFail(true)
+	assert.False(t, writer.Set("2", user{Name: "bob"}))
+	_, ok = writer.Get("2")
+	assert.False(t, ok)
+	server.setFail(false)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, errs, 3)
+	assert.Contains(t, errs[0].Error(), "encode")
+	assert.Contains(t, errs[1].Error(), "set")
+	assert.Contains(t, errs[2].Error(), "get")
+}
+
+// TestTieredCache_Clear 验证 Clear 在集群与大键空间上删除全部带前缀的键。
+func TestTieredCache_Clear(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		nodes       []*fakeRedis
+		remote      func(t *testing.T, nodes []*fakeRedis) RemoteClient
+		options     []TieredOption
+	}{
+		{
+			name:        "success/cluster",
+			description: "验证集群客户端在每个主节点上扫描，并逐个删除键以避免跨槽错误。",
+			nodes:       []*fakeRedis{newFakeRedis(), newFakeRedis(), newFakeRedis()},
+			remote: func(t *testing.T, nodes []*fakeRedis) RemoteClient {
+				return newFakeCluster(t, nodes...)
+			},
+		},
+		{
+			name:        "success/batch-timeout",
+			description: "验证超时按批计算，整体耗时超过超时时间的 Clear 仍然删除全部键。",
+			nodes:       []*fakeRedis{{kv: map[string]string{}, expireAt: map[string]time.Time{}, subscribers: map[string][]*fakeConn{}, commands: map[string]int{}, scanPage: 4}},
+			remote: func(t *testing.T, nodes []*fakeRedis) RemoteClient {
+				return nodes[0].client(t)
+			},
+			options: []TieredOption{WithTieredTimeout(200 * time.Millisecond)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			var errs []error
+			var mu sync.Mutex
+			options := append([]TieredOption{WithTieredOnError(func(err error) {
+				mu.Lock()
+				defer mu.Unlock()
+				errs = append(errs, err)
+			})}, tt.options...)
+			tc, local := newTestTieredCacheWith(t, tt.remote(t, tt.nodes), options...)
+
+			total := func() int {
+				n := 0
+				for _, node := range tt.nodes {
+					n += node.len()
+				}
+				return n
+			}
+			for i := range 30 {
+				require.True(t, tc.Set(i, i))
+			}
+			require.Equal(t, 30, total())
+			for _, node := range tt.nodes {
+				node.mu.Lock()
+				node.delay = 20 * time.Millisecond
+				node.mu.Unlock()
+			}
+
+			tc.Clear()
+			assert.Equal(t, 0, total())
+			_, ok := local.Get("cache:1")
+			assert.False(t, ok)
+			mu.Lock()
+			defer mu.Unlock()
+			assert.Empty(t, errs)
+		})
+	}
+}
+
+// TestNewTieredCache 验证参数校验、订阅断开时的降级与键类型检查。
+func TestNewTieredCache(t *testing.T) {
+	server := newFakeRedis()
+	local, err := NewCache()
+	require.NoError(t, err)
+	defer func() {
+		_ = local.Close()
+	}()
+
+	_, err = NewTieredCache(nil, server.client(t))
+	assert.ErrorIs(t, err, ErrNilTier)
+	_, err = NewTieredCache(local, nil)
+	assert.ErrorIs(t, err, ErrNilTier)
+
+	tc, tieredLocal := newTestTieredCache(t, server)
+	require.True(t, tc.Set("k", "v"))
+
+	// 订阅断开后清空本地缓存，并直接读取 Redis。
+	tc.reset()
+	_, ok := tieredLocal.Get("cache:k")
+	assert.False(t, ok)
+	gets := server.count("GET")
+	value, ok := tc.Get("k")
+	require.True(t, ok)
+	assert.Equal(t, "v", value)
+	assert.Equal(t, gets+1, server.count("GET"))
+	_, ok = tieredLocal.Get("cache:k")
+	assert.False(t, ok)
+
+	assert.Panics(t, func() { tc.Get(struct{}{}) })
+	assert.Equal(t, `a\*b\?\[c\]\\`, escapeGlob(`a*b?[c]\`))
+	require.NoError(t, tc.Close())
+	require.NoError(t, tc.Close())
+	assert.False(t, tc.ready.Load())
+}
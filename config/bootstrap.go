@@ -0,0 +1,711 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	cockroachdberrors "github.com/cockroachdb/errors"
+	"github.com/spf13/cast"
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// SourceDefault 表示取值来自目标结构体的初始值。
+	SourceDefault Source = "default"
+	// SourceFile 表示取值来自配置文件。
+	SourceFile Source = "file"
+	// SourceEnv 表示取值来自环境变量。
+	SourceEnv Source = "env"
+	// SourceFlag 表示取值来自命令行参数。
+	SourceFlag Source = "flag"
+
+	// defaultConfigFlag 是指定配置文件路径的默认命令行参数名。
+	defaultConfigFlag = "config"
+	// maskedValue 是敏感配置在有效配置输出中的替代文本。
+	maskedValue = "******"
+)
+
+var (
+	// durationType 用于识别 time.Duration 字段，避免将其按普通 int64 处理。
+	durationType = reflect.TypeOf(time.Duration(0))
+
+	// secretKeywords 是键名中出现即视为敏感配置的关键字。
+	secretKeywords = []string{"password", "passwd", "secret", "token"}
+)
+
+type (
+	// Source 表示一个配置项最终取值的来源。
+	Source string
+
+	// EffectiveValue 表示一个配置项的最终取值。
+	EffectiveValue struct {
+		// Key 是配置项的点分路径，例如 "db.max_conns"。
+		Key string
+		// Value 是配置项的文本形式；敏感配置已被遮蔽。
+		Value string
+		// Source 是配置项最终取值的来源。
+		Source Source
+	}
+
+	// Effective 是按键排序的最终有效配置。
+	Effective []EffectiveValue
+
+	// BootstrapOption 配置 Bootstrap 的行为。
+	BootstrapOption func(*bootstrapOptions)
+
+	// bootstrapOptions 包含 Bootstrap 的配置选项。
+	bootstrapOptions struct {
+		// args 是待解析的命令行参数，不包含程序名。
+		args []string
+		// name 是命令行参数集合的名称，用于帮助信息。
+		name string
+		// envPrefix 是环境变量前缀，为空时不读取环境变量。
+		envPrefix string
+		// lookupEnv 查询环境变量。
+		lookupEnv func(string) (string, bool)
+		// configFile 是默认配置文件路径。
+		configFile string
+		// configFlag 是指定配置文件路径的命令行参数名，为空时不注册该参数。
+		configFlag string
+		// output 接收有效配置输出，为 nil 时不输出。
+		output io.Writer
+	}
+
+	// bootstrapField 描述目标结构体中的一个可配置叶子字段。
+	bootstrapField struct {
+		// key 是点分配置路径。
+		key string
+		// usage 是命令行帮助信息。
+		usage string
+		// secret 表示是否在有效配置输出中遮蔽取值。
+		secret bool
+		// value 是可写的字段值。
+		value reflect.Value
+		// source 是字段当前取值的来源。
+		source Source
+	}
+)
+
+// WithArgs 设置待解析的命令行参数。
+//
+// 参数：
+//   - args: 不包含程序名的命令行参数；未设置时使用 os.Args[1:]。
+//
+// 返回：
+//   - BootstrapOption: Bootstrap 配置选项。
+func WithArgs(args []string) BootstrapOption {
+	return func(o *bootstrapOptions) {
+		o.args = args
+	}
+}
+
+// WithEnvPrefix 设置环境变量前缀并启用环境变量来源。
+//
+// 参数：
+//   - prefix: 环境变量前缀，例如 "APP" 时配置项 db.max_conns 对应 APP_DB_MAX_CONNS。
+//
+// 返回：
+//   - BootstrapOption: Bootstrap 配置选项。
+//
+// 未设置前缀时不读取环境变量，避免与 PATH、HOME 等系统变量意外冲突。
+func WithEnvPrefix(prefix string) BootstrapOption {
+	return func(o *bootstrapOptions) {
+		o.envPrefix = strings.TrimSuffix(strings.ToUpper(prefix), "_")
+	}
+}
+
+// WithLookupEnv 设置环境变量查询函数。
+//
+// 参数：
+//   - lookup: 环境变量查询函数，语义与 os.LookupEnv 相同；未设置时使用 os.LookupEnv。
+//
+// 返回：
+//   - BootstrapOption: Bootstrap 配置选项。
+func WithLookupEnv(lookup func(string) (string, bool)) BootstrapOption {
+	return func(o *bootstrapOptions) {
+		o.lookupEnv = lookup
+	}
+}
+
+// WithConfigFile 设置默认配置文件路径。
+//
+// 参数：
+//   - path: 配置文件路径，支持 .json、.yaml 和 .yml 扩展名；可被配置文件命令行参数覆盖。
+//
+// 返回：
+//   - BootstrapOption: Bootstrap 配置选项。
+func WithConfigFile(path string) BootstrapOption {
+	return func(o *bootstrapOptions) {
+		o.configFile = path
+	}
+}
+
+// WithConfigFlag 设置指定配置文件路径的命令行参数名。
+//
+// 参数：
+//   - name: 命令行参数名，默认为 "config"；为空时不注册该参数。
+//
+// 返回：
+//   - BootstrapOption: Bootstrap 配置选项。
+func WithConfigFlag(name string) BootstrapOption {
+	return func(o *bootstrapOptions) {
+		o.configFlag = name
+	}
+}
+
+// WithFlagSetName 设置命令行参数集合的名称。
+//
+// 参数：
+//   - name: 名称，出现在帮助信息中；未设置时使用程序文件名。
+//
+// 返回：
+//   - BootstrapOption: Bootstrap 配置选项。
+func WithFlagSetName(name string) BootstrapOption {
+	return func(o *bootstrapOptions) {
+		o.name = name
+	}
+}
+
+// WithOutput 设置有效配置的输出目标。
+//
+// 参数：
+//   - w: 输出目标；加载成功后写入 Effective.String() 的内容，为 nil 时不输出。
+//
+// 返回：
+//   - BootstrapOption: Bootstrap 配置选项。
+func WithOutput(w io.Writer) BootstrapOption {
+	return func(o *bootstrapOptions) {
+		o.output = w
+	}
+}
+
+// Bootstrap 合并配置文件、环境变量和命令行参数并写入目标结构体。
+//
+// 目标结构体的每个叶子字段对应一个点分配置路径，路径段依次取自 config 标签、json 标签和小写字段名，
+// 标签值为 "-" 的字段被忽略。字段可使用 usage 标签提供命令行帮助信息，使用 secret:"true" 标签
+// 声明敏感配置；键名包含 password、passwd、secret 或 token 的字段也会被视为敏感配置。
+// 支持的字段类型为 string、bool、整数、无符号整数、浮点数、time.Duration 和 []string。
+//
+// 取值优先级从低到高依次为：目标结构体初始值、配置文件、环境变量、显式传入的命令行参数。
+// 配置项 db.max_conns 对应命令行参数 --db-max-conns 和环境变量 <PREFIX>_DB_MAX_CONNS；
+// 环境变量中的 []string 取值以逗号分隔，配置文件中未知的键会被忽略。
+//
+// 参数：
+//   - target: 指向结构体的非 nil 指针。
+//   - opts: Bootstrap 配置选项。
+//
+// 返回：
+//   - Effective: 按键排序的最终有效配置，敏感配置已被遮蔽。
+//   - error: 目标类型不受支持、命令行参数解析失败、配置文件读取或解析失败、取值无法转换时返回错误；
+//     命令行包含 --help 时返回的错误满足 errors.Is(err, pflag.ErrHelp)。
+func Bootstrap(target interface{}, opts ...BootstrapOption) (Effective, error) {
+	o := &bootstrapOptions{
+		args:       os.Args[1:],
+		name:       filepath.Base(os.Args[0]),
+		lookupEnv:  os.LookupEnv,
+		configFlag: defaultConfigFlag,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	var err error
+	var effective Effective
+	var fields []*bootstrapField
+
+	rv := reflect.ValueOf(target)
+	if !rv.IsValid() || reflect.Ptr != rv.Kind() || rv.IsNil() || reflect.Struct != rv.Elem().Kind() {
+		err = cockroachdberrors.Newf("配置目标必须是指向结构体的非空指针，实际为 %[1]T。", target)
+	} else if fields, err = collectFields(rv.Elem(), "", nil); nil != err {
+		err = cockroachdberrors.Wrap(err, "解析配置结构出现错误。")
+	} else {
+		configFile := o.configFile
+		fs, errFlagSet := newFlagSet(o, fields, &configFile)
+		if nil != errFlagSet {
+			err = errFlagSet
+		} else if errParse := fs.Parse(o.args); nil != errParse {
+			err = cockroachdberrors.Wrap(errParse, "解析命令行参数出现错误。")
+		} else if errFile := applyFile(fields, configFile); nil != errFile {
+			err = errFile
+		} else if errEnv := applyEnv(fields, o); nil != errEnv {
+			err = errEnv
+		} else if errFlag := applyFlags(fields, fs); nil != errFlag {
+			err = errFlag
+		} else {
+			effective = newEffective(fields)
+			if nil != o.output {
+				_, _ = io.WriteString(o.output, effective.String())
+			}
+		}
+	}
+
+	return effective, err
+}
+
+// String 返回有效配置的多行文本，每行格式为 "key = value (source)"。
+//
+// 返回：
+//   - string: 有效配置文本；敏感配置已被遮蔽。
+func (e Effective) String() string {
+	buf := bytes.Buffer{}
+	for _, v := range e {
+		buf.WriteString(fmt.Sprintf("%[1]s = %[2]s (%[3]s)\n", v.Key, v.Value, v.Source))
+	}
+	return buf.String()
+}
+
+// Lookup 查询指定配置项的有效取值。
+//
+// 参数：
+//   - key: 点分配置路径。
+//
+// 返回：
+//   - EffectiveValue: 配置项的有效取值。
+//   - bool: 配置项存在时返回 true。
+func (e Effective) Lookup(key string) (EffectiveValue, bool) {
+	for _, v := range e {
+		if v.Key == key {
+			return v, true
+		}
+	}
+	return EffectiveValue{}, false
+}
+
+// collectFields 递归收集结构体中的可配置叶子字段。
+//
+// 参数：
+//   - v: 可寻址的结构体值。
+//   - prefix: 当前结构体的点分路径前缀。
+//   - fields: 已收集的字段。
+//
+// 返回：
+//   - []*bootstrapField: 追加后的字段列表。
+//   - error: 字段类型不受支持时返回错误。
+func collectFields(v reflect.Value, prefix string, fields []*bootstrapField) ([]*bootstrapField, error) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		name := fieldName(sf)
+		if "-" == name {
+			continue
+		}
+		key := name
+		if "" != prefix {
+			key = prefix + "." + name
+		}
+
+		fv := v.Field(i)
+		if reflect.Struct == fv.Kind() {
+			var err error
+			if fields, err = collectFields(fv, key, fields); nil != err {
+				return nil, err
+			}
+			continue
+		}
+		if !supportedKind(fv.Type()) {
+			return nil, cockroachdberrors.Newf("配置项 %[1]s 的类型 %[2]s 不受支持。", key, fv.Type())
+		}
+
+		secret := "true" == sf.Tag.Get("secret") || isSecretKey(name)
+		fields = append(fields, &bootstrapField{
+			key:    key,
+			usage:  sf.Tag.Get("usage"),
+			secret: secret,
+			value:  fv,
+			source: SourceDefault,
+		})
+	}
+	return fields, nil
+}
+
+// fieldName 返回字段对应的配置路径段。
+//
+// 参数：
+//   - sf: 结构体字段。
+//
+// 返回：
+//   - string: 依次取自 config 标签、json 标签和小写字段名；"-" 表示忽略该字段。
+func fieldName(sf reflect.StructField) string {
+	for _, tag := range []string{"config", "json"} {
+		if name, _, _ := strings.Cut(sf.Tag.Get(tag), ","); "" != name {
+			return name
+		}
+	}
+	return strings.ToLower(sf.Name)
+}
+
+// supportedKind 判断字段类型是否受支持。
+//
+// 参数：
+//   - t: 字段类型。
+//
+// 返回：
+//   - bool: 受支持时返回 true。
+func supportedKind(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	case reflect.Slice:
+		return reflect.String == t.Elem().Kind()
+	default:
+		return false
+	}
+}
+
+// isSecretKey 判断配置路径段是否包含敏感关键字。
+//
+// 参数：
+//   - name: 配置路径段。
+//
+// 返回：
+//   - bool: 包含敏感关键字时返回 true。
+func isSecretKey(name string) bool {
+	name = strings.ToLower(name)
+	for _, keyword := range secretKeywords {
+		if strings.Contains(name, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// flagName 返回配置项对应的命令行参数名。
+//
+// 参数：
+//   - key: 点分配置路径。
+//
+// 返回：
+//   - string: 将 "." 和 "_" 替换为 "-" 后的参数名。
+func flagName(key string) string {
+	return strings.NewReplacer(".", "-", "_", "-").Replace(key)
+}
+
+// envName 返回配置项对应的环境变量名。
+//
+// 参数：
+//   - prefix: 环境变量前缀。
+//   - key: 点分配置路径。
+//
+// 返回：
+//   - string: 前缀与大写配置路径以 "_" 连接后的环境变量名。
+func envName(prefix, key string) string {
+	return prefix + "_" + strings.ToUpper(strings.NewReplacer(".", "_", "-", "_").Replace(key))
+}
+
+// newFlagSet 创建包含配置文件参数和全部字段参数的命令行参数集合。
+//
+// 参数：
+//   - o: Bootstrap 配置选项。
+//   - fields: 可配置字段。
+//   - configFile: 接收配置文件路径参数取值的变量。
+//
+// 返回：
+//   - *pflag.FlagSet: 命令行参数集合。
+//   - error: 参数名冲突时返回错误。
+func newFlagSet(o *bootstrapOptions, fields []*bootstrapField, configFile *string) (*pflag.FlagSet, error) {
+	fs := pflag.NewFlagSet(o.name, pflag.ContinueOnError)
+	if "" != o.configFlag {
+		fs.StringVar(configFile, o.configFlag, *configFile, "配置文件路径")
+	}
+	for _, f := range fields {
+		if err := registerFlag(fs, f); nil != err {
+			return nil, err
+		}
+	}
+	return fs, nil
+}
+
+// registerFlag 为字段注册命令行参数。
+//
+// 参数：
+//   - fs: 命令行参数集合。
+//   - f: 可配置字段。
+//
+// 返回：
+//   - error: 参数名与已注册的参数冲突时返回错误。
+//
+// bool 字段注册为布尔参数，允许省略取值；其它字段注册为字符串参数，在解析后按字段类型转换。
+func registerFlag(fs *pflag.FlagSet, f *bootstrapField) error {
+	name := flagName(f.key)
+	if nil != fs.Lookup(name) {
+		return cockroachdberrors.Newf("配置项 %[1]s 的命令行参数 --%[2]s 已被注册。", f.key, name)
+	}
+	if reflect.Bool == f.value.Kind() {
+		fs.Bool(name, f.value.Bool(), f.usage)
+		return nil
+	}
+
+	def := formatValue(f.value)
+	if f.secret && "" != def {
+		def = maskedValue
+	}
+	fs.String(name, def, f.usage)
+	return nil
+}
+
+// applyFile 读取配置文件并写入字段。
+//
+// 参数：
+//   - fields: 可配置字段。
+//   - path: 配置文件路径，为空时跳过。
+//
+// 返回：
+//   - error: 文件读取、解析或取值转换失败时返回错误。
+func applyFile(fields []*bootstrapField, path string) error {
+	if "" == path {
+		return nil
+	}
+
+	data, err := os.ReadFile(path)
+	if nil != err {
+		return cockroachdberrors.Wrapf(err, "读取配置文件 %[1]s 出现错误。", path)
+	}
+
+	raw := make(map[string]interface{})
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.Unmarshal(data, &raw)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &raw)
+	default:
+		err = cockroachdberrors.Newf("不支持的配置文件格式 %[1]s。", filepath.Ext(path))
+	}
+	if nil != err {
+		return cockroachdberrors.Wrapf(err, "解析配置文件 %[1]s 出现错误。", path)
+	}
+
+	values := make(map[string]interface{})
+	flatten(values, "", raw)
+	for _, f := range fields {
+		if v, ok := values[f.key]; ok {
+			if err := setValue(f, v, SourceFile); nil != err {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// applyEnv 读取环境变量并写入字段。
+//
+// 参数：
+//   - fields: 可配置字段。
+//   - o: Bootstrap 配置选项。
+//
+// 返回：
+//   - error: 取值转换失败时返回错误。
+func applyEnv(fields []*bootstrapField, o *bootstrapOptions) error {
+	if "" == o.envPrefix || nil == o.lookupEnv {
+		return nil
+	}
+
+	for _, f := range fields {
+		v, ok := o.lookupEnv(envName(o.envPrefix, f.key))
+		if !ok {
+			continue
+		}
+		var raw interface{} = v
+		if reflect.Slice == f.value.Kind() {
+			raw = splitList(v)
+		}
+		if err := setValue(f, raw, SourceEnv); nil != err {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyFlags 将显式传入的命令行参数写入字段。
+//
+// 参数：
+//   - fields: 可配置字段。
+//   - fs: 已解析的命令行参数集合。
+//
+// 返回：
+//   - error: 取值转换失败时返回错误。
+func applyFlags(fields []*bootstrapField, fs *pflag.FlagSet) error {
+	for _, f := range fields {
+		flag := fs.Lookup(flagName(f.key))
+		if nil == flag || !flag.Changed {
+			continue
+		}
+		var raw interface{} = flag.Value.String()
+		if reflect.Slice == f.value.Kind() {
+			raw = splitList(flag.Value.String())
+		}
+		if err := setValue(f, raw, SourceFlag); nil != err {
+			return err
+		}
+	}
+	return nil
+}
+
+// flatten 将嵌套映射展开为点分路径映射。
+//
+// 参数：
+//   - dst: 接收展开结果的映射。
+//   - prefix: 当前路径前缀。
+//   - src: 待展开的映射。
+func flatten(dst map[string]interface{}, prefix string, src map[string]interface{}) {
+	for k, v := range src {
+		key := k
+		if "" != prefix {
+			key = prefix + "." + k
+		}
+		if nested, ok := v.(map[string]interface{}); ok {
+			flatten(dst, key, nested)
+			continue
+		}
+		dst[key] = v
+	}
+}
+
+// splitList 将逗号分隔的文本拆分为去除空白的字符串切片。
+//
+// 参数：
+//   - v: 逗号分隔的文本。
+//
+// 返回：
+//   - []string: 拆分结果；v 为空时返回空切片。
+func splitList(v string) []string {
+	list := make([]string, 0)
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); "" != item {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// setValue 按字段类型转换取值并写入字段。
+//
+// 参数：
+//   - f: 可配置字段。
+//   - raw: 原始取值。
+//   - source: 取值来源。
+//
+// 返回：
+//   - error: 取值无法转换为字段类型时返回错误。
+func setValue(f *bootstrapField, raw interface{}, source Source) error {
+	var err error
+	v := f.value
+
+	switch {
+	case durationType == v.Type():
+		var d time.Duration
+		if d, err = cast.ToDurationE(raw); nil == err {
+			v.SetInt(int64(d))
+		}
+	case reflect.String == v.Kind():
+		var s string
+		if s, err = cast.ToStringE(raw); nil == err {
+			v.SetString(s)
+		}
+	case reflect.Bool == v.Kind():
+		var b bool
+		if b, err = cast.ToBoolE(raw); nil == err {
+			v.SetBool(b)
+		}
+	case v.CanInt():
+		var i int64
+		if i, err = cast.ToInt64E(raw); nil == err {
+			if v.OverflowInt(i) {
+				err = cockroachdberrors.Newf("取值 %[1]d 超出范围。", i)
+			} else {
+				v.SetInt(i)
+			}
+		}
+	case v.CanUint():
+		var u uint64
+		if u, err = cast.ToUint64E(raw); nil == err {
+			if v.OverflowUint(u) {
+				err = cockroachdberrors.Newf("取值 %[1]d 超出范围。", u)
+			} else {
+				v.SetUint(u)
+			}
+		}
+	case v.CanFloat():
+		var fl float64
+		if fl, err = cast.ToFloat64E(raw); nil == err {
+			v.SetFloat(fl)
+		}
+	case reflect.Slice == v.Kind():
+		var list []string
+		if list, err = cast.ToStringSliceE(raw); nil == err {
+			// 元素可能是以 string 为底层类型的自定义类型，逐个设置而不是整体转换。
+			slice := reflect.MakeSlice(v.Type(), len(list), len(list))
+			for i, item := range list {
+				slice.Index(i).SetString(item)
+			}
+			v.Set(slice)
+		}
+	}
+
+	if nil != err {
+		return cockroachdberrors.Wrapf(err, "配置项 %[1]s 从 %[2]s 取值出现错误。", f.key, source)
+	}
+	f.source = source
+	return nil
+}
+
+// formatValue 返回字段取值的文本形式。
+//
+// 参数：
+//   - v: 字段值。
+//
+// 返回：
+//   - string: 字段取值的文本形式，[]string 以逗号连接。
+func formatValue(v reflect.Value) string {
+	if durationType == v.Type() {
+		return time.Duration(v.Int()).String()
+	}
+	if reflect.Slice == v.Kind() {
+		items := make([]string, v.Len())
+		for i := range items {
+			items[i] = v.Index(i).String()
+		}
+		return strings.Join(items, ",")
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+// newEffective 根据字段生成按键排序的有效配置。
+//
+// 参数：
+//   - fields: 可配置字段。
+//
+// 返回：
+//   - Effective: 有效配置；非空的敏感配置被遮蔽。
+func newEffective(fields []*bootstrapField) Effective {
+	effective := make(Effective, 0, len(fields))
+	for _, f := range fields {
+		value := formatValue(f.value)
+		if f.secret && "" != value {
+			value = maskedValue
+		}
+		effective = append(effective, EffectiveValue{Key: f.key, Value: value, Source: f.source})
+	}
+	sort.Slice(effective, func(i, j int) bool {
+		return effective[i].Key < effective[j].Key
+	})
+	return effective
+}
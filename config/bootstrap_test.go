@@ -0,0 +1,318 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package config
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// bootstrapTestConfig 是 Bootstrap 测试使用的目标结构体。
+type bootstrapTestConfig struct {
+	Name  string `json:"name" usage:"应用名称"`
+	Debug bool   `json:"debug"`
+	DB    struct {
+		Host     string        `json:"host"`
+		MaxConns int           `config:"max_conns"`
+		Timeout  time.Duration `json:"timeout"`
+		Password string        `json:"password"`
+	} `json:"db"`
+	APIKey  string   `json:"api_key" secret:"true"`
+	Tags    []string `json:"tags"`
+	Ratio   float64  `json:"ratio"`
+	Ignored string   `json:"-"`
+}
+
+// newBootstrapTestConfig 返回带初始值的测试目标结构体。
+//
+// 返回：
+//   - *bootstrapTestConfig: 带初始值的目标结构体。
+func newBootstrapTestConfig() *bootstrapTestConfig {
+	cfg := &bootstrapTestConfig{Name: "demo"}
+	cfg.DB.Host = "localhost"
+	cfg.DB.MaxConns = 10
+	return cfg
+}
+
+// TestBootstrap_Precedence 验证取值优先级依次为初始值、配置文件、环境变量和命令行参数。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestBootstrap_Precedence(t *testing.T) {
+	dir := t.TempDir()
+	yamlFile := filepath.Join(dir, "app.yaml")
+	require.NoError(t, os.WriteFile(yamlFile, []byte("db:\n  host: file-host\n  max_conns: 20\n  timeout: 3s\n  password: p@ss\ntags: [a, b]\nunknown: 1\n"), 0o600))
+	jsonFile := filepath.Join(dir, "app.json")
+	require.NoError(t, os.WriteFile(jsonFile, []byte(`{"db":{"host":"json-host","max_conns":30},"ratio":0.5}`), 0o600))
+
+	env := map[string]string{
+		"APP_DB_MAX_CONNS": "40",
+		"APP_TAGS":         "x, y",
+		"APP_API_KEY":      "k",
+	}
+	lookup := func(key string) (string, bool) {
+		v, ok := env[key]
+		return v, ok
+	}
+
+	tests := []struct {
+		name        string
+		description string
+		giveOpts    []BootstrapOption
+		check       func(t *testing.T, cfg *bootstrapTestConfig, effective Effective)
+	}{
+		{
+			name:        "success/defaults",
+			description: "验证没有任何来源时保留初始值，来源为 default。",
+			giveOpts:    []BootstrapOption{WithArgs(nil)},
+			check: func(t *testing.T, cfg *bootstrapTestConfig, effective Effective) {
+				assert.Equal(t, "localhost", cfg.DB.Host)
+				assert.Equal(t, 10, cfg.DB.MaxConns)
+				v, ok := effective.Lookup("db.max_conns")
+				require.True(t, ok)
+				assert.Equal(t, EffectiveValue{Key: "db.max_conns", Value: "10", Source: SourceDefault}, v)
+			},
+		},
+		{
+			name:        "success/yaml-file",
+			description: "验证 YAML 配置文件覆盖初始值，未知键被忽略。",
+			giveOpts:    []BootstrapOption{WithArgs(nil), WithConfigFile(yamlFile)},
+			check: func(t *testing.T, cfg *bootstrapTestConfig, effective Effective) {
+				assert.Equal(t, "file-host", cfg.DB.Host)
+				assert.Equal(t, 20, cfg.DB.MaxConns)
+				assert.Equal(t, 3*time.Second, cfg.DB.Timeout)
+				assert.Equal(t, []string{"a", "b"}, cfg.Tags)
+				v, _ := effective.Lookup("db.host")
+				assert.Equal(t, SourceFile, v.Source)
+			},
+		},
+		{
+			name:        "success/config-flag-overrides-file",
+			description: "验证 --config 参数覆盖默认配置文件路径，并支持 JSON 格式。",
+			giveOpts:    []BootstrapOption{WithArgs([]string{"--config", jsonFile}), WithConfigFile(yamlFile)},
+			check: func(t *testing.T, cfg *bootstrapTestConfig, effective Effective) {
+				assert.Equal(t, "json-host", cfg.DB.Host)
+				assert.Equal(t, 30, cfg.DB.MaxConns)
+				assert.Equal(t, 0.5, cfg.Ratio)
+				assert.Equal(t, time.Duration(0), cfg.DB.Timeout)
+			},
+		},
+		{
+			name:        "success/env-overrides-file",
+			description: "验证带前缀的环境变量覆盖配置文件，[]string 以逗号分隔。",
+			giveOpts:    []BootstrapOption{WithArgs(nil), WithConfigFile(yamlFile), WithEnvPrefix("app_"), WithLookupEnv(lookup)},
+			check: func(t *testing.T, cfg *bootstrapTestConfig, effective Effective) {
+				assert.Equal(t, "file-host", cfg.DB.Host)
+				assert.Equal(t, 40, cfg.DB.MaxConns)
+				assert.Equal(t, []string{"x", "y"}, cfg.Tags)
+				v, _ := effective.Lookup("db.max_conns")
+				assert.Equal(t, SourceEnv, v.Source)
+			},
+		},
+		{
+			name:        "success/flag-overrides-env",
+			description: "验证显式传入的命令行参数覆盖环境变量，布尔参数可以省略取值。",
+			giveOpts: []BootstrapOption{
+				WithArgs([]string{"--db-max-conns=50", "--debug", "--name", "cli"}),
+				WithConfigFile(yamlFile), WithEnvPrefix("APP"), WithLookupEnv(lookup),
+			},
+			check: func(t *testing.T, cfg *bootstrapTestConfig, effective Effective) {
+				assert.Equal(t, 50, cfg.DB.MaxConns)
+				assert.True(t, cfg.Debug)
+				assert.Equal(t, "cli", cfg.Name)
+				assert.Equal(t, "file-host", cfg.DB.Host)
+				v, _ := effective.Lookup("db.max_conns")
+				assert.Equal(t, SourceFlag, v.Source)
+			},
+		},
+		{
+			name:        "success/env-disabled-without-prefix",
+			description: "验证未设置前缀时不读取环境变量。",
+			giveOpts:    []BootstrapOption{WithArgs(nil), WithLookupEnv(lookup)},
+			check: func(t *testing.T, cfg *bootstrapTestConfig, effective Effective) {
+				assert.Equal(t, 10, cfg.DB.MaxConns)
+				assert.Empty(t, cfg.APIKey)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			cfg := newBootstrapTestConfig()
+			effective, err := Bootstrap(cfg, tt.giveOpts...)
+			require.NoError(t, err)
+			tt.check(t, cfg, effective)
+		})
+	}
+}
+
+// TestBootstrap_EffectiveMasksSecrets 验证有效配置按键排序输出，并遮蔽敏感配置。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestBootstrap_EffectiveMasksSecrets(t *testing.T) {
+	buf := &bytes.Buffer{}
+	cfg := newBootstrapTestConfig()
+	cfg.DB.Password = "p@ss"
+
+	effective, err := Bootstrap(cfg, WithArgs([]string{"--api-key", "k"}), WithOutput(buf))
+	require.NoError(t, err)
+
+	assert.Equal(t, "k", cfg.APIKey)
+	assert.Equal(t, effective.String(), buf.String())
+	assert.Contains(t, buf.String(), "api_key = ****** (flag)\n")
+	assert.Contains(t, buf.String(), "db.password = ****** (default)\n")
+	assert.Contains(t, buf.String(), "db.timeout = 0s (default)\n")
+	assert.NotContains(t, buf.String(), "p@ss")
+	_, ok := effective.Lookup("ignored")
+	assert.False(t, ok)
+
+	keys := make([]string, 0, len(effective))
+	for _, v := range effective {
+		keys = append(keys, v.Key)
+	}
+	assert.IsNonDecreasing(t, keys)
+}
+
+// bootstrapMode 是以 string 为底层类型的自定义类型，用于验证命名元素类型的切片字段。
+type bootstrapMode string
+
+// TestBootstrap_NamedStringSlice 验证元素为自定义 string 类型的切片字段可以输出和赋值。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestBootstrap_NamedStringSlice(t *testing.T) {
+	cfg := &struct {
+		Modes []bootstrapMode `json:"modes"`
+	}{Modes: []bootstrapMode{"read"}}
+
+	effective, err := Bootstrap(cfg, WithArgs(nil), WithOutput(&bytes.Buffer{}))
+	require.NoError(t, err)
+	v, ok := effective.Lookup("modes")
+	require.True(t, ok)
+	assert.Equal(t, "read", v.Value)
+
+	lookup := func(key string) (string, bool) {
+		if "APP_MODES" == key {
+			return "read,write", true
+		}
+		return "", false
+	}
+	effective, err = Bootstrap(cfg, WithArgs(nil), WithEnvPrefix("APP"), WithLookupEnv(lookup), WithOutput(&bytes.Buffer{}))
+	require.NoError(t, err)
+	assert.Equal(t, []bootstrapMode{"read", "write"}, cfg.Modes)
+	v, _ = effective.Lookup("modes")
+	assert.Equal(t, "read,write", v.Value)
+	assert.Equal(t, SourceEnv, v.Source)
+}
+
+// TestBootstrap_Errors 验证目标类型、命令行参数、配置文件和取值转换错误。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestBootstrap_Errors(t *testing.T) {
+	dir := t.TempDir()
+	badYAML := filepath.Join(dir, "bad.yaml")
+	require.NoError(t, os.WriteFile(badYAML, []byte("db: [\n"), 0o600))
+	tomlFile := filepath.Join(dir, "app.toml")
+	require.NoError(t, os.WriteFile(tomlFile, []byte("name = 1\n"), 0o600))
+
+	tests := []struct {
+		name        string
+		description string
+		giveTarget  interface{}
+		giveOpts    []BootstrapOption
+		wantHelp    bool
+	}{
+		{
+			name:        "error/non-pointer",
+			description: "验证目标不是指针时返回错误。",
+			giveTarget:  bootstrapTestConfig{},
+		},
+		{
+			name:        "error/nil",
+			description: "验证目标为 nil 时返回错误。",
+			giveTarget:  nil,
+		},
+		{
+			name:        "error/unsupported-field",
+			description: "验证字段类型不受支持时返回错误。",
+			giveTarget:  &struct{ M map[string]string }{},
+		},
+		{
+			name:        "error/flag-conflict",
+			description: "验证字段参数名与配置文件参数冲突时返回错误。",
+			giveTarget:  &struct{ Config string }{},
+		},
+		{
+			name:        "error/unknown-flag",
+			description: "验证未知命令行参数返回错误。",
+			giveTarget:  newBootstrapTestConfig(),
+			giveOpts:    []BootstrapOption{WithArgs([]string{"--nope"})},
+		},
+		{
+			name:        "error/help",
+			description: "验证 --help 返回可识别的 pflag.ErrHelp。",
+			giveTarget:  newBootstrapTestConfig(),
+			giveOpts:    []BootstrapOption{WithArgs([]string{"--help"})},
+			wantHelp:    true,
+		},
+		{
+			name:        "error/missing-file",
+			description: "验证显式指定的配置文件不存在时返回错误。",
+			giveTarget:  newBootstrapTestConfig(),
+			giveOpts:    []BootstrapOption{WithArgs(nil), WithConfigFile(filepath.Join(dir, "missing.yaml"))},
+		},
+		{
+			name:        "error/bad-yaml",
+			description: "验证配置文件格式错误时返回错误。",
+			giveTarget:  newBootstrapTestConfig(),
+			giveOpts:    []BootstrapOption{WithArgs(nil), WithConfigFile(badYAML)},
+		},
+		{
+			name:        "error/unsupported-format",
+			description: "验证不支持的配置文件扩展名返回错误。",
+			giveTarget:  newBootstrapTestConfig(),
+			giveOpts:    []BootstrapOption{WithArgs(nil), WithConfigFile(tomlFile)},
+		},
+		{
+			name:        "error/invalid-value",
+			description: "验证取值无法转换为字段类型时返回错误。",
+			giveTarget:  newBootstrapTestConfig(),
+			giveOpts:    []BootstrapOption{WithArgs([]string{"--db-max-conns", "many"})},
+		},
+		{
+			name:        "error/overflow",
+			description: "验证取值超出字段类型范围时返回错误。",
+			giveTarget:  &struct{ Small int8 }{},
+			giveOpts:    []BootstrapOption{WithArgs([]string{"--small", "300"})},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			opts := append([]BootstrapOption{WithArgs(nil)}, tt.giveOpts...)
+			effective, err := Bootstrap(tt.giveTarget, opts...)
+
+			require.Error(t, err)
+			assert.Nil(t, effective)
+			assert.Equal(t, tt.wantHelp, errors.Is(err, pflag.ErrHelp))
+		})
+	}
+}
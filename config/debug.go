@@ -0,0 +1,219 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package config
+
+import (
+	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	cockroachdberrors "github.com/cockroachdb/errors"
+)
+
+var (
+	// DefaultDebugRegistry 是默认的调试开关注册表，log、database/sql/driver 和 net/http 的相关
+	// 组件未指定注册表时使用它。初始状态下所有调试功能均关闭。
+	DefaultDebugRegistry = NewDebugRegistry(DebugFlags{})
+)
+
+type (
+	// DebugFlags 表示一组可在运行时切换的调试开关。
+	//
+	// 字段带有 config 标签，可直接嵌入 Bootstrap 的目标结构体，或通过 DebugRegistry.Load
+	// 从配置文件与环境变量加载。
+	DebugFlags struct {
+		// Pprof 表示是否开放 pprof 剖析接口，见 DebugRegistry.GatePprof。
+		Pprof bool `config:"pprof" usage:"是否开放 pprof 剖析接口"`
+		// VerboseSQL 表示是否记录每一条 SQL 语句，见 database/sql/driver 的 HookLogVerbose。
+		VerboseSQL bool `config:"verbose_sql" usage:"是否记录每一条 SQL 语句"`
+		// TraceSampleRate 是 HTTP 请求阶段耗时追踪的采样率，取值范围 [0, 1]，见 net/http 的 NewSampledTraceHook。
+		TraceSampleRate float64 `config:"trace_sample_rate" usage:"HTTP 请求追踪采样率，取值范围 [0, 1]"`
+		// LogLevel 是覆盖日志记录器级别的日志级别名称，例如 "debug"；为空时使用日志记录器原本的级别，
+		// 见 log 的 ApplyDebugFlags。
+		LogLevel string `config:"log_level" usage:"覆盖日志级别，为空时不覆盖"`
+	}
+
+	// DebugRegistry 保存当前生效的调试开关，并在开关变化时通知订阅者。
+	//
+	// 读取开关不加锁，适合在请求路径上频繁调用；DebugRegistry 是并发安全的。
+	DebugRegistry struct {
+		// flags 是当前生效的调试开关。
+		flags atomic.Pointer[DebugFlags]
+		// mu 保护 listeners 和 nextID，并串行化开关的修改与通知。
+		mu sync.Mutex
+		// listeners 按订阅编号保存变化回调。
+		listeners map[int]func(old, new DebugFlags)
+		// nextID 是下一个订阅编号。
+		nextID int
+	}
+)
+
+// NewDebugRegistry 创建调试开关注册表。
+//
+// 参数：
+//   - flags: 初始调试开关；TraceSampleRate 会被限制在 [0, 1] 范围内。
+//
+// 返回：
+//   - *DebugRegistry: 调试开关注册表。
+func NewDebugRegistry(flags DebugFlags) *DebugRegistry {
+	r := &DebugRegistry{listeners: make(map[int]func(old, new DebugFlags))}
+	flags = flags.normalize()
+	r.flags.Store(&flags)
+	return r
+}
+
+// Flags 返回当前生效的调试开关。
+//
+// 返回：
+//   - DebugFlags: 当前调试开关的副本。
+func (r *DebugRegistry) Flags() DebugFlags {
+	return *r.flags.Load()
+}
+
+// Pprof 返回是否开放 pprof 剖析接口。
+//
+// 返回：
+//   - bool: 开放时返回 true。
+func (r *DebugRegistry) Pprof() bool {
+	return r.flags.Load().Pprof
+}
+
+// VerboseSQL 返回是否记录每一条 SQL 语句。
+//
+// 返回：
+//   - bool: 记录时返回 true。
+func (r *DebugRegistry) VerboseSQL() bool {
+	return r.flags.Load().VerboseSQL
+}
+
+// TraceSampleRate 返回 HTTP 请求阶段耗时追踪的采样率。
+//
+// 返回：
+//   - float64: [0, 1] 范围内的采样率。
+func (r *DebugRegistry) TraceSampleRate() float64 {
+	return r.flags.Load().TraceSampleRate
+}
+
+// Set 替换当前生效的调试开关。
+//
+// 开关与当前取值不同时，按订阅顺序同步调用变化回调；回调中不应再修改本注册表。
+//
+// 参数：
+//   - flags: 新的调试开关；TraceSampleRate 会被限制在 [0, 1] 范围内。
+func (r *DebugRegistry) Set(flags DebugFlags) {
+	r.Update(func(f *DebugFlags) {
+		*f = flags
+	})
+}
+
+// Update 在当前调试开关的基础上修改部分开关。
+//
+// 参数：
+//   - fn: 修改函数，参数为当前开关的副本；为 nil 时不做任何修改。
+func (r *DebugRegistry) Update(fn func(*DebugFlags)) {
+	if nil == fn {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	old := *r.flags.Load()
+	flags := old
+	fn(&flags)
+	flags = flags.normalize()
+	if flags == old {
+		return
+	}
+	r.flags.Store(&flags)
+
+	ids := make([]int, 0, len(r.listeners))
+	for id := range r.listeners {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	for _, id := range ids {
+		r.listeners[id](old, flags)
+	}
+}
+
+// Load 从配置文件、环境变量和命令行参数加载调试开关并替换当前取值。
+//
+// 以当前开关作为初始值调用 Bootstrap，未出现在任何来源中的开关保持不变。例如使用
+// WithEnvPrefix("APP_DEBUG") 时，环境变量 APP_DEBUG_VERBOSE_SQL=true 打开 SQL 语句日志。
+//
+// 参数：
+//   - opts: Bootstrap 配置选项；未通过 WithArgs 指定时会解析 os.Args[1:]。
+//
+// 返回：
+//   - Effective: 调试开关的有效配置。
+//   - error: 加载失败时返回错误，此时当前开关保持不变。
+func (r *DebugRegistry) Load(opts ...BootstrapOption) (Effective, error) {
+	flags := r.Flags()
+	effective, err := Bootstrap(&flags, opts...)
+	if nil != err {
+		return nil, cockroachdberrors.Wrap(err, "加载调试开关出现错误。")
+	}
+	r.Set(flags)
+	return effective, nil
+}
+
+// OnChange 订阅调试开关的变化。
+//
+// 参数：
+//   - fn: 开关变化后调用的回调，参数为变化前与变化后的开关；为 nil 时不订阅。
+//
+// 返回：
+//   - func(): 取消订阅的函数，可重复调用。
+func (r *DebugRegistry) OnChange(fn func(old, new DebugFlags)) func() {
+	if nil == fn {
+		return func() {}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id := r.nextID
+	r.nextID++
+	r.listeners[id] = fn
+
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		delete(r.listeners, id)
+	}
+}
+
+// GatePprof 根据 Pprof 开关控制对 pprof 剖析接口的访问。
+//
+// 参数：
+//   - h: 提供 pprof 剖析接口的处理器，例如导入 net/http/pprof 后的 http.DefaultServeMux。
+//
+// 返回：
+//   - http.Handler: Pprof 开关打开时交给 h 处理，关闭时返回 404。
+func (r *DebugRegistry) GatePprof(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if !r.Pprof() {
+			http.NotFound(w, req)
+			return
+		}
+		h.ServeHTTP(w, req)
+	})
+}
+
+// normalize 把调试开关规范到合法范围内。
+//
+// 返回：
+//   - DebugFlags: TraceSampleRate 被限制在 [0, 1] 范围内的开关。
+func (f DebugFlags) normalize() DebugFlags {
+	switch {
+	case f.TraceSampleRate < 0 || f.TraceSampleRate != f.TraceSampleRate:
+		f.TraceSampleRate = 0
+	case f.TraceSampleRate > 1:
+		f.TraceSampleRate = 1
+	}
+	return f
+}
@@ -0,0 +1,142 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package config
+
+import (
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNewDebugRegistry 验证初始开关的规范化。
+func TestNewDebugRegistry(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		flags       DebugFlags
+		wantRate    float64
+	}{
+		{name: "success/in-range", description: "验证范围内的采样率保持不变。", flags: DebugFlags{TraceSampleRate: 0.25}, wantRate: 0.25},
+		{name: "success/negative", description: "验证负数采样率被限制为 0。", flags: DebugFlags{TraceSampleRate: -1}, wantRate: 0},
+		{name: "success/above-one", description: "验证大于 1 的采样率被限制为 1。", flags: DebugFlags{TraceSampleRate: 3}, wantRate: 1},
+		{name: "success/nan", description: "验证 NaN 采样率被视为 0。", flags: DebugFlags{TraceSampleRate: math.NaN()}, wantRate: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			r := NewDebugRegistry(tt.flags)
+			assert.Equal(t, tt.wantRate, r.TraceSampleRate())
+			assert.Equal(t, tt.wantRate, r.Flags().TraceSampleRate)
+		})
+	}
+}
+
+// TestDebugRegistry_OnChange 验证开关变化时按订阅顺序通知，取消订阅与未变化时不通知。
+func TestDebugRegistry_OnChange(t *testing.T) {
+	r := NewDebugRegistry(DebugFlags{})
+
+	var calls []string
+	var changes [][2]DebugFlags
+	cancelFirst := r.OnChange(func(old, new DebugFlags) {
+		calls = append(calls, "first")
+		changes = append(changes, [2]DebugFlags{old, new})
+	})
+	r.OnChange(func(old, new DebugFlags) {
+		calls = append(calls, "second")
+	})
+	r.OnChange(nil)()
+
+	r.Update(func(f *DebugFlags) { f.VerboseSQL = true })
+	assert.True(t, r.VerboseSQL())
+	assert.Equal(t, []string{"first", "second"}, calls)
+	require.Len(t, changes, 1)
+	assert.Equal(t, DebugFlags{}, changes[0][0])
+	assert.Equal(t, DebugFlags{VerboseSQL: true}, changes[0][1])
+
+	// 取值未变化时不通知。
+	r.Set(DebugFlags{VerboseSQL: true})
+	r.Update(nil)
+	assert.Len(t, calls, 2)
+
+	cancelFirst()
+	cancelFirst()
+	r.Set(DebugFlags{Pprof: true, TraceSampleRate: 2})
+	assert.Equal(t, []string{"first", "second", "second"}, calls)
+	assert.Equal(t, DebugFlags{Pprof: true, TraceSampleRate: 1}, r.Flags())
+}
+
+// TestDebugRegistry_Concurrent 验证并发读取与修改开关是安全的。
+func TestDebugRegistry_Concurrent(t *testing.T) {
+	r := NewDebugRegistry(DebugFlags{})
+	r.OnChange(func(old, new DebugFlags) {})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			r.Update(func(f *DebugFlags) { f.Pprof = 0 == i%2 })
+		}(i)
+		go func() {
+			defer wg.Done()
+			_ = r.Pprof()
+			_ = r.Flags()
+		}()
+	}
+	wg.Wait()
+}
+
+// TestDebugRegistry_Load 验证从环境变量与命令行参数加载开关，未出现的开关保持不变。
+func TestDebugRegistry_Load(t *testing.T) {
+	r := NewDebugRegistry(DebugFlags{Pprof: true, TraceSampleRate: 0.5})
+	env := map[string]string{
+		"APP_DEBUG_VERBOSE_SQL": "true",
+		"APP_DEBUG_LOG_LEVEL":   "debug",
+	}
+
+	notified := 0
+	r.OnChange(func(old, new DebugFlags) { notified++ })
+
+	effective, err := r.Load(
+		WithArgs([]string{"--trace-sample-rate=0.1"}),
+		WithEnvPrefix("APP_DEBUG"),
+		WithLookupEnv(func(key string) (string, bool) {
+			v, ok := env[key]
+			return v, ok
+		}),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, DebugFlags{Pprof: true, VerboseSQL: true, TraceSampleRate: 0.1, LogLevel: "debug"}, r.Flags())
+	assert.Equal(t, 1, notified)
+	assert.NotEmpty(t, effective)
+
+	_, err = r.Load(WithArgs([]string{"--trace-sample-rate=abc"}))
+	assert.Error(t, err)
+	assert.Equal(t, 0.1, r.TraceSampleRate())
+}
+
+// TestDebugRegistry_GatePprof 验证 Pprof 开关控制 pprof 接口的访问。
+func TestDebugRegistry_GatePprof(t *testing.T) {
+	r := NewDebugRegistry(DebugFlags{})
+	h := r.GatePprof(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/debug/pprof/", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	r.Update(func(f *DebugFlags) { f.Pprof = true })
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/debug/pprof/", nil))
+	assert.Equal(t, http.StatusNoContent, w.Code)
+}
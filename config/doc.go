@@ -2,11 +2,20 @@
 //
 // Licensed under the MIT License. See LICENSE file in the project root for full license information.
 
-// Package config 提供基于构建上下文的版本信息访问与格式化输出。
+// Package config 提供基于构建上下文的版本信息访问与格式化输出，以及应用启动配置加载。
 //
-// 本包当前围绕 CurrentVersion 暴露应用和类库的版本号、Git 提交、构建时间以及构建目
-// 录信息，并透传底层 BuildingContext 的调试状态。它不负责通用配置加载；包名中的
-// config 目前主要承载版本元数据展示能力。
+// 本包围绕 CurrentVersion 暴露应用和类库的版本号、Git 提交、构建时间以及构建目
+// 录信息，并透传底层 BuildingContext 的调试状态。
+//
+// Bootstrap 为基于 kit 的二进制提供统一的启动配置加载：按“结构体初始值、配置文件、
+// 带前缀的环境变量、显式传入的命令行参数”从低到高的优先级合并取值并写入目标结构体，
+// 返回按键排序的有效配置 Effective，其中带 secret 标签或键名包含敏感关键字的配置项
+// 会被遮蔽，适合在启动日志中输出。
+//
+// DebugFlags 描述可在运行时切换的调试开关：pprof 剖析接口、SQL 语句日志、HTTP 请求追踪采样率和日志级别覆盖。
+// DebugRegistry 保存当前开关并在变化时通知 OnChange 的订阅者，Load 通过 Bootstrap 从配置文件与环境变量加载开关；
+// log.ApplyDebugFlags、database/sql/driver.NewHookLogVerbose 和 net/http.NewSampledTraceHook 读取注册表，
+// 未指定注册表时使用 DefaultDebugRegistry。
 //
 // CurrentVersion 是默认构建信息实例。
 //   - 它作为值可直接参与 fmt 格式化输出；默认格式返回简短版本串 version
@@ -14,7 +23,8 @@
 //     生成的多行诊断文本。
 //   - 由于 CurrentVersion 是包级变量，调用方可直接调用 CurrentVersion.Version()、
 //     CurrentVersion.GitVersion()、CurrentVersion.BuildTimeString() 和
-//     CurrentVersion.Description() 获取版本与构建信息。
+//     CurrentVersion.Description() 获取版本与构建信息；CurrentVersion.SemVer() 将软件
+//     版本号解析为 github.com/fsyyft-go/kit/semver.Version，便于按版本约束判断。
 //   - 当需要按 fmt.Stringer 或 github.com/fsyyft-go/kit/go/build.BuildingContext
 //     接口传递版本信息时，应使用 &CurrentVersion；相关访问器以及 String 和
 //     Description 方法由 *version 实现，并直接透传底层构建字段。
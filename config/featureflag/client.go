@@ -0,0 +1,147 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package featureflag
+
+import (
+	"github.com/spf13/cast"
+)
+
+type (
+	// Client 使用 Provider 评估功能开关。
+	//
+	// 开关不存在或取值无法转换为定义的类型时返回定义中的默认值，评估本身不会失败，
+	// 便于在业务代码中直接使用。Client 是并发安全的，前提是 Provider 并发安全。
+	Client struct {
+		// provider 是功能开关配置的提供者。
+		provider Provider
+		// onInvalid 在取值无法转换时调用。
+		onInvalid func(key string, value any, err error)
+	}
+
+	// ClientOption 配置 Client 的行为。
+	ClientOption func(*Client)
+)
+
+// WithOnInvalid 设置取值无法转换为开关类型时的回调，便于记录配置错误。
+//
+// 参数：
+//   - fn: 回调函数，参数为开关的键、原始取值和转换错误。
+//
+// 返回：
+//   - ClientOption: Client 配置选项。
+func WithOnInvalid(fn func(key string, value any, err error)) ClientOption {
+	return func(c *Client) {
+		c.onInvalid = fn
+	}
+}
+
+// NewClient 创建使用 provider 评估功能开关的客户端。
+//
+// 参数：
+//   - provider: 功能开关配置的提供者。
+//   - opts: 可选配置。
+//
+// 返回：
+//   - *Client: 功能开关客户端。
+func NewClient(provider Provider, opts ...ClientOption) *Client {
+	c := &Client{provider: provider}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Bool 评估布尔类型的功能开关。
+//
+// 参数：
+//   - flag: 功能开关定义。
+//   - ec: 评估上下文。
+//
+// 返回：
+//   - bool: 开关取值；不存在或无法转换时返回 flag.Default。
+func (c *Client) Bool(flag BoolFlag, ec EvalContext) bool {
+	return evaluate(c, flag.Key, ec, flag.Default, cast.ToBoolE)
+}
+
+// Int 评估整数类型的功能开关。
+//
+// 参数：
+//   - flag: 功能开关定义。
+//   - ec: 评估上下文。
+//
+// 返回：
+//   - int64: 开关取值；不存在或无法转换时返回 flag.Default。
+func (c *Client) Int(flag IntFlag, ec EvalContext) int64 {
+	return evaluate(c, flag.Key, ec, flag.Default, cast.ToInt64E)
+}
+
+// String 评估字符串类型的功能开关。
+//
+// 参数：
+//   - flag: 功能开关定义。
+//   - ec: 评估上下文。
+//
+// 返回：
+//   - string: 开关取值；不存在或无法转换时返回 flag.Default。
+func (c *Client) String(flag StringFlag, ec EvalContext) string {
+	return evaluate(c, flag.Key, ec, flag.Default, cast.ToStringE)
+}
+
+// Enabled 评估百分比灰度类型的功能开关，判断当前用户是否落入灰度范围。
+//
+// 百分比取值支持数字和 "30%" 形式的字符串，可通过规则为特定属性设置不同的百分比，
+// 例如对内部员工设置 100。
+//
+// 参数：
+//   - flag: 功能开关定义。
+//   - ec: 评估上下文，UserID 用于分桶。
+//
+// 返回：
+//   - bool: 用户落入灰度范围时返回 true。
+func (c *Client) Enabled(flag PercentFlag, ec EvalContext) bool {
+	percent := evaluate(c, flag.Key, ec, flag.Default, toPercent)
+	return inRollout(flag.Key, ec.UserID, percent)
+}
+
+// OnChange 订阅功能开关配置的变化。
+//
+// 参数：
+//   - fn: 配置变化后调用的回调，参数为按字典序排列的变化键。
+//
+// 返回：
+//   - func(): 取消订阅的函数。
+func (c *Client) OnChange(fn func(keys []string)) func() {
+	return c.provider.Subscribe(fn)
+}
+
+// evaluate 查找功能开关配置、匹配规则并转换为目标类型。
+//
+// 参数：
+//   - c: 功能开关客户端。
+//   - key: 功能开关的键。
+//   - ec: 评估上下文。
+//   - def: 默认值。
+//   - convert: 类型转换函数。
+//
+// 返回：
+//   - T: 开关取值；不存在、取值为 nil 或无法转换时返回 def。
+func evaluate[T any](c *Client, key string, ec EvalContext, def T, convert func(any) (T, error)) T {
+	spec, ok := c.provider.Lookup(key)
+	if !ok {
+		return def
+	}
+	raw := spec.resolve(ec)
+	if nil == raw {
+		return def
+	}
+	v, err := convert(raw)
+	if nil != err {
+		if nil != c.onInvalid {
+			c.onInvalid(key, raw, err)
+		}
+		return def
+	}
+	return v
+}
@@ -0,0 +1,114 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package featureflag
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// mapProvider 是直接保存 Spec 的测试用提供者。
+type mapProvider struct {
+	store
+}
+
+// Lookup 返回指定键的功能开关配置。
+//
+// 参数：
+//   - key: 功能开关的键。
+//
+// 返回：
+//   - Spec: 功能开关配置。
+//   - bool: 配置存在时返回 true。
+func (p *mapProvider) Lookup(key string) (Spec, bool) {
+	return p.lookup(key)
+}
+
+// newMapProvider 创建包含给定配置的测试用提供者。
+//
+// 参数：
+//   - specs: 初始功能开关配置。
+//
+// 返回：
+//   - *mapProvider: 测试用提供者。
+func newMapProvider(specs map[string]Spec) *mapProvider {
+	p := &mapProvider{}
+	p.replace(specs)
+	return p
+}
+
+// TestClient_Evaluate 验证各类型开关的取值、默认值和转换失败回调。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestClient_Evaluate(t *testing.T) {
+	provider := newMapProvider(map[string]Spec{
+		"checkout.new_flow": {Value: "true"},
+		"search.limit":      {Value: 20, Rules: []Rule{{Attribute: "plan", Values: []string{"pro"}, Value: 100}}},
+		"search.engine":     {Value: "v2"},
+		"checkout.rollout":  {Value: "0%", Rules: []Rule{{Attribute: "staff", Values: []string{"true"}, Value: 100}}},
+		"broken.bool":       {Value: "maybe"},
+		"null.value":        {Value: nil},
+	})
+	var invalid []string
+	client := NewClient(provider, WithOnInvalid(func(key string, value any, err error) {
+		invalid = append(invalid, key)
+	}))
+	pro := EvalContext{UserID: "alice", Attributes: map[string]string{"plan": "pro"}}
+	staff := EvalContext{UserID: "bob", Attributes: map[string]string{"staff": "true"}}
+
+	tests := []struct {
+		name        string
+		description string
+		evaluate    func() any
+		want        any
+		wantInvalid []string
+	}{
+		{name: "success/bool", description: "验证字符串取值转换为布尔值。", evaluate: func() any { return client.Bool(Bool("checkout.new_flow", false), EvalContext{}) }, want: true},
+		{name: "success/int-default-value", description: "验证没有规则命中时使用配置取值。", evaluate: func() any { return client.Int(Int("search.limit", 10), EvalContext{}) }, want: int64(20)},
+		{name: "success/int-rule", description: "验证规则命中时使用规则取值。", evaluate: func() any { return client.Int(Int("search.limit", 10), pro) }, want: int64(100)},
+		{name: "success/string", description: "验证字符串开关。", evaluate: func() any { return client.String(String("search.engine", "v1"), EvalContext{}) }, want: "v2"},
+		{name: "success/percent-rule", description: "验证规则将特定用户的灰度比例提升到 100%。", evaluate: func() any { return client.Enabled(Percent("checkout.rollout", 50), staff) }, want: true},
+		{name: "success/percent-zero", description: "验证 0% 时用户不命中。", evaluate: func() any { return client.Enabled(Percent("checkout.rollout", 100), pro) }, want: false},
+		{name: "boundary/missing", description: "验证开关不存在时返回定义中的默认值。", evaluate: func() any { return client.String(String("missing", "fallback"), EvalContext{}) }, want: "fallback"},
+		{name: "boundary/missing-percent", description: "验证灰度开关不存在时使用默认百分比。", evaluate: func() any { return client.Enabled(Percent("missing", 100), EvalContext{}) }, want: true},
+		{name: "boundary/nil-value", description: "验证取值为 nil 时返回默认值且不视为无效。", evaluate: func() any { return client.Bool(Bool("null.value", true), EvalContext{}) }, want: true},
+		{name: "error/invalid-value", description: "验证取值无法转换时返回默认值并调用回调。", evaluate: func() any { return client.Bool(Bool("broken.bool", true), EvalContext{}) }, want: true, wantInvalid: []string{"broken.bool"}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+			invalid = nil
+
+			assert.Equal(t, tt.want, tt.evaluate())
+			assert.Equal(t, tt.wantInvalid, invalid)
+		})
+	}
+}
+
+// TestClient_OnChange 验证订阅变化通知和取消订阅。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestClient_OnChange(t *testing.T) {
+	provider := newMapProvider(map[string]Spec{"a": {Value: 1}, "b": {Value: 2}})
+	client := NewClient(provider)
+
+	var got [][]string
+	unsubscribe := client.OnChange(func(keys []string) { got = append(got, keys) })
+
+	provider.replace(map[string]Spec{"a": {Value: 1}, "b": {Value: 3}, "c": {Value: 4}})
+	provider.replace(map[string]Spec{"b": {Value: 3}, "c": {Value: 4}})
+	provider.replace(map[string]Spec{"b": {Value: 3}, "c": {Value: 4}})
+	unsubscribe()
+	unsubscribe()
+	provider.replace(map[string]Spec{})
+
+	assert.Equal(t, [][]string{{"b", "c"}, {"a"}}, got)
+	assert.NotNil(t, client.OnChange(nil))
+}
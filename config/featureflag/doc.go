@@ -0,0 +1,16 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+// Package featureflag 提供类型化的功能开关定义、按上下文评估和配置变化通知。
+//
+// Bool、Int、String 和 Percent 定义带默认值的功能开关；Client 通过 Provider 查找开关配置，
+// 按 EvalContext 中的用户标识和属性匹配规则，并把取值转换为定义的类型。开关不存在或取值
+// 无法转换时返回默认值，评估不会失败。百分比灰度按开关键和用户标识的哈希分桶，
+// 同一用户的结果稳定，提高百分比只会新增命中用户。
+//
+// NewLocalProvider 从本地 YAML/JSON 文件读取开关，并允许带前缀的环境变量覆盖单个开关；
+// NewKratosProvider 读取 Kratos 配置的子树，与应用配置共用配置源并随配置推送更新。
+// 远程配置中心可以实现 Provider 接口接入。Provider 的配置变化通过 Subscribe 或
+// Client.OnChange 通知订阅者，回调参数为变化的开关键。
+package featureflag
@@ -0,0 +1,210 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package featureflag
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"strconv"
+
+	"github.com/spf13/cast"
+)
+
+const (
+	// AttributeUserID 是规则中引用 EvalContext.UserID 的属性名。
+	AttributeUserID = "user_id"
+
+	// percentBuckets 是百分比灰度的分桶数量，支持两位小数精度。
+	percentBuckets = 10000
+)
+
+type (
+	// EvalContext 是评估功能开关时的上下文。
+	EvalContext struct {
+		// UserID 是当前用户标识，用于百分比灰度分桶；规则中可通过属性名 "user_id" 引用。
+		UserID string
+		// Attributes 是用于规则匹配的附加属性，例如地区、版本和渠道。
+		Attributes map[string]string
+	}
+
+	// Rule 是按属性匹配的取值规则。
+	Rule struct {
+		// Attribute 是参与匹配的属性名。
+		Attribute string `json:"attribute" yaml:"attribute"`
+		// Values 是允许的属性值，属性值等于其中任一项时规则命中。
+		Values []string `json:"values" yaml:"values"`
+		// Value 是规则命中时的标志取值。
+		Value any `json:"value" yaml:"value"`
+	}
+
+	// Spec 是提供者返回的单个功能开关配置。
+	Spec struct {
+		// Value 是没有规则命中时的标志取值。
+		Value any `json:"value" yaml:"value"`
+		// Rules 是按顺序匹配的取值规则，第一条命中的规则生效。
+		Rules []Rule `json:"rules,omitempty" yaml:"rules,omitempty"`
+	}
+
+	// BoolFlag 是布尔类型的功能开关定义。
+	BoolFlag struct {
+		// Key 是功能开关的键。
+		Key string
+		// Default 是提供者中不存在该开关或取值无法转换时的默认值。
+		Default bool
+	}
+
+	// IntFlag 是整数类型的功能开关定义。
+	IntFlag struct {
+		// Key 是功能开关的键。
+		Key string
+		// Default 是提供者中不存在该开关或取值无法转换时的默认值。
+		Default int64
+	}
+
+	// StringFlag 是字符串类型的功能开关定义。
+	StringFlag struct {
+		// Key 是功能开关的键。
+		Key string
+		// Default 是提供者中不存在该开关或取值无法转换时的默认值。
+		Default string
+	}
+
+	// PercentFlag 是百分比灰度类型的功能开关定义。
+	//
+	// 取值为 0 到 100 之间的百分比，评估结果为当前用户是否落入灰度范围。
+	PercentFlag struct {
+		// Key 是功能开关的键，同时参与分桶，保证不同开关的灰度用户相互独立。
+		Key string
+		// Default 是提供者中不存在该开关或取值无法转换时的默认百分比。
+		Default float64
+	}
+)
+
+// Bool 定义一个布尔类型的功能开关。
+//
+// 参数：
+//   - key: 功能开关的键。
+//   - def: 默认值。
+//
+// 返回：
+//   - BoolFlag: 功能开关定义。
+func Bool(key string, def bool) BoolFlag {
+	return BoolFlag{Key: key, Default: def}
+}
+
+// Int 定义一个整数类型的功能开关。
+//
+// 参数：
+//   - key: 功能开关的键。
+//   - def: 默认值。
+//
+// 返回：
+//   - IntFlag: 功能开关定义。
+func Int(key string, def int64) IntFlag {
+	return IntFlag{Key: key, Default: def}
+}
+
+// String 定义一个字符串类型的功能开关。
+//
+// 参数：
+//   - key: 功能开关的键。
+//   - def: 默认值。
+//
+// 返回：
+//   - StringFlag: 功能开关定义。
+func String(key string, def string) StringFlag {
+	return StringFlag{Key: key, Default: def}
+}
+
+// Percent 定义一个百分比灰度类型的功能开关。
+//
+// 参数：
+//   - key: 功能开关的键。
+//   - def: 默认百分比，范围为 0 到 100。
+//
+// 返回：
+//   - PercentFlag: 功能开关定义。
+func Percent(key string, def float64) PercentFlag {
+	return PercentFlag{Key: key, Default: def}
+}
+
+// attribute 返回上下文中指定属性的值。
+//
+// 参数：
+//   - name: 属性名；为 "user_id" 且 Attributes 中不存在同名属性时返回 UserID。
+//
+// 返回：
+//   - string: 属性值。
+//   - bool: 属性存在时返回 true。
+func (ec EvalContext) attribute(name string) (string, bool) {
+	if v, ok := ec.Attributes[name]; ok {
+		return v, true
+	}
+	if AttributeUserID == name && "" != ec.UserID {
+		return ec.UserID, true
+	}
+	return "", false
+}
+
+// resolve 按规则返回上下文下的标志取值。
+//
+// 参数：
+//   - ec: 评估上下文。
+//
+// 返回：
+//   - any: 第一条命中规则的取值；没有规则命中时返回 Value。
+func (s Spec) resolve(ec EvalContext) any {
+	for _, rule := range s.Rules {
+		v, ok := ec.attribute(rule.Attribute)
+		if !ok {
+			continue
+		}
+		for _, want := range rule.Values {
+			if want == v {
+				return rule.Value
+			}
+		}
+	}
+	return s.Value
+}
+
+// inRollout 判断用户是否落入百分比灰度范围。
+//
+// 用户按 SHA-256(key + ":" + userID) 均匀分到 10000 个桶中，同一用户在同一开关上的结果稳定，
+// 提高百分比只会新增用户而不会移出已命中的用户。没有 UserID 的上下文只在百分比不小于 100 时命中。
+//
+// 参数：
+//   - key: 功能开关的键。
+//   - userID: 用户标识。
+//   - percent: 灰度百分比。
+//
+// 返回：
+//   - bool: 用户落入灰度范围时返回 true。
+func inRollout(key, userID string, percent float64) bool {
+	switch {
+	case percent >= 100:
+		return true
+	case percent <= 0 || "" == userID:
+		return false
+	}
+	sum := sha256.Sum256([]byte(key + ":" + userID))
+	bucket := binary.BigEndian.Uint64(sum[:8]) % percentBuckets
+	return float64(bucket) < percent*percentBuckets/100
+}
+
+// toPercent 将取值转换为百分比。
+//
+// 参数：
+//   - v: 待转换的取值，支持数字和 "30"、"30%" 形式的字符串。
+//
+// 返回：
+//   - float64: 百分比。
+//   - error: 取值无法转换时返回错误。
+func toPercent(v any) (float64, error) {
+	if s, ok := v.(string); ok && len(s) > 0 && '%' == s[len(s)-1] {
+		return strconv.ParseFloat(s[:len(s)-1], 64)
+	}
+	return cast.ToFloat64E(v)
+}
@@ -0,0 +1,132 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package featureflag
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSpec_Resolve 验证规则按顺序匹配属性并回退到默认取值。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestSpec_Resolve(t *testing.T) {
+	spec := Spec{
+		Value: "v1",
+		Rules: []Rule{
+			{Attribute: "region", Values: []string{"cn", "sg"}, Value: "v2"},
+			{Attribute: AttributeUserID, Values: []string{"alice"}, Value: "v3"},
+			{Attribute: "region", Values: []string{"us"}, Value: "v4"},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		description string
+		giveContext EvalContext
+		want        any
+	}{
+		{name: "success/attribute-match", description: "验证属性命中规则时使用规则取值。", giveContext: EvalContext{Attributes: map[string]string{"region": "sg"}}, want: "v2"},
+		{name: "success/user-id-match", description: "验证规则可通过 user_id 引用 UserID。", giveContext: EvalContext{UserID: "alice"}, want: "v3"},
+		{name: "success/first-rule-wins", description: "验证多条规则命中时第一条生效。", giveContext: EvalContext{UserID: "alice", Attributes: map[string]string{"region": "cn"}}, want: "v2"},
+		{name: "success/attribute-overrides-user-id", description: "验证 Attributes 中的 user_id 优先于 UserID。", giveContext: EvalContext{UserID: "alice", Attributes: map[string]string{AttributeUserID: "bob"}}, want: "v1"},
+		{name: "boundary/no-match", description: "验证没有规则命中时使用默认取值。", giveContext: EvalContext{Attributes: map[string]string{"region": "jp"}}, want: "v1"},
+		{name: "boundary/empty-context", description: "验证空上下文使用默认取值。", giveContext: EvalContext{}, want: "v1"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			assert.Equal(t, tt.want, spec.resolve(tt.giveContext))
+		})
+	}
+}
+
+// TestInRollout 验证百分比灰度的边界、比例和单调性。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestInRollout(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		giveUserID  string
+		givePercent float64
+		want        bool
+	}{
+		{name: "boundary/full", description: "验证 100% 时所有用户命中，包括没有 UserID 的上下文。", givePercent: 100, want: true},
+		{name: "boundary/zero", description: "验证 0% 时所有用户都不命中。", giveUserID: "alice", givePercent: 0, want: false},
+		{name: "boundary/no-user", description: "验证没有 UserID 且百分比小于 100 时不命中。", givePercent: 99.99, want: false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			assert.Equal(t, tt.want, inRollout("checkout", tt.giveUserID, tt.givePercent))
+		})
+	}
+
+	t.Run("success/distribution-and-monotonic", func(t *testing.T) {
+		t.Log("验证命中比例接近设定百分比，且提高百分比不会移出已命中的用户。")
+
+		hits10, hits30 := 0, 0
+		for i := 0; i < 20000; i++ {
+			user := "user-" + strconv.Itoa(i)
+			in10 := inRollout("checkout", user, 10)
+			in30 := inRollout("checkout", user, 30)
+			if in10 {
+				hits10++
+				require.True(t, in30, "user %s", user)
+			}
+			if in30 {
+				hits30++
+			}
+		}
+		assert.InDelta(t, 2000, hits10, 300)
+		assert.InDelta(t, 6000, hits30, 500)
+	})
+}
+
+// TestToPercent 验证百分比取值的转换。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestToPercent(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		give        any
+		want        float64
+		wantErr     bool
+	}{
+		{name: "success/number", description: "验证数字直接作为百分比。", give: 25, want: 25},
+		{name: "success/string", description: "验证数字字符串。", give: "12.5", want: 12.5},
+		{name: "success/percent-suffix", description: "验证带 % 后缀的字符串。", give: "30%", want: 30},
+		{name: "error/invalid", description: "验证无法解析的字符串返回错误。", give: "half%", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			got, err := toPercent(tt.give)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
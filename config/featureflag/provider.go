@@ -0,0 +1,233 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package featureflag
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+
+	"github.com/spf13/cast"
+)
+
+var (
+	// ErrInvalidSpec 表示功能开关配置的结构无法解析。
+	ErrInvalidSpec = errors.New("featureflag: invalid spec")
+)
+
+type (
+	// Provider 是功能开关配置的提供者。
+	//
+	// 本地文件、环境变量和 Kratos 配置由本包内置实现；远程配置中心等提供者只需实现该接口，
+	// 在本地缓存最新配置并在变化时通知订阅者。Lookup 会在每次评估时调用，实现应避免阻塞。
+	Provider interface {
+		// Lookup 返回指定键的功能开关配置。
+		//
+		// 参数：
+		//   - key: 功能开关的键。
+		//
+		// 返回：
+		//   - Spec: 功能开关配置。
+		//   - bool: 配置存在时返回 true。
+		Lookup(key string) (Spec, bool)
+
+		// Subscribe 订阅功能开关配置的变化。
+		//
+		// 参数：
+		//   - fn: 配置变化后调用的回调，参数为按字典序排列的变化键，包括新增、修改和删除的键。
+		//
+		// 返回：
+		//   - func(): 取消订阅的函数，可重复调用。
+		Subscribe(fn func(keys []string)) func()
+	}
+
+	// store 是并发安全的功能开关配置集合，负责比较变化并通知订阅者。
+	//
+	// 内置提供者嵌入 store，远程提供者也可以参照它的方式实现 Provider。
+	store struct {
+		// mu 保护 specs、subscribers 和 nextID。
+		mu sync.RWMutex
+		// specs 是当前的功能开关配置。
+		specs map[string]Spec
+		// subscribers 是按订阅编号保存的变化回调。
+		subscribers map[int]func(keys []string)
+		// nextID 是下一个订阅编号。
+		nextID int
+	}
+)
+
+// lookup 返回指定键的功能开关配置。
+//
+// 参数：
+//   - key: 功能开关的键。
+//
+// 返回：
+//   - Spec: 功能开关配置。
+//   - bool: 配置存在时返回 true。
+func (s *store) lookup(key string) (Spec, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	spec, ok := s.specs[key]
+	return spec, ok
+}
+
+// Subscribe 订阅功能开关配置的变化。
+//
+// 参数：
+//   - fn: 配置变化后调用的回调；为 nil 时不订阅。
+//
+// 返回：
+//   - func(): 取消订阅的函数，可重复调用。
+func (s *store) Subscribe(fn func(keys []string)) func() {
+	if nil == fn {
+		return func() {}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if nil == s.subscribers {
+		s.subscribers = make(map[int]func(keys []string))
+	}
+	id := s.nextID
+	s.nextID++
+	s.subscribers[id] = fn
+	return func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		delete(s.subscribers, id)
+	}
+}
+
+// replace 用新的配置替换当前配置，并在有变化时同步通知订阅者。
+//
+// 参数：
+//   - specs: 新的功能开关配置。
+//
+// 返回：
+//   - []string: 按字典序排列的变化键；没有变化时为空。
+func (s *store) replace(specs map[string]Spec) []string {
+	s.mu.Lock()
+	var changed []string
+	for key, spec := range specs {
+		if old, ok := s.specs[key]; !ok || !reflect.DeepEqual(old, spec) {
+			changed = append(changed, key)
+		}
+	}
+	for key := range s.specs {
+		if _, ok := specs[key]; !ok {
+			changed = append(changed, key)
+		}
+	}
+	s.specs = specs
+	subscribers := make([]func(keys []string), 0, len(s.subscribers))
+	for _, fn := range s.subscribers {
+		subscribers = append(subscribers, fn)
+	}
+	s.mu.Unlock()
+
+	if 0 == len(changed) {
+		return nil
+	}
+	sort.Strings(changed)
+	for _, fn := range subscribers {
+		fn(changed)
+	}
+	return changed
+}
+
+// parseSpecs 将嵌套的配置 map 解析为以点分键索引的功能开关配置。
+//
+// 包含 "value" 字段的 map 视为完整的 Spec，可带 "rules"；其他 map 按层级拼接键名继续展开；
+// 标量和数组直接作为 Spec.Value。
+//
+// 参数：
+//   - prefix: 当前层级的键前缀。
+//   - raw: 待解析的配置 map。
+//   - out: 解析结果的输出。
+//
+// 返回：
+//   - error: 规则结构无法解析时返回包装了 ErrInvalidSpec 的错误。
+func parseSpecs(prefix string, raw map[string]any, out map[string]Spec) error {
+	for name, v := range raw {
+		key := name
+		if "" != prefix {
+			key = prefix + "." + name
+		}
+		m, ok := toStringMap(v)
+		if !ok {
+			out[key] = Spec{Value: v}
+			continue
+		}
+		if _, ok := m["value"]; !ok {
+			if err := parseSpecs(key, m, out); nil != err {
+				return err
+			}
+			continue
+		}
+		spec, err := parseSpec(key, m)
+		if nil != err {
+			return err
+		}
+		out[key] = spec
+	}
+	return nil
+}
+
+// parseSpec 解析包含 "value" 和可选 "rules" 字段的功能开关配置。
+//
+// 参数：
+//   - key: 功能开关的键，用于错误信息。
+//   - m: 功能开关配置 map。
+//
+// 返回：
+//   - Spec: 解析后的功能开关配置。
+//   - error: 规则结构无法解析时返回包装了 ErrInvalidSpec 的错误。
+func parseSpec(key string, m map[string]any) (Spec, error) {
+	spec := Spec{Value: m["value"]}
+	rawRules, ok := m["rules"]
+	if !ok || nil == rawRules {
+		return spec, nil
+	}
+	rules, ok := rawRules.([]any)
+	if !ok {
+		return Spec{}, fmt.Errorf("%w: %s: rules must be a list", ErrInvalidSpec, key)
+	}
+	for i, raw := range rules {
+		rm, ok := toStringMap(raw)
+		if !ok {
+			return Spec{}, fmt.Errorf("%w: %s: rules[%d] must be a map", ErrInvalidSpec, key, i)
+		}
+		values, err := cast.ToStringSliceE(rm["values"])
+		if nil != err {
+			return Spec{}, fmt.Errorf("%w: %s: rules[%d].values: %v", ErrInvalidSpec, key, i, err)
+		}
+		spec.Rules = append(spec.Rules, Rule{
+			Attribute: cast.ToString(rm["attribute"]),
+			Values:    values,
+			Value:     rm["value"],
+		})
+	}
+	return spec, nil
+}
+
+// toStringMap 将 map[string]any 或 map[any]any 转换为 map[string]any。
+//
+// 参数：
+//   - v: 待转换的取值。
+//
+// 返回：
+//   - map[string]any: 转换结果。
+//   - bool: v 是 map 时返回 true。
+func toStringMap(v any) (map[string]any, bool) {
+	switch m := v.(type) {
+	case map[string]any:
+		return m, true
+	case map[any]any:
+		return cast.ToStringMap(m), true
+	default:
+		return nil, false
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package featureflag
+
+import (
+	"fmt"
+
+	kratosconfig "github.com/go-kratos/kratos/v2/config"
+)
+
+var (
+	_ Provider = (*KratosProvider)(nil)
+)
+
+type (
+	// KratosProvider 从 Kratos 配置的指定子树读取功能开关配置。
+	//
+	// 功能开关与应用配置共用 Kratos 配置源（文件、环境变量或配置中心），配置源推送变化后
+	// KratosProvider 会重新读取子树并通知订阅者。
+	KratosProvider struct {
+		store
+
+		// config 是 Kratos 配置实例。
+		config kratosconfig.Config
+		// key 是功能开关子树的键。
+		key string
+	}
+)
+
+// NewKratosProvider 创建从 Kratos 配置子树读取功能开关的提供者。
+//
+// config 必须已经调用 Load。NewKratosProvider 会通过 config.Watch 监听 key 的变化；
+// Kratos 对每个键只保留一个观察者，因此不应再对同一个 key 调用 Watch。
+//
+// 参数：
+//   - config: 已加载的 Kratos 配置实例。
+//   - key: 功能开关子树的键，例如 "feature_flags"。
+//
+// 返回：
+//   - *KratosProvider: 已读取当前配置的提供者。
+//   - error: 子树不存在、解析失败或注册监听失败时返回错误。
+func NewKratosProvider(config kratosconfig.Config, key string) (*KratosProvider, error) {
+	p := &KratosProvider{
+		config: config,
+		key:    key,
+	}
+	if err := p.reload(config.Value(key)); nil != err {
+		return nil, err
+	}
+	if err := config.Watch(key, func(_ string, v kratosconfig.Value) {
+		// 变化后的配置解析失败时保留原有配置，等待下一次推送。
+		_ = p.reload(v)
+	}); nil != err {
+		return nil, fmt.Errorf("watch feature flags %s: %w", key, err)
+	}
+	return p, nil
+}
+
+// Lookup 返回指定键的功能开关配置。
+//
+// 参数：
+//   - key: 功能开关相对于子树的键。
+//
+// 返回：
+//   - Spec: 功能开关配置。
+//   - bool: 配置存在时返回 true。
+func (p *KratosProvider) Lookup(key string) (Spec, bool) {
+	return p.lookup(key)
+}
+
+// reload 从配置值重新解析功能开关，并在变化时通知订阅者。
+//
+// 参数：
+//   - v: 功能开关子树的配置值。
+//
+// 返回：
+//   - error: 读取或解析失败时返回错误。
+func (p *KratosProvider) reload(v kratosconfig.Value) error {
+	raw := make(map[string]any)
+	if err := v.Scan(&raw); nil != err {
+		return fmt.Errorf("scan feature flags %s: %w", p.key, err)
+	}
+	specs := make(map[string]Spec)
+	if err := parseSpecs("", raw, specs); nil != err {
+		return fmt.Errorf("parse feature flags %s: %w", p.key, err)
+	}
+	p.replace(specs)
+	return nil
+}
@@ -0,0 +1,112 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package featureflag
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	kratosconfig "github.com/go-kratos/kratos/v2/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memorySource 是可推送变化的内存 Kratos 配置源。
+type memorySource struct {
+	// content 是初始 YAML 配置。
+	content string
+	// updates 传递推送的新 YAML 配置。
+	updates chan string
+}
+
+// memoryWatcher 是 memorySource 的变化监听器。
+type memoryWatcher struct {
+	// source 是被监听的配置源。
+	source *memorySource
+	// ctx 在 Stop 后结束。
+	ctx context.Context
+	// cancel 结束 ctx。
+	cancel context.CancelFunc
+}
+
+// Load 返回初始配置。
+//
+// 返回：
+//   - []*kratosconfig.KeyValue: 初始配置。
+//   - error: 始终为 nil。
+func (s *memorySource) Load() ([]*kratosconfig.KeyValue, error) {
+	return []*kratosconfig.KeyValue{{Key: "memory", Value: []byte(s.content), Format: "yaml"}}, nil
+}
+
+// Watch 返回变化监听器。
+//
+// 返回：
+//   - kratosconfig.Watcher: 变化监听器。
+//   - error: 始终为 nil。
+func (s *memorySource) Watch() (kratosconfig.Watcher, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &memoryWatcher{source: s, ctx: ctx, cancel: cancel}, nil
+}
+
+// Next 等待下一次推送的配置。
+//
+// 返回：
+//   - []*kratosconfig.KeyValue: 推送的配置。
+//   - error: Stop 后返回 context.Canceled。
+func (w *memoryWatcher) Next() ([]*kratosconfig.KeyValue, error) {
+	select {
+	case content := <-w.source.updates:
+		return []*kratosconfig.KeyValue{{Key: "memory", Value: []byte(content), Format: "yaml"}}, nil
+	case <-w.ctx.Done():
+		return nil, w.ctx.Err()
+	}
+}
+
+// Stop 停止监听。
+//
+// 返回：
+//   - error: 始终为 nil。
+func (w *memoryWatcher) Stop() error {
+	w.cancel()
+	return nil
+}
+
+// TestNewKratosProvider 验证从 Kratos 配置子树读取功能开关并响应配置源推送。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestNewKratosProvider(t *testing.T) {
+	source := &memorySource{
+		content: "app:\n  name: demo\nfeature_flags:\n  checkout:\n    new_flow: false\n  search.engine: v2\n",
+		updates: make(chan string),
+	}
+	config := kratosconfig.New(kratosconfig.WithSource(source))
+	require.NoError(t, config.Load())
+	t.Cleanup(func() { _ = config.Close() })
+
+	provider, err := NewKratosProvider(config, "feature_flags")
+	require.NoError(t, err)
+	client := NewClient(provider)
+	newFlow := Bool("checkout.new_flow", true)
+
+	assert.False(t, client.Bool(newFlow, EvalContext{}))
+	assert.Equal(t, "v2", client.String(String("search.engine", "v1"), EvalContext{}))
+
+	changed := make(chan []string, 1)
+	client.OnChange(func(keys []string) { changed <- keys })
+	source.updates <- "app:\n  name: demo\nfeature_flags:\n  checkout:\n    new_flow: true\n  search.engine: v2\n"
+
+	select {
+	case keys := <-changed:
+		assert.Equal(t, []string{"checkout.new_flow"}, keys)
+	case <-time.After(2 * time.Second):
+		require.Fail(t, "timed out waiting for feature flag change")
+	}
+	assert.True(t, client.Bool(newFlow, EvalContext{}))
+
+	_, err = NewKratosProvider(config, "missing")
+	assert.Error(t, err)
+}
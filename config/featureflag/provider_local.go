@@ -0,0 +1,180 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package featureflag
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	_ Provider = (*LocalProvider)(nil)
+)
+
+type (
+	// LocalProvider 从本地文件和环境变量读取功能开关配置。
+	//
+	// 环境变量优先于文件：评估键为 "checkout.new_flow" 的开关时，会先查找
+	// 前缀加 "CHECKOUT_NEW_FLOW" 的环境变量，存在时直接作为开关取值，忽略文件中的规则。
+	// 文件只在创建和调用 Reload 时读取，Reload 发现变化后通知订阅者；环境变量在每次评估时读取，不产生通知。
+	LocalProvider struct {
+		store
+
+		// path 是配置文件路径；为空时不读取文件。
+		path string
+		// envPrefix 是环境变量前缀；为空时不读取环境变量。
+		envPrefix string
+		// lookupEnv 查询环境变量。
+		lookupEnv func(string) (string, bool)
+	}
+
+	// LocalOption 配置 LocalProvider 的行为。
+	LocalOption func(*LocalProvider)
+)
+
+// WithFile 设置功能开关配置文件。
+//
+// 文件格式按扩展名识别：.json 使用 JSON，其他扩展名使用 YAML。
+//
+// 参数：
+//   - path: 配置文件路径。
+//
+// 返回：
+//   - LocalOption: LocalProvider 配置选项。
+func WithFile(path string) LocalOption {
+	return func(p *LocalProvider) {
+		p.path = path
+	}
+}
+
+// WithEnvPrefix 设置覆盖功能开关的环境变量前缀。
+//
+// 参数：
+//   - prefix: 环境变量前缀，例如 "FF_"；为空时不读取环境变量。
+//
+// 返回：
+//   - LocalOption: LocalProvider 配置选项。
+func WithEnvPrefix(prefix string) LocalOption {
+	return func(p *LocalProvider) {
+		p.envPrefix = prefix
+	}
+}
+
+// WithLookupEnv 设置查询环境变量的函数，主要用于测试。
+//
+// 参数：
+//   - lookup: 环境变量查询函数；为 nil 时保留默认的 os.LookupEnv。
+//
+// 返回：
+//   - LocalOption: LocalProvider 配置选项。
+func WithLookupEnv(lookup func(string) (string, bool)) LocalOption {
+	return func(p *LocalProvider) {
+		if nil != lookup {
+			p.lookupEnv = lookup
+		}
+	}
+}
+
+// NewLocalProvider 创建从本地文件和环境变量读取功能开关的提供者。
+//
+// 参数：
+//   - opts: 配置文件、环境变量前缀等可选配置。
+//
+// 返回：
+//   - *LocalProvider: 已加载配置文件的提供者。
+//   - error: 读取或解析配置文件失败时返回错误。
+func NewLocalProvider(opts ...LocalOption) (*LocalProvider, error) {
+	p := &LocalProvider{
+		lookupEnv: os.LookupEnv,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	if err := p.Reload(); nil != err {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Reload 重新读取配置文件，并在配置变化时通知订阅者。
+//
+// 读取或解析失败时保留原有配置。
+//
+// 参数：无。
+//
+// 返回：
+//   - error: 读取或解析配置文件失败时返回错误。
+func (p *LocalProvider) Reload() error {
+	specs := make(map[string]Spec)
+	if "" != p.path {
+		raw, err := readSpecFile(p.path)
+		if nil != err {
+			return err
+		}
+		if err := parseSpecs("", raw, specs); nil != err {
+			return fmt.Errorf("parse feature flag file %s: %w", p.path, err)
+		}
+	}
+	p.replace(specs)
+	return nil
+}
+
+// Lookup 返回指定键的功能开关配置，环境变量优先于文件。
+//
+// 参数：
+//   - key: 功能开关的键。
+//
+// 返回：
+//   - Spec: 功能开关配置。
+//   - bool: 配置存在时返回 true。
+func (p *LocalProvider) Lookup(key string) (Spec, bool) {
+	if "" != p.envPrefix {
+		if v, ok := p.lookupEnv(p.envPrefix + envName(key)); ok {
+			return Spec{Value: v}, true
+		}
+	}
+	return p.lookup(key)
+}
+
+// envName 将功能开关的键转换为环境变量名。
+//
+// 参数：
+//   - key: 功能开关的键。
+//
+// 返回：
+//   - string: 大写且以下划线替换 "." 和 "-" 的环境变量名。
+func envName(key string) string {
+	return strings.ToUpper(strings.NewReplacer(".", "_", "-", "_").Replace(key))
+}
+
+// readSpecFile 读取并解码功能开关配置文件。
+//
+// 参数：
+//   - path: 配置文件路径。
+//
+// 返回：
+//   - map[string]any: 解码后的配置；空文件返回空 map。
+//   - error: 读取或解码失败时返回错误。
+func readSpecFile(path string) (map[string]any, error) {
+	data, err := os.ReadFile(path)
+	if nil != err {
+		return nil, fmt.Errorf("read feature flag file: %w", err)
+	}
+	raw := make(map[string]any)
+	if strings.EqualFold(".json", filepath.Ext(path)) {
+		err = json.Unmarshal(data, &raw)
+	} else {
+		err = yaml.Unmarshal(data, &raw)
+	}
+	if nil != err {
+		return nil, fmt.Errorf("decode feature flag file %s: %w", path, err)
+	}
+	return raw, nil
+}
@@ -0,0 +1,191 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package featureflag
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeFlagFile 在临时目录写入功能开关配置文件。
+//
+// 参数：
+//   - t: 测试上下文，用于报告写入失败。
+//   - name: 文件名，扩展名决定解析格式。
+//   - content: 文件内容。
+//
+// 返回：
+//   - string: 文件路径。
+func writeFlagFile(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+// TestNewLocalProvider 验证本地文件的解析和环境变量覆盖。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestNewLocalProvider(t *testing.T) {
+	yamlContent := `
+checkout:
+  new_flow: true
+  rollout:
+    value: 30
+    rules:
+      - attribute: region
+        values: [cn, sg]
+        value: 100
+search.engine: v2
+`
+	jsonContent := `{"checkout": {"new_flow": true, "rollout": {"value": 30, "rules": [{"attribute": "region", "values": ["cn", "sg"], "value": 100}]}}, "search.engine": "v2"}`
+	env := map[string]string{"FF_SEARCH_ENGINE": "v3"}
+	lookupEnv := func(name string) (string, bool) {
+		v, ok := env[name]
+		return v, ok
+	}
+
+	tests := []struct {
+		name        string
+		description string
+		giveFile    string
+		giveContent string
+		giveOpts    []LocalOption
+		wantErrIs   error
+		wantErr     bool
+		wantSpecs   map[string]Spec
+	}{
+		{
+			name:        "success/yaml",
+			description: "验证 YAML 文件按层级展开键，并解析带规则的开关。",
+			giveFile:    "flags.yaml",
+			giveContent: yamlContent,
+			wantSpecs: map[string]Spec{
+				"checkout.new_flow": {Value: true},
+				"checkout.rollout":  {Value: 30, Rules: []Rule{{Attribute: "region", Values: []string{"cn", "sg"}, Value: 100}}},
+				"search.engine":     {Value: "v2"},
+			},
+		},
+		{
+			name:        "success/json",
+			description: "验证 JSON 文件按扩展名识别。",
+			giveFile:    "flags.JSON",
+			giveContent: jsonContent,
+			wantSpecs: map[string]Spec{
+				"checkout.new_flow": {Value: true},
+				"checkout.rollout":  {Value: float64(30), Rules: []Rule{{Attribute: "region", Values: []string{"cn", "sg"}, Value: float64(100)}}},
+				"search.engine":     {Value: "v2"},
+			},
+		},
+		{
+			name:        "success/env-overrides-file",
+			description: "验证环境变量优先于文件。",
+			giveFile:    "flags.yaml",
+			giveContent: yamlContent,
+			giveOpts:    []LocalOption{WithEnvPrefix("FF_"), WithLookupEnv(lookupEnv)},
+			wantSpecs: map[string]Spec{
+				"checkout.new_flow": {Value: true},
+				"search.engine":     {Value: "v3"},
+			},
+		},
+		{
+			name:        "boundary/env-only",
+			description: "验证不配置文件时只读取环境变量。",
+			giveOpts:    []LocalOption{WithEnvPrefix("FF_"), WithLookupEnv(lookupEnv)},
+			wantSpecs:   map[string]Spec{"search.engine": {Value: "v3"}},
+		},
+		{
+			name:        "error/invalid-rules",
+			description: "验证规则不是列表时返回 ErrInvalidSpec。",
+			giveFile:    "flags.yaml",
+			giveContent: "a:\n  value: 1\n  rules: oops\n",
+			wantErrIs:   ErrInvalidSpec,
+		},
+		{
+			name:        "error/invalid-rule-item",
+			description: "验证规则项不是 map 时返回 ErrInvalidSpec。",
+			giveFile:    "flags.yaml",
+			giveContent: "a:\n  value: 1\n  rules: [oops]\n",
+			wantErrIs:   ErrInvalidSpec,
+		},
+		{
+			name:        "error/invalid-yaml",
+			description: "验证文件无法解码时返回错误。",
+			giveFile:    "flags.yaml",
+			giveContent: "a: [",
+			wantErr:     true,
+		},
+		{
+			name:        "error/missing-file",
+			description: "验证文件不存在时返回错误。",
+			giveOpts:    []LocalOption{WithFile(filepath.Join(os.TempDir(), "featureflag-missing.yaml"))},
+			wantErrIs:   os.ErrNotExist,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			opts := tt.giveOpts
+			if "" != tt.giveFile {
+				opts = append([]LocalOption{WithFile(writeFlagFile(t, tt.giveFile, tt.giveContent))}, opts...)
+			}
+
+			provider, err := NewLocalProvider(opts...)
+
+			if nil != tt.wantErrIs || tt.wantErr {
+				require.Error(t, err)
+				if nil != tt.wantErrIs {
+					assert.ErrorIs(t, err, tt.wantErrIs)
+				}
+				assert.Nil(t, provider)
+				return
+			}
+			require.NoError(t, err)
+			for key, want := range tt.wantSpecs {
+				got, ok := provider.Lookup(key)
+				assert.True(t, ok, key)
+				assert.Equal(t, want, got, key)
+			}
+			_, ok := provider.Lookup("missing")
+			assert.False(t, ok)
+		})
+	}
+}
+
+// TestLocalProvider_Reload 验证重新加载文件后通知变化的键，且加载失败时保留原有配置。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestLocalProvider_Reload(t *testing.T) {
+	path := writeFlagFile(t, "flags.yaml", "a: 1\nb: 2\n")
+	provider, err := NewLocalProvider(WithFile(path))
+	require.NoError(t, err)
+
+	var notified [][]string
+	provider.Subscribe(func(keys []string) { notified = append(notified, keys) })
+
+	require.NoError(t, provider.Reload())
+	assert.Empty(t, notified)
+
+	require.NoError(t, os.WriteFile(path, []byte("b: 3\nc: 4\n"), 0o600))
+	require.NoError(t, provider.Reload())
+	assert.Equal(t, [][]string{{"a", "b", "c"}}, notified)
+
+	require.NoError(t, os.WriteFile(path, []byte("b: ["), 0o600))
+	require.Error(t, provider.Reload())
+	spec, ok := provider.Lookup("b")
+	assert.True(t, ok)
+	assert.Equal(t, 3, spec.Value)
+	assert.Len(t, notified, 1)
+}
@@ -11,6 +11,7 @@ import (
 	"strings"
 
 	kitgobuild "github.com/fsyyft-go/kit/go/build"
+	kitsemver "github.com/fsyyft-go/kit/semver"
 )
 
 const (
@@ -51,6 +52,20 @@ func (v *version) Version() string {
 	return v.buildingContext.Version()
 }
 
+// SemVer 将软件版本号解析为语义化版本。
+//
+// 软件版本号通常通过 -ldflags 注入，例如 v1.2.3；未注入或格式不符合语义化版本规范时返回错误，
+// 调用方可据此回退到默认行为。
+//
+// 参数：无。
+//
+// 返回：
+//   - kitsemver.Version: 解析后的语义化版本。
+//   - error: 版本号为空或不合法时返回包装 kitsemver.ErrInvalidVersion 的错误。
+func (v *version) SemVer() (kitsemver.Version, error) {
+	return kitsemver.Parse(v.buildingContext.Version())
+}
+
 // GitVersion 返回完整的应用 Git 版本号。
 //
 // 参数：无。
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	kitgobuild "github.com/fsyyft-go/kit/go/build"
+	kitsemver "github.com/fsyyft-go/kit/semver"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -505,3 +506,54 @@ func TestCurrentVersion_BasicInterfaces(t *testing.T) {
 		})
 	}
 }
+
+// TestVersion_SemVer 验证软件版本号按语义化版本解析。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestVersion_SemVer(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		giveVersion string
+		want        kitsemver.Version
+		wantErr     bool
+	}{
+		{
+			name:        "success/v-prefix",
+			description: "验证带 v 前缀的版本号被解析为语义化版本。",
+			giveVersion: "v1.2.3",
+			want:        kitsemver.Version{Major: 1, Minor: 2, Patch: 3},
+		},
+		{
+			name:        "success/prerelease",
+			description: "验证预发布版本号保留预发布标识。",
+			giveVersion: "2.0.0-rc.1",
+			want:        kitsemver.Version{Major: 2, Prerelease: "rc.1"},
+		},
+		{
+			name:        "error/empty",
+			description: "验证未注入版本号时返回错误。",
+			giveVersion: "",
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			v, ctx := newTestVersion()
+			ctx.version = tt.giveVersion
+
+			got, err := v.SemVer()
+			if tt.wantErr {
+				assert.ErrorIs(t, err, kitsemver.ErrInvalidVersion)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
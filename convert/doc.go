@@ -10,4 +10,16 @@
 //
 // 具体输入格式、结构体标签处理和错误信息由 gconv 当前实现决定。本包额外约定无符号整数
 // 转换会先按 int64 解析，负数或负数字符串返回 0 且不产生错误。
+//
+// 枚举转换不依赖 gconv：服务通过 RegisterEnum 或独立的 EnumRegistry 为整数枚举类型注册
+// 名称映射，随后使用 ToEnum 将名称、数值或数字字符串转换为枚举值，使用 EnumString 取回
+// 规范名称；未注册的名称或数值返回 ErrUnknownEnum，而不是静默回退为零值。
+//
+// 可空列转换同样不依赖 gconv：PtrTo、Deref 等泛型函数处理指针与值，Null、FromNull 处理
+// sql.Null[T]，NullString、FromNullTime 等函数在 *T 与标准库 sql.NullXxx 之间转换，
+// 统一约定 nil 指针与 Valid 为 false 互相对应。
+//
+// 时间布局转换衔接 kit/time 使用的 carbon 格式与标准库布局：LayoutToCarbon 与 CarbonToLayout
+// 在 "2006-01-02" 与 "Y-m-d" 两种风格之间转换，无法等价表达时返回 ErrUnsupportedLayout；
+// ParseAny 按 DefaultTimeLayouts 或通过 WithLayouts、WithCarbonFormats 配置的布局依次尝试解析。
 package convert
@@ -0,0 +1,343 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package convert
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var (
+	// ErrUnknownEnum 表示待转换的名称或数值未在枚举中注册。
+	ErrUnknownEnum = errors.New("unknown enum value")
+	// ErrEnumNotRegistered 表示枚举类型尚未注册。
+	ErrEnumNotRegistered = errors.New("enum not registered")
+	// ErrDuplicateEnum 表示枚举类型重复注册，或同一枚举中存在重复名称。
+	ErrDuplicateEnum = errors.New("duplicate enum")
+
+	// defaultEnumRegistry 是 RegisterEnum、ToEnum 等包级函数使用的默认注册表。
+	defaultEnumRegistry = NewEnumRegistry()
+)
+
+type (
+	// Integer 约束可注册为枚举的整数类型，包括以整数为底层类型的自定义类型。
+	Integer interface {
+		~int | ~int8 | ~int16 | ~int32 | ~int64 |
+			~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64
+	}
+
+	// EnumRegistry 保存各枚举类型的名称与数值映射，可安全并发使用。
+	//
+	// 零值不可直接使用，调用方应通过 NewEnumRegistry 创建；多数场景直接使用包级默认注册表即可。
+	EnumRegistry struct {
+		// mu 保护 enums。
+		mu sync.RWMutex
+		// enums 以枚举类型为键保存映射。
+		enums map[reflect.Type]*enumMapping
+	}
+
+	// EnumOption 定义注册枚举时的可选配置。
+	EnumOption func(*enumOptions)
+
+	// enumOptions 保存注册枚举时的可选配置。
+	enumOptions struct {
+		// caseInsensitive 表示按名称解析时忽略大小写。
+		caseInsensitive bool
+	}
+
+	// enumMapping 保存单个枚举类型的双向映射。
+	enumMapping struct {
+		// byName 是名称到数值的映射；忽略大小写时键为小写名称。
+		byName map[string]int64
+		// byValue 是数值到规范名称的映射。
+		byValue map[int64]string
+		// options 是注册时的配置。
+		options enumOptions
+	}
+)
+
+// NewEnumRegistry 创建空的枚举注册表。
+//
+// 返回：
+//   - *EnumRegistry: 可并发使用的注册表。
+func NewEnumRegistry() *EnumRegistry {
+	return &EnumRegistry{enums: make(map[reflect.Type]*enumMapping)}
+}
+
+// WithEnumCaseInsensitive 使按名称解析时忽略大小写，EnumString 仍返回注册时的规范名称。
+//
+// 返回：
+//   - EnumOption: 应用于 RegisterEnum 的配置项。
+func WithEnumCaseInsensitive() EnumOption {
+	return func(o *enumOptions) {
+		o.caseInsensitive = true
+	}
+}
+
+// RegisterEnum 在默认注册表中注册枚举类型 T 的数值与名称映射。
+//
+// 参数：
+//   - names: 数值到规范名称的映射，名称不能为空。
+//   - opts: 可选配置，例如 WithEnumCaseInsensitive。
+//
+// 返回：
+//   - error: T 已注册、名称为空或名称重复时返回 ErrDuplicateEnum 或描述性错误。
+func RegisterEnum[T Integer](names map[T]string, opts ...EnumOption) error {
+	return RegisterEnumIn(defaultEnumRegistry, names, opts...)
+}
+
+// MustRegisterEnum 与 RegisterEnum 相同，注册失败时 panic，适合在 init 或包级变量初始化中使用。
+//
+// 参数：
+//   - names: 数值到规范名称的映射。
+//   - opts: 可选配置。
+func MustRegisterEnum[T Integer](names map[T]string, opts ...EnumOption) {
+	if err := RegisterEnum(names, opts...); nil != err {
+		panic(err)
+	}
+}
+
+// RegisterEnumIn 在指定注册表中注册枚举类型 T 的数值与名称映射。
+//
+// 参数：
+//   - r: 目标注册表。
+//   - names: 数值到规范名称的映射，名称不能为空。
+//   - opts: 可选配置。
+//
+// 返回：
+//   - error: T 已注册、名称为空或名称重复时返回错误，重复时可通过 errors.Is 判断 ErrDuplicateEnum。
+func RegisterEnumIn[T Integer](r *EnumRegistry, names map[T]string, opts ...EnumOption) error {
+	typ := enumType[T]()
+	m := &enumMapping{
+		byName:  make(map[string]int64, len(names)),
+		byValue: make(map[int64]string, len(names)),
+	}
+	for _, opt := range opts {
+		opt(&m.options)
+	}
+
+	for value, name := range names {
+		if "" == name {
+			return fmt.Errorf("empty enum name for %s value %d", typ, value)
+		}
+		key := m.nameKey(name)
+		if _, ok := m.byName[key]; ok {
+			return fmt.Errorf("%w: name %q of %s", ErrDuplicateEnum, name, typ)
+		}
+		m.byName[key] = int64(value)
+		m.byValue[int64(value)] = name
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.enums[typ]; ok {
+		return fmt.Errorf("%w: %s already registered", ErrDuplicateEnum, typ)
+	}
+	r.enums[typ] = m
+	return nil
+}
+
+// ToEnum 使用默认注册表将 v 转换为枚举类型 T。
+//
+// 参数：
+//   - v: 待转换的值，可以是注册的名称、T 类型值、任意整数值或十进制数字字符串；
+//     字符串优先按名称匹配，未匹配时再按十进制数值解析。
+//
+// 返回：
+//   - T: 转换成功后的枚举值。
+//   - error: T 未注册时返回 ErrEnumNotRegistered；名称或数值未注册时返回 ErrUnknownEnum。
+func ToEnum[T Integer](v any) (T, error) {
+	return ToEnumIn[T](defaultEnumRegistry, v)
+}
+
+// ToEnumIn 使用指定注册表将 v 转换为枚举类型 T。
+//
+// 参数：
+//   - r: 枚举注册表。
+//   - v: 待转换的值，规则同 ToEnum。
+//
+// 返回：
+//   - T: 转换成功后的枚举值。
+//   - error: T 未注册时返回 ErrEnumNotRegistered；名称或数值未注册时返回 ErrUnknownEnum。
+func ToEnumIn[T Integer](r *EnumRegistry, v any) (T, error) {
+	typ := enumType[T]()
+	m, err := r.lookup(typ)
+	if nil != err {
+		return 0, err
+	}
+
+	var (
+		value int64
+		ok    bool
+	)
+	switch x := v.(type) {
+	case T:
+		value, ok = int64(x), true
+	case string:
+		value, ok = m.byName[m.nameKey(strings.TrimSpace(x))]
+		if !ok {
+			value, ok = parseEnumNumber(strings.TrimSpace(x))
+		}
+	case []byte:
+		return ToEnumIn[T](r, string(x))
+	default:
+		value, ok = enumInteger(v)
+	}
+
+	if ok {
+		if _, exists := m.byValue[value]; exists {
+			return T(value), nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %v for %s", ErrUnknownEnum, v, typ)
+}
+
+// EnumString 使用默认注册表返回枚举值的规范名称。
+//
+// 参数：
+//   - v: 枚举值。
+//
+// 返回：
+//   - string: 注册时的规范名称。
+//   - error: T 未注册时返回 ErrEnumNotRegistered；v 未注册时返回 ErrUnknownEnum。
+func EnumString[T Integer](v T) (string, error) {
+	return EnumStringIn(defaultEnumRegistry, v)
+}
+
+// EnumStringIn 使用指定注册表返回枚举值的规范名称。
+//
+// 参数：
+//   - r: 枚举注册表。
+//   - v: 枚举值。
+//
+// 返回：
+//   - string: 注册时的规范名称。
+//   - error: T 未注册时返回 ErrEnumNotRegistered；v 未注册时返回 ErrUnknownEnum。
+func EnumStringIn[T Integer](r *EnumRegistry, v T) (string, error) {
+	typ := enumType[T]()
+	m, err := r.lookup(typ)
+	if nil != err {
+		return "", err
+	}
+	name, ok := m.byValue[int64(v)]
+	if !ok {
+		return "", fmt.Errorf("%w: %d for %s", ErrUnknownEnum, int64(v), typ)
+	}
+	return name, nil
+}
+
+// EnumNames 使用默认注册表返回枚举类型 T 的全部规范名称，按数值升序排列，可用于生成文档或校验提示。
+//
+// 返回：
+//   - []string: 规范名称列表；T 未注册时返回 nil。
+func EnumNames[T Integer]() []string {
+	return EnumNamesIn[T](defaultEnumRegistry)
+}
+
+// EnumNamesIn 使用指定注册表返回枚举类型 T 的全部规范名称，按数值升序排列。
+//
+// 参数：
+//   - r: 枚举注册表。
+//
+// 返回：
+//   - []string: 规范名称列表；T 未注册时返回 nil。
+func EnumNamesIn[T Integer](r *EnumRegistry) []string {
+	m, err := r.lookup(enumType[T]())
+	if nil != err {
+		return nil
+	}
+	values := make([]int64, 0, len(m.byValue))
+	for value := range m.byValue {
+		values = append(values, value)
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+	names := make([]string, len(values))
+	for i, value := range values {
+		names[i] = m.byValue[value]
+	}
+	return names
+}
+
+// lookup 返回枚举类型的映射。
+//
+// 参数：
+//   - typ: 枚举类型。
+//
+// 返回：
+//   - *enumMapping: 已注册的映射。
+//   - error: 未注册时返回 ErrEnumNotRegistered。
+func (r *EnumRegistry) lookup(typ reflect.Type) (*enumMapping, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	m, ok := r.enums[typ]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrEnumNotRegistered, typ)
+	}
+	return m, nil
+}
+
+// nameKey 返回名称在 byName 中的键。
+//
+// 参数：
+//   - name: 枚举名称。
+//
+// 返回：
+//   - string: 忽略大小写时返回小写名称，否则原样返回。
+func (m *enumMapping) nameKey(name string) string {
+	if m.options.caseInsensitive {
+		return strings.ToLower(name)
+	}
+	return name
+}
+
+// enumType 返回类型参数 T 的反射类型。
+//
+// 返回：
+//   - reflect.Type: T 的反射类型。
+func enumType[T Integer]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+// parseEnumNumber 将十进制数字字符串解析为 int64。
+//
+// 参数：
+//   - s: 待解析的字符串。
+//
+// 返回：
+//   - int64: 解析结果。
+//   - bool: 解析成功时为 true。
+func parseEnumNumber(s string) (int64, bool) {
+	value, err := strconv.ParseInt(s, 10, 64)
+	return value, nil == err
+}
+
+// enumInteger 将任意整数类型的值转换为 int64。
+//
+// 参数：
+//   - v: 待转换的值。
+//
+// 返回：
+//   - int64: 转换结果。
+//   - bool: v 为整数类型且可无损表示为 int64 时为 true。
+func enumInteger(v any) (int64, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u := rv.Uint()
+		if u > 1<<63-1 {
+			return 0, false
+		}
+		return int64(u), true
+	default:
+		return 0, false
+	}
+}
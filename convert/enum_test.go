@@ -0,0 +1,212 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package convert
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type (
+	// orderStatus 是测试使用的整数枚举类型。
+	orderStatus int
+
+	// payChannel 是测试使用的无符号整数枚举类型。
+	payChannel uint8
+)
+
+const (
+	orderStatusPending orderStatus = iota + 1
+	orderStatusPaid
+	orderStatusClosed
+)
+
+// newTestEnumRegistry 创建注册了测试枚举的注册表。
+//
+// 参数：
+//   - t: 测试上下文，用于报告注册失败。
+//
+// 返回：
+//   - *EnumRegistry: 已注册 orderStatus 和 payChannel 的注册表。
+func newTestEnumRegistry(t *testing.T) *EnumRegistry {
+	t.Helper()
+
+	r := NewEnumRegistry()
+	require.NoError(t, RegisterEnumIn(r, map[orderStatus]string{
+		orderStatusPending: "pending",
+		orderStatusPaid:    "paid",
+		orderStatusClosed:  "closed",
+	}))
+	require.NoError(t, RegisterEnumIn(r, map[payChannel]string{
+		1: "Alipay",
+		2: "WeChat",
+	}, WithEnumCaseInsensitive()))
+	return r
+}
+
+// TestToEnumIn 验证名称、数值和数字字符串到枚举的转换。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestToEnumIn(t *testing.T) {
+	r := newTestEnumRegistry(t)
+
+	tests := []struct {
+		name        string
+		description string
+		give        any
+		want        orderStatus
+		wantErr     error
+	}{
+		{
+			name:        "success/name",
+			description: "按注册名称转换。",
+			give:        "paid",
+			want:        orderStatusPaid,
+		},
+		{
+			name:        "success/name-trim-space",
+			description: "名称两侧空白会被忽略。",
+			give:        " closed ",
+			want:        orderStatusClosed,
+		},
+		{
+			name:        "success/bytes",
+			description: "字节切片按字符串处理。",
+			give:        []byte("pending"),
+			want:        orderStatusPending,
+		},
+		{
+			name:        "success/enum-value",
+			description: "T 类型值校验后原样返回。",
+			give:        orderStatusPaid,
+			want:        orderStatusPaid,
+		},
+		{
+			name:        "success/integer",
+			description: "任意整数类型按数值转换。",
+			give:        uint16(3),
+			want:        orderStatusClosed,
+		},
+		{
+			name:        "success/numeric-string",
+			description: "未匹配名称的十进制字符串按数值转换。",
+			give:        "1",
+			want:        orderStatusPending,
+		},
+		{
+			name:        "error/unknown-name",
+			description: "未注册名称返回 ErrUnknownEnum。",
+			give:        "refunded",
+			wantErr:     ErrUnknownEnum,
+		},
+		{
+			name:        "error/case-sensitive",
+			description: "未启用忽略大小写时名称大小写必须一致。",
+			give:        "PAID",
+			wantErr:     ErrUnknownEnum,
+		},
+		{
+			name:        "error/unknown-value",
+			description: "未注册数值返回 ErrUnknownEnum。",
+			give:        9,
+			wantErr:     ErrUnknownEnum,
+		},
+		{
+			name:        "error/unsupported-type",
+			description: "非整数、非字符串输入返回 ErrUnknownEnum。",
+			give:        1.0,
+			wantErr:     ErrUnknownEnum,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			got, err := ToEnumIn[orderStatus](r, tt.give)
+			if nil != tt.wantErr {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Zero(t, got)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// TestEnumStringIn 验证枚举值到规范名称的转换以及忽略大小写的解析。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestEnumStringIn(t *testing.T) {
+	r := newTestEnumRegistry(t)
+
+	name, err := EnumStringIn(r, orderStatusPaid)
+	require.NoError(t, err)
+	assert.Equal(t, "paid", name)
+
+	_, err = EnumStringIn(r, orderStatus(0))
+	assert.ErrorIs(t, err, ErrUnknownEnum)
+
+	channel, err := ToEnumIn[payChannel](r, "wechat")
+	require.NoError(t, err)
+	name, err = EnumStringIn(r, channel)
+	require.NoError(t, err)
+	assert.Equal(t, "WeChat", name)
+
+	assert.Equal(t, []string{"pending", "paid", "closed"}, EnumNamesIn[orderStatus](r))
+	assert.Nil(t, EnumNamesIn[int](r))
+}
+
+// TestRegisterEnumIn_Errors 验证重复注册、重复名称和未注册类型的错误。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestRegisterEnumIn_Errors(t *testing.T) {
+	r := newTestEnumRegistry(t)
+
+	err := RegisterEnumIn(r, map[orderStatus]string{1: "x"})
+	assert.ErrorIs(t, err, ErrDuplicateEnum)
+
+	err = RegisterEnumIn(NewEnumRegistry(), map[payChannel]string{1: "a", 2: "A"}, WithEnumCaseInsensitive())
+	assert.ErrorIs(t, err, ErrDuplicateEnum)
+
+	err = RegisterEnumIn(NewEnumRegistry(), map[payChannel]string{1: ""})
+	assert.Error(t, err)
+
+	_, err = ToEnumIn[int](r, "1")
+	assert.ErrorIs(t, err, ErrEnumNotRegistered)
+	_, err = EnumStringIn(r, 1)
+	assert.ErrorIs(t, err, ErrEnumNotRegistered)
+}
+
+// TestDefaultEnumRegistry 验证包级函数使用默认注册表。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestDefaultEnumRegistry(t *testing.T) {
+	// 替换为新的默认注册表，重复运行测试时不会因重复注册而 panic。
+	previous := defaultEnumRegistry
+	defaultEnumRegistry = NewEnumRegistry()
+	t.Cleanup(func() { defaultEnumRegistry = previous })
+
+	type region int8
+	MustRegisterEnum(map[region]string{1: "cn", 2: "us"})
+	assert.Panics(t, func() { MustRegisterEnum(map[region]string{1: "cn"}) })
+
+	got, err := ToEnum[region]("us")
+	require.NoError(t, err)
+	assert.Equal(t, region(2), got)
+
+	name, err := EnumString(region(1))
+	require.NoError(t, err)
+	assert.Equal(t, "cn", name)
+	assert.Equal(t, []string{"cn", "us"}, EnumNames[region]())
+}
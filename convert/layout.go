@@ -0,0 +1,412 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package convert
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrUnsupportedLayout 表示格式中包含另一种风格无法表达的占位符，或字面量会被误识别为占位符。
+	ErrUnsupportedLayout = errors.New("unsupported time layout")
+	// ErrUnparsableTime 表示 ParseAny 尝试的所有布局均无法解析输入。
+	ErrUnparsableTime = errors.New("unparsable time")
+
+	// DefaultTimeLayouts 是 ParseAny 未指定布局时按顺序尝试的 Go 布局。
+	//
+	// 带时区的布局排在前面，日期时间优先于纯日期；调用方可以在此基础上追加自定义布局传给 WithLayouts。
+	DefaultTimeLayouts = []string{
+		time.RFC3339Nano,
+		"2006-01-02T15:04:05.999999999",
+		"2006-01-02 15:04:05.999999999Z07:00",
+		"2006-01-02 15:04:05.999999999 -0700 MST",
+		"2006-01-02 15:04:05.999999999",
+		"2006-01-02 15:04",
+		time.DateOnly,
+		"2006/01/02 15:04:05.999999999",
+		"2006/01/02 15:04",
+		"2006/01/02",
+		"20060102150405",
+		"20060102",
+		time.RFC1123Z,
+		time.RFC1123,
+		time.RFC850,
+		time.RFC822Z,
+		time.RFC822,
+		time.UnixDate,
+		time.RubyDate,
+		time.ANSIC,
+		time.TimeOnly,
+	}
+
+	// layoutToCarbon 是 Go 布局占位符到 carbon 格式字符的映射，小数秒单独处理。
+	layoutToCarbon = map[string]byte{
+		"January": 'F',
+		"Jan":     'M',
+		"Monday":  'l',
+		"Mon":     'D',
+		"MST":     'Z',
+		"2006":    'Y',
+		"06":      'y',
+		"01":      'm',
+		"1":       'n',
+		"02":      'd',
+		"2":       'j',
+		"15":      'H',
+		"03":      'h',
+		"3":       'g',
+		"04":      'i',
+		"05":      's',
+		"PM":      'A',
+		"pm":      'a',
+		"-0700":   'O',
+		"-07:00":  'P',
+		"Z0700":   'Q',
+		"Z07:00":  'R',
+	}
+
+	// carbonToLayout 是 carbon 格式字符到 Go 布局占位符的映射，小数秒单独处理。
+	carbonToLayout = map[byte]string{
+		'F': "January",
+		'M': "Jan",
+		'l': "Monday",
+		'D': "Mon",
+		'Z': "MST",
+		'Y': "2006",
+		'y': "06",
+		'm': "01",
+		'n': "1",
+		'd': "02",
+		'j': "2",
+		'H': "15",
+		'h': "03",
+		'g': "3",
+		'i': "04",
+		's': "05",
+		'A': "PM",
+		'a': "pm",
+		'O': "-0700",
+		'P': "-07:00",
+		'Q': "Z0700",
+		'R': "Z07:00",
+	}
+
+	// carbonFractions 是 carbon 小数秒格式字符对应的位数。
+	carbonFractions = map[byte]int{'u': 3, 'v': 6, 'x': 9}
+
+	// carbonOnly 是 carbon 支持但 Go 布局无法表达的格式字符，例如时间戳、周数与季度。
+	carbonOnly = "SUVXWNKLGwtzoqc"
+)
+
+type (
+	// ParseAnyOption 定义 ParseAny 的可选配置。
+	ParseAnyOption func(*parseAnyOptions)
+
+	// parseAnyOptions 保存 ParseAny 的可选配置。
+	parseAnyOptions struct {
+		// layouts 是按顺序尝试的 Go 布局。
+		layouts []string
+		// loc 是不含时区信息的输入使用的时区。
+		loc *time.Location
+		// err 是转换 carbon 格式时遇到的第一个错误。
+		err error
+	}
+)
+
+// WithLayouts 替换 ParseAny 尝试的 Go 布局，多次调用时追加。
+//
+// 参数：
+//   - layouts: 按顺序尝试的 Go 布局，例如 time.RFC3339 或 "2006-01-02"。
+//
+// 返回：
+//   - ParseAnyOption: 应用于 ParseAny 的配置项。
+func WithLayouts(layouts ...string) ParseAnyOption {
+	return func(o *parseAnyOptions) {
+		o.layouts = append(o.layouts, layouts...)
+	}
+}
+
+// WithCarbonFormats 以 carbon 格式追加 ParseAny 尝试的布局，与 WithLayouts 按调用顺序合并。
+//
+// 参数：
+//   - formats: carbon 格式，例如 "Y-m-d H:i:s"，通过 CarbonToLayout 转换。
+//
+// 返回：
+//   - ParseAnyOption: 应用于 ParseAny 的配置项；任一格式无法转换时 ParseAny 返回该转换错误。
+func WithCarbonFormats(formats ...string) ParseAnyOption {
+	return func(o *parseAnyOptions) {
+		for _, format := range formats {
+			layout, err := CarbonToLayout(format)
+			if nil != err {
+				if nil == o.err {
+					o.err = err
+				}
+				continue
+			}
+			o.layouts = append(o.layouts, layout)
+		}
+	}
+}
+
+// WithParseLocation 设置不含时区信息的输入使用的时区。
+//
+// 参数：
+//   - loc: 时区；为 nil 时保留默认的 time.Local。
+//
+// 返回：
+//   - ParseAnyOption: 应用于 ParseAny 的配置项。
+func WithParseLocation(loc *time.Location) ParseAnyOption {
+	return func(o *parseAnyOptions) {
+		if nil != loc {
+			o.loc = loc
+		}
+	}
+}
+
+// ParseAny 依次使用配置的布局解析时间字符串，返回第一个解析成功的结果。
+//
+// 未通过 WithLayouts 或 WithCarbonFormats 指定布局时使用 DefaultTimeLayouts；输入首尾的空白会被忽略。
+//
+// 参数：
+//   - s: 待解析的时间字符串。
+//   - opts: 可选配置，例如 WithLayouts、WithCarbonFormats 与 WithParseLocation。
+//
+// 返回：
+//   - time.Time: 解析结果；输入不含时区信息时位于 WithParseLocation 指定的时区，默认 time.Local。
+//   - error: carbon 格式无法转换时返回包装了 ErrUnsupportedLayout 的错误；所有布局均失败时返回包装了 ErrUnparsableTime 的错误。
+func ParseAny(s string, opts ...ParseAnyOption) (time.Time, error) {
+	o := &parseAnyOptions{loc: time.Local}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if nil != o.err {
+		return time.Time{}, o.err
+	}
+	layouts := o.layouts
+	if 0 == len(layouts) {
+		layouts = DefaultTimeLayouts
+	}
+
+	s = strings.TrimSpace(s)
+	for _, layout := range layouts {
+		if t, err := time.ParseInLocation(layout, s, o.loc); nil == err {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%w: %q matches none of %d layouts", ErrUnparsableTime, s, len(layouts))
+}
+
+// LayoutToCarbon 将 Go 时间布局转换为 carbon（PHP 风格）格式。
+//
+// 字面量中的 ASCII 字母与反斜杠会以反斜杠转义，避免被 carbon 识别为格式字符。
+// 小数秒 ".000"、".000000"、".000000000" 分别转换为 ".u"、".v"、".x"，".999" 系列同样转换，
+// 但 carbon 输出时始终保留末尾的 0。
+//
+// 参数：
+//   - layout: Go 时间布局，例如 "2006-01-02 15:04:05"。
+//
+// 返回：
+//   - string: carbon 格式，例如 "Y-m-d H:i:s"。
+//   - error: 布局中包含 carbon 无法表达的占位符（如 "_2"、"002"、不补零的分秒、"-07"）时返回包装了 ErrUnsupportedLayout 的错误。
+func LayoutToCarbon(layout string) (string, error) {
+	var b strings.Builder
+	for "" != layout {
+		prefix, token, suffix := nextLayoutToken(layout)
+		for i := 0; i < len(prefix); i++ {
+			c := prefix[i]
+			if '\\' == c || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') {
+				b.WriteByte('\\')
+			}
+			b.WriteByte(c)
+		}
+		layout = suffix
+		if "" == token {
+			break
+		}
+
+		if c, ok := layoutToCarbon[token]; ok {
+			b.WriteByte(c)
+			continue
+		}
+		if c, ok := fractionToCarbon(token); ok {
+			b.WriteByte(token[0])
+			b.WriteByte(c)
+			continue
+		}
+		return "", fmt.Errorf("%w: go layout element %q has no carbon equivalent", ErrUnsupportedLayout, token)
+	}
+	return b.String(), nil
+}
+
+// CarbonToLayout 将 carbon（PHP 风格）格式转换为 Go 时间布局。
+//
+// 反斜杠转义的字符按字面量输出。carbon 的 u、v、x 需紧跟在 "." 或 "," 之后，转换为 Go 的小数秒。
+//
+// 参数：
+//   - format: carbon 格式，例如 "Y-m-d H:i:s"。
+//
+// 返回：
+//   - string: Go 时间布局，例如 "2006-01-02 15:04:05"。
+//   - error: 格式中包含 Go 无法表达的字符（如时间戳 S、周数 W、季度 q），小数秒前缺少分隔符，
+//     或字面量会被 Go 识别为占位符（如数字、"Jan"）时返回包装了 ErrUnsupportedLayout 的错误。
+func CarbonToLayout(format string) (string, error) {
+	var (
+		b      strings.Builder
+		tokens []string
+	)
+	for i := 0; i < len(format); i++ {
+		c := format[i]
+		if '\\' == c {
+			if i+1 < len(format) {
+				i++
+				b.WriteByte(format[i])
+			}
+			continue
+		}
+		if layout, ok := carbonToLayout[c]; ok {
+			b.WriteString(layout)
+			tokens = append(tokens, layout)
+			continue
+		}
+		if digits, ok := carbonFractions[c]; ok {
+			out := b.String()
+			if "" == out || ('.' != out[len(out)-1] && ',' != out[len(out)-1]) {
+				return "", fmt.Errorf("%w: carbon character %q must follow '.' or ','", ErrUnsupportedLayout, c)
+			}
+			b.WriteString(strings.Repeat("0", digits))
+			tokens = append(tokens, out[len(out)-1:]+strings.Repeat("0", digits))
+			continue
+		}
+		if strings.IndexByte(carbonOnly, c) >= 0 {
+			return "", fmt.Errorf("%w: carbon character %q has no go layout equivalent", ErrUnsupportedLayout, c)
+		}
+		b.WriteByte(c)
+	}
+
+	// Go 布局没有转义机制，重新扫描确认字面量没有被识别为占位符。
+	layout := b.String()
+	rest := layout
+	for _, want := range tokens {
+		_, token, suffix := nextLayoutToken(rest)
+		if token != want {
+			return "", fmt.Errorf("%w: literal text in %q is ambiguous in go layout", ErrUnsupportedLayout, format)
+		}
+		rest = suffix
+	}
+	if _, token, _ := nextLayoutToken(rest); "" != token {
+		return "", fmt.Errorf("%w: literal text in %q is ambiguous in go layout", ErrUnsupportedLayout, format)
+	}
+	return layout, nil
+}
+
+// fractionToCarbon 将 Go 的小数秒占位符转换为 carbon 格式字符。
+//
+// 参数：
+//   - token: 以 "." 或 "," 开头的小数秒占位符。
+//
+// 返回：
+//   - byte: carbon 格式字符 u、v 或 x。
+//   - bool: 位数为 3、6 或 9 时返回 true。
+func fractionToCarbon(token string) (byte, bool) {
+	if len(token) < 2 || ('.' != token[0] && ',' != token[0]) {
+		return 0, false
+	}
+	for c, digits := range carbonFractions {
+		if digits == len(token)-1 {
+			return c, true
+		}
+	}
+	return 0, false
+}
+
+// nextLayoutToken 按 time 包的规则查找 Go 布局中的下一个占位符。
+//
+// 参数：
+//   - layout: Go 时间布局。
+//
+// 返回：
+//   - string: 占位符之前的字面量。
+//   - string: 占位符；不存在时为空字符串。
+//   - string: 占位符之后的剩余布局。
+func nextLayoutToken(layout string) (string, string, string) {
+	for i := 0; i < len(layout); i++ {
+		if n := layoutTokenLen(layout, i); n > 0 {
+			return layout[:i], layout[i : i+n], layout[i+n:]
+		}
+	}
+	return layout, "", ""
+}
+
+// layoutTokenLen 返回 Go 布局在指定位置开始的占位符长度，规则与 time 包保持一致。
+//
+// 参数：
+//   - layout: Go 时间布局。
+//   - i: 起始位置。
+//
+// 返回：
+//   - int: 占位符长度；该位置不是占位符时为 0。
+func layoutTokenLen(layout string, i int) int {
+	rest := layout[i:]
+	has := func(prefixes ...string) int {
+		for _, p := range prefixes {
+			if strings.HasPrefix(rest, p) {
+				return len(p)
+			}
+		}
+		return 0
+	}
+
+	switch rest[0] {
+	case 'J':
+		return has("January", "Jan")
+	case 'M':
+		return has("Monday", "Mon", "MST")
+	case '0':
+		if len(rest) > 1 && '1' <= rest[1] && rest[1] <= '6' {
+			return 2
+		}
+		return has("002")
+	case '1':
+		if n := has("15"); n > 0 {
+			return n
+		}
+		return 1
+	case '2':
+		if n := has("2006"); n > 0 {
+			return n
+		}
+		return 1
+	case '_':
+		// "_2006" 是字面量 "_" 加年份。
+		if strings.HasPrefix(rest, "_2") && !strings.HasPrefix(rest, "_2006") {
+			return 2
+		}
+		return has("__2")
+	case '3', '4', '5':
+		return 1
+	case 'P':
+		return has("PM")
+	case 'p':
+		return has("pm")
+	case '-':
+		return has("-07:00:00", "-070000", "-07:00", "-0700", "-07")
+	case 'Z':
+		return has("Z07:00:00", "Z070000", "Z07:00", "Z0700", "Z07")
+	case '.', ',':
+		if len(rest) > 1 && ('0' == rest[1] || '9' == rest[1]) {
+			j := 1
+			for j < len(rest) && rest[j] == rest[1] {
+				j++
+			}
+			if j == len(rest) || rest[j] < '0' || rest[j] > '9' {
+				return j
+			}
+		}
+	}
+	return 0
+}
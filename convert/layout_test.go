@@ -0,0 +1,314 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package convert
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dromara/carbon/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestLayoutToCarbon 验证 Go 布局到 carbon 格式的转换。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestLayoutToCarbon(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		give        string
+		want        string
+		wantErr     error
+	}{
+		{
+			name:        "success/date",
+			description: "日期布局。",
+			give:        time.DateOnly,
+			want:        "Y-m-d",
+		},
+		{
+			name:        "success/date-time",
+			description: "日期时间布局。",
+			give:        time.DateTime,
+			want:        "Y-m-d H:i:s",
+		},
+		{
+			name:        "success/rfc3339-nano",
+			description: "字面量字母被转义，.999999999 转换为 .x。",
+			give:        time.RFC3339Nano,
+			want:        `Y-m-d\TH:i:s.xR`,
+		},
+		{
+			name:        "success/rfc1123z",
+			description: "星期、月份名称与数字时区。",
+			give:        time.RFC1123Z,
+			want:        "D, d M Y H:i:s O",
+		},
+		{
+			name:        "success/kitchen",
+			description: "12 小时制与上下午。",
+			give:        time.Kitchen,
+			want:        "g:iA",
+		},
+		{
+			name:        "success/milli-comma",
+			description: "逗号分隔的毫秒。",
+			give:        "15:04:05,000",
+			want:        "H:i:s,u",
+		},
+		{
+			name:        "success/underscore-year",
+			description: "_2006 是字面量 _ 加年份。",
+			give:        "_2006",
+			want:        "_Y",
+		},
+		{
+			name:        "success/chinese",
+			description: "非 ASCII 字面量原样保留。",
+			give:        "2006年1月2日",
+			want:        "Y年n月j日",
+		},
+		{
+			name:        "error/space-padded-day",
+			description: "_2 在 carbon 中没有对应字符。",
+			give:        time.ANSIC,
+			wantErr:     ErrUnsupportedLayout,
+		},
+		{
+			name:        "error/unpadded-minute",
+			description: "不补零的分钟在 carbon 中没有对应字符。",
+			give:        "15:4",
+			wantErr:     ErrUnsupportedLayout,
+		},
+		{
+			name:        "error/fraction-digits",
+			description: "只支持 3、6、9 位小数秒。",
+			give:        "05.00",
+			wantErr:     ErrUnsupportedLayout,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			got, err := LayoutToCarbon(tt.give)
+			if nil != tt.wantErr {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// TestCarbonToLayout 验证 carbon 格式到 Go 布局的转换。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestCarbonToLayout(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		give        string
+		want        string
+		wantErr     error
+	}{
+		{
+			name:        "success/date",
+			description: "日期格式。",
+			give:        "Y-m-d",
+			want:        time.DateOnly,
+		},
+		{
+			name:        "success/date-time-milli",
+			description: "u 紧跟 . 时转换为毫秒。",
+			give:        "Y-m-d H:i:s.u",
+			want:        "2006-01-02 15:04:05.000",
+		},
+		{
+			name:        "success/escaped",
+			description: "反斜杠转义的字母按字面量输出。",
+			give:        `Y-m-d\TH:i:sP`,
+			want:        "2006-01-02T15:04:05-07:00",
+		},
+		{
+			name:        "success/names",
+			description: "星期、月份名称、上下午与时区名称。",
+			give:        "l, F j, Y g:i a Z",
+			want:        "Monday, January 2, 2006 3:04 pm MST",
+		},
+		{
+			name:        "error/timestamp",
+			description: "时间戳在 Go 布局中没有对应占位符。",
+			give:        "S",
+			wantErr:     ErrUnsupportedLayout,
+		},
+		{
+			name:        "error/quarter",
+			description: "季度在 Go 布局中没有对应占位符。",
+			give:        "Y-q",
+			wantErr:     ErrUnsupportedLayout,
+		},
+		{
+			name:        "error/fraction-without-dot",
+			description: "u 前缺少 . 或 , 时无法表达为小数秒。",
+			give:        "His u",
+			wantErr:     ErrUnsupportedLayout,
+		},
+		{
+			name:        "error/literal-digit",
+			description: "字面量数字会被 Go 识别为占位符。",
+			give:        "Y-m-d 1",
+			wantErr:     ErrUnsupportedLayout,
+		},
+		{
+			name:        "error/literal-month-name",
+			description: "字面量 Jan 会被 Go 识别为月份。",
+			give:        `\J\a\n Y`,
+			wantErr:     ErrUnsupportedLayout,
+		},
+		{
+			name:        "error/underscore-day",
+			description: "字面量 _ 与 j 相连会形成 Go 的 _2。",
+			give:        "Y_j",
+			wantErr:     ErrUnsupportedLayout,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			got, err := CarbonToLayout(tt.give)
+			if nil != tt.wantErr {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// TestLayoutBridge_MatchesCarbon 验证转换结果与 carbon 自身的格式化输出一致。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestLayoutBridge_MatchesCarbon(t *testing.T) {
+	t.Log("验证同一时刻按 carbon 格式与转换后的 Go 布局格式化得到相同的字符串，且可以往返转换。")
+
+	loc := time.FixedZone("CST", 8*3600)
+	give := time.Date(2025, 3, 7, 14, 5, 9, 123456789, loc)
+	for _, format := range []string{
+		"Y-m-d H:i:s",
+		"Y-m-d H:i:s.u",
+		"Y-m-d H:i:s.v",
+		"Y-m-d H:i:s.x",
+		`Y-m-d\TH:i:sP`,
+		"D, d M Y H:i:s O",
+		"l, F j, Y g:i A Z",
+		"y/n/j h:i a Q",
+		"YmdHis",
+	} {
+		layout, err := CarbonToLayout(format)
+		require.NoError(t, err, format)
+		assert.Equal(t, carbon.CreateFromStdTime(give).Format(format), give.Format(layout), format)
+
+		back, err := LayoutToCarbon(layout)
+		require.NoError(t, err, layout)
+		assert.Equal(t, give.Format(layout), carbon.CreateFromStdTime(give).Format(back), layout)
+	}
+}
+
+// TestParseAny 验证按布局列表解析时间字符串。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestParseAny(t *testing.T) {
+	loc := time.FixedZone("CST", 8*3600)
+
+	tests := []struct {
+		name        string
+		description string
+		give        string
+		opts        []ParseAnyOption
+		want        time.Time
+		wantErr     error
+	}{
+		{
+			name:        "success/rfc3339",
+			description: "默认布局解析带时区的 RFC3339。",
+			give:        "2025-03-07T14:05:09.5+08:00",
+			want:        time.Date(2025, 3, 7, 14, 5, 9, 500000000, loc),
+		},
+		{
+			name:        "success/date-time-location",
+			description: "不含时区的输入使用 WithParseLocation 指定的时区，首尾空白被忽略。",
+			give:        " 2025-03-07 14:05:09 ",
+			opts:        []ParseAnyOption{WithParseLocation(loc)},
+			want:        time.Date(2025, 3, 7, 14, 5, 9, 0, loc),
+		},
+		{
+			name:        "success/date-only",
+			description: "默认布局解析纯日期。",
+			give:        "2025/03/07",
+			opts:        []ParseAnyOption{WithParseLocation(time.UTC)},
+			want:        time.Date(2025, 3, 7, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:        "success/carbon-format",
+			description: "WithCarbonFormats 与 WithLayouts 合并，按调用顺序尝试。",
+			give:        "07.03.2025",
+			opts: []ParseAnyOption{
+				WithLayouts(time.DateOnly),
+				WithCarbonFormats("d.m.Y"),
+				WithParseLocation(time.UTC),
+			},
+			want: time.Date(2025, 3, 7, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:        "error/custom-layouts-replace-default",
+			description: "指定布局后不再尝试默认布局。",
+			give:        "2025-03-07",
+			opts:        []ParseAnyOption{WithLayouts(time.Kitchen)},
+			wantErr:     ErrUnparsableTime,
+		},
+		{
+			name:        "error/invalid-carbon-format",
+			description: "carbon 格式无法转换时返回转换错误。",
+			give:        "1741327509",
+			opts:        []ParseAnyOption{WithCarbonFormats("S")},
+			wantErr:     ErrUnsupportedLayout,
+		},
+		{
+			name:        "error/garbage",
+			description: "无法解析的输入。",
+			give:        "not a time",
+			wantErr:     ErrUnparsableTime,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			got, err := ParseAny(tt.give, tt.opts...)
+			if nil != tt.wantErr {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, tt.want.Equal(got), "got %v", got)
+			_, wantOffset := tt.want.Zone()
+			_, gotOffset := got.Zone()
+			assert.Equal(t, wantOffset, gotOffset)
+		})
+	}
+}
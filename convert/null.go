@@ -0,0 +1,361 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package convert
+
+import (
+	"database/sql"
+	"time"
+)
+
+// PtrTo 返回指向 v 副本的指针，便于为字面量或函数返回值取地址。
+//
+// 参数：
+//   - v: 任意值。
+//
+// 返回：
+//   - *T: 指向 v 副本的指针，每次调用返回新的地址。
+func PtrTo[T any](v T) *T {
+	return &v
+}
+
+// PtrToNonZero 返回指向 v 副本的指针，v 为零值时返回 nil。
+//
+// 适合把空字符串、0 等零值按“未设置”处理的可选字段。
+//
+// 参数：
+//   - v: 可比较的值。
+//
+// 返回：
+//   - *T: v 为零值时返回 nil，否则返回指向 v 副本的指针。
+func PtrToNonZero[T comparable](v T) *T {
+	var zero T
+	if zero == v {
+		return nil
+	}
+	return &v
+}
+
+// Deref 返回指针指向的值，指针为 nil 时返回零值。
+//
+// 参数：
+//   - p: 待解引用的指针。
+//
+// 返回：
+//   - T: p 为 nil 时返回 T 的零值，否则返回 *p。
+func Deref[T any](p *T) T {
+	if nil == p {
+		var zero T
+		return zero
+	}
+	return *p
+}
+
+// DerefOr 返回指针指向的值，指针为 nil 时返回 def。
+//
+// 参数：
+//   - p: 待解引用的指针。
+//   - def: p 为 nil 时使用的默认值。
+//
+// 返回：
+//   - T: p 为 nil 时返回 def，否则返回 *p。
+func DerefOr[T any](p *T, def T) T {
+	if nil == p {
+		return def
+	}
+	return *p
+}
+
+// Null 将值转换为有效的 sql.Null[T]。
+//
+// 参数：
+//   - v: 列值。
+//
+// 返回：
+//   - sql.Null[T]: V 为 v 且 Valid 为 true。
+func Null[T any](v T) sql.Null[T] {
+	return sql.Null[T]{V: v, Valid: true}
+}
+
+// NullIfZero 将值转换为 sql.Null[T]，零值按 NULL 处理。
+//
+// 参数：
+//   - v: 可比较的列值。
+//
+// 返回：
+//   - sql.Null[T]: v 为零值时 Valid 为 false，否则 V 为 v 且 Valid 为 true。
+func NullIfZero[T comparable](v T) sql.Null[T] {
+	var zero T
+	if zero == v {
+		return sql.Null[T]{}
+	}
+	return sql.Null[T]{V: v, Valid: true}
+}
+
+// NullFromPtr 将指针转换为 sql.Null[T]。
+//
+// 参数：
+//   - p: 可选值；为 nil 时表示 NULL。
+//
+// 返回：
+//   - sql.Null[T]: p 为 nil 时 Valid 为 false，否则 V 为 *p 且 Valid 为 true。
+func NullFromPtr[T any](p *T) sql.Null[T] {
+	if nil == p {
+		return sql.Null[T]{}
+	}
+	return sql.Null[T]{V: *p, Valid: true}
+}
+
+// FromNull 将 sql.Null[T] 转换为指针。
+//
+// 参数：
+//   - n: 扫描得到的可空列值。
+//
+// 返回：
+//   - *T: n.Valid 为 false 时返回 nil，否则返回指向 n.V 副本的指针。
+func FromNull[T any](n sql.Null[T]) *T {
+	if !n.Valid {
+		return nil
+	}
+	return &n.V
+}
+
+// FromNullOr 返回 sql.Null[T] 中的值，NULL 时返回 def。
+//
+// 参数：
+//   - n: 扫描得到的可空列值。
+//   - def: n.Valid 为 false 时使用的默认值。
+//
+// 返回：
+//   - T: n.Valid 为 false 时返回 def，否则返回 n.V。
+func FromNullOr[T any](n sql.Null[T], def T) T {
+	if !n.Valid {
+		return def
+	}
+	return n.V
+}
+
+// NullString 将 *string 转换为 sql.NullString。
+//
+// 参数：
+//   - p: 可选值；为 nil 时表示 NULL。
+//
+// 返回：
+//   - sql.NullString: p 为 nil 时 Valid 为 false，否则取值 *p 且 Valid 为 true。
+func NullString(p *string) sql.NullString {
+	if nil == p {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: *p, Valid: true}
+}
+
+// FromNullString 将 sql.NullString 转换为 *string。
+//
+// 参数：
+//   - n: 扫描得到的可空列值。
+//
+// 返回：
+//   - *string: n.Valid 为 false 时返回 nil，否则返回指向 n.String 副本的指针。
+func FromNullString(n sql.NullString) *string {
+	if !n.Valid {
+		return nil
+	}
+	return &n.String
+}
+
+// NullInt64 将 *int64 转换为 sql.NullInt64。
+//
+// 参数：
+//   - p: 可选值；为 nil 时表示 NULL。
+//
+// 返回：
+//   - sql.NullInt64: p 为 nil 时 Valid 为 false，否则取值 *p 且 Valid 为 true。
+func NullInt64(p *int64) sql.NullInt64 {
+	if nil == p {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: *p, Valid: true}
+}
+
+// FromNullInt64 将 sql.NullInt64 转换为 *int64。
+//
+// 参数：
+//   - n: 扫描得到的可空列值。
+//
+// 返回：
+//   - *int64: n.Valid 为 false 时返回 nil，否则返回指向 n.Int64 副本的指针。
+func FromNullInt64(n sql.NullInt64) *int64 {
+	if !n.Valid {
+		return nil
+	}
+	return &n.Int64
+}
+
+// NullInt32 将 *int32 转换为 sql.NullInt32。
+//
+// 参数：
+//   - p: 可选值；为 nil 时表示 NULL。
+//
+// 返回：
+//   - sql.NullInt32: p 为 nil 时 Valid 为 false，否则取值 *p 且 Valid 为 true。
+func NullInt32(p *int32) sql.NullInt32 {
+	if nil == p {
+		return sql.NullInt32{}
+	}
+	return sql.NullInt32{Int32: *p, Valid: true}
+}
+
+// FromNullInt32 将 sql.NullInt32 转换为 *int32。
+//
+// 参数：
+//   - n: 扫描得到的可空列值。
+//
+// 返回：
+//   - *int32: n.Valid 为 false 时返回 nil，否则返回指向 n.Int32 副本的指针。
+func FromNullInt32(n sql.NullInt32) *int32 {
+	if !n.Valid {
+		return nil
+	}
+	return &n.Int32
+}
+
+// NullInt16 将 *int16 转换为 sql.NullInt16。
+//
+// 参数：
+//   - p: 可选值；为 nil 时表示 NULL。
+//
+// 返回：
+//   - sql.NullInt16: p 为 nil 时 Valid 为 false，否则取值 *p 且 Valid 为 true。
+func NullInt16(p *int16) sql.NullInt16 {
+	if nil == p {
+		return sql.NullInt16{}
+	}
+	return sql.NullInt16{Int16: *p, Valid: true}
+}
+
+// FromNullInt16 将 sql.NullInt16 转换为 *int16。
+//
+// 参数：
+//   - n: 扫描得到的可空列值。
+//
+// 返回：
+//   - *int16: n.Valid 为 false 时返回 nil，否则返回指向 n.Int16 副本的指针。
+func FromNullInt16(n sql.NullInt16) *int16 {
+	if !n.Valid {
+		return nil
+	}
+	return &n.Int16
+}
+
+// NullByte 将 *byte 转换为 sql.NullByte。
+//
+// 参数：
+//   - p: 可选值；为 nil 时表示 NULL。
+//
+// 返回：
+//   - sql.NullByte: p 为 nil 时 Valid 为 false，否则取值 *p 且 Valid 为 true。
+func NullByte(p *byte) sql.NullByte {
+	if nil == p {
+		return sql.NullByte{}
+	}
+	return sql.NullByte{Byte: *p, Valid: true}
+}
+
+// FromNullByte 将 sql.NullByte 转换为 *byte。
+//
+// 参数：
+//   - n: 扫描得到的可空列值。
+//
+// 返回：
+//   - *byte: n.Valid 为 false 时返回 nil，否则返回指向 n.Byte 副本的指针。
+func FromNullByte(n sql.NullByte) *byte {
+	if !n.Valid {
+		return nil
+	}
+	return &n.Byte
+}
+
+// NullFloat64 将 *float64 转换为 sql.NullFloat64。
+//
+// 参数：
+//   - p: 可选值；为 nil 时表示 NULL。
+//
+// 返回：
+//   - sql.NullFloat64: p 为 nil 时 Valid 为 false，否则取值 *p 且 Valid 为 true。
+func NullFloat64(p *float64) sql.NullFloat64 {
+	if nil == p {
+		return sql.NullFloat64{}
+	}
+	return sql.NullFloat64{Float64: *p, Valid: true}
+}
+
+// FromNullFloat64 将 sql.NullFloat64 转换为 *float64。
+//
+// 参数：
+//   - n: 扫描得到的可空列值。
+//
+// 返回：
+//   - *float64: n.Valid 为 false 时返回 nil，否则返回指向 n.Float64 副本的指针。
+func FromNullFloat64(n sql.NullFloat64) *float64 {
+	if !n.Valid {
+		return nil
+	}
+	return &n.Float64
+}
+
+// NullBool 将 *bool 转换为 sql.NullBool。
+//
+// 参数：
+//   - p: 可选值；为 nil 时表示 NULL。
+//
+// 返回：
+//   - sql.NullBool: p 为 nil 时 Valid 为 false，否则取值 *p 且 Valid 为 true。
+func NullBool(p *bool) sql.NullBool {
+	if nil == p {
+		return sql.NullBool{}
+	}
+	return sql.NullBool{Bool: *p, Valid: true}
+}
+
+// FromNullBool 将 sql.NullBool 转换为 *bool。
+//
+// 参数：
+//   - n: 扫描得到的可空列值。
+//
+// 返回：
+//   - *bool: n.Valid 为 false 时返回 nil，否则返回指向 n.Bool 副本的指针。
+func FromNullBool(n sql.NullBool) *bool {
+	if !n.Valid {
+		return nil
+	}
+	return &n.Bool
+}
+
+// NullTime 将 *time.Time 转换为 sql.NullTime。
+//
+// 参数：
+//   - p: 可选值；为 nil 时表示 NULL。
+//
+// 返回：
+//   - sql.NullTime: p 为 nil 时 Valid 为 false，否则取值 *p 且 Valid 为 true。
+func NullTime(p *time.Time) sql.NullTime {
+	if nil == p {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: *p, Valid: true}
+}
+
+// FromNullTime 将 sql.NullTime 转换为 *time.Time。
+//
+// 参数：
+//   - n: 扫描得到的可空列值。
+//
+// 返回：
+//   - *time.Time: n.Valid 为 false 时返回 nil，否则返回指向 n.Time 副本的指针。
+func FromNullTime(n sql.NullTime) *time.Time {
+	if !n.Valid {
+		return nil
+	}
+	return &n.Time
+}
@@ -0,0 +1,117 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package convert
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPointerHelpers 验证取地址与解引用辅助函数。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestPointerHelpers(t *testing.T) {
+	p := PtrTo(42)
+	require.NotNil(t, p)
+	assert.Equal(t, 42, *p)
+	assert.NotSame(t, p, PtrTo(42), "每次调用应返回新的地址")
+
+	assert.Nil(t, PtrToNonZero(""))
+	assert.Nil(t, PtrToNonZero(0))
+	assert.Equal(t, "a", *PtrToNonZero("a"))
+
+	assert.Equal(t, 0, Deref[int](nil))
+	assert.Equal(t, "x", Deref(PtrTo("x")))
+	assert.Equal(t, 7, DerefOr(nil, 7))
+	assert.Equal(t, 3, DerefOr(PtrTo(3), 7))
+}
+
+// TestGenericNull 验证 sql.Null[T] 与值、指针之间的转换。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestGenericNull(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		give        sql.Null[string]
+		wantPtr     *string
+		wantOr      string
+	}{
+		{name: "success/value", description: "验证 Null 构造有效值。", give: Null("a"), wantPtr: PtrTo("a"), wantOr: "a"},
+		{name: "success/empty-value", description: "验证 Null 保留空字符串。", give: Null(""), wantPtr: PtrTo(""), wantOr: ""},
+		{name: "success/zero-as-null", description: "验证 NullIfZero 将空字符串视为 NULL。", give: NullIfZero(""), wantOr: "def"},
+		{name: "success/non-zero", description: "验证 NullIfZero 保留非零值。", give: NullIfZero("b"), wantPtr: PtrTo("b"), wantOr: "b"},
+		{name: "success/nil-ptr", description: "验证 nil 指针转换为 NULL。", give: NullFromPtr[string](nil), wantOr: "def"},
+		{name: "success/ptr", description: "验证非 nil 指针转换为有效值。", give: NullFromPtr(PtrTo("c")), wantPtr: PtrTo("c"), wantOr: "c"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			assert.Equal(t, tt.wantPtr, FromNull(tt.give))
+			assert.Equal(t, tt.wantOr, FromNullOr(tt.give, "def"))
+		})
+	}
+}
+
+// TestTypedNull 验证标准库 sql.NullXxx 类型与指针之间的转换。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestTypedNull(t *testing.T) {
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	t.Run("valid", func(t *testing.T) {
+		t.Log("验证非 nil 指针往返转换后值不变。")
+
+		assert.Equal(t, sql.NullString{String: "s", Valid: true}, NullString(PtrTo("s")))
+		assert.Equal(t, PtrTo("s"), FromNullString(NullString(PtrTo("s"))))
+		assert.Equal(t, PtrTo(int64(1)), FromNullInt64(NullInt64(PtrTo(int64(1)))))
+		assert.Equal(t, PtrTo(int32(2)), FromNullInt32(NullInt32(PtrTo(int32(2)))))
+		assert.Equal(t, PtrTo(int16(3)), FromNullInt16(NullInt16(PtrTo(int16(3)))))
+		assert.Equal(t, PtrTo(byte(4)), FromNullByte(NullByte(PtrTo(byte(4)))))
+		assert.Equal(t, PtrTo(1.5), FromNullFloat64(NullFloat64(PtrTo(1.5))))
+		assert.Equal(t, PtrTo(false), FromNullBool(NullBool(PtrTo(false))))
+		assert.Equal(t, &now, FromNullTime(NullTime(&now)))
+	})
+
+	t.Run("null", func(t *testing.T) {
+		t.Log("验证 nil 指针转换为 NULL，NULL 转换回 nil。")
+
+		assert.False(t, NullString(nil).Valid)
+		assert.False(t, NullInt64(nil).Valid)
+		assert.False(t, NullInt32(nil).Valid)
+		assert.False(t, NullInt16(nil).Valid)
+		assert.False(t, NullByte(nil).Valid)
+		assert.False(t, NullFloat64(nil).Valid)
+		assert.False(t, NullBool(nil).Valid)
+		assert.False(t, NullTime(nil).Valid)
+		assert.Nil(t, FromNullString(sql.NullString{String: "ignored"}))
+		assert.Nil(t, FromNullInt64(sql.NullInt64{}))
+		assert.Nil(t, FromNullInt32(sql.NullInt32{}))
+		assert.Nil(t, FromNullInt16(sql.NullInt16{}))
+		assert.Nil(t, FromNullByte(sql.NullByte{}))
+		assert.Nil(t, FromNullFloat64(sql.NullFloat64{}))
+		assert.Nil(t, FromNullBool(sql.NullBool{}))
+		assert.Nil(t, FromNullTime(sql.NullTime{Time: now}))
+	})
+
+	t.Run("copy", func(t *testing.T) {
+		t.Log("验证返回的指针不与原始变量共享内存。")
+
+		s := "orig"
+		n := NullString(&s)
+		s = "changed"
+		assert.Equal(t, "orig", *FromNullString(n))
+	})
+}
@@ -8,7 +8,11 @@
 // 以及字符串、Base64 和 Hex 编码转换。EncryptGCM 使用调用方提供的 nonce 生成
 // nonce || ciphertextAndTag，DecryptGCM 使用调用方提供的 nonce 解密不含 nonce 前缀的
 // ciphertextAndTag；EncryptGCMNonceLength 与 DecryptGCMNonceLength 处理带 nonce 前缀的
-// 组合密文。所有 GCM 调用都以 nil AAD 运行。
+// 组合密文。上述 GCM 调用都以 nil AAD 运行。
+//
+// SealEnvelope 与 OpenEnvelope 使用 crypto/envelope 定义的自描述密文信封，
+// 信封按密钥长度记录 A128GCM、A192GCM 或 A256GCM 算法与调用方提供的 key-id，
+// 并把信封头部作为 AAD 参与认证；EncryptStringEnvelope 与 DecryptStringEnvelope 处理其文本格式。
 //
 // AES 密钥长度必须满足标准库 aes.NewCipher 的要求。默认 GCM nonce 长度来自
 // cipher.AEAD.NonceSize，当前标准库 NewGCM 为 12 字节；同一密钥下 nonce 不得复用。
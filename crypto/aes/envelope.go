@@ -0,0 +1,171 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package aes
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"fmt"
+
+	kitbytes "github.com/fsyyft-go/kit/bytes"
+	kitenvelope "github.com/fsyyft-go/kit/crypto/envelope"
+)
+
+// SealEnvelope 使用 AES-GCM 加密数据，并返回自描述的密文信封。
+//
+// 算法按密钥长度选择 A128GCM、A192GCM 或 A256GCM，nonce 由 crypto/rand 随机生成。
+// 信封头部（版本、算法与 keyID）作为 AAD 参与认证，被篡改时 OpenEnvelope 认证失败。
+//
+// 参数：
+//   - key：AES 密钥字节切片，长度必须为 16、24 或 32 字节。
+//   - keyID：标识密钥的字符串，解密方据此选择密钥，长度不能超过 255 字节。
+//   - data：待加密的明文字节切片，可为空。
+//
+// 返回：
+//   - *kitenvelope.Envelope：包含 nonce、不含标签的密文与 GCM 标签的信封；失败时为 nil。
+//   - error：密钥长度非法、keyID 过长或随机源读取失败时返回错误。
+func SealEnvelope(key []byte, keyID string, data []byte) (*kitenvelope.Envelope, error) {
+	env := &kitenvelope.Envelope{Version: kitenvelope.Version1, Algorithm: gcmAlgorithm(len(key)), KeyID: keyID}
+
+	aead, err := newGCM(key)
+	if nil != err {
+		return nil, err
+	}
+	aad, err := env.AdditionalData()
+	if nil != err {
+		return nil, err
+	}
+	nonce, err := kitbytes.GenerateNonce(aead.NonceSize())
+	if nil != err {
+		return nil, err
+	}
+
+	sealed := aead.Seal(nil, nonce, data, aad)
+	tagStart := len(sealed) - aead.Overhead()
+	env.Nonce = nonce
+	env.Ciphertext = sealed[:tagStart:tagStart]
+	env.Tag = sealed[tagStart:]
+	return env, nil
+}
+
+// OpenEnvelope 使用 AES-GCM 解密 SealEnvelope 产生的信封。
+//
+// 调用方通常先读取 env.KeyID 选择密钥；信封算法必须与密钥长度对应。
+//
+// 参数：
+//   - key：AES 密钥字节切片，长度必须与信封算法一致。
+//   - env：待解密的信封，不能为 nil。
+//
+// 返回：
+//   - []byte：认证通过后解出的明文；失败时为 nil。
+//   - error：信封为 nil 或格式非法（kitenvelope.ErrMalformed）、算法与密钥不匹配
+//     （kitenvelope.ErrUnsupportedAlgorithm）、nonce 长度不匹配或认证失败时返回错误。
+func OpenEnvelope(key []byte, env *kitenvelope.Envelope) ([]byte, error) {
+	if nil == env {
+		return nil, kitenvelope.ErrMalformed
+	}
+	if want := gcmAlgorithm(len(key)); want != env.Algorithm {
+		return nil, fmt.Errorf("%w: 信封算法 %s 与 %d 字节密钥不匹配", kitenvelope.ErrUnsupportedAlgorithm, env.Algorithm, len(key))
+	}
+
+	aead, err := newGCM(key)
+	if nil != err {
+		return nil, err
+	}
+	aad, err := env.AdditionalData()
+	if nil != err {
+		return nil, err
+	}
+	if len(env.Nonce) != aead.NonceSize() {
+		return nil, fmt.Errorf("invalid nonce length: got %d, want %d", len(env.Nonce), aead.NonceSize())
+	}
+
+	sealed := make([]byte, 0, len(env.Ciphertext)+len(env.Tag))
+	sealed = append(sealed, env.Ciphertext...)
+	sealed = append(sealed, env.Tag...)
+	return aead.Open(nil, env.Nonce, sealed, aad)
+}
+
+// EncryptStringEnvelope 加密字符串，并返回文本格式的密文信封。
+//
+// 返回值为信封二进制格式的无填充 Base64 URL 编码，可交给 DecryptStringEnvelope 解密，
+// 也可以通过 kitenvelope.Parse 读取 KeyID 后再选择密钥。
+//
+// 参数：
+//   - key：AES 密钥字节切片，长度必须为 16、24 或 32 字节。
+//   - keyID：标识密钥的字符串，长度不能超过 255 字节。
+//   - data：待加密的字符串明文。
+//
+// 返回：
+//   - string：文本格式的密文信封；失败时为空字符串。
+//   - error：加密失败时返回错误，同 SealEnvelope。
+func EncryptStringEnvelope(key []byte, keyID, data string) (string, error) {
+	env, err := SealEnvelope(key, keyID, []byte(data))
+	if nil != err {
+		return "", err
+	}
+	text, err := env.MarshalText()
+	if nil != err {
+		return "", err
+	}
+	return string(text), nil
+}
+
+// DecryptStringEnvelope 解析文本格式的密文信封并解密为字符串。
+//
+// 参数：
+//   - key：AES 密钥字节切片，长度必须与信封算法一致。
+//   - text：EncryptStringEnvelope 返回的文本格式信封。
+//
+// 返回：
+//   - string：解密得到的明文字符串；失败时为空字符串。
+//   - error：信封解析失败或解密失败时返回错误，同 kitenvelope.Parse 与 OpenEnvelope。
+func DecryptStringEnvelope(key []byte, text string) (string, error) {
+	env, err := kitenvelope.Parse(text)
+	if nil != err {
+		return "", err
+	}
+	data, err := OpenEnvelope(key, env)
+	if nil != err {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// newGCM 使用密钥创建标准 nonce 长度的 AES-GCM 实例。
+//
+// 参数：
+//   - key：AES 密钥字节切片。
+//
+// 返回：
+//   - cipher.AEAD：AES-GCM 实例。
+//   - error：密钥长度非法时返回错误。
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if nil != err {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// gcmAlgorithm 返回密钥长度对应的信封算法。
+//
+// 参数：
+//   - keyLength：AES 密钥字节长度。
+//
+// 返回：
+//   - kitenvelope.Algorithm：对应的算法；长度非法时返回 AlgorithmUnknown。
+func gcmAlgorithm(keyLength int) kitenvelope.Algorithm {
+	switch keyLength {
+	case 16:
+		return kitenvelope.AlgorithmA128GCM
+	case 24:
+		return kitenvelope.AlgorithmA192GCM
+	case 32:
+		return kitenvelope.AlgorithmA256GCM
+	default:
+		return kitenvelope.AlgorithmUnknown
+	}
+}
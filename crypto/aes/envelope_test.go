@@ -0,0 +1,169 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package aes
+
+import (
+	"crypto/aes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	kitenvelope "github.com/fsyyft-go/kit/crypto/envelope"
+)
+
+// TestSealEnvelope 验证不同密钥长度的信封加解密往返。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestSealEnvelope(t *testing.T) {
+	tests := []struct {
+		name          string
+		description   string
+		key           []byte
+		data          []byte
+		wantAlgorithm kitenvelope.Algorithm
+	}{
+		{name: "success/a128gcm", description: "验证 16 字节密钥使用 A128GCM。", key: []byte(testKeyBytes[:16]), data: []byte(testPlainText), wantAlgorithm: kitenvelope.AlgorithmA128GCM},
+		{name: "success/a192gcm", description: "验证 24 字节密钥使用 A192GCM。", key: []byte(testKeyBytes[:24]), data: []byte(testPlainText), wantAlgorithm: kitenvelope.AlgorithmA192GCM},
+		{name: "success/a256gcm", description: "验证 32 字节密钥使用 A256GCM。", key: []byte(testKeyBytes), data: []byte(testPlainText), wantAlgorithm: kitenvelope.AlgorithmA256GCM},
+		{name: "boundary/empty", description: "验证空明文。", key: []byte(testKeyBytes), wantAlgorithm: kitenvelope.AlgorithmA256GCM},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			env, err := SealEnvelope(tt.key, "k1", tt.data)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAlgorithm, env.Algorithm)
+			assert.Equal(t, "k1", env.KeyID)
+			assert.Len(t, env.Nonce, testNonceLength)
+			assert.Len(t, env.Tag, 16)
+			assert.Len(t, env.Ciphertext, len(tt.data))
+
+			data, err := env.Marshal()
+			require.NoError(t, err)
+			parsed, err := kitenvelope.Unmarshal(data)
+			require.NoError(t, err)
+			plain, err := OpenEnvelope(tt.key, parsed)
+			require.NoError(t, err)
+			assert.Equal(t, string(tt.data), string(plain))
+		})
+	}
+}
+
+// TestOpenEnvelope_Errors 验证信封被篡改或密钥不匹配时解密失败。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestOpenEnvelope_Errors(t *testing.T) {
+	key := []byte(testKeyBytes)
+
+	tests := []struct {
+		name        string
+		description string
+		key         []byte
+		mutate      func(env *kitenvelope.Envelope)
+		wantErrIs   error
+	}{
+		{
+			name:        "error/key-id-tampered",
+			description: "验证 key-id 被替换时认证失败。",
+			key:         key,
+			mutate:      func(env *kitenvelope.Envelope) { env.KeyID = "k2" },
+		},
+		{
+			name:        "error/ciphertext-tampered",
+			description: "验证密文被篡改时认证失败。",
+			key:         key,
+			mutate:      func(env *kitenvelope.Envelope) { env.Ciphertext[0] ^= 0xff },
+		},
+		{
+			name:        "error/tag-tampered",
+			description: "验证认证标签被篡改时认证失败。",
+			key:         key,
+			mutate:      func(env *kitenvelope.Envelope) { env.Tag[0] ^= 0xff },
+		},
+		{
+			name:        "error/algorithm-mismatch",
+			description: "验证算法与密钥长度不匹配时返回 ErrUnsupportedAlgorithm。",
+			key:         key,
+			mutate:      func(env *kitenvelope.Envelope) { env.Algorithm = kitenvelope.AlgorithmA128GCM },
+			wantErrIs:   kitenvelope.ErrUnsupportedAlgorithm,
+		},
+		{
+			name:        "error/wrong-key",
+			description: "验证使用其他密钥时认证失败。",
+			key:         []byte("10987654321098765432109876543210"),
+		},
+		{
+			name:        "error/invalid-key",
+			description: "验证非法长度的密钥返回 ErrUnsupportedAlgorithm。",
+			key:         []byte("short"),
+			wantErrIs:   kitenvelope.ErrUnsupportedAlgorithm,
+		},
+		{
+			name:        "error/nonce-length",
+			description: "验证 nonce 长度不匹配时返回错误。",
+			key:         key,
+			mutate:      func(env *kitenvelope.Envelope) { env.Nonce = env.Nonce[:8] },
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			env, err := SealEnvelope(key, "k1", []byte(testPlainText))
+			require.NoError(t, err)
+			if nil != tt.mutate {
+				tt.mutate(env)
+			}
+
+			plain, err := OpenEnvelope(tt.key, env)
+			require.Error(t, err)
+			assert.Nil(t, plain)
+			if nil != tt.wantErrIs {
+				assert.ErrorIs(t, err, tt.wantErrIs)
+			}
+		})
+	}
+
+	_, err := OpenEnvelope(key, nil)
+	assert.ErrorIs(t, err, kitenvelope.ErrMalformed)
+
+	_, err = SealEnvelope([]byte("short"), "k1", nil)
+	assert.IsType(t, aes.KeySizeError(0), err)
+	_, err = SealEnvelope(key, string(make([]byte, 256)), nil)
+	assert.ErrorIs(t, err, kitenvelope.ErrFieldTooLong)
+}
+
+// TestStringEnvelope 验证文本格式信封的加解密。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestStringEnvelope(t *testing.T) {
+	key := []byte(testKeyBytes)
+
+	text, err := EncryptStringEnvelope(key, "k1", testPlainText)
+	require.NoError(t, err)
+	env, err := kitenvelope.Parse(text)
+	require.NoError(t, err)
+	assert.Equal(t, "k1", env.KeyID)
+
+	plain, err := DecryptStringEnvelope(key, text)
+	require.NoError(t, err)
+	assert.Equal(t, testPlainText, plain)
+
+	_, err = EncryptStringEnvelope([]byte("short"), "k1", testPlainText)
+	assert.Error(t, err)
+	_, err = DecryptStringEnvelope(key, "@@")
+	assert.ErrorIs(t, err, kitenvelope.ErrMalformed)
+	_, err = DecryptStringEnvelope([]byte(testKeyBytes[:16]), text)
+	assert.ErrorIs(t, err, kitenvelope.ErrUnsupportedAlgorithm)
+}
@@ -5,8 +5,9 @@
 package des
 
 import (
-	"bytes"
 	"errors"
+
+	kitpadding "github.com/fsyyft-go/kit/crypto/padding"
 )
 
 var (
@@ -28,9 +29,9 @@ func GetDefaultDESKey() string {
 
 // PKCS7Padding 使用 PKCS7 标准对 data 进行填充。
 //
-// blockSize 必须大于 0，且填充长度需要能放入单字节；函数不会对该约束做显式校验，
-// 传入非法 blockSize 可能导致 panic 或产生不可逆的填充数据。返回切片通过 append 构造，
-// 在容量允许时可能与 data 共享底层数组。
+// blockSize 必须在 [1, 255] 范围内，否则函数 panic。返回切片总是新分配的，不与 data 共享底层数组。
+//
+// Deprecated: 使用 padding.PKCS7Pad，它以 error 报告非法的 blockSize，并可被其它分组密码共用。
 //
 // 参数：
 //   - data: 需要填充的原始数据，可为空。
@@ -39,24 +40,10 @@ func GetDefaultDESKey() string {
 // 返回：
 //   - []byte: 追加 PKCS7 padding 后的数据。
 func PKCS7Padding(data []byte, blockSize int) []byte {
-	// PKCS7 填充规则说明：
-	// 1. 如果数据长度小于块大小，填充的值为"缺少的字节数"。
-	// 2. 如果数据长度是块大小的整数倍，填充一个完整块，每个字节的值为块大小。
-	// 3. 填充值的范围是 1-255，保证能放入一个字节中。
-
-	// 计算需要填充的字节数：
-	// - 如果数据长度是块大小的整数倍，填充一个完整块。
-	// - 如果不是整数倍，填充到下一个块大小。
-	padding := blockSize - len(data)%blockSize
-
-	// 创建填充数据：
-	// - 使用 bytes.Repeat 函数生成指定数量的填充字节。
-	// - 每个填充字节的值都等于填充的字节数。
-	padData := bytes.Repeat([]byte{byte(padding)}, padding)
-
-	// 将填充数据追加到原始数据后面。
-	d := append(data, padData...)
-
+	d, err := kitpadding.PKCS7Pad(data, blockSize)
+	if nil != err {
+		panic(err)
+	}
 	return d
 }
 
@@ -66,6 +53,8 @@ func PKCS7Padding(data []byte, blockSize int) []byte {
 // 由于没有 blockSize 参数，函数不会校验 padding 长度是否不超过加密块大小。
 // 返回的切片是 data 的子切片，会与输入共享底层数组。
 //
+// Deprecated: 使用 padding.PKCS7Unpad，它额外校验块大小并以常量时间检查填充内容。
+//
 // 参数：
 //   - data: 已经填充过的数据，长度必须大于 0，最后一个字节用于表示待移除的 padding 长度。
 //
@@ -10,6 +10,8 @@ import (
 	"encoding/hex"
 	"fmt"
 	"strings"
+
+	kitpadding "github.com/fsyyft-go/kit/crypto/padding"
 )
 
 // EncryptStringCBCPkCS7PaddingStringHex 使用字符串 key 对 data 执行 DES-CBC 加密并返回十六进制密文。
@@ -105,8 +107,8 @@ func EncryptCBCPkCS7PaddingAloneIV(key, iv, data []byte) ([]byte, error) {
 		// 验证 IV 长度是否等于块大小。
 		err = fmt.Errorf("IV length must equal block size")
 	} else {
-		// 对数据进行 PKCS7 填充。
-		dataPadded := PKCS7Padding(data, block.BlockSize())
+		// 对数据进行 PKCS7 填充，DES 块大小固定为 8，不会返回错误。
+		dataPadded, _ := kitpadding.PKCS7Pad(data, block.BlockSize())
 		// 创建 CBC 加密器。
 		mode := cipher.NewCBCEncrypter(block, iv)
 		// 分配结果缓冲区。
@@ -173,7 +175,7 @@ func DecryptStringCBCPkCS7PaddingHex(keyHex, dataHex string) (string, error) {
 // DecryptCBCPkCS7Padding 使用 key 兼作 DES 密钥和 IV 执行 CBC 解密。
 //
 // 该包装函数仅用于兼容历史调用方式。密文长度不是块大小整数倍时返回 error，不会 panic；
-// 块对齐后仅返回 PKCS7UnPadding 当前能识别的 padding 错误。
+// 块对齐后 padding 非法时返回包装 padding.ErrInvalidPadding 的错误。
 //
 // 参数：
 //   - key: 同时作为 DES 密钥和 IV 的字节切片，长度必须满足 crypto/des.NewCipher 要求。
@@ -181,7 +183,7 @@ func DecryptStringCBCPkCS7PaddingHex(keyHex, dataHex string) (string, error) {
 //
 // 返回：
 //   - []byte: 去除 PKCS7 padding 后的明文字节切片；发生错误时为 nil。
-//   - error: key 长度非法、data 长度不满足 CBC 分组要求，或 PKCS7UnPadding 当前能识别的 padding 错误。
+//   - error: key 长度非法、data 长度不满足 CBC 分组要求，或 padding 非法时返回包装 padding.ErrInvalidPadding 的错误。
 func DecryptCBCPkCS7Padding(key, data []byte) ([]byte, error) {
 	// 使用相同的值作为密钥和 IV。
 	return DecryptCBCPkCS7PaddingAloneIV(key, key, data)
@@ -190,7 +192,7 @@ func DecryptCBCPkCS7Padding(key, data []byte) ([]byte, error) {
 // DecryptCBCPkCS7PaddingAloneIV 使用独立 IV 执行 DES-CBC 解密并移除 PKCS7 padding。
 //
 // iv 长度必须等于 DES block size。data 长度不是块大小整数倍时返回 error，
-// 不会调用到底层 CBC 解密器产生 panic；块对齐后仅返回 PKCS7UnPadding 当前能识别的 padding 错误。
+// 不会调用到底层 CBC 解密器产生 panic；块对齐后 padding 非法时返回包装 padding.ErrInvalidPadding 的错误。
 //
 // 参数：
 //   - key: DES 密钥字节切片，长度必须满足 crypto/des.NewCipher 要求。
@@ -199,7 +201,7 @@ func DecryptCBCPkCS7Padding(key, data []byte) ([]byte, error) {
 //
 // 返回：
 //   - []byte: 解密并去除 PKCS7 padding 后的明文数据；发生错误时为 nil。
-//   - error: key 长度非法、iv 长度不是 DES block size、data 长度不满足 CBC 分组要求，或 PKCS7UnPadding 当前能识别的 padding 错误。
+//   - error: key 长度非法、iv 长度不是 DES block size、data 长度不满足 CBC 分组要求，或 padding 非法时返回包装 padding.ErrInvalidPadding 的错误。
 func DecryptCBCPkCS7PaddingAloneIV(key, iv, data []byte) ([]byte, error) {
 	var result []byte
 	var err error
@@ -220,8 +222,12 @@ func DecryptCBCPkCS7PaddingAloneIV(key, iv, data []byte) ([]byte, error) {
 		dataPadded := make([]byte, len(data))
 		// 执行解密操作。
 		mode.CryptBlocks(dataPadded, data)
-		// 移除 PKCS7 填充。
-		result, err = PKCS7UnPadding(dataPadded)
+		// 移除 PKCS7 填充，校验以常量时间完成且所有非法填充返回同一错误。
+		if unpadded, errUnpad := kitpadding.PKCS7Unpad(dataPadded, block.BlockSize()); nil != errUnpad {
+			err = fmt.Errorf("invalid padding: %w", errUnpad)
+		} else {
+			result = unpadded
+		}
 	}
 
 	return result, err
@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	kitdes "github.com/fsyyft-go/kit/crypto/des"
+	kitpadding "github.com/fsyyft-go/kit/crypto/padding"
 )
 
 // TestCBCPkCS7HexWrappers_PublicContracts 验证 CBC PKCS7 十六进制包装函数的公开行为。
@@ -141,6 +142,7 @@ func TestCBCPkCS7DecryptErrorBoundaries(t *testing.T) {
 			require.Error(t, gotErr)
 			assert.Empty(t, got)
 			assert.Contains(t, gotErr.Error(), tt.wantErrContains)
+			assert.ErrorIs(t, gotErr, kitpadding.ErrInvalidPadding)
 		})
 	}
 }
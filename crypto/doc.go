@@ -6,7 +6,8 @@
 //
 // 本包不提供根级别的加密、哈希或一次性密码 API，主要用于在 Go 文档中
 // 说明 crypto 目录的组织方式。具体能力由下级子包提供，调用方应直接导入
-// 所需子包，例如 aes、des、rsa、md5、sha 或 otp 相关实现。
+// 所需子包，例如 aes、des、rsa、md5、sha 或 otp 相关实现；envelope 定义 aes 与 rsa
+// 共用的自描述密文信封格式，padding 提供各分组密码共用的 PKCS7、零填充与 ISO 10126 填充，password 提供 argon2id/bcrypt 密码哈希与强度校验，uuid 提供 UUIDv7 与可排序令牌。
 //
 // 使用这些子包时，调用方需要结合各子包文档处理密钥来源、随机数、密文
 // 编码、错误返回和兼容性要求。涉及新业务安全设计时，应优先选择当前
@@ -0,0 +1,15 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+// Package envelope 定义自描述的密文信封格式。
+//
+// Envelope 以紧凑的二进制格式记录魔数、版本号、算法、key-id、nonce/IV、认证标签与密文，
+// 解密方无需事先约定拼接方式即可识别算法与密钥，从而安全地轮换密钥和算法。
+// Marshal 与 Unmarshal 处理二进制格式；MarshalText、Parse 与 String 处理无填充 Base64 URL
+// 文本格式，因此 Envelope 也可以直接作为 JSON 字符串字段编码。
+//
+// AdditionalData 返回魔数到 key-id 的信封头部，加密方应将其作为 AEAD 附加认证数据或
+// OAEP label 绑定，防止头部被替换。本包只定义格式，不执行加密；crypto/aes 与 crypto/rsa
+// 的 SealEnvelope / OpenEnvelope 负责生成与解密信封。
+package envelope
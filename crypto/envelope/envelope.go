@@ -0,0 +1,362 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package envelope
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math"
+)
+
+const (
+	// Version1 是当前的信封格式版本。
+	Version1 uint8 = 1
+
+	// headerFixedLength 是信封固定头部的长度：魔数、版本号、算法与 key-id 长度。
+	headerFixedLength = 5
+)
+
+// 以下为信封中可以标识的加密算法。
+const (
+	// AlgorithmUnknown 表示未知算法，不能用于序列化。
+	AlgorithmUnknown Algorithm = 0
+	// AlgorithmA128GCM 表示 AES-128-GCM。
+	AlgorithmA128GCM Algorithm = 1
+	// AlgorithmA192GCM 表示 AES-192-GCM。
+	AlgorithmA192GCM Algorithm = 2
+	// AlgorithmA256GCM 表示 AES-256-GCM。
+	AlgorithmA256GCM Algorithm = 3
+	// AlgorithmRSAOAEP256A256GCM 表示 SHA-256 RSA-OAEP 封装 AES-256-GCM 数据密钥的混合加密。
+	AlgorithmRSAOAEP256A256GCM Algorithm = 16
+)
+
+var (
+	// magic 是信封的魔数，取自 "KE"（kit envelope）。
+	magic = [2]byte{'K', 'E'}
+
+	// ErrMalformed 表示信封的二进制或文本格式不正确。
+	//
+	// 魔数不匹配、长度字段与实际数据不一致或文本编码非法时返回该错误。调用方可以使用 errors.Is 判断该错误。
+	ErrMalformed = errors.New("密文信封格式不正确。")
+
+	// ErrUnsupportedVersion 表示信封版本不受支持。
+	//
+	// 调用方可以使用 errors.Is 判断该错误。
+	ErrUnsupportedVersion = errors.New("密文信封版本不受支持。")
+
+	// ErrUnsupportedAlgorithm 表示信封中的算法未知，或与解密方使用的算法不一致。
+	//
+	// 调用方可以使用 errors.Is 判断该错误。
+	ErrUnsupportedAlgorithm = errors.New("密文信封算法不受支持。")
+
+	// ErrFieldTooLong 表示 key-id、nonce 或 tag 超过单字节长度字段能表示的 255 字节。
+	//
+	// 调用方可以使用 errors.Is 判断该错误。
+	ErrFieldTooLong = errors.New("密文信封字段过长。")
+)
+
+type (
+	// Algorithm 标识信封中密文使用的加密算法。
+	Algorithm uint8
+
+	// Envelope 是自描述的密文信封。
+	//
+	// 二进制格式（版本 1）为：
+	//
+	//	2 字节魔数 "KE" || 1 字节版本号 || 1 字节算法 || 1 字节 key-id 长度 || key-id ||
+	//	1 字节 nonce 长度 || nonce || 1 字节 tag 长度 || tag || ciphertext
+	//
+	// 魔数到 key-id 的部分由 AdditionalData 返回，加密时应作为附加认证数据绑定，
+	// 防止版本、算法或 key-id 被替换。文本格式为上述二进制格式的无填充 Base64 URL 编码。
+	Envelope struct {
+		// Version 是信封格式版本，为 0 时序列化为 Version1。
+		Version uint8
+		// Algorithm 是密文使用的加密算法。
+		Algorithm Algorithm
+		// KeyID 标识加密使用的密钥，解密方据此选择密钥，便于密钥轮换。
+		KeyID string
+		// Nonce 是加密使用的 nonce 或 IV，算法不需要时为空。
+		Nonce []byte
+		// Ciphertext 是不含认证标签的密文。
+		Ciphertext []byte
+		// Tag 是认证标签，算法不产生独立标签时为空。
+		Tag []byte
+	}
+)
+
+// String 返回算法名称。
+//
+// 参数：无。
+//
+// 返回：
+//   - string: 算法名称；未知算法返回 "unknown(n)"。
+func (a Algorithm) String() string {
+	switch a {
+	case AlgorithmA128GCM:
+		return "A128GCM"
+	case AlgorithmA192GCM:
+		return "A192GCM"
+	case AlgorithmA256GCM:
+		return "A256GCM"
+	case AlgorithmRSAOAEP256A256GCM:
+		return "RSA-OAEP-256+A256GCM"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(a))
+	}
+}
+
+// Known 检查算法是否为本包定义的算法。
+//
+// 参数：无。
+//
+// 返回：
+//   - bool: 算法已定义时返回 true。
+func (a Algorithm) Known() bool {
+	switch a {
+	case AlgorithmA128GCM, AlgorithmA192GCM, AlgorithmA256GCM, AlgorithmRSAOAEP256A256GCM:
+		return true
+	default:
+		return false
+	}
+}
+
+// AdditionalData 返回信封头部，即魔数、版本号、算法与 key-id 部分。
+//
+// 加密与解密时应把返回值作为 AEAD 的附加认证数据或 OAEP label，使头部被篡改时认证失败。
+//
+// 参数：无。
+//
+// 返回：
+//   - []byte: 信封头部。
+//   - error: key-id 过长（ErrFieldTooLong）或算法未知（ErrUnsupportedAlgorithm）时返回错误。
+func (e *Envelope) AdditionalData() ([]byte, error) {
+	if err := e.validate(); nil != err {
+		return nil, err
+	}
+	return e.appendHeader(make([]byte, 0, headerFixedLength+len(e.KeyID))), nil
+}
+
+// Marshal 将信封序列化为二进制格式。
+//
+// 参数：无。
+//
+// 返回：
+//   - []byte: 序列化后的信封。
+//   - error: 字段过长（ErrFieldTooLong）或算法未知（ErrUnsupportedAlgorithm）时返回错误。
+func (e *Envelope) Marshal() ([]byte, error) {
+	if err := e.validate(); nil != err {
+		return nil, err
+	}
+
+	size := headerFixedLength + len(e.KeyID) + 2 + len(e.Nonce) + len(e.Tag) + len(e.Ciphertext)
+	data := e.appendHeader(make([]byte, 0, size))
+	data = append(data, uint8(len(e.Nonce)))
+	data = append(data, e.Nonce...)
+	data = append(data, uint8(len(e.Tag)))
+	data = append(data, e.Tag...)
+	data = append(data, e.Ciphertext...)
+	return data, nil
+}
+
+// MarshalBinary 实现 encoding.BinaryMarshaler，等同于 Marshal。
+//
+// 参数：无。
+//
+// 返回：
+//   - []byte: 序列化后的信封。
+//   - error: 序列化失败时返回错误。
+func (e *Envelope) MarshalBinary() ([]byte, error) {
+	return e.Marshal()
+}
+
+// UnmarshalBinary 实现 encoding.BinaryUnmarshaler，从二进制格式解析信封。
+//
+// 解析出的切片字段均为 data 的副本。
+//
+// 参数：
+//   - data: 序列化后的信封。
+//
+// 返回：
+//   - error: 格式非法（ErrMalformed）、版本不支持（ErrUnsupportedVersion）或算法未知（ErrUnsupportedAlgorithm）时返回错误。
+func (e *Envelope) UnmarshalBinary(data []byte) error {
+	parsed, err := Unmarshal(data)
+	if nil != err {
+		return err
+	}
+	*e = *parsed
+	return nil
+}
+
+// MarshalText 实现 encoding.TextMarshaler，返回无填充 Base64 URL 编码的信封。
+//
+// 参数：无。
+//
+// 返回：
+//   - []byte: 文本格式的信封。
+//   - error: 序列化失败时返回错误。
+func (e *Envelope) MarshalText() ([]byte, error) {
+	data, err := e.Marshal()
+	if nil != err {
+		return nil, err
+	}
+	text := make([]byte, base64.RawURLEncoding.EncodedLen(len(data)))
+	base64.RawURLEncoding.Encode(text, data)
+	return text, nil
+}
+
+// UnmarshalText 实现 encoding.TextUnmarshaler，从文本格式解析信封。
+//
+// 参数：
+//   - text: 文本格式的信封。
+//
+// 返回：
+//   - error: 解析失败时返回错误。
+func (e *Envelope) UnmarshalText(text []byte) error {
+	parsed, err := Parse(string(text))
+	if nil != err {
+		return err
+	}
+	*e = *parsed
+	return nil
+}
+
+// String 返回文本格式的信封；序列化失败时返回空字符串。
+//
+// 参数：无。
+//
+// 返回：
+//   - string: 无填充 Base64 URL 编码的信封。
+func (e *Envelope) String() string {
+	text, err := e.MarshalText()
+	if nil != err {
+		return ""
+	}
+	return string(text)
+}
+
+// Unmarshal 从二进制格式解析信封。
+//
+// 参数：
+//   - data: 序列化后的信封。
+//
+// 返回：
+//   - *Envelope: 解析出的信封，切片字段均为 data 的副本。
+//   - error: 格式非法（ErrMalformed）、版本不支持（ErrUnsupportedVersion）或算法未知（ErrUnsupportedAlgorithm）时返回错误。
+func Unmarshal(data []byte) (*Envelope, error) {
+	if len(data) < headerFixedLength || magic[0] != data[0] || magic[1] != data[1] {
+		return nil, ErrMalformed
+	}
+	if Version1 != data[2] {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, data[2])
+	}
+	e := &Envelope{Version: data[2], Algorithm: Algorithm(data[3])}
+	if !e.Algorithm.Known() {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedAlgorithm, e.Algorithm)
+	}
+
+	rest := data[4:]
+	keyID, rest, ok := readField(rest)
+	if !ok {
+		return nil, ErrMalformed
+	}
+	nonce, rest, ok := readField(rest)
+	if !ok {
+		return nil, ErrMalformed
+	}
+	tag, rest, ok := readField(rest)
+	if !ok {
+		return nil, ErrMalformed
+	}
+
+	e.KeyID = string(keyID)
+	e.Nonce = clone(nonce)
+	e.Tag = clone(tag)
+	e.Ciphertext = clone(rest)
+	return e, nil
+}
+
+// Parse 从文本格式解析信封。
+//
+// 参数：
+//   - s: 无填充 Base64 URL 编码的信封。
+//
+// 返回：
+//   - *Envelope: 解析出的信封。
+//   - error: 文本编码非法时返回 ErrMalformed，其余错误同 Unmarshal。
+func Parse(s string) (*Envelope, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if nil != err {
+		return nil, fmt.Errorf("%w: %v", ErrMalformed, err)
+	}
+	return Unmarshal(data)
+}
+
+// validate 检查信封字段能否序列化。
+//
+// 参数：无。
+//
+// 返回：
+//   - error: 版本不支持、算法未知或字段过长时返回错误。
+func (e *Envelope) validate() error {
+	if 0 != e.Version && Version1 != e.Version {
+		return fmt.Errorf("%w: %d", ErrUnsupportedVersion, e.Version)
+	}
+	if !e.Algorithm.Known() {
+		return fmt.Errorf("%w: %s", ErrUnsupportedAlgorithm, e.Algorithm)
+	}
+	switch {
+	case len(e.KeyID) > math.MaxUint8:
+		return fmt.Errorf("%w: key-id 长度 %d", ErrFieldTooLong, len(e.KeyID))
+	case len(e.Nonce) > math.MaxUint8:
+		return fmt.Errorf("%w: nonce 长度 %d", ErrFieldTooLong, len(e.Nonce))
+	case len(e.Tag) > math.MaxUint8:
+		return fmt.Errorf("%w: tag 长度 %d", ErrFieldTooLong, len(e.Tag))
+	}
+	return nil
+}
+
+// appendHeader 将信封头部追加到 dst。
+//
+// 参数：
+//   - dst: 目标切片。
+//
+// 返回：
+//   - []byte: 追加后的切片。
+func (e *Envelope) appendHeader(dst []byte) []byte {
+	dst = append(dst, magic[0], magic[1], Version1, uint8(e.Algorithm), uint8(len(e.KeyID)))
+	return append(dst, e.KeyID...)
+}
+
+// readField 读取单字节长度前缀的字段。
+//
+// 参数：
+//   - data: 以长度字节开头的数据。
+//
+// 返回：
+//   - []byte: 字段内容。
+//   - []byte: 字段之后的剩余数据。
+//   - bool: 数据长度足够时返回 true。
+func readField(data []byte) ([]byte, []byte, bool) {
+	if len(data) < 1 || len(data) < 1+int(data[0]) {
+		return nil, nil, false
+	}
+	n := 1 + int(data[0])
+	return data[1:n], data[n:], true
+}
+
+// clone 复制切片，空切片返回 nil。
+//
+// 参数：
+//   - data: 源切片。
+//
+// 返回：
+//   - []byte: 副本。
+func clone(data []byte) []byte {
+	if 0 == len(data) {
+		return nil
+	}
+	return append([]byte(nil), data...)
+}
@@ -0,0 +1,191 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package envelope
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAlgorithm 验证算法名称与已知算法判断。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestAlgorithm(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		give        Algorithm
+		wantString  string
+		wantKnown   bool
+	}{
+		{name: "success/a128gcm", description: "验证 AES-128-GCM。", give: AlgorithmA128GCM, wantString: "A128GCM", wantKnown: true},
+		{name: "success/a192gcm", description: "验证 AES-192-GCM。", give: AlgorithmA192GCM, wantString: "A192GCM", wantKnown: true},
+		{name: "success/a256gcm", description: "验证 AES-256-GCM。", give: AlgorithmA256GCM, wantString: "A256GCM", wantKnown: true},
+		{name: "success/rsa", description: "验证 RSA 混合加密。", give: AlgorithmRSAOAEP256A256GCM, wantString: "RSA-OAEP-256+A256GCM", wantKnown: true},
+		{name: "boundary/unknown", description: "验证未知算法。", give: AlgorithmUnknown, wantString: "unknown(0)"},
+		{name: "boundary/undefined", description: "验证未定义的算法编号。", give: Algorithm(200), wantString: "unknown(200)"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			assert.Equal(t, tt.wantString, tt.give.String())
+			assert.Equal(t, tt.wantKnown, tt.give.Known())
+		})
+	}
+}
+
+// TestEnvelope_RoundTrip 验证二进制、文本与 JSON 编码的往返。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestEnvelope_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		give        Envelope
+		want        Envelope
+	}{
+		{
+			name:        "success/full",
+			description: "验证包含全部字段的信封。",
+			give:        Envelope{Version: Version1, Algorithm: AlgorithmA256GCM, KeyID: "k-2025", Nonce: []byte{1, 2, 3}, Ciphertext: []byte("cipher"), Tag: []byte{9, 8}},
+			want:        Envelope{Version: Version1, Algorithm: AlgorithmA256GCM, KeyID: "k-2025", Nonce: []byte{1, 2, 3}, Ciphertext: []byte("cipher"), Tag: []byte{9, 8}},
+		},
+		{
+			name:        "boundary/empty-fields",
+			description: "验证版本为 0 时按 Version1 序列化，空字段解析为 nil。",
+			give:        Envelope{Algorithm: AlgorithmRSAOAEP256A256GCM, Nonce: []byte{}},
+			want:        Envelope{Version: Version1, Algorithm: AlgorithmRSAOAEP256A256GCM},
+		},
+		{
+			name:        "boundary/max-field-length",
+			description: "验证 255 字节的 key-id、nonce 与 tag。",
+			give:        Envelope{Algorithm: AlgorithmA128GCM, KeyID: strings.Repeat("k", 255), Nonce: make([]byte, 255), Tag: make([]byte, 255)},
+			want:        Envelope{Version: Version1, Algorithm: AlgorithmA128GCM, KeyID: strings.Repeat("k", 255), Nonce: make([]byte, 255), Tag: make([]byte, 255)},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			data, err := tt.give.Marshal()
+			require.NoError(t, err)
+			assert.Equal(t, []byte("KE"), data[:2])
+			got, err := Unmarshal(data)
+			require.NoError(t, err)
+			assert.Equal(t, &tt.want, got)
+
+			binary, err := tt.give.MarshalBinary()
+			require.NoError(t, err)
+			var fromBinary Envelope
+			require.NoError(t, fromBinary.UnmarshalBinary(binary))
+			assert.Equal(t, tt.want, fromBinary)
+
+			text := tt.give.String()
+			assert.NotContains(t, text, "=")
+			parsed, err := Parse(text)
+			require.NoError(t, err)
+			assert.Equal(t, &tt.want, parsed)
+
+			encoded, err := json.Marshal(&tt.give)
+			require.NoError(t, err)
+			assert.Equal(t, `"`+text+`"`, string(encoded))
+			var fromJSON Envelope
+			require.NoError(t, json.Unmarshal(encoded, &fromJSON))
+			assert.Equal(t, tt.want, fromJSON)
+
+			aad, err := tt.give.AdditionalData()
+			require.NoError(t, err)
+			assert.Equal(t, data[:headerFixedLength+len(tt.give.KeyID)], aad)
+		})
+	}
+}
+
+// TestEnvelope_MarshalErrors 验证无法序列化的信封返回错误。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestEnvelope_MarshalErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		give        Envelope
+		wantErr     error
+	}{
+		{name: "error/version", description: "验证不支持的版本。", give: Envelope{Version: 2, Algorithm: AlgorithmA256GCM}, wantErr: ErrUnsupportedVersion},
+		{name: "error/algorithm", description: "验证未知算法。", give: Envelope{Algorithm: AlgorithmUnknown}, wantErr: ErrUnsupportedAlgorithm},
+		{name: "error/key-id", description: "验证过长的 key-id。", give: Envelope{Algorithm: AlgorithmA256GCM, KeyID: strings.Repeat("k", 256)}, wantErr: ErrFieldTooLong},
+		{name: "error/nonce", description: "验证过长的 nonce。", give: Envelope{Algorithm: AlgorithmA256GCM, Nonce: make([]byte, 256)}, wantErr: ErrFieldTooLong},
+		{name: "error/tag", description: "验证过长的 tag。", give: Envelope{Algorithm: AlgorithmA256GCM, Tag: make([]byte, 256)}, wantErr: ErrFieldTooLong},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			_, err := tt.give.Marshal()
+			assert.ErrorIs(t, err, tt.wantErr)
+			_, err = tt.give.AdditionalData()
+			assert.ErrorIs(t, err, tt.wantErr)
+			_, err = tt.give.MarshalText()
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Empty(t, tt.give.String())
+		})
+	}
+}
+
+// TestUnmarshal_Errors 验证非法输入的解析错误。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestUnmarshal_Errors(t *testing.T) {
+	valid, err := (&Envelope{Algorithm: AlgorithmA256GCM, KeyID: "k", Nonce: []byte{1}, Tag: []byte{2}}).Marshal()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		description string
+		give        []byte
+		wantErr     error
+	}{
+		{name: "error/empty", description: "验证空输入。", give: nil, wantErr: ErrMalformed},
+		{name: "error/magic", description: "验证魔数不匹配。", give: append([]byte("XX"), valid[2:]...), wantErr: ErrMalformed},
+		{name: "error/version", description: "验证不支持的版本。", give: append([]byte{'K', 'E', 9}, valid[3:]...), wantErr: ErrUnsupportedVersion},
+		{name: "error/algorithm", description: "验证未知算法。", give: append([]byte{'K', 'E', 1, 99}, valid[4:]...), wantErr: ErrUnsupportedAlgorithm},
+		{name: "error/truncated-key-id", description: "验证 key-id 被截断。", give: []byte{'K', 'E', 1, 3, 4, 'k'}, wantErr: ErrMalformed},
+		{name: "error/missing-nonce", description: "验证缺少 nonce 长度字段。", give: valid[:7], wantErr: ErrMalformed},
+		{name: "error/missing-tag", description: "验证缺少 tag 长度字段。", give: valid[:9], wantErr: ErrMalformed},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			got, err := Unmarshal(tt.give)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Nil(t, got)
+
+			var e Envelope
+			assert.ErrorIs(t, e.UnmarshalBinary(tt.give), tt.wantErr)
+		})
+	}
+
+	_, err = Parse("not base64!")
+	assert.ErrorIs(t, err, ErrMalformed)
+	var e Envelope
+	assert.ErrorIs(t, e.UnmarshalText([]byte("@@")), ErrMalformed)
+}
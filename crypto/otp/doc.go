@@ -7,7 +7,13 @@
 // NewOneTimePassword 会解码 Base32 secret，并应用 hash、digits、period、window、issuer
 // 和 label 等可选项。生成出的实例可返回当前口令、窗口内可接受口令，并生成
 // otpauth://totp/ URL；包级 VeryfyPassword 和 GenerateURL 是便捷包装。
-// 本包不提供 HOTP、密钥生成、状态持久化或重放检测；重复校验后的消费语义由调用方负责。
+// WithFormat 可将输出切换为 Steam Guard 的 5 位字符口令或字母数字口令，URL 会同步输出
+// encoder 与实际长度对应的 digits 参数。
+// 本包不提供密钥生成、状态持久化或重放检测；重复校验后的消费语义由调用方负责。
+//
+// GenerateRecoveryCodes 生成一次性恢复码，HashRecoveryCodes 配合 bcrypt 或 HMAC-SHA256
+// 哈希器生成可持久化的哈希，VerifyRecoveryCode 在验证成功时返回移除已用哈希的新列表，
+// 调用方保存该列表即可实现恢复码的单次使用。
 // 当前实现也不会在构建实例时校验 period 必须大于 0，调用方需要保证相关选项有效，
 // 否则后续生成或校验口令时可能因除零而 panic。
 package otp
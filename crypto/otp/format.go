@@ -0,0 +1,107 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package otp
+
+import (
+	"fmt"
+)
+
+// Format 定义一次性密码的输出字符集与长度规则。
+type Format int
+
+const (
+	// FormatNumeric 表示 RFC 4226/6238 定义的纯数字口令，长度由 WithDigits 决定，为默认格式。
+	FormatNumeric Format = iota
+	// FormatSteam 表示 Steam Guard 使用的 5 位口令，字符取自去除易混淆字符后的 26 个数字与大写字母。
+	FormatSteam
+	// FormatAlphanumeric 表示由数字与大写字母组成的口令，长度由 WithDigits 决定，取值范围为 1 到 6。
+	FormatAlphanumeric
+)
+
+const (
+	// steamAlphabet 是 Steam Guard 口令使用的字符表。
+	steamAlphabet = "23456789BCDFGHJKMNPQRTVWXY"
+	// steamDigits 是 Steam Guard 口令的固定长度。
+	steamDigits = 5
+	// alphanumericAlphabet 是字母数字口令使用的字符表。
+	alphanumericAlphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	// alphanumericMaxDigits 是字母数字口令的最大长度；31 位截断值只能覆盖 6 个 36 进制字符。
+	alphanumericMaxDigits = 6
+)
+
+// String 返回格式名称，与 otpauth URL 中 encoder 参数的取值一致。
+//
+// 返回：
+//   - string: numeric、steam 或 alphanumeric；未知格式返回 Format(n)。
+func (f Format) String() string {
+	switch f {
+	case FormatNumeric:
+		return "numeric"
+	case FormatSteam:
+		return "steam"
+	case FormatAlphanumeric:
+		return "alphanumeric"
+	default:
+		return fmt.Sprintf("Format(%d)", int(f))
+	}
+}
+
+// alphabet 返回格式对应的字符表。
+//
+// 返回：
+//   - string: 非数字格式的字符表；数字格式或未知格式返回空字符串。
+func (f Format) alphabet() string {
+	switch f {
+	case FormatSteam:
+		return steamAlphabet
+	case FormatAlphanumeric:
+		return alphanumericAlphabet
+	default:
+		return ""
+	}
+}
+
+// length 返回格式在给定位数配置下的实际口令长度。
+//
+// 参数：
+//   - digits: 原始位数配置。
+//
+// 返回：
+//   - int: Steam 格式固定为 5；字母数字格式在 digits 超出 1 到 6 时取 6；其他格式原样返回 digits。
+func (f Format) length(digits int) int {
+	switch f {
+	case FormatSteam:
+		return steamDigits
+	case FormatAlphanumeric:
+		if digits < 1 || digits > alphanumericMaxDigits {
+			return alphanumericMaxDigits
+		}
+		return digits
+	default:
+		return digits
+	}
+}
+
+// encodeAlphabet 将 HOTP 动态截断值按字符表编码为口令。
+//
+// 与 Steam Guard 的实现一致，每次取截断值对字符表长度的余数作为下一个字符，再用商继续计算，
+// 因此低位在前。
+//
+// 参数：
+//   - value: 动态截断后的 31 位整数。
+//   - alphabet: 非空字符表。
+//   - length: 口令长度。
+//
+// 返回：
+//   - string: 编码后的口令。
+func encodeAlphabet(value uint32, alphabet string, length int) string {
+	size := uint32(len(alphabet)) // nolint: gosec
+	code := make([]byte, length)
+	for idx := range code {
+		code[idx] = alphabet[value%size]
+		value /= size
+	}
+	return string(code)
+}
@@ -0,0 +1,126 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package otp
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFormat_RFC4226Vectors 验证各输出格式基于 RFC 4226 动态截断值的编码结果。
+//
+// RFC 4226 Appendix D 给出计数器 0、1、2 的截断值分别为 0x4c93cf18、0x41397eea、0x082fef30，
+// 期望值按 Steam Guard 的低位在前规则由这些截断值编码得到。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestFormat_RFC4226Vectors(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		options     []OneTimePasswordOption
+		counter     uint64
+		want        string
+	}{
+		{name: "success/numeric", description: "验证数字格式保持 RFC 4226 口令。", counter: 0, want: "755224"},
+		{name: "success/steam-0", description: "验证 Steam 格式计数器 0 的口令。", options: []OneTimePasswordOption{WithFormat(FormatSteam)}, counter: 0, want: "GG5F5"},
+		{name: "success/steam-1", description: "验证 Steam 格式计数器 1 的口令。", options: []OneTimePasswordOption{WithFormat(FormatSteam)}, counter: 1, want: "PV9M4"},
+		{name: "success/steam-ignore-digits", description: "验证 Steam 格式忽略 digits 配置。", options: []OneTimePasswordOption{WithDigits(8), WithFormat(FormatSteam)}, counter: 2, want: "B26KJ"},
+		{name: "success/alphanumeric-0", description: "验证字母数字格式计数器 0 的口令。", options: []OneTimePasswordOption{WithFormat(FormatAlphanumeric)}, counter: 0, want: "4HRW8L"},
+		{name: "success/alphanumeric-short", description: "验证字母数字格式按 digits 截取长度。", options: []OneTimePasswordOption{WithFormat(FormatAlphanumeric), WithDigits(4)}, counter: 1, want: "EBDI"},
+		{name: "success/alphanumeric-clamp", description: "验证字母数字格式的 digits 超过 6 时按 6 位生成。", options: []OneTimePasswordOption{WithFormat(FormatAlphanumeric), WithDigits(8)}, counter: 2, want: "003S92"},
+		{name: "success/unknown", description: "验证未知格式按数字格式处理。", options: []OneTimePasswordOption{WithFormat(Format(99))}, counter: 0, want: "755224"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			// 12345678901234567890 的无填充 Base32 表示。
+			newOneTimePassword, err := NewOneTimePassword("GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ", tt.options...)
+			require.NoError(t, err)
+
+			got, err := newOneTimePassword.passwordAt(tt.counter)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// TestFormat_String 验证格式名称。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestFormat_String(t *testing.T) {
+	assert.Equal(t, "numeric", FormatNumeric.String())
+	assert.Equal(t, "steam", FormatSteam.String())
+	assert.Equal(t, "alphanumeric", FormatAlphanumeric.String())
+	assert.Equal(t, "Format(99)", Format(99).String())
+}
+
+// TestFormat_URLAndVerification 验证非数字格式的口令生成、验证与 otpauth URL 参数。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestFormat_URLAndVerification(t *testing.T) {
+	const secret = "ORSXG5DJNZTQ"
+	tests := []struct {
+		name        string
+		description string
+		options     []OneTimePasswordOption
+		wantURL     string
+		wantPattern string
+	}{
+		{
+			name:        "success/steam",
+			description: "验证 Steam 格式生成 5 位口令，URL 输出 encoder=steam 与 digits=5。",
+			options:     []OneTimePasswordOption{WithFormat(FormatSteam), WithIssuer("Steam")},
+			wantURL:     "otpauth://totp/?secret=ORSXG5DJNZTQ&issuer=Steam&encoder=steam&digits=5&",
+			wantPattern: `^[23456789BCDFGHJKMNPQRTVWXY]{5}$`,
+		},
+		{
+			name:        "success/alphanumeric-default",
+			description: "验证字母数字格式使用默认位数时 URL 不输出 digits。",
+			options:     []OneTimePasswordOption{WithFormat(FormatAlphanumeric)},
+			wantURL:     "otpauth://totp/?secret=ORSXG5DJNZTQ&encoder=alphanumeric&",
+			wantPattern: `^[0-9A-Z]{6}$`,
+		},
+		{
+			name:        "success/alphanumeric-clamp",
+			description: "验证字母数字格式的 URL 按实际口令长度输出 digits。",
+			options:     []OneTimePasswordOption{WithFormat(FormatAlphanumeric), WithDigits(4)},
+			wantURL:     "otpauth://totp/?secret=ORSXG5DJNZTQ&encoder=alphanumeric&digits=4&",
+			wantPattern: `^[0-9A-Z]{4}$`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			newOneTimePassword, err := NewOneTimePassword(secret, tt.options...)
+			require.NoError(t, err)
+
+			password, err := newOneTimePassword.Password()
+			require.NoError(t, err)
+			assert.Regexp(t, tt.wantPattern, password)
+			assert.True(t, newOneTimePassword.VeryfyPassword(password))
+			assert.True(t, newOneTimePassword.VeryfyPassword(strings.ToLower(password)))
+			assert.True(t, VeryfyPassword(secret, password, tt.options...))
+
+			passwords, err := newOneTimePassword.EffectivePassword()
+			require.NoError(t, err)
+			assert.Contains(t, passwords, password)
+
+			assert.Equal(t, tt.wantURL, newOneTimePassword.GenerateURL())
+			assert.Equal(t, tt.wantURL, GenerateURL(secret, tt.options...))
+		})
+	}
+}
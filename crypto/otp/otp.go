@@ -10,7 +10,6 @@ import (
 	"crypto/sha1"
 	"crypto/sha256"
 	"crypto/sha512"
-	"encoding/base32"
 	"encoding/binary"
 	"fmt"
 	"hash"
@@ -18,6 +17,8 @@ import (
 	"strconv"
 	"strings"
 	"time"
+
+	kitbytes "github.com/fsyyft-go/kit/bytes"
 )
 
 /**
@@ -34,8 +35,8 @@ type (
 	// OneTimePasswordOption 定义 NewOneTimePassword 可接收的配置选项。
 	//
 	// OneTimePasswordOption 包含未导出的 apply 方法，调用方通常应通过
-	// WithSHA256、WithSHA512、WithDigits、WithPeriodSeconds、WithWindowSize、
-	// WithIssuer 或 WithLabel 创建选项，而不是在包外自行实现。NewOneTimePassword
+	// WithSHA256、WithSHA512、WithDigits、WithFormat、WithPeriodSeconds、
+	// WithWindowSize、WithIssuer 或 WithLabel 创建选项，而不是在包外自行实现。NewOneTimePassword
 	// 只会跳过值为 nil 的接口选项；接口值非 nil 的实现都会被调用。
 	OneTimePasswordOption interface {
 		// apply 将选项应用于 OTP 实例。
@@ -147,6 +148,27 @@ func WithDigits(digits int) OneTimePasswordOption {
 	return (OneTimePasswordOptionFunc)(f)
 }
 
+// WithFormat 返回设置一次性密码输出格式的选项。
+//
+// 参数：
+//   - format: 口令格式。FormatNumeric 保持 RFC 6238 的纯数字口令；FormatSteam 生成 5 位 Steam Guard 口令，
+//     忽略 digits 配置；FormatAlphanumeric 生成数字与大写字母组成的口令，digits 超出 1 到 6 时按 6 位生成。
+//     未知格式按 FormatNumeric 处理。
+//
+// 返回：
+//   - OneTimePasswordOption: 应用于 NewOneTimePassword 的选项；非数字格式会在 otpauth URL 中输出 encoder 参数，
+//     digits 参数按实际口令长度输出。
+func WithFormat(format Format) OneTimePasswordOption {
+	// 定义一个函数，用于设置 oneTimePassword 实例的输出格式。
+	f := func(password *oneTimePassword) {
+		// 设置输出格式。
+		password.format = format
+	}
+
+	// 将函数转换为 OneTimePasswordOptionFunc 类型并返回。
+	return (OneTimePasswordOptionFunc)(f)
+}
+
 // WithPeriodSeconds 返回设置 TOTP 时间步长的选项。
 //
 // 参数：
@@ -227,11 +249,6 @@ func WithLabel(label string) OneTimePasswordOption {
  * ========== ========== ========== ========== ==========
  */
 
-var (
-	// base32Encoded 定义一个无填充的 Base32 编码器。
-	base32Encoded = base32.StdEncoding.WithPadding(base32.NoPadding)
-)
-
 var (
 	// 空赋值确保 oneTimePassword 类型实现了 OneTimePassword 接口。
 	_ OneTimePassword = (*oneTimePassword)(nil)
@@ -249,7 +266,7 @@ type (
 		// 参数：无。
 		//
 		// 返回：
-		//   - string: 按原始 digits 配置作为宽度左侧补零后的数字口令；生成失败时为空字符串。
+		//   - string: 数字格式为按原始 digits 配置作为宽度左侧补零后的数字口令，其他格式为按 WithFormat 字符表编码的口令；生成失败时为空字符串。
 		//   - error: 底层 HOTP 生成失败时返回错误。periodSeconds 为 0 会在计算时间步时 panic；为负数会转换为 uint64 并产生异常计数器语义。
 		Password() (string, error)
 
@@ -276,7 +293,7 @@ type (
 		// 参数：无。
 		//
 		// 返回：
-		//   - string: 包含 secret 参数以及可选 issuer、algorithm、digits 和 period 参数的 URL，可用于生成二维码；digits 和 period 按原始配置值输出，该方法不会校验边界。
+		//   - string: 包含 secret 参数以及可选 issuer、algorithm、encoder、digits 和 period 参数的 URL，可用于生成二维码；数字格式的 digits 和 period 按原始配置值输出，该方法不会校验边界。
 		GenerateURL() string
 	}
 
@@ -287,6 +304,7 @@ type (
 		hashCipher      string           // 哈希算法名称。
 		hashFunc        func() hash.Hash // 哈希算法。
 		digits          int              // 原始密码位数配置。
+		format          Format           // 口令输出格式。
 		periodSeconds   int              // TOTP 时间步长（单位为秒）。
 		windowSize      int              // 验证窗口半径配置。
 
@@ -300,20 +318,24 @@ type (
 // 参数：无。
 //
 // 返回：
-//   - string: 按原始 digits 配置作为宽度左侧补零后的数字口令；生成失败时为空字符串。
+//   - string: 数字格式为按原始 digits 配置作为宽度左侧补零后的数字口令，其他格式为按 WithFormat 字符表编码的口令；生成失败时为空字符串。
 //   - error: 底层 HOTP 生成失败时返回错误。periodSeconds 为 0 会在计算时间步时 panic；为负数会转换为 uint64 并产生异常计数器语义。
 func (o *oneTimePassword) Password() (string, error) {
 	// 定义返回值。
 	var passwordString string
 	var err error
 
-	// 生成基于时间的一次性密码。
-	if password, errPassword := timeBasedOneTimePassword(o.hashFunc, o.secretKey, o.periodSeconds, o.digits); nil != errPassword {
+	// 获取当前时间的 Unix 时间戳。
+	seconds := uint64(time.Now().Unix()) // nolint: gosec
+	// 计算当前的计数器值。
+	counter := seconds / uint64(o.periodSeconds) // nolint: gosec
+
+	// 生成当前计数器对应的一次性密码。
+	if password, errPassword := o.passwordAt(counter); nil != errPassword {
 		// 如果生成过程中出现错误，则返回错误。
 		err = errPassword
 	} else {
-		// 将生成的密码按原始 digits 配置宽度转换为字符串。
-		passwordString = fmt.Sprintf("%0*d", o.digits, password)
+		passwordString = password
 	}
 
 	// 返回生成的密码和可能的错误。
@@ -344,14 +366,12 @@ func (o *oneTimePassword) EffectivePassword() ([]string, error) {
 
 	// 遍历从最小计数器值到窗口上界之前的半开范围。
 	for tmpCounter := minCounter; tmpCounter < maxCounter; tmpCounter++ {
-		// 生成基于 HMAC 的一次性密码。
-		if tmpPassword, errPassword := hmacBasedOneTimePassword(o.hashFunc, o.secretKey, tmpCounter, o.digits); nil != errPassword {
+		// 生成计数器对应的一次性密码。
+		if passwordString, errPassword := o.passwordAt(tmpCounter); nil != errPassword {
 			// 如果生成过程中出现错误，则设置错误并中断循环。
 			err = errPassword
 			break
 		} else {
-			// 将生成的密码按原始 digits 配置宽度转换为字符串。
-			passwordString := fmt.Sprintf("%0*d", o.digits, tmpPassword)
 			// 将密码添加到结果切片中。
 			passwordStrings = append(passwordStrings, passwordString)
 		}
@@ -384,10 +404,8 @@ func (o *oneTimePassword) VeryfyPassword(password string) bool {
 
 	// 遍历从最小计数器值到窗口上界之前的半开范围。
 	for tmpCounter := minCounter; tmpCounter < maxCounter; tmpCounter++ {
-		// 生成基于 HMAC 的一次性密码。
-		if tmpPassword, errPassword := hmacBasedOneTimePassword(o.hashFunc, o.secretKey, tmpCounter, o.digits); nil == errPassword {
-			// 如果生成成功，将生成的密码转换为指定长度的字符串。
-			passwordString := fmt.Sprintf("%0*d", o.digits, tmpPassword)
+		// 生成计数器对应的一次性密码。
+		if passwordString, errPassword := o.passwordAt(tmpCounter); nil == errPassword {
 			// 比较生成的密码与提供的密码是否匹配，比较时忽略大小写。
 			if resultValue = strings.EqualFold(passwordString, password); resultValue {
 				// 如果匹配，则设置结果为 true 并中断循环。
@@ -405,7 +423,7 @@ func (o *oneTimePassword) VeryfyPassword(password string) bool {
 // 参数：无。
 //
 // 返回：
-//   - string: 包含 secret 参数以及可选 issuer、algorithm、digits 和 period 参数的 URL，可用于生成二维码；digits 和 period 按原始配置值输出，该方法不会校验边界。
+//   - string: 包含 secret 参数以及可选 issuer、algorithm、encoder、digits 和 period 参数的 URL，可用于生成二维码；数字格式的 digits 和 period 按原始配置值输出，该方法不会校验边界。
 func (o *oneTimePassword) GenerateURL() string {
 	// 创建一个字节缓冲区，用于构建 URL。
 	buffer := bytes.Buffer{}
@@ -437,10 +455,17 @@ func (o *oneTimePassword) GenerateURL() string {
 		buffer.WriteString("&")
 	}
 
-	// 如果密码长度不是默认值，则将其添加到 URL 中。
-	if defaultDigits != o.digits {
+	// 如果不是数字格式，则将编码方式添加到 URL 中，供支持 encoder 参数的验证器识别。
+	if o.format.alphabet() != "" {
+		buffer.WriteString("encoder=")
+		buffer.WriteString(o.format.String())
+		buffer.WriteString("&")
+	}
+
+	// 如果密码长度不是默认值，则将其添加到 URL 中；非数字格式按实际口令长度输出。
+	if digits := o.format.length(o.digits); defaultDigits != digits {
 		buffer.WriteString("digits=")
-		buffer.WriteString(strconv.Itoa(o.digits))
+		buffer.WriteString(strconv.Itoa(digits))
 		buffer.WriteString("&")
 	}
 
@@ -455,6 +480,33 @@ func (o *oneTimePassword) GenerateURL() string {
 	return buffer.String()
 }
 
+// passwordAt 按实例配置的格式生成指定计数器的一次性密码。
+//
+// 参数：
+//   - counter: HOTP 计数器值。
+//
+// 返回：
+//   - string: 数字格式按原始 digits 配置作为宽度左侧补零；其他格式按 Format 的字符表与长度编码。
+//   - error: 底层 HOTP 生成失败时返回错误。
+func (o *oneTimePassword) passwordAt(counter uint64) (string, error) {
+	// 数字格式沿用 RFC 4226 的取模与补零规则。
+	alphabet := o.format.alphabet()
+	if "" == alphabet {
+		password, err := hmacBasedOneTimePassword(o.hashFunc, o.secretKey, counter, o.digits)
+		if nil != err {
+			return "", err
+		}
+		return fmt.Sprintf("%0*d", o.digits, password), nil
+	}
+
+	// 其他格式直接对动态截断值按字符表编码。
+	value, err := dynamicTruncate(o.hashFunc, o.secretKey, counter)
+	if nil != err {
+		return "", err
+	}
+	return encodeAlphabet(value, alphabet, o.format.length(o.digits)), nil
+}
+
 // NewOneTimePassword 创建使用 Base32 密钥的一次性密码实例。
 //
 // 参数：
@@ -477,7 +529,7 @@ func NewOneTimePassword(secretKeyBase32 string, options ...OneTimePasswordOption
 	var err error
 
 	// 将 Base32 编码的密钥解码为字节数组。
-	newOneTimePassword.secretKey, err = base32Encoded.DecodeString(secretKeyBase32)
+	newOneTimePassword.secretKey, err = kitbytes.DecodeBase32NoPad(secretKeyBase32)
 
 	// 如果解码成功且提供了选项，则应用这些选项。
 	if nil == err && nil != options && len(options) > 0 {
@@ -575,31 +627,17 @@ func hmacBasedOneTimePassword(hashFunc func() hash.Hash, key []byte, counter uin
 	var resultValue int
 	var err error
 
-	// 如果未提供哈希函数，则使用默认的 SHA1 哈希函数。
-	if nil == hashFunc {
-		hashFunc = sha1.New
-	}
-
 	// 限制密码长度在 0 到 8 位之间，如果超出范围，则设为 8 位。
 	if digits > 8 || digits < 0 {
 		// 长度不能超过 8 位。
 		digits = 8
 	}
 
-	// 创建一个新的 HMAC 对象，使用提供的哈希函数和密钥。
-	h := hmac.New(hashFunc, key)
-	// 将计数器写入 HMAC 对象。
-	if errWrite := binary.Write(h, binary.BigEndian, counter); nil != errWrite {
-		// 如果写入过程中出现错误，则返回错误。
-		err = errWrite
+	// 计算动态截断值。
+	if effectiveValue, errTruncate := dynamicTruncate(hashFunc, key, counter); nil != errTruncate {
+		// 如果计算过程中出现错误，则返回错误。
+		err = errTruncate
 	} else {
-		// 没有发生错误，进入下一步。
-		// 计算 HMAC 值。
-		sum := h.Sum(nil)
-		// 取 Hash 后的最后 4 个 byte。
-		offset := sum[len(sum)-1] & 0x0f
-		// 从 HMAC 值中提取有效值。
-		effectiveValue := binary.BigEndian.Uint32(sum[offset:]) & 0x7FFFFFFF
 		// 计算模数，用于截取指定位数的密码。
 		effectiveModule := uint32(1)
 		for idx := 0; idx < digits; idx++ {
@@ -612,3 +650,34 @@ func hmacBasedOneTimePassword(hashFunc func() hash.Hash, key []byte, counter uin
 	// 返回生成的密码和可能的错误。
 	return resultValue, err
 }
+
+// dynamicTruncate 计算 HOTP 的 HMAC 值并按 RFC 4226 动态截断为 31 位整数。
+//
+// 参数：
+//   - hashFunc: 用于生成 HMAC 的哈希函数；为 nil 时使用 SHA1。
+//   - key: 已解码的密钥字节，可为空切片。
+//   - counter: HOTP 计数器值。
+//
+// 返回：
+//   - uint32: 动态截断后清除最高位的整数。
+//   - error: 将 counter 写入 HMAC 时失败则返回错误。
+func dynamicTruncate(hashFunc func() hash.Hash, key []byte, counter uint64) (uint32, error) {
+	// 如果未提供哈希函数，则使用默认的 SHA1 哈希函数。
+	if nil == hashFunc {
+		hashFunc = sha1.New
+	}
+
+	// 创建一个新的 HMAC 对象，使用提供的哈希函数和密钥。
+	h := hmac.New(hashFunc, key)
+	// 将计数器写入 HMAC 对象。
+	if err := binary.Write(h, binary.BigEndian, counter); nil != err {
+		return 0, err
+	}
+
+	// 计算 HMAC 值。
+	sum := h.Sum(nil)
+	// 取 Hash 后的最后 4 个 byte。
+	offset := sum[len(sum)-1] & 0x0f
+	// 从 HMAC 值中提取有效值。
+	return binary.BigEndian.Uint32(sum[offset:]) & 0x7FFFFFFF, nil
+}
@@ -18,6 +18,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	kitbytes "github.com/fsyyft-go/kit/bytes"
 )
 
 /*
@@ -52,7 +54,7 @@ go tool cover -html=coverage.out -o coverage.html
 
 // 测试常量定义，用于各个测试用例。
 const (
-	// 用于测试的密钥，Base32 编码，被 base32Encoded 解码后使用。
+	// 用于测试的密钥，Base32 编码，被 kitbytes.DecodeBase32NoPad 解码后使用。
 	testSecretBase32 = "JBSWY3DPEHPK3PXP"
 	// 测试用的标签。
 	testLabel = "test@example.com"
@@ -396,7 +398,7 @@ func TestHMACBasedOneTimePassword(t *testing.T) {
 	}
 
 	// 解码测试密钥。
-	key, err := base32Encoded.DecodeString(testSecretBase32)
+	key, err := kitbytes.DecodeBase32NoPad(testSecretBase32)
 	require.NoError(t, err, "解码测试密钥不应出错。")
 
 	// 执行测试用例。
@@ -424,7 +426,7 @@ func TestHMACBasedOneTimePassword(t *testing.T) {
 // TestTimeBasedOneTimePassword 测试基于时间的一次性密码生成功能。
 func TestTimeBasedOneTimePassword(t *testing.T) {
 	// 解码测试密钥。
-	key, err := base32Encoded.DecodeString(testSecretBase32)
+	key, err := kitbytes.DecodeBase32NoPad(testSecretBase32)
 	require.NoError(t, err, "解码测试密钥不应出错。")
 
 	// 表格驱动测试用例。
@@ -0,0 +1,348 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package otp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	// recoveryCodeAlphabet 是恢复码使用的 Crockford Base32 字母表，去除了易混淆的 i、l、o、u。
+	// 字母表长度为 32，单个随机字节取低 5 位即可均匀映射，无需拒绝采样。
+	recoveryCodeAlphabet = "0123456789abcdefghjkmnpqrstvwxyz"
+	// recoveryCodeSeparator 是恢复码分组之间的分隔符。
+	recoveryCodeSeparator = "-"
+	// sha256RecoveryPrefix 是 SHA-256 恢复码哈希的前缀。
+	sha256RecoveryPrefix = "sha256:"
+)
+
+var (
+	// defaultRecoveryCodeLength 默认的恢复码有效字符数为 10，约 50 位熵。
+	defaultRecoveryCodeLength = 10
+	// defaultRecoveryCodeGroupSize 默认每 5 个字符插入一个分隔符。
+	defaultRecoveryCodeGroupSize = 5
+	// recoveryRandReader 是生成恢复码的随机源，测试中可替换。
+	recoveryRandReader io.Reader = rand.Reader
+	// recoveryCodeNormalizer 将用户输入中的易混淆字符映射为字母表中的字符，并移除分隔符和空白。
+	recoveryCodeNormalizer = strings.NewReplacer("-", "", " ", "", "\t", "", "i", "1", "l", "1", "o", "0")
+)
+
+var (
+	// 空赋值确保内置哈希器实现了 RecoveryCodeHasher 接口。
+	_ RecoveryCodeHasher = (*bcryptRecoveryCodeHasher)(nil)
+	_ RecoveryCodeHasher = (*sha256RecoveryCodeHasher)(nil)
+)
+
+type (
+	// RecoveryCodeOption 定义 GenerateRecoveryCodes 可接收的配置选项。
+	//
+	// 参数：
+	//   - *recoveryCodeOptions: 待修改的恢复码配置。
+	RecoveryCodeOption func(*recoveryCodeOptions)
+
+	// recoveryCodeOptions 保存恢复码的格式配置。
+	recoveryCodeOptions struct {
+		length    int // 有效字符数，不含分隔符。
+		groupSize int // 每组字符数；小于等于 0 时不分组。
+	}
+
+	// RecoveryCodeHasher 定义恢复码的哈希存储与比对方式。
+	//
+	// 恢复码只应以哈希形式持久化；Hash 和 Match 接收的恢复码都会先经过规范化，
+	// 用户输入时的大小写、分隔符和易混淆字符不影响比对结果。
+	RecoveryCodeHasher interface {
+		// Hash 计算恢复码的哈希。
+		//
+		// 参数：
+		//   - code: 恢复码明文。
+		//
+		// 返回：
+		//   - string: 可持久化的哈希字符串。
+		//   - error: 哈希计算失败时返回错误。
+		Hash(code string) (string, error)
+
+		// Match 判断恢复码是否与哈希匹配。
+		//
+		// 参数：
+		//   - hash: Hash 生成的哈希字符串。
+		//   - code: 待验证的恢复码。
+		//
+		// 返回：
+		//   - bool: 匹配时返回 true；哈希格式无法识别时返回 false。
+		Match(hash, code string) bool
+	}
+
+	// bcryptRecoveryCodeHasher 使用 bcrypt 哈希恢复码。
+	bcryptRecoveryCodeHasher struct {
+		cost int // bcrypt 成本因子。
+	}
+
+	// sha256RecoveryCodeHasher 使用 HMAC-SHA256 哈希恢复码。
+	sha256RecoveryCodeHasher struct {
+		pepper []byte // HMAC 密钥，为空时等价于普通 SHA-256 的密钥化变体。
+	}
+)
+
+// WithRecoveryCodeLength 设置恢复码的有效字符数。
+//
+// 每个字符携带 5 位熵，默认 10 个字符约 50 位熵。
+//
+// 参数：
+//   - length: 有效字符数，不含分隔符；必须大于 0。
+//
+// 返回：
+//   - RecoveryCodeOption: 应用于 GenerateRecoveryCodes 的选项。
+func WithRecoveryCodeLength(length int) RecoveryCodeOption {
+	return func(o *recoveryCodeOptions) {
+		o.length = length
+	}
+}
+
+// WithRecoveryCodeGroupSize 设置恢复码每组的字符数，组之间以 "-" 分隔，便于用户抄写。
+//
+// 参数：
+//   - groupSize: 每组字符数；小于等于 0 时不分组。
+//
+// 返回：
+//   - RecoveryCodeOption: 应用于 GenerateRecoveryCodes 的选项。
+func WithRecoveryCodeGroupSize(groupSize int) RecoveryCodeOption {
+	return func(o *recoveryCodeOptions) {
+		o.groupSize = groupSize
+	}
+}
+
+// GenerateRecoveryCodes 生成 n 个密码学安全的随机恢复码。
+//
+// 恢复码使用小写 Crockford Base32 字母表，默认格式形如 "x3k9p-7mqa2"。生成的恢复码应只展示给用户一次，
+// 并通过 HashRecoveryCodes 转换为哈希后持久化；同一批恢复码之间保证互不相同。
+//
+// 参数：
+//   - n: 生成数量；为 0 时返回空切片。
+//   - options: 可选配置项，按传入顺序应用；nil 选项会被跳过。
+//
+// 返回：
+//   - []string: 格式化后的恢复码。
+//   - error: n 为负数、有效字符数不大于 0 或随机源读取失败时返回错误。
+func GenerateRecoveryCodes(n int, options ...RecoveryCodeOption) ([]string, error) {
+	opts := recoveryCodeOptions{
+		length:    defaultRecoveryCodeLength,
+		groupSize: defaultRecoveryCodeGroupSize,
+	}
+	for _, option := range options {
+		if nil != option {
+			option(&opts)
+		}
+	}
+	if n < 0 {
+		return nil, fmt.Errorf("恢复码数量不能为负数：%d", n)
+	}
+	if opts.length <= 0 {
+		return nil, fmt.Errorf("恢复码长度必须大于 0：%d", opts.length)
+	}
+
+	codes := make([]string, 0, n)
+	seen := make(map[string]struct{}, n)
+	raw := make([]byte, opts.length)
+	for len(codes) < n {
+		if _, err := io.ReadFull(recoveryRandReader, raw); nil != err {
+			return nil, fmt.Errorf("生成恢复码失败：%w", err)
+		}
+		code := formatRecoveryCode(raw, opts.groupSize)
+		if _, ok := seen[code]; ok {
+			continue
+		}
+		seen[code] = struct{}{}
+		codes = append(codes, code)
+	}
+	return codes, nil
+}
+
+// HashRecoveryCodes 计算一组恢复码的哈希，用于持久化。
+//
+// 参数：
+//   - codes: 恢复码明文。
+//   - hasher: 哈希器，例如 NewBcryptRecoveryCodeHasher 或 NewSHA256RecoveryCodeHasher 的返回值。
+//
+// 返回：
+//   - []string: 与 codes 一一对应的哈希。
+//   - error: 任一恢复码哈希失败时返回错误。
+func HashRecoveryCodes(codes []string, hasher RecoveryCodeHasher) ([]string, error) {
+	hashes := make([]string, 0, len(codes))
+	for _, code := range codes {
+		hash, err := hasher.Hash(code)
+		if nil != err {
+			return nil, err
+		}
+		hashes = append(hashes, hash)
+	}
+	return hashes, nil
+}
+
+// VerifyRecoveryCode 验证恢复码并消费匹配的哈希。
+//
+// 恢复码只能使用一次：匹配成功时返回移除了该哈希的新切片，调用方必须用它替换持久化的哈希列表，
+// 并在并发场景下通过数据库事务或比较并交换保证同一恢复码不会被重复使用。
+// 使用 bcrypt 哈希时每次比对都有明显开销，验证耗时随剩余恢复码数量线性增长。
+//
+// 参数：
+//   - hashes: 持久化的恢复码哈希列表，不会被修改。
+//   - code: 用户输入的恢复码，大小写、分隔符和空白不影响比对。
+//   - hasher: 生成 hashes 时使用的哈希器。
+//
+// 返回：
+//   - []string: 匹配成功时为移除了匹配哈希的新切片，否则为 hashes 本身。
+//   - bool: 匹配成功时返回 true。
+func VerifyRecoveryCode(hashes []string, code string, hasher RecoveryCodeHasher) ([]string, bool) {
+	if "" == normalizeRecoveryCode(code) {
+		return hashes, false
+	}
+	for i, hash := range hashes {
+		if hasher.Match(hash, code) {
+			remaining := make([]string, 0, len(hashes)-1)
+			remaining = append(remaining, hashes[:i]...)
+			return append(remaining, hashes[i+1:]...), true
+		}
+	}
+	return hashes, false
+}
+
+// NewBcryptRecoveryCodeHasher 创建使用 bcrypt 的恢复码哈希器。
+//
+// 参数：
+//   - cost: bcrypt 成本因子；超出 bcrypt 允许范围时使用 bcrypt.DefaultCost。
+//
+// 返回：
+//   - RecoveryCodeHasher: bcrypt 哈希器。
+func NewBcryptRecoveryCodeHasher(cost int) RecoveryCodeHasher {
+	if cost < bcrypt.MinCost || cost > bcrypt.MaxCost {
+		cost = bcrypt.DefaultCost
+	}
+	return &bcryptRecoveryCodeHasher{cost: cost}
+}
+
+// Hash 使用 bcrypt 计算恢复码的哈希。
+//
+// 参数：
+//   - code: 恢复码明文。
+//
+// 返回：
+//   - string: bcrypt 哈希字符串。
+//   - error: bcrypt 计算失败时返回错误。
+func (h *bcryptRecoveryCodeHasher) Hash(code string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(normalizeRecoveryCode(code)), h.cost)
+	if nil != err {
+		return "", fmt.Errorf("计算恢复码哈希失败：%w", err)
+	}
+	return string(hash), nil
+}
+
+// Match 判断恢复码是否与 bcrypt 哈希匹配。
+//
+// 参数：
+//   - hash: bcrypt 哈希字符串。
+//   - code: 待验证的恢复码。
+//
+// 返回：
+//   - bool: 匹配时返回 true。
+func (h *bcryptRecoveryCodeHasher) Match(hash, code string) bool {
+	return nil == bcrypt.CompareHashAndPassword([]byte(hash), []byte(normalizeRecoveryCode(code)))
+}
+
+// NewSHA256RecoveryCodeHasher 创建使用 HMAC-SHA256 的恢复码哈希器。
+//
+// 恢复码本身是高熵随机值，无需 bcrypt 的慢哈希即可抵御离线猜测，适合验证频繁或恢复码数量较多的场景；
+// pepper 保存在配置中而非数据库中，可进一步防止数据库泄露后的离线校验。
+//
+// 参数：
+//   - pepper: HMAC 密钥；可为空。
+//
+// 返回：
+//   - RecoveryCodeHasher: HMAC-SHA256 哈希器，哈希格式为 "sha256:" 加十六进制摘要。
+func NewSHA256RecoveryCodeHasher(pepper []byte) RecoveryCodeHasher {
+	return &sha256RecoveryCodeHasher{pepper: append([]byte(nil), pepper...)}
+}
+
+// Hash 使用 HMAC-SHA256 计算恢复码的哈希。
+//
+// 参数：
+//   - code: 恢复码明文。
+//
+// 返回：
+//   - string: "sha256:" 加十六进制摘要。
+//   - error: 始终为 nil。
+func (h *sha256RecoveryCodeHasher) Hash(code string) (string, error) {
+	return sha256RecoveryPrefix + hex.EncodeToString(h.sum(code)), nil
+}
+
+// Match 以常量时间判断恢复码是否与 HMAC-SHA256 哈希匹配。
+//
+// 参数：
+//   - hash: "sha256:" 加十六进制摘要形式的哈希。
+//   - code: 待验证的恢复码。
+//
+// 返回：
+//   - bool: 匹配时返回 true；哈希格式无法识别时返回 false。
+func (h *sha256RecoveryCodeHasher) Match(hash, code string) bool {
+	digest, ok := strings.CutPrefix(hash, sha256RecoveryPrefix)
+	if !ok {
+		return false
+	}
+	want, err := hex.DecodeString(digest)
+	if nil != err {
+		return false
+	}
+	return hmac.Equal(want, h.sum(code))
+}
+
+// sum 计算规范化后恢复码的 HMAC-SHA256 摘要。
+//
+// 参数：
+//   - code: 恢复码。
+//
+// 返回：
+//   - []byte: 摘要。
+func (h *sha256RecoveryCodeHasher) sum(code string) []byte {
+	mac := hmac.New(sha256.New, h.pepper)
+	mac.Write([]byte(normalizeRecoveryCode(code)))
+	return mac.Sum(nil)
+}
+
+// formatRecoveryCode 将随机字节映射为字母表字符并按组插入分隔符。
+//
+// 参数：
+//   - raw: 随机字节，每个字节生成一个字符。
+//   - groupSize: 每组字符数；小于等于 0 时不分组。
+//
+// 返回：
+//   - string: 格式化后的恢复码。
+func formatRecoveryCode(raw []byte, groupSize int) string {
+	var sb strings.Builder
+	for i, b := range raw {
+		if groupSize > 0 && i > 0 && 0 == i%groupSize {
+			sb.WriteString(recoveryCodeSeparator)
+		}
+		sb.WriteByte(recoveryCodeAlphabet[b&0x1f])
+	}
+	return sb.String()
+}
+
+// normalizeRecoveryCode 规范化恢复码，使用户输入与生成格式无关。
+//
+// 参数：
+//   - code: 恢复码。
+//
+// 返回：
+//   - string: 转为小写、移除分隔符和空白、将 i/l 映射为 1 且 o 映射为 0 后的恢复码。
+func normalizeRecoveryCode(code string) string {
+	return recoveryCodeNormalizer.Replace(strings.ToLower(strings.TrimSpace(code)))
+}
@@ -0,0 +1,219 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package otp
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// TestGenerateRecoveryCodes 测试恢复码的生成数量、格式与错误处理。
+func TestGenerateRecoveryCodes(t *testing.T) {
+	testCases := []struct {
+		name        string               // 测试用例名称。
+		description string               // 测试用例描述。
+		n           int                  // 生成数量。
+		options     []RecoveryCodeOption // 应用的选项。
+		wantLength  int                  // 期望的单个恢复码长度，含分隔符。
+		wantGroups  int                  // 期望的分组数量。
+		wantErr     bool                 // 是否期望返回错误。
+	}{
+		{
+			name:        "success/default",
+			description: "默认生成 10 个字符、每 5 个字符一组的恢复码",
+			n:           10,
+			wantLength:  11,
+			wantGroups:  2,
+		},
+		{
+			name:        "success/custom-format",
+			description: "自定义长度和分组大小，最后一组允许不足",
+			n:           3,
+			options:     []RecoveryCodeOption{WithRecoveryCodeLength(16), WithRecoveryCodeGroupSize(6), nil},
+			wantLength:  18,
+			wantGroups:  3,
+		},
+		{
+			name:        "success/no-group",
+			description: "分组大小为 0 时不插入分隔符",
+			n:           2,
+			options:     []RecoveryCodeOption{WithRecoveryCodeGroupSize(0)},
+			wantLength:  10,
+			wantGroups:  1,
+		},
+		{
+			name:        "boundary/zero",
+			description: "数量为 0 时返回空切片",
+			n:           0,
+		},
+		{
+			name:        "error/negative",
+			description: "数量为负数时返回错误",
+			n:           -1,
+			wantErr:     true,
+		},
+		{
+			name:        "error/invalid-length",
+			description: "有效字符数不大于 0 时返回错误",
+			n:           1,
+			options:     []RecoveryCodeOption{WithRecoveryCodeLength(0)},
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			codes, err := GenerateRecoveryCodes(tt.n, tt.options...)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, codes, tt.n)
+
+			seen := make(map[string]struct{}, len(codes))
+			for _, code := range codes {
+				assert.Len(t, code, tt.wantLength)
+				assert.Len(t, strings.Split(code, recoveryCodeSeparator), tt.wantGroups)
+				for _, c := range strings.ReplaceAll(code, recoveryCodeSeparator, "") {
+					assert.Contains(t, recoveryCodeAlphabet, string(c))
+				}
+				assert.NotContains(t, seen, code)
+				seen[code] = struct{}{}
+			}
+		})
+	}
+}
+
+// TestGenerateRecoveryCodes_RandReader 测试随机源对恢复码生成的影响。
+func TestGenerateRecoveryCodes_RandReader(t *testing.T) {
+	original := recoveryRandReader
+	defer func() { recoveryRandReader = original }()
+
+	t.Run("success/skip-duplicate", func(t *testing.T) {
+		// 前两次读取相同的随机字节，第二个恢复码应被丢弃并重新生成。
+		recoveryRandReader = bytes.NewReader([]byte{
+			0, 1, 2, 3, 4, 5, 6, 7, 8, 9,
+			0, 1, 2, 3, 4, 5, 6, 7, 8, 9,
+			10, 11, 12, 13, 14, 15, 16, 17, 18, 19,
+		})
+		codes, err := GenerateRecoveryCodes(2)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"01234-56789", "abcde-fghjk"}, codes)
+	})
+
+	t.Run("error/reader", func(t *testing.T) {
+		recoveryRandReader = iotest.ErrReader(errors.New("boom"))
+		codes, err := GenerateRecoveryCodes(1)
+		assert.Nil(t, codes)
+		assert.ErrorContains(t, err, "boom")
+	})
+}
+
+// TestRecoveryCodeHasher 测试内置哈希器的哈希与比对行为。
+func TestRecoveryCodeHasher(t *testing.T) {
+	testCases := []struct {
+		name        string             // 测试用例名称。
+		description string             // 测试用例描述。
+		hasher      RecoveryCodeHasher // 被测哈希器。
+		prefix      string             // 期望的哈希前缀。
+	}{
+		{
+			name:        "success/bcrypt",
+			description: "bcrypt 哈希器生成的哈希可被 bcrypt 识别",
+			hasher:      NewBcryptRecoveryCodeHasher(bcrypt.MinCost),
+			prefix:      "$2a$",
+		},
+		{
+			name:        "success/sha256",
+			description: "HMAC-SHA256 哈希器生成带前缀的十六进制摘要",
+			hasher:      NewSHA256RecoveryCodeHasher([]byte("pepper")),
+			prefix:      sha256RecoveryPrefix,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			hash, err := tt.hasher.Hash("x3k9p-7mqa2")
+			require.NoError(t, err)
+			assert.True(t, strings.HasPrefix(hash, tt.prefix))
+
+			// 大小写、分隔符、空白和易混淆字符不影响比对。
+			assert.True(t, tt.hasher.Match(hash, "x3k9p-7mqa2"))
+			assert.True(t, tt.hasher.Match(hash, " X3K9P 7MQA2 "))
+			assert.True(t, tt.hasher.Match(hash, "x3k9p7mqa2"))
+			assert.False(t, tt.hasher.Match(hash, "x3k9p-7mqa3"))
+			assert.False(t, tt.hasher.Match("invalid", "x3k9p-7mqa2"))
+		})
+	}
+
+	t.Run("boundary/sha256-pepper", func(t *testing.T) {
+		hash, err := NewSHA256RecoveryCodeHasher([]byte("a")).Hash("abcde")
+		require.NoError(t, err)
+		assert.False(t, NewSHA256RecoveryCodeHasher([]byte("b")).Match(hash, "abcde"))
+		assert.False(t, NewSHA256RecoveryCodeHasher(nil).Match(sha256RecoveryPrefix+"zz", "abcde"))
+	})
+
+	t.Run("boundary/bcrypt-cost", func(t *testing.T) {
+		hasher, ok := NewBcryptRecoveryCodeHasher(bcrypt.MaxCost + 1).(*bcryptRecoveryCodeHasher)
+		require.True(t, ok)
+		assert.Equal(t, bcrypt.DefaultCost, hasher.cost)
+	})
+
+	t.Run("boundary/normalize", func(t *testing.T) {
+		assert.Equal(t, "1100", normalizeRecoveryCode("I-L o O"))
+	})
+}
+
+// TestVerifyRecoveryCode 测试恢复码的单次使用验证。
+func TestVerifyRecoveryCode(t *testing.T) {
+	hasher := NewSHA256RecoveryCodeHasher([]byte("pepper"))
+	codes, err := GenerateRecoveryCodes(3)
+	require.NoError(t, err)
+	hashes, err := HashRecoveryCodes(codes, hasher)
+	require.NoError(t, err)
+	require.Len(t, hashes, 3)
+
+	remaining, ok := VerifyRecoveryCode(hashes, strings.ToUpper(codes[1]), hasher)
+	require.True(t, ok)
+	assert.Equal(t, []string{hashes[0], hashes[2]}, remaining)
+	assert.Len(t, hashes, 3, "原哈希列表不应被修改")
+
+	// 已使用的恢复码不能再次通过验证。
+	again, ok := VerifyRecoveryCode(remaining, codes[1], hasher)
+	assert.False(t, ok)
+	assert.Equal(t, remaining, again)
+
+	// 其余恢复码依然可用。
+	remaining, ok = VerifyRecoveryCode(remaining, codes[2], hasher)
+	assert.True(t, ok)
+	assert.Equal(t, []string{hashes[0]}, remaining)
+
+	_, ok = VerifyRecoveryCode(hashes, " - ", hasher)
+	assert.False(t, ok)
+	_, ok = VerifyRecoveryCode(nil, codes[0], hasher)
+	assert.False(t, ok)
+}
+
+// TestHashRecoveryCodes_Error 测试哈希失败时的错误传递。
+func TestHashRecoveryCodes_Error(t *testing.T) {
+	// bcrypt 拒绝超过 72 字节的输入。
+	hashes, err := HashRecoveryCodes([]string{strings.Repeat("a", 73)}, NewBcryptRecoveryCodeHasher(bcrypt.MinCost))
+	assert.Nil(t, hashes)
+	assert.Error(t, err)
+}
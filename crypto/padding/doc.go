@@ -0,0 +1,13 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+// Package padding 提供分组密码使用的填充与去填充函数。
+//
+// PKCS7Pad 与 PKCS7Unpad 实现 PKCS7 填充，去填充以常量时间校验填充内容，不合法时统一返回
+// ErrInvalidPadding，避免 CBC 解密结果成为填充预言；ZeroPad 与 ZeroUnpad 实现兼容旧系统的零填充；
+// ISO10126Pad 与 ISO10126Unpad 实现最后一个字节为填充长度、其余为随机字节的 ISO 10126 填充。
+//
+// 所有函数都要求块大小在 [1, 255] 范围内，否则返回 ErrInvalidBlockSize。填充函数总是返回新分配的切片，
+// 去填充函数返回输入的子切片。crypto/des 等分组密码实现共用本包，不再各自维护填充逻辑。
+package padding
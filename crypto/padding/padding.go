@@ -0,0 +1,203 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package padding
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"errors"
+	"io"
+)
+
+const (
+	// maxBlockSize 是填充长度能放入单字节时允许的最大块大小。
+	maxBlockSize = 255
+)
+
+var (
+	// ErrInvalidBlockSize 表示块大小不在 [1, 255] 范围内。
+	ErrInvalidBlockSize = errors.New("块大小不合法。")
+	// ErrInvalidPadding 表示数据长度不是块大小的整数倍，或填充内容不合法。
+	ErrInvalidPadding = errors.New("填充不合法。")
+
+	// randReader 是 ISO10126Pad 生成随机填充字节使用的随机源，测试中可替换。
+	randReader io.Reader = rand.Reader
+)
+
+// PKCS7Pad 使用 PKCS7 标准对 data 进行填充。
+//
+// 填充长度为 blockSize - len(data)%blockSize，每个填充字节的值都等于填充长度；
+// 数据长度已是块大小的整数倍时追加一个完整的块。
+//
+// 参数：
+//   - data: 需要填充的原始数据，可为空。
+//   - blockSize: 加密块大小，单位为字节，取值范围 [1, 255]。
+//
+// 返回：
+//   - []byte: 填充后的数据，总是新分配的切片，不与 data 共享底层数组。
+//   - error: blockSize 不合法时返回 ErrInvalidBlockSize。
+func PKCS7Pad(data []byte, blockSize int) ([]byte, error) {
+	if err := checkBlockSize(blockSize); nil != err {
+		return nil, err
+	}
+
+	n := blockSize - len(data)%blockSize
+	out := make([]byte, len(data)+n)
+	copy(out, data)
+	for i := len(data); i < len(out); i++ {
+		out[i] = byte(n)
+	}
+	return out, nil
+}
+
+// PKCS7Unpad 移除 data 末尾的 PKCS7 填充。
+//
+// 填充校验以常量时间完成：无论填充在哪个位置不合法，都检查最后一个块的全部字节，
+// 且所有不合法的情况返回同一个错误，避免 CBC 解密结果被用作填充预言（padding oracle）。
+//
+// 参数：
+//   - data: 已填充的数据，长度必须是 blockSize 的正整数倍。
+//   - blockSize: 加密块大小，单位为字节，取值范围 [1, 255]。
+//
+// 返回：
+//   - []byte: 去除填充后的数据，是 data 的子切片，与输入共享底层数组。
+//   - error: blockSize 不合法时返回 ErrInvalidBlockSize；数据长度或填充内容不合法时返回 ErrInvalidPadding。
+func PKCS7Unpad(data []byte, blockSize int) ([]byte, error) {
+	if err := checkBlockSize(blockSize); nil != err {
+		return nil, err
+	}
+	n := len(data)
+	if 0 == n || 0 != n%blockSize {
+		return nil, ErrInvalidPadding
+	}
+
+	padLen := data[n-1]
+	good := subtle.ConstantTimeLessOrEq(1, int(padLen)) & subtle.ConstantTimeLessOrEq(int(padLen), blockSize)
+	for i := 0; i < blockSize; i++ {
+		// 属于填充的字节必须等于填充长度，其余字节不参与判断但同样被访问。
+		inPad := subtle.ConstantTimeLessOrEq(i+1, int(padLen))
+		good &= subtle.ConstantTimeByteEq(data[n-1-i], padLen) | (inPad ^ 1)
+	}
+	if 1 != good {
+		return nil, ErrInvalidPadding
+	}
+	return data[:n-int(padLen)], nil
+}
+
+// ZeroPad 使用 0 字节把 data 补齐到块大小的整数倍。
+//
+// 数据长度已是块大小的整数倍（包括空数据）时不追加任何字节。由于原始数据末尾的 0 字节与填充无法区分，
+// 零填充只适用于原始数据不以 0 结尾的场景，例如文本；兼容旧系统之外应使用 PKCS7Pad。
+//
+// 参数：
+//   - data: 需要填充的原始数据，可为空。
+//   - blockSize: 加密块大小，单位为字节，取值范围 [1, 255]。
+//
+// 返回：
+//   - []byte: 填充后的数据，总是新分配的切片，不与 data 共享底层数组。
+//   - error: blockSize 不合法时返回 ErrInvalidBlockSize。
+func ZeroPad(data []byte, blockSize int) ([]byte, error) {
+	if err := checkBlockSize(blockSize); nil != err {
+		return nil, err
+	}
+
+	n := (blockSize - len(data)%blockSize) % blockSize
+	out := make([]byte, len(data)+n)
+	copy(out, data)
+	return out, nil
+}
+
+// ZeroUnpad 移除 data 末尾由 ZeroPad 追加的 0 字节。
+//
+// 最多移除 blockSize-1 个末尾的 0 字节；原始数据本身以 0 结尾时，这些字节同样会被移除。
+//
+// 参数：
+//   - data: 已填充的数据，长度必须是 blockSize 的整数倍，可为空。
+//   - blockSize: 加密块大小，单位为字节，取值范围 [1, 255]。
+//
+// 返回：
+//   - []byte: 去除填充后的数据，是 data 的子切片，与输入共享底层数组。
+//   - error: blockSize 不合法时返回 ErrInvalidBlockSize；数据长度不是块大小的整数倍时返回 ErrInvalidPadding。
+func ZeroUnpad(data []byte, blockSize int) ([]byte, error) {
+	if err := checkBlockSize(blockSize); nil != err {
+		return nil, err
+	}
+	if 0 != len(data)%blockSize {
+		return nil, ErrInvalidPadding
+	}
+
+	n := len(data)
+	for i := 0; i < blockSize-1 && n > 0 && 0 == data[n-1]; i++ {
+		n--
+	}
+	return data[:n], nil
+}
+
+// ISO10126Pad 使用 ISO 10126 标准对 data 进行填充。
+//
+// 填充长度的计算与 PKCS7 相同，最后一个字节为填充长度，其余填充字节为随机值。
+//
+// 参数：
+//   - data: 需要填充的原始数据，可为空。
+//   - blockSize: 加密块大小，单位为字节，取值范围 [1, 255]。
+//
+// 返回：
+//   - []byte: 填充后的数据，总是新分配的切片，不与 data 共享底层数组。
+//   - error: blockSize 不合法时返回 ErrInvalidBlockSize；读取随机数失败时返回对应错误。
+func ISO10126Pad(data []byte, blockSize int) ([]byte, error) {
+	if err := checkBlockSize(blockSize); nil != err {
+		return nil, err
+	}
+
+	n := blockSize - len(data)%blockSize
+	out := make([]byte, len(data)+n)
+	copy(out, data)
+	if _, err := io.ReadFull(randReader, out[len(data):len(out)-1]); nil != err {
+		return nil, err
+	}
+	out[len(out)-1] = byte(n)
+	return out, nil
+}
+
+// ISO10126Unpad 移除 data 末尾的 ISO 10126 填充。
+//
+// 只校验最后一个字节表示的填充长度，随机填充字节不参与校验。
+//
+// 参数：
+//   - data: 已填充的数据，长度必须是 blockSize 的正整数倍。
+//   - blockSize: 加密块大小，单位为字节，取值范围 [1, 255]。
+//
+// 返回：
+//   - []byte: 去除填充后的数据，是 data 的子切片，与输入共享底层数组。
+//   - error: blockSize 不合法时返回 ErrInvalidBlockSize；数据长度或填充长度不合法时返回 ErrInvalidPadding。
+func ISO10126Unpad(data []byte, blockSize int) ([]byte, error) {
+	if err := checkBlockSize(blockSize); nil != err {
+		return nil, err
+	}
+	n := len(data)
+	if 0 == n || 0 != n%blockSize {
+		return nil, ErrInvalidPadding
+	}
+
+	padLen := int(data[n-1])
+	if padLen < 1 || padLen > blockSize {
+		return nil, ErrInvalidPadding
+	}
+	return data[:n-padLen], nil
+}
+
+// checkBlockSize 校验块大小是否在 [1, 255] 范围内。
+//
+// 参数：
+//   - blockSize: 加密块大小。
+//
+// 返回：
+//   - error: 不合法时返回 ErrInvalidBlockSize。
+func checkBlockSize(blockSize int) error {
+	if blockSize < 1 || blockSize > maxBlockSize {
+		return ErrInvalidBlockSize
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package padding
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPKCS7Pad 验证 PKCS7 填充的长度、内容与块大小校验。
+func TestPKCS7Pad(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		data        []byte
+		blockSize   int
+		want        []byte
+		wantErr     error
+	}{
+		{name: "success/empty", description: "验证空数据填充一个完整块。", data: []byte{}, blockSize: 8, want: []byte{8, 8, 8, 8, 8, 8, 8, 8}},
+		{name: "success/partial", description: "验证不足一块的数据填充到块大小。", data: []byte{1, 2, 3}, blockSize: 8, want: []byte{1, 2, 3, 5, 5, 5, 5, 5}},
+		{name: "success/aligned", description: "验证整块数据追加一个完整块。", data: []byte{1, 2, 3, 4}, blockSize: 4, want: []byte{1, 2, 3, 4, 4, 4, 4, 4}},
+		{name: "success/block-1", description: "验证块大小为 1 时填充一个值为 1 的字节。", data: []byte{9}, blockSize: 1, want: []byte{9, 1}},
+		{name: "error/zero-block", description: "验证块大小为 0 时返回 ErrInvalidBlockSize。", data: []byte{1}, blockSize: 0, wantErr: ErrInvalidBlockSize},
+		{name: "error/large-block", description: "验证块大小超过 255 时返回 ErrInvalidBlockSize。", data: []byte{1}, blockSize: 256, wantErr: ErrInvalidBlockSize},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			got, err := PKCS7Pad(tt.data, tt.blockSize)
+			if nil != tt.wantErr {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	// 填充结果不与输入共享底层数组。
+	data := make([]byte, 3, 16)
+	padded, err := PKCS7Pad(data, 8)
+	require.NoError(t, err)
+	padded[0] = 0xff
+	assert.Equal(t, byte(0), data[0])
+	assert.Equal(t, byte(0), data[:4][3])
+}
+
+// TestPKCS7Unpad 验证 PKCS7 去填充对合法与各类不合法填充的处理。
+func TestPKCS7Unpad(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		data        []byte
+		blockSize   int
+		want        []byte
+		wantErr     error
+	}{
+		{name: "success/partial", description: "验证移除部分块填充。", data: []byte{1, 2, 3, 5, 5, 5, 5, 5}, blockSize: 8, want: []byte{1, 2, 3}},
+		{name: "success/full-block", description: "验证移除完整块填充后得到空数据。", data: []byte{4, 4, 4, 4}, blockSize: 4, want: []byte{}},
+		{name: "success/multi-block", description: "验证多块数据只检查最后一块。", data: []byte{1, 2, 3, 4, 5, 6, 2, 2}, blockSize: 4, want: []byte{1, 2, 3, 4, 5, 6}},
+		{name: "error/empty", description: "验证空数据返回 ErrInvalidPadding。", data: []byte{}, blockSize: 8, wantErr: ErrInvalidPadding},
+		{name: "error/unaligned", description: "验证长度不是块大小整数倍时返回 ErrInvalidPadding。", data: []byte{1, 2, 3}, blockSize: 4, wantErr: ErrInvalidPadding},
+		{name: "error/zero", description: "验证填充长度为 0 时返回 ErrInvalidPadding。", data: []byte{1, 2, 3, 0}, blockSize: 4, wantErr: ErrInvalidPadding},
+		{name: "error/exceeds-block", description: "验证填充长度超过块大小时返回 ErrInvalidPadding。", data: []byte{5, 5, 5, 5, 5, 5, 5, 5}, blockSize: 4, wantErr: ErrInvalidPadding},
+		{name: "error/mismatch", description: "验证填充字节不一致时返回 ErrInvalidPadding。", data: []byte{1, 3, 2, 3}, blockSize: 4, wantErr: ErrInvalidPadding},
+		{name: "error/block-size", description: "验证块大小不合法时返回 ErrInvalidBlockSize。", data: []byte{1}, blockSize: -1, wantErr: ErrInvalidBlockSize},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			got, err := PKCS7Unpad(tt.data, tt.blockSize)
+			if nil != tt.wantErr {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// TestPKCS7RoundTrip 验证各种长度与块大小下填充和去填充互逆。
+func TestPKCS7RoundTrip(t *testing.T) {
+	t.Log("验证长度 0 到 40 的数据在块大小 1、8、16、255 下填充后能还原。")
+
+	for _, blockSize := range []int{1, 8, 16, 255} {
+		for n := 0; n <= 40; n++ {
+			data := bytes.Repeat([]byte{0xab}, n)
+			padded, err := PKCS7Pad(data, blockSize)
+			require.NoError(t, err)
+			assert.Zero(t, len(padded)%blockSize)
+			got, err := PKCS7Unpad(padded, blockSize)
+			require.NoError(t, err)
+			assert.Equal(t, data, got)
+		}
+	}
+}
+
+// TestZeroPad 验证零填充与去填充。
+func TestZeroPad(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		data        []byte
+		blockSize   int
+		wantPadded  []byte
+		wantData    []byte
+	}{
+		{name: "success/partial", description: "验证不足一块的数据以 0 补齐，去填充后还原。", data: []byte("abc"), blockSize: 4, wantPadded: []byte{'a', 'b', 'c', 0}, wantData: []byte("abc")},
+		{name: "success/aligned", description: "验证整块数据不追加填充。", data: []byte("abcd"), blockSize: 4, wantPadded: []byte("abcd"), wantData: []byte("abcd")},
+		{name: "success/empty", description: "验证空数据保持为空。", data: []byte{}, blockSize: 4, wantPadded: []byte{}, wantData: []byte{}},
+		{name: "boundary/trailing-zero", description: "验证原始数据末尾的 0 字节同样被移除。", data: []byte{'a', 0}, blockSize: 4, wantPadded: []byte{'a', 0, 0, 0}, wantData: []byte{'a'}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			padded, err := ZeroPad(tt.data, tt.blockSize)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantPadded, padded)
+			got, err := ZeroUnpad(padded, tt.blockSize)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantData, got)
+		})
+	}
+
+	// 最多移除 blockSize-1 个 0 字节。
+	got, err := ZeroUnpad([]byte{0, 0, 0, 0}, 4)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0}, got)
+
+	_, err = ZeroUnpad([]byte{1, 2, 3}, 4)
+	assert.ErrorIs(t, err, ErrInvalidPadding)
+	_, err = ZeroPad(nil, 0)
+	assert.ErrorIs(t, err, ErrInvalidBlockSize)
+}
+
+// errReader 是总是返回错误的随机源。
+type errReader struct{}
+
+// Read 返回固定错误。
+func (errReader) Read(p []byte) (int, error) {
+	return 0, errors.New("rand failed")
+}
+
+// TestISO10126Pad 验证 ISO 10126 填充与去填充。
+func TestISO10126Pad(t *testing.T) {
+	t.Log("验证填充长度与 PKCS7 相同、最后一个字节为填充长度，且去填充只校验填充长度。")
+
+	for n := 0; n <= 17; n++ {
+		data := bytes.Repeat([]byte{7}, n)
+		padded, err := ISO10126Pad(data, 8)
+		require.NoError(t, err)
+		assert.Zero(t, len(padded)%8)
+		assert.Equal(t, byte(len(padded)-n), padded[len(padded)-1])
+		got, err := ISO10126Unpad(padded, 8)
+		require.NoError(t, err)
+		assert.Equal(t, data, got)
+	}
+
+	_, err := ISO10126Unpad([]byte{1, 2, 3, 0}, 4)
+	assert.ErrorIs(t, err, ErrInvalidPadding)
+	_, err = ISO10126Unpad([]byte{1, 2, 3, 9}, 4)
+	assert.ErrorIs(t, err, ErrInvalidPadding)
+	_, err = ISO10126Unpad(nil, 4)
+	assert.ErrorIs(t, err, ErrInvalidPadding)
+	_, err = ISO10126Pad(nil, 256)
+	assert.ErrorIs(t, err, ErrInvalidBlockSize)
+
+	original := randReader
+	randReader = errReader{}
+	defer func() { randReader = original }()
+	_, err = ISO10126Pad([]byte{1}, 8)
+	assert.Error(t, err)
+}
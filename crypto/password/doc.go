@@ -0,0 +1,14 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+// Package password 提供面向存储的密码哈希与强度校验工具。
+//
+// HashPassword 按 Policy 先校验密码强度，再使用 argon2id（默认）或 bcrypt 计算自带参数的哈希字符串；
+// VerifyPassword 从哈希字符串识别算法与参数，以常量时间完成比对，无需知道生成时的策略。
+// 提升成本参数或切换算法后，在验证成功时调用 NeedsRehash 判断旧哈希是否需要用新策略重新计算，
+// 从而在用户登录时逐步迁移。ValidateStrength 可单独用于注册或修改密码表单的校验，违反的规则
+// 通过 errors.Join 汇总，均匹配 ErrWeakPassword。
+//
+// 本包用于替代以 MD5、SHA 等快速摘要保存密码的做法；不负责密码传输、限流或账户锁定。
+package password
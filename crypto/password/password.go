@@ -0,0 +1,349 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package password
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// 以下为支持的密码哈希算法。
+const (
+	// AlgorithmArgon2id 表示 argon2id，是 DefaultPolicy 使用的算法。
+	AlgorithmArgon2id Algorithm = "argon2id"
+	// AlgorithmBcrypt 表示 bcrypt，用于兼容已有哈希或受限环境。
+	AlgorithmBcrypt Algorithm = "bcrypt"
+)
+
+const (
+	// argon2idPrefix 是 argon2id PHC 格式哈希的前缀。
+	argon2idPrefix = "$argon2id$"
+	// argon2idFormat 是 argon2id PHC 格式哈希的参数部分格式。
+	argon2idFormat = "$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s"
+	// argon2idMaxMemory 是解析哈希时允许的最大内存参数（KiB），防止恶意哈希耗尽内存。
+	argon2idMaxMemory = 4 * 1024 * 1024
+)
+
+var (
+	// ErrUnsupportedAlgorithm 表示策略中的算法不受支持。
+	//
+	// 调用方可以使用 errors.Is 判断该错误。
+	ErrUnsupportedAlgorithm = errors.New("密码哈希算法不受支持。")
+
+	// ErrMalformedHash 表示哈希字符串无法识别或参数非法。
+	//
+	// 调用方可以使用 errors.Is 判断该错误。
+	ErrMalformedHash = errors.New("密码哈希格式不正确。")
+
+	// ErrInvalidPolicy 表示策略中的哈希参数超出允许范围。
+	//
+	// 调用方可以使用 errors.Is 判断该错误。
+	ErrInvalidPolicy = errors.New("密码策略参数不正确。")
+
+	// randReader 是生成盐值的随机源，测试中可替换。
+	randReader io.Reader = rand.Reader
+)
+
+type (
+	// Algorithm 标识密码哈希算法。
+	Algorithm string
+
+	// Argon2idParams 保存 argon2id 的成本参数。
+	//
+	// 字段为 0 时使用 DefaultPolicy 中的对应值。
+	Argon2idParams struct {
+		// Time 是迭代次数。
+		Time uint32
+		// Memory 是内存开销，单位为 KiB。
+		Memory uint32
+		// Threads 是并行度。
+		Threads uint8
+		// KeyLength 是输出哈希的字节数。
+		KeyLength uint32
+		// SaltLength 是随机盐值的字节数。
+		SaltLength uint32
+	}
+
+	// Policy 描述密码哈希与强度校验策略。
+	//
+	// 哈希参数决定 HashPassword 生成的哈希格式，NeedsRehash 会将已有哈希与之比较；
+	// 强度规则由 ValidateStrength 使用，HashPassword 在哈希前同样会执行校验。
+	// 零值策略等价于 DefaultPolicy 的哈希参数且不启用任何强度规则。
+	Policy struct {
+		// Algorithm 是哈希算法，为空时使用 AlgorithmArgon2id。
+		Algorithm Algorithm
+		// BcryptCost 是 bcrypt 成本因子，为 0 时使用 bcrypt.DefaultCost。
+		BcryptCost int
+		// Argon2id 是 argon2id 的成本参数。
+		Argon2id Argon2idParams
+
+		// MinLength 是密码的最少字符数，为 0 时不限制。
+		MinLength int
+		// MaxLength 是密码的最多字符数，为 0 时不限制；使用 bcrypt 时密码字节数不能超过 72。
+		MaxLength int
+		// RequireUpper 要求密码至少包含一个大写字母。
+		RequireUpper bool
+		// RequireLower 要求密码至少包含一个小写字母。
+		RequireLower bool
+		// RequireDigit 要求密码至少包含一个数字。
+		RequireDigit bool
+		// RequireSymbol 要求密码至少包含一个符号或标点。
+		RequireSymbol bool
+		// MinClasses 要求密码至少包含的字符类别数（大写、小写、数字、符号），为 0 时不限制。
+		MinClasses int
+		// Forbidden 是禁止使用的密码列表，比较时忽略大小写。
+		Forbidden []string
+	}
+)
+
+// DefaultPolicy 返回默认的密码策略。
+//
+// 默认使用 RFC 9106 推荐的 argon2id 参数（t=3、m=64MiB、p=4、32 字节哈希、16 字节盐值），
+// 并要求密码至少 8 个字符、至多 128 个字符。
+//
+// 参数：无。
+//
+// 返回：
+//   - Policy: 默认策略。
+func DefaultPolicy() Policy {
+	return Policy{
+		Algorithm:  AlgorithmArgon2id,
+		BcryptCost: bcrypt.DefaultCost,
+		Argon2id: Argon2idParams{
+			Time:       3,
+			Memory:     64 * 1024,
+			Threads:    4,
+			KeyLength:  32,
+			SaltLength: 16,
+		},
+		MinLength: 8,
+		MaxLength: 128,
+	}
+}
+
+// HashPassword 按策略校验密码强度并计算密码哈希。
+//
+// argon2id 哈希使用 PHC 字符串格式，例如 "$argon2id$v=19$m=65536,t=3,p=4$<salt>$<hash>"；
+// bcrypt 哈希使用标准的 "$2a$" 格式。两种格式都自带参数，VerifyPassword 无需策略即可验证。
+//
+// 参数：
+//   - password: 密码明文。
+//   - policy: 密码策略。
+//
+// 返回：
+//   - string: 可持久化的哈希字符串。
+//   - error: 密码强度不足（ErrWeakPassword）、策略非法（ErrInvalidPolicy、ErrUnsupportedAlgorithm）或哈希失败时返回错误。
+func HashPassword(password string, policy Policy) (string, error) {
+	if err := ValidateStrength(password, policy); nil != err {
+		return "", err
+	}
+	policy = policy.normalize()
+
+	switch policy.Algorithm {
+	case AlgorithmArgon2id:
+		return hashArgon2id(password, policy.Argon2id)
+	case AlgorithmBcrypt:
+		if policy.BcryptCost < bcrypt.MinCost || policy.BcryptCost > bcrypt.MaxCost {
+			return "", fmt.Errorf("%w: bcrypt 成本因子超出范围：%d", ErrInvalidPolicy, policy.BcryptCost)
+		}
+		hash, err := bcrypt.GenerateFromPassword([]byte(password), policy.BcryptCost)
+		if nil != err {
+			return "", fmt.Errorf("计算密码哈希失败：%w", err)
+		}
+		return string(hash), nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedAlgorithm, policy.Algorithm)
+	}
+}
+
+// VerifyPassword 以常量时间验证密码是否与哈希匹配。
+//
+// 算法与参数从哈希字符串中识别，策略变更后旧哈希仍可验证；验证成功后可调用 NeedsRehash
+// 判断是否需要用新策略重新哈希。
+//
+// 参数：
+//   - hash: HashPassword 生成的哈希字符串或标准 bcrypt 哈希。
+//   - password: 待验证的密码明文。
+//
+// 返回：
+//   - bool: 匹配时返回 true。
+//   - error: 哈希格式无法识别或参数非法时返回 ErrMalformedHash；密码不匹配不视为错误。
+func VerifyPassword(hash, password string) (bool, error) {
+	switch {
+	case strings.HasPrefix(hash, argon2idPrefix):
+		params, salt, key, err := parseArgon2id(hash)
+		if nil != err {
+			return false, err
+		}
+		got := argon2.IDKey([]byte(password), salt, params.Time, params.Memory, params.Threads, uint32(len(key)))
+		return 1 == subtle.ConstantTimeCompare(got, key), nil
+	case isBcryptHash(hash):
+		err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+		if nil == err {
+			return true, nil
+		}
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return false, nil
+		}
+		return false, fmt.Errorf("%w: %v", ErrMalformedHash, err)
+	default:
+		return false, ErrMalformedHash
+	}
+}
+
+// NeedsRehash 判断哈希是否与当前策略的算法或参数不一致。
+//
+// 通常在 VerifyPassword 验证成功、仍持有密码明文时调用；返回 true 时应使用 HashPassword
+// 重新计算哈希并替换持久化的值，从而在提升成本参数或切换算法后逐步迁移旧哈希。
+//
+// 参数：
+//   - hash: 已有的哈希字符串。
+//   - policy: 当前密码策略。
+//
+// 返回：
+//   - bool: 算法或成本参数与策略不一致，或哈希格式无法识别时返回 true。
+func NeedsRehash(hash string, policy Policy) bool {
+	policy = policy.normalize()
+
+	switch {
+	case strings.HasPrefix(hash, argon2idPrefix):
+		if AlgorithmArgon2id != policy.Algorithm {
+			return true
+		}
+		params, salt, key, err := parseArgon2id(hash)
+		if nil != err {
+			return true
+		}
+		want := policy.Argon2id
+		return params.Time != want.Time || params.Memory != want.Memory || params.Threads != want.Threads ||
+			uint32(len(key)) != want.KeyLength || uint32(len(salt)) != want.SaltLength
+	case isBcryptHash(hash):
+		if AlgorithmBcrypt != policy.Algorithm {
+			return true
+		}
+		cost, err := bcrypt.Cost([]byte(hash))
+		return nil != err || cost != policy.BcryptCost
+	default:
+		return true
+	}
+}
+
+// normalize 使用默认值填充策略中为零的哈希参数。
+//
+// 参数：无。
+//
+// 返回：
+//   - Policy: 填充后的策略副本。
+func (p Policy) normalize() Policy {
+	defaults := DefaultPolicy()
+	if "" == p.Algorithm {
+		p.Algorithm = defaults.Algorithm
+	}
+	if 0 == p.BcryptCost {
+		p.BcryptCost = defaults.BcryptCost
+	}
+	if 0 == p.Argon2id.Time {
+		p.Argon2id.Time = defaults.Argon2id.Time
+	}
+	if 0 == p.Argon2id.Memory {
+		p.Argon2id.Memory = defaults.Argon2id.Memory
+	}
+	if 0 == p.Argon2id.Threads {
+		p.Argon2id.Threads = defaults.Argon2id.Threads
+	}
+	if 0 == p.Argon2id.KeyLength {
+		p.Argon2id.KeyLength = defaults.Argon2id.KeyLength
+	}
+	if 0 == p.Argon2id.SaltLength {
+		p.Argon2id.SaltLength = defaults.Argon2id.SaltLength
+	}
+	return p
+}
+
+// hashArgon2id 使用 argon2id 计算密码哈希并编码为 PHC 字符串。
+//
+// 参数：
+//   - password: 密码明文。
+//   - params: argon2id 成本参数，字段均已填充。
+//
+// 返回：
+//   - string: PHC 格式的哈希字符串。
+//   - error: 参数非法或随机源读取失败时返回错误。
+func hashArgon2id(password string, params Argon2idParams) (string, error) {
+	if params.Memory < 8*uint32(params.Threads) || params.Memory > argon2idMaxMemory {
+		return "", fmt.Errorf("%w: argon2id 内存参数超出范围：%d", ErrInvalidPolicy, params.Memory)
+	}
+	if params.KeyLength < 16 || params.SaltLength < 8 {
+		return "", fmt.Errorf("%w: argon2id 哈希至少 16 字节、盐值至少 8 字节", ErrInvalidPolicy)
+	}
+
+	salt := make([]byte, params.SaltLength)
+	if _, err := io.ReadFull(randReader, salt); nil != err {
+		return "", fmt.Errorf("生成盐值失败：%w", err)
+	}
+	key := argon2.IDKey([]byte(password), salt, params.Time, params.Memory, params.Threads, params.KeyLength)
+	return fmt.Sprintf(argon2idFormat, argon2.Version, params.Memory, params.Time, params.Threads,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+// parseArgon2id 解析 argon2id PHC 格式的哈希字符串。
+//
+// 参数：
+//   - hash: PHC 格式的哈希字符串。
+//
+// 返回：
+//   - Argon2idParams: 解析出的成本参数，KeyLength 与 SaltLength 为实际长度。
+//   - []byte: 盐值。
+//   - []byte: 哈希值。
+//   - error: 格式非法、版本不支持或参数超出范围时返回 ErrMalformedHash。
+func parseArgon2id(hash string) (Argon2idParams, []byte, []byte, error) {
+	var params Argon2idParams
+	parts := strings.Split(hash, "$")
+	if 6 != len(parts) {
+		return params, nil, nil, ErrMalformedHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); nil != err || argon2.Version != version {
+		return params, nil, nil, fmt.Errorf("%w: 不支持的 argon2 版本：%s", ErrMalformedHash, parts[2])
+	}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Time, &params.Threads); nil != err {
+		return params, nil, nil, fmt.Errorf("%w: argon2id 参数非法：%s", ErrMalformedHash, parts[3])
+	}
+	if 0 == params.Time || 0 == params.Threads || params.Memory > argon2idMaxMemory {
+		return params, nil, nil, fmt.Errorf("%w: argon2id 参数超出范围：%s", ErrMalformedHash, parts[3])
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if nil != err {
+		return params, nil, nil, fmt.Errorf("%w: argon2id 盐值编码非法", ErrMalformedHash)
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if nil != err || 0 == len(key) {
+		return params, nil, nil, fmt.Errorf("%w: argon2id 哈希值编码非法", ErrMalformedHash)
+	}
+	params.SaltLength = uint32(len(salt))
+	params.KeyLength = uint32(len(key))
+	return params, salt, key, nil
+}
+
+// isBcryptHash 判断字符串是否具有 bcrypt 哈希的前缀。
+//
+// 参数：
+//   - hash: 哈希字符串。
+//
+// 返回：
+//   - bool: 以 "$2a$"、"$2b$" 或 "$2y$" 开头时返回 true。
+func isBcryptHash(hash string) bool {
+	return strings.HasPrefix(hash, "$2a$") || strings.HasPrefix(hash, "$2b$") || strings.HasPrefix(hash, "$2y$")
+}
@@ -0,0 +1,227 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package password
+
+import (
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// fastPolicy 返回测试使用的低成本策略。
+//
+// 参数：
+//   - algorithm: 哈希算法。
+//
+// 返回：
+//   - Policy: 低成本策略。
+func fastPolicy(algorithm Algorithm) Policy {
+	return Policy{
+		Algorithm:  algorithm,
+		BcryptCost: bcrypt.MinCost,
+		Argon2id:   Argon2idParams{Time: 1, Memory: 64, Threads: 1, KeyLength: 16, SaltLength: 8},
+	}
+}
+
+// TestHashPassword_RoundTrip 验证不同算法的哈希与验证往返。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestHashPassword_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		policy      Policy
+		wantPrefix  string
+	}{
+		{name: "success/argon2id", description: "验证 argon2id 生成 PHC 格式哈希。", policy: fastPolicy(AlgorithmArgon2id), wantPrefix: "$argon2id$v=19$m=64,t=1,p=1$"},
+		{name: "success/bcrypt", description: "验证 bcrypt 生成标准格式哈希。", policy: fastPolicy(AlgorithmBcrypt), wantPrefix: "$2a$04$"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			hash, err := HashPassword("correct horse", tt.policy)
+			require.NoError(t, err)
+			assert.True(t, strings.HasPrefix(hash, tt.wantPrefix), hash)
+
+			ok, err := VerifyPassword(hash, "correct horse")
+			require.NoError(t, err)
+			assert.True(t, ok)
+
+			ok, err = VerifyPassword(hash, "wrong horse")
+			require.NoError(t, err)
+			assert.False(t, ok)
+
+			another, err := HashPassword("correct horse", tt.policy)
+			require.NoError(t, err)
+			assert.NotEqual(t, hash, another)
+			assert.False(t, NeedsRehash(hash, tt.policy))
+		})
+	}
+}
+
+// TestHashPassword_Errors 验证强度不足、策略非法与随机源失败时返回错误。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestHashPassword_Errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		password    string
+		policy      func() Policy
+		wantErr     error
+	}{
+		{
+			name:        "error/weak",
+			description: "验证强度不足时不计算哈希。",
+			password:    "short",
+			policy:      DefaultPolicy,
+			wantErr:     ErrWeakPassword,
+		},
+		{
+			name:        "error/algorithm",
+			description: "验证未知算法。",
+			password:    "password",
+			policy:      func() Policy { return Policy{Algorithm: "md5"} },
+			wantErr:     ErrUnsupportedAlgorithm,
+		},
+		{
+			name:        "error/bcrypt-cost",
+			description: "验证超出范围的 bcrypt 成本因子。",
+			password:    "password",
+			policy:      func() Policy { return Policy{Algorithm: AlgorithmBcrypt, BcryptCost: 100} },
+			wantErr:     ErrInvalidPolicy,
+		},
+		{
+			name:        "error/argon2id-key-length",
+			description: "验证过短的 argon2id 哈希长度。",
+			password:    "password",
+			policy: func() Policy {
+				p := fastPolicy(AlgorithmArgon2id)
+				p.Argon2id.KeyLength = 4
+				return p
+			},
+			wantErr: ErrInvalidPolicy,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			_, err := HashPassword(tt.password, tt.policy())
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+
+	t.Run("error/rand", func(t *testing.T) {
+		t.Log("验证随机源读取失败时返回错误。")
+
+		original := randReader
+		randReader = iotest.ErrReader(assert.AnError)
+		defer func() { randReader = original }()
+
+		_, err := HashPassword("password", fastPolicy(AlgorithmArgon2id))
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+// TestVerifyPassword_Malformed 验证无法识别的哈希返回 ErrMalformedHash。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestVerifyPassword_Malformed(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		hash        string
+	}{
+		{name: "error/empty", description: "验证空哈希。", hash: ""},
+		{name: "error/md5", description: "验证十六进制摘要。", hash: "5f4dcc3b5aa765d61d8327deb882cf99"},
+		{name: "error/argon2id-parts", description: "验证段数不足的 argon2id 哈希。", hash: "$argon2id$v=19$m=64,t=1,p=1$c2FsdA"},
+		{name: "error/argon2id-version", description: "验证不支持的 argon2 版本。", hash: "$argon2id$v=16$m=64,t=1,p=1$c2FsdHNhbHQ$aGFzaGhhc2hoYXNoaGFzaA"},
+		{name: "error/argon2id-params", description: "验证非法的成本参数。", hash: "$argon2id$v=19$m=64,t=0,p=1$c2FsdHNhbHQ$aGFzaGhhc2hoYXNoaGFzaA"},
+		{name: "error/argon2id-memory", description: "验证超出上限的内存参数。", hash: "$argon2id$v=19$m=99999999,t=1,p=1$c2FsdHNhbHQ$aGFzaGhhc2hoYXNoaGFzaA"},
+		{name: "error/argon2id-salt", description: "验证非法的盐值编码。", hash: "$argon2id$v=19$m=64,t=1,p=1$!!$aGFzaGhhc2hoYXNoaGFzaA"},
+		{name: "error/bcrypt", description: "验证截断的 bcrypt 哈希。", hash: "$2a$04$short"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			ok, err := VerifyPassword(tt.hash, "password")
+			assert.False(t, ok)
+			assert.ErrorIs(t, err, ErrMalformedHash)
+		})
+	}
+}
+
+// TestNeedsRehash 验证策略变更后的重新哈希判断。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestNeedsRehash(t *testing.T) {
+	argonHash, err := HashPassword("password", fastPolicy(AlgorithmArgon2id))
+	require.NoError(t, err)
+	bcryptHash, err := HashPassword("password", fastPolicy(AlgorithmBcrypt))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		description string
+		hash        string
+		policy      func() Policy
+		want        bool
+	}{
+		{name: "success/argon2id-same", description: "验证参数一致时无需重新哈希。", hash: argonHash, policy: func() Policy { return fastPolicy(AlgorithmArgon2id) }},
+		{name: "success/bcrypt-same", description: "验证成本一致时无需重新哈希。", hash: bcryptHash, policy: func() Policy { return fastPolicy(AlgorithmBcrypt) }},
+		{
+			name:        "success/argon2id-memory",
+			description: "验证提升内存参数后需要重新哈希。",
+			hash:        argonHash,
+			policy: func() Policy {
+				p := fastPolicy(AlgorithmArgon2id)
+				p.Argon2id.Memory = 128
+				return p
+			},
+			want: true,
+		},
+		{
+			name:        "success/bcrypt-cost",
+			description: "验证提升成本因子后需要重新哈希。",
+			hash:        bcryptHash,
+			policy: func() Policy {
+				p := fastPolicy(AlgorithmBcrypt)
+				p.BcryptCost = bcrypt.MinCost + 1
+				return p
+			},
+			want: true,
+		},
+		{name: "success/bcrypt-to-argon2id", description: "验证切换算法后需要重新哈希。", hash: bcryptHash, policy: func() Policy { return fastPolicy(AlgorithmArgon2id) }, want: true},
+		{name: "success/argon2id-to-bcrypt", description: "验证切换算法后需要重新哈希。", hash: argonHash, policy: func() Policy { return fastPolicy(AlgorithmBcrypt) }, want: true},
+		{name: "boundary/default", description: "验证低成本哈希相对默认策略需要重新哈希。", hash: argonHash, policy: DefaultPolicy, want: true},
+		{name: "boundary/legacy", description: "验证无法识别的旧哈希需要重新哈希。", hash: "5f4dcc3b5aa765d61d8327deb882cf99", policy: DefaultPolicy, want: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			assert.Equal(t, tt.want, NeedsRehash(tt.hash, tt.policy()))
+		})
+	}
+}
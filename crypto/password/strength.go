@@ -0,0 +1,114 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package password
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// bcryptMaxBytes 是 bcrypt 能处理的最大密码字节数。
+	bcryptMaxBytes = 72
+)
+
+var (
+	// ErrWeakPassword 表示密码不满足策略的强度规则。
+	//
+	// ValidateStrength 返回的错误会同时匹配 ErrWeakPassword 和具体违反的规则错误，
+	// 调用方可以使用 errors.Is 判断。
+	ErrWeakPassword = errors.New("密码强度不足。")
+
+	// ErrTooShort 表示密码字符数少于 Policy.MinLength。
+	ErrTooShort = fmt.Errorf("%w: 密码长度过短", ErrWeakPassword)
+	// ErrTooLong 表示密码字符数多于 Policy.MaxLength，或 bcrypt 密码超过 72 字节。
+	ErrTooLong = fmt.Errorf("%w: 密码长度过长", ErrWeakPassword)
+	// ErrMissingUpper 表示密码缺少大写字母。
+	ErrMissingUpper = fmt.Errorf("%w: 缺少大写字母", ErrWeakPassword)
+	// ErrMissingLower 表示密码缺少小写字母。
+	ErrMissingLower = fmt.Errorf("%w: 缺少小写字母", ErrWeakPassword)
+	// ErrMissingDigit 表示密码缺少数字。
+	ErrMissingDigit = fmt.Errorf("%w: 缺少数字", ErrWeakPassword)
+	// ErrMissingSymbol 表示密码缺少符号。
+	ErrMissingSymbol = fmt.Errorf("%w: 缺少符号", ErrWeakPassword)
+	// ErrTooFewClasses 表示密码包含的字符类别数少于 Policy.MinClasses。
+	ErrTooFewClasses = fmt.Errorf("%w: 字符类别过少", ErrWeakPassword)
+	// ErrForbidden 表示密码位于 Policy.Forbidden 禁用列表中。
+	ErrForbidden = fmt.Errorf("%w: 密码被禁止使用", ErrWeakPassword)
+)
+
+// ValidateStrength 按策略的强度规则校验密码。
+//
+// 长度按 Unicode 字符计算；密码违反多条规则时，返回的错误通过 errors.Join 汇总全部违反项，
+// 便于一次性提示用户。
+//
+// 参数：
+//   - password: 密码明文。
+//   - policy: 密码策略，仅使用其中的强度规则与算法。
+//
+// 返回：
+//   - error: 满足全部规则时返回 nil，否则返回匹配 ErrWeakPassword 的错误。
+func ValidateStrength(password string, policy Policy) error {
+	var errs []error
+
+	length := utf8.RuneCountInString(password)
+	if policy.MinLength > 0 && length < policy.MinLength {
+		errs = append(errs, fmt.Errorf("%w：至少 %d 个字符", ErrTooShort, policy.MinLength))
+	}
+	if policy.MaxLength > 0 && length > policy.MaxLength {
+		errs = append(errs, fmt.Errorf("%w：至多 %d 个字符", ErrTooLong, policy.MaxLength))
+	} else if AlgorithmBcrypt == policy.Algorithm && len(password) > bcryptMaxBytes {
+		errs = append(errs, fmt.Errorf("%w：bcrypt 至多 %d 字节", ErrTooLong, bcryptMaxBytes))
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			hasSymbol = true
+		}
+	}
+	if policy.RequireUpper && !hasUpper {
+		errs = append(errs, ErrMissingUpper)
+	}
+	if policy.RequireLower && !hasLower {
+		errs = append(errs, ErrMissingLower)
+	}
+	if policy.RequireDigit && !hasDigit {
+		errs = append(errs, ErrMissingDigit)
+	}
+	if policy.RequireSymbol && !hasSymbol {
+		errs = append(errs, ErrMissingSymbol)
+	}
+	if policy.MinClasses > 0 {
+		classes := 0
+		for _, has := range []bool{hasUpper, hasLower, hasDigit, hasSymbol} {
+			if has {
+				classes++
+			}
+		}
+		if classes < policy.MinClasses {
+			errs = append(errs, fmt.Errorf("%w：至少 %d 类", ErrTooFewClasses, policy.MinClasses))
+		}
+	}
+
+	for _, forbidden := range policy.Forbidden {
+		if strings.EqualFold(password, forbidden) {
+			errs = append(errs, ErrForbidden)
+			break
+		}
+	}
+
+	return errors.Join(errs...)
+}
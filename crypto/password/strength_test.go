@@ -0,0 +1,65 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package password
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestValidateStrength 验证各项强度规则。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestValidateStrength(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		password    string
+		policy      Policy
+		wantErrs    []error
+	}{
+		{name: "success/zero-policy", description: "验证零值策略不启用任何规则。", password: ""},
+		{name: "success/default", description: "验证满足默认策略的密码。", password: "correct horse", policy: DefaultPolicy()},
+		{
+			name:        "success/all-rules",
+			description: "验证同时满足全部规则的密码。",
+			password:    "Tr0ub4dor&3",
+			policy:      Policy{MinLength: 8, MaxLength: 64, RequireUpper: true, RequireLower: true, RequireDigit: true, RequireSymbol: true, MinClasses: 4},
+		},
+		{name: "boundary/unicode-length", description: "验证长度按字符而非字节计算。", password: "密码密码", policy: Policy{MinLength: 4, MaxLength: 4}},
+		{name: "error/too-short", description: "验证过短的密码。", password: "abc", policy: DefaultPolicy(), wantErrs: []error{ErrTooShort}},
+		{name: "error/too-long", description: "验证过长的密码。", password: strings.Repeat("a", 129), policy: DefaultPolicy(), wantErrs: []error{ErrTooLong}},
+		{name: "error/bcrypt-bytes", description: "验证 bcrypt 超过 72 字节的密码。", password: strings.Repeat("密", 25), policy: Policy{Algorithm: AlgorithmBcrypt}, wantErrs: []error{ErrTooLong}},
+		{
+			name:        "error/classes",
+			description: "验证缺少多个字符类别时汇总全部违反项。",
+			password:    "lowercase",
+			policy:      Policy{RequireUpper: true, RequireDigit: true, RequireSymbol: true, MinClasses: 3},
+			wantErrs:    []error{ErrMissingUpper, ErrMissingDigit, ErrMissingSymbol, ErrTooFewClasses},
+		},
+		{name: "error/lower", description: "验证缺少小写字母。", password: "UPPER", policy: Policy{RequireLower: true}, wantErrs: []error{ErrMissingLower}},
+		{name: "error/forbidden", description: "验证禁用列表忽略大小写。", password: "Password1", policy: Policy{Forbidden: []string{"123456", "password1"}}, wantErrs: []error{ErrForbidden}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			err := ValidateStrength(tt.password, tt.policy)
+			if 0 == len(tt.wantErrs) {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, ErrWeakPassword)
+			for _, want := range tt.wantErrs {
+				assert.ErrorIs(t, err, want)
+			}
+		})
+	}
+}
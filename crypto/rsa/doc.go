@@ -5,10 +5,20 @@
 // Package rsa 提供 RSA-OAEP、PEM 密钥转换和历史兼容的 RSA 包装函数。
 //
 // 本包接受 PKCS#1 RSA PRIVATE KEY PEM 私钥和 PKIX PUBLIC KEY PEM 公钥，
-// 可在 PEM 字节与标准库 RSA key 类型之间转换。OAEP 入口默认使用 SHA-256 和 nil label，
-// 自定义 hash 或 label 时，加密与解密必须使用完全一致的参数。
+// 可在 PEM 字节与标准库 RSA key 类型之间转换。ConvertPrivateKeyWithPassword 额外支持口令加密的私钥，
+// 包括传统 PEM 加密（Proc-Type: 4,ENCRYPTED）与 PKCS#8 ENCRYPTED PRIVATE KEY（PBES2 + PBKDF2）。
+// OAEP 入口默认使用 SHA-256 和 nil label，自定义 hash 或 label 时，加密与解密必须使用完全一致的参数。
+//
+// 单次加密的明文长度受密钥长度和填充方式限制，超过时返回 *MessageTooLongError，
+// 它兼容 errors.Is(err, rsa.ErrMessageTooLong)。需要加密更长的数据时，EncryptLarge /
+// DecryptLarge 提供 RSA-OAEP 封装 AES-256-GCM 数据密钥的混合加密；
+// EncryptPublicKeyOAEPChunked / DecryptPrivateKeyOAEPChunked 以及 NewOAEPEncryptWriter /
+// NewOAEPDecryptReader 提供纯 RSA-OAEP 的定长分块格式，每个分块的 label 绑定分块序号，
+// 最后一个分块带结束标志，可以发现分块重排和截断。
+// 混合加密的密文是 crypto/envelope 定义的自描述信封：SealEnvelope / OpenEnvelope 直接处理可携带 key-id 的信封，
+// EncryptLarge 输出其二进制格式；信封头部同时作为 OAEP label 与 GCM AAD。DecryptLarge 仍可解密早期的版本号格式。
 //
 // PKCS#1 v1.5 encryption 以及“私钥加密、公钥解密”函数仅为兼容历史密文格式、
-// 旧协议或迁移场景保留，不提供分块、大消息处理、签名验签或协议级认证策略；
+// 旧协议或迁移场景保留，不提供签名验签或协议级认证策略；
 // 新代码应优先使用 OAEP 或标准库 crypto/rsa 的签名 API。
 package rsa
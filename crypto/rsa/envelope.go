@@ -0,0 +1,143 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package rsa
+
+// 本文件提供基于自描述密文信封的 RSA-OAEP + AES-256-GCM 混合加密。
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	kitenvelope "github.com/fsyyft-go/kit/crypto/envelope"
+)
+
+// SealEnvelope 使用 RSA 公钥对任意长度数据进行混合加密，并返回自描述的密文信封。
+//
+// 算法为 kitenvelope.AlgorithmRSAOAEP256A256GCM：随机生成 AES-256 数据密钥加密数据，
+// 再以 SHA-256 RSA-OAEP 加密数据密钥。信封头部（版本、算法与 keyID）同时作为 OAEP label
+// 与 GCM AAD，被篡改时 OpenEnvelope 失败。信封字段的含义为：
+//
+//	Nonce = 12 字节 GCM nonce，Tag = GCM 认证标签，
+//	Ciphertext = 2 字节大端序封装密钥长度 || 封装密钥 || 数据密文
+//
+// 参数：
+//   - pubKey: RSA 公钥对象，必须非 nil，模数至少需要容纳 32 字节的 SHA-256 OAEP 明文。
+//   - keyID: 标识 RSA 密钥对的字符串，解密方据此选择私钥，长度不能超过 255 字节。
+//   - dataClear: 需要加密的明文数据，长度不限。
+//
+// 返回：
+//   - *kitenvelope.Envelope: 混合加密后的信封。
+//   - error: keyID 过长、公钥无效、随机数生成失败或加密失败时返回错误。
+func SealEnvelope(pubKey *rsa.PublicKey, keyID string, dataClear []byte) (*kitenvelope.Envelope, error) {
+	env := &kitenvelope.Envelope{Version: kitenvelope.Version1, Algorithm: kitenvelope.AlgorithmRSAOAEP256A256GCM, KeyID: keyID}
+	aad, err := env.AdditionalData()
+	if nil != err {
+		return nil, err
+	}
+
+	key := make([]byte, largeKeySize)
+	if _, err := io.ReadFull(rand.Reader, key); nil != err {
+		return nil, err
+	}
+	wrapped, err := EncryptPublicKeyOAEPWithHash(pubKey, key, sha256.New(), aad)
+	if nil != err {
+		return nil, err
+	}
+	aead, err := newEnvelopeGCM(key)
+	if nil != err {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); nil != err {
+		return nil, err
+	}
+
+	sealed := aead.Seal(nil, nonce, dataClear, aad)
+	tagStart := len(sealed) - aead.Overhead()
+
+	ciphertext := make([]byte, 0, 2+len(wrapped)+tagStart)
+	ciphertext = binary.BigEndian.AppendUint16(ciphertext, uint16(len(wrapped)))
+	ciphertext = append(ciphertext, wrapped...)
+	ciphertext = append(ciphertext, sealed[:tagStart]...)
+
+	env.Nonce = nonce
+	env.Ciphertext = ciphertext
+	env.Tag = sealed[tagStart:]
+	return env, nil
+}
+
+// OpenEnvelope 使用 RSA 私钥解密 SealEnvelope 产生的信封。
+//
+// 调用方通常先读取 env.KeyID 选择私钥。
+//
+// 参数：
+//   - privKey: RSA 私钥对象，必须非 nil。
+//   - env: 待解密的信封，不能为 nil。
+//
+// 返回：
+//   - []byte: 解密后的明文数据。
+//   - error: 信封为 nil 或结构非法（kitenvelope.ErrMalformed）、算法不是 RSA-OAEP-256+A256GCM
+//     （kitenvelope.ErrUnsupportedAlgorithm）、数据密钥解密失败或 GCM 认证失败时返回错误。
+func OpenEnvelope(privKey *rsa.PrivateKey, env *kitenvelope.Envelope) ([]byte, error) {
+	if nil == env {
+		return nil, kitenvelope.ErrMalformed
+	}
+	if kitenvelope.AlgorithmRSAOAEP256A256GCM != env.Algorithm {
+		return nil, fmt.Errorf("%w: %s", kitenvelope.ErrUnsupportedAlgorithm, env.Algorithm)
+	}
+	aad, err := env.AdditionalData()
+	if nil != err {
+		return nil, err
+	}
+	if len(env.Ciphertext) < 2 {
+		return nil, kitenvelope.ErrMalformed
+	}
+	n := int(binary.BigEndian.Uint16(env.Ciphertext))
+	if len(env.Ciphertext) < 2+n {
+		return nil, kitenvelope.ErrMalformed
+	}
+
+	key, err := DecryptPrivateKeyOAEPWithHash(privKey, env.Ciphertext[2:2+n], sha256.New(), aad)
+	if nil != err {
+		return nil, err
+	}
+	if len(key) != largeKeySize {
+		return nil, kitenvelope.ErrMalformed
+	}
+	aead, err := newEnvelopeGCM(key)
+	if nil != err {
+		return nil, err
+	}
+	if len(env.Nonce) != aead.NonceSize() {
+		return nil, kitenvelope.ErrMalformed
+	}
+
+	sealed := make([]byte, 0, len(env.Ciphertext)-2-n+len(env.Tag))
+	sealed = append(sealed, env.Ciphertext[2+n:]...)
+	sealed = append(sealed, env.Tag...)
+	return aead.Open(nil, env.Nonce, sealed, aad)
+}
+
+// newEnvelopeGCM 使用数据密钥创建 AES-GCM 实例。
+//
+// 参数：
+//   - key: AES-256 数据密钥。
+//
+// 返回：
+//   - cipher.AEAD: AES-GCM 实例。
+//   - error: 密钥长度非法时返回错误。
+func newEnvelopeGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if nil != err {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
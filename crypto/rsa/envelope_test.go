@@ -0,0 +1,132 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package rsa
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	kitenvelope "github.com/fsyyft-go/kit/crypto/envelope"
+)
+
+// TestSealEnvelope 验证 RSA 混合加密信封的往返以及篡改检测。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestSealEnvelope(t *testing.T) {
+	privateKey, _, _ := generateTestKeyPair(t, 2048)
+	otherKey, _, _ := generateTestKeyPair(t, 2048)
+	publicKey := &privateKey.PublicKey
+
+	plain := make([]byte, 64<<10)
+	_, err := rand.Read(plain)
+	require.NoError(t, err)
+
+	env, err := SealEnvelope(publicKey, "rsa-2025", plain)
+	require.NoError(t, err)
+	assert.Equal(t, kitenvelope.AlgorithmRSAOAEP256A256GCM, env.Algorithm)
+	assert.Equal(t, "rsa-2025", env.KeyID)
+
+	text, err := env.MarshalText()
+	require.NoError(t, err)
+	parsed, err := kitenvelope.Parse(string(text))
+	require.NoError(t, err)
+	decrypted, err := OpenEnvelope(privateKey, parsed)
+	require.NoError(t, err)
+	assert.True(t, bytes.Equal(plain, decrypted))
+
+	empty, err := SealEnvelope(publicKey, "", nil)
+	require.NoError(t, err)
+	decrypted, err = OpenEnvelope(privateKey, empty)
+	require.NoError(t, err)
+	assert.Empty(t, decrypted)
+
+	tests := []struct {
+		name        string
+		description string
+		privKey     *rsa.PrivateKey
+		mutate      func(env *kitenvelope.Envelope)
+		wantErrIs   error
+	}{
+		{
+			name:        "error/key-id-tampered",
+			description: "验证 key-id 被替换时封装密钥解密失败。",
+			privKey:     privateKey,
+			mutate:      func(env *kitenvelope.Envelope) { env.KeyID = "other" },
+		},
+		{
+			name:        "error/ciphertext-tampered",
+			description: "验证数据密文被篡改时 GCM 认证失败。",
+			privKey:     privateKey,
+			mutate:      func(env *kitenvelope.Envelope) { env.Ciphertext[len(env.Ciphertext)-1] ^= 0xff },
+		},
+		{
+			name:        "error/wrong-key",
+			description: "验证使用其他私钥时解密失败。",
+			privKey:     otherKey,
+		},
+		{
+			name:        "error/algorithm",
+			description: "验证非 RSA 算法返回 ErrUnsupportedAlgorithm。",
+			privKey:     privateKey,
+			mutate:      func(env *kitenvelope.Envelope) { env.Algorithm = kitenvelope.AlgorithmA256GCM },
+			wantErrIs:   kitenvelope.ErrUnsupportedAlgorithm,
+		},
+		{
+			name:        "error/short-ciphertext",
+			description: "验证缺少封装密钥长度字段时返回 ErrMalformed。",
+			privKey:     privateKey,
+			mutate:      func(env *kitenvelope.Envelope) { env.Ciphertext = env.Ciphertext[:1] },
+			wantErrIs:   kitenvelope.ErrMalformed,
+		},
+		{
+			name:        "error/wrapped-key-length",
+			description: "验证封装密钥长度超过实际数据时返回 ErrMalformed。",
+			privKey:     privateKey,
+			mutate: func(env *kitenvelope.Envelope) {
+				binary.BigEndian.PutUint16(env.Ciphertext, uint16(len(env.Ciphertext)))
+			},
+			wantErrIs: kitenvelope.ErrMalformed,
+		},
+		{
+			name:        "error/nonce-length",
+			description: "验证 nonce 长度不匹配时返回 ErrMalformed。",
+			privKey:     privateKey,
+			mutate:      func(env *kitenvelope.Envelope) { env.Nonce = nil },
+			wantErrIs:   kitenvelope.ErrMalformed,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			env, err := SealEnvelope(publicKey, "rsa-2025", []byte("hello"))
+			require.NoError(t, err)
+			if nil != tt.mutate {
+				tt.mutate(env)
+			}
+
+			decrypted, err := OpenEnvelope(tt.privKey, env)
+			require.Error(t, err)
+			assert.Nil(t, decrypted)
+			if nil != tt.wantErrIs {
+				assert.ErrorIs(t, err, tt.wantErrIs)
+			}
+		})
+	}
+
+	_, err = OpenEnvelope(privateKey, nil)
+	assert.ErrorIs(t, err, kitenvelope.ErrMalformed)
+	_, err = SealEnvelope(publicKey, string(make([]byte, 256)), nil)
+	assert.ErrorIs(t, err, kitenvelope.ErrFieldTooLong)
+}
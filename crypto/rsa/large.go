@@ -0,0 +1,532 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package rsa
+
+// 本文件提供超出单次 RSA 加密长度限制时的处理方式：明文过长的类型化错误、
+// 基于 RSA-OAEP 的分块加密（含流式 Writer/Reader），以及 RSA-OAEP + AES-256-GCM 的混合加密。
+
+import (
+	"bytes"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+
+	kitaes "github.com/fsyyft-go/kit/crypto/aes"
+	kitenvelope "github.com/fsyyft-go/kit/crypto/envelope"
+)
+
+const (
+	// pkcs1v15Overhead 是 PKCS#1 v1.5 encryption 填充占用的最小字节数。
+	pkcs1v15Overhead = 11
+
+	// chunkFlagMore 表示分块密文后面还有后续分块。
+	chunkFlagMore byte = 0
+	// chunkFlagFinal 表示当前分块是最后一个分块。
+	chunkFlagFinal byte = 1
+
+	// largeVersion 是早期 EncryptLarge 输出格式的版本号，与信封魔数的首字节不同，DecryptLarge 据此区分格式。
+	largeVersion byte = 1
+	// largeKeySize 是混合加密使用的 AES-256 数据密钥长度。
+	largeKeySize = 32
+	// largeNonceSize 是早期 EncryptLarge 格式使用的 GCM nonce 长度。
+	largeNonceSize = 12
+)
+
+var (
+	// ErrChunkTruncated 表示分块密文在最后一个分块之前结束。
+	//
+	// 分块密文被截断或最后一个分块丢失时返回该错误。调用方可以使用 errors.Is 判断该错误。
+	ErrChunkTruncated = errors.New("分块密文不完整。")
+
+	// ErrChunkMalformed 表示分块密文结构非法。
+	//
+	// 分块标志非法、最后一个分块之后仍有数据，或单个分块长度与密钥长度不一致时返回该错误。
+	// 调用方可以使用 errors.Is 判断该错误。
+	ErrChunkMalformed = errors.New("分块密文格式不正确。")
+
+	// ErrLargeCiphertext 表示 DecryptLarge 的输入不是合法的混合加密密文。
+	//
+	// 既不是信封也不是早期格式、信封算法不是 RSA-OAEP-256+A256GCM，或长度字段与实际数据不一致时返回该错误。
+	// 调用方可以使用 errors.Is 判断该错误。
+	ErrLargeCiphertext = errors.New("混合加密密文格式不正确。")
+)
+
+type (
+	// MessageTooLongError 表示明文长度超过单次 RSA 加密允许的最大长度。
+	//
+	// EncryptPublicKey、EncryptPublicKeyOAEPWithHash 及其 PEM 包装函数在明文过长时返回该错误。
+	// 它可以通过 errors.As 取得实际长度和最大长度，也可以通过 errors.Is 与标准库
+	// rsa.ErrMessageTooLong 比较。需要加密更长的数据时，请使用 EncryptLarge 或分块加密函数。
+	MessageTooLongError struct {
+		// Length 是实际明文长度。
+		Length int
+		// Max 是当前密钥和填充方式允许的最大明文长度。
+		Max int
+	}
+
+	// chunkWriter 是 NewOAEPEncryptWriter 返回的流式分块加密器。
+	chunkWriter struct {
+		w      io.Writer
+		pubKey *rsa.PublicKey
+		hash   hash.Hash
+		label  []byte
+		size   int
+		buf    []byte
+		index  uint64
+		closed bool
+		err    error
+	}
+
+	// chunkReader 是 NewOAEPDecryptReader 返回的流式分块解密器。
+	chunkReader struct {
+		r       io.Reader
+		privKey *rsa.PrivateKey
+		hash    hash.Hash
+		label   []byte
+		block   []byte
+		buf     []byte
+		index   uint64
+		final   bool
+		err     error
+	}
+)
+
+// Error 返回明文过长的错误描述。
+//
+// 返回：
+//   - string: 包含实际长度和最大长度的错误描述。
+func (e *MessageTooLongError) Error() string {
+	return fmt.Sprintf("明文长度 %d 超过当前密钥允许的最大长度 %d。", e.Length, e.Max)
+}
+
+// Unwrap 返回标准库的 rsa.ErrMessageTooLong，使 errors.Is 判断保持兼容。
+//
+// 返回：
+//   - error: 标准库 rsa.ErrMessageTooLong。
+func (e *MessageTooLongError) Unwrap() error {
+	return rsa.ErrMessageTooLong
+}
+
+// MaxPlaintextOAEP 返回指定公钥和 hash 下单次 RSA-OAEP 加密允许的最大明文长度。
+//
+// 参数：
+//   - pubKey: RSA 公钥对象，为 nil 时返回 0。
+//   - hash: OAEP 使用的哈希函数，为 nil 时返回 0。
+//
+// 返回：
+//   - int: 最大明文字节数；密钥过小无法容纳 OAEP 填充时返回 0。
+func MaxPlaintextOAEP(pubKey *rsa.PublicKey, hash hash.Hash) int {
+	if nil == pubKey || nil == pubKey.N || nil == hash {
+		return 0
+	}
+	if max := pubKey.Size() - 2*hash.Size() - 2; max > 0 {
+		return max
+	}
+	return 0
+}
+
+// MaxPlaintextPKCS1v15 返回指定公钥下单次 PKCS#1 v1.5 加密允许的最大明文长度。
+//
+// 参数：
+//   - pubKey: RSA 公钥对象，为 nil 时返回 0。
+//
+// 返回：
+//   - int: 最大明文字节数；密钥过小无法容纳填充时返回 0。
+func MaxPlaintextPKCS1v15(pubKey *rsa.PublicKey) int {
+	if nil == pubKey || nil == pubKey.N {
+		return 0
+	}
+	if max := pubKey.Size() - pkcs1v15Overhead; max > 0 {
+		return max
+	}
+	return 0
+}
+
+// checkMessageLength 在明文超过最大长度时返回 *MessageTooLongError。
+//
+// pubKey 为 nil 或模数缺失时不做检查，由底层加密函数返回原有错误。
+//
+// 参数：
+//   - pubKey: RSA 公钥对象。
+//   - length: 明文长度。
+//   - overhead: 填充方式占用的字节数。
+//
+// 返回：
+//   - error: 明文过长时返回 *MessageTooLongError，否则返回 nil。
+func checkMessageLength(pubKey *rsa.PublicKey, length, overhead int) error {
+	if nil == pubKey || nil == pubKey.N {
+		return nil
+	}
+	max := pubKey.Size() - overhead
+	if max < 0 {
+		max = 0
+	}
+	if length > max {
+		return &MessageTooLongError{Length: length, Max: max}
+	}
+	return nil
+}
+
+// chunkLabel 生成分块加密使用的 OAEP label，将调用方 label 与分块序号绑定。
+//
+// 参数：
+//   - label: 调用方提供的 OAEP label，可以为 nil。
+//   - index: 分块序号，从 0 开始。
+//
+// 返回：
+//   - []byte: label || 8 字节大端序分块序号。
+func chunkLabel(label []byte, index uint64) []byte {
+	result := make([]byte, len(label)+8)
+	copy(result, label)
+	binary.BigEndian.PutUint64(result[len(label):], index)
+	return result
+}
+
+// NewOAEPEncryptWriter 创建一个流式 RSA-OAEP 分块加密器，写入的明文按块加密后写入 w。
+//
+// 每个分块的明文为 1 字节分块标志加上至多 MaxPlaintextOAEP-1 字节数据，加密后恰好为
+// 公钥模数字节数，因此密文流由定长分块组成。每个分块的 OAEP label 为调用方 label
+// 拼接 8 字节大端序分块序号，分块被重排、删除或替换都会导致解密失败；最后一个分块
+// 带有结束标志，用于发现尾部截断。必须调用 Close 写出最后一个分块。
+//
+// 参数：
+//   - w: 密文输出目标。
+//   - pubKey: RSA 公钥对象，必须非 nil。
+//   - hash: OAEP 使用的哈希函数，不能为 nil；加密和解密必须使用相同算法。
+//   - label: OAEP 使用的标签，可以为 nil；加密和解密必须逐字节一致。
+//
+// 返回：
+//   - io.WriteCloser: 流式加密器，非并发安全。
+//   - error: hash 为 nil、公钥无效或密钥过小无法分块时返回错误。
+func NewOAEPEncryptWriter(w io.Writer, pubKey *rsa.PublicKey, hash hash.Hash, label []byte) (io.WriteCloser, error) {
+	if nil == hash {
+		return nil, ErrNilHash
+	}
+	if nil == pubKey || nil == pubKey.N {
+		return nil, errors.New("公钥不能为空。")
+	}
+	size := MaxPlaintextOAEP(pubKey, hash) - 1
+	if size <= 0 {
+		return nil, &MessageTooLongError{Length: 1, Max: MaxPlaintextOAEP(pubKey, hash)}
+	}
+	return &chunkWriter{
+		w:      w,
+		pubKey: pubKey,
+		hash:   hash,
+		label:  label,
+		size:   size,
+		buf:    make([]byte, 1, size+1),
+	}, nil
+}
+
+// Write 缓存明文并将已满的分块加密写出。
+//
+// 参数：
+//   - p: 待加密的明文数据。
+//
+// 返回：
+//   - int: 已接收的明文字节数。
+//   - error: 加密器已关闭、加密失败或写出失败时返回错误。
+func (c *chunkWriter) Write(p []byte) (int, error) {
+	if c.closed {
+		return 0, errors.New("分块加密器已关闭。")
+	}
+	if nil != c.err {
+		return 0, c.err
+	}
+
+	n := 0
+	for len(p) > 0 {
+		m := c.size + 1 - len(c.buf)
+		if m > len(p) {
+			m = len(p)
+		}
+		c.buf = append(c.buf, p[:m]...)
+		p = p[m:]
+		n += m
+
+		// 仅在还有后续数据时写出已满的分块，最后一个分块留给 Close 带上结束标志。
+		if len(c.buf) == c.size+1 && len(p) > 0 {
+			if c.err = c.flush(chunkFlagMore); nil != c.err {
+				return n, c.err
+			}
+		}
+	}
+	return n, nil
+}
+
+// Close 写出带结束标志的最后一个分块。
+//
+// 返回：
+//   - error: 加密失败或写出失败时返回错误；重复调用返回 nil。
+func (c *chunkWriter) Close() error {
+	if c.closed {
+		return nil
+	}
+	c.closed = true
+	if nil != c.err {
+		return c.err
+	}
+
+	// 缓冲区已满时，先按普通分块写出，再写出一个只含结束标志的空分块，
+	// 这样解密端可以在读到已满分块时不必预读下一个分块。
+	if len(c.buf) == c.size+1 {
+		if c.err = c.flush(chunkFlagMore); nil != c.err {
+			return c.err
+		}
+	}
+	c.err = c.flush(chunkFlagFinal)
+	return c.err
+}
+
+// flush 使用指定分块标志加密当前缓冲区并写出。
+//
+// 参数：
+//   - flag: 分块标志。
+//
+// 返回：
+//   - error: 加密失败或写出失败时返回错误。
+func (c *chunkWriter) flush(flag byte) error {
+	c.buf[0] = flag
+	block, err := EncryptPublicKeyOAEPWithHash(c.pubKey, c.buf, c.hash, chunkLabel(c.label, c.index))
+	if nil != err {
+		return err
+	}
+	if _, err = c.w.Write(block); nil != err {
+		return err
+	}
+	c.index++
+	c.buf = c.buf[:1]
+	return nil
+}
+
+// NewOAEPDecryptReader 创建一个流式 RSA-OAEP 分块解密器，从 r 读取 NewOAEPEncryptWriter 产生的密文。
+//
+// 解密器逐块读取私钥模数字节数的密文并校验分块序号和结束标志。密文在最后一个分块之前
+// 结束时返回 ErrChunkTruncated；最后一个分块之后仍有数据时返回 ErrChunkMalformed。
+//
+// 参数：
+//   - r: 密文输入来源。
+//   - privKey: RSA 私钥对象，必须非 nil。
+//   - hash: OAEP 使用的哈希函数，不能为 nil；加密和解密必须使用相同算法。
+//   - label: OAEP 使用的标签，可以为 nil；加密和解密必须逐字节一致。
+//
+// 返回：
+//   - io.Reader: 流式解密器，非并发安全。
+//   - error: hash 为 nil 或私钥无效时返回错误。
+func NewOAEPDecryptReader(r io.Reader, privKey *rsa.PrivateKey, hash hash.Hash, label []byte) (io.Reader, error) {
+	if nil == hash {
+		return nil, ErrNilHash
+	}
+	if nil == privKey || nil == privKey.N {
+		return nil, errors.New("私钥不能为空。")
+	}
+	return &chunkReader{
+		r:       r,
+		privKey: privKey,
+		hash:    hash,
+		label:   label,
+		block:   make([]byte, privKey.Size()),
+	}, nil
+}
+
+// Read 解密后续分块并返回明文。
+//
+// 参数：
+//   - p: 用于接收明文的缓冲区。
+//
+// 返回：
+//   - int: 写入 p 的明文字节数。
+//   - error: 全部明文读取完毕时返回 io.EOF；密文截断、格式非法或解密失败时返回错误。
+func (c *chunkReader) Read(p []byte) (int, error) {
+	for 0 == len(c.buf) {
+		if nil != c.err {
+			return 0, c.err
+		}
+		c.err = c.next()
+	}
+	n := copy(p, c.buf)
+	c.buf = c.buf[n:]
+	return n, nil
+}
+
+// next 读取并解密下一个分块。
+//
+// 返回：
+//   - error: 全部分块读取完毕时返回 io.EOF；其余情况下返回读取或解密错误。
+func (c *chunkReader) next() error {
+	if c.final {
+		// 最后一个分块之后不允许再有数据。
+		if n, err := io.ReadFull(c.r, c.block[:1]); 0 != n {
+			return ErrChunkMalformed
+		} else if io.EOF != err {
+			return err
+		}
+		return io.EOF
+	}
+
+	if _, err := io.ReadFull(c.r, c.block); io.EOF == err || io.ErrUnexpectedEOF == err {
+		return ErrChunkTruncated
+	} else if nil != err {
+		return err
+	}
+
+	plain, err := DecryptPrivateKeyOAEPWithHash(c.privKey, c.block, c.hash, chunkLabel(c.label, c.index))
+	if nil != err {
+		return err
+	}
+	if 0 == len(plain) || (chunkFlagMore != plain[0] && chunkFlagFinal != plain[0]) {
+		return ErrChunkMalformed
+	}
+	c.index++
+	c.final = chunkFlagFinal == plain[0]
+	c.buf = plain[1:]
+	return nil
+}
+
+// EncryptPublicKeyOAEPChunked 使用 RSA 公钥按 RSA-OAEP 对任意长度数据进行分块加密。
+//
+// 输出格式与 NewOAEPEncryptWriter 一致，由若干个定长分块组成，可使用
+// DecryptPrivateKeyOAEPChunked 或 NewOAEPDecryptReader 解密。由于每个分块都需要一次
+// RSA 运算，大数据量时建议使用 EncryptLarge。
+//
+// 参数：
+//   - pubKey: RSA 公钥对象，必须非 nil。
+//   - dataClear: 需要加密的明文数据，长度不限。
+//   - hash: OAEP 使用的哈希函数，不能为 nil；加密和解密必须使用相同算法。
+//   - label: OAEP 使用的标签，可以为 nil；加密和解密必须逐字节一致。
+//
+// 返回：
+//   - []byte: 分块加密后的密文数据。
+//   - error: hash 为 nil、公钥无效或加密失败时返回错误。
+func EncryptPublicKeyOAEPChunked(pubKey *rsa.PublicKey, dataClear []byte, hash hash.Hash, label []byte) ([]byte, error) {
+	var dataCipher []byte
+	var err error
+
+	buf := &bytes.Buffer{}
+	if w, errWriter := NewOAEPEncryptWriter(buf, pubKey, hash, label); nil != errWriter {
+		err = errWriter
+	} else if _, errWrite := w.Write(dataClear); nil != errWrite {
+		err = errWrite
+	} else if errClose := w.Close(); nil != errClose {
+		err = errClose
+	} else {
+		dataCipher = buf.Bytes()
+	}
+
+	return dataCipher, err
+}
+
+// DecryptPrivateKeyOAEPChunked 使用 RSA 私钥解密 EncryptPublicKeyOAEPChunked 产生的分块密文。
+//
+// 参数：
+//   - privKey: RSA 私钥对象，必须非 nil。
+//   - dataCipher: 分块加密后的密文数据。
+//   - hash: OAEP 使用的哈希函数，不能为 nil；加密和解密必须使用相同算法。
+//   - label: OAEP 使用的标签，可以为 nil；加密和解密必须逐字节一致。
+//
+// 返回：
+//   - []byte: 解密后的明文数据。
+//   - error: hash 为 nil、私钥无效、密文截断（ErrChunkTruncated）、格式非法（ErrChunkMalformed）或解密失败时返回错误。
+func DecryptPrivateKeyOAEPChunked(privKey *rsa.PrivateKey, dataCipher []byte, hash hash.Hash, label []byte) ([]byte, error) {
+	var dataClear []byte
+	var err error
+
+	if r, errReader := NewOAEPDecryptReader(bytes.NewReader(dataCipher), privKey, hash, label); nil != errReader {
+		err = errReader
+	} else if plain, errRead := io.ReadAll(r); nil != errRead {
+		err = errRead
+	} else {
+		dataClear = plain
+	}
+
+	return dataClear, err
+}
+
+// EncryptLarge 使用 RSA 公钥对任意长度数据进行混合加密。
+//
+// 本函数等同于以空 keyID 调用 SealEnvelope 并序列化信封：随机生成 AES-256 数据密钥，使用 AES-GCM
+// 加密数据，再使用 SHA-256 RSA-OAEP 加密数据密钥。输出为 crypto/envelope 定义的二进制信封，
+// 也可以使用 kitenvelope.Unmarshal 与 OpenEnvelope 解密。需要 keyID 或文本格式时直接使用 SealEnvelope。
+//
+// GCM 认证标签保证数据未被篡改；封装密钥或信封头部被篡改时 RSA-OAEP 解密失败。
+//
+// 参数：
+//   - pubKey: RSA 公钥对象，必须非 nil，模数至少需要容纳 32 字节的 SHA-256 OAEP 明文。
+//   - dataClear: 需要加密的明文数据，长度不限。
+//
+// 返回：
+//   - []byte: 二进制格式的密文信封。
+//   - error: 公钥无效、随机数生成失败或加密失败时返回错误。
+func EncryptLarge(pubKey *rsa.PublicKey, dataClear []byte) ([]byte, error) {
+	env, err := SealEnvelope(pubKey, "", dataClear)
+	if nil != err {
+		return nil, err
+	}
+	return env.Marshal()
+}
+
+// DecryptLarge 使用 RSA 私钥解密 EncryptLarge 产生的混合加密密文。
+//
+// 除二进制信封外，仍可解密早期版本以 1 字节版本号开头的格式：
+//
+//	1 字节版本号 || 2 字节大端序封装密钥长度 || 封装密钥 || 12 字节 nonce || ciphertextAndTag
+//
+// 参数：
+//   - privKey: RSA 私钥对象，必须非 nil。
+//   - dataCipher: EncryptLarge 产生的密文数据。
+//
+// 返回：
+//   - []byte: 解密后的明文数据。
+//   - error: 密文格式非法（ErrLargeCiphertext）、数据密钥解密失败或 GCM 认证失败时返回错误。
+func DecryptLarge(privKey *rsa.PrivateKey, dataCipher []byte) ([]byte, error) {
+	if 0 < len(dataCipher) && largeVersion == dataCipher[0] {
+		return decryptLargeLegacy(privKey, dataCipher)
+	}
+
+	env, err := kitenvelope.Unmarshal(dataCipher)
+	if nil != err {
+		return nil, fmt.Errorf("%w: %w", ErrLargeCiphertext, err)
+	}
+	dataClear, err := OpenEnvelope(privKey, env)
+	if errors.Is(err, kitenvelope.ErrMalformed) || errors.Is(err, kitenvelope.ErrUnsupportedAlgorithm) {
+		return nil, fmt.Errorf("%w: %w", ErrLargeCiphertext, err)
+	}
+	return dataClear, err
+}
+
+// decryptLargeLegacy 解密早期版本 EncryptLarge 以 1 字节版本号开头的密文。
+//
+// 参数：
+//   - privKey: RSA 私钥对象，必须非 nil。
+//   - dataCipher: 以 largeVersion 开头的密文数据。
+//
+// 返回：
+//   - []byte: 解密后的明文数据。
+//   - error: 密文格式非法（ErrLargeCiphertext）、数据密钥解密失败或 GCM 认证失败时返回错误。
+func decryptLargeLegacy(privKey *rsa.PrivateKey, dataCipher []byte) ([]byte, error) {
+	var dataClear []byte
+	var err error
+
+	if len(dataCipher) < 3 {
+		err = ErrLargeCiphertext
+	} else if n := int(binary.BigEndian.Uint16(dataCipher[1:3])); len(dataCipher) < 3+n+largeNonceSize {
+		err = ErrLargeCiphertext
+	} else if key, errUnwrap := DecryptPrivateKeyOAEPWithHash(privKey, dataCipher[3:3+n], sha256.New(), nil); nil != errUnwrap {
+		err = errUnwrap
+	} else if len(key) != largeKeySize {
+		err = ErrLargeCiphertext
+	} else if _, plain, errOpen := kitaes.DecryptGCMNonceLength(key, largeNonceSize, dataCipher[3+n:]); nil != errOpen {
+		err = errOpen
+	} else {
+		dataClear = plain
+	}
+
+	return dataClear, err
+}
@@ -0,0 +1,407 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package rsa
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	kitaes "github.com/fsyyft-go/kit/crypto/aes"
+	kitenvelope "github.com/fsyyft-go/kit/crypto/envelope"
+)
+
+// TestMessageTooLongError 验证单次加密明文过长时返回可识别的类型化错误。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestMessageTooLongError(t *testing.T) {
+	privateKey, _, publicKeyBytes := generateTestKeyPair(t, 1024)
+	publicKey := &privateKey.PublicKey
+	oaepMax := MaxPlaintextOAEP(publicKey, sha256.New())
+	pkcsMax := MaxPlaintextPKCS1v15(publicKey)
+	require.Equal(t, 128-2*sha256.Size-2, oaepMax)
+	require.Equal(t, 128-11, pkcsMax)
+
+	tests := []struct {
+		name        string
+		description string
+		encrypt     func(data []byte) ([]byte, error)
+		max         int
+	}{
+		{
+			name:        "error/oaep",
+			description: "验证 OAEP 结构体入口返回 *MessageTooLongError。",
+			encrypt:     func(data []byte) ([]byte, error) { return EncryptPublicKeyOAEP(publicKey, data) },
+			max:         oaepMax,
+		},
+		{
+			name:        "error/oaep-pem",
+			description: "验证 OAEP PEM 入口透传 *MessageTooLongError。",
+			encrypt:     func(data []byte) ([]byte, error) { return EncryptPubKeyOAEP(publicKeyBytes, data) },
+			max:         oaepMax,
+		},
+		{
+			name:        "error/pkcs1v15",
+			description: "验证 PKCS#1 v1.5 结构体入口返回 *MessageTooLongError。",
+			encrypt:     func(data []byte) ([]byte, error) { return EncryptPublicKey(publicKey, data) },
+			max:         pkcsMax,
+		},
+		{
+			name:        "error/pkcs1v15-pem",
+			description: "验证 PKCS#1 v1.5 PEM 入口透传 *MessageTooLongError。",
+			encrypt:     func(data []byte) ([]byte, error) { return EncryptPubKey(publicKeyBytes, data) },
+			max:         pkcsMax,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			_, err := tt.encrypt(make([]byte, tt.max))
+			require.NoError(t, err, "最大长度的明文应该加密成功。")
+
+			_, err = tt.encrypt(make([]byte, tt.max+1))
+			var tooLong *MessageTooLongError
+			require.True(t, errors.As(err, &tooLong), "明文过长应返回 *MessageTooLongError。")
+			assert.Equal(t, tt.max+1, tooLong.Length)
+			assert.Equal(t, tt.max, tooLong.Max)
+			assert.ErrorIs(t, err, rsa.ErrMessageTooLong, "应兼容标准库 rsa.ErrMessageTooLong。")
+		})
+	}
+
+	assert.Zero(t, MaxPlaintextOAEP(nil, sha256.New()))
+	assert.Zero(t, MaxPlaintextOAEP(publicKey, nil))
+	assert.Zero(t, MaxPlaintextPKCS1v15(nil))
+}
+
+// TestOAEPChunked 验证分块加密支持任意长度明文，并在分块边界处正确往返。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestOAEPChunked(t *testing.T) {
+	privateKey, _, _ := generateTestKeyPair(t, 1024)
+	publicKey := &privateKey.PublicKey
+	size := MaxPlaintextOAEP(publicKey, sha256.New()) - 1
+
+	tests := []struct {
+		name        string
+		description string
+		length      int
+		label       []byte
+		wantBlocks  int
+	}{
+		{name: "boundary/empty", description: "验证空明文产生一个结束分块。", length: 0, wantBlocks: 1},
+		{name: "success/short", description: "验证短明文产生一个分块。", length: 10, wantBlocks: 1},
+		{name: "boundary/exact-chunk", description: "验证恰好一个分块的明文额外产生一个结束分块。", length: size, wantBlocks: 2},
+		{name: "boundary/chunk-plus-one", description: "验证超过一个分块一字节时产生两个分块。", length: size + 1, wantBlocks: 2},
+		{name: "success/large", description: "验证远超密钥长度的明文可以往返。", length: 10*size + 7, label: []byte("ctx"), wantBlocks: 11},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			plain := make([]byte, tt.length)
+			_, err := rand.Read(plain)
+			require.NoError(t, err)
+
+			cipherText, err := EncryptPublicKeyOAEPChunked(publicKey, plain, sha256.New(), tt.label)
+			require.NoError(t, err)
+			assert.Len(t, cipherText, tt.wantBlocks*publicKey.Size())
+
+			decrypted, err := DecryptPrivateKeyOAEPChunked(privateKey, cipherText, sha256.New(), tt.label)
+			require.NoError(t, err)
+			assert.Equal(t, len(plain), len(decrypted))
+			assert.True(t, bytes.Equal(plain, decrypted))
+		})
+	}
+}
+
+// TestOAEPChunked_Tampering 验证分块被截断、重排、追加或使用错误参数时解密失败。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestOAEPChunked_Tampering(t *testing.T) {
+	privateKey, _, _ := generateTestKeyPair(t, 1024)
+	publicKey := &privateKey.PublicKey
+	k := publicKey.Size()
+	plain := bytes.Repeat([]byte("0123456789"), 30)
+
+	cipherText, err := EncryptPublicKeyOAEPChunked(publicKey, plain, sha256.New(), nil)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, len(cipherText)/k, 3)
+
+	tests := []struct {
+		name        string
+		description string
+		tamper      func(data []byte) []byte
+		label       []byte
+		wantErrIs   error
+	}{
+		{
+			name:        "error/drop-final-chunk",
+			description: "验证删除最后一个分块返回 ErrChunkTruncated。",
+			tamper:      func(data []byte) []byte { return data[:len(data)-k] },
+			wantErrIs:   ErrChunkTruncated,
+		},
+		{
+			name:        "error/partial-chunk",
+			description: "验证分块不完整返回 ErrChunkTruncated。",
+			tamper:      func(data []byte) []byte { return data[:len(data)-1] },
+			wantErrIs:   ErrChunkTruncated,
+		},
+		{
+			name:        "error/trailing-data",
+			description: "验证最后一个分块之后仍有数据返回 ErrChunkMalformed。",
+			tamper:      func(data []byte) []byte { return append(data, 0) },
+			wantErrIs:   ErrChunkMalformed,
+		},
+		{
+			name:        "error/reordered",
+			description: "验证分块重排后解密失败。",
+			tamper: func(data []byte) []byte {
+				result := append([]byte{}, data[k:2*k]...)
+				result = append(result, data[:k]...)
+				return append(result, data[2*k:]...)
+			},
+		},
+		{
+			name:        "error/wrong-label",
+			description: "验证 label 不一致时解密失败。",
+			tamper:      func(data []byte) []byte { return data },
+			label:       []byte("other"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			data := tt.tamper(append([]byte{}, cipherText...))
+			_, err := DecryptPrivateKeyOAEPChunked(privateKey, data, sha256.New(), tt.label)
+			require.Error(t, err)
+			if nil != tt.wantErrIs {
+				assert.ErrorIs(t, err, tt.wantErrIs)
+			}
+		})
+	}
+
+	_, err = EncryptPublicKeyOAEPChunked(publicKey, plain, nil, nil)
+	assert.ErrorIs(t, err, ErrNilHash)
+	_, err = DecryptPrivateKeyOAEPChunked(privateKey, cipherText, nil, nil)
+	assert.ErrorIs(t, err, ErrNilHash)
+	_, err = EncryptPublicKeyOAEPChunked(nil, plain, sha256.New(), nil)
+	assert.Error(t, err)
+	_, err = DecryptPrivateKeyOAEPChunked(nil, cipherText, sha256.New(), nil)
+	assert.Error(t, err)
+}
+
+// TestOAEPStream 验证流式 Writer/Reader 以任意写入和读取粒度往返，并与一次性分块函数兼容。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestOAEPStream(t *testing.T) {
+	privateKey, _, _ := generateTestKeyPair(t, 1024)
+	publicKey := &privateKey.PublicKey
+	plain := bytes.Repeat([]byte("stream-data-"), 100)
+
+	buf := &bytes.Buffer{}
+	w, err := NewOAEPEncryptWriter(buf, publicKey, sha1.New(), []byte("stream"))
+	require.NoError(t, err)
+	for i := 0; i < len(plain); i += 7 {
+		end := i + 7
+		if end > len(plain) {
+			end = len(plain)
+		}
+		n, errWrite := w.Write(plain[i:end])
+		require.NoError(t, errWrite)
+		require.Equal(t, end-i, n)
+	}
+	require.NoError(t, w.Close())
+	require.NoError(t, w.Close(), "重复关闭应返回 nil。")
+	_, err = w.Write([]byte("x"))
+	assert.Error(t, err, "关闭后写入应返回错误。")
+
+	decrypted, err := DecryptPrivateKeyOAEPChunked(privateKey, buf.Bytes(), sha1.New(), []byte("stream"))
+	require.NoError(t, err)
+	assert.Equal(t, plain, decrypted)
+
+	r, err := NewOAEPDecryptReader(bytes.NewReader(buf.Bytes()), privateKey, sha1.New(), []byte("stream"))
+	require.NoError(t, err)
+	got := make([]byte, 0, len(plain))
+	small := make([]byte, 5)
+	for {
+		n, errRead := r.Read(small)
+		got = append(got, small[:n]...)
+		if io.EOF == errRead {
+			break
+		}
+		require.NoError(t, errRead)
+	}
+	assert.Equal(t, plain, got)
+}
+
+// TestEncryptLarge 验证混合加密可以处理大数据并发现篡改。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestEncryptLarge(t *testing.T) {
+	privateKey, _, _ := generateTestKeyPair(t, 2048)
+	otherKey, _, _ := generateTestKeyPair(t, 2048)
+	publicKey := &privateKey.PublicKey
+
+	plain := make([]byte, 1<<20)
+	_, err := rand.Read(plain)
+	require.NoError(t, err)
+
+	cipherText, err := EncryptLarge(publicKey, plain)
+	require.NoError(t, err)
+
+	decrypted, err := DecryptLarge(privateKey, cipherText)
+	require.NoError(t, err)
+	assert.True(t, bytes.Equal(plain, decrypted))
+
+	// 输出为信封，可以使用 OpenEnvelope 解密。
+	env, err := kitenvelope.Unmarshal(cipherText)
+	require.NoError(t, err)
+	assert.Equal(t, kitenvelope.AlgorithmRSAOAEP256A256GCM, env.Algorithm)
+	decrypted, err = OpenEnvelope(privateKey, env)
+	require.NoError(t, err)
+	assert.True(t, bytes.Equal(plain, decrypted))
+
+	// 早期版本的格式仍可解密。
+	legacy := encryptLargeLegacy(t, publicKey, []byte("legacy"))
+	decrypted, err = DecryptLarge(privateKey, legacy)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("legacy"), decrypted)
+
+	empty, err := EncryptLarge(publicKey, nil)
+	require.NoError(t, err)
+	decrypted, err = DecryptLarge(privateKey, empty)
+	require.NoError(t, err)
+	assert.Empty(t, decrypted)
+
+	tests := []struct {
+		name        string
+		description string
+		privKey     *rsa.PrivateKey
+		data        func() []byte
+		wantErrIs   error
+	}{
+		{
+			name:        "error/short",
+			description: "验证过短的输入返回 ErrLargeCiphertext。",
+			privKey:     privateKey,
+			data:        func() []byte { return []byte{largeVersion, 0} },
+			wantErrIs:   ErrLargeCiphertext,
+		},
+		{
+			name:        "error/version",
+			description: "验证不支持的版本号返回 ErrLargeCiphertext。",
+			privKey:     privateKey,
+			data: func() []byte {
+				data := append([]byte{}, cipherText...)
+				data[0] = 9
+				return data
+			},
+			wantErrIs: ErrLargeCiphertext,
+		},
+		{
+			name:        "error/legacy-truncated",
+			description: "验证早期格式的长度字段与数据不一致时返回 ErrLargeCiphertext。",
+			privKey:     privateKey,
+			data:        func() []byte { return legacy[:3+publicKey.Size()] },
+			wantErrIs:   ErrLargeCiphertext,
+		},
+		{
+			name:        "error/algorithm",
+			description: "验证其它算法的信封返回 ErrLargeCiphertext。",
+			privKey:     privateKey,
+			data: func() []byte {
+				other := &kitenvelope.Envelope{Algorithm: kitenvelope.AlgorithmA256GCM, Ciphertext: []byte("x")}
+				data, err := other.Marshal()
+				require.NoError(t, err)
+				return data
+			},
+			wantErrIs: ErrLargeCiphertext,
+		},
+		{
+			name:        "error/truncated",
+			description: "验证长度字段与数据不一致时返回 ErrLargeCiphertext。",
+			privKey:     privateKey,
+			data:        func() []byte { return cipherText[:3+publicKey.Size()] },
+			wantErrIs:   ErrLargeCiphertext,
+		},
+		{
+			name:        "error/tampered-body",
+			description: "验证篡改数据密文后 GCM 认证失败。",
+			privKey:     privateKey,
+			data: func() []byte {
+				data := append([]byte{}, cipherText...)
+				data[len(data)-1] ^= 0xff
+				return data
+			},
+		},
+		{
+			name:        "error/wrong-key",
+			description: "验证使用其他私钥无法解开数据密钥。",
+			privKey:     otherKey,
+			data:        func() []byte { return cipherText },
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			_, err := DecryptLarge(tt.privKey, tt.data())
+			require.Error(t, err)
+			if nil != tt.wantErrIs {
+				assert.ErrorIs(t, err, tt.wantErrIs)
+			}
+		})
+	}
+}
+
+// encryptLargeLegacy 按早期 EncryptLarge 的格式加密数据，用于验证 DecryptLarge 的兼容性。
+//
+// 参数：
+//   - t: 测试上下文，用于报告加密失败。
+//   - pubKey: RSA 公钥对象。
+//   - dataClear: 需要加密的明文数据。
+//
+// 返回：
+//   - []byte: 早期格式的密文数据。
+func encryptLargeLegacy(t *testing.T, pubKey *rsa.PublicKey, dataClear []byte) []byte {
+	t.Helper()
+
+	key := make([]byte, largeKeySize)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+	wrapped, err := EncryptPublicKeyOAEPWithHash(pubKey, key, sha256.New(), nil)
+	require.NoError(t, err)
+	sealed, err := kitaes.EncryptGCMNonceLength(key, largeNonceSize, dataClear)
+	require.NoError(t, err)
+
+	dataCipher := []byte{largeVersion}
+	dataCipher = binary.BigEndian.AppendUint16(dataCipher, uint16(len(wrapped)))
+	dataCipher = append(dataCipher, wrapped...)
+	return append(dataCipher, sealed...)
+}
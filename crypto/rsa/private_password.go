@@ -0,0 +1,252 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package rsa
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/des"
+	"crypto/pbkdf2"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"hash"
+
+	kitpadding "github.com/fsyyft-go/kit/crypto/padding"
+)
+
+const (
+	// BlockTypeEncryptedPrivateKey 是 PKCS#8 加密私钥的 PEM block 类型。
+	BlockTypeEncryptedPrivateKey = "ENCRYPTED PRIVATE KEY"
+	// BlockTypePKCS8PrivateKey 是未加密 PKCS#8 私钥的 PEM block 类型。
+	BlockTypePKCS8PrivateKey = "PRIVATE KEY"
+)
+
+var (
+	// ErrIncorrectPassphrase 表示私钥口令不正确。
+	//
+	// 解密后的填充或私钥结构非法时返回该错误，调用方可以使用 errors.Is 判断。
+	ErrIncorrectPassphrase = errors.New("私钥口令不正确。")
+
+	// ErrUnsupportedKeyEncryption 表示私钥的加密方式不受支持。
+	//
+	// 目前支持 PBES2 + PBKDF2（HMAC-SHA1/224/256/384/512）配合 AES-CBC 或 3DES-CBC，
+	// 以及传统 PEM 加密；scrypt 等其他方案返回该错误。调用方可以使用 errors.Is 判断该错误。
+	ErrUnsupportedKeyEncryption = errors.New("私钥加密方式不受支持。")
+)
+
+var (
+	// oidPBES2 是 PKCS#5 v2.0 PBES2 加密方案。
+	oidPBES2 = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 13}
+	// oidPBKDF2 是 PKCS#5 v2.0 PBKDF2 密钥派生函数。
+	oidPBKDF2 = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 12}
+
+	// pbkdf2PRFs 是 PBKDF2 支持的伪随机函数，键为 HMAC 算法 OID 的字符串形式。
+	pbkdf2PRFs = map[string]func() hash.Hash{
+		"1.2.840.113549.2.7":  sha1.New,
+		"1.2.840.113549.2.8":  sha256.New224,
+		"1.2.840.113549.2.9":  sha256.New,
+		"1.2.840.113549.2.10": sha512.New384,
+		"1.2.840.113549.2.11": sha512.New,
+	}
+
+	// pbes2Ciphers 是 PBES2 支持的分组密码，键为加密算法 OID 的字符串形式。
+	pbes2Ciphers = map[string]pbes2Cipher{
+		"2.16.840.1.101.3.4.1.2":  {keySize: 16, newBlock: aes.NewCipher},
+		"2.16.840.1.101.3.4.1.22": {keySize: 24, newBlock: aes.NewCipher},
+		"2.16.840.1.101.3.4.1.42": {keySize: 32, newBlock: aes.NewCipher},
+		"1.2.840.113549.3.7":      {keySize: 24, newBlock: des.NewTripleDESCipher},
+	}
+)
+
+type (
+	// pbes2Cipher 描述 PBES2 使用的 CBC 分组密码。
+	pbes2Cipher struct {
+		keySize  int                                    // 密钥字节数。
+		newBlock func(key []byte) (cipher.Block, error) // 分组密码构造函数。
+	}
+
+	// encryptedPrivateKeyInfo 是 RFC 5208 定义的 EncryptedPrivateKeyInfo。
+	encryptedPrivateKeyInfo struct {
+		Algorithm     pkix.AlgorithmIdentifier
+		EncryptedData []byte
+	}
+
+	// pbes2Params 是 RFC 8018 定义的 PBES2-params。
+	pbes2Params struct {
+		KeyDerivationFunc pkix.AlgorithmIdentifier
+		EncryptionScheme  pkix.AlgorithmIdentifier
+	}
+
+	// pbkdf2Params 是 RFC 8018 定义的 PBKDF2-params，PRF 缺省为 HMAC-SHA1。
+	pbkdf2Params struct {
+		Salt           []byte
+		IterationCount int
+		KeyLength      int                      `asn1:"optional"`
+		PRF            pkix.AlgorithmIdentifier `asn1:"optional"`
+	}
+)
+
+// ConvertPrivateKeyWithPassword 使用口令将加密的 PEM 私钥解析为 *rsa.PrivateKey。
+//
+// 支持以下 PEM 格式，便于直接使用以加密形式落盘的运维密钥：
+//   - 传统 PEM 加密的 RSA PRIVATE KEY（含 Proc-Type: 4,ENCRYPTED 与 DEK-Info 头），例如 openssl genrsa -aes256 的输出。
+//   - ENCRYPTED PRIVATE KEY，即 PKCS#8 + PBES2/PBKDF2，例如 openssl pkcs8 -topk8 -v2 aes-256-cbc 的输出。
+//   - 未加密的 RSA PRIVATE KEY 与 PRIVATE KEY，此时忽略口令。
+//
+// 传统 PEM 加密使用 MD5 派生密钥且没有完整性校验，仅为兼容既有密钥保留，新生成的密钥应使用 PKCS#8 + PBES2。
+//
+// 参数：
+//   - privateKey: PEM 编码的私钥字节切片。
+//   - passphrase: 私钥口令。
+//
+// 返回：
+//   - *rsa.PrivateKey: 解析成功后的 RSA 私钥对象。
+//   - error: PEM 解码失败、block 类型不受支持或私钥不是 RSA 私钥时返回 ErrDecodePrivateKey；
+//     口令错误时返回 ErrIncorrectPassphrase；加密方式不受支持时返回包装了 ErrUnsupportedKeyEncryption 的错误。
+func ConvertPrivateKeyWithPassword(privateKey, passphrase []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(privateKey)
+	if nil == block {
+		return nil, ErrDecodePrivateKey
+	}
+
+	switch block.Type {
+	case BlockTypePrivateKey:
+		der := block.Bytes
+		//nolint:staticcheck // 传统 PEM 加密已弃用，这里仅为兼容既有密钥。
+		if x509.IsEncryptedPEMBlock(block) {
+			var err error
+			//nolint:staticcheck // 传统 PEM 加密已弃用，这里仅为兼容既有密钥。
+			if der, err = x509.DecryptPEMBlock(block, passphrase); nil != err {
+				if errors.Is(err, x509.IncorrectPasswordError) {
+					return nil, ErrIncorrectPassphrase
+				}
+				return nil, fmt.Errorf("%w: %v", ErrUnsupportedKeyEncryption, err)
+			}
+			// 传统 PEM 加密没有完整性校验，填充恰好合法时由 PKCS#1 解析失败识别口令错误。
+			priv, err := x509.ParsePKCS1PrivateKey(der)
+			if nil != err {
+				return nil, ErrIncorrectPassphrase
+			}
+			return priv, nil
+		}
+		return x509.ParsePKCS1PrivateKey(der)
+	case BlockTypeEncryptedPrivateKey:
+		der, err := decryptPKCS8(block.Bytes, passphrase)
+		if nil != err {
+			return nil, err
+		}
+		// PBES2 同样没有完整性校验，解析失败视为口令错误。
+		key, err := x509.ParsePKCS8PrivateKey(der)
+		if nil != err {
+			return nil, ErrIncorrectPassphrase
+		}
+		return assertRSAPrivateKey(key)
+	case BlockTypePKCS8PrivateKey:
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if nil != err {
+			return nil, err
+		}
+		return assertRSAPrivateKey(key)
+	default:
+		return nil, ErrDecodePrivateKey
+	}
+}
+
+// assertRSAPrivateKey 确认 PKCS#8 私钥是 RSA 私钥。
+//
+// 参数：
+//   - key: x509.ParsePKCS8PrivateKey 返回的私钥。
+//
+// 返回：
+//   - *rsa.PrivateKey: RSA 私钥。
+//   - error: 不是 RSA 私钥时返回 ErrDecodePrivateKey。
+func assertRSAPrivateKey(key any) (*rsa.PrivateKey, error) {
+	priv, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("%w: private key type %T is not RSA", ErrDecodePrivateKey, key)
+	}
+	return priv, nil
+}
+
+// decryptPKCS8 解密 PKCS#8 EncryptedPrivateKeyInfo，返回未加密的 PKCS#8 DER。
+//
+// 参数：
+//   - der: EncryptedPrivateKeyInfo 的 DER 编码。
+//   - passphrase: 私钥口令。
+//
+// 返回：
+//   - []byte: 解密并去除填充后的 PKCS#8 PrivateKeyInfo DER。
+//   - error: 结构非法时返回 ErrDecodePrivateKey；算法不受支持时返回 ErrUnsupportedKeyEncryption；
+//     填充非法时返回 ErrIncorrectPassphrase。
+func decryptPKCS8(der, passphrase []byte) ([]byte, error) {
+	var info encryptedPrivateKeyInfo
+	if rest, err := asn1.Unmarshal(der, &info); nil != err || 0 != len(rest) {
+		return nil, ErrDecodePrivateKey
+	}
+	if !info.Algorithm.Algorithm.Equal(oidPBES2) {
+		return nil, fmt.Errorf("%w: encryption algorithm %s", ErrUnsupportedKeyEncryption, info.Algorithm.Algorithm)
+	}
+
+	var params pbes2Params
+	if _, err := asn1.Unmarshal(info.Algorithm.Parameters.FullBytes, &params); nil != err {
+		return nil, ErrDecodePrivateKey
+	}
+	if !params.KeyDerivationFunc.Algorithm.Equal(oidPBKDF2) {
+		return nil, fmt.Errorf("%w: key derivation function %s", ErrUnsupportedKeyEncryption, params.KeyDerivationFunc.Algorithm)
+	}
+	var kdf pbkdf2Params
+	if _, err := asn1.Unmarshal(params.KeyDerivationFunc.Parameters.FullBytes, &kdf); nil != err {
+		return nil, ErrDecodePrivateKey
+	}
+
+	// PRF 缺省时按 RFC 8018 使用 HMAC-SHA1。
+	prf := sha1.New
+	if 0 != len(kdf.PRF.Algorithm) {
+		var ok bool
+		if prf, ok = pbkdf2PRFs[kdf.PRF.Algorithm.String()]; !ok {
+			return nil, fmt.Errorf("%w: pbkdf2 prf %s", ErrUnsupportedKeyEncryption, kdf.PRF.Algorithm)
+		}
+	}
+	c, ok := pbes2Ciphers[params.EncryptionScheme.Algorithm.String()]
+	if !ok {
+		return nil, fmt.Errorf("%w: cipher %s", ErrUnsupportedKeyEncryption, params.EncryptionScheme.Algorithm)
+	}
+	if 0 != kdf.KeyLength && c.keySize != kdf.KeyLength {
+		return nil, fmt.Errorf("%w: key length %d", ErrDecodePrivateKey, kdf.KeyLength)
+	}
+	var iv []byte
+	if _, err := asn1.Unmarshal(params.EncryptionScheme.Parameters.FullBytes, &iv); nil != err {
+		return nil, ErrDecodePrivateKey
+	}
+
+	key, err := pbkdf2.Key(prf, string(passphrase), kdf.Salt, kdf.IterationCount, c.keySize)
+	if nil != err {
+		return nil, fmt.Errorf("%w: %v", ErrDecodePrivateKey, err)
+	}
+	b, err := c.newBlock(key)
+	if nil != err {
+		return nil, err
+	}
+	if len(iv) != b.BlockSize() || 0 == len(info.EncryptedData) || 0 != len(info.EncryptedData)%b.BlockSize() {
+		return nil, ErrDecodePrivateKey
+	}
+
+	plain := make([]byte, len(info.EncryptedData))
+	cipher.NewCBCDecrypter(b, iv).CryptBlocks(plain, info.EncryptedData)
+	// 填充以常量时间校验，填充非法与口令错误返回同一个错误。
+	data, err := kitpadding.PKCS7Unpad(plain, b.BlockSize())
+	if nil != err {
+		return nil, ErrIncorrectPassphrase
+	}
+	return data, nil
+}
@@ -81,7 +81,7 @@ func EncryptPubKey(publicKey, dataClear []byte) ([]byte, error) {
 //
 // 返回：
 //   - []byte: 使用 PKCS#1 v1.5 填充方案加密后的密文数据。
-//   - error: 公钥无效、明文过长、底层加密失败或 panic 被拦截时返回错误。
+//   - error: 公钥无效、明文过长（*MessageTooLongError）、底层加密失败或 panic 被拦截时返回错误。
 //
 // Deprecated: PKCS#1 v1.5 encryption 不推荐新代码使用；新代码请使用
 // EncryptPublicKeyOAEP。默认 OAEP 函数使用 SHA-256 和 nil label；如需指定 OAEP
@@ -94,6 +94,11 @@ func EncryptPublicKey(pubKey *rsa.PublicKey, dataClear []byte) (dataCipher []byt
 		}
 	}()
 
+	// 明文过长时返回可识别的 *MessageTooLongError，而不是仅返回标准库的通用错误。
+	if err = checkMessageLength(pubKey, len(dataClear), pkcs1v15Overhead); nil != err {
+		return nil, err
+	}
+
 	// 使用标准库函数 rsa.EncryptPKCS1v15 进行加密操作。
 	//lint:ignore SA1019 仅用于兼容历史 PKCS#1 v1.5 密文格式；新代码请使用 EncryptPublicKeyOAEP 或 EncryptPublicKeyOAEPWithHash。
 	dataCipher, err = rsa.EncryptPKCS1v15(rand.Reader, pubKey, dataClear)
@@ -106,7 +111,8 @@ func EncryptPublicKey(pubKey *rsa.PublicKey, dataClear []byte) (dataCipher []byt
 //
 // 默认使用 SHA-256 作为 OAEP 哈希函数，并使用 nil label。解密时必须使用相同的
 // hash 和 label；如需指定 OAEP hash 或 label，请使用 EncryptPubKeyOAEPWithHash。
-// 本函数不做分块，明文长度不能超过当前密钥和 SHA-256 OAEP 允许的最大值。
+// 本函数不做分块，明文长度不能超过当前密钥和 SHA-256 OAEP 允许的最大值；
+// 更长的数据请使用 EncryptLarge 或 EncryptPublicKeyOAEPChunked。
 //
 // 参数：
 //   - publicKey: PEM 编码的 RSA 公钥数据。
@@ -146,7 +152,8 @@ func EncryptPubKeyOAEPWithHash(publicKey, dataClear []byte, hash hash.Hash, labe
 //
 // 默认使用 SHA-256 作为 OAEP 哈希函数，并使用 nil label。解密时必须使用相同的
 // hash 和 label；如需指定 OAEP hash 或 label，请使用 EncryptPublicKeyOAEPWithHash。
-// 本函数不做分块，明文长度不能超过当前密钥和 SHA-256 OAEP 允许的最大值。
+// 本函数不做分块，明文长度不能超过当前密钥和 SHA-256 OAEP 允许的最大值；
+// 更长的数据请使用 EncryptLarge 或 EncryptPublicKeyOAEPChunked。
 //
 // 参数：
 //   - pubKey: RSA 公钥对象，必须非 nil 且包含有效模数和指数。
@@ -173,7 +180,8 @@ func EncryptPublicKeyOAEP(pubKey *rsa.PublicKey, dataClear []byte) ([]byte, erro
 //
 // 返回：
 //   - []byte: 使用 OAEP 加密后的密文数据。
-//   - error: hash 为 nil、明文过长、公钥无效、底层加密失败或 panic 被拦截时返回错误；hash 为 nil 时可使用 errors.Is 判断 ErrNilHash。
+//   - error: hash 为 nil、明文过长、公钥无效、底层加密失败或 panic 被拦截时返回错误；hash 为 nil 时可使用 errors.Is 判断 ErrNilHash，
+//     明文过长时返回 *MessageTooLongError，可使用 errors.As 取得最大长度。
 func EncryptPublicKeyOAEPWithHash(pubKey *rsa.PublicKey, dataClear []byte, hash hash.Hash, label []byte) (dataCipher []byte, err error) {
 	if hash == nil {
 		return nil, ErrNilHash
@@ -186,6 +194,11 @@ func EncryptPublicKeyOAEPWithHash(pubKey *rsa.PublicKey, dataClear []byte, hash
 		}
 	}()
 
+	// 明文过长时返回可识别的 *MessageTooLongError，而不是仅返回标准库的通用错误。
+	if err = checkMessageLength(pubKey, len(dataClear), 2*hash.Size()+2); nil != err {
+		return nil, err
+	}
+
 	dataCipher, err = rsa.EncryptOAEP(hash, rand.Reader, pubKey, dataClear, label)
 
 	// 返回加密后的密文和可能的错误。
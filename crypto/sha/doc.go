@@ -8,4 +8,8 @@
 // 返回小写十六进制编码结果，并保留一个兼容既有 API 的 error 返回值。当前实现直接
 // 使用 crypto/sha1 和 crypto/sha256 的 Sum API，因此 error 始终为 nil。对应的
 // WithoutError 变体仅返回摘要字符串，适合不需要双返回值签名的调用场景。
+//
+// SHA256File、SHA512File 和 HashFile 以流式方式计算文件摘要。HashTree 并行遍历目录树，
+// 按包含/排除模式筛选普通文件并生成以相对路径为键的 Manifest；Manifest 可按 sha256sum
+// 兼容格式输出、解析和比较，VerifyTree 用于部署时校验制品目录是否与清单一致。
 package sha
@@ -0,0 +1,109 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package sha
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+)
+
+const (
+	// AlgorithmSHA1 表示 SHA1 摘要算法，仅用于兼容既有清单，不建议用于新的完整性校验。
+	AlgorithmSHA1 Algorithm = "sha1"
+	// AlgorithmSHA256 表示 SHA256 摘要算法，是 HashTree 的默认算法。
+	AlgorithmSHA256 Algorithm = "sha256"
+	// AlgorithmSHA512 表示 SHA512 摘要算法。
+	AlgorithmSHA512 Algorithm = "sha512"
+)
+
+var (
+	// ErrUnsupportedAlgorithm 表示摘要算法不受支持。
+	ErrUnsupportedAlgorithm = errors.New("unsupported hash algorithm")
+)
+
+type (
+	// Algorithm 表示文件和目录树摘要使用的算法名称。
+	Algorithm string
+)
+
+// New 创建算法对应的 hash.Hash。
+//
+// 参数：无。
+//
+// 返回：
+//   - hash.Hash: 新的摘要计算器。
+//   - error: 算法不受支持时返回 ErrUnsupportedAlgorithm。
+func (a Algorithm) New() (hash.Hash, error) {
+	switch a {
+	case AlgorithmSHA1:
+		return sha1.New(), nil
+	case AlgorithmSHA256:
+		return sha256.New(), nil
+	case AlgorithmSHA512:
+		return sha512.New(), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedAlgorithm, string(a))
+	}
+}
+
+// SHA256File 返回文件内容的 SHA256 摘要小写十六进制字符串。
+//
+// 文件以流式方式读取，内存占用与文件大小无关。
+//
+// 参数：
+//   - path: 文件路径。
+//
+// 返回：
+//   - string: 文件内容的 SHA256 摘要小写十六进制编码。
+//   - error: 打开或读取文件失败时返回错误。
+func SHA256File(path string) (string, error) {
+	return HashFile(AlgorithmSHA256, path)
+}
+
+// SHA512File 返回文件内容的 SHA512 摘要小写十六进制字符串。
+//
+// 参数：
+//   - path: 文件路径。
+//
+// 返回：
+//   - string: 文件内容的 SHA512 摘要小写十六进制编码。
+//   - error: 打开或读取文件失败时返回错误。
+func SHA512File(path string) (string, error) {
+	return HashFile(AlgorithmSHA512, path)
+}
+
+// HashFile 使用指定算法返回文件内容摘要的小写十六进制字符串。
+//
+// 参数：
+//   - algorithm: 摘要算法。
+//   - path: 文件路径。
+//
+// 返回：
+//   - string: 文件内容摘要的小写十六进制编码。
+//   - error: 算法不受支持时返回 ErrUnsupportedAlgorithm；打开或读取文件失败时返回对应错误。
+func HashFile(algorithm Algorithm, path string) (string, error) {
+	h, err := algorithm.New()
+	if nil != err {
+		return "", err
+	}
+
+	f, err := os.Open(path)
+	if nil != err {
+		return "", err
+	}
+	defer func() { _ = f.Close() }()
+
+	if _, err := io.Copy(h, f); nil != err {
+		return "", fmt.Errorf("read %s: %w", path, err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,101 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package sha_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	kitsha "github.com/fsyyft-go/kit/crypto/sha"
+)
+
+// TestHashFile 验证文件摘要与标准摘要向量一致，并覆盖算法不受支持和文件不存在的错误。
+func TestHashFile(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "hello.txt")
+	require.NoError(t, os.WriteFile(file, []byte("hello world"), 0o600))
+
+	tests := []struct {
+		name        string
+		description string
+		algorithm   kitsha.Algorithm
+		path        string
+		want        string
+		wantErr     error
+	}{
+		{
+			name:        "success/sha1",
+			description: "验证 SHA1 文件摘要与字符串摘要一致。",
+			algorithm:   kitsha.AlgorithmSHA1,
+			path:        file,
+			want:        "2aae6c35c94fcfb415dbe95f408b9ce91ee846ed",
+		},
+		{
+			name:        "success/sha256",
+			description: "验证 SHA256 文件摘要与标准向量一致。",
+			algorithm:   kitsha.AlgorithmSHA256,
+			path:        file,
+			want:        "b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9",
+		},
+		{
+			name:        "success/sha512",
+			description: "验证 SHA512 文件摘要与标准向量一致。",
+			algorithm:   kitsha.AlgorithmSHA512,
+			path:        file,
+			want:        "309ecc489c12d6eb4cc40f50c902f2b4d0ed77ee511a7c7a9bcd3ca86d4cd86f989dd35bc5ff499670da34255b45b0cfd830e81f605dcf7dc5542e93ae9cd76f",
+		},
+		{
+			name:        "error/unsupported-algorithm",
+			description: "验证未知算法返回 ErrUnsupportedAlgorithm。",
+			algorithm:   kitsha.Algorithm("md5"),
+			path:        file,
+			wantErr:     kitsha.ErrUnsupportedAlgorithm,
+		},
+		{
+			name:        "error/not-exist",
+			description: "验证文件不存在时返回 os.ErrNotExist。",
+			algorithm:   kitsha.AlgorithmSHA256,
+			path:        filepath.Join(dir, "missing"),
+			wantErr:     os.ErrNotExist,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			got, err := kitsha.HashFile(tt.algorithm, tt.path)
+			if nil != tt.wantErr {
+				require.ErrorIs(t, err, tt.wantErr)
+				assert.Empty(t, got)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// TestSHA256FileAndSHA512File 验证便捷函数与 HashFile 的结果一致。
+func TestSHA256FileAndSHA512File(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "empty")
+	require.NoError(t, os.WriteFile(file, nil, 0o600))
+
+	sum256, err := kitsha.SHA256File(file)
+	require.NoError(t, err)
+	assert.Equal(t, "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", sum256)
+
+	sum512, err := kitsha.SHA512File(file)
+	require.NoError(t, err)
+	want512, err := kitsha.HashFile(kitsha.AlgorithmSHA512, file)
+	require.NoError(t, err)
+	assert.Equal(t, want512, sum512)
+	assert.Len(t, sum512, 128)
+}
@@ -0,0 +1,416 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package sha
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var (
+	// ErrInvalidManifest 表示清单文本格式不正确。
+	ErrInvalidManifest = errors.New("invalid manifest")
+)
+
+type (
+	// TreeOption 定义 HashTree 的可选配置。
+	TreeOption func(*treeOptions)
+
+	// treeOptions 保存 HashTree 的配置。
+	treeOptions struct {
+		// algorithm 是摘要算法。
+		algorithm Algorithm
+		// includes 是文件需要匹配的 glob 模式；为空时包含全部文件。
+		includes []string
+		// excludes 是需要排除的文件或目录的 glob 模式。
+		excludes []string
+		// workers 是并行计算摘要的 goroutine 数量。
+		workers int
+	}
+
+	// Manifest 是目录树的摘要清单。
+	Manifest struct {
+		// Algorithm 是计算摘要使用的算法。
+		Algorithm Algorithm
+		// Files 是以 / 分隔的相对路径到摘要小写十六进制编码的映射。
+		Files map[string]string
+	}
+
+	// ManifestDiff 描述两个清单之间的差异，各列表均按路径升序排列。
+	ManifestDiff struct {
+		// Added 是仅存在于新清单中的路径。
+		Added []string
+		// Removed 是仅存在于旧清单中的路径。
+		Removed []string
+		// Changed 是两个清单中摘要不同的路径。
+		Changed []string
+	}
+)
+
+// WithTreeAlgorithm 设置 HashTree 使用的摘要算法，默认为 AlgorithmSHA256。
+//
+// 参数：
+//   - algorithm: 摘要算法。
+//
+// 返回：
+//   - TreeOption: 应用于 HashTree 的配置项。
+func WithTreeAlgorithm(algorithm Algorithm) TreeOption {
+	return func(o *treeOptions) {
+		o.algorithm = algorithm
+	}
+}
+
+// WithInclude 只包含匹配任一模式的文件，可多次调用累加。
+//
+// 模式使用 path.Match 语法。不含 / 的模式匹配文件名（例如 "*.so"），含 / 的模式匹配以 / 分隔的
+// 相对路径（例如 "bin/*"）。包含规则只作用于文件，不影响目录遍历。
+//
+// 参数：
+//   - patterns: glob 模式。
+//
+// 返回：
+//   - TreeOption: 应用于 HashTree 的配置项。
+func WithInclude(patterns ...string) TreeOption {
+	return func(o *treeOptions) {
+		o.includes = append(o.includes, patterns...)
+	}
+}
+
+// WithExclude 排除匹配任一模式的文件或目录，可多次调用累加，优先级高于 WithInclude。
+//
+// 模式语法同 WithInclude；匹配到的目录整体跳过，例如 ".git" 或 "node_modules"。
+//
+// 参数：
+//   - patterns: glob 模式。
+//
+// 返回：
+//   - TreeOption: 应用于 HashTree 的配置项。
+func WithExclude(patterns ...string) TreeOption {
+	return func(o *treeOptions) {
+		o.excludes = append(o.excludes, patterns...)
+	}
+}
+
+// WithWorkers 设置并行计算摘要的 goroutine 数量，默认为 runtime.NumCPU()。
+//
+// 参数：
+//   - workers: goroutine 数量；小于等于 0 时使用默认值。
+//
+// 返回：
+//   - TreeOption: 应用于 HashTree 的配置项。
+func WithWorkers(workers int) TreeOption {
+	return func(o *treeOptions) {
+		o.workers = workers
+	}
+}
+
+// HashTree 遍历目录并计算其中每个普通文件的摘要，生成确定性的清单。
+//
+// 清单以 / 分隔的相对路径为键，与操作系统和遍历顺序无关；符号链接、设备文件等非普通文件不计入清单，
+// 也不会跟随符号链接进入其它目录。文件摘要由多个 goroutine 并行计算。
+//
+// 参数：
+//   - dir: 根目录。
+//   - opts: 算法、包含/排除模式和并行度等配置。
+//
+// 返回：
+//   - *Manifest: 目录树的摘要清单。
+//   - error: 算法不受支持、模式语法错误、遍历或读取文件失败时返回错误。
+func HashTree(dir string, opts ...TreeOption) (*Manifest, error) {
+	o := &treeOptions{
+		algorithm: AlgorithmSHA256,
+		workers:   runtime.NumCPU(),
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.workers <= 0 {
+		o.workers = runtime.NumCPU()
+	}
+	if _, err := o.algorithm.New(); nil != err {
+		return nil, err
+	}
+	for _, pattern := range append(append([]string(nil), o.includes...), o.excludes...) {
+		if _, err := path.Match(pattern, ""); nil != err {
+			return nil, fmt.Errorf("pattern %q: %w", pattern, err)
+		}
+	}
+
+	var files []string
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if nil != err {
+			return err
+		}
+		if p == dir {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, p)
+		if nil != err {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if d.IsDir() {
+			if matchAny(o.excludes, rel) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() || matchAny(o.excludes, rel) {
+			return nil
+		}
+		if len(o.includes) > 0 && !matchAny(o.includes, rel) {
+			return nil
+		}
+		files = append(files, rel)
+		return nil
+	})
+	if nil != err {
+		return nil, err
+	}
+
+	hashes, err := hashFiles(dir, files, o.algorithm, o.workers)
+	if nil != err {
+		return nil, err
+	}
+	return &Manifest{Algorithm: o.algorithm, Files: hashes}, nil
+}
+
+// VerifyTree 重新计算目录树摘要并与期望清单比较，适合部署前校验制品。
+//
+// 参数：
+//   - dir: 根目录。
+//   - want: 期望的清单，其算法用于重新计算摘要。
+//   - opts: 包含/排除模式和并行度等配置，应与生成 want 时一致；算法配置会被 want.Algorithm 覆盖。
+//
+// 返回：
+//   - ManifestDiff: 目录树相对于 want 的差异，Empty 为 true 表示校验通过。
+//   - error: 重新计算摘要失败时返回错误。
+func VerifyTree(dir string, want *Manifest, opts ...TreeOption) (ManifestDiff, error) {
+	got, err := HashTree(dir, append(opts, WithTreeAlgorithm(want.Algorithm))...)
+	if nil != err {
+		return ManifestDiff{}, err
+	}
+	return want.Diff(got), nil
+}
+
+// ParseManifest 解析 WriteTo 输出的清单文本。
+//
+// 每行格式为 "<摘要>  <路径>"，与 sha256sum 等命令的输出兼容；空行会被忽略。
+//
+// 参数：
+//   - r: 清单文本。
+//   - algorithm: 清单使用的摘要算法，用于校验摘要长度。
+//
+// 返回：
+//   - *Manifest: 解析得到的清单。
+//   - error: 算法不受支持时返回 ErrUnsupportedAlgorithm；行格式、摘要长度错误或路径重复时返回 ErrInvalidManifest。
+func ParseManifest(r io.Reader, algorithm Algorithm) (*Manifest, error) {
+	h, err := algorithm.New()
+	if nil != err {
+		return nil, err
+	}
+	size := h.Size()
+
+	m := &Manifest{Algorithm: algorithm, Files: make(map[string]string)}
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		if "" == strings.TrimSpace(text) {
+			continue
+		}
+		sum, name, ok := strings.Cut(text, "  ")
+		if !ok || "" == name {
+			return nil, fmt.Errorf("%w: line %d: missing separator", ErrInvalidManifest, line)
+		}
+		if raw, err := hex.DecodeString(sum); nil != err || len(raw) != size {
+			return nil, fmt.Errorf("%w: line %d: bad %s digest", ErrInvalidManifest, line, algorithm)
+		}
+		if _, exists := m.Files[name]; exists {
+			return nil, fmt.Errorf("%w: line %d: duplicate path %q", ErrInvalidManifest, line, name)
+		}
+		m.Files[name] = strings.ToLower(sum)
+	}
+	if err := scanner.Err(); nil != err {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Paths 返回清单中的全部路径，按字节序升序排列。
+//
+// 参数：无。
+//
+// 返回：
+//   - []string: 排序后的路径列表。
+func (m *Manifest) Paths() []string {
+	paths := make([]string, 0, len(m.Files))
+	for p := range m.Files {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// WriteTo 以 "<摘要>  <路径>" 的行格式按路径升序写出清单，输出与 sha256sum 等命令兼容。
+//
+// 参数：
+//   - w: 输出目标。
+//
+// 返回：
+//   - int64: 写出的字节数。
+//   - error: 写出失败时返回错误。
+func (m *Manifest) WriteTo(w io.Writer) (int64, error) {
+	var buf bytes.Buffer
+	for _, p := range m.Paths() {
+		buf.WriteString(m.Files[p])
+		buf.WriteString("  ")
+		buf.WriteString(p)
+		buf.WriteByte('\n')
+	}
+	return buf.WriteTo(w)
+}
+
+// Digest 返回清单文本的摘要，可作为整个目录树的单一指纹。
+//
+// 参数：无。
+//
+// 返回：
+//   - string: 使用清单算法对 WriteTo 输出计算的摘要小写十六进制编码；算法不受支持时为空字符串。
+func (m *Manifest) Digest() string {
+	h, err := m.Algorithm.New()
+	if nil != err {
+		return ""
+	}
+	_, _ = m.WriteTo(h)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Diff 比较两个清单。
+//
+// 参数：
+//   - other: 新清单。
+//
+// 返回：
+//   - ManifestDiff: other 相对于 m 新增、删除和变化的路径。
+func (m *Manifest) Diff(other *Manifest) ManifestDiff {
+	var d ManifestDiff
+	for _, p := range m.Paths() {
+		sum, ok := other.Files[p]
+		switch {
+		case !ok:
+			d.Removed = append(d.Removed, p)
+		case sum != m.Files[p]:
+			d.Changed = append(d.Changed, p)
+		}
+	}
+	for _, p := range other.Paths() {
+		if _, ok := m.Files[p]; !ok {
+			d.Added = append(d.Added, p)
+		}
+	}
+	return d
+}
+
+// Empty 判断是否不存在差异。
+//
+// 参数：无。
+//
+// 返回：
+//   - bool: 没有新增、删除和变化的路径时为 true。
+func (d ManifestDiff) Empty() bool {
+	return 0 == len(d.Added) && 0 == len(d.Removed) && 0 == len(d.Changed)
+}
+
+// hashFiles 使用 workers 个 goroutine 并行计算文件摘要。
+//
+// 参数：
+//   - dir: 根目录。
+//   - files: 以 / 分隔的相对路径。
+//   - algorithm: 摘要算法。
+//   - workers: goroutine 数量。
+//
+// 返回：
+//   - map[string]string: 相对路径到摘要的映射。
+//   - error: 任一文件读取失败时返回第一个错误，剩余文件不再计算。
+func hashFiles(dir string, files []string, algorithm Algorithm, workers int) (map[string]string, error) {
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		firstErr error
+		hashes   = make(map[string]string, len(files))
+		jobs     = make(chan string)
+		done     = make(chan struct{})
+		doneOnce sync.Once
+	)
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for rel := range jobs {
+				sum, err := HashFile(algorithm, filepath.Join(dir, filepath.FromSlash(rel)))
+				mu.Lock()
+				if nil != err {
+					if nil == firstErr {
+						firstErr = err
+					}
+					doneOnce.Do(func() { close(done) })
+				} else {
+					hashes[rel] = sum
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+feed:
+	for _, rel := range files {
+		select {
+		case jobs <- rel:
+		case <-done:
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if nil != firstErr {
+		return nil, firstErr
+	}
+	return hashes, nil
+}
+
+// matchAny 判断相对路径是否匹配任一模式。
+//
+// 参数：
+//   - patterns: glob 模式，语法已预先校验。
+//   - rel: 以 / 分隔的相对路径。
+//
+// 返回：
+//   - bool: 匹配任一模式时为 true。
+func matchAny(patterns []string, rel string) bool {
+	base := path.Base(rel)
+	for _, pattern := range patterns {
+		name := rel
+		if !strings.Contains(pattern, "/") {
+			name = base
+		}
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
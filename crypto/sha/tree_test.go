@@ -0,0 +1,274 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package sha_test
+
+import (
+	"bytes"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	kitsha "github.com/fsyyft-go/kit/crypto/sha"
+)
+
+const (
+	// sumHello 是 "hello" 的 SHA256 摘要。
+	sumHello = "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+	// sumWorld 是 "world" 的 SHA256 摘要。
+	sumWorld = "486ea46224d1bb4fb680f34f7c9ad96a8f24ec88be73ea8e5a6c65260e9cb8a7"
+)
+
+// writeTree 在临时目录中按相对路径创建文件并返回根目录。
+//
+// 参数：
+//   - t: 测试上下文。
+//   - files: 以 / 分隔的相对路径到文件内容的映射。
+//
+// 返回：
+//   - string: 根目录路径。
+func writeTree(t *testing.T, files map[string]string) string {
+	t.Helper()
+	root := t.TempDir()
+	for rel, content := range files {
+		p := filepath.Join(root, filepath.FromSlash(rel))
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0o755))
+		require.NoError(t, os.WriteFile(p, []byte(content), 0o600))
+	}
+	return root
+}
+
+// TestHashTree 验证目录树清单的内容以及包含、排除模式。
+func TestHashTree(t *testing.T) {
+	files := map[string]string{
+		"a.txt":                "hello",
+		"bin/app":              "world",
+		"bin/app.debug":        "hello",
+		"lib/x/y.so":           "world",
+		".git/HEAD":            "hello",
+		"node_modules/m/a.txt": "hello",
+	}
+
+	tests := []struct {
+		name        string
+		description string
+		opts        []kitsha.TreeOption
+		want        map[string]string
+	}{
+		{
+			name:        "success/all-files",
+			description: "验证默认包含全部普通文件并以 / 分隔的相对路径为键。",
+			opts:        []kitsha.TreeOption{kitsha.WithWorkers(2)},
+			want: map[string]string{
+				"a.txt":                sumHello,
+				"bin/app":              sumWorld,
+				"bin/app.debug":        sumHello,
+				"lib/x/y.so":           sumWorld,
+				".git/HEAD":            sumHello,
+				"node_modules/m/a.txt": sumHello,
+			},
+		},
+		{
+			name:        "success/exclude-dirs",
+			description: "验证按目录名排除时整个目录被跳过。",
+			opts:        []kitsha.TreeOption{kitsha.WithExclude(".git", "node_modules")},
+			want: map[string]string{
+				"a.txt":         sumHello,
+				"bin/app":       sumWorld,
+				"bin/app.debug": sumHello,
+				"lib/x/y.so":    sumWorld,
+			},
+		},
+		{
+			name:        "success/include-with-exclude",
+			description: "验证包含路径模式与排除文件名模式组合时排除优先。",
+			opts: []kitsha.TreeOption{
+				kitsha.WithInclude("bin/*", "*.so"),
+				kitsha.WithExclude("*.debug"),
+			},
+			want: map[string]string{
+				"bin/app":    sumWorld,
+				"lib/x/y.so": sumWorld,
+			},
+		},
+		{
+			name:        "boundary/no-match",
+			description: "验证没有文件匹配时返回空清单。",
+			opts:        []kitsha.TreeOption{kitsha.WithInclude("*.exe")},
+			want:        map[string]string{},
+		},
+	}
+
+	root := writeTree(t, files)
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			m, err := kitsha.HashTree(root, tt.opts...)
+			require.NoError(t, err)
+			assert.Equal(t, kitsha.AlgorithmSHA256, m.Algorithm)
+			assert.Equal(t, tt.want, m.Files)
+		})
+	}
+}
+
+// TestHashTree_Errors 验证算法、模式和目录错误。
+func TestHashTree_Errors(t *testing.T) {
+	root := writeTree(t, map[string]string{"a.txt": "hello"})
+
+	tests := []struct {
+		name        string
+		description string
+		dir         string
+		opts        []kitsha.TreeOption
+		wantErr     error
+	}{
+		{
+			name:        "error/unsupported-algorithm",
+			description: "验证未知算法返回 ErrUnsupportedAlgorithm。",
+			dir:         root,
+			opts:        []kitsha.TreeOption{kitsha.WithTreeAlgorithm("md5")},
+			wantErr:     kitsha.ErrUnsupportedAlgorithm,
+		},
+		{
+			name:        "error/bad-pattern",
+			description: "验证模式语法错误时返回 path.ErrBadPattern。",
+			dir:         root,
+			opts:        []kitsha.TreeOption{kitsha.WithExclude("[")},
+			wantErr:     path.ErrBadPattern,
+		},
+		{
+			name:        "error/dir-not-exist",
+			description: "验证根目录不存在时返回 os.ErrNotExist。",
+			dir:         filepath.Join(root, "missing"),
+			wantErr:     os.ErrNotExist,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			m, err := kitsha.HashTree(tt.dir, tt.opts...)
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Nil(t, m)
+		})
+	}
+}
+
+// TestHashTree_SkipSymlink 验证符号链接不计入清单。
+func TestHashTree_SkipSymlink(t *testing.T) {
+	root := writeTree(t, map[string]string{"a.txt": "hello"})
+	if err := os.Symlink(filepath.Join(root, "a.txt"), filepath.Join(root, "link")); nil != err {
+		t.Skipf("symlink not supported: %v", err)
+	}
+
+	m, err := kitsha.HashTree(root)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a.txt"}, m.Paths())
+}
+
+// TestManifest_RoundTrip 验证清单输出的确定性、Digest 与 ParseManifest 往返一致。
+func TestManifest_RoundTrip(t *testing.T) {
+	root := writeTree(t, map[string]string{"b/c.txt": "world", "a.txt": "hello"})
+
+	m1, err := kitsha.HashTree(root, kitsha.WithWorkers(1))
+	require.NoError(t, err)
+	m2, err := kitsha.HashTree(root, kitsha.WithWorkers(8))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	n, err := m1.WriteTo(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, int64(buf.Len()), n)
+	assert.Equal(t, sumHello+"  a.txt\n"+sumWorld+"  b/c.txt\n", buf.String())
+	assert.Equal(t, kitsha.SHA256HashStringWithoutError(buf.String()), m1.Digest())
+	assert.Equal(t, m1.Digest(), m2.Digest())
+
+	parsed, err := kitsha.ParseManifest(strings.NewReader(buf.String()+"\n"), kitsha.AlgorithmSHA256)
+	require.NoError(t, err)
+	assert.Equal(t, m1, parsed)
+}
+
+// TestParseManifest_Errors 验证清单文本格式错误。
+func TestParseManifest_Errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		text        string
+		algorithm   kitsha.Algorithm
+		wantErr     error
+	}{
+		{
+			name:        "error/missing-separator",
+			description: "验证缺少两个空格分隔符时返回 ErrInvalidManifest。",
+			text:        sumHello + " a.txt\n",
+			algorithm:   kitsha.AlgorithmSHA256,
+			wantErr:     kitsha.ErrInvalidManifest,
+		},
+		{
+			name:        "error/digest-length",
+			description: "验证摘要长度与算法不符时返回 ErrInvalidManifest。",
+			text:        sumHello + "  a.txt\n",
+			algorithm:   kitsha.AlgorithmSHA512,
+			wantErr:     kitsha.ErrInvalidManifest,
+		},
+		{
+			name:        "error/duplicate-path",
+			description: "验证路径重复时返回 ErrInvalidManifest。",
+			text:        sumHello + "  a.txt\n" + sumWorld + "  a.txt\n",
+			algorithm:   kitsha.AlgorithmSHA256,
+			wantErr:     kitsha.ErrInvalidManifest,
+		},
+		{
+			name:        "error/unsupported-algorithm",
+			description: "验证未知算法返回 ErrUnsupportedAlgorithm。",
+			text:        "",
+			algorithm:   kitsha.Algorithm("md5"),
+			wantErr:     kitsha.ErrUnsupportedAlgorithm,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			m, err := kitsha.ParseManifest(strings.NewReader(tt.text), tt.algorithm)
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Nil(t, m)
+		})
+	}
+}
+
+// TestVerifyTree 验证目录树与清单比较得到的差异。
+func TestVerifyTree(t *testing.T) {
+	root := writeTree(t, map[string]string{"a.txt": "hello", "b.txt": "hello", "c.txt": "hello"})
+	want, err := kitsha.HashTree(root, kitsha.WithTreeAlgorithm(kitsha.AlgorithmSHA512))
+	require.NoError(t, err)
+
+	diff, err := kitsha.VerifyTree(root, want)
+	require.NoError(t, err)
+	assert.True(t, diff.Empty())
+
+	require.NoError(t, os.WriteFile(filepath.Join(root, "a.txt"), []byte("world"), 0o600))
+	require.NoError(t, os.Remove(filepath.Join(root, "b.txt")))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "d.txt"), []byte("hello"), 0o600))
+
+	diff, err = kitsha.VerifyTree(root, want)
+	require.NoError(t, err)
+	assert.False(t, diff.Empty())
+	assert.Equal(t, kitsha.ManifestDiff{
+		Added:   []string{"d.txt"},
+		Removed: []string{"b.txt"},
+		Changed: []string{"a.txt"},
+	}, diff)
+}
@@ -0,0 +1,16 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+// Package uuid 提供基于 crypto/rand 的时间有序标识符：RFC 9562 UUIDv7 与紧凑的可排序令牌 Token。
+//
+// 两者都以 48 位毫秒时间戳开头，后接密码学安全的随机数，生成时无需像 snowflake 那样分配节点号，
+// 适合作为数据库主键：按时间递增插入可减少 B+ 树页分裂，字节序、文本字典序与生成顺序一致。
+// NewV7 生成 UUIDv7，文本为 36 个字符的 8-4-4-4-12 格式；NewToken 生成 Token，文本为 26 个字符的
+// 大写 Crockford Base32，更短且适合放在 URL 中。Parse 与 ParseToken 解析文本形式，UUID 与 Token
+// 实现了 encoding.TextMarshaler、driver.Valuer 与 sql.Scanner，可直接用于 JSON 与 database/sql。
+//
+// 同一 Generator 生成的值严格递增，同一毫秒内或时钟回拨时沿用上一次的时间戳并以随机步长递增随机部分；
+// 包级函数共享一个生成器。时间戳会暴露生成时间，不应用于需要隐藏创建时间的场景；
+// 需要纯随机令牌时使用 bytes.GenerateToken。
+package uuid
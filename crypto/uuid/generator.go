@@ -0,0 +1,281 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package uuid
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+const (
+	// maxUnixMilli 是 48 位时间戳能表示的最大毫秒数，约为公元 10889 年。
+	maxUnixMilli = 1<<48 - 1
+
+	// uuidHiMask 是 UUIDv7 rand_a 字段的掩码，共 12 位。
+	uuidHiMask = 1<<12 - 1
+	// uuidLoMask 是 UUIDv7 rand_b 字段的掩码，共 62 位。
+	uuidLoMask = 1<<62 - 1
+	// tokenHiMask 是 Token 随机部分高 16 位的掩码。
+	tokenHiMask = 1<<16 - 1
+	// tokenLoMask 是 Token 随机部分低 64 位的掩码。
+	tokenLoMask = 1<<64 - 1
+)
+
+var (
+	// ErrClockOutOfRange 表示时钟返回的时间早于 1970 年或超出 48 位毫秒时间戳的范围。
+	//
+	// 调用方可以使用 errors.Is 判断该错误。
+	ErrClockOutOfRange = errors.New("时间超出 48 位毫秒时间戳的范围。")
+
+	// defaultGenerator 是包级函数使用的生成器。
+	defaultGenerator = NewGenerator()
+)
+
+type (
+	// GeneratorOption 定义 NewGenerator 可接收的配置选项。
+	//
+	// 参数：
+	//   - *Generator: 待修改的生成器。
+	GeneratorOption func(*Generator)
+
+	// Generator 生成 UUIDv7 与 Token，可被多个 goroutine 并发使用。
+	//
+	// 同一生成器生成的 UUID 之间、Token 之间严格递增：同一毫秒内或时钟回拨时沿用上一次的时间戳，
+	// 并把随机部分加上一个随机步长（RFC 9562 6.2 节的方法 2），使后续值仍难以猜测；
+	// 随机部分耗尽时时间戳进位 1 毫秒。
+	Generator struct {
+		mu    sync.Mutex       // 保护 uuid 与 token 状态。
+		now   func() time.Time // 时钟。
+		rand  io.Reader        // 随机源。
+		uuid  monotonic        // 上一个 UUID 的状态。
+		token monotonic        // 上一个 Token 的状态。
+	}
+
+	// monotonic 保存上一次生成值的时间戳与随机部分。
+	monotonic struct {
+		ms int64  // 毫秒时间戳，未生成过时为 -1。
+		hi uint16 // 随机部分的高位。
+		lo uint64 // 随机部分的低位。
+	}
+)
+
+// WithClock 设置生成器使用的时钟，主要用于测试。
+//
+// 参数：
+//   - now: 返回当前时间的函数；为 nil 时保持默认的 time.Now。
+//
+// 返回：
+//   - GeneratorOption: 应用于 NewGenerator 的选项。
+func WithClock(now func() time.Time) GeneratorOption {
+	return func(g *Generator) {
+		if nil != now {
+			g.now = now
+		}
+	}
+}
+
+// WithRandReader 设置生成器使用的随机源。
+//
+// 默认使用 crypto/rand.Reader；替换为非密码学安全的随机源会使生成值可被预测。
+//
+// 参数：
+//   - r: 随机源；为 nil 时保持默认值。
+//
+// 返回：
+//   - GeneratorOption: 应用于 NewGenerator 的选项。
+func WithRandReader(r io.Reader) GeneratorOption {
+	return func(g *Generator) {
+		if nil != r {
+			g.rand = r
+		}
+	}
+}
+
+// NewGenerator 创建 UUIDv7 与 Token 生成器。
+//
+// 多数场景直接使用包级的 NewV7 与 NewToken 即可；需要替换时钟或随机源，
+// 或希望不同业务的序列互不影响时再创建独立的生成器。
+//
+// 参数：
+//   - opts: 生成器选项。
+//
+// 返回：
+//   - *Generator: 生成器实例。
+func NewGenerator(opts ...GeneratorOption) *Generator {
+	g := &Generator{
+		now:   time.Now,
+		rand:  rand.Reader,
+		uuid:  monotonic{ms: -1},
+		token: monotonic{ms: -1},
+	}
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g
+}
+
+// NewV7 生成一个 UUIDv7。
+//
+// 参数：无。
+//
+// 返回：
+//   - UUID: 前 48 位为毫秒时间戳、其余 74 位为随机数的 UUID，出错时为 Nil。
+//   - error: 时钟超出范围时返回 ErrClockOutOfRange；随机源失败时返回其错误。
+func (g *Generator) NewV7() (UUID, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if err := g.next(&g.uuid, uuidHiMask, uuidLoMask); nil != err {
+		return Nil, err
+	}
+
+	var u UUID
+	putUint48(u[:6], g.uuid.ms)
+	binary.BigEndian.PutUint16(u[6:8], g.uuid.hi)
+	binary.BigEndian.PutUint64(u[8:], g.uuid.lo)
+	// 版本号 0111 占 rand_a 之前的 4 位，变体 10 占 rand_b 之前的 2 位。
+	u[6] |= 0x70
+	u[8] |= 0x80
+	return u, nil
+}
+
+// NewToken 生成一个 Token。
+//
+// 参数：无。
+//
+// 返回：
+//   - Token: 前 48 位为毫秒时间戳、其余 80 位为随机数的令牌，出错时为零值。
+//   - error: 时钟超出范围时返回 ErrClockOutOfRange；随机源失败时返回其错误。
+func (g *Generator) NewToken() (Token, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if err := g.next(&g.token, tokenHiMask, tokenLoMask); nil != err {
+		return Token{}, err
+	}
+
+	var t Token
+	putUint48(t[:6], g.token.ms)
+	binary.BigEndian.PutUint16(t[6:8], g.token.hi)
+	binary.BigEndian.PutUint64(t[8:], g.token.lo)
+	return t, nil
+}
+
+// next 推进 s 到下一个值，调用方需持有 g.mu。
+//
+// 参数：
+//   - s: 待推进的状态。
+//   - hiMask: 随机部分高位的掩码。
+//   - loMask: 随机部分低位的掩码。
+//
+// 返回：
+//   - error: 时钟超出范围或随机源失败时返回错误，此时 s 保持不变。
+func (g *Generator) next(s *monotonic, hiMask uint16, loMask uint64) error {
+	ms := g.now().UnixMilli()
+	if ms < 0 || ms > maxUnixMilli {
+		return fmt.Errorf("%w: %d", ErrClockOutOfRange, ms)
+	}
+	if ms > s.ms {
+		return g.reseed(s, ms, hiMask, loMask)
+	}
+
+	// 同一毫秒内或时钟回拨：沿用上一次的时间戳，随机部分加上 [1, 2^32] 的随机步长。
+	var buf [4]byte
+	if _, err := io.ReadFull(g.rand, buf[:]); nil != err {
+		return err
+	}
+	step := uint64(binary.BigEndian.Uint32(buf[:])) + 1
+	lo := s.lo + step
+	hi := s.hi
+	// loMask 为 62 位时和不会溢出 uint64，超过掩码即进位；为 64 位时以回绕判断进位。
+	if lo > loMask || lo < s.lo {
+		lo &= loMask
+		if hi == hiMask {
+			// 随机部分耗尽，时间戳进位 1 毫秒并重新生成随机部分。
+			if s.ms+1 > maxUnixMilli {
+				return fmt.Errorf("%w: %d", ErrClockOutOfRange, s.ms+1)
+			}
+			return g.reseed(s, s.ms+1, hiMask, loMask)
+		}
+		hi++
+	}
+	s.hi, s.lo = hi, lo
+	return nil
+}
+
+// reseed 以新的时间戳和随机部分更新 s。
+//
+// 参数：
+//   - s: 待更新的状态。
+//   - ms: 新的毫秒时间戳。
+//   - hiMask: 随机部分高位的掩码。
+//   - loMask: 随机部分低位的掩码。
+//
+// 返回：
+//   - error: 随机源失败时返回错误，此时 s 保持不变。
+func (g *Generator) reseed(s *monotonic, ms int64, hiMask uint16, loMask uint64) error {
+	var buf [10]byte
+	if _, err := io.ReadFull(g.rand, buf[:]); nil != err {
+		return err
+	}
+	s.ms = ms
+	s.hi = binary.BigEndian.Uint16(buf[:2]) & hiMask
+	s.lo = binary.BigEndian.Uint64(buf[2:]) & loMask
+	return nil
+}
+
+// NewV7 使用包级生成器生成一个 UUIDv7。
+//
+// 参数：无。
+//
+// 返回：
+//   - UUID: 按生成顺序严格递增的 UUIDv7，出错时为 Nil。
+//   - error: 随机源失败时返回其错误。
+func NewV7() (UUID, error) {
+	return defaultGenerator.NewV7()
+}
+
+// NewToken 使用包级生成器生成一个 Token。
+//
+// 参数：无。
+//
+// 返回：
+//   - Token: 按生成顺序严格递增的令牌，出错时为零值。
+//   - error: 随机源失败时返回其错误。
+func NewToken() (Token, error) {
+	return defaultGenerator.NewToken()
+}
+
+// putUint48 以大端序把 v 的低 48 位写入 b。
+//
+// 参数：
+//   - b: 长度至少为 6 的目标切片。
+//   - v: 待写入的值。
+func putUint48(b []byte, v int64) {
+	_ = b[5]
+	b[0] = byte(v >> 40)
+	b[1] = byte(v >> 32)
+	b[2] = byte(v >> 24)
+	b[3] = byte(v >> 16)
+	b[4] = byte(v >> 8)
+	b[5] = byte(v)
+}
+
+// uint48 以大端序读取 b 的前 6 个字节。
+//
+// 参数：
+//   - b: 长度至少为 6 的切片。
+//
+// 返回：
+//   - int64: 读取的值。
+func uint48(b []byte) int64 {
+	_ = b[5]
+	return int64(b[0])<<40 | int64(b[1])<<32 | int64(b[2])<<24 | int64(b[3])<<16 | int64(b[4])<<8 | int64(b[5])
+}
@@ -0,0 +1,190 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package uuid
+
+import (
+	"database/sql/driver"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	// ErrInvalidUUID 表示字符串或字节无法解析为 UUID。
+	//
+	// 调用方可以使用 errors.Is 判断该错误。
+	ErrInvalidUUID = errors.New("UUID 格式不正确。")
+
+	// Nil 是所有位都为 0 的 UUID。
+	Nil UUID
+)
+
+var (
+	// 空赋值确保 UUID 实现了 database/sql 的值转换接口。
+	_ driver.Valuer = UUID{}
+)
+
+// UUID 是 RFC 9562 定义的 128 位通用唯一标识符。
+//
+// 字节按网络字节序排列，UUIDv7 的字节序与时间顺序一致，可直接比较或作为 BINARY(16) 主键存储；
+// 文本形式为小写的 8-4-4-4-12 格式。
+type UUID [16]byte
+
+// Parse 解析 UUID 的文本形式。
+//
+// 参数：
+//   - s: 8-4-4-4-12 格式或不含连字符的 32 位十六进制字符串，不区分大小写，
+//     可以带有 "urn:uuid:" 前缀或花括号。
+//
+// 返回：
+//   - UUID: 解析结果，出错时为 Nil。
+//   - error: 格式不正确时返回包装了 ErrInvalidUUID 的错误。
+func Parse(s string) (UUID, error) {
+	raw := s
+	switch {
+	case 45 == len(s) && "urn:uuid:" == s[:9]:
+		s = s[9:]
+	case 38 == len(s) && '{' == s[0] && '}' == s[37]:
+		s = s[1:37]
+	}
+
+	var u UUID
+	switch len(s) {
+	case 32:
+		if _, err := hex.Decode(u[:], []byte(s)); nil != err {
+			return Nil, fmt.Errorf("%w: %q", ErrInvalidUUID, raw)
+		}
+	case 36:
+		if '-' != s[8] || '-' != s[13] || '-' != s[18] || '-' != s[23] {
+			return Nil, fmt.Errorf("%w: %q", ErrInvalidUUID, raw)
+		}
+		compact := s[:8] + s[9:13] + s[14:18] + s[19:23] + s[24:]
+		if _, err := hex.Decode(u[:], []byte(compact)); nil != err {
+			return Nil, fmt.Errorf("%w: %q", ErrInvalidUUID, raw)
+		}
+	default:
+		return Nil, fmt.Errorf("%w: %q", ErrInvalidUUID, raw)
+	}
+	return u, nil
+}
+
+// String 返回 UUID 的 8-4-4-4-12 格式小写文本。
+//
+// 参数：无。
+//
+// 返回：
+//   - string: 36 个字符的文本。
+func (u UUID) String() string {
+	var buf [36]byte
+	hex.Encode(buf[0:8], u[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], u[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], u[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], u[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], u[10:])
+	return string(buf[:])
+}
+
+// Version 返回 UUID 的版本号。
+//
+// 参数：无。
+//
+// 返回：
+//   - int: 第 7 个字节的高 4 位，UUIDv7 为 7。
+func (u UUID) Version() int {
+	return int(u[6] >> 4)
+}
+
+// Time 返回 UUIDv7 中记录的生成时间。
+//
+// 参数：无。
+//
+// 返回：
+//   - time.Time: 毫秒精度的本地时间；版本号不是 7 时返回零值。
+func (u UUID) Time() time.Time {
+	if 7 != u.Version() {
+		return time.Time{}
+	}
+	return time.UnixMilli(uint48(u[:6]))
+}
+
+// IsNil 判断 UUID 是否为 Nil。
+//
+// 参数：无。
+//
+// 返回：
+//   - bool: 所有位都为 0 时返回 true。
+func (u UUID) IsNil() bool {
+	return Nil == u
+}
+
+// MarshalText 实现 encoding.TextMarshaler，输出 String 的结果。
+//
+// 参数：无。
+//
+// 返回：
+//   - []byte: 36 个字符的文本。
+//   - error: 始终为 nil。
+func (u UUID) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+// UnmarshalText 实现 encoding.TextUnmarshaler，接受 Parse 支持的格式。
+//
+// 参数：
+//   - text: UUID 文本。
+//
+// 返回：
+//   - error: 格式不正确时返回包装了 ErrInvalidUUID 的错误，此时 u 保持不变。
+func (u *UUID) UnmarshalText(text []byte) error {
+	parsed, err := Parse(string(text))
+	if nil != err {
+		return err
+	}
+	*u = parsed
+	return nil
+}
+
+// Value 实现 driver.Valuer，以文本形式写入数据库。
+//
+// 需要以 BINARY(16) 存储时，可传入 u[:]。
+//
+// 参数：无。
+//
+// 返回：
+//   - driver.Value: String 的结果。
+//   - error: 始终为 nil。
+func (u UUID) Value() (driver.Value, error) {
+	return u.String(), nil
+}
+
+// Scan 实现 sql.Scanner，支持文本列与 16 字节的二进制列。
+//
+// 参数：
+//   - src: 数据库返回的值，可以是 string、[]byte 或 nil；nil 扫描为 Nil。
+//
+// 返回：
+//   - error: 类型不支持或格式不正确时返回包装了 ErrInvalidUUID 的错误。
+func (u *UUID) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*u = Nil
+		return nil
+	case string:
+		return u.UnmarshalText([]byte(v))
+	case []byte:
+		if 16 == len(v) {
+			copy(u[:], v)
+			return nil
+		}
+		return u.UnmarshalText(v)
+	default:
+		return fmt.Errorf("%w: 不支持的类型 %T", ErrInvalidUUID, src)
+	}
+}
@@ -0,0 +1,395 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package uuid
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fixedClock 返回可手动调整的时钟。
+//
+// 参数：
+//   - ms: 初始毫秒时间戳。
+//
+// 返回：
+//   - func() time.Time: 时钟函数。
+//   - func(ms int64): 修改时钟的函数。
+func fixedClock(ms int64) (func() time.Time, func(ms int64)) {
+	var mu sync.Mutex
+	now := func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return time.UnixMilli(ms)
+	}
+	set := func(v int64) {
+		mu.Lock()
+		defer mu.Unlock()
+		ms = v
+	}
+	return now, set
+}
+
+// TestGenerator_NewV7 验证 UUIDv7 的版本、变体、时间戳与单调递增。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestGenerator_NewV7(t *testing.T) {
+	t.Log("验证同一毫秒内、时钟回拨与时钟前进时生成的 UUIDv7 严格递增，且版本与变体正确。")
+
+	const start = 1700000000123
+	now, set := fixedClock(start)
+	g := NewGenerator(WithClock(now))
+
+	var ids []UUID
+	for i := 0; i < 100; i++ {
+		u, err := g.NewV7()
+		require.NoError(t, err)
+		ids = append(ids, u)
+	}
+	set(start - 1000)
+	u, err := g.NewV7()
+	require.NoError(t, err)
+	ids = append(ids, u)
+	set(start + 1)
+	u, err = g.NewV7()
+	require.NoError(t, err)
+	ids = append(ids, u)
+
+	for i, id := range ids {
+		assert.Equal(t, 7, id.Version())
+		assert.Equal(t, byte(0x80), id[8]&0xC0, "变体应为 10")
+		if i > 0 {
+			assert.Equal(t, -1, bytes.Compare(ids[i-1][:], id[:]), "第 %d 个 UUID 应大于前一个", i)
+			assert.Less(t, ids[i-1].String(), id.String())
+		}
+	}
+	assert.Equal(t, time.UnixMilli(start), ids[0].Time())
+	assert.Equal(t, time.UnixMilli(start), ids[100].Time(), "时钟回拨时沿用上一次的时间戳")
+	assert.Equal(t, time.UnixMilli(start+1), ids[101].Time())
+}
+
+// TestGenerator_Overflow 验证随机部分耗尽时时间戳进位。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestGenerator_Overflow(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		generate    func(g *Generator) (int64, error)
+		state       func(g *Generator) *monotonic
+		hi          uint16
+		lo          uint64
+	}{
+		{
+			name:        "boundary/uuid",
+			description: "验证 UUIDv7 的 74 位随机部分耗尽时时间戳进位 1 毫秒。",
+			generate: func(g *Generator) (int64, error) {
+				u, err := g.NewV7()
+				return u.Time().UnixMilli(), err
+			},
+			state: func(g *Generator) *monotonic { return &g.uuid },
+			hi:    uuidHiMask,
+			lo:    uuidLoMask,
+		},
+		{
+			name:        "boundary/token",
+			description: "验证 Token 的 80 位随机部分耗尽时时间戳进位 1 毫秒。",
+			generate: func(g *Generator) (int64, error) {
+				tk, err := g.NewToken()
+				return tk.Time().UnixMilli(), err
+			},
+			state: func(g *Generator) *monotonic { return &g.token },
+			hi:    tokenHiMask,
+			lo:    tokenLoMask,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			now, _ := fixedClock(1000)
+			g := NewGenerator(WithClock(now))
+			ms, err := tt.generate(g)
+			require.NoError(t, err)
+			assert.Equal(t, int64(1000), ms)
+
+			s := tt.state(g)
+			s.hi, s.lo = tt.hi, tt.lo
+			ms, err = tt.generate(g)
+			require.NoError(t, err)
+			assert.Equal(t, int64(1001), ms)
+		})
+	}
+}
+
+// TestGenerator_Errors 验证时钟越界与随机源失败时返回错误。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestGenerator_Errors(t *testing.T) {
+	readErr := iotest.ErrTimeout
+
+	tests := []struct {
+		name        string
+		description string
+		opts        []GeneratorOption
+		wantErr     error
+	}{
+		{
+			name:        "error/before-epoch",
+			description: "验证早于 1970 年的时间返回 ErrClockOutOfRange。",
+			opts:        []GeneratorOption{WithClock(func() time.Time { return time.UnixMilli(-1) })},
+			wantErr:     ErrClockOutOfRange,
+		},
+		{
+			name:        "error/after-48-bits",
+			description: "验证超出 48 位毫秒时间戳的时间返回 ErrClockOutOfRange。",
+			opts:        []GeneratorOption{WithClock(func() time.Time { return time.UnixMilli(maxUnixMilli + 1) })},
+			wantErr:     ErrClockOutOfRange,
+		},
+		{
+			name:        "error/rand",
+			description: "验证随机源失败时返回其错误。",
+			opts:        []GeneratorOption{WithRandReader(iotest.ErrReader(readErr))},
+			wantErr:     readErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			g := NewGenerator(tt.opts...)
+			u, err := g.NewV7()
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.True(t, u.IsNil())
+			tk, err := g.NewToken()
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, Token{}, tk)
+		})
+	}
+}
+
+// TestNewV7_Concurrent 验证包级函数并发生成的值互不相同。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestNewV7_Concurrent(t *testing.T) {
+	t.Log("验证多个 goroutine 并发调用 NewV7 与 NewToken 时不产生重复值。")
+
+	const workers, perWorker = 8, 500
+	var (
+		mu     sync.Mutex
+		uuids  = make(map[UUID]struct{})
+		tokens = make(map[Token]struct{})
+		wg     sync.WaitGroup
+	)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < perWorker; j++ {
+				u, err := NewV7()
+				assert.NoError(t, err)
+				tk, err := NewToken()
+				assert.NoError(t, err)
+				mu.Lock()
+				uuids[u] = struct{}{}
+				tokens[tk] = struct{}{}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Len(t, uuids, workers*perWorker)
+	assert.Len(t, tokens, workers*perWorker)
+}
+
+// TestParse 验证 UUID 文本形式的解析。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestParse(t *testing.T) {
+	const canonical = "0189e1b2-3c4d-7e5f-8a6b-7c8d9e0fa1b2"
+
+	tests := []struct {
+		name        string
+		description string
+		input       string
+		wantErr     bool
+	}{
+		{name: "success/canonical", description: "验证 8-4-4-4-12 格式。", input: canonical},
+		{name: "success/upper", description: "验证大写十六进制。", input: strings.ToUpper(canonical)},
+		{name: "success/compact", description: "验证不含连字符的 32 位十六进制。", input: strings.ReplaceAll(canonical, "-", "")},
+		{name: "success/urn", description: "验证 urn:uuid: 前缀。", input: "urn:uuid:" + canonical},
+		{name: "success/braces", description: "验证花括号。", input: "{" + canonical + "}"},
+		{name: "error/empty", description: "验证空字符串。", input: "", wantErr: true},
+		{name: "error/hyphen", description: "验证连字符位置错误。", input: "0189e1b23-c4d-7e5f-8a6b-7c8d9e0fa1b2", wantErr: true},
+		{name: "error/hex", description: "验证非十六进制字符。", input: "0189e1b2-3c4d-7e5f-8a6b-7c8d9e0fa1bz", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			u, err := Parse(tt.input)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidUUID)
+				assert.True(t, u.IsNil())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, canonical, u.String())
+			assert.Equal(t, 7, u.Version())
+			assert.Equal(t, int64(0x0189e1b23c4d), u.Time().UnixMilli())
+		})
+	}
+}
+
+// TestUUID_Encoding 验证 UUID 的 JSON 与数据库值转换。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestUUID_Encoding(t *testing.T) {
+	t.Log("验证 UUID 通过 JSON 往返，并能从文本、16 字节二进制与 nil 扫描。")
+
+	u, err := NewV7()
+	require.NoError(t, err)
+
+	data, err := json.Marshal(map[string]UUID{"id": u})
+	require.NoError(t, err)
+	assert.Equal(t, `{"id":"`+u.String()+`"}`, string(data))
+	var decoded map[string]UUID
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, u, decoded["id"])
+
+	v, err := u.Value()
+	require.NoError(t, err)
+	assert.Equal(t, u.String(), v)
+
+	var scanned UUID
+	require.NoError(t, scanned.Scan(u.String()))
+	assert.Equal(t, u, scanned)
+	require.NoError(t, scanned.Scan(u[:]))
+	assert.Equal(t, u, scanned)
+	require.NoError(t, scanned.Scan(nil))
+	assert.True(t, scanned.IsNil())
+	assert.ErrorIs(t, scanned.Scan(42), ErrInvalidUUID)
+	assert.ErrorIs(t, scanned.UnmarshalText([]byte("bad")), ErrInvalidUUID)
+
+	assert.Equal(t, time.Time{}, Nil.Time(), "非 UUIDv7 不返回时间")
+}
+
+// TestToken 验证 Token 的编码、解析与排序。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestToken(t *testing.T) {
+	var maxToken Token
+	for i := range maxToken {
+		maxToken[i] = 0xFF
+	}
+
+	tests := []struct {
+		name        string
+		description string
+		token       Token
+		want        string
+	}{
+		{name: "boundary/zero", description: "验证零值编码为 26 个 0。", token: Token{}, want: strings.Repeat("0", 26)},
+		{name: "boundary/max", description: "验证最大值的首字符为 7。", token: maxToken, want: "7" + strings.Repeat("Z", 25)},
+		{name: "success/one", description: "验证最低位编码在末尾。", token: Token{15: 1}, want: strings.Repeat("0", 25) + "1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			assert.Equal(t, tt.want, tt.token.String())
+			parsed, err := ParseToken(tt.want)
+			require.NoError(t, err)
+			assert.Equal(t, tt.token, parsed)
+			parsed, err = ParseToken(strings.ToLower(tt.want))
+			require.NoError(t, err)
+			assert.Equal(t, tt.token, parsed)
+		})
+	}
+
+	now, set := fixedClock(1700000000000)
+	g := NewGenerator(WithClock(now))
+	var texts []string
+	for i := 0; i < 50; i++ {
+		if 25 == i {
+			set(1700000000500)
+		}
+		tk, err := g.NewToken()
+		require.NoError(t, err)
+		texts = append(texts, tk.String())
+	}
+	assert.True(t, sort.StringsAreSorted(texts), "Token 文本的字典序与生成顺序一致")
+	first, err := ParseToken(texts[0])
+	require.NoError(t, err)
+	assert.Equal(t, time.UnixMilli(1700000000000), first.Time())
+	last, err := ParseToken(texts[49])
+	require.NoError(t, err)
+	assert.Equal(t, time.UnixMilli(1700000000500), last.Time())
+}
+
+// TestParseToken_Errors 验证非法 Token 文本的解析错误与 Crockford 易混淆字符的映射。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestParseToken_Errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		input       string
+		wantErr     bool
+	}{
+		{name: "success/confusable", description: "验证 I、L 解析为 1，O 解析为 0。", input: strings.Repeat("0", 23) + "OIL"},
+		{name: "error/length", description: "验证长度不是 26。", input: "01ARZ3NDEKTSV4RRFFQ69G5FA", wantErr: true},
+		{name: "error/char", description: "验证不属于字母表的 U。", input: strings.Repeat("0", 25) + "U", wantErr: true},
+		{name: "error/overflow", description: "验证首字符大于 7 时超出 128 位。", input: "8" + strings.Repeat("0", 25), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			tk, err := ParseToken(tt.input)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidToken)
+				assert.Equal(t, Token{}, tk)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, strings.Repeat("0", 23)+"011", tk.String())
+		})
+	}
+
+	var tk Token
+	require.NoError(t, tk.Scan(strings.Repeat("0", 25)+"1"))
+	assert.Equal(t, Token{15: 1}, tk)
+	require.NoError(t, tk.Scan(Token{15: 2}.String()))
+	raw := Token{15: 3}
+	require.NoError(t, tk.Scan(raw[:]))
+	assert.Equal(t, raw, tk)
+	v, err := tk.Value()
+	require.NoError(t, err)
+	assert.Equal(t, raw.String(), v)
+	assert.ErrorIs(t, tk.Scan(1.5), ErrInvalidToken)
+}
@@ -0,0 +1,287 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	kitcache "github.com/fsyyft-go/kit/cache"
+)
+
+const (
+	// invalidateChannel 是 Redis 在 RESP2 重定向模式下发布失效通知的频道。
+	invalidateChannel = "__redis__:invalidate"
+)
+
+var (
+	// invalidateRetryDefault 是失效订阅连接断开后重新接收前的等待时间。
+	invalidateRetryDefault = time.Second
+)
+
+type (
+	// clientSideCacheProvider 由启用客户端缓存的 Redis 实现提供，RedisExtension 通过它识别本地缓存。
+	clientSideCacheProvider interface {
+		// clientSideCache 返回客户端缓存；未启用时返回 nil。
+		clientSideCache() *clientSideCache
+	}
+
+	// clientSideCache 基于 Redis 6 的键跟踪实现客户端缓存。
+	//
+	// 失效通知使用广播模式（CLIENT TRACKING ON BCAST），由独立的订阅连接通过 REDIRECT 指向自身并订阅
+	// __redis__:invalidate 频道接收，因此业务连接无需开启跟踪，也不依赖 RESP3。订阅连接未就绪或断开期间
+	// 不读写本地缓存，重新建立跟踪时清空本地缓存，避免使用断连期间错过失效通知的旧值。
+	clientSideCache struct {
+		// local 是保存键值的本地缓存，必须由本实例独占。
+		local kitcache.Cache
+		// prefixes 是参与缓存的键前缀，为空时缓存全部键。
+		prefixes []string
+
+		// client 是只用于接收失效通知的底层客户端。
+		client *goredis.Client
+		// pubsub 是订阅失效频道的连接。
+		pubsub *goredis.PubSub
+
+		// ready 表示失效订阅已生效，可以读写本地缓存。
+		ready atomic.Bool
+		// seq 在每次失效时递增，用于丢弃读取期间发生失效的结果。
+		seq atomic.Uint64
+
+		// cancel 用于结束接收循环。
+		cancel context.CancelFunc
+		// done 在接收循环退出时关闭。
+		done chan struct{}
+	}
+)
+
+// WithClientSideCache 启用 Redis 6 客户端缓存。
+//
+// 启用后，通过 RedisExtension.Get 读取且匹配前缀的键会保存到 local 中，之后的读取直接命中本地缓存；
+// 其他客户端修改这些键时，Redis 推送失效通知，本地副本随即删除。通过 RedisExtension 的 Set、Del、Expire
+// 写入的键会立即从本地删除。NewRedis 会额外建立一条订阅连接接收失效通知，该连接就绪之前读取直接访问 Redis。
+//
+// 参数：
+//   - local: 保存键值的本地缓存，必须由本客户端独占，重连或收到全量失效通知时会被整体清空；为 nil 时不启用。
+//   - prefixes: 参与缓存的键前缀，同时作为 CLIENT TRACKING 的 PREFIX 参数以减少无关通知；为空时缓存全部键。
+//
+// 返回：
+//   - Option: 应用于 NewRedis 的客户端缓存配置项。
+func WithClientSideCache(local kitcache.Cache, prefixes ...string) Option {
+	return func(o *redisClient) {
+		o.localCache = local
+		o.cachePrefixes = append([]string(nil), prefixes...)
+	}
+}
+
+// newClientSideCache 创建客户端缓存并启动失效通知的接收循环。
+//
+// 参数：
+//   - opt: 失效订阅连接使用的连接配置，协议会被固定为 RESP2。
+//   - local: 保存键值的本地缓存。
+//   - prefixes: 参与缓存的键前缀。
+//
+// 返回：
+//   - *clientSideCache: 已启动的客户端缓存。
+func newClientSideCache(opt *goredis.Options, local kitcache.Cache, prefixes []string) *clientSideCache {
+	c := &clientSideCache{
+		local:    local,
+		prefixes: prefixes,
+		done:     make(chan struct{}),
+	}
+
+	opt.Protocol = 2
+	opt.OnConnect = c.track
+	c.client = goredis.NewClient(opt)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+	c.pubsub = c.client.Subscribe(ctx)
+	go c.receive(ctx)
+	return c
+}
+
+// track 在订阅连接建立时开启键跟踪，并将失效通知重定向到该连接自身。
+//
+// 参数：
+//   - ctx: 控制命令执行生命周期的上下文。
+//   - cn: 新建立的订阅连接。
+//
+// 返回：
+//   - error: 获取连接 ID 或开启跟踪失败时返回错误，go-redis 会关闭该连接并在下次接收时重连。
+func (c *clientSideCache) track(ctx context.Context, cn *goredis.Conn) error {
+	id, err := cn.ClientID(ctx).Result()
+	if nil != err {
+		return fmt.Errorf("获取失效订阅连接 ID 失败：%w", err)
+	}
+	args := []interface{}{"CLIENT", "TRACKING", "ON", "REDIRECT", id, "BCAST"}
+	for _, prefix := range c.prefixes {
+		args = append(args, "PREFIX", prefix)
+	}
+	if err := cn.Do(ctx, args...).Err(); nil != err {
+		return fmt.Errorf("开启键跟踪失败：%w", err)
+	}
+	// 新连接建立之前可能错过了失效通知。
+	c.invalidateAll()
+	return nil
+}
+
+// receive 循环接收失效通知，直到 ctx 结束。
+//
+// 参数：
+//   - ctx: 接收循环的生命周期。
+func (c *clientSideCache) receive(ctx context.Context) {
+	defer close(c.done)
+
+	if err := c.pubsub.Subscribe(ctx, invalidateChannel); nil != err {
+		c.reset()
+	}
+	for {
+		msg, err := c.pubsub.Receive(ctx)
+		if nil != err {
+			c.reset()
+			if nil != ctx.Err() {
+				return
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(invalidateRetryDefault):
+			}
+			continue
+		}
+		c.handle(msg)
+	}
+}
+
+// handle 处理订阅连接收到的单条消息。
+//
+// 参数：
+//   - msg: PubSub.Receive 返回的消息。
+func (c *clientSideCache) handle(msg interface{}) {
+	switch m := msg.(type) {
+	case *goredis.Subscription:
+		if "subscribe" == m.Kind && invalidateChannel == m.Channel {
+			c.invalidateAll()
+			c.ready.Store(true)
+		}
+	case *goredis.Message:
+		if invalidateChannel != m.Channel {
+			return
+		}
+		// FLUSHALL、FLUSHDB 时 Redis 发送空的失效列表。
+		if 0 == len(m.PayloadSlice) {
+			c.invalidateAll()
+			return
+		}
+		for _, key := range m.PayloadSlice {
+			c.invalidate(key)
+		}
+	}
+}
+
+// reset 在订阅连接不可用时停止使用本地缓存并清空已有内容。
+func (c *clientSideCache) reset() {
+	c.ready.Store(false)
+	c.invalidateAll()
+}
+
+// cacheable 判断键是否参与客户端缓存。
+//
+// 参数：
+//   - key: Redis 键名。
+//
+// 返回：
+//   - bool: 键匹配任一前缀或未配置前缀时返回 true。
+func (c *clientSideCache) cacheable(key string) bool {
+	if 0 == len(c.prefixes) {
+		return true
+	}
+	for _, prefix := range c.prefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// get 优先从本地缓存读取键值，未命中时通过 redis 执行 GET 并在结果仍然有效时写入本地缓存。
+//
+// 参数：
+//   - ctx: 控制命令执行生命周期的上下文。
+//   - redis: 执行 GET 的 Redis 实例。
+//   - key: Redis 键名。
+//
+// 返回：
+//   - *Cmd: GET 命令结果；本地命中时为携带缓存值的已完成命令。
+func (c *clientSideCache) get(ctx context.Context, redis Redis, key string) *Cmd {
+	if !c.ready.Load() || !c.cacheable(key) {
+		return redis.Do(ctx, "GET", key)
+	}
+	if value, ok := c.local.Get(key); ok {
+		cmd := goredis.NewCmd(ctx, "GET", key)
+		cmd.SetVal(value)
+		return cmd
+	}
+
+	seq := c.seq.Load()
+	cmd := redis.Do(ctx, "GET", key)
+	if nil != cmd.Err() {
+		return cmd
+	}
+	// 读取期间发生过失效或订阅断开时，结果可能已经过期，不写入本地缓存。
+	if c.ready.Load() && seq == c.seq.Load() {
+		c.local.Set(key, cmd.Val())
+		// 再次确认，避免与写入并发的失效被覆盖。
+		if seq != c.seq.Load() {
+			c.local.Delete(key)
+		}
+	}
+	return cmd
+}
+
+// invalidate 删除单个键的本地副本。
+//
+// 参数：
+//   - key: Redis 键名。
+func (c *clientSideCache) invalidate(key string) {
+	c.seq.Add(1)
+	c.local.Delete(key)
+}
+
+// invalidateAll 清空本地缓存。
+func (c *clientSideCache) invalidateAll() {
+	c.seq.Add(1)
+	c.local.Clear()
+}
+
+// close 停止接收循环并关闭失效订阅连接。
+//
+// 参数：无。
+//
+// 返回：
+//   - error: 关闭订阅连接或底层客户端失败时返回错误。
+func (c *clientSideCache) close() error {
+	c.cancel()
+	err := c.pubsub.Close()
+	<-c.done
+	c.reset()
+	return errors.Join(err, c.client.Close())
+}
+
+// clientSideCache 返回客户端缓存；未启用时返回 nil。
+//
+// 参数：无。
+//
+// 返回：
+//   - *clientSideCache: 客户端缓存。
+func (c *redisClient) clientSideCache() *clientSideCache {
+	return c.csc
+}
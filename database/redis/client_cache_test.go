@@ -0,0 +1,327 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package redis
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	kitcache "github.com/fsyyft-go/kit/cache"
+)
+
+var (
+	// 空赋值确保 mapCache 实现了 kitcache.Cache 接口。
+	_ kitcache.Cache = (*mapCache)(nil)
+	// 空赋值确保 redisClient 实现了 clientSideCacheProvider 接口。
+	_ clientSideCacheProvider = (*redisClient)(nil)
+)
+
+// mapCache 是基于 map 的本地缓存，写入立即可见，便于断言客户端缓存行为。
+type mapCache struct {
+	mu sync.Mutex
+	m  map[interface{}]interface{}
+}
+
+// newMapCache 创建空的 mapCache。
+func newMapCache() *mapCache {
+	return &mapCache{m: make(map[interface{}]interface{})}
+}
+
+// Get 读取键值。
+func (c *mapCache) Get(key interface{}) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	v, ok := c.m[key]
+	return v, ok
+}
+
+// GetWithTTL 读取键值，剩余时间恒为 0。
+func (c *mapCache) GetWithTTL(key interface{}) (interface{}, bool, time.Duration) {
+	v, ok := c.Get(key)
+	return v, ok, 0
+}
+
+// Set 写入键值。
+func (c *mapCache) Set(key interface{}, value interface{}) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.m[key] = value
+	return true
+}
+
+// SetWithTTL 写入键值并忽略过期时间。
+func (c *mapCache) SetWithTTL(key interface{}, value interface{}, _ time.Duration) bool {
+	return c.Set(key, value)
+}
+
+// Delete 删除键。
+func (c *mapCache) Delete(key interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.m, key)
+}
+
+// Clear 清空全部键。
+func (c *mapCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.m = make(map[interface{}]interface{})
+}
+
+// Stats 不记录统计，返回零值。
+func (c *mapCache) Stats() kitcache.CacheStats {
+	return kitcache.CacheStats{}
+}
+
+// Close 不做任何操作。
+func (c *mapCache) Close() error {
+	return nil
+}
+
+// countCommand 统计内存 RESP 服务收到指定命令的次数。
+//
+// 参数：
+//   - s: 内存 RESP 服务。
+//   - name: Redis 命令名。
+//   - args: 命令参数。
+//
+// 返回值：
+//   - int: 完全匹配的命令次数。
+func countCommand(s *memoryRedisServer, name string, args ...string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n := 0
+	for _, record := range s.records {
+		if record.name == name && equalStrings(record.args, args) {
+			n++
+		}
+	}
+	return n
+}
+
+// newCachedMemoryRedisClient 构造挂载客户端缓存的内存 Redis 客户端。
+//
+// 客户端缓存不启动失效订阅，由测试直接调用 handle 模拟订阅确认与失效通知。
+//
+// 参数：
+//   - t: 测试上下文。
+//   - prefixes: 参与缓存的键前缀。
+//
+// 返回值：
+//   - RedisExtension: 被测扩展。
+//   - *clientSideCache: 挂载的客户端缓存。
+//   - *mapCache: 本地缓存。
+//   - *memoryRedisServer: 内存 RESP 服务。
+func newCachedMemoryRedisClient(t *testing.T, prefixes ...string) (RedisExtension, *clientSideCache, *mapCache, *memoryRedisServer) {
+	t.Helper()
+
+	client, server := newMemoryRedisClient(t)
+	local := newMapCache()
+	client.csc = &clientSideCache{local: local, prefixes: prefixes}
+	return NewRedisExtension(client), client.csc, local, server
+}
+
+// TestWithClientSideCache 验证客户端缓存选项的配置行为。
+func TestWithClientSideCache(t *testing.T) {
+	local := newMapCache()
+	prefixes := []string{"user:"}
+	client := &redisClient{}
+	WithClientSideCache(local, prefixes...)(client)
+	prefixes[0] = "changed:"
+
+	assert.Same(t, local, client.localCache)
+	assert.Equal(t, []string{"user:"}, client.cachePrefixes)
+	assert.Nil(t, client.clientSideCache())
+}
+
+// TestClientSideCache_Handle 验证订阅确认与失效通知的处理。
+func TestClientSideCache_Handle(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		give        interface{}
+		wantReady   bool
+		wantKeys    []string
+	}{
+		{
+			name:        "success/subscribe",
+			description: "验证订阅确认后清空本地缓存并进入就绪状态。",
+			give:        &goredis.Subscription{Kind: "subscribe", Channel: invalidateChannel, Count: 1},
+			wantReady:   true,
+		},
+		{
+			name:        "success/invalidate-keys",
+			description: "验证失效通知只删除列出的键。",
+			give:        &goredis.Message{Channel: invalidateChannel, PayloadSlice: []string{"a", "c"}},
+			wantKeys:    []string{"b"},
+		},
+		{
+			name:        "success/invalidate-all",
+			description: "验证空的失效列表清空本地缓存。",
+			give:        &goredis.Message{Channel: invalidateChannel},
+		},
+		{
+			name:        "ignore/other-channel",
+			description: "验证忽略其他频道的消息。",
+			give:        &goredis.Message{Channel: "other", PayloadSlice: []string{"a"}},
+			wantKeys:    []string{"a", "b", "c"},
+		},
+		{
+			name:        "ignore/pong",
+			description: "验证忽略心跳响应。",
+			give:        &goredis.Pong{},
+			wantKeys:    []string{"a", "b", "c"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			local := newMapCache()
+			for _, key := range []string{"a", "b", "c"} {
+				local.Set(key, key)
+			}
+			c := &clientSideCache{local: local}
+			seq := c.seq.Load()
+
+			c.handle(tt.give)
+
+			assert.Equal(t, tt.wantReady, c.ready.Load())
+			keys := make([]string, 0)
+			for _, key := range []string{"a", "b", "c"} {
+				if _, ok := local.Get(key); ok {
+					keys = append(keys, key)
+				}
+			}
+			assert.Equal(t, append([]string{}, tt.wantKeys...), keys)
+			if len(tt.wantKeys) < 3 {
+				assert.Greater(t, c.seq.Load(), seq)
+			}
+		})
+	}
+}
+
+// TestClientSideCache_Cacheable 验证键前缀匹配。
+func TestClientSideCache_Cacheable(t *testing.T) {
+	assert.True(t, (&clientSideCache{}).cacheable("any"))
+
+	c := &clientSideCache{prefixes: []string{"user:", "conf:"}}
+	assert.True(t, c.cacheable("user:1"))
+	assert.True(t, c.cacheable("conf:app"))
+	assert.False(t, c.cacheable("order:1"))
+}
+
+// TestRedisExtension_GetWithClientSideCache 验证扩展读取时使用本地缓存并在写入时失效。
+func TestRedisExtension_GetWithClientSideCache(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("not-ready", func(t *testing.T) {
+		t.Log("验证失效订阅就绪之前每次读取都访问 Redis 且不写入本地缓存。")
+
+		ext, _, local, server := newCachedMemoryRedisClient(t)
+		require.NoError(t, ext.Set(ctx, "k", "v", 0).Err())
+		for i := 0; i < 2; i++ {
+			got, err := ext.Get(ctx, "k").Result()
+			require.NoError(t, err)
+			assert.Equal(t, "v", got)
+		}
+		assert.Equal(t, 2, countCommand(server, "GET", "k"))
+		_, ok := local.Get("k")
+		assert.False(t, ok)
+	})
+
+	t.Run("hit", func(t *testing.T) {
+		t.Log("验证就绪后第二次读取命中本地缓存。")
+
+		ext, csc, _, server := newCachedMemoryRedisClient(t)
+		csc.ready.Store(true)
+		require.NoError(t, ext.Set(ctx, "k", "v", 0).Err())
+		for i := 0; i < 3; i++ {
+			got, err := ext.Get(ctx, "k").Result()
+			require.NoError(t, err)
+			assert.Equal(t, "v", got)
+		}
+		assert.Equal(t, 1, countCommand(server, "GET", "k"))
+	})
+
+	t.Run("write-invalidates", func(t *testing.T) {
+		t.Log("验证通过扩展写入、删除和设置过期时间会删除本地副本。")
+
+		ext, csc, local, server := newCachedMemoryRedisClient(t)
+		csc.ready.Store(true)
+		require.NoError(t, ext.Set(ctx, "k", "v1", 0).Err())
+		require.NoError(t, ext.Get(ctx, "k").Err())
+
+		require.NoError(t, ext.Set(ctx, "k", "v2", 0).Err())
+		got, err := ext.Get(ctx, "k").Result()
+		require.NoError(t, err)
+		assert.Equal(t, "v2", got)
+		assert.Equal(t, 2, countCommand(server, "GET", "k"))
+
+		require.NoError(t, ext.Expire(ctx, "k", time.Minute).Err())
+		_, ok := local.Get("k")
+		assert.False(t, ok)
+
+		require.NoError(t, ext.Get(ctx, "k").Err())
+		require.NoError(t, ext.Del(ctx, "k").Err())
+		assert.ErrorIs(t, ext.Get(ctx, "k").Err(), goredis.Nil)
+		_, ok = local.Get("k")
+		assert.False(t, ok)
+	})
+
+	t.Run("push-invalidates", func(t *testing.T) {
+		t.Log("验证收到失效通知后重新从 Redis 读取。")
+
+		ext, csc, _, server := newCachedMemoryRedisClient(t)
+		csc.ready.Store(true)
+		require.NoError(t, ext.Set(ctx, "k", "v", 0).Err())
+		require.NoError(t, ext.Get(ctx, "k").Err())
+
+		csc.handle(&goredis.Message{Channel: invalidateChannel, PayloadSlice: []string{"k"}})
+		require.NoError(t, ext.Get(ctx, "k").Err())
+		assert.Equal(t, 2, countCommand(server, "GET", "k"))
+	})
+
+	t.Run("prefix", func(t *testing.T) {
+		t.Log("验证不匹配前缀的键不写入本地缓存。")
+
+		ext, csc, local, server := newCachedMemoryRedisClient(t, "user:")
+		csc.ready.Store(true)
+		require.NoError(t, ext.Set(ctx, "order:1", "v", 0).Err())
+		require.NoError(t, ext.Set(ctx, "user:1", "v", 0).Err())
+		for i := 0; i < 2; i++ {
+			require.NoError(t, ext.Get(ctx, "order:1").Err())
+			require.NoError(t, ext.Get(ctx, "user:1").Err())
+		}
+		assert.Equal(t, 2, countCommand(server, "GET", "order:1"))
+		assert.Equal(t, 1, countCommand(server, "GET", "user:1"))
+		_, ok := local.Get("order:1")
+		assert.False(t, ok)
+	})
+
+	t.Run("reset", func(t *testing.T) {
+		t.Log("验证订阅断开后停止使用并清空本地缓存。")
+
+		ext, csc, local, server := newCachedMemoryRedisClient(t)
+		csc.ready.Store(true)
+		require.NoError(t, ext.Set(ctx, "k", "v", 0).Err())
+		require.NoError(t, ext.Get(ctx, "k").Err())
+
+		csc.reset()
+		_, ok := local.Get("k")
+		assert.False(t, ok)
+		require.NoError(t, ext.Get(ctx, "k").Err())
+		assert.Equal(t, 2, countCommand(server, "GET", "k"))
+	})
+}
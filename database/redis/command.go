@@ -5,6 +5,8 @@
 package redis
 
 import (
+	"errors"
+
 	"github.com/redis/go-redis/v9"
 )
 
@@ -24,6 +26,12 @@ var (
 	//
 	// 该错误是 go-redis/v9 的 redis.TxFailedErr 别名，通常由 WATCH 相关事务在提交阶段返回。
 	TxFailedErr = redis.TxFailedErr //nolint:errname
+
+	// ErrInvalidArgument 表示扩展命令的参数不合法，命令不会发送到 Redis。
+	ErrInvalidArgument = errors.New("redis: invalid argument")
+
+	// ErrUnexpectedReply 表示 Redis 返回的结果类型与扩展命令期望的不一致。
+	ErrUnexpectedReply = errors.New("redis: unexpected reply")
 )
 
 // 基础命令接口定义。
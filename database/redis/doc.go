@@ -9,4 +9,14 @@
 //
 // RedisExtension 在基础接口上补充常用 KV 与过期操作；ScriptFlush 和 ScriptKill 会按底层实现暴露的能力分派，
 // 当通过 NewRedisExtension 包装的底层实现未提供对应方法时返回 nil，调用方需要显式处理。
+//
+// RedisExtension 还为 GEO、HyperLogLog 与位图命令提供带类型的方法，直接返回解析后的结果和错误；
+// PFCountEach、SetBits 与 GetBits 等批量方法在同一管道中执行，结果顺序与传入参数一致。
+//
+// BulkGet 与 BulkSet 基于 MGET/MSET 与管道批量读写字符串值，键数较多时自动拆分为多条命令，整体只需一次往返；
+// BulkGetAs、BulkSetAs、BulkGetJSON 与 BulkSetJSON 在其上提供带类型的泛型变体。
+//
+// WithClientSideCache 基于 Redis 6 的键跟踪（CLIENT TRACKING BCAST）启用客户端缓存：RedisExtension.Get
+// 读取的键保存在本地 kit 缓存中，其他客户端修改这些键时由独立订阅连接接收失效通知并删除本地副本；
+// 订阅连接断开期间不使用本地缓存，重连后整体清空。
 package redis
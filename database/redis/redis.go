@@ -6,8 +6,11 @@ package redis
 
 import (
 	"context"
+	"errors"
 
 	goredis "github.com/redis/go-redis/v9"
+
+	kitcache "github.com/fsyyft-go/kit/cache"
 )
 
 type (
@@ -88,6 +91,13 @@ type (
 		addr string
 		// password 是连接 Redis 服务器时使用的认证密码。
 		password string
+
+		// localCache 是客户端缓存使用的本地缓存，为 nil 时不启用客户端缓存。
+		localCache kitcache.Cache
+		// cachePrefixes 是参与客户端缓存的键前缀。
+		cachePrefixes []string
+		// csc 是已启用的客户端缓存。
+		csc *clientSideCache
 	}
 )
 
@@ -113,6 +123,12 @@ func NewRedis(opts ...Option) Redis {
 		Addr:     o.addr,
 		Password: o.password,
 	})
+	if nil != o.localCache {
+		o.csc = newClientSideCache(&goredis.Options{
+			Addr:     o.addr,
+			Password: o.password,
+		}, o.localCache, o.cachePrefixes)
+	}
 
 	return o
 }
@@ -286,7 +302,10 @@ func (c *redisClient) ScriptKill(ctx context.Context) *StatusCmd {
 // 参数：无。
 //
 // 返回：
-//   - error: 底层 go-redis 客户端关闭失败时返回错误。
+//   - error: 底层 go-redis 客户端或客户端缓存的订阅连接关闭失败时返回错误。
 func (c *redisClient) Close() error {
-	return c.client.Close()
+	if nil == c.csc {
+		return c.client.Close()
+	}
+	return errors.Join(c.csc.close(), c.client.Close())
 }
@@ -81,6 +81,160 @@ type (
 		// 返回：
 		//   - *StatusCmd: 底层支持脚本终止能力时返回对应命令；否则返回 nil。
 		ScriptKill(ctx context.Context) *StatusCmd
+
+		// GeoAdd 将一个或多个地理位置写入 GEO 集合。
+		//
+		// 参数：
+		//   - ctx: 控制命令执行生命周期的上下文。
+		//   - key: GEO 集合的 Redis 键名。
+		//   - locations: 要写入的位置，只使用 Name、Longitude 与 Latitude；为空时不发送命令。
+		//
+		// 返回：
+		//   - int64: 新增的成员数量，更新已有成员的坐标不计入。
+		//   - error: 命令执行失败或结果类型不符合预期时返回错误。
+		GeoAdd(ctx context.Context, key string, locations ...GeoLocation) (int64, error)
+
+		// GeoSearch 使用 GEOSEARCH 查询指定范围内的成员。
+		//
+		// 参数：
+		//   - ctx: 控制命令执行生命周期的上下文。
+		//   - key: GEO 集合的 Redis 键名。
+		//   - query: 查询条件；Member 非空时以成员为中心，否则以经纬度为中心；Radius 为正时按半径查询，否则按矩形查询。
+		//
+		// 返回：
+		//   - []GeoLocation: 查询结果，Dist、GeoHash 与坐标只在对应的 With 选项开启时填充。
+		//   - error: 查询条件不合法时返回 ErrInvalidArgument，命令执行失败或结果类型不符合预期时返回错误。
+		GeoSearch(ctx context.Context, key string, query *GeoSearchLocationQuery) ([]GeoLocation, error)
+
+		// PFAdd 向 HyperLogLog 添加元素。
+		//
+		// 参数：
+		//   - ctx: 控制命令执行生命周期的上下文。
+		//   - key: HyperLogLog 的 Redis 键名。
+		//   - elements: 要添加的元素；为空时仅在键不存在时创建空结构。
+		//
+		// 返回：
+		//   - bool: 内部寄存器是否发生变化，即基数估算值可能改变。
+		//   - error: 命令执行失败时返回错误。
+		PFAdd(ctx context.Context, key string, elements ...interface{}) (bool, error)
+
+		// PFCount 返回一个或多个 HyperLogLog 并集的基数估算值。
+		//
+		// 参数：
+		//   - ctx: 控制命令执行生命周期的上下文。
+		//   - keys: HyperLogLog 的 Redis 键名列表；为空时返回 0 且不发送命令。
+		//
+		// 返回：
+		//   - int64: 并集的基数估算值。
+		//   - error: 命令执行失败时返回错误。
+		PFCount(ctx context.Context, keys ...string) (int64, error)
+
+		// PFCountEach 在同一管道中分别统计每个 HyperLogLog 的基数估算值。
+		//
+		// 参数：
+		//   - ctx: 控制管道执行生命周期的上下文。
+		//   - keys: HyperLogLog 的 Redis 键名列表；为空时返回空结果且不发送命令。
+		//
+		// 返回：
+		//   - []int64: 与 keys 顺序一致的基数估算值。
+		//   - error: 任一命令执行失败时返回错误。
+		PFCountEach(ctx context.Context, keys ...string) ([]int64, error)
+
+		// PFMerge 将多个 HyperLogLog 合并到目标键。
+		//
+		// 参数：
+		//   - ctx: 控制命令执行生命周期的上下文。
+		//   - dest: 合并结果写入的 Redis 键名。
+		//   - sources: 参与合并的 Redis 键名列表。
+		//
+		// 返回：
+		//   - error: 命令执行失败时返回错误。
+		PFMerge(ctx context.Context, dest string, sources ...string) error
+
+		// SetBit 设置位图中指定偏移量的位。
+		//
+		// 参数：
+		//   - ctx: 控制命令执行生命周期的上下文。
+		//   - key: 位图的 Redis 键名。
+		//   - offset: 位偏移量。
+		//   - value: true 表示置 1，false 表示置 0。
+		//
+		// 返回：
+		//   - bool: 该位原来的值。
+		//   - error: 命令执行失败时返回错误。
+		SetBit(ctx context.Context, key string, offset int64, value bool) (bool, error)
+
+		// GetBit 读取位图中指定偏移量的位。
+		//
+		// 参数：
+		//   - ctx: 控制命令执行生命周期的上下文。
+		//   - key: 位图的 Redis 键名。
+		//   - offset: 位偏移量。
+		//
+		// 返回：
+		//   - bool: 该位是否为 1；键不存在或偏移量超出长度时为 false。
+		//   - error: 命令执行失败时返回错误。
+		GetBit(ctx context.Context, key string, offset int64) (bool, error)
+
+		// SetBits 在同一管道中将多个偏移量设置为相同的值。
+		//
+		// 参数：
+		//   - ctx: 控制管道执行生命周期的上下文。
+		//   - key: 位图的 Redis 键名。
+		//   - value: true 表示置 1，false 表示置 0。
+		//   - offsets: 位偏移量列表；为空时返回空结果且不发送命令。
+		//
+		// 返回：
+		//   - []bool: 与 offsets 顺序一致的原值。
+		//   - error: 任一命令执行失败时返回错误。
+		SetBits(ctx context.Context, key string, value bool, offsets ...int64) ([]bool, error)
+
+		// GetBits 在同一管道中读取多个偏移量的位。
+		//
+		// 参数：
+		//   - ctx: 控制管道执行生命周期的上下文。
+		//   - key: 位图的 Redis 键名。
+		//   - offsets: 位偏移量列表；为空时返回空结果且不发送命令。
+		//
+		// 返回：
+		//   - []bool: 与 offsets 顺序一致的位值。
+		//   - error: 任一命令执行失败时返回错误。
+		GetBits(ctx context.Context, key string, offsets ...int64) ([]bool, error)
+
+		// BitCount 统计位图中值为 1 的位数。
+		//
+		// 参数：
+		//   - ctx: 控制命令执行生命周期的上下文。
+		//   - key: 位图的 Redis 键名。
+		//   - bitCount: 统计范围；为 nil 时统计整个位图，Unit 为空时按字节解释范围。
+		//
+		// 返回：
+		//   - int64: 值为 1 的位数。
+		//   - error: 命令执行失败时返回错误。
+		BitCount(ctx context.Context, key string, bitCount *BitCount) (int64, error)
+
+		// BulkGet 使用 MGET 批量读取多个键的字符串值。
+		//
+		// 参数：
+		//   - ctx: 控制管道执行生命周期的上下文。
+		//   - keys: 要读取的 Redis 键名列表；为空时返回空结果且不发送命令。
+		//
+		// 返回：
+		//   - map[string]string: 存在的键及其值。
+		//   - []string: 不存在的键，顺序与 keys 中首次出现的顺序一致。
+		//   - error: 命令执行失败或结果类型不符合预期时返回错误。
+		BulkGet(ctx context.Context, keys ...string) (map[string]string, []string, error)
+
+		// BulkSet 在同一管道中批量写入多个键的字符串值。
+		//
+		// 参数：
+		//   - ctx: 控制管道执行生命周期的上下文。
+		//   - values: 要写入的键值对；为空时不发送命令。
+		//   - ttl: 键过期时间；非正值使用 MSET 且不设置过期时间，正值对每个键使用带过期参数的 SET。
+		//
+		// 返回：
+		//   - error: 任一命令执行失败时返回错误。
+		BulkSet(ctx context.Context, values map[string]string, ttl time.Duration) error
 	}
 
 	// redisExtension 将 Redis 基础实现包装为 RedisExtension。
@@ -166,6 +320,28 @@ func redisExpirationUnitAndTTL(expiration time.Duration) (string, int64) {
 	return "PX", int64(ttl)
 }
 
+// pipelineDo 在同一管道中依次执行多条命令。
+//
+// 参数：
+//   - ctx: 控制管道执行生命周期的上下文。
+//   - commands: 每条命令的参数列表，按 Redis 协议顺序传递。
+//
+// 返回：
+//   - []*Cmd: 与 commands 顺序一致的命令结果。
+//   - error: 管道执行失败或任一命令失败时返回错误。
+func (r *redisExtension) pipelineDo(ctx context.Context, commands [][]interface{}) ([]*Cmd, error) {
+	cmds := make([]*Cmd, 0, len(commands))
+	if _, err := r.redis.Pipelined(ctx, func(pipe Pipeliner) error {
+		for _, args := range commands {
+			cmds = append(cmds, pipe.Do(ctx, args...))
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return cmds, nil
+}
+
 // Do 执行任意 Redis 命令。
 //
 // 参数：
@@ -310,13 +486,18 @@ func (r *redisExtension) ScriptLoad(ctx context.Context, script string) *StringC
 
 // Get 获取指定键的值。
 //
+// 底层实例通过 WithClientSideCache 启用客户端缓存时，匹配前缀的键优先读取本地副本，未命中时读取 Redis 并写入本地缓存。
+//
 // 参数：
 //   - ctx: 控制命令执行生命周期的上下文。
 //   - key: 要读取的 Redis 键名。
 //
 // 返回：
-//   - *Cmd: GET 命令结果；键不存在时 Err 通常为 ErrNil。
+//   - *Cmd: GET 命令结果；键不存在时 Err 通常为 ErrNil，不存在的键不会写入本地缓存。
 func (r *redisExtension) Get(ctx context.Context, key string) *Cmd {
+	if csc := r.clientSideCache(); nil != csc {
+		return csc.get(ctx, r.redis, key)
+	}
 	return r.redis.Do(ctx, "GET", key)
 }
 
@@ -333,6 +514,7 @@ func (r *redisExtension) Get(ctx context.Context, key string) *Cmd {
 func (r *redisExtension) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) *Cmd {
 	args := []interface{}{"SET", key, value}
 	args = append(args, redisSetExpirationArgs(expiration)...)
+	defer r.invalidateLocal(key)
 	return r.redis.Do(ctx, args...)
 }
 
@@ -345,6 +527,7 @@ func (r *redisExtension) Set(ctx context.Context, key string, value interface{},
 // 返回：
 //   - *Cmd: DEL 命令结果；执行错误由返回值的 Err 方法承载。
 func (r *redisExtension) Del(ctx context.Context, key string) *Cmd {
+	defer r.invalidateLocal(key)
 	return r.redis.Do(ctx, "DEL", key)
 }
 
@@ -358,9 +541,33 @@ func (r *redisExtension) Del(ctx context.Context, key string) *Cmd {
 // 返回：
 //   - *Cmd: 过期命令结果；执行错误由返回值的 Err 方法承载。
 func (r *redisExtension) Expire(ctx context.Context, key string, expiration time.Duration) *Cmd {
+	defer r.invalidateLocal(key)
 	return r.redis.Do(ctx, redisExpireArgs(key, expiration)...)
 }
 
+// clientSideCache 返回底层 Redis 实例启用的客户端缓存。
+//
+// 参数：无。
+//
+// 返回：
+//   - *clientSideCache: 底层实例通过 WithClientSideCache 启用客户端缓存时返回该缓存；否则返回 nil。
+func (r *redisExtension) clientSideCache() *clientSideCache {
+	if p, ok := r.redis.(clientSideCacheProvider); ok {
+		return p.clientSideCache()
+	}
+	return nil
+}
+
+// invalidateLocal 删除键在客户端缓存中的本地副本，未启用客户端缓存时不做任何操作。
+//
+// 参数：
+//   - key: Redis 键名。
+func (r *redisExtension) invalidateLocal(key string) {
+	if csc := r.clientSideCache(); nil != csc {
+		csc.invalidate(key)
+	}
+}
+
 // ScriptFlush 按底层客户端能力清空脚本缓存。
 //
 // 当底层 Redis 实现提供 ScriptFlush(context.Context) *StatusCmd 时委托调用；否则返回 nil，调用方应处理 nil 返回值。
@@ -0,0 +1,140 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package redis
+
+import (
+	"context"
+)
+
+// SetBit 设置位图中指定偏移量的位。
+//
+// 参数：
+//   - ctx: 控制命令执行生命周期的上下文。
+//   - key: 位图的 Redis 键名。
+//   - offset: 位偏移量。
+//   - value: true 表示置 1，false 表示置 0。
+//
+// 返回：
+//   - bool: 该位原来的值。
+//   - error: 命令执行失败时返回错误。
+func (r *redisExtension) SetBit(ctx context.Context, key string, offset int64, value bool) (bool, error) {
+	return r.redis.Do(ctx, "SETBIT", key, offset, bitValue(value)).Bool()
+}
+
+// GetBit 读取位图中指定偏移量的位。
+//
+// 参数：
+//   - ctx: 控制命令执行生命周期的上下文。
+//   - key: 位图的 Redis 键名。
+//   - offset: 位偏移量。
+//
+// 返回：
+//   - bool: 该位是否为 1；键不存在或偏移量超出长度时为 false。
+//   - error: 命令执行失败时返回错误。
+func (r *redisExtension) GetBit(ctx context.Context, key string, offset int64) (bool, error) {
+	return r.redis.Do(ctx, "GETBIT", key, offset).Bool()
+}
+
+// SetBits 在同一管道中将多个偏移量设置为相同的值。
+//
+// 参数：
+//   - ctx: 控制管道执行生命周期的上下文。
+//   - key: 位图的 Redis 键名。
+//   - value: true 表示置 1，false 表示置 0。
+//   - offsets: 位偏移量列表；为空时返回空结果且不发送命令。
+//
+// 返回：
+//   - []bool: 与 offsets 顺序一致的原值。
+//   - error: 任一命令执行失败时返回错误。
+func (r *redisExtension) SetBits(ctx context.Context, key string, value bool, offsets ...int64) ([]bool, error) {
+	commands := make([][]interface{}, 0, len(offsets))
+	for _, offset := range offsets {
+		commands = append(commands, []interface{}{"SETBIT", key, offset, bitValue(value)})
+	}
+	return r.pipelineBools(ctx, commands)
+}
+
+// GetBits 在同一管道中读取多个偏移量的位。
+//
+// 参数：
+//   - ctx: 控制管道执行生命周期的上下文。
+//   - key: 位图的 Redis 键名。
+//   - offsets: 位偏移量列表；为空时返回空结果且不发送命令。
+//
+// 返回：
+//   - []bool: 与 offsets 顺序一致的位值。
+//   - error: 任一命令执行失败时返回错误。
+func (r *redisExtension) GetBits(ctx context.Context, key string, offsets ...int64) ([]bool, error) {
+	commands := make([][]interface{}, 0, len(offsets))
+	for _, offset := range offsets {
+		commands = append(commands, []interface{}{"GETBIT", key, offset})
+	}
+	return r.pipelineBools(ctx, commands)
+}
+
+// BitCount 统计位图中值为 1 的位数。
+//
+// 参数：
+//   - ctx: 控制命令执行生命周期的上下文。
+//   - key: 位图的 Redis 键名。
+//   - bitCount: 统计范围；为 nil 时统计整个位图，Unit 为空时按字节解释范围。
+//
+// 返回：
+//   - int64: 值为 1 的位数。
+//   - error: 命令执行失败时返回错误。
+func (r *redisExtension) BitCount(ctx context.Context, key string, bitCount *BitCount) (int64, error) {
+	args := []interface{}{"BITCOUNT", key}
+	if bitCount != nil {
+		args = append(args, bitCount.Start, bitCount.End)
+		if bitCount.Unit != "" {
+			args = append(args, bitCount.Unit)
+		}
+	}
+	return r.redis.Do(ctx, args...).Int64()
+}
+
+// pipelineBools 在同一管道中执行返回 0 或 1 的命令，并转换为布尔值。
+//
+// 参数：
+//   - ctx: 控制管道执行生命周期的上下文。
+//   - commands: 每条命令的参数列表；为空时返回空结果且不发送命令。
+//
+// 返回：
+//   - []bool: 与 commands 顺序一致的结果。
+//   - error: 管道执行失败或任一命令失败时返回错误。
+func (r *redisExtension) pipelineBools(ctx context.Context, commands [][]interface{}) ([]bool, error) {
+	if len(commands) == 0 {
+		return []bool{}, nil
+	}
+
+	cmds, err := r.pipelineDo(ctx, commands)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]bool, 0, len(cmds))
+	for _, cmd := range cmds {
+		value, err := cmd.Bool()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+// bitValue 将布尔值转换为 SETBIT 使用的 0 或 1。
+//
+// 参数：
+//   - value: 要写入的位值。
+//
+// 返回：
+//   - int: true 返回 1，false 返回 0。
+func bitValue(value bool) int {
+	if value {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,254 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+const (
+	// bulkBatchSize 是单条 MGET 或 MSET 命令携带的最大键数，超过时拆分为同一管道中的多条命令，
+	// 避免单条命令过大阻塞 Redis。
+	bulkBatchSize = 500
+)
+
+// BulkGet 使用 MGET 批量读取多个键的字符串值。
+//
+// 键数超过单条命令上限时拆分为多条 MGET，并在同一管道中发送，整体只需一次网络往返。
+// 重复的键只读取一次。BulkGet 直接读取 Redis，不使用 WithClientSideCache 启用的本地缓存。
+//
+// 参数：
+//   - ctx: 控制管道执行生命周期的上下文。
+//   - keys: 要读取的 Redis 键名列表；为空时返回空结果且不发送命令。
+//
+// 返回：
+//   - map[string]string: 存在的键及其值。
+//   - []string: 不存在的键，顺序与 keys 中首次出现的顺序一致。
+//   - error: 命令执行失败或结果类型不符合预期时返回错误。
+func (r *redisExtension) BulkGet(ctx context.Context, keys ...string) (map[string]string, []string, error) {
+	keys = uniqueKeys(keys)
+	values := make(map[string]string, len(keys))
+	missing := make([]string, 0)
+	if len(keys) == 0 {
+		return values, missing, nil
+	}
+
+	batches := splitKeys(keys, bulkBatchSize)
+	commands := make([][]interface{}, 0, len(batches))
+	for _, batch := range batches {
+		commands = append(commands, keyArgs("MGET", batch))
+	}
+	cmds, err := r.pipelineDo(ctx, commands)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for i, cmd := range cmds {
+		replies, err := cmd.Slice()
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(replies) != len(batches[i]) {
+			return nil, nil, fmt.Errorf("%w: MGET returned %d values for %d keys", ErrUnexpectedReply, len(replies), len(batches[i]))
+		}
+		for j, reply := range replies {
+			key := batches[i][j]
+			switch v := reply.(type) {
+			case nil:
+				missing = append(missing, key)
+			case string:
+				values[key] = v
+			default:
+				return nil, nil, fmt.Errorf("%w: MGET value of %q is %T", ErrUnexpectedReply, key, reply)
+			}
+		}
+	}
+	return values, missing, nil
+}
+
+// BulkSet 在同一管道中批量写入多个键的字符串值。
+//
+// ttl 非正时使用 MSET 写入且不设置过期时间，键数超过单条命令上限时拆分为多条 MSET；ttl 为正时每个键使用一条
+// 带过期参数的 SET。所有命令在同一管道中发送，但整体不是原子操作，失败时部分键可能已经写入。
+// 写入的键在客户端缓存中的本地副本会被删除。
+//
+// 参数：
+//   - ctx: 控制管道执行生命周期的上下文。
+//   - values: 要写入的键值对；为空时不发送命令。
+//   - ttl: 键过期时间；非正值表示不设置过期时间，整秒使用 EX，非整秒向上取整为毫秒并使用 PX。
+//
+// 返回：
+//   - error: 任一命令执行失败时返回错误。
+func (r *redisExtension) BulkSet(ctx context.Context, values map[string]string, ttl time.Duration) error {
+	if len(values) == 0 {
+		return nil
+	}
+
+	// 按键排序，使发送的命令顺序稳定，便于排查问题。
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	defer func() {
+		for _, key := range keys {
+			r.invalidateLocal(key)
+		}
+	}()
+
+	var commands [][]interface{}
+	if ttl <= 0 {
+		for _, batch := range splitKeys(keys, bulkBatchSize) {
+			args := make([]interface{}, 0, 1+2*len(batch))
+			args = append(args, "MSET")
+			for _, key := range batch {
+				args = append(args, key, values[key])
+			}
+			commands = append(commands, args)
+		}
+	} else {
+		expiration := redisSetExpirationArgs(ttl)
+		commands = make([][]interface{}, 0, len(keys))
+		for _, key := range keys {
+			args := []interface{}{"SET", key, values[key]}
+			commands = append(commands, append(args, expiration...))
+		}
+	}
+
+	_, err := r.pipelineDo(ctx, commands)
+	return err
+}
+
+// BulkGetAs 使用 BulkGet 批量读取多个键，并用 decode 将值转换为 T。
+//
+// 参数：
+//   - ctx: 控制管道执行生命周期的上下文。
+//   - r: 执行批量读取的 Redis 扩展实例。
+//   - decode: 将 Redis 中的字符串值转换为 T 的函数。
+//   - keys: 要读取的 Redis 键名列表；为空时返回空结果且不发送命令。
+//
+// 返回：
+//   - map[string]T: 存在的键及转换后的值。
+//   - []string: 不存在的键，顺序与 keys 中首次出现的顺序一致。
+//   - error: 读取失败，或任一值转换失败时返回错误，转换错误包含对应的键名。
+func BulkGetAs[T any](ctx context.Context, r RedisExtension, decode func(string) (T, error), keys ...string) (map[string]T, []string, error) {
+	raw, missing, err := r.BulkGet(ctx, keys...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	values := make(map[string]T, len(raw))
+	for key, s := range raw {
+		v, err := decode(s)
+		if err != nil {
+			return nil, nil, fmt.Errorf("redis: decode value of %q: %w", key, err)
+		}
+		values[key] = v
+	}
+	return values, missing, nil
+}
+
+// BulkSetAs 用 encode 将值转换为字符串后使用 BulkSet 批量写入。
+//
+// 参数：
+//   - ctx: 控制管道执行生命周期的上下文。
+//   - r: 执行批量写入的 Redis 扩展实例。
+//   - values: 要写入的键值对；为空时不发送命令。
+//   - ttl: 键过期时间，语义与 BulkSet 相同。
+//   - encode: 将 T 转换为写入 Redis 的字符串的函数。
+//
+// 返回：
+//   - error: 任一值转换失败时返回错误且不发送命令，转换错误包含对应的键名；写入失败时返回 BulkSet 的错误。
+func BulkSetAs[T any](ctx context.Context, r RedisExtension, values map[string]T, ttl time.Duration, encode func(T) (string, error)) error {
+	raw := make(map[string]string, len(values))
+	for key, v := range values {
+		s, err := encode(v)
+		if err != nil {
+			return fmt.Errorf("redis: encode value of %q: %w", key, err)
+		}
+		raw[key] = s
+	}
+	return r.BulkSet(ctx, raw, ttl)
+}
+
+// BulkGetJSON 批量读取以 JSON 编码保存的值并解码为 T。
+//
+// 参数：
+//   - ctx: 控制管道执行生命周期的上下文。
+//   - r: 执行批量读取的 Redis 扩展实例。
+//   - keys: 要读取的 Redis 键名列表；为空时返回空结果且不发送命令。
+//
+// 返回：
+//   - map[string]T: 存在的键及解码后的值。
+//   - []string: 不存在的键，顺序与 keys 中首次出现的顺序一致。
+//   - error: 读取失败，或任一值不是合法的 JSON 时返回错误。
+func BulkGetJSON[T any](ctx context.Context, r RedisExtension, keys ...string) (map[string]T, []string, error) {
+	return BulkGetAs(ctx, r, func(s string) (T, error) {
+		var v T
+		err := json.Unmarshal([]byte(s), &v)
+		return v, err
+	}, keys...)
+}
+
+// BulkSetJSON 将值编码为 JSON 后批量写入。
+//
+// 参数：
+//   - ctx: 控制管道执行生命周期的上下文。
+//   - r: 执行批量写入的 Redis 扩展实例。
+//   - values: 要写入的键值对；为空时不发送命令。
+//   - ttl: 键过期时间，语义与 BulkSet 相同。
+//
+// 返回：
+//   - error: 任一值编码失败或写入失败时返回错误。
+func BulkSetJSON[T any](ctx context.Context, r RedisExtension, values map[string]T, ttl time.Duration) error {
+	return BulkSetAs(ctx, r, values, ttl, func(v T) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	})
+}
+
+// uniqueKeys 去除键名列表中的重复项，保留首次出现的顺序。
+//
+// 参数：
+//   - keys: 键名列表。
+//
+// 返回：
+//   - []string: 去重后的键名列表。
+func uniqueKeys(keys []string) []string {
+	seen := make(map[string]struct{}, len(keys))
+	unique := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		unique = append(unique, key)
+	}
+	return unique
+}
+
+// splitKeys 将键名列表按 size 拆分为多个批次。
+//
+// 参数：
+//   - keys: 键名列表。
+//   - size: 每个批次的最大键数，必须为正。
+//
+// 返回：
+//   - [][]string: 拆分后的批次，与 keys 共享底层数组。
+func splitKeys(keys []string, size int) [][]string {
+	batches := make([][]string, 0, (len(keys)+size-1)/size)
+	for len(keys) > size {
+		batches = append(batches, keys[:size])
+		keys = keys[size:]
+	}
+	if len(keys) > 0 {
+		batches = append(batches, keys)
+	}
+	return batches
+}
@@ -0,0 +1,231 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// geoUnitDefault 是 GEOSEARCH 未指定距离单位时使用的单位，与 go-redis 保持一致。
+	geoUnitDefault = "km"
+)
+
+// GeoAdd 将一个或多个地理位置写入 GEO 集合。
+//
+// 参数：
+//   - ctx: 控制命令执行生命周期的上下文。
+//   - key: GEO 集合的 Redis 键名。
+//   - locations: 要写入的位置，只使用 Name、Longitude 与 Latitude；为空时不发送命令。
+//
+// 返回：
+//   - int64: 新增的成员数量，更新已有成员的坐标不计入。
+//   - error: 命令执行失败或结果类型不符合预期时返回错误。
+func (r *redisExtension) GeoAdd(ctx context.Context, key string, locations ...GeoLocation) (int64, error) {
+	if len(locations) == 0 {
+		return 0, nil
+	}
+
+	args := make([]interface{}, 0, 2+3*len(locations))
+	args = append(args, "GEOADD", key)
+	for _, location := range locations {
+		args = append(args, location.Longitude, location.Latitude, location.Name)
+	}
+	return r.redis.Do(ctx, args...).Int64()
+}
+
+// GeoSearch 使用 GEOSEARCH 查询指定范围内的成员。
+//
+// 参数：
+//   - ctx: 控制命令执行生命周期的上下文。
+//   - key: GEO 集合的 Redis 键名。
+//   - query: 查询条件；Member 非空时以成员为中心，否则以经纬度为中心；Radius 为正时按半径查询，否则按矩形查询。
+//
+// 返回：
+//   - []GeoLocation: 查询结果，Dist、GeoHash 与坐标只在对应的 With 选项开启时填充。
+//   - error: 查询条件不合法时返回 ErrInvalidArgument，命令执行失败或结果类型不符合预期时返回错误。
+func (r *redisExtension) GeoSearch(ctx context.Context, key string, query *GeoSearchLocationQuery) ([]GeoLocation, error) {
+	args, err := geoSearchArgs(key, query)
+	if err != nil {
+		return nil, err
+	}
+
+	reply, err := r.redis.Do(ctx, args...).Slice()
+	if err != nil {
+		return nil, err
+	}
+	return parseGeoSearchReply(reply, query)
+}
+
+// geoSearchArgs 根据查询条件构造 GEOSEARCH 命令参数。
+//
+// 参数：
+//   - key: GEO 集合的 Redis 键名。
+//   - query: 查询条件。
+//
+// 返回：
+//   - []interface{}: 可直接传给 Redis Do 方法的命令参数。
+//   - error: query 为 nil、未给出查询范围或范围不为正时返回 ErrInvalidArgument。
+func geoSearchArgs(key string, query *GeoSearchLocationQuery) ([]interface{}, error) {
+	if query == nil {
+		return nil, fmt.Errorf("%w: geo search query is nil", ErrInvalidArgument)
+	}
+
+	args := []interface{}{"GEOSEARCH", key}
+	if query.Member != "" {
+		args = append(args, "FROMMEMBER", query.Member)
+	} else {
+		args = append(args, "FROMLONLAT", query.Longitude, query.Latitude)
+	}
+
+	switch {
+	case query.Radius > 0:
+		args = append(args, "BYRADIUS", query.Radius, geoUnit(query.RadiusUnit))
+	case query.BoxWidth > 0 && query.BoxHeight > 0:
+		args = append(args, "BYBOX", query.BoxWidth, query.BoxHeight, geoUnit(query.BoxUnit))
+	default:
+		return nil, fmt.Errorf("%w: geo search requires a positive radius or box size", ErrInvalidArgument)
+	}
+
+	if query.Sort != "" {
+		args = append(args, strings.ToUpper(query.Sort))
+	}
+	if query.Count > 0 {
+		args = append(args, "COUNT", query.Count)
+		if query.CountAny {
+			args = append(args, "ANY")
+		}
+	}
+	if query.WithCoord {
+		args = append(args, "WITHCOORD")
+	}
+	if query.WithDist {
+		args = append(args, "WITHDIST")
+	}
+	if query.WithHash {
+		args = append(args, "WITHHASH")
+	}
+	return args, nil
+}
+
+// geoUnit 返回 GEOSEARCH 使用的距离单位。
+//
+// 参数：
+//   - unit: 调用方指定的单位，可以是 m、km、ft 或 mi。
+//
+// 返回：
+//   - string: unit 为空时返回默认单位 km，否则返回 unit。
+func geoUnit(unit string) string {
+	if unit == "" {
+		return geoUnitDefault
+	}
+	return unit
+}
+
+// parseGeoSearchReply 将 GEOSEARCH 的结果转换为位置列表。
+//
+// 开启任一 With 选项时，每个成员的结果依次为名称、距离、GeoHash 与坐标，未开启的项不出现。
+//
+// 参数：
+//   - reply: GEOSEARCH 返回的数组。
+//   - query: 发送命令时使用的查询条件，用于确定结果结构。
+//
+// 返回：
+//   - []GeoLocation: 与 reply 顺序一致的位置列表。
+//   - error: 结果结构与查询条件不符时返回 ErrUnexpectedReply。
+func parseGeoSearchReply(reply []interface{}, query *GeoSearchLocationQuery) ([]GeoLocation, error) {
+	locations := make([]GeoLocation, 0, len(reply))
+	withAny := query.WithCoord || query.WithDist || query.WithHash
+	for _, item := range reply {
+		if !withAny {
+			name, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("%w: geo member is %T", ErrUnexpectedReply, item)
+			}
+			locations = append(locations, GeoLocation{Name: name})
+			continue
+		}
+
+		fields, ok := item.([]interface{})
+		if !ok || len(fields) == 0 {
+			return nil, fmt.Errorf("%w: geo search item is %T", ErrUnexpectedReply, item)
+		}
+		name, ok := fields[0].(string)
+		if !ok {
+			return nil, fmt.Errorf("%w: geo member is %T", ErrUnexpectedReply, fields[0])
+		}
+		location := GeoLocation{Name: name}
+		fields = fields[1:]
+
+		var err error
+		if query.WithDist {
+			if len(fields) == 0 {
+				return nil, fmt.Errorf("%w: geo search item misses distance", ErrUnexpectedReply)
+			}
+			if location.Dist, err = replyFloat(fields[0]); err != nil {
+				return nil, err
+			}
+			fields = fields[1:]
+		}
+		if query.WithHash {
+			if len(fields) == 0 {
+				return nil, fmt.Errorf("%w: geo search item misses hash", ErrUnexpectedReply)
+			}
+			hash, ok := fields[0].(int64)
+			if !ok {
+				return nil, fmt.Errorf("%w: geo hash is %T", ErrUnexpectedReply, fields[0])
+			}
+			location.GeoHash = hash
+			fields = fields[1:]
+		}
+		if query.WithCoord {
+			if len(fields) == 0 {
+				return nil, fmt.Errorf("%w: geo search item misses coordinates", ErrUnexpectedReply)
+			}
+			coord, ok := fields[0].([]interface{})
+			if !ok || len(coord) != 2 {
+				return nil, fmt.Errorf("%w: geo coordinates are %v", ErrUnexpectedReply, fields[0])
+			}
+			if location.Longitude, err = replyFloat(coord[0]); err != nil {
+				return nil, err
+			}
+			if location.Latitude, err = replyFloat(coord[1]); err != nil {
+				return nil, err
+			}
+		}
+		locations = append(locations, location)
+	}
+	return locations, nil
+}
+
+// replyFloat 将 Redis 返回的数值转换为 float64。
+//
+// RESP2 以字符串返回浮点数，RESP3 可能直接返回 double，两种协议都需要兼容。
+//
+// 参数：
+//   - value: Redis 返回的单个值。
+//
+// 返回：
+//   - float64: 转换后的数值。
+//   - error: value 不是数值或无法解析时返回 ErrUnexpectedReply。
+func replyFloat(value interface{}) (float64, error) {
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case int64:
+		return float64(v), nil
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %v", ErrUnexpectedReply, err)
+		}
+		return f, nil
+	default:
+		return 0, fmt.Errorf("%w: number is %T", ErrUnexpectedReply, value)
+	}
+}
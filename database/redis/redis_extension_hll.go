@@ -0,0 +1,107 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package redis
+
+import (
+	"context"
+)
+
+// PFAdd 向 HyperLogLog 添加元素。
+//
+// 参数：
+//   - ctx: 控制命令执行生命周期的上下文。
+//   - key: HyperLogLog 的 Redis 键名。
+//   - elements: 要添加的元素；为空时仅在键不存在时创建空结构。
+//
+// 返回：
+//   - bool: 内部寄存器是否发生变化，即基数估算值可能改变。
+//   - error: 命令执行失败时返回错误。
+func (r *redisExtension) PFAdd(ctx context.Context, key string, elements ...interface{}) (bool, error) {
+	args := make([]interface{}, 0, 2+len(elements))
+	args = append(args, "PFADD", key)
+	args = append(args, elements...)
+	return r.redis.Do(ctx, args...).Bool()
+}
+
+// PFCount 返回一个或多个 HyperLogLog 并集的基数估算值。
+//
+// 参数：
+//   - ctx: 控制命令执行生命周期的上下文。
+//   - keys: HyperLogLog 的 Redis 键名列表；为空时返回 0 且不发送命令。
+//
+// 返回：
+//   - int64: 并集的基数估算值。
+//   - error: 命令执行失败时返回错误。
+func (r *redisExtension) PFCount(ctx context.Context, keys ...string) (int64, error) {
+	if len(keys) == 0 {
+		return 0, nil
+	}
+	return r.redis.Do(ctx, keyArgs("PFCOUNT", keys)...).Int64()
+}
+
+// PFCountEach 在同一管道中分别统计每个 HyperLogLog 的基数估算值。
+//
+// 参数：
+//   - ctx: 控制管道执行生命周期的上下文。
+//   - keys: HyperLogLog 的 Redis 键名列表；为空时返回空结果且不发送命令。
+//
+// 返回：
+//   - []int64: 与 keys 顺序一致的基数估算值。
+//   - error: 任一命令执行失败时返回错误。
+func (r *redisExtension) PFCountEach(ctx context.Context, keys ...string) ([]int64, error) {
+	if len(keys) == 0 {
+		return []int64{}, nil
+	}
+
+	commands := make([][]interface{}, 0, len(keys))
+	for _, key := range keys {
+		commands = append(commands, []interface{}{"PFCOUNT", key})
+	}
+	cmds, err := r.pipelineDo(ctx, commands)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make([]int64, 0, len(cmds))
+	for _, cmd := range cmds {
+		count, err := cmd.Int64()
+		if err != nil {
+			return nil, err
+		}
+		counts = append(counts, count)
+	}
+	return counts, nil
+}
+
+// PFMerge 将多个 HyperLogLog 合并到目标键。
+//
+// 参数：
+//   - ctx: 控制命令执行生命周期的上下文。
+//   - dest: 合并结果写入的 Redis 键名。
+//   - sources: 参与合并的 Redis 键名列表。
+//
+// 返回：
+//   - error: 命令执行失败时返回错误。
+func (r *redisExtension) PFMerge(ctx context.Context, dest string, sources ...string) error {
+	args := keyArgs("PFMERGE", append([]string{dest}, sources...))
+	return r.redis.Do(ctx, args...).Err()
+}
+
+// keyArgs 将命令名称与键名列表组合为命令参数。
+//
+// 参数：
+//   - command: Redis 命令名称。
+//   - keys: 追加在命令名称之后的键名列表。
+//
+// 返回：
+//   - []interface{}: 可直接传给 Redis Do 方法的命令参数。
+func keyArgs(command string, keys []string) []interface{} {
+	args := make([]interface{}, 0, 1+len(keys))
+	args = append(args, command)
+	for _, key := range keys {
+		args = append(args, key)
+	}
+	return args
+}
@@ -13,6 +13,7 @@ import (
 	"fmt"
 	"io"
 	"net"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -38,6 +39,9 @@ type memoryRedisServer struct {
 	mu      sync.Mutex
 	kv      map[string]string
 	scripts map[string]string
+	bits    map[string]map[int64]bool
+	hll     map[string]map[string]struct{}
+	geo     map[string]map[string][2]string
 	records []respCommand
 }
 
@@ -438,6 +442,96 @@ func TestRedisExtension_CommandArguments(t *testing.T) {
 			},
 			wantArgs: []interface{}{"EXPIRE", "extension:expire:key", int64(0)},
 		},
+		{
+			name:        "success/geo-add",
+			description: "验证 GeoAdd 按经度、纬度、成员名的顺序展开多个位置。",
+			act: func(ext RedisExtension, ctx context.Context) {
+				_, _ = ext.GeoAdd(ctx, "extension:geo",
+					GeoLocation{Name: "palermo", Longitude: 13.361389, Latitude: 38.115556},
+					GeoLocation{Name: "catania", Longitude: 15.087269, Latitude: 37.502669},
+				)
+			},
+			wantArgs: []interface{}{"GEOADD", "extension:geo", 13.361389, 38.115556, "palermo", 15.087269, 37.502669, "catania"},
+		},
+		{
+			name:        "success/geo-search-by-radius",
+			description: "验证 GeoSearch 以经纬度为中心按半径查询时使用默认单位并追加排序、数量与 With 选项。",
+			act: func(ext RedisExtension, ctx context.Context) {
+				_, _ = ext.GeoSearch(ctx, "extension:geo", &GeoSearchLocationQuery{
+					GeoSearchQuery: GeoSearchQuery{Longitude: 15, Latitude: 37, Radius: 200, Sort: "asc", Count: 2, CountAny: true},
+					WithCoord:      true,
+					WithDist:       true,
+					WithHash:       true,
+				})
+			},
+			wantArgs: []interface{}{"GEOSEARCH", "extension:geo", "FROMLONLAT", float64(15), float64(37), "BYRADIUS", float64(200), "km", "ASC", "COUNT", 2, "ANY", "WITHCOORD", "WITHDIST", "WITHHASH"},
+		},
+		{
+			name:        "success/geo-search-by-box",
+			description: "验证 GeoSearch 以成员为中心按矩形查询，未开启 COUNT 时忽略 CountAny。",
+			act: func(ext RedisExtension, ctx context.Context) {
+				_, _ = ext.GeoSearch(ctx, "extension:geo", &GeoSearchLocationQuery{
+					GeoSearchQuery: GeoSearchQuery{Member: "palermo", BoxWidth: 400, BoxHeight: 300, BoxUnit: "m", CountAny: true},
+				})
+			},
+			wantArgs: []interface{}{"GEOSEARCH", "extension:geo", "FROMMEMBER", "palermo", "BYBOX", float64(400), float64(300), "m"},
+		},
+		{
+			name:        "success/pf-add",
+			description: "验证 PFAdd 将元素追加在键名之后。",
+			act: func(ext RedisExtension, ctx context.Context) {
+				_, _ = ext.PFAdd(ctx, "extension:hll", "u1", "u2")
+			},
+			wantArgs: []interface{}{"PFADD", "extension:hll", "u1", "u2"},
+		},
+		{
+			name:        "success/pf-count",
+			description: "验证 PFCount 在多个键时统计并集。",
+			act: func(ext RedisExtension, ctx context.Context) {
+				_, _ = ext.PFCount(ctx, "extension:hll:a", "extension:hll:b")
+			},
+			wantArgs: []interface{}{"PFCOUNT", "extension:hll:a", "extension:hll:b"},
+		},
+		{
+			name:        "success/pf-merge",
+			description: "验证 PFMerge 将目标键放在来源键之前。",
+			act: func(ext RedisExtension, ctx context.Context) {
+				_ = ext.PFMerge(ctx, "extension:hll:all", "extension:hll:a", "extension:hll:b")
+			},
+			wantArgs: []interface{}{"PFMERGE", "extension:hll:all", "extension:hll:a", "extension:hll:b"},
+		},
+		{
+			name:        "success/set-bit",
+			description: "验证 SetBit 将布尔值转换为 0 或 1。",
+			act: func(ext RedisExtension, ctx context.Context) {
+				_, _ = ext.SetBit(ctx, "extension:bitmap", 7, true)
+			},
+			wantArgs: []interface{}{"SETBIT", "extension:bitmap", int64(7), 1},
+		},
+		{
+			name:        "success/get-bit",
+			description: "验证 GetBit 使用 GETBIT 命令和偏移量进行委托。",
+			act: func(ext RedisExtension, ctx context.Context) {
+				_, _ = ext.GetBit(ctx, "extension:bitmap", 7)
+			},
+			wantArgs: []interface{}{"GETBIT", "extension:bitmap", int64(7)},
+		},
+		{
+			name:        "success/bit-count-whole",
+			description: "验证 BitCount 在范围为 nil 时统计整个位图。",
+			act: func(ext RedisExtension, ctx context.Context) {
+				_, _ = ext.BitCount(ctx, "extension:bitmap", nil)
+			},
+			wantArgs: []interface{}{"BITCOUNT", "extension:bitmap"},
+		},
+		{
+			name:        "success/bit-count-range",
+			description: "验证 BitCount 追加范围与单位。",
+			act: func(ext RedisExtension, ctx context.Context) {
+				_, _ = ext.BitCount(ctx, "extension:bitmap", &BitCount{Start: 0, End: -1, Unit: "BIT"})
+			},
+			wantArgs: []interface{}{"BITCOUNT", "extension:bitmap", int64(0), int64(-1), "BIT"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -642,6 +736,364 @@ func TestRedisExtension_UnsupportedScriptFlushAndKill(t *testing.T) {
 	}
 }
 
+// TestRedisExtension_TypedCommands 验证 GEO、HyperLogLog 与位图扩展命令的结果转换和批量管道。
+//
+// 该测试使用内存 RESP 服务执行真实的 go-redis 命令与管道，覆盖结果解析和批量结果顺序。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestRedisExtension_TypedCommands(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		assert      func(t *testing.T, ctx context.Context, ext RedisExtension, server *memoryRedisServer)
+	}{
+		{
+			name:        "success/bitmap",
+			description: "验证 SetBit、SetBits、GetBit、GetBits 与 BitCount 返回位的原值、当前值和计数。",
+			assert: func(t *testing.T, ctx context.Context, ext RedisExtension, server *memoryRedisServer) {
+				old, err := ext.SetBit(ctx, "bitmap", 3, true)
+				require.NoError(t, err)
+				assert.False(t, old)
+
+				olds, err := ext.SetBits(ctx, "bitmap", true, 1, 3, 5)
+				require.NoError(t, err)
+				assert.Equal(t, []bool{false, true, false}, olds)
+
+				bit, err := ext.GetBit(ctx, "bitmap", 5)
+				require.NoError(t, err)
+				assert.True(t, bit)
+
+				bits, err := ext.GetBits(ctx, "bitmap", 0, 1, 3, 5)
+				require.NoError(t, err)
+				assert.Equal(t, []bool{false, true, true, true}, bits)
+
+				count, err := ext.BitCount(ctx, "bitmap", nil)
+				require.NoError(t, err)
+				assert.Equal(t, int64(3), count)
+			},
+		},
+		{
+			name:        "success/hyperloglog",
+			description: "验证 PFAdd 返回是否变化，PFCount 统计并集，PFCountEach 按键分别统计，PFMerge 合并结果。",
+			assert: func(t *testing.T, ctx context.Context, ext RedisExtension, server *memoryRedisServer) {
+				changed, err := ext.PFAdd(ctx, "uv:a", "u1", "u2")
+				require.NoError(t, err)
+				assert.True(t, changed)
+				changed, err = ext.PFAdd(ctx, "uv:a", "u1")
+				require.NoError(t, err)
+				assert.False(t, changed)
+				_, err = ext.PFAdd(ctx, "uv:b", "u2", "u3")
+				require.NoError(t, err)
+
+				count, err := ext.PFCount(ctx, "uv:a", "uv:b")
+				require.NoError(t, err)
+				assert.Equal(t, int64(3), count)
+
+				counts, err := ext.PFCountEach(ctx, "uv:a", "uv:b", "uv:missing")
+				require.NoError(t, err)
+				assert.Equal(t, []int64{2, 2, 0}, counts)
+
+				require.NoError(t, ext.PFMerge(ctx, "uv:all", "uv:a", "uv:b"))
+				count, err = ext.PFCount(ctx, "uv:all")
+				require.NoError(t, err)
+				assert.Equal(t, int64(3), count)
+			},
+		},
+		{
+			name:        "success/geo",
+			description: "验证 GeoAdd 返回新增数量，GeoSearch 按 With 选项解析距离、GeoHash 与坐标。",
+			assert: func(t *testing.T, ctx context.Context, ext RedisExtension, server *memoryRedisServer) {
+				added, err := ext.GeoAdd(ctx, "geo",
+					GeoLocation{Name: "palermo", Longitude: 13.361389, Latitude: 38.115556},
+					GeoLocation{Name: "catania", Longitude: 15.087269, Latitude: 37.502669},
+				)
+				require.NoError(t, err)
+				assert.Equal(t, int64(2), added)
+				added, err = ext.GeoAdd(ctx, "geo", GeoLocation{Name: "palermo", Longitude: 13.4, Latitude: 38.1})
+				require.NoError(t, err)
+				assert.Equal(t, int64(0), added)
+
+				locations, err := ext.GeoSearch(ctx, "geo", &GeoSearchLocationQuery{
+					GeoSearchQuery: GeoSearchQuery{Member: "palermo", Radius: 200},
+				})
+				require.NoError(t, err)
+				assert.Equal(t, []GeoLocation{{Name: "catania"}, {Name: "palermo"}}, locations)
+
+				locations, err = ext.GeoSearch(ctx, "geo", &GeoSearchLocationQuery{
+					GeoSearchQuery: GeoSearchQuery{Longitude: 15, Latitude: 37, Radius: 200},
+					WithCoord:      true,
+					WithDist:       true,
+					WithHash:       true,
+				})
+				require.NoError(t, err)
+				assert.Equal(t, []GeoLocation{
+					{Name: "catania", Longitude: 15.087269, Latitude: 37.502669, Dist: 1.5, GeoHash: 42},
+					{Name: "palermo", Longitude: 13.4, Latitude: 38.1, Dist: 1.5, GeoHash: 42},
+				}, locations)
+				assert.True(t, server.hasCommand("GEOSEARCH", "geo", "FROMLONLAT", "15", "37", "BYRADIUS", "200", "km", "WITHCOORD", "WITHDIST", "WITHHASH"))
+			},
+		},
+		{
+			name:        "success/bulk",
+			description: "验证 BulkSet 与 BulkGet 批量读写，缺失的键单独返回，带过期时间时逐键使用 SET。",
+			assert: func(t *testing.T, ctx context.Context, ext RedisExtension, server *memoryRedisServer) {
+				require.NoError(t, ext.BulkSet(ctx, map[string]string{"user:2": "bob", "user:1": "alice"}, 0))
+				assert.True(t, server.hasCommand("MSET", "user:1", "alice", "user:2", "bob"))
+				require.NoError(t, ext.BulkSet(ctx, map[string]string{"user:3": "carol"}, 1500*time.Millisecond))
+				assert.True(t, server.hasCommand("SET", "user:3", "carol", "PX", "1500"))
+
+				values, missing, err := ext.BulkGet(ctx, "user:1", "user:4", "user:3", "user:1", "user:5")
+				require.NoError(t, err)
+				assert.Equal(t, map[string]string{"user:1": "alice", "user:3": "carol"}, values)
+				assert.Equal(t, []string{"user:4", "user:5"}, missing)
+				assert.True(t, server.hasCommand("MGET", "user:1", "user:4", "user:3", "user:5"))
+			},
+		},
+		{
+			name:        "success/bulk-batches",
+			description: "验证键数超过单条命令上限时拆分为多条 MSET 与 MGET。",
+			assert: func(t *testing.T, ctx context.Context, ext RedisExtension, server *memoryRedisServer) {
+				values := make(map[string]string, bulkBatchSize+1)
+				keys := make([]string, 0, bulkBatchSize+1)
+				for i := 0; i <= bulkBatchSize; i++ {
+					key := fmt.Sprintf("k:%04d", i)
+					values[key] = fmt.Sprint(i)
+					keys = append(keys, key)
+				}
+				require.NoError(t, ext.BulkSet(ctx, values, 0))
+
+				got, missing, err := ext.BulkGet(ctx, keys...)
+				require.NoError(t, err)
+				assert.Equal(t, values, got)
+				assert.Empty(t, missing)
+				assert.Equal(t, 2, server.countCommand("MSET"))
+				assert.Equal(t, 2, server.countCommand("MGET"))
+			},
+		},
+		{
+			name:        "success/bulk-typed",
+			description: "验证 BulkSetJSON 与 BulkGetJSON 按类型编解码，BulkGetAs 的解码错误包含键名。",
+			assert: func(t *testing.T, ctx context.Context, ext RedisExtension, server *memoryRedisServer) {
+				type user struct {
+					Name string `json:"name"`
+				}
+				require.NoError(t, BulkSetJSON(ctx, ext, map[string]user{"u:1": {Name: "alice"}}, 0))
+				users, missing, err := BulkGetJSON[user](ctx, ext, "u:1", "u:2")
+				require.NoError(t, err)
+				assert.Equal(t, map[string]user{"u:1": {Name: "alice"}}, users)
+				assert.Equal(t, []string{"u:2"}, missing)
+
+				require.NoError(t, BulkSetAs(ctx, ext, map[string]int64{"n:1": 7}, 0, func(v int64) (string, error) {
+					return strconv.FormatInt(v, 10), nil
+				}))
+				numbers, _, err := BulkGetAs(ctx, ext, func(s string) (int64, error) {
+					return strconv.ParseInt(s, 10, 64)
+				}, "n:1")
+				require.NoError(t, err)
+				assert.Equal(t, map[string]int64{"n:1": 7}, numbers)
+
+				_, _, err = BulkGetJSON[int](ctx, ext, "u:1")
+				assert.ErrorContains(t, err, `"u:1"`)
+			},
+		},
+		{
+			name:        "error/batch-command-failure",
+			description: "验证批量命令中任一命令失败时返回错误。",
+			assert: func(t *testing.T, ctx context.Context, ext RedisExtension, server *memoryRedisServer) {
+				_, err := ext.GetBits(ctx, "bitmap", 1, -1)
+				assert.ErrorContains(t, err, "bit offset")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+			client, server := newMemoryRedisClient(t)
+
+			tt.assert(t, context.Background(), NewRedisExtension(client), server)
+		})
+	}
+}
+
+// TestRedisExtension_TypedCommandsWithoutRequest 验证扩展命令在空批量与非法参数下不发送命令。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestRedisExtension_TypedCommandsWithoutRequest(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		act         func(ext RedisExtension, ctx context.Context) (interface{}, error)
+		want        interface{}
+		wantErr     error
+	}{
+		{
+			name:        "boundary/geo-add-empty",
+			description: "验证 GeoAdd 在没有位置时返回 0。",
+			act: func(ext RedisExtension, ctx context.Context) (interface{}, error) {
+				return ext.GeoAdd(ctx, "geo")
+			},
+			want: int64(0),
+		},
+		{
+			name:        "boundary/pf-count-empty",
+			description: "验证 PFCount 在没有键时返回 0。",
+			act: func(ext RedisExtension, ctx context.Context) (interface{}, error) {
+				return ext.PFCount(ctx)
+			},
+			want: int64(0),
+		},
+		{
+			name:        "boundary/pf-count-each-empty",
+			description: "验证 PFCountEach 在没有键时返回空切片。",
+			act: func(ext RedisExtension, ctx context.Context) (interface{}, error) {
+				return ext.PFCountEach(ctx)
+			},
+			want: []int64{},
+		},
+		{
+			name:        "boundary/set-bits-empty",
+			description: "验证 SetBits 在没有偏移量时返回空切片。",
+			act: func(ext RedisExtension, ctx context.Context) (interface{}, error) {
+				return ext.SetBits(ctx, "bitmap", true)
+			},
+			want: []bool{},
+		},
+		{
+			name:        "boundary/get-bits-empty",
+			description: "验证 GetBits 在没有偏移量时返回空切片。",
+			act: func(ext RedisExtension, ctx context.Context) (interface{}, error) {
+				return ext.GetBits(ctx, "bitmap")
+			},
+			want: []bool{},
+		},
+		{
+			name:        "boundary/bulk-get-empty",
+			description: "验证 BulkGet 在没有键时返回空结果。",
+			act: func(ext RedisExtension, ctx context.Context) (interface{}, error) {
+				values, missing, err := ext.BulkGet(ctx)
+				return []interface{}{values, missing}, err
+			},
+			want: []interface{}{map[string]string{}, []string{}},
+		},
+		{
+			name:        "boundary/bulk-set-empty",
+			description: "验证 BulkSet 在没有键值对时不发送命令。",
+			act: func(ext RedisExtension, ctx context.Context) (interface{}, error) {
+				return nil, ext.BulkSet(ctx, nil, time.Minute)
+			},
+		},
+		{
+			name:        "error/bulk-set-as-encode",
+			description: "验证 BulkSetAs 编码失败时返回错误且不发送命令。",
+			act: func(ext RedisExtension, ctx context.Context) (interface{}, error) {
+				return nil, BulkSetAs(ctx, ext, map[string]int{"a": 1}, 0, func(int) (string, error) {
+					return "", ErrInvalidArgument
+				})
+			},
+			wantErr: ErrInvalidArgument,
+		},
+		{
+			name:        "error/geo-search-nil-query",
+			description: "验证 GeoSearch 拒绝 nil 查询条件。",
+			act: func(ext RedisExtension, ctx context.Context) (interface{}, error) {
+				return ext.GeoSearch(ctx, "geo", nil)
+			},
+			wantErr: ErrInvalidArgument,
+		},
+		{
+			name:        "error/geo-search-without-range",
+			description: "验证 GeoSearch 在没有正半径或完整矩形时报错。",
+			act: func(ext RedisExtension, ctx context.Context) (interface{}, error) {
+				return ext.GeoSearch(ctx, "geo", &GeoSearchLocationQuery{
+					GeoSearchQuery: GeoSearchQuery{Member: "palermo", BoxWidth: 10},
+				})
+			},
+			wantErr: ErrInvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+			fake := newFakeRedis()
+
+			got, err := tt.act(NewRedisExtension(fake), context.Background())
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			assert.Empty(t, fake.doCalls)
+			assert.Zero(t, fake.pipelinedCalls)
+		})
+	}
+}
+
+// TestParseGeoSearchReply 验证 GEOSEARCH 结果在 RESP3 数值类型与异常结构下的解析。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestParseGeoSearchReply(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		reply       []interface{}
+		query       *GeoSearchLocationQuery
+		want        []GeoLocation
+		wantErr     bool
+	}{
+		{
+			name:        "success/resp3-doubles",
+			description: "验证 RESP3 直接返回 double 时可以解析距离与坐标。",
+			reply:       []interface{}{[]interface{}{"a", 0.25, []interface{}{float64(1), float64(2)}}},
+			query:       &GeoSearchLocationQuery{WithDist: true, WithCoord: true},
+			want:        []GeoLocation{{Name: "a", Dist: 0.25, Longitude: 1, Latitude: 2}},
+		},
+		{
+			name:        "error/missing-field",
+			description: "验证开启 WITHHASH 但结果缺少 GeoHash 时报错。",
+			reply:       []interface{}{[]interface{}{"a"}},
+			query:       &GeoSearchLocationQuery{WithHash: true},
+			wantErr:     true,
+		},
+		{
+			name:        "error/invalid-distance",
+			description: "验证距离无法解析为数值时报错。",
+			reply:       []interface{}{[]interface{}{"a", "far"}},
+			query:       &GeoSearchLocationQuery{WithDist: true},
+			wantErr:     true,
+		},
+		{
+			name:        "error/unexpected-item",
+			description: "验证未开启 With 选项但结果不是成员名时报错。",
+			reply:       []interface{}{int64(1)},
+			query:       &GeoSearchLocationQuery{},
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			got, err := parseGeoSearchReply(tt.reply, tt.query)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrUnexpectedReply)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 // newMemoryRedisClient 构造使用内存 RESP 服务的 redisClient。
 //
 // 该辅助函数通过 go-redis 的 Dialer 注入 net.Pipe 连接，使客户端方法在单元测试中无需访问真实 Redis 服务。
@@ -658,6 +1110,9 @@ func newMemoryRedisClient(t *testing.T) (*redisClient, *memoryRedisServer) {
 	server := &memoryRedisServer{
 		kv:      make(map[string]string),
 		scripts: make(map[string]string),
+		bits:    make(map[string]map[int64]bool),
+		hll:     make(map[string]map[string]struct{}),
+		geo:     make(map[string]map[string][2]string),
 	}
 	client := goredis.NewClient(&goredis.Options{
 		Addr:     "memory.redis:6379",
@@ -793,6 +1248,24 @@ func (s *memoryRedisServer) handleCommand(args []string) respReply {
 			return respReply{kind: "nil"}
 		}
 		return respReply{kind: "bulk", value: value}
+	case "MGET":
+		replies := make([]respReply, 0, len(args)-1)
+		for _, key := range args[1:] {
+			if value, ok := s.kv[key]; ok {
+				replies = append(replies, respReply{kind: "bulk", value: value})
+			} else {
+				replies = append(replies, respReply{kind: "nil"})
+			}
+		}
+		return respReply{kind: "array", value: replies}
+	case "MSET":
+		if len(args) < 3 || len(args)%2 == 0 {
+			return respReply{kind: "error", value: "ERR wrong number of arguments"}
+		}
+		for i := 1; i < len(args); i += 2 {
+			s.kv[args[i]] = args[i+1]
+		}
+		return respReply{kind: "simple", value: "OK"}
 	case "DEL":
 		var deleted int64
 		for _, key := range args[1:] {
@@ -804,6 +1277,12 @@ func (s *memoryRedisServer) handleCommand(args []string) respReply {
 		return respReply{kind: "int", value: deleted}
 	case "EXPIRE", "PEXPIRE", "PERSIST":
 		return respReply{kind: "int", value: int64(1)}
+	case "SETBIT", "GETBIT", "BITCOUNT":
+		return s.handleBitmap(command, args)
+	case "PFADD", "PFCOUNT", "PFMERGE":
+		return s.handleHyperLogLog(command, args)
+	case "GEOADD", "GEOSEARCH":
+		return s.handleGeo(command, args)
 	case "EVAL", "EVAL_RO", "EVALSHA", "EVALSHA_RO":
 		return evalReply(args)
 	case "SCRIPT":
@@ -813,6 +1292,178 @@ func (s *memoryRedisServer) handleCommand(args []string) respReply {
 	}
 }
 
+// handleBitmap 生成位图命令的响应。
+//
+// 该辅助方法按位保存 SETBIT 写入的值，BITCOUNT 忽略范围参数并统计整个位图。
+//
+// 参数：
+//   - command: 大写的命令名称。
+//   - args: RESP 命令参数列表，首项为命令名。
+//
+// 返回值：
+//   - respReply: 可序列化为 RESP 的命令响应。
+func (s *memoryRedisServer) handleBitmap(command string, args []string) respReply {
+	if len(args) < 2 {
+		return respReply{kind: "error", value: "ERR wrong number of arguments"}
+	}
+	bits := s.bits[args[1]]
+	if command == "BITCOUNT" {
+		var count int64
+		for _, bit := range bits {
+			if bit {
+				count++
+			}
+		}
+		return respReply{kind: "int", value: count}
+	}
+
+	if len(args) < 3 {
+		return respReply{kind: "error", value: "ERR wrong number of arguments"}
+	}
+	offset, err := strconv.ParseInt(args[2], 10, 64)
+	if err != nil || offset < 0 {
+		return respReply{kind: "error", value: "ERR bit offset is not an integer or out of range"}
+	}
+	var old int64
+	if bits[offset] {
+		old = 1
+	}
+	if command == "SETBIT" {
+		if len(args) < 4 {
+			return respReply{kind: "error", value: "ERR wrong number of arguments"}
+		}
+		if bits == nil {
+			bits = make(map[int64]bool)
+			s.bits[args[1]] = bits
+		}
+		bits[offset] = args[3] == "1"
+	}
+	return respReply{kind: "int", value: old}
+}
+
+// handleHyperLogLog 生成 HyperLogLog 命令的响应。
+//
+// 该辅助方法使用精确集合代替概率结构，便于断言基数。
+//
+// 参数：
+//   - command: 大写的命令名称。
+//   - args: RESP 命令参数列表，首项为命令名。
+//
+// 返回值：
+//   - respReply: 可序列化为 RESP 的命令响应。
+func (s *memoryRedisServer) handleHyperLogLog(command string, args []string) respReply {
+	if len(args) < 2 {
+		return respReply{kind: "error", value: "ERR wrong number of arguments"}
+	}
+
+	switch command {
+	case "PFADD":
+		set, ok := s.hll[args[1]]
+		changed := !ok
+		if !ok {
+			set = make(map[string]struct{})
+			s.hll[args[1]] = set
+		}
+		for _, element := range args[2:] {
+			if _, ok := set[element]; !ok {
+				set[element] = struct{}{}
+				changed = true
+			}
+		}
+		if changed {
+			return respReply{kind: "int", value: int64(1)}
+		}
+		return respReply{kind: "int", value: int64(0)}
+	case "PFMERGE":
+		merged := make(map[string]struct{})
+		for _, key := range args[1:] {
+			for element := range s.hll[key] {
+				merged[element] = struct{}{}
+			}
+		}
+		s.hll[args[1]] = merged
+		return respReply{kind: "simple", value: "OK"}
+	default:
+		union := make(map[string]struct{})
+		for _, key := range args[1:] {
+			for element := range s.hll[key] {
+				union[element] = struct{}{}
+			}
+		}
+		return respReply{kind: "int", value: int64(len(union))}
+	}
+}
+
+// handleGeo 生成 GEO 命令的响应。
+//
+// GEOSEARCH 忽略中心与范围，按成员名顺序返回全部成员，距离固定为 1.5，GeoHash 固定为 42，
+// 并按 WITHDIST、WITHHASH、WITHCOORD 的 Redis 顺序组织附加字段。
+//
+// 参数：
+//   - command: 大写的命令名称。
+//   - args: RESP 命令参数列表，首项为命令名。
+//
+// 返回值：
+//   - respReply: 可序列化为 RESP 的命令响应。
+func (s *memoryRedisServer) handleGeo(command string, args []string) respReply {
+	if len(args) < 2 {
+		return respReply{kind: "error", value: "ERR wrong number of arguments"}
+	}
+
+	if command == "GEOADD" {
+		if (len(args)-2)%3 != 0 || len(args) == 2 {
+			return respReply{kind: "error", value: "ERR wrong number of arguments"}
+		}
+		members, ok := s.geo[args[1]]
+		if !ok {
+			members = make(map[string][2]string)
+			s.geo[args[1]] = members
+		}
+		var added int64
+		for i := 2; i < len(args); i += 3 {
+			if _, ok := members[args[i+2]]; !ok {
+				added++
+			}
+			members[args[i+2]] = [2]string{args[i], args[i+1]}
+		}
+		return respReply{kind: "int", value: added}
+	}
+
+	options := make(map[string]bool)
+	for _, arg := range args[2:] {
+		options[strings.ToUpper(arg)] = true
+	}
+	names := make([]string, 0, len(s.geo[args[1]]))
+	for name := range s.geo[args[1]] {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	replies := make([]respReply, 0, len(names))
+	for _, name := range names {
+		if !options["WITHDIST"] && !options["WITHHASH"] && !options["WITHCOORD"] {
+			replies = append(replies, respReply{kind: "bulk", value: name})
+			continue
+		}
+		item := []respReply{{kind: "bulk", value: name}}
+		if options["WITHDIST"] {
+			item = append(item, respReply{kind: "bulk", value: "1.5"})
+		}
+		if options["WITHHASH"] {
+			item = append(item, respReply{kind: "int", value: int64(42)})
+		}
+		if options["WITHCOORD"] {
+			coord := s.geo[args[1]][name]
+			item = append(item, respReply{kind: "array", value: []respReply{
+				{kind: "bulk", value: coord[0]},
+				{kind: "bulk", value: coord[1]},
+			}})
+		}
+		replies = append(replies, respReply{kind: "array", value: item})
+	}
+	return respReply{kind: "array", value: replies}
+}
+
 // handleScript 生成 SCRIPT 子命令的稳定响应。
 //
 // 该辅助方法覆盖测试涉及的 LOAD、EXISTS、FLUSH 和 KILL 子命令。
@@ -891,6 +1542,27 @@ func (s *memoryRedisServer) hasCommand(name string, args ...string) bool {
 	return false
 }
 
+// countCommand 统计内存 RESP 服务收到指定命令的次数。
+//
+// 参数：
+//   - name: 期望的 Redis 命令名。
+//
+// 返回值：
+//   - int: 收到该命令的次数。
+func (s *memoryRedisServer) countCommand(name string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	name = strings.ToUpper(name)
+	count := 0
+	for _, record := range s.records {
+		if record.name == name {
+			count++
+		}
+	}
+	return count
+}
+
 // readRESPArray 读取一条 RESP 数组命令。
 //
 // 该辅助函数仅实现 go-redis 测试命令所需的 RESP 数组、Bulk String、Simple String 和 Integer 解析。
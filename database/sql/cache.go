@@ -0,0 +1,254 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package sql
+
+import (
+	"context"
+	stdsql "database/sql"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	kitcache "github.com/fsyyft-go/kit/cache"
+	kitflight "github.com/fsyyft-go/kit/internal/flight"
+)
+
+const (
+	// queryKeysSweepMin 是清理失效键记录的最小记录数量。
+	queryKeysSweepMin = 1024
+)
+
+var (
+	// ErrNilScanFunc 表示调用 CachedQuery 时未提供结果集扫描函数。
+	ErrNilScanFunc = errors.New("sql: scan func is nil")
+)
+
+type (
+	// ScanFunc 把结果集转换为缓存值。
+	//
+	// 参数：
+	//   - rows: 查询返回的结果集；CachedQuery 在扫描后负责关闭，扫描函数也可以自行关闭，例如直接使用 ScanMaps。
+	//
+	// 返回：
+	//   - T: 写入缓存并返回给调用方的值。
+	//   - error: 扫描失败时返回错误，此时不会写入缓存。
+	ScanFunc[T any] func(rows *stdsql.Rows) (T, error)
+
+	// QueryCache 为 CachedQuery 提供查询结果缓存、并发未命中合并和按键前缀失效能力。
+	//
+	// QueryCache 与底层 Cache 共享存储，不改变其关闭责任。它记录通过 CachedQuery 写入的键，InvalidatePrefix
+	// 据此删除匹配的缓存项；直接写入底层 Cache 的键不受前缀失效影响。零值 QueryCache 不可直接使用，
+	// 调用方应通过 NewQueryCache 创建。
+	QueryCache struct {
+		// cache 是存放查询结果的底层缓存。
+		cache kitcache.Cache
+
+		// mu 保护 keys、sweepAt 与 generation。
+		mu sync.Mutex
+		// keys 记录通过 CachedQuery 写入的键及其过期时间，零值表示永不过期。
+		keys map[string]time.Time
+		// sweepAt 是下一次清理 keys 中已过期记录的记录数量。
+		sweepAt int
+		// generation 在每次失效时递增，失效前开始的查询结果不再写入缓存。
+		generation uint64
+		// flights 合并同一个键的并发未命中。
+		flights kitflight.Group[string, any]
+	}
+)
+
+// NewQueryCache 在已有 Cache 上创建查询结果缓存。
+//
+// 参数：
+//   - cache: 存放查询结果的底层缓存实例，调用方应保证其非 nil。
+//
+// 返回：
+//   - *QueryCache: 创建成功的查询结果缓存。
+func NewQueryCache(cache kitcache.Cache) *QueryCache {
+	return &QueryCache{
+		cache:   cache,
+		keys:    make(map[string]time.Time),
+		sweepAt: queryKeysSweepMin,
+	}
+}
+
+// CachedQuery 优先从缓存读取 key 对应的查询结果，未命中时执行查询、扫描并写入缓存。
+//
+// 适合读多写少的字典表、配置表等查询。同一个键的并发未命中只执行一次查询，其它调用等待并共享结果，
+// 避免缓存过期瞬间大量请求同时打到数据库。查询或扫描失败时不写入缓存。查询期间发生的 Invalidate 或
+// InvalidatePrefix 会使本次结果不写入缓存，避免写回失效前读到的旧数据。
+//
+// 缓存值类型与 T 不一致时按未命中处理；共享结果的类型与 T 不一致时，本次调用会单独执行查询。
+//
+// 参数：
+//   - ctx: 查询上下文，仅在未命中且由本次调用执行查询时使用；执行查询的调用被取消时，等待者同样收到该错误。
+//   - db: 执行查询的数据库句柄。
+//   - qc: 查询结果缓存。
+//   - key: 缓存键，建议以表名等作为前缀，便于使用 InvalidatePrefix 批量失效。
+//   - ttl: 缓存有效期，小于等于 0 时表示永不过期。
+//   - query: SQL 语句。
+//   - args: SQL 参数。
+//   - scan: 把结果集转换为缓存值的函数。
+//
+// 返回：
+//   - T: 缓存值或查询得到的值；失败时为 T 的零值。
+//   - error: scan 为 nil 时返回 ErrNilScanFunc；查询、扫描或读取结果集失败时返回对应错误；执行查询的调用 panic 时，
+//     panic 继续向该调用方传播，等待者收到 kitcache.ErrLoaderPanicked。
+func CachedQuery[T any](ctx context.Context, db Queryer, qc *QueryCache, key string, ttl time.Duration, query string, args []any, scan ScanFunc[T]) (T, error) {
+	var zero T
+	if nil == scan {
+		return zero, ErrNilScanFunc
+	}
+	if value, ok := qc.cache.Get(key); ok {
+		if v, ok := value.(T); ok {
+			return v, nil
+		}
+	}
+
+	load := func() (any, error) {
+		return queryAndScan(ctx, db, query, args, scan)
+	}
+	value, err := qc.load(key, ttl, load)
+	if nil != err {
+		return zero, err
+	}
+	if v, ok := value.(T); ok {
+		return v, nil
+	}
+	// 同一个键被不同类型的调用共享时，本次调用单独执行查询，不写入缓存。
+	return queryAndScan(ctx, db, query, args, scan)
+}
+
+// Invalidate 删除指定键的缓存项。
+//
+// 参数：
+//   - keys: 待删除的缓存键；不存在的键被忽略。
+func (qc *QueryCache) Invalidate(keys ...string) {
+	qc.mu.Lock()
+	qc.generation++
+	for _, key := range keys {
+		delete(qc.keys, key)
+	}
+	qc.mu.Unlock()
+
+	for _, key := range keys {
+		qc.cache.Delete(key)
+	}
+}
+
+// InvalidatePrefix 删除通过 CachedQuery 写入、且键以 prefix 开头的缓存项。
+//
+// 适合在写入某张表后批量失效该表相关的全部查询，例如以 "user:" 为前缀。prefix 为空时删除全部记录的键。
+//
+// 参数：
+//   - prefix: 键前缀。
+//
+// 返回：
+//   - int: 删除的键数量，包含底层缓存中已过期或已驱逐、但仍被记录的键。
+func (qc *QueryCache) InvalidatePrefix(prefix string) int {
+	qc.mu.Lock()
+	qc.generation++
+	keys := make([]string, 0)
+	for key := range qc.keys {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+			delete(qc.keys, key)
+		}
+	}
+	qc.mu.Unlock()
+
+	for _, key := range keys {
+		qc.cache.Delete(key)
+	}
+	return len(keys)
+}
+
+// load 执行加载函数并写入缓存，合并同一个键的并发加载。
+//
+// 参数：
+//   - key: 缓存键。
+//   - ttl: 缓存有效期。
+//   - fn: 加载函数。
+//
+// 返回：
+//   - any: 加载得到的值。
+//   - error: 加载函数返回的错误；执行加载函数的调用 panic 时，等待者收到 kitcache.ErrLoaderPanicked。
+func (qc *QueryCache) load(key string, ttl time.Duration, fn func() (any, error)) (any, error) {
+	return qc.flights.Do(key, func() (any, error) {
+		qc.mu.Lock()
+		generation := qc.generation
+		qc.mu.Unlock()
+
+		value, err := fn()
+		if nil != err {
+			return value, err
+		}
+
+		// 查询期间发生过失效时不写回，避免覆盖失效后的状态。
+		qc.mu.Lock()
+		if generation == qc.generation {
+			qc.cache.SetWithTTL(key, value, ttl)
+			qc.record(key, ttl)
+		}
+		qc.mu.Unlock()
+		return value, nil
+	})
+}
+
+// record 记录写入的键，记录数量达到 sweepAt 时删除已过期的记录，调用方必须持有 qc.mu。
+//
+// 清理的开销分摊到写入上，记录数量不超过未过期记录数量的 2 倍与 queryKeysSweepMin 中的较大值。底层缓存的写入可能
+// 尚未生效，因此不以读取底层缓存判断键是否存在；永不过期的键被容量驱逐后，其记录保留到下一次失效。
+//
+// 参数：
+//   - key: 写入的缓存键。
+//   - ttl: 缓存有效期，小于等于 0 时表示永不过期。
+func (qc *QueryCache) record(key string, ttl time.Duration) {
+	now := time.Now()
+	var expireAt time.Time
+	if ttl > 0 {
+		expireAt = now.Add(ttl)
+	}
+	qc.keys[key] = expireAt
+	if len(qc.keys) < qc.sweepAt {
+		return
+	}
+	for k, at := range qc.keys {
+		if !at.IsZero() && !now.Before(at) {
+			delete(qc.keys, k)
+		}
+	}
+	qc.sweepAt = max(2*len(qc.keys), queryKeysSweepMin)
+}
+
+// queryAndScan 执行查询并使用 scan 转换结果集。
+//
+// 参数：
+//   - ctx: 查询上下文。
+//   - db: 执行查询的数据库句柄。
+//   - query: SQL 语句。
+//   - args: SQL 参数。
+//   - scan: 结果集扫描函数。
+//
+// 返回：
+//   - T: 扫描得到的值。
+//   - error: 查询、扫描或读取结果集失败时返回错误。
+func queryAndScan[T any](ctx context.Context, db Queryer, query string, args []any, scan ScanFunc[T]) (T, error) {
+	var zero T
+	rows, err := db.QueryContext(ctx, query, args...)
+	if nil != err {
+		return zero, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	value, err := scan(rows)
+	if nil != err {
+		return zero, err
+	}
+	if err := rows.Err(); nil != err {
+		return zero, err
+	}
+	return value, nil
+}
@@ -0,0 +1,335 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package sql
+
+import (
+	"context"
+	stdsql "database/sql"
+	"database/sql/driver"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	kitcache "github.com/fsyyft-go/kit/cache"
+	kittestdriver "github.com/fsyyft-go/kit/database/sql/testdriver"
+)
+
+var _ kitcache.Cache = (*mapCache)(nil)
+
+// mapCache 是同步写入的内存缓存，避免 Ristretto 异步写入使测试结果不稳定。
+type mapCache struct {
+	mu     sync.Mutex
+	values map[interface{}]interface{}
+	ttls   map[interface{}]time.Duration
+}
+
+// newMapCache 创建空的 mapCache。
+func newMapCache() *mapCache {
+	return &mapCache{values: make(map[interface{}]interface{}), ttls: make(map[interface{}]time.Duration)}
+}
+
+// Get 返回 key 对应的值。
+func (c *mapCache) Get(key interface{}) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	v, ok := c.values[key]
+	return v, ok
+}
+
+// GetWithTTL 返回 key 对应的值与写入时的 TTL。
+func (c *mapCache) GetWithTTL(key interface{}) (interface{}, bool, time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	v, ok := c.values[key]
+	return v, ok, c.ttls[key]
+}
+
+// Set 写入永不过期的值。
+func (c *mapCache) Set(key interface{}, value interface{}) bool {
+	return c.SetWithTTL(key, value, 0)
+}
+
+// SetWithTTL 写入值并记录 TTL。
+func (c *mapCache) SetWithTTL(key interface{}, value interface{}, ttl time.Duration) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.values[key] = value
+	c.ttls[key] = ttl
+	return true
+}
+
+// Delete 删除 key。
+func (c *mapCache) Delete(key interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.values, key)
+	delete(c.ttls, key)
+}
+
+// Clear 清空全部值。
+func (c *mapCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	clear(c.values)
+	clear(c.ttls)
+}
+
+// Stats 不记录统计，返回零值。
+func (c *mapCache) Stats() kitcache.CacheStats {
+	return kitcache.CacheStats{}
+}
+
+// Close 无操作。
+func (c *mapCache) Close() error {
+	return nil
+}
+
+// scanNames 把单列结果集扫描为字符串切片。
+func scanNames(rows *stdsql.Rows) ([]string, error) {
+	names := make([]string, 0)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); nil != err {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// TestCachedQuery 验证命中、未命中、失败不写缓存与类型不匹配的处理。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestCachedQuery(t *testing.T) {
+	errQuery := errors.New("query failed")
+
+	tests := []struct {
+		name        string
+		description string
+		cached      interface{}
+		queryErr    error
+		scan        ScanFunc[[]string]
+		want        []string
+		wantErrIs   error
+		wantCalls   int
+		wantCached  bool
+	}{
+		{
+			name:        "success/miss",
+			description: "验证未命中时执行查询并按 TTL 写入缓存。",
+			scan:        scanNames,
+			want:        []string{"alice", "bob"},
+			wantCalls:   1,
+			wantCached:  true,
+		},
+		{
+			name:        "success/hit",
+			description: "验证命中时不执行查询。",
+			cached:      []string{"cached"},
+			scan:        scanNames,
+			want:        []string{"cached"},
+			wantCached:  true,
+		},
+		{
+			name:        "success/type-mismatch",
+			description: "验证缓存值类型不一致时按未命中处理并覆盖。",
+			cached:      42,
+			scan:        scanNames,
+			want:        []string{"alice", "bob"},
+			wantCalls:   1,
+			wantCached:  true,
+		},
+		{
+			name:        "error/query",
+			description: "验证查询失败时返回错误且不写入缓存。",
+			queryErr:    errQuery,
+			scan:        scanNames,
+			wantErrIs:   errQuery,
+			wantCalls:   1,
+		},
+		{
+			name:        "error/scan",
+			description: "验证扫描失败时返回错误且不写入缓存。",
+			scan: func(*stdsql.Rows) ([]string, error) {
+				return nil, errQuery
+			},
+			wantErrIs: errQuery,
+			wantCalls: 1,
+		},
+		{
+			name:        "error/nil-scan",
+			description: "验证未提供扫描函数时返回 ErrNilScanFunc。",
+			wantErrIs:   ErrNilScanFunc,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			d := kittestdriver.New()
+			expectation := d.ExpectQuery("FROM users")
+			if nil != tt.queryErr {
+				expectation.WillReturnError(tt.queryErr)
+			} else {
+				expectation.WillReturnRows([]string{"name"}, []driver.Value{"alice"}, []driver.Value{"bob"})
+			}
+			db, _ := kittestdriver.NewDB(d)
+			defer func() { _ = db.Close() }()
+			c := newMapCache()
+			if nil != tt.cached {
+				c.Set("users:all", tt.cached)
+			}
+
+			qc := NewQueryCache(c)
+			got, err := CachedQuery(context.Background(), db, qc, "users:all", time.Minute, "SELECT name FROM users WHERE status = ?", []any{1}, tt.scan)
+			if nil != tt.wantErrIs {
+				require.ErrorIs(t, err, tt.wantErrIs)
+				assert.Nil(t, got)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			assert.Equal(t, tt.wantCalls, expectation.Calls())
+
+			value, cached, ttl := c.GetWithTTL("users:all")
+			assert.Equal(t, tt.wantCached, cached)
+			if 1 == tt.wantCalls && cached {
+				assert.Equal(t, tt.want, value)
+				assert.Equal(t, time.Minute, ttl)
+			}
+		})
+	}
+}
+
+// TestCachedQuery_Singleflight 验证同一个键的并发未命中只执行一次查询。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestCachedQuery_Singleflight(t *testing.T) {
+	d := kittestdriver.New()
+	expectation := d.ExpectQuery("FROM users").
+		WillReturnRows([]string{"name"}, []driver.Value{"alice"}).
+		WillDelay(50 * time.Millisecond)
+	db, _ := kittestdriver.NewDB(d)
+	defer func() { _ = db.Close() }()
+	qc := NewQueryCache(newMapCache())
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Go(func() {
+			got, err := CachedQuery(context.Background(), db, qc, "users:all", time.Minute, "SELECT name FROM users", nil, scanNames)
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"alice"}, got)
+		})
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1, expectation.Calls())
+}
+
+// TestQueryCache_Invalidate 验证按键与按前缀失效，以及查询期间失效时结果不写回。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestQueryCache_Invalidate(t *testing.T) {
+	d := kittestdriver.New()
+	expectation := d.ExpectQuery("FROM users").WillReturnRows([]string{"name"}, []driver.Value{"alice"})
+	db, _ := kittestdriver.NewDB(d)
+	defer func() { _ = db.Close() }()
+	c := newMapCache()
+	qc := NewQueryCache(c)
+	ctx := context.Background()
+
+	for _, key := range []string{"users:1", "users:2", "roles:1"} {
+		_, err := CachedQuery(ctx, db, qc, key, 0, "SELECT name FROM users", nil, scanNames)
+		require.NoError(t, err)
+	}
+	require.Equal(t, 3, expectation.Calls())
+
+	// 按前缀失效只删除匹配的键。
+	assert.Equal(t, 2, qc.InvalidatePrefix("users:"))
+	_, ok := c.Get("users:1")
+	assert.False(t, ok)
+	_, ok = c.Get("roles:1")
+	assert.True(t, ok)
+	assert.Equal(t, 0, qc.InvalidatePrefix("users:"))
+
+	// 按键失效后再次查询会重新执行。
+	qc.Invalidate("roles:1")
+	_, err := CachedQuery(ctx, db, qc, "roles:1", 0, "SELECT name FROM users", nil, scanNames)
+	require.NoError(t, err)
+	assert.Equal(t, 4, expectation.Calls())
+
+	// 查询期间发生失效时结果不写回缓存。
+	_, err = CachedQuery(ctx, db, qc, "users:3", 0, "SELECT name FROM users", nil, func(rows *stdsql.Rows) ([]string, error) {
+		qc.InvalidatePrefix("users:")
+		return scanNames(rows)
+	})
+	require.NoError(t, err)
+	_, ok = c.Get("users:3")
+	assert.False(t, ok)
+}
+
+// TestCachedQuery_Panic 验证执行查询的调用 panic 时，panic 继续传播，并发等待的调用收到 ErrLoaderPanicked。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestCachedQuery_Panic(t *testing.T) {
+	d := kittestdriver.New()
+	d.ExpectQuery("FROM users").
+		WillReturnRows([]string{"name"}, []driver.Value{"alice"}).
+		WillDelay(50 * time.Millisecond)
+	db, _ := kittestdriver.NewDB(d)
+	defer func() { _ = db.Close() }()
+	qc := NewQueryCache(newMapCache())
+	scan := func(*stdsql.Rows) ([]string, error) { panic("boom") }
+
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		assert.PanicsWithValue(t, "boom", func() {
+			_, _ = CachedQuery(context.Background(), db, qc, "users:all", time.Minute, "SELECT name FROM users", nil, scan)
+		})
+	})
+	time.Sleep(10 * time.Millisecond)
+	for range 3 {
+		wg.Go(func() {
+			got, err := CachedQuery(context.Background(), db, qc, "users:all", time.Minute, "SELECT name FROM users", nil, scan)
+			assert.ErrorIs(t, err, kitcache.ErrLoaderPanicked)
+			assert.Nil(t, got)
+		})
+	}
+	wg.Wait()
+}
+
+// TestQueryCache_RecordSweep 验证键记录达到阈值时清理已过期的记录，永不过期的记录保留。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestQueryCache_RecordSweep(t *testing.T) {
+	qc := NewQueryCache(newMapCache())
+
+	qc.mu.Lock()
+	defer qc.mu.Unlock()
+	qc.record("dict:1", 0)
+	for i := range queryKeysSweepMin - 2 {
+		qc.record("users:"+strconv.Itoa(i), time.Nanosecond)
+	}
+	time.Sleep(time.Millisecond)
+	assert.Len(t, qc.keys, queryKeysSweepMin-1)
+
+	// 达到阈值时删除已过期的记录，阈值按剩余记录数量重新计算。
+	qc.record("users:live", time.Minute)
+	assert.Len(t, qc.keys, 2)
+	assert.Contains(t, qc.keys, "dict:1")
+	assert.Contains(t, qc.keys, "users:live")
+	assert.Equal(t, queryKeysSweepMin, qc.sweepAt)
+}
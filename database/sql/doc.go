@@ -0,0 +1,18 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+// Package sql 提供基于标准库 database/sql 的通用查询辅助函数。
+//
+// QueryToMaps 与 ScanMaps 把结果集的每一行转换为以列名为键的 map[string]any；QueryToJSON 与 ScanJSON
+// 把结果集逐行写出为 NDJSON，不会把整个结果集读入内存。两者都按列的数据库类型规范化值：NULL 为 nil，
+// DECIMAL/NUMERIC 为保留精度的 json.Number，文本形式的整数与浮点数解析为数字，其他文本为 string，
+// 驱动已解析的 time.Time 等值保持不变。适合管理、调试接口和数据导出工具，不适合作为业务层的数据访问方式。
+//
+// CachedQuery 结合 cache 包缓存读多写少的查询结果：命中时直接返回，未命中时执行查询并写入缓存，同一个键的并发
+// 未命中只执行一次查询。NewQueryCache 创建的 QueryCache 记录写入的键，支持按键或按前缀失效。
+//
+// WithNoLog 与 WithForceLog 返回按查询关闭或强制驱动日志 Hook 的上下文，等同于子包 driver 中的同名函数。
+//
+// 驱动 Hook、MySQL 连接构造器与测试驱动分别由子包 driver、mysql 与 testdriver 提供。
+package sql
@@ -13,5 +13,25 @@
 // 执行 Before、按逆序执行 After；NewHookLogError 和 NewHookLogSlow 则提供
 // 错误日志与慢查询日志的现成 Hook。
 //
+// Before 阶段的 Hook 可以通过 HookContext.SetContext 替换底层操作使用的上下文，
+// 包装器会在操作结束或结果集关闭时释放该上下文。NewHookQueryTimeout 基于该能力为
+// 没有截止时间的查询附加默认超时；HookContext.Canceled 和 TimedOut 用于将上下文
+// 取消、超时与普通数据库错误区分开。
+//
+// Hook 可以额外实现 Retrier 接口，在操作失败后决定是否等待并重新执行；每次尝试的
+// HookContext.Retries 依次递增，HookContext.InTx 标记操作是否处于显式事务中。
+// NewHookRetry 提供只重试事务之外幂等操作的现成策略，使用带抖动的指数退避，
+// 并在重试耗尽时记录日志。
+//
+// 查询操作的 Hook 可以在 After 阶段调用 HookContext.OnRowsClose，包装器随后统计读取结果集的
+// 耗时和行数，并在结果集关闭时回调；HookLogSlow 借此按驱动调用与读取结果集的总耗时判断慢查询，
+// 并分别记录两个阶段的耗时和读取的行数。
+//
+// NewHookLogVerbose 按 config.DebugRegistry 的 VerboseSQL 开关记录每一次操作，开关可在运行时切换，
+// 适合常驻在 Hook 链中、排查问题时临时打开。
+//
+// WithNoLog 与 WithForceLog 按上下文控制单次操作的日志：前者使日志 Hook 跳过执行频率极高的查询，
+// 后者使 HookLogSlow 忽略慢查询阈值、HookLogVerbose 忽略 VerboseSQL 开关，完整记录关键路径。
+//
 // 本包只负责驱动包装与 Hook 编排，不负责注册具体数据库驱动或创建 *sql.DB。
 package driver
@@ -8,6 +8,10 @@ import (
 	"context"
 	"database/sql/driver"
 	"errors"
+	"io"
+	"reflect"
+	"sync"
+	"time"
 )
 
 // 以下断言确保包装类型满足 database/sql/driver 相关接口。
@@ -21,6 +25,15 @@ var (
 	_ driver.ConnBeginTx        = (*kitConn)(nil)
 	_ driver.NamedValueChecker  = (*kitConn)(nil)
 	_ driver.SessionResetter    = (*kitConn)(nil)
+
+	_ Retrier = (*HookManager)(nil)
+
+	_ driver.RowsNextResultSet              = (*kitRows)(nil)
+	_ driver.RowsColumnTypeScanType         = (*kitRows)(nil)
+	_ driver.RowsColumnTypeDatabaseTypeName = (*kitRows)(nil)
+	_ driver.RowsColumnTypeLength           = (*kitRows)(nil)
+	_ driver.RowsColumnTypeNullable         = (*kitRows)(nil)
+	_ driver.RowsColumnTypePrecisionScale   = (*kitRows)(nil)
 )
 
 // KitDriver 包装底层 driver.Driver，并在连接及其派生对象的操作前后执行 Hook。
@@ -63,24 +76,30 @@ func NewKitDriver(d driver.Driver, h Hook) *KitDriver {
 //   - driver.Conn: 成功时返回带 Hook 包装的连接。
 //   - error: Hook.Before 返回错误、底层 driver.Open 失败或 Hook.After 返回错误时返回错误。
 func (d *KitDriver) Open(name string) (driver.Conn, error) {
-	// 创建一个新的钩子上下文，用于连接操作。
-	ctx := NewHookContext(context.Background(), OpConnect, "", nil)
+	var conn driver.Conn
+	err := retry(context.Background(), d.hook, func(retries int) (*HookContext, error) {
+		// 创建一个新的钩子上下文，用于连接操作。
+		ctx := NewHookContext(context.Background(), OpConnect, "", nil)
+		ctx.retries = retries
 
-	// 执行前置钩子。
-	if err := d.hook.Before(ctx); err != nil {
-		return nil, err
-	}
+		// 执行前置钩子。
+		if err := d.hook.Before(ctx); err != nil {
+			return ctx, err
+		}
 
-	// 调用原始驱动的 Open 方法。
-	conn, err := d.driver.Open(name)
-	// 设置操作结果。
-	ctx.SetResult(conn, err)
+		// 调用原始驱动的 Open 方法。
+		c, err := d.driver.Open(name)
+		// 设置操作结果。
+		ctx.SetResult(c, err)
 
-	// 执行后置钩子。
-	if err := d.hook.After(ctx); err != nil {
-		return nil, err
-	}
+		// 执行后置钩子。
+		if err := d.hook.After(ctx); err != nil {
+			return ctx, err
+		}
 
+		conn = c
+		return ctx, err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -101,6 +120,26 @@ type kitConn struct {
 	driver.Conn
 	// 用于执行钩子操作的接口实例。
 	hook Hook
+	// inTx 表示连接当前处于 BeginTx 开启且尚未提交或回滚的事务中。
+	inTx bool
+}
+
+// newHookContext 创建连接上操作的 HookContext，并记录重试次数和事务状态。
+//
+// 参数：
+//   - ctx: 底层操作使用的原始上下文。
+//   - opType: 当前数据库操作的类型。
+//   - query: 当前操作关联的 SQL。
+//   - args: 当前操作的命名参数列表。
+//   - retries: 本次尝试之前已经重试的次数。
+//
+// 返回：
+//   - *HookContext: 新的 HookContext。
+func (c *kitConn) newHookContext(ctx context.Context, opType OpType, query string, args []driver.NamedValue, retries int) *HookContext {
+	hookCtx := NewHookContext(ctx, opType, query, args)
+	hookCtx.retries = retries
+	hookCtx.inTx = c.inTx
+	return hookCtx
 }
 
 // PrepareContext 创建带 Hook 包装的预处理语句。
@@ -115,24 +154,31 @@ type kitConn struct {
 func (c *kitConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
 	// 检查原始连接是否支持 PrepareContext。
 	if preparerCtx, ok := c.Conn.(driver.ConnPrepareContext); ok {
-		// 创建预处理语句的钩子上下文。
-		hookCtx := NewHookContext(ctx, OpPrepare, query, nil)
-
-		// 执行前置钩子。
-		if err := c.hook.Before(hookCtx); err != nil {
-			return nil, err
-		}
-
-		// 调用原始连接的 PrepareContext 方法。
-		stmt, err := preparerCtx.PrepareContext(ctx, query)
-		// 设置操作结果。
-		hookCtx.SetResult(stmt, err)
-
-		// 执行后置钩子。
-		if err := c.hook.After(hookCtx); err != nil {
-			return nil, err
-		}
-
+		var stmt driver.Stmt
+		err := retry(ctx, c.hook, func(retries int) (*HookContext, error) {
+			// 创建预处理语句的钩子上下文。
+			hookCtx := c.newHookContext(ctx, OpPrepare, query, nil, retries)
+			// Hook 替换的上下文在本次操作结束后释放。
+			defer hookCtx.releaseContext()
+
+			// 执行前置钩子。
+			if err := c.hook.Before(hookCtx); err != nil {
+				return hookCtx, err
+			}
+
+			// 调用原始连接的 PrepareContext 方法。
+			s, err := preparerCtx.PrepareContext(hookCtx.Context(), query)
+			// 设置操作结果。
+			hookCtx.SetResult(s, err)
+
+			// 执行后置钩子。
+			if err := c.hook.After(hookCtx); err != nil {
+				return hookCtx, err
+			}
+
+			stmt = s
+			return hookCtx, err
+		})
 		if err != nil {
 			return nil, err
 		}
@@ -142,6 +188,7 @@ func (c *kitConn) PrepareContext(ctx context.Context, query string) (driver.Stmt
 			Stmt:  stmt,
 			hook:  c.hook,
 			query: query,
+			conn:  c,
 		}, nil
 	}
 	return nil, errors.New("driver does not support prepare context")
@@ -160,24 +207,34 @@ func (c *kitConn) PrepareContext(ctx context.Context, query string) (driver.Stmt
 func (c *kitConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
 	// 检查原始连接是否支持 ExecContext。
 	if execer, ok := c.Conn.(driver.ExecerContext); ok {
-		// 创建执行操作的钩子上下文。
-		hookCtx := NewHookContext(ctx, OpExec, query, args)
-
-		// 执行前置钩子。
-		if err := c.hook.Before(hookCtx); err != nil {
+		var result driver.Result
+		err := retry(ctx, c.hook, func(retries int) (*HookContext, error) {
+			// 创建执行操作的钩子上下文。
+			hookCtx := c.newHookContext(ctx, OpExec, query, args, retries)
+			// Hook 替换的上下文在本次操作结束后释放。
+			defer hookCtx.releaseContext()
+
+			// 执行前置钩子。
+			if err := c.hook.Before(hookCtx); err != nil {
+				return hookCtx, err
+			}
+
+			// 调用原始连接的 ExecContext 方法。
+			r, err := execer.ExecContext(hookCtx.Context(), query, args)
+			// 设置操作结果。
+			hookCtx.SetResult(r, err)
+
+			// 执行后置钩子。
+			if err := c.hook.After(hookCtx); err != nil {
+				return hookCtx, err
+			}
+
+			result = r
+			return hookCtx, err
+		})
+		if nil != err && nil == result {
 			return nil, err
 		}
-
-		// 调用原始连接的 ExecContext 方法。
-		result, err := execer.ExecContext(ctx, query, args)
-		// 设置操作结果。
-		hookCtx.SetResult(result, err)
-
-		// 执行后置钩子。
-		if err := c.hook.After(hookCtx); err != nil {
-			return nil, err
-		}
-
 		return result, err
 	}
 	return nil, errors.New("driver does not support exec context")
@@ -196,24 +253,35 @@ func (c *kitConn) ExecContext(ctx context.Context, query string, args []driver.N
 func (c *kitConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
 	// 检查原始连接是否支持 QueryContext。
 	if queryer, ok := c.Conn.(driver.QueryerContext); ok {
-		// 创建查询操作的钩子上下文。
-		hookCtx := NewHookContext(ctx, OpQuery, query, args)
-
-		// 执行前置钩子。
-		if err := c.hook.Before(hookCtx); err != nil {
+		var rows driver.Rows
+		err := retry(ctx, c.hook, func(retries int) (*HookContext, error) {
+			// 创建查询操作的钩子上下文。
+			hookCtx := c.newHookContext(ctx, OpQuery, query, args, retries)
+
+			// 执行前置钩子。
+			if err := c.hook.Before(hookCtx); err != nil {
+				hookCtx.releaseContext()
+				return hookCtx, err
+			}
+
+			// 调用原始连接的 QueryContext 方法。
+			r, err := queryer.QueryContext(hookCtx.Context(), query, args)
+			// 设置操作结果。
+			hookCtx.SetResult(r, err)
+
+			// 执行后置钩子。
+			if err := c.hook.After(hookCtx); err != nil {
+				hookCtx.releaseContext()
+				return hookCtx, err
+			}
+
+			// Hook 替换的上下文需要在结果集关闭后释放，否则读取结果集时会被提前取消。
+			rows = hookCtx.bindRows(r)
+			return hookCtx, err
+		})
+		if nil != err && nil == rows {
 			return nil, err
 		}
-
-		// 调用原始连接的 QueryContext 方法。
-		rows, err := queryer.QueryContext(ctx, query, args)
-		// 设置操作结果。
-		hookCtx.SetResult(rows, err)
-
-		// 执行后置钩子。
-		if err := c.hook.After(hookCtx); err != nil {
-			return nil, err
-		}
-
 		return rows, err
 	}
 	return nil, errors.New("driver does not support query context")
@@ -229,25 +297,29 @@ func (c *kitConn) QueryContext(ctx context.Context, query string, args []driver.
 func (c *kitConn) Ping(ctx context.Context) error {
 	// 检查原始连接是否支持 Ping。
 	if pinger, ok := c.Conn.(driver.Pinger); ok {
-		// 创建 ping 操作的钩子上下文。
-		hookCtx := NewHookContext(ctx, OpPing, "", nil)
-
-		// 执行前置钩子。
-		if err := c.hook.Before(hookCtx); err != nil {
-			return err
-		}
-
-		// 调用原始连接的 Ping 方法。
-		err := pinger.Ping(ctx)
-		// 设置操作结果。
-		hookCtx.SetResult(nil, err)
-
-		// 执行后置钩子。
-		if err := c.hook.After(hookCtx); err != nil {
-			return err
-		}
-
-		return err
+		return retry(ctx, c.hook, func(retries int) (*HookContext, error) {
+			// 创建 ping 操作的钩子上下文。
+			hookCtx := c.newHookContext(ctx, OpPing, "", nil, retries)
+			// Hook 替换的上下文在本次操作结束后释放。
+			defer hookCtx.releaseContext()
+
+			// 执行前置钩子。
+			if err := c.hook.Before(hookCtx); err != nil {
+				return hookCtx, err
+			}
+
+			// 调用原始连接的 Ping 方法。
+			err := pinger.Ping(hookCtx.Context())
+			// 设置操作结果。
+			hookCtx.SetResult(nil, err)
+
+			// 执行后置钩子。
+			if err := c.hook.After(hookCtx); err != nil {
+				return hookCtx, err
+			}
+
+			return hookCtx, err
+		})
 	}
 	return errors.New("driver does not support ping")
 }
@@ -264,32 +336,41 @@ func (c *kitConn) Ping(ctx context.Context) error {
 func (c *kitConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
 	// 检查原始连接是否支持 BeginTx。
 	if beginner, ok := c.Conn.(driver.ConnBeginTx); ok {
-		// 创建开始事务的钩子上下文。
-		hookCtx := NewHookContext(ctx, OpBegin, "", nil)
-
-		// 执行前置钩子。
-		if err := c.hook.Before(hookCtx); err != nil {
-			return nil, err
-		}
-
-		// 调用原始连接的 BeginTx 方法。
-		tx, err := beginner.BeginTx(ctx, opts)
-		// 设置操作结果。
-		hookCtx.SetResult(tx, err)
-
-		// 执行后置钩子。
-		if err := c.hook.After(hookCtx); err != nil {
-			return nil, err
-		}
-
+		var tx driver.Tx
+		err := retry(ctx, c.hook, func(retries int) (*HookContext, error) {
+			// 创建开始事务的钩子上下文。
+			hookCtx := c.newHookContext(ctx, OpBegin, "", nil, retries)
+			// Hook 替换的上下文在本次操作结束后释放。
+			defer hookCtx.releaseContext()
+
+			// 执行前置钩子。
+			if err := c.hook.Before(hookCtx); err != nil {
+				return hookCtx, err
+			}
+
+			// 调用原始连接的 BeginTx 方法。
+			t, err := beginner.BeginTx(hookCtx.Context(), opts)
+			// 设置操作结果。
+			hookCtx.SetResult(t, err)
+
+			// 执行后置钩子。
+			if err := c.hook.After(hookCtx); err != nil {
+				return hookCtx, err
+			}
+
+			tx = t
+			return hookCtx, err
+		})
 		if err != nil {
 			return nil, err
 		}
 
 		// 返回包装后的事务实例。
+		c.inTx = true
 		return &kitTx{
 			Tx:   tx,
 			hook: c.hook,
+			conn: c,
 		}, nil
 	}
 	return nil, errors.New("driver does not support begin tx")
@@ -335,6 +416,25 @@ type kitStmt struct {
 	hook Hook
 	// query 是预处理 SQL 语句文本。
 	query string
+	// conn 是创建该语句的连接，用于读取事务状态。
+	conn *kitConn
+}
+
+// newHookContext 创建预处理语句操作的 HookContext，并记录重试次数和事务状态。
+//
+// 参数：
+//   - ctx: 底层操作使用的原始上下文。
+//   - opType: 当前数据库操作的类型。
+//   - args: 当前操作的命名参数列表。
+//   - retries: 本次尝试之前已经重试的次数。
+//
+// 返回：
+//   - *HookContext: 新的 HookContext。
+func (s *kitStmt) newHookContext(ctx context.Context, opType OpType, args []driver.NamedValue, retries int) *HookContext {
+	hookCtx := NewHookContext(ctx, opType, s.query, args)
+	hookCtx.retries = retries
+	hookCtx.inTx = nil != s.conn && s.conn.inTx
+	return hookCtx
 }
 
 // ExecContext 执行预处理语句并在操作前后执行 Hook。
@@ -349,24 +449,34 @@ type kitStmt struct {
 func (s *kitStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
 	// 检查原始语句是否支持 ExecContext。
 	if execer, ok := s.Stmt.(driver.StmtExecContext); ok {
-		// 创建执行预处理语句的钩子上下文。
-		hookCtx := NewHookContext(ctx, OpStmtExec, s.query, args)
-
-		// 执行前置钩子。
-		if err := s.hook.Before(hookCtx); err != nil {
+		var result driver.Result
+		err := retry(ctx, s.hook, func(retries int) (*HookContext, error) {
+			// 创建执行预处理语句的钩子上下文。
+			hookCtx := s.newHookContext(ctx, OpStmtExec, args, retries)
+			// Hook 替换的上下文在本次操作结束后释放。
+			defer hookCtx.releaseContext()
+
+			// 执行前置钩子。
+			if err := s.hook.Before(hookCtx); err != nil {
+				return hookCtx, err
+			}
+
+			// 调用原始语句的 ExecContext 方法。
+			r, err := execer.ExecContext(hookCtx.Context(), args)
+			// 设置操作结果。
+			hookCtx.SetResult(r, err)
+
+			// 执行后置钩子。
+			if err := s.hook.After(hookCtx); err != nil {
+				return hookCtx, err
+			}
+
+			result = r
+			return hookCtx, err
+		})
+		if nil != err && nil == result {
 			return nil, err
 		}
-
-		// 调用原始语句的 ExecContext 方法。
-		result, err := execer.ExecContext(ctx, args)
-		// 设置操作结果。
-		hookCtx.SetResult(result, err)
-
-		// 执行后置钩子。
-		if err := s.hook.After(hookCtx); err != nil {
-			return nil, err
-		}
-
 		return result, err
 	}
 	return nil, errors.New("stmt does not support exec context")
@@ -384,24 +494,35 @@ func (s *kitStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (dr
 func (s *kitStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
 	// 检查原始语句是否支持 QueryContext。
 	if queryer, ok := s.Stmt.(driver.StmtQueryContext); ok {
-		// 创建查询预处理语句的钩子上下文。
-		hookCtx := NewHookContext(ctx, OpStmtQuery, s.query, args)
-
-		// 执行前置钩子。
-		if err := s.hook.Before(hookCtx); err != nil {
-			return nil, err
-		}
-
-		// 调用原始语句的 QueryContext 方法。
-		rows, err := queryer.QueryContext(ctx, args)
-		// 设置操作结果。
-		hookCtx.SetResult(rows, err)
-
-		// 执行后置钩子。
-		if err := s.hook.After(hookCtx); err != nil {
+		var rows driver.Rows
+		err := retry(ctx, s.hook, func(retries int) (*HookContext, error) {
+			// 创建查询预处理语句的钩子上下文。
+			hookCtx := s.newHookContext(ctx, OpStmtQuery, args, retries)
+
+			// 执行前置钩子。
+			if err := s.hook.Before(hookCtx); err != nil {
+				hookCtx.releaseContext()
+				return hookCtx, err
+			}
+
+			// 调用原始语句的 QueryContext 方法。
+			r, err := queryer.QueryContext(hookCtx.Context(), args)
+			// 设置操作结果。
+			hookCtx.SetResult(r, err)
+
+			// 执行后置钩子。
+			if err := s.hook.After(hookCtx); err != nil {
+				hookCtx.releaseContext()
+				return hookCtx, err
+			}
+
+			// Hook 替换的上下文需要在结果集关闭后释放，否则读取结果集时会被提前取消。
+			rows = hookCtx.bindRows(r)
+			return hookCtx, err
+		})
+		if nil != err && nil == rows {
 			return nil, err
 		}
-
 		return rows, err
 	}
 	return nil, errors.New("stmt does not support query context")
@@ -441,6 +562,15 @@ type kitTx struct {
 	driver.Tx
 	// 用于执行钩子操作的接口实例。
 	hook Hook
+	// conn 是开启该事务的连接，事务结束时清除其事务状态。
+	conn *kitConn
+}
+
+// end 在事务提交或回滚后清除连接的事务状态。
+func (t *kitTx) end() {
+	if nil != t.conn {
+		t.conn.inTx = false
+	}
 }
 
 // Commit 提交事务并在操作前后执行 Hook。
@@ -452,6 +582,9 @@ type kitTx struct {
 func (t *kitTx) Commit() error {
 	// 创建提交事务的钩子上下文。
 	hookCtx := NewHookContext(context.Background(), OpCommit, "", nil)
+	hookCtx.inTx = true
+	// 无论提交是否成功，事务都已结束。
+	defer t.end()
 
 	// 执行前置钩子。
 	if err := t.hook.Before(hookCtx); err != nil {
@@ -480,6 +613,9 @@ func (t *kitTx) Commit() error {
 func (t *kitTx) Rollback() error {
 	// 创建回滚事务的钩子上下文。
 	hookCtx := NewHookContext(context.Background(), OpRollback, "", nil)
+	hookCtx.inTx = true
+	// 无论回滚是否成功，事务都已结束。
+	defer t.end()
 
 	// 执行前置钩子。
 	if err := t.hook.Before(hookCtx); err != nil {
@@ -498,3 +634,199 @@ func (t *kitTx) Rollback() error {
 
 	return err
 }
+
+// kitRows 包装底层 driver.Rows，在结果集关闭时释放 Hook 替换的上下文并通知 OnRowsClose 登记的回调。
+//
+// kitRows 只在 Hook 调用 HookContext.SetContext 登记了 release，或调用 HookContext.OnRowsClose
+// 登记了回调时使用。它把读取结果集的耗时和行数累计到查询操作的 HookContext 上，并转发
+// database/sql 会探测的结果集可选接口；底层未实现时返回与 database/sql 默认行为一致的值。
+// database/sql 保证 Next 与 Close 不会并发调用，因此累计统计无需加锁。
+type kitRows struct {
+	// 原始结果集实例。
+	driver.Rows
+	// release 释放 Hook 替换的上下文，可为 nil。
+	release func()
+	// hookCtx 是返回该结果集的查询操作的 HookContext。
+	hookCtx *HookContext
+	// once 保证关闭逻辑只执行一次。
+	once sync.Once
+}
+
+// Next 读取下一行并累计读取耗时和行数。
+//
+// 参数：
+//   - dest: 接收当前行各列值的切片。
+//
+// 返回：
+//   - error: 底层结果集返回的错误；没有更多数据时为 io.EOF。
+func (r *kitRows) Next(dest []driver.Value) error {
+	start := time.Now()
+	err := r.Rows.Next(dest)
+	r.hookCtx.rowsDuration += time.Since(start)
+	if nil == err {
+		r.hookCtx.rowsScanned++
+	}
+	return err
+}
+
+// Close 关闭底层结果集，随后通知 OnRowsClose 登记的回调并释放 Hook 替换的上下文。
+//
+// 参数：无。
+//
+// 返回：
+//   - error: 底层结果集关闭失败时返回错误。
+func (r *kitRows) Close() error {
+	start := time.Now()
+	err := r.Rows.Close()
+	r.once.Do(func() {
+		r.hookCtx.rowsDuration += time.Since(start)
+		r.hookCtx.rowsClosed = true
+		for _, fn := range r.hookCtx.onRowsClose {
+			fn(r.hookCtx)
+		}
+		if nil != r.release {
+			r.release()
+		}
+	})
+	return err
+}
+
+// HasNextResultSet 转发到底层 driver.RowsNextResultSet。
+//
+// 参数：无。
+//
+// 返回：
+//   - bool: 存在下一个结果集时返回 true；底层不支持时返回 false。
+func (r *kitRows) HasNextResultSet() bool {
+	if next, ok := r.Rows.(driver.RowsNextResultSet); ok {
+		return next.HasNextResultSet()
+	}
+	return false
+}
+
+// NextResultSet 转发到底层 driver.RowsNextResultSet。
+//
+// 参数：无。
+//
+// 返回：
+//   - error: 底层返回的错误；底层不支持时返回 io.EOF。
+func (r *kitRows) NextResultSet() error {
+	if next, ok := r.Rows.(driver.RowsNextResultSet); ok {
+		start := time.Now()
+		err := next.NextResultSet()
+		r.hookCtx.rowsDuration += time.Since(start)
+		return err
+	}
+	return io.EOF
+}
+
+// ColumnTypeScanType 转发到底层 driver.RowsColumnTypeScanType。
+//
+// 参数：
+//   - index: 列序号。
+//
+// 返回：
+//   - reflect.Type: 列的扫描类型；底层不支持时返回 interface{} 类型。
+func (r *kitRows) ColumnTypeScanType(index int) reflect.Type {
+	if typer, ok := r.Rows.(driver.RowsColumnTypeScanType); ok {
+		return typer.ColumnTypeScanType(index)
+	}
+	return reflect.TypeOf((*interface{})(nil)).Elem()
+}
+
+// ColumnTypeDatabaseTypeName 转发到底层 driver.RowsColumnTypeDatabaseTypeName。
+//
+// 参数：
+//   - index: 列序号。
+//
+// 返回：
+//   - string: 列的数据库类型名；底层不支持时返回空字符串。
+func (r *kitRows) ColumnTypeDatabaseTypeName(index int) string {
+	if typer, ok := r.Rows.(driver.RowsColumnTypeDatabaseTypeName); ok {
+		return typer.ColumnTypeDatabaseTypeName(index)
+	}
+	return ""
+}
+
+// ColumnTypeLength 转发到底层 driver.RowsColumnTypeLength。
+//
+// 参数：
+//   - index: 列序号。
+//
+// 返回：
+//   - int64: 列长度。
+//   - bool: 底层支持且列为变长类型时返回 true。
+func (r *kitRows) ColumnTypeLength(index int) (int64, bool) {
+	if typer, ok := r.Rows.(driver.RowsColumnTypeLength); ok {
+		return typer.ColumnTypeLength(index)
+	}
+	return 0, false
+}
+
+// ColumnTypeNullable 转发到底层 driver.RowsColumnTypeNullable。
+//
+// 参数：
+//   - index: 列序号。
+//
+// 返回：
+//   - bool: 列是否可为空。
+//   - bool: 底层能够确定可空性时返回 true。
+func (r *kitRows) ColumnTypeNullable(index int) (bool, bool) {
+	if typer, ok := r.Rows.(driver.RowsColumnTypeNullable); ok {
+		return typer.ColumnTypeNullable(index)
+	}
+	return false, false
+}
+
+// ColumnTypePrecisionScale 转发到底层 driver.RowsColumnTypePrecisionScale。
+//
+// 参数：
+//   - index: 列序号。
+//
+// 返回：
+//   - int64: 精度。
+//   - int64: 小数位数。
+//   - bool: 底层支持且列为十进制类型时返回 true。
+func (r *kitRows) ColumnTypePrecisionScale(index int) (int64, int64, bool) {
+	if typer, ok := r.Rows.(driver.RowsColumnTypePrecisionScale); ok {
+		return typer.ColumnTypePrecisionScale(index)
+	}
+	return 0, 0, false
+}
+
+// retry 执行一次操作，并在 Hook 实现 Retrier 且要求重试时等待后再次执行。
+//
+// 只有底层操作返回错误且该错误未被 Hook.After 覆盖时才会询问 Retrier；Hook.Before 失败不会重试。
+//
+// 参数：
+//   - ctx: 操作上下文；等待重试期间该上下文结束时停止重试。
+//   - hook: 当前连接使用的 Hook。
+//   - attempt: 执行一次完整的 Before、底层操作和 After 流程，参数为已经重试的次数，
+//     返回本次尝试的 HookContext 和应返回给调用方的错误。
+//
+// 返回：
+//   - error: 最后一次尝试返回的错误。
+func retry(ctx context.Context, hook Hook, attempt func(retries int) (*HookContext, error)) error {
+	retrier, ok := hook.(Retrier)
+	for retries := 0; ; retries++ {
+		hookCtx, err := attempt(retries)
+		if nil == err || !ok || err != hookCtx.OriginError() {
+			return err
+		}
+		delay, again := retrier.Retry(hookCtx)
+		if !again {
+			return err
+		}
+		if delay > 0 {
+			timer := time.NewTimer(delay)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return err
+			}
+		} else if nil != ctx.Err() {
+			return err
+		}
+	}
+}
@@ -1239,6 +1239,7 @@ func (tx *testTx) Rollback() error {
 // 该辅助类型用于避免查询相关测试依赖真实数据库服务。
 type testRows struct {
 	columns []string
+	rows    int
 }
 
 // Columns 返回预设列名。
@@ -1258,11 +1259,17 @@ func (r *testRows) Columns() []string {
 //   - error: 始终为 nil。
 func (r *testRows) Close() error { return nil }
 
-// Next 表示结果集已经耗尽。
+// Next 依次返回预设数量的空行，之后表示结果集已经耗尽。
 //
 // 参数：
 //   - dest: 目标值切片，本辅助实现不写入任何数据。
 //
 // 返回：
-//   - error: 始终返回 io.EOF 表示没有更多数据。
-func (r *testRows) Next(dest []driver.Value) error { return io.EOF }
+//   - error: 仍有预设行时返回 nil，否则返回 io.EOF 表示没有更多数据。
+func (r *testRows) Next(dest []driver.Value) error {
+	if r.rows > 0 {
+		r.rows--
+		return nil
+	}
+	return io.EOF
+}
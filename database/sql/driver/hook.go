@@ -7,6 +7,7 @@ package driver
 import (
 	"context"
 	"database/sql/driver"
+	"errors"
 	"sync"
 	"time"
 )
@@ -93,10 +94,14 @@ func (o OpType) String() string {
 // 和原始错误，并实现 context.Context 以透传取消信号、截止时间和上下文值。
 // NewHookContext 创建后会立即记录开始时间；调用 SetResult 后，Duration 才表示
 // 本次操作的实际耗时。Hook 之间还可以通过 SetHookValue 和 GetHookValue 在当前
-// 操作内共享数据。
+// 操作内共享数据。Before 阶段的 Hook 可以通过 SetContext 替换底层操作使用的上下文，
+// 例如为没有截止时间的查询附加默认超时。查询操作还可以通过 OnRowsClose 在结果集关闭后
+// 读取 RowsDuration 和 RowsScanned，区分驱动调用耗时与读取结果集耗时。
 type HookContext struct {
-	// 原始上下文对象。
+	// 原始上下文对象；Hook 调用 SetContext 后替换为新的上下文。
 	originContext context.Context
+	// release 在底层操作不再需要 SetContext 设置的上下文时调用。
+	release func()
 	// 操作类型。
 	opType OpType
 	// SQL 查询语句。
@@ -113,6 +118,18 @@ type HookContext struct {
 	originResult interface{}
 	// 用于存储钩子相关的键值对数据。
 	hookMap sync.Map
+	// retries 是本次尝试之前已经重试的次数，首次执行为 0。
+	retries int
+	// inTx 表示操作是否发生在显式事务中。
+	inTx bool
+	// rowsDuration 是读取和关闭结果集的累计耗时。
+	rowsDuration time.Duration
+	// rowsScanned 是从结果集读取的行数。
+	rowsScanned int64
+	// rowsClosed 表示结果集已经关闭，rowsDuration 和 rowsScanned 不再变化。
+	rowsClosed bool
+	// onRowsClose 是结果集关闭时依次调用的回调。
+	onRowsClose []func(ctx *HookContext)
 }
 
 // NewHookContext 创建一次数据库操作对应的 HookContext。
@@ -173,7 +190,8 @@ func (h *HookContext) EndTime() time.Time {
 
 // Duration 返回当前操作从开始到记录结果之间的耗时。
 //
-// 在 SetResult 调用前，Duration 的返回值不表示有效耗时。
+// 在 SetResult 调用前，Duration 的返回值不表示有效耗时。对查询操作，Duration 只包含驱动调用
+// 返回结果集之前的耗时，不包含读取结果集的耗时。
 //
 // 参数：无。
 //
@@ -183,6 +201,64 @@ func (h *HookContext) Duration() time.Duration {
 	return h.endTime.Sub(h.startTime)
 }
 
+// RowsDuration 返回读取和关闭结果集的累计耗时。
+//
+// 只有 Hook 在 After 阶段调用 OnRowsClose 后，包装器才会统计结果集；统计的是调用 Next、
+// NextResultSet 和 Close 的耗时，不包含调用方在两次 Next 之间处理数据的时间。
+//
+// 参数：无。
+//
+// 返回：
+//   - time.Duration: 读取结果集的累计耗时；未统计时返回 0。
+func (h *HookContext) RowsDuration() time.Duration {
+	return h.rowsDuration
+}
+
+// RowsScanned 返回从结果集读取的行数。
+//
+// 参数：无。
+//
+// 返回：
+//   - int64: 所有结果集中 Next 成功返回的次数；未统计时返回 0。
+func (h *HookContext) RowsScanned() int64 {
+	return h.rowsScanned
+}
+
+// RowsClosed 判断结果集是否已经关闭。
+//
+// 参数：无。
+//
+// 返回：
+//   - bool: 结果集已关闭、RowsDuration 和 RowsScanned 为最终值时返回 true。
+func (h *HookContext) RowsClosed() bool {
+	return h.rowsClosed
+}
+
+// TotalDuration 返回驱动调用与读取结果集的总耗时。
+//
+// 参数：无。
+//
+// 返回：
+//   - time.Duration: Duration 与 RowsDuration 之和。
+func (h *HookContext) TotalDuration() time.Duration {
+	return h.Duration() + h.rowsDuration
+}
+
+// OnRowsClose 登记在结果集关闭后调用的回调。
+//
+// OnRowsClose 只对成功返回结果集的 OpQuery 和 OpStmtQuery 生效，应在 After 阶段调用；
+// 登记后包装器会统计读取结果集的耗时和行数，并在结果集关闭时按登记顺序调用 fn，
+// 此时 RowsClosed 返回 true。操作失败、没有返回结果集或后续 Hook.After 返回错误时，
+// 登记的回调不会被调用。
+//
+// 参数：
+//   - fn: 结果集关闭后调用的回调，参数为当前操作的 HookContext。
+func (h *HookContext) OnRowsClose(fn func(ctx *HookContext)) {
+	if nil != fn {
+		h.onRowsClose = append(h.onRowsClose, fn)
+	}
+}
+
 // OpType 返回当前操作的类型。
 //
 // 参数：无。
@@ -242,6 +318,31 @@ func (h *HookContext) OriginResult() interface{} {
 	return h.originResult
 }
 
+// Retries 返回本次尝试之前已经重试的次数。
+//
+// 首次执行为 0；Hook 实现 Retrier 并要求重试时，包装器会以新的 HookContext 重新执行整个
+// Before、底层操作和 After 流程，每次重试的 Retries 依次加一。
+//
+// 参数：无。
+//
+// 返回：
+//   - int: 已经重试的次数。
+func (h *HookContext) Retries() int {
+	return h.retries
+}
+
+// InTx 判断操作是否发生在 BeginTx 开启且尚未提交或回滚的事务中。
+//
+// OpBegin 本身不算在事务中；OpCommit 和 OpRollback 算在事务中。
+//
+// 参数：无。
+//
+// 返回：
+//   - bool: 操作发生在显式事务中时返回 true。
+func (h *HookContext) InTx() bool {
+	return h.inTx
+}
+
 // GetHookValue 读取当前操作中由 Hook 保存的共享数据。
 //
 // 参数：
@@ -263,6 +364,96 @@ func (h *HookContext) SetHookValue(key string, value interface{}) {
 	h.hookMap.Store(key, value)
 }
 
+// Context 返回底层操作实际使用的上下文。
+//
+// 未调用 SetContext 时返回 NewHookContext 传入的原始上下文。
+//
+// 参数：无。
+//
+// 返回：
+//   - context.Context: 底层操作实际使用的上下文。
+func (h *HookContext) Context() context.Context {
+	return h.originContext
+}
+
+// SetContext 替换底层操作使用的上下文。
+//
+// SetContext 只应在 Before 阶段调用，新的上下文会传递给底层 driver，并作为后续
+// Deadline、Done、Err 和 Value 的委托对象。release 会在底层操作不再需要该上下文时
+// 由包装器调用一次：普通操作在 After 执行完成后调用，查询操作在结果集关闭时调用。
+// 多次调用时，之前登记的 release 也会被调用。
+//
+// 参数：
+//   - ctx: 新的上下文，通常派生自 Context 的返回值。
+//   - release: 释放新上下文的函数，例如 context.WithTimeout 返回的 cancel；可为 nil。
+func (h *HookContext) SetContext(ctx context.Context, release func()) {
+	h.originContext = ctx
+	if nil == release {
+		return
+	}
+	if previous := h.release; nil != previous {
+		h.release = func() {
+			release()
+			previous()
+		}
+	} else {
+		h.release = release
+	}
+}
+
+// Canceled 判断底层操作是否因上下文取消或超时而失败。
+//
+// 仅当底层操作返回错误且操作使用的上下文已结束时返回 true，用于将取消与普通数据库错误区分记录。
+//
+// 参数：无。
+//
+// 返回：
+//   - bool: 操作因上下文取消或超时失败时返回 true。
+func (h *HookContext) Canceled() bool {
+	return nil != h.originError && nil != h.originContext.Err()
+}
+
+// TimedOut 判断底层操作是否因上下文截止时间到达而失败。
+//
+// 参数：无。
+//
+// 返回：
+//   - bool: 操作失败且上下文错误为 context.DeadlineExceeded 时返回 true。
+func (h *HookContext) TimedOut() bool {
+	return h.Canceled() && errors.Is(h.originContext.Err(), context.DeadlineExceeded)
+}
+
+// releaseContext 调用 SetContext 登记的 release，并保证只调用一次。
+func (h *HookContext) releaseContext() {
+	if release := h.release; nil != release {
+		h.release = nil
+		release()
+	}
+}
+
+// bindRows 将 SetContext 登记的 release 和 OnRowsClose 登记的回调绑定到结果集的 Close 上。
+//
+// 两者都未登记时原样返回 rows；rows 为 nil 时立即释放上下文并丢弃回调。
+//
+// 参数：
+//   - rows: 底层操作返回的结果集。
+//
+// 返回：
+//   - driver.Rows: 需要延迟释放或统计结果集时返回包装后的结果集，否则返回 rows。
+func (h *HookContext) bindRows(rows driver.Rows) driver.Rows {
+	if nil == h.release && 0 == len(h.onRowsClose) {
+		return rows
+	}
+	if nil == rows {
+		h.onRowsClose = nil
+		h.releaseContext()
+		return rows
+	}
+	release := h.release
+	h.release = nil
+	return &kitRows{Rows: rows, release: release, hookCtx: h}
+}
+
 // Deadline 返回原始上下文的截止时间。
 //
 // 参数：无。
@@ -330,6 +521,23 @@ type Hook interface {
 	After(ctx *HookContext) error
 }
 
+// Retrier 是 Hook 可选实现的重试策略接口。
+//
+// 包装器在 Connect、Prepare、Exec、Query、Ping、Begin、StmtExec 和 StmtQuery 操作失败、且错误未被
+// Hook.After 覆盖时调用 Retry；返回重试时等待指定时长后，以新的 HookContext 重新执行该操作。
+// 等待期间操作上下文结束时不再重试，直接返回最后一次的错误。
+type Retrier interface {
+	// Retry 决定失败的操作是否重试。
+	//
+	// 参数：
+	//   - ctx: 失败的这次尝试的 HookContext，可通过 OriginError 和 Retries 读取错误与已重试次数。
+	//
+	// 返回：
+	//   - time.Duration: 下一次重试前的等待时长。
+	//   - bool: 需要重试时返回 true。
+	Retry(ctx *HookContext) (time.Duration, bool)
+}
+
 // HookManager 按顺序编排多个 Hook。
 //
 // HookManager 会按 AddHook 的注册顺序调用 Before，并按相反顺序调用 After，
@@ -393,3 +601,22 @@ func (m *HookManager) After(ctx *HookContext) error {
 	}
 	return nil
 }
+
+// Retry 按注册顺序询问实现了 Retrier 的 Hook，返回第一个要求重试的结果。
+//
+// 参数：
+//   - ctx: 失败的这次尝试的 HookContext。
+//
+// 返回：
+//   - time.Duration: 下一次重试前的等待时长。
+//   - bool: 任一 Retrier 要求重试时返回 true；没有 Hook 实现 Retrier 时返回 false。
+func (m *HookManager) Retry(ctx *HookContext) (time.Duration, bool) {
+	for _, hook := range m.hooks {
+		if retrier, ok := hook.(Retrier); ok {
+			if delay, retry := retrier.Retry(ctx); retry {
+				return delay, true
+			}
+		}
+	}
+	return 0, false
+}
@@ -17,7 +17,7 @@ type (
 	//
 	// HookLogError 会在 After 阶段检查 HookContext.OriginError；当底层操作返回
 	// 错误时，它会异步提交一条包含操作类型、耗时以及可选 namespace、SQL 和
-	// 参数摘要的错误日志。调用方应提供可用的 logger。
+	// 参数摘要的错误日志。上下文经 WithNoLog 标记的操作不记录。调用方应提供可用的 logger。
 	HookLogError struct {
 		// namespace 是日志记录的命名空间。
 		namespace string
@@ -54,8 +54,10 @@ func (h *HookLogError) Before(ctx *HookContext) error {
 
 // After 在底层操作返回错误时异步记录错误日志。
 //
-// After 仅在 HookContext.OriginError 非 nil 时写日志。日志字段包含 operation、
-// duration，以及存在时的 namespace、query 和 args。
+// After 仅在 HookContext.OriginError 非 nil 且上下文未经 WithNoLog 标记时写日志。日志字段包含 operation、
+// duration，以及存在时的 namespace、query 和 args。操作因上下文取消或超时失败时
+// （HookContext.Canceled 为 true），改为记录 Warn 日志，并附加 canceled 字段，
+// 其值为上下文错误（context.Canceled 或 context.DeadlineExceeded），以便与数据库错误区分。
 //
 // 参数：
 //   - ctx: 当前操作的 HookContext。
@@ -63,8 +65,8 @@ func (h *HookLogError) Before(ctx *HookContext) error {
 // 返回：
 //   - error: 始终返回 nil，不会覆盖原始操作结果。
 func (h *HookLogError) After(ctx *HookContext) error {
-	// 只有在出现错误时才记录日志。
-	if nil == ctx.OriginError() {
+	// 只有在出现错误且上下文未关闭日志时才记录日志。
+	if nil == ctx.OriginError() || LogSuppress == LogModeFromContext(ctx) {
 		return nil
 	}
 
@@ -89,6 +91,15 @@ func (h *HookLogError) After(ctx *HookContext) error {
 		m["args"] = argsStr
 	}
 
+	// 上下文取消或超时不是数据库错误，单独以警告级别记录。
+	if ctx.Canceled() {
+		m["canceled"] = ctx.Err()
+		_ = kitgoroutine.Submit(func() {
+			h.logger.WithFields(m).Warn(ctx.OriginError())
+		})
+		return nil
+	}
+
 	// 记录错误日志。
 	_ = kitgoroutine.Submit(func() {
 		h.logger.WithFields(m).Error(ctx.OriginError())
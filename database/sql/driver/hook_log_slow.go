@@ -18,7 +18,11 @@ type (
 	//
 	// HookLogSlow 会在 After 阶段比较 HookContext.Duration 与 threshold；当耗时
 	// 大于等于阈值时，它会异步提交一条包含操作类型、耗时以及可选 namespace、
-	// SQL 和参数摘要的警告日志。调用方应提供可用的 logger。
+	// SQL 和参数摘要的警告日志。成功返回结果集的查询改为在结果集关闭后按驱动调用与
+	// 读取结果集的总耗时判断，日志额外包含两个阶段各自的耗时和读取的行数，用于区分
+	// 慢在数据库服务端还是慢在读取大结果集。上下文经 WithNoLog 标记的操作不记录；
+	// 经 WithForceLog 标记的操作忽略阈值，未达到阈值时以 Info 级别记录并附加 forced 字段。
+	// 调用方应提供可用的 logger。
 	HookLogSlow struct {
 		// namespace 是日志记录的命名空间。
 		namespace string
@@ -60,7 +64,10 @@ func (h *HookLogSlow) Before(ctx *HookContext) error {
 // After 在操作耗时达到阈值时异步记录慢操作日志。
 //
 // After 仅在 HookContext.Duration 大于等于 threshold 时写日志。日志字段包含
-// operation、duration，以及存在时的 namespace、query 和 args。
+// operation、duration，以及存在时的 namespace、query 和 args。成功返回结果集的
+// OpQuery 和 OpStmtQuery 不在此时判断，而是通过 HookContext.OnRowsClose 推迟到结果集关闭后，
+// 以 TotalDuration 作为 duration，并额外记录 driver_duration、rows_duration 和 rows。
+// 日志模式由 LogModeFromContext 决定，见 WithNoLog 与 WithForceLog。
 //
 // 参数：
 //   - ctx: 当前操作的 HookContext。
@@ -68,12 +75,86 @@ func (h *HookLogSlow) Before(ctx *HookContext) error {
 // 返回：
 //   - error: 始终返回 nil，不会覆盖原始操作结果。
 func (h *HookLogSlow) After(ctx *HookContext) error {
-	// 只记录耗时达到阈值的操作。
+	if LogSuppress == LogModeFromContext(ctx) {
+		return nil
+	}
+
+	// 查询的耗时包含读取结果集的阶段，等结果集关闭后再判断。
+	if (ctx.OpType() == OpQuery || ctx.OpType() == OpStmtQuery) && nil == ctx.OriginError() && nil != ctx.OriginResult() {
+		ctx.OnRowsClose(h.afterRows)
+		return nil
+	}
+
+	// 只记录耗时达到阈值或被强制记录的操作。
 	duration := ctx.Duration()
-	if duration < h.threshold {
+	slow := duration >= h.threshold
+	if !slow && LogForce != LogModeFromContext(ctx) {
 		return nil
 	}
 
+	h.log(h.fields(ctx, duration), slow)
+
+	return nil
+}
+
+// afterRows 在查询结果集关闭后，按总耗时判断并记录慢查询日志。
+//
+// 参数：
+//   - ctx: 查询操作的 HookContext，结果集已关闭。
+func (h *HookLogSlow) afterRows(ctx *HookContext) {
+	duration := ctx.TotalDuration()
+	slow := duration >= h.threshold
+	if !slow && LogForce != LogModeFromContext(ctx) {
+		return
+	}
+
+	m := h.fields(ctx, duration)
+	m["driver_duration"] = ctx.Duration()
+	m["rows_duration"] = ctx.RowsDuration()
+	m["rows"] = ctx.RowsScanned()
+	h.log(m, slow)
+}
+
+// fields 构建慢操作日志的公共字段。
+//
+// 参数：
+//   - ctx: 当前操作的 HookContext。
+//   - duration: 写入 duration 字段的耗时。
+//
+// 返回：
+//   - map[string]interface{}: 包含 operation、duration 以及存在时的 namespace、query 和 args 的字段。
+func (h *HookLogSlow) fields(ctx *HookContext, duration time.Duration) map[string]interface{} {
+	return logFields(h.namespace, ctx, duration)
+}
+
+// log 异步提交慢操作日志。
+//
+// 参数：
+//   - m: 日志字段。
+//   - slow: 耗时是否达到阈值；未达到阈值的强制记录以 Info 级别写入并附加 forced 字段。
+func (h *HookLogSlow) log(m map[string]interface{}, slow bool) {
+	if !slow {
+		m["forced"] = true
+		_ = kitgoroutine.Submit(func() {
+			h.logger.WithFields(m).Info("")
+		})
+		return
+	}
+	_ = kitgoroutine.Submit(func() {
+		h.logger.WithFields(m).Warn("")
+	})
+}
+
+// logFields 构建操作日志的公共字段。
+//
+// 参数：
+//   - namespace: 日志记录的命名空间；为空时省略该字段。
+//   - ctx: 当前操作的 HookContext。
+//   - duration: 写入 duration 字段的耗时。
+//
+// 返回：
+//   - map[string]interface{}: 包含 operation、duration 以及存在时的 namespace、query 和 args 的字段。
+func logFields(namespace string, ctx *HookContext, duration time.Duration) map[string]interface{} {
 	// 构建参数字符串。
 	var args []string
 	for _, arg := range ctx.Args() {
@@ -85,8 +166,8 @@ func (h *HookLogSlow) After(ctx *HookContext) error {
 		"operation": ctx.OpType(),
 		"duration":  duration,
 	}
-	if h.namespace != "" {
-		m["namespace"] = h.namespace
+	if namespace != "" {
+		m["namespace"] = namespace
 	}
 	if ctx.Query() != "" {
 		m["query"] = ctx.Query()
@@ -94,11 +175,5 @@ func (h *HookLogSlow) After(ctx *HookContext) error {
 	if argsStr != "" {
 		m["args"] = argsStr
 	}
-
-	// 记录慢操作日志。
-	_ = kitgoroutine.Submit(func() {
-		h.logger.WithFields(m).Warn("")
-	})
-
-	return nil
+	return m
 }
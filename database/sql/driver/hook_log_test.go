@@ -8,10 +8,12 @@ import (
 	"context"
 	"database/sql/driver"
 	"errors"
+	"io"
 	"sync"
 	"testing"
 	"time"
 
+	kitconfig "github.com/fsyyft-go/kit/config"
 	kitlog "github.com/fsyyft-go/kit/log"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -177,8 +179,8 @@ func TestHookLogSlow_Behavior(t *testing.T) {
 
 			logger := newCaptureLogger()
 			hook := NewHookLogSlow(tt.giveNamespace, logger, tt.giveThreshold)
-			ctx := NewHookContext(context.Background(), OpQuery, tt.giveQuery, tt.giveArgs)
-			ctx.SetResult(&testRows{}, nil)
+			ctx := NewHookContext(context.Background(), OpExec, tt.giveQuery, tt.giveArgs)
+			ctx.SetResult(driver.RowsAffected(1), nil)
 
 			if tt.wantBeforeNoError {
 				assert.NoError(t, hook.Before(ctx))
@@ -194,7 +196,7 @@ func TestHookLogSlow_Behavior(t *testing.T) {
 			entry := logger.requireEntry(t)
 			assert.Equal(t, "warn", entry.level)
 			assert.Equal(t, "", entry.message)
-			assert.Equal(t, OpQuery, entry.fields["operation"])
+			assert.Equal(t, OpExec, entry.fields["operation"])
 			assert.IsType(t, time.Duration(0), entry.fields["duration"])
 			assert.GreaterOrEqual(t, entry.fields["duration"].(time.Duration), time.Duration(0))
 			if tt.wantNamespace != "" {
@@ -213,9 +215,247 @@ func TestHookLogSlow_Behavior(t *testing.T) {
 	}
 }
 
+// TestHookLogSlow_QueryStages 验证慢查询日志在结果集关闭后按总耗时判断，并记录各阶段耗时与行数。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestHookLogSlow_QueryStages(t *testing.T) {
+	tests := []struct {
+		name          string
+		description   string
+		giveThreshold time.Duration
+		giveRows      int
+		giveQueryErr  error
+		wantLog       bool
+		wantRows      int64
+	}{
+		{
+			name:          "success/logs-after-rows-closed",
+			description:   "验证查询成功时日志推迟到结果集关闭后写入，并包含驱动调用耗时、读取结果集耗时和行数。",
+			giveThreshold: -time.Nanosecond,
+			giveRows:      3,
+			wantLog:       true,
+			wantRows:      3,
+		},
+		{
+			name:          "success/below-threshold-skips-log",
+			description:   "验证总耗时低于阈值时结果集关闭后也不记录日志。",
+			giveThreshold: time.Hour,
+			giveRows:      3,
+		},
+		{
+			name:          "boundary/query-error-logs-immediately",
+			description:   "验证查询失败时没有结果集，日志在 After 阶段立即写入且不含结果集字段。",
+			giveThreshold: -time.Nanosecond,
+			giveQueryErr:  errors.New("query failed"),
+			wantLog:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			logger := newCaptureLogger()
+			base := &testFullConn{
+				queryContextFn: func(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+					if nil != tt.giveQueryErr {
+						return nil, tt.giveQueryErr
+					}
+					return &testRows{rows: tt.giveRows}, nil
+				},
+			}
+			conn := &kitConn{Conn: base, hook: NewHookLogSlow("billing", logger, tt.giveThreshold)}
+
+			rows, err := conn.QueryContext(context.Background(), "SELECT id FROM users", nil)
+			if nil != tt.giveQueryErr {
+				require.ErrorIs(t, err, tt.giveQueryErr)
+				entry := logger.requireEntry(t)
+				assert.Equal(t, OpQuery, entry.fields["operation"])
+				assert.NotContains(t, entry.fields, "rows")
+				assert.NotContains(t, entry.fields, "rows_duration")
+				return
+			}
+			require.NoError(t, err)
+
+			dest := make([]driver.Value, 1)
+			for nil == rows.Next(dest) {
+			}
+			assert.Empty(t, logger.snapshotEntries(), "结果集关闭前不应记录日志。")
+			require.NoError(t, rows.Close())
+			require.NoError(t, rows.Close(), "重复关闭不应重复记录。")
+
+			if !tt.wantLog {
+				time.Sleep(10 * time.Millisecond)
+				assert.Empty(t, logger.snapshotEntries())
+				return
+			}
+
+			entry := logger.requireEntry(t)
+			assert.Equal(t, "warn", entry.level)
+			assert.Equal(t, OpQuery, entry.fields["operation"])
+			assert.Equal(t, "billing", entry.fields["namespace"])
+			assert.Equal(t, tt.wantRows, entry.fields["rows"])
+			driverDuration := entry.fields["driver_duration"].(time.Duration)
+			rowsDuration := entry.fields["rows_duration"].(time.Duration)
+			assert.Equal(t, driverDuration+rowsDuration, entry.fields["duration"])
+			time.Sleep(10 * time.Millisecond)
+			assert.Len(t, logger.snapshotEntries(), 1)
+		})
+	}
+}
+
+// TestHookLogVerbose_Behavior 验证操作日志 Hook 按 VerboseSQL 开关记录操作，查询在结果集关闭后记录行数。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestHookLogVerbose_Behavior(t *testing.T) {
+	execErr := errors.New("exec failed")
+
+	tests := []struct {
+		name        string
+		description string
+		giveVerbose bool
+		giveOp      OpType
+		giveErr     error
+		wantLog     bool
+		wantRows    bool
+	}{
+		{
+			name:        "success/disabled-skips-log",
+			description: "验证 VerboseSQL 关闭时不记录日志。",
+			giveOp:      OpExec,
+		},
+		{
+			name:        "success/exec-logs-fields",
+			description: "验证 VerboseSQL 打开时记录执行操作的命名空间、SQL、参数和耗时。",
+			giveVerbose: true,
+			giveOp:      OpExec,
+			wantLog:     true,
+		},
+		{
+			name:        "success/exec-error-logs-error",
+			description: "验证失败的操作在日志中附带 error 字段。",
+			giveVerbose: true,
+			giveOp:      OpExec,
+			giveErr:     execErr,
+			wantLog:     true,
+		},
+		{
+			name:        "success/query-logs-after-rows-closed",
+			description: "验证查询在结果集关闭后记录，并包含读取的行数。",
+			giveVerbose: true,
+			giveOp:      OpQuery,
+			wantLog:     true,
+			wantRows:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			logger := newCaptureLogger()
+			registry := kitconfig.NewDebugRegistry(kitconfig.DebugFlags{VerboseSQL: tt.giveVerbose})
+			base := &testFullConn{
+				execContextFn: func(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+					return driver.RowsAffected(1), tt.giveErr
+				},
+				queryContextFn: func(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+					return &testRows{rows: 2}, nil
+				},
+			}
+			conn := &kitConn{Conn: base, hook: NewHookLogVerbose("billing", logger, registry)}
+			args := []driver.NamedValue{{Ordinal: 1, Value: "alice"}}
+
+			if OpQuery == tt.giveOp {
+				rows, err := conn.QueryContext(context.Background(), "SELECT id FROM users WHERE name=?", args)
+				require.NoError(t, err)
+				dest := make([]driver.Value, 1)
+				for nil == rows.Next(dest) {
+				}
+				assert.Empty(t, logger.snapshotEntries(), "结果集关闭前不应记录日志。")
+				require.NoError(t, rows.Close())
+			} else {
+				_, err := conn.ExecContext(context.Background(), "SELECT id FROM users WHERE name=?", args)
+				assert.ErrorIs(t, err, tt.giveErr)
+			}
+
+			if !tt.wantLog {
+				time.Sleep(10 * time.Millisecond)
+				assert.Empty(t, logger.snapshotEntries())
+				return
+			}
+
+			entry := logger.requireEntry(t)
+			assert.Equal(t, "info", entry.level)
+			assert.Equal(t, tt.giveOp, entry.fields["operation"])
+			assert.Equal(t, "billing", entry.fields["namespace"])
+			assert.Equal(t, "SELECT id FROM users WHERE name=?", entry.fields["query"])
+			assert.Equal(t, "alice", entry.fields["args"])
+			assert.IsType(t, time.Duration(0), entry.fields["duration"])
+			if nil != tt.giveErr {
+				assert.Equal(t, tt.giveErr, entry.fields["error"])
+			} else {
+				assert.NotContains(t, entry.fields, "error")
+			}
+			if tt.wantRows {
+				assert.Equal(t, int64(2), entry.fields["rows"])
+			} else {
+				assert.NotContains(t, entry.fields, "rows")
+			}
+		})
+	}
+}
+
+// TestHookContext_OnRowsClose 验证结果集统计和关闭回调。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestHookContext_OnRowsClose(t *testing.T) {
+	t.Log("验证登记回调后结果集被包装并统计行数，关闭时回调读取到最终统计；未登记回调且未替换上下文时不包装。")
+
+	ctx := NewHookContext(context.Background(), OpQuery, "SELECT 1", nil)
+	plain := &testRows{rows: 2}
+	ctx.SetResult(plain, nil)
+	assert.Same(t, plain, ctx.bindRows(plain))
+
+	var closed []int64
+	ctx.OnRowsClose(nil)
+	ctx.OnRowsClose(func(ctx *HookContext) {
+		assert.True(t, ctx.RowsClosed())
+		closed = append(closed, ctx.RowsScanned())
+	})
+	rows := ctx.bindRows(plain)
+	_, ok := rows.(*kitRows)
+	require.True(t, ok)
+
+	dest := make([]driver.Value, 1)
+	require.NoError(t, rows.Next(dest))
+	assert.Equal(t, int64(1), ctx.RowsScanned())
+	assert.False(t, ctx.RowsClosed())
+	require.NoError(t, rows.Next(dest))
+	assert.Equal(t, io.EOF, rows.Next(dest))
+	require.NoError(t, rows.Close())
+	require.NoError(t, rows.Close())
+
+	assert.Equal(t, []int64{2}, closed)
+	assert.Equal(t, int64(2), ctx.RowsScanned())
+	assert.GreaterOrEqual(t, ctx.RowsDuration(), time.Duration(0))
+	assert.Equal(t, ctx.Duration()+ctx.RowsDuration(), ctx.TotalDuration())
+
+	// 没有结果集时丢弃回调。
+	ctx = NewHookContext(context.Background(), OpQuery, "SELECT 1", nil)
+	ctx.OnRowsClose(func(ctx *HookContext) { closed = append(closed, -1) })
+	assert.Nil(t, ctx.bindRows(nil))
+	assert.Equal(t, []int64{2}, closed)
+}
+
 // captureLogEntry 保存一次测试日志调用的级别、字段和消息。
 //
-// 该辅助结构用于断言 HookLogError 和 HookLogSlow 传递给 logger 的结构化字段。
+// 该辅助结构用于断言 HookLogError、HookLogSlow 和 HookLogVerbose 传递给 logger 的结构化字段。
 type captureLogEntry struct {
 	level   string
 	fields  map[string]interface{}
@@ -224,7 +464,7 @@ type captureLogEntry struct {
 
 // captureLogger 是实现 kitlog.Logger 的内存日志记录器。
 //
-// 该辅助类型通过嵌入 kitlog.Logger 保持接口兼容，并覆盖 Hook 使用的 WithFields、Error 和 Warn 方法。
+// 该辅助类型通过嵌入 kitlog.Logger 保持接口兼容，并覆盖 Hook 使用的 WithFields、Error、Info 和 Warn 方法。
 type captureLogger struct {
 	kitlog.Logger
 	mu      sync.Mutex
@@ -269,6 +509,14 @@ func (l *captureLogger) Error(args ...interface{}) {
 	l.record("error", firstLogArg(args))
 }
 
+// Info 记录一次 info 级别日志调用。
+//
+// 参数：
+//   - args: Hook 传入的日志消息参数。
+func (l *captureLogger) Info(args ...interface{}) {
+	l.record("info", firstLogArg(args))
+}
+
 // Warn 记录一次 warn 级别日志调用。
 //
 // 参数：
@@ -0,0 +1,113 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package driver
+
+import (
+	kitconfig "github.com/fsyyft-go/kit/config"
+	kitlog "github.com/fsyyft-go/kit/log"
+	kitgoroutine "github.com/fsyyft-go/kit/runtime/goroutine"
+)
+
+type (
+	// HookLogVerbose 是一个按调试开关记录每一次数据库操作的 Hook。
+	//
+	// HookLogVerbose 在 After 阶段读取 DebugRegistry 的 VerboseSQL 开关；开关打开时，
+	// 它会异步提交一条包含操作类型、耗时以及可选 namespace、SQL、参数摘要和错误的信息日志。
+	// 开关可在运行时切换，关闭时只有一次原子读取的开销，适合常驻在 Hook 链中，
+	// 排查问题时临时打开。上下文经 WithNoLog 标记的操作不记录，经 WithForceLog 标记的操作
+	// 忽略开关总是记录。调用方应提供可用的 logger。
+	HookLogVerbose struct {
+		// namespace 是日志记录的命名空间。
+		namespace string
+		// logger 是用于记录操作信息的日志记录器。
+		logger kitlog.Logger
+		// registry 是提供 VerboseSQL 开关的调试开关注册表。
+		registry *kitconfig.DebugRegistry
+	}
+)
+
+// NewHookLogVerbose 创建一个按调试开关记录数据库操作的 Hook。
+//
+// 参数：
+//   - namespace: 写入日志字段的命名空间；为空时省略该字段。
+//   - logger: 用于输出操作日志的记录器；调用方应传入非 nil 实例。
+//   - registry: 提供 VerboseSQL 开关的调试开关注册表；为 nil 时使用 config.DefaultDebugRegistry。
+//
+// 返回：
+//   - *HookLogVerbose: 在 VerboseSQL 开关打开时异步写日志的 Hook。
+func NewHookLogVerbose(namespace string, logger kitlog.Logger, registry *kitconfig.DebugRegistry) *HookLogVerbose {
+	if nil == registry {
+		registry = kitconfig.DefaultDebugRegistry
+	}
+	return &HookLogVerbose{
+		namespace: namespace,
+		logger:    logger,
+		registry:  registry,
+	}
+}
+
+// Before 在执行数据库操作前不做任何处理。
+//
+// 参数：
+//   - ctx: 当前操作的 HookContext。
+//
+// 返回：
+//   - error: 始终返回 nil，不会阻止底层操作执行。
+func (h *HookLogVerbose) Before(ctx *HookContext) error {
+	return nil
+}
+
+// After 在 VerboseSQL 开关打开时异步记录操作日志。
+//
+// 日志字段包含 operation、duration，以及存在时的 namespace、query、args 和 error。
+// 成功返回结果集的 OpQuery 和 OpStmtQuery 通过 HookContext.OnRowsClose 推迟到结果集关闭后记录，
+// 以 TotalDuration 作为 duration，并额外记录 rows。日志模式由 LogModeFromContext 决定，
+// 见 WithNoLog 与 WithForceLog。
+//
+// 参数：
+//   - ctx: 当前操作的 HookContext。
+//
+// 返回：
+//   - error: 始终返回 nil，不会覆盖原始操作结果。
+func (h *HookLogVerbose) After(ctx *HookContext) error {
+	mode := LogModeFromContext(ctx)
+	if LogSuppress == mode || (LogForce != mode && !h.registry.VerboseSQL()) {
+		return nil
+	}
+
+	// 查询在结果集关闭后记录，日志中包含读取的行数。
+	if (ctx.OpType() == OpQuery || ctx.OpType() == OpStmtQuery) && nil == ctx.OriginError() && nil != ctx.OriginResult() {
+		ctx.OnRowsClose(h.afterRows)
+		return nil
+	}
+
+	m := logFields(h.namespace, ctx, ctx.Duration())
+	if nil != ctx.OriginError() {
+		m["error"] = ctx.OriginError()
+	}
+	h.log(m)
+
+	return nil
+}
+
+// afterRows 在查询结果集关闭后记录查询日志。
+//
+// 参数：
+//   - ctx: 查询操作的 HookContext，结果集已关闭。
+func (h *HookLogVerbose) afterRows(ctx *HookContext) {
+	m := logFields(h.namespace, ctx, ctx.TotalDuration())
+	m["rows"] = ctx.RowsScanned()
+	h.log(m)
+}
+
+// log 异步提交操作日志。
+//
+// 参数：
+//   - m: 日志字段。
+func (h *HookLogVerbose) log(m map[string]interface{}) {
+	_ = kitgoroutine.Submit(func() {
+		h.logger.WithFields(m).Info("")
+	})
+}
@@ -0,0 +1,135 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package driver
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	kitlog "github.com/fsyyft-go/kit/log"
+	kitgoroutine "github.com/fsyyft-go/kit/runtime/goroutine"
+)
+
+const (
+	// HookValueQueryTimeout 是 HookQueryTimeout 附加默认超时时写入 HookContext 的共享数据键。
+	//
+	// 对应的值为 time.Duration 类型的超时时长；未附加默认超时的操作不存在该键。
+	HookValueQueryTimeout = "kit.query_timeout"
+)
+
+type (
+	// HookQueryTimeout 是一个为查询附加默认超时的 Hook。
+	//
+	// HookQueryTimeout 在 Before 阶段检查 Exec、Query、StmtExec 和 StmtQuery 操作的上下文；
+	// 调用方上下文没有截止时间时，通过 HookContext.SetContext 附加 context.WithTimeout
+	// 派生的上下文，调用方已经设置截止时间时保持不变。After 阶段在操作因该默认超时
+	// 失败时异步提交一条警告日志，记录被超时终止的查询。
+	HookQueryTimeout struct {
+		// namespace 是日志记录的命名空间。
+		namespace string
+		// logger 是用于记录超时查询的日志记录器。
+		logger kitlog.Logger
+		// timeout 是默认查询超时时长。
+		timeout time.Duration
+	}
+)
+
+// NewHookQueryTimeout 创建一个默认查询超时 Hook。
+//
+// 参数：
+//   - namespace: 写入日志字段的命名空间；为空时省略该字段。
+//   - logger: 用于输出超时日志的记录器；为 nil 时只附加超时、不记录日志。
+//   - timeout: 默认查询超时时长；非正值表示不附加超时。
+//
+// 返回：
+//   - *HookQueryTimeout: 为没有截止时间的查询附加默认超时的 Hook。
+func NewHookQueryTimeout(namespace string, logger kitlog.Logger, timeout time.Duration) *HookQueryTimeout {
+	return &HookQueryTimeout{
+		namespace: namespace,
+		logger:    logger,
+		timeout:   timeout,
+	}
+}
+
+// Before 在调用方上下文没有截止时间时为查询附加默认超时。
+//
+// 参数：
+//   - ctx: 当前操作的 HookContext。
+//
+// 返回：
+//   - error: 始终返回 nil，不会阻止底层操作执行。
+func (h *HookQueryTimeout) Before(ctx *HookContext) error {
+	if h.timeout <= 0 {
+		return nil
+	}
+
+	// 只处理执行 SQL 的操作，连接、事务和 Ping 等操作保持原样。
+	switch ctx.OpType() {
+	case OpExec, OpQuery, OpStmtExec, OpStmtQuery:
+	default:
+		return nil
+	}
+
+	// 调用方已经设置截止时间时尊重调用方的设置。
+	if _, ok := ctx.Deadline(); ok {
+		return nil
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx.Context(), h.timeout)
+	ctx.SetContext(timeoutCtx, cancel)
+	ctx.SetHookValue(HookValueQueryTimeout, h.timeout)
+
+	return nil
+}
+
+// After 在操作因默认超时失败时异步记录警告日志。
+//
+// 只有由本 Hook 附加的超时才会记录；调用方自行设置的截止时间或主动取消不会记录。
+// 日志字段包含 operation、duration、timeout，以及存在时的 namespace、query 和 args。
+//
+// 参数：
+//   - ctx: 当前操作的 HookContext。
+//
+// 返回：
+//   - error: 始终返回 nil，不会覆盖原始操作结果。
+func (h *HookQueryTimeout) After(ctx *HookContext) error {
+	if nil == h.logger || !ctx.TimedOut() {
+		return nil
+	}
+	if _, ok := ctx.GetHookValue(HookValueQueryTimeout); !ok {
+		return nil
+	}
+
+	// 构建参数字符串。
+	var args []string
+	for _, arg := range ctx.Args() {
+		args = append(args, fmt.Sprintf("%v", arg.Value))
+	}
+	argsStr := strings.Join(args, ", ")
+
+	m := map[string]interface{}{
+		"operation": ctx.OpType(),
+		"duration":  ctx.Duration(),
+		"timeout":   h.timeout,
+	}
+	if h.namespace != "" {
+		m["namespace"] = h.namespace
+	}
+	if ctx.Query() != "" {
+		m["query"] = ctx.Query()
+	}
+	if argsStr != "" {
+		m["args"] = argsStr
+	}
+
+	// 记录超时日志。
+	_ = kitgoroutine.Submit(func() {
+		h.logger.WithFields(m).Warn("query killed by timeout")
+	})
+
+	return nil
+}
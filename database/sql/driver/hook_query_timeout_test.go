@@ -0,0 +1,185 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package driver
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestHookQueryTimeout_Before 验证默认超时只附加到没有截止时间的 SQL 执行操作。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestHookQueryTimeout_Before(t *testing.T) {
+	deadlineCtx, cancel := context.WithTimeout(context.Background(), time.Hour)
+	t.Cleanup(cancel)
+
+	tests := []struct {
+		name         string
+		description  string
+		giveCtx      context.Context
+		giveOp       OpType
+		giveTimeout  time.Duration
+		wantDeadline bool
+		wantApplied  bool
+	}{
+		{name: "success/query-without-deadline", description: "验证没有截止时间的查询附加默认超时。", giveCtx: context.Background(), giveOp: OpQuery, giveTimeout: time.Second, wantDeadline: true, wantApplied: true},
+		{name: "success/stmt-exec-without-deadline", description: "验证没有截止时间的预处理执行附加默认超时。", giveCtx: context.Background(), giveOp: OpStmtExec, giveTimeout: time.Second, wantDeadline: true, wantApplied: true},
+		{name: "boundary/caller-deadline-kept", description: "验证调用方已设置截止时间时不覆盖。", giveCtx: deadlineCtx, giveOp: OpExec, giveTimeout: time.Second, wantDeadline: true},
+		{name: "boundary/non-sql-op-skipped", description: "验证 Ping 等非 SQL 操作不附加超时。", giveCtx: context.Background(), giveOp: OpPing, giveTimeout: time.Second},
+		{name: "boundary/non-positive-timeout", description: "验证非正超时不附加超时。", giveCtx: context.Background(), giveOp: OpQuery, giveTimeout: 0},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			hook := NewHookQueryTimeout("ns", nil, tt.giveTimeout)
+			ctx := NewHookContext(tt.giveCtx, tt.giveOp, "SELECT 1", nil)
+			require.NoError(t, hook.Before(ctx))
+			defer ctx.releaseContext()
+
+			deadline, ok := ctx.Deadline()
+			assert.Equal(t, tt.wantDeadline, ok)
+			_, applied := ctx.GetHookValue(HookValueQueryTimeout)
+			assert.Equal(t, tt.wantApplied, applied)
+			if tt.wantApplied {
+				assert.WithinDuration(t, time.Now().Add(tt.giveTimeout), deadline, time.Second)
+				assert.NotEqual(t, tt.giveCtx, ctx.Context())
+			} else {
+				assert.Equal(t, tt.giveCtx, ctx.Context())
+			}
+		})
+	}
+}
+
+// TestHookQueryTimeout_KillsSlowQuery 验证默认超时会取消底层操作并记录超时日志，取消与普通错误分开记录。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestHookQueryTimeout_KillsSlowQuery(t *testing.T) {
+	timeoutLogger := newCaptureLogger()
+	errorLogger := newCaptureLogger()
+	manager := NewHookManager()
+	manager.AddHook(NewHookQueryTimeout("orders", timeoutLogger, 20*time.Millisecond))
+	manager.AddHook(NewHookLogError("orders", errorLogger))
+
+	conn := &kitConn{
+		Conn: &testFullConn{
+			execContextFn: func(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+				<-ctx.Done()
+				return nil, ctx.Err()
+			},
+		},
+		hook: manager,
+	}
+
+	_, err := conn.ExecContext(context.Background(), "SELECT SLEEP(10)", []driver.NamedValue{{Ordinal: 1, Value: 7}})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	entry := timeoutLogger.requireEntry(t)
+	assert.Equal(t, "warn", entry.level)
+	assert.Equal(t, "query killed by timeout", entry.message)
+	assert.Equal(t, 20*time.Millisecond, entry.fields["timeout"])
+	assert.Equal(t, "orders", entry.fields["namespace"])
+	assert.Equal(t, "SELECT SLEEP(10)", entry.fields["query"])
+	assert.Equal(t, "7", entry.fields["args"])
+
+	entry = errorLogger.requireEntry(t)
+	assert.Equal(t, "warn", entry.level, "超时不应按错误级别记录。")
+	assert.Equal(t, context.DeadlineExceeded, entry.fields["canceled"])
+}
+
+// TestHookQueryTimeout_CallerCancelNotLogged 验证调用方主动取消时超时 Hook 不记录日志，错误 Hook 记录为取消。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestHookQueryTimeout_CallerCancelNotLogged(t *testing.T) {
+	timeoutLogger := newCaptureLogger()
+	errorLogger := newCaptureLogger()
+	manager := NewHookManager()
+	manager.AddHook(NewHookQueryTimeout("", timeoutLogger, time.Hour))
+	manager.AddHook(NewHookLogError("", errorLogger))
+
+	callerCtx, cancel := context.WithCancel(context.Background())
+	conn := &kitConn{
+		Conn: &testFullConn{
+			execContextFn: func(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+				cancel()
+				<-ctx.Done()
+				return nil, ctx.Err()
+			},
+		},
+		hook: manager,
+	}
+
+	_, err := conn.ExecContext(callerCtx, "UPDATE t SET v = 1", nil)
+	require.ErrorIs(t, err, context.Canceled)
+
+	entry := errorLogger.requireEntry(t)
+	assert.Equal(t, "warn", entry.level)
+	assert.Equal(t, context.Canceled, entry.fields["canceled"])
+	assert.Empty(t, timeoutLogger.snapshotEntries())
+}
+
+// TestHookContext_SetContextReleasedWithRows 验证查询附加的上下文在结果集关闭后才释放。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestHookContext_SetContextReleasedWithRows(t *testing.T) {
+	var queryCtx context.Context
+	base := &testFullConn{
+		queryContextFn: func(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+			queryCtx = ctx
+			return &testRows{columns: []string{"id"}}, nil
+		},
+	}
+	conn := &kitConn{Conn: base, hook: NewHookQueryTimeout("", nil, time.Hour)}
+
+	rows, err := conn.QueryContext(context.Background(), "SELECT id FROM t", nil)
+	require.NoError(t, err)
+	require.NotNil(t, queryCtx)
+	assert.NoError(t, queryCtx.Err(), "结果集关闭前上下文不应被取消。")
+	assert.Equal(t, []string{"id"}, rows.Columns())
+
+	wrapped, ok := rows.(*kitRows)
+	require.True(t, ok)
+	assert.False(t, wrapped.HasNextResultSet())
+	assert.Equal(t, "", wrapped.ColumnTypeDatabaseTypeName(0))
+
+	require.NoError(t, rows.Close())
+	assert.ErrorIs(t, queryCtx.Err(), context.Canceled, "结果集关闭后上下文应被释放。")
+	require.NoError(t, rows.Close(), "重复关闭不应重复释放。")
+
+	// 查询失败时立即释放上下文。
+	queryErr := errors.New("query failed")
+	base.queryContextFn = func(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+		queryCtx = ctx
+		return nil, queryErr
+	}
+	rows, err = conn.QueryContext(context.Background(), "SELECT id FROM t", nil)
+	require.ErrorIs(t, err, queryErr)
+	assert.Nil(t, rows)
+	assert.ErrorIs(t, queryCtx.Err(), context.Canceled)
+
+	// 未附加超时的查询不包装结果集。
+	plain := &testRows{}
+	base.queryContextFn = func(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+		return plain, nil
+	}
+	deadlineCtx, cancel := context.WithTimeout(context.Background(), time.Hour)
+	defer cancel()
+	rows, err = conn.QueryContext(deadlineCtx, "SELECT id FROM t", nil)
+	require.NoError(t, err)
+	assert.Same(t, plain, rows)
+}
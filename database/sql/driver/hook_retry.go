@@ -0,0 +1,277 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package driver
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	kitlog "github.com/fsyyft-go/kit/log"
+	kitgoroutine "github.com/fsyyft-go/kit/runtime/goroutine"
+)
+
+const (
+	// defaultRetryBaseDelay 是第一次重试前的默认等待时长。
+	defaultRetryBaseDelay = 10 * time.Millisecond
+	// defaultRetryMaxDelay 是单次重试等待时长的默认上限。
+	defaultRetryMaxDelay = time.Second
+)
+
+var (
+	_ Hook    = (*HookRetry)(nil)
+	_ Retrier = (*HookRetry)(nil)
+)
+
+type (
+	// HookRetry 是在驱动层自动重试瞬时错误的 Hook。
+	//
+	// HookRetry 只重试幂等操作：Connect、Ping、Prepare、Query、StmtQuery 和 Begin；
+	// Exec 和 StmtExec 需要通过 WithRetryExec 显式开启。发生在显式事务中的操作从不重试，
+	// 因为事务中的错误通常已导致整个事务回滚，应由业务代码重新执行整个事务。
+	//
+	// 重试等待时长按指数退避计算并带有随机抖动，不超过设置的上限。达到最大重试次数后，
+	// HookRetry 会异步记录一条 Warn 日志，并把最后一次的错误返回给调用方。
+	HookRetry struct {
+		// namespace 是日志记录的命名空间。
+		namespace string
+		// logger 是用于记录重试耗尽的日志记录器。
+		logger kitlog.Logger
+		// maxRetries 是最大重试次数。
+		maxRetries int
+		// baseDelay 是第一次重试前的等待时长。
+		baseDelay time.Duration
+		// maxDelay 是单次重试等待时长的上限。
+		maxDelay time.Duration
+		// retryable 判断错误是否为可重试的瞬时错误。
+		retryable func(error) bool
+		// retryExec 表示是否重试 Exec 和 StmtExec 操作。
+		retryExec bool
+	}
+
+	// RetryOption 定义 HookRetry 的配置选项。
+	RetryOption func(*HookRetry)
+)
+
+// WithRetryBackoff 设置重试的退避时长。
+//
+// 第 n 次重试前等待 base*2^n，并在 [d/2, d] 范围内随机抖动，其中 d 不超过 max。
+//
+// 参数：
+//   - base: 第一次重试前的等待时长；小于等于 0 时保留默认值 10ms。
+//   - max: 单次等待时长的上限；小于等于 0 时保留默认值 1s。
+//
+// 返回：
+//   - RetryOption: 应用于 HookRetry 的配置选项。
+func WithRetryBackoff(base, max time.Duration) RetryOption {
+	return func(h *HookRetry) {
+		if base > 0 {
+			h.baseDelay = base
+		}
+		if max > 0 {
+			h.maxDelay = max
+		}
+	}
+}
+
+// WithRetryClassifier 设置判断错误是否可重试的函数。
+//
+// 默认只重试 driver.ErrBadConn；具体数据库的瞬时错误（例如 MySQL 的死锁和锁等待超时）
+// 应由对应的包提供分类函数。
+//
+// 参数：
+//   - retryable: 返回 true 表示错误可重试；为 nil 时保留默认分类函数。
+//
+// 返回：
+//   - RetryOption: 应用于 HookRetry 的配置选项。
+func WithRetryClassifier(retryable func(error) bool) RetryOption {
+	return func(h *HookRetry) {
+		if nil != retryable {
+			h.retryable = retryable
+		}
+	}
+}
+
+// WithRetryExec 设置是否重试 Exec 和 StmtExec 操作。
+//
+// 写操作不一定是幂等的，只有确认所有写语句都可以安全地重复执行时才应开启。
+//
+// 参数：
+//   - retryExec: 为 true 时重试 Exec 和 StmtExec 操作。
+//
+// 返回：
+//   - RetryOption: 应用于 HookRetry 的配置选项。
+func WithRetryExec(retryExec bool) RetryOption {
+	return func(h *HookRetry) {
+		h.retryExec = retryExec
+	}
+}
+
+// NewHookRetry 创建一个瞬时错误重试 Hook。
+//
+// 参数：
+//   - namespace: 写入日志字段的命名空间；为空时省略该字段。
+//   - logger: 用于记录重试耗尽的记录器；为 nil 时不记录日志。
+//   - maxRetries: 最大重试次数；小于等于 0 时不重试。
+//   - opts: 可选的配置选项。
+//
+// 返回：
+//   - *HookRetry: 重试瞬时错误的 Hook。
+func NewHookRetry(namespace string, logger kitlog.Logger, maxRetries int, opts ...RetryOption) *HookRetry {
+	h := &HookRetry{
+		namespace:  namespace,
+		logger:     logger,
+		maxRetries: maxRetries,
+		baseDelay:  defaultRetryBaseDelay,
+		maxDelay:   defaultRetryMaxDelay,
+		retryable:  isBadConn,
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// Before 在执行数据库操作前不做任何处理。
+//
+// 参数：
+//   - ctx: 当前操作的 HookContext。
+//
+// 返回：
+//   - error: 始终返回 nil。
+func (h *HookRetry) Before(ctx *HookContext) error {
+	return nil
+}
+
+// After 在执行数据库操作后不做任何处理，重试决策由 Retry 完成。
+//
+// 参数：
+//   - ctx: 当前操作的 HookContext。
+//
+// 返回：
+//   - error: 始终返回 nil，不会覆盖原始操作结果。
+func (h *HookRetry) After(ctx *HookContext) error {
+	return nil
+}
+
+// Retry 判断失败的操作是否重试，并计算重试前的等待时长。
+//
+// 以下情况不重试：操作不是幂等操作、操作发生在事务中、操作因上下文取消或超时失败、
+// 错误不是瞬时错误。driver.ErrBadConn 只在 Connect 操作上重试，其他操作返回
+// ErrBadConn 时 database/sql 会丢弃该连接并自行在新连接上重新执行。
+// 错误可重试但已达到最大重试次数时，异步记录一条 Warn 日志。
+//
+// 参数：
+//   - ctx: 失败的这次尝试的 HookContext。
+//
+// 返回：
+//   - time.Duration: 下一次重试前的等待时长。
+//   - bool: 需要重试时返回 true。
+func (h *HookRetry) Retry(ctx *HookContext) (time.Duration, bool) {
+	err := ctx.OriginError()
+	if nil == err || ctx.InTx() || ctx.Canceled() || !h.idempotent(ctx.OpType()) {
+		return 0, false
+	}
+	if OpConnect != ctx.OpType() && isBadConn(err) {
+		return 0, false
+	}
+	if !h.retryable(err) {
+		return 0, false
+	}
+	if ctx.Retries() >= h.maxRetries {
+		h.logExhausted(ctx)
+		return 0, false
+	}
+	return h.backoff(ctx.Retries()), true
+}
+
+// idempotent 判断操作类型是否允许重试。
+//
+// 参数：
+//   - opType: 数据库操作类型。
+//
+// 返回：
+//   - bool: 允许重试时返回 true。
+func (h *HookRetry) idempotent(opType OpType) bool {
+	switch opType {
+	case OpConnect, OpPing, OpPrepare, OpQuery, OpStmtQuery, OpBegin:
+		return true
+	case OpExec, OpStmtExec:
+		return h.retryExec
+	default:
+		return false
+	}
+}
+
+// backoff 计算第 retries 次重试前的等待时长。
+//
+// 参数：
+//   - retries: 已经重试的次数。
+//
+// 返回：
+//   - time.Duration: 带抖动的等待时长，范围为 [d/2, d]，d 不超过 maxDelay。
+func (h *HookRetry) backoff(retries int) time.Duration {
+	delay := h.maxDelay
+	// 位移超过上限前停止计算，避免溢出。
+	if retries < 31 {
+		if d := h.baseDelay << uint(retries); d > 0 && d < h.maxDelay {
+			delay = d
+		}
+	}
+	half := delay / 2
+	return half + rand.N(delay-half+1)
+}
+
+// logExhausted 异步记录重试耗尽的日志。
+//
+// 日志字段包含 operation、retries、duration、error，以及存在时的 namespace、query 和 args。
+//
+// 参数：
+//   - ctx: 最后一次尝试的 HookContext。
+func (h *HookRetry) logExhausted(ctx *HookContext) {
+	if nil == h.logger {
+		return
+	}
+
+	var args []string
+	for _, arg := range ctx.Args() {
+		args = append(args, fmt.Sprintf("%v", arg.Value))
+	}
+	argsStr := strings.Join(args, ", ")
+
+	m := map[string]interface{}{
+		"operation": ctx.OpType(),
+		"retries":   ctx.Retries(),
+		"duration":  ctx.Duration(),
+		"error":     ctx.OriginError(),
+	}
+	if h.namespace != "" {
+		m["namespace"] = h.namespace
+	}
+	if ctx.Query() != "" {
+		m["query"] = ctx.Query()
+	}
+	if argsStr != "" {
+		m["args"] = argsStr
+	}
+
+	_ = kitgoroutine.Submit(func() {
+		h.logger.WithFields(m).Warn("retries exhausted")
+	})
+}
+
+// isBadConn 判断错误是否为 driver.ErrBadConn。
+//
+// 参数：
+//   - err: 待判断的错误。
+//
+// 返回：
+//   - bool: err 为 driver.ErrBadConn 或包装了它时返回 true。
+func isBadConn(err error) bool {
+	return errors.Is(err, driver.ErrBadConn)
+}
@@ -0,0 +1,234 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package driver
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestHookRetry_Retry 验证重试 Hook 对操作类型、事务状态、错误分类和重试次数的判断。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestHookRetry_Retry(t *testing.T) {
+	transientErr := errors.New("deadlock")
+	permanentErr := errors.New("syntax error")
+	classifier := func(err error) bool { return errors.Is(err, transientErr) || errors.Is(err, driver.ErrBadConn) }
+
+	tests := []struct {
+		name        string
+		description string
+		giveOp      OpType
+		giveErr     error
+		giveRetries int
+		giveInTx    bool
+		giveExec    bool
+		wantRetry   bool
+		wantLog     bool
+	}{
+		{name: "success/query-transient-error", description: "验证查询遇到瞬时错误时重试。", giveOp: OpQuery, giveErr: transientErr, wantRetry: true},
+		{name: "success/connect-bad-conn", description: "验证建立连接返回 ErrBadConn 时重试。", giveOp: OpConnect, giveErr: driver.ErrBadConn, wantRetry: true},
+		{name: "success/exec-enabled", description: "验证开启 WithRetryExec 后 Exec 遇到瞬时错误时重试。", giveOp: OpExec, giveErr: transientErr, giveExec: true, wantRetry: true},
+		{name: "boundary/exec-disabled", description: "验证默认不重试非幂等的 Exec。", giveOp: OpExec, giveErr: transientErr},
+		{name: "boundary/in-tx", description: "验证事务中的操作不重试。", giveOp: OpQuery, giveErr: transientErr, giveInTx: true},
+		{name: "boundary/commit", description: "验证提交事务不重试。", giveOp: OpCommit, giveErr: transientErr},
+		{name: "boundary/query-bad-conn", description: "验证非连接操作的 ErrBadConn 交给 database/sql 处理。", giveOp: OpQuery, giveErr: driver.ErrBadConn},
+		{name: "boundary/no-error", description: "验证操作成功时不重试。", giveOp: OpQuery},
+		{name: "error/permanent-error", description: "验证非瞬时错误不重试。", giveOp: OpQuery, giveErr: permanentErr},
+		{name: "error/retries-exhausted", description: "验证达到最大重试次数后不再重试并记录警告日志。", giveOp: OpQuery, giveErr: transientErr, giveRetries: 2, wantLog: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			logger := newCaptureLogger()
+			hook := NewHookRetry("accounting", logger, 2,
+				WithRetryClassifier(classifier),
+				WithRetryBackoff(time.Millisecond, 4*time.Millisecond),
+				WithRetryExec(tt.giveExec))
+			ctx := NewHookContext(context.Background(), tt.giveOp, "SELECT 1", nil)
+			ctx.retries = tt.giveRetries
+			ctx.inTx = tt.giveInTx
+			ctx.SetResult(nil, tt.giveErr)
+
+			delay, retry := hook.Retry(ctx)
+
+			assert.Equal(t, tt.wantRetry, retry)
+			if tt.wantRetry {
+				assert.GreaterOrEqual(t, delay, 500*time.Microsecond)
+				assert.LessOrEqual(t, delay, time.Millisecond)
+			}
+			if !tt.wantLog {
+				assert.Empty(t, logger.snapshotEntries())
+				return
+			}
+			entry := logger.requireEntry(t)
+			assert.Equal(t, "warn", entry.level)
+			assert.Equal(t, "retries exhausted", entry.message)
+			assert.Equal(t, 2, entry.fields["retries"])
+			assert.Equal(t, "accounting", entry.fields["namespace"])
+			assert.Equal(t, "SELECT 1", entry.fields["query"])
+			assert.ErrorIs(t, entry.fields["error"].(error), transientErr)
+		})
+	}
+}
+
+// TestHookRetry_Backoff 验证退避时长按指数增长且不超过上限。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestHookRetry_Backoff(t *testing.T) {
+	hook := NewHookRetry("", nil, 100, WithRetryBackoff(10*time.Millisecond, 50*time.Millisecond))
+
+	for retries, want := range []time.Duration{10, 20, 40, 50, 50} {
+		want *= time.Millisecond
+		got := hook.backoff(retries)
+		assert.GreaterOrEqual(t, got, want/2, "retries=%d", retries)
+		assert.LessOrEqual(t, got, want, "retries=%d", retries)
+	}
+	assert.LessOrEqual(t, hook.backoff(99), 50*time.Millisecond)
+}
+
+// TestKitConn_Retry 验证包装连接按 Retrier 的决策重新执行失败的操作。
+//
+// 该测试覆盖瞬时错误后成功、重试耗尽、Hook 覆盖错误不重试和等待期间上下文结束，
+// 并断言每次尝试的 HookContext.Retries 依次递增。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestKitConn_Retry(t *testing.T) {
+	transientErr := errors.New("lock wait timeout")
+	afterErr := errors.New("after failed")
+
+	tests := []struct {
+		name         string
+		description  string
+		giveFailures int
+		giveAfterErr error
+		giveDelay    time.Duration
+		giveCancel   bool
+		wantErrIs    error
+		wantCalls    int
+		wantRetries  []int
+	}{
+		{name: "success/recovers-after-transient-errors", description: "验证瞬时错误重试后成功返回结果。", giveFailures: 2, wantCalls: 3, wantRetries: []int{0, 1, 2}},
+		{name: "error/retries-exhausted", description: "验证重试耗尽后返回最后一次的错误。", giveFailures: 10, wantErrIs: transientErr, wantCalls: 4, wantRetries: []int{0, 1, 2, 3}},
+		{name: "boundary/after-error-not-retried", description: "验证 After 覆盖的错误不触发重试。", giveFailures: 1, giveAfterErr: afterErr, wantErrIs: afterErr, wantCalls: 1, wantRetries: []int{0}},
+		{name: "boundary/context-done-while-waiting", description: "验证等待重试期间上下文结束时返回最后一次的错误。", giveFailures: 10, giveDelay: time.Hour, giveCancel: true, wantErrIs: transientErr, wantCalls: 1, wantRetries: []int{0}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			calls := 0
+			base := &testFullConn{
+				queryContextFn: func(context.Context, string, []driver.NamedValue) (driver.Rows, error) {
+					calls++
+					if calls <= tt.giveFailures {
+						return nil, transientErr
+					}
+					return &testRows{}, nil
+				},
+			}
+			var retries []int
+			hook := &retryingHook{
+				recordingHook: recordingHook{
+					afterErr: tt.giveAfterErr,
+					afterFn: func(ctx *HookContext) {
+						retries = append(retries, ctx.Retries())
+					},
+				},
+				max:   3,
+				delay: tt.giveDelay,
+			}
+			conn := &kitConn{Conn: base, hook: hook}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tt.giveCancel {
+				hook.onRetry = cancel
+			}
+
+			rows, err := conn.QueryContext(ctx, "SELECT 1", nil)
+
+			if nil != tt.wantErrIs {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, tt.wantErrIs)
+				assert.Nil(t, rows)
+			} else {
+				require.NoError(t, err)
+				assert.NotNil(t, rows)
+			}
+			assert.Equal(t, tt.wantCalls, calls)
+			assert.Equal(t, tt.wantRetries, retries)
+		})
+	}
+}
+
+// TestKitConn_RetryTracksTransaction 验证连接在事务开始和结束时更新 HookContext.InTx。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestKitConn_RetryTracksTransaction(t *testing.T) {
+	var inTx []bool
+	hook := &recordingHook{
+		afterFn: func(ctx *HookContext) {
+			inTx = append(inTx, ctx.InTx())
+		},
+	}
+	conn := &kitConn{Conn: &testFullConn{}, hook: hook}
+	ctx := context.Background()
+
+	tx, err := conn.BeginTx(ctx, driver.TxOptions{})
+	require.NoError(t, err)
+	stmt, err := conn.PrepareContext(ctx, "SELECT 1")
+	require.NoError(t, err)
+	_, err = stmt.(driver.StmtQueryContext).QueryContext(ctx, nil)
+	require.NoError(t, err)
+	require.NoError(t, tx.Commit())
+	_, err = conn.ExecContext(ctx, "UPDATE t SET a = 1", nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, []bool{false, true, true, true, false}, inTx)
+}
+
+// retryingHook 是在 recordingHook 基础上实现 Retrier 的测试辅助 Hook。
+//
+// 该辅助类型在已重试次数小于 max 时总是要求重试，并可在每次要求重试时执行回调。
+type retryingHook struct {
+	recordingHook
+	max     int
+	delay   time.Duration
+	onRetry func()
+}
+
+// Retry 在已重试次数小于 max 时要求重试。
+//
+// 参数：
+//   - ctx: 失败的这次尝试的 HookContext。
+//
+// 返回：
+//   - time.Duration: 预设的等待时长。
+//   - bool: 已重试次数小于 max 时返回 true。
+func (h *retryingHook) Retry(ctx *HookContext) (time.Duration, bool) {
+	if ctx.Retries() >= h.max {
+		return 0, false
+	}
+	if nil != h.onRetry {
+		h.onRetry()
+	}
+	return h.delay, true
+}
@@ -0,0 +1,93 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package driver
+
+import (
+	"context"
+)
+
+// LogMode 表示上下文对日志 Hook 的要求。
+//
+// 可选值包括：
+//   - LogDefault: 按 Hook 自身的阈值和开关记录日志。
+//   - LogSuppress: 不记录日志。
+//   - LogForce: 忽略慢操作阈值和 VerboseSQL 开关，记录每一次操作。
+type LogMode int
+
+const (
+	// LogDefault 表示按 Hook 自身的阈值和开关记录日志。
+	LogDefault LogMode = iota
+	// LogSuppress 表示不记录日志，由 WithNoLog 设置。
+	LogSuppress
+	// LogForce 表示记录每一次操作，由 WithForceLog 设置。
+	LogForce
+)
+
+// logModeKey 是 LogMode 在上下文中的键。
+type logModeKey struct{}
+
+// String 返回日志模式的字符串表示。
+//
+// 参数：无。
+//
+// 返回：
+//   - string: 已知模式返回固定英文名称；未知值返回 "Unknown"。
+func (m LogMode) String() string {
+	switch m {
+	case LogDefault:
+		return "Default"
+	case LogSuppress:
+		return "Suppress"
+	case LogForce:
+		return "Force"
+	default:
+		return "Unknown"
+	}
+}
+
+// WithNoLog 返回使日志 Hook 跳过其中数据库操作的上下文。
+//
+// 适用于执行频率极高、日志价值很低的查询；HookLogSlow、HookLogError 与 HookLogVerbose 都不会记录
+// 使用该上下文的操作，包括失败的操作。在其上再调用 WithForceLog 会覆盖该设置。
+//
+// 参数：
+//   - ctx: 父上下文。
+//
+// 返回：
+//   - context.Context: 携带 LogSuppress 的上下文。
+func WithNoLog(ctx context.Context) context.Context {
+	return context.WithValue(ctx, logModeKey{}, LogSuppress)
+}
+
+// WithForceLog 返回使日志 Hook 记录其中每一次数据库操作的上下文。
+//
+// 适用于需要完整审计的关键路径；HookLogSlow 忽略慢操作阈值，未达到阈值的操作以 Info 级别记录并附加
+// forced 字段，HookLogVerbose 忽略 VerboseSQL 开关。在其上再调用 WithNoLog 会覆盖该设置。
+//
+// 参数：
+//   - ctx: 父上下文。
+//
+// 返回：
+//   - context.Context: 携带 LogForce 的上下文。
+func WithForceLog(ctx context.Context) context.Context {
+	return context.WithValue(ctx, logModeKey{}, LogForce)
+}
+
+// LogModeFromContext 返回上下文中设置的日志模式。
+//
+// 参数：
+//   - ctx: 数据库操作使用的上下文，可以是 HookContext。
+//
+// 返回：
+//   - LogMode: 最近一次 WithNoLog 或 WithForceLog 设置的模式；未设置时返回 LogDefault。
+func LogModeFromContext(ctx context.Context) LogMode {
+	if nil == ctx {
+		return LogDefault
+	}
+	if mode, ok := ctx.Value(logModeKey{}).(LogMode); ok {
+		return mode
+	}
+	return LogDefault
+}
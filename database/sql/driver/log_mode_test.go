@@ -0,0 +1,177 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package driver
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	kitconfig "github.com/fsyyft-go/kit/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestLogModeFromContext 验证 WithNoLog 与 WithForceLog 写入的日志模式及其覆盖关系。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestLogModeFromContext(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		ctx         context.Context
+		want        LogMode
+	}{
+		{name: "success/default", description: "验证未设置时返回 LogDefault。", ctx: context.Background(), want: LogDefault},
+		{name: "success/no-log", description: "验证 WithNoLog 设置 LogSuppress。", ctx: WithNoLog(context.Background()), want: LogSuppress},
+		{name: "success/force-log", description: "验证 WithForceLog 设置 LogForce。", ctx: WithForceLog(context.Background()), want: LogForce},
+		{name: "success/override", description: "验证后设置的模式覆盖先设置的模式。", ctx: WithNoLog(WithForceLog(context.Background())), want: LogSuppress},
+		{name: "success/hook-context", description: "验证 HookContext 透传原始上下文中的模式。", ctx: NewHookContext(WithForceLog(context.Background()), OpExec, "", nil), want: LogForce},
+		{name: "boundary/nil", description: "验证 nil 上下文返回 LogDefault。", ctx: nil, want: LogDefault},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			assert.Equal(t, tt.want, LogModeFromContext(tt.ctx))
+		})
+	}
+
+	assert.Equal(t, "Default", LogDefault.String())
+	assert.Equal(t, "Suppress", LogSuppress.String())
+	assert.Equal(t, "Force", LogForce.String())
+	assert.Equal(t, "Unknown", LogMode(-1).String())
+}
+
+// TestHookLog_LogMode 验证日志 Hook 按上下文中的日志模式跳过或强制记录操作。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestHookLog_LogMode(t *testing.T) {
+	execErr := errors.New("exec failed")
+
+	tests := []struct {
+		name        string
+		description string
+		newHook     func(logger *captureLogger) Hook
+		withMode    func(ctx context.Context) context.Context
+		giveErr     error
+		// wantLevel 是期望的日志级别，为空表示不应记录日志。
+		wantLevel  string
+		wantForced bool
+	}{
+		{
+			name:        "success/slow-no-log-skips-slow-operation",
+			description: "验证 WithNoLog 使慢操作日志 Hook 跳过达到阈值的操作。",
+			newHook:     func(l *captureLogger) Hook { return NewHookLogSlow("", l, -time.Nanosecond) },
+			withMode:    WithNoLog,
+		},
+		{
+			name:        "success/slow-force-log-below-threshold",
+			description: "验证 WithForceLog 使慢操作日志 Hook 以 Info 级别记录未达到阈值的操作，并附加 forced 字段。",
+			newHook:     func(l *captureLogger) Hook { return NewHookLogSlow("", l, time.Hour) },
+			withMode:    WithForceLog,
+			wantLevel:   "info",
+			wantForced:  true,
+		},
+		{
+			name:        "success/slow-force-log-above-threshold",
+			description: "验证 WithForceLog 下达到阈值的操作仍以 Warn 级别记录，不附加 forced 字段。",
+			newHook:     func(l *captureLogger) Hook { return NewHookLogSlow("", l, -time.Nanosecond) },
+			withMode:    WithForceLog,
+			wantLevel:   "warn",
+		},
+		{
+			name:        "success/error-no-log-skips-error",
+			description: "验证 WithNoLog 使错误日志 Hook 跳过失败的操作。",
+			newHook:     func(l *captureLogger) Hook { return NewHookLogError("", l) },
+			withMode:    WithNoLog,
+			giveErr:     execErr,
+		},
+		{
+			name:        "success/error-force-log-keeps-error",
+			description: "验证 WithForceLog 不改变错误日志 Hook 的行为。",
+			newHook:     func(l *captureLogger) Hook { return NewHookLogError("", l) },
+			withMode:    WithForceLog,
+			giveErr:     execErr,
+			wantLevel:   "error",
+		},
+		{
+			name:        "success/verbose-force-log-ignores-switch",
+			description: "验证 WithForceLog 使详细日志 Hook 在 VerboseSQL 关闭时仍记录操作。",
+			newHook: func(l *captureLogger) Hook {
+				return NewHookLogVerbose("", l, kitconfig.NewDebugRegistry(kitconfig.DebugFlags{}))
+			},
+			withMode:  WithForceLog,
+			wantLevel: "info",
+		},
+		{
+			name:        "success/verbose-no-log-ignores-switch",
+			description: "验证 WithNoLog 使详细日志 Hook 在 VerboseSQL 打开时也不记录操作。",
+			newHook: func(l *captureLogger) Hook {
+				return NewHookLogVerbose("", l, kitconfig.NewDebugRegistry(kitconfig.DebugFlags{VerboseSQL: true}))
+			},
+			withMode: WithNoLog,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			logger := newCaptureLogger()
+			hook := tt.newHook(logger)
+			ctx := NewHookContext(tt.withMode(context.Background()), OpExec, "UPDATE users SET name=?", nil)
+			ctx.SetResult(driver.RowsAffected(1), tt.giveErr)
+			require.NoError(t, hook.After(ctx))
+
+			if "" == tt.wantLevel {
+				time.Sleep(10 * time.Millisecond)
+				assert.Empty(t, logger.snapshotEntries())
+				return
+			}
+			entry := logger.requireEntry(t)
+			assert.Equal(t, tt.wantLevel, entry.level)
+			assert.Equal(t, "UPDATE users SET name=?", entry.fields["query"])
+			if tt.wantForced {
+				assert.Equal(t, true, entry.fields["forced"])
+			} else {
+				assert.NotContains(t, entry.fields, "forced")
+			}
+		})
+	}
+}
+
+// TestHookLogSlow_ForceLogQuery 验证强制记录的查询在结果集关闭后记录，即使未达到阈值。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestHookLogSlow_ForceLogQuery(t *testing.T) {
+	t.Log("验证 WithForceLog 标记的查询在结果集关闭后以 Info 级别记录行数。")
+
+	logger := newCaptureLogger()
+	base := &testFullConn{
+		queryContextFn: func(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+			return &testRows{rows: 2}, nil
+		},
+	}
+	conn := &kitConn{Conn: base, hook: NewHookLogSlow("", logger, time.Hour)}
+
+	rows, err := conn.QueryContext(WithForceLog(context.Background()), "SELECT id FROM users", nil)
+	require.NoError(t, err)
+	dest := make([]driver.Value, 1)
+	for nil == rows.Next(dest) {
+	}
+	require.NoError(t, rows.Close())
+
+	entry := logger.requireEntry(t)
+	assert.Equal(t, "info", entry.level)
+	assert.Equal(t, true, entry.fields["forced"])
+	assert.Equal(t, int64(2), entry.fields["rows"])
+}
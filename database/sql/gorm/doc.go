@@ -2,12 +2,16 @@
 //
 // Licensed under the MIT License. See LICENSE file in the project root for full license information.
 
-// Package gorm 提供 kit/log 与 GORM logger.Interface 之间的日志适配器。
+// Package gorm 提供 kit/log 与 GORM logger.Interface 之间的日志适配器，以及基于 GORM 的泛型仓储。
 //
 // NewLogger 根据底层 kit logger 的当前级别初始化 GORM 日志级别，并通过
 // gorm logger.Interface 的 Info、Warn、Error 和 Trace 输出 SQL、影响行数、
-// 执行错误与慢查询信息。
+// 执行错误与慢查询信息。适配器会异步调用底层 logger，因此不保证日志在当前方法返回前已经完成写出。
 //
-// 适配器会异步调用底层 logger，因此不保证日志在当前方法返回前已经完成写出。
-// 本包只负责日志桥接，不负责创建 gorm.DB、配置迁移或管理数据库连接。
+// NewRepository 为模型创建 Repository，提供 GetByID、GetByIDs、Create、按版本列实现乐观锁的
+// UpdateWithVersion、基于 ON CONFLICT / ON DUPLICATE KEY UPDATE 的 BatchUpsert，以及遵循 GORM 软删除
+// 语义的 Delete、WithTrashed、OnlyTrashed、Restore 与 ForceDelete。乐观锁更新未命中记录时返回
+// ErrVersionConflict，模型缺少所需的主键、版本列或软删除字段时返回 ErrInvalidModel。
+//
+// 本包不负责创建 gorm.DB、配置迁移或管理数据库连接。
 package gorm
@@ -0,0 +1,511 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package gorm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+var (
+	// ErrVersionConflict 表示乐观锁更新未命中任何记录。
+	//
+	// 记录已被其他请求修改（版本号已变化）、已被删除或已被软删除时返回该错误，调用方通常应重新读取后重试。
+	// 调用方可以使用 errors.Is 判断该错误。
+	ErrVersionConflict = errors.New("记录版本冲突，可能已被修改或删除。")
+	// ErrInvalidModel 表示模型不满足操作要求，例如没有主键、没有版本列或不支持软删除。
+	//
+	// 调用方可以使用 errors.Is 判断该错误。
+	ErrInvalidModel = errors.New("模型不满足操作要求。")
+)
+
+var (
+	// versionColumnDefault 是未配置 WithVersionColumn 时使用的版本列名称。
+	versionColumnDefault = "version"
+	// upsertBatchSizeDefault 是未配置 WithBatchSize 时 BatchUpsert 每批写入的记录数。
+	upsertBatchSizeDefault = 100
+	// deletedAtType 是 gorm 软删除字段的类型。
+	deletedAtType = reflect.TypeOf(gorm.DeletedAt{})
+)
+
+type (
+	// RepositoryOption 配置 NewRepository 创建的仓储。
+	RepositoryOption func(*repositoryOptions)
+
+	// UpsertOption 配置单次 BatchUpsert 调用。
+	UpsertOption func(*upsertOptions)
+
+	// repositoryOptions 包含仓储配置选项。
+	repositoryOptions struct {
+		// versionColumn 是乐观锁使用的版本列名称。
+		versionColumn string
+	}
+
+	// upsertOptions 包含 BatchUpsert 的配置选项。
+	upsertOptions struct {
+		// batchSize 是每批写入的记录数。
+		batchSize int
+		// conflictColumns 是判断冲突的列，为空时使用主键。
+		conflictColumns []string
+		// updateColumns 是冲突时更新的列，为空时更新全部非主键列。
+		updateColumns []string
+	}
+
+	// Repository 是基于 gorm 的泛型仓储，封装按主键读取、乐观锁更新、批量 upsert 与软删除相关操作。
+	//
+	// T 是 gorm 模型的结构体类型，必须定义主键；需要乐观锁时定义整数版本列，需要软删除时嵌入 gorm.Model
+	// 或定义 gorm.DeletedAt 字段。Repository 不保存请求状态，可以并发使用；在事务中使用时通过 WithTx 派生。
+	Repository[T any] struct {
+		// db 是底层 gorm 连接。
+		db *gorm.DB
+		// schema 是模型的解析结果。
+		schema *schema.Schema
+		// primaryKey 是用于按主键查询的字段。
+		primaryKey *schema.Field
+		// version 是乐观锁版本字段，模型没有版本列时为 nil。
+		version *schema.Field
+		// deletedAt 是软删除字段，模型不支持软删除时为 nil。
+		deletedAt *schema.Field
+	}
+)
+
+// WithVersionColumn 配置乐观锁使用的版本列。
+//
+// 参数：
+//   - column: 版本列的数据库列名或结构体字段名，默认值为 version；列必须为整数类型。
+//
+// 返回：
+//   - RepositoryOption: 仓储配置选项。
+func WithVersionColumn(column string) RepositoryOption {
+	return func(o *repositoryOptions) {
+		o.versionColumn = column
+	}
+}
+
+// WithBatchSize 配置 BatchUpsert 每批写入的记录数。
+//
+// 参数：
+//   - size: 每批记录数；小于等于 0 时使用默认值 100。
+//
+// 返回：
+//   - UpsertOption: BatchUpsert 配置选项。
+func WithBatchSize(size int) UpsertOption {
+	return func(o *upsertOptions) {
+		if size > 0 {
+			o.batchSize = size
+		}
+	}
+}
+
+// WithConflictColumns 配置 BatchUpsert 判断冲突的列，通常是唯一索引的列。
+//
+// 参数：
+//   - columns: 数据库列名；为空时使用主键。MySQL 按表上的全部唯一索引判断冲突并忽略该配置，
+//     PostgreSQL 与 SQLite 需要它与某个唯一索引完全一致。
+//
+// 返回：
+//   - UpsertOption: BatchUpsert 配置选项。
+func WithConflictColumns(columns ...string) UpsertOption {
+	return func(o *upsertOptions) {
+		o.conflictColumns = append(o.conflictColumns, columns...)
+	}
+}
+
+// WithUpdateColumns 配置 BatchUpsert 冲突时更新的列。
+//
+// 参数：
+//   - columns: 数据库列名；为空时更新全部非主键、非创建时间列。
+//
+// 返回：
+//   - UpsertOption: BatchUpsert 配置选项。
+func WithUpdateColumns(columns ...string) UpsertOption {
+	return func(o *upsertOptions) {
+		o.updateColumns = append(o.updateColumns, columns...)
+	}
+}
+
+// NewRepository 创建模型 T 的仓储。
+//
+// 参数：
+//   - db: gorm 连接，不能为 nil。
+//   - opts: 仓储配置选项。
+//
+// 返回：
+//   - *Repository[T]: 创建的仓储。
+//   - error: 模型无法解析时返回解析错误，模型没有主键时返回包装了 ErrInvalidModel 的错误。
+func NewRepository[T any](db *gorm.DB, opts ...RepositoryOption) (*Repository[T], error) {
+	o := &repositoryOptions{
+		versionColumn: versionColumnDefault,
+	}
+	for _, opt := range opts {
+		if nil != opt {
+			opt(o)
+		}
+	}
+
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(new(T)); nil != err {
+		return nil, err
+	}
+	s := stmt.Schema
+	if nil == s.PrioritizedPrimaryField {
+		return nil, fmt.Errorf("%w: %s 没有唯一的主键", ErrInvalidModel, s.Name)
+	}
+
+	r := &Repository[T]{
+		db:         db,
+		schema:     s,
+		primaryKey: s.PrioritizedPrimaryField,
+	}
+	if "" != o.versionColumn {
+		r.version = s.LookUpField(o.versionColumn)
+	}
+	for _, field := range s.Fields {
+		if deletedAtType == field.FieldType && "" != field.DBName {
+			r.deletedAt = field
+			break
+		}
+	}
+	return r, nil
+}
+
+// WithTx 返回使用指定连接或事务的仓储副本。
+//
+// 参数：
+//   - tx: gorm 事务或其他连接，通常来自 db.Transaction 的回调参数。
+//
+// 返回：
+//   - *Repository[T]: 共享模型解析结果的仓储副本。
+func (r *Repository[T]) WithTx(tx *gorm.DB) *Repository[T] {
+	c := *r
+	c.db = tx
+	return &c
+}
+
+// DB 返回绑定上下文与模型 T 的 gorm 会话，用于编写仓储未覆盖的查询。
+//
+// 会话遵循 gorm 的软删除语义，默认不包含已软删除的记录。
+//
+// 参数：
+//   - ctx: 请求上下文。
+//
+// 返回：
+//   - *gorm.DB: 新的 gorm 会话。
+func (r *Repository[T]) DB(ctx context.Context) *gorm.DB {
+	return r.db.WithContext(ctx).Model(new(T))
+}
+
+// GetByID 按主键读取记录，已软删除的记录视为不存在。
+//
+// 参数：
+//   - ctx: 请求上下文。
+//   - id: 主键值。
+//
+// 返回：
+//   - *T: 读取到的记录。
+//   - error: 记录不存在时返回 gorm.ErrRecordNotFound，其余情况返回数据库错误。
+func (r *Repository[T]) GetByID(ctx context.Context, id interface{}) (*T, error) {
+	entity := new(T)
+	if err := r.db.WithContext(ctx).Where(r.primaryKeyEq(id)).First(entity).Error; nil != err {
+		return nil, err
+	}
+	return entity, nil
+}
+
+// GetByIDs 按主键批量读取记录，已软删除的记录与不存在的主键被忽略。
+//
+// 参数：
+//   - ctx: 请求上下文。
+//   - ids: 主键值的切片，例如 []int64 或 []string；不是切片时视为单个主键值。
+//
+// 返回：
+//   - []T: 读取到的记录，顺序由数据库决定；ids 为 nil 或空切片时返回空切片且不访问数据库。
+//   - error: 数据库错误。
+func (r *Repository[T]) GetByIDs(ctx context.Context, ids interface{}) ([]T, error) {
+	values := toInterfaces(ids)
+	entities := make([]T, 0, len(values))
+	if 0 == len(values) {
+		return entities, nil
+	}
+	err := r.db.WithContext(ctx).
+		Where(clause.IN{Column: r.primaryKeyColumn(), Values: values}).
+		Find(&entities).Error
+	if nil != err {
+		return nil, err
+	}
+	return entities, nil
+}
+
+// Create 创建记录。
+//
+// 参数：
+//   - ctx: 请求上下文。
+//   - entity: 待创建的记录，自增主键、默认值与版本号等由 gorm 回填。
+//
+// 返回：
+//   - error: 数据库错误。
+func (r *Repository[T]) Create(ctx context.Context, entity *T) error {
+	return r.db.WithContext(ctx).Create(entity).Error
+}
+
+// UpdateWithVersion 使用版本列实现乐观锁更新。
+//
+// 更新语句形如 `UPDATE ... SET ..., version = 旧版本+1 WHERE id = ? AND version = 旧版本`，并遵循 gorm
+// 的软删除语义，因此已软删除的记录同样视为冲突。更新成功后 entity 的版本号为新版本，失败时恢复为旧版本。
+//
+// 参数：
+//   - ctx: 请求上下文。
+//   - entity: 待更新的记录，主键与版本号必须为读取时的值。
+//   - columns: 需要更新的数据库列或字段名；为空时更新除主键外的全部字段（包括零值与创建时间），
+//     版本列总是会被更新。
+//
+// 返回：
+//   - error: 模型没有整数版本列或 entity 没有主键时返回包装了 ErrInvalidModel 的错误；
+//     未命中任何记录时返回 ErrVersionConflict；其余情况返回数据库错误。
+func (r *Repository[T]) UpdateWithVersion(ctx context.Context, entity *T, columns ...string) error {
+	if nil == r.version || !isInteger(r.version.FieldType) {
+		return fmt.Errorf("%w: %s 没有整数版本列", ErrInvalidModel, r.schema.Name)
+	}
+	rv := reflect.ValueOf(entity).Elem()
+	if _, zero := r.primaryKey.ValueOf(ctx, rv); zero {
+		return fmt.Errorf("%w: 主键 %s 为零值", ErrInvalidModel, r.primaryKey.Name)
+	}
+
+	current, _ := r.version.ValueOf(ctx, rv)
+	next := reflect.ValueOf(current).Convert(reflect.TypeOf(int64(0))).Int() + 1
+	if err := r.version.Set(ctx, rv, next); nil != err {
+		return err
+	}
+
+	tx := r.db.WithContext(ctx).
+		Model(entity).
+		Where(clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: r.version.DBName}, Value: current})
+	if 0 == len(columns) {
+		tx = tx.Select("*")
+	} else {
+		tx = tx.Select(r.version.DBName, columns)
+	}
+	result := tx.Updates(entity)
+	if nil == result.Error && 0 == result.RowsAffected {
+		result.Error = ErrVersionConflict
+	}
+	if nil != result.Error {
+		if err := r.version.Set(ctx, rv, current); nil != err {
+			return errors.Join(result.Error, err)
+		}
+		return result.Error
+	}
+	return nil
+}
+
+// BatchUpsert 批量插入记录，主键或唯一索引冲突时更新已有记录。
+//
+// 已软删除的记录在冲突时同样被更新，但软删除字段只有在出现在更新列中时才会被恢复。
+//
+// 参数：
+//   - ctx: 请求上下文。
+//   - entities: 待写入的记录；为空时不访问数据库。
+//   - opts: BatchUpsert 配置选项。
+//
+// 返回：
+//   - error: 数据库错误；未开启 SkipDefaultTransaction 时多批写入在同一事务中执行，任一批失败时整体回滚。
+func (r *Repository[T]) BatchUpsert(ctx context.Context, entities []T, opts ...UpsertOption) error {
+	if 0 == len(entities) {
+		return nil
+	}
+	o := &upsertOptions{
+		batchSize: upsertBatchSizeDefault,
+	}
+	for _, opt := range opts {
+		if nil != opt {
+			opt(o)
+		}
+	}
+
+	onConflict := clause.OnConflict{UpdateAll: 0 == len(o.updateColumns)}
+	conflictColumns := o.conflictColumns
+	if 0 == len(conflictColumns) {
+		for _, field := range r.schema.PrimaryFields {
+			conflictColumns = append(conflictColumns, field.DBName)
+		}
+	}
+	for _, column := range conflictColumns {
+		onConflict.Columns = append(onConflict.Columns, clause.Column{Name: column})
+	}
+	if !onConflict.UpdateAll {
+		onConflict.DoUpdates = clause.AssignmentColumns(o.updateColumns)
+	}
+
+	return r.db.WithContext(ctx).Clauses(onConflict).CreateInBatches(&entities, o.batchSize).Error
+}
+
+// Delete 按主键删除记录；模型支持软删除时执行软删除。
+//
+// 参数：
+//   - ctx: 请求上下文。
+//   - id: 主键值。
+//
+// 返回：
+//   - error: 记录不存在或已软删除时返回 gorm.ErrRecordNotFound，其余情况返回数据库错误。
+func (r *Repository[T]) Delete(ctx context.Context, id interface{}) error {
+	return affected(r.db.WithContext(ctx).Where(r.primaryKeyEq(id)).Delete(new(T)))
+}
+
+// WithTrashed 返回包含已软删除记录的 gorm 会话。
+//
+// 参数：
+//   - ctx: 请求上下文。
+//
+// 返回：
+//   - *gorm.DB: 使用 Unscoped 的会话；模型不支持软删除时与 DB 返回的会话等价。
+func (r *Repository[T]) WithTrashed(ctx context.Context) *gorm.DB {
+	return r.DB(ctx).Unscoped()
+}
+
+// OnlyTrashed 返回只包含已软删除记录的 gorm 会话。
+//
+// 参数：
+//   - ctx: 请求上下文。
+//
+// 返回：
+//   - *gorm.DB: 附加 `deleted_at IS NOT NULL` 条件的会话；模型不支持软删除时会话携带
+//     包装了 ErrInvalidModel 的错误，执行查询时返回该错误。
+func (r *Repository[T]) OnlyTrashed(ctx context.Context) *gorm.DB {
+	db := r.WithTrashed(ctx)
+	if nil == r.deletedAt {
+		_ = db.AddError(fmt.Errorf("%w: %s 不支持软删除", ErrInvalidModel, r.schema.Name))
+		return db
+	}
+	return db.Where(clause.Neq{Column: r.deletedAtColumn(), Value: nil})
+}
+
+// Restore 恢复已软删除的记录。
+//
+// 参数：
+//   - ctx: 请求上下文。
+//   - id: 主键值。
+//
+// 返回：
+//   - error: 模型不支持软删除时返回包装了 ErrInvalidModel 的错误；记录不存在或未被软删除时返回
+//     gorm.ErrRecordNotFound；其余情况返回数据库错误。
+func (r *Repository[T]) Restore(ctx context.Context, id interface{}) error {
+	if nil == r.deletedAt {
+		return fmt.Errorf("%w: %s 不支持软删除", ErrInvalidModel, r.schema.Name)
+	}
+	return affected(r.WithTrashed(ctx).
+		Where(r.primaryKeyEq(id)).
+		Where(clause.Neq{Column: r.deletedAtColumn(), Value: nil}).
+		Update(r.deletedAt.DBName, nil))
+}
+
+// ForceDelete 按主键物理删除记录，包括已软删除的记录。
+//
+// 参数：
+//   - ctx: 请求上下文。
+//   - id: 主键值。
+//
+// 返回：
+//   - error: 记录不存在时返回 gorm.ErrRecordNotFound，其余情况返回数据库错误。
+func (r *Repository[T]) ForceDelete(ctx context.Context, id interface{}) error {
+	return affected(r.db.WithContext(ctx).Unscoped().Where(r.primaryKeyEq(id)).Delete(new(T)))
+}
+
+// primaryKeyColumn 返回带当前表名限定的主键列。
+//
+// 参数：无。
+//
+// 返回：
+//   - clause.Column: 主键列。
+func (r *Repository[T]) primaryKeyColumn() clause.Column {
+	return clause.Column{Table: clause.CurrentTable, Name: r.primaryKey.DBName}
+}
+
+// primaryKeyEq 返回主键等值条件。
+//
+// 使用显式条件而不是 First(dest, id)，避免字符串主键被 gorm 当作 SQL 条件拼接。
+//
+// 参数：
+//   - id: 主键值。
+//
+// 返回：
+//   - clause.Eq: 主键等值条件。
+func (r *Repository[T]) primaryKeyEq(id interface{}) clause.Eq {
+	return clause.Eq{Column: r.primaryKeyColumn(), Value: id}
+}
+
+// deletedAtColumn 返回带当前表名限定的软删除列。
+//
+// 参数：无。
+//
+// 返回：
+//   - clause.Column: 软删除列。
+func (r *Repository[T]) deletedAtColumn() clause.Column {
+	return clause.Column{Table: clause.CurrentTable, Name: r.deletedAt.DBName}
+}
+
+// affected 将未影响任何行的执行结果转换为 gorm.ErrRecordNotFound。
+//
+// 参数：
+//   - result: gorm 执行结果。
+//
+// 返回：
+//   - error: 执行错误；未出错且影响行数为 0 时返回 gorm.ErrRecordNotFound。
+func affected(result *gorm.DB) error {
+	if nil != result.Error {
+		return result.Error
+	}
+	if 0 == result.RowsAffected {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// toInterfaces 将切片展开为 []interface{}。
+//
+// 参数：
+//   - values: 任意类型的切片或数组；不是切片或数组时视为单个值。
+//
+// 返回：
+//   - []interface{}: 展开后的值；values 为 nil 时返回 nil。
+func toInterfaces(values interface{}) []interface{} {
+	if nil == values {
+		return nil
+	}
+	if vs, ok := values.([]interface{}); ok {
+		return vs
+	}
+	rv := reflect.ValueOf(values)
+	if reflect.Slice != rv.Kind() && reflect.Array != rv.Kind() {
+		return []interface{}{values}
+	}
+	out := make([]interface{}, rv.Len())
+	for i := range out {
+		out[i] = rv.Index(i).Interface()
+	}
+	return out
+}
+
+// isInteger 判断类型是否为整数。
+//
+// 参数：
+//   - t: 待判断的类型。
+//
+// 返回：
+//   - bool: 为有符号或无符号整数时返回 true。
+func isInteger(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	default:
+		return false
+	}
+}
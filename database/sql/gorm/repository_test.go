@@ -0,0 +1,259 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package gorm
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+
+	kittestdriver "github.com/fsyyft-go/kit/database/sql/testdriver"
+)
+
+// testAccount 是带版本列与软删除字段的测试模型。
+type testAccount struct {
+	ID        int64 `gorm:"primaryKey"`
+	Name      string
+	Version   int64
+	DeletedAt gorm.DeletedAt
+}
+
+// testTag 是不带版本列与软删除字段的测试模型。
+type testTag struct {
+	Code string `gorm:"primaryKey"`
+	Name string
+}
+
+// newTestRepository 创建基于内存驱动的仓储。
+//
+// 参数：
+//   - t: 测试上下文。
+//
+// 返回：
+//   - *Repository[M]: 测试仓储。
+//   - *testdriver.Driver: 用于编排 SQL 期望的内存驱动。
+func newTestRepository[M any](t *testing.T) (*Repository[M], *kittestdriver.Driver) {
+	fake := kittestdriver.New()
+	sqlDB, _ := kittestdriver.NewDB(fake)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: sqlDB, SkipInitializeWithVersion: true}), &gorm.Config{
+		SkipDefaultTransaction: true,
+		Logger:                 gormlogger.Discard,
+	})
+	require.NoError(t, err)
+	repo, err := NewRepository[M](db)
+	require.NoError(t, err)
+	return repo, fake
+}
+
+// TestRepository_GetByID 验证按主键读取与软删除过滤。
+func TestRepository_GetByID(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		rows        [][]driver.Value
+		want        *testAccount
+		wantErr     error
+	}{
+		{
+			name:        "success/found",
+			description: "验证读取到记录。",
+			rows:        [][]driver.Value{{int64(7), "alice", int64(3), nil}},
+			want:        &testAccount{ID: 7, Name: "alice", Version: 3},
+		},
+		{
+			name:        "error/not-found",
+			description: "验证记录不存在时返回 gorm.ErrRecordNotFound。",
+			wantErr:     gorm.ErrRecordNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			repo, fake := newTestRepository[testAccount](t)
+			query := fake.ExpectQuery("`test_accounts`.`deleted_at` IS NULL").
+				WillReturnRows([]string{"id", "name", "version", "deleted_at"}, tt.rows...)
+
+			got, err := repo.GetByID(context.Background(), int64(7))
+			assert.Equal(t, 1, query.Calls())
+			assert.Equal(t, int64(7), query.Args()[0][0].Value)
+			if nil != tt.wantErr {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// TestRepository_GetByIDs 验证按主键批量读取。
+func TestRepository_GetByIDs(t *testing.T) {
+	repo, fake := newTestRepository[testTag](t)
+	query := fake.ExpectQuery("`test_tags`.`code` IN").
+		WillReturnRows([]string{"code", "name"}, []driver.Value{"go", "Go"}, []driver.Value{"rs", "Rust"})
+
+	got, err := repo.GetByIDs(context.Background(), []string{"go", "rs"})
+	require.NoError(t, err)
+	assert.Equal(t, []testTag{{Code: "go", Name: "Go"}, {Code: "rs", Name: "Rust"}}, got)
+	assert.Len(t, query.Args()[0], 2)
+
+	got, err = repo.GetByIDs(context.Background(), []string{})
+	require.NoError(t, err)
+	assert.Empty(t, got)
+	assert.Equal(t, 1, query.Calls())
+}
+
+// TestRepository_UpdateWithVersion 验证乐观锁更新。
+func TestRepository_UpdateWithVersion(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		columns     []string
+		affected    int64
+		wantVersion int64
+		wantErr     error
+	}{
+		{
+			name:        "success/all-columns",
+			description: "验证更新全部字段并递增版本号。",
+			affected:    1,
+			wantVersion: 4,
+		},
+		{
+			name:        "success/selected-columns",
+			description: "验证只更新指定列时仍递增版本号。",
+			columns:     []string{"name"},
+			affected:    1,
+			wantVersion: 4,
+		},
+		{
+			name:        "error/conflict",
+			description: "验证未命中记录时返回 ErrVersionConflict 并恢复版本号。",
+			affected:    0,
+			wantVersion: 3,
+			wantErr:     ErrVersionConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			repo, fake := newTestRepository[testAccount](t)
+			update := fake.ExpectExec("`test_accounts`.`version` = ?").WillReturnResult(0, tt.affected)
+
+			account := &testAccount{ID: 7, Name: "bob", Version: 3}
+			err := repo.UpdateWithVersion(context.Background(), account, tt.columns...)
+			assert.Equal(t, 1, update.Calls())
+			assert.Equal(t, tt.wantVersion, account.Version)
+			if nil != tt.wantErr {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			var values []interface{}
+			for _, arg := range update.Args()[0] {
+				values = append(values, arg.Value)
+			}
+			assert.Contains(t, values, int64(4))
+			assert.Contains(t, values, int64(3))
+			assert.Contains(t, values, "bob")
+		})
+	}
+
+	t.Run("error/invalid-model", func(t *testing.T) {
+		t.Log("验证模型没有版本列或主键为零值时返回 ErrInvalidModel。")
+
+		tags, _ := newTestRepository[testTag](t)
+		assert.ErrorIs(t, tags.UpdateWithVersion(context.Background(), &testTag{Code: "go"}), ErrInvalidModel)
+
+		accounts, _ := newTestRepository[testAccount](t)
+		assert.ErrorIs(t, accounts.UpdateWithVersion(context.Background(), &testAccount{}), ErrInvalidModel)
+	})
+}
+
+// TestRepository_BatchUpsert 验证批量 upsert。
+func TestRepository_BatchUpsert(t *testing.T) {
+	repo, fake := newTestRepository[testTag](t)
+	upsert := fake.ExpectExec("ON DUPLICATE KEY UPDATE").WillReturnResult(0, 1)
+
+	tags := []testTag{{Code: "go", Name: "Go"}, {Code: "rs", Name: "Rust"}, {Code: "py", Name: "Python"}}
+	require.NoError(t, repo.BatchUpsert(context.Background(), tags, WithBatchSize(2), WithUpdateColumns("name")))
+	assert.Equal(t, 2, upsert.Calls())
+
+	require.NoError(t, repo.BatchUpsert(context.Background(), nil))
+	assert.Equal(t, 2, upsert.Calls())
+}
+
+// TestRepository_SoftDelete 验证软删除、恢复与物理删除。
+func TestRepository_SoftDelete(t *testing.T) {
+	t.Run("success/delete", func(t *testing.T) {
+		t.Log("验证支持软删除的模型执行 UPDATE 并排除已删除记录。")
+
+		repo, fake := newTestRepository[testAccount](t)
+		del := fake.ExpectExec("SET `deleted_at`=?").WillReturnResult(0, 1)
+		require.NoError(t, repo.Delete(context.Background(), int64(7)))
+		assert.Equal(t, 1, del.Calls())
+	})
+
+	t.Run("error/delete-missing", func(t *testing.T) {
+		t.Log("验证未影响任何行时返回 gorm.ErrRecordNotFound。")
+
+		repo, fake := newTestRepository[testAccount](t)
+		fake.ExpectExec("SET `deleted_at`=?").WillReturnResult(0, 0)
+		assert.ErrorIs(t, repo.Delete(context.Background(), int64(7)), gorm.ErrRecordNotFound)
+	})
+
+	t.Run("success/restore", func(t *testing.T) {
+		t.Log("验证只恢复已软删除的记录。")
+
+		repo, fake := newTestRepository[testAccount](t)
+		restore := fake.ExpectExec("`test_accounts`.`deleted_at` IS NOT NULL").WillReturnResult(0, 1)
+		require.NoError(t, repo.Restore(context.Background(), int64(7)))
+		assert.Equal(t, 1, restore.Calls())
+	})
+
+	t.Run("success/force-delete", func(t *testing.T) {
+		t.Log("验证物理删除执行 DELETE。")
+
+		repo, fake := newTestRepository[testAccount](t)
+		del := fake.ExpectExec("DELETE FROM `test_accounts`").WillReturnResult(0, 1)
+		require.NoError(t, repo.ForceDelete(context.Background(), int64(7)))
+		assert.Equal(t, 1, del.Calls())
+	})
+
+	t.Run("success/only-trashed", func(t *testing.T) {
+		t.Log("验证 OnlyTrashed 只查询已软删除的记录。")
+
+		repo, fake := newTestRepository[testAccount](t)
+		query := fake.ExpectQuery("`test_accounts`.`deleted_at` IS NOT NULL").
+			WillReturnRows([]string{"id", "name", "version", "deleted_at"})
+		var accounts []testAccount
+		require.NoError(t, repo.OnlyTrashed(context.Background()).Find(&accounts).Error)
+		assert.Equal(t, 1, query.Calls())
+	})
+
+	t.Run("error/not-soft-deletable", func(t *testing.T) {
+		t.Log("验证不支持软删除的模型返回 ErrInvalidModel。")
+
+		repo, _ := newTestRepository[testTag](t)
+		assert.ErrorIs(t, repo.Restore(context.Background(), "go"), ErrInvalidModel)
+		var tags []testTag
+		assert.ErrorIs(t, repo.OnlyTrashed(context.Background()).Find(&tags).Error, ErrInvalidModel)
+	})
+}
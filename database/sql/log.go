@@ -0,0 +1,39 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package sql
+
+import (
+	"context"
+
+	kitdriver "github.com/fsyyft-go/kit/database/sql/driver"
+)
+
+// WithNoLog 返回使驱动日志 Hook 跳过其中数据库操作的上下文。
+//
+// 适用于执行频率极高、日志价值很低的查询；慢操作、错误和详细日志 Hook 都不会记录使用该上下文的操作。
+// 等同于 driver.WithNoLog。
+//
+// 参数：
+//   - ctx: 父上下文。
+//
+// 返回：
+//   - context.Context: 关闭日志的上下文，传给 QueryContext、ExecContext 等方法使用。
+func WithNoLog(ctx context.Context) context.Context {
+	return kitdriver.WithNoLog(ctx)
+}
+
+// WithForceLog 返回使驱动日志 Hook 记录其中每一次数据库操作的上下文。
+//
+// 适用于需要完整记录的关键路径；慢操作日志 Hook 忽略阈值，详细日志 Hook 忽略 VerboseSQL 开关。
+// 等同于 driver.WithForceLog。
+//
+// 参数：
+//   - ctx: 父上下文。
+//
+// 返回：
+//   - context.Context: 强制记录日志的上下文，传给 QueryContext、ExecContext 等方法使用。
+func WithForceLog(ctx context.Context) context.Context {
+	return kitdriver.WithForceLog(ctx)
+}
@@ -9,8 +9,15 @@
 // 同一 namespace 会复用已注册的驱动名称，便于在不同调用点共享同一组
 // driver 包 Hook 规则。
 //
+// 连接池参数通过 WithMaxOpenConns、WithMaxIdleConns、WithConnMaxLifetime 与 WithConnMaxIdleTime
+// 配置，未设置时默认最大打开 100 个连接、保留 10 个空闲连接，连接最长复用 3 分钟、空闲 1 分钟后关闭。
+//
 // 当启用 WithLogError 或 WithSlowThreshold 时，本包会按需安装错误日志或
-// 慢查询日志 Hook；若未显式提供 logger，则会在需要时创建默认 logger。
+// 慢查询日志 Hook；WithDefaultQueryTimeout 会为没有截止时间的查询附加默认超时，
+// 并记录被超时终止的查询；WithRetry 会在事务之外自动重试幂等操作遇到的瞬时错误，
+// 瞬时错误由 IsTransientError 判断，包括坏连接、死锁和锁等待超时。
+// 若未显式提供 logger，则会在需要时创建默认 logger。
 // NewMySQL 仅调用 sql.Open，不会主动 Ping 数据库，调用方需要在需要时
-// 自行校验连通性。
+// 自行校验连通性。WithDriver 可以替换被包装的底层驱动，便于在单元测试中
+// 使用 database/sql/testdriver 代替真实 MySQL 服务。
 package mysql
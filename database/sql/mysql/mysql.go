@@ -6,6 +6,8 @@ package mysql
 
 import (
 	"database/sql"
+	"database/sql/driver"
+	"errors"
 	"fmt"
 	"net/url"
 	"slices"
@@ -32,14 +34,24 @@ var (
 var (
 	// defaultDSN 是 NewMySQL 未显式配置 DSN 时使用的默认数据源名称。
 	defaultDSN = "test:test@tcp(localhost:3306)/test?parseTime=true&loc=Local&allowNativePasswords=true&interpolateParams=true"
-	// defaultPoolIdleTime 是连接可被复用的默认最大生命周期，最终传给 (*sql.DB).SetConnMaxLifetime。
-	defaultPoolIdleTime = 10 * time.Second
-	// defaultPoolMaxIdleTime 是空闲连接在连接池中保留的默认最长时间，最终传给 (*sql.DB).SetConnMaxIdleTime。
-	defaultPoolMaxIdleTime = 10 * time.Second
-	// defaultPoolMaxOpenConns 是连接池默认允许同时打开的最大连接数。
-	defaultPoolMaxOpenConns = 100
-	// defaultPoolMaxIdleConns 是连接池默认保留的最大空闲连接数。
-	defaultPoolMaxIdleConns = 10
+	// defaultConnMaxLifetime 是连接可被复用的默认最大生命周期，最终传给 (*sql.DB).SetConnMaxLifetime。
+	//
+	// 取值低于 MySQL 与常见代理的空闲断开时长，使连接在被服务端断开前由客户端主动轮换，
+	// 同时避免过短的生命周期在高并发下频繁重建连接。
+	defaultConnMaxLifetime = 3 * time.Minute
+	// defaultConnMaxIdleTime 是空闲连接在连接池中保留的默认最长时间，最终传给 (*sql.DB).SetConnMaxIdleTime。
+	defaultConnMaxIdleTime = time.Minute
+	// defaultMaxOpenConns 是连接池默认允许同时打开的最大连接数。
+	defaultMaxOpenConns = 100
+	// defaultMaxIdleConns 是连接池默认保留的最大空闲连接数。
+	defaultMaxIdleConns = 10
+)
+
+const (
+	// errLockDeadlock 是 InnoDB 检测到死锁并回滚事务时返回的错误码 ER_LOCK_DEADLOCK。
+	errLockDeadlock = 1213
+	// errLockWaitTimeout 是等待行锁超时时返回的错误码 ER_LOCK_WAIT_TIMEOUT。
+	errLockWaitTimeout = 1205
 )
 
 type (
@@ -50,14 +62,14 @@ type (
 	MySQLOptions struct {
 		// dns 保存传递给 go-sql-driver/mysql 的 DSN 字符串。
 		dns string
-		// poolIdleTime 定义连接可被复用的最大生命周期。
-		poolIdleTime time.Duration
-		// poolMaxIdleTime 定义空闲连接在连接池中保留的最长时间。
-		poolMaxIdleTime time.Duration
-		// poolMaxOpenConns 定义连接池中允许的最大打开连接数。
-		poolMaxOpenConns int
-		// poolMaxIdleConns 定义连接池中允许的最大空闲连接数。
-		poolMaxIdleConns int
+		// connMaxLifetime 定义连接可被复用的最大生命周期。
+		connMaxLifetime time.Duration
+		// connMaxIdleTime 定义空闲连接在连接池中保留的最长时间。
+		connMaxIdleTime time.Duration
+		// maxOpenConns 定义连接池中允许的最大打开连接数。
+		maxOpenConns int
+		// maxIdleConns 定义连接池中允许的最大空闲连接数。
+		maxIdleConns int
 		// hook 用于管理数据库操作的 Hook 链。
 		hook *kitdriver.HookManager
 		// namespace 定义当前配置对应的 driver 注册命名空间。
@@ -68,6 +80,14 @@ type (
 		logError bool
 		// slowThreshold 定义自动慢操作日志 Hook 的耗时阈值。
 		slowThreshold time.Duration
+		// defaultQueryTimeout 定义自动默认查询超时 Hook 的超时时长。
+		defaultQueryTimeout time.Duration
+		// retryMax 定义自动重试 Hook 的最大重试次数。
+		retryMax int
+		// retryOpts 是传给自动重试 Hook 的附加配置。
+		retryOpts []kitdriver.RetryOption
+		// driver 是被 Hook 包装的底层 driver；为 nil 时使用 go-sql-driver/mysql。
+		driver driver.Driver
 	}
 
 	// MySQLOption 定义按引用修改 MySQLOptions 的函数式选项。
@@ -131,9 +151,72 @@ func WithDSNParams(baseDSN string, params map[string]string) MySQLOption {
 	}
 }
 
+// WithMaxOpenConns 设置 NewMySQL 创建的 *sql.DB 的最大打开连接数。
+//
+// 该选项最终映射到 (*sql.DB).SetMaxOpenConns，未设置时默认为 100。非正值表示不限制，沿用标准库 database/sql。
+//
+// 参数：
+//   - n: 连接池允许同时打开的最大连接数。
+//
+// 返回：
+//   - MySQLOption: 设置最大打开连接数的配置函数。
+func WithMaxOpenConns(n int) MySQLOption {
+	return func(o *MySQLOptions) {
+		o.maxOpenConns = n
+	}
+}
+
+// WithMaxIdleConns 设置 NewMySQL 创建的 *sql.DB 的最大空闲连接数。
+//
+// 该选项最终映射到 (*sql.DB).SetMaxIdleConns，未设置时默认为 10。非正值表示不保留空闲连接，
+// 超过最大打开连接数时会被标准库 database/sql 下调为最大打开连接数。
+//
+// 参数：
+//   - n: 连接池允许保留的最大空闲连接数。
+//
+// 返回：
+//   - MySQLOption: 设置最大空闲连接数的配置函数。
+func WithMaxIdleConns(n int) MySQLOption {
+	return func(o *MySQLOptions) {
+		o.maxIdleConns = n
+	}
+}
+
+// WithConnMaxLifetime 设置 NewMySQL 创建的 *sql.DB 的连接最大生命周期。
+//
+// 该选项最终映射到 (*sql.DB).SetConnMaxLifetime，未设置时默认为 3 分钟。应小于 MySQL 的 wait_timeout
+// 以及负载均衡、代理的空闲断开时长；非正值表示连接不因存在时长被关闭，沿用标准库 database/sql。
+//
+// 参数：
+//   - d: 连接可被复用的最长时间。
+//
+// 返回：
+//   - MySQLOption: 设置连接最大生命周期的配置函数。
+func WithConnMaxLifetime(d time.Duration) MySQLOption {
+	return func(o *MySQLOptions) {
+		o.connMaxLifetime = d
+	}
+}
+
+// WithConnMaxIdleTime 设置 NewMySQL 创建的 *sql.DB 的连接最大空闲时长。
+//
+// 该选项最终映射到 (*sql.DB).SetConnMaxIdleTime，未设置时默认为 1 分钟，使流量回落后多余的空闲连接
+// 及时释放；非正值表示空闲连接不因空闲时长被关闭，沿用标准库 database/sql。
+//
+// 参数：
+//   - d: 空闲连接在连接池中保留的最长时间。
+//
+// 返回：
+//   - MySQLOption: 设置连接最大空闲时长的配置函数。
+func WithConnMaxIdleTime(d time.Duration) MySQLOption {
+	return func(o *MySQLOptions) {
+		o.connMaxIdleTime = d
+	}
+}
+
 // WithPoolIdleTime 设置 NewMySQL 创建的 *sql.DB 的连接最大生命周期。
 //
-// 该选项最终映射到 (*sql.DB).SetConnMaxLifetime。非正值的含义沿用标准库 database/sql。
+// Deprecated: 名称与实际含义不符，使用 WithConnMaxLifetime。
 //
 // 参数：
 //   - idleTime: 连接可被复用的最长时间。
@@ -141,14 +224,12 @@ func WithDSNParams(baseDSN string, params map[string]string) MySQLOption {
 // 返回：
 //   - MySQLOption: 设置连接最大生命周期的配置函数。
 func WithPoolIdleTime(idleTime time.Duration) MySQLOption {
-	return func(o *MySQLOptions) {
-		o.poolIdleTime = idleTime
-	}
+	return WithConnMaxLifetime(idleTime)
 }
 
 // WithPoolMaxIdleTime 设置 NewMySQL 创建的 *sql.DB 的连接最大空闲时长。
 //
-// 该选项最终映射到 (*sql.DB).SetConnMaxIdleTime。非正值的含义沿用标准库 database/sql。
+// Deprecated: 使用与 database/sql 命名一致的 WithConnMaxIdleTime。
 //
 // 参数：
 //   - maxIdleTime: 空闲连接在连接池中保留的最长时间。
@@ -156,14 +237,12 @@ func WithPoolIdleTime(idleTime time.Duration) MySQLOption {
 // 返回：
 //   - MySQLOption: 设置连接最大空闲时长的配置函数。
 func WithPoolMaxIdleTime(maxIdleTime time.Duration) MySQLOption {
-	return func(o *MySQLOptions) {
-		o.poolMaxIdleTime = maxIdleTime
-	}
+	return WithConnMaxIdleTime(maxIdleTime)
 }
 
 // WithPoolMaxOpenConns 设置 NewMySQL 创建的 *sql.DB 的最大打开连接数。
 //
-// 该选项最终映射到 (*sql.DB).SetMaxOpenConns。非正值的含义沿用标准库 database/sql。
+// Deprecated: 使用与 database/sql 命名一致的 WithMaxOpenConns。
 //
 // 参数：
 //   - maxOpenConns: 连接池允许同时打开的最大连接数。
@@ -171,14 +250,12 @@ func WithPoolMaxIdleTime(maxIdleTime time.Duration) MySQLOption {
 // 返回：
 //   - MySQLOption: 设置最大打开连接数的配置函数。
 func WithPoolMaxOpenConns(maxOpenConns int) MySQLOption {
-	return func(o *MySQLOptions) {
-		o.poolMaxOpenConns = maxOpenConns
-	}
+	return WithMaxOpenConns(maxOpenConns)
 }
 
 // WithPoolMaxIdleConns 设置 NewMySQL 创建的 *sql.DB 的最大空闲连接数。
 //
-// 该选项最终映射到 (*sql.DB).SetMaxIdleConns。非正值与超过最大打开连接数时的行为沿用标准库 database/sql。
+// Deprecated: 使用与 database/sql 命名一致的 WithMaxIdleConns。
 //
 // 参数：
 //   - maxIdleConns: 连接池允许保留的最大空闲连接数。
@@ -186,9 +263,7 @@ func WithPoolMaxOpenConns(maxOpenConns int) MySQLOption {
 // 返回：
 //   - MySQLOption: 设置最大空闲连接数的配置函数。
 func WithPoolMaxIdleConns(maxIdleConns int) MySQLOption {
-	return func(o *MySQLOptions) {
-		o.poolMaxIdleConns = maxIdleConns
-	}
+	return WithMaxIdleConns(maxIdleConns)
 }
 
 // WithNamespace 设置当前配置对应的 driver 注册命名空间。
@@ -256,9 +331,68 @@ func WithSlowThreshold(slowThreshold time.Duration) MySQLOption {
 	}
 }
 
+// WithDefaultQueryTimeout 设置自动默认查询超时 Hook 的超时时长。
+//
+// 该选项只在 NewMySQL 首次为某个 namespace 注册 driver 且未显式提供
+// WithHookManager 时生效。调用方上下文没有截止时间时，Exec 和 Query 类操作会使用
+// context.WithTimeout 附加该超时；调用方已经设置截止时间时保持不变。查询因该超时
+// 被终止时会记录 Warn 日志。非正值表示不自动安装默认查询超时 Hook。
+//
+// 参数：
+//   - timeout: 默认查询超时时长。
+//
+// 返回：
+//   - MySQLOption: 设置默认查询超时的配置函数。
+func WithDefaultQueryTimeout(timeout time.Duration) MySQLOption {
+	return func(o *MySQLOptions) {
+		o.defaultQueryTimeout = timeout
+	}
+}
+
+// WithRetry 设置自动重试 Hook 的最大重试次数。
+//
+// 该选项只在 NewMySQL 首次为某个 namespace 注册 driver 且未显式提供
+// WithHookManager 时生效。安装的 HookRetry 使用 IsTransientError 判断错误，
+// 在事务之外自动重试连接、Ping、Prepare、Query 和 Begin 遇到的死锁（1213）与
+// 锁等待超时（1205），重试耗尽时记录 Warn 日志。Exec 默认不重试，确认写语句幂等时
+// 可传入 kitdriver.WithRetryExec(true)。非正值表示不自动安装重试 Hook。
+//
+// 参数：
+//   - maxRetries: 最大重试次数。
+//   - opts: 传给 HookRetry 的附加配置，例如退避时长；可覆盖默认的错误分类函数。
+//
+// 返回：
+//   - MySQLOption: 设置自动重试的配置函数。
+func WithRetry(maxRetries int, opts ...kitdriver.RetryOption) MySQLOption {
+	return func(o *MySQLOptions) {
+		o.retryMax = maxRetries
+		o.retryOpts = opts
+	}
+}
+
+// IsTransientError 判断错误是否为重试后可能成功的 MySQL 瞬时错误。
+//
+// 瞬时错误包括 driver.ErrBadConn、死锁（1213）和锁等待超时（1205）。
+//
+// 参数：
+//   - err: 待判断的错误。
+//
+// 返回：
+//   - bool: err 为瞬时错误或包装了瞬时错误时返回 true。
+func IsTransientError(err error) bool {
+	if errors.Is(err, driver.ErrBadConn) {
+		return true
+	}
+	var mysqlErr *gosqldriver.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return errLockDeadlock == mysqlErr.Number || errLockWaitTimeout == mysqlErr.Number
+	}
+	return false
+}
+
 // WithHookManager 指定一个自定义 HookManager 供驱动包装使用。
 //
-// 传入非 nil HookManager 后，NewMySQL 不会再为当前调用自动安装 HookLogError 或 HookLogSlow；
+// 传入非 nil HookManager 后，NewMySQL 不会再为当前调用自动安装 HookRetry、HookLogError、HookLogSlow 或 HookQueryTimeout；
 // 调用方需要自行向该管理器注册所需 Hook。传入 nil 等价于未指定。
 //
 // 参数：
@@ -272,6 +406,24 @@ func WithHookManager(hook *kitdriver.HookManager) MySQLOption {
 	}
 }
 
+// WithDriver 替换被 Hook 包装的底层 driver。
+//
+// 未指定时使用 go-sql-driver/mysql。该选项主要用于在单元测试中接入
+// database/sql/testdriver 等内存 driver，在不依赖真实 MySQL 服务的情况下验证
+// Hook 配置；DSN 仍会按 MySQL 格式校验并原样传给底层 driver。传入 nil 等价于未指定。
+// 与其他 Hook 配置一样，该选项只在对应 namespace 首次注册 driver 时生效。
+//
+// 参数：
+//   - d: 底层 driver 实现。
+//
+// 返回：
+//   - MySQLOption: 设置底层 driver 的配置函数。
+func WithDriver(d driver.Driver) MySQLOption {
+	return func(o *MySQLOptions) {
+		o.driver = d
+	}
+}
+
 // NewMySQL 基于 go-sql-driver/mysql 构造一个 *sql.DB 和清理函数。
 //
 // NewMySQL 会先应用默认配置与传入选项，使用 ParseDSN 校验 DSN，然后以
@@ -280,8 +432,8 @@ func WithHookManager(hook *kitdriver.HookManager) MySQLOption {
 // Ping 数据库，调用方需要在需要时自行验证连通性。
 //
 // 同一 namespace 的 driver 只会注册一次，后续调用会复用既有 driver 和其
-// 初次注册时确定的 Hook 配置；新的 WithHookManager、WithLogError 和
-// WithSlowThreshold 不会重新装配已注册 driver。
+// 初次注册时确定的 Hook 配置；新的 WithHookManager、WithLogError、
+// WithSlowThreshold、WithDefaultQueryTimeout、WithRetry 和 WithDriver 不会重新装配已注册 driver。
 //
 // 参数：
 //   - opts: 按顺序应用的 MySQL 构造选项。
@@ -295,18 +447,8 @@ func NewMySQL(opts ...MySQLOption) (*sql.DB, func(), error) {
 	driverLocker.Lock()
 	defer driverLocker.Unlock()
 
-	// 初始化默认配置选项。
-	options := &MySQLOptions{
-		dns:              defaultDSN,
-		poolIdleTime:     defaultPoolIdleTime,
-		poolMaxIdleTime:  defaultPoolMaxIdleTime,
-		poolMaxOpenConns: defaultPoolMaxOpenConns,
-		poolMaxIdleConns: defaultPoolMaxIdleConns,
-	}
-	// 应用用户提供的配置选项。
-	for _, opt := range opts {
-		opt(options)
-	}
+	// 初始化默认配置选项，并应用用户提供的配置选项。
+	options := newMySQLOptions(opts...)
 
 	var err error
 	if _, err = gosqldriver.ParseDSN(options.dns); nil != err {
@@ -332,7 +474,10 @@ func NewMySQL(opts ...MySQLOption) (*sql.DB, func(), error) {
 		}
 
 		// 创建并注册带有钩子的 MySQL 驱动。
-		originalDriver := gosqldriver.MySQLDriver{}
+		var originalDriver driver.Driver = gosqldriver.MySQLDriver{}
+		if nil != options.driver {
+			originalDriver = options.driver
+		}
 		kitDriver := kitdriver.NewKitDriver(originalDriver, options.hook)
 		sql.Register(driverName, kitDriver)
 	}
@@ -346,10 +491,10 @@ func NewMySQL(opts ...MySQLOption) (*sql.DB, func(), error) {
 		return nil, nil, err
 	} else {
 		// 配置连接池参数。
-		db.SetMaxOpenConns(options.poolMaxOpenConns)
-		db.SetMaxIdleConns(options.poolMaxIdleConns)
-		db.SetConnMaxIdleTime(options.poolMaxIdleTime)
-		db.SetConnMaxLifetime(options.poolIdleTime)
+		db.SetMaxOpenConns(options.maxOpenConns)
+		db.SetMaxIdleConns(options.maxIdleConns)
+		db.SetConnMaxIdleTime(options.connMaxIdleTime)
+		db.SetConnMaxLifetime(options.connMaxLifetime)
 	}
 
 	// 定义清理函数。
@@ -362,6 +507,29 @@ func NewMySQL(opts ...MySQLOption) (*sql.DB, func(), error) {
 	return db, cleanup, err
 }
 
+// newMySQLOptions 创建带默认值的构造配置，并按顺序应用传入的选项。
+//
+// 连接池默认最大打开 100 个连接、保留 10 个空闲连接，连接最长复用 3 分钟、空闲 1 分钟后关闭。
+//
+// 参数：
+//   - opts: 按顺序应用的 MySQL 构造选项。
+//
+// 返回：
+//   - *MySQLOptions: 应用选项后的构造配置。
+func newMySQLOptions(opts ...MySQLOption) *MySQLOptions {
+	options := &MySQLOptions{
+		dns:             defaultDSN,
+		connMaxLifetime: defaultConnMaxLifetime,
+		connMaxIdleTime: defaultConnMaxIdleTime,
+		maxOpenConns:    defaultMaxOpenConns,
+		maxIdleConns:    defaultMaxIdleConns,
+	}
+	for _, opt := range opts {
+		opt(options)
+	}
+	return options
+}
+
 // configureHooks 根据 MySQL 选项配置 HookManager。
 //
 // 参数：
@@ -372,7 +540,7 @@ func NewMySQL(opts ...MySQLOption) (*sql.DB, func(), error) {
 //   - error: 创建默认 logger 失败时返回错误；不需要默认 logger 或配置成功时返回 nil。
 func configureHooks(hook *kitdriver.HookManager, opts *MySQLOptions) error {
 	var err error
-	if opts.logError || opts.slowThreshold > 0 {
+	if opts.logError || opts.slowThreshold > 0 || opts.defaultQueryTimeout > 0 || opts.retryMax > 0 {
 		if nil == opts.logger {
 			opts.logger, err = newLogger()
 			if nil != err {
@@ -381,6 +549,19 @@ func configureHooks(hook *kitdriver.HookManager, opts *MySQLOptions) error {
 		}
 	}
 
+	// 配置重试钩子。
+	if opts.retryMax > 0 {
+		retryOpts := append([]kitdriver.RetryOption{kitdriver.WithRetryClassifier(IsTransientError)}, opts.retryOpts...)
+		h := kitdriver.NewHookRetry(opts.namespace, opts.logger, opts.retryMax, retryOpts...)
+		hook.AddHook(h)
+	}
+
+	// 配置默认查询超时钩子，先于日志钩子注册，使后续钩子观察到附加超时后的上下文。
+	if opts.defaultQueryTimeout > 0 {
+		h := kitdriver.NewHookQueryTimeout(opts.namespace, opts.logger, opts.defaultQueryTimeout)
+		hook.AddHook(h)
+	}
+
 	// 配置错误日志钩子。
 	if opts.logError {
 		h := kitdriver.NewHookLogError(opts.namespace, opts.logger)
@@ -5,14 +5,20 @@
 package mysql
 
 import (
+	"context"
 	"database/sql"
+	"database/sql/driver"
 	"errors"
 	"fmt"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	gosqldriver "github.com/go-sql-driver/mysql"
+
 	kitdriver "github.com/fsyyft-go/kit/database/sql/driver"
+	kittestdriver "github.com/fsyyft-go/kit/database/sql/testdriver"
 	kitlog "github.com/fsyyft-go/kit/log"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -29,6 +35,7 @@ func TestMySQLOptions_Apply(t *testing.T) {
 	require.NoError(t, err)
 
 	hook := kitdriver.NewHookManager()
+	fake := kittestdriver.New()
 
 	tests := []struct {
 		name        string
@@ -49,7 +56,7 @@ func TestMySQLOptions_Apply(t *testing.T) {
 			description: "验证 WithPoolIdleTime 将连接最大生命周期写入配置。",
 			giveOption:  WithPoolIdleTime(3 * time.Second),
 			assert: func(t *testing.T, got *MySQLOptions) {
-				assert.Equal(t, 3*time.Second, got.poolIdleTime)
+				assert.Equal(t, 3*time.Second, got.connMaxLifetime)
 			},
 		},
 		{
@@ -57,7 +64,7 @@ func TestMySQLOptions_Apply(t *testing.T) {
 			description: "验证 WithPoolMaxIdleTime 将连接最大空闲时间写入配置。",
 			giveOption:  WithPoolMaxIdleTime(4 * time.Second),
 			assert: func(t *testing.T, got *MySQLOptions) {
-				assert.Equal(t, 4*time.Second, got.poolMaxIdleTime)
+				assert.Equal(t, 4*time.Second, got.connMaxIdleTime)
 			},
 		},
 		{
@@ -65,7 +72,7 @@ func TestMySQLOptions_Apply(t *testing.T) {
 			description: "验证 WithPoolMaxOpenConns 将最大打开连接数写入配置。",
 			giveOption:  WithPoolMaxOpenConns(17),
 			assert: func(t *testing.T, got *MySQLOptions) {
-				assert.Equal(t, 17, got.poolMaxOpenConns)
+				assert.Equal(t, 17, got.maxOpenConns)
 			},
 		},
 		{
@@ -73,7 +80,39 @@ func TestMySQLOptions_Apply(t *testing.T) {
 			description: "验证 WithPoolMaxIdleConns 将最大空闲连接数写入配置。",
 			giveOption:  WithPoolMaxIdleConns(5),
 			assert: func(t *testing.T, got *MySQLOptions) {
-				assert.Equal(t, 5, got.poolMaxIdleConns)
+				assert.Equal(t, 5, got.maxIdleConns)
+			},
+		},
+		{
+			name:        "success/max-open-conns",
+			description: "验证 WithMaxOpenConns 将最大打开连接数写入配置。",
+			giveOption:  WithMaxOpenConns(23),
+			assert: func(t *testing.T, got *MySQLOptions) {
+				assert.Equal(t, 23, got.maxOpenConns)
+			},
+		},
+		{
+			name:        "success/max-idle-conns",
+			description: "验证 WithMaxIdleConns 将最大空闲连接数写入配置。",
+			giveOption:  WithMaxIdleConns(6),
+			assert: func(t *testing.T, got *MySQLOptions) {
+				assert.Equal(t, 6, got.maxIdleConns)
+			},
+		},
+		{
+			name:        "success/conn-max-lifetime",
+			description: "验证 WithConnMaxLifetime 将连接最大生命周期写入配置。",
+			giveOption:  WithConnMaxLifetime(5 * time.Minute),
+			assert: func(t *testing.T, got *MySQLOptions) {
+				assert.Equal(t, 5*time.Minute, got.connMaxLifetime)
+			},
+		},
+		{
+			name:        "success/conn-max-idle-time",
+			description: "验证 WithConnMaxIdleTime 将连接最大空闲时间写入配置。",
+			giveOption:  WithConnMaxIdleTime(30 * time.Second),
+			assert: func(t *testing.T, got *MySQLOptions) {
+				assert.Equal(t, 30*time.Second, got.connMaxIdleTime)
 			},
 		},
 		{
@@ -108,6 +147,31 @@ func TestMySQLOptions_Apply(t *testing.T) {
 				assert.Equal(t, 250*time.Millisecond, got.slowThreshold)
 			},
 		},
+		{
+			name:        "success/default-query-timeout",
+			description: "验证 WithDefaultQueryTimeout 将默认查询超时写入配置。",
+			giveOption:  WithDefaultQueryTimeout(3 * time.Second),
+			assert: func(t *testing.T, got *MySQLOptions) {
+				assert.Equal(t, 3*time.Second, got.defaultQueryTimeout)
+			},
+		},
+		{
+			name:        "success/retry",
+			description: "验证 WithRetry 将最大重试次数和附加配置写入配置。",
+			giveOption:  WithRetry(3, kitdriver.WithRetryExec(true)),
+			assert: func(t *testing.T, got *MySQLOptions) {
+				assert.Equal(t, 3, got.retryMax)
+				assert.Len(t, got.retryOpts, 1)
+			},
+		},
+		{
+			name:        "success/driver",
+			description: "验证 WithDriver 将底层驱动写入配置。",
+			giveOption:  WithDriver(fake),
+			assert: func(t *testing.T, got *MySQLOptions) {
+				assert.Same(t, fake, got.driver)
+			},
+		},
 		{
 			name:        "success/hook-manager",
 			description: "验证 WithHookManager 将外部钩子管理器写入配置。",
@@ -288,6 +352,20 @@ func TestConfigureHooks_LoggingOptions(t *testing.T) {
 			wantLogger:       true,
 			wantMinimumHooks: 2,
 		},
+		{
+			name:             "success/default-query-timeout",
+			description:      "验证启用默认查询超时时创建默认日志记录器并注册超时钩子。",
+			giveOptions:      MySQLOptions{namespace: testNamespace(t, "hooks-query-timeout"), defaultQueryTimeout: time.Second},
+			wantLogger:       true,
+			wantMinimumHooks: 1,
+		},
+		{
+			name:             "success/retry",
+			description:      "验证启用自动重试时创建默认日志记录器并注册重试钩子。",
+			giveOptions:      MySQLOptions{namespace: testNamespace(t, "hooks-retry"), retryMax: 2},
+			wantLogger:       true,
+			wantMinimumHooks: 1,
+		},
 	}
 
 	for _, tt := range tests {
@@ -313,6 +391,58 @@ func TestConfigureHooks_LoggingOptions(t *testing.T) {
 	}
 }
 
+// TestNewMySQLOptions_Defaults 验证未传入选项时连接池使用推荐的默认值，且选项按顺序覆盖默认值。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestNewMySQLOptions_Defaults(t *testing.T) {
+	tests := []struct {
+		name            string
+		description     string
+		giveOptions     []MySQLOption
+		wantMaxOpen     int
+		wantMaxIdle     int
+		wantMaxLifetime time.Duration
+		wantMaxIdleTime time.Duration
+	}{
+		{
+			name:            "success/defaults",
+			description:     "验证默认最大打开 100 个连接、保留 10 个空闲连接，连接最长复用 3 分钟、空闲 1 分钟。",
+			wantMaxOpen:     100,
+			wantMaxIdle:     10,
+			wantMaxLifetime: 3 * time.Minute,
+			wantMaxIdleTime: time.Minute,
+		},
+		{
+			name:        "success/override-in-order",
+			description: "验证废弃的别名与新选项写入同一字段，后传入的选项覆盖先传入的选项。",
+			giveOptions: []MySQLOption{
+				WithPoolMaxOpenConns(1),
+				WithMaxOpenConns(50),
+				WithConnMaxLifetime(time.Minute),
+				WithPoolIdleTime(0),
+			},
+			wantMaxOpen:     50,
+			wantMaxIdle:     10,
+			wantMaxLifetime: 0,
+			wantMaxIdleTime: time.Minute,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			got := newMySQLOptions(tt.giveOptions...)
+			assert.Equal(t, defaultDSN, got.dns)
+			assert.Equal(t, tt.wantMaxOpen, got.maxOpenConns)
+			assert.Equal(t, tt.wantMaxIdle, got.maxIdleConns)
+			assert.Equal(t, tt.wantMaxLifetime, got.connMaxLifetime)
+			assert.Equal(t, tt.wantMaxIdleTime, got.connMaxIdleTime)
+		})
+	}
+}
+
 // TestNewMySQL_ConnectionLifecycle 验证 NewMySQL 创建的数据库句柄和清理函数在无真实数据库服务时的稳定行为。
 //
 // 该测试通过表驱动用例覆盖默认连接池、定制连接池、外部钩子管理器和日志钩子配置，确保 sql.Open 懒连接语义下不访问外部 MySQL 服务。
@@ -332,7 +462,7 @@ func TestNewMySQL_ConnectionLifecycle(t *testing.T) {
 			giveOptions: func(t *testing.T) []MySQLOption {
 				return []MySQLOption{WithNamespace(testNamespace(t, "default-pool"))}
 			},
-			wantMaxOpenConns: defaultPoolMaxOpenConns,
+			wantMaxOpenConns: defaultMaxOpenConns,
 		},
 		{
 			name:        "success/custom-pool-and-dsn",
@@ -341,10 +471,10 @@ func TestNewMySQL_ConnectionLifecycle(t *testing.T) {
 				return []MySQLOption{
 					WithNamespace(testNamespace(t, "custom-pool")),
 					WithDSN("user:pass@tcp(127.0.0.1:3306)/unit?parseTime=true"),
-					WithPoolMaxOpenConns(7),
-					WithPoolMaxIdleConns(3),
-					WithPoolIdleTime(2 * time.Second),
-					WithPoolMaxIdleTime(time.Second),
+					WithMaxOpenConns(7),
+					WithMaxIdleConns(3),
+					WithConnMaxLifetime(2 * time.Second),
+					WithConnMaxIdleTime(time.Second),
 				}
 			},
 			wantMaxOpenConns: 7,
@@ -358,7 +488,7 @@ func TestNewMySQL_ConnectionLifecycle(t *testing.T) {
 					WithHookManager(kitdriver.NewHookManager()),
 				}
 			},
-			wantMaxOpenConns: defaultPoolMaxOpenConns,
+			wantMaxOpenConns: defaultMaxOpenConns,
 		},
 		{
 			name:        "success/logging-hooks",
@@ -370,7 +500,7 @@ func TestNewMySQL_ConnectionLifecycle(t *testing.T) {
 					WithSlowThreshold(time.Millisecond),
 				}
 			},
-			wantMaxOpenConns: defaultPoolMaxOpenConns,
+			wantMaxOpenConns: defaultMaxOpenConns,
 		},
 	}
 
@@ -713,6 +843,112 @@ func TestNewMySQL_RegisteredDriverReuse(t *testing.T) {
 	assertDatabaseClosed(t, secondDB)
 }
 
+// TestNewMySQL_WithTestDriver 验证 WithDriver 接入内存驱动后，NewMySQL 配置的 Hook 链能够被完整观察。
+//
+// 该测试使用 testdriver 模拟慢查询，确保默认查询超时 Hook 在不依赖真实 MySQL 服务时也能终止查询并写入共享数据。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestNewMySQL_WithTestDriver(t *testing.T) {
+	fake := kittestdriver.New()
+	fake.ExpectExec("INSERT INTO users").WillReturnResult(9, 1)
+	fake.ExpectQuery("SELECT SLEEP").WillDelay(time.Minute)
+
+	recorder := kittestdriver.NewRecorder()
+	hook := kitdriver.NewHookManager()
+	hook.AddHook(recorder)
+	hook.AddHook(kitdriver.NewHookQueryTimeout("", nil, 20*time.Millisecond))
+
+	db, cleanup, err := NewMySQL(
+		WithNamespace(uniqueNamespace(t, "testdriver")),
+		WithDriver(fake),
+		WithHookManager(hook),
+	)
+	require.NoError(t, err)
+	defer cleanup()
+
+	result, err := db.Exec("INSERT INTO users (name) VALUES (?)", "kit")
+	require.NoError(t, err)
+	id, err := result.LastInsertId()
+	require.NoError(t, err)
+	assert.Equal(t, int64(9), id)
+
+	_, err = db.Query("SELECT SLEEP(60)")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	queries := recorder.Filter(kitdriver.OpQuery)
+	require.Len(t, queries, 1)
+	assert.True(t, queries[0].TimedOut())
+	timeout, ok := queries[0].GetHookValue(kitdriver.HookValueQueryTimeout)
+	assert.True(t, ok)
+	assert.Equal(t, 20*time.Millisecond, timeout)
+
+	execs := recorder.Filter(kitdriver.OpExec)
+	require.Len(t, execs, 1)
+	assert.Equal(t, "kit", execs[0].Args()[0].Value)
+}
+
+// TestIsTransientError 验证 MySQL 瞬时错误的识别。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestIsTransientError(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		giveErr     error
+		want        bool
+	}{
+		{name: "success/deadlock", description: "验证死锁错误为瞬时错误。", giveErr: &gosqldriver.MySQLError{Number: 1213}, want: true},
+		{name: "success/lock-wait-timeout", description: "验证锁等待超时为瞬时错误。", giveErr: &gosqldriver.MySQLError{Number: 1205}, want: true},
+		{name: "success/bad-conn", description: "验证 ErrBadConn 为瞬时错误。", giveErr: driver.ErrBadConn, want: true},
+		{name: "success/wrapped", description: "验证包装后的死锁错误仍被识别。", giveErr: fmt.Errorf("update: %w", &gosqldriver.MySQLError{Number: 1213}), want: true},
+		{name: "boundary/nil", description: "验证 nil 不是瞬时错误。"},
+		{name: "error/duplicate-entry", description: "验证唯一键冲突不是瞬时错误。", giveErr: &gosqldriver.MySQLError{Number: 1062}},
+		{name: "error/other", description: "验证普通错误不是瞬时错误。", giveErr: errors.New("boom")},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			assert.Equal(t, tt.want, IsTransientError(tt.giveErr))
+		})
+	}
+}
+
+// TestNewMySQL_WithRetry 验证 WithRetry 安装的重试 Hook 在查询遇到死锁时自动重试并在耗尽后返回错误。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestNewMySQL_WithRetry(t *testing.T) {
+	logger, err := kitlog.NewLogger()
+	require.NoError(t, err)
+
+	deadlock := &gosqldriver.MySQLError{Number: 1213, Message: "Deadlock found when trying to get lock"}
+	fake := kittestdriver.New()
+	query := fake.ExpectQuery("SELECT balance").WillReturnError(deadlock)
+	exec := fake.ExpectExec("UPDATE accounts").WillReturnError(deadlock)
+
+	db, cleanup, err := NewMySQL(
+		WithNamespace(uniqueNamespace(t, "retry")),
+		WithDriver(fake),
+		WithLogger(logger),
+		WithRetry(2, kitdriver.WithRetryBackoff(time.Millisecond, time.Millisecond)),
+	)
+	require.NoError(t, err)
+	defer cleanup()
+
+	_, err = db.Query("SELECT balance FROM accounts")
+	require.ErrorIs(t, err, deadlock)
+	assert.Equal(t, 3, query.Calls())
+
+	_, err = db.Exec("UPDATE accounts SET balance = 0")
+	require.ErrorIs(t, err, deadlock)
+	assert.Equal(t, 1, exec.Calls())
+}
+
 // testNamespace 构造当前测试进程内稳定唯一的 MySQL 驱动命名空间。
 //
 // 该辅助函数将测试名与语义后缀组合，并替换不利于诊断的分隔符，避免全局 sql 驱动注册表在不同用例间发生命名冲突。
@@ -730,6 +966,26 @@ func testNamespace(t *testing.T, suffix string) string {
 	return strings.ToLower(name + "-" + suffix)
 }
 
+// namespaceSeq 为 uniqueNamespace 生成递增序号。
+var namespaceSeq atomic.Int64
+
+// uniqueNamespace 构造每次调用都不同的 MySQL 驱动命名空间。
+//
+// NewMySQL 只在命名空间首次注册驱动时采用 WithDriver 与 Hook 配置，使用 -count 重复运行时沿用 testNamespace
+// 会复用上一轮注册的驱动；依赖本轮 WithDriver 的测试应使用该辅助函数。
+//
+// 参数：
+//   - t: 测试上下文，用于标记辅助函数调用栈并读取当前测试名。
+//   - suffix: 命名空间的语义后缀，用于区分同一测试内的不同驱动配置。
+//
+// 返回：
+//   - string: 附加递增序号的命名空间。
+func uniqueNamespace(t *testing.T, suffix string) string {
+	t.Helper()
+
+	return fmt.Sprintf("%s-%d", testNamespace(t, suffix), namespaceSeq.Add(1))
+}
+
 // assertDatabaseClosed 验证数据库句柄已经被关闭。
 //
 // 该辅助函数通过关闭后的 Ping 行为确认 cleanup 生效；由于 database/sql 在关闭状态下会直接返回错误，因此不会访问外部数据库服务。
@@ -759,7 +1015,7 @@ func countHookManagerHooks(t *testing.T, hook *kitdriver.HookManager) int {
 	t.Helper()
 
 	manager := fmt.Sprintf("%#v", hook)
-	return strings.Count(manager, "HookLog")
+	return strings.Count(manager, "HookLog") + strings.Count(manager, "HookQueryTimeout") + strings.Count(manager, "HookRetry")
 }
 
 // replaceSQLOpen 临时替换 mysql 包内部的 sql.Open 调用入口。
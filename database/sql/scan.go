@@ -0,0 +1,382 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package sql
+
+import (
+	"context"
+	stdsql "database/sql"
+	"encoding/json"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+type (
+	// Queryer 是可以执行查询的数据库句柄，*sql.DB、*sql.Tx 与 *sql.Conn 均满足该接口。
+	Queryer interface {
+		// QueryContext 执行查询并返回结果集。
+		//
+		// 参数：
+		//   - ctx: 查询上下文。
+		//   - query: SQL 语句。
+		//   - args: SQL 参数。
+		//
+		// 返回：
+		//   - *sql.Rows: 结果集。
+		//   - error: 查询失败时返回错误。
+		QueryContext(ctx context.Context, query string, args ...any) (*stdsql.Rows, error)
+	}
+
+	// rowScanner 按列类型把结果集的每一行转换为通用值。
+	rowScanner struct {
+		// rows 是正在读取的结果集。
+		rows *stdsql.Rows
+		// columns 是结果集列名。
+		columns []string
+		// types 是每列的数据库类型名，已转换为大写。
+		types []string
+		// last 标记每列是否为同名列中的最后一列，只有最后一列写入结果。
+		last []bool
+		// values 是 Scan 的目标值。
+		values []any
+		// dest 是指向 values 各元素的指针。
+		dest []any
+	}
+)
+
+// QueryToMaps 执行查询，并把每一行转换为以列名为键的 map。
+//
+// 值按列的数据库类型规范化，规则见 ScanMaps。结果集会被完整读入内存，不适合导出大量数据，
+// 大结果集应使用 QueryToJSON 逐行写出。
+//
+// 参数：
+//   - ctx: 查询上下文。
+//   - db: 执行查询的数据库句柄。
+//   - query: SQL 语句。
+//   - args: SQL 参数。
+//
+// 返回：
+//   - []map[string]any: 按结果集顺序排列的行；没有数据时为空切片。
+//   - error: 查询、读取或扫描失败时返回错误。
+func QueryToMaps(ctx context.Context, db Queryer, query string, args ...any) ([]map[string]any, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if nil != err {
+		return nil, err
+	}
+	return ScanMaps(rows)
+}
+
+// QueryToJSON 执行查询，并把每一行以 NDJSON 格式（每行一个 JSON 对象）写入 w。
+//
+// 行按读取顺序逐行写出，不会把结果集整体读入内存，适合数据导出和调试接口。JSON 对象的键顺序与列顺序一致，
+// 值的规范化规则见 ScanMaps。
+//
+// 参数：
+//   - ctx: 查询上下文。
+//   - db: 执行查询的数据库句柄。
+//   - w: NDJSON 输出目标。
+//   - query: SQL 语句。
+//   - args: SQL 参数。
+//
+// 返回：
+//   - int: 成功写出的行数。
+//   - error: 查询、读取、扫描、编码或写入失败时返回错误。
+func QueryToJSON(ctx context.Context, db Queryer, w io.Writer, query string, args ...any) (int, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if nil != err {
+		return 0, err
+	}
+	return ScanJSON(rows, w)
+}
+
+// ScanMaps 读取结果集的所有行并转换为以列名为键的 map，读取完成后关闭 rows。
+//
+// 值按列的数据库类型（ColumnType.DatabaseTypeName）规范化：
+//   - NULL 转换为 nil。
+//   - DECIMAL、NUMERIC 类型转换为 json.Number，保留原始精度。
+//   - 以文本形式返回的整数与浮点类型分别转换为 int64 与 float64，超出 int64 范围的无符号整数转换为 json.Number。
+//   - 其他 []byte 值是合法 UTF-8 时转换为 string，否则保留为 []byte。
+//   - time.Time 等驱动已解析的值保持不变。
+//
+// 存在同名列（例如多表连接）时以最后一列为准。
+//
+// 参数：
+//   - rows: 待读取的结果集。
+//
+// 返回：
+//   - []map[string]any: 按结果集顺序排列的行；没有数据时为空切片。
+//   - error: 读取或扫描失败时返回错误。
+func ScanMaps(rows *stdsql.Rows) ([]map[string]any, error) {
+	defer func() { _ = rows.Close() }()
+
+	scanner, err := newRowScanner(rows)
+	if nil != err {
+		return nil, err
+	}
+
+	result := make([]map[string]any, 0)
+	for rows.Next() {
+		if err := scanner.scan(); nil != err {
+			return nil, err
+		}
+		row := make(map[string]any, len(scanner.columns))
+		for i, column := range scanner.columns {
+			if scanner.last[i] {
+				row[column] = scanner.value(i)
+			}
+		}
+		result = append(result, row)
+	}
+	if err := rows.Err(); nil != err {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ScanJSON 逐行读取结果集并以 NDJSON 格式写入 w，读取完成后关闭 rows。
+//
+// 值的规范化规则见 ScanMaps；time.Time 编码为 RFC 3339 字符串，json.Number 按数字原样输出，
+// []byte 编码为 Base64 字符串，NaN 与正负无穷编码为字符串。
+//
+// 参数：
+//   - rows: 待读取的结果集。
+//   - w: NDJSON 输出目标。
+//
+// 返回：
+//   - int: 成功写出的行数。
+//   - error: 读取、扫描、编码或写入失败时返回错误。
+func ScanJSON(rows *stdsql.Rows, w io.Writer) (int, error) {
+	defer func() { _ = rows.Close() }()
+
+	scanner, err := newRowScanner(rows)
+	if nil != err {
+		return 0, err
+	}
+
+	keys := make([][]byte, len(scanner.columns))
+	for i, column := range scanner.columns {
+		if keys[i], err = json.Marshal(column); nil != err {
+			return 0, err
+		}
+	}
+
+	count := 0
+	var line []byte
+	for rows.Next() {
+		if err := scanner.scan(); nil != err {
+			return count, err
+		}
+
+		line = append(line[:0], '{')
+		first := true
+		for i := range scanner.columns {
+			if !scanner.last[i] {
+				continue
+			}
+			value, err := marshalJSONValue(scanner.value(i))
+			if nil != err {
+				return count, err
+			}
+			if !first {
+				line = append(line, ',')
+			}
+			first = false
+			line = append(line, keys[i]...)
+			line = append(line, ':')
+			line = append(line, value...)
+		}
+		line = append(line, '}', '\n')
+
+		if _, err := w.Write(line); nil != err {
+			return count, err
+		}
+		count++
+	}
+	if err := rows.Err(); nil != err {
+		return count, err
+	}
+	return count, nil
+}
+
+// newRowScanner 读取结果集的列信息并创建 rowScanner。
+//
+// 参数：
+//   - rows: 待读取的结果集。
+//
+// 返回：
+//   - *rowScanner: 行扫描器。
+//   - error: 获取列信息失败时返回错误。
+func newRowScanner(rows *stdsql.Rows) (*rowScanner, error) {
+	columns, err := rows.Columns()
+	if nil != err {
+		return nil, err
+	}
+	columnTypes, err := rows.ColumnTypes()
+	if nil != err {
+		return nil, err
+	}
+
+	s := &rowScanner{
+		rows:    rows,
+		columns: columns,
+		types:   make([]string, len(columns)),
+		last:    make([]bool, len(columns)),
+		values:  make([]any, len(columns)),
+		dest:    make([]any, len(columns)),
+	}
+	seen := make(map[string]struct{}, len(columns))
+	for i := len(columns) - 1; i >= 0; i-- {
+		if _, ok := seen[columns[i]]; !ok {
+			seen[columns[i]] = struct{}{}
+			s.last[i] = true
+		}
+		if i < len(columnTypes) && nil != columnTypes[i] {
+			s.types[i] = strings.ToUpper(columnTypes[i].DatabaseTypeName())
+		}
+		s.dest[i] = &s.values[i]
+	}
+	return s, nil
+}
+
+// scan 把当前行读入 values。
+//
+// 参数：无。
+//
+// 返回：
+//   - error: 扫描失败时返回错误。
+func (s *rowScanner) scan() error {
+	return s.rows.Scan(s.dest...)
+}
+
+// value 返回当前行第 i 列规范化后的值。
+//
+// 参数：
+//   - i: 列下标。
+//
+// 返回：
+//   - any: 规范化后的值。
+func (s *rowScanner) value(i int) any {
+	return normalizeValue(s.values[i], s.types[i])
+}
+
+// normalizeValue 按数据库类型规范化扫描得到的值。
+//
+// 参数：
+//   - value: 扫描得到的值。
+//   - dbType: 大写的数据库类型名，未知时为空字符串。
+//
+// 返回：
+//   - any: 规范化后的值，规则见 ScanMaps。
+func normalizeValue(value any, dbType string) any {
+	var text string
+	switch v := value.(type) {
+	case []byte:
+		text = string(v)
+	case string:
+		text = v
+	default:
+		return value
+	}
+
+	switch {
+	case isDecimalType(dbType):
+		if isJSONNumber(text) {
+			return json.Number(text)
+		}
+	case isIntegerType(dbType):
+		if n, err := strconv.ParseInt(text, 10, 64); nil == err {
+			return n
+		}
+		if _, err := strconv.ParseUint(text, 10, 64); nil == err {
+			return json.Number(text)
+		}
+	case isFloatType(dbType):
+		if f, err := strconv.ParseFloat(text, 64); nil == err {
+			return f
+		}
+	}
+
+	if b, ok := value.([]byte); ok && !utf8.Valid(b) {
+		return b
+	}
+	return text
+}
+
+// isDecimalType 判断数据库类型是否为定点小数。
+//
+// 参数：
+//   - dbType: 大写的数据库类型名。
+//
+// 返回：
+//   - bool: DECIMAL 或 NUMERIC 类型返回 true。
+func isDecimalType(dbType string) bool {
+	return strings.Contains(dbType, "DECIMAL") || strings.Contains(dbType, "NUMERIC")
+}
+
+// isIntegerType 判断数据库类型是否为整数。
+//
+// 参数：
+//   - dbType: 大写的数据库类型名。
+//
+// 返回：
+//   - bool: TINYINT、SMALLINT、MEDIUMINT、INT、INTEGER、BIGINT 等类型返回 true。
+func isIntegerType(dbType string) bool {
+	return strings.Contains(dbType, "INT") && !strings.Contains(dbType, "INTERVAL") && !strings.Contains(dbType, "POINT")
+}
+
+// isFloatType 判断数据库类型是否为浮点数。
+//
+// 参数：
+//   - dbType: 大写的数据库类型名。
+//
+// 返回：
+//   - bool: FLOAT、DOUBLE、REAL 类型返回 true。
+func isFloatType(dbType string) bool {
+	return strings.Contains(dbType, "FLOAT") || strings.Contains(dbType, "DOUBLE") || "REAL" == dbType
+}
+
+// isJSONNumber 判断文本是否为合法的 JSON 数字。
+//
+// 参数：
+//   - text: 待判断的文本。
+//
+// 返回：
+//   - bool: 可以作为 json.Number 原样输出时返回 true。
+func isJSONNumber(text string) bool {
+	if "" == text || !('-' == text[0] || ('0' <= text[0] && text[0] <= '9')) {
+		return false
+	}
+	if last := text[len(text)-1]; last < '0' || last > '9' {
+		return false
+	}
+	return json.Valid([]byte(text))
+}
+
+// marshalJSONValue 把规范化后的值编码为 JSON。
+//
+// 参数：
+//   - value: 规范化后的值。
+//
+// 返回：
+//   - []byte: JSON 编码结果。
+//   - error: 值无法编码时返回错误。
+func marshalJSONValue(value any) ([]byte, error) {
+	switch v := value.(type) {
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return json.Marshal(strconv.FormatFloat(v, 'g', -1, 64))
+		}
+	case float32:
+		if math.IsNaN(float64(v)) || math.IsInf(float64(v), 0) {
+			return json.Marshal(strconv.FormatFloat(float64(v), 'g', -1, 32))
+		}
+	case time.Time:
+		return json.Marshal(v.Format(time.RFC3339Nano))
+	}
+	return json.Marshal(value)
+}
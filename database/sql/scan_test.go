@@ -0,0 +1,192 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package sql
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	kittestdriver "github.com/fsyyft-go/kit/database/sql/testdriver"
+)
+
+// failWriter 是总是写入失败的 io.Writer。
+type failWriter struct{}
+
+// Write 返回固定错误。
+func (failWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+// TestQueryToMaps 验证查询结果按列类型规范化为 map。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestQueryToMaps(t *testing.T) {
+	created := time.Date(2025, 1, 2, 3, 4, 5, 6, time.UTC)
+	d := kittestdriver.New()
+	d.ExpectQuery("FROM orders").
+		WillReturnRows(
+			[]string{"id", "amount", "note", "created_at", "raw", "big", "ratio", "id"},
+			[]driver.Value{int64(1), []byte("12.50"), []byte("首单"), created, []byte{0xff, 0xfe}, []byte("18446744073709551615"), []byte("0.25"), int64(10)},
+			[]driver.Value{int64(2), nil, nil, nil, nil, []byte("42"), []byte("n/a"), int64(20)},
+		).
+		WillReturnColumnTypes("BIGINT", "DECIMAL", "VARCHAR", "DATETIME", "BLOB", "UNSIGNED BIGINT", "double", "INT")
+	db, _ := kittestdriver.NewDB(d)
+	defer func() { _ = db.Close() }()
+
+	rows, err := QueryToMaps(context.Background(), db, "SELECT * FROM orders WHERE id > ?", 0)
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+
+	assert.Equal(t, map[string]any{
+		"id":         int64(10),
+		"amount":     json.Number("12.50"),
+		"note":       "首单",
+		"created_at": created,
+		"raw":        []byte{0xff, 0xfe},
+		"big":        json.Number("18446744073709551615"),
+		"ratio":      0.25,
+	}, rows[0])
+	assert.Equal(t, map[string]any{
+		"id":         int64(20),
+		"amount":     nil,
+		"note":       nil,
+		"created_at": nil,
+		"raw":        nil,
+		"big":        int64(42),
+		"ratio":      "n/a",
+	}, rows[1])
+}
+
+// TestQueryToMaps_Errors 验证空结果与查询失败。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestQueryToMaps_Errors(t *testing.T) {
+	queryErr := errors.New("table not found")
+
+	tests := []struct {
+		name        string
+		description string
+		setup       func(d *kittestdriver.Driver)
+		wantErr     error
+		wantRows    int
+	}{
+		{
+			name:        "boundary/empty",
+			description: "验证没有数据时返回空切片而不是 nil。",
+			setup: func(d *kittestdriver.Driver) {
+				d.ExpectQuery("SELECT").WillReturnRows([]string{"id"})
+			},
+		},
+		{
+			name:        "error/query",
+			description: "验证查询错误原样返回。",
+			setup: func(d *kittestdriver.Driver) {
+				d.ExpectQuery("SELECT").WillReturnError(queryErr)
+			},
+			wantErr: queryErr,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			d := kittestdriver.New()
+			tt.setup(d)
+			db, _ := kittestdriver.NewDB(d)
+			defer func() { _ = db.Close() }()
+
+			rows, err := QueryToMaps(context.Background(), db, "SELECT id FROM t")
+			if nil != tt.wantErr {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, rows)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotNil(t, rows)
+			assert.Len(t, rows, tt.wantRows)
+		})
+	}
+}
+
+// TestQueryToJSON 验证 NDJSON 输出的键顺序、类型编码与错误处理。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestQueryToJSON(t *testing.T) {
+	created := time.Date(2025, 1, 2, 3, 4, 5, 0, time.FixedZone("CST", 8*3600))
+	d := kittestdriver.New()
+	d.ExpectQuery("FROM orders").
+		WillReturnRows(
+			[]string{"id", "amount", "created_at", "raw", "score", "ok", "tag"},
+			[]driver.Value{int64(1), []byte("-0.001"), created, []byte{0x00, 0xff}, math.Inf(1), true, []byte(`a"b`)},
+			[]driver.Value{int64(2), []byte("1e3"), nil, nil, 1.5, false, nil},
+		).
+		WillReturnColumnTypes("BIGINT", "NUMERIC", "TIMESTAMP", "VARBINARY", "DOUBLE", "BOOL", "TEXT")
+	d.ExpectQuery("FROM broken").WillReturnError(errors.New("broken"))
+	db, _ := kittestdriver.NewDB(d)
+	defer func() { _ = db.Close() }()
+
+	var buf bytes.Buffer
+	n, err := QueryToJSON(context.Background(), db, &buf, "SELECT * FROM orders")
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t,
+		`{"id":1,"amount":-0.001,"created_at":"2025-01-02T03:04:05+08:00","raw":"AP8=","score":"+Inf","ok":true,"tag":"a\"b"}`+"\n"+
+			`{"id":2,"amount":1e3,"created_at":null,"raw":null,"score":1.5,"ok":false,"tag":null}`+"\n",
+		buf.String())
+
+	n, err = QueryToJSON(context.Background(), db, failWriter{}, "SELECT * FROM orders")
+	assert.EqualError(t, err, "disk full")
+	assert.Equal(t, 0, n)
+
+	n, err = QueryToJSON(context.Background(), db, &buf, "SELECT * FROM broken")
+	assert.EqualError(t, err, "broken")
+	assert.Equal(t, 0, n)
+}
+
+// TestNormalizeValue 验证按数据库类型规范化单个值。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestNormalizeValue(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		value       any
+		dbType      string
+		want        any
+	}{
+		{name: "success/decimal-string", description: "验证字符串形式的定点数转换为 json.Number。", value: "3.14", dbType: "DECIMAL", want: json.Number("3.14")},
+		{name: "boundary/decimal-trailing-space", description: "验证带空白的定点数不会转换为 json.Number。", value: []byte("3.14 "), dbType: "DECIMAL", want: "3.14 "},
+		{name: "boundary/decimal-invalid", description: "验证非数字文本保留为字符串。", value: []byte("NaN"), dbType: "NUMERIC", want: "NaN"},
+		{name: "success/int-text", description: "验证文本形式的整数转换为 int64。", value: []byte("-7"), dbType: "SMALLINT", want: int64(-7)},
+		{name: "boundary/point-not-int", description: "验证 POINT 类型不按整数解析。", value: []byte("1"), dbType: "POINT", want: "1"},
+		{name: "success/float-text", description: "验证文本形式的浮点数转换为 float64。", value: []byte("2.5"), dbType: "FLOAT", want: 2.5},
+		{name: "success/unknown-type", description: "验证未知类型的 UTF-8 文本转换为 string。", value: []byte("abc"), dbType: "", want: "abc"},
+		{name: "success/passthrough", description: "验证驱动已解析的值保持不变。", value: int64(5), dbType: "DECIMAL", want: int64(5)},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			assert.Equal(t, tt.want, normalizeValue(tt.value, tt.dbType))
+		})
+	}
+}
@@ -0,0 +1,15 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+// Package testdriver 提供用于单元测试的内存 database/sql 驱动和 Hook 记录器。
+//
+// Driver 实现 driver.Driver，按注册顺序匹配 ExpectExec 和 ExpectQuery 设置的
+// SQL 片段，返回预设的执行结果、结果集或错误；FailOn 可以让连接、Ping、事务等
+// 操作失败，WillDelay 可以模拟遵守上下文取消的慢查询。Recorder 实现
+// kit database/sql/driver 的 Hook 接口，记录每次操作完成后的 HookContext。
+//
+// NewDB 将 Driver 与 Hook 链通过 NewKitDriver 包装后返回 *sql.DB，无需全局注册
+// 驱动名称；需要验证 kit mysql 构造器时，可配合 mysql.WithDriver 使用。
+// 本包只用于测试，不解析 SQL，也不模拟事务隔离语义。
+package testdriver
@@ -0,0 +1,114 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package testdriver
+
+import (
+	"sync"
+
+	kitdriver "github.com/fsyyft-go/kit/database/sql/driver"
+)
+
+var (
+	_ kitdriver.Hook = (*Recorder)(nil)
+)
+
+type (
+	// Recorder 是记录每次操作 HookContext 的 Hook。
+	//
+	// Recorder 在 After 阶段保存 HookContext，此时结果、错误和耗时都已写入。
+	// Recorder 的方法是并发安全的。
+	Recorder struct {
+		mu       sync.Mutex
+		contexts []*kitdriver.HookContext
+	}
+)
+
+// NewRecorder 创建一个空的 Recorder。
+//
+// 返回：
+//   - *Recorder: 新的记录器。
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+// Before 在操作执行前不做任何处理。
+//
+// 参数：
+//   - ctx: 当前操作的 HookContext。
+//
+// 返回：
+//   - error: 始终返回 nil。
+func (r *Recorder) Before(ctx *kitdriver.HookContext) error {
+	return nil
+}
+
+// After 记录操作完成后的 HookContext。
+//
+// 参数：
+//   - ctx: 当前操作的 HookContext。
+//
+// 返回：
+//   - error: 始终返回 nil。
+func (r *Recorder) After(ctx *kitdriver.HookContext) error {
+	r.mu.Lock()
+	r.contexts = append(r.contexts, ctx)
+	r.mu.Unlock()
+	return nil
+}
+
+// Contexts 返回已记录的 HookContext。
+//
+// 返回：
+//   - []*kitdriver.HookContext: 按完成顺序排列的 HookContext 副本切片。
+func (r *Recorder) Contexts() []*kitdriver.HookContext {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	contexts := make([]*kitdriver.HookContext, len(r.contexts))
+	copy(contexts, r.contexts)
+	return contexts
+}
+
+// Ops 返回已记录操作的类型。
+//
+// 返回：
+//   - []kitdriver.OpType: 按完成顺序排列的操作类型。
+func (r *Recorder) Ops() []kitdriver.OpType {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ops := make([]kitdriver.OpType, 0, len(r.contexts))
+	for _, ctx := range r.contexts {
+		ops = append(ops, ctx.OpType())
+	}
+	return ops
+}
+
+// Filter 返回指定类型操作的 HookContext。
+//
+// 参数：
+//   - ops: 需要保留的操作类型。
+//
+// 返回：
+//   - []*kitdriver.HookContext: 按完成顺序排列的匹配记录。
+func (r *Recorder) Filter(ops ...kitdriver.OpType) []*kitdriver.HookContext {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var contexts []*kitdriver.HookContext
+	for _, ctx := range r.contexts {
+		for _, op := range ops {
+			if ctx.OpType() == op {
+				contexts = append(contexts, ctx)
+				break
+			}
+		}
+	}
+	return contexts
+}
+
+// Reset 清空已记录的 HookContext。
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	r.contexts = nil
+	r.mu.Unlock()
+}
@@ -0,0 +1,705 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package testdriver
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	kitdriver "github.com/fsyyft-go/kit/database/sql/driver"
+)
+
+// ErrNoExpectation 表示 Exec 或 Query 的 SQL 没有匹配任何预设期望。
+//
+// 调用方可以使用 errors.Is 判断该错误；错误信息中包含未匹配的 SQL。
+var ErrNoExpectation = errors.New("testdriver: no expectation matches query")
+
+// 以下断言确保测试驱动满足 database/sql/driver 相关接口。
+var (
+	_ driver.Driver             = (*Driver)(nil)
+	_ driver.Conn               = (*conn)(nil)
+	_ driver.ConnPrepareContext = (*conn)(nil)
+	_ driver.ExecerContext      = (*conn)(nil)
+	_ driver.QueryerContext     = (*conn)(nil)
+	_ driver.Pinger             = (*conn)(nil)
+	_ driver.ConnBeginTx        = (*conn)(nil)
+	_ driver.NamedValueChecker  = (*conn)(nil)
+	_ driver.StmtExecContext    = (*stmt)(nil)
+	_ driver.StmtQueryContext   = (*stmt)(nil)
+
+	_ driver.RowsColumnTypeDatabaseTypeName = (*rows)(nil)
+)
+
+type (
+	// Driver 是可编排结果的内存数据库驱动。
+	//
+	// Driver 的方法是并发安全的，可以在 *sql.DB 使用期间继续追加期望。
+	Driver struct {
+		mu           sync.Mutex
+		expectations []*Expectation
+		failures     map[kitdriver.OpType]error
+		opened       int
+		closed       int
+	}
+
+	// Expectation 描述一条 SQL 期望及其返回值。
+	//
+	// Expectation 由 Driver.ExpectExec 或 Driver.ExpectQuery 创建，通过链式方法设置返回值。
+	// 期望可以被重复匹配，Calls 返回已匹配的次数。
+	Expectation struct {
+		mu      sync.Mutex
+		query   bool
+		pattern string
+		result  driver.Result
+		columns []string
+		types   []string
+		values  [][]driver.Value
+		err     error
+		delay   time.Duration
+		calls   int
+		args    [][]driver.NamedValue
+	}
+
+	// conn 是 Driver 打开的内存连接。
+	conn struct {
+		driver *Driver
+	}
+
+	// stmt 是内存预处理语句，执行时按预处理 SQL 匹配期望。
+	stmt struct {
+		conn  *conn
+		query string
+	}
+
+	// tx 是内存事务，只记录提交和回滚并返回预设错误。
+	tx struct {
+		conn *conn
+	}
+
+	// rows 是内存结果集。
+	rows struct {
+		columns []string
+		types   []string
+		values  [][]driver.Value
+		pos     int
+	}
+
+	// connector 将 driver.Driver 适配为 driver.Connector，供 sql.OpenDB 使用。
+	connector struct {
+		driver driver.Driver
+	}
+)
+
+// New 创建一个没有任何期望的内存驱动。
+//
+// 返回：
+//   - *Driver: 新的内存驱动。
+func New() *Driver {
+	return &Driver{
+		failures: make(map[kitdriver.OpType]error),
+	}
+}
+
+// NewDB 使用 Hook 链包装内存驱动并返回 *sql.DB。
+//
+// NewDB 通过 sql.OpenDB 打开数据库，不会向 database/sql 全局注册驱动名称。返回的
+// Recorder 最先注册，After 阶段按注册逆序执行，因此 Recorder 在其他 Hook 之后记录，
+// 能观察到其他 Hook 写入的共享数据。
+//
+// 参数：
+//   - d: 内存驱动。
+//   - hooks: 额外的 Hook，按传入顺序注册。
+//
+// 返回：
+//   - *sql.DB: 包装后的数据库句柄，由调用方负责关闭。
+//   - *Recorder: 记录所有操作 HookContext 的记录器。
+func NewDB(d *Driver, hooks ...kitdriver.Hook) (*sql.DB, *Recorder) {
+	recorder := NewRecorder()
+	manager := kitdriver.NewHookManager()
+	manager.AddHook(recorder)
+	for _, hook := range hooks {
+		manager.AddHook(hook)
+	}
+	return sql.OpenDB(&connector{driver: kitdriver.NewKitDriver(d, manager)}), recorder
+}
+
+// ExpectExec 追加一条 Exec 期望，匹配包含 pattern 的 SQL。
+//
+// 默认返回 LastInsertId 为 0、RowsAffected 为 0 的结果。
+//
+// 参数：
+//   - pattern: SQL 片段；空字符串匹配任意 SQL。
+//
+// 返回：
+//   - *Expectation: 新追加的期望，可继续链式设置返回值。
+func (d *Driver) ExpectExec(pattern string) *Expectation {
+	return d.expect(false, pattern)
+}
+
+// ExpectQuery 追加一条 Query 期望，匹配包含 pattern 的 SQL。
+//
+// 默认返回没有列和行的空结果集。
+//
+// 参数：
+//   - pattern: SQL 片段；空字符串匹配任意 SQL。
+//
+// 返回：
+//   - *Expectation: 新追加的期望，可继续链式设置返回值。
+func (d *Driver) ExpectQuery(pattern string) *Expectation {
+	return d.expect(true, pattern)
+}
+
+// FailOn 让指定类型的操作返回 err。
+//
+// 支持 OpConnect、OpPing、OpBegin、OpCommit、OpRollback、OpPrepare 和 OpStmtClose；
+// Exec 与 Query 类操作请使用 Expectation.WillReturnError。err 为 nil 时取消设置。
+//
+// 参数：
+//   - op: 操作类型。
+//   - err: 操作返回的错误。
+func (d *Driver) FailOn(op kitdriver.OpType, err error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if nil == err {
+		delete(d.failures, op)
+	} else {
+		d.failures[op] = err
+	}
+}
+
+// Reset 清除所有期望和失败设置。
+func (d *Driver) Reset() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.expectations = nil
+	d.failures = make(map[kitdriver.OpType]error)
+}
+
+// OpenConns 返回当前未关闭的连接数。
+//
+// 返回：
+//   - int: 已打开且尚未关闭的连接数。
+func (d *Driver) OpenConns() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.opened - d.closed
+}
+
+// Open 打开一个内存连接，name 会被忽略。
+//
+// 参数：
+//   - name: 数据源名称，本驱动不解释该参数。
+//
+// 返回：
+//   - driver.Conn: 内存连接。
+//   - error: 通过 FailOn(OpConnect, err) 设置的错误。
+func (d *Driver) Open(name string) (driver.Conn, error) {
+	if err := d.failure(kitdriver.OpConnect); nil != err {
+		return nil, err
+	}
+	d.mu.Lock()
+	d.opened++
+	d.mu.Unlock()
+	return &conn{driver: d}, nil
+}
+
+// expect 追加一条期望。
+//
+// 参数：
+//   - query: 是否为 Query 期望。
+//   - pattern: SQL 片段。
+//
+// 返回：
+//   - *Expectation: 新追加的期望。
+func (d *Driver) expect(query bool, pattern string) *Expectation {
+	e := &Expectation{query: query, pattern: pattern, result: driver.RowsAffected(0)}
+	d.mu.Lock()
+	d.expectations = append(d.expectations, e)
+	d.mu.Unlock()
+	return e
+}
+
+// failure 返回指定操作的预设错误。
+//
+// 参数：
+//   - op: 操作类型。
+//
+// 返回：
+//   - error: 预设错误；未设置时为 nil。
+func (d *Driver) failure(op kitdriver.OpType) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.failures[op]
+}
+
+// match 按注册顺序查找第一条匹配的期望。
+//
+// 参数：
+//   - query: 是否为 Query 操作。
+//   - sqlText: 实际执行的 SQL。
+//
+// 返回：
+//   - *Expectation: 匹配的期望。
+//   - error: 没有匹配的期望时返回包装 ErrNoExpectation 的错误。
+func (d *Driver) match(query bool, sqlText string) (*Expectation, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, e := range d.expectations {
+		if e.query == query && strings.Contains(sqlText, e.pattern) {
+			return e, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %q", ErrNoExpectation, sqlText)
+}
+
+// WillReturnResult 设置 Exec 期望返回的结果。
+//
+// 参数：
+//   - lastInsertID: LastInsertId 返回的值。
+//   - rowsAffected: RowsAffected 返回的值。
+//
+// 返回：
+//   - *Expectation: 当前期望，便于链式调用。
+func (e *Expectation) WillReturnResult(lastInsertID, rowsAffected int64) *Expectation {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.result = result{lastInsertID: lastInsertID, rowsAffected: rowsAffected}
+	return e
+}
+
+// WillReturnRows 设置 Query 期望返回的结果集。
+//
+// 参数：
+//   - columns: 列名。
+//   - values: 每一行的值，长度应与 columns 一致。
+//
+// 返回：
+//   - *Expectation: 当前期望，便于链式调用。
+func (e *Expectation) WillReturnRows(columns []string, values ...[]driver.Value) *Expectation {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.columns = columns
+	e.values = values
+	return e
+}
+
+// WillReturnColumnTypes 设置 Query 期望返回的结果集的列数据库类型名。
+//
+// 参数：
+//   - types: 按列顺序排列的数据库类型名，例如 "DECIMAL"、"VARCHAR"；缺少的列返回空字符串。
+//
+// 返回：
+//   - *Expectation: 当前期望，便于链式调用。
+func (e *Expectation) WillReturnColumnTypes(types ...string) *Expectation {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.types = types
+	return e
+}
+
+// WillReturnError 设置期望返回的错误。
+//
+// 参数：
+//   - err: 操作返回的错误；为 nil 时恢复正常返回。
+//
+// 返回：
+//   - *Expectation: 当前期望，便于链式调用。
+func (e *Expectation) WillReturnError(err error) *Expectation {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.err = err
+	return e
+}
+
+// WillDelay 设置期望在返回前等待的时长。
+//
+// 等待期间上下文被取消或超时时，操作立即返回上下文错误，用于模拟被终止的慢查询。
+//
+// 参数：
+//   - delay: 等待时长。
+//
+// 返回：
+//   - *Expectation: 当前期望，便于链式调用。
+func (e *Expectation) WillDelay(delay time.Duration) *Expectation {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.delay = delay
+	return e
+}
+
+// Calls 返回期望已被匹配的次数。
+//
+// 返回：
+//   - int: 匹配次数。
+func (e *Expectation) Calls() int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.calls
+}
+
+// Args 返回每次匹配时传入的参数。
+//
+// 返回：
+//   - [][]driver.NamedValue: 按调用顺序排列的参数列表副本。
+func (e *Expectation) Args() [][]driver.NamedValue {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	args := make([][]driver.NamedValue, len(e.args))
+	copy(args, e.args)
+	return args
+}
+
+// run 记录一次匹配，并在等待预设时长后返回预设结果。
+//
+// 参数：
+//   - ctx: 操作上下文。
+//   - args: 操作参数。
+//
+// 返回：
+//   - *Expectation: 记录时刻的期望快照。
+//   - error: 上下文取消或预设错误。
+func (e *Expectation) run(ctx context.Context, args []driver.NamedValue) (*Expectation, error) {
+	e.mu.Lock()
+	e.calls++
+	e.args = append(e.args, args)
+	snapshot := &Expectation{result: e.result, columns: e.columns, types: e.types, values: e.values, err: e.err, delay: e.delay}
+	e.mu.Unlock()
+
+	if snapshot.delay > 0 {
+		timer := time.NewTimer(snapshot.delay)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+	if nil != snapshot.err {
+		return nil, snapshot.err
+	}
+	return snapshot, nil
+}
+
+// Prepare 创建内存预处理语句。
+//
+// 参数：
+//   - query: SQL 语句。
+//
+// 返回：
+//   - driver.Stmt: 内存预处理语句。
+//   - error: 通过 FailOn(OpPrepare, err) 设置的错误。
+func (c *conn) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+// PrepareContext 创建内存预处理语句。
+//
+// 参数：
+//   - ctx: 操作上下文。
+//   - query: SQL 语句。
+//
+// 返回：
+//   - driver.Stmt: 内存预处理语句。
+//   - error: 通过 FailOn(OpPrepare, err) 设置的错误。
+func (c *conn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	if err := c.driver.failure(kitdriver.OpPrepare); nil != err {
+		return nil, err
+	}
+	return &stmt{conn: c, query: query}, nil
+}
+
+// Close 关闭内存连接。
+//
+// 返回：
+//   - error: 始终为 nil。
+func (c *conn) Close() error {
+	c.driver.mu.Lock()
+	c.driver.closed++
+	c.driver.mu.Unlock()
+	return nil
+}
+
+// Begin 开始内存事务。
+//
+// 返回：
+//   - driver.Tx: 内存事务。
+//   - error: 通过 FailOn(OpBegin, err) 设置的错误。
+func (c *conn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+// BeginTx 开始内存事务。
+//
+// 参数：
+//   - ctx: 操作上下文。
+//   - opts: 事务选项，本驱动不解释该参数。
+//
+// 返回：
+//   - driver.Tx: 内存事务。
+//   - error: 通过 FailOn(OpBegin, err) 设置的错误。
+func (c *conn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if err := c.driver.failure(kitdriver.OpBegin); nil != err {
+		return nil, err
+	}
+	return &tx{conn: c}, nil
+}
+
+// ExecContext 按期望执行 SQL。
+//
+// 参数：
+//   - ctx: 操作上下文。
+//   - query: SQL 语句。
+//   - args: 命名参数列表。
+//
+// 返回：
+//   - driver.Result: 预设执行结果。
+//   - error: 没有匹配的期望、上下文取消或预设错误。
+func (c *conn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	e, err := c.driver.match(false, query)
+	if nil != err {
+		return nil, err
+	}
+	if e, err = e.run(ctx, args); nil != err {
+		return nil, err
+	}
+	return e.result, nil
+}
+
+// QueryContext 按期望查询 SQL。
+//
+// 参数：
+//   - ctx: 操作上下文。
+//   - query: SQL 语句。
+//   - args: 命名参数列表。
+//
+// 返回：
+//   - driver.Rows: 预设结果集。
+//   - error: 没有匹配的期望、上下文取消或预设错误。
+func (c *conn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	e, err := c.driver.match(true, query)
+	if nil != err {
+		return nil, err
+	}
+	if e, err = e.run(ctx, args); nil != err {
+		return nil, err
+	}
+	return &rows{columns: e.columns, types: e.types, values: e.values}, nil
+}
+
+// Ping 检测内存连接。
+//
+// 参数：
+//   - ctx: 操作上下文。
+//
+// 返回：
+//   - error: 通过 FailOn(OpPing, err) 设置的错误。
+func (c *conn) Ping(ctx context.Context) error {
+	return c.driver.failure(kitdriver.OpPing)
+}
+
+// CheckNamedValue 接受任意参数值，避免 database/sql 转换测试数据。
+//
+// 参数：
+//   - nv: 命名参数。
+//
+// 返回：
+//   - error: 始终为 nil。
+func (c *conn) CheckNamedValue(nv *driver.NamedValue) error {
+	return nil
+}
+
+// Close 关闭预处理语句。
+//
+// 返回：
+//   - error: 通过 FailOn(OpStmtClose, err) 设置的错误。
+func (s *stmt) Close() error {
+	return s.conn.driver.failure(kitdriver.OpStmtClose)
+}
+
+// NumInput 返回 -1，表示不检查参数个数。
+//
+// 返回：
+//   - int: 始终为 -1。
+func (s *stmt) NumInput() int {
+	return -1
+}
+
+// Exec 使用旧接口执行预处理语句。
+//
+// 参数：
+//   - args: 参数值列表。
+//
+// 返回：
+//   - driver.Result: 预设执行结果。
+//   - error: 没有匹配的期望或预设错误。
+func (s *stmt) Exec(args []driver.Value) (driver.Result, error) {
+	return s.ExecContext(context.Background(), namedValues(args))
+}
+
+// Query 使用旧接口查询预处理语句。
+//
+// 参数：
+//   - args: 参数值列表。
+//
+// 返回：
+//   - driver.Rows: 预设结果集。
+//   - error: 没有匹配的期望或预设错误。
+func (s *stmt) Query(args []driver.Value) (driver.Rows, error) {
+	return s.QueryContext(context.Background(), namedValues(args))
+}
+
+// ExecContext 按预处理 SQL 匹配期望并执行。
+//
+// 参数：
+//   - ctx: 操作上下文。
+//   - args: 命名参数列表。
+//
+// 返回：
+//   - driver.Result: 预设执行结果。
+//   - error: 没有匹配的期望、上下文取消或预设错误。
+func (s *stmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	return s.conn.ExecContext(ctx, s.query, args)
+}
+
+// QueryContext 按预处理 SQL 匹配期望并查询。
+//
+// 参数：
+//   - ctx: 操作上下文。
+//   - args: 命名参数列表。
+//
+// 返回：
+//   - driver.Rows: 预设结果集。
+//   - error: 没有匹配的期望、上下文取消或预设错误。
+func (s *stmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	return s.conn.QueryContext(ctx, s.query, args)
+}
+
+// Commit 提交内存事务。
+//
+// 返回：
+//   - error: 通过 FailOn(OpCommit, err) 设置的错误。
+func (t *tx) Commit() error {
+	return t.conn.driver.failure(kitdriver.OpCommit)
+}
+
+// Rollback 回滚内存事务。
+//
+// 返回：
+//   - error: 通过 FailOn(OpRollback, err) 设置的错误。
+func (t *tx) Rollback() error {
+	return t.conn.driver.failure(kitdriver.OpRollback)
+}
+
+// Columns 返回结果集列名。
+//
+// 返回：
+//   - []string: 列名。
+func (r *rows) Columns() []string {
+	return r.columns
+}
+
+// ColumnTypeDatabaseTypeName 返回列的数据库类型名。
+//
+// 参数：
+//   - index: 列下标。
+//
+// 返回：
+//   - string: WillReturnColumnTypes 设置的类型名；未设置时为空字符串。
+func (r *rows) ColumnTypeDatabaseTypeName(index int) string {
+	if index < len(r.types) {
+		return r.types[index]
+	}
+	return ""
+}
+
+// Close 关闭结果集。
+//
+// 返回：
+//   - error: 始终为 nil。
+func (r *rows) Close() error {
+	return nil
+}
+
+// Next 将下一行写入 dest。
+//
+// 参数：
+//   - dest: 目标值切片。
+//
+// 返回：
+//   - error: 没有更多行时返回 io.EOF。
+func (r *rows) Next(dest []driver.Value) error {
+	if r.pos >= len(r.values) {
+		return io.EOF
+	}
+	copy(dest, r.values[r.pos])
+	r.pos++
+	return nil
+}
+
+// Connect 打开一个新连接。
+//
+// 参数：
+//   - ctx: 操作上下文，本实现不使用。
+//
+// 返回：
+//   - driver.Conn: 底层驱动打开的连接。
+//   - error: 底层驱动打开失败时返回错误。
+func (c *connector) Connect(ctx context.Context) (driver.Conn, error) {
+	return c.driver.Open("")
+}
+
+// Driver 返回底层驱动。
+//
+// 返回：
+//   - driver.Driver: 底层驱动。
+func (c *connector) Driver() driver.Driver {
+	return c.driver
+}
+
+// result 是 Exec 期望返回的执行结果。
+type result struct {
+	lastInsertID int64
+	rowsAffected int64
+}
+
+// LastInsertId 返回预设的自增主键。
+//
+// 返回：
+//   - int64: 预设的自增主键。
+//   - error: 始终为 nil。
+func (r result) LastInsertId() (int64, error) {
+	return r.lastInsertID, nil
+}
+
+// RowsAffected 返回预设的影响行数。
+//
+// 返回：
+//   - int64: 预设的影响行数。
+//   - error: 始终为 nil。
+func (r result) RowsAffected() (int64, error) {
+	return r.rowsAffected, nil
+}
+
+// namedValues 将旧接口的参数值转换为命名参数。
+//
+// 参数：
+//   - args: 参数值列表。
+//
+// 返回：
+//   - []driver.NamedValue: 序号从 1 开始的命名参数列表。
+func namedValues(args []driver.Value) []driver.NamedValue {
+	named := make([]driver.NamedValue, len(args))
+	for i, arg := range args {
+		named[i] = driver.NamedValue{Ordinal: i + 1, Value: arg}
+	}
+	return named
+}
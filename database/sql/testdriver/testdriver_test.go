@@ -0,0 +1,320 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package testdriver
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	kitdriver "github.com/fsyyft-go/kit/database/sql/driver"
+)
+
+// TestDriver_Exec 验证 Exec 按注册顺序匹配期望并返回预设结果或错误。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestDriver_Exec(t *testing.T) {
+	execErr := errors.New("duplicate key")
+
+	tests := []struct {
+		name             string
+		description      string
+		giveSetup        func(d *Driver)
+		giveQuery        string
+		wantErr          error
+		wantLastInsertID int64
+		wantRowsAffected int64
+	}{
+		{
+			name:        "success/result",
+			description: "验证匹配的 Exec 返回预设的自增主键和影响行数。",
+			giveSetup: func(d *Driver) {
+				d.ExpectExec("INSERT INTO users").WillReturnResult(3, 1)
+			},
+			giveQuery:        "INSERT INTO users (name) VALUES (?)",
+			wantLastInsertID: 3,
+			wantRowsAffected: 1,
+		},
+		{
+			name:        "success/first-match-wins",
+			description: "验证多条期望同时匹配时使用最先注册的期望。",
+			giveSetup: func(d *Driver) {
+				d.ExpectExec("UPDATE").WillReturnResult(0, 2)
+				d.ExpectExec("UPDATE users").WillReturnResult(0, 5)
+			},
+			giveQuery:        "UPDATE users SET name = ?",
+			wantRowsAffected: 2,
+		},
+		{
+			name:        "error/scripted",
+			description: "验证期望设置的错误原样返回。",
+			giveSetup: func(d *Driver) {
+				d.ExpectExec("INSERT").WillReturnError(execErr)
+			},
+			giveQuery: "INSERT INTO users (name) VALUES (?)",
+			wantErr:   execErr,
+		},
+		{
+			name:        "error/no-expectation",
+			description: "验证没有匹配的期望时返回 ErrNoExpectation。",
+			giveSetup: func(d *Driver) {
+				d.ExpectQuery("DELETE")
+			},
+			giveQuery: "DELETE FROM users",
+			wantErr:   ErrNoExpectation,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			d := New()
+			tt.giveSetup(d)
+			db, _ := NewDB(d)
+			defer db.Close()
+
+			result, err := db.Exec(tt.giveQuery, "kit")
+			if nil != tt.wantErr {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			id, err := result.LastInsertId()
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantLastInsertID, id)
+			affected, err := result.RowsAffected()
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantRowsAffected, affected)
+		})
+	}
+}
+
+// TestDriver_Query 验证 Query 返回预设结果集并记录调用参数。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestDriver_Query(t *testing.T) {
+	d := New()
+	e := d.ExpectQuery("FROM users").WillReturnRows(
+		[]string{"id", "name"},
+		[]driver.Value{int64(1), "alice"},
+		[]driver.Value{int64(2), "bob"},
+	)
+	db, recorder := NewDB(d)
+	defer db.Close()
+
+	for i := 0; i < 2; i++ {
+		rows, err := db.Query("SELECT id, name FROM users WHERE id > ?", int64(i))
+		require.NoError(t, err)
+
+		var names []string
+		for rows.Next() {
+			var id int64
+			var name string
+			require.NoError(t, rows.Scan(&id, &name))
+			names = append(names, name)
+		}
+		require.NoError(t, rows.Err())
+		require.NoError(t, rows.Close())
+		assert.Equal(t, []string{"alice", "bob"}, names)
+	}
+
+	assert.Equal(t, 2, e.Calls())
+	args := e.Args()
+	require.Len(t, args, 2)
+	assert.Equal(t, int64(1), args[1][0].Value)
+
+	queries := recorder.Filter(kitdriver.OpQuery)
+	require.Len(t, queries, 2)
+	assert.Equal(t, "SELECT id, name FROM users WHERE id > ?", queries[0].Query())
+	assert.NoError(t, queries[0].OriginError())
+
+	// 列类型：未设置的列返回空字符串。
+	e.WillReturnColumnTypes("BIGINT")
+	rows, err := db.Query("SELECT id, name FROM users")
+	require.NoError(t, err)
+	columnTypes, err := rows.ColumnTypes()
+	require.NoError(t, err)
+	require.NoError(t, rows.Close())
+	require.Len(t, columnTypes, 2)
+	assert.Equal(t, "BIGINT", columnTypes[0].DatabaseTypeName())
+	assert.Equal(t, "", columnTypes[1].DatabaseTypeName())
+}
+
+// TestDriver_PrepareAndTx 验证预处理语句和事务操作经过 Hook 链并被记录。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestDriver_PrepareAndTx(t *testing.T) {
+	d := New()
+	d.ExpectExec("UPDATE accounts").WillReturnResult(0, 1)
+	db, recorder := NewDB(d)
+	defer db.Close()
+
+	tx, err := db.Begin()
+	require.NoError(t, err)
+	stmt, err := tx.Prepare("UPDATE accounts SET balance = ? WHERE id = ?")
+	require.NoError(t, err)
+	_, err = stmt.Exec(100, 1)
+	require.NoError(t, err)
+	require.NoError(t, stmt.Close())
+	require.NoError(t, tx.Commit())
+
+	assert.Equal(t, []kitdriver.OpType{
+		kitdriver.OpConnect,
+		kitdriver.OpBegin,
+		kitdriver.OpPrepare,
+		kitdriver.OpStmtExec,
+		kitdriver.OpStmtClose,
+		kitdriver.OpCommit,
+	}, recorder.Ops())
+
+	recorder.Reset()
+	assert.Empty(t, recorder.Contexts())
+}
+
+// TestDriver_FailOn 验证 FailOn 为非 SQL 操作注入错误，并可通过 nil 取消。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestDriver_FailOn(t *testing.T) {
+	injected := errors.New("injected")
+
+	tests := []struct {
+		name        string
+		description string
+		giveOp      kitdriver.OpType
+		run         func(d *Driver) error
+	}{
+		{
+			name:        "error/connect",
+			description: "验证连接失败会传递给 Ping 调用方。",
+			giveOp:      kitdriver.OpConnect,
+			run: func(d *Driver) error {
+				db, _ := NewDB(d)
+				defer db.Close()
+				return db.Ping()
+			},
+		},
+		{
+			name:        "error/ping",
+			description: "验证 Ping 失败会传递给调用方。",
+			giveOp:      kitdriver.OpPing,
+			run: func(d *Driver) error {
+				db, _ := NewDB(d)
+				defer db.Close()
+				return db.Ping()
+			},
+		},
+		{
+			name:        "error/begin",
+			description: "验证开始事务失败会传递给调用方。",
+			giveOp:      kitdriver.OpBegin,
+			run: func(d *Driver) error {
+				db, _ := NewDB(d)
+				defer db.Close()
+				_, err := db.Begin()
+				return err
+			},
+		},
+		{
+			name:        "error/rollback",
+			description: "验证回滚失败会传递给调用方。",
+			giveOp:      kitdriver.OpRollback,
+			run: func(d *Driver) error {
+				db, _ := NewDB(d)
+				defer db.Close()
+				tx, err := db.Begin()
+				if nil != err {
+					return err
+				}
+				return tx.Rollback()
+			},
+		},
+		{
+			name:        "error/prepare",
+			description: "验证预处理失败会传递给调用方。",
+			giveOp:      kitdriver.OpPrepare,
+			run: func(d *Driver) error {
+				db, _ := NewDB(d)
+				defer db.Close()
+				_, err := db.Prepare("SELECT 1")
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			d := New()
+			d.FailOn(tt.giveOp, injected)
+			require.ErrorIs(t, tt.run(d), injected)
+
+			d.FailOn(tt.giveOp, nil)
+			require.NoError(t, tt.run(d))
+		})
+	}
+}
+
+// TestDriver_DelayHonoursContext 验证 WillDelay 在上下文超时后立即返回，并能被默认查询超时 Hook 识别。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestDriver_DelayHonoursContext(t *testing.T) {
+	d := New()
+	d.ExpectQuery("SELECT SLEEP").WillDelay(time.Minute)
+	d.ExpectExec("UPDATE").WillDelay(time.Millisecond).WillReturnResult(0, 1)
+	db, recorder := NewDB(d, kitdriver.NewHookQueryTimeout("", nil, 20*time.Millisecond))
+	defer db.Close()
+
+	start := time.Now()
+	_, err := db.Query("SELECT SLEEP(60)")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 10*time.Second)
+
+	_, err = db.Exec("UPDATE t SET v = 1")
+	require.NoError(t, err)
+
+	queries := recorder.Filter(kitdriver.OpQuery)
+	require.Len(t, queries, 1)
+	assert.True(t, queries[0].Canceled())
+	assert.True(t, queries[0].TimedOut())
+
+	execs := recorder.Filter(kitdriver.OpExec)
+	require.Len(t, execs, 1)
+	assert.False(t, execs[0].Canceled())
+}
+
+// TestDriver_ResetAndOpenConns 验证 Reset 清除期望和失败设置，OpenConns 统计未关闭连接。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestDriver_ResetAndOpenConns(t *testing.T) {
+	d := New()
+	d.ExpectExec("INSERT")
+	d.FailOn(kitdriver.OpPing, errors.New("down"))
+	d.Reset()
+
+	db, _ := NewDB(d)
+	require.NoError(t, db.Ping())
+	assert.Equal(t, 1, d.OpenConns())
+
+	_, err := db.Exec("INSERT INTO t VALUES (1)")
+	require.ErrorIs(t, err, ErrNoExpectation)
+
+	require.NoError(t, db.Close())
+	assert.Equal(t, 0, d.OpenConns())
+}
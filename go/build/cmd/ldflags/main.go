@@ -0,0 +1,50 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+// ldflags 根据本地 Git 仓库状态输出注入 go/build 构建信息的 -ldflags 参数值。
+//
+// 适合在 go:generate 中调用，把结果写入文件后交给 go build：
+//
+//	//go:generate go run github.com/fsyyft-go/kit/go/build/cmd/ldflags -o .ldflags
+//
+//	go generate ./... && go build -ldflags "$(cat .ldflags)" ./cmd/app
+//
+// 也可以直接在 shell 中使用：
+//
+//	go build -ldflags "$(go run github.com/fsyyft-go/kit/go/build/cmd/ldflags)" ./cmd/app
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	kitbuild "github.com/fsyyft-go/kit/go/build"
+)
+
+func main() {
+	dir := flag.String("dir", "", "读取 Git 信息的目录，默认为当前工作目录")
+	version := flag.String("version", "", "指定软件版本，默认取 git describe --tags --always --dirty 的结果")
+	output := flag.String("o", "", "输出文件，默认输出到标准输出")
+	flag.Parse()
+
+	ldflags, err := kitbuild.Ldflags(context.Background(),
+		kitbuild.WithStampDir(*dir),
+		kitbuild.WithStampVersion(*version),
+	)
+	if nil != err {
+		fmt.Fprintf(os.Stderr, "ldflags: %v\n", err)
+		os.Exit(1)
+	}
+
+	if "" == *output {
+		fmt.Println(ldflags)
+		return
+	}
+	if err := os.WriteFile(*output, []byte(ldflags+"\n"), 0o644); nil != err {
+		fmt.Fprintf(os.Stderr, "ldflags: %v\n", err)
+		os.Exit(1)
+	}
+}
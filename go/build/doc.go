@@ -12,6 +12,7 @@ Package build 提供了用于获取和管理 Go 程序构建环境信息的工
   - 支持获取软件版本和 Git 版本信息
   - 提供构建时间和环境路径信息
   - 自动检测调试环境状态
+  - 根据本地 Git 仓库状态生成 -ldflags 参数
 
 2. 版本信息：
   - Version：软件版本号
@@ -56,6 +57,12 @@ Package build 提供了用于获取和管理 Go 程序构建环境信息的工
 	                   -X github.com/fsyyft-go/kit/go/build.gitVersion=$(git rev-parse HEAD) \
 	                   -X github.com/fsyyft-go/kit/go/build.buildTimeString=$(date +%Y%m%d%H%M%S%3N)"
 
+也可以使用 Ldflags 或配套的 cmd/ldflags 命令根据本地 Git 仓库状态生成同样的参数：
+
+	//go:generate go run github.com/fsyyft-go/kit/go/build/cmd/ldflags -o .ldflags
+
+	go generate ./... && go build -ldflags "$(cat .ldflags)" ./cmd/app
+
 常量定义：
 
 	TimeLayout = "20060102150405000"    // 时间格式化模板
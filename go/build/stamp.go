@@ -0,0 +1,222 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package build
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// PackagePath 是本包的导入路径，-ldflags 的 -X 参数使用它定位构建信息变量。
+const PackagePath = "github.com/fsyyft-go/kit/go/build"
+
+var (
+	// ErrGitCommand 表示执行 git 命令失败，常见原因是未安装 git 或目录不在 Git 仓库中。
+	//
+	// 调用方可以使用 errors.Is 判断该错误。
+	ErrGitCommand = errors.New("执行 git 命令失败。")
+
+	// ErrInvalidStampValue 表示构建信息的值无法写入 -ldflags，即同时包含单引号和双引号。
+	//
+	// 调用方可以使用 errors.Is 判断该错误。
+	ErrInvalidStampValue = errors.New("构建信息的值无法写入 -ldflags。")
+)
+
+type (
+	// Stamp 保存构建时需要注入的构建信息，对应本包的 version、gitVersion 与 buildTimeString 变量。
+	Stamp struct {
+		Version         string // 软件版本。
+		GitVersion      string // 完整的 Git 提交哈希值。
+		BuildTimeString string // 构建时间，格式为 TimeLayout。
+	}
+
+	// StampOption 定义 ReadStamp 的配置函数。
+	StampOption func(*stampOptions)
+
+	// stampOptions 保存 ReadStamp 的配置。
+	stampOptions struct {
+		dir     string                                                                // 执行 git 命令的目录。
+		version string                                                                // 指定的软件版本。
+		now     func() time.Time                                                      // 获取构建时间的时钟。
+		git     func(ctx context.Context, dir string, args ...string) (string, error) // 执行 git 命令的函数。
+	}
+)
+
+// WithStampDir 设置读取 Git 信息的目录。
+//
+// 参数：
+//   - dir：Git 仓库内的任意目录；为空字符串时使用当前工作目录。
+//
+// 返回值：
+//   - StampOption：设置目录的配置函数。
+func WithStampDir(dir string) StampOption {
+	return func(o *stampOptions) {
+		o.dir = dir
+	}
+}
+
+// WithStampVersion 指定软件版本，不再通过 git describe 推导。
+//
+// 参数：
+//   - version：软件版本；为空字符串时仍通过 git describe 推导。
+//
+// 返回值：
+//   - StampOption：指定版本的配置函数。
+func WithStampVersion(version string) StampOption {
+	return func(o *stampOptions) {
+		o.version = version
+	}
+}
+
+// WithStampClock 设置获取构建时间的时钟，便于生成可复现的构建信息。
+//
+// 参数：
+//   - now：返回当前时间的函数；为 nil 时保留默认的 time.Now。
+//
+// 返回值：
+//   - StampOption：设置时钟的配置函数。
+func WithStampClock(now func() time.Time) StampOption {
+	return func(o *stampOptions) {
+		if nil != now {
+			o.now = now
+		}
+	}
+}
+
+// ReadStamp 根据本地仓库状态计算构建信息。
+//
+// 软件版本取 git describe --tags --always --dirty 的结果：有标签时形如 v1.2.3-4-gabcdef12，
+// 没有标签时为短提交哈希，工作区有未提交修改时带 -dirty 后缀；Git 版本取 git rev-parse HEAD；
+// 构建时间取当前时间按 TimeLayout 格式化的结果。
+//
+// 参数：
+//   - ctx：控制 git 命令执行的上下文。
+//   - opts：可选的配置函数。
+//
+// 返回值：
+//   - Stamp：计算得到的构建信息。
+//   - error：git 命令执行失败时返回包装了 ErrGitCommand 的错误。
+func ReadStamp(ctx context.Context, opts ...StampOption) (Stamp, error) {
+	o := &stampOptions{now: time.Now, git: runGit}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	gitVersion, err := o.git(ctx, o.dir, "rev-parse", "HEAD")
+	if nil != err {
+		return Stamp{}, err
+	}
+
+	version := o.version
+	if "" == version {
+		if version, err = o.git(ctx, o.dir, "describe", "--tags", "--always", "--dirty"); nil != err {
+			return Stamp{}, err
+		}
+	}
+
+	return Stamp{
+		Version:         version,
+		GitVersion:      gitVersion,
+		BuildTimeString: o.now().Format(TimeLayout),
+	}, nil
+}
+
+// Ldflags 根据本地仓库状态计算 go build 的 -ldflags 参数值，等价于 ReadStamp 后调用 Stamp.Ldflags。
+//
+// 参数：
+//   - ctx：控制 git 命令执行的上下文。
+//   - opts：可选的配置函数。
+//
+// 返回值：
+//   - string：可直接传给 go build -ldflags 的参数值。
+//   - error：git 命令执行失败或值无法写入时返回错误。
+func Ldflags(ctx context.Context, opts ...StampOption) (string, error) {
+	stamp, err := ReadStamp(ctx, opts...)
+	if nil != err {
+		return "", err
+	}
+	return stamp.Ldflags()
+}
+
+// Ldflags 返回注入该构建信息的 -ldflags 参数值。
+//
+// 每个非空字段生成一个 -X 参数，空字段被忽略；包含空白或引号的值按 go 命令的规则加引号。
+//
+// 返回值：
+//   - string：形如 "-X github.com/fsyyft-go/kit/go/build.version=v1.0.0 ..." 的参数值。
+//   - error：某个值同时包含单引号和双引号时返回包装了 ErrInvalidStampValue 的错误。
+func (s Stamp) Ldflags() (string, error) {
+	vars := []struct {
+		name  string
+		value string
+	}{
+		{name: "version", value: s.Version},
+		{name: "gitVersion", value: s.GitVersion},
+		{name: "buildTimeString", value: s.BuildTimeString},
+	}
+
+	flags := make([]string, 0, 2*len(vars))
+	for _, v := range vars {
+		if "" == v.value {
+			continue
+		}
+		arg, err := quoteFlag(PackagePath + "." + v.name + "=" + v.value)
+		if nil != err {
+			return "", fmt.Errorf("%w: %s", err, v.name)
+		}
+		flags = append(flags, "-X", arg)
+	}
+	return strings.Join(flags, " "), nil
+}
+
+// quoteFlag 按 go 命令拆分 -ldflags 的规则为参数加引号。
+//
+// go 命令按空白拆分 -ldflags，支持单引号或双引号包裹，但不支持转义。
+//
+// 参数：
+//   - arg：待写入的参数。
+//
+// 返回值：
+//   - string：不含空白与引号时原样返回，否则为加引号后的参数。
+//   - error：同时包含单引号和双引号时返回 ErrInvalidStampValue。
+func quoteFlag(arg string) (string, error) {
+	if !strings.ContainsAny(arg, " \t\n\r'\"") {
+		return arg, nil
+	}
+	if !strings.Contains(arg, "'") {
+		return "'" + arg + "'", nil
+	}
+	if !strings.Contains(arg, "\"") {
+		return "\"" + arg + "\"", nil
+	}
+	return "", ErrInvalidStampValue
+}
+
+// runGit 在指定目录执行 git 命令。
+//
+// 参数：
+//   - ctx：控制命令执行的上下文。
+//   - dir：执行命令的目录；为空字符串时使用当前工作目录。
+//   - args：git 子命令及其参数。
+//
+// 返回值：
+//   - string：去除首尾空白后的标准输出。
+//   - error：命令执行失败时返回包装了 ErrGitCommand 的错误，包含标准错误输出。
+func runGit(ctx context.Context, dir string, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); nil != err {
+		return "", fmt.Errorf("%w: git %s: %v: %s", ErrGitCommand, strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
@@ -0,0 +1,208 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package build
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeGit 返回按子命令应答的 git 替身，并记录调用过的子命令。
+//
+// 参数：
+//   - outputs：子命令到标准输出的映射，未列出的子命令返回 ErrGitCommand。
+//   - calls：记录调用过的子命令，可以为 nil。
+//
+// 返回值：
+//   - func(context.Context, string, ...string) (string, error)：git 替身。
+func fakeGit(outputs map[string]string, calls *[]string) func(context.Context, string, ...string) (string, error) {
+	return func(_ context.Context, _ string, args ...string) (string, error) {
+		if nil != calls {
+			*calls = append(*calls, args[0])
+		}
+		if out, ok := outputs[args[0]]; ok {
+			return out, nil
+		}
+		return "", ErrGitCommand
+	}
+}
+
+// TestReadStamp 验证 ReadStamp 从 git 输出与时钟计算构建信息。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestReadStamp(t *testing.T) {
+	giveNow := time.Date(2025, 6, 18, 12, 34, 56, 0, time.UTC)
+	giveOutputs := map[string]string{
+		"rev-parse": "0123456789abcdef0123456789abcdef01234567",
+		"describe":  "v1.2.3-4-g01234567-dirty",
+	}
+
+	tests := []struct {
+		name        string
+		description string
+		outputs     map[string]string
+		opts        []StampOption
+		want        Stamp
+		wantCalls   []string
+		wantErr     error
+	}{
+		{
+			name:        "success/describe",
+			description: "验证未指定版本时软件版本取 git describe 的结果，构建时间按 TimeLayout 格式化。",
+			outputs:     giveOutputs,
+			want: Stamp{
+				Version:         "v1.2.3-4-g01234567-dirty",
+				GitVersion:      "0123456789abcdef0123456789abcdef01234567",
+				BuildTimeString: giveNow.Format(TimeLayout),
+			},
+			wantCalls: []string{"rev-parse", "describe"},
+		},
+		{
+			name:        "success/explicit-version",
+			description: "验证 WithStampVersion 指定版本时不再执行 git describe。",
+			outputs:     giveOutputs,
+			opts:        []StampOption{WithStampVersion("v2.0.0")},
+			want: Stamp{
+				Version:         "v2.0.0",
+				GitVersion:      "0123456789abcdef0123456789abcdef01234567",
+				BuildTimeString: giveNow.Format(TimeLayout),
+			},
+			wantCalls: []string{"rev-parse"},
+		},
+		{
+			name:        "error/not-repository",
+			description: "验证 git rev-parse 失败时返回 ErrGitCommand。",
+			outputs:     map[string]string{},
+			wantCalls:   []string{"rev-parse"},
+			wantErr:     ErrGitCommand,
+		},
+		{
+			name:        "error/describe",
+			description: "验证 git describe 失败时返回 ErrGitCommand。",
+			outputs:     map[string]string{"rev-parse": "0123456789abcdef0123456789abcdef01234567"},
+			wantCalls:   []string{"rev-parse", "describe"},
+			wantErr:     ErrGitCommand,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			var calls []string
+			opts := append([]StampOption{
+				WithStampClock(func() time.Time { return giveNow }),
+				func(o *stampOptions) { o.git = fakeGit(tt.outputs, &calls) },
+			}, tt.opts...)
+
+			got, err := ReadStamp(context.Background(), opts...)
+			assert.Equal(t, tt.wantCalls, calls)
+			if nil != tt.wantErr {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// TestStamp_Ldflags 验证 Stamp.Ldflags 生成的 -X 参数与引号规则。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestStamp_Ldflags(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		give        Stamp
+		want        string
+		wantErr     error
+	}{
+		{
+			name:        "success/all-fields",
+			description: "验证每个字段生成一个 -X 参数。",
+			give:        Stamp{Version: "v1.0.0", GitVersion: "abcdef", BuildTimeString: "20250618123456000"},
+			want: "-X github.com/fsyyft-go/kit/go/build.version=v1.0.0" +
+				" -X github.com/fsyyft-go/kit/go/build.gitVersion=abcdef" +
+				" -X github.com/fsyyft-go/kit/go/build.buildTimeString=20250618123456000",
+		},
+		{
+			name:        "boundary/empty-fields",
+			description: "验证空字段被忽略，全部为空时返回空字符串。",
+			give:        Stamp{},
+			want:        "",
+		},
+		{
+			name:        "success/quote-space",
+			description: "验证包含空格的值使用单引号包裹。",
+			give:        Stamp{Version: "v1.0.0 beta"},
+			want:        "-X 'github.com/fsyyft-go/kit/go/build.version=v1.0.0 beta'",
+		},
+		{
+			name:        "success/quote-single-quote",
+			description: "验证包含单引号的值使用双引号包裹。",
+			give:        Stamp{Version: "it's"},
+			want:        `-X "github.com/fsyyft-go/kit/go/build.version=it's"`,
+		},
+		{
+			name:        "error/both-quotes",
+			description: "验证同时包含单引号和双引号的值返回 ErrInvalidStampValue。",
+			give:        Stamp{Version: `a'b"c`},
+			wantErr:     ErrInvalidStampValue,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			got, err := tt.give.Ldflags()
+			if nil != tt.wantErr {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// TestLdflags_Repository 验证 Ldflags 在真实的 Git 仓库中读取提交信息与标签。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestLdflags_Repository(t *testing.T) {
+	t.Log("验证在带标签的临时仓库中生成的 -ldflags 包含标签版本与完整提交哈希，非仓库目录返回 ErrGitCommand。")
+
+	if _, err := exec.LookPath("git"); nil != err {
+		t.Skip("未安装 git")
+	}
+
+	dir := t.TempDir()
+	gitRun := func(args ...string) string {
+		out, err := runGit(context.Background(), dir, args...)
+		require.NoError(t, err)
+		return out
+	}
+	gitRun("init", "-q")
+	gitRun("-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "--allow-empty", "-m", "init")
+	gitRun("tag", "v0.1.0")
+	head := gitRun("rev-parse", "HEAD")
+
+	got, err := Ldflags(context.Background(), WithStampDir(dir))
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(got, "-X "+PackagePath+".version=v0.1.0 -X "+PackagePath+".gitVersion="+head+" "), got)
+
+	_, err = Ldflags(context.Background(), WithStampDir(t.TempDir()))
+	assert.ErrorIs(t, err, ErrGitCommand)
+}
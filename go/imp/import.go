@@ -119,9 +119,9 @@ func determineGroup(path, alias string) ImportGroup {
 //   - bool：如果是内置包则返回 true，否则返回 false。
 func isBuiltinPackage(path string) bool {
 	builtinPackages := []string{
-		"archive", "bufio", "builtin", "bytes", "compress", "container", "context",
+		"archive", "bufio", "builtin", "bytes", "cmp", "compress", "container", "context",
 		"crypto", "database", "debug", "encoding", "errors", "expvar", "flag", "fmt",
-		"go", "hash", "html", "image", "internal", "io", "log", "math", "mime",
+		"go", "hash", "html", "image", "internal", "io", "iter", "log", "maps", "math", "mime",
 		"net", "os", "path", "plugin", "reflect", "regexp", "runtime", "slices", "sort",
 		"strconv", "strings", "sync", "syscall", "testing", "text", "time",
 		"unicode", "unsafe",
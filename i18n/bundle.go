@@ -0,0 +1,556 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package i18n
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	// ErrMessageNotFound 表示回退链上的所有语言都没有定义该消息。
+	//
+	// 调用方可以使用 errors.Is 判断该错误。
+	ErrMessageNotFound = errors.New("消息不存在。")
+	// ErrInvalidMessage 表示消息定义不合法，例如复数消息缺少 other 类别、取值不是字符串或模板无法解析。
+	//
+	// 调用方可以使用 errors.Is 判断该错误。
+	ErrInvalidMessage = errors.New("消息定义不合法。")
+	// ErrUnsupportedFormat 表示消息文件的格式不受支持，当前支持 JSON 与 YAML。
+	//
+	// 调用方可以使用 errors.Is 判断该错误。
+	ErrUnsupportedFormat = errors.New("不支持的消息文件格式。")
+)
+
+var (
+	// defaultLanguageDefault 是未指定默认语言时使用的语言，与 time 包的默认语言环境一致。
+	defaultLanguageDefault = "zh-CN"
+	// loadPatternsDefault 是 LoadFS 未指定匹配模式时使用的模式。
+	loadPatternsDefault = []string{"*.json", "*.yaml", "*.yml"}
+)
+
+type (
+	// Option 配置 NewBundle 创建的消息包。
+	Option func(*Bundle)
+
+	// Bundle 保存多种语言的消息，是 Localizer 的数据来源。
+	//
+	// Bundle 可以并发使用；加载消息与查找消息可以同时进行，同一键后加载的定义覆盖先加载的定义。
+	Bundle struct {
+		// defaultLanguage 是回退链末尾的默认语言。
+		defaultLanguage string
+		// pluralRules 是调用方注册的复数规则，按规范化的语言标签索引。
+		pluralRules map[string]PluralRule
+
+		// mu 保护 messages。
+		mu sync.RWMutex
+		// messages 按语言与消息键索引。
+		messages map[string]map[string]*message
+	}
+
+	// message 是单条消息在各复数类别下的文案。
+	message struct {
+		// forms 按复数类别索引文案；非复数消息只有 PluralOther。
+		forms map[PluralCategory]*text
+	}
+
+	// text 是单个文案及其预解析的模板。
+	text struct {
+		// raw 是原始文案。
+		raw string
+		// tmpl 是解析后的模板，文案不包含模板动作时为 nil。
+		tmpl *template.Template
+	}
+)
+
+// WithPluralRule 为语言注册复数规则，覆盖内置规则。
+//
+// 参数：
+//   - lang: 语言标签；查找时先匹配完整标签，再逐级匹配父级标签。
+//   - rule: 复数规则；传入 nil 时忽略。
+//
+// 返回：
+//   - Option: 消息包配置选项。
+func WithPluralRule(lang string, rule PluralRule) Option {
+	return func(b *Bundle) {
+		if lang = CanonicalLanguage(lang); "" != lang && nil != rule {
+			b.pluralRules[lang] = rule
+		}
+	}
+}
+
+// NewBundle 创建消息包。
+//
+// 参数：
+//   - defaultLanguage: 默认语言，所有回退链都以它结尾；为空时使用 zh-CN。
+//   - opts: 消息包配置选项。
+//
+// 返回：
+//   - *Bundle: 不含任何消息的消息包。
+func NewBundle(defaultLanguage string, opts ...Option) *Bundle {
+	if defaultLanguage = CanonicalLanguage(defaultLanguage); "" == defaultLanguage {
+		defaultLanguage = defaultLanguageDefault
+	}
+	b := &Bundle{
+		defaultLanguage: defaultLanguage,
+		pluralRules:     make(map[string]PluralRule),
+		messages:        make(map[string]map[string]*message),
+	}
+	for _, opt := range opts {
+		if nil != opt {
+			opt(b)
+		}
+	}
+	return b
+}
+
+// DefaultLanguage 返回消息包的默认语言。
+//
+// 参数：无。
+//
+// 返回：
+//   - string: 规范化的默认语言标签。
+func (b *Bundle) DefaultLanguage() string {
+	return b.defaultLanguage
+}
+
+// Languages 返回已加载消息的语言。
+//
+// 参数：无。
+//
+// 返回：
+//   - []string: 按字典序排列的规范化语言标签。
+func (b *Bundle) Languages() []string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	langs := make([]string, 0, len(b.messages))
+	for lang := range b.messages {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+// AddMessages 添加一组非复数消息。
+//
+// 参数：
+//   - lang: 语言标签。
+//   - messages: 消息键到文案的映射，文案可以包含 text/template 动作，例如 `你好，{{.Name}}`。
+//
+// 返回：
+//   - error: 语言标签为空或模板无法解析时返回包装了 ErrInvalidMessage 的错误，此时不添加任何消息。
+func (b *Bundle) AddMessages(lang string, messages map[string]string) error {
+	parsed := make(map[string]*message, len(messages))
+	for key, raw := range messages {
+		m, err := newMessage(key, map[PluralCategory]string{PluralOther: raw})
+		if nil != err {
+			return err
+		}
+		parsed[key] = m
+	}
+	return b.add(lang, parsed)
+}
+
+// AddPluralMessage 添加一条复数消息。
+//
+// 参数：
+//   - lang: 语言标签。
+//   - key: 消息键。
+//   - forms: 复数类别到文案的映射，必须包含 PluralOther。
+//
+// 返回：
+//   - error: 缺少 PluralOther、类别不合法或模板无法解析时返回包装了 ErrInvalidMessage 的错误。
+func (b *Bundle) AddPluralMessage(lang, key string, forms map[PluralCategory]string) error {
+	m, err := newMessage(key, forms)
+	if nil != err {
+		return err
+	}
+	return b.add(lang, map[string]*message{key: m})
+}
+
+// Parse 解析 JSON 或 YAML 格式的消息并添加到消息包。
+//
+// 文件内容是消息键到文案的映射，嵌套映射的键以 "." 连接，例如 `user: {greeting: 你好}` 定义消息 `user.greeting`；
+// 键全部为复数类别（zero、one、two、few、many、other）的映射视为一条复数消息。
+//
+// 参数：
+//   - lang: 语言标签。
+//   - format: 文件格式，支持 json、yaml 与 yml，不区分大小写，可以带 "." 前缀。
+//   - data: 文件内容。
+//
+// 返回：
+//   - error: 格式不受支持时返回包装了 ErrUnsupportedFormat 的错误，内容无法解码时返回解码错误，
+//     消息不合法时返回包装了 ErrInvalidMessage 的错误；出错时不添加任何消息。
+func (b *Bundle) Parse(lang, format string, data []byte) error {
+	raw := make(map[string]interface{})
+	switch strings.ToLower(strings.TrimPrefix(format, ".")) {
+	case "json":
+		if err := json.Unmarshal(data, &raw); nil != err {
+			return fmt.Errorf("解析 JSON 消息失败：%w", err)
+		}
+	case "yaml", "yml":
+		if err := yaml.Unmarshal(data, &raw); nil != err {
+			return fmt.Errorf("解析 YAML 消息失败：%w", err)
+		}
+	default:
+		return fmt.Errorf("%w: %q", ErrUnsupportedFormat, format)
+	}
+
+	parsed := make(map[string]*message)
+	if err := flatten("", raw, parsed); nil != err {
+		return err
+	}
+	return b.add(lang, parsed)
+}
+
+// LoadFile 从文件加载消息，语言取自文件名。
+//
+// 参数：
+//   - name: 文件路径，文件名形如 `zh-CN.yaml` 或 `active.zh-CN.json`，语言为扩展名之前最后一个 "." 之后的部分。
+//
+// 返回：
+//   - error: 读取或解析失败时返回错误。
+func (b *Bundle) LoadFile(name string) error {
+	data, err := os.ReadFile(name)
+	if nil != err {
+		return err
+	}
+	lang, format := splitFileName(filepath.Base(name))
+	if err := b.Parse(lang, format, data); nil != err {
+		return fmt.Errorf("加载消息文件 %s 失败：%w", name, err)
+	}
+	return nil
+}
+
+// LoadFS 从文件系统加载消息，通常与 embed.FS 配合使用。
+//
+// 参数：
+//   - fsys: 文件系统。
+//   - patterns: fs.Glob 匹配模式，例如 `locales/*.yaml`；为空时加载根目录下的 JSON 与 YAML 文件。
+//     语言取自文件名，规则与 LoadFile 相同；匹配到的目录会被跳过。
+//
+// 返回：
+//   - error: 模式不合法、读取或解析失败时返回错误，出错前已加载的文件保留。
+func (b *Bundle) LoadFS(fsys fs.FS, patterns ...string) error {
+	if 0 == len(patterns) {
+		patterns = loadPatternsDefault
+	}
+	for _, pattern := range patterns {
+		names, err := fs.Glob(fsys, pattern)
+		if nil != err {
+			return err
+		}
+		for _, name := range names {
+			info, err := fs.Stat(fsys, name)
+			if nil != err {
+				return err
+			}
+			if info.IsDir() {
+				continue
+			}
+			data, err := fs.ReadFile(fsys, name)
+			if nil != err {
+				return err
+			}
+			lang, format := splitFileName(path.Base(name))
+			if err := b.Parse(lang, format, data); nil != err {
+				return fmt.Errorf("加载消息文件 %s 失败：%w", name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Localizer 创建按语言偏好查找消息的 Localizer。
+//
+// 回退链依次为：每个偏好语言及其父级标签、语言子标签相同的已加载语言（共有子标签多者优先）、默认语言及其父级标签。
+// 例如偏好 `zh-Hant-TW`、默认语言 `en` 时依次查找 `zh-Hant-TW`、`zh-Hant`、`zh`、已加载的 `zh-*`、`en`。
+// 回退链在创建时按已加载的语言计算，之后加载的新语言需要重新创建 Localizer 才能参与匹配。
+//
+// 参数：
+//   - langs: 按优先级从高到低排列的语言标签，通常来自 ParseAcceptLanguage。
+//
+// 返回：
+//   - *Localizer: 可以并发使用的 Localizer。
+func (b *Bundle) Localizer(langs ...string) *Localizer {
+	b.mu.RLock()
+	loaded := make([]string, 0, len(b.messages))
+	for lang := range b.messages {
+		loaded = append(loaded, lang)
+	}
+	b.mu.RUnlock()
+	sort.Strings(loaded)
+
+	l := &Localizer{bundle: b}
+	seen := make(map[string]struct{})
+	add := func(lang string) {
+		if _, ok := seen[lang]; !ok {
+			seen[lang] = struct{}{}
+			l.languages = append(l.languages, lang)
+		}
+	}
+	for _, lang := range langs {
+		for _, parent := range ParentLanguages(lang) {
+			add(parent)
+		}
+		for _, candidate := range sameBaseLanguages(lang, loaded) {
+			add(candidate)
+		}
+	}
+	for _, parent := range ParentLanguages(b.defaultLanguage) {
+		add(parent)
+	}
+	return l
+}
+
+// Match 返回与语言偏好最匹配的已加载语言。
+//
+// 参数：
+//   - langs: 按优先级从高到低排列的语言标签。
+//
+// 返回：
+//   - string: 回退链上第一个已加载的语言；均未加载时返回默认语言。
+func (b *Bundle) Match(langs ...string) string {
+	return b.Localizer(langs...).Language()
+}
+
+// add 将解析后的消息添加到指定语言。
+//
+// 参数：
+//   - lang: 语言标签。
+//   - messages: 解析后的消息。
+//
+// 返回：
+//   - error: 语言标签为空时返回包装了 ErrInvalidMessage 的错误。
+func (b *Bundle) add(lang string, messages map[string]*message) error {
+	if lang = CanonicalLanguage(lang); "" == lang {
+		return fmt.Errorf("%w: 语言标签为空", ErrInvalidMessage)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	target, ok := b.messages[lang]
+	if !ok {
+		target = make(map[string]*message, len(messages))
+		b.messages[lang] = target
+	}
+	for key, m := range messages {
+		target[key] = m
+	}
+	return nil
+}
+
+// lookup 按语言查找消息。
+//
+// 参数：
+//   - lang: 规范化的语言标签。
+//   - key: 消息键。
+//
+// 返回：
+//   - *message: 找到的消息。
+//   - bool: 找到时返回 true。
+func (b *Bundle) lookup(lang, key string) (*message, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	m, ok := b.messages[lang][key]
+	return m, ok
+}
+
+// hasLanguage 判断语言是否已加载消息。
+//
+// 参数：
+//   - lang: 规范化的语言标签。
+//
+// 返回：
+//   - bool: 已加载时返回 true。
+func (b *Bundle) hasLanguage(lang string) bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	_, ok := b.messages[lang]
+	return ok
+}
+
+// sameBaseLanguages 返回与语言标签的语言子标签相同的已加载语言。
+//
+// 参数：
+//   - lang: 语言标签。
+//   - loaded: 按字典序排列的已加载语言。
+//
+// 返回：
+//   - []string: 按与 lang 共有的文字、地区等子标签数量从多到少排列的语言，数量相同时保持字典序；
+//     例如 `zh-Hant-TW` 优先匹配 `zh-TW` 而不是 `zh-CN`。
+func sameBaseLanguages(lang string, loaded []string) []string {
+	base := BaseLanguage(lang)
+	subtags := strings.Split(CanonicalLanguage(lang), "-")[1:]
+	shared := func(candidate string) int {
+		n := 0
+		for _, s := range strings.Split(candidate, "-")[1:] {
+			for _, want := range subtags {
+				if s == want {
+					n++
+					break
+				}
+			}
+		}
+		return n
+	}
+
+	var candidates []string
+	for _, candidate := range loaded {
+		if base == BaseLanguage(candidate) {
+			candidates = append(candidates, candidate)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return shared(candidates[i]) > shared(candidates[j])
+	})
+	return candidates
+}
+
+// newMessage 解析消息的各复数类别文案。
+//
+// 参数：
+//   - key: 消息键，用作模板名称。
+//   - forms: 复数类别到文案的映射。
+//
+// 返回：
+//   - *message: 解析后的消息。
+//   - error: 缺少 PluralOther、类别不合法或模板无法解析时返回包装了 ErrInvalidMessage 的错误。
+func newMessage(key string, forms map[PluralCategory]string) (*message, error) {
+	if "" == key {
+		return nil, fmt.Errorf("%w: 消息键为空", ErrInvalidMessage)
+	}
+	if _, ok := forms[PluralOther]; !ok {
+		return nil, fmt.Errorf("%w: 消息 %s 缺少 other 文案", ErrInvalidMessage, key)
+	}
+	m := &message{forms: make(map[PluralCategory]*text, len(forms))}
+	for category, raw := range forms {
+		if !isPluralCategory(string(category)) {
+			return nil, fmt.Errorf("%w: 消息 %s 的复数类别 %q 不合法", ErrInvalidMessage, key, category)
+		}
+		t := &text{raw: raw}
+		if strings.Contains(raw, "{{") {
+			tmpl, err := template.New(key).Parse(raw)
+			if nil != err {
+				return nil, fmt.Errorf("%w: %v", ErrInvalidMessage, err)
+			}
+			t.tmpl = tmpl
+		}
+		m.forms[category] = t
+	}
+	return m, nil
+}
+
+// flatten 将解码后的嵌套映射展开为消息。
+//
+// 参数：
+//   - prefix: 当前层级的键前缀。
+//   - raw: 解码后的映射。
+//   - out: 展开结果。
+//
+// 返回：
+//   - error: 取值既不是字符串也不是映射，或复数消息不合法时返回包装了 ErrInvalidMessage 的错误。
+func flatten(prefix string, raw map[string]interface{}, out map[string]*message) error {
+	for k, v := range raw {
+		key := k
+		if "" != prefix {
+			key = prefix + "." + k
+		}
+		switch value := v.(type) {
+		case string:
+			m, err := newMessage(key, map[PluralCategory]string{PluralOther: value})
+			if nil != err {
+				return err
+			}
+			out[key] = m
+		case map[string]interface{}:
+			if forms, ok := pluralForms(value); ok {
+				m, err := newMessage(key, forms)
+				if nil != err {
+					return err
+				}
+				out[key] = m
+				continue
+			}
+			if err := flatten(key, value, out); nil != err {
+				return err
+			}
+		default:
+			return fmt.Errorf("%w: 消息 %s 的取值类型 %T 不是字符串", ErrInvalidMessage, key, v)
+		}
+	}
+	return nil
+}
+
+// pluralForms 判断映射是否为复数消息。
+//
+// 参数：
+//   - raw: 解码后的映射。
+//
+// 返回：
+//   - map[PluralCategory]string: 复数类别到文案的映射。
+//   - bool: 映射非空、键全部为复数类别且取值全部为字符串时返回 true。
+func pluralForms(raw map[string]interface{}) (map[PluralCategory]string, bool) {
+	if 0 == len(raw) {
+		return nil, false
+	}
+	forms := make(map[PluralCategory]string, len(raw))
+	for k, v := range raw {
+		s, ok := v.(string)
+		if !ok || !isPluralCategory(k) {
+			return nil, false
+		}
+		forms[PluralCategory(k)] = s
+	}
+	return forms, true
+}
+
+// isPluralCategory 判断字符串是否为复数类别。
+//
+// 参数：
+//   - s: 待判断的字符串。
+//
+// 返回：
+//   - bool: s 为 zero、one、two、few、many 或 other 时返回 true。
+func isPluralCategory(s string) bool {
+	switch PluralCategory(s) {
+	case PluralZero, PluralOne, PluralTwo, PluralFew, PluralMany, PluralOther:
+		return true
+	default:
+		return false
+	}
+}
+
+// splitFileName 从消息文件名中拆分语言与格式。
+//
+// 参数：
+//   - name: 不含目录的文件名。
+//
+// 返回：
+//   - string: 语言标签。
+//   - string: 不含 "." 的扩展名。
+func splitFileName(name string) (string, string) {
+	ext := path.Ext(name)
+	stem := strings.TrimSuffix(name, ext)
+	if i := strings.LastIndex(stem, "."); i >= 0 {
+		stem = stem[i+1:]
+	}
+	return stem, strings.TrimPrefix(ext, ".")
+}
@@ -0,0 +1,131 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package i18n
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestBundle_Parse 验证 JSON 与 YAML 消息的解析。
+func TestBundle_Parse(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		format      string
+		give        string
+		wantErr     error
+	}{
+		{
+			name:        "success/json",
+			description: "验证 JSON 嵌套键与复数消息。",
+			format:      "json",
+			give:        `{"user":{"greeting":"Hello"},"apples":{"one":"one apple","other":"{{.Count}} apples"}}`,
+		},
+		{
+			name:        "success/yaml",
+			description: "验证 YAML 嵌套键与复数消息。",
+			format:      ".YML",
+			give:        "user:\n  greeting: Hello\napples:\n  one: one apple\n  other: \"{{.Count}} apples\"\n",
+		},
+		{
+			name:        "error/format",
+			description: "验证不支持的格式。",
+			format:      "toml",
+			give:        `a = "b"`,
+			wantErr:     ErrUnsupportedFormat,
+		},
+		{
+			name:        "error/value",
+			description: "验证非字符串取值。",
+			format:      "json",
+			give:        `{"count":1}`,
+			wantErr:     ErrInvalidMessage,
+		},
+		{
+			name:        "error/template",
+			description: "验证无法解析的模板。",
+			format:      "json",
+			give:        `{"broken":"{{.Name"}`,
+			wantErr:     ErrInvalidMessage,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			b := NewBundle("en")
+			err := b.Parse("en", tt.format, []byte(tt.give))
+			if nil != tt.wantErr {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Empty(t, b.Languages())
+				return
+			}
+			require.NoError(t, err)
+
+			l := b.Localizer("en")
+			assert.Equal(t, "Hello", l.T("user.greeting", nil))
+			assert.Equal(t, "one apple", l.N("apples", 1, nil))
+			assert.Equal(t, "3 apples", l.N("apples", 3, nil))
+		})
+	}
+}
+
+// TestBundle_AddPluralMessage 验证复数消息的校验。
+func TestBundle_AddPluralMessage(t *testing.T) {
+	b := NewBundle("")
+	assert.Equal(t, "zh-CN", b.DefaultLanguage())
+
+	err := b.AddPluralMessage("en", "apples", map[PluralCategory]string{PluralOne: "one apple"})
+	assert.ErrorIs(t, err, ErrInvalidMessage)
+	err = b.AddPluralMessage("en", "apples", map[PluralCategory]string{"lots": "x", PluralOther: "y"})
+	assert.ErrorIs(t, err, ErrInvalidMessage)
+	err = b.AddMessages(" ", map[string]string{"a": "b"})
+	assert.ErrorIs(t, err, ErrInvalidMessage)
+}
+
+// TestBundle_LoadFS 验证从文件系统加载消息。
+func TestBundle_LoadFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"zh-CN.yaml":              {Data: []byte("hello: 你好\n")},
+		"active.en.json":          {Data: []byte(`{"hello":"Hello"}`)},
+		"README.md":               {Data: []byte("# locales")},
+		"locales/ja.yml":          {Data: []byte("hello: こんにちは\n")},
+		"locales/sub.json/x.json": {Data: []byte(`{}`)},
+		"broken/en.json":          {Data: []byte(`{`)},
+		"unsupported/en-US.toml":  {Data: []byte(`hello = "Hi"`)},
+	}
+
+	b := NewBundle("en")
+	require.NoError(t, b.LoadFS(fsys))
+	assert.Equal(t, []string{"en", "zh-CN"}, b.Languages())
+
+	require.NoError(t, b.LoadFS(fsys, "locales/*"))
+	assert.Equal(t, []string{"en", "ja", "zh-CN"}, b.Languages())
+	assert.Equal(t, "こんにちは", b.Localizer("ja-JP").T("hello", nil))
+
+	assert.Error(t, b.LoadFS(fsys, "broken/*.json"))
+	assert.ErrorIs(t, b.LoadFS(fsys, "unsupported/*"), ErrUnsupportedFormat)
+	assert.Error(t, b.LoadFS(fsys, "["))
+}
+
+// TestBundle_LoadFile 验证从文件加载消息。
+func TestBundle_LoadFile(t *testing.T) {
+	dir := t.TempDir()
+	name := filepath.Join(dir, "messages.fr.json")
+	require.NoError(t, os.WriteFile(name, []byte(`{"hello":"Bonjour"}`), 0o600))
+
+	b := NewBundle("en")
+	require.NoError(t, b.LoadFile(name))
+	assert.Equal(t, "Bonjour", b.Localizer("fr").T("hello", nil))
+	assert.Error(t, b.LoadFile(filepath.Join(dir, "missing.json")))
+}
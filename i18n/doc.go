@@ -0,0 +1,19 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+// Package i18n 提供多语言消息包、复数规则、模板变量与语言协商。
+//
+// Bundle 保存多种语言的消息，可以通过 AddMessages、AddPluralMessage 直接添加，也可以通过 LoadFS（通常配合
+// embed.FS）、LoadFile 与 Parse 加载 JSON 或 YAML 文件，语言取自文件名。文件中的嵌套映射以 "." 连接为消息键，
+// 键全部为 zero、one、two、few、many、other 的映射视为一条复数消息。文案可以包含 text/template 动作，
+// 在加载时预解析。
+//
+// Bundle.Localizer 按语言偏好创建 Localizer，回退链依次为偏好语言及其父级标签、语言子标签相同的已加载语言、
+// 默认语言，任一环节缺少消息时继续向后查找。Localize 返回错误，T 与 N 在消息缺失时返回消息键本身；
+// N 按语言的 CLDR 整数复数规则选择文案，规则可以通过 WithPluralRule 覆盖。
+//
+// ParseAcceptLanguage 解析 Accept-Language 请求头，NewContext 与 FromContext 在 context 中传递 Localizer，
+// kratos/middleware/locale 基于它们实现服务端语言协商。Localizer.Language 返回协商出的语言，可以直接传给
+// time.DiffForHumans 输出同一语言的相对时间。
+package i18n
@@ -0,0 +1,151 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package i18n
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// CanonicalLanguage 将语言标签规范化为 BCP 47 的常用书写形式。
+//
+// 语言子标签转为小写，4 个字母的文字子标签首字母大写，2 个字母的地区子标签转为大写，下划线视为连字符，
+// 例如 `zh_hant_tw` 规范化为 `zh-Hant-TW`，`EN-us` 规范化为 `en-US`。
+//
+// 参数：
+//   - tag: 待规范化的语言标签。
+//
+// 返回：
+//   - string: 规范化后的语言标签；tag 为空白时返回空字符串。
+func CanonicalLanguage(tag string) string {
+	tag = strings.TrimSpace(strings.ReplaceAll(tag, "_", "-"))
+	if "" == tag {
+		return ""
+	}
+	parts := strings.Split(tag, "-")
+	for i, part := range parts {
+		switch {
+		case 0 == i:
+			parts[i] = strings.ToLower(part)
+		case 4 == len(part) && isLetters(part):
+			parts[i] = strings.ToUpper(part[:1]) + strings.ToLower(part[1:])
+		case 2 == len(part) && isLetters(part):
+			parts[i] = strings.ToUpper(part)
+		default:
+			parts[i] = strings.ToLower(part)
+		}
+	}
+	return strings.Join(parts, "-")
+}
+
+// ParentLanguages 返回语言标签及其逐级去掉末尾子标签后的父级标签。
+//
+// 参数：
+//   - tag: 语言标签，会先经过 CanonicalLanguage 规范化。
+//
+// 返回：
+//   - []string: 由具体到宽泛排列的标签，例如 `zh-Hant-TW` 返回 `zh-Hant-TW`、`zh-Hant`、`zh`；
+//     tag 为空白时返回 nil。
+func ParentLanguages(tag string) []string {
+	tag = CanonicalLanguage(tag)
+	if "" == tag {
+		return nil
+	}
+	chain := []string{tag}
+	for i := strings.LastIndex(tag, "-"); i > 0; i = strings.LastIndex(tag, "-") {
+		tag = tag[:i]
+		chain = append(chain, tag)
+	}
+	return chain
+}
+
+// BaseLanguage 返回语言标签的语言子标签。
+//
+// 参数：
+//   - tag: 语言标签。
+//
+// 返回：
+//   - string: 小写的语言子标签，例如 `zh-Hant-TW` 返回 `zh`。
+func BaseLanguage(tag string) string {
+	tag = CanonicalLanguage(tag)
+	if i := strings.Index(tag, "-"); i > 0 {
+		return tag[:i]
+	}
+	return tag
+}
+
+// ParseAcceptLanguage 解析 HTTP Accept-Language 请求头。
+//
+// 参数：
+//   - header: 请求头取值，例如 `zh-CN,zh;q=0.9,en;q=0.8`。
+//
+// 返回：
+//   - []string: 按权重从高到低排列的规范化语言标签，权重相同时保持原有顺序；q=0、通配符 `*`
+//     与无法解析的权重会被忽略，重复的标签只保留第一次出现的位置。
+func ParseAcceptLanguage(header string) []string {
+	type weighted struct {
+		tag string
+		q   float64
+	}
+
+	var items []weighted
+	seen := make(map[string]struct{})
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(part, ";")
+		tag := CanonicalLanguage(fields[0])
+		if "" == tag || "*" == tag {
+			continue
+		}
+		q := 1.0
+		valid := true
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if !strings.HasPrefix(param, "q=") {
+				continue
+			}
+			v, err := strconv.ParseFloat(param[2:], 64)
+			if nil != err || v < 0 || v > 1 {
+				valid = false
+				break
+			}
+			q = v
+		}
+		if !valid || 0 == q {
+			continue
+		}
+		if _, ok := seen[tag]; ok {
+			continue
+		}
+		seen[tag] = struct{}{}
+		items = append(items, weighted{tag: tag, q: q})
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].q > items[j].q
+	})
+	tags := make([]string, 0, len(items))
+	for _, item := range items {
+		tags = append(tags, item.tag)
+	}
+	return tags
+}
+
+// isLetters 判断字符串是否只包含 ASCII 字母。
+//
+// 参数：
+//   - s: 待判断的字符串。
+//
+// 返回：
+//   - bool: s 只包含 ASCII 字母时返回 true。
+func isLetters(s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i] | 0x20
+		if c < 'a' || c > 'z' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,67 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package i18n
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCanonicalLanguage 验证语言标签规范化。
+func TestCanonicalLanguage(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		give        string
+		want        string
+	}{
+		{name: "success/region", description: "验证地区子标签转为大写。", give: "EN-us", want: "en-US"},
+		{name: "success/underscore", description: "验证下划线视为连字符，文字子标签首字母大写。", give: "zh_hant_tw", want: "zh-Hant-TW"},
+		{name: "success/numeric-region", description: "验证数字地区子标签保持原样。", give: "es-419", want: "es-419"},
+		{name: "success/blank", description: "验证空白输入返回空字符串。", give: "  ", want: ""},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			assert.Equal(t, tt.want, CanonicalLanguage(tt.give))
+		})
+	}
+}
+
+// TestParentLanguages 验证父级标签的计算。
+func TestParentLanguages(t *testing.T) {
+	assert.Equal(t, []string{"zh-Hant-TW", "zh-Hant", "zh"}, ParentLanguages("zh-hant-tw"))
+	assert.Equal(t, []string{"en"}, ParentLanguages("en"))
+	assert.Nil(t, ParentLanguages(""))
+	assert.Equal(t, "zh", BaseLanguage("zh-CN"))
+}
+
+// TestParseAcceptLanguage 验证 Accept-Language 请求头解析。
+func TestParseAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		give        string
+		want        []string
+	}{
+		{name: "success/weights", description: "验证按权重排序。", give: "en;q=0.8, zh-CN, zh;q=0.9", want: []string{"zh-CN", "zh", "en"}},
+		{name: "success/stable", description: "验证权重相同时保持原有顺序。", give: "fr, de", want: []string{"fr", "de"}},
+		{name: "success/skip", description: "验证忽略 q=0、通配符、非法权重与重复标签。", give: "ja;q=0, *, ko;q=x, en, EN;q=0.5", want: []string{"en"}},
+		{name: "success/empty", description: "验证空请求头。", give: "", want: []string{}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			assert.Equal(t, tt.want, ParseAcceptLanguage(tt.give))
+		})
+	}
+}
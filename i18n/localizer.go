@@ -0,0 +1,232 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package i18n
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+const (
+	// countKey 是复数消息模板中数量变量的名称。
+	countKey = "Count"
+)
+
+type (
+	// LocalizeOption 配置单次 Localize 调用。
+	LocalizeOption func(*localizeOptions)
+
+	// localizeOptions 包含单次查找的配置。
+	localizeOptions struct {
+		// count 是选择复数类别使用的数量。
+		count int
+		// hasCount 表示是否设置了 count。
+		hasCount bool
+		// data 是模板变量。
+		data map[string]interface{}
+	}
+
+	// Localizer 按语言偏好的回退链查找并渲染消息，由 Bundle.Localizer 创建。
+	//
+	// Localizer 可以并发使用；nil Localizer 的 T 与 N 返回消息键本身，便于在未注入 Localizer 的上下文中调用。
+	Localizer struct {
+		// bundle 是消息来源。
+		bundle *Bundle
+		// languages 是按优先级排列的回退链。
+		languages []string
+	}
+
+	// contextKey 是 Localizer 在 context 中的键。
+	contextKey struct{}
+)
+
+// WithCount 设置选择复数类别使用的数量，同时作为模板变量 `.Count` 传入。
+//
+// 参数：
+//   - n: 数量。
+//
+// 返回：
+//   - LocalizeOption: 查找配置选项。
+func WithCount(n int) LocalizeOption {
+	return func(o *localizeOptions) {
+		o.count = n
+		o.hasCount = true
+	}
+}
+
+// WithData 设置模板变量。
+//
+// 参数：
+//   - data: 模板变量，模板中以 `{{.Name}}` 的形式引用；不会被修改。
+//
+// 返回：
+//   - LocalizeOption: 查找配置选项。
+func WithData(data map[string]interface{}) LocalizeOption {
+	return func(o *localizeOptions) {
+		o.data = data
+	}
+}
+
+// Language 返回回退链上第一个已加载消息的语言。
+//
+// 返回值可以直接作为 time.DiffForHumans 的语言环境，使相对时间与消息使用同一语言。
+//
+// 参数：无。
+//
+// 返回：
+//   - string: 规范化的语言标签；均未加载时返回默认语言，nil Localizer 返回空字符串。
+func (l *Localizer) Language() string {
+	if nil == l {
+		return ""
+	}
+	for _, lang := range l.languages {
+		if l.bundle.hasLanguage(lang) {
+			return lang
+		}
+	}
+	return l.bundle.defaultLanguage
+}
+
+// Languages 返回 Localizer 的回退链。
+//
+// 参数：无。
+//
+// 返回：
+//   - []string: 按查找顺序排列的规范化语言标签副本。
+func (l *Localizer) Languages() []string {
+	if nil == l {
+		return nil
+	}
+	return append([]string(nil), l.languages...)
+}
+
+// Localize 查找并渲染消息。
+//
+// 沿回退链查找第一个定义了 key 的语言。设置了 WithCount 时按该语言的复数规则选择文案：数量为 0 且定义了
+// zero 文案时优先使用 zero，其次使用规则返回的类别，未定义该类别时使用 other。
+//
+// 参数：
+//   - key: 消息键。
+//   - opts: 查找配置选项。
+//
+// 返回：
+//   - string: 渲染后的文案。
+//   - error: 回退链上均未定义 key 时返回包装了 ErrMessageNotFound 的错误，模板执行失败时返回执行错误。
+func (l *Localizer) Localize(key string, opts ...LocalizeOption) (string, error) {
+	if nil == l {
+		return "", fmt.Errorf("%w: %s", ErrMessageNotFound, key)
+	}
+	o := &localizeOptions{}
+	for _, opt := range opts {
+		if nil != opt {
+			opt(o)
+		}
+	}
+
+	for _, lang := range l.languages {
+		m, ok := l.bundle.lookup(lang, key)
+		if !ok {
+			continue
+		}
+		t := m.forms[PluralOther]
+		if o.hasCount {
+			if form, ok := m.forms[PluralZero]; ok && 0 == o.count {
+				t = form
+			} else if form, ok := m.forms[pluralRuleFor(lang, l.bundle.pluralRules)(o.count)]; ok {
+				t = form
+			}
+		}
+		return t.render(o)
+	}
+	return "", fmt.Errorf("%w: %s", ErrMessageNotFound, key)
+}
+
+// T 查找并渲染非复数消息。
+//
+// 参数：
+//   - key: 消息键。
+//   - data: 模板变量，可以为 nil。
+//
+// 返回：
+//   - string: 渲染后的文案；消息不存在或模板执行失败时返回 key，使界面上至少显示可追查的键。
+func (l *Localizer) T(key string, data map[string]interface{}) string {
+	s, err := l.Localize(key, WithData(data))
+	if nil != err {
+		return key
+	}
+	return s
+}
+
+// N 查找并渲染复数消息。
+//
+// 参数：
+//   - key: 消息键。
+//   - count: 数量，用于选择复数类别并作为模板变量 `.Count` 传入。
+//   - data: 其他模板变量，可以为 nil。
+//
+// 返回：
+//   - string: 渲染后的文案；消息不存在或模板执行失败时返回 key。
+func (l *Localizer) N(key string, count int, data map[string]interface{}) string {
+	s, err := l.Localize(key, WithCount(count), WithData(data))
+	if nil != err {
+		return key
+	}
+	return s
+}
+
+// render 渲染文案。
+//
+// 参数：
+//   - o: 查找配置，提供模板变量与数量。
+//
+// 返回：
+//   - string: 渲染后的文案，不含模板动作时为原始文案。
+//   - error: 模板执行失败时返回执行错误。
+func (t *text) render(o *localizeOptions) (string, error) {
+	if nil == t.tmpl {
+		return t.raw, nil
+	}
+	data := o.data
+	if o.hasCount {
+		data = make(map[string]interface{}, len(o.data)+1)
+		for k, v := range o.data {
+			data[k] = v
+		}
+		if _, ok := data[countKey]; !ok {
+			data[countKey] = o.count
+		}
+	}
+	var sb strings.Builder
+	if err := t.tmpl.Execute(&sb, data); nil != err {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+// NewContext 返回携带 Localizer 的 context。
+//
+// 参数：
+//   - ctx: 父 context。
+//   - l: 当前请求使用的 Localizer。
+//
+// 返回：
+//   - context.Context: 携带 Localizer 的 context。
+func NewContext(ctx context.Context, l *Localizer) context.Context {
+	return context.WithValue(ctx, contextKey{}, l)
+}
+
+// FromContext 从 context 中取出 Localizer。
+//
+// 参数：
+//   - ctx: 由 NewContext 或语言协商中间件生成的 context。
+//
+// 返回：
+//   - *Localizer: 取出的 Localizer；不存在时为 nil，仍可安全调用 T 与 N。
+//   - bool: 存在时返回 true。
+func FromContext(ctx context.Context) (*Localizer, bool) {
+	l, ok := ctx.Value(contextKey{}).(*Localizer)
+	return l, ok && nil != l
+}
@@ -0,0 +1,181 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package i18n
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestBundle 创建测试使用的消息包。
+//
+// 参数：
+//   - t: 测试上下文。
+//
+// 返回：
+//   - *Bundle: 包含 en、zh-CN 与 zh-TW 消息、默认语言为 en 的消息包。
+func newTestBundle(t *testing.T) *Bundle {
+	b := NewBundle("en")
+	require.NoError(t, b.AddMessages("en", map[string]string{
+		"hello":   "Hello, {{.Name}}",
+		"only.en": "English only",
+		"broken":  "{{.Name.Missing}}",
+	}))
+	require.NoError(t, b.AddPluralMessage("en", "items", map[PluralCategory]string{
+		PluralZero:  "no items",
+		PluralOne:   "one item",
+		PluralOther: "{{.Count}} items in {{.Place}}",
+	}))
+	require.NoError(t, b.AddMessages("zh-CN", map[string]string{"hello": "你好，{{.Name}}"}))
+	require.NoError(t, b.AddPluralMessage("zh-CN", "items", map[PluralCategory]string{
+		PluralOne:   "一件",
+		PluralOther: "{{.Count}} 件",
+	}))
+	require.NoError(t, b.AddMessages("zh-TW", map[string]string{"hello": "妳好，{{.Name}}"}))
+	return b
+}
+
+// TestLocalizer_Localize 验证消息查找、复数选择与回退。
+func TestLocalizer_Localize(t *testing.T) {
+	b := newTestBundle(t)
+
+	tests := []struct {
+		name        string
+		description string
+		langs       []string
+		key         string
+		opts        []LocalizeOption
+		want        string
+		wantErr     error
+	}{
+		{
+			name:        "success/exact",
+			description: "验证精确匹配语言并渲染模板变量。",
+			langs:       []string{"zh-CN"},
+			key:         "hello",
+			opts:        []LocalizeOption{WithData(map[string]interface{}{"Name": "世界"})},
+			want:        "你好，世界",
+		},
+		{
+			name:        "success/base-match",
+			description: "验证只有语言子标签时匹配同语言的已加载语言。",
+			langs:       []string{"zh"},
+			key:         "hello",
+			opts:        []LocalizeOption{WithData(map[string]interface{}{"Name": "世界"})},
+			want:        "你好，世界",
+		},
+		{
+			name:        "success/fallback-default",
+			description: "验证偏好语言缺少消息时回退到默认语言。",
+			langs:       []string{"zh-CN"},
+			key:         "only.en",
+			want:        "English only",
+		},
+		{
+			name:        "success/unknown-language",
+			description: "验证未加载的偏好语言回退到默认语言。",
+			langs:       []string{"de-DE"},
+			key:         "hello",
+			opts:        []LocalizeOption{WithData(map[string]interface{}{"Name": "Welt"})},
+			want:        "Hello, Welt",
+		},
+		{
+			name:        "success/plural-zero",
+			description: "验证数量为 0 时优先使用 zero 文案。",
+			langs:       []string{"en"},
+			key:         "items",
+			opts:        []LocalizeOption{WithCount(0)},
+			want:        "no items",
+		},
+		{
+			name:        "success/plural-other",
+			description: "验证复数文案同时使用数量与其他模板变量。",
+			langs:       []string{"en"},
+			key:         "items",
+			opts:        []LocalizeOption{WithCount(3), WithData(map[string]interface{}{"Place": "cart"})},
+			want:        "3 items in cart",
+		},
+		{
+			name:        "success/plural-rule",
+			description: "验证按语言规则选择类别，中文数量为 1 时仍使用 other。",
+			langs:       []string{"zh-CN"},
+			key:         "items",
+			opts:        []LocalizeOption{WithCount(1)},
+			want:        "1 件",
+		},
+		{
+			name:        "success/no-count",
+			description: "验证未设置数量时使用 other 文案。",
+			langs:       []string{"zh-CN"},
+			key:         "items",
+			opts:        []LocalizeOption{WithData(map[string]interface{}{"Count": "若干"})},
+			want:        "若干 件",
+		},
+		{
+			name:        "error/not-found",
+			description: "验证回退链上均未定义消息。",
+			langs:       []string{"en"},
+			key:         "missing",
+			wantErr:     ErrMessageNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			got, err := b.Localizer(tt.langs...).Localize(tt.key, tt.opts...)
+			if nil != tt.wantErr {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// TestLocalizer_Language 验证语言匹配结果。
+func TestLocalizer_Language(t *testing.T) {
+	b := newTestBundle(t)
+
+	assert.Equal(t, "zh-TW", b.Match("zh-Hant-TW"))
+	assert.Equal(t, "zh-CN", b.Match("zh-Hans"))
+	assert.Equal(t, "en", b.Match("ko", "en-GB"))
+	assert.Equal(t, "en", b.Match())
+	assert.Equal(t, []string{"fr-CA", "fr", "en"}, b.Localizer("fr-CA").Languages())
+}
+
+// TestLocalizer_TN 验证 T 与 N 的降级行为。
+func TestLocalizer_TN(t *testing.T) {
+	b := newTestBundle(t)
+	l := b.Localizer("en")
+
+	assert.Equal(t, "missing", l.T("missing", nil))
+	assert.Equal(t, "broken", l.T("broken", map[string]interface{}{"Name": "x"}))
+	assert.Equal(t, "one item", l.N("items", 1, nil))
+
+	var nilLocalizer *Localizer
+	assert.Equal(t, "hello", nilLocalizer.T("hello", nil))
+	assert.Equal(t, "items", nilLocalizer.N("items", 2, nil))
+	assert.Equal(t, "", nilLocalizer.Language())
+}
+
+// TestContext 验证 Localizer 在 context 中的存取。
+func TestContext(t *testing.T) {
+	l := newTestBundle(t).Localizer("zh-CN")
+
+	got, ok := FromContext(NewContext(context.Background(), l))
+	assert.True(t, ok)
+	assert.Same(t, l, got)
+
+	got, ok = FromContext(context.Background())
+	assert.False(t, ok)
+	assert.Nil(t, got)
+}
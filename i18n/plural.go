@@ -0,0 +1,219 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package i18n
+
+const (
+	// PluralZero 是 CLDR 的 zero 复数类别，也用于为数量 0 提供专门的文案。
+	PluralZero PluralCategory = "zero"
+	// PluralOne 是 CLDR 的 one 复数类别。
+	PluralOne PluralCategory = "one"
+	// PluralTwo 是 CLDR 的 two 复数类别。
+	PluralTwo PluralCategory = "two"
+	// PluralFew 是 CLDR 的 few 复数类别。
+	PluralFew PluralCategory = "few"
+	// PluralMany 是 CLDR 的 many 复数类别。
+	PluralMany PluralCategory = "many"
+	// PluralOther 是 CLDR 的 other 复数类别，所有语言都必须提供该类别的文案。
+	PluralOther PluralCategory = "other"
+)
+
+type (
+	// PluralCategory 是 CLDR 定义的复数类别。
+	PluralCategory string
+
+	// PluralRule 根据数量返回语言使用的复数类别。
+	//
+	// 参数：
+	//   - n: 数量，负数按绝对值处理。
+	//
+	// 返回：
+	//   - PluralCategory: n 对应的复数类别。
+	PluralRule func(n int) PluralCategory
+)
+
+var (
+	// pluralRules 是按语言子标签索引的内置整数复数规则，参考 CLDR 的整数部分。
+	pluralRules = map[string]PluralRule{}
+)
+
+// init 注册内置的复数规则。
+//
+// 参数：无。
+func init() {
+	for _, lang := range []string{"zh", "ja", "ko", "vi", "th", "id", "ms", "lo", "my", "km"} {
+		pluralRules[lang] = pluralRuleOther
+	}
+	for _, lang := range []string{"fr", "hi", "fa", "pt"} {
+		pluralRules[lang] = pluralRuleZeroOne
+	}
+	for _, lang := range []string{"ru", "uk", "be"} {
+		pluralRules[lang] = pluralRuleEastSlavic
+	}
+	for _, lang := range []string{"cs", "sk"} {
+		pluralRules[lang] = pluralRuleCzech
+	}
+	pluralRules["pl"] = pluralRulePolish
+	pluralRules["ar"] = pluralRuleArabic
+}
+
+// pluralRuleFor 返回语言使用的复数规则。
+//
+// 参数：
+//   - tag: 语言标签。
+//   - custom: 调用方注册的规则，优先于内置规则。
+//
+// 返回：
+//   - PluralRule: 按完整标签、父级标签、语言子标签依次查找的规则；均未找到时使用英语规则。
+func pluralRuleFor(tag string, custom map[string]PluralRule) PluralRule {
+	for _, lang := range ParentLanguages(tag) {
+		if rule, ok := custom[lang]; ok {
+			return rule
+		}
+	}
+	if rule, ok := pluralRules[BaseLanguage(tag)]; ok {
+		return rule
+	}
+	return pluralRuleOneOther
+}
+
+// abs 返回整数的绝对值。
+//
+// 参数：
+//   - n: 整数。
+//
+// 返回：
+//   - int: n 的绝对值。
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// pluralRuleOther 是不区分单复数的语言使用的规则，例如中文、日文。
+//
+// 参数：
+//   - int: 数量，不参与判断。
+//
+// 返回：
+//   - PluralCategory: 始终为 PluralOther。
+func pluralRuleOther(int) PluralCategory {
+	return PluralOther
+}
+
+// pluralRuleOneOther 是英语、德语等语言使用的规则，也是未知语言的默认规则。
+//
+// 参数：
+//   - n: 数量。
+//
+// 返回：
+//   - PluralCategory: 数量为 1 时为 PluralOne，否则为 PluralOther。
+func pluralRuleOneOther(n int) PluralCategory {
+	if 1 == abs(n) {
+		return PluralOne
+	}
+	return PluralOther
+}
+
+// pluralRuleZeroOne 是法语等语言使用的规则，0 与 1 都属于单数。
+//
+// 参数：
+//   - n: 数量。
+//
+// 返回：
+//   - PluralCategory: 数量为 0 或 1 时为 PluralOne，否则为 PluralOther。
+func pluralRuleZeroOne(n int) PluralCategory {
+	if abs(n) <= 1 {
+		return PluralOne
+	}
+	return PluralOther
+}
+
+// pluralRuleEastSlavic 是俄语、乌克兰语与白俄罗斯语使用的规则。
+//
+// 参数：
+//   - n: 数量。
+//
+// 返回：
+//   - PluralCategory: 个位为 1（11 除外）时为 PluralOne，个位为 2 到 4（12 到 14 除外）时为 PluralFew，
+//     否则为 PluralMany。
+func pluralRuleEastSlavic(n int) PluralCategory {
+	n = abs(n)
+	mod10, mod100 := n%10, n%100
+	switch {
+	case 1 == mod10 && 11 != mod100:
+		return PluralOne
+	case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+		return PluralFew
+	default:
+		return PluralMany
+	}
+}
+
+// pluralRulePolish 是波兰语使用的规则。
+//
+// 参数：
+//   - n: 数量。
+//
+// 返回：
+//   - PluralCategory: 数量为 1 时为 PluralOne，个位为 2 到 4（12 到 14 除外）时为 PluralFew，否则为 PluralMany。
+func pluralRulePolish(n int) PluralCategory {
+	n = abs(n)
+	mod10, mod100 := n%10, n%100
+	switch {
+	case 1 == n:
+		return PluralOne
+	case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+		return PluralFew
+	default:
+		return PluralMany
+	}
+}
+
+// pluralRuleCzech 是捷克语与斯洛伐克语使用的规则。
+//
+// 参数：
+//   - n: 数量。
+//
+// 返回：
+//   - PluralCategory: 数量为 1 时为 PluralOne，2 到 4 时为 PluralFew，否则为 PluralOther。
+func pluralRuleCzech(n int) PluralCategory {
+	n = abs(n)
+	switch {
+	case 1 == n:
+		return PluralOne
+	case n >= 2 && n <= 4:
+		return PluralFew
+	default:
+		return PluralOther
+	}
+}
+
+// pluralRuleArabic 是阿拉伯语使用的规则。
+//
+// 参数：
+//   - n: 数量。
+//
+// 返回：
+//   - PluralCategory: 0、1、2 分别为 PluralZero、PluralOne、PluralTwo，百位以下为 3 到 10 时为 PluralFew，
+//     11 到 99 时为 PluralMany，否则为 PluralOther。
+func pluralRuleArabic(n int) PluralCategory {
+	n = abs(n)
+	mod100 := n % 100
+	switch {
+	case 0 == n:
+		return PluralZero
+	case 1 == n:
+		return PluralOne
+	case 2 == n:
+		return PluralTwo
+	case mod100 >= 3 && mod100 <= 10:
+		return PluralFew
+	case mod100 >= 11:
+		return PluralMany
+	default:
+		return PluralOther
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package i18n
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestPluralRuleFor 验证内置复数规则。
+func TestPluralRuleFor(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		lang        string
+		give        []int
+		want        []PluralCategory
+	}{
+		{
+			name:        "success/zh",
+			description: "验证中文不区分单复数。",
+			lang:        "zh-CN",
+			give:        []int{0, 1, 2},
+			want:        []PluralCategory{PluralOther, PluralOther, PluralOther},
+		},
+		{
+			name:        "success/en",
+			description: "验证英语与未知语言只有 1 为单数。",
+			lang:        "xx",
+			give:        []int{0, 1, -1, 2},
+			want:        []PluralCategory{PluralOther, PluralOne, PluralOne, PluralOther},
+		},
+		{
+			name:        "success/fr",
+			description: "验证法语 0 与 1 都属于单数。",
+			lang:        "fr-CA",
+			give:        []int{0, 1, 2},
+			want:        []PluralCategory{PluralOne, PluralOne, PluralOther},
+		},
+		{
+			name:        "success/ru",
+			description: "验证俄语按个位与十位选择类别。",
+			lang:        "ru",
+			give:        []int{1, 2, 5, 11, 12, 21, 22, 25},
+			want:        []PluralCategory{PluralOne, PluralFew, PluralMany, PluralMany, PluralMany, PluralOne, PluralFew, PluralMany},
+		},
+		{
+			name:        "success/pl",
+			description: "验证波兰语 21 不属于单数。",
+			lang:        "pl",
+			give:        []int{1, 3, 21, 24},
+			want:        []PluralCategory{PluralOne, PluralFew, PluralMany, PluralFew},
+		},
+		{
+			name:        "success/ar",
+			description: "验证阿拉伯语的六个类别。",
+			lang:        "ar",
+			give:        []int{0, 1, 2, 3, 11, 100},
+			want:        []PluralCategory{PluralZero, PluralOne, PluralTwo, PluralFew, PluralMany, PluralOther},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			rule := pluralRuleFor(tt.lang, nil)
+			for i, n := range tt.give {
+				assert.Equal(t, tt.want[i], rule(n), "n=%d", n)
+			}
+		})
+	}
+
+	t.Run("custom", func(t *testing.T) {
+		t.Log("验证调用方注册的规则按父级标签匹配并优先于内置规则。")
+
+		custom := map[string]PluralRule{"zh": pluralRuleOneOther}
+		assert.Equal(t, PluralOne, pluralRuleFor("zh-CN", custom)(1))
+	})
+}
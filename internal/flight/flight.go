@@ -0,0 +1,77 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+// Package flight 合并同一个键的并发加载，供 cache 与 database/sql 等包共享同一套合并与 panic 处理语义。
+package flight
+
+import (
+	"errors"
+	"sync"
+)
+
+var (
+	// ErrPanicked 表示执行加载函数的调用发生了 panic，等待同一结果的其它调用收到该错误。
+	ErrPanicked = errors.New("loader panicked")
+)
+
+type (
+	// Group 合并同一个键的并发调用。
+	//
+	// 零值 Group 可以直接使用，Group 不可复制。
+	Group[K comparable, V any] struct {
+		// mu 保护 calls。
+		mu sync.Mutex
+		// calls 记录正在进行的调用。
+		calls map[K]*call[V]
+	}
+
+	// call 是一次正在进行的调用。
+	call[V any] struct {
+		// wg 在调用完成后释放等待者。
+		wg sync.WaitGroup
+		// value 是 fn 返回的值。
+		value V
+		// err 是 fn 返回的错误；fn panic 时保持为 ErrPanicked。
+		err error
+	}
+)
+
+// Do 执行 fn，同一个键的并发调用只执行一次并共享结果。
+//
+// 执行 fn 的调用 panic 时，panic 继续向该调用方传播，并发等待的调用收到 V 的零值与 ErrPanicked。
+//
+// 参数：
+//   - key: 合并调用的键。
+//   - fn: 加载函数。
+//
+// 返回：
+//   - V: fn 返回的值。
+//   - error: fn 返回的错误；执行 fn 的调用 panic 时，等待者收到 ErrPanicked。
+func (g *Group[K, V]) Do(key K, fn func() (V, error)) (V, error) {
+	g.mu.Lock()
+	if c, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		c.wg.Wait()
+		return c.value, c.err
+	}
+	if nil == g.calls {
+		g.calls = make(map[K]*call[V])
+	}
+	c := &call[V]{err: ErrPanicked}
+	c.wg.Add(1)
+	g.calls[key] = c
+	g.mu.Unlock()
+
+	// fn panic 时同样释放等待者，panic 继续向上传播。
+	defer func() {
+		g.mu.Lock()
+		delete(g.calls, key)
+		g.mu.Unlock()
+		c.wg.Done()
+	}()
+
+	value, err := fn()
+	c.value, c.err = value, err
+	return value, err
+}
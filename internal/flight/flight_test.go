@@ -0,0 +1,97 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package flight
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGroup_Do 验证并发调用合并、错误共享与 panic 处理。
+func TestGroup_Do(t *testing.T) {
+	errLoad := errors.New("load failed")
+
+	tests := []struct {
+		name        string
+		description string
+		fn          func() (int, error)
+		wantValue   int
+		wantErr     error
+		wantPanic   bool
+	}{
+		{
+			name:        "success/shared",
+			description: "验证并发调用只执行一次并共享结果。",
+			fn:          func() (int, error) { return 42, nil },
+			wantValue:   42,
+		},
+		{
+			name:        "error/shared",
+			description: "验证等待者收到同一个错误。",
+			fn:          func() (int, error) { return 0, errLoad },
+			wantErr:     errLoad,
+		},
+		{
+			name:        "error/panic",
+			description: "验证执行者的 panic 继续传播，等待者收到 ErrPanicked。",
+			fn:          func() (int, error) { panic("boom") },
+			wantErr:     ErrPanicked,
+			wantPanic:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			var (
+				g       Group[string, int]
+				calls   atomic.Int32
+				started = make(chan struct{})
+				release = make(chan struct{})
+			)
+			notify := sync.OnceFunc(func() { close(started) })
+			fn := func() (int, error) {
+				calls.Add(1)
+				notify()
+				<-release
+				return tt.fn()
+			}
+
+			var wg sync.WaitGroup
+			wg.Go(func() {
+				if tt.wantPanic {
+					assert.PanicsWithValue(t, "boom", func() { _, _ = g.Do("k", fn) })
+					return
+				}
+				value, err := g.Do("k", fn)
+				assert.Equal(t, tt.wantValue, value)
+				assert.ErrorIs(t, err, tt.wantErr)
+			})
+			<-started
+
+			const waiters = 8
+			for range waiters {
+				wg.Go(func() {
+					value, err := g.Do("k", fn)
+					assert.Equal(t, tt.wantValue, value)
+					assert.ErrorIs(t, err, tt.wantErr)
+				})
+			}
+			// 等待者在 release 之前进入等待；晚到的调用会在执行结束后重新执行 fn。
+			time.Sleep(20 * time.Millisecond)
+			close(release)
+			wg.Wait()
+
+			assert.Equal(t, int32(1), calls.Load())
+			assert.Empty(t, g.calls)
+		})
+	}
+}
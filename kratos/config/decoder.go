@@ -6,7 +6,9 @@ package config
 
 import (
 	"fmt"
+	"path/filepath"
 	"strings"
+	"sync"
 
 	kratosconfig "github.com/go-kratos/kratos/v2/config"
 	kratosencoding "github.com/go-kratos/kratos/v2/encoding"
@@ -25,12 +27,25 @@ type (
 	DecoderOptions struct {
 		// Resolve 是一个可选的解析函数，用于在解码完成后对配置进行额外处理。
 		Resolve Resolve
+		// Capture 控制是否保留每个配置源解码并解析后的结果，供 Snapshot 获取生效配置。
+		Capture bool
+		// Include 控制是否处理配置中的 include 指令。
+		Include bool
+		// IncludeDir 是顶层配置源所在目录，为空时使用当前工作目录。
+		IncludeDir string
 	}
 
 	// Decoder 是配置解码器，用于将配置从源格式解码到目标映射。
 	// 它嵌入了 DecoderOptions 结构体，继承了其所有字段和方法。
 	Decoder struct {
 		DecoderOptions
+
+		// mu 保护 captured 和 capturedKeys。
+		mu sync.Mutex
+		// captured 保存每个配置源 key 最近一次解码后的结果副本。
+		captured map[string]map[string]any
+		// capturedKeys 按首次解码顺序记录配置源 key，Snapshot 按该顺序合并。
+		capturedKeys []string
 	}
 )
 
@@ -49,6 +64,22 @@ func WithResolve(fn Resolve) DecoderOption {
 	}
 }
 
+// WithCapture 设置 Decoder 是否保留解码后的配置结果。
+//
+// 启用后，每次 Decode 成功都会以配置源 key 为单位保存 target 的深拷贝，调用 Snapshot 可获取合并后的生效配置，
+// 用于配合 Dump 和 Diff 排查热更新问题。保存的结果包含已解密的敏感值，输出前应使用掩码模式脱敏。
+//
+// 参数：
+//   - capture：是否保留解码结果。
+//
+// 返回值：
+//   - DecoderOption：可用于配置 Decoder 的选项函数。
+func WithCapture(capture bool) DecoderOption {
+	return func(o *DecoderOptions) {
+		o.Capture = capture
+	}
+}
+
 // NewDecoder 创建一个新的配置解码器。
 //
 // 默认情况下，Decoder 会在 codec 解码成功后执行包级 defaultResolve.Resolve；
@@ -90,6 +121,60 @@ func NewDecoder(opts ...DecoderOption) *Decoder {
 // 返回值：
 //   - error：codec 不存在、反序列化失败或 Resolve 处理失败时返回错误。
 func (d *Decoder) Decode(src *kratosconfig.KeyValue, target map[string]any) error {
+	if err := d.decode(src, target); nil != err {
+		return err
+	}
+	if d.Capture {
+		d.capture(src.Key, target)
+	}
+	return nil
+}
+
+// Snapshot 返回已捕获配置的合并结果。
+//
+// 各配置源的结果按首次解码顺序合并，后解码的配置源覆盖先前相同路径的值，与 Kratos 合并配置源的顺序一致。
+// 未通过 WithCapture 启用捕获时返回空 map。
+//
+// 返回值：
+//   - map[string]any：合并后生效配置的深拷贝，调用方可以自由修改。
+func (d *Decoder) Snapshot() map[string]any {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	merged := make(map[string]any)
+	for _, key := range d.capturedKeys {
+		mergeMap(merged, d.captured[key])
+	}
+	return merged
+}
+
+// capture 保存配置源解码结果的深拷贝。
+//
+// 参数：
+//   - key：配置源 key。
+//   - target：解码结果。
+func (d *Decoder) capture(key string, target map[string]any) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if nil == d.captured {
+		d.captured = make(map[string]map[string]any)
+	}
+	if _, ok := d.captured[key]; !ok {
+		d.capturedKeys = append(d.capturedKeys, key)
+	}
+	d.captured[key] = copyMap(target)
+}
+
+// decode 执行 Decode 的实际解码逻辑。
+//
+// 参数：
+//   - src：Kratos 配置源。
+//   - target：解码结果写入的 map[string]any。
+//
+// 返回值：
+//   - error：codec 不存在、反序列化失败或 Resolve 处理失败时返回错误。
+func (d *Decoder) decode(src *kratosconfig.KeyValue, target map[string]any) error {
 	if src.Format == "" {
 		// 当格式为空时，将键 "aaa.bbb" 展开为 map[aaa]map[bbb]interface{}。
 		keys := strings.Split(src.Key, ".")
@@ -111,6 +196,12 @@ func (d *Decoder) Decode(src *kratosconfig.KeyValue, target map[string]any) erro
 		if err := codec.Unmarshal(src.Value, &target); nil != err {
 			return err
 		}
+		// 如果启用了 include 指令，先合并被引入的文件，再对最终结果执行 Resolve。
+		if d.Include {
+			if err := resolveIncludes(filepath.Clean(filepath.Join(d.IncludeDir, src.Key)), target, nil); nil != err {
+				return err
+			}
+		}
 		// 如果设置了解析函数，则调用它进行额外处理。
 		if nil != d.Resolve {
 			if err := d.Resolve(target); nil != err {
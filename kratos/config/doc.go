@@ -8,4 +8,15 @@
 // 展开为嵌套 map；在 src.Format 非空时委托 Kratos codec 解码到 map[string]any。
 // RegisterResolve 用于扩展包级默认解析器，当前内置 .b64、.des 和 .env 后缀处理。
 // 包级解析器注册会修改全局 map，应在程序初始化阶段或并发解码开始前完成。
+//
+// WithCapture 使 Decoder 保存每个配置源解析后的结果，Snapshot 返回合并后的生效配置。
+// Dump 按 key 排序输出脱敏后的配置，Diff 返回两份配置之间排序且脱敏的变化列表，用于排查热更新和审计。
+// Values 以 "server.http.addr"、"hosts[0]" 形式的路径读取单个配置项，GetDuration、GetBytesSize 等方法
+// 负责类型转换，路径不存在或无法转换时返回默认值。
+//
+// WithInclude 启用 "include: [base.yaml, secrets.yaml]" 形式的指令：相对路径相对于引入方所在目录解析，
+// 按列出顺序合并且引入方自身的配置优先，循环引用时返回 ErrIncludeCycle。
+//
+// NewHTTPSource 返回基于 kit net/http 轮询远程 URL 的 Kratos 配置源：请求携带 If-None-Match，304 视为未变化；
+// WithSignature 启用响应体的 HMAC 签名校验，校验失败的响应不会进入解码与 Watch 流程。
 package config
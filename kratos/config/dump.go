@@ -0,0 +1,337 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// ChangeAdded 表示新配置中新增的 key。
+	ChangeAdded ChangeKind = "added"
+	// ChangeRemoved 表示新配置中删除的 key。
+	ChangeRemoved ChangeKind = "removed"
+	// ChangeModified 表示值发生变化的 key。
+	ChangeModified ChangeKind = "modified"
+
+	// maskedValue 是被脱敏的值的输出内容。
+	maskedValue = "******"
+)
+
+var (
+	// DefaultMaskPatterns 是常用敏感配置项的掩码模式，可直接传给 Dump 和 Diff。
+	DefaultMaskPatterns = []string{
+		"*password*",
+		"*passwd*",
+		"*secret*",
+		"*token*",
+		"*credential*",
+		"*private*key*",
+		"*" + suffixDES,
+	}
+)
+
+type (
+	// ChangeKind 表示配置项的变化类型。
+	ChangeKind string
+
+	// Change 描述一个配置项的变化。
+	Change struct {
+		// Key 是点分隔的配置路径，数组元素使用 [i] 表示，例如 "server.http.addr"、"hosts[0]"。
+		Key string
+		// Kind 是变化类型。
+		Kind ChangeKind
+		// Old 是旧值的 JSON 表示，新增时为空字符串；命中掩码模式时为 "******"。
+		Old string
+		// New 是新值的 JSON 表示，删除时为空字符串；命中掩码模式时为 "******"。
+		New string
+	}
+
+	// masker 按掩码模式脱敏配置值。
+	masker struct {
+		// patterns 是转换为小写的掩码模式。
+		patterns []string
+	}
+)
+
+// Dump 将配置按 key 排序后逐行输出，每行格式为 "key = value"。
+//
+// 嵌套 map 展开为点分隔的 key，数组元素使用 [i] 下标，值使用 JSON 表示。key 或其最后一段
+// 命中任一掩码模式时，值输出为 "******"。
+//
+// 参数：
+//   - w：输出目标。
+//   - cfg：配置，通常来自 Decoder.Snapshot。
+//   - maskPatterns：掩码模式，使用 path.Match 语法且不区分大小写，例如 "*password*"、"db.dsn"。
+//
+// 返回值：
+//   - error：掩码模式语法错误或写入失败时返回错误。
+func Dump(w io.Writer, cfg map[string]any, maskPatterns ...string) error {
+	masker, err := newMasker(maskPatterns)
+	if nil != err {
+		return err
+	}
+
+	flat := flatten(cfg)
+	for _, key := range sortedKeys(flat) {
+		if _, err := fmt.Fprintf(w, "%s = %s\n", key, masker.render(key, flat[key])); nil != err {
+			return err
+		}
+	}
+	return nil
+}
+
+// Diff 比较两份配置并返回按 key 排序的变化列表。
+//
+// 配置按 Dump 相同的规则展开后逐项比较；命中掩码模式的值仍参与比较，但在结果中脱敏，
+// 因此敏感配置的变化可以被发现而不会泄露具体内容。
+//
+// 参数：
+//   - oldCfg：旧配置。
+//   - newCfg：新配置。
+//   - maskPatterns：掩码模式，规则同 Dump。
+//
+// 返回值：
+//   - []Change：按 key 排序的变化列表；没有变化时为空。
+//   - error：掩码模式语法错误时返回错误。
+func Diff(oldCfg, newCfg map[string]any, maskPatterns ...string) ([]Change, error) {
+	masker, err := newMasker(maskPatterns)
+	if nil != err {
+		return nil, err
+	}
+
+	oldFlat, newFlat := flatten(oldCfg), flatten(newCfg)
+	keys := sortedKeys(oldFlat)
+	for key := range newFlat {
+		if _, ok := oldFlat[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var changes []Change
+	for _, key := range keys {
+		oldVal, inOld := oldFlat[key]
+		newVal, inNew := newFlat[key]
+		switch {
+		case !inOld:
+			changes = append(changes, Change{Key: key, Kind: ChangeAdded, New: masker.render(key, newVal)})
+		case !inNew:
+			changes = append(changes, Change{Key: key, Kind: ChangeRemoved, Old: masker.render(key, oldVal)})
+		case encodeValue(oldVal) != encodeValue(newVal):
+			changes = append(changes, Change{
+				Key:  key,
+				Kind: ChangeModified,
+				Old:  masker.render(key, oldVal),
+				New:  masker.render(key, newVal),
+			})
+		}
+	}
+	return changes, nil
+}
+
+// String 返回变化的单行描述。
+//
+// 返回值：
+//   - string：新增为 "+ key = new"，删除为 "- key = old"，修改为 "~ key: old -> new"。
+func (c Change) String() string {
+	switch c.Kind {
+	case ChangeAdded:
+		return fmt.Sprintf("+ %s = %s", c.Key, c.New)
+	case ChangeRemoved:
+		return fmt.Sprintf("- %s = %s", c.Key, c.Old)
+	default:
+		return fmt.Sprintf("~ %s: %s -> %s", c.Key, c.Old, c.New)
+	}
+}
+
+// newMasker 校验掩码模式并创建 masker。
+//
+// 参数：
+//   - patterns：掩码模式。
+//
+// 返回值：
+//   - *masker：脱敏器。
+//   - error：模式语法错误时返回包装了 path.ErrBadPattern 的错误。
+func newMasker(patterns []string) (*masker, error) {
+	m := &masker{patterns: make([]string, 0, len(patterns))}
+	for _, pattern := range patterns {
+		lower := strings.ToLower(pattern)
+		if _, err := path.Match(lower, ""); nil != err {
+			return nil, fmt.Errorf("mask pattern %q: %w", pattern, err)
+		}
+		m.patterns = append(m.patterns, lower)
+	}
+	return m, nil
+}
+
+// render 返回配置值的输出内容，key 命中掩码模式时返回 "******"。
+//
+// 参数：
+//   - key：展开后的配置 key。
+//   - value：配置值。
+//
+// 返回值：
+//   - string：脱敏后的值或值的 JSON 表示。
+func (m *masker) render(key string, value any) string {
+	lower := strings.ToLower(key)
+	last := lower
+	if i := strings.LastIndexAny(lower, ".]"); i >= 0 {
+		last = lower[i+1:]
+	}
+	for _, pattern := range m.patterns {
+		if ok, _ := path.Match(pattern, lower); ok {
+			return maskedValue
+		}
+		if ok, _ := path.Match(pattern, last); ok && "" != last {
+			return maskedValue
+		}
+	}
+	return encodeValue(value)
+}
+
+// encodeValue 返回配置值的 JSON 表示，无法编码时回退为 %v 格式。
+//
+// 参数：
+//   - value：配置值。
+//
+// 返回值：
+//   - string：值的文本表示。
+func encodeValue(value any) string {
+	// 空 Format 的配置源直接保存原始字节，按字符串输出更便于阅读。
+	if b, ok := value.([]byte); ok {
+		value = string(b)
+	}
+	if b, err := json.Marshal(value); nil == err {
+		return string(b)
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// flatten 将嵌套配置展开为点分隔 key 到叶子值的映射。
+//
+// 空 map 和空数组作为叶子值保留，以便区分“不存在”和“为空”。
+//
+// 参数：
+//   - cfg：嵌套配置。
+//
+// 返回值：
+//   - map[string]any：展开后的配置。
+func flatten(cfg map[string]any) map[string]any {
+	flat := make(map[string]any)
+	flattenInto(flat, "", cfg)
+	return flat
+}
+
+// flattenInto 递归展开配置值。
+//
+// 参数：
+//   - flat：展开结果。
+//   - prefix：当前值的 key 前缀。
+//   - value：当前值。
+func flattenInto(flat map[string]any, prefix string, value any) {
+	switch v := value.(type) {
+	case map[string]any:
+		if 0 == len(v) && "" != prefix {
+			flat[prefix] = v
+			return
+		}
+		for k, child := range v {
+			key := k
+			if "" != prefix {
+				key = prefix + "." + k
+			}
+			flattenInto(flat, key, child)
+		}
+	case []any:
+		if 0 == len(v) {
+			flat[prefix] = v
+			return
+		}
+		for i, child := range v {
+			flattenInto(flat, prefix+"["+strconv.Itoa(i)+"]", child)
+		}
+	default:
+		flat[prefix] = v
+	}
+}
+
+// sortedKeys 返回按字典序排序的 key 列表。
+//
+// 参数：
+//   - m：展开后的配置。
+//
+// 返回值：
+//   - []string：排序后的 key。
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// copyMap 深拷贝配置 map，嵌套的 map 和数组也会被复制。
+//
+// 参数：
+//   - src：源配置。
+//
+// 返回值：
+//   - map[string]any：配置副本。
+func copyMap(src map[string]any) map[string]any {
+	dst := make(map[string]any, len(src))
+	for k, v := range src {
+		dst[k] = copyValue(v)
+	}
+	return dst
+}
+
+// copyValue 深拷贝配置值。
+//
+// 参数：
+//   - value：配置值。
+//
+// 返回值：
+//   - any：配置值副本。
+func copyValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		return copyMap(v)
+	case []any:
+		dst := make([]any, len(v))
+		for i, child := range v {
+			dst[i] = copyValue(child)
+		}
+		return dst
+	case []byte:
+		return append([]byte(nil), v...)
+	default:
+		return v
+	}
+}
+
+// mergeMap 将 src 深度合并到 dst，两侧均为 map 的 key 递归合并，其余情况由 src 覆盖。
+//
+// 参数：
+//   - dst：合并目标。
+//   - src：合并来源，其值会被深拷贝。
+func mergeMap(dst, src map[string]any) {
+	for k, v := range src {
+		if sub, ok := v.(map[string]any); ok {
+			if existing, ok := dst[k].(map[string]any); ok {
+				mergeMap(existing, sub)
+				continue
+			}
+		}
+		dst[k] = copyValue(v)
+	}
+}
@@ -0,0 +1,203 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package config
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"path"
+	"testing"
+
+	kratosconfig "github.com/go-kratos/kratos/v2/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDump 验证配置展开、排序和脱敏输出。
+func TestDump(t *testing.T) {
+	cfg := map[string]any{
+		"server": map[string]any{
+			"http": map[string]any{"addr": ":8000", "timeout": 1.5},
+		},
+		"data": map[string]any{
+			"database": map[string]any{"Password": "p@ss", "dsn": "root:p@ss@tcp(db)/app"},
+		},
+		"hosts": []any{"a", map[string]any{"token": "t"}},
+		"empty": map[string]any{},
+	}
+
+	tests := []struct {
+		name        string
+		description string
+		patterns    []string
+		want        string
+		wantErr     error
+	}{
+		{
+			name:        "success/no-mask",
+			description: "验证不传掩码模式时按 key 排序输出全部值。",
+			want: `data.database.Password = "p@ss"
+data.database.dsn = "root:p@ss@tcp(db)/app"
+empty = {}
+hosts[0] = "a"
+hosts[1].token = "t"
+server.http.addr = ":8000"
+server.http.timeout = 1.5
+`,
+		},
+		{
+			name:        "success/default-mask",
+			description: "验证默认掩码模式按最后一段 key 不区分大小写匹配，完整路径模式匹配指定 key。",
+			patterns:    append([]string{"data.database.dsn"}, DefaultMaskPatterns...),
+			want: `data.database.Password = ******
+data.database.dsn = ******
+empty = {}
+hosts[0] = "a"
+hosts[1].token = ******
+server.http.addr = ":8000"
+server.http.timeout = 1.5
+`,
+		},
+		{
+			name:        "error/bad-pattern",
+			description: "验证掩码模式语法错误时返回 path.ErrBadPattern。",
+			patterns:    []string{"["},
+			wantErr:     path.ErrBadPattern,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			var buf bytes.Buffer
+			err := Dump(&buf, cfg, tt.patterns...)
+			if nil != tt.wantErr {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+// TestDiff 验证配置变化的识别、排序和脱敏。
+func TestDiff(t *testing.T) {
+	oldCfg := map[string]any{
+		"server": map[string]any{"addr": ":8000", "debug": true},
+		"db":     map[string]any{"password": "old"},
+		"hosts":  []any{"a", "b"},
+	}
+	newCfg := map[string]any{
+		"server": map[string]any{"addr": ":9000"},
+		"db":     map[string]any{"password": "new"},
+		"hosts":  []any{"a", "b", "c"},
+		"log":    map[string]any{"level": "debug"},
+	}
+
+	tests := []struct {
+		name        string
+		description string
+		oldCfg      map[string]any
+		newCfg      map[string]any
+		patterns    []string
+		want        []Change
+	}{
+		{
+			name:        "success/changes-sorted-and-masked",
+			description: "验证新增、删除和修改按 key 排序，敏感值变化被发现但脱敏。",
+			oldCfg:      oldCfg,
+			newCfg:      newCfg,
+			patterns:    DefaultMaskPatterns,
+			want: []Change{
+				{Key: "db.password", Kind: ChangeModified, Old: "******", New: "******"},
+				{Key: "hosts[2]", Kind: ChangeAdded, New: `"c"`},
+				{Key: "log.level", Kind: ChangeAdded, New: `"debug"`},
+				{Key: "server.addr", Kind: ChangeModified, Old: `":8000"`, New: `":9000"`},
+				{Key: "server.debug", Kind: ChangeRemoved, Old: "true"},
+			},
+		},
+		{
+			name:        "boundary/identical",
+			description: "验证相同配置没有变化。",
+			oldCfg:      oldCfg,
+			newCfg:      copyMap(oldCfg),
+			want:        nil,
+		},
+		{
+			name:        "boundary/nil-old",
+			description: "验证旧配置为 nil 时全部视为新增。",
+			newCfg:      map[string]any{"a": 1},
+			want:        []Change{{Key: "a", Kind: ChangeAdded, New: "1"}},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			changes, err := Diff(tt.oldCfg, tt.newCfg, tt.patterns...)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, changes)
+		})
+	}
+
+	_, err := Diff(oldCfg, newCfg, "[")
+	assert.ErrorIs(t, err, path.ErrBadPattern)
+}
+
+// TestChange_String 验证变化的单行描述。
+func TestChange_String(t *testing.T) {
+	assert.Equal(t, `+ a = "x"`, Change{Key: "a", Kind: ChangeAdded, New: `"x"`}.String())
+	assert.Equal(t, `- a = "x"`, Change{Key: "a", Kind: ChangeRemoved, Old: `"x"`}.String())
+	assert.Equal(t, `~ a: 1 -> 2`, Change{Key: "a", Kind: ChangeModified, Old: "1", New: "2"}.String())
+}
+
+// TestDecoder_CaptureSnapshot 验证启用捕获后 Snapshot 返回解析后按配置源顺序合并的配置副本。
+func TestDecoder_CaptureSnapshot(t *testing.T) {
+	secret := base64.StdEncoding.EncodeToString([]byte("s3cret"))
+	d := NewDecoder(WithCapture(true))
+
+	decode := func(key, value string) {
+		t.Helper()
+		require.NoError(t, d.Decode(&kratosconfig.KeyValue{Key: key, Format: "json", Value: []byte(value)}, map[string]any{}))
+	}
+	decode("base.json", `{"app":{"name":"demo","key.b64":"`+secret+`"},"port":8000}`)
+	decode("override.json", `{"app":{"name":"prod"}}`)
+	require.NoError(t, d.Decode(&kratosconfig.KeyValue{Key: "env.mode", Value: []byte("blue")}, map[string]any{}))
+
+	snapshot := d.Snapshot()
+	assert.Equal(t, map[string]any{
+		"app":  map[string]any{"name": "prod", "key.b64": secret, "key": "s3cret"},
+		"port": float64(8000),
+		"env":  map[string]any{"mode": []byte("blue")},
+	}, snapshot)
+
+	// 热更新后重新解码同一配置源，Snapshot 反映最新结果，旧快照不受影响。
+	snapshot["port"] = "mutated"
+	decode("base.json", `{"app":{"name":"demo"},"port":9000}`)
+	changes, err := Diff(map[string]any{"port": float64(8000)}, map[string]any{"port": d.Snapshot()["port"]})
+	require.NoError(t, err)
+	assert.Equal(t, []Change{{Key: "port", Kind: ChangeModified, Old: "8000", New: "9000"}}, changes)
+
+	var buf bytes.Buffer
+	require.NoError(t, Dump(&buf, d.Snapshot(), DefaultMaskPatterns...))
+	assert.Equal(t, "app.name = \"prod\"\nenv.mode = \"blue\"\nport = 9000\n", buf.String())
+}
+
+// TestDecoder_CaptureDisabled 验证默认不捕获，解码失败时也不捕获。
+func TestDecoder_CaptureDisabled(t *testing.T) {
+	d := NewDecoder()
+	require.NoError(t, d.Decode(&kratosconfig.KeyValue{Key: "a.json", Format: "json", Value: []byte(`{"a":1}`)}, map[string]any{}))
+	assert.Empty(t, d.Snapshot())
+
+	d = NewDecoder(WithCapture(true), WithResolve(func(map[string]interface{}) error { return errors.New("boom") }))
+	require.Error(t, d.Decode(&kratosconfig.KeyValue{Key: "a.json", Format: "json", Value: []byte(`{"a":1}`)}, map[string]any{}))
+	assert.Empty(t, d.Snapshot())
+}
@@ -0,0 +1,395 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package config
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cast"
+)
+
+var (
+	// bytesSizeUnits 是 ParseBytesSize 支持的单位及其字节数，key 为小写。
+	//
+	// 单字母单位和 KiB 等二进制单位按 1024 进位，KB 等十进制单位按 1000 进位。
+	bytesSizeUnits = map[string]float64{
+		"":    1,
+		"b":   1,
+		"k":   1 << 10,
+		"kb":  1e3,
+		"kib": 1 << 10,
+		"m":   1 << 20,
+		"mb":  1e6,
+		"mib": 1 << 20,
+		"g":   1 << 30,
+		"gb":  1e9,
+		"gib": 1 << 30,
+		"t":   1 << 40,
+		"tb":  1e12,
+		"tib": 1 << 40,
+		"p":   1 << 50,
+		"pb":  1e15,
+		"pib": 1 << 50,
+	}
+)
+
+type (
+	// Values 是解析后的配置 map，提供按路径读取单个配置项的类型化方法。
+	//
+	// 路径使用与 Dump 相同的写法：以点分隔 map 的 key，以 [i] 访问数组元素，例如
+	// "server.http.addr"、"hosts[0]"、"data.redis[1].addr"。当某一层 map 中存在与剩余路径
+	// 完全相同的 key 时优先使用该 key，因此包含点的 key 也可以读取。
+	//
+	// Get 系列方法在路径不存在或值无法转换为目标类型时返回默认值，适合只需读取少量配置项、
+	// 不想定义完整结构体的小型服务。
+	Values map[string]any
+)
+
+// Values 返回已捕获的生效配置，便于按路径读取单个配置项。
+//
+// 返回值：
+//   - Values：Snapshot 的结果；未启用捕获时为空。
+func (d *Decoder) Values() Values {
+	return Values(d.Snapshot())
+}
+
+// Lookup 按路径查找配置值。
+//
+// 参数：
+//   - path：配置路径，例如 "server.http.addr" 或 "hosts[0]"。
+//
+// 返回值：
+//   - any：配置值，可能是嵌套的 map 或数组。
+//   - bool：路径存在时返回 true。
+func (v Values) Lookup(path string) (any, bool) {
+	if "" == path {
+		return nil, false
+	}
+	return lookupPath(map[string]any(v), path)
+}
+
+// Has 判断路径是否存在。
+//
+// 参数：
+//   - path：配置路径。
+//
+// 返回值：
+//   - bool：路径存在时返回 true，值为 nil 也视为存在。
+func (v Values) Has(path string) bool {
+	_, ok := v.Lookup(path)
+	return ok
+}
+
+// GetString 读取字符串配置。
+//
+// 参数：
+//   - path：配置路径。
+//   - def：默认值。
+//
+// 返回值：
+//   - string：配置值；数字和布尔值会转换为字符串，路径不存在或值为 map、数组时返回 def。
+func (v Values) GetString(path, def string) string {
+	value, ok := v.Lookup(path)
+	if !ok {
+		return def
+	}
+	if b, isBytes := value.([]byte); isBytes {
+		return string(b)
+	}
+	s, err := cast.ToStringE(value)
+	if nil != err {
+		return def
+	}
+	return s
+}
+
+// GetInt 读取整数配置。
+//
+// 参数：
+//   - path：配置路径。
+//   - def：默认值。
+//
+// 返回值：
+//   - int：配置值；字符串形式的十进制数字也可转换，路径不存在或无法转换时返回 def。
+func (v Values) GetInt(path string, def int) int {
+	value, ok := v.Lookup(path)
+	if !ok {
+		return def
+	}
+	i, err := cast.ToIntE(value)
+	if nil != err {
+		return def
+	}
+	return i
+}
+
+// GetInt64 读取 64 位整数配置。
+//
+// 参数：
+//   - path：配置路径。
+//   - def：默认值。
+//
+// 返回值：
+//   - int64：配置值；路径不存在或无法转换时返回 def。
+func (v Values) GetInt64(path string, def int64) int64 {
+	value, ok := v.Lookup(path)
+	if !ok {
+		return def
+	}
+	i, err := cast.ToInt64E(value)
+	if nil != err {
+		return def
+	}
+	return i
+}
+
+// GetFloat64 读取浮点数配置。
+//
+// 参数：
+//   - path：配置路径。
+//   - def：默认值。
+//
+// 返回值：
+//   - float64：配置值；路径不存在或无法转换时返回 def。
+func (v Values) GetFloat64(path string, def float64) float64 {
+	value, ok := v.Lookup(path)
+	if !ok {
+		return def
+	}
+	f, err := cast.ToFloat64E(value)
+	if nil != err {
+		return def
+	}
+	return f
+}
+
+// GetBool 读取布尔配置。
+//
+// 参数：
+//   - path：配置路径。
+//   - def：默认值。
+//
+// 返回值：
+//   - bool：配置值；支持 "true"、"false"、"1"、"0" 等 strconv.ParseBool 可识别的字符串，
+//     路径不存在或无法转换时返回 def。
+func (v Values) GetBool(path string, def bool) bool {
+	value, ok := v.Lookup(path)
+	if !ok {
+		return def
+	}
+	b, err := cast.ToBoolE(value)
+	if nil != err {
+		return def
+	}
+	return b
+}
+
+// GetDuration 读取时长配置。
+//
+// 字符串按 time.ParseDuration 解析，例如 "1.5s"、"1m30s"；数字以及纯数字字符串按秒解析，
+// 与配置文件中常见的 "timeout: 3" 写法一致。
+//
+// 参数：
+//   - path：配置路径。
+//   - def：默认值。
+//
+// 返回值：
+//   - time.Duration：配置值；路径不存在或无法解析时返回 def。
+func (v Values) GetDuration(path string, def time.Duration) time.Duration {
+	value, ok := v.Lookup(path)
+	if !ok {
+		return def
+	}
+	d, err := toDuration(value)
+	if nil != err {
+		return def
+	}
+	return d
+}
+
+// GetBytesSize 读取字节大小配置。
+//
+// 字符串按 ParseBytesSize 解析，例如 "10MiB"、"512KB"；数字按字节数解析。
+//
+// 参数：
+//   - path：配置路径。
+//   - def：默认值。
+//
+// 返回值：
+//   - int64：字节数；路径不存在或无法解析时返回 def。
+func (v Values) GetBytesSize(path string, def int64) int64 {
+	value, ok := v.Lookup(path)
+	if !ok {
+		return def
+	}
+	if s, isString := value.(string); isString {
+		size, err := ParseBytesSize(s)
+		if nil != err {
+			return def
+		}
+		return size
+	}
+	size, err := cast.ToInt64E(value)
+	if nil != err || size < 0 {
+		return def
+	}
+	return size
+}
+
+// GetStringSlice 读取字符串列表配置。
+//
+// 数组中的每个元素按 GetString 的规则转换为字符串；字符串值按逗号分隔并去除首尾空白，
+// 便于通过环境变量覆盖列表配置。
+//
+// 参数：
+//   - path：配置路径。
+//   - def：默认值。
+//
+// 返回值：
+//   - []string：配置值；路径不存在、值类型不支持或任一元素无法转换时返回 def。
+func (v Values) GetStringSlice(path string, def []string) []string {
+	value, ok := v.Lookup(path)
+	if !ok {
+		return def
+	}
+	switch vv := value.(type) {
+	case []any:
+		result := make([]string, 0, len(vv))
+		for _, item := range vv {
+			s, err := cast.ToStringE(item)
+			if nil != err {
+				return def
+			}
+			result = append(result, s)
+		}
+		return result
+	case []string:
+		return append([]string(nil), vv...)
+	case string:
+		if "" == strings.TrimSpace(vv) {
+			return []string{}
+		}
+		parts := strings.Split(vv, ",")
+		for i := range parts {
+			parts[i] = strings.TrimSpace(parts[i])
+		}
+		return parts
+	default:
+		return def
+	}
+}
+
+// ParseBytesSize 解析带单位的字节大小。
+//
+// 数字部分可以是小数，单位不区分大小写且允许与数字之间有空白：B、K/KiB、M/MiB、G/GiB、T/TiB、P/PiB
+// 按 1024 进位，KB、MB、GB、TB、PB 按 1000 进位；没有单位时按字节解析。
+//
+// 参数：
+//   - s：字节大小文本，例如 "10MiB"、"1.5 GB"、"4096"。
+//
+// 返回值：
+//   - int64：字节数，小数部分向下取整。
+//   - error：格式非法、单位未知、数值为负或超出 int64 范围时返回错误。
+func ParseBytesSize(s string) (int64, error) {
+	text := strings.TrimSpace(s)
+	end := 0
+	for end < len(text) && (('0' <= text[end] && text[end] <= '9') || '.' == text[end]) {
+		end++
+	}
+	if 0 == end {
+		return 0, fmt.Errorf("invalid bytes size %q", s)
+	}
+
+	number, err := strconv.ParseFloat(text[:end], 64)
+	if nil != err {
+		return 0, fmt.Errorf("invalid bytes size %q: %w", s, err)
+	}
+	unit, ok := bytesSizeUnits[strings.ToLower(strings.TrimSpace(text[end:]))]
+	if !ok {
+		return 0, fmt.Errorf("invalid bytes size %q: unknown unit %q", s, strings.TrimSpace(text[end:]))
+	}
+
+	size := math.Floor(number * unit)
+	if size >= math.MaxInt64 {
+		return 0, fmt.Errorf("invalid bytes size %q: overflows int64", s)
+	}
+	return int64(size), nil
+}
+
+// toDuration 将配置值转换为时长。
+//
+// 参数：
+//   - value：配置值。
+//
+// 返回值：
+//   - time.Duration：时长。
+//   - error：无法转换时返回错误。
+func toDuration(value any) (time.Duration, error) {
+	if s, ok := value.(string); ok {
+		s = strings.TrimSpace(s)
+		if seconds, err := strconv.ParseFloat(s, 64); nil == err {
+			return time.Duration(seconds * float64(time.Second)), nil
+		}
+		return time.ParseDuration(s)
+	}
+	seconds, err := cast.ToFloat64E(value)
+	if nil != err {
+		return 0, err
+	}
+	return time.Duration(seconds * float64(time.Second)), nil
+}
+
+// lookupPath 在嵌套配置中按路径查找值。
+//
+// 参数：
+//   - current：当前层级的值。
+//   - path：剩余路径，可以以 "." 或 "[" 开头。
+//
+// 返回值：
+//   - any：找到的值。
+//   - bool：路径存在时返回 true。
+func lookupPath(current any, path string) (any, bool) {
+	path = strings.TrimPrefix(path, ".")
+	if "" == path {
+		return current, true
+	}
+
+	if strings.HasPrefix(path, "[") {
+		list, ok := current.([]any)
+		if !ok {
+			return nil, false
+		}
+		end := strings.IndexByte(path, ']')
+		if end < 0 {
+			return nil, false
+		}
+		index, err := strconv.Atoi(path[1:end])
+		if nil != err || index < 0 || index >= len(list) {
+			return nil, false
+		}
+		return lookupPath(list[index], path[end+1:])
+	}
+
+	m, ok := current.(map[string]any)
+	if !ok {
+		return nil, false
+	}
+	if value, exists := m[path]; exists {
+		return value, true
+	}
+	end := strings.IndexAny(path, ".[")
+	if end < 0 {
+		return nil, false
+	}
+	child, exists := m[path[:end]]
+	if !exists {
+		return nil, false
+	}
+	return lookupPath(child, path[end:])
+}
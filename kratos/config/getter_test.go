@@ -0,0 +1,182 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package config
+
+import (
+	"testing"
+	"time"
+
+	kratosconfig "github.com/go-kratos/kratos/v2/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestValues 返回 getter 测试使用的配置。
+//
+// 返回值：
+//   - Values：测试配置。
+func newTestValues() Values {
+	return Values{
+		"server": map[string]any{
+			"http": map[string]any{"addr": ":8000", "timeout": "1.5s", "port": 8000, "debug": "true"},
+			"grpc": map[string]any{"timeout": 3, "ratio": 0.5},
+		},
+		"data": map[string]any{
+			"redis": []any{
+				map[string]any{"addr": "127.0.0.1:6379"},
+				map[string]any{"addr": "127.0.0.1:6380"},
+			},
+		},
+		"upload":       map[string]any{"max": "10MiB", "min": 512, "bad": "10XB"},
+		"hosts":        []any{"a", "b", 3},
+		"tags":         "x, y ,z",
+		"log.level":    "info",
+		"secret.bytes": []byte("raw"),
+		"nil":          nil,
+	}
+}
+
+// TestValues_Lookup 验证路径表达式的解析。
+func TestValues_Lookup(t *testing.T) {
+	v := newTestValues()
+
+	tests := []struct {
+		name        string
+		description string
+		path        string
+		want        any
+		wantOK      bool
+	}{
+		{name: "success/nested", description: "验证点分路径读取嵌套 map。", path: "server.http.addr", want: ":8000", wantOK: true},
+		{name: "success/index", description: "验证数组下标。", path: "hosts[1]", want: "b", wantOK: true},
+		{name: "success/index-nested", description: "验证数组元素中的 map。", path: "data.redis[1].addr", want: "127.0.0.1:6380", wantOK: true},
+		{name: "success/dotted-key", description: "验证包含点的 key 优先完整匹配。", path: "log.level", want: "info", wantOK: true},
+		{name: "success/nil", description: "验证值为 nil 的 key 视为存在。", path: "nil", wantOK: true},
+		{name: "success/map", description: "验证可以读取中间层 map。", path: "server.grpc", want: map[string]any{"timeout": 3, "ratio": 0.5}, wantOK: true},
+		{name: "error/missing", description: "验证不存在的 key。", path: "server.http.missing"},
+		{name: "error/empty", description: "验证空路径。", path: ""},
+		{name: "error/out-of-range", description: "验证越界下标。", path: "hosts[3]"},
+		{name: "error/negative-index", description: "验证负数下标。", path: "hosts[-1]"},
+		{name: "error/unclosed", description: "验证未闭合的下标。", path: "hosts[0"},
+		{name: "error/index-on-map", description: "验证对 map 使用下标。", path: "server[0]"},
+		{name: "error/key-on-leaf", description: "验证对叶子值继续访问。", path: "server.http.addr.port"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			got, ok := v.Lookup(tt.path)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantOK, v.Has(tt.path))
+		})
+	}
+}
+
+// TestValues_Getters 验证类型化读取与默认值。
+func TestValues_Getters(t *testing.T) {
+	v := newTestValues()
+
+	t.Run("string", func(t *testing.T) {
+		assert.Equal(t, ":8000", v.GetString("server.http.addr", "def"))
+		assert.Equal(t, "8000", v.GetString("server.http.port", "def"))
+		assert.Equal(t, "raw", v.GetString("secret.bytes", "def"))
+		assert.Equal(t, "def", v.GetString("server.http", "def"))
+		assert.Equal(t, "def", v.GetString("missing", "def"))
+	})
+
+	t.Run("number", func(t *testing.T) {
+		assert.Equal(t, 8000, v.GetInt("server.http.port", 1))
+		assert.Equal(t, int64(3), v.GetInt64("server.grpc.timeout", 1))
+		assert.Equal(t, 0.5, v.GetFloat64("server.grpc.ratio", 1))
+		assert.Equal(t, 1, v.GetInt("server.http.addr", 1))
+		assert.Equal(t, int64(1), v.GetInt64("missing", 1))
+		assert.Equal(t, float64(1), v.GetFloat64("hosts", 1))
+	})
+
+	t.Run("bool", func(t *testing.T) {
+		assert.True(t, v.GetBool("server.http.debug", false))
+		assert.True(t, v.GetBool("server.http.addr", true))
+		assert.False(t, v.GetBool("missing", false))
+	})
+
+	t.Run("duration", func(t *testing.T) {
+		assert.Equal(t, 1500*time.Millisecond, v.GetDuration("server.http.timeout", time.Second))
+		assert.Equal(t, 3*time.Second, v.GetDuration("server.grpc.timeout", time.Second))
+		assert.Equal(t, 500*time.Millisecond, v.GetDuration("server.grpc.ratio", time.Second))
+		assert.Equal(t, time.Second, v.GetDuration("server.http.addr", time.Second))
+		assert.Equal(t, time.Second, v.GetDuration("missing", time.Second))
+	})
+
+	t.Run("bytes-size", func(t *testing.T) {
+		assert.Equal(t, int64(10<<20), v.GetBytesSize("upload.max", 1))
+		assert.Equal(t, int64(512), v.GetBytesSize("upload.min", 1))
+		assert.Equal(t, int64(1), v.GetBytesSize("upload.bad", 1))
+		assert.Equal(t, int64(1), v.GetBytesSize("missing", 1))
+	})
+
+	t.Run("string-slice", func(t *testing.T) {
+		assert.Equal(t, []string{"a", "b", "3"}, v.GetStringSlice("hosts", nil))
+		assert.Equal(t, []string{"x", "y", "z"}, v.GetStringSlice("tags", nil))
+		assert.Equal(t, []string{"def"}, v.GetStringSlice("server.http", []string{"def"}))
+		assert.Equal(t, []string{"def"}, v.GetStringSlice("missing", []string{"def"}))
+	})
+}
+
+// TestParseBytesSize 验证字节大小解析。
+func TestParseBytesSize(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		give        string
+		want        int64
+		wantErr     bool
+	}{
+		{name: "success/plain", description: "验证无单位按字节解析。", give: "4096", want: 4096},
+		{name: "success/bytes", description: "验证 B 单位。", give: "64B", want: 64},
+		{name: "success/kib", description: "验证二进制单位。", give: "10MiB", want: 10 << 20},
+		{name: "success/short", description: "验证单字母单位按 1024 进位。", give: "2k", want: 2048},
+		{name: "success/si", description: "验证十进制单位。", give: "512KB", want: 512000},
+		{name: "success/fraction", description: "验证小数与空白。", give: " 1.5 GiB ", want: 3 << 29},
+		{name: "success/case", description: "验证单位不区分大小写。", give: "1gb", want: 1e9},
+		{name: "error/empty", description: "验证空字符串。", give: "", wantErr: true},
+		{name: "error/negative", description: "验证负数。", give: "-1MB", wantErr: true},
+		{name: "error/unit", description: "验证未知单位。", give: "10XB", wantErr: true},
+		{name: "error/number", description: "验证非法数字。", give: "1.2.3MB", wantErr: true},
+		{name: "error/overflow", description: "验证超出 int64 范围。", give: "9000000PiB", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			got, err := ParseBytesSize(tt.give)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// TestDecoder_Values 验证从 Decoder 捕获的配置读取单个配置项。
+func TestDecoder_Values(t *testing.T) {
+	d := NewDecoder(WithCapture(true))
+	target := make(map[string]any)
+	require.NoError(t, d.Decode(&kratosconfig.KeyValue{
+		Key:    "app.json",
+		Value:  []byte(`{"server":{"http":{"timeout":"2s","hosts":["a","b"]}}}`),
+		Format: "json",
+	}, target))
+
+	v := d.Values()
+	assert.Equal(t, 2*time.Second, v.GetDuration("server.http.timeout", 0))
+	assert.Equal(t, []string{"a", "b"}, v.GetStringSlice("server.http.hosts", nil))
+}
@@ -0,0 +1,158 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	kratosencoding "github.com/go-kratos/kratos/v2/encoding"
+)
+
+const (
+	// IncludeKey 是配置文件中声明引入其它配置文件的指令 key。
+	IncludeKey = "include"
+)
+
+var (
+	// ErrIncludeCycle 表示 include 指令形成了循环引用。
+	ErrIncludeCycle = errors.New("config include cycle")
+)
+
+// WithInclude 启用配置文件中的 include 指令，并设置顶层配置源的所在目录。
+//
+// 启用后，Decode 会读取配置中 "include: [other.yaml, secrets.yaml]" 形式的指令并删除该 key，按列出顺序
+// 依次解码被引入的文件，后列出的文件覆盖先列出的文件，当前文件自身的配置最后合并并覆盖所有被引入的文件。
+// 被引入的文件同样可以包含 include 指令，其中的相对路径相对于该文件所在目录解析；形成循环引用时返回 ErrIncludeCycle。
+// 被引入文件的格式由扩展名决定，Resolve 在全部合并完成后对最终结果执行一次。
+//
+// 参数：
+//   - dir：顶层配置源所在目录，顶层 include 中的相对路径相对于该目录解析；为空时使用当前工作目录。
+//
+// 返回值：
+//   - DecoderOption：可用于配置 Decoder 的选项函数。
+func WithInclude(dir string) DecoderOption {
+	return func(o *DecoderOptions) {
+		o.Include = true
+		o.IncludeDir = dir
+	}
+}
+
+// resolveIncludes 处理 target 中的 include 指令，把被引入的文件合并到 target 之下。
+//
+// 参数：
+//   - file：target 对应的配置文件路径，用于解析相对路径和检测循环引用。
+//   - target：已解码的配置，处理完成后包含合并后的结果且不再含 include 指令。
+//   - chain：从顶层配置源到 file 的引用链，不包含 file 本身。
+//
+// 返回值：
+//   - error：include 指令格式不合法、文件读取或解码失败、存在循环引用时返回错误。
+func resolveIncludes(file string, target map[string]any, chain []string) error {
+	raw, ok := target[IncludeKey]
+	if !ok {
+		return nil
+	}
+	delete(target, IncludeKey)
+
+	paths, err := includePaths(raw)
+	if nil != err {
+		return fmt.Errorf("%s: %w", file, err)
+	}
+
+	chain = append(chain, file)
+	merged := make(map[string]any)
+	for _, p := range paths {
+		if !filepath.IsAbs(p) {
+			p = filepath.Join(filepath.Dir(file), p)
+		}
+		p = filepath.Clean(p)
+		for _, visited := range chain {
+			if visited == p {
+				return fmt.Errorf("%w: %s -> %s", ErrIncludeCycle, strings.Join(chain, " -> "), p)
+			}
+		}
+
+		included, err := decodeIncludeFile(p)
+		if nil != err {
+			return fmt.Errorf("%s: include %s: %w", file, p, err)
+		}
+		if err := resolveIncludes(p, included, chain); nil != err {
+			return err
+		}
+		mergeMap(merged, included)
+	}
+
+	// 当前文件自身的配置覆盖所有被引入的文件。
+	mergeMap(merged, target)
+	for k := range target {
+		delete(target, k)
+	}
+	for k, v := range merged {
+		target[k] = v
+	}
+	return nil
+}
+
+// includePaths 把 include 指令的值转换为路径列表。
+//
+// 参数：
+//   - raw：include 指令的值，可以是单个字符串或字符串列表。
+//
+// 返回值：
+//   - []string：按声明顺序排列的路径。
+//   - error：值不是字符串或字符串列表，或包含空路径时返回错误。
+func includePaths(raw any) ([]string, error) {
+	switch v := raw.(type) {
+	case string:
+		if "" == v {
+			return nil, fmt.Errorf("%s: empty path", IncludeKey)
+		}
+		return []string{v}, nil
+	case []any:
+		paths := make([]string, 0, len(v))
+		for i, item := range v {
+			p, ok := item.(string)
+			if !ok || "" == p {
+				return nil, fmt.Errorf("%s[%d]: want non-empty string, got %v", IncludeKey, i, item)
+			}
+			paths = append(paths, p)
+		}
+		return paths, nil
+	default:
+		return nil, fmt.Errorf("%s: want string or list of strings, got %T", IncludeKey, raw)
+	}
+}
+
+// decodeIncludeFile 读取并解码被引入的配置文件。
+//
+// 参数：
+//   - path：配置文件路径，格式由扩展名决定，yml 视为 yaml。
+//
+// 返回值：
+//   - map[string]any：解码结果。
+//   - error：读取失败、格式不受支持或反序列化失败时返回错误。
+func decodeIncludeFile(path string) (map[string]any, error) {
+	format := strings.TrimPrefix(filepath.Ext(path), ".")
+	if "yml" == format {
+		format = "yaml"
+	}
+	codec := kratosencoding.GetCodec(format)
+	if nil == codec {
+		return nil, fmt.Errorf("unsupported format: %q", format)
+	}
+
+	data, err := os.ReadFile(path)
+	if nil != err {
+		return nil, err
+	}
+	target := make(map[string]any)
+	if err := codec.Unmarshal(data, &target); nil != err {
+		return nil, err
+	}
+	return target, nil
+}
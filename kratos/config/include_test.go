@@ -0,0 +1,180 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	kratosconfig "github.com/go-kratos/kratos/v2/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeConfigFiles 在 dir 下写入测试配置文件，文件名可包含子目录。
+//
+// 参数：
+//   - t: 测试上下文，用于报告写入失败。
+//   - dir: 配置文件根目录。
+//   - files: 文件名到文件内容的映射。
+func writeConfigFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+}
+
+// TestDecode_Include 验证 include 指令的相对路径解析、合并顺序、循环检测和错误路径。
+func TestDecode_Include(t *testing.T) {
+	tests := []struct {
+		name            string
+		description     string
+		giveFiles       map[string]string
+		giveSrc         string
+		wantTarget      map[string]any
+		wantErrIs       error
+		wantErrContains string
+	}{
+		{
+			name:        "success/merge-order",
+			description: "验证后列出的文件覆盖先列出的文件，当前文件自身的配置覆盖所有被引入的文件，且 include 指令被删除。",
+			giveFiles: map[string]string{
+				"base.json":    `{"app":{"name":"base","port":8000},"log":"info"}`,
+				"secrets.json": `{"app":{"name":"secrets","token":"t"},"log":"warn"}`,
+			},
+			giveSrc: `{"include":["base.json","secrets.json"],"log":"debug"}`,
+			wantTarget: map[string]any{
+				"app": map[string]any{"name": "secrets", "port": float64(8000), "token": "t"},
+				"log": "debug",
+			},
+		},
+		{
+			name:        "success/single-string",
+			description: "验证 include 指令可以是单个字符串。",
+			giveFiles: map[string]string{
+				"base.json": `{"a":1}`,
+			},
+			giveSrc:    `{"include":"base.json","b":2}`,
+			wantTarget: map[string]any{"a": float64(1), "b": float64(2)},
+		},
+		{
+			name:        "success/nested-relative",
+			description: "验证嵌套 include 中的相对路径相对于被引入文件所在目录解析，并允许多个文件引入同一文件。",
+			giveFiles: map[string]string{
+				"conf/db.json":            `{"include":["common/shared.json"],"db":{"host":"db"}}`,
+				"conf/cache.json":         `{"include":["common/shared.json"],"cache":{"host":"cache"}}`,
+				"conf/common/shared.json": `{"shared":true}`,
+			},
+			giveSrc: `{"include":["conf/db.json","conf/cache.json"]}`,
+			wantTarget: map[string]any{
+				"db":     map[string]any{"host": "db"},
+				"cache":  map[string]any{"host": "cache"},
+				"shared": true,
+			},
+		},
+		{
+			name:        "success/empty-list",
+			description: "验证空的 include 列表只删除指令本身。",
+			giveSrc:     `{"include":[],"a":1}`,
+			wantTarget:  map[string]any{"a": float64(1)},
+		},
+		{
+			name:        "error/cycle",
+			description: "验证间接循环引用返回 ErrIncludeCycle，错误中包含引用链。",
+			giveFiles: map[string]string{
+				"a.json": `{"include":["b.json"]}`,
+				"b.json": `{"include":["config.json"]}`,
+			},
+			giveSrc:         `{"include":["a.json"]}`,
+			wantErrIs:       ErrIncludeCycle,
+			wantErrContains: "a.json -> ",
+		},
+		{
+			name:        "error/self-include",
+			description: "验证文件引入自身时返回 ErrIncludeCycle。",
+			giveFiles: map[string]string{
+				"a.json": `{"include":["./a.json"]}`,
+			},
+			giveSrc:   `{"include":["a.json"]}`,
+			wantErrIs: ErrIncludeCycle,
+		},
+		{
+			name:            "error/invalid-type",
+			description:     "验证 include 指令不是字符串或字符串列表时返回错误。",
+			giveSrc:         `{"include":{"file":"a.json"}}`,
+			wantErrContains: "want string or list of strings",
+		},
+		{
+			name:            "error/invalid-item",
+			description:     "验证 include 列表包含非字符串元素时返回错误。",
+			giveSrc:         `{"include":["a.json",1]}`,
+			wantErrContains: "include[1]",
+		},
+		{
+			name:            "error/missing-file",
+			description:     "验证被引入的文件不存在时返回错误。",
+			giveSrc:         `{"include":["missing.json"]}`,
+			wantErrIs:       os.ErrNotExist,
+			wantErrContains: "missing.json",
+		},
+		{
+			name:        "error/unsupported-format",
+			description: "验证被引入文件的扩展名没有对应 codec 时返回错误。",
+			giveFiles: map[string]string{
+				"a.conf": `a=1`,
+			},
+			giveSrc:         `{"include":["a.conf"]}`,
+			wantErrContains: `unsupported format: "conf"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			dir := t.TempDir()
+			writeConfigFiles(t, dir, tt.giveFiles)
+			d := NewDecoder(WithInclude(dir), WithResolve(nil))
+			target := map[string]any{}
+			err := d.Decode(&kratosconfig.KeyValue{Key: "config.json", Format: "json", Value: []byte(tt.giveSrc)}, target)
+
+			if nil != tt.wantErrIs || "" != tt.wantErrContains {
+				require.Error(t, err)
+				if nil != tt.wantErrIs {
+					assert.ErrorIs(t, err, tt.wantErrIs)
+				}
+				assert.Contains(t, err.Error(), tt.wantErrContains)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantTarget, target)
+		})
+	}
+}
+
+// TestDecode_IncludeDisabled 验证未启用 WithInclude 时 include 作为普通配置项保留，且 Resolve 在合并后执行。
+func TestDecode_IncludeDisabled(t *testing.T) {
+	t.Log("验证默认不处理 include 指令；启用后 Resolve 能看到被引入文件中的配置。")
+
+	src := &kratosconfig.KeyValue{Key: "config.json", Format: "json", Value: []byte(`{"include":["base.json"]}`)}
+	target := map[string]any{}
+	require.NoError(t, NewDecoder().Decode(src, target))
+	assert.Equal(t, map[string]any{"include": []any{"base.json"}}, target)
+
+	dir := t.TempDir()
+	writeConfigFiles(t, dir, map[string]string{"base.json": `{"name":"kit"}`})
+	var resolved map[string]any
+	d := NewDecoder(WithInclude(dir), WithResolve(func(target map[string]any) error {
+		resolved = copyMap(target)
+		return nil
+	}))
+	target = map[string]any{}
+	require.NoError(t, d.Decode(src, target))
+	assert.Equal(t, map[string]any{"name": "kit"}, resolved)
+}
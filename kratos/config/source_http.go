@@ -0,0 +1,456 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package config
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	kratosconfig "github.com/go-kratos/kratos/v2/config"
+
+	kitcryptosha "github.com/fsyyft-go/kit/crypto/sha"
+	kithttp "github.com/fsyyft-go/kit/net/http"
+)
+
+const (
+	// HTTPSourceSignatureHeader 是 HTTP 配置源默认读取签名的响应头。
+	HTTPSourceSignatureHeader = "X-Config-Signature"
+
+	// httpSourcePollIntervalDefault 是 HTTP 配置源默认的轮询间隔。
+	httpSourcePollIntervalDefault = 30 * time.Second
+)
+
+var (
+	// 断言 HTTPSource 实现 Kratos 配置源接口。
+	_ kratosconfig.Source = (*HTTPSource)(nil)
+	// 断言 httpWatcher 实现 Kratos 配置监听接口。
+	_ kratosconfig.Watcher = (*httpWatcher)(nil)
+
+	// ErrHTTPSourceStatus 表示配置服务返回了 200 和 304 以外的状态码。
+	ErrHTTPSourceStatus = errors.New("config http source unexpected status")
+	// ErrHTTPSourceSignature 表示响应缺少签名或签名校验失败。
+	ErrHTTPSourceSignature = errors.New("config http source signature mismatch")
+)
+
+type (
+	// HTTPSourceOption 是一个函数类型，用于配置 HTTPSource 的选项。
+	HTTPSourceOption func(*httpSourceOptions)
+
+	// httpSourceOptions 包含 HTTP 配置源的配置选项。
+	httpSourceOptions struct {
+		// client 是发送请求的 kit HTTP 客户端。
+		client kithttp.Client
+		// interval 是 Watch 的轮询间隔。
+		interval time.Duration
+		// key 是配置源 key，为空时使用 URL 路径的最后一段。
+		key string
+		// format 是配置格式，为空时依次根据 URL 扩展名和 Content-Type 推断。
+		format string
+		// header 是每次请求附加的请求头，例如鉴权信息。
+		header http.Header
+		// secret 是校验签名使用的 HMAC 密钥，为空时不校验签名。
+		secret []byte
+		// algorithm 是 HMAC 使用的摘要算法。
+		algorithm kitcryptosha.Algorithm
+		// signatureHeader 是读取签名的响应头。
+		signatureHeader string
+	}
+
+	// HTTPSource 是基于 kit net/http 轮询远程 URL 的 Kratos 配置源。
+	//
+	// 请求携带上一次响应的 ETag（If-None-Match），服务端返回 304 时视为未变化；配置了签名密钥时，
+	// 响应体的 HMAC 必须与签名响应头一致才会被采用。适合没有 etcd、Consul 等配置中心的小型部署。
+	HTTPSource struct {
+		// url 是配置地址。
+		url string
+		// options 是配置选项。
+		options httpSourceOptions
+
+		// mu 保护 etag 和 last。
+		mu sync.Mutex
+		// etag 是最近一次采用的响应的 ETag。
+		etag string
+		// last 是最近一次采用的配置。
+		last *kratosconfig.KeyValue
+	}
+
+	// httpWatcher 按固定间隔轮询 HTTPSource，仅在配置内容变化时返回。
+	httpWatcher struct {
+		// source 是被监听的配置源。
+		source *HTTPSource
+		// ticker 控制轮询节奏。
+		ticker *time.Ticker
+		// ctx 在 Stop 时取消，用于结束等待和进行中的请求。
+		ctx context.Context
+		// cancel 取消 ctx。
+		cancel context.CancelFunc
+	}
+)
+
+// WithHTTPClient 设置 HTTP 配置源发送请求使用的客户端。
+//
+// 参数：
+//   - client：kit HTTP 客户端，可携带签名、重试日志等 Hook；为 nil 时使用 kithttp.NewClient() 创建的默认客户端。
+//
+// 返回值：
+//   - HTTPSourceOption：可用于配置 HTTPSource 的选项函数。
+func WithHTTPClient(client kithttp.Client) HTTPSourceOption {
+	return func(o *httpSourceOptions) {
+		o.client = client
+	}
+}
+
+// WithPollInterval 设置 Watch 的轮询间隔。
+//
+// 参数：
+//   - interval：轮询间隔；小于等于 0 时使用默认值 30 秒。
+//
+// 返回值：
+//   - HTTPSourceOption：可用于配置 HTTPSource 的选项函数。
+func WithPollInterval(interval time.Duration) HTTPSourceOption {
+	return func(o *httpSourceOptions) {
+		o.interval = interval
+	}
+}
+
+// WithHTTPSourceKey 设置配置源 key。
+//
+// 参数：
+//   - key：配置源 key，对应 KeyValue.Key；为空时使用 URL 路径的最后一段。
+//
+// 返回值：
+//   - HTTPSourceOption：可用于配置 HTTPSource 的选项函数。
+func WithHTTPSourceKey(key string) HTTPSourceOption {
+	return func(o *httpSourceOptions) {
+		o.key = key
+	}
+}
+
+// WithHTTPSourceFormat 设置配置格式。
+//
+// 参数：
+//   - format：Kratos codec 名称，例如 yaml、json；为空时先取 URL 扩展名（yml 视为 yaml），
+//     再根据响应的 Content-Type 推断。
+//
+// 返回值：
+//   - HTTPSourceOption：可用于配置 HTTPSource 的选项函数。
+func WithHTTPSourceFormat(format string) HTTPSourceOption {
+	return func(o *httpSourceOptions) {
+		o.format = format
+	}
+}
+
+// WithHTTPSourceHeader 为每次请求附加请求头。
+//
+// 参数：
+//   - key：请求头名称。
+//   - value：请求头的值，多次设置同名请求头时以最后一次为准。
+//
+// 返回值：
+//   - HTTPSourceOption：可用于配置 HTTPSource 的选项函数。
+func WithHTTPSourceHeader(key, value string) HTTPSourceOption {
+	return func(o *httpSourceOptions) {
+		if nil == o.header {
+			o.header = make(http.Header)
+		}
+		o.header.Set(key, value)
+	}
+}
+
+// WithSignature 启用响应签名校验。
+//
+// 服务端以 HMAC(secret, 响应体) 的小写十六进制写入签名响应头，也可以带 "<算法>=" 前缀，例如 "sha256=..."。
+// 签名缺失或不匹配时 Load 返回 ErrHTTPSourceSignature，Watch 丢弃该响应并继续使用原配置。
+//
+// 参数：
+//   - secret：HMAC 密钥；为空时不校验签名。
+//   - algorithm：摘要算法，为空时使用 kitcryptosha.AlgorithmSHA256。
+//
+// 返回值：
+//   - HTTPSourceOption：可用于配置 HTTPSource 的选项函数。
+func WithSignature(secret []byte, algorithm kitcryptosha.Algorithm) HTTPSourceOption {
+	return func(o *httpSourceOptions) {
+		o.secret = secret
+		o.algorithm = algorithm
+	}
+}
+
+// WithSignatureHeader 设置读取签名的响应头。
+//
+// 参数：
+//   - header：响应头名称；为空时使用 HTTPSourceSignatureHeader。
+//
+// 返回值：
+//   - HTTPSourceOption：可用于配置 HTTPSource 的选项函数。
+func WithSignatureHeader(header string) HTTPSourceOption {
+	return func(o *httpSourceOptions) {
+		o.signatureHeader = header
+	}
+}
+
+// NewHTTPSource 创建轮询远程 URL 的 Kratos 配置源。
+//
+// 参数：
+//   - rawURL：配置地址。
+//   - opts：可选的 HTTPSourceOption 列表；nil 选项会被忽略。
+//
+// 返回值：
+//   - *HTTPSource：可传给 kratos config.WithSource 的配置源。
+func NewHTTPSource(rawURL string, opts ...HTTPSourceOption) *HTTPSource {
+	options := httpSourceOptions{
+		interval:        httpSourcePollIntervalDefault,
+		algorithm:       kitcryptosha.AlgorithmSHA256,
+		signatureHeader: HTTPSourceSignatureHeader,
+	}
+	for _, o := range opts {
+		if nil == o {
+			continue
+		}
+		o(&options)
+	}
+	if nil == options.client {
+		options.client = kithttp.NewClient()
+	}
+	if options.interval <= 0 {
+		options.interval = httpSourcePollIntervalDefault
+	}
+	if "" == options.algorithm {
+		options.algorithm = kitcryptosha.AlgorithmSHA256
+	}
+	if "" == options.signatureHeader {
+		options.signatureHeader = HTTPSourceSignatureHeader
+	}
+
+	return &HTTPSource{url: rawURL, options: options}
+}
+
+// Load 实现 kratosconfig.Source 接口，请求并返回当前配置。
+//
+// 服务端返回 304 且已有缓存时返回缓存的配置。
+//
+// 返回值：
+//   - []*kratosconfig.KeyValue：包含一个配置项的列表。
+//   - error：请求失败、状态码异常、签名校验失败或无法确定格式时返回错误。
+func (s *HTTPSource) Load() ([]*kratosconfig.KeyValue, error) {
+	kv, _, err := s.fetch(context.Background())
+	if nil != err {
+		return nil, err
+	}
+	return []*kratosconfig.KeyValue{kv}, nil
+}
+
+// Watch 实现 kratosconfig.Source 接口，返回按轮询间隔检查变化的监听器。
+//
+// 返回值：
+//   - kratosconfig.Watcher：轮询监听器。
+//   - error：始终为 nil。
+func (s *HTTPSource) Watch() (kratosconfig.Watcher, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &httpWatcher{
+		source: s,
+		ticker: time.NewTicker(s.options.interval),
+		ctx:    ctx,
+		cancel: cancel,
+	}, nil
+}
+
+// fetch 发送一次条件请求，并在响应有效时更新缓存。
+//
+// 参数：
+//   - ctx：请求上下文。
+//
+// 返回值：
+//   - *kratosconfig.KeyValue：当前采用的配置。
+//   - bool：配置内容相对上一次采用的结果是否变化；首次成功时为 true。
+//   - error：请求失败、状态码异常、签名校验失败或无法确定格式时返回错误，缓存保持不变。
+func (s *HTTPSource) fetch(ctx context.Context) (*kratosconfig.KeyValue, bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if nil != err {
+		return nil, false, err
+	}
+	for k, v := range s.options.header {
+		req.Header[k] = append([]string(nil), v...)
+	}
+	s.mu.Lock()
+	etag, last := s.etag, s.last
+	s.mu.Unlock()
+	if "" != etag && nil != last {
+		req.Header.Set("If-None-Match", etag)
+	}
+
+	resp, err := s.options.client.Do(ctx, req)
+	if nil != err {
+		return nil, false, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if http.StatusNotModified == resp.StatusCode && nil != last {
+		return last, false, nil
+	}
+	if http.StatusOK != resp.StatusCode {
+		return nil, false, fmt.Errorf("%w: %s %d", ErrHTTPSourceStatus, s.url, resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if nil != err {
+		return nil, false, err
+	}
+	if err := s.verify(body, resp.Header.Get(s.options.signatureHeader)); nil != err {
+		return nil, false, err
+	}
+	format, err := s.format(resp.Header.Get("Content-Type"))
+	if nil != err {
+		return nil, false, err
+	}
+
+	kv := &kratosconfig.KeyValue{Key: s.key(), Value: body, Format: format}
+	changed := nil == last || last.Format != kv.Format || !bytes.Equal(last.Value, kv.Value)
+
+	s.mu.Lock()
+	s.etag = resp.Header.Get("ETag")
+	if changed {
+		s.last = kv
+	} else {
+		kv = s.last
+	}
+	s.mu.Unlock()
+	return kv, changed, nil
+}
+
+// verify 校验响应体签名。
+//
+// 参数：
+//   - body：响应体。
+//   - signature：签名响应头的值。
+//
+// 返回值：
+//   - error：未启用签名校验时为 nil；签名缺失、格式错误或不匹配时返回包装了 ErrHTTPSourceSignature 的错误；
+//     摘要算法不受支持时返回 kitcryptosha.ErrUnsupportedAlgorithm。
+func (s *HTTPSource) verify(body []byte, signature string) error {
+	if 0 == len(s.options.secret) {
+		return nil
+	}
+	if _, err := s.options.algorithm.New(); nil != err {
+		return err
+	}
+	if "" == signature {
+		return fmt.Errorf("%w: missing %s", ErrHTTPSourceSignature, s.options.signatureHeader)
+	}
+
+	signature = strings.TrimPrefix(signature, string(s.options.algorithm)+"=")
+	got, err := hex.DecodeString(signature)
+	if nil != err {
+		return fmt.Errorf("%w: %v", ErrHTTPSourceSignature, err)
+	}
+	mac := hmac.New(func() hash.Hash {
+		h, _ := s.options.algorithm.New()
+		return h
+	}, s.options.secret)
+	_, _ = mac.Write(body)
+	if !hmac.Equal(got, mac.Sum(nil)) {
+		return fmt.Errorf("%w: %s", ErrHTTPSourceSignature, s.url)
+	}
+	return nil
+}
+
+// key 返回配置源 key。
+//
+// 返回值：
+//   - string：WithHTTPSourceKey 设置的 key，未设置时为 URL 路径的最后一段，路径为空时为完整 URL。
+func (s *HTTPSource) key() string {
+	if "" != s.options.key {
+		return s.options.key
+	}
+	if u, err := url.Parse(s.url); nil == err {
+		if base := path.Base(u.Path); "" != base && "/" != base && "." != base {
+			return base
+		}
+	}
+	return s.url
+}
+
+// format 确定配置格式。
+//
+// 参数：
+//   - contentType：响应的 Content-Type。
+//
+// 返回值：
+//   - string：Kratos codec 名称。
+//   - error：无法从选项、URL 扩展名和 Content-Type 中确定格式时返回错误。
+func (s *HTTPSource) format(contentType string) (string, error) {
+	if "" != s.options.format {
+		return s.options.format, nil
+	}
+	if u, err := url.Parse(s.url); nil == err {
+		if ext := strings.TrimPrefix(path.Ext(u.Path), "."); "" != ext {
+			if "yml" == ext {
+				ext = "yaml"
+			}
+			return ext, nil
+		}
+	}
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch {
+	case strings.HasSuffix(mediaType, "json"):
+		return "json", nil
+	case strings.HasSuffix(mediaType, "yaml"), strings.HasSuffix(mediaType, "yml"):
+		return "yaml", nil
+	case strings.HasSuffix(mediaType, "xml"):
+		return "xml", nil
+	case strings.HasSuffix(mediaType, "toml"):
+		return "toml", nil
+	default:
+		return "", fmt.Errorf("config http source %s: cannot determine format from Content-Type %q", s.url, contentType)
+	}
+}
+
+// Next 实现 kratosconfig.Watcher 接口，阻塞直到配置内容变化。
+//
+// 返回值：
+//   - []*kratosconfig.KeyValue：变化后的配置。
+//   - error：Stop 后返回 context.Canceled；单次轮询失败时返回该错误，Kratos 记录日志后继续调用 Next。
+func (w *httpWatcher) Next() ([]*kratosconfig.KeyValue, error) {
+	for {
+		select {
+		case <-w.ctx.Done():
+			return nil, w.ctx.Err()
+		case <-w.ticker.C:
+		}
+
+		kv, changed, err := w.source.fetch(w.ctx)
+		if nil != err {
+			if nil != w.ctx.Err() {
+				return nil, w.ctx.Err()
+			}
+			return nil, err
+		}
+		if changed {
+			return []*kratosconfig.KeyValue{kv}, nil
+		}
+	}
+}
+
+// Stop 实现 kratosconfig.Watcher 接口，停止轮询并取消进行中的请求。
+//
+// 返回值：
+//   - error：始终为 nil。
+func (w *httpWatcher) Stop() error {
+	w.ticker.Stop()
+	w.cancel()
+	return nil
+}
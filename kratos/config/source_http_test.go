@@ -0,0 +1,286 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package config
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	kratosconfig "github.com/go-kratos/kratos/v2/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	kitcryptosha "github.com/fsyyft-go/kit/crypto/sha"
+)
+
+// configServer 是支持 ETag 与签名响应头的测试配置服务。
+type configServer struct {
+	mu          sync.Mutex
+	body        string
+	contentType string
+	secret      []byte
+	signature   string
+	status      int
+	requests    atomic.Int32
+	notModified atomic.Int32
+	lastHeader  http.Header
+}
+
+// set 更新配置内容，ETag 随内容变化。
+func (s *configServer) set(body string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.body = body
+}
+
+// ServeHTTP 按 If-None-Match 返回 304 或完整配置。
+func (s *configServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.requests.Add(1)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastHeader = r.Header.Clone()
+
+	if 0 != s.status {
+		w.WriteHeader(s.status)
+		return
+	}
+	sum := sha256.Sum256([]byte(s.body))
+	etag := `"` + hex.EncodeToString(sum[:8]) + `"`
+	if etag == r.Header.Get("If-None-Match") {
+		s.notModified.Add(1)
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("ETag", etag)
+	if "" != s.contentType {
+		w.Header().Set("Content-Type", s.contentType)
+	}
+	switch {
+	case "" != s.signature:
+		w.Header().Set(HTTPSourceSignatureHeader, s.signature)
+	case 0 != len(s.secret):
+		mac := hmac.New(sha256.New, s.secret)
+		_, _ = mac.Write([]byte(s.body))
+		w.Header().Set(HTTPSourceSignatureHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+	_, _ = w.Write([]byte(s.body))
+}
+
+// TestHTTPSource_Load 验证 HTTP 配置源的格式推断、签名校验与错误路径。
+func TestHTTPSource_Load(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		server      *configServer
+		path        string
+		opts        []HTTPSourceOption
+		wantKV      *kratosconfig.KeyValue
+		wantErrIs   error
+		wantErr     bool
+	}{
+		{
+			name:        "success/format-from-extension",
+			description: "验证 URL 扩展名 yml 视为 yaml，key 为路径最后一段。",
+			server:      &configServer{body: "a: 1"},
+			path:        "/configs/app.yml",
+			wantKV:      &kratosconfig.KeyValue{Key: "app.yml", Value: []byte("a: 1"), Format: "yaml"},
+		},
+		{
+			name:        "success/format-from-content-type",
+			description: "验证 URL 没有扩展名时根据 Content-Type 推断格式，并使用指定的 key 与请求头。",
+			server:      &configServer{body: `{"a":1}`, contentType: "application/json; charset=utf-8"},
+			path:        "/configs/app",
+			opts:        []HTTPSourceOption{WithHTTPSourceKey("remote"), WithHTTPSourceHeader("Authorization", "Bearer t")},
+			wantKV:      &kratosconfig.KeyValue{Key: "remote", Value: []byte(`{"a":1}`), Format: "json"},
+		},
+		{
+			name:        "success/signature",
+			description: "验证带算法前缀的 HMAC 签名校验通过。",
+			server:      &configServer{body: "a: 1", secret: []byte("s3cret")},
+			path:        "/app.yaml",
+			opts:        []HTTPSourceOption{WithSignature([]byte("s3cret"), kitcryptosha.AlgorithmSHA256)},
+			wantKV:      &kratosconfig.KeyValue{Key: "app.yaml", Value: []byte("a: 1"), Format: "yaml"},
+		},
+		{
+			name:        "error/signature-mismatch",
+			description: "验证密钥不一致时返回 ErrHTTPSourceSignature。",
+			server:      &configServer{body: "a: 1", secret: []byte("other")},
+			path:        "/app.yaml",
+			opts:        []HTTPSourceOption{WithSignature([]byte("s3cret"), "")},
+			wantErrIs:   ErrHTTPSourceSignature,
+		},
+		{
+			name:        "error/signature-missing",
+			description: "验证启用签名校验但响应缺少签名时返回 ErrHTTPSourceSignature。",
+			server:      &configServer{body: "a: 1"},
+			path:        "/app.yaml",
+			opts:        []HTTPSourceOption{WithSignature([]byte("s3cret"), "")},
+			wantErrIs:   ErrHTTPSourceSignature,
+		},
+		{
+			name:        "error/signature-malformed",
+			description: "验证签名不是十六进制时返回 ErrHTTPSourceSignature。",
+			server:      &configServer{body: "a: 1", signature: "not-hex"},
+			path:        "/app.yaml",
+			opts:        []HTTPSourceOption{WithSignature([]byte("s3cret"), "")},
+			wantErrIs:   ErrHTTPSourceSignature,
+		},
+		{
+			name:        "error/unsupported-algorithm",
+			description: "验证摘要算法不受支持时返回 ErrUnsupportedAlgorithm。",
+			server:      &configServer{body: "a: 1", secret: []byte("s3cret")},
+			path:        "/app.yaml",
+			opts:        []HTTPSourceOption{WithSignature([]byte("s3cret"), "md4")},
+			wantErrIs:   kitcryptosha.ErrUnsupportedAlgorithm,
+		},
+		{
+			name:        "error/status",
+			description: "验证 200 和 304 以外的状态码返回 ErrHTTPSourceStatus。",
+			server:      &configServer{status: http.StatusNotFound},
+			path:        "/app.yaml",
+			wantErrIs:   ErrHTTPSourceStatus,
+		},
+		{
+			name:        "error/unknown-format",
+			description: "验证无法确定格式时返回错误。",
+			server:      &configServer{body: "a", contentType: "text/plain"},
+			path:        "/app",
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			ts := httptest.NewServer(tt.server)
+			t.Cleanup(ts.Close)
+
+			kvs, err := NewHTTPSource(ts.URL+tt.path, tt.opts...).Load()
+			if nil != tt.wantErrIs {
+				require.ErrorIs(t, err, tt.wantErrIs)
+				return
+			}
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, kvs, 1)
+			assert.Equal(t, tt.wantKV, kvs[0])
+			if "remote" == tt.wantKV.Key {
+				tt.server.mu.Lock()
+				assert.Equal(t, "Bearer t", tt.server.lastHeader.Get("Authorization"))
+				tt.server.mu.Unlock()
+			}
+		})
+	}
+}
+
+// TestHTTPSource_ETag 验证 Load 携带 If-None-Match，服务端返回 304 时沿用缓存。
+func TestHTTPSource_ETag(t *testing.T) {
+	server := &configServer{body: "a: 1"}
+	ts := httptest.NewServer(server)
+	t.Cleanup(ts.Close)
+
+	source := NewHTTPSource(ts.URL + "/app.yaml")
+	first, err := source.Load()
+	require.NoError(t, err)
+	second, err := source.Load()
+	require.NoError(t, err)
+
+	assert.Equal(t, first, second)
+	assert.Equal(t, int32(2), server.requests.Load())
+	assert.Equal(t, int32(1), server.notModified.Load())
+}
+
+// TestHTTPSource_Watch 验证轮询只在内容变化时返回，签名无效的更新被丢弃，Stop 结束等待。
+func TestHTTPSource_Watch(t *testing.T) {
+	server := &configServer{body: "a: 1", secret: []byte("s3cret")}
+	ts := httptest.NewServer(server)
+	t.Cleanup(ts.Close)
+
+	source := NewHTTPSource(ts.URL+"/app.yaml",
+		WithPollInterval(10*time.Millisecond),
+		WithSignature([]byte("s3cret"), kitcryptosha.AlgorithmSHA256),
+	)
+	_, err := source.Load()
+	require.NoError(t, err)
+	w, err := source.Watch()
+	require.NoError(t, err)
+
+	// 内容未变化时 Next 持续轮询，收到 304 后不返回。
+	result := make(chan []*kratosconfig.KeyValue, 1)
+	go func() {
+		kvs, err := w.Next()
+		assert.NoError(t, err)
+		result <- kvs
+	}()
+	require.Eventually(t, func() bool { return server.notModified.Load() >= 2 }, time.Second, 5*time.Millisecond)
+	server.set("a: 2")
+	select {
+	case kvs := <-result:
+		require.Len(t, kvs, 1)
+		assert.Equal(t, "a: 2", string(kvs[0].Value))
+	case <-time.After(time.Second):
+		t.Fatal("Next 未返回变化后的配置")
+	}
+
+	// 签名无效的更新被丢弃，缓存保持不变。
+	server.mu.Lock()
+	server.secret = []byte("other")
+	server.body = "a: 3"
+	server.mu.Unlock()
+	_, err = w.Next()
+	require.ErrorIs(t, err, ErrHTTPSourceSignature)
+	kvs, err := source.Load()
+	require.ErrorIs(t, err, ErrHTTPSourceSignature)
+	assert.Nil(t, kvs)
+	assert.Equal(t, "a: 2", string(source.last.Value))
+
+	// Stop 后 Next 返回 context.Canceled，Kratos 据此结束监听。
+	require.NoError(t, w.Stop())
+	_, err = w.Next()
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+// TestHTTPSource_KratosConfig 验证 HTTP 配置源接入 Kratos 配置的热更新流程。
+func TestHTTPSource_KratosConfig(t *testing.T) {
+	server := &configServer{body: `{"app":{"name":"v1"}}`}
+	ts := httptest.NewServer(server)
+	t.Cleanup(ts.Close)
+
+	c := kratosconfig.New(
+		kratosconfig.WithSource(NewHTTPSource(ts.URL+"/app.json", WithPollInterval(10*time.Millisecond))),
+		kratosconfig.WithDecoder(NewDecoder().Decode),
+	)
+	require.NoError(t, c.Load())
+	t.Cleanup(func() { _ = c.Close() })
+
+	name, err := c.Value("app.name").String()
+	require.NoError(t, err)
+	assert.Equal(t, "v1", name)
+
+	changed := make(chan string, 1)
+	require.NoError(t, c.Watch("app.name", func(_ string, v kratosconfig.Value) {
+		s, _ := v.String()
+		changed <- s
+	}))
+	server.set(`{"app":{"name":"v2"}}`)
+	select {
+	case got := <-changed:
+		assert.Equal(t, "v2", got)
+	case <-time.After(2 * time.Second):
+		t.Fatal("未收到配置变化通知")
+	}
+}
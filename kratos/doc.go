@@ -14,7 +14,11 @@ Package kratos 提供了对 go-kratos 框架的扩展和增强功能，包括配
   - 集成 Kratos 配置系统
 
 2. middleware - 中间件：
+  - accesscontrol：来源 IP 与请求头准入控制中间件
   - basicauth：HTTP 基本认证中间件
+  - locale：基于 i18n 的语言协商中间件
+  - maintenance：维护模式与功能熔断开关中间件
+  - tracing：基于 OpenTelemetry 的链路追踪中间件
   - validate：请求验证中间件
 
 3. transport - 传输层：
@@ -0,0 +1,324 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package accesscontrol
+
+import (
+	"context"
+	"net/http"
+	"net/netip"
+	"sort"
+	"strings"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc/peer"
+)
+
+const (
+	// namespace 定义 Prometheus 指标命名空间。
+	namespace = "kit_kratos"
+	// subsystem 定义 Prometheus 指标子系统名称。
+	subsystem = "middleware"
+
+	// headerForwardedFor 是代理追加客户端地址的请求头。
+	headerForwardedFor = "X-Forwarded-For"
+)
+
+var (
+	// ErrAccessDenied 表示请求未通过访问控制规则。
+	//
+	// 中间件返回的错误会在元数据中携带 operation、client_ip 与 rule，rule 取值为 RuleDenyCIDR 等常量；
+	// 调用方通常按 403 Forbidden 处理，并可通过 errors.Is 或 reason `ACCESS_DENIED` 识别。
+	ErrAccessDenied = errors.Forbidden("ACCESS_DENIED", "Access denied")
+
+	// MetricDeniedTotal 记录被访问控制拒绝的请求次数。
+	//
+	// 标签：
+	//   - operation：被拒绝请求的 Operation。
+	//   - rule：命中的规则名称，取值为 RuleDenyCIDR、RuleAllowCIDR、RuleRequireHeader 或 RuleDenyHeader。
+	MetricDeniedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "access_denied_total",
+		Help:      "kratos access control denied total.",
+	}, []string{"operation", "rule"})
+)
+
+type (
+	// Option 配置 New 与 Server 返回的访问控制中间件。
+	//
+	// Option 通常由 WithRules、WithOperationRules、WithTrustedProxies 或 WithRemoteAddr 返回。
+	Option func(*options)
+
+	// RemoteAddrFunc 返回直接连接到服务端的对端地址。
+	//
+	// 参数：
+	//   - ctx context.Context：当前请求上下文。
+	//
+	// 返回值：
+	//   - string：`IP:port` 或 IP 形式的地址；无法获取时返回空字符串。
+	RemoteAddrFunc func(ctx context.Context) string
+
+	// options 包含中间件配置选项。
+	options struct {
+		// 未命中任何分组的 Operation 使用的规则。
+		rules []Rule
+		// 按 Operation 前缀分组的规则。
+		groups map[string][]Rule
+		// 可信代理的网段。
+		trustedProxies []string
+		// 获取对端地址的函数。
+		remoteAddr RemoteAddrFunc
+	}
+
+	// group 是按 Operation 前缀匹配的策略。
+	group struct {
+		// prefix 是 Operation 前缀。
+		prefix string
+		// policy 是该分组的策略。
+		policy *policy
+	}
+
+	// requester 是可以取得 *http.Request 的服务端 transport，Kratos HTTP transport 实现该接口。
+	requester interface {
+		// Request 返回当前请求。
+		Request() *http.Request
+	}
+)
+
+// WithRules 配置未命中任何分组的 Operation 使用的规则。
+//
+// 参数：
+//   - rules ...Rule：访问控制规则，多次调用时追加。
+//
+// 返回值：
+//   - Option：中间件配置选项。
+//
+// 若未设置该选项且请求未命中分组，中间件不做限制。
+func WithRules(rules ...Rule) Option {
+	return func(o *options) {
+		o.rules = append(o.rules, rules...)
+	}
+}
+
+// WithOperationRules 为 Operation 前缀相同的一组接口单独配置规则。
+//
+// 参数：
+//   - prefix string：Operation 前缀，例如 `/admin.v1.` 或完整的 `/admin.v1.Admin/Reset`。
+//   - rules ...Rule：该分组的访问控制规则，对同一前缀多次调用时追加。
+//
+// 返回值：
+//   - Option：中间件配置选项。
+//
+// 请求命中多个分组时使用前缀最长的分组；命中分组后只检查该分组的规则，不再检查 WithRules 的规则。
+// rules 为空的分组表示该组接口不做限制。
+func WithOperationRules(prefix string, rules ...Rule) Option {
+	return func(o *options) {
+		o.groups[prefix] = append(o.groups[prefix], rules...)
+	}
+}
+
+// WithTrustedProxies 配置可信代理的网段，用于从 X-Forwarded-For 中识别客户端 IP。
+//
+// 参数：
+//   - cidrs ...string：CIDR 网段或单个 IP，多次调用时追加。
+//
+// 返回值：
+//   - Option：中间件配置选项。
+//
+// 只有对端地址属于可信代理时才读取 X-Forwarded-For，并从右向左跳过可信代理，取第一个不可信的地址
+// 作为客户端 IP；未配置时始终使用对端地址，避免客户端伪造请求头绕过规则。
+func WithTrustedProxies(cidrs ...string) Option {
+	return func(o *options) {
+		o.trustedProxies = append(o.trustedProxies, cidrs...)
+	}
+}
+
+// WithRemoteAddr 配置获取对端地址的函数。
+//
+// 参数：
+//   - fn RemoteAddrFunc：获取对端地址的函数；传入 nil 时保留默认实现。
+//
+// 返回值：
+//   - Option：中间件配置选项。
+//
+// 默认实现对 HTTP 请求读取 http.Request.RemoteAddr，对 gRPC 请求读取 peer.Peer 的地址。
+func WithRemoteAddr(fn RemoteAddrFunc) Option {
+	return func(o *options) {
+		if nil != fn {
+			o.remoteAddr = fn
+		}
+	}
+}
+
+// New 创建用于服务端请求的访问控制中间件。
+//
+// 参数：
+//   - opts ...Option：中间件配置选项。
+//
+// 返回值：
+//   - middleware.Middleware：在进入后续处理器前检查来源 IP 与请求头的中间件。
+//   - error：规则或可信代理配置不合法时返回包装了 ErrInvalidRule 的错误。
+//
+// 中间件按 transport.ServerContext 中的 Operation 选择规则分组，识别客户端 IP 后依次检查 IP 黑名单、
+// IP 白名单与请求头规则；未通过时不调用处理器，累加 MetricDeniedTotal 并返回携带元数据的 ErrAccessDenied。
+// 上下文中不存在服务端 transport 时直接调用后续处理器。
+// 访问控制应在认证之前执行，使被拒绝的来源不会触发认证失败计数等副作用，因此应放在 basicauth 等中间件之前。
+func New(opts ...Option) (middleware.Middleware, error) {
+	o := &options{
+		groups:     make(map[string][]Rule),
+		remoteAddr: defaultRemoteAddr,
+	}
+	for _, opt := range opts {
+		if nil == opt {
+			continue
+		}
+		opt(o)
+	}
+
+	fallback, err := newPolicy(o.rules)
+	if nil != err {
+		return nil, err
+	}
+	groups := make([]group, 0, len(o.groups))
+	for prefix, rules := range o.groups {
+		p, err := newPolicy(rules)
+		if nil != err {
+			return nil, err
+		}
+		groups = append(groups, group{prefix: prefix, policy: p})
+	}
+	// 前缀长的分组优先匹配。
+	sort.Slice(groups, func(i, j int) bool {
+		return len(groups[i].prefix) > len(groups[j].prefix)
+	})
+	trusted, err := parsePrefixes(o.trustedProxies)
+	if nil != err {
+		return nil, err
+	}
+
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			tr, ok := transport.FromServerContext(ctx)
+			if !ok {
+				return handler(ctx, req)
+			}
+
+			operation := tr.Operation()
+			p := fallback
+			for _, g := range groups {
+				if strings.HasPrefix(operation, g.prefix) {
+					p = g.policy
+					break
+				}
+			}
+
+			ip := clientIP(o.remoteAddr(ctx), tr.RequestHeader(), trusted)
+			if rule := p.check(ip, tr.RequestHeader()); "" != rule {
+				MetricDeniedTotal.WithLabelValues(operation, rule).Inc()
+				addr := ""
+				if ip.IsValid() {
+					addr = ip.String()
+				}
+				return nil, ErrAccessDenied.WithMetadata(map[string]string{
+					"operation": operation,
+					"client_ip": addr,
+					"rule":      rule,
+				})
+			}
+			return handler(ctx, req)
+		}
+	}, nil
+}
+
+// Server 创建用于服务端请求的访问控制中间件，配置不合法时 panic。
+//
+// 参数：
+//   - opts ...Option：中间件配置选项。
+//
+// 返回值：
+//   - middleware.Middleware：访问控制中间件，行为与 New 相同。
+//
+// 适合在服务初始化时使用固定配置；规则来自外部配置时应使用 New 并处理错误。
+func Server(opts ...Option) middleware.Middleware {
+	m, err := New(opts...)
+	if nil != err {
+		panic(err)
+	}
+	return m
+}
+
+// clientIP 识别客户端 IP。
+//
+// 参数：
+//   - remoteAddr string：对端地址。
+//   - header transport.Header：请求头，可以为 nil。
+//   - trusted []netip.Prefix：可信代理网段。
+//
+// 返回值：
+//   - netip.Addr：客户端 IP；对端地址或 X-Forwarded-For 中需要使用的地址无法解析时返回零值。
+func clientIP(remoteAddr string, header transport.Header, trusted []netip.Prefix) netip.Addr {
+	ip, ok := parseAddr(remoteAddr)
+	if !ok || !containsAddr(trusted, ip) || nil == header {
+		return ip
+	}
+
+	var hops []string
+	for _, value := range header.Values(headerForwardedFor) {
+		hops = append(hops, strings.Split(value, ",")...)
+	}
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop, ok := parseAddr(hops[i])
+		if !ok {
+			return netip.Addr{}
+		}
+		ip = hop
+		if !containsAddr(trusted, hop) {
+			break
+		}
+	}
+	return ip
+}
+
+// parseAddr 解析 `IP:port` 或 IP 形式的地址。
+//
+// 参数：
+//   - addr string：待解析的地址。
+//
+// 返回值：
+//   - netip.Addr：解析结果，IPv4 映射的 IPv6 地址转换为 IPv4。
+//   - bool：解析成功时返回 true。
+func parseAddr(addr string) (netip.Addr, bool) {
+	addr = strings.TrimSpace(addr)
+	if ap, err := netip.ParseAddrPort(addr); nil == err {
+		return ap.Addr().Unmap(), true
+	}
+	ip, err := netip.ParseAddr(strings.Trim(addr, "[]"))
+	if nil != err {
+		return netip.Addr{}, false
+	}
+	return ip.Unmap(), true
+}
+
+// defaultRemoteAddr 是未配置 WithRemoteAddr 时使用的对端地址获取函数。
+//
+// 参数：
+//   - ctx context.Context：当前请求上下文。
+//
+// 返回值：
+//   - string：HTTP 请求的 RemoteAddr 或 gRPC 对端地址；均不可用时返回空字符串。
+func defaultRemoteAddr(ctx context.Context) string {
+	if tr, ok := transport.FromServerContext(ctx); ok {
+		if r, ok := tr.(requester); ok && nil != r.Request() {
+			return r.Request().RemoteAddr
+		}
+	}
+	if p, ok := peer.FromContext(ctx); ok && nil != p.Addr {
+		return p.Addr.String()
+	}
+	return ""
+}
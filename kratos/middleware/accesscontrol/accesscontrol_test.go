@@ -0,0 +1,240 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package accesscontrol
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/peer"
+)
+
+// headerCarrier 使用 http.Header 实现 transport.Header。
+type headerCarrier http.Header
+
+// Get 返回指定键的第一个值。
+func (hc headerCarrier) Get(key string) string { return http.Header(hc).Get(key) }
+
+// Set 设置指定键的值。
+func (hc headerCarrier) Set(key string, value string) { http.Header(hc).Set(key, value) }
+
+// Add 追加指定键的值。
+func (hc headerCarrier) Add(key string, value string) { http.Header(hc).Add(key, value) }
+
+// Keys 返回所有键。
+func (hc headerCarrier) Keys() []string {
+	keys := make([]string, 0, len(hc))
+	for k := range hc {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// Values 返回指定键的所有值。
+func (hc headerCarrier) Values(key string) []string { return http.Header(hc).Values(key) }
+
+// mockTransport 实现 transport.Transporter 与 requester，提供测试所需的 Operation、请求头和请求。
+type mockTransport struct {
+	transport.Transporter
+	operation string
+	request   *http.Request
+}
+
+// Operation 返回预设的 Operation。
+func (m *mockTransport) Operation() string {
+	return m.operation
+}
+
+// RequestHeader 返回请求的请求头。
+func (m *mockTransport) RequestHeader() transport.Header {
+	return headerCarrier(m.request.Header)
+}
+
+// Request 返回预设的请求。
+func (m *mockTransport) Request() *http.Request {
+	return m.request
+}
+
+// newContext 创建携带 HTTP 服务端 transport 的上下文。
+//
+// 参数：
+//   - operation string：请求的 Operation。
+//   - remoteAddr string：请求的对端地址。
+//   - header http.Header：请求头，可以为 nil。
+//
+// 返回值：
+//   - context.Context：携带 transport 的上下文。
+func newContext(operation, remoteAddr string, header http.Header) context.Context {
+	if nil == header {
+		header = http.Header{}
+	}
+	r := &http.Request{RemoteAddr: remoteAddr, Header: header}
+	return transport.NewServerContext(context.Background(), &mockTransport{operation: operation, request: r})
+}
+
+// okHandler 是始终成功的处理器。
+func okHandler(context.Context, interface{}) (interface{}, error) {
+	return "ok", nil
+}
+
+// TestServer 验证分组选择、可信代理与拒绝错误。
+func TestServer(t *testing.T) {
+	m := Server(
+		WithRules(Deny("203.0.113.0/24")),
+		WithOperationRules("/admin.v1.", Allow("10.0.0.0/8"), RequireHeader("X-Admin-Token")),
+		WithOperationRules("/admin.v1.Admin/Health"),
+		WithTrustedProxies("192.168.0.0/16"),
+	)
+
+	tests := []struct {
+		name        string
+		description string
+		operation   string
+		remoteAddr  string
+		header      http.Header
+		wantRule    string
+		wantIP      string
+	}{
+		{
+			name:        "success/default",
+			description: "验证未命中分组时使用默认规则并放行。",
+			operation:   "/api.v1.Api/Get",
+			remoteAddr:  "198.51.100.1:1234",
+		},
+		{
+			name:        "error/default-deny",
+			description: "验证默认规则的黑名单。",
+			operation:   "/api.v1.Api/Get",
+			remoteAddr:  "203.0.113.9:1234",
+			wantRule:    RuleDenyCIDR,
+			wantIP:      "203.0.113.9",
+		},
+		{
+			name:        "success/group",
+			description: "验证命中分组后满足分组规则时放行。",
+			operation:   "/admin.v1.Admin/Reset",
+			remoteAddr:  "10.0.0.1:1234",
+			header:      http.Header{"X-Admin-Token": {"t"}},
+		},
+		{
+			name:        "error/group-header",
+			description: "验证分组的请求头规则。",
+			operation:   "/admin.v1.Admin/Reset",
+			remoteAddr:  "10.0.0.1:1234",
+			wantRule:    RuleRequireHeader,
+			wantIP:      "10.0.0.1",
+		},
+		{
+			name:        "success/longest-prefix",
+			description: "验证前缀最长的空分组覆盖外层分组，不再检查默认规则。",
+			operation:   "/admin.v1.Admin/Health",
+			remoteAddr:  "203.0.113.9:1234",
+		},
+		{
+			name:        "success/trusted-proxy",
+			description: "验证对端为可信代理时从 X-Forwarded-For 识别客户端 IP。",
+			operation:   "/admin.v1.Admin/Reset",
+			remoteAddr:  "192.168.1.1:443",
+			header:      http.Header{"X-Forwarded-For": {"203.0.113.9, 10.2.3.4", "192.168.1.2"}, "X-Admin-Token": {"t"}},
+		},
+		{
+			name:        "error/untrusted-forwarded-for",
+			description: "验证对端不是可信代理时忽略 X-Forwarded-For。",
+			operation:   "/admin.v1.Admin/Reset",
+			remoteAddr:  "198.51.100.1:1234",
+			header:      http.Header{"X-Forwarded-For": {"10.2.3.4"}, "X-Admin-Token": {"t"}},
+			wantRule:    RuleAllowCIDR,
+			wantIP:      "198.51.100.1",
+		},
+		{
+			name:        "error/invalid-forwarded-for",
+			description: "验证 X-Forwarded-For 无法解析时视为无法识别 IP。",
+			operation:   "/admin.v1.Admin/Reset",
+			remoteAddr:  "192.168.1.1:443",
+			header:      http.Header{"X-Forwarded-For": {"unknown"}, "X-Admin-Token": {"t"}},
+			wantRule:    RuleAllowCIDR,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			before := testutil.ToFloat64(MetricDeniedTotal.WithLabelValues(tt.operation, tt.wantRule))
+			reply, err := m(okHandler)(newContext(tt.operation, tt.remoteAddr, tt.header), nil)
+			if "" == tt.wantRule {
+				require.NoError(t, err)
+				assert.Equal(t, "ok", reply)
+				return
+			}
+
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, ErrAccessDenied))
+			se := errors.FromError(err)
+			assert.Equal(t, int32(403), se.Code)
+			assert.Equal(t, map[string]string{
+				"operation": tt.operation,
+				"client_ip": tt.wantIP,
+				"rule":      tt.wantRule,
+			}, se.Metadata)
+			assert.Equal(t, before+1, testutil.ToFloat64(MetricDeniedTotal.WithLabelValues(tt.operation, tt.wantRule)))
+		})
+	}
+}
+
+// TestServer_Passthrough 验证上下文中不存在 transport 时直接调用处理器。
+func TestServer_Passthrough(t *testing.T) {
+	reply, err := Server(WithRules(Allow("10.0.0.0/8")))(okHandler)(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, "ok", reply)
+}
+
+// TestNew_InvalidRule 验证不合法配置返回错误，Server 触发 panic。
+func TestNew_InvalidRule(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		opts        []Option
+	}{
+		{name: "error/rule", description: "验证默认规则不合法。", opts: []Option{WithRules(Allow("bad"))}},
+		{name: "error/group", description: "验证分组规则不合法。", opts: []Option{WithOperationRules("/a", Deny("10.0.0.0/99"))}},
+		{name: "error/proxy", description: "验证可信代理不合法。", opts: []Option{WithTrustedProxies("bad")}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			_, err := New(tt.opts...)
+			assert.ErrorIs(t, err, ErrInvalidRule)
+			assert.Panics(t, func() { Server(tt.opts...) })
+		})
+	}
+}
+
+// TestDefaultRemoteAddr 验证默认对端地址获取函数与自定义函数。
+func TestDefaultRemoteAddr(t *testing.T) {
+	assert.Equal(t, "10.0.0.1:80", defaultRemoteAddr(newContext("/a", "10.0.0.1:80", nil)))
+
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.2"), Port: 50051}})
+	assert.Equal(t, "10.0.0.2:50051", defaultRemoteAddr(ctx))
+	assert.Equal(t, "", defaultRemoteAddr(context.Background()))
+
+	m := Server(
+		WithRules(Allow("10.0.0.3")),
+		WithRemoteAddr(func(context.Context) string { return "[::ffff:10.0.0.3]:1" }),
+	)
+	_, err := m(okHandler)(newContext("/a", "198.51.100.1:1", nil), nil)
+	assert.NoError(t, err)
+}
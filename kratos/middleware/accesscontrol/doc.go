@@ -0,0 +1,21 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+// Package accesscontrol 提供用于 Kratos 服务端的准入控制中间件，按来源 IP 与请求头决定是否放行请求。
+//
+// 规则由 Allow、Deny 两类 CIDR 名单与 RequireHeader、DenyHeader 两类请求头规则组成：
+// Deny 优先于 Allow，配置 Allow 后不在名单内或无法识别 IP 的请求被拒绝，请求头规则需全部满足。
+// WithRules 配置默认规则，WithOperationRules 按 Operation 前缀为一组接口单独配置规则，
+// 命中多个分组时前缀最长的分组生效，命中分组后不再检查默认规则。
+//
+// 客户端 IP 默认取 HTTP 请求的 RemoteAddr 或 gRPC 对端地址；对端属于 WithTrustedProxies
+// 配置的可信代理时，从右向左解析 X-Forwarded-For 并跳过可信代理。未配置可信代理时不读取该请求头，
+// 防止客户端伪造来源。
+//
+// 未通过检查的请求不会进入处理器，中间件返回 code 为 403、reason 为 ACCESS_DENIED 且携带
+// operation、client_ip 与 rule 元数据的 ErrAccessDenied，并累加 MetricDeniedTotal。
+// 中间件应放在 basicauth 等认证中间件之前，使被拒绝的来源不会触发认证逻辑。
+//
+// MetricDeniedTotal 不会自动注册，需要调用方通过 prometheus.MustRegister 注册到所用的 Registerer。
+package accesscontrol
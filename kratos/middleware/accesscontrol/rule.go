@@ -0,0 +1,268 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package accesscontrol
+
+import (
+	stderrors "errors"
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"github.com/go-kratos/kratos/v2/transport"
+)
+
+const (
+	// RuleDenyCIDR 表示客户端 IP 命中 Deny 配置的网段。
+	RuleDenyCIDR = "deny_cidr"
+	// RuleAllowCIDR 表示配置了 Allow 网段但客户端 IP 不在其中或无法识别。
+	RuleAllowCIDR = "allow_cidr"
+	// RuleRequireHeader 表示请求头不满足 RequireHeader 的要求。
+	RuleRequireHeader = "require_header"
+	// RuleDenyHeader 表示请求头命中 DenyHeader 的取值。
+	RuleDenyHeader = "deny_header"
+)
+
+var (
+	// ErrInvalidRule 表示规则配置不合法，例如 CIDR 或 IP 无法解析、请求头名称为空。
+	//
+	// New 返回包装了该错误的 error，Server 在配置不合法时以该错误 panic。
+	ErrInvalidRule = stderrors.New("访问控制规则不合法。")
+)
+
+type (
+	// Rule 是作用于一组 Operation 的访问控制规则，由 Allow、Deny、RequireHeader 或 DenyHeader 创建。
+	//
+	// 同一组内的多条规则同时生效：Deny 优先于 Allow，所有请求头规则都必须满足。
+	Rule func(*policy) error
+
+	// policy 是一组 Operation 使用的访问控制策略。
+	policy struct {
+		// allow 是允许访问的网段，为空表示不限制来源。
+		allow []netip.Prefix
+		// deny 是拒绝访问的网段。
+		deny []netip.Prefix
+		// headers 是按配置顺序检查的请求头规则。
+		headers []headerRule
+	}
+
+	// headerRule 是单条请求头规则。
+	headerRule struct {
+		// name 是请求头名称。
+		name string
+		// values 是匹配的取值，为空表示只检查是否存在非空值。
+		values []string
+		// deny 为 true 时命中即拒绝，否则未命中即拒绝。
+		deny bool
+	}
+)
+
+// Allow 创建来源 IP 白名单规则。
+//
+// 配置后只有客户端 IP 落在任一网段内的请求可以通过；无法识别客户端 IP 的请求同样被拒绝。
+// 多次调用时网段合并。
+//
+// 参数：
+//   - cidrs ...string：CIDR 网段（如 `10.0.0.0/8`）或单个 IP（视为 /32 或 /128）。
+//
+// 返回值：
+//   - Rule：访问控制规则。
+func Allow(cidrs ...string) Rule {
+	return func(p *policy) error {
+		prefixes, err := parsePrefixes(cidrs)
+		if nil != err {
+			return err
+		}
+		p.allow = append(p.allow, prefixes...)
+		return nil
+	}
+}
+
+// Deny 创建来源 IP 黑名单规则。
+//
+// 客户端 IP 落在任一网段内的请求被拒绝，优先于 Allow；无法识别客户端 IP 时该规则不生效。
+// 多次调用时网段合并。
+//
+// 参数：
+//   - cidrs ...string：CIDR 网段或单个 IP。
+//
+// 返回值：
+//   - Rule：访问控制规则。
+func Deny(cidrs ...string) Rule {
+	return func(p *policy) error {
+		prefixes, err := parsePrefixes(cidrs)
+		if nil != err {
+			return err
+		}
+		p.deny = append(p.deny, prefixes...)
+		return nil
+	}
+}
+
+// RequireHeader 创建必须满足的请求头规则。
+//
+// 参数：
+//   - name string：请求头名称，不区分大小写。
+//   - values ...string：允许的取值，区分大小写；为空时只要求请求头存在且非空。
+//
+// 返回值：
+//   - Rule：访问控制规则；请求头缺失或取值不在 values 中时拒绝访问。
+func RequireHeader(name string, values ...string) Rule {
+	return headerRuleOf(name, values, false)
+}
+
+// DenyHeader 创建命中即拒绝的请求头规则。
+//
+// 参数：
+//   - name string：请求头名称，不区分大小写。
+//   - values ...string：拒绝的取值，区分大小写；为空时请求头存在且非空即拒绝。
+//
+// 返回值：
+//   - Rule：访问控制规则。
+func DenyHeader(name string, values ...string) Rule {
+	return headerRuleOf(name, values, true)
+}
+
+// headerRuleOf 创建请求头规则。
+//
+// 参数：
+//   - name string：请求头名称。
+//   - values []string：匹配的取值。
+//   - deny bool：命中时是否拒绝。
+//
+// 返回值：
+//   - Rule：访问控制规则；name 为空时返回 ErrInvalidRule。
+func headerRuleOf(name string, values []string, deny bool) Rule {
+	return func(p *policy) error {
+		name = strings.TrimSpace(name)
+		if "" == name {
+			return fmt.Errorf("%w: 请求头名称为空", ErrInvalidRule)
+		}
+		p.headers = append(p.headers, headerRule{
+			name:   name,
+			values: append([]string(nil), values...),
+			deny:   deny,
+		})
+		return nil
+	}
+}
+
+// newPolicy 按规则创建策略。
+//
+// 参数：
+//   - rules []Rule：访问控制规则，nil 元素被忽略。
+//
+// 返回值：
+//   - *policy：创建的策略。
+//   - error：任一规则不合法时返回包装了 ErrInvalidRule 的错误。
+func newPolicy(rules []Rule) (*policy, error) {
+	p := &policy{}
+	for _, rule := range rules {
+		if nil == rule {
+			continue
+		}
+		if err := rule(p); nil != err {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// check 判断请求是否满足策略。
+//
+// 参数：
+//   - ip netip.Addr：客户端 IP；无法识别时为零值。
+//   - header transport.Header：请求头，可以为 nil。
+//
+// 返回值：
+//   - string：拒绝时为命中的规则名称，如 RuleDenyCIDR；通过时为空字符串。
+func (p *policy) check(ip netip.Addr, header transport.Header) string {
+	if ip.IsValid() && containsAddr(p.deny, ip) {
+		return RuleDenyCIDR
+	}
+	if 0 != len(p.allow) && (!ip.IsValid() || !containsAddr(p.allow, ip)) {
+		return RuleAllowCIDR
+	}
+	for _, rule := range p.headers {
+		var value string
+		if nil != header {
+			value = header.Get(rule.name)
+		}
+		matched := "" != value && (0 == len(rule.values) || containsString(rule.values, value))
+		if rule.deny && matched {
+			return RuleDenyHeader
+		}
+		if !rule.deny && !matched {
+			return RuleRequireHeader
+		}
+	}
+	return ""
+}
+
+// parsePrefixes 解析 CIDR 网段或单个 IP。
+//
+// 参数：
+//   - cidrs []string：CIDR 网段或单个 IP。
+//
+// 返回值：
+//   - []netip.Prefix：解析结果，IPv4 映射的 IPv6 地址转换为 IPv4。
+//   - error：任一项无法解析时返回包装了 ErrInvalidRule 的错误。
+func parsePrefixes(cidrs []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		cidr = strings.TrimSpace(cidr)
+		if strings.Contains(cidr, "/") {
+			prefix, err := netip.ParsePrefix(cidr)
+			if nil != err {
+				return nil, fmt.Errorf("%w: %v", ErrInvalidRule, err)
+			}
+			if prefix.Addr().Is4In6() && prefix.Bits() >= 96 {
+				prefix = netip.PrefixFrom(prefix.Addr().Unmap(), prefix.Bits()-96)
+			}
+			prefixes = append(prefixes, prefix.Masked())
+			continue
+		}
+		addr, err := netip.ParseAddr(cidr)
+		if nil != err {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidRule, err)
+		}
+		addr = addr.Unmap()
+		prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+	}
+	return prefixes, nil
+}
+
+// containsAddr 判断 IP 是否落在任一网段内。
+//
+// 参数：
+//   - prefixes []netip.Prefix：网段。
+//   - ip netip.Addr：待判断的 IP。
+//
+// 返回值：
+//   - bool：落在任一网段内时返回 true。
+func containsAddr(prefixes []netip.Prefix, ip netip.Addr) bool {
+	for _, prefix := range prefixes {
+		if prefix.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsString 判断字符串是否在列表中。
+//
+// 参数：
+//   - values []string：字符串列表。
+//   - value string：待判断的字符串。
+//
+// 返回值：
+//   - bool：value 在列表中时返回 true。
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,134 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package accesscontrol
+
+import (
+	"net/http"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParsePrefixes 验证网段与单个 IP 的解析。
+func TestParsePrefixes(t *testing.T) {
+	prefixes, err := parsePrefixes([]string{"10.0.0.0/8", " 192.168.1.1 ", "::1", "::ffff:172.16.0.0/108", "10.1.2.3/16"})
+	require.NoError(t, err)
+	assert.Equal(t, []netip.Prefix{
+		netip.MustParsePrefix("10.0.0.0/8"),
+		netip.MustParsePrefix("192.168.1.1/32"),
+		netip.MustParsePrefix("::1/128"),
+		netip.MustParsePrefix("172.16.0.0/12"),
+		netip.MustParsePrefix("10.1.0.0/16"),
+	}, prefixes)
+
+	for _, give := range []string{"", "10.0.0.0/33", "example.com"} {
+		_, err := parsePrefixes([]string{give})
+		assert.ErrorIs(t, err, ErrInvalidRule, give)
+	}
+}
+
+// TestPolicy_Check 验证 IP 名单与请求头规则的判断顺序。
+func TestPolicy_Check(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		rules       []Rule
+		ip          string
+		header      http.Header
+		want        string
+	}{
+		{
+			name:        "success/no-rules",
+			description: "验证未配置规则时放行。",
+			ip:          "1.2.3.4",
+		},
+		{
+			name:        "success/allow",
+			description: "验证 IP 在白名单内时放行。",
+			rules:       []Rule{Allow("10.0.0.0/8")},
+			ip:          "10.1.2.3",
+		},
+		{
+			name:        "error/allow-miss",
+			description: "验证 IP 不在白名单内时拒绝。",
+			rules:       []Rule{Allow("10.0.0.0/8")},
+			ip:          "11.0.0.1",
+			want:        RuleAllowCIDR,
+		},
+		{
+			name:        "error/allow-unknown-ip",
+			description: "验证配置白名单后无法识别 IP 时拒绝。",
+			rules:       []Rule{Allow("10.0.0.0/8")},
+			want:        RuleAllowCIDR,
+		},
+		{
+			name:        "error/deny-over-allow",
+			description: "验证黑名单优先于白名单。",
+			rules:       []Rule{Allow("10.0.0.0/8"), Deny("10.0.0.5")},
+			ip:          "10.0.0.5",
+			want:        RuleDenyCIDR,
+		},
+		{
+			name:        "success/deny-unknown-ip",
+			description: "验证只配置黑名单时无法识别 IP 的请求放行。",
+			rules:       []Rule{Deny("10.0.0.0/8")},
+		},
+		{
+			name:        "success/require-header",
+			description: "验证请求头取值在允许范围内时放行。",
+			rules:       []Rule{RequireHeader("x-env", "prod", "staging")},
+			header:      http.Header{"X-Env": {"staging"}},
+		},
+		{
+			name:        "error/require-header-missing",
+			description: "验证缺少必需请求头时拒绝。",
+			rules:       []Rule{RequireHeader("X-Tenant")},
+			want:        RuleRequireHeader,
+		},
+		{
+			name:        "error/require-header-value",
+			description: "验证请求头取值不在允许范围内时拒绝。",
+			rules:       []Rule{RequireHeader("X-Env", "prod")},
+			header:      http.Header{"X-Env": {"Prod"}},
+			want:        RuleRequireHeader,
+		},
+		{
+			name:        "error/deny-header",
+			description: "验证请求头命中拒绝取值时拒绝。",
+			rules:       []Rule{DenyHeader("User-Agent", "bad-bot")},
+			header:      http.Header{"User-Agent": {"bad-bot"}},
+			want:        RuleDenyHeader,
+		},
+		{
+			name:        "success/deny-header-absent",
+			description: "验证请求头不存在时不触发拒绝规则。",
+			rules:       []Rule{DenyHeader("X-Debug")},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			p, err := newPolicy(tt.rules)
+			require.NoError(t, err)
+			var ip netip.Addr
+			if "" != tt.ip {
+				ip = netip.MustParseAddr(tt.ip)
+			}
+			var header headerCarrier
+			if nil != tt.header {
+				header = headerCarrier(tt.header)
+			}
+			assert.Equal(t, tt.want, p.check(ip, header))
+		})
+	}
+
+	_, err := newPolicy([]Rule{RequireHeader(" ")})
+	assert.ErrorIs(t, err, ErrInvalidRule)
+}
@@ -0,0 +1,410 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"math/rand/v2"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	kitbasicauth "github.com/fsyyft-go/kit/kratos/middleware/basicauth"
+	kitlog "github.com/fsyyft-go/kit/log"
+)
+
+const (
+	// namespace 定义 Prometheus 指标命名空间。
+	namespace = "kit_kratos"
+	// subsystem 定义 Prometheus 指标子系统名称。
+	subsystem = "middleware"
+
+	// DetailCode 是审计事件 Details 中的响应码，成功时为 200，失败时为 Kratos 错误的 code。
+	DetailCode = "code"
+	// DetailReason 是审计事件 Details 中 Kratos 错误的 reason，成功时不存在。
+	DetailReason = "reason"
+	// DetailLatency 是审计事件 Details 中的处理耗时，单位为毫秒。
+	DetailLatency = "latency_ms"
+	// DetailTransport 是审计事件 Details 中的传输类型，例如 http、grpc。
+	DetailTransport = "transport"
+	// DetailFields 是审计事件 Details 中按白名单提取的请求字段。
+	DetailFields = "fields"
+)
+
+var (
+	// MetricSinkErrorsTotal 记录审计事件写入 Sink 失败的次数。
+	//
+	// 标签：
+	//   - operation：写入失败的审计事件对应的 Operation。
+	MetricSinkErrorsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "audit_sink_errors_total",
+		Help:      "kratos audit sink write errors total.",
+	}, []string{"operation"})
+)
+
+type (
+	// Sink 接收审计事件，实现需要可安全并发调用。
+	Sink interface {
+		// Write 写入一条审计事件。
+		//
+		// 参数：
+		//   - ctx context.Context：请求上下文。
+		//   - event kitlog.AuditEvent：审计事件。
+		//
+		// 返回值：
+		//   - error：写入失败时返回错误，不影响请求的返回结果。
+		Write(ctx context.Context, event kitlog.AuditEvent) error
+	}
+
+	// SinkFunc 是函数形式的 Sink。
+	SinkFunc func(ctx context.Context, event kitlog.AuditEvent) error
+
+	// IdentityFunc 从请求上下文中提取执行操作的主体。
+	//
+	// 参数：
+	//   - ctx context.Context：经过认证中间件处理后的上下文。
+	//
+	// 返回值：
+	//   - string：主体标识，例如用户名；无法识别时返回空字符串。
+	IdentityFunc func(ctx context.Context) string
+
+	// ResourceFunc 从请求中提取操作的目标资源。
+	//
+	// 参数：
+	//   - ctx context.Context：请求上下文。
+	//   - req interface{}：已解码的请求对象。
+	//
+	// 返回值：
+	//   - string：目标资源，例如 order/123。
+	ResourceFunc func(ctx context.Context, req interface{}) string
+
+	// ErrorHandler 处理审计事件写入失败。
+	//
+	// 参数：
+	//   - ctx context.Context：请求上下文。
+	//   - event kitlog.AuditEvent：写入失败的审计事件。
+	//   - err error：Sink 返回的错误。
+	ErrorHandler func(ctx context.Context, event kitlog.AuditEvent, err error)
+
+	// Option 配置 Server 返回的审计中间件。
+	//
+	// Option 通常由 WithIdentity、WithFields、WithSampleRate 等函数返回。
+	Option func(*options)
+
+	// options 包含中间件配置选项。
+	options struct {
+		// 提取主体的函数。
+		identity IdentityFunc
+		// 提取目标资源的函数，为 nil 时资源为空。
+		resource ResourceFunc
+		// 未单独配置的 Operation 使用的请求字段白名单。
+		fields []string
+		// 按 Operation 单独配置的请求字段白名单。
+		operationFields map[string][]string
+		// 按 Operation 前缀配置的成功请求采样率。
+		sampleRates map[string]float64
+		// 写入失败时的处理函数。
+		errorHandler ErrorHandler
+	}
+)
+
+// Write 调用 f 写入审计事件。
+//
+// 参数：
+//   - ctx context.Context：请求上下文。
+//   - event kitlog.AuditEvent：审计事件。
+//
+// 返回值：
+//   - error：f 返回的错误。
+func (f SinkFunc) Write(ctx context.Context, event kitlog.AuditEvent) error {
+	return f(ctx, event)
+}
+
+// LoggerSink 返回把审计事件写入 log 包审计通道的 Sink。
+//
+// 参数：
+//   - logger *kitlog.AuditLogger：审计日志，记录以哈希链写入专用目录。
+//
+// 返回值：
+//   - Sink：写入 logger 的 Sink。
+func LoggerSink(logger *kitlog.AuditLogger) Sink {
+	return SinkFunc(func(_ context.Context, event kitlog.AuditEvent) error {
+		_, err := logger.Log(event)
+		return err
+	})
+}
+
+// WithIdentity 配置提取主体的函数。
+//
+// 参数：
+//   - fn IdentityFunc：提取主体的函数；传入 nil 时保留默认实现。
+//
+// 返回值：
+//   - Option：中间件配置选项。
+//
+// 默认从 basicauth.UsernameFromContext 读取用户名，使用其它认证方式时应配置该选项，
+// 并把审计中间件放在认证中间件之后。
+func WithIdentity(fn IdentityFunc) Option {
+	return func(o *options) {
+		if nil != fn {
+			o.identity = fn
+		}
+	}
+}
+
+// WithResource 配置提取目标资源的函数。
+//
+// 参数：
+//   - fn ResourceFunc：提取目标资源的函数。
+//
+// 返回值：
+//   - Option：中间件配置选项。
+//
+// 若未设置该选项，审计事件的 Resource 为空字符串。
+func WithResource(fn ResourceFunc) Option {
+	return func(o *options) {
+		o.resource = fn
+	}
+}
+
+// WithFields 配置未单独设置白名单的 Operation 记录的请求字段。
+//
+// 参数：
+//   - fields ...string：字段路径，嵌套字段以点号分隔，例如 `order.id`；proto 消息使用 proto 字段名。
+//
+// 返回值：
+//   - Option：中间件配置选项。
+//
+// 只有白名单中的字段会写入审计事件，避免密码等敏感字段泄露；请求中不存在的字段会被忽略。
+func WithFields(fields ...string) Option {
+	return func(o *options) {
+		o.fields = fields
+	}
+}
+
+// WithOperationFields 为指定 Operation 单独配置记录的请求字段。
+//
+// 参数：
+//   - operation string：Kratos 的 Operation，例如 `/order.v1.Order/Delete`。
+//   - fields ...string：字段路径；不传表示该 Operation 不记录请求字段。
+//
+// 返回值：
+//   - Option：中间件配置选项。
+//
+// 对同一 Operation 多次设置时，后设置的值生效。
+func WithOperationFields(operation string, fields ...string) Option {
+	return func(o *options) {
+		o.operationFields[operation] = fields
+	}
+}
+
+// WithSampleRate 为匹配 Operation 前缀的成功请求配置采样率。
+//
+// 参数：
+//   - prefix string：Operation 前缀，例如 `/order.v1.Order/Get`；多个前缀匹配时最长的生效。
+//   - rate float64：采样率，取值 0 至 1；小于等于 0 表示不记录成功请求，大于等于 1 表示全部记录。
+//
+// 返回值：
+//   - Option：中间件配置选项。
+//
+// 采样只作用于成功的请求，失败与被拒绝的请求始终记录，适合降低高频只读接口的审计量。
+// 未匹配任何前缀的 Operation 全部记录。
+func WithSampleRate(prefix string, rate float64) Option {
+	return func(o *options) {
+		o.sampleRates[prefix] = rate
+	}
+}
+
+// WithErrorHandler 配置审计事件写入失败时的处理函数。
+//
+// 参数：
+//   - fn ErrorHandler：处理函数，例如记录到普通日志或触发告警。
+//
+// 返回值：
+//   - Option：中间件配置选项。
+//
+// 写入失败不会改变请求的返回结果；无论是否配置该选项都会累加 MetricSinkErrorsTotal。
+func WithErrorHandler(fn ErrorHandler) Option {
+	return func(o *options) {
+		o.errorHandler = fn
+	}
+}
+
+// Server 创建用于服务端请求的审计中间件。
+//
+// 参数：
+//   - sink Sink：接收审计事件的 Sink，例如 LoggerSink。
+//   - opts ...Option：中间件配置选项。
+//
+// 返回值：
+//   - middleware.Middleware：在处理器返回后记录审计事件的中间件。
+//
+// 每个请求生成一条 kitlog.AuditEvent：Actor 为 IdentityFunc 提取的主体，Action 为 Operation，Resource 为
+// ResourceFunc 提取的资源；Result 在处理器成功时为 AuditResultSuccess，返回 401 或 403 错误时为
+// AuditResultDenied，其它错误为 AuditResultFailure。Details 包含 DetailCode、DetailLatency、DetailTransport，
+// 失败时包含 DetailReason，配置字段白名单时包含 DetailFields。上下文中不存在服务端 transport 时
+// Operation 与传输类型为空字符串。
+func Server(sink Sink, opts ...Option) middleware.Middleware {
+	o := &options{
+		identity: func(ctx context.Context) string {
+			username, _ := kitbasicauth.UsernameFromContext(ctx)
+			return username
+		},
+		operationFields: make(map[string][]string),
+		sampleRates:     make(map[string]float64),
+	}
+	for _, opt := range opts {
+		if nil == opt {
+			continue
+		}
+		opt(o)
+	}
+
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			start := time.Now()
+			reply, err := handler(ctx, req)
+			latency := time.Since(start)
+
+			var operation, kind string
+			if tr, ok := transport.FromServerContext(ctx); ok {
+				operation = tr.Operation()
+				kind = tr.Kind().String()
+			}
+			if nil == err && !o.sampled(operation) {
+				return reply, err
+			}
+
+			code, result := http.StatusOK, kitlog.AuditResultSuccess
+			details := map[string]interface{}{
+				DetailLatency:   float64(latency) / float64(time.Millisecond),
+				DetailTransport: kind,
+			}
+			if nil != err {
+				e := errors.FromError(err)
+				code = int(e.Code)
+				details[DetailReason] = e.Reason
+				result = kitlog.AuditResultFailure
+				if http.StatusUnauthorized == code || http.StatusForbidden == code {
+					result = kitlog.AuditResultDenied
+				}
+			}
+			details[DetailCode] = code
+			if fields := o.fieldsOf(operation); 0 != len(fields) {
+				if values := extractFields(req, fields); 0 != len(values) {
+					details[DetailFields] = values
+				}
+			}
+
+			event := kitlog.AuditEvent{
+				Time:    start,
+				Actor:   o.identity(ctx),
+				Action:  operation,
+				Result:  result,
+				Details: details,
+			}
+			if nil != o.resource {
+				event.Resource = o.resource(ctx, req)
+			}
+			if werr := sink.Write(ctx, event); nil != werr {
+				MetricSinkErrorsTotal.WithLabelValues(operation).Inc()
+				if nil != o.errorHandler {
+					o.errorHandler(ctx, event, werr)
+				}
+			}
+			return reply, err
+		}
+	}
+}
+
+// sampled 判断成功的请求是否需要记录。
+//
+// 参数：
+//   - operation string：请求的 Operation。
+//
+// 返回值：
+//   - bool：需要记录时返回 true。
+func (o *options) sampled(operation string) bool {
+	rate, matched := 1.0, -1
+	for prefix, r := range o.sampleRates {
+		if len(prefix) > matched && strings.HasPrefix(operation, prefix) {
+			rate, matched = r, len(prefix)
+		}
+	}
+	switch {
+	case rate >= 1:
+		return true
+	case rate <= 0:
+		return false
+	default:
+		return rand.Float64() < rate
+	}
+}
+
+// fieldsOf 返回 Operation 使用的请求字段白名单。
+//
+// 参数：
+//   - operation string：请求的 Operation。
+//
+// 返回值：
+//   - []string：字段路径。
+func (o *options) fieldsOf(operation string) []string {
+	if fields, ok := o.operationFields[operation]; ok {
+		return fields
+	}
+	return o.fields
+}
+
+// extractFields 按白名单从请求对象中提取字段。
+//
+// 参数：
+//   - req interface{}：请求对象；proto.Message 按 protojson 的 proto 字段名序列化，其它类型按 encoding/json 序列化。
+//   - fields []string：以点号分隔的字段路径。
+//
+// 返回值：
+//   - map[string]interface{}：以字段路径为键的取值；请求无法序列化或字段不存在时不包含对应的键。
+func extractFields(req interface{}, fields []string) map[string]interface{} {
+	var data []byte
+	var err error
+	if m, ok := req.(proto.Message); ok {
+		data, err = protojson.MarshalOptions{UseProtoNames: true}.Marshal(m)
+	} else {
+		data, err = json.Marshal(req)
+	}
+	if nil != err {
+		return nil
+	}
+	var root map[string]interface{}
+	if err := json.Unmarshal(data, &root); nil != err {
+		return nil
+	}
+
+	values := make(map[string]interface{}, len(fields))
+	for _, field := range fields {
+		var current interface{} = root
+		for _, name := range strings.Split(field, ".") {
+			object, ok := current.(map[string]interface{})
+			if !ok {
+				current = nil
+				break
+			}
+			current = object[name]
+		}
+		if nil != current {
+			values[field] = current
+		}
+	}
+	return values
+}
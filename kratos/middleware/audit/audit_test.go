@@ -0,0 +1,239 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package audit
+
+import (
+	"bytes"
+	"context"
+	stderrors "errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	kitlog "github.com/fsyyft-go/kit/log"
+)
+
+type (
+	// mockTransport 实现 transport.Transporter，仅提供测试所需的 Kind 和 Operation。
+	mockTransport struct {
+		transport.Transporter
+		operation string
+	}
+
+	// recorder 是记录审计事件的 Sink。
+	recorder struct {
+		mu     sync.Mutex
+		events []kitlog.AuditEvent
+		err    error
+	}
+
+	// identityKey 是测试中保存主体的上下文键。
+	identityKey struct{}
+)
+
+// Kind 返回 HTTP 传输类型。
+func (m *mockTransport) Kind() transport.Kind {
+	return transport.KindHTTP
+}
+
+// Operation 返回预设的 Operation。
+func (m *mockTransport) Operation() string {
+	return m.operation
+}
+
+// Write 记录审计事件。
+func (r *recorder) Write(_ context.Context, event kitlog.AuditEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+	return r.err
+}
+
+// snapshot 返回已记录的审计事件。
+func (r *recorder) snapshot() []kitlog.AuditEvent {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]kitlog.AuditEvent(nil), r.events...)
+}
+
+// TestServer 验证审计事件的主体、结果、响应码与字段白名单。
+func TestServer(t *testing.T) {
+	identity := WithIdentity(func(ctx context.Context) string {
+		name, _ := ctx.Value(identityKey{}).(string)
+		return name
+	})
+
+	tests := []struct {
+		name        string
+		description string
+		opts        []Option
+		req         interface{}
+		handlerErr  error
+		wantResult  string
+		wantCode    int
+		wantReason  string
+		wantFields  map[string]interface{}
+	}{
+		{
+			name:        "success",
+			description: "验证成功请求记录为 success，只提取白名单字段。",
+			opts:        []Option{identity, WithFields("order.id", "note", "missing")},
+			req: map[string]interface{}{
+				"order":    map[string]interface{}{"id": "o-1", "amount": 12},
+				"password": "secret",
+			},
+			wantResult: kitlog.AuditResultSuccess,
+			wantCode:   200,
+			wantFields: map[string]interface{}{"order.id": "o-1"},
+		},
+		{
+			name:        "denied",
+			description: "验证 403 错误记录为 denied。",
+			opts:        []Option{identity},
+			req:         "request",
+			handlerErr:  errors.Forbidden("FORBIDDEN", "forbidden"),
+			wantResult:  kitlog.AuditResultDenied,
+			wantCode:    403,
+			wantReason:  "FORBIDDEN",
+		},
+		{
+			name:        "failure",
+			description: "验证其它错误记录为 failure，非 Kratos 错误按 500 处理。",
+			opts:        []Option{identity},
+			req:         "request",
+			handlerErr:  stderrors.New("boom"),
+			wantResult:  kitlog.AuditResultFailure,
+			wantCode:    500,
+		},
+		{
+			name:        "proto",
+			description: "验证 proto 请求按字段名提取，单独配置的 Operation 白名单优先。",
+			opts: []Option{
+				identity,
+				WithFields("ignored"),
+				WithOperationFields("/order.v1.Order/Delete", "id"),
+			},
+			req:        mustStruct(t, map[string]interface{}{"id": "o-2", "token": "secret"}),
+			wantResult: kitlog.AuditResultSuccess,
+			wantCode:   200,
+			wantFields: map[string]interface{}{"id": "o-2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			sink := &recorder{}
+			tr := &mockTransport{operation: "/order.v1.Order/Delete"}
+			ctx := context.WithValue(transport.NewServerContext(context.Background(), tr), identityKey{}, "alice")
+			m := Server(sink, append(tt.opts, WithResource(func(context.Context, interface{}) string { return "order" }))...)
+
+			reply, err := m(func(context.Context, interface{}) (interface{}, error) {
+				return "reply", tt.handlerErr
+			})(ctx, tt.req)
+			assert.Equal(t, "reply", reply)
+			assert.Equal(t, tt.handlerErr, err)
+
+			events := sink.snapshot()
+			require.Len(t, events, 1)
+			event := events[0]
+			assert.Equal(t, "alice", event.Actor)
+			assert.Equal(t, "/order.v1.Order/Delete", event.Action)
+			assert.Equal(t, "order", event.Resource)
+			assert.Equal(t, tt.wantResult, event.Result)
+			assert.Equal(t, tt.wantCode, event.Details[DetailCode])
+			assert.Equal(t, "http", event.Details[DetailTransport])
+			assert.GreaterOrEqual(t, event.Details[DetailLatency], 0.0)
+			assert.False(t, event.Time.IsZero())
+			if "" != tt.wantReason {
+				assert.Equal(t, tt.wantReason, event.Details[DetailReason])
+			}
+			if nil == tt.wantFields {
+				assert.NotContains(t, event.Details, DetailFields)
+			} else {
+				assert.Equal(t, tt.wantFields, event.Details[DetailFields])
+			}
+		})
+	}
+}
+
+// TestServer_Sampling 验证采样只作用于成功请求，且最长的前缀生效。
+func TestServer_Sampling(t *testing.T) {
+	sink := &recorder{}
+	m := Server(sink,
+		WithSampleRate("/order.v1.Order/", 1),
+		WithSampleRate("/order.v1.Order/Get", 0),
+	)
+
+	call := func(operation string, err error) {
+		ctx := transport.NewServerContext(context.Background(), &mockTransport{operation: operation})
+		_, _ = m(func(context.Context, interface{}) (interface{}, error) { return nil, err })(ctx, nil)
+	}
+	call("/order.v1.Order/Get", nil)
+	call("/order.v1.Order/Get", errors.NotFound("NOT_FOUND", "not found"))
+	call("/order.v1.Order/Delete", nil)
+	call("/user.v1.User/Get", nil)
+
+	var actions []string
+	for _, event := range sink.snapshot() {
+		actions = append(actions, event.Action+":"+event.Result)
+	}
+	assert.Equal(t, []string{
+		"/order.v1.Order/Get:failure",
+		"/order.v1.Order/Delete:success",
+		"/user.v1.User/Get:success",
+	}, actions)
+}
+
+// TestServer_SinkError 验证写入失败不影响请求结果，并调用错误处理函数与累加指标。
+func TestServer_SinkError(t *testing.T) {
+	sink := &recorder{err: stderrors.New("disk full")}
+	var handled error
+	m := Server(sink, WithErrorHandler(func(_ context.Context, _ kitlog.AuditEvent, err error) { handled = err }))
+
+	operation := "/audit.test/SinkError"
+	before := testutil.ToFloat64(MetricSinkErrorsTotal.WithLabelValues(operation))
+	ctx := transport.NewServerContext(context.Background(), &mockTransport{operation: operation})
+	reply, err := m(func(context.Context, interface{}) (interface{}, error) { return "ok", nil })(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "ok", reply)
+	assert.Equal(t, sink.err, handled)
+	assert.Equal(t, before+1, testutil.ToFloat64(MetricSinkErrorsTotal.WithLabelValues(operation)))
+}
+
+// TestLoggerSink 验证 LoggerSink 把事件写入审计日志。
+func TestLoggerSink(t *testing.T) {
+	dir := t.TempDir()
+	logger, err := kitlog.NewAuditLogger(dir)
+	require.NoError(t, err)
+
+	m := Server(LoggerSink(logger))
+	_, err = m(func(context.Context, interface{}) (interface{}, error) { return nil, nil })(context.Background(), nil)
+	require.NoError(t, err)
+	require.NoError(t, logger.Close())
+
+	var buf bytes.Buffer
+	n, err := kitlog.ExportAuditLog(dir, &buf, time.Time{}, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Contains(t, buf.String(), `"result":"success"`)
+}
+
+// mustStruct 创建测试用的 proto 请求。
+func mustStruct(t *testing.T, fields map[string]interface{}) *structpb.Struct {
+	t.Helper()
+
+	s, err := structpb.NewStruct(fields)
+	require.NoError(t, err)
+	return s
+}
@@ -0,0 +1,19 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+// Package audit 提供用于 Kratos 服务端的审计中间件，记录“谁在什么时候做了什么、结果如何”。
+//
+// Server 在处理器返回后为每个请求生成一条 log.AuditEvent 并写入 Sink：Actor 取自认证中间件写入上下文的
+// 主体（默认读取 basicauth.UsernameFromContext，可通过 WithIdentity 对接其它认证方式），Action 为 Operation，
+// Result 按处理器返回的错误区分 success、denied（401、403）与 failure，Details 记录响应码、错误 reason、
+// 耗时与传输类型。审计中间件应放在认证中间件之后，才能取得主体。
+//
+// 请求字段只按 WithFields、WithOperationFields 配置的白名单提取，嵌套字段以点号分隔，避免把密码等敏感信息
+// 写入审计日志。WithSampleRate 按 Operation 前缀对成功请求采样，用于降低高频只读接口的审计量，失败与被拒绝
+// 的请求始终记录。
+//
+// LoggerSink 把事件写入 log.AuditLogger 的哈希链审计通道，也可以实现 Sink 或使用 SinkFunc 写入消息队列等
+// 外部系统。写入失败不影响请求结果，会累加 MetricSinkErrorsTotal 并调用 WithErrorHandler 配置的处理函数。
+// MetricSinkErrorsTotal 不会自动注册，需要调用方通过 prometheus.MustRegister 注册到所用的 Registerer。
+package audit
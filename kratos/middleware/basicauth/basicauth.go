@@ -8,10 +8,13 @@ import (
 	"context"
 	"encoding/base64"
 	"strings"
+	"time"
 
 	"github.com/go-kratos/kratos/v2/errors"
 	"github.com/go-kratos/kratos/v2/middleware"
 	"github.com/go-kratos/kratos/v2/transport"
+
+	kitcache "github.com/fsyyft-go/kit/cache"
 )
 
 var (
@@ -23,6 +26,13 @@ var (
 	// WWW-Authenticate 响应头；调用方通常按 401 Unauthorized 处理，
 	// 并可通过返回的 Kratos 错误 reason `UNAUTHORIZED` 识别该失败。
 	ErrInvalidBasicAuth = errors.Unauthorized("UNAUTHORIZED", "Invalid basic authentication")
+
+	// ErrBasicAuthLocked 表示用户名因连续认证失败被临时锁定。
+	//
+	// 仅在通过 WithLockout 启用失败锁定后返回。锁定期间中间件不会调用
+	// CredentialValidator，并会写入 Retry-After 响应头；调用方通常按
+	// 429 Too Many Requests 处理，并可通过 reason `TOO_MANY_REQUESTS` 识别。
+	ErrBasicAuthLocked = errors.New(429, "TOO_MANY_REQUESTS", "Too many failed basic authentication attempts")
 )
 
 type (
@@ -32,12 +42,17 @@ type (
 	// 调用方应只传入有效选项。
 	Option func(*options)
 
+	// usernameKey 是上下文中保存已认证用户名的键类型。
+	usernameKey struct{}
+
 	// options 包含中间件配置选项。
 	options struct {
 		// 认证信息验证器。
 		validator CredentialValidator
 		// 认证域，显示在浏览器认证对话框中。
 		realm string
+		// 连续失败锁定记录器，为 nil 时不启用锁定。
+		lockout *lockout
 	}
 
 	// CredentialValidator 校验从 Authorization 头中解析出的用户名和密码。
@@ -70,6 +85,46 @@ func WithValidator(validator CredentialValidator) Option {
 	}
 }
 
+// WithCredentialStore 使用 CredentialStore 校验凭据。
+//
+// 参数：
+//   - store CredentialStore：用于校验用户名与密码的凭据存储。
+//
+// 返回值：
+//   - Option：中间件配置选项。
+//
+// 该选项会替换 WithValidator 配置的 validator，后设置的选项生效。store 返回错误时按认证失败处理。
+// store 必须为非 nil。
+func WithCredentialStore(store CredentialStore) Option {
+	return WithValidator(func(ctx context.Context, username, password string) bool {
+		ok, err := store.Verify(ctx, username, password)
+		return nil == err && ok
+	})
+}
+
+// WithLockout 启用连续认证失败锁定。
+//
+// 参数：
+//   - cache kitcache.Cache：保存失败计数的缓存，应专用于锁定。
+//   - maxFailures int：触发锁定的连续失败次数，小于等于 0 时该选项无效。
+//   - duration time.Duration：锁定时长，每次失败都会从当前时间重新计算。
+//
+// 返回值：
+//   - Option：中间件配置选项。
+//
+// 同一用户名连续失败 maxFailures 次后，在 duration 内的请求直接返回 ErrBasicAuthLocked，
+// Retry-After 响应头为锁定剩余的秒数；认证成功会清除该用户名的失败计数。
+//
+// 失败计数只保存在缓存中：Ristretto 的准入策略可能拒绝写入，容量不足时也可能淘汰计数，
+// 二者都会使锁定静默失效。与其它模块共享缓存会加剧这种情况，应为锁定使用单独的缓存实例。
+func WithLockout(cache kitcache.Cache, maxFailures int, duration time.Duration) Option {
+	return func(o *options) {
+		if maxFailures > 0 && nil != cache {
+			o.lockout = newLockout(cache, maxFailures, duration)
+		}
+	}
+}
+
 // WithRealm 配置认证失败时写入 WWW-Authenticate 头的 realm。
 //
 // 参数：
@@ -98,6 +153,10 @@ func WithRealm(realm string) Option {
 // 当请求缺少凭据、凭据格式非法或 CredentialValidator 返回 false 时，中间件会设置
 // `WWW-Authenticate: Basic realm="..."` 响应头并返回 ErrInvalidBasicAuth。
 //
+// 通过 WithLockout 启用失败锁定后，连续失败达到上限的用户名会在锁定期内直接收到 ErrBasicAuthLocked。
+//
+// 认证通过后用户名会写入上下文，后续处理器与审计等中间件可通过 UsernameFromContext 取出。
+//
 // 若上下文中不存在服务端 transport，中间件不会尝试认证，而是直接调用后续处理器。
 // 未显式配置时，默认 validator 始终拒绝认证，默认 realm 为 `Restricted`。
 func Server(opts ...Option) middleware.Middleware {
@@ -135,12 +194,24 @@ func Server(opts ...Option) middleware.Middleware {
 					return nil, ErrInvalidBasicAuth
 				}
 
+				// 已被锁定的用户名不再校验密码；未锁定时先计入一次失败，认证成功后再清除。
+				if nil != o.lockout {
+					if remaining, ok := o.lockout.attempt(username); !ok {
+						tr.ReplyHeader().Set("Retry-After", retryAfter(remaining))
+						return nil, ErrBasicAuthLocked
+					}
+				}
+
 				// 验证用户名和密码。
 				if !o.validator(ctx, username, password) {
 					// 如果验证失败，设置 WWW-Authenticate 头，触发浏览器的认证对话框。
 					tr.ReplyHeader().Set("WWW-Authenticate", `Basic realm="`+o.realm+`"`)
 					return nil, ErrInvalidBasicAuth
 				}
+				if nil != o.lockout {
+					o.lockout.reset(username)
+				}
+				ctx = context.WithValue(ctx, usernameKey{}, username)
 			}
 			// 验证通过，继续处理请求。
 			return handler(ctx, req)
@@ -148,6 +219,19 @@ func Server(opts ...Option) middleware.Middleware {
 	}
 }
 
+// UsernameFromContext 返回 Server 认证通过后写入上下文的用户名。
+//
+// 参数：
+//   - ctx context.Context：处理器收到的上下文。
+//
+// 返回值：
+//   - string：已认证的用户名。
+//   - bool：上下文中存在已认证用户名时返回 true。
+func UsernameFromContext(ctx context.Context) (string, bool) {
+	username, ok := ctx.Value(usernameKey{}).(string)
+	return username, ok
+}
+
 // parseBasicAuth 解析 HTTP Basic Auth 头部值，返回用户名和密码。
 //
 // 参数：
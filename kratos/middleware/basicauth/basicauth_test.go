@@ -45,6 +45,7 @@ import (
 	"github.com/go-kratos/kratos/v2/errors"
 	"github.com/go-kratos/kratos/v2/transport"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // mockTransport 实现了transport.Transport接口，用于在测试中模拟HTTP传输层。
@@ -452,6 +453,28 @@ func TestServer(t *testing.T) {
 	}
 }
 
+// TestUsernameFromContext 测试认证通过后用户名被写入处理器上下文。
+func TestUsernameFromContext(t *testing.T) {
+	mockTr := newMockTransport()
+	mockTr.header["Authorization"] = makeBasicAuthHeader("alice", "secret")
+	ctx := transport.NewServerContext(context.Background(), mockTr)
+
+	var username string
+	var ok bool
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		username, ok = UsernameFromContext(ctx)
+		return nil, nil
+	}
+	m := Server(WithValidator(func(ctx context.Context, username, password string) bool { return true }))
+	_, err := m(handler)(ctx, "request")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "alice", username)
+
+	_, ok = UsernameFromContext(context.Background())
+	assert.False(t, ok)
+}
+
 // TestDefaultValidator 测试默认验证器的行为。
 // 验证在不提供自定义验证器时，默认验证器对各种用户名和密码组合的处理。
 func TestDefaultValidator(t *testing.T) {
@@ -11,4 +11,9 @@
 // 默认 validator 始终拒绝认证，realm 默认为 Restricted，因此公开服务通常
 // 需要显式提供凭据校验逻辑。若上下文中没有服务端 transport 信息，
 // 中间件会跳过认证并继续调用后续处理器。
+//
+// 除回调外，也可以通过 WithCredentialStore 使用 CredentialStore 校验凭据。
+// 内置实现包括 bcrypt 静态映射 NewStaticStore、可自动重新加载的 htpasswd 文件
+// NewHtpasswdStore 以及基于 SQL 查询的 NewSQLStore。WithLockout 基于 cache 包
+// 记录连续失败次数，超过上限的用户名在锁定期内直接收到 ErrBasicAuthLocked。
 package basicauth
@@ -0,0 +1,168 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package basicauth
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	cockroachdberrors "github.com/cockroachdb/errors"
+)
+
+var (
+	// 断言 HtpasswdStore 实现 CredentialStore 接口。
+	_ CredentialStore = (*HtpasswdStore)(nil)
+)
+
+type (
+	// HtpasswdStore 是基于 htpasswd 文件的凭据存储。
+	//
+	// 文件每行格式为 `username:hash`，支持 bcrypt 与 `{SHA}` 哈希；空行和以 `#` 开头的行会被忽略。
+	// reloadInterval 大于 0 时，Verify 会在距离上次检查超过该间隔后比较文件修改时间并按需重新加载；
+	// 重新加载失败时保留上一次成功加载的内容。HtpasswdStore 可并发调用。
+	HtpasswdStore struct {
+		// path 是 htpasswd 文件路径。
+		path string
+		// reloadInterval 是检查文件变化的最小间隔，小于等于 0 时禁用自动重新加载。
+		reloadInterval time.Duration
+
+		// mu 保护以下字段。
+		mu sync.RWMutex
+		// hashes 保存最近一次成功加载的用户名到密码哈希映射。
+		hashes map[string]string
+		// modTime 是最近一次成功加载时文件的修改时间。
+		modTime time.Time
+		// checkedAt 是最近一次检查文件变化的时间。
+		checkedAt time.Time
+	}
+)
+
+// NewHtpasswdStore 加载 htpasswd 文件并创建凭据存储。
+//
+// 参数：
+//   - path string：htpasswd 文件路径。
+//   - reloadInterval time.Duration：检查文件变化的最小间隔，小于等于 0 时禁用自动重新加载。
+//
+// 返回值：
+//   - *HtpasswdStore：新创建的凭据存储。
+//   - error：首次加载文件失败时返回错误。
+func NewHtpasswdStore(path string, reloadInterval time.Duration) (*HtpasswdStore, error) {
+	s := &HtpasswdStore{
+		path:           path,
+		reloadInterval: reloadInterval,
+	}
+	if err := s.Reload(); nil != err {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Reload 立即重新加载 htpasswd 文件。
+//
+// 加载失败时保留上一次成功加载的内容。
+//
+// 参数：无。
+//
+// 返回值：
+//   - error：读取或解析文件失败时返回错误。
+func (s *HtpasswdStore) Reload() error {
+	info, err := os.Stat(s.path)
+	if nil != err {
+		return cockroachdberrors.Wrapf(err, "读取 htpasswd 文件 %[1]s 信息出现错误。", s.path)
+	}
+	data, err := os.ReadFile(s.path)
+	if nil != err {
+		return cockroachdberrors.Wrapf(err, "读取 htpasswd 文件 %[1]s 出现错误。", s.path)
+	}
+	hashes, err := parseHtpasswd(data)
+	if nil != err {
+		return cockroachdberrors.Wrapf(err, "解析 htpasswd 文件 %[1]s 出现错误。", s.path)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.hashes = hashes
+	s.modTime = info.ModTime()
+	s.checkedAt = time.Now()
+
+	return nil
+}
+
+// Verify 校验用户名与密码，必要时先检查文件是否需要重新加载。
+//
+// 参数：
+//   - ctx context.Context：当前请求上下文。
+//   - username string：待校验的用户名。
+//   - password string：待校验的密码。
+//
+// 返回值：
+//   - bool：凭据通过校验时返回 true。
+//   - error：始终为 nil；自动重新加载失败不会影响本次校验。
+func (s *HtpasswdStore) Verify(ctx context.Context, username, password string) (bool, error) {
+	s.reloadIfChanged()
+
+	return LookupFunc(func(_ context.Context, username string) (string, bool, error) {
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+		hash, found := s.hashes[username]
+		return hash, found, nil
+	}).Verify(ctx, username, password)
+}
+
+// reloadIfChanged 在超过检查间隔且文件修改时间变化时重新加载文件。
+//
+// 参数：无。
+func (s *HtpasswdStore) reloadIfChanged() {
+	if s.reloadInterval <= 0 {
+		return
+	}
+
+	s.mu.Lock()
+	if time.Since(s.checkedAt) < s.reloadInterval {
+		s.mu.Unlock()
+		return
+	}
+	s.checkedAt = time.Now()
+	modTime := s.modTime
+	s.mu.Unlock()
+
+	if info, err := os.Stat(s.path); nil == err && !info.ModTime().Equal(modTime) {
+		_ = s.Reload()
+	}
+}
+
+// parseHtpasswd 解析 htpasswd 文件内容。
+//
+// 参数：
+//   - data []byte：htpasswd 文件内容。
+//
+// 返回值：
+//   - map[string]string：用户名到密码哈希的映射。
+//   - error：存在缺少冒号分隔符或用户名为空的行时返回错误。
+func parseHtpasswd(data []byte) (map[string]string, error) {
+	hashes := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if "" == line || strings.HasPrefix(line, "#") {
+			continue
+		}
+		username, hash, ok := strings.Cut(line, ":")
+		if !ok || "" == username {
+			return nil, cockroachdberrors.Newf("第 %[1]d 行格式错误。", lineNumber)
+		}
+		hashes[username] = hash
+	}
+
+	return hashes, scanner.Err()
+}
@@ -0,0 +1,123 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package basicauth
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// TestParseHtpasswd 验证 htpasswd 内容解析会忽略注释与空行并拒绝格式错误的行。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestParseHtpasswd(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		giveData    string
+		want        map[string]string
+		wantErr     bool
+	}{
+		{
+			name:        "success/comments-and-blank-lines",
+			description: "验证注释和空行被忽略，哈希中的冒号被保留。",
+			giveData:    "# comment\n\nadmin:$2y$hash\nops:{SHA}abc:def\n",
+			want:        map[string]string{"admin": "$2y$hash", "ops": "{SHA}abc:def"},
+		},
+		{
+			name:        "error/missing-separator",
+			description: "验证缺少冒号分隔符的行返回错误。",
+			giveData:    "admin\n",
+			wantErr:     true,
+		},
+		{
+			name:        "error/empty-username",
+			description: "验证用户名为空的行返回错误。",
+			giveData:    ":hash\n",
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			got, err := parseHtpasswd([]byte(tt.giveData))
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// TestHtpasswdStore_Reload 验证 htpasswd 凭据存储在文件变化后自动重新加载，并在加载失败时保留旧内容。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestHtpasswdStore_Reload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".htpasswd")
+	writeHtpasswd(t, path, "admin", "first", time.Now().Add(-time.Hour))
+
+	store, err := NewHtpasswdStore(path, time.Nanosecond)
+	require.NoError(t, err)
+
+	ok, err := store.Verify(context.Background(), "admin", "first")
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	// 文件内容与修改时间变化后，下一次 Verify 会加载新密码。
+	writeHtpasswd(t, path, "admin", "second", time.Now())
+	ok, err = store.Verify(context.Background(), "admin", "second")
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	// 文件格式错误时，Reload 返回错误并保留上一次成功加载的内容。
+	require.NoError(t, os.WriteFile(path, []byte("broken\n"), 0o600))
+	require.Error(t, store.Reload())
+	ok, err = store.Verify(context.Background(), "admin", "second")
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
+
+// TestNewHtpasswdStore_MissingFile 验证文件不存在时构造函数返回错误。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestNewHtpasswdStore_MissingFile(t *testing.T) {
+	store, err := NewHtpasswdStore(filepath.Join(t.TempDir(), "missing"), 0)
+
+	require.Error(t, err)
+	assert.Nil(t, store)
+}
+
+// writeHtpasswd 写入只包含一个 bcrypt 用户的 htpasswd 文件并设置修改时间。
+//
+// 参数：
+//   - t: 测试上下文，用于报告夹具构造失败。
+//   - path: 文件路径。
+//   - username: 用户名。
+//   - password: 明文密码。
+//   - modTime: 文件修改时间。
+func writeHtpasswd(t *testing.T, path, username, password string, modTime time.Time) {
+	t.Helper()
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, []byte(username+":"+string(hash)+"\n"), 0o600))
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+}
@@ -0,0 +1,108 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package basicauth
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	kitcache "github.com/fsyyft-go/kit/cache"
+)
+
+const (
+	// lockoutKeyPrefix 是失败计数在缓存中的键前缀，避免与共享缓存中的其它键冲突。
+	lockoutKeyPrefix = "kit:basicauth:lockout:"
+)
+
+type (
+	// lockout 基于缓存记录每个用户名的连续认证失败次数。
+	//
+	// 失败次数达到 maxFailures 后，用户名在 duration 内被锁定；每次失败都会刷新过期时间，
+	// 认证成功会清除计数。计数依赖缓存保留写入的值，缓存拒绝或淘汰计数时锁定失效。
+	lockout struct {
+		// cache 保存失败计数，值类型为 int。
+		cache kitcache.Cache
+		// maxFailures 是触发锁定的连续失败次数。
+		maxFailures int
+		// duration 是失败计数的保留时长，即锁定时长。
+		duration time.Duration
+		// mu 串行化失败计数的读改写。
+		mu sync.Mutex
+	}
+)
+
+// newLockout 创建失败锁定记录器。
+//
+// 参数：
+//   - cache kitcache.Cache：保存失败计数的缓存。
+//   - maxFailures int：触发锁定的连续失败次数。
+//   - duration time.Duration：锁定时长。
+//
+// 返回值：
+//   - *lockout：失败锁定记录器。
+func newLockout(cache kitcache.Cache, maxFailures int, duration time.Duration) *lockout {
+	return &lockout{
+		cache:       cache,
+		maxFailures: maxFailures,
+		duration:    duration,
+	}
+}
+
+// attempt 检查用户名是否处于锁定状态，未锁定时预先记录一次失败。
+//
+// 检查与计数在同一临界区内完成，并发的猜测请求最多只有 maxFailures 个能进入密码校验；
+// 认证成功后由 reset 清除预先记录的失败。
+//
+// 参数：
+//   - username string：待认证的用户名。
+//
+// 返回值：
+//   - time.Duration：已锁定时为锁定剩余时长，未锁定时为 0。
+//   - bool：未锁定、允许校验密码时返回 true。
+func (l *lockout) attempt(username string) (time.Duration, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	key := lockoutKeyPrefix + username
+	failures, remaining := 0, time.Duration(0)
+	if v, ok, ttl := l.cache.GetWithTTL(key); ok {
+		if n, ok := v.(int); ok {
+			failures, remaining = n, ttl
+		}
+	}
+	if failures >= l.maxFailures {
+		return remaining, false
+	}
+
+	l.cache.SetWithTTL(key, failures+1, l.duration)
+	return 0, true
+}
+
+// reset 清除用户名的失败计数。
+//
+// 参数：
+//   - username string：认证成功的用户名。
+func (l *lockout) reset(username string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.cache.Delete(lockoutKeyPrefix + username)
+}
+
+// retryAfter 将锁定剩余时长转换为 Retry-After 响应头的秒数。
+//
+// 参数：
+//   - remaining time.Duration：锁定剩余时长。
+//
+// 返回值：
+//   - string：向上取整的秒数，至少为 1。
+func retryAfter(remaining time.Duration) string {
+	seconds := int64((remaining + time.Second - 1) / time.Second)
+	if seconds < 1 {
+		seconds = 1
+	}
+	return strconv.FormatInt(seconds, 10)
+}
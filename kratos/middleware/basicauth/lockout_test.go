@@ -0,0 +1,161 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package basicauth
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	kitcache "github.com/fsyyft-go/kit/cache"
+)
+
+// TestServer_Lockout 验证连续认证失败达到上限后用户名被锁定，成功认证会清除失败计数。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestServer_Lockout(t *testing.T) {
+	c, err := kitcache.NewCache()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = c.Close() })
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.MinCost)
+	require.NoError(t, err)
+	mw := Server(
+		WithCredentialStore(NewStaticStore(map[string]string{"admin": string(hash), "ops": string(hash)})),
+		WithLockout(c, 2, time.Minute),
+	)
+	handler := mw(func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	})
+	call := func(username, password string) (*mockTransport, error) {
+		tr := newMockTransport()
+		tr.header["Authorization"] = makeBasicAuthHeader(username, password)
+		_, err := handler(transport.NewServerContext(context.Background(), tr), nil)
+		return tr, err
+	}
+
+	// 第一次失败后成功，失败计数被清除。
+	_, err = call("ops", "wrong")
+	require.ErrorIs(t, err, ErrInvalidBasicAuth)
+	_, err = call("ops", "secret")
+	require.NoError(t, err)
+	_, err = call("ops", "wrong")
+	require.ErrorIs(t, err, ErrInvalidBasicAuth)
+	_, err = call("ops", "secret")
+	require.NoError(t, err)
+
+	// 连续失败达到上限后，即使密码正确也会被拒绝。
+	for i := 0; i < 2; i++ {
+		_, err = call("admin", "wrong")
+		require.ErrorIs(t, err, ErrInvalidBasicAuth)
+	}
+	tr, err := call("admin", "secret")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrBasicAuthLocked))
+	assert.EqualValues(t, 429, errors.FromError(err).Code)
+	assert.Equal(t, "60", tr.reply["Retry-After"])
+
+	// 其它用户名不受影响。
+	_, err = call("ops", "secret")
+	require.NoError(t, err)
+}
+
+// TestWithLockout_Disabled 验证非正失败次数或 nil 缓存不会启用锁定。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestWithLockout_Disabled(t *testing.T) {
+	c, err := kitcache.NewCache()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = c.Close() })
+
+	o := &options{}
+	WithLockout(c, 0, time.Minute)(o)
+	assert.Nil(t, o.lockout)
+	WithLockout(nil, 3, time.Minute)(o)
+	assert.Nil(t, o.lockout)
+	WithLockout(c, 3, time.Minute)(o)
+	assert.NotNil(t, o.lockout)
+}
+
+// TestServer_LockoutConcurrent 验证并发的猜测请求不能绕过失败上限。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestServer_LockoutConcurrent(t *testing.T) {
+	c, err := kitcache.NewCache()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = c.Close() })
+
+	var verified atomic.Int32
+	mw := Server(
+		WithValidator(func(ctx context.Context, username, password string) bool {
+			verified.Add(1)
+			// 放大校验耗时，让并发请求在失败被记录前全部到达。
+			time.Sleep(20 * time.Millisecond)
+			return false
+		}),
+		WithLockout(c, 3, time.Minute),
+	)
+	handler := mw(func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	})
+
+	const requests = 20
+	var (
+		wg     sync.WaitGroup
+		locked atomic.Int32
+	)
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			tr := newMockTransport()
+			tr.header["Authorization"] = makeBasicAuthHeader("admin", "guess")
+			if _, err := handler(transport.NewServerContext(context.Background(), tr), nil); errors.Is(err, ErrBasicAuthLocked) {
+				locked.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.EqualValues(t, 3, verified.Load(), "只有上限内的请求可以校验密码")
+	assert.EqualValues(t, requests-3, locked.Load())
+}
+
+// TestRetryAfter 验证 Retry-After 使用向上取整且至少为 1 的剩余秒数。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestRetryAfter(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		remaining   time.Duration
+		want        string
+	}{
+		{name: "boundary/zero", description: "验证剩余时长为 0 时返回 1。", remaining: 0, want: "1"},
+		{name: "boundary/sub-second", description: "验证不足 1 秒时返回 1。", remaining: 300 * time.Millisecond, want: "1"},
+		{name: "success/round-up", description: "验证非整秒向上取整。", remaining: 1500 * time.Millisecond, want: "2"},
+		{name: "success/exact", description: "验证整秒保持不变。", remaining: time.Minute, want: "60"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+			assert.Equal(t, tt.want, retryAfter(tt.remaining))
+		})
+	}
+}
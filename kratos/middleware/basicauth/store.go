@@ -0,0 +1,204 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package basicauth
+
+import (
+	"context"
+	"crypto/sha1" //nolint:gosec
+	"crypto/subtle"
+	"database/sql"
+	"encoding/base64"
+	"strings"
+	"sync"
+
+	cockroachdberrors "github.com/cockroachdb/errors"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	// 断言内置凭据存储实现 CredentialStore 接口。
+	_ CredentialStore = (*StaticStore)(nil)
+	_ CredentialStore = (LookupFunc)(nil)
+)
+
+const (
+	// shaPrefix 是 htpasswd SHA1 哈希格式的前缀。
+	shaPrefix = "{SHA}"
+)
+
+var (
+	// dummyBcryptHash 在首次使用时生成默认成本的 bcrypt 哈希，用于用户名不存在时执行一次等价耗时的校验，
+	// 避免通过响应时间枚举用户名。延迟生成可以避免导入本包时付出 bcrypt 计算成本。
+	dummyBcryptHash = sync.OnceValue(func() []byte {
+		hash, err := bcrypt.GenerateFromPassword([]byte("fsyyft-go/kit basicauth dummy password"), bcrypt.DefaultCost)
+		if nil != err {
+			panic(err)
+		}
+		return hash
+	})
+)
+
+type (
+	// CredentialStore 按用户名校验密码。
+	//
+	// 实现必须可并发调用。用户名不存在与密码错误都应返回 false 和 nil 错误；
+	// 只有后端不可用等无法判断结果的情况才返回错误，此时 Server 同样拒绝认证。
+	CredentialStore interface {
+		// Verify 校验用户名与密码。
+		//
+		// 参数：
+		//   - ctx context.Context：当前请求上下文。
+		//   - username string：从 Authorization 头中解析出的用户名。
+		//   - password string：从 Authorization 头中解析出的密码。
+		//
+		// 返回值：
+		//   - bool：凭据通过校验时返回 true。
+		//   - error：后端查询失败时返回错误。
+		Verify(ctx context.Context, username, password string) (bool, error)
+	}
+
+	// LookupFunc 按用户名查询已存储的密码哈希，并适配为 CredentialStore。
+	//
+	// 参数：
+	//   - ctx context.Context：当前请求上下文。
+	//   - username string：待查询的用户名。
+	//
+	// 返回值：
+	//   - string：用户名对应的密码哈希，支持 bcrypt 与 `{SHA}` 格式。
+	//   - bool：用户名存在时返回 true。
+	//   - error：后端查询失败时返回错误。
+	LookupFunc func(ctx context.Context, username string) (string, bool, error)
+
+	// StaticStore 是基于内存映射的凭据存储，映射值为密码哈希。
+	//
+	// StaticStore 创建后只读，可并发调用。
+	StaticStore struct {
+		// hashes 保存用户名到密码哈希的映射。
+		hashes map[string]string
+	}
+)
+
+// Verify 查询用户名对应的密码哈希并校验密码。
+//
+// 用户名不存在时仍会执行一次 bcrypt 校验，使存在与不存在的用户名耗时相近。
+//
+// 参数：
+//   - ctx context.Context：当前请求上下文。
+//   - username string：待校验的用户名。
+//   - password string：待校验的密码。
+//
+// 返回值：
+//   - bool：凭据通过校验时返回 true。
+//   - error：查询函数返回的错误。
+func (f LookupFunc) Verify(ctx context.Context, username, password string) (bool, error) {
+	hash, found, err := f(ctx, username)
+	if nil != err {
+		return false, err
+	}
+	if !found {
+		_ = bcrypt.CompareHashAndPassword(dummyBcryptHash(), []byte(password))
+		return false, nil
+	}
+
+	return VerifyHash(hash, password), nil
+}
+
+// Verify 校验用户名与密码。
+//
+// 参数：
+//   - ctx context.Context：当前请求上下文。
+//   - username string：待校验的用户名。
+//   - password string：待校验的密码。
+//
+// 返回值：
+//   - bool：凭据通过校验时返回 true。
+//   - error：始终为 nil。
+func (s *StaticStore) Verify(ctx context.Context, username, password string) (bool, error) {
+	return LookupFunc(func(_ context.Context, username string) (string, bool, error) {
+		hash, found := s.hashes[username]
+		return hash, found, nil
+	}).Verify(ctx, username, password)
+}
+
+// NewStaticStore 创建基于内存映射的凭据存储。
+//
+// 参数：
+//   - hashes map[string]string：用户名到密码哈希的映射，哈希可由 HashPassword 生成；映射会被复制。
+//
+// 返回值：
+//   - *StaticStore：新创建的凭据存储。
+func NewStaticStore(hashes map[string]string) *StaticStore {
+	copied := make(map[string]string, len(hashes))
+	for username, hash := range hashes {
+		copied[username] = hash
+	}
+
+	return &StaticStore{hashes: copied}
+}
+
+// NewSQLStore 创建基于 SQL 查询的凭据存储。
+//
+// query 必须只接收一个用户名参数并返回一列密码哈希，例如
+// `SELECT password_hash FROM users WHERE username = ?`。查询无结果时视为用户名不存在。
+//
+// 参数：
+//   - db *sql.DB：执行查询的数据库连接池。
+//   - query string：按用户名查询密码哈希的 SQL。
+//
+// 返回值：
+//   - CredentialStore：基于 SQL 查询的凭据存储。
+func NewSQLStore(db *sql.DB, query string) CredentialStore {
+	return LookupFunc(func(ctx context.Context, username string) (string, bool, error) {
+		var hash string
+		if err := db.QueryRowContext(ctx, query, username).Scan(&hash); nil != err {
+			if cockroachdberrors.Is(err, sql.ErrNoRows) {
+				return "", false, nil
+			}
+			return "", false, cockroachdberrors.Wrap(err, "查询凭据出现错误。")
+		}
+		return hash, true, nil
+	})
+}
+
+// HashPassword 使用 bcrypt 默认成本生成密码哈希。
+//
+// 参数：
+//   - password string：明文密码。
+//
+// 返回值：
+//   - string：bcrypt 密码哈希。
+//   - error：密码超过 bcrypt 长度上限等情况下返回错误。
+func HashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if nil != err {
+		return "", cockroachdberrors.Wrap(err, "生成密码哈希出现错误。")
+	}
+
+	return string(hash), nil
+}
+
+// VerifyHash 校验明文密码是否与存储的哈希匹配。
+//
+// 支持 bcrypt（`$2a$`、`$2b$`、`$2y$` 前缀）与 htpasswd 的 `{SHA}` 格式，
+// `{SHA}` 格式使用常量时间比较。其它格式一律视为不匹配。
+//
+// 参数：
+//   - hash string：存储的密码哈希。
+//   - password string：待校验的明文密码。
+//
+// 返回值：
+//   - bool：密码匹配时返回 true。
+func VerifyHash(hash, password string) bool {
+	switch {
+	case strings.HasPrefix(hash, "$2a$"), strings.HasPrefix(hash, "$2b$"), strings.HasPrefix(hash, "$2y$"):
+		return nil == bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+	case strings.HasPrefix(hash, shaPrefix):
+		sum := sha1.Sum([]byte(password)) //nolint:gosec
+		expected := base64.StdEncoding.EncodeToString(sum[:])
+		return 1 == subtle.ConstantTimeCompare([]byte(strings.TrimPrefix(hash, shaPrefix)), []byte(expected))
+	default:
+		return false
+	}
+}
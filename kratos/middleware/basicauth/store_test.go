@@ -0,0 +1,251 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package basicauth
+
+import (
+	"context"
+	"crypto/sha1" //nolint:gosec
+	"database/sql"
+	"database/sql/driver"
+	"encoding/base64"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	errTestLookup = errors.New("test lookup failed")
+)
+
+// TestVerifyHash 验证密码哈希校验支持 bcrypt 与 {SHA} 格式并拒绝未知格式。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestVerifyHash(t *testing.T) {
+	bcryptHash, err := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.MinCost)
+	require.NoError(t, err)
+	sum := sha1.Sum([]byte("secret")) //nolint:gosec
+	shaHash := shaPrefix + base64.StdEncoding.EncodeToString(sum[:])
+
+	tests := []struct {
+		name         string
+		description  string
+		giveHash     string
+		givePassword string
+		want         bool
+	}{
+		{
+			name:         "success/bcrypt",
+			description:  "验证 bcrypt 哈希可以校验正确密码。",
+			giveHash:     string(bcryptHash),
+			givePassword: "secret",
+			want:         true,
+		},
+		{
+			name:         "error/bcrypt-wrong-password",
+			description:  "验证 bcrypt 哈希拒绝错误密码。",
+			giveHash:     string(bcryptHash),
+			givePassword: "wrong",
+		},
+		{
+			name:         "success/sha",
+			description:  "验证 htpasswd {SHA} 哈希可以校验正确密码。",
+			giveHash:     shaHash,
+			givePassword: "secret",
+			want:         true,
+		},
+		{
+			name:         "error/sha-wrong-password",
+			description:  "验证 {SHA} 哈希拒绝错误密码。",
+			giveHash:     shaHash,
+			givePassword: "wrong",
+		},
+		{
+			name:         "error/plaintext-rejected",
+			description:  "验证未知格式（包括明文）一律视为不匹配。",
+			giveHash:     "secret",
+			givePassword: "secret",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			assert.Equal(t, tt.want, VerifyHash(tt.giveHash, tt.givePassword))
+		})
+	}
+}
+
+// TestHashPassword 验证 HashPassword 生成的哈希可被 VerifyHash 校验，并拒绝超长密码。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestHashPassword(t *testing.T) {
+	hash, err := HashPassword("secret")
+	require.NoError(t, err)
+	assert.True(t, VerifyHash(hash, "secret"))
+
+	_, err = HashPassword(string(make([]byte, 100)))
+	require.Error(t, err)
+}
+
+// TestStaticStore_Verify 验证静态凭据存储的校验结果以及对输入映射的复制。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestStaticStore_Verify(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.MinCost)
+	require.NoError(t, err)
+	hashes := map[string]string{"admin": string(hash)}
+	store := NewStaticStore(hashes)
+	delete(hashes, "admin")
+
+	ok, err := store.Verify(context.Background(), "admin", "secret")
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = store.Verify(context.Background(), "admin", "wrong")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	ok, err = store.Verify(context.Background(), "nobody", "secret")
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+// TestLookupFunc_VerifyError 验证查询函数返回错误时 Verify 透传错误并拒绝认证。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestLookupFunc_VerifyError(t *testing.T) {
+	store := LookupFunc(func(context.Context, string) (string, bool, error) {
+		return "", false, errTestLookup
+	})
+
+	ok, err := store.Verify(context.Background(), "admin", "secret")
+
+	require.ErrorIs(t, err, errTestLookup)
+	assert.False(t, ok)
+}
+
+// TestNewSQLStore 验证 SQL 凭据存储对查询结果、无结果和查询错误的处理。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestNewSQLStore(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		description string
+		giveRows    map[string]string
+		giveErr     error
+		wantOK      bool
+		wantErr     bool
+	}{
+		{
+			name:        "success/found",
+			description: "验证查询到哈希时按哈希校验密码。",
+			giveRows:    map[string]string{"admin": string(hash)},
+			wantOK:      true,
+		},
+		{
+			name:        "error/no-rows",
+			description: "验证查询无结果时视为用户名不存在。",
+			giveRows:    map[string]string{},
+		},
+		{
+			name:        "error/query-failed",
+			description: "验证查询失败时返回错误。",
+			giveErr:     errTestLookup,
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			db := sql.OpenDB(&testConnector{rows: tt.giveRows, err: tt.giveErr})
+			t.Cleanup(func() { _ = db.Close() })
+
+			ok, err := NewSQLStore(db, "SELECT hash FROM users WHERE username = ?").Verify(context.Background(), "admin", "secret")
+			if tt.wantErr {
+				require.ErrorIs(t, err, errTestLookup)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantOK, ok)
+		})
+	}
+}
+
+// testConnector 是按用户名返回预设哈希的最小 SQL 驱动连接器。
+type testConnector struct {
+	rows map[string]string
+	err  error
+}
+
+// Connect 返回测试连接。
+func (c *testConnector) Connect(context.Context) (driver.Conn, error) { return &testConn{c: c}, nil }
+
+// Driver 返回测试驱动。
+func (c *testConnector) Driver() driver.Driver { return nil }
+
+// testConn 是只支持 QueryContext 的测试连接。
+type testConn struct {
+	c *testConnector
+}
+
+// Prepare 不被测试路径使用。
+func (c *testConn) Prepare(string) (driver.Stmt, error) { return nil, driver.ErrSkip }
+
+// Close 关闭测试连接。
+func (c *testConn) Close() error { return nil }
+
+// Begin 不被测试路径使用。
+func (c *testConn) Begin() (driver.Tx, error) { return nil, driver.ErrSkip }
+
+// QueryContext 按第一个参数返回预设哈希或错误。
+func (c *testConn) QueryContext(_ context.Context, _ string, args []driver.NamedValue) (driver.Rows, error) {
+	if nil != c.c.err {
+		return nil, c.c.err
+	}
+	rows := &testRows{}
+	if hash, ok := c.c.rows[args[0].Value.(string)]; ok {
+		rows.values = []string{hash}
+	}
+	return rows, nil
+}
+
+// testRows 是单列结果集。
+type testRows struct {
+	values []string
+}
+
+// Columns 返回列名。
+func (r *testRows) Columns() []string { return []string{"hash"} }
+
+// Close 关闭结果集。
+func (r *testRows) Close() error { return nil }
+
+// Next 依次返回预设值。
+func (r *testRows) Next(dest []driver.Value) error {
+	if 0 == len(r.values) {
+		return io.EOF
+	}
+	dest[0] = r.values[0]
+	r.values = r.values[1:]
+	return nil
+}
@@ -0,0 +1,315 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package cors
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	// Option 配置 Server 返回的 CORS 中间件。
+	Option func(*options)
+
+	// options 包含中间件配置选项。
+	options struct {
+		// 允许的来源规则，支持 `*` 与单个 `*` 通配符，例如 `https://*.example.com`。
+		allowedOrigins []string
+		// 允许的请求方法，统一为大写。
+		allowedMethods []string
+		// 允许的请求头，统一为规范化形式。
+		allowedHeaders []string
+		// 允许浏览器读取的响应头。
+		exposedHeaders []string
+		// 是否允许携带凭据。
+		allowCredentials bool
+		// 预检结果缓存时长，小于等于 0 时不写入 Access-Control-Max-Age。
+		maxAge time.Duration
+	}
+)
+
+// WithAllowedOrigins 设置允许的来源。
+//
+// 参数：
+//   - origins ...string：来源规则；`*` 表示任意来源，其它规则最多包含一个 `*` 通配符，
+//     例如 `https://*.example.com`。比较时忽略大小写。
+//
+// 返回值：
+//   - Option：中间件配置选项。
+//
+// 未设置时不允许任何跨域来源。
+func WithAllowedOrigins(origins ...string) Option {
+	return func(o *options) {
+		o.allowedOrigins = make([]string, 0, len(origins))
+		for _, origin := range origins {
+			o.allowedOrigins = append(o.allowedOrigins, strings.ToLower(origin))
+		}
+	}
+}
+
+// WithAllowedMethods 设置预检请求允许的方法。
+//
+// 参数：
+//   - methods ...string：允许的 HTTP 方法。
+//
+// 返回值：
+//   - Option：中间件配置选项。
+//
+// 未设置时允许 GET、POST、PUT、PATCH、DELETE 和 HEAD。
+func WithAllowedMethods(methods ...string) Option {
+	return func(o *options) {
+		o.allowedMethods = make([]string, 0, len(methods))
+		for _, method := range methods {
+			o.allowedMethods = append(o.allowedMethods, strings.ToUpper(method))
+		}
+	}
+}
+
+// WithAllowedHeaders 设置预检请求允许的请求头。
+//
+// 参数：
+//   - headers ...string：允许的请求头；`*` 表示允许预检请求声明的任意请求头。
+//
+// 返回值：
+//   - Option：中间件配置选项。
+//
+// 未设置时只允许 Origin、Accept、Content-Type 和 Authorization。
+func WithAllowedHeaders(headers ...string) Option {
+	return func(o *options) {
+		o.allowedHeaders = make([]string, 0, len(headers))
+		for _, header := range headers {
+			o.allowedHeaders = append(o.allowedHeaders, http.CanonicalHeaderKey(header))
+		}
+	}
+}
+
+// WithExposedHeaders 设置允许浏览器读取的响应头。
+//
+// 参数：
+//   - headers ...string：暴露给浏览器的响应头。
+//
+// 返回值：
+//   - Option：中间件配置选项。
+func WithExposedHeaders(headers ...string) Option {
+	return func(o *options) {
+		o.exposedHeaders = append([]string(nil), headers...)
+	}
+}
+
+// WithAllowCredentials 设置是否允许跨域请求携带凭据。
+//
+// 参数：
+//   - allow bool：为 true 时写入 `Access-Control-Allow-Credentials: true`。
+//
+// 返回值：
+//   - Option：中间件配置选项。
+//
+// 允许凭据时，即使来源规则为 `*`，响应也会回写具体的请求来源，而不是 `*`。
+func WithAllowCredentials(allow bool) Option {
+	return func(o *options) {
+		o.allowCredentials = allow
+	}
+}
+
+// WithMaxAge 设置浏览器缓存预检结果的时长。
+//
+// 参数：
+//   - maxAge time.Duration：缓存时长，按秒截断；小于等于 0 时不写入 Access-Control-Max-Age。
+//
+// 返回值：
+//   - Option：中间件配置选项。
+func WithMaxAge(maxAge time.Duration) Option {
+	return func(o *options) {
+		o.maxAge = maxAge
+	}
+}
+
+// Server 创建 CORS 中间件。
+//
+// 参数：
+//   - opts ...Option：中间件配置选项。
+//
+// 返回值：
+//   - gin.HandlerFunc：处理跨域请求的 Gin 中间件。
+//
+// 不带 Origin 头或来源不被允许的请求会原样交给后续处理器，不写入任何 CORS 响应头，
+// 由浏览器负责拦截。来源被允许时，预检请求（携带 Access-Control-Request-Method 的 OPTIONS 请求）
+// 在方法与请求头均被允许时以 204 响应并终止后续处理器，否则以 403 终止；
+// 简单请求和实际请求会写入允许来源、凭据与暴露头后继续执行。
+func Server(opts ...Option) gin.HandlerFunc {
+	o := &options{
+		allowedMethods: []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete, http.MethodHead},
+		allowedHeaders: []string{"Origin", "Accept", "Content-Type", "Authorization"},
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	allowedMethods := strings.Join(o.allowedMethods, ", ")
+	exposedHeaders := strings.Join(o.exposedHeaders, ", ")
+	maxAge := strconv.Itoa(int(o.maxAge.Seconds()))
+
+	return func(c *gin.Context) {
+		origin := c.GetHeader("Origin")
+		if "" == origin {
+			c.Next()
+			return
+		}
+
+		header := c.Writer.Header()
+		// 响应随 Origin 变化，告知缓存按 Origin 区分。
+		header.Add("Vary", "Origin")
+		if !o.originAllowed(origin) {
+			c.Next()
+			return
+		}
+
+		if o.allowCredentials || !o.anyOrigin() {
+			header.Set("Access-Control-Allow-Origin", origin)
+		} else {
+			header.Set("Access-Control-Allow-Origin", "*")
+		}
+		if o.allowCredentials {
+			header.Set("Access-Control-Allow-Credentials", "true")
+		}
+
+		requestMethod := c.GetHeader("Access-Control-Request-Method")
+		if http.MethodOptions != c.Request.Method || "" == requestMethod {
+			if "" != exposedHeaders {
+				header.Set("Access-Control-Expose-Headers", exposedHeaders)
+			}
+			c.Next()
+			return
+		}
+
+		// 预检请求。
+		header.Add("Vary", "Access-Control-Request-Method")
+		header.Add("Vary", "Access-Control-Request-Headers")
+		requestHeaders := parseHeaderList(c.GetHeader("Access-Control-Request-Headers"))
+		if !o.methodAllowed(requestMethod) || !o.headersAllowed(requestHeaders) {
+			c.AbortWithStatus(http.StatusForbidden)
+			return
+		}
+
+		header.Set("Access-Control-Allow-Methods", allowedMethods)
+		if len(requestHeaders) > 0 {
+			header.Set("Access-Control-Allow-Headers", strings.Join(requestHeaders, ", "))
+		}
+		if o.maxAge > 0 {
+			header.Set("Access-Control-Max-Age", maxAge)
+		}
+		c.AbortWithStatus(http.StatusNoContent)
+	}
+}
+
+// anyOrigin 判断来源规则是否包含 `*`。
+//
+// 返回值：
+//   - bool：允许任意来源时返回 true。
+func (o *options) anyOrigin() bool {
+	for _, pattern := range o.allowedOrigins {
+		if "*" == pattern {
+			return true
+		}
+	}
+	return false
+}
+
+// originAllowed 判断来源是否匹配任一来源规则。
+//
+// 参数：
+//   - origin string：请求的 Origin 头。
+//
+// 返回值：
+//   - bool：来源被允许时返回 true。
+func (o *options) originAllowed(origin string) bool {
+	origin = strings.ToLower(origin)
+	for _, pattern := range o.allowedOrigins {
+		if matchOrigin(pattern, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// methodAllowed 判断预检请求声明的方法是否被允许。
+//
+// 参数：
+//   - method string：Access-Control-Request-Method 头的值。
+//
+// 返回值：
+//   - bool：方法被允许时返回 true。
+func (o *options) methodAllowed(method string) bool {
+	method = strings.ToUpper(method)
+	for _, allowed := range o.allowedMethods {
+		if allowed == method {
+			return true
+		}
+	}
+	return false
+}
+
+// headersAllowed 判断预检请求声明的请求头是否全部被允许。
+//
+// 参数：
+//   - headers []string：规范化后的请求头列表。
+//
+// 返回值：
+//   - bool：全部被允许时返回 true。
+func (o *options) headersAllowed(headers []string) bool {
+	for _, header := range headers {
+		allowed := false
+		for _, candidate := range o.allowedHeaders {
+			if "*" == candidate || candidate == header {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return false
+		}
+	}
+	return true
+}
+
+// matchOrigin 使用来源规则匹配来源。
+//
+// 参数：
+//   - pattern string：小写来源规则，最多包含一个 `*`。
+//   - origin string：小写请求来源。
+//
+// 返回值：
+//   - bool：匹配时返回 true。
+func matchOrigin(pattern, origin string) bool {
+	if "*" == pattern {
+		return true
+	}
+	prefix, suffix, wildcard := strings.Cut(pattern, "*")
+	if !wildcard {
+		return pattern == origin
+	}
+	return len(origin) > len(prefix)+len(suffix) && strings.HasPrefix(origin, prefix) && strings.HasSuffix(origin, suffix)
+}
+
+// parseHeaderList 解析逗号分隔的请求头列表并规范化。
+//
+// 参数：
+//   - value string：逗号分隔的请求头。
+//
+// 返回值：
+//   - []string：规范化后的非空请求头。
+func parseHeaderList(value string) []string {
+	headers := make([]string, 0)
+	for _, header := range strings.Split(value, ",") {
+		if header = strings.TrimSpace(header); "" != header {
+			headers = append(headers, http.CanonicalHeaderKey(header))
+		}
+	}
+	return headers
+}
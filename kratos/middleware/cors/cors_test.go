@@ -0,0 +1,238 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package cors
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestServer 验证 CORS 中间件对简单请求、预检请求和不允许来源的处理。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestServer(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name        string
+		description string
+		giveOpts    []Option
+		giveMethod  string
+		giveHeaders map[string]string
+		wantStatus  int
+		wantHeaders map[string]string
+		wantNext    bool
+	}{
+		{
+			name:        "success/no-origin",
+			description: "验证不带 Origin 的请求不写入 CORS 响应头并继续执行。",
+			giveOpts:    []Option{WithAllowedOrigins("*")},
+			giveMethod:  http.MethodGet,
+			wantStatus:  http.StatusOK,
+			wantHeaders: map[string]string{"Access-Control-Allow-Origin": "", "Vary": ""},
+			wantNext:    true,
+		},
+		{
+			name:        "success/any-origin",
+			description: "验证允许任意来源且不允许凭据时返回 *。",
+			giveOpts:    []Option{WithAllowedOrigins("*"), WithExposedHeaders("X-Request-Id")},
+			giveMethod:  http.MethodGet,
+			giveHeaders: map[string]string{"Origin": "https://a.example.com"},
+			wantStatus:  http.StatusOK,
+			wantHeaders: map[string]string{
+				"Access-Control-Allow-Origin":   "*",
+				"Access-Control-Expose-Headers": "X-Request-Id",
+				"Vary":                          "Origin",
+			},
+			wantNext: true,
+		},
+		{
+			name:        "success/wildcard-origin-with-credentials",
+			description: "验证通配符来源匹配子域名，允许凭据时回写具体来源。",
+			giveOpts:    []Option{WithAllowedOrigins("https://*.example.com"), WithAllowCredentials(true)},
+			giveMethod:  http.MethodPost,
+			giveHeaders: map[string]string{"Origin": "https://App.example.com"},
+			wantStatus:  http.StatusOK,
+			wantHeaders: map[string]string{
+				"Access-Control-Allow-Origin":      "https://App.example.com",
+				"Access-Control-Allow-Credentials": "true",
+			},
+			wantNext: true,
+		},
+		{
+			name:        "error/origin-not-allowed",
+			description: "验证来源不被允许时不写入允许来源并继续执行，由浏览器拦截。",
+			giveOpts:    []Option{WithAllowedOrigins("https://*.example.com")},
+			giveMethod:  http.MethodGet,
+			giveHeaders: map[string]string{"Origin": "https://example.com"},
+			wantStatus:  http.StatusOK,
+			wantHeaders: map[string]string{"Access-Control-Allow-Origin": "", "Vary": "Origin"},
+			wantNext:    true,
+		},
+		{
+			name:        "success/preflight",
+			description: "验证合法预检请求以 204 短路并写入方法、请求头与缓存时长。",
+			giveOpts: []Option{
+				WithAllowedOrigins("https://a.example.com"),
+				WithAllowedMethods("get", "put"),
+				WithAllowedHeaders("content-type", "x-token"),
+				WithMaxAge(10 * time.Minute),
+			},
+			giveMethod: http.MethodOptions,
+			giveHeaders: map[string]string{
+				"Origin":                         "https://a.example.com",
+				"Access-Control-Request-Method":  "PUT",
+				"Access-Control-Request-Headers": "x-token, Content-Type",
+			},
+			wantStatus: http.StatusNoContent,
+			wantHeaders: map[string]string{
+				"Access-Control-Allow-Origin":  "https://a.example.com",
+				"Access-Control-Allow-Methods": "GET, PUT",
+				"Access-Control-Allow-Headers": "X-Token, Content-Type",
+				"Access-Control-Max-Age":       "600",
+			},
+		},
+		{
+			name:        "error/preflight-method-not-allowed",
+			description: "验证预检请求声明的方法不被允许时以 403 短路。",
+			giveOpts:    []Option{WithAllowedOrigins("*"), WithAllowedMethods("GET")},
+			giveMethod:  http.MethodOptions,
+			giveHeaders: map[string]string{
+				"Origin":                        "https://a.example.com",
+				"Access-Control-Request-Method": "DELETE",
+			},
+			wantStatus:  http.StatusForbidden,
+			wantHeaders: map[string]string{"Access-Control-Allow-Methods": ""},
+		},
+		{
+			name:        "error/preflight-header-not-allowed",
+			description: "验证预检请求声明的请求头不被允许时以 403 短路。",
+			giveOpts:    []Option{WithAllowedOrigins("*")},
+			giveMethod:  http.MethodOptions,
+			giveHeaders: map[string]string{
+				"Origin":                         "https://a.example.com",
+				"Access-Control-Request-Method":  "GET",
+				"Access-Control-Request-Headers": "X-Secret",
+			},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:        "success/preflight-any-header",
+			description: "验证允许请求头为 * 时接受任意声明的请求头，且不设置缓存时长。",
+			giveOpts:    []Option{WithAllowedOrigins("*"), WithAllowedHeaders("*")},
+			giveMethod:  http.MethodOptions,
+			giveHeaders: map[string]string{
+				"Origin":                         "https://a.example.com",
+				"Access-Control-Request-Method":  "GET",
+				"Access-Control-Request-Headers": "X-Secret",
+			},
+			wantStatus: http.StatusNoContent,
+			wantHeaders: map[string]string{
+				"Access-Control-Allow-Headers": "X-Secret",
+				"Access-Control-Max-Age":       "",
+			},
+		},
+		{
+			name:        "boundary/options-without-request-method",
+			description: "验证不带 Access-Control-Request-Method 的 OPTIONS 请求按普通请求继续执行。",
+			giveOpts:    []Option{WithAllowedOrigins("*")},
+			giveMethod:  http.MethodOptions,
+			giveHeaders: map[string]string{"Origin": "https://a.example.com"},
+			wantStatus:  http.StatusOK,
+			wantNext:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			next := false
+			engine := gin.New()
+			engine.Use(Server(tt.giveOpts...))
+			engine.Handle(tt.giveMethod, "/", func(c *gin.Context) {
+				next = true
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(tt.giveMethod, "/", nil)
+			for k, v := range tt.giveHeaders {
+				req.Header.Set(k, v)
+			}
+			resp := httptest.NewRecorder()
+			engine.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.wantStatus, resp.Code)
+			assert.Equal(t, tt.wantNext, next)
+			for k, v := range tt.wantHeaders {
+				assert.Equal(t, v, resp.Header().Get(k), k)
+			}
+		})
+	}
+}
+
+// TestMatchOrigin 验证来源规则的精确匹配与通配符匹配。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestMatchOrigin(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		givePattern string
+		giveOrigin  string
+		want        bool
+	}{
+		{
+			name:        "success/exact",
+			description: "验证不含通配符的规则精确匹配。",
+			givePattern: "https://example.com",
+			giveOrigin:  "https://example.com",
+			want:        true,
+		},
+		{
+			name:        "success/subdomain",
+			description: "验证通配符匹配子域名。",
+			givePattern: "https://*.example.com",
+			giveOrigin:  "https://a.b.example.com",
+			want:        true,
+		},
+		{
+			name:        "error/empty-wildcard",
+			description: "验证通配符至少匹配一个字符，裸域名不匹配子域名规则。",
+			givePattern: "https://*.example.com",
+			giveOrigin:  "https://.example.com",
+		},
+		{
+			name:        "error/suffix-mismatch",
+			description: "验证后缀不同的来源不匹配。",
+			givePattern: "https://*.example.com",
+			giveOrigin:  "https://a.example.com.evil.com",
+		},
+		{
+			name:        "success/port-wildcard",
+			description: "验证通配符可以用于端口。",
+			givePattern: "http://localhost:*",
+			giveOrigin:  "http://localhost:8080",
+			want:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			assert.Equal(t, tt.want, matchOrigin(tt.givePattern, tt.giveOrigin))
+		})
+	}
+}
@@ -0,0 +1,13 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+// Package cors 提供用于 Gin 适配层的跨域资源共享（CORS）中间件。
+//
+// 预检请求在进入 Kratos 处理链之前就需要响应，因此 Server 返回 gin.HandlerFunc，
+// 而不是 Kratos middleware.Middleware。中间件支持带通配符的允许来源、允许方法与请求头、
+// 暴露响应头、凭据和预检缓存时长；合法的预检请求会被直接以 204 响应并终止后续处理器。
+//
+// 配合 kratos/transport/http 的 Parse 与 WithGroup，可以为不同路由前缀挂载不同的 CORS 配置，
+// Parse 会为这些路由补充 OPTIONS 路由，使预检请求能够到达对应配置的中间件。
+package cors
@@ -4,10 +4,18 @@
 
 // Package middleware 汇总用于 Kratos 服务端请求处理的中间件子包。
 //
-// 当前子包包括 basicauth 和 validate：basicauth 提供基于 HTTP Basic
-// Authentication 的服务端认证中间件；validate 提供调用请求对象
-// Validate() error 方法的校验中间件。调用方应直接导入所需子包，并按
-// Kratos middleware.Middleware 契约接入服务端链路。
+// 当前子包包括 accesscontrol、audit、basicauth、cors、locale、maintenance、payload、timeout、tracing 和 validate：accesscontrol
+// 提供按来源 IP 与请求头准入的中间件；audit 提供记录主体、操作、结果与耗时的审计中间件；basicauth 提供基于 HTTP Basic
+// Authentication 的服务端认证中间件；cors 提供用于 Gin 适配层的跨域资源共享
+// 中间件；locale 提供按 Accept-Language 协商语言并注入 i18n.Localizer 的中间件；
+// maintenance 提供维护期间按开关或时间窗口返回 503 的维护模式中间件；
+// payload 提供按 Operation 记录负载大小并拒绝过大请求的中间件；timeout
+// 提供支持按 Operation 覆盖超时时长的处理器超时中间件；tracing 提供基于 OpenTelemetry
+// 的链路追踪中间件；validate 提供调用请求对象
+// Validate() error 方法的校验中间件。
+// 调用方应直接导入所需子包；accesscontrol、audit、basicauth、locale、maintenance、payload、timeout 与 validate 按 Kratos middleware.Middleware
+// 契约接入服务端链路，cors 返回 gin.HandlerFunc，通过 kratos/transport/http 的
+// WithGroup 按路由前缀挂载；tracing 同时提供两种形式，二者配合时共用同一个 span。
 //
 // 本包本身仅作为分类入口，不直接导出中间件构造函数。各子包的错误返回、
 // 默认配置和自定义回调语义在对应 package comment 与函数文档中说明。
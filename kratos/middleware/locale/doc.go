@@ -0,0 +1,10 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+// Package locale 提供用于 Kratos 服务端的语言协商中间件。
+//
+// Server 从 WithHeader 配置的自定义请求头与 Accept-Language 中解析语言偏好，使用 i18n.Bundle 创建
+// *i18n.Localizer 并写入上下文，同时把协商出的语言写入 Content-Language 响应头。处理器通过
+// i18n.FromContext 取出 Localizer 渲染消息；偏好语言均未加载时回退到 Bundle 的默认语言。
+package locale
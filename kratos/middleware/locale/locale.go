@@ -0,0 +1,113 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package locale
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+
+	kiti18n "github.com/fsyyft-go/kit/i18n"
+)
+
+const (
+	// headerAcceptLanguage 是客户端声明语言偏好的标准请求头。
+	headerAcceptLanguage = "Accept-Language"
+	// headerContentLanguage 是声明响应语言的标准响应头。
+	headerContentLanguage = "Content-Language"
+)
+
+type (
+	// Option 配置 Server 返回的语言协商中间件。
+	//
+	// Option 通常由 WithHeader 或 WithContentLanguage 返回。
+	Option func(*options)
+
+	// options 包含中间件配置选项。
+	options struct {
+		// 优先于 Accept-Language 读取的自定义请求头。
+		header string
+		// 是否写入 Content-Language 响应头。
+		contentLanguage bool
+	}
+)
+
+// WithHeader 配置优先于 Accept-Language 读取的自定义请求头。
+//
+// 参数：
+//   - name string：请求头名称，例如 `X-Locale`；取值可以是单个语言标签，也可以与 Accept-Language 格式相同。
+//
+// 返回值：
+//   - Option：中间件配置选项。
+//
+// 自定义请求头中的语言排在 Accept-Language 之前，适合让用户在界面上显式切换语言。
+func WithHeader(name string) Option {
+	return func(o *options) {
+		o.header = name
+	}
+}
+
+// WithContentLanguage 配置是否写入 Content-Language 响应头。
+//
+// 参数：
+//   - enabled bool：为 true 时把协商出的语言写入响应头。
+//
+// 返回值：
+//   - Option：中间件配置选项。
+//
+// 若未设置该选项，默认写入。
+func WithContentLanguage(enabled bool) Option {
+	return func(o *options) {
+		o.contentLanguage = enabled
+	}
+}
+
+// Server 创建用于服务端请求的语言协商中间件。
+//
+// 参数：
+//   - bundle *i18n.Bundle：消息包，用于匹配已加载的语言并创建 Localizer。
+//   - opts ...Option：中间件配置选项。
+//
+// 返回值：
+//   - middleware.Middleware：把协商出的 *i18n.Localizer 写入上下文的中间件。
+//
+// 中间件依次读取 WithHeader 配置的请求头与 Accept-Language，按 i18n.ParseAcceptLanguage 的优先级创建
+// Localizer，并通过 i18n.NewContext 写入上下文；处理器使用 i18n.FromContext 取出后调用 T、N 渲染消息，
+// 或把 Localizer.Language 传给 time.DiffForHumans 输出同一语言的相对时间。上下文中不存在服务端 transport
+// 时写入默认语言的 Localizer。
+func Server(bundle *kiti18n.Bundle, opts ...Option) middleware.Middleware {
+	o := &options{
+		contentLanguage: true,
+	}
+	for _, opt := range opts {
+		if nil == opt {
+			continue
+		}
+		opt(o)
+	}
+
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			tr, ok := transport.FromServerContext(ctx)
+			if !ok {
+				return handler(kiti18n.NewContext(ctx, bundle.Localizer()), req)
+			}
+
+			var langs []string
+			if header := tr.RequestHeader(); nil != header {
+				if "" != o.header {
+					langs = append(langs, kiti18n.ParseAcceptLanguage(header.Get(o.header))...)
+				}
+				langs = append(langs, kiti18n.ParseAcceptLanguage(header.Get(headerAcceptLanguage))...)
+			}
+			l := bundle.Localizer(langs...)
+			if o.contentLanguage && nil != tr.ReplyHeader() {
+				tr.ReplyHeader().Set(headerContentLanguage, l.Language())
+			}
+			return handler(kiti18n.NewContext(ctx, l), req)
+		}
+	}
+}
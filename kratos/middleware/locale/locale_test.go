@@ -0,0 +1,133 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package locale
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	kiti18n "github.com/fsyyft-go/kit/i18n"
+)
+
+// headerCarrier 是基于 http.Header 的 transport.Header 实现。
+type headerCarrier http.Header
+
+// Get 返回请求头的第一个取值。
+func (h headerCarrier) Get(key string) string { return http.Header(h).Get(key) }
+
+// Set 设置请求头。
+func (h headerCarrier) Set(key, value string) { http.Header(h).Set(key, value) }
+
+// Add 追加请求头。
+func (h headerCarrier) Add(key, value string) { http.Header(h).Add(key, value) }
+
+// Keys 返回全部请求头名称。
+func (h headerCarrier) Keys() []string {
+	keys := make([]string, 0, len(h))
+	for k := range h {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// Values 返回请求头的全部取值。
+func (h headerCarrier) Values(key string) []string { return http.Header(h).Values(key) }
+
+// mockTransport 实现 transport.Transporter，仅提供测试所需的请求头与响应头。
+type mockTransport struct {
+	transport.Transporter
+	request headerCarrier
+	reply   headerCarrier
+}
+
+// RequestHeader 返回请求头。
+func (m *mockTransport) RequestHeader() transport.Header { return m.request }
+
+// ReplyHeader 返回响应头。
+func (m *mockTransport) ReplyHeader() transport.Header { return m.reply }
+
+// TestServer 验证语言协商、上下文写入与响应头。
+func TestServer(t *testing.T) {
+	bundle := kiti18n.NewBundle("en")
+	require.NoError(t, bundle.AddMessages("en", map[string]string{"hello": "Hello"}))
+	require.NoError(t, bundle.AddMessages("zh-CN", map[string]string{"hello": "你好"}))
+	require.NoError(t, bundle.AddMessages("ja", map[string]string{"hello": "こんにちは"}))
+
+	tests := []struct {
+		name            string
+		description     string
+		opts            []Option
+		header          map[string]string
+		noTransport     bool
+		want            string
+		wantContentLang string
+	}{
+		{
+			name:            "success/accept-language",
+			description:     "验证按 Accept-Language 权重协商语言。",
+			header:          map[string]string{"Accept-Language": "fr;q=0.9, zh;q=0.8, en;q=0.5"},
+			want:            "你好",
+			wantContentLang: "zh-CN",
+		},
+		{
+			name:            "success/custom-header",
+			description:     "验证自定义请求头优先于 Accept-Language。",
+			opts:            []Option{WithHeader("X-Locale")},
+			header:          map[string]string{"Accept-Language": "zh-CN", "X-Locale": "ja"},
+			want:            "こんにちは",
+			wantContentLang: "ja",
+		},
+		{
+			name:            "success/fallback",
+			description:     "验证未声明偏好时使用默认语言。",
+			want:            "Hello",
+			wantContentLang: "en",
+		},
+		{
+			name:        "success/no-content-language",
+			description: "验证关闭 Content-Language 响应头。",
+			opts:        []Option{WithContentLanguage(false)},
+			header:      map[string]string{"Accept-Language": "ja"},
+			want:        "こんにちは",
+		},
+		{
+			name:        "success/no-transport",
+			description: "验证上下文中不存在 transport 时写入默认语言的 Localizer。",
+			noTransport: true,
+			want:        "Hello",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			tr := &mockTransport{request: headerCarrier{}, reply: headerCarrier{}}
+			for k, v := range tt.header {
+				tr.request.Set(k, v)
+			}
+			ctx := context.Background()
+			if !tt.noTransport {
+				ctx = transport.NewServerContext(ctx, tr)
+			}
+
+			handler := Server(bundle, tt.opts...)(func(ctx context.Context, req interface{}) (interface{}, error) {
+				l, ok := kiti18n.FromContext(ctx)
+				require.True(t, ok)
+				return l.T("hello", nil), nil
+			})
+			reply, err := handler(ctx, nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, reply)
+			assert.Equal(t, tt.wantContentLang, tr.reply.Get("Content-Language"))
+		})
+	}
+}
@@ -0,0 +1,19 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+// Package maintenance 提供用于 Kratos 服务端的维护模式中间件，在维护期间拒绝匹配的请求。
+//
+// 维护状态由三类来源决定，任一来源生效即进入维护：WithWindows 配置的计划内时间窗口、
+// WithFlag 使用的 featureflag 布尔开关，以及 WithSwitch 添加的自定义开关。WithFlag 评估时
+// 以 AttributeOperation 传入请求的 Operation，因此同一开关可通过规则只关闭部分接口，作为功能熔断开关使用；
+// 配合 featureflag.NewKratosProvider 时配置中心的变更无需重启即可生效。
+//
+// WithOperations 限定受影响的 Operation 前缀，WithAllowOperations 配置始终放行的前缀，
+// 用于健康检查与管理接口，放行名单优先。
+//
+// 被拒绝的请求不会进入处理器，中间件设置 Retry-After 响应头，返回 code 为 503、reason 为
+// MAINTENANCE 且携带 operation 与 retry_after 元数据的 ErrMaintenance，并累加 MetricRejectedTotal。
+// 时间窗口触发时 Retry-After 为窗口的剩余时长，开关触发时为 WithRetryAfter 配置的间隔，默认 1 分钟。
+// MetricRejectedTotal 不会自动注册，需要调用方通过 prometheus.MustRegister 注册到所用的 Registerer。
+package maintenance
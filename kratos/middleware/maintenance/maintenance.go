@@ -0,0 +1,304 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package maintenance
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/prometheus/client_golang/prometheus"
+
+	kitfeatureflag "github.com/fsyyft-go/kit/config/featureflag"
+)
+
+const (
+	// defaultRetryAfter 是未配置 WithRetryAfter 时开关触发维护返回的重试间隔。
+	defaultRetryAfter = time.Minute
+
+	// headerRetryAfter 是告知客户端重试间隔的响应头。
+	headerRetryAfter = "Retry-After"
+
+	// AttributeOperation 是 WithFlag 评估功能开关时写入 EvalContext 的属性名，取值为请求的 Operation，
+	// 可在开关规则中按 Operation 单独关闭接口。
+	AttributeOperation = "operation"
+
+	// namespace 定义 Prometheus 指标命名空间。
+	namespace = "kit_kratos"
+	// subsystem 定义 Prometheus 指标子系统名称。
+	subsystem = "middleware"
+)
+
+var (
+	// ErrMaintenance 表示服务处于维护状态，请求未被处理。
+	//
+	// 中间件返回的错误会在元数据中携带 operation 与 retry_after（秒），并设置 Retry-After 响应头；
+	// 调用方通常按 503 Service Unavailable 处理，并可通过 errors.Is 或 reason `MAINTENANCE` 识别。
+	ErrMaintenance = errors.ServiceUnavailable("MAINTENANCE", "Service is under maintenance")
+
+	// MetricRejectedTotal 记录因维护被拒绝的请求次数。
+	//
+	// 标签：
+	//   - operation：被拒绝请求的 Operation。
+	MetricRejectedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "maintenance_rejected_total",
+		Help:      "kratos maintenance rejected total.",
+	}, []string{"operation"})
+)
+
+type (
+	// Option 配置 Server 返回的维护模式中间件。
+	//
+	// Option 通常由 WithSwitch、WithFlag、WithWindows、WithOperations、WithAllowOperations 或 WithRetryAfter 返回。
+	Option func(*options)
+
+	// Switch 判断请求是否应进入维护状态。
+	//
+	// 参数：
+	//   - ctx context.Context：当前请求上下文。
+	//   - operation string：请求的 Operation。
+	//
+	// 返回值：
+	//   - bool：返回 true 时请求被拒绝。
+	//
+	// Switch 在每个匹配的请求上调用，应读取内存中的状态并快速返回，且必须并发安全。
+	Switch func(ctx context.Context, operation string) bool
+
+	// Window 是计划内的维护时间窗口，包含 Start，不包含 End。
+	Window struct {
+		// Start 是维护开始时间。
+		Start time.Time
+		// End 是维护结束时间，窗口内返回的 Retry-After 为距 End 的剩余时长。
+		End time.Time
+	}
+
+	// options 包含中间件配置选项。
+	options struct {
+		// 任一返回 true 即进入维护状态的开关。
+		switches []Switch
+		// 计划内的维护时间窗口。
+		windows []Window
+		// 受维护影响的 Operation 前缀，为空表示所有 Operation。
+		operations []string
+		// 不受维护影响的 Operation 前缀。
+		allow []string
+		// 开关触发维护时返回的重试间隔。
+		retryAfter time.Duration
+		// 获取当前时间的函数。
+		now func() time.Time
+	}
+)
+
+// WithSwitch 添加维护开关。
+//
+// 参数：
+//   - sw Switch：维护开关；传入 nil 时忽略。
+//
+// 返回值：
+//   - Option：中间件配置选项。
+//
+// 多次调用时任一开关返回 true 即进入维护状态。
+func WithSwitch(sw Switch) Option {
+	return func(o *options) {
+		if nil != sw {
+			o.switches = append(o.switches, sw)
+		}
+	}
+}
+
+// WithFlag 使用功能开关作为维护开关。
+//
+// 参数：
+//   - client *featureflag.Client：功能开关客户端；为 nil 时忽略。
+//   - flag featureflag.BoolFlag：维护开关的定义，取值为 true 时进入维护状态。
+//
+// 返回值：
+//   - Option：中间件配置选项。
+//
+// 评估时 EvalContext 的 Attributes 包含 AttributeOperation，可通过开关规则只关闭部分接口；
+// 配合 featureflag.NewKratosProvider 使用时，配置中心的变更会在下一个请求生效，无需重启服务。
+func WithFlag(client *kitfeatureflag.Client, flag kitfeatureflag.BoolFlag) Option {
+	if nil == client {
+		return func(*options) {}
+	}
+	return WithSwitch(func(_ context.Context, operation string) bool {
+		return client.Bool(flag, kitfeatureflag.EvalContext{
+			Attributes: map[string]string{AttributeOperation: operation},
+		})
+	})
+}
+
+// WithWindows 添加计划内的维护时间窗口。
+//
+// 参数：
+//   - windows ...Window：维护时间窗口，多次调用时追加；End 不晚于 Start 的窗口被忽略。
+//
+// 返回值：
+//   - Option：中间件配置选项。
+//
+// 当前时间落在任一窗口内时进入维护状态，Retry-After 取命中窗口中最晚的 End 距当前时间的剩余时长。
+func WithWindows(windows ...Window) Option {
+	return func(o *options) {
+		for _, w := range windows {
+			if w.End.After(w.Start) {
+				o.windows = append(o.windows, w)
+			}
+		}
+	}
+}
+
+// WithOperations 限定受维护影响的 Operation 前缀。
+//
+// 参数：
+//   - prefixes ...string：Operation 前缀，例如 `/order.v1.` 或完整的 `/order.v1.Order/Create`，多次调用时追加。
+//
+// 返回值：
+//   - Option：中间件配置选项。
+//
+// 若未设置该选项，维护状态影响所有 Operation（WithAllowOperations 放行的除外）。
+func WithOperations(prefixes ...string) Option {
+	return func(o *options) {
+		o.operations = append(o.operations, prefixes...)
+	}
+}
+
+// WithAllowOperations 配置始终放行的 Operation 前缀，例如健康检查与管理接口。
+//
+// 参数：
+//   - prefixes ...string：Operation 前缀，多次调用时追加。
+//
+// 返回值：
+//   - Option：中间件配置选项。
+//
+// 放行名单优先于 WithOperations，命中时不评估任何开关。
+func WithAllowOperations(prefixes ...string) Option {
+	return func(o *options) {
+		o.allow = append(o.allow, prefixes...)
+	}
+}
+
+// WithRetryAfter 配置开关触发维护时返回的重试间隔。
+//
+// 参数：
+//   - d time.Duration：重试间隔，按秒向上取整；小于等于 0 表示不设置 Retry-After 响应头。
+//
+// 返回值：
+//   - Option：中间件配置选项。
+//
+// 若未设置该选项，重试间隔为 1 分钟。时间窗口触发的维护使用窗口的剩余时长，不受该选项影响。
+func WithRetryAfter(d time.Duration) Option {
+	return func(o *options) {
+		o.retryAfter = d
+	}
+}
+
+// Server 创建用于服务端请求的维护模式中间件。
+//
+// 参数：
+//   - opts ...Option：中间件配置选项。
+//
+// 返回值：
+//   - middleware.Middleware：维护期间拒绝匹配请求的中间件。
+//
+// 中间件从 transport.ServerContext 读取 Operation，命中 WithAllowOperations 或未命中 WithOperations 时
+// 直接调用后续处理器；否则依次检查维护时间窗口与开关，处于维护状态时不调用处理器，设置 Retry-After
+// 响应头，累加 MetricRejectedTotal 并返回携带元数据的 ErrMaintenance。上下文中不存在服务端 transport
+// 时直接调用后续处理器。中间件应放在靠前的位置，使维护期间的请求不会触发认证与业务逻辑。
+func Server(opts ...Option) middleware.Middleware {
+	o := &options{
+		retryAfter: defaultRetryAfter,
+		now:        time.Now,
+	}
+	for _, opt := range opts {
+		if nil == opt {
+			continue
+		}
+		opt(o)
+	}
+
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			tr, ok := transport.FromServerContext(ctx)
+			if !ok {
+				return handler(ctx, req)
+			}
+
+			operation := tr.Operation()
+			if hasPrefix(o.allow, operation) || (len(o.operations) > 0 && !hasPrefix(o.operations, operation)) {
+				return handler(ctx, req)
+			}
+
+			retryAfter, active := o.check(ctx, operation)
+			if !active {
+				return handler(ctx, req)
+			}
+
+			MetricRejectedTotal.WithLabelValues(operation).Inc()
+			metadata := map[string]string{"operation": operation}
+			if retryAfter > 0 {
+				seconds := strconv.FormatInt(int64((retryAfter+time.Second-1)/time.Second), 10)
+				metadata["retry_after"] = seconds
+				if header := tr.ReplyHeader(); nil != header {
+					header.Set(headerRetryAfter, seconds)
+				}
+			}
+			return nil, ErrMaintenance.WithMetadata(metadata)
+		}
+	}
+}
+
+// check 判断请求是否处于维护状态。
+//
+// 参数：
+//   - ctx context.Context：当前请求上下文。
+//   - operation string：请求的 Operation。
+//
+// 返回值：
+//   - time.Duration：重试间隔；小于等于 0 表示不设置 Retry-After。
+//   - bool：处于维护状态时返回 true。
+func (o *options) check(ctx context.Context, operation string) (time.Duration, bool) {
+	if len(o.windows) > 0 {
+		now := o.now()
+		var end time.Time
+		for _, w := range o.windows {
+			if !now.Before(w.Start) && now.Before(w.End) && w.End.After(end) {
+				end = w.End
+			}
+		}
+		if !end.IsZero() {
+			return end.Sub(now), true
+		}
+	}
+
+	for _, sw := range o.switches {
+		if sw(ctx, operation) {
+			return o.retryAfter, true
+		}
+	}
+	return 0, false
+}
+
+// hasPrefix 判断 operation 是否以任一前缀开头。
+//
+// 参数：
+//   - prefixes []string：Operation 前缀。
+//   - operation string：请求的 Operation。
+//
+// 返回值：
+//   - bool：命中任一前缀时返回 true。
+func hasPrefix(prefixes []string, operation string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(operation, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,268 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package maintenance
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	kitfeatureflag "github.com/fsyyft-go/kit/config/featureflag"
+)
+
+// headerCarrier 使用 http.Header 实现 transport.Header。
+type headerCarrier http.Header
+
+// Get 返回指定键的第一个值。
+func (hc headerCarrier) Get(key string) string { return http.Header(hc).Get(key) }
+
+// Set 设置指定键的值。
+func (hc headerCarrier) Set(key string, value string) { http.Header(hc).Set(key, value) }
+
+// Add 追加指定键的值。
+func (hc headerCarrier) Add(key string, value string) { http.Header(hc).Add(key, value) }
+
+// Keys 返回所有键。
+func (hc headerCarrier) Keys() []string {
+	keys := make([]string, 0, len(hc))
+	for k := range hc {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// Values 返回指定键的所有值。
+func (hc headerCarrier) Values(key string) []string { return http.Header(hc).Values(key) }
+
+// mockTransport 实现 transport.Transporter，提供测试所需的 Operation 与响应头。
+type mockTransport struct {
+	transport.Transporter
+	operation string
+	reply     http.Header
+}
+
+// Operation 返回预设的 Operation。
+func (m *mockTransport) Operation() string {
+	return m.operation
+}
+
+// ReplyHeader 返回响应头。
+func (m *mockTransport) ReplyHeader() transport.Header {
+	return headerCarrier(m.reply)
+}
+
+// newContext 创建携带服务端 transport 的上下文。
+//
+// 参数：
+//   - operation string：请求的 Operation。
+//
+// 返回值：
+//   - context.Context：携带 transport 的上下文。
+//   - http.Header：中间件写入的响应头。
+func newContext(operation string) (context.Context, http.Header) {
+	reply := http.Header{}
+	return transport.NewServerContext(context.Background(), &mockTransport{operation: operation, reply: reply}), reply
+}
+
+// okHandler 是始终成功的处理器。
+func okHandler(context.Context, interface{}) (interface{}, error) {
+	return "ok", nil
+}
+
+// mapProvider 是基于固定映射的 featureflag.Provider 替身。
+type mapProvider map[string]kitfeatureflag.Spec
+
+// Lookup 返回指定键的功能开关配置。
+func (p mapProvider) Lookup(key string) (kitfeatureflag.Spec, bool) {
+	spec, ok := p[key]
+	return spec, ok
+}
+
+// Subscribe 不支持订阅，返回空的取消函数。
+func (p mapProvider) Subscribe(func(keys []string)) func() {
+	return func() {}
+}
+
+// withClock 返回固定当前时间的配置选项。
+//
+// 参数：
+//   - now time.Time：固定的当前时间。
+//
+// 返回值：
+//   - Option：中间件配置选项。
+func withClock(now time.Time) Option {
+	return func(o *options) {
+		o.now = func() time.Time { return now }
+	}
+}
+
+// TestServer 验证开关、时间窗口、Operation 匹配与放行名单。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestServer(t *testing.T) {
+	giveNow := time.Date(2025, 6, 18, 2, 0, 0, 0, time.UTC)
+	on := func(context.Context, string) bool { return true }
+	off := func(context.Context, string) bool { return false }
+	flag := kitfeatureflag.Bool("maintenance", false)
+	flags := kitfeatureflag.NewClient(mapProvider{
+		"maintenance": {
+			Value: false,
+			Rules: []kitfeatureflag.Rule{{Attribute: AttributeOperation, Values: []string{"/order.v1.Order/Create"}, Value: true}},
+		},
+	})
+
+	tests := []struct {
+		name           string
+		description    string
+		opts           []Option
+		operation      string
+		wantRetryAfter string
+		wantRejected   bool
+	}{
+		{
+			name:        "success/no-switch",
+			description: "验证未配置开关与窗口时放行所有请求。",
+			operation:   "/order.v1.Order/Create",
+		},
+		{
+			name:        "success/switch-off",
+			description: "验证开关均为 false 时放行。",
+			opts:        []Option{WithSwitch(off), WithSwitch(nil)},
+			operation:   "/order.v1.Order/Create",
+		},
+		{
+			name:           "error/switch-on",
+			description:    "验证任一开关为 true 时返回默认 60 秒的 Retry-After。",
+			opts:           []Option{WithSwitch(off), WithSwitch(on)},
+			operation:      "/order.v1.Order/Create",
+			wantRetryAfter: "60",
+			wantRejected:   true,
+		},
+		{
+			name:           "error/retry-after-round-up",
+			description:    "验证重试间隔按秒向上取整。",
+			opts:           []Option{WithSwitch(on), WithRetryAfter(1500 * time.Millisecond)},
+			operation:      "/order.v1.Order/Create",
+			wantRetryAfter: "2",
+			wantRejected:   true,
+		},
+		{
+			name:         "error/no-retry-after",
+			description:  "验证重试间隔小于等于 0 时不设置 Retry-After。",
+			opts:         []Option{WithSwitch(on), WithRetryAfter(0)},
+			operation:    "/order.v1.Order/Create",
+			wantRejected: true,
+		},
+		{
+			name:        "success/allow-list",
+			description: "验证放行名单中的健康检查与管理接口不受维护影响。",
+			opts:        []Option{WithSwitch(on), WithAllowOperations("/grpc.health.v1.Health/", "/admin.v1.")},
+			operation:   "/admin.v1.Admin/Resume",
+		},
+		{
+			name:        "success/unmatched-operation",
+			description: "验证配置 WithOperations 后未命中前缀的接口不受维护影响。",
+			opts:        []Option{WithSwitch(on), WithOperations("/order.v1.")},
+			operation:   "/user.v1.User/Get",
+		},
+		{
+			name:           "error/matched-operation",
+			description:    "验证命中 WithOperations 前缀的接口被拒绝。",
+			opts:           []Option{WithSwitch(on), WithOperations("/order.v1.")},
+			operation:      "/order.v1.Order/Create",
+			wantRetryAfter: "60",
+			wantRejected:   true,
+		},
+		{
+			name:           "error/flag-rule",
+			description:    "验证功能开关规则按 operation 属性只关闭指定接口。",
+			opts:           []Option{WithFlag(flags, flag)},
+			operation:      "/order.v1.Order/Create",
+			wantRetryAfter: "60",
+			wantRejected:   true,
+		},
+		{
+			name:        "success/flag-default",
+			description: "验证功能开关规则未命中时放行，nil 客户端被忽略。",
+			opts:        []Option{WithFlag(flags, flag), WithFlag(nil, flag)},
+			operation:   "/order.v1.Order/Get",
+		},
+		{
+			name:        "error/window",
+			description: "验证处于维护窗口时 Retry-After 取最晚结束窗口的剩余时长。",
+			opts: []Option{
+				withClock(giveNow),
+				WithWindows(
+					Window{Start: giveNow.Add(-time.Hour), End: giveNow.Add(10 * time.Minute)},
+					Window{Start: giveNow, End: giveNow.Add(30 * time.Minute)},
+					Window{Start: giveNow.Add(time.Hour), End: giveNow.Add(2 * time.Hour)},
+				),
+			},
+			operation:      "/order.v1.Order/Create",
+			wantRetryAfter: "1800",
+			wantRejected:   true,
+		},
+		{
+			name:        "success/window-ended",
+			description: "验证窗口不包含 End，End 不晚于 Start 的窗口被忽略。",
+			opts: []Option{
+				withClock(giveNow),
+				WithWindows(
+					Window{Start: giveNow.Add(-time.Hour), End: giveNow},
+					Window{Start: giveNow.Add(time.Hour), End: giveNow.Add(-time.Hour)},
+				),
+			},
+			operation: "/order.v1.Order/Create",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			before := testutil.ToFloat64(MetricRejectedTotal.WithLabelValues(tt.operation))
+			ctx, reply := newContext(tt.operation)
+			got, err := Server(tt.opts...)(okHandler)(ctx, nil)
+			if !tt.wantRejected {
+				require.NoError(t, err)
+				assert.Equal(t, "ok", got)
+				assert.Empty(t, reply.Get(headerRetryAfter))
+				return
+			}
+
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, ErrMaintenance))
+			se := errors.FromError(err)
+			assert.Equal(t, int32(503), se.Code)
+			wantMetadata := map[string]string{"operation": tt.operation}
+			if "" != tt.wantRetryAfter {
+				wantMetadata["retry_after"] = tt.wantRetryAfter
+			}
+			assert.Equal(t, wantMetadata, se.Metadata)
+			assert.Equal(t, tt.wantRetryAfter, reply.Get(headerRetryAfter))
+			assert.Equal(t, before+1, testutil.ToFloat64(MetricRejectedTotal.WithLabelValues(tt.operation)))
+		})
+	}
+}
+
+// TestServer_Passthrough 验证上下文中不存在 transport 时直接调用处理器。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestServer_Passthrough(t *testing.T) {
+	t.Log("验证上下文中不存在服务端 transport 时不评估开关。")
+
+	reply, err := Server(WithSwitch(func(context.Context, string) bool { return true }))(okHandler)(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, "ok", reply)
+}
@@ -0,0 +1,18 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+// Package payload 提供用于 Kratos 服务端的请求与响应负载大小中间件。
+//
+// Server 按 transport.ServerContext 中的 Operation 将请求和响应负载大小记录到
+// MetricRequestBytes 与 MetricResponseBytes 直方图。请求大小优先取 Content-Length
+// 请求头，缺失时使用 Sizer 计算已解码的请求对象；默认 Sizer 支持 proto.Message、
+// []byte 和 string，其它类型可通过 WithSizer 自定义。
+//
+// 配置 WithMaxRequestBytes 或 WithOperationMaxRequestBytes 后，超过上限的请求不会
+// 进入处理器，中间件返回 code 为 413、reason 为 PAYLOAD_TOO_LARGE 且携带 operation、
+// size 与 limit 元数据的 ErrPayloadTooLarge，并累加 MetricRejectedTotal。
+// 中间件运行时请求体已由传输层读取和解码，限制请求体读取本身仍需在传输层配置。
+//
+// 本包的指标均不会自动注册，需要调用方通过 prometheus.MustRegister 注册到所用的 Registerer。
+package payload
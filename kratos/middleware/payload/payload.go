@@ -0,0 +1,250 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package payload
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// namespace 定义 Prometheus 指标命名空间。
+	namespace = "kit_kratos"
+	// subsystem 定义 Prometheus 指标子系统名称。
+	subsystem = "middleware"
+
+	// headerContentLength 是读取请求体大小的请求头。
+	headerContentLength = "Content-Length"
+)
+
+var (
+	// ErrPayloadTooLarge 表示请求负载超过了配置的大小上限。
+	//
+	// 中间件返回的错误会在元数据中携带 operation、size 和 limit（均为十进制字符串），调用方通常按
+	// 413 Payload Too Large 处理，并可通过 errors.Is 或 reason `PAYLOAD_TOO_LARGE` 识别。
+	ErrPayloadTooLarge = errors.New(413, "PAYLOAD_TOO_LARGE", "Request payload too large")
+
+	// MetricRequestBytes 记录请求负载的大小。
+	//
+	// 标签：
+	//   - operation：请求的 Operation；上下文中不存在服务端 transport 时为空字符串。
+	MetricRequestBytes = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "request_size_bytes",
+		Help:      "kratos request payload size in bytes.",
+		Buckets:   prometheus.ExponentialBuckets(64, 4, 10),
+	}, []string{"operation"})
+
+	// MetricResponseBytes 记录响应负载的大小。
+	//
+	// 标签：
+	//   - operation：请求的 Operation；上下文中不存在服务端 transport 时为空字符串。
+	MetricResponseBytes = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "response_size_bytes",
+		Help:      "kratos response payload size in bytes.",
+		Buckets:   prometheus.ExponentialBuckets(64, 4, 10),
+	}, []string{"operation"})
+
+	// MetricRejectedTotal 记录因负载过大被拒绝的请求次数。
+	//
+	// 标签：
+	//   - operation：被拒绝请求的 Operation；上下文中不存在服务端 transport 时为空字符串。
+	MetricRejectedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "payload_rejected_total",
+		Help:      "kratos request payload rejected total.",
+	}, []string{"operation"})
+)
+
+type (
+	// Sizer 计算请求或响应对象的负载大小。
+	//
+	// 参数：
+	//   - v interface{}：请求或响应对象。
+	//
+	// 返回值：
+	//   - int：负载字节数；无法计算时返回负数，此时不记录指标也不做限制判断。
+	Sizer func(v interface{}) int
+
+	// Option 配置 Server 返回的负载中间件。
+	//
+	// Option 通常由 WithMaxRequestBytes、WithOperationMaxRequestBytes 或 WithSizer 返回。
+	Option func(*options)
+
+	// options 包含中间件配置选项。
+	options struct {
+		// 未单独配置的 Operation 使用的请求负载上限。
+		limit int64
+		// 按 Operation 单独配置的请求负载上限。
+		operations map[string]int64
+		// 计算请求和响应对象大小的函数。
+		sizer Sizer
+	}
+)
+
+// WithMaxRequestBytes 配置未单独设置上限的 Operation 使用的请求负载上限。
+//
+// 参数：
+//   - limit int64：请求负载上限，单位为字节；小于等于 0 表示不限制。
+//
+// 返回值：
+//   - Option：中间件配置选项。
+//
+// 若未设置该选项，默认不限制请求负载大小，仅记录指标。
+func WithMaxRequestBytes(limit int64) Option {
+	return func(o *options) {
+		o.limit = limit
+	}
+}
+
+// WithOperationMaxRequestBytes 为指定 Operation 单独配置请求负载上限。
+//
+// 参数：
+//   - operation string：Kratos 的 Operation，例如 `/upload.v1.Upload/Put`。
+//   - limit int64：该 Operation 的请求负载上限；小于等于 0 表示该 Operation 不限制。
+//
+// 返回值：
+//   - Option：中间件配置选项。
+//
+// 对同一 Operation 多次设置时，后设置的值生效。
+func WithOperationMaxRequestBytes(operation string, limit int64) Option {
+	return func(o *options) {
+		o.operations[operation] = limit
+	}
+}
+
+// WithSizer 配置计算请求和响应对象大小的函数。
+//
+// 参数：
+//   - sizer Sizer：大小计算函数；传入 nil 时保留默认实现。
+//
+// 返回值：
+//   - Option：中间件配置选项。
+//
+// 默认实现对 proto.Message 使用 proto.Size，对 []byte 和 string 使用长度，其它类型视为无法计算。
+func WithSizer(sizer Sizer) Option {
+	return func(o *options) {
+		if nil != sizer {
+			o.sizer = sizer
+		}
+	}
+}
+
+// Server 创建用于服务端请求的负载大小中间件。
+//
+// 参数：
+//   - opts ...Option：中间件配置选项。
+//
+// 返回值：
+//   - middleware.Middleware：记录请求、响应负载大小并按需拒绝过大请求的中间件。
+//
+// 请求大小优先取服务端 transport 请求头中的 Content-Length，缺失或无效时（例如 gRPC、分块传输）
+// 使用 Sizer 计算已解码的请求对象；响应大小使用 Sizer 计算处理器返回的对象，处理器返回错误时不记录。
+// 请求大小超过 Operation 对应上限时，中间件不调用处理器，累加 MetricRejectedTotal 并返回携带
+// 元数据的 ErrPayloadTooLarge。
+func Server(opts ...Option) middleware.Middleware {
+	o := &options{
+		operations: make(map[string]int64),
+		sizer:      defaultSizer,
+	}
+	for _, opt := range opts {
+		if nil == opt {
+			continue
+		}
+		opt(o)
+	}
+
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			var operation string
+			size := int64(-1)
+			if tr, ok := transport.FromServerContext(ctx); ok {
+				operation = tr.Operation()
+				size = contentLength(tr)
+			}
+			if size < 0 {
+				size = int64(o.sizer(req))
+			}
+
+			if size >= 0 {
+				MetricRequestBytes.WithLabelValues(operation).Observe(float64(size))
+
+				limit, ok := o.operations[operation]
+				if !ok {
+					limit = o.limit
+				}
+				if limit > 0 && size > limit {
+					MetricRejectedTotal.WithLabelValues(operation).Inc()
+					return nil, ErrPayloadTooLarge.WithMetadata(map[string]string{
+						"operation": operation,
+						"size":      strconv.FormatInt(size, 10),
+						"limit":     strconv.FormatInt(limit, 10),
+					})
+				}
+			}
+
+			reply, err := handler(ctx, req)
+			if nil == err {
+				if n := o.sizer(reply); n >= 0 {
+					MetricResponseBytes.WithLabelValues(operation).Observe(float64(n))
+				}
+			}
+			return reply, err
+		}
+	}
+}
+
+// contentLength 读取请求头中的 Content-Length。
+//
+// 参数：
+//   - tr transport.Transporter：服务端 transport。
+//
+// 返回值：
+//   - int64：请求体字节数；请求头缺失或无效时返回 -1。
+func contentLength(tr transport.Transporter) int64 {
+	header := tr.RequestHeader()
+	if nil == header {
+		return -1
+	}
+	value := header.Get(headerContentLength)
+	if "" == value {
+		return -1
+	}
+	n, err := strconv.ParseInt(value, 10, 64)
+	if nil != err || n < 0 {
+		return -1
+	}
+	return n
+}
+
+// defaultSizer 是未配置 WithSizer 时使用的大小计算函数。
+//
+// 参数：
+//   - v interface{}：请求或响应对象。
+//
+// 返回值：
+//   - int：proto.Message 的编码长度、[]byte 或 string 的长度；其它类型返回 -1。
+func defaultSizer(v interface{}) int {
+	switch t := v.(type) {
+	case proto.Message:
+		return proto.Size(t)
+	case []byte:
+		return len(t)
+	case string:
+		return len(t)
+	default:
+		return -1
+	}
+}
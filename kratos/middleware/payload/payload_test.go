@@ -0,0 +1,251 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package payload
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// headerCarrier 使用 http.Header 实现 transport.Header。
+type headerCarrier http.Header
+
+// Get 返回指定键的第一个值。
+func (hc headerCarrier) Get(key string) string { return http.Header(hc).Get(key) }
+
+// Set 设置指定键的值。
+func (hc headerCarrier) Set(key string, value string) { http.Header(hc).Set(key, value) }
+
+// Add 追加指定键的值。
+func (hc headerCarrier) Add(key string, value string) { http.Header(hc).Add(key, value) }
+
+// Keys 返回所有键。
+func (hc headerCarrier) Keys() []string {
+	keys := make([]string, 0, len(hc))
+	for k := range hc {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// Values 返回指定键的所有值。
+func (hc headerCarrier) Values(key string) []string { return http.Header(hc).Values(key) }
+
+// mockTransport 实现 transport.Transporter，仅提供测试所需的 Operation 和请求头。
+type mockTransport struct {
+	transport.Transporter
+	operation string
+	header    headerCarrier
+}
+
+// Operation 返回预设的 Operation。
+func (m *mockTransport) Operation() string {
+	return m.operation
+}
+
+// RequestHeader 返回预设的请求头。
+func (m *mockTransport) RequestHeader() transport.Header {
+	if nil == m.header {
+		return nil
+	}
+	return m.header
+}
+
+// TestServer 验证负载大小的计算、限制判断与指标记录。
+func TestServer(t *testing.T) {
+	message := wrapperspb.String("fsyyft-go kit payload")
+	messageSize := proto.Size(message)
+
+	tests := []struct {
+		name          string
+		description   string
+		operation     string
+		contentLength string
+		opts          []Option
+		req           interface{}
+		reply         interface{}
+		handlerErr    error
+		wantRejected  bool
+		wantSize      string
+		wantRequest   bool
+		wantResponse  bool
+	}{
+		{
+			name:          "success/content-length",
+			description:   "验证优先使用 Content-Length 作为请求大小，并记录 proto 响应大小。",
+			operation:     "/payload.v1.Svc/ContentLength",
+			contentLength: "1024",
+			opts:          []Option{WithMaxRequestBytes(2048)},
+			req:           message,
+			reply:         message,
+			wantRequest:   true,
+			wantResponse:  true,
+		},
+		{
+			name:         "success/proto-sizer",
+			description:  "验证缺少 Content-Length 时使用 proto.Size 计算请求大小。",
+			operation:    "/payload.v1.Svc/Proto",
+			opts:         []Option{WithMaxRequestBytes(int64(messageSize))},
+			req:          message,
+			reply:        "ok",
+			wantRequest:  true,
+			wantResponse: true,
+		},
+		{
+			name:          "error/default-limit",
+			description:   "验证超过默认上限时拒绝请求并返回携带元数据的 413 错误。",
+			operation:     "/payload.v1.Svc/Default",
+			contentLength: "4096",
+			opts:          []Option{WithMaxRequestBytes(1024)},
+			wantRejected:  true,
+			wantSize:      "4096",
+			wantRequest:   true,
+		},
+		{
+			name:        "error/operation-limit",
+			description: "验证单操作上限覆盖默认上限。",
+			operation:   "/payload.v1.Svc/Small",
+			opts: []Option{
+				WithMaxRequestBytes(1 << 20),
+				WithOperationMaxRequestBytes("/payload.v1.Svc/Small", 4),
+			},
+			req:          []byte("12345"),
+			wantRejected: true,
+			wantSize:     "5",
+			wantRequest:  true,
+		},
+		{
+			name:          "boundary/operation-unlimited",
+			description:   "验证单操作上限小于等于 0 时不限制该 Operation。",
+			operation:     "/payload.v1.Svc/Upload",
+			contentLength: "1048576",
+			opts: []Option{
+				WithMaxRequestBytes(1),
+				WithOperationMaxRequestBytes("/payload.v1.Svc/Upload", 0),
+			},
+			reply:        []byte("ok"),
+			wantRequest:  true,
+			wantResponse: true,
+		},
+		{
+			name:          "boundary/unknown-size",
+			description:   "验证 Content-Length 无效且对象无法计算大小时不记录指标也不拒绝。",
+			operation:     "/payload.v1.Svc/Unknown",
+			contentLength: "invalid",
+			opts:          []Option{WithMaxRequestBytes(1)},
+			req:           struct{}{},
+			reply:         struct{}{},
+		},
+		{
+			name:         "boundary/custom-sizer",
+			description:  "验证自定义 Sizer 生效，nil Sizer 被忽略。",
+			operation:    "/payload.v1.Svc/Custom",
+			opts:         []Option{WithSizer(func(interface{}) int { return 100 }), WithSizer(nil), WithMaxRequestBytes(99)},
+			req:          struct{}{},
+			wantRejected: true,
+			wantSize:     "100",
+			wantRequest:  true,
+		},
+		{
+			name:        "error/handler-error",
+			description: "验证处理器返回错误时原样透传且不记录响应大小。",
+			operation:   "/payload.v1.Svc/Fail",
+			req:         "request",
+			reply:       "ignored",
+			handlerErr:  errors.BadRequest("BAD", "bad"),
+			wantRequest: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			tr := &mockTransport{operation: tt.operation}
+			if "" != tt.contentLength {
+				tr.header = headerCarrier{}
+				tr.header.Set("Content-Length", tt.contentLength)
+			}
+			ctx := transport.NewServerContext(context.Background(), tr)
+
+			// 指标为包级变量，按调用前后的差值断言，重复运行测试时不受之前记录的影响。
+			requestBefore := histogramCount(t, MetricRequestBytes.WithLabelValues(tt.operation))
+			responseBefore := histogramCount(t, MetricResponseBytes.WithLabelValues(tt.operation))
+			rejectedBefore := testutil.ToFloat64(MetricRejectedTotal.WithLabelValues(tt.operation))
+
+			called := false
+			reply, err := Server(tt.opts...)(func(ctx context.Context, req interface{}) (interface{}, error) {
+				called = true
+				return tt.reply, tt.handlerErr
+			})(ctx, tt.req)
+
+			assert.Equal(t, boolToFloat(tt.wantRequest), histogramCount(t, MetricRequestBytes.WithLabelValues(tt.operation))-requestBefore)
+			assert.Equal(t, boolToFloat(tt.wantResponse), histogramCount(t, MetricResponseBytes.WithLabelValues(tt.operation))-responseBefore)
+			assert.Equal(t, boolToFloat(tt.wantRejected), testutil.ToFloat64(MetricRejectedTotal.WithLabelValues(tt.operation))-rejectedBefore)
+
+			switch {
+			case tt.wantRejected:
+				require.Error(t, err)
+				assert.False(t, called)
+				assert.Nil(t, reply)
+				assert.True(t, errors.Is(err, ErrPayloadTooLarge))
+				assert.Equal(t, 413, errors.Code(err))
+				e := errors.FromError(err)
+				assert.Equal(t, tt.operation, e.Metadata["operation"])
+				assert.Equal(t, tt.wantSize, e.Metadata["size"])
+				assert.NotEmpty(t, e.Metadata["limit"])
+			case nil != tt.handlerErr:
+				assert.True(t, called)
+				assert.True(t, errors.Is(err, tt.handlerErr))
+			default:
+				require.NoError(t, err)
+				assert.True(t, called)
+				assert.Equal(t, tt.reply, reply)
+			}
+		})
+	}
+}
+
+// TestServer_NoTransport 验证上下文中不存在服务端 transport 时 Operation 视为空字符串并使用 Sizer。
+func TestServer_NoTransport(t *testing.T) {
+	_, err := Server(WithOperationMaxRequestBytes("", 2))(func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, nil
+	})(context.Background(), "abc")
+	require.Error(t, err)
+	assert.Equal(t, "", errors.FromError(err).Metadata["operation"])
+	assert.Equal(t, "3", errors.FromError(err).Metadata["size"])
+}
+
+// histogramCount 返回直方图已记录的样本数。
+func histogramCount(t *testing.T, observer interface{}) float64 {
+	t.Helper()
+
+	collector, ok := observer.(interface {
+		Write(*dto.Metric) error
+	})
+	require.True(t, ok)
+	var m dto.Metric
+	require.NoError(t, collector.Write(&m))
+	return float64(m.GetHistogram().GetSampleCount())
+}
+
+// boolToFloat 将布尔值转换为 0 或 1。
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,17 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+// Package timeout 提供用于 Kratos 服务端的处理器超时中间件。
+//
+// Server 使用 context.WithTimeout 包装后续处理器，超时时长按 transport.ServerContext
+// 中的 Operation 选择：优先使用 WithOperationTimeout 或 WithOperationTimeouts 设置的
+// 单操作超时，未命中时使用 WithDefault 设置的默认超时。传输层超时只能对所有接口使用
+// 同一个值，而导出、报表等接口往往需要更长的处理时间。
+//
+// 处理器在超时后返回错误时，中间件将其转换为 code 为 504、reason 为 GATEWAY_TIMEOUT
+// 的 ErrTimeout，并累加 MetricTimeoutTotal 指标；处理器在超时后仍成功返回时保留其结果。
+// 中间件不会在超时后强行中断处理器，处理器需要自行响应 ctx 的取消。
+//
+// MetricTimeoutTotal 不会自动注册，需要调用方通过 prometheus.MustRegister 注册到所用的 Registerer。
+package timeout
@@ -0,0 +1,159 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package timeout
+
+import (
+	"context"
+	stderrors "errors"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// defaultTimeout 是未配置 WithDefault 时使用的默认超时时长。
+	defaultTimeout = 30 * time.Second
+
+	// namespace 定义 Prometheus 指标命名空间。
+	namespace = "kit_kratos"
+	// subsystem 定义 Prometheus 指标子系统名称。
+	subsystem = "middleware"
+)
+
+var (
+	// ErrTimeout 表示处理器在超时时长内未能完成。
+	//
+	// 处理器超时后返回错误时，Server 返回携带原始错误作为 cause 的 ErrTimeout；调用方通常按
+	// 504 Gateway Timeout 处理，并可通过 errors.Is 或 reason `GATEWAY_TIMEOUT` 识别。
+	ErrTimeout = errors.New(504, "GATEWAY_TIMEOUT", "Request handler timed out")
+
+	// MetricTimeoutTotal 记录处理器超时的次数。
+	//
+	// 标签：
+	//   - operation：超时请求的 Operation；上下文中不存在服务端 transport 时为空字符串。
+	MetricTimeoutTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "timeout_total",
+		Help:      "kratos handler timeout total.",
+	}, []string{"operation"})
+)
+
+type (
+	// Option 配置 Server 返回的超时中间件。
+	//
+	// Option 通常由 WithDefault、WithOperationTimeout 或 WithOperationTimeouts 返回。
+	Option func(*options)
+
+	// options 包含中间件配置选项。
+	options struct {
+		// 未单独配置的 Operation 使用的超时时长。
+		timeout time.Duration
+		// 按 Operation 单独配置的超时时长。
+		operations map[string]time.Duration
+	}
+)
+
+// WithDefault 配置未单独设置超时的 Operation 使用的默认超时时长。
+//
+// 参数：
+//   - timeout time.Duration：默认超时时长；小于等于 0 表示默认不设置超时。
+//
+// 返回值：
+//   - Option：中间件配置选项。
+//
+// 若未设置该选项，默认超时时长为 30 秒。
+func WithDefault(timeout time.Duration) Option {
+	return func(o *options) {
+		o.timeout = timeout
+	}
+}
+
+// WithOperationTimeout 为指定 Operation 单独配置超时时长。
+//
+// 参数：
+//   - operation string：Kratos 的 Operation，例如 `/helloworld.v1.Greeter/SayHello`。
+//   - timeout time.Duration：该 Operation 的超时时长；小于等于 0 表示该 Operation 不设置超时。
+//
+// 返回值：
+//   - Option：中间件配置选项。
+//
+// 对同一 Operation 多次设置时，后设置的值生效。
+func WithOperationTimeout(operation string, timeout time.Duration) Option {
+	return func(o *options) {
+		o.operations[operation] = timeout
+	}
+}
+
+// WithOperationTimeouts 批量为多个 Operation 单独配置超时时长。
+//
+// 参数：
+//   - timeouts map[string]time.Duration：Operation 到超时时长的映射，取值语义同 WithOperationTimeout。
+//
+// 返回值：
+//   - Option：中间件配置选项。
+//
+// 映射会在应用选项时复制，之后修改 timeouts 不影响已创建的中间件。
+func WithOperationTimeouts(timeouts map[string]time.Duration) Option {
+	return func(o *options) {
+		for operation, timeout := range timeouts {
+			o.operations[operation] = timeout
+		}
+	}
+}
+
+// Server 创建用于服务端请求的超时中间件。
+//
+// 参数：
+//   - opts ...Option：中间件配置选项。
+//
+// 返回值：
+//   - middleware.Middleware：使用带超时的上下文调用后续处理器的中间件。
+//
+// 中间件从 transport.ServerContext 读取 Operation 并选择超时时长；上下文中不存在服务端 transport
+// 时使用默认超时。若请求上下文已有更早的截止时间，以更早者为准。处理器返回错误且上下文已超时时，
+// 中间件返回 ErrTimeout 并累加 MetricTimeoutTotal；处理器返回的其它错误和成功结果原样透传。
+func Server(opts ...Option) middleware.Middleware {
+	o := &options{
+		timeout:    defaultTimeout,
+		operations: make(map[string]time.Duration),
+	}
+	for _, opt := range opts {
+		if nil == opt {
+			continue
+		}
+		opt(o)
+	}
+
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			var operation string
+			if tr, ok := transport.FromServerContext(ctx); ok {
+				operation = tr.Operation()
+			}
+
+			timeout, ok := o.operations[operation]
+			if !ok {
+				timeout = o.timeout
+			}
+			if timeout <= 0 {
+				return handler(ctx, req)
+			}
+
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+
+			reply, err := handler(ctx, req)
+			if nil != err && (stderrors.Is(ctx.Err(), context.DeadlineExceeded) || stderrors.Is(err, context.DeadlineExceeded)) {
+				MetricTimeoutTotal.WithLabelValues(operation).Inc()
+				return nil, ErrTimeout.WithCause(err)
+			}
+			return reply, err
+		}
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package timeout
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockTransport 实现 transport.Transporter，仅提供测试所需的 Operation。
+type mockTransport struct {
+	transport.Transporter
+	operation string
+}
+
+// Operation 返回预设的 Operation。
+func (m *mockTransport) Operation() string {
+	return m.operation
+}
+
+// TestServer 验证超时选择、超时错误转换和指标记录。
+func TestServer(t *testing.T) {
+	// waitHandler 等待 d 或上下文结束，上下文结束时返回 ctx.Err()。
+	waitHandler := func(d time.Duration) func(ctx context.Context, req interface{}) (interface{}, error) {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(d):
+				return "ok", nil
+			}
+		}
+	}
+
+	tests := []struct {
+		name        string
+		description string
+		operation   string
+		opts        []Option
+		handler     func(ctx context.Context, req interface{}) (interface{}, error)
+		wantReply   interface{}
+		wantTimeout bool
+		wantErr     error
+	}{
+		{
+			name:        "success/within-default",
+			description: "验证处理器在默认超时内完成时原样返回结果。",
+			operation:   "/api.v1.Svc/Fast",
+			opts:        []Option{WithDefault(200 * time.Millisecond)},
+			handler:     waitHandler(time.Millisecond),
+			wantReply:   "ok",
+		},
+		{
+			name:        "error/default-timeout",
+			description: "验证超过默认超时时返回 ErrTimeout。",
+			operation:   "/api.v1.Svc/Slow",
+			opts:        []Option{WithDefault(20 * time.Millisecond)},
+			handler:     waitHandler(time.Second),
+			wantTimeout: true,
+		},
+		{
+			name:        "success/operation-override-longer",
+			description: "验证单操作超时覆盖更短的默认超时。",
+			operation:   "/api.v1.Svc/Export",
+			opts: []Option{
+				WithDefault(10 * time.Millisecond),
+				WithOperationTimeout("/api.v1.Svc/Export", 500*time.Millisecond),
+			},
+			handler:   waitHandler(50 * time.Millisecond),
+			wantReply: "ok",
+		},
+		{
+			name:        "error/operation-map-shorter",
+			description: "验证通过映射配置的更短超时生效。",
+			operation:   "/api.v1.Svc/Ping",
+			opts: []Option{
+				WithDefault(time.Second),
+				WithOperationTimeouts(map[string]time.Duration{"/api.v1.Svc/Ping": 20 * time.Millisecond}),
+			},
+			handler:     waitHandler(time.Second),
+			wantTimeout: true,
+		},
+		{
+			name:        "boundary/operation-disabled",
+			description: "验证单操作超时小于等于 0 时不设置截止时间。",
+			operation:   "/api.v1.Svc/Stream",
+			opts:        []Option{WithDefault(time.Millisecond), WithOperationTimeout("/api.v1.Svc/Stream", 0)},
+			handler: func(ctx context.Context, req interface{}) (interface{}, error) {
+				_, ok := ctx.Deadline()
+				return ok, nil
+			},
+			wantReply: false,
+		},
+		{
+			name:        "boundary/success-after-deadline",
+			description: "验证处理器忽略取消并在超时后成功返回时保留其结果。",
+			operation:   "/api.v1.Svc/Stubborn",
+			opts:        []Option{WithDefault(10 * time.Millisecond)},
+			handler: func(ctx context.Context, req interface{}) (interface{}, error) {
+				<-ctx.Done()
+				return "late", nil
+			},
+			wantReply: "late",
+		},
+		{
+			name:        "error/other-error-passthrough",
+			description: "验证未超时的业务错误原样透传。",
+			operation:   "/api.v1.Svc/Fail",
+			handler: func(ctx context.Context, req interface{}) (interface{}, error) {
+				return nil, errors.BadRequest("BAD", "bad")
+			},
+			wantErr: errors.BadRequest("BAD", "bad"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			ctx := transport.NewServerContext(context.Background(), &mockTransport{operation: tt.operation})
+			before := testutil.ToFloat64(MetricTimeoutTotal.WithLabelValues(tt.operation))
+
+			reply, err := Server(tt.opts...)(tt.handler)(ctx, nil)
+
+			after := testutil.ToFloat64(MetricTimeoutTotal.WithLabelValues(tt.operation))
+			switch {
+			case tt.wantTimeout:
+				require.Error(t, err)
+				assert.True(t, errors.Is(err, ErrTimeout))
+				assert.Equal(t, 504, errors.Code(err))
+				assert.True(t, stderrors.Is(err, context.DeadlineExceeded))
+				assert.Nil(t, reply)
+				assert.Equal(t, before+1, after)
+			case nil != tt.wantErr:
+				assert.True(t, errors.Is(err, tt.wantErr))
+				assert.Equal(t, before, after)
+			default:
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantReply, reply)
+				assert.Equal(t, before, after)
+			}
+		})
+	}
+}
+
+// TestServer_NoTransport 验证上下文中不存在服务端 transport 时 Operation 按空字符串匹配，未命中时使用默认超时。
+func TestServer_NoTransport(t *testing.T) {
+	handler := Server(WithOperationTimeout("", time.Hour), nil)(func(ctx context.Context, req interface{}) (interface{}, error) {
+		deadline, ok := ctx.Deadline()
+		require.True(t, ok)
+		return time.Until(deadline) > time.Minute, nil
+	})
+
+	reply, err := handler(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, true, reply)
+
+	reply, err = Server()(func(ctx context.Context, req interface{}) (interface{}, error) {
+		deadline, ok := ctx.Deadline()
+		return ok && time.Until(deadline) <= defaultTimeout, nil
+	})(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, true, reply)
+}
@@ -0,0 +1,14 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+// Package tracing 提供基于 OpenTelemetry 的链路追踪中间件。
+//
+// Server 按 Kratos middleware.Middleware 契约接入服务端链路，为每个请求创建以 Operation 命名的
+// 服务端 span；Gin 返回 gin.HandlerFunc，在 Gin 适配层的认证与路由级处理器之前创建 span。
+// 两者配合使用时，Server 沿用 Gin 创建的 span 并改用 Operation 命名，一个请求只产生一个服务端 span。
+//
+// 中间件通过传播器从请求头（如 traceparent）提取上游 span 上下文作为父 span，记录响应状态码与
+// Kratos 错误的 reason、code，并把 span 写入处理器收到的上下文，下游的 HTTP、SQL、Redis 等调用
+// 可以据此创建子 span。
+package tracing
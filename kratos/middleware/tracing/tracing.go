@@ -0,0 +1,249 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package tracing
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// instrumentationName 是创建 Tracer 时使用的插桩库名称。
+	instrumentationName = "github.com/fsyyft-go/kit/kratos/middleware/tracing"
+)
+
+var (
+	// AttributeOperation 是记录 Kratos Operation 的 span 属性键。
+	AttributeOperation = attribute.Key("kratos.operation")
+	// AttributeErrorReason 是记录 Kratos 错误 reason 的 span 属性键。
+	AttributeErrorReason = attribute.Key("kratos.error.reason")
+	// AttributeErrorCode 是记录 Kratos 错误 code 的 span 属性键。
+	AttributeErrorCode = attribute.Key("kratos.error.code")
+)
+
+type (
+	// Option 配置 Gin 与 Server 返回的追踪中间件。
+	Option func(*options)
+
+	// options 包含中间件配置选项。
+	options struct {
+		// 创建 Tracer 使用的 TracerProvider。
+		tracerProvider trace.TracerProvider
+		// 从请求头提取上游 span 上下文使用的传播器。
+		propagator propagation.TextMapPropagator
+	}
+
+	// ginSpanKey 是 Gin 创建的 span 在上下文中的键。
+	ginSpanKey struct{}
+)
+
+// WithTracerProvider 设置创建 span 使用的 TracerProvider。
+//
+// 参数：
+//   - provider trace.TracerProvider：TracerProvider；为 nil 时忽略。
+//
+// 返回值：
+//   - Option：中间件配置选项。
+//
+// 未设置时使用 otel.GetTracerProvider 返回的全局 TracerProvider。
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(o *options) {
+		if nil != provider {
+			o.tracerProvider = provider
+		}
+	}
+}
+
+// WithPropagator 设置从请求头提取上游 span 上下文使用的传播器。
+//
+// 参数：
+//   - propagator propagation.TextMapPropagator：传播器；为 nil 时忽略。
+//
+// 返回值：
+//   - Option：中间件配置选项。
+//
+// 未设置时使用 otel.GetTextMapPropagator 返回的全局传播器；全局传播器默认不做任何提取，
+// 通常应设置为 propagation.TraceContext 以识别 traceparent 请求头。
+func WithPropagator(propagator propagation.TextMapPropagator) Option {
+	return func(o *options) {
+		if nil != propagator {
+			o.propagator = propagator
+		}
+	}
+}
+
+// newOptions 应用配置选项。
+//
+// 参数：
+//   - opts []Option：中间件配置选项。
+//
+// 返回值：
+//   - *options：应用后的配置。
+func newOptions(opts []Option) *options {
+	o := &options{
+		tracerProvider: otel.GetTracerProvider(),
+		propagator:     otel.GetTextMapPropagator(),
+	}
+	for _, opt := range opts {
+		if nil == opt {
+			continue
+		}
+		opt(o)
+	}
+	return o
+}
+
+// Gin 创建用于 Gin 适配层的追踪中间件。
+//
+// 参数：
+//   - opts ...Option：中间件配置选项。
+//
+// 返回值：
+//   - gin.HandlerFunc：为每个请求创建服务端 span 的 Gin 处理器。
+//
+// 中间件从请求头提取上游 span 上下文，以其为父 span 创建服务端 span，并把 span 写入请求上下文，
+// 因此认证、路由级处理器以及 Kratos 处理链都处于同一个 span 中。span 名称初始为 `方法 路由`，
+// 请求进入 Kratos 处理链后由 Server 改为 Kratos Operation。请求结束时记录响应状态码，
+// 状态码大于等于 500 时把 span 状态置为 Error。应通过 Gin 的 Use 或 kratos/transport/http 的
+// WithGroup 挂载在其它处理器之前，并与 Server 配合使用。
+func Gin(opts ...Option) gin.HandlerFunc {
+	o := newOptions(opts)
+	tracer := o.tracerProvider.Tracer(instrumentationName)
+
+	return func(c *gin.Context) {
+		req := c.Request
+		ctx := o.propagator.Extract(req.Context(), propagation.HeaderCarrier(req.Header))
+
+		route := c.FullPath()
+		name := req.Method
+		if "" != route {
+			name += " " + route
+		}
+		attrs := []attribute.KeyValue{
+			attribute.String("http.request.method", req.Method),
+			attribute.String("url.path", req.URL.Path),
+			attribute.String("client.address", c.ClientIP()),
+		}
+		if "" != route {
+			attrs = append(attrs, attribute.String("http.route", route))
+		}
+		if ua := req.UserAgent(); "" != ua {
+			attrs = append(attrs, attribute.String("user_agent.original", ua))
+		}
+
+		ctx, span := tracer.Start(ctx, name,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(attrs...),
+		)
+		defer span.End()
+
+		c.Request = req.WithContext(context.WithValue(ctx, ginSpanKey{}, span))
+		c.Next()
+
+		status := c.Writer.Status()
+		span.SetAttributes(attribute.Int("http.response.status_code", status))
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+		for _, err := range c.Errors {
+			span.RecordError(err.Err)
+		}
+	}
+}
+
+// Server 创建用于服务端请求的追踪中间件。
+//
+// 参数：
+//   - opts ...Option：中间件配置选项；与 Gin 配合使用时应传入相同的配置。
+//
+// 返回值：
+//   - middleware.Middleware：在 span 上下文中调用后续处理器的中间件。
+//
+// 请求由 Gin 创建了 span 时，中间件沿用该 span，只把名称改为 Kratos Operation；否则从 transport 请求头
+// 提取上游 span 上下文，创建以 Operation 命名的服务端 span。处理器收到的上下文携带该 span，下游的 HTTP、
+// SQL、Redis 等调用可以据此创建子 span。处理器返回错误时记录错误以及 Kratos 错误的 reason 与 code，
+// code 大于等于 500 时把 span 状态置为 Error。上下文中不存在服务端 transport 时直接调用后续处理器。
+func Server(opts ...Option) middleware.Middleware {
+	o := newOptions(opts)
+	tracer := o.tracerProvider.Tracer(instrumentationName)
+
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			tr, ok := transport.FromServerContext(ctx)
+			if !ok {
+				return handler(ctx, req)
+			}
+			operation := tr.Operation()
+
+			span := ginSpan(ctx)
+			if nil != span {
+				span.SetName(operation)
+				span.SetAttributes(AttributeOperation.String(operation))
+			} else {
+				ctx = o.propagator.Extract(ctx, tr.RequestHeader())
+				ctx, span = tracer.Start(ctx, operation,
+					trace.WithSpanKind(trace.SpanKindServer),
+					trace.WithAttributes(
+						AttributeOperation.String(operation),
+						attribute.String("rpc.system", string(tr.Kind())),
+					),
+				)
+				defer span.End()
+			}
+
+			reply, err := handler(ctx, req)
+			if nil != err {
+				recordError(span, err)
+			}
+			return reply, err
+		}
+	}
+}
+
+// ginSpan 返回 Gin 为当前请求创建的 span。
+//
+// 参数：
+//   - ctx context.Context：请求上下文。
+//
+// 返回值：
+//   - trace.Span：上下文中的当前 span 由 Gin 创建时返回该 span，否则返回 nil。
+func ginSpan(ctx context.Context) trace.Span {
+	span, ok := ctx.Value(ginSpanKey{}).(trace.Span)
+	if !ok {
+		return nil
+	}
+	// 只有当前 span 仍是 Gin 创建的 span 时才沿用；span 实现不一定可比较，因此比较 span 上下文。
+	if !span.SpanContext().Equal(trace.SpanContextFromContext(ctx)) {
+		return nil
+	}
+	return span
+}
+
+// recordError 在 span 上记录处理器返回的错误。
+//
+// 参数：
+//   - span trace.Span：当前请求的 span。
+//   - err error：处理器返回的错误。
+func recordError(span trace.Span, err error) {
+	se := errors.FromError(err)
+	span.RecordError(err)
+	span.SetAttributes(
+		AttributeErrorReason.String(se.Reason),
+		AttributeErrorCode.Int(int(se.Code)),
+	)
+	if se.Code >= http.StatusInternalServerError {
+		span.SetStatus(codes.Error, se.Message)
+	}
+}
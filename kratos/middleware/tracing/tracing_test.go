@@ -0,0 +1,297 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package tracing
+
+import (
+	"context"
+	stderrors "errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// testTraceparent 是测试使用的上游 traceparent 请求头。
+	testTraceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+)
+
+// headerCarrier 基于 http.Header 实现 transport.Header。
+type headerCarrier http.Header
+
+// Get 返回键对应的第一个值。
+func (h headerCarrier) Get(key string) string { return http.Header(h).Get(key) }
+
+// Set 设置键对应的值。
+func (h headerCarrier) Set(key, value string) { http.Header(h).Set(key, value) }
+
+// Add 追加键对应的值。
+func (h headerCarrier) Add(key, value string) { http.Header(h).Add(key, value) }
+
+// Keys 返回全部键。
+func (h headerCarrier) Keys() []string {
+	keys := make([]string, 0, len(h))
+	for k := range h {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// Values 返回键对应的全部值。
+func (h headerCarrier) Values(key string) []string { return http.Header(h).Values(key) }
+
+// mockTransport 实现 transport.Transporter，仅提供测试所需的方法。
+type mockTransport struct {
+	transport.Transporter
+	operation string
+	header    headerCarrier
+}
+
+// Kind 返回 HTTP 传输类型。
+func (m *mockTransport) Kind() transport.Kind {
+	return transport.KindHTTP
+}
+
+// Operation 返回预设的 Operation。
+func (m *mockTransport) Operation() string {
+	return m.operation
+}
+
+// RequestHeader 返回预设的请求头。
+func (m *mockTransport) RequestHeader() transport.Header {
+	return m.header
+}
+
+// newTestProvider 创建记录已结束 span 的 TracerProvider。
+//
+// 参数：
+//   - t *testing.T：测试上下文。
+//
+// 返回值：
+//   - *sdktrace.TracerProvider：TracerProvider。
+//   - *tracetest.SpanRecorder：span 记录器。
+func newTestProvider(t *testing.T) (*sdktrace.TracerProvider, *tracetest.SpanRecorder) {
+	t.Helper()
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+	return provider, recorder
+}
+
+// attributeValue 返回 span 中指定属性的值。
+//
+// 参数：
+//   - span sdktrace.ReadOnlySpan：已结束的 span。
+//   - key attribute.Key：属性键。
+//
+// 返回值：
+//   - attribute.Value：属性值；不存在时返回零值。
+func attributeValue(span sdktrace.ReadOnlySpan, key attribute.Key) attribute.Value {
+	for _, kv := range span.Attributes() {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+// TestServer 验证 Kratos 中间件创建的 span 名称、父 span、状态以及上下文传播。
+func TestServer(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		traceparent string
+		err         error
+		wantCode    codes.Code
+		wantReason  string
+		wantRemote  bool
+	}{
+		{
+			name:        "success/root",
+			description: "验证没有上游 span 时创建以 Operation 命名的根 span。",
+			wantCode:    codes.Unset,
+		},
+		{
+			name:        "success/traceparent",
+			description: "验证存在 traceparent 请求头时以上游 span 为父 span。",
+			traceparent: testTraceparent,
+			wantCode:    codes.Unset,
+			wantRemote:  true,
+		},
+		{
+			name:        "error/client",
+			description: "验证 4xx 错误只记录错误与 reason，不把 span 状态置为 Error。",
+			err:         errors.BadRequest("INVALID_ARGUMENT", "bad request"),
+			wantCode:    codes.Unset,
+			wantReason:  "INVALID_ARGUMENT",
+		},
+		{
+			name:        "error/server",
+			description: "验证 5xx 错误与普通错误把 span 状态置为 Error。",
+			err:         stderrors.New("boom"),
+			wantCode:    codes.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			provider, recorder := newTestProvider(t)
+			m := Server(WithTracerProvider(provider), WithPropagator(propagation.TraceContext{}))
+
+			header := headerCarrier(http.Header{})
+			if "" != tt.traceparent {
+				header.Set("traceparent", tt.traceparent)
+			}
+			tr := &mockTransport{operation: "/api.v1.Greeter/SayHello", header: header}
+			ctx := transport.NewServerContext(context.Background(), tr)
+
+			var handlerSpan trace.SpanContext
+			_, err := m(func(ctx context.Context, req interface{}) (interface{}, error) {
+				handlerSpan = trace.SpanContextFromContext(ctx)
+				return "ok", tt.err
+			})(ctx, nil)
+			assert.Equal(t, tt.err, err)
+
+			spans := recorder.Ended()
+			require.Len(t, spans, 1)
+			span := spans[0]
+			assert.Equal(t, "/api.v1.Greeter/SayHello", span.Name())
+			assert.Equal(t, trace.SpanKindServer, span.SpanKind())
+			assert.Equal(t, span.SpanContext(), handlerSpan)
+			assert.Equal(t, "/api.v1.Greeter/SayHello", attributeValue(span, AttributeOperation).AsString())
+			assert.Equal(t, tt.wantCode, span.Status().Code)
+			assert.Equal(t, tt.wantRemote, span.Parent().IsRemote())
+			if tt.wantRemote {
+				assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", span.SpanContext().TraceID().String())
+			}
+			if nil != tt.err {
+				assert.Len(t, span.Events(), 1)
+				assert.Equal(t, tt.wantReason, attributeValue(span, AttributeErrorReason).AsString())
+			}
+		})
+	}
+}
+
+// TestServer_WithoutTransport 验证上下文中没有服务端 transport 时不创建 span。
+func TestServer_WithoutTransport(t *testing.T) {
+	provider, recorder := newTestProvider(t)
+	m := Server(WithTracerProvider(provider))
+
+	reply, err := m(func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	})(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, "ok", reply)
+	assert.Empty(t, recorder.Ended())
+}
+
+// TestGin 验证 Gin 中间件创建的 span 以及与 Server 共用同一个 span。
+func TestGin(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name        string
+		description string
+		status      int
+		err         error
+		withServer  bool
+		wantName    string
+		wantCode    codes.Code
+	}{
+		{
+			name:        "success/route",
+			description: "验证只使用 Gin 中间件时以方法和路由命名 span 并记录状态码。",
+			status:      http.StatusOK,
+			wantName:    "GET /users/:id",
+			wantCode:    codes.Unset,
+		},
+		{
+			name:        "error/status",
+			description: "验证响应状态码大于等于 500 时把 span 状态置为 Error。",
+			status:      http.StatusBadGateway,
+			wantName:    "GET /users/:id",
+			wantCode:    codes.Error,
+		},
+		{
+			name:        "success/kratos",
+			description: "验证请求进入 Kratos 处理链后沿用 Gin 的 span 并改用 Operation 命名。",
+			status:      http.StatusOK,
+			withServer:  true,
+			wantName:    "/api.v1.User/GetUser",
+			wantCode:    codes.Unset,
+		},
+		{
+			name:        "error/kratos",
+			description: "验证 Kratos 处理器返回的错误记录在 Gin 创建的 span 上。",
+			status:      http.StatusServiceUnavailable,
+			err:         errors.ServiceUnavailable("UNAVAILABLE", "unavailable"),
+			withServer:  true,
+			wantName:    "/api.v1.User/GetUser",
+			wantCode:    codes.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			provider, recorder := newTestProvider(t)
+			opts := []Option{WithTracerProvider(provider), WithPropagator(propagation.TraceContext{})}
+
+			var handlerSpan trace.SpanContext
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				handlerSpan = trace.SpanContextFromContext(ctx)
+				return nil, tt.err
+			}
+
+			engine := gin.New()
+			engine.Use(Gin(opts...))
+			engine.GET("/users/:id", func(c *gin.Context) {
+				ctx := c.Request.Context()
+				if tt.withServer {
+					tr := &mockTransport{operation: "/api.v1.User/GetUser", header: headerCarrier(c.Request.Header)}
+					_, _ = Server(opts...)(handler)(transport.NewServerContext(ctx, tr), nil)
+				} else {
+					_, _ = handler(ctx, nil)
+				}
+				c.Status(tt.status)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/users/42", nil)
+			req.Header.Set("traceparent", testTraceparent)
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+			assert.Equal(t, tt.status, w.Code)
+
+			spans := recorder.Ended()
+			require.Len(t, spans, 1)
+			span := spans[0]
+			assert.Equal(t, tt.wantName, span.Name())
+			assert.Equal(t, trace.SpanKindServer, span.SpanKind())
+			assert.Equal(t, span.SpanContext(), handlerSpan)
+			assert.True(t, span.Parent().IsRemote())
+			assert.Equal(t, "/users/:id", attributeValue(span, "http.route").AsString())
+			assert.Equal(t, int64(tt.status), attributeValue(span, "http.response.status_code").AsInt64())
+			assert.Equal(t, tt.wantCode, span.Status().Code)
+			if nil != tt.err {
+				assert.Equal(t, "UNAVAILABLE", attributeValue(span, AttributeErrorReason).AsString())
+			}
+		})
+	}
+}
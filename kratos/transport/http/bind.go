@@ -0,0 +1,540 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package http
+
+import (
+	"bytes"
+	"encoding"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dromara/carbon/v2"
+	"github.com/gin-gonic/gin"
+	kratoshttp "github.com/go-kratos/kratos/v2/transport/http"
+
+	// 导入 kit/time 以初始化 carbon 的默认布局与时区，时间参数的解析与其保持一致。
+	_ "github.com/fsyyft-go/kit/time"
+)
+
+const (
+	// bindSourceQuery 是查询参数来源的结构体标签名。
+	bindSourceQuery = "query"
+	// bindSourceHeader 是请求头来源的结构体标签名。
+	bindSourceHeader = "header"
+	// bindSourcePath 是路径参数来源的结构体标签名。
+	bindSourcePath = "path"
+
+	// bindParamLayout 是时间布局的标签参数名。
+	bindParamLayout = "layout"
+)
+
+var (
+	// ErrBind 表示请求参数无法绑定到目标字段。
+	ErrBind = errors.New("绑定请求参数失败")
+
+	// ErrInvalidBindTarget 表示绑定目标不是非 nil 的结构体指针。
+	ErrInvalidBindTarget = errors.New("绑定目标必须是非 nil 的结构体指针")
+
+	// bindSources 是按覆盖顺序排列的参数来源，后面的来源覆盖前面的来源。
+	bindSources = []string{bindSourceQuery, bindSourceHeader, bindSourcePath}
+
+	// defaultBinder 是包级 Bind、BindRequest 和 RegisterDecoder 使用的绑定器。
+	defaultBinder = NewBinder()
+
+	// textUnmarshalerType 是 encoding.TextUnmarshaler 的反射类型。
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+type (
+	// Decoder 将请求中的字符串值解码为注册类型的值。
+	//
+	// 参数：
+	//   - values：同名参数的全部取值，标签带 split 时已按逗号拆分；至少包含一个元素。
+	//   - params：标签中 key=value 形式的参数，例如 `query:"from,layout=2006-01-02"` 中的 layout。
+	//
+	// 返回值：
+	//   - interface{}：解码结果，必须可赋值给注册的类型；返回 nil 时字段被置为零值。
+	//   - error：取值无效时返回错误。
+	Decoder func(values []string, params map[string]string) (interface{}, error)
+
+	// BinderOption 配置 NewBinder 创建的绑定器。
+	BinderOption func(*Binder)
+
+	// Binder 将请求体、查询参数、请求头和路径参数绑定到同一个请求结构体。
+	//
+	// 请求体按 Content-Type 选择 Kratos codec 解码到整个结构体；随后按字段的 query、header、path
+	// 标签依次覆盖，路径参数优先级最高。未出现在请求中的字段保持原值，可预先填入默认值。
+	// Binder 可并发使用。
+	Binder struct {
+		// mu 保护 decoders。
+		mu sync.RWMutex
+
+		// decoders 是按目标类型注册的解码器。
+		decoders map[reflect.Type]Decoder
+	}
+
+	// bindTag 是解析后的字段绑定标签。
+	bindTag struct {
+		// name 是参数名。
+		name string
+
+		// split 表示是否按逗号拆分取值。
+		split bool
+
+		// required 表示参数缺失时是否返回错误。
+		required bool
+
+		// params 是 key=value 形式的标签参数。
+		params map[string]string
+	}
+)
+
+// WithDecoder 为绑定器注册指定类型的解码器。
+//
+// 参数：
+//   - typ：目标类型，例如 reflect.TypeOf(time.Time{})。
+//   - decoder：解码器；为 nil 时移除该类型已注册的解码器。
+//
+// 返回值：
+//   - BinderOption：NewBinder 的配置选项。
+func WithDecoder(typ reflect.Type, decoder Decoder) BinderOption {
+	return func(b *Binder) {
+		b.register(typ, decoder)
+	}
+}
+
+// NewBinder 创建请求绑定器。
+//
+// 绑定器内置 time.Time 与 time.Duration 的解码器：time.Time 默认依次尝试 kit/time 配置的 carbon
+// 默认布局、RFC 3339、日期时间与日期布局，并使用 carbon 的默认时区，可通过标签参数 layout 指定布局；
+// time.Duration 使用 time.ParseDuration。
+//
+// 参数：
+//   - opts：可选配置，例如通过 WithDecoder 注册或覆盖解码器。
+//
+// 返回值：
+//   - *Binder：绑定器实例。
+func NewBinder(opts ...BinderOption) *Binder {
+	b := &Binder{
+		decoders: map[reflect.Type]Decoder{
+			reflect.TypeOf(time.Time{}):      decodeTime,
+			reflect.TypeOf(time.Duration(0)): decodeDuration,
+		},
+	}
+	for _, opt := range opts {
+		if nil == opt {
+			continue
+		}
+		opt(b)
+	}
+	return b
+}
+
+// Register 为绑定器注册指定类型的解码器，可在绑定器使用期间调用。
+//
+// 参数：
+//   - typ：目标类型。
+//   - decoder：解码器；为 nil 时移除该类型已注册的解码器。
+func (b *Binder) Register(typ reflect.Type, decoder Decoder) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.register(typ, decoder)
+}
+
+// Bind 将 Gin 请求绑定到 v。
+//
+// 参数：
+//   - c：Gin 上下文，路径参数取自 c.Params。
+//   - v：非 nil 的结构体指针。
+//
+// 返回值：
+//   - error：v 无效时返回 ErrInvalidBindTarget，请求体或字段无法解码时返回包装了 ErrBind 的错误。
+func (b *Binder) Bind(c *gin.Context, v interface{}) error {
+	vars := make(url.Values, len(c.Params))
+	for _, p := range c.Params {
+		vars.Add(p.Key, p.Value)
+	}
+	return b.BindRequest(c.Request, vars, v)
+}
+
+// BindRequest 将 HTTP 请求绑定到 v。
+//
+// 在 Kratos 处理器中可传入 ctx.Request() 与 ctx.Vars()。请求体读取后会被还原，不影响后续处理器再次读取。
+//
+// 参数：
+//   - r：HTTP 请求。
+//   - vars：路径参数，可以为 nil。
+//   - v：非 nil 的结构体指针。
+//
+// 返回值：
+//   - error：v 无效时返回 ErrInvalidBindTarget，请求体或字段无法解码时返回包装了 ErrBind 的错误。
+func (b *Binder) BindRequest(r *http.Request, vars url.Values, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return ErrInvalidBindTarget
+	}
+
+	if err := bindBody(r, v); nil != err {
+		return err
+	}
+
+	lookup := func(source, name string) []string {
+		switch source {
+		case bindSourceQuery:
+			return r.URL.Query()[name]
+		case bindSourceHeader:
+			return r.Header.Values(name)
+		default:
+			return vars[name]
+		}
+	}
+	return b.bindStruct(rv.Elem(), lookup)
+}
+
+// Bind 使用默认绑定器将 Gin 请求绑定到 v。
+//
+// 参数：
+//   - c：Gin 上下文。
+//   - v：非 nil 的结构体指针。
+//
+// 返回值：
+//   - error：绑定失败时返回错误，语义同 Binder.Bind。
+func Bind(c *gin.Context, v interface{}) error {
+	return defaultBinder.Bind(c, v)
+}
+
+// BindRequest 使用默认绑定器将 HTTP 请求绑定到 v。
+//
+// 参数：
+//   - r：HTTP 请求。
+//   - vars：路径参数，可以为 nil。
+//   - v：非 nil 的结构体指针。
+//
+// 返回值：
+//   - error：绑定失败时返回错误，语义同 Binder.BindRequest。
+func BindRequest(r *http.Request, vars url.Values, v interface{}) error {
+	return defaultBinder.BindRequest(r, vars, v)
+}
+
+// RegisterDecoder 为默认绑定器注册指定类型的解码器。
+//
+// 参数：
+//   - typ：目标类型。
+//   - decoder：解码器；为 nil 时移除该类型已注册的解码器。
+func RegisterDecoder(typ reflect.Type, decoder Decoder) {
+	defaultBinder.Register(typ, decoder)
+}
+
+// register 在不加锁的情况下注册解码器。
+//
+// 参数：
+//   - typ：目标类型。
+//   - decoder：解码器；为 nil 时移除。
+func (b *Binder) register(typ reflect.Type, decoder Decoder) {
+	if nil == decoder {
+		delete(b.decoders, typ)
+		return
+	}
+	b.decoders[typ] = decoder
+}
+
+// decoder 查找指定类型的解码器。
+//
+// 参数：
+//   - typ：目标类型。
+//
+// 返回值：
+//   - Decoder：已注册的解码器。
+//   - bool：是否已注册。
+func (b *Binder) decoder(typ reflect.Type) (Decoder, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	d, ok := b.decoders[typ]
+	return d, ok
+}
+
+// bindBody 按 Content-Type 将请求体解码到 v，并还原请求体。
+//
+// 参数：
+//   - r：HTTP 请求。
+//   - v：结构体指针。
+//
+// 返回值：
+//   - error：读取或解码失败时返回包装了 ErrBind 的错误。
+func bindBody(r *http.Request, v interface{}) error {
+	if nil == r.Body || http.NoBody == r.Body {
+		return nil
+	}
+	data, err := io.ReadAll(r.Body)
+	_ = r.Body.Close()
+	r.Body = io.NopCloser(bytes.NewReader(data))
+	if nil != err {
+		return fmt.Errorf("%w: body: %v", ErrBind, err)
+	}
+	if 0 == len(data) {
+		return nil
+	}
+
+	codec, _ := kratoshttp.CodecForRequest(r, "Content-Type")
+	if err := codec.Unmarshal(data, v); nil != err {
+		return fmt.Errorf("%w: body: %v", ErrBind, err)
+	}
+	return nil
+}
+
+// bindStruct 按字段标签绑定结构体字段，匿名嵌入的结构体会递归处理。
+//
+// 参数：
+//   - rv：可寻址的结构体值。
+//   - lookup：按来源和参数名查询取值的函数。
+//
+// 返回值：
+//   - error：字段绑定失败时返回包装了 ErrBind 的错误。
+func (b *Binder) bindStruct(rv reflect.Value, lookup func(source, name string) []string) error {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		sf := rt.Field(i)
+		field := rv.Field(i)
+
+		tagged := false
+		for _, source := range bindSources {
+			raw, ok := sf.Tag.Lookup(source)
+			if !ok || "-" == raw {
+				continue
+			}
+			tagged = true
+			if !sf.IsExported() {
+				continue
+			}
+
+			tag := parseBindTag(raw, sf.Name)
+			values := lookup(source, tag.name)
+			if tag.split {
+				values = splitValues(values)
+			}
+			if 0 == len(values) {
+				if tag.required {
+					return fmt.Errorf("%w: %s 参数 %s 缺失", ErrBind, source, tag.name)
+				}
+				continue
+			}
+
+			decoded, err := b.decode(sf.Type, values, tag.params)
+			if nil != err {
+				return fmt.Errorf("%w: %s 参数 %s: %v", ErrBind, source, tag.name, err)
+			}
+			field.Set(decoded)
+		}
+
+		if tagged || !sf.Anonymous {
+			continue
+		}
+		// 未带标签的匿名嵌入结构体递归绑定，nil 指针嵌入不会被自动创建。
+		if field.Kind() == reflect.Pointer && !field.IsNil() {
+			field = field.Elem()
+		}
+		if field.Kind() == reflect.Struct {
+			if err := b.bindStruct(field, lookup); nil != err {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// decode 将字符串取值解码为指定类型的值。
+//
+// 参数：
+//   - typ：目标类型。
+//   - values：非空的取值列表。
+//   - params：标签参数。
+//
+// 返回值：
+//   - reflect.Value：可赋值给 typ 的值。
+//   - error：取值无效或类型不受支持时返回错误。
+func (b *Binder) decode(typ reflect.Type, values []string, params map[string]string) (reflect.Value, error) {
+	if d, ok := b.decoder(typ); ok {
+		v, err := d(values, params)
+		if nil != err {
+			return reflect.Value{}, err
+		}
+		if nil == v {
+			return reflect.Zero(typ), nil
+		}
+		rv := reflect.ValueOf(v)
+		if !rv.Type().AssignableTo(typ) {
+			return reflect.Value{}, fmt.Errorf("解码器返回了 %s，无法赋值给 %s", rv.Type(), typ)
+		}
+		return rv, nil
+	}
+
+	switch typ.Kind() {
+	case reflect.Pointer:
+		ev, err := b.decode(typ.Elem(), values, params)
+		if nil != err {
+			return reflect.Value{}, err
+		}
+		p := reflect.New(typ.Elem())
+		p.Elem().Set(ev)
+		return p, nil
+	case reflect.Slice:
+		if typ.Elem().Kind() == reflect.Uint8 {
+			return reflect.ValueOf([]byte(values[0])).Convert(typ), nil
+		}
+		s := reflect.MakeSlice(typ, 0, len(values))
+		for _, value := range values {
+			ev, err := b.decode(typ.Elem(), []string{value}, params)
+			if nil != err {
+				return reflect.Value{}, err
+			}
+			s = reflect.Append(s, ev)
+		}
+		return s, nil
+	}
+
+	value := values[0]
+	if reflect.PointerTo(typ).Implements(textUnmarshalerType) {
+		p := reflect.New(typ)
+		if err := p.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(value)); nil != err {
+			return reflect.Value{}, err
+		}
+		return p.Elem(), nil
+	}
+
+	rv := reflect.New(typ).Elem()
+	switch typ.Kind() {
+	case reflect.String:
+		rv.SetString(value)
+	case reflect.Bool:
+		v, err := strconv.ParseBool(value)
+		if nil != err {
+			return reflect.Value{}, err
+		}
+		rv.SetBool(v)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v, err := strconv.ParseInt(value, 10, typ.Bits())
+		if nil != err {
+			return reflect.Value{}, err
+		}
+		rv.SetInt(v)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v, err := strconv.ParseUint(value, 10, typ.Bits())
+		if nil != err {
+			return reflect.Value{}, err
+		}
+		rv.SetUint(v)
+	case reflect.Float32, reflect.Float64:
+		v, err := strconv.ParseFloat(value, typ.Bits())
+		if nil != err {
+			return reflect.Value{}, err
+		}
+		rv.SetFloat(v)
+	default:
+		return reflect.Value{}, fmt.Errorf("不支持的字段类型 %s", typ)
+	}
+	return rv, nil
+}
+
+// parseBindTag 解析字段绑定标签。
+//
+// 标签格式为 `name[,split][,required][,key=value...]`，name 为空时使用字段名。
+//
+// 参数：
+//   - raw：标签原始值。
+//   - fieldName：字段名。
+//
+// 返回值：
+//   - bindTag：解析结果。
+func parseBindTag(raw, fieldName string) bindTag {
+	parts := strings.Split(raw, ",")
+	tag := bindTag{name: strings.TrimSpace(parts[0])}
+	if "" == tag.name {
+		tag.name = fieldName
+	}
+	for _, part := range parts[1:] {
+		part = strings.TrimSpace(part)
+		switch part {
+		case "split":
+			tag.split = true
+		case "required":
+			tag.required = true
+		default:
+			if key, value, ok := strings.Cut(part, "="); ok {
+				if nil == tag.params {
+					tag.params = make(map[string]string)
+				}
+				tag.params[strings.TrimSpace(key)] = strings.TrimSpace(value)
+			}
+		}
+	}
+	return tag
+}
+
+// splitValues 按逗号拆分取值，去除空白并丢弃空元素。
+//
+// 参数：
+//   - values：原始取值。
+//
+// 返回值：
+//   - []string：拆分后的取值。
+func splitValues(values []string) []string {
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); "" != item {
+				result = append(result, item)
+			}
+		}
+	}
+	return result
+}
+
+// decodeTime 是 time.Time 的内置解码器。
+//
+// 参数：
+//   - values：取值列表，使用第一个元素。
+//   - params：标签参数，layout 指定时间布局。
+//
+// 返回值：
+//   - interface{}：解析得到的 time.Time。
+//   - error：无法按布局解析时返回错误。
+func decodeTime(values []string, params map[string]string) (interface{}, error) {
+	var c *carbon.Carbon
+	if layout, ok := params[bindParamLayout]; ok {
+		c = carbon.ParseByLayout(values[0], layout)
+	} else {
+		c = carbon.ParseByLayouts(values[0], []string{carbon.DefaultLayout, time.RFC3339Nano, carbon.DateTimeLayout, carbon.DateLayout})
+	}
+	if nil != c.Error {
+		return nil, c.Error
+	}
+	if c.IsInvalid() {
+		return nil, fmt.Errorf("无效的时间 %q", values[0])
+	}
+	return c.StdTime(), nil
+}
+
+// decodeDuration 是 time.Duration 的内置解码器。
+//
+// 参数：
+//   - values：取值列表，使用第一个元素。
+//   - params：标签参数，未使用。
+//
+// 返回值：
+//   - interface{}：解析得到的 time.Duration。
+//   - error：无法解析时返回错误。
+func decodeDuration(values []string, _ map[string]string) (interface{}, error) {
+	return time.ParseDuration(values[0])
+}
@@ -0,0 +1,286 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package http
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dromara/carbon/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type (
+	// bindPage 是用于验证匿名嵌入递归绑定的分页参数。
+	bindPage struct {
+		Page int `query:"page"`
+		Size int `query:"size"`
+	}
+
+	// bindRequest 是覆盖多种来源与类型的请求结构体。
+	bindRequest struct {
+		bindPage
+
+		ID      int64         `path:"id,required" json:"-"`
+		Name    string        `json:"name"`
+		Tags    []string      `query:"tags,split" json:"-"`
+		IDs     []uint16      `query:"ids" json:"-"`
+		Token   string        `header:"X-Token" json:"-"`
+		Debug   *bool         `query:"debug" json:"-"`
+		Ratio   float32       `query:"ratio" json:"-"`
+		From    time.Time     `query:"from,layout=2006-01-02" json:"-"`
+		Since   time.Time     `query:"since" json:"-"`
+		Timeout time.Duration `query:"timeout" json:"-"`
+		Raw     []byte        `query:"raw" json:"-"`
+		Ignored string        `query:"-" json:"-"`
+		Source  string        `query:"source" header:"X-Source" json:"-"`
+	}
+
+	// bindLevel 实现 encoding.TextUnmarshaler，用于验证文本解码。
+	bindLevel int
+)
+
+// UnmarshalText 将 low/high 解码为级别。
+func (l *bindLevel) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "low":
+		*l = 1
+	case "high":
+		*l = 2
+	default:
+		return errors.New("unknown level")
+	}
+	return nil
+}
+
+// TestBind 验证 Gin 请求从请求体、查询参数、请求头与路径参数绑定到同一结构体。
+func TestBind(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	engine := gin.New()
+	var got bindRequest
+	var bindErr error
+	var body string
+	engine.POST("/users/:id", func(c *gin.Context) {
+		got = bindRequest{bindPage: bindPage{Size: 20}}
+		bindErr = Bind(c, &got)
+		// 请求体在绑定后被还原，后续处理器仍可读取。
+		data, _ := io.ReadAll(c.Request.Body)
+		body = string(data)
+	})
+
+	query := url.Values{}
+	query.Set("page", "3")
+	query.Set("tags", "a, b,,c")
+	query.Add("ids", "1")
+	query.Add("ids", "2")
+	query.Set("debug", "true")
+	query.Set("ratio", "0.5")
+	query.Set("from", "2025-01-02")
+	query.Set("since", "2025-01-02 03:04:05")
+	query.Set("timeout", "1m30s")
+	query.Set("raw", "bytes")
+	query.Set("Ignored", "x")
+	query.Set("source", "query")
+
+	req := httptest.NewRequest(http.MethodPost, "/users/42?"+query.Encode(), strings.NewReader(`{"name":"kit"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Token", "secret")
+	req.Header.Set("X-Source", "header")
+	engine.ServeHTTP(httptest.NewRecorder(), req)
+
+	require.NoError(t, bindErr)
+	debug := true
+	assert.Equal(t, bindRequest{
+		bindPage: bindPage{Page: 3, Size: 20},
+		ID:       42,
+		Name:     "kit",
+		Tags:     []string{"a", "b", "c"},
+		IDs:      []uint16{1, 2},
+		Token:    "secret",
+		Debug:    &debug,
+		Ratio:    0.5,
+		From:     carbon.ParseByLayout("2025-01-02", "2006-01-02").StdTime(),
+		Since:    carbon.ParseByLayout("2025-01-02 03:04:05", carbon.DateTimeLayout).StdTime(),
+		Timeout:  90 * time.Second,
+		Raw:      []byte("bytes"),
+		Source:   "header",
+	}, got)
+	assert.Equal(t, `{"name":"kit"}`, body)
+}
+
+// TestBindRequest 验证绑定失败与自定义解码器的行为。
+func TestBindRequest(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		binder      *Binder
+		target      func() interface{}
+		rawQuery    string
+		vars        url.Values
+		body        string
+		want        interface{}
+		wantErr     error
+	}{
+		{
+			name:        "error/invalid-target",
+			description: "验证绑定目标不是结构体指针时返回 ErrInvalidBindTarget。",
+			target:      func() interface{} { return bindPage{} },
+			wantErr:     ErrInvalidBindTarget,
+		},
+		{
+			name:        "error/required-missing",
+			description: "验证 required 参数缺失时返回 ErrBind。",
+			target:      func() interface{} { return &bindRequest{} },
+			wantErr:     ErrBind,
+		},
+		{
+			name:        "error/invalid-int",
+			description: "验证取值无法解析为字段类型时返回 ErrBind。",
+			target:      func() interface{} { return &bindPage{} },
+			rawQuery:    "page=abc",
+			wantErr:     ErrBind,
+		},
+		{
+			name:        "error/invalid-body",
+			description: "验证请求体无法解码时返回 ErrBind。",
+			target:      func() interface{} { return &bindPage{} },
+			body:        `{`,
+			wantErr:     ErrBind,
+		},
+		{
+			name:        "error/unsupported-type",
+			description: "验证不受支持的字段类型返回 ErrBind。",
+			target: func() interface{} {
+				return &struct {
+					M map[string]string `query:"m"`
+				}{}
+			},
+			rawQuery: "m=1",
+			wantErr:  ErrBind,
+		},
+		{
+			name:        "success/text-unmarshaler",
+			description: "验证实现 encoding.TextUnmarshaler 的类型通过 UnmarshalText 解码。",
+			target: func() interface{} {
+				return &struct {
+					Level bindLevel `query:"level"`
+				}{}
+			},
+			rawQuery: "level=high",
+			want: &struct {
+				Level bindLevel `query:"level"`
+			}{Level: 2},
+		},
+		{
+			name:        "success/custom-decoder",
+			description: "验证注册的解码器接收拆分后的全部取值与标签参数。",
+			binder: NewBinder(WithDecoder(reflect.TypeOf([]int(nil)), func(values []string, params map[string]string) (interface{}, error) {
+				return []int{len(values), len(params["sep"])}, nil
+			})),
+			target: func() interface{} {
+				return &struct {
+					Counts []int `query:"counts,split,sep=ab"`
+				}{}
+			},
+			rawQuery: "counts=1,2,3",
+			want: &struct {
+				Counts []int `query:"counts,split,sep=ab"`
+			}{Counts: []int{3, 2}},
+		},
+		{
+			name:        "error/decoder-type-mismatch",
+			description: "验证解码器返回值无法赋值给字段时返回 ErrBind。",
+			binder: NewBinder(WithDecoder(reflect.TypeOf(""), func([]string, map[string]string) (interface{}, error) {
+				return 1, nil
+			})),
+			target: func() interface{} {
+				return &struct {
+					S string `path:"s"`
+				}{}
+			},
+			vars:    url.Values{"s": {"x"}},
+			wantErr: ErrBind,
+		},
+		{
+			name:        "error/removed-time-decoder",
+			description: "验证注册 nil 解码器后 time.Duration 按整数解析。",
+			binder:      NewBinder(WithDecoder(reflect.TypeOf(time.Duration(0)), nil)),
+			target: func() interface{} {
+				return &struct {
+					D time.Duration `query:"d"`
+				}{}
+			},
+			rawQuery: "d=1s",
+			wantErr:  ErrBind,
+		},
+		{
+			name:        "error/invalid-time",
+			description: "验证无法按布局解析的时间返回 ErrBind。",
+			target: func() interface{} {
+				return &struct {
+					T time.Time `query:"t,layout=2006-01-02"`
+				}{}
+			},
+			rawQuery: "t=2025/01/02",
+			wantErr:  ErrBind,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			var body io.Reader = http.NoBody
+			if "" != tt.body {
+				body = strings.NewReader(tt.body)
+			}
+			req := httptest.NewRequest(http.MethodPost, "/?"+tt.rawQuery, body)
+			req.Header.Set("Content-Type", "application/json")
+
+			target := tt.target()
+			var err error
+			if nil != tt.binder {
+				err = tt.binder.BindRequest(req, tt.vars, target)
+			} else {
+				err = BindRequest(req, tt.vars, target)
+			}
+			if nil != tt.wantErr {
+				assert.True(t, errors.Is(err, tt.wantErr), "err: %v", err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, target)
+		})
+	}
+}
+
+// TestRegisterDecoder 验证默认绑定器注册与移除解码器。
+func TestRegisterDecoder(t *testing.T) {
+	type upper string
+	typ := reflect.TypeOf(upper(""))
+	RegisterDecoder(typ, func(values []string, _ map[string]string) (interface{}, error) {
+		return upper(strings.ToUpper(values[0])), nil
+	})
+	defer RegisterDecoder(typ, nil)
+
+	var v struct {
+		U upper `header:"X-Upper"`
+	}
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("X-Upper", "kit")
+	require.NoError(t, BindRequest(req, nil, &v))
+	assert.Equal(t, upper("KIT"), v.U)
+}
@@ -0,0 +1,565 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package http
+
+import (
+	"compress/flate"
+	"compress/gzip"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// encodingGzip 是 gzip 内容编码。
+	encodingGzip = "gzip"
+	// encodingDeflate 是 deflate 内容编码。
+	encodingDeflate = "deflate"
+	// encodingBrotli 是 br 内容编码，仅用于预压缩静态资源。
+	encodingBrotli = "br"
+
+	// defaultCompressionMinSize 是默认的最小压缩字节数，更小的响应压缩收益有限。
+	defaultCompressionMinSize = 1024
+)
+
+var (
+	// defaultCompressionContentTypes 是默认允许压缩的媒体类型。
+	defaultCompressionContentTypes = []string{
+		"text/html",
+		"text/plain",
+		"text/css",
+		"text/csv",
+		"text/xml",
+		"text/javascript",
+		"application/javascript",
+		"application/x-javascript",
+		"application/json",
+		"application/problem+json",
+		"application/xml",
+		"application/wasm",
+		"image/svg+xml",
+	}
+
+	// dynamicEncodings 是动态压缩支持的编码，按服务端偏好排序。
+	dynamicEncodings = []string{encodingGzip, encodingDeflate}
+
+	// precompressedEncodings 是预压缩静态资源支持的编码及文件扩展名，按服务端偏好排序。
+	precompressedEncodings = []struct {
+		encoding string
+		ext      string
+	}{
+		{encoding: encodingBrotli, ext: ".br"},
+		{encoding: encodingGzip, ext: ".gz"},
+	}
+)
+
+type (
+	// CompressionOption 配置 WithCompression 与 Compress 的响应压缩行为。
+	CompressionOption func(*compression)
+
+	// compression 包含响应压缩的配置与编码器池。
+	compression struct {
+		// level 是 gzip 与 deflate 的压缩级别。
+		level int
+
+		// minSize 是最小压缩字节数，响应体小于该值时原样返回。
+		minSize int
+
+		// contentTypes 是允许压缩的媒体类型，以 "/*" 结尾的项按主类型匹配。
+		contentTypes []string
+
+		// precompressed 表示静态资源是否优先使用同名的 .br、.gz 预压缩文件。
+		precompressed bool
+
+		// gzipPool 复用 gzip 编码器。
+		gzipPool sync.Pool
+
+		// flatePool 复用 deflate 编码器。
+		flatePool sync.Pool
+	}
+
+	// compressor 是支持 Flush 与 Reset 的压缩编码器。
+	compressor interface {
+		io.WriteCloser
+
+		// Flush 把缓冲的数据压缩并写入底层 Writer。
+		Flush() error
+
+		// Reset 丢弃当前状态并改为写入 w。
+		Reset(w io.Writer)
+	}
+
+	// compressWriter 包装 gin.ResponseWriter，在响应体达到最小压缩字节数后按协商的编码压缩输出。
+	compressWriter struct {
+		gin.ResponseWriter
+
+		// config 是响应压缩配置。
+		config *compression
+
+		// encoding 是与客户端协商的内容编码。
+		encoding string
+
+		// buf 缓冲尚未决定是否压缩的响应体。
+		buf []byte
+
+		// decided 表示是否压缩已经确定，响应头已提交。
+		decided bool
+
+		// encoder 是正在使用的压缩编码器，不压缩时为 nil。
+		encoder compressor
+	}
+)
+
+// WithCompression 为 Parse 注册的所有路由和静态资源启用 gzip/deflate 响应压缩。
+//
+// 参数：
+//   - opts：压缩级别、最小压缩字节数、允许压缩的媒体类型等可选配置。
+//
+// 返回值：
+//   - ParseOption：Parse 的配置选项。
+//
+// 压缩处理器在路由组处理器之前执行。按请求的 Accept-Encoding（支持 q 值）协商编码，服务端偏好 gzip；
+// 响应已设置 Content-Encoding、Content-Range，状态码为 1xx、204、206、304，媒体类型不在允许列表中，
+// 或响应体小于最小压缩字节数时原样返回。静态资源默认优先返回同名的 .br 或 .gz 预压缩文件。
+func WithCompression(opts ...CompressionOption) ParseOption {
+	return func(o *parseOptions) {
+		o.compression = newCompression(opts)
+	}
+}
+
+// Compress 返回压缩响应的 Gin 处理器，可以直接挂载到 Gin Engine 或路由组上。
+//
+// 参数：
+//   - opts：压缩级别、最小压缩字节数、允许压缩的媒体类型等可选配置。
+//
+// 返回值：
+//   - gin.HandlerFunc：按 Accept-Encoding 协商并压缩后续处理器输出的处理器，规则与 WithCompression 相同。
+func Compress(opts ...CompressionOption) gin.HandlerFunc {
+	return newCompression(opts).handler()
+}
+
+// WithCompressionLevel 设置 gzip 与 deflate 的压缩级别。
+//
+// 参数：
+//   - level：压缩级别，取值范围为 gzip.HuffmanOnly 到 gzip.BestCompression；超出范围时使用 gzip.DefaultCompression。
+//
+// 返回值：
+//   - CompressionOption：响应压缩配置选项。
+func WithCompressionLevel(level int) CompressionOption {
+	return func(c *compression) {
+		if level < gzip.HuffmanOnly || level > gzip.BestCompression {
+			level = gzip.DefaultCompression
+		}
+		c.level = level
+	}
+}
+
+// WithCompressionMinSize 设置最小压缩字节数，默认为 1024。
+//
+// 参数：
+//   - size：最小压缩字节数；响应体小于该值时原样返回，小于等于 0 表示总是压缩。
+//
+// 返回值：
+//   - CompressionOption：响应压缩配置选项。
+func WithCompressionMinSize(size int) CompressionOption {
+	return func(c *compression) {
+		c.minSize = size
+	}
+}
+
+// WithCompressionContentTypes 替换允许压缩的媒体类型列表。
+//
+// 参数：
+//   - types：媒体类型，例如 `application/json`；以 `/*` 结尾的项按主类型匹配，例如 `text/*`。
+//     匹配时忽略大小写与 charset 等参数。默认列表包含常见的文本、JSON、JavaScript、XML 与 SVG 类型。
+//
+// 返回值：
+//   - CompressionOption：响应压缩配置选项。
+func WithCompressionContentTypes(types ...string) CompressionOption {
+	return func(c *compression) {
+		c.contentTypes = make([]string, 0, len(types))
+		for _, t := range types {
+			c.contentTypes = append(c.contentTypes, strings.ToLower(strings.TrimSpace(t)))
+		}
+	}
+}
+
+// WithPrecompressed 设置静态资源是否优先使用预压缩文件，默认启用。
+//
+// 参数：
+//   - enabled：为 true 时，客户端接受 br 或 gzip 且文件系统中存在同名的 .br 或 .gz 文件时直接返回该文件，
+//     并按原文件扩展名设置 Content-Type；预压缩文件不受最小压缩字节数与媒体类型列表限制。
+//
+// 返回值：
+//   - CompressionOption：响应压缩配置选项。
+func WithPrecompressed(enabled bool) CompressionOption {
+	return func(c *compression) {
+		c.precompressed = enabled
+	}
+}
+
+// newCompression 创建响应压缩配置。
+//
+// 参数：
+//   - opts：可选配置。
+//
+// 返回值：
+//   - *compression：应用默认值与可选配置后的压缩配置。
+func newCompression(opts []CompressionOption) *compression {
+	c := &compression{
+		level:         gzip.DefaultCompression,
+		minSize:       defaultCompressionMinSize,
+		contentTypes:  defaultCompressionContentTypes,
+		precompressed: true,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// handler 返回压缩响应的 Gin 处理器。
+//
+// 返回值：
+//   - gin.HandlerFunc：按协商结果包装 ResponseWriter 的处理器。
+func (c *compression) handler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		encoding := negotiateEncoding(ctx.GetHeader("Accept-Encoding"), dynamicEncodings)
+		if "" == encoding {
+			ctx.Next()
+			return
+		}
+
+		w := &compressWriter{
+			ResponseWriter: ctx.Writer,
+			config:         c,
+			encoding:       encoding,
+		}
+		ctx.Writer = w
+		defer func() {
+			w.finish()
+			ctx.Writer = w.ResponseWriter
+		}()
+		ctx.Next()
+	}
+}
+
+// allowed 判断媒体类型是否允许压缩。
+//
+// 参数：
+//   - contentType：Content-Type 响应头的值。
+//
+// 返回值：
+//   - bool：媒体类型在允许列表中时返回 true。
+func (c *compression) allowed(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if nil != err {
+		return false
+	}
+	for _, t := range c.contentTypes {
+		if t == mediaType {
+			return true
+		}
+		if strings.HasSuffix(t, "/*") && strings.HasPrefix(mediaType, t[:len(t)-1]) {
+			return true
+		}
+	}
+	return false
+}
+
+// newEncoder 从池中获取写入 w 的编码器。
+//
+// 参数：
+//   - encoding：内容编码，gzip 或 deflate。
+//   - w：压缩数据的输出目标。
+//
+// 返回值：
+//   - compressor：重置为写入 w 的编码器。
+func (c *compression) newEncoder(encoding string, w io.Writer) compressor {
+	pool := &c.gzipPool
+	if encodingDeflate == encoding {
+		pool = &c.flatePool
+	}
+	if enc, ok := pool.Get().(compressor); ok {
+		enc.Reset(w)
+		return enc
+	}
+
+	// 级别已在 WithCompressionLevel 中校验，不会返回错误。
+	if encodingDeflate == encoding {
+		enc, _ := flate.NewWriter(w, c.level)
+		return enc
+	}
+	enc, _ := gzip.NewWriterLevel(w, c.level)
+	return enc
+}
+
+// releaseEncoder 把编码器放回池中。
+//
+// 参数：
+//   - encoding：内容编码。
+//   - enc：已关闭的编码器。
+func (c *compression) releaseEncoder(encoding string, enc compressor) {
+	enc.Reset(io.Discard)
+	if encodingDeflate == encoding {
+		c.flatePool.Put(enc)
+		return
+	}
+	c.gzipPool.Put(enc)
+}
+
+// precompressedFile 查找与请求协商匹配的预压缩文件。
+//
+// 参数：
+//   - fsys：静态文件系统。
+//   - r：HTTP 请求。
+//   - name：原始文件名。
+//
+// 返回值：
+//   - string：预压缩文件名；未启用或没有可接受的预压缩文件时为空串。
+//   - string：预压缩文件的内容编码。
+//   - bool：是否存在任一预压缩文件，存在时响应需要设置 Vary 响应头。
+func (c *compression) precompressedFile(fsys fs.FS, r *http.Request, name string) (string, string, bool) {
+	if nil == c || !c.precompressed {
+		return "", "", false
+	}
+
+	candidates := make([]string, 0, len(precompressedEncodings))
+	files := make(map[string]string, len(precompressedEncodings))
+	for _, p := range precompressedEncodings {
+		file := name + p.ext
+		if info, err := fs.Stat(fsys, file); nil == err && !info.IsDir() {
+			candidates = append(candidates, p.encoding)
+			files[p.encoding] = file
+		}
+	}
+	if 0 == len(candidates) {
+		return "", "", false
+	}
+	encoding := negotiateEncoding(r.Header.Get("Accept-Encoding"), candidates)
+	if "" == encoding {
+		return "", "", true
+	}
+	return files[encoding], encoding, true
+}
+
+// Write 缓冲或压缩写出响应体。
+//
+// 参数：
+//   - data：响应体数据。
+//
+// 返回值：
+//   - int：写入的字节数，与压缩后的大小无关。
+//   - error：写入底层 ResponseWriter 失败时返回错误。
+func (w *compressWriter) Write(data []byte) (int, error) {
+	if !w.decided {
+		w.buf = append(w.buf, data...)
+		if len(w.buf) < w.config.minSize {
+			return len(data), nil
+		}
+		if err := w.commit(false); nil != err {
+			return 0, err
+		}
+		return len(data), nil
+	}
+	if nil != w.encoder {
+		return w.encoder.Write(data)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+// WriteString 缓冲或压缩写出字符串响应体。
+//
+// 参数：
+//   - s：响应体字符串。
+//
+// 返回值：
+//   - int：写入的字节数。
+//   - error：写入失败时返回错误。
+func (w *compressWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// WriteHeaderNow 立即提交响应头；此后的响应体不再压缩。
+func (w *compressWriter) WriteHeaderNow() {
+	if !w.decided {
+		_ = w.commit(false)
+	}
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+// Flush 提交响应头并把已缓冲的数据发送给客户端。
+//
+// 尚未决定是否压缩时，即使响应体小于最小压缩字节数，也会按媒体类型决定是否压缩，以支持流式响应。
+func (w *compressWriter) Flush() {
+	if !w.decided {
+		_ = w.commit(true)
+	}
+	if nil != w.encoder {
+		_ = w.encoder.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+// commit 决定是否压缩，提交响应头并写出已缓冲的数据。
+//
+// 参数：
+//   - force：是否忽略最小压缩字节数。
+//
+// 返回值：
+//   - error：写入底层 ResponseWriter 失败时返回错误。
+func (w *compressWriter) commit(force bool) error {
+	w.decided = true
+	buf := w.buf
+	w.buf = nil
+
+	if w.shouldCompress(buf, force) {
+		header := w.Header()
+		header.Set("Content-Encoding", w.encoding)
+		header.Del("Content-Length")
+		// 压缩后内容与原 ETag 不再逐字节一致，改为弱 ETag。
+		if etag := header.Get("ETag"); "" != etag && !strings.HasPrefix(etag, "W/") {
+			header.Set("ETag", "W/"+etag)
+		}
+		w.encoder = w.config.newEncoder(w.encoding, w.ResponseWriter)
+		if len(buf) > 0 {
+			_, err := w.encoder.Write(buf)
+			return err
+		}
+		return nil
+	}
+
+	if len(buf) > 0 {
+		_, err := w.ResponseWriter.Write(buf)
+		return err
+	}
+	return nil
+}
+
+// shouldCompress 判断响应是否需要压缩，并在媒体类型可压缩时设置 Vary 响应头。
+//
+// 参数：
+//   - buf：已缓冲的响应体。
+//   - force：是否忽略最小压缩字节数。
+//
+// 返回值：
+//   - bool：需要压缩时返回 true。
+func (w *compressWriter) shouldCompress(buf []byte, force bool) bool {
+	header := w.Header()
+	if "" != header.Get("Content-Encoding") || "" != header.Get("Content-Range") {
+		return false
+	}
+	switch status := w.Status(); {
+	case status < http.StatusOK, http.StatusNoContent == status, http.StatusPartialContent == status, http.StatusNotModified == status:
+		return false
+	}
+
+	contentType := header.Get("Content-Type")
+	if "" == contentType {
+		if 0 == len(buf) {
+			return false
+		}
+		// 与 net/http 的行为一致，根据内容嗅探媒体类型。
+		contentType = http.DetectContentType(buf)
+		header.Set("Content-Type", contentType)
+	}
+	if !w.config.allowed(contentType) {
+		return false
+	}
+	header.Add("Vary", "Accept-Encoding")
+
+	if force {
+		return true
+	}
+	if length := header.Get("Content-Length"); "" != length {
+		if n, err := strconv.Atoi(length); nil == err && n < w.config.minSize {
+			return false
+		}
+	}
+	return len(buf) >= w.config.minSize && len(buf) > 0
+}
+
+// finish 在处理器链返回后提交尚未提交的响应并关闭编码器。
+func (w *compressWriter) finish() {
+	if !w.decided {
+		if 0 == len(w.buf) {
+			// 没有响应体时不提交，由 Gin 按原状态码写出响应头。
+			w.decided = true
+		} else {
+			_ = w.commit(false)
+		}
+	}
+	if nil != w.encoder {
+		_ = w.encoder.Close()
+		w.config.releaseEncoder(w.encoding, w.encoder)
+		w.encoder = nil
+	}
+}
+
+// negotiateEncoding 按 Accept-Encoding 选择内容编码。
+//
+// 参数：
+//   - accept：Accept-Encoding 请求头的值。
+//   - supported：服务端支持的编码，按偏好排序。
+//
+// 返回值：
+//   - string：q 值最高的编码，q 值相同时按服务端偏好；没有可接受的编码时返回空串，表示不压缩。
+func negotiateEncoding(accept string, supported []string) string {
+	if "" == accept || 0 == len(supported) {
+		return ""
+	}
+
+	weights := make(map[string]float64)
+	for _, part := range strings.Split(accept, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if "" == name {
+			continue
+		}
+		q := 1.0
+		for _, param := range strings.Split(params, ";") {
+			key, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+			if ok && "q" == strings.ToLower(strings.TrimSpace(key)) {
+				if v, err := strconv.ParseFloat(strings.TrimSpace(value), 64); nil == err {
+					q = v
+				}
+			}
+		}
+		weights[name] = q
+	}
+
+	best, bestQ := "", 0.0
+	for _, encoding := range supported {
+		q, ok := weights[encoding]
+		if !ok {
+			q, ok = weights["*"]
+		}
+		if ok && q > bestQ {
+			best, bestQ = encoding, q
+		}
+	}
+	return best
+}
+
+// precompressedContentType 返回预压缩文件对应原始文件的 Content-Type。
+//
+// 参数：
+//   - name：原始文件名。
+//
+// 返回值：
+//   - string：按扩展名推断的媒体类型；无法推断时为 application/octet-stream。
+func precompressedContentType(name string) string {
+	if contentType := mime.TypeByExtension(path.Ext(name)); "" != contentType {
+		return contentType
+	}
+	return "application/octet-stream"
+}
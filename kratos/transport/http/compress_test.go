@@ -0,0 +1,400 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package http
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/gin-gonic/gin"
+	kratoshttp "github.com/go-kratos/kratos/v2/transport/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// decodeBody 按 Content-Encoding 解码响应体。
+func decodeBody(t *testing.T, resp *httptest.ResponseRecorder) string {
+	t.Helper()
+
+	var r io.Reader = resp.Body
+	switch resp.Header().Get("Content-Encoding") {
+	case encodingGzip:
+		gr, err := gzip.NewReader(resp.Body)
+		require.NoError(t, err)
+		r = gr
+	case encodingDeflate:
+		r = flate.NewReader(resp.Body)
+	}
+	body, err := io.ReadAll(r)
+	require.NoError(t, err)
+	return string(body)
+}
+
+// TestNegotiateEncoding 测试按 Accept-Encoding 的 q 值与服务端偏好协商编码。
+func TestNegotiateEncoding(t *testing.T) {
+	tests := []struct {
+		name        string   // 测试用例名称。
+		description string   // 用例语义说明。
+		accept      string   // Accept-Encoding 请求头。
+		supported   []string // 服务端支持的编码。
+		want        string   // 期望的编码。
+	}{
+		{name: "缺少请求头", description: "验证没有 Accept-Encoding 时不压缩。", accept: "", supported: dynamicEncodings, want: ""},
+		{name: "服务端偏好", description: "验证 q 值相同时按服务端偏好选择 gzip。", accept: "deflate, gzip", supported: dynamicEncodings, want: encodingGzip},
+		{name: "按 q 值选择", description: "验证选择 q 值最高的编码。", accept: "gzip;q=0.5, deflate;q=0.8", supported: dynamicEncodings, want: encodingDeflate},
+		{name: "q 为 0 表示拒绝", description: "验证 q=0 的编码不会被选择。", accept: "gzip;q=0, deflate;q=0", supported: dynamicEncodings, want: ""},
+		{name: "通配符", description: "验证 * 匹配未列出的编码，显式列出的编码优先使用自己的 q 值。", accept: "gzip;q=0, *;q=0.1", supported: dynamicEncodings, want: encodingDeflate},
+		{name: "大小写与空白", description: "验证编码名与参数忽略大小写和空白。", accept: " GZIP ; Q=0.9 ,identity", supported: dynamicEncodings, want: encodingGzip},
+		{name: "不支持的编码", description: "验证只接受不支持的编码时不压缩。", accept: "br, zstd", supported: dynamicEncodings, want: ""},
+		{name: "预压缩偏好 br", description: "验证预压缩文件优先选择 br。", accept: "gzip, br", supported: []string{encodingBrotli, encodingGzip}, want: encodingBrotli},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			assert.Equal(t, tt.want, negotiateEncoding(tt.accept, tt.supported))
+		})
+	}
+}
+
+// TestCompress 测试压缩处理器的阈值、媒体类型、状态码与已编码响应的处理。
+func TestCompress(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	large := strings.Repeat("hello kit ", 200)
+
+	tests := []struct {
+		name         string               // 测试用例名称。
+		description  string               // 用例语义说明。
+		opts         []CompressionOption  // 压缩配置。
+		accept       string               // Accept-Encoding 请求头。
+		handler      func(c *gin.Context) // 业务处理器。
+		wantStatus   int                  // 期望状态码。
+		wantEncoding string               // 期望的 Content-Encoding。
+		wantBody     string               // 期望解码后的响应体。
+		check        func(http.Header)    // 额外的响应头检查。
+	}{
+		{
+			name:        "gzip 压缩大响应",
+			description: "验证超过最小压缩字节数的 JSON 响应使用 gzip 压缩，并设置 Vary、移除 Content-Length、弱化 ETag。",
+			accept:      "gzip, deflate",
+			handler: func(c *gin.Context) {
+				c.Header("ETag", `"v1"`)
+				c.Header("Content-Length", "2000")
+				c.Data(http.StatusOK, "application/json; charset=utf-8", []byte(large))
+			},
+			wantStatus:   http.StatusOK,
+			wantEncoding: encodingGzip,
+			wantBody:     large,
+			check: func(h http.Header) {
+				assert.Equal(t, "Accept-Encoding", h.Get("Vary"))
+				assert.Empty(t, h.Get("Content-Length"))
+				assert.Equal(t, `W/"v1"`, h.Get("ETag"))
+			},
+		},
+		{
+			name:        "deflate 压缩",
+			description: "验证客户端只接受 deflate 时使用 deflate 压缩，分多次写入的数据完整。",
+			accept:      "deflate",
+			handler: func(c *gin.Context) {
+				c.Header("Content-Type", "text/plain")
+				c.Status(http.StatusCreated)
+				_, _ = c.Writer.WriteString(large[:500])
+				_, _ = c.Writer.Write([]byte(large[500:]))
+			},
+			wantStatus:   http.StatusCreated,
+			wantEncoding: encodingDeflate,
+			wantBody:     large,
+		},
+		{
+			name:        "小于阈值不压缩",
+			description: "验证小于最小压缩字节数的响应原样返回，但仍设置 Vary。",
+			accept:      "gzip",
+			handler: func(c *gin.Context) {
+				c.String(http.StatusOK, "small")
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   "small",
+			check: func(h http.Header) {
+				assert.Equal(t, "Accept-Encoding", h.Get("Vary"))
+			},
+		},
+		{
+			name:        "自定义阈值",
+			description: "验证 WithCompressionMinSize(0) 时小响应也会压缩。",
+			opts:        []CompressionOption{WithCompressionMinSize(0), WithCompressionLevel(gzip.BestSpeed)},
+			accept:      "gzip",
+			handler: func(c *gin.Context) {
+				c.String(http.StatusOK, "small")
+			},
+			wantStatus:   http.StatusOK,
+			wantEncoding: encodingGzip,
+			wantBody:     "small",
+		},
+		{
+			name:        "客户端不接受压缩",
+			description: "验证没有 Accept-Encoding 时原样返回且不设置 Vary。",
+			handler: func(c *gin.Context) {
+				c.String(http.StatusOK, large)
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   large,
+			check: func(h http.Header) {
+				assert.Empty(t, h.Get("Vary"))
+			},
+		},
+		{
+			name:        "媒体类型不在允许列表",
+			description: "验证图片等已压缩的媒体类型不会再次压缩。",
+			accept:      "gzip",
+			handler: func(c *gin.Context) {
+				c.Data(http.StatusOK, "image/png", []byte(large))
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   large,
+			check: func(h http.Header) {
+				assert.Empty(t, h.Get("Vary"))
+			},
+		},
+		{
+			name:        "通配媒体类型",
+			description: "验证 WithCompressionContentTypes 支持 type/* 通配。",
+			opts:        []CompressionOption{WithCompressionContentTypes("Image/*")},
+			accept:      "gzip",
+			handler: func(c *gin.Context) {
+				c.Data(http.StatusOK, "image/bmp", []byte(large))
+			},
+			wantStatus:   http.StatusOK,
+			wantEncoding: encodingGzip,
+			wantBody:     large,
+		},
+		{
+			name:        "嗅探媒体类型",
+			description: "验证未设置 Content-Type 时按内容嗅探后再判断是否压缩。",
+			accept:      "gzip",
+			handler: func(c *gin.Context) {
+				_, _ = c.Writer.Write([]byte("<html>" + large))
+			},
+			wantStatus:   http.StatusOK,
+			wantEncoding: encodingGzip,
+			wantBody:     "<html>" + large,
+			check: func(h http.Header) {
+				assert.Equal(t, "text/html; charset=utf-8", h.Get("Content-Type"))
+			},
+		},
+		{
+			name:        "已编码响应",
+			description: "验证已设置 Content-Encoding 的响应原样返回。",
+			accept:      "gzip",
+			handler: func(c *gin.Context) {
+				c.Header("Content-Encoding", "identity")
+				c.String(http.StatusOK, large)
+			},
+			wantStatus:   http.StatusOK,
+			wantEncoding: "identity",
+			wantBody:     large,
+		},
+		{
+			name:        "部分内容",
+			description: "验证 206 响应不会压缩。",
+			accept:      "gzip",
+			handler: func(c *gin.Context) {
+				c.Data(http.StatusPartialContent, "text/plain", []byte(large))
+			},
+			wantStatus: http.StatusPartialContent,
+			wantBody:   large,
+		},
+		{
+			name:        "未修改",
+			description: "验证 304 响应保持状态码且没有响应体。",
+			accept:      "gzip",
+			handler: func(c *gin.Context) {
+				c.Status(http.StatusNotModified)
+			},
+			wantStatus: http.StatusNotModified,
+		},
+		{
+			name:        "流式响应",
+			description: "验证 Flush 时即使小于阈值也按媒体类型压缩，并把已写入的数据发送给客户端。",
+			accept:      "gzip",
+			handler: func(c *gin.Context) {
+				c.Header("Content-Type", "text/event-stream")
+				_, _ = c.Writer.WriteString("data: 1\n\n")
+				c.Writer.Flush()
+				_, _ = c.Writer.WriteString("data: 2\n\n")
+			},
+			opts:         []CompressionOption{WithCompressionContentTypes("text/event-stream")},
+			wantStatus:   http.StatusOK,
+			wantEncoding: encodingGzip,
+			wantBody:     "data: 1\n\ndata: 2\n\n",
+		},
+		{
+			name:        "提前提交响应头",
+			description: "验证 WriteHeaderNow 后响应不再压缩。",
+			accept:      "gzip",
+			handler: func(c *gin.Context) {
+				c.Header("Content-Type", "text/plain")
+				c.Writer.WriteHeaderNow()
+				_, _ = c.Writer.WriteString(large)
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   large,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			engine := gin.New()
+			engine.GET("/", Compress(tt.opts...), tt.handler)
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if "" != tt.accept {
+				req.Header.Set("Accept-Encoding", tt.accept)
+			}
+			resp := httptest.NewRecorder()
+			engine.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.wantStatus, resp.Code)
+			assert.Equal(t, tt.wantEncoding, resp.Header().Get("Content-Encoding"))
+			assert.Equal(t, tt.wantBody, decodeBody(t, resp))
+			if nil != tt.check {
+				tt.check(resp.Header())
+			}
+		})
+	}
+}
+
+// TestParseWithCompression 测试 Parse 为 Kratos 路由与静态资源启用压缩，并优先返回预压缩文件。
+func TestParseWithCompression(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	large := strings.Repeat(`{"id":1},`, 200)
+	srv := kratoshttp.NewServer()
+	router := getRouter(srv)
+	router.Handle("/api/users", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(large))
+	})).Methods("GET")
+
+	var gz bytes.Buffer
+	zw := gzip.NewWriter(&gz)
+	_, _ = zw.Write([]byte("console.log('gz')"))
+	require.NoError(t, zw.Close())
+
+	assets := fstest.MapFS{
+		"app.js":     {Data: []byte("console.log('raw')")},
+		"app.js.gz":  {Data: gz.Bytes()},
+		"app.js.br":  {Data: []byte("brotli-bytes")},
+		"style.css":  {Data: []byte(strings.Repeat("a{}", 500))},
+		"index.html": {Data: []byte("<html></html>")},
+	}
+
+	newEngine := func(opts ...CompressionOption) *gin.Engine {
+		engine := gin.New()
+		Parse(srv, engine, WithStatic("/", assets), WithCompression(opts...))
+		return engine
+	}
+
+	tests := []struct {
+		name         string      // 测试用例名称。
+		description  string      // 用例语义说明。
+		engine       *gin.Engine // 处理请求的 Gin 引擎。
+		path         string      // 请求路径。
+		accept       string      // Accept-Encoding 请求头。
+		wantEncoding string      // 期望的 Content-Encoding。
+		wantType     string      // 期望的 Content-Type 前缀。
+		wantBody     string      // 期望解码后的响应体。
+	}{
+		{
+			name:         "Kratos 路由压缩",
+			description:  "验证代理到 Kratos 的响应被压缩。",
+			engine:       newEngine(),
+			path:         "/api/users",
+			accept:       "gzip",
+			wantEncoding: encodingGzip,
+			wantType:     "application/json",
+			wantBody:     large,
+		},
+		{
+			name:         "预压缩 br",
+			description:  "验证客户端接受 br 时返回 .br 文件，并按原扩展名设置 Content-Type。",
+			engine:       newEngine(),
+			path:         "/app.js",
+			accept:       "gzip, br",
+			wantEncoding: encodingBrotli,
+			wantType:     "text/javascript",
+			wantBody:     "brotli-bytes",
+		},
+		{
+			name:         "预压缩 gzip",
+			description:  "验证客户端只接受 gzip 时返回 .gz 文件。",
+			engine:       newEngine(),
+			path:         "/app.js",
+			accept:       "gzip",
+			wantEncoding: encodingGzip,
+			wantType:     "text/javascript",
+			wantBody:     "console.log('gz')",
+		},
+		{
+			name:        "不接受压缩时返回原文件",
+			description: "验证客户端不接受压缩时返回原始文件。",
+			engine:      newEngine(),
+			path:        "/app.js",
+			wantType:    "text/javascript",
+			wantBody:    "console.log('raw')",
+		},
+		{
+			name:        "禁用预压缩",
+			description: "验证 WithPrecompressed(false) 时忽略预压缩文件，小文件不压缩。",
+			engine:      newEngine(WithPrecompressed(false)),
+			path:        "/app.js",
+			accept:      "br, gzip",
+			wantType:    "text/javascript",
+			wantBody:    "console.log('raw')",
+		},
+		{
+			name:         "静态资源动态压缩",
+			description:  "验证没有预压缩文件的静态资源按规则动态压缩。",
+			engine:       newEngine(),
+			path:         "/style.css",
+			accept:       "gzip",
+			wantEncoding: encodingGzip,
+			wantType:     "text/css",
+			wantBody:     strings.Repeat("a{}", 500),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if "" != tt.accept {
+				req.Header.Set("Accept-Encoding", tt.accept)
+			}
+			resp := httptest.NewRecorder()
+			tt.engine.ServeHTTP(resp, req)
+
+			assert.Equal(t, http.StatusOK, resp.Code)
+			assert.Equal(t, tt.wantEncoding, resp.Header().Get("Content-Encoding"))
+			assert.True(t, strings.HasPrefix(resp.Header().Get("Content-Type"), tt.wantType), resp.Header().Get("Content-Type"))
+			if encodingBrotli == tt.wantEncoding {
+				assert.Equal(t, tt.wantBody, resp.Body.String())
+				return
+			}
+			assert.Equal(t, tt.wantBody, decodeBody(t, resp))
+		})
+	}
+}
@@ -6,6 +6,28 @@
 //
 // Parse 会遍历已注册到 kratoshttp.Server 的 mux 路由，并将其转换为等价的 Gin 路由，
 // 再把请求回送给原 kratoshttp.Server 处理。
+// WithGroup 可为指定前缀下的路由在代理之前挂载 Gin 处理器（例如 CORS 中间件），
+// 多个前缀同时匹配时使用最长前缀；Parse 会为路由组内缺少 OPTIONS 的路径补充注册 OPTIONS 路由，
+// 使预检请求能够到达路由组处理器。
+// WithStatic、WithStaticDir 和 WithSPA 通过 Gin 的 NoRoute 处理器挂载静态文件与单页应用回退，
+// 支持 embed.FS 并可配置 Cache-Control，Kratos 路由始终优先。
+// WithCompression 为上述路由与静态资源启用 gzip/deflate 响应压缩，按 Accept-Encoding 协商编码，
+// 只压缩超过最小字节数且媒体类型在允许列表中的响应，静态资源优先返回同名的 .br、.gz 预压缩文件；
+// Compress 提供同样规则的独立 Gin 处理器。
+// HandleFunc 与 Handle 在注册路由时记录路由选项，WithRoute 按路径为其它路由补充选项，
+// Parse 据此为单条路由挂载请求体限制、超时、认证（WithAuthenticator）与 Gin 处理器，
+// 未配置认证处理器时需要认证的路由一律返回 401。
+// WithRouteUpload 按 FileRule 声明的字段、大小上限与 MIME 类型在认证之后解析 multipart 请求，
+// 文件保存为临时文件并通过 UploadFromContext 交给处理器，处理器返回后删除；
+// StreamFiles 与 ParseFiles 提供同样的校验，前者以流的方式逐个读取文件，
+// 校验失败返回可映射为 400、413、415 状态码的 *FileError。
+// Bind 与 BindRequest 按 Content-Type 解码请求体，再按字段的 query、header、path 标签
+// 依次覆盖，使处理器无需手动解析上下文；标签支持 split 拆分逗号列表、required 必填与
+// layout 等参数，time.Time 默认与 kit/time 配置的 carbon 布局和时区保持一致，
+// 其它类型可通过 RegisterDecoder 或 NewBinder 注册解码器。
+// WithOpenAPI 在 Parse 时根据路由表生成 OpenAPI 3 文档并挂载 Swagger UI，请求与响应结构来自
+// WithRouteDoc 声明的类型，按与 Bind 相同的标签规则划分路径、查询、请求头参数与 JSON 请求体；
+// GenerateOpenAPI 直接返回 JSON 文档，便于在构建阶段导出。
 // GetPaths 提供路由提取辅助，主要用于本包桥接逻辑和调试场景；当前 RouteInfo 的字段未导出，
 // 包外调用方无法直接读取其中的 method 和 path。
 // 本包不创建 HTTP server，也不替换 Kratos 中间件、编解码或错误处理链语义；
@@ -0,0 +1,129 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package http
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	// ParseOption 配置 Parse 的路由注册行为。
+	ParseOption func(*parseOptions)
+
+	// parseOptions 包含 Parse 的配置选项。
+	parseOptions struct {
+		// groups 是按前缀挂载 Gin 处理器的路由组。
+		groups []routeGroup
+
+		// statics 是通过 NoRoute 提供的静态资源挂载。
+		statics []*staticMount
+
+		// compression 是响应压缩配置，为 nil 时不压缩。
+		compression *compression
+
+		// routes 是通过 WithRoute 按 Gin 格式路径设置的路由选项。
+		routes map[string][]RouteOption
+
+		// authenticators 是需要认证的路由使用的认证处理器。
+		authenticators []gin.HandlerFunc
+
+		// openapi 是 WithOpenAPI 设置的文档配置，为 nil 时不提供文档。
+		openapi *openAPIOptions
+	}
+
+	// routeGroup 表示一组共享 Gin 处理器的路由前缀。
+	routeGroup struct {
+		// prefix 是 Gin 格式的路由前缀，不带末尾斜杠。
+		prefix string
+
+		// handlers 是在代理到 Kratos 之前执行的 Gin 处理器。
+		handlers []gin.HandlerFunc
+	}
+)
+
+// WithGroup 为指定前缀下的路由挂载 Gin 处理器。
+//
+// 参数：
+//   - prefix：路由前缀，按路径段匹配，例如 `/api` 匹配 `/api` 和 `/api/users`，但不匹配 `/apis`。
+//   - handlers：在请求代理到 Kratos 之前依次执行的 Gin 处理器，例如 CORS 中间件。
+//
+// 返回值：
+//   - ParseOption：Parse 的配置选项。
+//
+// 多个路由组的前缀同时匹配时，只使用最长前缀的处理器。
+// 路由组内的路由若没有在 Kratos 中注册 OPTIONS 方法，Parse 会额外注册 OPTIONS 路由，
+// 使预检请求能够经过路由组的处理器。
+func WithGroup(prefix string, handlers ...gin.HandlerFunc) ParseOption {
+	return func(o *parseOptions) {
+		o.groups = append(o.groups, routeGroup{
+			prefix:   strings.TrimRight(parsePath(prefix), "/"),
+			handlers: handlers,
+		})
+	}
+}
+
+// match 返回路径所属路由组的处理器。
+//
+// 参数：
+//   - path：Gin 格式的路由路径。
+//
+// 返回值：
+//   - []gin.HandlerFunc：最长匹配前缀的处理器；没有匹配的路由组时返回 nil。
+//   - bool：是否匹配到路由组。
+func (o *parseOptions) match(path string) ([]gin.HandlerFunc, bool) {
+	var handlers []gin.HandlerFunc
+	matched := false
+	longest := -1
+
+	for _, g := range o.groups {
+		// 前缀为 "/" 时裁剪后为空串，匹配所有路径。
+		if path != g.prefix && !strings.HasPrefix(path, g.prefix+"/") {
+			continue
+		}
+		if len(g.prefix) > longest {
+			longest = len(g.prefix)
+			handlers = g.handlers
+			matched = true
+		}
+	}
+
+	return handlers, matched
+}
+
+// chain 返回路径在 Gin 中的完整处理器链。
+//
+// 参数：
+//   - path：Gin 格式的路由路径。
+//   - route：路由配置，为 nil 时不挂载路由级处理器。
+//   - proxy：将请求代理到 Kratos 的处理器。
+//
+// 返回值：
+//   - []gin.HandlerFunc：启用压缩时的压缩处理器、路由组处理器、路由级处理器加上代理处理器。
+//   - bool：是否匹配到路由组。
+func (o *parseOptions) chain(path string, route *routeOptions, proxy gin.HandlerFunc) ([]gin.HandlerFunc, bool) {
+	handlers, matched := o.match(path)
+	routeHandlers := route.chain(o.authenticators)
+	chain := make([]gin.HandlerFunc, 0, len(handlers)+len(routeHandlers)+2)
+	if nil != o.compression {
+		chain = append(chain, o.compression.handler())
+	}
+	chain = append(chain, handlers...)
+	chain = append(chain, routeHandlers...)
+	return append(chain, proxy), matched
+}
+
+// isOptions 判断方法是否为 OPTIONS。
+//
+// 参数：
+//   - method：HTTP 方法。
+//
+// 返回值：
+//   - bool：方法为 OPTIONS 时返回 true。
+func isOptions(method string) bool {
+	return strings.EqualFold(http.MethodOptions, method)
+}
@@ -0,0 +1,186 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	kratoshttp "github.com/go-kratos/kratos/v2/transport/http"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseOptionsMatch 测试路由组按路径段匹配最长前缀。
+func TestParseOptionsMatch(t *testing.T) {
+	root := func(c *gin.Context) { c.Header("X-Group", "root") }
+	api := func(c *gin.Context) { c.Header("X-Group", "api") }
+	admin := func(c *gin.Context) { c.Header("X-Group", "admin") }
+
+	// 定义测试用例。
+	tests := []struct {
+		name        string          // 测试用例名称。
+		description string          // 用例语义说明。
+		groups      []ParseOption   // 路由组配置。
+		path        string          // 待匹配的 Gin 路径。
+		wantMatched bool            // 是否期望匹配。
+		wantHandler gin.HandlerFunc // 期望的处理器。
+	}{
+		{
+			name:        "精确匹配前缀",
+			description: "验证路径与前缀完全相同时匹配该路由组。",
+			groups:      []ParseOption{WithGroup("/api", api)},
+			path:        "/api",
+			wantMatched: true,
+			wantHandler: api,
+		},
+		{
+			name:        "按路径段匹配",
+			description: "验证前缀只匹配完整路径段，/api 不匹配 /apis。",
+			groups:      []ParseOption{WithGroup("/api/", api)},
+			path:        "/apis/users",
+			wantMatched: false,
+		},
+		{
+			name:        "最长前缀优先",
+			description: "验证多个路由组同时匹配时使用最长前缀的处理器。",
+			groups:      []ParseOption{WithGroup("/", root), WithGroup("/api", api), WithGroup("/api/admin", admin)},
+			path:        "/api/admin/users/:id",
+			wantMatched: true,
+			wantHandler: admin,
+		},
+		{
+			name:        "根前缀匹配所有路径",
+			description: "验证前缀为 / 的路由组匹配所有路径。",
+			groups:      []ParseOption{WithGroup("/", root), WithGroup("/api", api)},
+			path:        "/health",
+			wantMatched: true,
+			wantHandler: root,
+		},
+	}
+
+	// 执行测试用例。
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			o := &parseOptions{}
+			for _, opt := range tt.groups {
+				opt(o)
+			}
+
+			handlers, matched := o.match(tt.path)
+			assert.Equal(t, tt.wantMatched, matched)
+			if tt.wantMatched {
+				assert.Len(t, handlers, 1)
+				w := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(w)
+				handlers[0](c)
+				want := httptest.NewRecorder()
+				wc, _ := gin.CreateTestContext(want)
+				tt.wantHandler(wc)
+				assert.Equal(t, want.Header().Get("X-Group"), w.Header().Get("X-Group"))
+			}
+		})
+	}
+}
+
+// TestParseWithGroup 测试 Parse 为路由组挂载处理器并补充 OPTIONS 路由。
+func TestParseWithGroup(t *testing.T) {
+	// 设置 Gin 为测试模式。
+	gin.SetMode(gin.TestMode)
+
+	// 创建服务器并注册路由，其中 /api/items 已在 Kratos 中注册 OPTIONS。
+	srv := kratoshttp.NewServer()
+	router := getRouter(srv)
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Method", r.Method)
+		w.WriteHeader(http.StatusOK)
+	})
+	router.Handle("/api/users/{id}", ok).Methods("GET", "POST")
+	router.Handle("/api/items", ok).Methods("GET", "OPTIONS")
+	router.Handle("/health", ok).Methods("GET")
+
+	// 路由组处理器标记请求，遇到 OPTIONS 时直接终止。
+	group := func(c *gin.Context) {
+		c.Header("X-Group", "api")
+		if http.MethodOptions == c.Request.Method {
+			c.AbortWithStatus(http.StatusNoContent)
+		}
+	}
+
+	engine := gin.New()
+	assert.NotPanics(t, func() {
+		Parse(srv, engine, WithGroup("/api", group))
+	})
+
+	// 定义测试用例。
+	tests := []struct {
+		name        string // 测试用例名称。
+		description string // 用例语义说明。
+		method      string // 请求方法。
+		path        string // 请求路径。
+		wantStatus  int    // 期望状态码。
+		wantGroup   string // 期望的路由组标记。
+		wantMethod  string // 期望 Kratos 处理器看到的方法。
+	}{
+		{
+			name:        "路由组内请求",
+			description: "验证路由组内的请求先经过路由组处理器再代理到 Kratos。",
+			method:      http.MethodGet,
+			path:        "/api/users/1",
+			wantStatus:  http.StatusOK,
+			wantGroup:   "api",
+			wantMethod:  http.MethodGet,
+		},
+		{
+			name:        "补充的 OPTIONS 路由",
+			description: "验证 Parse 为路由组内缺少 OPTIONS 的路径补充注册，预检请求到达路由组处理器。",
+			method:      http.MethodOptions,
+			path:        "/api/users/1",
+			wantStatus:  http.StatusNoContent,
+			wantGroup:   "api",
+		},
+		{
+			name:        "已注册的 OPTIONS 路由",
+			description: "验证 Kratos 已注册 OPTIONS 的路径不会重复注册，仍经过路由组处理器。",
+			method:      http.MethodOptions,
+			path:        "/api/items",
+			wantStatus:  http.StatusNoContent,
+			wantGroup:   "api",
+		},
+		{
+			name:        "路由组外请求",
+			description: "验证路由组外的请求不经过路由组处理器。",
+			method:      http.MethodGet,
+			path:        "/health",
+			wantStatus:  http.StatusOK,
+			wantMethod:  http.MethodGet,
+		},
+		{
+			name:        "路由组外不补充 OPTIONS",
+			description: "验证路由组外的路径不会补充注册 OPTIONS 路由。",
+			method:      http.MethodOptions,
+			path:        "/health",
+			wantStatus:  http.StatusNotFound,
+		},
+	}
+
+	// 执行测试用例。
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			resp := httptest.NewRecorder()
+			engine.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.wantStatus, resp.Code)
+			assert.Equal(t, tt.wantGroup, resp.Header().Get("X-Group"))
+			assert.Equal(t, tt.wantMethod, resp.Header().Get("X-Method"))
+		})
+	}
+}
@@ -0,0 +1,655 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package http
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// sniffLength 是嗅探文件 MIME 类型读取的字节数，与 http.DetectContentType 使用的长度一致。
+	sniffLength = 512
+
+	// maxFormValueBytesDefault 是非文件表单字段累计字节数的默认上限。
+	maxFormValueBytesDefault int64 = 1 << 20
+)
+
+var (
+	// ErrMultipart 表示请求不是合法的 multipart/form-data 请求，或非文件表单字段超过上限。
+	ErrMultipart = errors.New("解析 multipart 请求失败")
+
+	// ErrFileMissing 表示必需的上传文件缺失。
+	ErrFileMissing = errors.New("缺少上传文件")
+
+	// ErrFileTooLarge 表示上传文件超过字段允许的最大字节数。
+	ErrFileTooLarge = errors.New("上传文件过大")
+
+	// ErrFileType 表示上传文件的 MIME 类型不在字段允许的列表中。
+	ErrFileType = errors.New("上传文件类型不允许")
+
+	// ErrTooManyFiles 表示同一字段的上传文件数量超过上限。
+	ErrTooManyFiles = errors.New("上传文件数量过多")
+
+	// ErrUnexpectedFile 表示请求包含未声明的文件字段。
+	ErrUnexpectedFile = errors.New("未声明的上传文件")
+)
+
+type (
+	// FileRule 声明一个文件字段的校验规则。
+	FileRule struct {
+		// Field 是表单字段名。
+		Field string
+
+		// MaxSize 是单个文件的最大字节数，小于等于 0 时不限制。
+		MaxSize int64
+
+		// AllowedTypes 是允许的 MIME 类型，支持 image/* 形式的通配；为空时不限制。
+		AllowedTypes []string
+
+		// Required 表示请求中至少需要包含一个该字段的文件。
+		Required bool
+
+		// MaxFiles 是该字段允许的文件数量，小于等于 0 时为 1。
+		MaxFiles int
+	}
+
+	// FileError 是上传文件未通过校验时返回的结构化错误。
+	//
+	// 可以用 errors.Is 判断 ErrFileMissing、ErrFileTooLarge、ErrFileType、ErrTooManyFiles 与 ErrUnexpectedFile，
+	// 用 errors.As 取出字段、文件名等信息构造响应。
+	FileError struct {
+		// Field 是表单字段名。
+		Field string
+
+		// Filename 是客户端提供的文件名，ErrFileMissing 时为空。
+		Filename string
+
+		// ContentType 是检测到的 MIME 类型，仅 ErrFileType 时设置。
+		ContentType string
+
+		// Limit 是超出的上限：ErrFileTooLarge 时为最大字节数，ErrTooManyFiles 时为最大文件数。
+		Limit int64
+
+		// Err 是错误类别，为本包定义的文件错误之一。
+		Err error
+	}
+
+	// FilePart 是以流的方式读取的上传文件。
+	//
+	// 读取的累计字节数超过 FileRule.MaxSize 时，Read 返回 ErrFileTooLarge 类别的 *FileError。
+	FilePart struct {
+		// Field 是表单字段名。
+		Field string
+
+		// Filename 是客户端提供的文件名，未经清理，保存到磁盘前应只取其基本名称。
+		Filename string
+
+		// ContentType 是按文件内容嗅探得到的 MIME 类型，规则见 detectContentType。
+		ContentType string
+
+		// Header 是 part 的原始请求头。
+		Header textproto.MIMEHeader
+
+		// reader 依次读取嗅探时缓存的内容与剩余内容。
+		reader io.Reader
+
+		// maxSize 是最大字节数，小于等于 0 时不限制。
+		maxSize int64
+
+		// size 是已读取的字节数。
+		size int64
+
+		// err 是超出大小上限后持续返回的错误。
+		err error
+	}
+
+	// UploadedFile 是保存到临时文件的上传文件。
+	UploadedFile struct {
+		// Field 是表单字段名。
+		Field string
+
+		// Filename 是客户端提供的文件名，未经清理。
+		Filename string
+
+		// ContentType 是按文件内容嗅探得到的 MIME 类型。
+		ContentType string
+
+		// Size 是文件字节数。
+		Size int64
+
+		// Path 是临时文件路径；需要保留文件时应在处理器返回前移动到其他位置。
+		Path string
+	}
+
+	// MultipartForm 是 ParseFiles 解析得到的表单。
+	MultipartForm struct {
+		// Values 是非文件表单字段。
+		Values url.Values
+
+		// Files 是按字段名分组的上传文件，顺序与请求中的顺序一致。
+		Files map[string][]*UploadedFile
+	}
+
+	// MultipartOption 配置 multipart 请求的解析行为。
+	MultipartOption func(*multipartOptions)
+
+	// multipartOptions 包含 multipart 请求的解析选项。
+	multipartOptions struct {
+		// tempDir 是保存上传文件的目录，为空时使用 os.TempDir。
+		tempDir string
+
+		// maxValueBytes 是非文件表单字段的累计字节数上限。
+		maxValueBytes int64
+
+		// allowUnknown 表示未声明的文件字段被丢弃而不是返回 ErrUnexpectedFile。
+		allowUnknown bool
+	}
+
+	// uploadRoute 是 WithRouteUpload 配置的文件规则与解析选项。
+	uploadRoute struct {
+		// rules 是文件字段的校验规则。
+		rules []FileRule
+
+		// opts 是解析选项。
+		opts []MultipartOption
+	}
+
+	// uploadContextKey 是 MultipartForm 在请求上下文中的键。
+	uploadContextKey struct{}
+)
+
+// Error 返回包含错误类别、字段名与文件名的描述。
+//
+// 返回值：
+//   - string：错误描述。
+func (e *FileError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%v: 字段 %s", e.Err, e.Field)
+	if "" != e.Filename {
+		fmt.Fprintf(&b, " 文件 %q", e.Filename)
+	}
+	switch {
+	case errors.Is(e.Err, ErrFileType):
+		fmt.Fprintf(&b, " 类型 %s", e.ContentType)
+	case errors.Is(e.Err, ErrFileTooLarge):
+		fmt.Fprintf(&b, " 超过 %d 字节", e.Limit)
+	case errors.Is(e.Err, ErrTooManyFiles):
+		fmt.Fprintf(&b, " 超过 %d 个", e.Limit)
+	}
+	return b.String()
+}
+
+// Unwrap 返回错误类别。
+//
+// 返回值：
+//   - error：本包定义的文件错误之一。
+func (e *FileError) Unwrap() error {
+	return e.Err
+}
+
+// StatusCode 返回与错误类别对应的 HTTP 状态码。
+//
+// 返回值：
+//   - int：ErrFileTooLarge 为 413，ErrFileType 为 415，其它为 400。
+func (e *FileError) StatusCode() int {
+	switch {
+	case errors.Is(e.Err, ErrFileTooLarge):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(e.Err, ErrFileType):
+		return http.StatusUnsupportedMediaType
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+// WithUploadTempDir 设置 ParseFiles 保存上传文件的目录。
+//
+// 参数：
+//   - dir：目录，为空时使用 os.TempDir。
+//
+// 返回值：
+//   - MultipartOption：multipart 解析选项。
+func WithUploadTempDir(dir string) MultipartOption {
+	return func(o *multipartOptions) {
+		o.tempDir = dir
+	}
+}
+
+// WithMaxFormValueBytes 设置非文件表单字段的累计字节数上限。
+//
+// 参数：
+//   - limit：最大字节数，小于等于 0 时使用默认值 1 MiB。
+//
+// 返回值：
+//   - MultipartOption：multipart 解析选项。
+func WithMaxFormValueBytes(limit int64) MultipartOption {
+	return func(o *multipartOptions) {
+		if limit > 0 {
+			o.maxValueBytes = limit
+		}
+	}
+}
+
+// WithAllowUnknownFiles 设置是否丢弃未声明的文件字段。
+//
+// 参数：
+//   - allow：为 true 时读取并丢弃未声明字段的文件，为 false 时返回 ErrUnexpectedFile。
+//
+// 返回值：
+//   - MultipartOption：multipart 解析选项。
+func WithAllowUnknownFiles(allow bool) MultipartOption {
+	return func(o *multipartOptions) {
+		o.allowUnknown = allow
+	}
+}
+
+// StreamFiles 以流的方式逐个读取 multipart 请求中的文件，不在内存或磁盘中缓存文件内容。
+//
+// 每个文件在通过字段、数量与类型校验后交给 fn 读取；fn 返回后剩余内容会被读取并丢弃，
+// 以保证超过大小上限的文件总能被发现。文件内容只能在 fn 内读取。
+// 全部 part 读取完成后检查 Required 字段是否都已出现。
+//
+// 参数：
+//   - r：HTTP 请求，在 Kratos 处理器中传入 ctx.Request()。
+//   - rules：文件字段的校验规则。
+//   - fn：处理单个文件的函数，返回错误时停止读取并原样返回该错误。
+//   - opts：解析选项。
+//
+// 返回值：
+//   - url.Values：非文件表单字段，只包含出错前已读取的字段。
+//   - error：请求无法解析时返回包装了 ErrMultipart 的错误，文件未通过校验时返回 *FileError，fn 的错误原样返回。
+func StreamFiles(r *http.Request, rules []FileRule, fn func(part *FilePart) error, opts ...MultipartOption) (url.Values, error) {
+	o := newMultipartOptions(opts)
+	mr, err := r.MultipartReader()
+	if nil != err {
+		return nil, fmt.Errorf("%w: %w", ErrMultipart, err)
+	}
+
+	ruleMap := make(map[string]FileRule, len(rules))
+	for _, rule := range rules {
+		ruleMap[rule.Field] = rule
+	}
+	counts := make(map[string]int, len(rules))
+	values := url.Values{}
+	remaining := o.maxValueBytes
+
+	for {
+		p, err := mr.NextPart()
+		if io.EOF == err {
+			break
+		}
+		if nil != err {
+			return values, fmt.Errorf("%w: %w", ErrMultipart, err)
+		}
+
+		name := p.FormName()
+		if "" == name {
+			_ = p.Close()
+			continue
+		}
+		if "" == p.FileName() {
+			data, err := io.ReadAll(io.LimitReader(p, remaining+1))
+			_ = p.Close()
+			if nil != err {
+				return values, fmt.Errorf("%w: %w", ErrMultipart, err)
+			}
+			if remaining -= int64(len(data)); remaining < 0 {
+				return values, fmt.Errorf("%w: 表单字段超过 %d 字节", ErrMultipart, o.maxValueBytes)
+			}
+			values.Add(name, string(data))
+			continue
+		}
+
+		rule, ok := ruleMap[name]
+		if !ok {
+			if !o.allowUnknown {
+				_ = p.Close()
+				return values, &FileError{Field: name, Filename: p.FileName(), Err: ErrUnexpectedFile}
+			}
+			_, err := io.Copy(io.Discard, p)
+			_ = p.Close()
+			if nil != err {
+				return values, fmt.Errorf("%w: %w", ErrMultipart, err)
+			}
+			continue
+		}
+
+		if counts[name]++; counts[name] > rule.maxFiles() {
+			_ = p.Close()
+			return values, &FileError{Field: name, Filename: p.FileName(), Limit: int64(rule.maxFiles()), Err: ErrTooManyFiles}
+		}
+		if err := streamPart(p, rule, fn); nil != err {
+			return values, err
+		}
+	}
+
+	for _, rule := range rules {
+		if rule.Required && 0 == counts[rule.Field] {
+			return values, &FileError{Field: rule.Field, Err: ErrFileMissing}
+		}
+	}
+	return values, nil
+}
+
+// ParseFiles 读取 multipart 请求，把通过校验的文件保存为临时文件。
+//
+// 校验规则与 StreamFiles 相同；任一文件未通过校验时已保存的临时文件会被删除。
+// 调用方负责在使用完毕后调用 MultipartForm.RemoveAll。
+//
+// 参数：
+//   - r：HTTP 请求，在 Kratos 处理器中传入 ctx.Request()。
+//   - rules：文件字段的校验规则。
+//   - opts：解析选项，例如 WithUploadTempDir。
+//
+// 返回值：
+//   - *MultipartForm：非文件表单字段与上传文件。
+//   - error：语义同 StreamFiles，创建或写入临时文件失败时返回对应错误。
+func ParseFiles(r *http.Request, rules []FileRule, opts ...MultipartOption) (*MultipartForm, error) {
+	o := newMultipartOptions(opts)
+	form := &MultipartForm{Files: make(map[string][]*UploadedFile)}
+	values, err := StreamFiles(r, rules, func(part *FilePart) error {
+		f, err := os.CreateTemp(o.tempDir, "kit-upload-*")
+		if nil != err {
+			return err
+		}
+		// 先登记再写入，保证出错时 RemoveAll 能删除该文件。
+		file := &UploadedFile{Field: part.Field, Filename: part.Filename, ContentType: part.ContentType, Path: f.Name()}
+		form.Files[part.Field] = append(form.Files[part.Field], file)
+
+		file.Size, err = io.Copy(f, part)
+		if closeErr := f.Close(); nil == err {
+			err = closeErr
+		}
+		return err
+	}, opts...)
+	if nil != err {
+		_ = form.RemoveAll()
+		return nil, err
+	}
+	form.Values = values
+	return form, nil
+}
+
+// WithRouteUpload 声明路由接收的上传文件。
+//
+// 参数：
+//   - rules：文件字段的校验规则。
+//   - opts：解析选项。
+//
+// 返回值：
+//   - RouteOption：路由配置选项。
+//
+// Gin 侧在认证之后、路由级处理器与请求代理到 Kratos 之前调用 ParseFiles，文件未通过校验时按 FileError.StatusCode 返回，
+// 请求体无法解析时返回 400，超过 WithRouteBodyLimit 时返回 413，保存临时文件失败时返回 500；
+// 错误同时通过 gin.Context.Error 记录。
+// 校验通过后，处理器通过 UploadFromContext(r.Context()) 读取表单，请求体已被读取而替换为空；
+// 临时文件在处理器返回后删除。
+func WithRouteUpload(rules []FileRule, opts ...MultipartOption) RouteOption {
+	return func(o *routeOptions) {
+		o.upload = &uploadRoute{rules: rules, opts: opts}
+	}
+}
+
+// UploadFromContext 返回 WithRouteUpload 解析得到的表单。
+//
+// 参数：
+//   - ctx：请求上下文，在 Kratos 处理器中传入 ctx.Request().Context()。
+//
+// 返回值：
+//   - *MultipartForm：解析得到的表单。
+//   - bool：路由未配置 WithRouteUpload 时返回 false。
+func UploadFromContext(ctx context.Context) (*MultipartForm, bool) {
+	form, ok := ctx.Value(uploadContextKey{}).(*MultipartForm)
+	return form, ok
+}
+
+// File 返回字段的第一个上传文件。
+//
+// 参数：
+//   - field：表单字段名。
+//
+// 返回值：
+//   - *UploadedFile：上传文件，字段没有文件时返回 nil。
+func (f *MultipartForm) File(field string) *UploadedFile {
+	if files := f.Files[field]; len(files) > 0 {
+		return files[0]
+	}
+	return nil
+}
+
+// RemoveAll 删除全部临时文件，已被移走的文件会被忽略。
+//
+// 返回值：
+//   - error：删除失败时返回第一个错误。
+func (f *MultipartForm) RemoveAll() error {
+	var first error
+	for _, files := range f.Files {
+		for _, file := range files {
+			if err := os.Remove(file.Path); nil != err && !errors.Is(err, os.ErrNotExist) && nil == first {
+				first = err
+			}
+		}
+	}
+	return first
+}
+
+// Open 打开上传文件的临时文件。
+//
+// 返回值：
+//   - *os.File：只读文件，调用方负责关闭。
+//   - error：打开失败时返回错误。
+func (f *UploadedFile) Open() (*os.File, error) {
+	return os.Open(f.Path)
+}
+
+// Read 读取文件内容。
+//
+// 参数：
+//   - b：读取缓冲区。
+//
+// 返回值：
+//   - int：读取的字节数。
+//   - error：读取结束时返回 io.EOF，超过大小上限时返回 ErrFileTooLarge 类别的 *FileError。
+func (p *FilePart) Read(b []byte) (int, error) {
+	if nil != p.err {
+		return 0, p.err
+	}
+	n, err := p.reader.Read(b)
+	p.size += int64(n)
+	if p.maxSize > 0 && p.size > p.maxSize {
+		n -= int(p.size - p.maxSize)
+		p.size = p.maxSize
+		p.err = &FileError{Field: p.Field, Filename: p.Filename, Limit: p.maxSize, Err: ErrFileTooLarge}
+		return n, p.err
+	}
+	return n, err
+}
+
+// Size 返回已读取的字节数。
+//
+// 返回值：
+//   - int64：已读取的字节数。
+func (p *FilePart) Size() int64 {
+	return p.size
+}
+
+// maxFiles 返回字段允许的文件数量。
+//
+// 返回值：
+//   - int：MaxFiles 小于等于 0 时为 1。
+func (r FileRule) maxFiles() int {
+	return max(r.MaxFiles, 1)
+}
+
+// allows 判断 MIME 类型是否在允许列表中。
+//
+// 参数：
+//   - mediaType：不含参数的 MIME 类型。
+//
+// 返回值：
+//   - bool：允许列表为空，或类型与任一项相同或匹配其 type/* 通配时返回 true。
+func (r FileRule) allows(mediaType string) bool {
+	if 0 == len(r.AllowedTypes) {
+		return true
+	}
+	for _, allowed := range r.AllowedTypes {
+		allowed = strings.ToLower(strings.TrimSpace(allowed))
+		if allowed == mediaType || "*/*" == allowed {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(allowed, "/*"); ok && strings.HasPrefix(mediaType, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// newMultipartOptions 依次应用 multipart 解析选项。
+//
+// 参数：
+//   - opts：解析选项。
+//
+// 返回值：
+//   - multipartOptions：应用后的选项。
+func newMultipartOptions(opts []MultipartOption) multipartOptions {
+	o := multipartOptions{maxValueBytes: maxFormValueBytesDefault}
+	for _, opt := range opts {
+		if nil != opt {
+			opt(&o)
+		}
+	}
+	return o
+}
+
+// streamPart 嗅探文件类型并交给 fn 读取，fn 返回后读取并丢弃剩余内容。
+//
+// 参数：
+//   - p：multipart 中的文件 part。
+//   - rule：字段的校验规则。
+//   - fn：处理文件的函数。
+//
+// 返回值：
+//   - error：文件未通过校验时返回 *FileError，读取失败时返回包装了 ErrMultipart 的错误，fn 的错误原样返回。
+func streamPart(p *multipart.Part, rule FileRule, fn func(part *FilePart) error) error {
+	defer func() { _ = p.Close() }()
+
+	head := make([]byte, sniffLength)
+	n, err := io.ReadFull(p, head)
+	if nil != err && io.EOF != err && io.ErrUnexpectedEOF != err {
+		return fmt.Errorf("%w: %w", ErrMultipart, err)
+	}
+	head = head[:n]
+
+	contentType := detectContentType(head, p.Header.Get("Content-Type"))
+	if !rule.allows(contentType) {
+		return &FileError{Field: rule.Field, Filename: p.FileName(), ContentType: contentType, Err: ErrFileType}
+	}
+
+	part := &FilePart{
+		Field:       rule.Field,
+		Filename:    p.FileName(),
+		ContentType: contentType,
+		Header:      p.Header,
+		reader:      io.MultiReader(bytes.NewReader(head), p),
+		maxSize:     rule.MaxSize,
+	}
+	if err := fn(part); nil != err {
+		return err
+	}
+	if _, err := io.Copy(io.Discard, part); nil != err {
+		var fileErr *FileError
+		if errors.As(err, &fileErr) {
+			return err
+		}
+		return fmt.Errorf("%w: %w", ErrMultipart, err)
+	}
+	return nil
+}
+
+// detectContentType 按内容嗅探 MIME 类型，无法识别时使用声明的类型。
+//
+// 纯文本无法按内容区分 CSV、Markdown 等格式，嗅探结果为 text/plain 且声明的类型同为 text/* 时使用声明的类型。
+//
+// 参数：
+//   - head：文件开头最多 512 字节的内容。
+//   - declared：part 声明的 Content-Type。
+//
+// 返回值：
+//   - string：小写、不含参数的 MIME 类型。
+func detectContentType(head []byte, declared string) string {
+	detected, _, _ := mime.ParseMediaType(http.DetectContentType(head))
+	mediaType, _, err := mime.ParseMediaType(declared)
+	if nil != err {
+		return detected
+	}
+	switch {
+	case "application/octet-stream" == detected:
+		return mediaType
+	case "text/plain" == detected && strings.HasPrefix(mediaType, "text/"):
+		return mediaType
+	default:
+		return detected
+	}
+}
+
+// uploadHandler 返回按文件规则解析请求并把表单放入请求上下文的 Gin 处理器。
+//
+// 参数：
+//   - u：WithRouteUpload 配置的文件规则与解析选项。
+//
+// 返回值：
+//   - gin.HandlerFunc：解析失败时按错误类型返回 400、413、415 或 500，后续处理器返回后删除临时文件。
+func uploadHandler(u *uploadRoute) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		form, err := ParseFiles(c.Request, u.rules, u.opts...)
+		if nil != err {
+			_ = c.Error(err)
+			c.AbortWithStatus(uploadStatus(err))
+			return
+		}
+		defer func() { _ = form.RemoveAll() }()
+
+		req := c.Request.WithContext(context.WithValue(c.Request.Context(), uploadContextKey{}, form))
+		req.Body = http.NoBody
+		req.ContentLength = 0
+		c.Request = req
+		c.Next()
+	}
+}
+
+// uploadStatus 返回解析上传请求失败时的 HTTP 状态码。
+//
+// 参数：
+//   - err：ParseFiles 返回的错误。
+//
+// 返回值：
+//   - int：*FileError 使用其 StatusCode，请求体超过限制为 413，请求无法解析为 400，保存临时文件失败为 500。
+func uploadStatus(err error) int {
+	var fileErr *FileError
+	if errors.As(err, &fileErr) {
+		return fileErr.StatusCode()
+	}
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return http.StatusRequestEntityTooLarge
+	}
+	if errors.Is(err, ErrMultipart) {
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
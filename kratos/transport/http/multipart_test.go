@@ -0,0 +1,332 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package http
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	kratoshttp "github.com/go-kratos/kratos/v2/transport/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pngHeader 是 PNG 文件签名，http.DetectContentType 据此识别为 image/png。
+var pngHeader = []byte("\x89PNG\r\n\x1a\n")
+
+// multipartPart 是构造测试请求使用的表单字段。
+type multipartPart struct {
+	field    string
+	filename string
+	// contentType 是 part 声明的 Content-Type，为空时不设置。
+	contentType string
+	body        []byte
+}
+
+// newMultipartRequest 构造 multipart/form-data 请求。
+//
+// 参数：
+//   - t：测试上下文，用于报告构造失败。
+//   - parts：表单字段，filename 为空时写入普通字段。
+//
+// 返回值：
+//   - *http.Request：POST /upload 请求。
+func newMultipartRequest(t *testing.T, parts ...multipartPart) *http.Request {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	for _, p := range parts {
+		if "" == p.filename {
+			require.NoError(t, w.WriteField(p.field, string(p.body)))
+			continue
+		}
+		header := make(textproto.MIMEHeader)
+		header.Set("Content-Disposition", `form-data; name="`+p.field+`"; filename="`+p.filename+`"`)
+		if "" != p.contentType {
+			header.Set("Content-Type", p.contentType)
+		}
+		pw, err := w.CreatePart(header)
+		require.NoError(t, err)
+		_, err = pw.Write(p.body)
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/upload", &buf)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	return req
+}
+
+// TestParseFiles 验证上传文件的校验规则、结构化错误与临时文件。
+func TestParseFiles(t *testing.T) {
+	avatar := FileRule{Field: "avatar", MaxSize: 64, AllowedTypes: []string{"image/*"}, Required: true}
+	docs := FileRule{Field: "docs", MaxFiles: 2, AllowedTypes: []string{"text/csv", "application/pdf"}}
+
+	tests := []struct {
+		name        string
+		description string
+		rules       []FileRule
+		opts        []MultipartOption
+		parts       []multipartPart
+		wantErr     error
+		wantStatus  int
+		wantField   string
+		check       func(t *testing.T, form *MultipartForm)
+	}{
+		{
+			name:        "success/files-and-values",
+			description: "验证文件按内容嗅探类型并保存为临时文件，同名字段保留多个文件，普通字段写入 Values。",
+			rules:       []FileRule{avatar, docs},
+			parts: []multipartPart{
+				{field: "title", body: []byte("report")},
+				{field: "avatar", filename: "a.png", contentType: "application/octet-stream", body: append(pngHeader, "data"...)},
+				{field: "docs", filename: "a.csv", contentType: "text/csv", body: []byte("id,name\n1,kit\n")},
+				{field: "docs", filename: "b.csv", contentType: "text/csv", body: []byte("id\n")},
+			},
+			check: func(t *testing.T, form *MultipartForm) {
+				assert.Equal(t, "report", form.Values.Get("title"))
+				file := form.File("avatar")
+				require.NotNil(t, file)
+				assert.Equal(t, "a.png", file.Filename)
+				assert.Equal(t, "image/png", file.ContentType)
+				assert.Equal(t, int64(len(pngHeader)+4), file.Size)
+				f, err := file.Open()
+				require.NoError(t, err)
+				data, err := io.ReadAll(f)
+				require.NoError(t, f.Close())
+				require.NoError(t, err)
+				assert.Equal(t, append(pngHeader, "data"...), data)
+
+				require.Len(t, form.Files["docs"], 2)
+				assert.Equal(t, "text/csv", form.Files["docs"][0].ContentType)
+				assert.Equal(t, "b.csv", form.Files["docs"][1].Filename)
+				assert.Nil(t, form.File("missing"))
+			},
+		},
+		{
+			name:        "error/missing-required",
+			description: "验证缺少必需文件时返回 ErrFileMissing，对应 400。",
+			rules:       []FileRule{avatar},
+			parts:       []multipartPart{{field: "title", body: []byte("x")}},
+			wantErr:     ErrFileMissing,
+			wantStatus:  http.StatusBadRequest,
+			wantField:   "avatar",
+		},
+		{
+			name:        "error/too-large",
+			description: "验证文件超过 MaxSize 时返回 ErrFileTooLarge，对应 413。",
+			rules:       []FileRule{avatar},
+			parts:       []multipartPart{{field: "avatar", filename: "a.png", body: append(pngHeader, bytes.Repeat([]byte{0}, 64)...)}},
+			wantErr:     ErrFileTooLarge,
+			wantStatus:  http.StatusRequestEntityTooLarge,
+			wantField:   "avatar",
+		},
+		{
+			name:        "error/type-not-allowed",
+			description: "验证按内容识别出的类型不在允许列表时返回 ErrFileType，对应 415，声明的类型不能绕过校验。",
+			rules:       []FileRule{avatar},
+			parts:       []multipartPart{{field: "avatar", filename: "a.png", contentType: "image/png", body: []byte("<html><body>x</body></html>")}},
+			wantErr:     ErrFileType,
+			wantStatus:  http.StatusUnsupportedMediaType,
+			wantField:   "avatar",
+		},
+		{
+			name:        "error/too-many-files",
+			description: "验证同一字段的文件数量超过 MaxFiles 时返回 ErrTooManyFiles。",
+			rules:       []FileRule{docs},
+			parts: []multipartPart{
+				{field: "docs", filename: "a.csv", contentType: "text/csv", body: []byte("a")},
+				{field: "docs", filename: "b.csv", contentType: "text/csv", body: []byte("b")},
+				{field: "docs", filename: "c.csv", contentType: "text/csv", body: []byte("c")},
+			},
+			wantErr:    ErrTooManyFiles,
+			wantStatus: http.StatusBadRequest,
+			wantField:  "docs",
+		},
+		{
+			name:        "error/unexpected-file",
+			description: "验证未声明的文件字段返回 ErrUnexpectedFile。",
+			rules:       []FileRule{docs},
+			parts:       []multipartPart{{field: "other", filename: "x.txt", body: []byte("x")}},
+			wantErr:     ErrUnexpectedFile,
+			wantStatus:  http.StatusBadRequest,
+			wantField:   "other",
+		},
+		{
+			name:        "success/allow-unknown-files",
+			description: "验证 WithAllowUnknownFiles 丢弃未声明的文件字段。",
+			rules:       []FileRule{docs},
+			opts:        []MultipartOption{WithAllowUnknownFiles(true)},
+			parts:       []multipartPart{{field: "other", filename: "x.txt", body: []byte("x")}},
+			check: func(t *testing.T, form *MultipartForm) {
+				assert.Empty(t, form.Files)
+			},
+		},
+		{
+			name:        "error/form-values-too-large",
+			description: "验证普通字段累计超过 WithMaxFormValueBytes 时返回 ErrMultipart。",
+			opts:        []MultipartOption{WithMaxFormValueBytes(4)},
+			parts:       []multipartPart{{field: "a", body: []byte("abc")}, {field: "b", body: []byte("de")}},
+			wantErr:     ErrMultipart,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			dir := t.TempDir()
+			opts := append([]MultipartOption{WithUploadTempDir(dir)}, tt.opts...)
+			form, err := ParseFiles(newMultipartRequest(t, tt.parts...), tt.rules, opts...)
+			if nil != tt.wantErr {
+				require.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, form)
+				var fileErr *FileError
+				if errors.As(err, &fileErr) {
+					assert.Equal(t, tt.wantField, fileErr.Field)
+					assert.Equal(t, tt.wantStatus, fileErr.StatusCode())
+				}
+				// 校验失败时已保存的临时文件被删除。
+				entries, readErr := os.ReadDir(dir)
+				require.NoError(t, readErr)
+				assert.Empty(t, entries)
+				return
+			}
+
+			require.NoError(t, err)
+			tt.check(t, form)
+			require.NoError(t, form.RemoveAll())
+			entries, err := os.ReadDir(dir)
+			require.NoError(t, err)
+			assert.Empty(t, entries)
+		})
+	}
+
+	_, err := ParseFiles(httptest.NewRequest(http.MethodPost, "/upload", strings.NewReader("{}")), nil)
+	assert.ErrorIs(t, err, ErrMultipart, "非 multipart 请求返回 ErrMultipart。")
+}
+
+// TestStreamFiles 验证以流的方式读取文件，以及处理器未读完时仍能发现超过上限的文件。
+func TestStreamFiles(t *testing.T) {
+	t.Log("验证 fn 读取到完整内容；fn 只读取部分内容时，剩余内容被丢弃并按大小上限校验。")
+
+	rule := FileRule{Field: "log", MaxSize: 8}
+	var got []string
+	values, err := StreamFiles(newMultipartRequest(t,
+		multipartPart{field: "log", filename: "a.log", body: []byte("12345678")},
+		multipartPart{field: "note", body: []byte("n")},
+	), []FileRule{{Field: "log", MaxSize: 8, MaxFiles: 2}}, func(part *FilePart) error {
+		data, err := io.ReadAll(part)
+		got = append(got, part.Filename+":"+string(data))
+		assert.Equal(t, int64(len(data)), part.Size())
+		return err
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a.log:12345678"}, got)
+	assert.Equal(t, "n", values.Get("note"))
+
+	_, err = StreamFiles(newMultipartRequest(t,
+		multipartPart{field: "log", filename: "big.log", body: []byte("123456789")},
+	), []FileRule{rule}, func(part *FilePart) error {
+		buf := make([]byte, 2)
+		_, err := part.Read(buf)
+		return err
+	})
+	var fileErr *FileError
+	require.ErrorAs(t, err, &fileErr)
+	assert.ErrorIs(t, err, ErrFileTooLarge)
+	assert.Equal(t, int64(8), fileErr.Limit)
+	assert.Contains(t, err.Error(), "big.log")
+
+	handlerErr := errors.New("handler failed")
+	_, err = StreamFiles(newMultipartRequest(t,
+		multipartPart{field: "log", filename: "a.log", body: []byte("1")},
+	), []FileRule{rule}, func(part *FilePart) error {
+		return handlerErr
+	})
+	assert.ErrorIs(t, err, handlerErr)
+}
+
+// TestParseWithRouteUpload 验证 WithRouteUpload 在 Gin 侧校验上传文件并把表单交给 Kratos 处理器。
+func TestParseWithRouteUpload(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	srv := kratoshttp.NewServer()
+	var savedPath string
+	HandleFunc(srv, "/upload", func(w http.ResponseWriter, r *http.Request) {
+		form, ok := UploadFromContext(r.Context())
+		if !ok {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		file := form.File("avatar")
+		savedPath = file.Path
+		w.Header().Set("X-File", file.Filename+":"+file.ContentType)
+		w.Header().Set("X-Title", form.Values.Get("title"))
+		w.WriteHeader(http.StatusOK)
+	}, WithRouteUpload([]FileRule{{Field: "avatar", MaxSize: 64, AllowedTypes: []string{"image/png"}, Required: true}})).Methods(http.MethodPost)
+
+	engine := gin.New()
+	Parse(srv, engine)
+
+	tests := []struct {
+		name        string
+		description string
+		parts       []multipartPart
+		wantStatus  int
+	}{
+		{
+			name:        "success/upload",
+			description: "验证合法文件到达处理器，处理器返回后临时文件被删除。",
+			parts: []multipartPart{
+				{field: "title", body: []byte("me")},
+				{field: "avatar", filename: "me.png", body: append(pngHeader, "x"...)},
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:        "error/type",
+			description: "验证类型不允许时返回 415，请求不会到达处理器。",
+			parts:       []multipartPart{{field: "avatar", filename: "me.png", body: []byte("plain text")}},
+			wantStatus:  http.StatusUnsupportedMediaType,
+		},
+		{
+			name:        "error/missing",
+			description: "验证缺少必需文件时返回 400。",
+			parts:       []multipartPart{{field: "title", body: []byte("me")}},
+			wantStatus:  http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			savedPath = ""
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, newMultipartRequest(t, tt.parts...))
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if http.StatusOK != tt.wantStatus {
+				assert.Empty(t, savedPath)
+				return
+			}
+			assert.Equal(t, "me.png:image/png", w.Header().Get("X-File"))
+			assert.Equal(t, "me", w.Header().Get("X-Title"))
+			require.NotEmpty(t, savedPath)
+			_, err := os.Stat(savedPath)
+			assert.ErrorIs(t, err, os.ErrNotExist)
+		})
+	}
+}
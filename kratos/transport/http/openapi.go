@@ -0,0 +1,918 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package http
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	kratoshttp "github.com/go-kratos/kratos/v2/transport/http"
+)
+
+const (
+	// openAPIVersion 是生成文档使用的 OpenAPI 规范版本。
+	openAPIVersion = "3.0.3"
+	// openAPIDocumentFile 是文档在挂载路径下的文件名。
+	openAPIDocumentFile = "openapi.json"
+	// openAPIErrorSchema 是 Kratos 错误响应在 components 中的名称。
+	openAPIErrorSchema = "kratos.Status"
+	// openAPIContentType 是请求体与响应体的媒体类型。
+	openAPIContentType = "application/json"
+
+	// defaultOpenAPITitle 是未设置标题时的文档标题。
+	defaultOpenAPITitle = "API"
+	// defaultOpenAPIVersion 是未设置版本时的接口版本。
+	defaultOpenAPIVersion = "1.0.0"
+	// defaultSwaggerUIAssets 是 Swagger UI 静态资源的默认地址。
+	defaultSwaggerUIAssets = "https://unpkg.com/swagger-ui-dist@5"
+)
+
+var (
+	// openAPIPathParam 匹配 Gin 格式路径中的参数段。
+	openAPIPathParam = regexp.MustCompile(`[:*]([^/]+)`)
+	// openAPIUnsafeName 匹配 components 名称中不允许出现的字符。
+	openAPIUnsafeName = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+	// timeType 是 time.Time 的反射类型。
+	timeType = reflect.TypeOf(time.Time{})
+	// rawMessageType 是 json.RawMessage 的反射类型。
+	rawMessageType = reflect.TypeOf(json.RawMessage(nil))
+	// jsonNumberType 是 json.Number 的反射类型。
+	jsonNumberType = reflect.TypeOf(json.Number(""))
+	// jsonMarshalerType 是 json.Marshaler 的反射类型。
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	// textMarshalerType 是 encoding.TextMarshaler 的反射类型。
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+type (
+	// RouteDoc 描述一条路由在 OpenAPI 文档中的内容。
+	RouteDoc struct {
+		// Summary 是接口摘要。
+		Summary string
+
+		// Description 是接口的详细说明。
+		Description string
+
+		// Tags 是接口分组标签。
+		Tags []string
+
+		// Request 是请求结构体的值或指针，例如 CreateUserRequest{} 或 (*CreateUserRequest)(nil)，为 nil 时不生成参数与请求体。
+		//
+		// 带 query、header、path 标签的字段生成对应位置的参数，与 Bind 的规则一致；其余字段按 json 标签命名，
+		// 与路径参数同名的字段视为路径参数，GET、HEAD、DELETE 请求中的标量与切片字段视为查询参数，
+		// 其它方法中的字段组成 JSON 请求体。
+		Request interface{}
+
+		// Response 是成功响应体的值或指针，为 nil 时 200 响应不带内容。
+		Response interface{}
+
+		// Deprecated 标记接口已弃用。
+		Deprecated bool
+
+		// Hidden 表示不在文档中展示该路由。
+		Hidden bool
+	}
+
+	// OpenAPIOption 配置 WithOpenAPI 生成的文档与 Swagger UI。
+	OpenAPIOption func(*openAPIOptions)
+
+	// openAPIOptions 包含 OpenAPI 文档的配置选项。
+	openAPIOptions struct {
+		// path 是 Gin 格式的挂载路径，不带末尾斜杠；根路径为空串。
+		path string
+
+		// title 是文档标题。
+		title string
+
+		// version 是接口版本。
+		version string
+
+		// description 是文档说明。
+		description string
+
+		// servers 是文档中声明的服务地址。
+		servers []string
+
+		// ui 表示是否提供 Swagger UI 页面。
+		ui bool
+
+		// assets 是 Swagger UI 静态资源的地址前缀。
+		assets string
+
+		// handlers 是文档与 Swagger UI 路由的 Gin 处理器。
+		handlers []gin.HandlerFunc
+	}
+
+	// openAPIDocument 是 OpenAPI 文档的根对象。
+	openAPIDocument struct {
+		OpenAPI    string                                  `json:"openapi"`
+		Info       openAPIInfo                             `json:"info"`
+		Servers    []openAPIServer                         `json:"servers,omitempty"`
+		Paths      map[string]map[string]*openAPIOperation `json:"paths"`
+		Components openAPIComponents                       `json:"components"`
+	}
+
+	// openAPIInfo 是文档的基本信息。
+	openAPIInfo struct {
+		Title       string `json:"title"`
+		Description string `json:"description,omitempty"`
+		Version     string `json:"version"`
+	}
+
+	// openAPIServer 是文档中声明的服务地址。
+	openAPIServer struct {
+		URL string `json:"url"`
+	}
+
+	// openAPIComponents 是文档中可复用的组件。
+	openAPIComponents struct {
+		Schemas map[string]*openAPISchema `json:"schemas,omitempty"`
+	}
+
+	// openAPIOperation 是一个路径上单个 HTTP 方法的接口描述。
+	openAPIOperation struct {
+		OperationID string                      `json:"operationId"`
+		Summary     string                      `json:"summary,omitempty"`
+		Description string                      `json:"description,omitempty"`
+		Tags        []string                    `json:"tags,omitempty"`
+		Deprecated  bool                        `json:"deprecated,omitempty"`
+		Parameters  []*openAPIParameter         `json:"parameters,omitempty"`
+		RequestBody *openAPIRequestBody         `json:"requestBody,omitempty"`
+		Responses   map[string]*openAPIResponse `json:"responses"`
+	}
+
+	// openAPIParameter 是路径、查询或请求头参数。
+	openAPIParameter struct {
+		Name     string         `json:"name"`
+		In       string         `json:"in"`
+		Required bool           `json:"required,omitempty"`
+		Schema   *openAPISchema `json:"schema"`
+	}
+
+	// openAPIRequestBody 是请求体描述。
+	openAPIRequestBody struct {
+		Required bool                        `json:"required,omitempty"`
+		Content  map[string]openAPIMediaType `json:"content"`
+	}
+
+	// openAPIResponse 是响应描述。
+	openAPIResponse struct {
+		Description string                      `json:"description"`
+		Content     map[string]openAPIMediaType `json:"content,omitempty"`
+	}
+
+	// openAPIMediaType 是某个媒体类型下的内容描述。
+	openAPIMediaType struct {
+		Schema *openAPISchema `json:"schema"`
+	}
+
+	// openAPISchema 是 OpenAPI 3.0 的 Schema 对象子集。
+	openAPISchema struct {
+		Ref                  string                    `json:"$ref,omitempty"`
+		Type                 string                    `json:"type,omitempty"`
+		Format               string                    `json:"format,omitempty"`
+		Description          string                    `json:"description,omitempty"`
+		Nullable             bool                      `json:"nullable,omitempty"`
+		Items                *openAPISchema            `json:"items,omitempty"`
+		Properties           map[string]*openAPISchema `json:"properties,omitempty"`
+		AdditionalProperties *openAPISchema            `json:"additionalProperties,omitempty"`
+		Required             []string                  `json:"required,omitempty"`
+	}
+
+	// schemaGenerator 通过反射把 Go 类型转换为 Schema，并收集具名结构体组件。
+	schemaGenerator struct {
+		// schemas 是已生成的组件，键为组件名称。
+		schemas map[string]*openAPISchema
+
+		// names 是类型对应的组件名称。
+		names map[reflect.Type]string
+	}
+)
+
+// WithRouteDoc 设置路由在 OpenAPI 文档中的摘要、标签与请求、响应类型。
+//
+// 参数：
+//   - doc：路由文档，多次设置时以最后一次为准。
+//
+// 返回值：
+//   - RouteOption：路由配置选项。
+//
+// 未设置 WithRouteDoc 的路由同样出现在文档中，只包含路径参数与默认响应；protobuf 生成代码注册的路由可以通过
+// WithRoute 补充文档。
+func WithRouteDoc(doc RouteDoc) RouteOption {
+	return func(o *routeOptions) {
+		o.doc = &doc
+	}
+}
+
+// WithOpenAPIInfo 设置文档标题与接口版本。
+//
+// 参数：
+//   - title：文档标题，为空时使用 API。
+//   - version：接口版本，为空时使用 1.0.0。
+//
+// 返回值：
+//   - OpenAPIOption：OpenAPI 配置选项。
+func WithOpenAPIInfo(title, version string) OpenAPIOption {
+	return func(o *openAPIOptions) {
+		if "" != title {
+			o.title = title
+		}
+		if "" != version {
+			o.version = version
+		}
+	}
+}
+
+// WithOpenAPIDescription 设置文档说明。
+//
+// 参数：
+//   - description：文档说明，支持 CommonMark。
+//
+// 返回值：
+//   - OpenAPIOption：OpenAPI 配置选项。
+func WithOpenAPIDescription(description string) OpenAPIOption {
+	return func(o *openAPIOptions) {
+		o.description = description
+	}
+}
+
+// WithOpenAPIServers 设置文档中声明的服务地址。
+//
+// 参数：
+//   - urls：服务地址，例如 https://api.example.com；未设置时 Swagger UI 向文档所在的主机发送请求。
+//
+// 返回值：
+//   - OpenAPIOption：OpenAPI 配置选项。
+func WithOpenAPIServers(urls ...string) OpenAPIOption {
+	return func(o *openAPIOptions) {
+		o.servers = append(o.servers, urls...)
+	}
+}
+
+// WithSwaggerUI 设置是否提供 Swagger UI 页面。
+//
+// 参数：
+//   - enabled：为 false 时只提供 openapi.json，默认提供。
+//
+// 返回值：
+//   - OpenAPIOption：OpenAPI 配置选项。
+func WithSwaggerUI(enabled bool) OpenAPIOption {
+	return func(o *openAPIOptions) {
+		o.ui = enabled
+	}
+}
+
+// WithSwaggerUIAssets 设置 Swagger UI 静态资源的地址前缀。
+//
+// 参数：
+//   - baseURL：包含 swagger-ui.css 与 swagger-ui-bundle.js 的地址前缀，默认使用 unpkg 上的 swagger-ui-dist@5；
+//     内网环境可以指向自行托管的副本，例如配合 WithStatic 挂载的路径。
+//
+// 返回值：
+//   - OpenAPIOption：OpenAPI 配置选项。
+func WithSwaggerUIAssets(baseURL string) OpenAPIOption {
+	return func(o *openAPIOptions) {
+		if "" != baseURL {
+			o.assets = strings.TrimRight(baseURL, "/")
+		}
+	}
+}
+
+// WithOpenAPIMiddleware 为文档与 Swagger UI 路由挂载 Gin 处理器。
+//
+// 参数：
+//   - handlers：依次执行的 Gin 处理器，例如限制只有内网或已认证的用户可以访问文档。
+//
+// 返回值：
+//   - OpenAPIOption：OpenAPI 配置选项。
+func WithOpenAPIMiddleware(handlers ...gin.HandlerFunc) OpenAPIOption {
+	return func(o *openAPIOptions) {
+		o.handlers = append(o.handlers, handlers...)
+	}
+}
+
+// WithOpenAPI 在指定路径下提供根据路由表生成的 OpenAPI 3 文档与 Swagger UI。
+//
+// 参数：
+//   - path：挂载路径，例如 `/docs`：`/docs` 返回 Swagger UI 页面，`/docs/openapi.json` 返回文档。
+//   - opts：文档信息、Swagger UI 等可选配置。
+//
+// 返回值：
+//   - ParseOption：Parse 的配置选项。
+//
+// 文档在 Parse 时根据已注册的 Kratos 路由生成一次，之后注册的路由不会出现在文档中。
+// 路径、方法与路径参数来自路由表，请求与响应的结构来自 WithRouteDoc 声明的类型；需要认证的路由额外声明 401 响应，
+// 所有接口都声明 Kratos 错误结构的 default 响应。文档路由直接注册到 Gin，不经过路由组与认证处理器，
+// 需要保护时使用 WithOpenAPIMiddleware；挂载路径不能与 Kratos 路由冲突。
+func WithOpenAPI(path string, opts ...OpenAPIOption) ParseOption {
+	return func(o *parseOptions) {
+		o.openapi = newOpenAPIOptions(path, opts)
+	}
+}
+
+// GenerateOpenAPI 根据 kratos http.Server 的路由表生成 OpenAPI 3 文档。
+//
+// 参数：
+//   - s：kratos http.Server 指针。
+//   - opts：Parse 的配置选项；WithRoute 补充的路由文档与 WithOpenAPI 的文档信息会生效，其它选项被忽略。
+//
+// 返回值：
+//   - []byte：JSON 格式的文档，可用于在构建阶段导出或生成客户端代码。
+//   - error：序列化失败时返回错误。
+func GenerateOpenAPI(s *kratoshttp.Server, opts ...ParseOption) ([]byte, error) {
+	o := &parseOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	doc := o.openapi
+	if nil == doc {
+		doc = newOpenAPIOptions("", nil)
+	}
+	return json.Marshal(doc.build(GetPaths(s), o))
+}
+
+// newOpenAPIOptions 创建 OpenAPI 配置并应用选项。
+//
+// 参数：
+//   - path：挂载路径。
+//   - opts：OpenAPI 配置选项。
+//
+// 返回值：
+//   - *openAPIOptions：应用后的配置。
+func newOpenAPIOptions(path string, opts []OpenAPIOption) *openAPIOptions {
+	o := &openAPIOptions{
+		path:    strings.TrimRight(parsePath(path), "/"),
+		title:   defaultOpenAPITitle,
+		version: defaultOpenAPIVersion,
+		ui:      true,
+		assets:  defaultSwaggerUIAssets,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// register 在 Gin 中注册文档与 Swagger UI 路由。
+//
+// 参数：
+//   - e：gin.Engine 指针。
+//   - routeInfos：Kratos 路由表。
+//   - po：Parse 的配置选项，提供 WithRoute 补充的路由文档与压缩配置。
+func (o *openAPIOptions) register(e *gin.Engine, routeInfos []RouteInfo, po *parseOptions) {
+	data, err := json.Marshal(o.build(routeInfos, po))
+	docPath := o.path + "/" + openAPIDocumentFile
+
+	handlers := make([]gin.HandlerFunc, 0, len(o.handlers)+2)
+	if nil != po.compression {
+		handlers = append(handlers, po.compression.handler())
+	}
+	handlers = append(handlers, o.handlers...)
+
+	e.GET(docPath, append(handlers, func(c *gin.Context) {
+		if nil != err {
+			_ = c.AbortWithError(http.StatusInternalServerError, err)
+			return
+		}
+		c.Data(http.StatusOK, openAPIContentType, data)
+	})...)
+
+	if !o.ui {
+		return
+	}
+	uiPath := o.path
+	if "" == uiPath {
+		uiPath = "/"
+	}
+	page := []byte(swaggerUIPage(o.title, o.assets, docPath))
+	e.GET(uiPath, append(handlers, func(c *gin.Context) {
+		c.Data(http.StatusOK, "text/html; charset=utf-8", page)
+	})...)
+}
+
+// build 根据路由表生成文档。
+//
+// 参数：
+//   - routeInfos：Kratos 路由表。
+//   - po：Parse 的配置选项，提供 WithRoute 补充的路由文档。
+//
+// 返回值：
+//   - *openAPIDocument：生成的文档。
+func (o *openAPIOptions) build(routeInfos []RouteInfo, po *parseOptions) *openAPIDocument {
+	g := &schemaGenerator{
+		schemas: map[string]*openAPISchema{openAPIErrorSchema: kratosErrorSchema()},
+		names:   make(map[reflect.Type]string),
+	}
+	doc := &openAPIDocument{
+		OpenAPI: openAPIVersion,
+		Info:    openAPIInfo{Title: o.title, Description: o.description, Version: o.version},
+		Paths:   make(map[string]map[string]*openAPIOperation),
+	}
+	for _, server := range o.servers {
+		doc.Servers = append(doc.Servers, openAPIServer{URL: server})
+	}
+
+	for _, routeInfo := range routeInfos {
+		if isOptions(routeInfo.method) {
+			continue
+		}
+		ginPath := parsePath(routeInfo.path)
+		route := newRouteOptions(routeInfo.options, po.routes[ginPath])
+		var rd RouteDoc
+		if nil != route && nil != route.doc {
+			rd = *route.doc
+		}
+		if rd.Hidden {
+			continue
+		}
+
+		path, pathParams := openAPIPath(ginPath)
+		op := g.operation(strings.ToUpper(routeInfo.method), path, pathParams, rd)
+		if nil != route && route.requireAuth {
+			op.Responses["401"] = &openAPIResponse{Description: http.StatusText(http.StatusUnauthorized)}
+		}
+		if nil == doc.Paths[path] {
+			doc.Paths[path] = make(map[string]*openAPIOperation)
+		}
+		doc.Paths[path][strings.ToLower(routeInfo.method)] = op
+	}
+
+	doc.Components.Schemas = g.schemas
+	return doc
+}
+
+// operation 生成单个接口的描述。
+//
+// 参数：
+//   - method：大写的 HTTP 方法。
+//   - path：OpenAPI 格式的路径。
+//   - pathParams：路径参数名。
+//   - rd：路由文档。
+//
+// 返回值：
+//   - *openAPIOperation：接口描述。
+func (g *schemaGenerator) operation(method, path string, pathParams []string, rd RouteDoc) *openAPIOperation {
+	op := &openAPIOperation{
+		OperationID: operationID(method, path),
+		Summary:     rd.Summary,
+		Description: rd.Description,
+		Tags:        rd.Tags,
+		Deprecated:  rd.Deprecated,
+		Responses: map[string]*openAPIResponse{
+			"default": {
+				Description: "错误响应",
+				Content:     map[string]openAPIMediaType{openAPIContentType: {Schema: &openAPISchema{Ref: componentRef(openAPIErrorSchema)}}},
+			},
+		},
+	}
+
+	params, body := g.request(method, pathParams, rd.Request)
+	op.Parameters = params
+	if nil != body {
+		op.RequestBody = &openAPIRequestBody{
+			Required: true,
+			Content:  map[string]openAPIMediaType{openAPIContentType: {Schema: body}},
+		}
+	}
+
+	ok := &openAPIResponse{Description: http.StatusText(http.StatusOK)}
+	if nil != rd.Response {
+		ok.Content = map[string]openAPIMediaType{openAPIContentType: {Schema: g.schema(reflect.TypeOf(rd.Response))}}
+	}
+	op.Responses["200"] = ok
+	return op
+}
+
+// request 根据请求类型生成参数与请求体。
+//
+// 参数：
+//   - method：大写的 HTTP 方法。
+//   - pathParams：路径参数名。
+//   - request：请求结构体的值或指针，可以为 nil。
+//
+// 返回值：
+//   - []*openAPIParameter：路径、查询与请求头参数；路径参数总是全部列出。
+//   - *openAPISchema：请求体结构，没有请求体时为 nil。
+func (g *schemaGenerator) request(method string, pathParams []string, request interface{}) ([]*openAPIParameter, *openAPISchema) {
+	params := make([]*openAPIParameter, 0, len(pathParams))
+	covered := make(map[string]bool, len(pathParams))
+	for _, name := range pathParams {
+		covered[name] = false
+	}
+	hasBody := http.MethodGet != method && http.MethodHead != method && http.MethodDelete != method
+
+	var body *openAPISchema
+	if t := reflect.TypeOf(request); nil != t {
+		t = indirectType(t)
+		if t.Kind() != reflect.Struct {
+			if hasBody {
+				body = g.schema(t)
+			}
+		} else {
+			props := make(map[string]*openAPISchema)
+			diverted := false
+			for _, sf := range structFields(t) {
+				tagged := false
+				for _, source := range bindSources {
+					raw, ok := sf.Tag.Lookup(source)
+					if !ok || "-" == raw {
+						continue
+					}
+					tagged = true
+					tag := parseBindTag(raw, sf.Name)
+					params = append(params, &openAPIParameter{
+						Name:     tag.name,
+						In:       source,
+						Required: tag.required || bindSourcePath == source,
+						Schema:   g.schema(sf.Type),
+					})
+					if bindSourcePath == source {
+						covered[tag.name] = true
+					}
+				}
+				if tagged {
+					diverted = true
+					continue
+				}
+
+				name, ok := jsonFieldName(sf)
+				if !ok {
+					continue
+				}
+				schema := g.schema(sf.Type)
+				switch {
+				case isPathParam(covered, name):
+					params = append(params, &openAPIParameter{Name: name, In: bindSourcePath, Required: true, Schema: schema})
+					covered[name] = true
+					diverted = true
+				case hasBody:
+					props[name] = schema
+				case isQuerySchema(schema):
+					params = append(params, &openAPIParameter{Name: name, In: bindSourceQuery, Schema: schema})
+				}
+			}
+			if hasBody && len(props) > 0 {
+				if diverted || "" == t.Name() {
+					body = &openAPISchema{Type: "object", Properties: props}
+				} else {
+					body = g.schema(t)
+				}
+			}
+		}
+	}
+
+	// 请求类型未声明的路径参数按字符串处理，保证路径中的参数都有描述。
+	for _, name := range pathParams {
+		if !covered[name] {
+			params = append(params, &openAPIParameter{Name: name, In: bindSourcePath, Required: true, Schema: &openAPISchema{Type: "string"}})
+		}
+	}
+	return params, body
+}
+
+// schema 返回类型对应的 Schema，具名结构体生成组件并返回引用。
+//
+// 参数：
+//   - t：Go 类型。
+//
+// 返回值：
+//   - *openAPISchema：类型的 Schema；无法表示的类型返回空 Schema，表示任意值。
+func (g *schemaGenerator) schema(t reflect.Type) *openAPISchema {
+	nullable := false
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+		nullable = true
+	}
+
+	switch {
+	case t == timeType:
+		return &openAPISchema{Type: "string", Format: "date-time", Nullable: nullable}
+	case t == rawMessageType:
+		return &openAPISchema{}
+	case t == jsonNumberType:
+		return &openAPISchema{Type: "number", Nullable: nullable}
+	case implements(t, jsonMarshalerType):
+		return &openAPISchema{}
+	case implements(t, textMarshalerType):
+		return &openAPISchema{Type: "string", Nullable: nullable}
+	}
+
+	var s *openAPISchema
+	switch t.Kind() {
+	case reflect.Bool:
+		s = &openAPISchema{Type: "boolean"}
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16:
+		s = &openAPISchema{Type: "integer", Format: "int32"}
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		s = &openAPISchema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		s = &openAPISchema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		s = &openAPISchema{Type: "number", Format: "double"}
+	case reflect.String:
+		s = &openAPISchema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// []byte 按 encoding/json 的规则编码为 Base64 字符串。
+			s = &openAPISchema{Type: "string", Format: "byte"}
+		} else {
+			s = &openAPISchema{Type: "array", Items: g.schema(t.Elem())}
+		}
+	case reflect.Map:
+		s = &openAPISchema{Type: "object", AdditionalProperties: g.schema(t.Elem())}
+	case reflect.Struct:
+		if "" == t.Name() {
+			s = g.structSchema(t)
+		} else {
+			return &openAPISchema{Ref: componentRef(g.component(t))}
+		}
+	default:
+		// interface 等无法静态确定的类型表示任意值。
+		return &openAPISchema{}
+	}
+	s.Nullable = nullable
+	return s
+}
+
+// component 为具名结构体生成组件，返回组件名称。
+//
+// 参数：
+//   - t：具名结构体类型。
+//
+// 返回值：
+//   - string：组件名称；同名的不同类型以包名区分。
+func (g *schemaGenerator) component(t reflect.Type) string {
+	if name, ok := g.names[t]; ok {
+		return name
+	}
+
+	name := openAPIUnsafeName.ReplaceAllString(t.Name(), "_")
+	if _, exists := g.schemas[name]; exists {
+		pkg := t.PkgPath()
+		name = openAPIUnsafeName.ReplaceAllString(pkg[strings.LastIndex(pkg, "/")+1:], "_") + "." + name
+		for i := 2; ; i++ {
+			if _, exists := g.schemas[name]; !exists {
+				break
+			}
+			name = fmt.Sprintf("%s%d", strings.TrimRight(name, "0123456789"), i)
+		}
+	}
+
+	// 先登记名称再展开字段，自引用的类型得到同一个引用。
+	g.names[t] = name
+	g.schemas[name] = &openAPISchema{}
+	*g.schemas[name] = *g.structSchema(t)
+	return name
+}
+
+// structSchema 展开结构体的 JSON 字段。
+//
+// 参数：
+//   - t：结构体类型。
+//
+// 返回值：
+//   - *openAPISchema：object 类型的 Schema。
+func (g *schemaGenerator) structSchema(t reflect.Type) *openAPISchema {
+	s := &openAPISchema{Type: "object", Properties: make(map[string]*openAPISchema)}
+	for _, sf := range structFields(t) {
+		name, ok := jsonFieldName(sf)
+		if !ok {
+			continue
+		}
+		field := g.schema(sf.Type)
+		if opts := strings.Split(sf.Tag.Get("json"), ","); len(opts) > 1 && contains(opts[1:], "string") {
+			field = &openAPISchema{Type: "string"}
+		}
+		// OpenAPI 3.0 忽略与 $ref 并列的属性，引用组件的字段不附加说明。
+		if description := sf.Tag.Get("description"); "" != description && "" == field.Ref {
+			field.Description = description
+		}
+		s.Properties[name] = field
+	}
+	return s
+}
+
+// structFields 返回结构体的导出字段，未命名的匿名嵌入结构体字段被展开。
+//
+// 参数：
+//   - t：结构体类型。
+//
+// 返回值：
+//   - []reflect.StructField：按声明顺序排列的字段。
+func structFields(t reflect.Type) []reflect.StructField {
+	fields := make([]reflect.StructField, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.Anonymous {
+			name, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+			if ft := indirectType(sf.Type); "" == name && ft.Kind() == reflect.Struct {
+				fields = append(fields, structFields(ft)...)
+				continue
+			}
+		}
+		if sf.IsExported() {
+			fields = append(fields, sf)
+		}
+	}
+	return fields
+}
+
+// jsonFieldName 返回字段在 JSON 中的名称。
+//
+// 参数：
+//   - sf：结构体字段。
+//
+// 返回值：
+//   - string：json 标签中的名称，未设置时为字段名。
+//   - bool：字段被 json:"-" 忽略时返回 false。
+func jsonFieldName(sf reflect.StructField) (string, bool) {
+	tag := sf.Tag.Get("json")
+	if "-" == tag {
+		return "", false
+	}
+	name, _, _ := strings.Cut(tag, ",")
+	if "" == name {
+		name = sf.Name
+	}
+	return name, true
+}
+
+// openAPIPath 把 Gin 格式路径转换为 OpenAPI 格式，并返回路径参数名。
+//
+// 参数：
+//   - ginPath：Gin 格式的路由路径。
+//
+// 返回值：
+//   - string：OpenAPI 格式的路径，例如 `/users/{id}`。
+//   - []string：按出现顺序排列的路径参数名。
+func openAPIPath(ginPath string) (string, []string) {
+	names := make([]string, 0)
+	path := openAPIPathParam.ReplaceAllStringFunc(ginPath, func(segment string) string {
+		names = append(names, segment[1:])
+		return "{" + segment[1:] + "}"
+	})
+	return path, names
+}
+
+// operationID 根据方法与路径生成接口标识。
+//
+// 参数：
+//   - method：HTTP 方法。
+//   - path：OpenAPI 格式的路径。
+//
+// 返回值：
+//   - string：例如 GET /users/{id} 生成 get_users_id。
+func operationID(method, path string) string {
+	parts := []string{strings.ToLower(method)}
+	for _, segment := range strings.FieldsFunc(path, func(r rune) bool {
+		return !('a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || '0' <= r && r <= '9')
+	}) {
+		parts = append(parts, segment)
+	}
+	return strings.Join(parts, "_")
+}
+
+// swaggerUIPage 生成加载文档的 Swagger UI 页面。
+//
+// 参数：
+//   - title：页面标题。
+//   - assets：Swagger UI 静态资源的地址前缀。
+//   - docPath：文档路径。
+//
+// 返回值：
+//   - string：HTML 页面。
+func swaggerUIPage(title, assets, docPath string) string {
+	// json.Marshal 会转义 <、>、&，结果可以安全地嵌入 script。
+	url, _ := json.Marshal(docPath)
+	assets = html.EscapeString(assets)
+	return `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>` + html.EscapeString(title) + `</title>
+<link rel="stylesheet" href="` + assets + `/swagger-ui.css">
+</head>
+<body>
+<div id="swagger-ui"></div>
+<script src="` + assets + `/swagger-ui-bundle.js" crossorigin></script>
+<script>
+window.onload = function () {
+  window.ui = SwaggerUIBundle({url: ` + string(url) + `, dom_id: "#swagger-ui"});
+};
+</script>
+</body>
+</html>
+`
+}
+
+// kratosErrorSchema 返回 Kratos 错误响应的结构。
+//
+// 参数：无。
+//
+// 返回值：
+//   - *openAPISchema：包含 code、reason、message、metadata 的 object。
+func kratosErrorSchema() *openAPISchema {
+	return &openAPISchema{
+		Type: "object",
+		Properties: map[string]*openAPISchema{
+			"code":     {Type: "integer", Format: "int32"},
+			"reason":   {Type: "string"},
+			"message":  {Type: "string"},
+			"metadata": {Type: "object", AdditionalProperties: &openAPISchema{Type: "string"}},
+		},
+	}
+}
+
+// componentRef 返回组件的引用路径。
+//
+// 参数：
+//   - name：组件名称。
+//
+// 返回值：
+//   - string：例如 #/components/schemas/User。
+func componentRef(name string) string {
+	return "#/components/schemas/" + name
+}
+
+// indirectType 返回指针指向的最终类型。
+//
+// 参数：
+//   - t：Go 类型。
+//
+// 返回值：
+//   - reflect.Type：去除全部指针后的类型。
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+// implements 判断类型或其指针是否实现接口。
+//
+// 参数：
+//   - t：非指针类型。
+//   - iface：接口类型。
+//
+// 返回值：
+//   - bool：t 或 *t 实现 iface 时返回 true。
+func implements(t, iface reflect.Type) bool {
+	return t.Implements(iface) || reflect.PointerTo(t).Implements(iface)
+}
+
+// isPathParam 判断名称是否为路径参数。
+//
+// 参数：
+//   - params：路径参数名到是否已描述的映射。
+//   - name：参数名。
+//
+// 返回值：
+//   - bool：name 是路径参数且尚未被描述时返回 true。
+func isPathParam(params map[string]bool, name string) bool {
+	covered, ok := params[name]
+	return ok && !covered
+}
+
+// isQuerySchema 判断 Schema 能否作为查询参数。
+//
+// 参数：
+//   - s：字段的 Schema。
+//
+// 返回值：
+//   - bool：标量或标量数组返回 true。
+func isQuerySchema(s *openAPISchema) bool {
+	if "array" == s.Type {
+		return nil != s.Items && isQuerySchema(s.Items)
+	}
+	return "" != s.Type && "object" != s.Type
+}
+
+// contains 判断切片中是否包含指定字符串。
+//
+// 参数：
+//   - values：字符串切片。
+//   - target：目标字符串。
+//
+// 返回值：
+//   - bool：包含时返回 true。
+func contains(values []string, target string) bool {
+	for _, v := range values {
+		if target == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,247 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	kratoshttp "github.com/go-kratos/kratos/v2/transport/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type (
+	// openAPIPage 是测试用的分页参数，以匿名嵌入方式展开。
+	openAPIPage struct {
+		Page int `json:"page"`
+		Size int `json:"size"`
+	}
+
+	// openAPIListUsersRequest 是测试用的列表请求。
+	openAPIListUsersRequest struct {
+		openAPIPage
+		Keyword string   `json:"keyword"`
+		Status  []string `json:"status"`
+		Filter  struct {
+			Role string `json:"role"`
+		} `json:"filter"`
+		Token string `header:"X-Token,required"`
+	}
+
+	// openAPIUser 是测试用的用户结构。
+	openAPIUser struct {
+		ID        int64          `json:"id,string"`
+		Name      string         `json:"name" description:"用户名"`
+		Email     *string        `json:"email,omitempty"`
+		Avatar    []byte         `json:"avatar"`
+		CreatedAt time.Time      `json:"created_at"`
+		Tags      map[string]int `json:"tags"`
+		Manager   *openAPIUser   `json:"manager"`
+		Extra     interface{}    `json:"extra"`
+		Secret    string         `json:"-"`
+	}
+
+	// openAPIUpdateUserRequest 是测试用的更新请求，ID 来自路径。
+	openAPIUpdateUserRequest struct {
+		ID   int64  `json:"id"`
+		Name string `json:"name"`
+	}
+)
+
+// newOpenAPIServer 创建注册了测试路由的 Kratos 服务器。
+func newOpenAPIServer() *kratoshttp.Server {
+	srv := kratoshttp.NewServer()
+	noop := func(w http.ResponseWriter, r *http.Request) {}
+	HandleFunc(srv, "/users", noop, WithRouteDoc(RouteDoc{
+		Summary:  "用户列表",
+		Tags:     []string{"user"},
+		Request:  openAPIListUsersRequest{},
+		Response: []openAPIUser{},
+	})).Methods(http.MethodGet)
+	HandleFunc(srv, "/users", noop, WithRouteDoc(RouteDoc{
+		Request:  (*openAPIUser)(nil),
+		Response: &openAPIUser{},
+	})).Methods(http.MethodPost)
+	HandleFunc(srv, "/users/{id}", noop, WithRouteAuth(), WithRouteDoc(RouteDoc{
+		Request:    openAPIUpdateUserRequest{},
+		Deprecated: true,
+	})).Methods(http.MethodPut)
+	HandleFunc(srv, "/orders/{id:[0-9]+}/items/{item}", noop).Methods(http.MethodDelete)
+	HandleFunc(srv, "/internal", noop, WithRouteDoc(RouteDoc{Hidden: true})).Methods(http.MethodGet)
+	HandleFunc(srv, "/users", noop).Methods(http.MethodOptions)
+	return srv
+}
+
+// TestGenerateOpenAPI 测试根据路由表生成 OpenAPI 文档。
+func TestGenerateOpenAPI(t *testing.T) {
+	data, err := GenerateOpenAPI(newOpenAPIServer(),
+		WithOpenAPI("/docs", WithOpenAPIInfo("User API", "2.0.0"), WithOpenAPIServers("https://api.example.com")),
+		WithRoute("/orders/:id/items/:item", WithRouteDoc(RouteDoc{Summary: "删除订单项"})),
+	)
+	require.NoError(t, err)
+
+	var doc openAPIDocument
+	require.NoError(t, json.Unmarshal(data, &doc))
+
+	t.Run("info", func(t *testing.T) {
+		t.Log("验证文档信息、服务地址与隐藏路由、OPTIONS 路由的过滤。")
+
+		assert.Equal(t, openAPIVersion, doc.OpenAPI)
+		assert.Equal(t, openAPIInfo{Title: "User API", Version: "2.0.0"}, doc.Info)
+		assert.Equal(t, []openAPIServer{{URL: "https://api.example.com"}}, doc.Servers)
+		assert.Len(t, doc.Paths, 3)
+		assert.NotContains(t, doc.Paths, "/internal")
+		assert.Len(t, doc.Paths["/users"], 2)
+	})
+
+	t.Run("query", func(t *testing.T) {
+		t.Log("验证 GET 请求的标量字段生成查询参数，绑定标签生成请求头参数，嵌套对象被忽略。")
+
+		op := doc.Paths["/users"]["get"]
+		require.NotNil(t, op)
+		assert.Equal(t, "get_users", op.OperationID)
+		assert.Equal(t, "用户列表", op.Summary)
+		assert.Equal(t, []string{"user"}, op.Tags)
+		assert.Nil(t, op.RequestBody)
+
+		params := make(map[string]*openAPIParameter)
+		for _, p := range op.Parameters {
+			params[p.In+":"+p.Name] = p
+		}
+		assert.Len(t, params, 5)
+		assert.Equal(t, &openAPISchema{Type: "integer", Format: "int64"}, params["query:page"].Schema)
+		assert.Contains(t, params, "query:size")
+		assert.Contains(t, params, "query:keyword")
+		assert.Equal(t, &openAPISchema{Type: "array", Items: &openAPISchema{Type: "string"}}, params["query:status"].Schema)
+		assert.True(t, params["header:X-Token"].Required)
+
+		ok := op.Responses["200"].Content[openAPIContentType].Schema
+		assert.Equal(t, "array", ok.Type)
+		assert.Equal(t, componentRef("openAPIUser"), ok.Items.Ref)
+		assert.Equal(t, componentRef(openAPIErrorSchema), op.Responses["default"].Content[openAPIContentType].Schema.Ref)
+	})
+
+	t.Run("body", func(t *testing.T) {
+		t.Log("验证 POST 请求体引用组件，组件字段按 json 标签与类型生成。")
+
+		op := doc.Paths["/users"]["post"]
+		require.NotNil(t, op)
+		require.NotNil(t, op.RequestBody)
+		assert.Equal(t, componentRef("openAPIUser"), op.RequestBody.Content[openAPIContentType].Schema.Ref)
+
+		user := doc.Components.Schemas["openAPIUser"]
+		require.NotNil(t, user)
+		assert.Len(t, user.Properties, 8)
+		assert.Equal(t, &openAPISchema{Type: "string"}, user.Properties["id"])
+		assert.Equal(t, &openAPISchema{Type: "string", Description: "用户名"}, user.Properties["name"])
+		assert.Equal(t, &openAPISchema{Type: "string", Nullable: true}, user.Properties["email"])
+		assert.Equal(t, &openAPISchema{Type: "string", Format: "byte"}, user.Properties["avatar"])
+		assert.Equal(t, &openAPISchema{Type: "string", Format: "date-time"}, user.Properties["created_at"])
+		assert.Equal(t, &openAPISchema{Type: "object", AdditionalProperties: &openAPISchema{Type: "integer", Format: "int64"}}, user.Properties["tags"])
+		assert.Equal(t, componentRef("openAPIUser"), user.Properties["manager"].Ref)
+		assert.Equal(t, &openAPISchema{}, user.Properties["extra"])
+	})
+
+	t.Run("path", func(t *testing.T) {
+		t.Log("验证与路径参数同名的字段生成路径参数，其余字段组成内联请求体，需要认证的路由声明 401。")
+
+		op := doc.Paths["/users/{id}"]["put"]
+		require.NotNil(t, op)
+		assert.True(t, op.Deprecated)
+		require.Len(t, op.Parameters, 1)
+		assert.Equal(t, &openAPIParameter{Name: "id", In: "path", Required: true, Schema: &openAPISchema{Type: "integer", Format: "int64"}}, op.Parameters[0])
+		assert.Equal(t, &openAPISchema{Type: "object", Properties: map[string]*openAPISchema{"name": {Type: "string"}}},
+			op.RequestBody.Content[openAPIContentType].Schema)
+		assert.Contains(t, op.Responses, "401")
+	})
+
+	t.Run("undocumented", func(t *testing.T) {
+		t.Log("验证未声明请求类型的路由按字符串生成路径参数，WithRoute 补充的文档生效。")
+
+		op := doc.Paths["/orders/{id}/items/{item}"]["delete"]
+		require.NotNil(t, op)
+		assert.Equal(t, "delete_orders_id_items_item", op.OperationID)
+		assert.Equal(t, "删除订单项", op.Summary)
+		require.Len(t, op.Parameters, 2)
+		assert.Equal(t, "id", op.Parameters[0].Name)
+		assert.Equal(t, "item", op.Parameters[1].Name)
+		assert.Equal(t, &openAPISchema{Type: "string"}, op.Parameters[1].Schema)
+		assert.NotContains(t, op.Responses, "401")
+	})
+}
+
+// TestParseWithOpenAPI 测试 Parse 挂载文档与 Swagger UI 路由。
+func TestParseWithOpenAPI(t *testing.T) {
+	// 设置 Gin 为测试模式。
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name        string
+		description string
+		opts        []OpenAPIOption
+		path        string
+		wantStatus  int
+		wantType    string
+		wantBody    []string
+	}{
+		{
+			name:        "document",
+			description: "验证文档路由返回 JSON 文档。",
+			path:        "/docs/openapi.json",
+			wantStatus:  http.StatusOK,
+			wantType:    openAPIContentType,
+			wantBody:    []string{`"openapi":"3.0.3"`, `"/users"`},
+		},
+		{
+			name:        "ui",
+			description: "验证 Swagger UI 页面加载文档并使用自定义静态资源地址。",
+			opts:        []OpenAPIOption{WithOpenAPIInfo("User <API>", ""), WithSwaggerUIAssets("/assets/swagger/")},
+			path:        "/docs",
+			wantStatus:  http.StatusOK,
+			wantType:    "text/html; charset=utf-8",
+			wantBody:    []string{`url: "/docs/openapi.json"`, `href="/assets/swagger/swagger-ui.css"`, "User &lt;API&gt;"},
+		},
+		{
+			name:        "ui-disabled",
+			description: "验证关闭 Swagger UI 后只提供文档。",
+			opts:        []OpenAPIOption{WithSwaggerUI(false)},
+			path:        "/docs",
+			wantStatus:  http.StatusNotFound,
+		},
+		{
+			name:        "middleware",
+			description: "验证 WithOpenAPIMiddleware 挂载的处理器作用于文档路由。",
+			opts: []OpenAPIOption{WithOpenAPIMiddleware(func(c *gin.Context) {
+				c.AbortWithStatus(http.StatusForbidden)
+			})},
+			path:       "/docs/openapi.json",
+			wantStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			e := gin.New()
+			Parse(newOpenAPIServer(), e, WithOpenAPI("/docs/", tt.opts...))
+
+			w := httptest.NewRecorder()
+			e.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if "" != tt.wantType {
+				assert.Equal(t, tt.wantType, w.Header().Get("Content-Type"))
+			}
+			for _, want := range tt.wantBody {
+				assert.Contains(t, w.Body.String(), want)
+			}
+		})
+	}
+}
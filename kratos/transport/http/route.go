@@ -0,0 +1,290 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	kratoshttp "github.com/go-kratos/kratos/v2/transport/http"
+	"github.com/gorilla/mux"
+)
+
+type (
+	// RouteOption 配置单条路由在 Gin 侧的处理行为。
+	RouteOption func(*routeOptions)
+
+	// routeOptions 包含单条路由的配置选项。
+	routeOptions struct {
+		// timeout 是请求上下文的超时时间，为 0 时不设置。
+		timeout time.Duration
+
+		// bodyLimit 是请求体的最大字节数，为 0 时不限制。
+		bodyLimit int64
+
+		// requireAuth 表示路由需要经过认证处理器。
+		requireAuth bool
+
+		// upload 是 WithRouteUpload 声明的上传文件，为 nil 时不解析。
+		upload *uploadRoute
+
+		// handlers 是在代理到 Kratos 之前执行的路由级 Gin 处理器。
+		handlers []gin.HandlerFunc
+
+		// doc 是 WithRouteDoc 设置的 OpenAPI 文档，为 nil 时只生成默认描述。
+		doc *RouteDoc
+	}
+)
+
+var (
+	// routeRegistry 记录通过 HandleFunc、Handle 注册的 mux 路由与其路由选项，键为 *mux.Route。
+	routeRegistry sync.Map
+)
+
+// WithRouteTimeout 设置路由的请求超时时间。
+//
+// 参数：
+//   - timeout：超时时间，小于等于 0 时不设置。
+//
+// 返回值：
+//   - RouteOption：路由配置选项。
+//
+// 超时通过请求上下文传递给 Kratos 处理器；Kratos 服务器自身的超时同样生效，因此路由超时只能比服务器超时更短。
+// 处理器返回时上下文已超时且尚未写出响应，Gin 侧返回 504 Gateway Timeout。
+func WithRouteTimeout(timeout time.Duration) RouteOption {
+	return func(o *routeOptions) {
+		o.timeout = timeout
+	}
+}
+
+// WithRouteBodyLimit 设置路由允许的最大请求体字节数。
+//
+// 参数：
+//   - limit：最大字节数，小于等于 0 时不限制。
+//
+// 返回值：
+//   - RouteOption：路由配置选项。
+//
+// Content-Length 超过限制的请求直接返回 413 Request Entity Too Large；未声明长度的请求体在读取超过限制时返回错误，
+// 由 Kratos 的解码与错误处理链决定响应。
+func WithRouteBodyLimit(limit int64) RouteOption {
+	return func(o *routeOptions) {
+		o.bodyLimit = limit
+	}
+}
+
+// WithRouteMiddleware 为路由挂载 Gin 处理器。
+//
+// 参数：
+//   - handlers：在路由组处理器之后、请求代理到 Kratos 之前依次执行的 Gin 处理器，多次设置时追加。
+//
+// 返回值：
+//   - RouteOption：路由配置选项。
+func WithRouteMiddleware(handlers ...gin.HandlerFunc) RouteOption {
+	return func(o *routeOptions) {
+		o.handlers = append(o.handlers, handlers...)
+	}
+}
+
+// WithRouteAuth 标记路由需要认证。
+//
+// 参数：无。
+//
+// 返回值：
+//   - RouteOption：路由配置选项。
+//
+// 需要认证的路由会执行 WithAuthenticator 配置的认证处理器；Parse 未配置认证处理器时，
+// 这类路由一律返回 401 Unauthorized，避免因遗漏配置而放行。
+func WithRouteAuth() RouteOption {
+	return func(o *routeOptions) {
+		o.requireAuth = true
+	}
+}
+
+// WithAuthenticator 设置需要认证的路由使用的认证处理器。
+//
+// 参数：
+//   - handlers：认证处理器，认证失败时应调用 Abort 系列方法终止请求。
+//
+// 返回值：
+//   - ParseOption：Parse 的配置选项。
+func WithAuthenticator(handlers ...gin.HandlerFunc) ParseOption {
+	return func(o *parseOptions) {
+		o.authenticators = handlers
+	}
+}
+
+// WithRoute 为指定路径的路由设置路由选项。
+//
+// 参数：
+//   - path：路由路径，支持 Mux 与 Gin 两种格式，例如 `/users/{id}` 与 `/users/:id` 等价。
+//   - opts：路由配置选项。
+//
+// 返回值：
+//   - ParseOption：Parse 的配置选项。
+//
+// WithRoute 适用于由 protobuf 生成代码等无法改用 HandleFunc 注册的路由；同一路径通过 HandleFunc 注册过选项时，
+// WithRoute 的选项在其之后应用。
+func WithRoute(path string, opts ...RouteOption) ParseOption {
+	return func(o *parseOptions) {
+		if nil == o.routes {
+			o.routes = make(map[string][]RouteOption)
+		}
+		path = parsePath(path)
+		o.routes[path] = append(o.routes[path], opts...)
+	}
+}
+
+// HandleFunc 在 Kratos HTTP Server 上注册处理函数，并记录 Parse 挂载 Gin 路由时使用的路由选项。
+//
+// 参数：
+//   - s：kratos http.Server 指针。
+//   - path：Mux 格式的路由路径。
+//   - h：处理函数。
+//   - opts：路由配置选项，例如 WithRouteTimeout、WithRouteBodyLimit、WithRouteMiddleware、WithRouteAuth、WithRouteUpload。
+//
+// 返回值：
+//   - *mux.Route：注册的路由，可继续调用 Methods 等方法限定匹配条件；s 为 nil 时返回 nil。
+//
+// 与 kratoshttp.Server.HandleFunc 相同，路由直接注册到内部 mux.Router，只是额外记录路由选项。
+func HandleFunc(s *kratoshttp.Server, path string, h http.HandlerFunc, opts ...RouteOption) *mux.Route {
+	return Handle(s, path, h, opts...)
+}
+
+// Handle 在 Kratos HTTP Server 上注册处理器，并记录 Parse 挂载 Gin 路由时使用的路由选项。
+//
+// 参数：
+//   - s：kratos http.Server 指针。
+//   - path：Mux 格式的路由路径。
+//   - h：处理器。
+//   - opts：路由配置选项。
+//
+// 返回值：
+//   - *mux.Route：注册的路由；s 为 nil 时返回 nil。
+func Handle(s *kratoshttp.Server, path string, h http.Handler, opts ...RouteOption) *mux.Route {
+	router := getRouter(s)
+	if nil == router {
+		return nil
+	}
+
+	route := router.Handle(path, h)
+	if len(opts) > 0 {
+		routeRegistry.Store(route, opts)
+	}
+	return route
+}
+
+// registeredRouteOptions 返回通过 HandleFunc、Handle 为路由记录的选项。
+//
+// 参数：
+//   - route：mux 路由。
+//
+// 返回值：
+//   - []RouteOption：路由选项；未记录时返回 nil。
+func registeredRouteOptions(route *mux.Route) []RouteOption {
+	if opts, ok := routeRegistry.Load(route); ok {
+		return opts.([]RouteOption)
+	}
+	return nil
+}
+
+// newRouteOptions 依次应用路由选项。
+//
+// 参数：
+//   - opts：路由配置选项，可为多组。
+//
+// 返回值：
+//   - *routeOptions：应用后的路由配置；没有任何选项时返回 nil。
+func newRouteOptions(opts ...[]RouteOption) *routeOptions {
+	var o *routeOptions
+	for _, group := range opts {
+		for _, opt := range group {
+			if nil == o {
+				o = &routeOptions{}
+			}
+			opt(o)
+		}
+	}
+	return o
+}
+
+// chain 返回路由配置对应的 Gin 处理器。
+//
+// 参数：
+//   - authenticators：WithAuthenticator 配置的认证处理器。
+//
+// 返回值：
+//   - []gin.HandlerFunc：依次为请求体限制、超时、认证、上传文件解析与路由级处理器。
+func (o *routeOptions) chain(authenticators []gin.HandlerFunc) []gin.HandlerFunc {
+	if nil == o {
+		return nil
+	}
+
+	chain := make([]gin.HandlerFunc, 0, len(authenticators)+len(o.handlers)+3)
+	if o.bodyLimit > 0 {
+		chain = append(chain, bodyLimitHandler(o.bodyLimit))
+	}
+	if o.timeout > 0 {
+		chain = append(chain, timeoutHandler(o.timeout))
+	}
+	if o.requireAuth {
+		if 0 == len(authenticators) {
+			chain = append(chain, func(c *gin.Context) {
+				c.AbortWithStatus(http.StatusUnauthorized)
+			})
+		} else {
+			chain = append(chain, authenticators...)
+		}
+	}
+	if nil != o.upload {
+		chain = append(chain, uploadHandler(o.upload))
+	}
+	return append(chain, o.handlers...)
+}
+
+// bodyLimitHandler 返回限制请求体大小的 Gin 处理器。
+//
+// 参数：
+//   - limit：最大字节数。
+//
+// 返回值：
+//   - gin.HandlerFunc：Content-Length 超过限制时返回 413，否则以 http.MaxBytesReader 包装请求体。
+func bodyLimitHandler(limit int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.ContentLength > limit {
+			c.AbortWithStatus(http.StatusRequestEntityTooLarge)
+			return
+		}
+		if nil != c.Request.Body {
+			c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limit)
+		}
+		c.Next()
+	}
+}
+
+// timeoutHandler 返回为请求上下文设置超时的 Gin 处理器。
+//
+// 参数：
+//   - timeout：超时时间。
+//
+// 返回值：
+//   - gin.HandlerFunc：后续处理器返回时上下文已超时且未写出响应，返回 504。
+func timeoutHandler(timeout time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) && !c.Writer.Written() {
+			c.AbortWithStatus(http.StatusGatewayTimeout)
+		}
+	}
+}
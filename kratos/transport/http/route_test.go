@@ -0,0 +1,201 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package http
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	kratoshttp "github.com/go-kratos/kratos/v2/transport/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestHandleFunc 测试 HandleFunc 注册路由并记录路由选项。
+func TestHandleFunc(t *testing.T) {
+	t.Log("验证 HandleFunc 注册的路由能被 GetPaths 提取，并携带路由选项；s 为 nil 时返回 nil。")
+
+	srv := kratoshttp.NewServer()
+	route := HandleFunc(srv, "/users/{id}", func(w http.ResponseWriter, r *http.Request) {}, WithRouteAuth())
+	require.NotNil(t, route)
+	route.Methods(http.MethodGet)
+	srv.HandleFunc("/plain", func(w http.ResponseWriter, r *http.Request) {})
+
+	routes := GetPaths(srv)
+	require.Len(t, routes, 2)
+	assert.Equal(t, "/users/{id}", routes[0].path)
+	assert.Len(t, routes[0].options, 1)
+	assert.Empty(t, routes[1].options)
+
+	assert.Nil(t, HandleFunc(nil, "/users", func(w http.ResponseWriter, r *http.Request) {}))
+}
+
+// TestParseWithRouteOptions 测试 Parse 按路由选项挂载超时、请求体限制、认证与路由级处理器。
+func TestParseWithRouteOptions(t *testing.T) {
+	// 设置 Gin 为测试模式。
+	gin.SetMode(gin.TestMode)
+
+	srv := kratoshttp.NewServer()
+	echo := func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if nil != err {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("X-Body", string(body))
+		w.WriteHeader(http.StatusOK)
+	}
+	HandleFunc(srv, "/upload", echo, WithRouteBodyLimit(4)).Methods(http.MethodPost)
+	HandleFunc(srv, "/slow", func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}, WithRouteTimeout(10*time.Millisecond)).Methods(http.MethodGet)
+	HandleFunc(srv, "/admin", echo, WithRouteAuth(), WithRouteMiddleware(func(c *gin.Context) {
+		c.Header("X-Route", "admin")
+	})).Methods(http.MethodGet)
+	srv.HandleFunc("/report/{id}", echo)
+	HandleFunc(srv, "/open", echo, WithRouteAuth()).Methods(http.MethodGet)
+
+	authenticator := func(c *gin.Context) {
+		if "secret" != c.GetHeader("Authorization") {
+			c.AbortWithStatus(http.StatusForbidden)
+		}
+	}
+
+	engine := gin.New()
+	Parse(srv, engine,
+		WithAuthenticator(authenticator),
+		WithRoute("/report/:id", WithRouteAuth()),
+	)
+
+	// 未配置认证处理器时，需要认证的路由一律拒绝。
+	strict := gin.New()
+	Parse(srv, strict)
+
+	// 定义测试用例。
+	tests := []struct {
+		name        string      // 测试用例名称。
+		description string      // 用例语义说明。
+		engine      *gin.Engine // 使用的 Gin 引擎。
+		method      string      // 请求方法。
+		path        string      // 请求路径。
+		body        string      // 请求体。
+		chunked     bool        // 是否不声明 Content-Length。
+		header      string      // Authorization 请求头。
+		wantStatus  int         // 期望状态码。
+		wantBody    string      // 期望 Kratos 处理器读到的请求体。
+		wantRoute   string      // 期望的路由级处理器标记。
+	}{
+		{
+			name:        "请求体未超过限制",
+			description: "验证请求体不超过限制时正常代理到 Kratos。",
+			engine:      engine,
+			method:      http.MethodPost,
+			path:        "/upload",
+			body:        "abcd",
+			wantStatus:  http.StatusOK,
+			wantBody:    "abcd",
+		},
+		{
+			name:        "Content-Length 超过限制",
+			description: "验证声明长度超过限制时直接返回 413。",
+			engine:      engine,
+			method:      http.MethodPost,
+			path:        "/upload",
+			body:        "abcdef",
+			wantStatus:  http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:        "未声明长度的请求体超过限制",
+			description: "验证未声明长度的请求体在读取超过限制时返回错误。",
+			engine:      engine,
+			method:      http.MethodPost,
+			path:        "/upload",
+			body:        "abcdef",
+			chunked:     true,
+			wantStatus:  http.StatusBadRequest,
+		},
+		{
+			name:        "路由超时",
+			description: "验证路由超时后处理器未写出响应时返回 504。",
+			engine:      engine,
+			method:      http.MethodGet,
+			path:        "/slow",
+			wantStatus:  http.StatusGatewayTimeout,
+		},
+		{
+			name:        "认证失败",
+			description: "验证需要认证的路由执行认证处理器，认证失败时不执行路由级处理器。",
+			engine:      engine,
+			method:      http.MethodGet,
+			path:        "/admin",
+			wantStatus:  http.StatusForbidden,
+		},
+		{
+			name:        "认证成功",
+			description: "验证认证成功后依次执行路由级处理器并代理到 Kratos。",
+			engine:      engine,
+			method:      http.MethodGet,
+			path:        "/admin",
+			header:      "secret",
+			wantStatus:  http.StatusOK,
+			wantRoute:   "admin",
+		},
+		{
+			name:        "WithRoute 设置认证",
+			description: "验证 WithRoute 为未经 HandleFunc 注册的路由设置路由选项。",
+			engine:      engine,
+			method:      http.MethodGet,
+			path:        "/report/1",
+			wantStatus:  http.StatusForbidden,
+		},
+		{
+			name:        "未配置认证处理器",
+			description: "验证未配置认证处理器时需要认证的路由返回 401。",
+			engine:      strict,
+			method:      http.MethodGet,
+			path:        "/open",
+			header:      "secret",
+			wantStatus:  http.StatusUnauthorized,
+		},
+		{
+			name:        "不需要认证的路由",
+			description: "验证未设置路由选项的路由不受认证处理器影响。",
+			engine:      strict,
+			method:      http.MethodGet,
+			path:        "/report/1",
+			wantStatus:  http.StatusOK,
+		},
+	}
+
+	// 执行测试用例。
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			var body io.Reader
+			if "" != tt.body {
+				body = strings.NewReader(tt.body)
+			}
+			req := httptest.NewRequest(tt.method, tt.path, body)
+			if tt.chunked {
+				req.ContentLength = -1
+			}
+			if "" != tt.header {
+				req.Header.Set("Authorization", tt.header)
+			}
+			resp := httptest.NewRecorder()
+			tt.engine.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.wantStatus, resp.Code)
+			assert.Equal(t, tt.wantBody, resp.Header().Get("X-Body"))
+			assert.Equal(t, tt.wantRoute, resp.Header().Get("X-Route"))
+		})
+	}
+}
@@ -91,13 +91,16 @@ type (
 
 	// RouteInfo 表示一条提取到的路由信息。
 	//
-	// RouteInfo 当前仅导出类型本身，method 和 path 字段未导出，主要供本包内部桥接逻辑和调试场景复用。
+	// RouteInfo 当前仅导出类型本身，method、path 和 options 字段未导出，主要供本包内部桥接逻辑和调试场景复用。
 	RouteInfo struct {
 		// method 是 HTTP 请求方法（GET、POST、PUT 等）。
 		method string
 
 		// path 是路由路径模板。
 		path string
+
+		// options 是通过 HandleFunc、Handle 注册路由时记录的路由选项。
+		options []RouteOption
 	}
 )
 
@@ -162,8 +165,9 @@ func GetPaths(s *kratoshttp.Server) []RouteInfo {
 		// 为每个 HTTP 方法创建路由信息对象并添加到结果切片中。
 		for _, m := range method {
 			routeInfos = append(routeInfos, RouteInfo{
-				method: m,
-				path:   path,
+				method:  m,
+				path:    path,
+				options: registeredRouteOptions(route),
 			})
 		}
 
@@ -177,25 +181,76 @@ func GetPaths(s *kratoshttp.Server) []RouteInfo {
 // 参数：
 //   - s：kratos http.Server 指针。
 //   - e：gin.Engine 指针。
-func Parse(s *kratoshttp.Server, e *gin.Engine) {
+//   - opts：可选配置，例如通过 WithGroup 为路由前缀挂载 Gin 处理器，通过 WithStatic、WithSPA 挂载静态资源，
+//     通过 WithCompression 启用响应压缩，或通过 WithRoute、WithAuthenticator 配置路由级处理。
+//
+// 通过 HandleFunc、Handle 注册时携带的路由选项与 WithRoute 的选项一起生效，路由级处理器在路由组处理器之后执行。
+// 为路由组补充注册的 OPTIONS 路由不挂载路由级处理器，预检请求不受认证、请求体限制影响。
+//
+// 配置了静态资源挂载时，Parse 会设置 Engine 的 NoRoute 处理器，未命中任何路由的请求再按挂载前缀查找文件。
+func Parse(s *kratoshttp.Server, e *gin.Engine, opts ...ParseOption) {
 	// 检查输入参数是否为空。
 	if s == nil || e == nil {
 		return
 	}
 
+	o := &parseOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	// 将请求代理到 Kratos HTTP 服务器处理。
+	proxy := func(c *gin.Context) {
+		s.ServeHTTP(c.Writer, c.Request)
+	}
+
 	// 获取所有路由信息。
 	routeInfos := GetPaths(s)
 
+	// 记录已注册 OPTIONS 的路径，以及需要补充 OPTIONS 的路由组路径（保持注册顺序）。
+	optionsPaths := make(map[string]struct{})
+	groupPaths := make([]string, 0)
+
 	// 遍历所有路由信息并注册到 Gin 引擎。
 	for _, routeInfo := range routeInfos {
 		// 将 Mux 路径格式转换为 Gin 路径格式。
 		path := parsePath(routeInfo.path)
 
-		// 在 Gin 中注册路由处理函数。
-		e.Handle(routeInfo.method, path, func(c *gin.Context) {
-			// 将请求代理到 Kratos HTTP 服务器处理。
-			s.ServeHTTP(c.Writer, c.Request)
-		})
+		// 在 Gin 中注册路由处理函数，路由组处理器与路由级处理器先于代理执行。
+		route := newRouteOptions(routeInfo.options, o.routes[path])
+		handlers, grouped := o.chain(path, route, proxy)
+		e.Handle(routeInfo.method, path, handlers...)
+
+		if isOptions(routeInfo.method) {
+			optionsPaths[path] = struct{}{}
+		} else if grouped {
+			groupPaths = append(groupPaths, path)
+		}
+	}
+
+	// 为路由组内缺少 OPTIONS 的路径补充注册，使预检请求能够到达路由组处理器。
+	for _, path := range groupPaths {
+		if _, ok := optionsPaths[path]; ok {
+			continue
+		}
+		optionsPaths[path] = struct{}{}
+
+		handlers, _ := o.chain(path, nil, proxy)
+		e.Handle(http.MethodOptions, path, handlers...)
+	}
+
+	// 文档在全部 Kratos 路由注册后生成，路由组与认证处理器不作用于文档路由。
+	if nil != o.openapi {
+		o.openapi.register(e, routeInfos, o)
+	}
+
+	// 静态资源放在 NoRoute 中，Kratos 路由优先，且避免与 Gin 通配路由冲突。
+	if len(o.statics) > 0 {
+		if nil != o.compression {
+			e.NoRoute(o.compression.handler(), o.staticHandler())
+		} else {
+			e.NoRoute(o.staticHandler())
+		}
 	}
 }
 
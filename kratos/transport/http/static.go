@@ -0,0 +1,290 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package http
+
+import (
+	"bytes"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// defaultIndexFile 是目录和 SPA 回退默认使用的入口文件。
+	defaultIndexFile = "index.html"
+	// defaultIndexCacheControl 是入口文件默认的 Cache-Control，保证发布后客户端能及时获取新版本。
+	defaultIndexCacheControl = "no-cache"
+)
+
+type (
+	// StaticOption 配置 WithStatic、WithStaticDir 和 WithSPA 挂载的静态资源行为。
+	StaticOption func(*staticMount)
+
+	// staticMount 表示一个挂载到路由前缀下的静态文件系统。
+	staticMount struct {
+		// prefix 是 Gin 格式的挂载前缀，不带末尾斜杠；根前缀为空串。
+		prefix string
+
+		// fsys 是提供静态文件的文件系统。
+		fsys fs.FS
+
+		// spa 表示未找到文件时是否回退到入口文件。
+		spa bool
+
+		// index 是目录和 SPA 回退使用的入口文件名。
+		index string
+
+		// cacheControl 是普通静态文件的 Cache-Control 值，为空时不设置。
+		cacheControl string
+
+		// indexCacheControl 是入口文件的 Cache-Control 值，为空时不设置。
+		indexCacheControl string
+	}
+)
+
+// WithStatic 将文件系统挂载到指定前缀下，提供 GET 和 HEAD 静态文件访问。
+//
+// 参数：
+//   - prefix：挂载前缀，按路径段匹配，例如 `/assets` 匹配 `/assets/app.js`。
+//   - fsys：静态文件系统，可以是 embed.FS（通常配合 fs.Sub 去掉目录前缀）或 os.DirFS。
+//   - opts：缓存头、入口文件等可选配置。
+//
+// 返回值：
+//   - ParseOption：Parse 的配置选项。
+//
+// 静态资源通过 Gin 的 NoRoute 处理器提供，因此 Kratos 路由总是优先，且不会与 Gin 通配路由冲突；
+// 调用 Parse 时会覆盖 Engine 上已有的 NoRoute 处理器。多个挂载的前缀同时匹配时使用最长前缀。
+// 请求目录时返回目录下的入口文件，不提供目录列表。
+func WithStatic(prefix string, fsys fs.FS, opts ...StaticOption) ParseOption {
+	return withStaticMount(prefix, fsys, false, opts)
+}
+
+// WithStaticDir 将本地目录挂载到指定前缀下，等价于 WithStatic(prefix, os.DirFS(dir), opts...)。
+//
+// 参数：
+//   - prefix：挂载前缀。
+//   - dir：本地目录路径。
+//   - opts：缓存头、入口文件等可选配置。
+//
+// 返回值：
+//   - ParseOption：Parse 的配置选项。
+func WithStaticDir(prefix, dir string, opts ...StaticOption) ParseOption {
+	return WithStatic(prefix, os.DirFS(dir), opts...)
+}
+
+// WithSPA 将单页应用挂载到指定前缀下。
+//
+// 参数：
+//   - prefix：挂载前缀，例如 `/` 或 `/console`。
+//   - fsys：包含入口文件和构建产物的文件系统。
+//   - opts：缓存头、入口文件等可选配置。
+//
+// 返回值：
+//   - ParseOption：Parse 的配置选项。
+//
+// 与 WithStatic 相同地提供静态文件；对于前缀下未找到文件、且最后一个路径段没有扩展名的 GET 或 HEAD
+// 请求，返回入口文件，由前端路由处理。带扩展名的缺失资源（例如 `/app.js`）仍返回 404，避免把 HTML
+// 当作脚本返回。
+func WithSPA(prefix string, fsys fs.FS, opts ...StaticOption) ParseOption {
+	return withStaticMount(prefix, fsys, true, opts)
+}
+
+// WithCacheControl 设置普通静态文件的 Cache-Control 响应头。
+//
+// 参数：
+//   - value：Cache-Control 值，例如 `public, max-age=31536000, immutable`；为空时不设置。
+//
+// 返回值：
+//   - StaticOption：静态资源配置选项。
+func WithCacheControl(value string) StaticOption {
+	return func(m *staticMount) {
+		m.cacheControl = value
+	}
+}
+
+// WithIndexCacheControl 设置入口文件的 Cache-Control 响应头，默认为 `no-cache`。
+//
+// 参数：
+//   - value：Cache-Control 值；为空时不设置。
+//
+// 返回值：
+//   - StaticOption：静态资源配置选项。
+func WithIndexCacheControl(value string) StaticOption {
+	return func(m *staticMount) {
+		m.indexCacheControl = value
+	}
+}
+
+// WithIndexFile 设置目录和 SPA 回退使用的入口文件名，默认为 `index.html`。
+//
+// 参数：
+//   - name：相对于文件系统根目录的入口文件名。
+//
+// 返回值：
+//   - StaticOption：静态资源配置选项。
+func WithIndexFile(name string) StaticOption {
+	return func(m *staticMount) {
+		m.index = strings.TrimPrefix(name, "/")
+	}
+}
+
+// withStaticMount 创建静态资源挂载配置。
+//
+// 参数：
+//   - prefix：挂载前缀。
+//   - fsys：静态文件系统。
+//   - spa：是否启用 SPA 回退。
+//   - opts：可选配置。
+//
+// 返回值：
+//   - ParseOption：Parse 的配置选项。
+func withStaticMount(prefix string, fsys fs.FS, spa bool, opts []StaticOption) ParseOption {
+	return func(o *parseOptions) {
+		m := &staticMount{
+			prefix:            strings.TrimRight(parsePath(prefix), "/"),
+			fsys:              fsys,
+			spa:               spa,
+			index:             defaultIndexFile,
+			indexCacheControl: defaultIndexCacheControl,
+		}
+		for _, opt := range opts {
+			opt(m)
+		}
+		o.statics = append(o.statics, m)
+	}
+}
+
+// matchStatic 返回请求路径所属的静态资源挂载。
+//
+// 参数：
+//   - requestPath：请求路径。
+//
+// 返回值：
+//   - *staticMount：最长匹配前缀的挂载；没有匹配时返回 nil。
+func (o *parseOptions) matchStatic(requestPath string) *staticMount {
+	var matched *staticMount
+	for _, m := range o.statics {
+		if requestPath != m.prefix && !strings.HasPrefix(requestPath, m.prefix+"/") {
+			continue
+		}
+		if nil == matched || len(m.prefix) > len(matched.prefix) {
+			matched = m
+		}
+	}
+	return matched
+}
+
+// staticHandler 返回分发静态资源请求的 Gin 处理器。
+//
+// 返回值：
+//   - gin.HandlerFunc：匹配挂载时提供文件，否则返回 404。
+func (o *parseOptions) staticHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		method := c.Request.Method
+		if http.MethodGet != method && http.MethodHead != method {
+			c.AbortWithStatus(http.StatusNotFound)
+			return
+		}
+		m := o.matchStatic(c.Request.URL.Path)
+		if nil == m || !m.serve(c, o.compression) {
+			c.AbortWithStatus(http.StatusNotFound)
+		}
+	}
+}
+
+// serve 尝试为请求提供静态文件。
+//
+// 参数：
+//   - c：Gin 请求上下文。
+//   - comp：响应压缩配置，决定是否使用预压缩文件；未启用压缩时为 nil。
+//
+// 返回值：
+//   - bool：已写入响应时返回 true；未找到文件时返回 false。
+func (m *staticMount) serve(c *gin.Context, comp *compression) bool {
+	// 去掉前缀后清理路径，path.Clean 会消除 ".." 等片段，防止越出文件系统根目录。
+	name := strings.TrimPrefix(path.Clean("/"+strings.TrimPrefix(c.Request.URL.Path, m.prefix)), "/")
+	if "" == name {
+		name = "."
+	}
+
+	if info, err := fs.Stat(m.fsys, name); nil == err {
+		if !info.IsDir() {
+			return m.serveFile(c, name, name == m.index, comp)
+		}
+		index := path.Join(name, m.index)
+		if _, err := fs.Stat(m.fsys, index); nil == err {
+			return m.serveFile(c, index, true, comp)
+		}
+	}
+
+	if m.spa && "" == path.Ext(name) {
+		return m.serveFile(c, m.index, true, comp)
+	}
+	return false
+}
+
+// serveFile 使用 http.ServeContent 写出文件，支持条件请求和 Range。
+//
+// 参数：
+//   - c：Gin 请求上下文。
+//   - name：文件系统中的文件名。
+//   - index：是否为入口文件，决定使用的 Cache-Control。
+//   - comp：响应压缩配置，启用预压缩时优先写出同名的 .br 或 .gz 文件；可以为 nil。
+//
+// 返回值：
+//   - bool：已写入响应时返回 true；文件无法打开时返回 false。
+func (m *staticMount) serveFile(c *gin.Context, name string, index bool, comp *compression) bool {
+	file, encoding, variants := comp.precompressedFile(m.fsys, c.Request, name)
+	if "" == file {
+		file = name
+	}
+
+	f, err := m.fsys.Open(file)
+	if nil != err {
+		return false
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if nil != err || info.IsDir() {
+		return false
+	}
+
+	if variants {
+		c.Writer.Header().Add("Vary", "Accept-Encoding")
+	}
+	if "" != encoding {
+		// 预压缩文件按原始文件的扩展名确定媒体类型，已设置 Content-Encoding 的响应不会被再次压缩。
+		c.Header("Content-Encoding", encoding)
+		c.Header("Content-Type", precompressedContentType(name))
+	}
+
+	cacheControl := m.cacheControl
+	if index {
+		cacheControl = m.indexCacheControl
+	}
+	if "" != cacheControl {
+		c.Header("Cache-Control", cacheControl)
+	}
+
+	if rs, ok := f.(io.ReadSeeker); ok {
+		http.ServeContent(c.Writer, c.Request, info.Name(), info.ModTime(), rs)
+		return true
+	}
+
+	// 不支持 Seek 的文件读入内存后再写出，以便仍然支持 Range。
+	data, err := io.ReadAll(f)
+	if nil != err {
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return true
+	}
+	http.ServeContent(c.Writer, c.Request, info.Name(), info.ModTime(), bytes.NewReader(data))
+	return true
+}
@@ -0,0 +1,197 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package http
+
+import (
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/gin-gonic/gin"
+	kratoshttp "github.com/go-kratos/kratos/v2/transport/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// noSeekFS 包装文件系统，使打开的文件不实现 io.Seeker。
+type noSeekFS struct {
+	fs.FS
+}
+
+// noSeekFile 是只暴露 fs.File 方法的文件。
+type noSeekFile struct {
+	fs.File
+}
+
+// Open 打开文件并隐藏 Seek 方法。
+func (n noSeekFS) Open(name string) (fs.File, error) {
+	f, err := n.FS.Open(name)
+	if nil != err {
+		return nil, err
+	}
+	return noSeekFile{File: f}, nil
+}
+
+// TestParseWithStatic 测试 Parse 挂载静态资源与 SPA 回退，并保证 Kratos 路由优先。
+func TestParseWithStatic(t *testing.T) {
+	// 设置 Gin 为测试模式。
+	gin.SetMode(gin.TestMode)
+
+	// 创建服务器并注册与静态资源前缀重叠的 Kratos 路由。
+	srv := kratoshttp.NewServer()
+	router := getRouter(srv)
+	router.Handle("/api/users", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("kratos"))
+	})).Methods("GET")
+
+	spa := fstest.MapFS{
+		"index.html":    {Data: []byte("<html>app</html>")},
+		"assets/app.js": {Data: []byte("console.log(1)")},
+	}
+	docs := fstest.MapFS{
+		"index.html":       {Data: []byte("docs home")},
+		"guide/index.html": {Data: []byte("guide")},
+		"raw.txt":          {Data: []byte("0123456789")},
+	}
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "logo.svg"), []byte("<svg/>"), 0o644))
+
+	engine := gin.New()
+	Parse(srv, engine,
+		WithSPA("/", spa, WithCacheControl("public, max-age=31536000, immutable")),
+		WithStatic("/docs", noSeekFS{FS: docs}, WithIndexCacheControl("")),
+		WithStaticDir("/files", dir),
+	)
+
+	// 定义测试用例。
+	tests := []struct {
+		name             string // 测试用例名称。
+		description      string // 用例语义说明。
+		method           string // 请求方法。
+		path             string // 请求路径。
+		header           string // Range 请求头。
+		wantStatus       int    // 期望状态码。
+		wantBody         string // 期望响应体。
+		wantCacheControl string // 期望 Cache-Control。
+	}{
+		{
+			name:        "Kratos 路由优先",
+			description: "验证与静态资源前缀重叠的 Kratos 路由仍由 Kratos 处理。",
+			method:      http.MethodGet,
+			path:        "/api/users",
+			wantStatus:  http.StatusOK,
+			wantBody:    "kratos",
+		},
+		{
+			name:             "静态资源使用缓存头",
+			description:      "验证普通静态文件使用 WithCacheControl 设置的缓存头。",
+			method:           http.MethodGet,
+			path:             "/assets/app.js",
+			wantStatus:       http.StatusOK,
+			wantBody:         "console.log(1)",
+			wantCacheControl: "public, max-age=31536000, immutable",
+		},
+		{
+			name:             "SPA 回退到入口文件",
+			description:      "验证未知的前端路由返回 index.html 且使用入口文件缓存头。",
+			method:           http.MethodGet,
+			path:             "/orders/42",
+			wantStatus:       http.StatusOK,
+			wantBody:         "<html>app</html>",
+			wantCacheControl: "no-cache",
+		},
+		{
+			name:        "缺失的带扩展名资源",
+			description: "验证带扩展名的缺失资源返回 404 而不是入口文件。",
+			method:      http.MethodGet,
+			path:        "/assets/missing.js",
+			wantStatus:  http.StatusNotFound,
+		},
+		{
+			name:        "非 GET 请求",
+			description: "验证静态资源只响应 GET 和 HEAD。",
+			method:      http.MethodPost,
+			path:        "/orders/42",
+			wantStatus:  http.StatusNotFound,
+		},
+		{
+			name:        "目录返回入口文件",
+			description: "验证请求目录时返回目录下的入口文件，且最长前缀优先。",
+			method:      http.MethodGet,
+			path:        "/docs/guide/",
+			wantStatus:  http.StatusOK,
+			wantBody:    "guide",
+		},
+		{
+			name:        "非 SPA 挂载不回退",
+			description: "验证 WithStatic 挂载下缺失的路径返回 404。",
+			method:      http.MethodGet,
+			path:        "/docs/unknown",
+			wantStatus:  http.StatusNotFound,
+		},
+		{
+			name:        "不可 Seek 文件支持 Range",
+			description: "验证不实现 io.Seeker 的文件仍支持 Range 请求。",
+			method:      http.MethodGet,
+			path:        "/docs/raw.txt",
+			header:      "bytes=2-4",
+			wantStatus:  http.StatusPartialContent,
+			wantBody:    "234",
+		},
+		{
+			name:        "路径穿越被清理",
+			description: "验证 .. 片段不会越出挂载的文件系统。",
+			method:      http.MethodGet,
+			path:        "/files/../../logo.svg",
+			wantStatus:  http.StatusOK,
+			wantBody:    "<svg/>",
+		},
+		{
+			name:        "本地目录 HEAD 请求",
+			description: "验证 WithStaticDir 挂载的本地目录响应 HEAD 请求。",
+			method:      http.MethodHead,
+			path:        "/files/logo.svg",
+			wantStatus:  http.StatusOK,
+		},
+	}
+
+	// 执行测试用例。
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if "" != tt.header {
+				req.Header.Set("Range", tt.header)
+			}
+			resp := httptest.NewRecorder()
+			engine.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.wantStatus, resp.Code)
+			if "" != tt.wantBody {
+				body, _ := io.ReadAll(resp.Body)
+				assert.Equal(t, tt.wantBody, string(body))
+			}
+			assert.Equal(t, tt.wantCacheControl, resp.Header().Get("Cache-Control"))
+		})
+	}
+}
+
+// TestMatchStatic 测试静态资源挂载按路径段匹配最长前缀。
+func TestMatchStatic(t *testing.T) {
+	o := &parseOptions{}
+	WithStatic("/", fstest.MapFS{})(o)
+	WithStatic("/static/", fstest.MapFS{})(o)
+
+	assert.Equal(t, "/static", o.matchStatic("/static/a.css").prefix)
+	assert.Equal(t, "", o.matchStatic("/statics/a.css").prefix)
+	assert.Nil(t, (&parseOptions{}).matchStatic("/a"))
+}
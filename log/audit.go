@@ -0,0 +1,558 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package log
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// AuditResultSuccess 表示审计事件对应的操作成功。
+	AuditResultSuccess = "success"
+	// AuditResultFailure 表示审计事件对应的操作失败。
+	AuditResultFailure = "failure"
+	// AuditResultDenied 表示审计事件对应的操作被拒绝。
+	AuditResultDenied = "denied"
+
+	// auditFilePrefix 是审计日志分段文件名前缀。
+	auditFilePrefix = "audit-"
+	// auditFileSuffix 是审计日志分段文件名后缀。
+	auditFileSuffix = ".jsonl"
+	// auditFileLayout 是审计日志分段文件名中的日期格式，按 UTC 日期分段。
+	auditFileLayout = "20060102"
+	// auditFileMode 是审计日志文件权限，审计内容通常只允许属主读写。
+	auditFileMode = 0600
+	// auditDirMode 是审计日志目录权限。
+	auditDirMode = 0700
+)
+
+var (
+	// ErrAuditTampered 表示审计日志的哈希链校验失败，记录可能被篡改、删除或重排。
+	ErrAuditTampered = errors.New("审计日志哈希链校验失败")
+)
+
+type (
+	// AuditEvent 定义一条结构化审计事件。
+	AuditEvent struct {
+		// Time 是事件发生时间；零值表示使用写入时间。
+		Time time.Time
+		// Actor 是执行操作的主体，例如用户名或服务账号。
+		Actor string
+		// Action 是执行的操作，例如 user.login、order.delete。
+		Action string
+		// Resource 是操作的目标资源，例如 order/123。
+		Resource string
+		// Result 是操作结果，建议使用 AuditResultSuccess、AuditResultFailure 或 AuditResultDenied。
+		Result string
+		// Details 是附加的结构化信息，需可被 JSON 序列化；写入时按 JSON 规范化，结构体等值会转换为 map。
+		Details map[string]interface{}
+	}
+
+	// AuditRecord 定义写入审计日志的一条记录。
+	//
+	// Hash 是除 Hash 字段外的记录 JSON 与前一条记录哈希串联后的 SHA-256 十六进制摘要，
+	// 任一记录被修改、删除或重排都会导致后续哈希无法对应。
+	AuditRecord struct {
+		// Seq 是从 1 开始的记录序号。
+		Seq uint64 `json:"seq"`
+		// Time 是事件发生时间（UTC）。
+		Time time.Time `json:"time"`
+		// Actor 是执行操作的主体。
+		Actor string `json:"actor"`
+		// Action 是执行的操作。
+		Action string `json:"action"`
+		// Resource 是操作的目标资源。
+		Resource string `json:"resource"`
+		// Result 是操作结果。
+		Result string `json:"result"`
+		// Details 是附加的结构化信息。
+		Details map[string]interface{} `json:"details,omitempty"`
+		// PrevHash 是前一条记录的 Hash；第一条记录为空字符串。
+		PrevHash string `json:"prev_hash"`
+		// Hash 是本条记录的链式哈希。
+		Hash string `json:"hash"`
+	}
+
+	// AuditLogger 将审计事件写入专用目录，与普通日志相互隔离。
+	//
+	// 审计记录以 JSON Lines 格式按 UTC 日期写入 audit-YYYYMMDD.jsonl 分段文件，
+	// 每条记录携带链式 SHA-256 哈希。AuditLogger 可安全地被多个 goroutine 并发使用。
+	AuditLogger struct {
+		// dir 是审计日志目录。
+		dir string
+		// retention 是分段文件的保留时长，0 表示永久保留。
+		retention time.Duration
+		// now 返回当前时间。
+		now func() time.Time
+		// mu 串行化写入，保证哈希链顺序。
+		mu sync.Mutex
+		// file 是当前写入的分段文件。
+		file *os.File
+		// day 是当前分段文件的日期。
+		day string
+		// seq 是最后一条记录的序号。
+		seq uint64
+		// lastHash 是最后一条记录的哈希。
+		lastHash string
+	}
+
+	// AuditOption 定义审计日志配置修改函数。
+	AuditOption func(*AuditLogger)
+)
+
+// WithAuditRetention 设置审计日志分段文件的保留时长。
+//
+// 参数：
+//   - retention：保留时长；分段日期早于当前时间减去保留时长的文件会在打开和切换分段时删除。
+//     0 表示永久保留。
+//
+// 返回：
+//   - AuditOption：应用于 AuditLogger 的配置选项。
+//
+// 删除最早的分段不会破坏剩余记录的哈希链，校验时以保留的第一条记录的 PrevHash 作为锚点。
+func WithAuditRetention(retention time.Duration) AuditOption {
+	return func(l *AuditLogger) {
+		l.retention = retention
+	}
+}
+
+// WithAuditClock 设置审计日志使用的时钟。
+//
+// 参数：
+//   - now：返回当前时间的函数，主要用于测试；nil 会被忽略。
+//
+// 返回：
+//   - AuditOption：应用于 AuditLogger 的配置选项。
+func WithAuditClock(now func() time.Time) AuditOption {
+	return func(l *AuditLogger) {
+		if nil != now {
+			l.now = now
+		}
+	}
+}
+
+// NewAuditLogger 创建写入指定目录的审计日志实例。
+//
+// 目录中已存在审计记录时，会从最后一条记录继续序号与哈希链。
+//
+// 参数：
+//   - dir：审计日志目录，不存在时自动创建。
+//   - opts：可选配置项。
+//
+// 返回：
+//   - *AuditLogger：审计日志实例。
+//   - error：目录创建失败、已有记录无法解析时返回错误。
+func NewAuditLogger(dir string, opts ...AuditOption) (*AuditLogger, error) {
+	l := &AuditLogger{
+		dir: dir,
+		now: time.Now,
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+
+	if err := os.MkdirAll(dir, auditDirMode); nil != err {
+		return nil, fmt.Errorf("创建审计日志目录失败：%w", err)
+	}
+
+	files, err := auditFiles(dir)
+	if nil != err {
+		return nil, err
+	}
+	// 从最后一个非空分段恢复序号与哈希链。
+	for i := len(files) - 1; i >= 0; i-- {
+		last, ok, err := lastAuditRecord(files[i])
+		if nil != err {
+			return nil, err
+		}
+		if ok {
+			l.seq, l.lastHash = last.Seq, last.Hash
+			break
+		}
+	}
+
+	l.prune()
+
+	return l, nil
+}
+
+// Log 写入一条审计事件。
+//
+// 参数：
+//   - event：审计事件。
+//
+// 返回：
+//   - AuditRecord：写入的记录，包含序号与链式哈希。
+//   - error：事件无法序列化或写入失败时返回错误；失败时哈希链不前进。
+func (l *AuditLogger) Log(event AuditEvent) (AuditRecord, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now().UTC()
+	if event.Time.IsZero() {
+		event.Time = now
+	}
+	details, err := canonicalAuditDetails(event.Details)
+	if nil != err {
+		return AuditRecord{}, err
+	}
+
+	record := AuditRecord{
+		Seq:      l.seq + 1,
+		Time:     event.Time.UTC(),
+		Actor:    event.Actor,
+		Action:   event.Action,
+		Resource: event.Resource,
+		Result:   event.Result,
+		Details:  details,
+		PrevHash: l.lastHash,
+	}
+
+	hash, err := auditHash(record)
+	if nil != err {
+		return AuditRecord{}, err
+	}
+	record.Hash = hash
+
+	line, err := json.Marshal(record)
+	if nil != err {
+		return AuditRecord{}, fmt.Errorf("序列化审计记录失败：%w", err)
+	}
+
+	if err := l.rotate(now); nil != err {
+		return AuditRecord{}, err
+	}
+	if _, err := l.file.Write(append(line, '\n')); nil != err {
+		return AuditRecord{}, fmt.Errorf("写入审计记录失败：%w", err)
+	}
+
+	l.seq, l.lastHash = record.Seq, record.Hash
+	return record, nil
+}
+
+// Close 关闭当前分段文件。
+//
+// 返回：
+//   - error：关闭文件失败时返回错误。
+func (l *AuditLogger) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if nil == l.file {
+		return nil
+	}
+	err := l.file.Close()
+	l.file, l.day = nil, ""
+	return err
+}
+
+// rotate 在日期变化时切换分段文件并清理过期分段。
+//
+// 参数：
+//   - now：当前 UTC 时间。
+//
+// 返回：
+//   - error：打开分段文件失败时返回错误。
+func (l *AuditLogger) rotate(now time.Time) error {
+	day := now.Format(auditFileLayout)
+	if nil != l.file && day == l.day {
+		return nil
+	}
+
+	file, err := os.OpenFile(filepath.Join(l.dir, auditFilePrefix+day+auditFileSuffix),
+		os.O_CREATE|os.O_WRONLY|os.O_APPEND, auditFileMode)
+	if nil != err {
+		return fmt.Errorf("打开审计日志文件失败：%w", err)
+	}
+	if nil != l.file {
+		_ = l.file.Close()
+	}
+	l.file, l.day = file, day
+
+	l.prune()
+	return nil
+}
+
+// prune 删除超过保留时长的分段文件，当前分段不会被删除。
+func (l *AuditLogger) prune() {
+	if l.retention <= 0 {
+		return
+	}
+
+	cutoff := l.now().UTC().Add(-l.retention)
+	files, err := auditFiles(l.dir)
+	if nil != err {
+		return
+	}
+	for _, file := range files {
+		day, ok := auditFileDay(file)
+		if !ok || day.Format(auditFileLayout) == l.day {
+			continue
+		}
+		// 分段覆盖整天，整天都早于截止时间才删除。
+		if day.AddDate(0, 0, 1).Before(cutoff) {
+			_ = os.Remove(file)
+		}
+	}
+}
+
+// VerifyAuditLog 校验目录中全部审计记录的哈希链。
+//
+// 参数：
+//   - dir：审计日志目录。
+//
+// 返回：
+//   - int：校验通过的记录数。
+//   - error：记录无法解析时返回错误；哈希链断裂时返回包装 ErrAuditTampered 的错误，
+//     错误信息包含首条异常记录的序号。
+func VerifyAuditLog(dir string) (int, error) {
+	return ExportAuditLog(dir, io.Discard, time.Time{}, time.Time{})
+}
+
+// ExportAuditLog 校验审计日志哈希链并导出指定时间范围内的记录。
+//
+// 导出内容为 JSON Lines 格式的原始记录，保留 PrevHash 与 Hash，
+// 接收方可以据此独立复核导出片段的完整性。
+//
+// 参数：
+//   - dir：审计日志目录。
+//   - w：导出目标。
+//   - from：起始时间（含），零值表示不限制。
+//   - to：结束时间（不含），零值表示不限制。
+//
+// 返回：
+//   - int：导出的记录数。
+//   - error：记录无法解析或写入失败时返回错误；哈希链断裂时返回包装 ErrAuditTampered 的错误，
+//     此时已写入的记录仍然有效。
+func ExportAuditLog(dir string, w io.Writer, from, to time.Time) (int, error) {
+	files, err := auditFiles(dir)
+	if nil != err {
+		return 0, err
+	}
+
+	exported := 0
+	var prev *AuditRecord
+	for _, file := range files {
+		err := readAuditFile(file, func(record AuditRecord, line []byte) error {
+			if err := verifyAuditRecord(prev, record); nil != err {
+				return err
+			}
+			prev = &record
+
+			if (!from.IsZero() && record.Time.Before(from)) || (!to.IsZero() && !record.Time.Before(to)) {
+				return nil
+			}
+			// line 引用读取缓冲区，复制后再追加换行，避免覆盖缓冲区中的后续内容。
+			out := make([]byte, 0, len(line)+1)
+			if _, err := w.Write(append(append(out, line...), '\n')); nil != err {
+				return fmt.Errorf("导出审计记录失败：%w", err)
+			}
+			exported++
+			return nil
+		})
+		if nil != err {
+			return exported, err
+		}
+	}
+
+	return exported, nil
+}
+
+// verifyAuditRecord 校验记录哈希以及与前一条记录的链接关系。
+//
+// 参数：
+//   - prev：前一条记录，nil 表示这是保留的第一条记录。
+//   - record：待校验的记录。
+//
+// 返回：
+//   - error：校验失败时返回包装 ErrAuditTampered 的错误。
+func verifyAuditRecord(prev *AuditRecord, record AuditRecord) error {
+	hash, err := auditHash(record)
+	if nil != err {
+		return err
+	}
+	if hash != record.Hash {
+		return fmt.Errorf("%w：记录 %d 的哈希不匹配", ErrAuditTampered, record.Seq)
+	}
+	if nil == prev {
+		// 保留的第一条记录以自身 PrevHash 作为锚点；序号为 1 时必须没有前驱。
+		if 1 == record.Seq && "" != record.PrevHash {
+			return fmt.Errorf("%w：记录 %d 的前驱哈希不匹配", ErrAuditTampered, record.Seq)
+		}
+		return nil
+	}
+	if record.Seq != prev.Seq+1 || record.PrevHash != prev.Hash {
+		return fmt.Errorf("%w：记录 %d 的前驱哈希不匹配", ErrAuditTampered, record.Seq)
+	}
+	return nil
+}
+
+// canonicalAuditDetails 将附加信息转换为从日志读回时的形式。
+//
+// 校验时哈希基于读回的记录重新计算：结构体读回为按字段名排序的 map，数字读回为 json.Number。
+// 写入前按同样方式经 JSON 往返一次，使写入与校验时序列化出的内容一致。
+//
+// 参数：
+//   - details：审计事件的附加信息。
+//
+// 返回：
+//   - map[string]interface{}：规范化后的附加信息；details 为空时返回 nil。
+//   - error：附加信息无法序列化时返回错误。
+func canonicalAuditDetails(details map[string]interface{}) (map[string]interface{}, error) {
+	if 0 == len(details) {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(details)
+	if nil != err {
+		return nil, fmt.Errorf("序列化审计记录失败：%w", err)
+	}
+	var canonical map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&canonical); nil != err {
+		return nil, fmt.Errorf("序列化审计记录失败：%w", err)
+	}
+	return canonical, nil
+}
+
+// auditHash 计算记录的链式哈希。
+//
+// 参数：
+//   - record：审计记录，Hash 字段不参与计算。
+//
+// 返回：
+//   - string：SHA-256 十六进制摘要。
+//   - error：记录无法序列化时返回错误。
+func auditHash(record AuditRecord) (string, error) {
+	record.Hash = ""
+	data, err := json.Marshal(record)
+	if nil != err {
+		return "", fmt.Errorf("序列化审计记录失败：%w", err)
+	}
+
+	h := sha256.New()
+	h.Write([]byte(record.PrevHash))
+	h.Write(data)
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// auditFiles 返回目录中按日期排序的审计分段文件。
+//
+// 参数：
+//   - dir：审计日志目录。
+//
+// 返回：
+//   - []string：分段文件路径。
+//   - error：读取目录失败时返回错误。
+func auditFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if nil != err {
+		return nil, fmt.Errorf("读取审计日志目录失败：%w", err)
+	}
+
+	files := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		file := filepath.Join(dir, entry.Name())
+		if _, ok := auditFileDay(file); ok {
+			files = append(files, file)
+		}
+	}
+	// 文件名中的日期为定长格式，按字典序即按日期排序。
+	sort.Strings(files)
+	return files, nil
+}
+
+// auditFileDay 解析分段文件名中的日期。
+//
+// 参数：
+//   - file：分段文件路径。
+//
+// 返回：
+//   - time.Time：分段日期（UTC 零点）。
+//   - bool：文件名符合分段格式时返回 true。
+func auditFileDay(file string) (time.Time, bool) {
+	name := filepath.Base(file)
+	if !strings.HasPrefix(name, auditFilePrefix) || !strings.HasSuffix(name, auditFileSuffix) {
+		return time.Time{}, false
+	}
+	day, err := time.Parse(auditFileLayout, strings.TrimSuffix(strings.TrimPrefix(name, auditFilePrefix), auditFileSuffix))
+	return day, nil == err
+}
+
+// lastAuditRecord 读取分段文件中的最后一条记录。
+//
+// 参数：
+//   - file：分段文件路径。
+//
+// 返回：
+//   - AuditRecord：最后一条记录。
+//   - bool：文件包含记录时返回 true。
+//   - error：记录无法解析时返回错误。
+func lastAuditRecord(file string) (AuditRecord, bool, error) {
+	var last AuditRecord
+	found := false
+	err := readAuditFile(file, func(record AuditRecord, _ []byte) error {
+		last, found = record, true
+		return nil
+	})
+	return last, found, err
+}
+
+// readAuditFile 逐条读取分段文件中的记录。
+//
+// 参数：
+//   - file：分段文件路径。
+//   - fn：处理每条记录的回调，line 为记录的原始 JSON；返回错误时停止读取。
+//
+// 返回：
+//   - error：文件读取、记录解析或回调失败时返回错误。
+func readAuditFile(file string, fn func(record AuditRecord, line []byte) error) error {
+	f, err := os.Open(file)
+	if nil != err {
+		return fmt.Errorf("打开审计日志文件失败：%w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if 0 == len(line) {
+			continue
+		}
+
+		var record AuditRecord
+		decoder := json.NewDecoder(bytes.NewReader(line))
+		// 保留数字的原始文本，避免大整数经 float64 往返后哈希不一致。
+		decoder.UseNumber()
+		if err := decoder.Decode(&record); nil != err {
+			return fmt.Errorf("%w：解析审计记录失败：%v", ErrAuditTampered, err)
+		}
+		if err := fn(record, line); nil != err {
+			return err
+		}
+	}
+	if err := scanner.Err(); nil != err {
+		return fmt.Errorf("读取审计日志文件失败：%w", err)
+	}
+	return nil
+}
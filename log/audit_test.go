@@ -0,0 +1,323 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package log
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAuditLogger_ChainAndResume 验证审计记录构成哈希链，重新打开后继续序号与哈希链。
+//
+// 参数：
+//   - t：测试上下文，用于报告断言失败。
+func TestAuditLogger_ChainAndResume(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+
+	l, err := NewAuditLogger(dir, WithAuditClock(clock))
+	require.NoError(t, err)
+
+	first, err := l.Log(AuditEvent{Actor: "alice", Action: "user.login", Resource: "session", Result: AuditResultSuccess})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), first.Seq)
+	assert.Empty(t, first.PrevHash)
+	assert.Equal(t, now, first.Time)
+	assert.Len(t, first.Hash, 64)
+
+	second, err := l.Log(AuditEvent{
+		Actor: "alice", Action: "order.delete", Resource: "order/1", Result: AuditResultDenied,
+		Details: map[string]interface{}{"id": uint64(1) << 60, "reason": "no permission"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, first.Hash, second.PrevHash)
+	require.NoError(t, l.Close())
+
+	// 重新打开后从最后一条记录继续。
+	l, err = NewAuditLogger(dir, WithAuditClock(clock))
+	require.NoError(t, err)
+	third, err := l.Log(AuditEvent{Actor: "bob", Action: "user.logout", Result: AuditResultSuccess})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), third.Seq)
+	assert.Equal(t, second.Hash, third.PrevHash)
+	require.NoError(t, l.Close())
+
+	n, err := VerifyAuditLog(dir)
+	require.NoError(t, err)
+	assert.Equal(t, 3, n)
+}
+
+// TestAuditLogger_Concurrent 验证并发写入时哈希链保持连续。
+//
+// 参数：
+//   - t：测试上下文，用于报告断言失败。
+func TestAuditLogger_Concurrent(t *testing.T) {
+	dir := t.TempDir()
+	l, err := NewAuditLogger(dir)
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errLog := l.Log(AuditEvent{Actor: "svc", Action: "tick", Result: AuditResultSuccess})
+			assert.NoError(t, errLog)
+		}()
+	}
+	wg.Wait()
+	require.NoError(t, l.Close())
+
+	n, err := VerifyAuditLog(dir)
+	require.NoError(t, err)
+	assert.Equal(t, 20, n)
+}
+
+// TestAuditLogger_DetailsRoundTrip 验证附加信息经 JSON 读回后形式变化时，未改动的日志仍能通过校验。
+//
+// 参数：
+//   - t：测试上下文，用于运行子测试和报告断言失败。
+func TestAuditLogger_DetailsRoundTrip(t *testing.T) {
+	type request struct {
+		Zeta  string
+		Alpha int
+	}
+
+	tests := []struct {
+		name        string
+		description string
+		details     map[string]interface{}
+		key         string
+		want        interface{}
+	}{
+		{
+			name:        "success/struct",
+			description: "验证结构体读回为按字段名排序的 map 后哈希仍然一致。",
+			details:     map[string]interface{}{"req": request{Zeta: "z", Alpha: 1}},
+			key:         "req",
+			want:        map[string]interface{}{"Alpha": json.Number("1"), "Zeta": "z"},
+		},
+		{
+			name:        "success/struct-pointer",
+			description: "验证结构体指针同样按读回形式写入。",
+			details:     map[string]interface{}{"req": &request{Zeta: "z"}},
+			key:         "req",
+			want:        map[string]interface{}{"Alpha": json.Number("0"), "Zeta": "z"},
+		},
+		{
+			name:        "success/large-int64",
+			description: "验证超过 2^53 的 int64 保持精度。",
+			details:     map[string]interface{}{"id": int64(1)<<62 + 1},
+			key:         "id",
+			want:        json.Number("4611686018427387905"),
+		},
+		{
+			name:        "success/float",
+			description: "验证浮点数按 JSON 文本读回。",
+			details:     map[string]interface{}{"ratio": 1.5},
+			key:         "ratio",
+			want:        json.Number("1.5"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			dir := t.TempDir()
+			l, err := NewAuditLogger(dir)
+			require.NoError(t, err)
+			record, err := l.Log(AuditEvent{Actor: "alice", Action: "order.create", Result: AuditResultSuccess, Details: tt.details})
+			require.NoError(t, err)
+			_, err = l.Log(AuditEvent{Actor: "alice", Action: "order.read", Result: AuditResultSuccess, Details: tt.details})
+			require.NoError(t, err)
+			require.NoError(t, l.Close())
+
+			assert.Equal(t, tt.want, record.Details[tt.key])
+			n, err := VerifyAuditLog(dir)
+			require.NoError(t, err)
+			assert.Equal(t, 2, n)
+		})
+	}
+
+	t.Run("error/unmarshalable", func(t *testing.T) {
+		t.Log("验证附加信息无法序列化时返回错误且哈希链不前进。")
+
+		l, err := NewAuditLogger(t.TempDir())
+		require.NoError(t, err)
+		defer func() { _ = l.Close() }()
+
+		_, err = l.Log(AuditEvent{Action: "x", Details: map[string]interface{}{"ch": make(chan int)}})
+		assert.Error(t, err)
+		record, err := l.Log(AuditEvent{Action: "y"})
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), record.Seq)
+	})
+}
+
+// TestVerifyAuditLog_DetectsTampering 验证修改、删除和重排记录都会被哈希链校验发现。
+//
+// 参数：
+//   - t：测试上下文，用于运行子测试和报告断言失败。
+func TestVerifyAuditLog_DetectsTampering(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		tamper      func(lines []string) []string
+		wantErr     bool
+	}{
+		{
+			name:        "success/untouched",
+			description: "验证未被修改的记录校验通过。",
+			tamper:      func(lines []string) []string { return lines },
+		},
+		{
+			name:        "error/modified",
+			description: "验证修改记录内容后哈希不匹配。",
+			tamper: func(lines []string) []string {
+				lines[1] = strings.Replace(lines[1], `"actor":"alice"`, `"actor":"mallory"`, 1)
+				return lines
+			},
+			wantErr: true,
+		},
+		{
+			name:        "error/deleted",
+			description: "验证删除中间记录后前驱哈希不匹配。",
+			tamper: func(lines []string) []string {
+				return append(lines[:1], lines[2:]...)
+			},
+			wantErr: true,
+		},
+		{
+			name:        "error/reordered",
+			description: "验证重排记录后前驱哈希不匹配。",
+			tamper: func(lines []string) []string {
+				lines[1], lines[2] = lines[2], lines[1]
+				return lines
+			},
+			wantErr: true,
+		},
+		{
+			name:        "boundary/head-truncated",
+			description: "验证按保留策略删除最早记录时，剩余记录以第一条的前驱哈希为锚点校验通过。",
+			tamper:      func(lines []string) []string { return lines[1:] },
+		},
+		{
+			name:        "error/garbage",
+			description: "验证无法解析的记录返回错误。",
+			tamper:      func(lines []string) []string { return append(lines, "{not json") },
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			dir := t.TempDir()
+			l, err := NewAuditLogger(dir)
+			require.NoError(t, err)
+			for _, actor := range []string{"root", "alice", "bob", "carol"} {
+				_, err = l.Log(AuditEvent{Actor: actor, Action: "read", Resource: "doc", Result: AuditResultSuccess})
+				require.NoError(t, err)
+			}
+			require.NoError(t, l.Close())
+
+			files, err := auditFiles(dir)
+			require.NoError(t, err)
+			require.Len(t, files, 1)
+			data, err := os.ReadFile(files[0])
+			require.NoError(t, err)
+			lines := tt.tamper(strings.Split(strings.TrimSpace(string(data)), "\n"))
+			require.NoError(t, os.WriteFile(files[0], []byte(strings.Join(lines, "\n")+"\n"), 0o600))
+
+			_, err = VerifyAuditLog(dir)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrAuditTampered)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+// TestAuditLogger_RetentionAndExport 验证按日期分段、保留时长清理以及按时间范围导出。
+//
+// 参数：
+//   - t：测试上下文，用于报告断言失败。
+func TestAuditLogger_RetentionAndExport(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+
+	l, err := NewAuditLogger(dir, WithAuditClock(clock), WithAuditRetention(48*time.Hour))
+	require.NoError(t, err)
+	for day := 0; day < 4; day++ {
+		now = time.Date(2025, 3, 1+day, 12, 0, 0, 0, time.UTC)
+		_, err = l.Log(AuditEvent{Actor: "alice", Action: "read", Result: AuditResultSuccess})
+		require.NoError(t, err)
+	}
+	require.NoError(t, l.Close())
+
+	// 当前为 3 月 4 日 12 点，保留 48 小时，3 月 1 日分段整天早于截止时间被删除。
+	files, err := auditFiles(dir)
+	require.NoError(t, err)
+	names := make([]string, 0, len(files))
+	for _, file := range files {
+		names = append(names, filepath.Base(file))
+	}
+	assert.Equal(t, []string{"audit-20250302.jsonl", "audit-20250303.jsonl", "audit-20250304.jsonl"}, names)
+
+	// 删除最早分段后剩余记录仍可校验。
+	n, err := VerifyAuditLog(dir)
+	require.NoError(t, err)
+	assert.Equal(t, 3, n)
+
+	// 导出 3 月 3 日（含）之后的记录。
+	buf := &bytes.Buffer{}
+	n, err = ExportAuditLog(dir, buf, time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC), time.Time{})
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	seqs := make([]uint64, 0)
+	scanner := bufio.NewScanner(buf)
+	for scanner.Scan() {
+		var record AuditRecord
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+		seqs = append(seqs, record.Seq)
+	}
+	assert.Equal(t, []uint64{3, 4}, seqs)
+}
+
+// TestNewAuditLogger_Errors 验证目录不可用和已有记录损坏时返回错误。
+//
+// 参数：
+//   - t：测试上下文，用于报告断言失败。
+func TestNewAuditLogger_Errors(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "file")
+	require.NoError(t, os.WriteFile(file, nil, 0o600))
+	_, err := NewAuditLogger(filepath.Join(file, "audit"))
+	require.Error(t, err)
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "audit-20250301.jsonl"), []byte("broken\n"), 0o600))
+	_, err = NewAuditLogger(dir)
+	require.ErrorIs(t, err, ErrAuditTampered)
+
+	_, err = VerifyAuditLog(filepath.Join(dir, "missing"))
+	require.Error(t, err)
+}
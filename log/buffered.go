@@ -0,0 +1,380 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package log
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+const (
+	// defaultBufferSize 是 BufferedWriter 默认的缓冲区大小。
+	defaultBufferSize = 64 << 10
+	// defaultFlushInterval 是 BufferedWriter 默认的定时刷新间隔。
+	defaultFlushInterval = time.Second
+)
+
+var (
+	// ErrWriterClosed 表示向已关闭的 BufferedWriter 写入。
+	//
+	// 调用方可以使用 errors.Is 判断该错误。
+	ErrWriterClosed = errors.New("日志写入器已关闭。")
+
+	// bufferedWriters 记录尚未关闭的 BufferedWriter，供 SyncBuffers 与退出钩子使用。
+	bufferedWriters = struct {
+		sync.Mutex
+		// writers 是尚未关闭的写入器集合。
+		writers map[*BufferedWriter]struct{}
+	}{
+		writers: make(map[*BufferedWriter]struct{}),
+	}
+
+	// bufferedExitHookOnce 保证退出钩子只注册一次。
+	bufferedExitHookOnce sync.Once
+)
+
+type (
+	// BufferedWriter 是批量写入底层 io.Writer 的日志写入器，在关键时刻保证落盘。
+	//
+	// 普通写入先追加到内存缓冲区，缓冲区写满或到达定时刷新间隔时批量写出，减少系统调用；
+	// 以下时刻会刷新缓冲区，并在底层写入器提供 Sync 方法（例如 *os.File）时调用 Sync 落盘：
+	//   - StdLogger 与 LogrusLogger 以该写入器为输出时，每次记录 Error、Fatal 级别日志之后；
+	//   - Fatal 与 ExitOnPanic 执行退出钩子时；
+	//   - 收到 WithSyncOnSignal 指定的信号时；
+	//   - 调用 Sync、SyncBuffers 或 Close 时。
+	//
+	// 进程因运行时致命错误或其它 goroutine 中未恢复的 panic 崩溃时无法执行上述流程，
+	// 最多丢失一个定时刷新间隔内的 Error 以下级别日志。BufferedWriter 的所有方法都可以并发调用。
+	BufferedWriter struct {
+		// mu 保护 buf 与 closed，并串行化对底层写入器的写入。
+		mu sync.Mutex
+		// w 是底层写入器。
+		w io.Writer
+		// buf 是尚未写出的日志内容。
+		buf []byte
+		// size 是缓冲区大小。
+		size int
+		// closed 表示写入器已关闭。
+		closed bool
+
+		// interval 是定时刷新间隔，小于等于 0 时不定时刷新。
+		interval time.Duration
+		// signals 是触发同步的信号。
+		signals []os.Signal
+		// done 在 Close 时关闭，通知后台 goroutine 退出。
+		done chan struct{}
+		// wg 等待后台 goroutine 退出。
+		wg sync.WaitGroup
+	}
+
+	// BufferedWriterOption 定义 NewBufferedWriter 的配置选项。
+	BufferedWriterOption func(*BufferedWriter)
+)
+
+// WithBufferSize 设置缓冲区大小。
+//
+// 参数：
+//   - size：缓冲区字节数；小于等于 0 时使用默认值 64 KiB。单次写入不小于该值时直接写出。
+//
+// 返回：
+//   - BufferedWriterOption：应用于 BufferedWriter 的配置选项。
+func WithBufferSize(size int) BufferedWriterOption {
+	return func(w *BufferedWriter) {
+		if size <= 0 {
+			size = defaultBufferSize
+		}
+		w.size = size
+	}
+}
+
+// WithFlushInterval 设置定时刷新间隔。
+//
+// 定时刷新只把缓冲区写入底层写入器，不调用 Sync。
+//
+// 参数：
+//   - interval：刷新间隔，默认 1 秒；小于等于 0 时不定时刷新，缓冲区写满或同步时才写出。
+//
+// 返回：
+//   - BufferedWriterOption：应用于 BufferedWriter 的配置选项。
+func WithFlushInterval(interval time.Duration) BufferedWriterOption {
+	return func(w *BufferedWriter) {
+		w.interval = interval
+	}
+}
+
+// WithSyncOnSignal 设置收到指定信号时同步缓冲区。
+//
+// 收到信号后刷新缓冲区并落盘，随后停止监听并向当前进程重新发送该信号：未注册其它处理器时进程按默认行为终止，
+// 已通过 signal.Notify 注册处理器的程序（例如 Kratos App 的优雅关闭）会再收到一次该信号。
+// 当前平台无法重新发送信号时（例如 Windows 上的 os.Interrupt），以退出码 1 退出。
+// 信号触发后写入器保持可用，优雅关闭过程中的日志仍会写入，应在关闭流程结束时调用 Close 或 SyncBuffers。
+//
+// 参数：
+//   - signals：触发同步的信号；未传入时为 os.Interrupt 与 syscall.SIGTERM。
+//
+// 返回：
+//   - BufferedWriterOption：应用于 BufferedWriter 的配置选项。
+func WithSyncOnSignal(signals ...os.Signal) BufferedWriterOption {
+	return func(w *BufferedWriter) {
+		if 0 == len(signals) {
+			signals = []os.Signal{os.Interrupt, syscall.SIGTERM}
+		}
+		w.signals = signals
+	}
+}
+
+// NewBufferedWriter 创建批量写入 w 的日志写入器。
+//
+// 参数：
+//   - w：底层写入器，例如日志文件；为 nil 时使用标准输出。
+//   - opts：缓冲区大小、定时刷新间隔与触发同步的信号等配置。
+//
+// 返回：
+//   - *BufferedWriter：创建的写入器；不再使用时应调用 Close，以写出剩余内容并停止后台 goroutine。
+func NewBufferedWriter(w io.Writer, opts ...BufferedWriterOption) *BufferedWriter {
+	if nil == w {
+		w = os.Stdout
+	}
+
+	bw := &BufferedWriter{
+		w:        w,
+		size:     defaultBufferSize,
+		interval: defaultFlushInterval,
+		done:     make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(bw)
+	}
+	bw.buf = make([]byte, 0, bw.size)
+
+	if bw.interval > 0 {
+		bw.wg.Go(bw.flushLoop)
+	}
+	if len(bw.signals) > 0 {
+		// 在返回前注册信号，保证 NewBufferedWriter 返回后收到的信号都会触发同步。
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, bw.signals...)
+		bw.wg.Go(func() { bw.signalLoop(signals) })
+	}
+
+	// 退出钩子按注册逆序执行，首个写入器创建时注册，使其晚于其它资源的清理执行，能够写出清理过程中的日志。
+	bufferedExitHookOnce.Do(func() {
+		RegisterExitHook("log.BufferedWriter", func(context.Context, ExitEvent) error {
+			return SyncBuffers()
+		})
+	})
+	bufferedWriters.Lock()
+	bufferedWriters.writers[bw] = struct{}{}
+	bufferedWriters.Unlock()
+
+	return bw
+}
+
+// SyncBuffers 同步全部尚未关闭的 BufferedWriter。
+//
+// 适合在 main 函数返回前或优雅关闭流程结束时调用，确保未达到刷新条件的日志写出并落盘。
+//
+// 参数：无。
+//
+// 返回：
+//   - error：各写入器同步失败的错误，以 errors.Join 合并。
+func SyncBuffers() error {
+	bufferedWriters.Lock()
+	writers := make([]*BufferedWriter, 0, len(bufferedWriters.writers))
+	for w := range bufferedWriters.writers {
+		writers = append(writers, w)
+	}
+	bufferedWriters.Unlock()
+
+	var errs []error
+	for _, w := range writers {
+		if err := w.Sync(); nil != err && !errors.Is(err, ErrWriterClosed) {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Write 把 p 追加到缓冲区，缓冲区空间不足时先写出已有内容。
+//
+// 参数：
+//   - p：一条或多条日志的内容，Write 返回后调用方可以复用 p。
+//
+// 返回：
+//   - int：写入的字节数。
+//   - error：写入器已关闭时返回 ErrWriterClosed，写出缓冲区失败时返回底层写入器的错误。
+func (w *BufferedWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return 0, ErrWriterClosed
+	}
+	if len(w.buf)+len(p) > w.size {
+		if err := w.flushLocked(); nil != err {
+			return 0, err
+		}
+	}
+	if len(p) >= w.size {
+		return w.w.Write(p)
+	}
+	w.buf = append(w.buf, p...)
+	return len(p), nil
+}
+
+// Flush 把缓冲区内容写入底层写入器，不调用 Sync。
+//
+// 参数：无。
+//
+// 返回：
+//   - error：写入器已关闭时返回 ErrWriterClosed，写出失败时返回底层写入器的错误，未写出的内容保留在缓冲区。
+func (w *BufferedWriter) Flush() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return ErrWriterClosed
+	}
+	return w.flushLocked()
+}
+
+// Sync 把缓冲区内容写入底层写入器，并在底层写入器提供 Sync 方法时落盘。
+//
+// 底层写入器为终端或管道时 Sync 返回的 EINVAL 会被忽略。
+//
+// 参数：无。
+//
+// 返回：
+//   - error：写入器已关闭时返回 ErrWriterClosed，写出或落盘失败时返回底层写入器的错误。
+func (w *BufferedWriter) Sync() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return ErrWriterClosed
+	}
+	return w.syncLocked()
+}
+
+// Close 同步缓冲区，停止后台 goroutine，并在底层写入器实现 io.Closer 时关闭底层写入器，标准输出与标准错误除外。
+//
+// 重复调用 Close 返回 nil。
+//
+// 参数：无。
+//
+// 返回：
+//   - error：同步或关闭底层写入器失败时返回错误，以 errors.Join 合并。
+func (w *BufferedWriter) Close() error {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return nil
+	}
+	errSync := w.syncLocked()
+	w.closed = true
+	w.mu.Unlock()
+
+	close(w.done)
+	w.wg.Wait()
+
+	bufferedWriters.Lock()
+	delete(bufferedWriters.writers, w)
+	bufferedWriters.Unlock()
+
+	var errClose error
+	if closer, ok := w.w.(io.Closer); ok && os.Stdout != w.w && os.Stderr != w.w {
+		errClose = closer.Close()
+	}
+	return errors.Join(errSync, errClose)
+}
+
+// flushLocked 写出缓冲区内容，调用方需持有 mu。
+//
+// 参数：无。
+//
+// 返回：
+//   - error：底层写入器返回的错误，未写出的内容保留在缓冲区。
+func (w *BufferedWriter) flushLocked() error {
+	if 0 == len(w.buf) {
+		return nil
+	}
+	n, err := w.w.Write(w.buf)
+	if n < len(w.buf) && nil == err {
+		err = io.ErrShortWrite
+	}
+	w.buf = w.buf[:copy(w.buf, w.buf[n:])]
+	return err
+}
+
+// syncLocked 写出缓冲区内容并落盘，调用方需持有 mu。
+//
+// 参数：无。
+//
+// 返回：
+//   - error：写出或落盘失败时返回错误。
+func (w *BufferedWriter) syncLocked() error {
+	if err := w.flushLocked(); nil != err {
+		return err
+	}
+	syncer, ok := w.w.(interface{ Sync() error })
+	if !ok {
+		return nil
+	}
+	if err := syncer.Sync(); nil != err && !errors.Is(err, syscall.EINVAL) && !errors.Is(err, syscall.ENOTSUP) {
+		return err
+	}
+	return nil
+}
+
+// flushLoop 按定时刷新间隔写出缓冲区，直到写入器关闭。
+//
+// 参数：无。
+func (w *BufferedWriter) flushLoop() {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.done:
+			return
+		case <-ticker.C:
+			_ = w.Flush()
+		}
+	}
+}
+
+// signalLoop 在收到指定信号时同步缓冲区并重新发送信号，直到写入器关闭。
+//
+// 参数：
+//   - ch：已通过 signal.Notify 注册的信号通道。
+func (w *BufferedWriter) signalLoop(ch chan os.Signal) {
+	defer signal.Stop(ch)
+
+	select {
+	case <-w.done:
+	case sig := <-ch:
+		_ = w.Sync()
+		signal.Stop(ch)
+		if process, err := os.FindProcess(os.Getpid()); nil != err || nil != process.Signal(sig) {
+			callExitFunc(1)
+		}
+	}
+}
+
+// syncOutput 在日志输出为 BufferedWriter 时同步缓冲区，供记录 Error、Fatal 级别日志后调用。
+//
+// 参数：
+//   - w：日志实例的输出。
+func syncOutput(w io.Writer) {
+	if bw, ok := w.(*BufferedWriter); ok {
+		_ = bw.Sync()
+	}
+}
@@ -0,0 +1,354 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package log
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type (
+	// syncRecorder 是记录写入内容与 Sync 次数的底层写入器。
+	syncRecorder struct {
+		mu     sync.Mutex
+		buf    bytes.Buffer
+		writes int
+		syncs  int
+		closed bool
+	}
+)
+
+// Write 记录写入内容。
+//
+// 参数：
+//   - p: 待写入的数据。
+//
+// 返回：
+//   - int: 写入的字节数。
+//   - error: 写入错误。
+func (r *syncRecorder) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.writes++
+	return r.buf.Write(p)
+}
+
+// Sync 记录同步次数。
+//
+// 返回：
+//   - error: 始终为 nil。
+func (r *syncRecorder) Sync() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.syncs++
+	return nil
+}
+
+// Close 记录关闭状态。
+//
+// 返回：
+//   - error: 始终为 nil。
+func (r *syncRecorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.closed = true
+	return nil
+}
+
+// snapshot 返回已写入的内容、写入次数与同步次数。
+//
+// 返回：
+//   - string: 已写入的内容。
+//   - int: 写入次数。
+//   - int: 同步次数。
+func (r *syncRecorder) snapshot() (string, int, int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.buf.String(), r.writes, r.syncs
+}
+
+// TestBufferedWriter_Write 验证缓冲区的批量写出、直接写出与关闭行为。
+func TestBufferedWriter_Write(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		size        int
+		writes      []string
+		wantContent string
+		wantWrites  int
+	}{
+		{
+			name:        "buffered",
+			description: "验证未写满缓冲区时不写出。",
+			size:        16,
+			writes:      []string{"abc", "def"},
+			wantContent: "",
+			wantWrites:  0,
+		},
+		{
+			name:        "overflow",
+			description: "验证缓冲区空间不足时先批量写出已有内容。",
+			size:        8,
+			writes:      []string{"abcd", "efg", "hij"},
+			wantContent: "abcdefg",
+			wantWrites:  1,
+		},
+		{
+			name:        "large",
+			description: "验证不小于缓冲区的写入在写出已有内容后直接写出。",
+			size:        4,
+			writes:      []string{"ab", "cdefgh"},
+			wantContent: "abcdefgh",
+			wantWrites:  2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			rec := &syncRecorder{}
+			w := NewBufferedWriter(rec, WithBufferSize(tt.size), WithFlushInterval(0))
+			for _, s := range tt.writes {
+				n, err := w.Write([]byte(s))
+				require.NoError(t, err)
+				assert.Equal(t, len(s), n)
+			}
+			content, writes, syncs := rec.snapshot()
+			assert.Equal(t, tt.wantContent, content)
+			assert.Equal(t, tt.wantWrites, writes)
+			assert.Zero(t, syncs)
+
+			require.NoError(t, w.Close())
+			content, _, syncs = rec.snapshot()
+			assert.Equal(t, strings.Join(tt.writes, ""), content)
+			assert.Equal(t, 1, syncs)
+			assert.True(t, rec.closed)
+
+			_, err := w.Write([]byte("x"))
+			assert.ErrorIs(t, err, ErrWriterClosed)
+			assert.ErrorIs(t, w.Sync(), ErrWriterClosed)
+			assert.NoError(t, w.Close())
+		})
+	}
+}
+
+// TestBufferedWriter_FlushInterval 验证定时刷新只写出不落盘。
+func TestBufferedWriter_FlushInterval(t *testing.T) {
+	rec := &syncRecorder{}
+	w := NewBufferedWriter(rec, WithFlushInterval(10*time.Millisecond))
+	defer func() { _ = w.Close() }()
+
+	_, err := w.Write([]byte("tick"))
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		content, _, _ := rec.snapshot()
+		return "tick" == content
+	}, time.Second, 5*time.Millisecond)
+	_, _, syncs := rec.snapshot()
+	assert.Zero(t, syncs)
+}
+
+// TestBufferedWriter_SyncsOnError 验证日志实例以 BufferedWriter 为输出时，Error 与 Fatal 级别日志立即落盘。
+func TestBufferedWriter_SyncsOnError(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		run         func(l Logger)
+		wantSyncs   int
+	}{
+		{
+			name:        "info",
+			description: "验证 Info 级别日志只写入缓冲区。",
+			run:         func(l Logger) { l.Info("info") },
+			wantSyncs:   0,
+		},
+		{
+			name:        "error",
+			description: "验证 Error 与 Errorf 级别日志记录后同步。",
+			run: func(l Logger) {
+				l.Info("info")
+				l.Error("error")
+				l.Errorf("%s", "errorf")
+			},
+			wantSyncs: 2,
+		},
+		{
+			name:        "fatal",
+			description: "验证 Fatal 级别日志在退出前同步。",
+			run: func(l Logger) {
+				_ = CaptureFatal(func() { l.WithField("k", "v").Fatal("fatal") })
+			},
+			wantSyncs: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		for _, logType := range []LogType{LogTypeStd, LogTypeLogrus} {
+			t.Run(tt.name+"/"+string(logType), func(t *testing.T) {
+				t.Log(tt.description)
+
+				logger, err := NewLogger(WithLogType(logType), WithBufferedOutput(4096, 0))
+				require.NoError(t, err)
+				rec := &syncRecorder{}
+				bw := NewBufferedWriter(rec, WithFlushInterval(0))
+				defer func() { _ = bw.Close() }()
+				replaceOutput(t, logger, bw)
+
+				tt.run(logger)
+				content, _, syncs := rec.snapshot()
+				// Fatal 还会经由退出钩子同步一次。
+				assert.GreaterOrEqual(t, syncs, tt.wantSyncs)
+				if 0 == tt.wantSyncs {
+					assert.Zero(t, syncs)
+					assert.Empty(t, content)
+				} else {
+					assert.NotEmpty(t, content)
+				}
+			})
+		}
+	}
+}
+
+// TestBufferedWriter_File 验证 NewLogger 缓冲文件输出，SyncBuffers 写出剩余日志。
+func TestBufferedWriter_File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	logger, err := NewLogger(WithOutput(path), WithBufferedOutput(4096, 0))
+	require.NoError(t, err)
+	bw, ok := logger.(*StdLogger).logger.Writer().(*BufferedWriter)
+	require.True(t, ok)
+	defer func() { _ = bw.Close() }()
+
+	logger.Info("buffered")
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Empty(t, data)
+
+	require.NoError(t, SyncBuffers())
+	data, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "buffered")
+}
+
+// TestBufferedWriter_SyncOnSignal 验证收到指定信号时同步，并把信号重新发送给其它处理器。
+func TestBufferedWriter_SyncOnSignal(t *testing.T) {
+	if "windows" == runtime.GOOS {
+		t.Skip("Windows 不支持向当前进程发送 SIGHUP。")
+	}
+
+	// 模拟应用自身的信号处理器，避免重新发送的信号终止测试进程。
+	appSignals := make(chan os.Signal, 2)
+	signal.Notify(appSignals, syscall.SIGHUP)
+	defer signal.Stop(appSignals)
+
+	rec := &syncRecorder{}
+	w := NewBufferedWriter(rec, WithFlushInterval(0), WithSyncOnSignal(syscall.SIGHUP))
+	defer func() { _ = w.Close() }()
+	_, err := w.Write([]byte("before-signal"))
+	require.NoError(t, err)
+
+	process, err := os.FindProcess(os.Getpid())
+	require.NoError(t, err)
+	require.NoError(t, process.Signal(syscall.SIGHUP))
+	require.Eventually(t, func() bool {
+		_, _, syncs := rec.snapshot()
+		return 1 == syncs
+	}, time.Second, 5*time.Millisecond)
+	content, _, _ := rec.snapshot()
+	assert.Equal(t, "before-signal", content)
+
+	// 原始信号与重新发送的信号都送达应用处理器。
+	for range 2 {
+		select {
+		case <-appSignals:
+		case <-time.After(time.Second):
+			t.Fatal("等待信号超时。")
+		}
+	}
+
+	// 信号触发后写入器仍可使用。
+	_, err = w.Write([]byte("|after"))
+	require.NoError(t, err)
+	require.NoError(t, w.Sync())
+	content, _, _ = rec.snapshot()
+	assert.Equal(t, "before-signal|after", content)
+}
+
+// TestBufferedWriter_WriteError 验证底层写入失败时保留未写出的内容。
+func TestBufferedWriter_WriteError(t *testing.T) {
+	fail := &failingWriter{err: errors.New("disk full")}
+	w := NewBufferedWriter(fail, WithFlushInterval(0))
+
+	_, err := w.Write([]byte("keep"))
+	require.NoError(t, err)
+	assert.ErrorIs(t, w.Flush(), fail.err)
+
+	fail.err = nil
+	require.NoError(t, w.Close())
+	assert.Equal(t, "keep", fail.buf.String())
+}
+
+type (
+	// failingWriter 是可以模拟写入失败的底层写入器。
+	failingWriter struct {
+		buf bytes.Buffer
+		err error
+	}
+)
+
+// Write 在 err 非 nil 时返回 err，否则记录写入内容。
+//
+// 参数：
+//   - p: 待写入的数据。
+//
+// 返回：
+//   - int: 写入的字节数。
+//   - error: 模拟的写入错误。
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if nil != w.err {
+		return 0, w.err
+	}
+	return w.buf.Write(p)
+}
+
+// replaceOutput 把 NewLogger 创建的日志实例的输出替换为 w。
+//
+// 参数：
+//   - t: 测试上下文，用于报告不支持的日志实现。
+//   - logger: NewLogger 创建的日志实例。
+//   - w: 新的输出。
+func replaceOutput(t *testing.T, logger Logger, w *BufferedWriter) {
+	t.Helper()
+
+	if dedup, ok := logger.(*DedupLogger); ok {
+		logger = dedup.next
+	}
+	switch l := logger.(type) {
+	case *StdLogger:
+		old, _ := l.logger.Writer().(*BufferedWriter)
+		l.logger.SetOutput(w)
+		require.NotNil(t, old)
+		_ = old.Close()
+	case *LogrusLogger:
+		old, _ := l.logger.Logger.Out.(*BufferedWriter)
+		l.logger.Logger.SetOutput(w)
+		require.NotNil(t, old)
+		_ = old.Close()
+	default:
+		t.Fatalf("不支持的日志实现：%T", logger)
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package log
+
+import (
+	"strings"
+	"sync"
+
+	kitconfig "github.com/fsyyft-go/kit/config"
+)
+
+// ApplyDebugFlags 让日志记录器的级别跟随调试开关中的 LogLevel。
+//
+// 调用时立即按当前开关设置级别，此后开关变化时同步调整：LogLevel 为合法的级别名称（不区分大小写）时
+// 使用该级别，为空或无法解析时恢复调用时日志记录器的级别。排查问题时可在运行时把 LogLevel 设为 "debug"，
+// 排查结束后清空即可恢复。
+//
+// 参数：
+//   - logger：需要调整级别的日志记录器，调用方应传入非 nil 实例。
+//   - registry：提供 LogLevel 开关的调试开关注册表；为 nil 时使用 config.DefaultDebugRegistry。
+//
+// 返回：
+//   - func()：取消跟随并恢复调用时级别的函数，可重复调用。
+func ApplyDebugFlags(logger Logger, registry *kitconfig.DebugRegistry) func() {
+	if nil == registry {
+		registry = kitconfig.DefaultDebugRegistry
+	}
+
+	base := logger.GetLevel()
+	var mu sync.Mutex
+	stopped := false
+
+	// apply 每次都读取注册表的最新开关，避免订阅与首次设置之间的修改被旧值覆盖。
+	apply := func() {
+		mu.Lock()
+		defer mu.Unlock()
+		if stopped {
+			return
+		}
+
+		level := base
+		if name := registry.Flags().LogLevel; "" != name {
+			if parsed, err := ParseLevel(strings.ToLower(name)); nil == err {
+				level = parsed
+			}
+		}
+		if logger.GetLevel() != level {
+			logger.SetLevel(level)
+		}
+	}
+
+	unsubscribe := registry.OnChange(func(old, new kitconfig.DebugFlags) {
+		if old.LogLevel != new.LogLevel {
+			apply()
+		}
+	})
+	apply()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			unsubscribe()
+			mu.Lock()
+			defer mu.Unlock()
+			stopped = true
+			logger.SetLevel(base)
+		})
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package log
+
+import (
+	stdlog "log"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	kitconfig "github.com/fsyyft-go/kit/config"
+)
+
+// TestApplyDebugFlags 验证日志级别跟随调试开关中的 LogLevel 变化。
+func TestApplyDebugFlags(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		initial     string
+		change      string
+		wantInitial Level
+		wantChanged Level
+	}{
+		{
+			name:        "success/override",
+			description: "验证 LogLevel 打开时覆盖级别，清空后恢复原级别。",
+			initial:     "",
+			change:      "debug",
+			wantInitial: WarnLevel,
+			wantChanged: DebugLevel,
+		},
+		{
+			name:        "success/initial-override",
+			description: "验证调用时已设置的 LogLevel 立即生效，且不区分大小写。",
+			initial:     "ERROR",
+			change:      "info",
+			wantInitial: ErrorLevel,
+			wantChanged: InfoLevel,
+		},
+		{
+			name:        "boundary/invalid",
+			description: "验证无法解析的 LogLevel 不覆盖原级别。",
+			initial:     "debug",
+			change:      "verbose",
+			wantInitial: DebugLevel,
+			wantChanged: WarnLevel,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			buf := &syncBuffer{}
+			logger := &StdLogger{logger: stdlog.New(buf, "", 0), fields: make(map[string]interface{}), level: WarnLevel}
+			registry := kitconfig.NewDebugRegistry(kitconfig.DebugFlags{LogLevel: tt.initial})
+
+			cancel := ApplyDebugFlags(logger, registry)
+			assert.Equal(t, tt.wantInitial, logger.GetLevel())
+
+			registry.Update(func(f *kitconfig.DebugFlags) { f.LogLevel = tt.change })
+			assert.Equal(t, tt.wantChanged, logger.GetLevel())
+
+			// 其它开关的变化不影响日志级别。
+			logger.SetLevel(FatalLevel)
+			registry.Update(func(f *kitconfig.DebugFlags) { f.VerboseSQL = true })
+			assert.Equal(t, FatalLevel, logger.GetLevel())
+
+			cancel()
+			cancel()
+			assert.Equal(t, WarnLevel, logger.GetLevel())
+			registry.Update(func(f *kitconfig.DebugFlags) { f.LogLevel = "debug" })
+			assert.Equal(t, WarnLevel, logger.GetLevel())
+		})
+	}
+
+	// 打开 debug 级别后输出调试日志。
+	buf := &syncBuffer{}
+	logger := &StdLogger{logger: stdlog.New(buf, "", 0), fields: make(map[string]interface{}), level: InfoLevel}
+	registry := kitconfig.NewDebugRegistry(kitconfig.DebugFlags{})
+	defer ApplyDebugFlags(logger, registry)()
+	logger.Debug("hidden")
+	registry.Set(kitconfig.DebugFlags{LogLevel: "debug"})
+	logger.Debug("visible")
+	lines := buf.lines()
+	assert.Len(t, lines, 1)
+	assert.True(t, strings.Contains(lines[0], "visible"))
+}
@@ -0,0 +1,371 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package log
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DedupRepeatedField 是重复摘要日志中记录被折叠次数的字段名。
+	DedupRepeatedField = "repeated"
+)
+
+var (
+	_ Logger        = (*DedupLogger)(nil)
+	_ ContextLogger = (*DedupLogger)(nil)
+)
+
+type (
+	// DedupLogger 实现了 Logger 接口，在时间窗口内折叠连续重复的日志。
+	//
+	// 级别、消息和字段都相同的连续日志视为重复：窗口内首条照常输出，其后的重复日志被抑制，
+	// 直到出现不同的日志或窗口结束时输出一条 "last message repeated N times" 摘要，
+	// 摘要沿用被折叠日志的级别和字段，并附加 repeated 字段。窗口从首条日志输出时开始计算。
+	// 适合降低紧密重试循环产生的日志噪音。
+	//
+	// 通过 WithField、WithFields 派生的实例与原实例共享去重状态，因此不同字段的日志交替出现时不会被折叠。
+	// Fatal 级别日志不参与去重，记录前会先输出尚未输出的摘要。
+	DedupLogger struct {
+		// next 是实际输出日志的下游 Logger。
+		next Logger
+		// fields 是当前实例累积的字段，用于计算去重键。
+		fields map[string]interface{}
+		// state 是所有派生实例共享的去重状态。
+		state *dedupState
+	}
+
+	// dedupState 保存 DedupLogger 及其派生实例共享的去重状态。
+	dedupState struct {
+		// mu 保护其余字段，并保证摘要与后续日志的输出顺序。
+		mu sync.Mutex
+		// window 是去重时间窗口。
+		window time.Duration
+		// key 是最近一条已输出日志的去重键。
+		key string
+		// level 是最近一条已输出日志的级别。
+		level Level
+		// logger 是输出最近一条日志的下游 Logger，用于以相同字段输出摘要。
+		logger Logger
+		// start 是最近一条日志的输出时间，即当前窗口的起点。
+		start time.Time
+		// repeated 是当前窗口内被抑制的重复次数。
+		repeated int
+		// timer 在窗口结束时输出尚未输出的摘要。
+		timer *time.Timer
+	}
+)
+
+// WithDedup 设置 NewLogger 创建的日志实例折叠连续重复日志的时间窗口。
+//
+// 参数：
+//   - window：去重时间窗口；小于等于 0 表示不去重。
+//
+// 返回：
+//   - Option：应用于 LoggerOptions 的配置选项。
+func WithDedup(window time.Duration) Option {
+	return func(opts *LoggerOptions) {
+		opts.DedupWindow = window
+	}
+}
+
+// NewDedupLogger 创建折叠连续重复日志的 DedupLogger。
+//
+// 参数：
+//   - next：实际输出日志的下游 Logger。
+//   - window：去重时间窗口；小于等于 0 时直接返回 next。
+//
+// 返回：
+//   - Logger：具备去重能力的日志实例。
+func NewDedupLogger(next Logger, window time.Duration) Logger {
+	if window <= 0 {
+		return next
+	}
+	return &DedupLogger{
+		next:   next,
+		fields: make(map[string]interface{}),
+		state:  &dedupState{window: window},
+	}
+}
+
+// Flush 立即输出尚未输出的重复摘要，并结束当前窗口。
+//
+// 程序退出前调用可避免丢失最后一个窗口内的重复计数。
+//
+// 参数：无。
+func (l *DedupLogger) Flush() {
+	l.state.mu.Lock()
+	defer l.state.mu.Unlock()
+	l.state.flushLocked()
+	l.state.key = ""
+}
+
+// SetLevel 实现 Logger 接口的日志级别设置方法。
+//
+// 参数：
+//   - level：要设置的日志级别，直接设置到下游 Logger。
+func (l *DedupLogger) SetLevel(level Level) {
+	l.next.SetLevel(level)
+}
+
+// GetLevel 实现 Logger 接口的日志级别获取方法。
+//
+// 返回：
+//   - Level：下游 Logger 当前的日志级别。
+func (l *DedupLogger) GetLevel() Level {
+	return l.next.GetLevel()
+}
+
+// Debug 实现 Logger 接口的调试级别日志记录。
+//
+// 参数：
+//   - args：要记录的内容，支持任意类型的值。
+func (l *DedupLogger) Debug(args ...interface{}) {
+	l.log(DebugLevel, fmt.Sprint(args...))
+}
+
+// Debugf 实现 Logger 接口的格式化调试级别日志记录。
+//
+// 参数：
+//   - format：格式化字符串。
+//   - args：格式化参数。
+func (l *DedupLogger) Debugf(format string, args ...interface{}) {
+	l.log(DebugLevel, fmt.Sprintf(format, args...))
+}
+
+// Info 实现 Logger 接口的信息级别日志记录。
+//
+// 参数：
+//   - args：要记录的内容，支持任意类型的值。
+func (l *DedupLogger) Info(args ...interface{}) {
+	l.log(InfoLevel, fmt.Sprint(args...))
+}
+
+// Infof 实现 Logger 接口的格式化信息级别日志记录。
+//
+// 参数：
+//   - format：格式化字符串。
+//   - args：格式化参数。
+func (l *DedupLogger) Infof(format string, args ...interface{}) {
+	l.log(InfoLevel, fmt.Sprintf(format, args...))
+}
+
+// Warn 实现 Logger 接口的警告级别日志记录。
+//
+// 参数：
+//   - args：要记录的内容，支持任意类型的值。
+func (l *DedupLogger) Warn(args ...interface{}) {
+	l.log(WarnLevel, fmt.Sprint(args...))
+}
+
+// Warnf 实现 Logger 接口的格式化警告级别日志记录。
+//
+// 参数：
+//   - format：格式化字符串。
+//   - args：格式化参数。
+func (l *DedupLogger) Warnf(format string, args ...interface{}) {
+	l.log(WarnLevel, fmt.Sprintf(format, args...))
+}
+
+// Error 实现 Logger 接口的错误级别日志记录。
+//
+// 参数：
+//   - args：要记录的内容，支持任意类型的值。
+func (l *DedupLogger) Error(args ...interface{}) {
+	l.log(ErrorLevel, fmt.Sprint(args...))
+}
+
+// Errorf 实现 Logger 接口的格式化错误级别日志记录。
+//
+// 参数：
+//   - format：格式化字符串。
+//   - args：格式化参数。
+func (l *DedupLogger) Errorf(format string, args ...interface{}) {
+	l.log(ErrorLevel, fmt.Sprintf(format, args...))
+}
+
+// Fatal 实现 Logger 接口的致命错误级别日志记录。
+// 先输出尚未输出的重复摘要，再交由下游 Logger 记录并退出。
+//
+// 参数：
+//   - args：要记录的内容，支持任意类型的值。
+func (l *DedupLogger) Fatal(args ...interface{}) {
+	l.Flush()
+	l.next.Fatal(args...)
+}
+
+// Fatalf 实现 Logger 接口的格式化致命错误级别日志记录。
+// 先输出尚未输出的重复摘要，再交由下游 Logger 记录并退出。
+//
+// 参数：
+//   - format：格式化字符串。
+//   - args：格式化参数。
+func (l *DedupLogger) Fatalf(format string, args ...interface{}) {
+	l.Flush()
+	l.next.Fatalf(format, args...)
+}
+
+// WithField 实现 Logger 接口的单字段添加方法。
+//
+// 参数：
+//   - key：字段名。
+//   - value：字段值。
+//
+// 返回：
+//   - Logger：包含新字段并共享去重状态的新 Logger 实例。
+func (l *DedupLogger) WithField(key string, value interface{}) Logger {
+	return l.WithFields(map[string]interface{}{key: value})
+}
+
+// WithFields 实现 Logger 接口的多字段添加方法。
+//
+// 参数：
+//   - fields：要添加的字段映射。
+//
+// 返回：
+//   - Logger：包含新字段并共享去重状态的新 Logger 实例。
+func (l *DedupLogger) WithFields(fields map[string]interface{}) Logger {
+	newFields := make(map[string]interface{}, len(l.fields)+len(fields))
+	for k, v := range l.fields {
+		newFields[k] = v
+	}
+	for k, v := range fields {
+		newFields[k] = v
+	}
+	return &DedupLogger{
+		next:   l.next.WithFields(fields),
+		fields: newFields,
+		state:  l.state,
+	}
+}
+
+// WithContext 实现 ContextLogger 接口的上下文绑定方法。
+// 上下文不参与去重键的计算，下游 Logger 未实现 ContextLogger 时忽略 ctx。
+//
+// 参数：
+//   - ctx：记录日志时使用的上下文。
+//
+// 返回：
+//   - Logger：绑定 ctx 并共享去重状态的新 Logger 实例。
+func (l *DedupLogger) WithContext(ctx context.Context) Logger {
+	return &DedupLogger{
+		next:   WithContext(l.next, ctx),
+		fields: l.fields,
+		state:  l.state,
+	}
+}
+
+// log 记录一条非 Fatal 级别的日志，并在窗口内抑制重复日志。
+//
+// 参数：
+//   - level：日志级别。
+//   - msg：格式化后的日志消息。
+func (l *DedupLogger) log(level Level, msg string) {
+	// 不会输出的日志不影响去重状态。
+	if level < l.next.GetLevel() {
+		return
+	}
+
+	key := l.key(level, msg)
+	now := time.Now()
+
+	s := l.state
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if key == s.key && now.Sub(s.start) < s.window {
+		s.repeated++
+		if nil == s.timer {
+			s.timer = time.AfterFunc(s.window-now.Sub(s.start), s.expire)
+		}
+		return
+	}
+
+	s.flushLocked()
+	s.key = key
+	s.level = level
+	s.logger = l.next
+	s.start = now
+	emit(l.next, level, msg)
+}
+
+// key 计算日志的去重键，字段按名称排序以保证相同字段得到相同的键。
+//
+// 参数：
+//   - level：日志级别。
+//   - msg：格式化后的日志消息。
+//
+// 返回：
+//   - string：由级别、字段和消息组成的去重键。
+func (l *DedupLogger) key(level Level, msg string) string {
+	names := make([]string, 0, len(l.fields))
+	for k := range l.fields {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	b.WriteString(level.String())
+	for _, k := range names {
+		fmt.Fprintf(&b, "\x00%s=%v", k, l.fields[k])
+	}
+	b.WriteString("\x00")
+	b.WriteString(msg)
+	return b.String()
+}
+
+// expire 在窗口结束时输出尚未输出的摘要，使后续相同日志重新开始计数。
+//
+// 参数：无。
+func (s *dedupState) expire() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// 窗口已被新日志或 Flush 提前结束时，timer 已被置空或属于新的窗口。
+	if nil == s.timer || time.Since(s.start) < s.window {
+		return
+	}
+	s.timer = nil
+	s.flushLocked()
+	s.key = ""
+}
+
+// flushLocked 输出尚未输出的重复摘要，调用方必须持有 s.mu。
+//
+// 参数：无。
+func (s *dedupState) flushLocked() {
+	if nil != s.timer {
+		s.timer.Stop()
+		s.timer = nil
+	}
+	if s.repeated > 0 {
+		emit(s.logger.WithField(DedupRepeatedField, s.repeated), s.level,
+			fmt.Sprintf("last message repeated %d times", s.repeated))
+		s.repeated = 0
+	}
+}
+
+// emit 以指定级别向下游 Logger 输出日志。
+//
+// 参数：
+//   - logger：下游 Logger。
+//   - level：日志级别，不包括 FatalLevel。
+//   - msg：格式化后的日志消息。
+func emit(logger Logger, level Level, msg string) {
+	switch level {
+	case DebugLevel:
+		logger.Debug(msg)
+	case InfoLevel:
+		logger.Info(msg)
+	case WarnLevel:
+		logger.Warn(msg)
+	default:
+		logger.Error(msg)
+	}
+}
@@ -0,0 +1,216 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package log
+
+import (
+	"bytes"
+	stdlog "log"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type (
+	// syncBuffer 是可并发写入和读取的缓冲区，用于观察窗口结束时由定时器输出的摘要。
+	syncBuffer struct {
+		mu  sync.Mutex
+		buf bytes.Buffer
+	}
+)
+
+// Write 并发安全地写入数据。
+//
+// 参数：
+//   - p: 待写入的数据。
+//
+// 返回：
+//   - int: 写入的字节数。
+//   - error: 写入错误。
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+// lines 返回已写入的非空行。
+//
+// 返回：
+//   - []string: 已写入的日志行。
+func (b *syncBuffer) lines() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	text := strings.TrimSpace(b.buf.String())
+	if "" == text {
+		return nil
+	}
+	return strings.Split(text, "\n")
+}
+
+// newDedupTestLogger 创建写入内存缓冲区的去重日志实例。
+//
+// 参数：
+//   - window: 去重时间窗口。
+//
+// 返回：
+//   - *DedupLogger: 去重日志实例。
+//   - *syncBuffer: 日志输出缓冲区，每行不含时间戳。
+func newDedupTestLogger(window time.Duration) (*DedupLogger, *syncBuffer) {
+	buf := &syncBuffer{}
+	next := &StdLogger{
+		logger: stdlog.New(buf, "", 0),
+		fields: make(map[string]interface{}),
+		level:  InfoLevel,
+	}
+	return NewDedupLogger(next, window).(*DedupLogger), buf
+}
+
+// TestDedupLogger_CollapsesRepeats 验证连续重复日志的折叠和摘要输出时机。
+func TestDedupLogger_CollapsesRepeats(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		run         func(l *DedupLogger)
+		want        []string
+	}{
+		{
+			name:        "success/collapse-until-different",
+			description: "验证重复日志被抑制，出现不同日志时先输出摘要。",
+			run: func(l *DedupLogger) {
+				for i := 0; i < 4; i++ {
+					l.Warnf("retry %s", "db")
+				}
+				l.Info("connected")
+			},
+			want: []string{
+				"[WARN] retry db",
+				"[WARN] [repeated=3] last message repeated 3 times",
+				"[INFO] connected",
+			},
+		},
+		{
+			name:        "success/no-repeat-no-summary",
+			description: "验证没有重复时不输出摘要。",
+			run: func(l *DedupLogger) {
+				l.Info("a")
+				l.Info("b")
+				l.Info("a")
+			},
+			want: []string{"[INFO] a", "[INFO] b", "[INFO] a"},
+		},
+		{
+			name:        "boundary/level-differs",
+			description: "验证消息相同但级别不同的日志不会被折叠。",
+			run: func(l *DedupLogger) {
+				l.Info("x")
+				l.Error("x")
+			},
+			want: []string{"[INFO] x", "[ERROR] x"},
+		},
+		{
+			name:        "boundary/fields-differ",
+			description: "验证消息相同但字段不同的日志不会被折叠。",
+			run: func(l *DedupLogger) {
+				l.WithField("id", 1).Info("x")
+				l.WithField("id", 2).Info("x")
+				l.Info("x")
+			},
+			want: []string{"[INFO] [id=1] x", "[INFO] [id=2] x", "[INFO] x"},
+		},
+		{
+			name:        "boundary/filtered-level-ignored",
+			description: "验证低于日志级别的日志既不输出也不打断去重。",
+			run: func(l *DedupLogger) {
+				l.Info("x")
+				l.Debug("hidden")
+				l.Info("x")
+				l.Flush()
+			},
+			want: []string{"[INFO] x", "[INFO] [repeated=1] last message repeated 1 times"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			l, buf := newDedupTestLogger(time.Minute)
+			tt.run(l)
+			assert.Equal(t, tt.want, buf.lines())
+		})
+	}
+}
+
+// TestDedupLogger_DerivedSharesState 验证字段相同的不同派生实例共享去重状态，摘要沿用被折叠日志的字段。
+func TestDedupLogger_DerivedSharesState(t *testing.T) {
+	l, buf := newDedupTestLogger(time.Minute)
+
+	l.WithField("id", 1).Info("x")
+	l.WithFields(map[string]interface{}{"id": 1}).Info("x")
+	l.Flush()
+
+	lines := buf.lines()
+	require.Len(t, lines, 2)
+	assert.Equal(t, "[INFO] [id=1] x", lines[0])
+	assert.Contains(t, lines[1], "id=1")
+	assert.Contains(t, lines[1], "repeated=1")
+	assert.True(t, strings.HasSuffix(lines[1], "] last message repeated 1 times"))
+}
+
+// TestDedupLogger_WindowExpiry 验证窗口结束时自动输出摘要，之后相同日志重新输出。
+func TestDedupLogger_WindowExpiry(t *testing.T) {
+	l, buf := newDedupTestLogger(50 * time.Millisecond)
+
+	l.Error("timeout")
+	l.Error("timeout")
+	l.Error("timeout")
+
+	require.Eventually(t, func() bool { return 2 == len(buf.lines()) }, time.Second, 5*time.Millisecond)
+	assert.Equal(t, "[ERROR] [repeated=2] last message repeated 2 times", buf.lines()[1])
+
+	l.Error("timeout")
+	assert.Equal(t, []string{
+		"[ERROR] timeout",
+		"[ERROR] [repeated=2] last message repeated 2 times",
+		"[ERROR] timeout",
+	}, buf.lines())
+}
+
+// TestDedupLogger_Concurrent 验证并发写入时重复计数不丢失。
+func TestDedupLogger_Concurrent(t *testing.T) {
+	l, buf := newDedupTestLogger(time.Minute)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				l.Info("busy")
+			}
+		}()
+	}
+	wg.Wait()
+	l.Flush()
+
+	assert.Equal(t, []string{"[INFO] busy", "[INFO] [repeated=799] last message repeated 799 times"}, buf.lines())
+}
+
+// TestNewLogger_WithDedup 验证 WithDedup 选项控制 NewLogger 是否包装去重日志实例。
+func TestNewLogger_WithDedup(t *testing.T) {
+	logger, err := NewLogger(WithLogType(LogTypeConsole), WithDedup(time.Second), WithLevel(WarnLevel))
+	require.NoError(t, err)
+	require.IsType(t, &DedupLogger{}, logger)
+	assert.Equal(t, WarnLevel, logger.GetLevel())
+	assert.IsType(t, &DedupLogger{}, logger.WithField("k", "v"))
+
+	logger, err = NewLogger(WithLogType(LogTypeConsole), WithDedup(0))
+	require.NoError(t, err)
+	assert.IsType(t, &StdLogger{}, logger)
+}
@@ -10,4 +10,35 @@
 // NewLogger 用于创建独立日志器，可通过 Option 选择 Std、Console 或 Logrus 实现，
 // 并配置级别、输出路径、输出格式和日志轮转。JSONFormat 与 TextFormat 仅影响 Logrus 格式化；
 // 当前 Logger 接口不提供 Close 方法，调用方也无法显式关闭文件型实现。
+//
+// Lazy 创建延迟求值的字段值：作为 WithField、WithFields 的值时，只有日志级别启用、日志真正输出时才调用求值函数，
+// 结果被缓存，适合为 Debug 日志附加开销较大的序列化内容。
+//
+// WithDedup 或 NewDedupLogger 启用重复日志折叠：窗口内级别、消息和字段都相同的连续日志只输出首条，
+// 出现不同日志或窗口结束时输出一条带 repeated 字段的 "last message repeated N times" 摘要。
+//
+// WithModuleLevels 或 NewModuleLogger 启用按模块设置的日志级别：通过 WithField(ModuleField, "database/sql")
+// 派生的 Logger 按该模块的级别过滤，模块以 / 分层，未单独设置时沿用最近的上级模块或默认级别；
+// SetModuleLevel 与 ResetModuleLevel 可在运行时调整，并立即作用于已派生的 Logger。
+// ApplyDebugFlags 让 Logger 的级别跟随 config.DebugRegistry 中的 LogLevel 开关，开关清空时恢复原级别。
+//
+// Fatal 记录日志后按逆序执行 RegisterExitHook 注册的退出钩子（例如关闭数据库、刷新异步日志），
+// 再以 SetExitCode 设置的退出码退出；ExitOnPanic 以相同流程处理未恢复的 panic。测试中可使用
+// CaptureFatal 将当前 goroutine 中的 Fatal 转换为 *FatalError，跳过退出钩子并避免测试进程退出。
+//
+// OTelLogger 将日志桥接为 OpenTelemetry 日志记录：级别映射为 Severity，字段转换为属性，
+// 通过 WithContext 绑定的上下文用于关联 trace 与 span；NewLogger 可通过 LogTypeOTel 使用全局 LoggerProvider，
+// 配合 SDK 的 OTLP exporter 即可接入 OTLP 日志管道。
+//
+// WithOutput 设置为 OutputJournald 或 OutputEventLog 时，Std 与 Logrus 类型改为写入平台原生日志系统：
+// journald 通过原生协议接收级别映射后的 PRIORITY 与转换为大写的结构化字段，Windows 事件日志按级别写为
+// 信息、警告或错误事件；也可直接使用 NewJournaldLogger 与 NewEventLogLogger 创建 SinkLogger。
+//
+// WithBufferedOutput 或 NewBufferedWriter 启用缓冲输出：日志先写入内存缓冲区，写满或到达定时刷新间隔时批量写出；
+// 记录 Error、Fatal 级别日志后、执行退出钩子时以及收到 WithSyncOnSignal 指定的信号时刷新缓冲区并 fsync，
+// 程序正常结束前调用 SyncBuffers 写出剩余日志。
+//
+// AuditLogger 提供与普通日志隔离的审计通道：结构化 AuditEvent（主体、操作、资源、结果）
+// 以 JSON Lines 格式按 UTC 日期写入专用目录，每条记录携带链式 SHA-256 哈希用于防篡改，
+// 支持按保留时长清理分段文件；VerifyAuditLog 与 ExportAuditLog 用于校验哈希链和导出记录。
 package log
@@ -0,0 +1,378 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package log
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// ExitReasonFatal 表示进程因 Fatal 或 Fatalf 日志退出。
+	ExitReasonFatal ExitReason = "fatal"
+	// ExitReasonPanic 表示进程因 ExitOnPanic 捕获的 panic 退出。
+	ExitReasonPanic ExitReason = "panic"
+
+	// defaultExitCode 是 Fatal 和 ExitOnPanic 默认使用的进程退出码。
+	defaultExitCode = 1
+	// defaultExitHookTimeout 是执行全部退出钩子的默认总超时时间。
+	defaultExitHookTimeout = 5 * time.Second
+)
+
+var (
+	// exitState 保存退出钩子和退出行为配置。
+	exitState = struct {
+		sync.Mutex
+		// hooks 是按注册顺序保存的退出钩子。
+		hooks []exitHookEntry
+		// nextID 是下一个退出钩子的注册编号。
+		nextID uint64
+		// code 是进程退出码。
+		code int
+		// timeout 是执行全部退出钩子的总超时时间。
+		timeout time.Duration
+		// exit 是最终退出进程的函数。
+		exit func(code int)
+	}{
+		code:    defaultExitCode,
+		timeout: defaultExitHookTimeout,
+		exit:    os.Exit,
+	}
+
+	// exitRunLock 串行化退出流程，避免并发 Fatal 重复执行钩子时相互交错。
+	exitRunLock sync.Mutex
+
+	// captures 记录正在执行 CaptureFatal 的 goroutine，只有这些 goroutine 中的 Fatal 转换为错误而不退出进程。
+	captures = struct {
+		sync.Mutex
+		// depth 以 goroutine 编号记录嵌套的 CaptureFatal 数量。
+		depth map[int64]int
+	}{
+		depth: make(map[int64]int),
+	}
+)
+
+type (
+	// ExitReason 定义触发退出流程的原因。
+	ExitReason string
+
+	// ExitEvent 描述一次退出流程，作为参数传递给每个退出钩子。
+	ExitEvent struct {
+		// Reason 是触发退出的原因。
+		Reason ExitReason
+		// Message 是 Fatal 日志内容或 panic 值的字符串表示。
+		Message string
+		// Code 是即将使用的进程退出码。
+		Code int
+		// Panic 是 ExitOnPanic 捕获的原始 panic 值；Fatal 触发时为 nil。
+		Panic interface{}
+		// Stack 是 ExitOnPanic 捕获 panic 时的调用栈；Fatal 触发时为 nil。
+		Stack []byte
+	}
+
+	// ExitHook 定义进程退出前执行的清理函数，例如关闭数据库连接或刷新异步日志。
+	//
+	// 参数：
+	//   - ctx：带有退出钩子总超时时间的上下文，钩子应在 ctx 结束前返回。
+	//   - event：本次退出流程的描述。
+	//
+	// 返回：
+	//   - error：清理失败时返回错误，错误会写入标准错误输出，不影响后续钩子执行。
+	//
+	// 钩子中不应调用 Fatal，否则该调用会阻塞至退出钩子总超时时间结束。
+	ExitHook func(ctx context.Context, event ExitEvent) error
+
+	// FatalError 是 CaptureFatal 期间 Fatal 或 ExitOnPanic 转换得到的错误。
+	FatalError struct {
+		// ExitEvent 是被拦截的退出流程描述。
+		ExitEvent
+	}
+
+	// exitHookEntry 是已注册的退出钩子。
+	exitHookEntry struct {
+		// id 是注册编号，用于注销。
+		id uint64
+		// name 是钩子名称，用于错误输出。
+		name string
+		// hook 是钩子函数。
+		hook ExitHook
+	}
+)
+
+// Error 返回致命错误的描述。
+//
+// 参数：无。
+//
+// 返回：
+//   - string：包含退出原因、退出码和消息的描述。
+func (e *FatalError) Error() string {
+	return fmt.Sprintf("%s（退出码 %d）：%s", e.Reason, e.Code, e.Message)
+}
+
+// RegisterExitHook 注册进程因 Fatal 或 ExitOnPanic 退出前执行的钩子。
+//
+// 钩子按注册的逆序执行，与 defer 一致：先注册的基础资源（例如异步日志写入器）最后关闭。所有钩子共享
+// SetExitHookTimeout 设置的总超时时间，超时后剩余钩子不再执行，进程直接退出。钩子返回的错误或 panic
+// 会写入标准错误输出，不会中断其它钩子。
+//
+// 参数：
+//   - name：钩子名称，用于错误输出。
+//   - hook：钩子函数；为 nil 时忽略。
+//
+// 返回：
+//   - func()：注销该钩子的函数，可重复调用。
+func RegisterExitHook(name string, hook ExitHook) func() {
+	if nil == hook {
+		return func() {}
+	}
+
+	exitState.Lock()
+	defer exitState.Unlock()
+	exitState.nextID++
+	id := exitState.nextID
+	exitState.hooks = append(exitState.hooks, exitHookEntry{id: id, name: name, hook: hook})
+
+	return func() {
+		exitState.Lock()
+		defer exitState.Unlock()
+		for i, entry := range exitState.hooks {
+			if entry.id == id {
+				exitState.hooks = append(exitState.hooks[:i:i], exitState.hooks[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// SetExitCode 设置 Fatal 和 ExitOnPanic 退出进程时使用的退出码，默认为 1。
+//
+// 参数：
+//   - code：进程退出码。
+func SetExitCode(code int) {
+	exitState.Lock()
+	defer exitState.Unlock()
+	exitState.code = code
+}
+
+// SetExitHookTimeout 设置执行全部退出钩子的总超时时间，默认为 5 秒。
+//
+// 参数：
+//   - timeout：总超时时间；小于等于 0 时恢复默认值。
+func SetExitHookTimeout(timeout time.Duration) {
+	if timeout <= 0 {
+		timeout = defaultExitHookTimeout
+	}
+	exitState.Lock()
+	defer exitState.Unlock()
+	exitState.timeout = timeout
+}
+
+// SetExitFunc 设置退出钩子执行完毕后退出进程的函数，默认为 os.Exit。
+//
+// LogrusLogger 的 Fatal 使用底层 logrus.Logger 的 ExitFunc，NewLogrusLogger 创建的实例默认委托到该函数。
+//
+// 参数：
+//   - exit：退出函数；为 nil 时恢复 os.Exit。
+func SetExitFunc(exit func(code int)) {
+	if nil == exit {
+		exit = os.Exit
+	}
+	exitState.Lock()
+	defer exitState.Unlock()
+	exitState.exit = exit
+}
+
+// CaptureFatal 执行 fn，并将其中触发的 Fatal 或 ExitOnPanic 转换为 *FatalError 返回，保持测试进程存活。
+//
+// 捕获只作用于调用 CaptureFatal 的 goroutine：该 goroutine 中的 Fatal 不执行退出钩子、不退出进程，而是通过
+// panic 中止 fn 的后续代码并由 CaptureFatal 恢复；其它 goroutine 中的 Fatal 不受影响，照常执行退出钩子并退出。
+// fn 中的其它 panic 会原样继续传播。
+//
+// 参数：
+//   - fn：待执行的函数。
+//
+// 返回：
+//   - error：fn 触发 Fatal 时返回 *FatalError，否则返回 nil。
+func CaptureFatal(fn func()) (err error) {
+	id := goroutineID()
+	captures.Lock()
+	captures.depth[id]++
+	captures.Unlock()
+
+	defer func() {
+		captures.Lock()
+		if captures.depth[id]--; captures.depth[id] <= 0 {
+			delete(captures.depth, id)
+		}
+		captures.Unlock()
+
+		if r := recover(); nil != r {
+			fatalErr, ok := r.(*FatalError)
+			if !ok {
+				panic(r)
+			}
+			err = fatalErr
+		}
+	}()
+
+	fn()
+	return nil
+}
+
+// ExitOnPanic 捕获当前 goroutine 的 panic，记录日志并执行退出钩子后退出进程。
+//
+// ExitOnPanic 必须直接通过 defer 调用，通常位于 main 函数或长期运行 goroutine 的入口：
+//
+//	defer log.ExitOnPanic()
+//
+// 捕获到 panic 时，使用全局 Logger 以错误级别记录 panic 值和调用栈，随后与 Fatal 相同地执行退出钩子并
+// 以 SetExitCode 设置的退出码退出。未发生 panic 时不做任何处理。
+func ExitOnPanic() {
+	r := recover()
+	if nil == r {
+		return
+	}
+
+	event := ExitEvent{
+		Reason:  ExitReasonPanic,
+		Message: fmt.Sprint(r),
+		Panic:   r,
+		Stack:   debug.Stack(),
+	}
+	GetLogger().WithFields(map[string]interface{}{
+		"panic": event.Message,
+		"stack": string(event.Stack),
+	}).Error("捕获到未处理的 panic")
+	runExit(event, nil)
+}
+
+// callExitFunc 使用 SetExitFunc 设置的函数退出进程。
+//
+// 参数：
+//   - code：进程退出码。
+func callExitFunc(code int) {
+	exitState.Lock()
+	exit := exitState.exit
+	exitState.Unlock()
+	exit(code)
+}
+
+// fatalExit 执行 Fatal 触发的退出流程。
+//
+// 参数：
+//   - message：Fatal 日志内容。
+//   - exit：退出进程的函数；为 nil 时使用 SetExitFunc 设置的函数。
+func fatalExit(message string, exit func(code int)) {
+	runExit(ExitEvent{Reason: ExitReasonFatal, Message: message}, exit)
+}
+
+// runExit 执行退出钩子并退出进程；当前 goroutine 正在执行 CaptureFatal 时不执行钩子，转换为 *FatalError。
+//
+// 参数：
+//   - event：退出流程描述，Code 字段由当前配置填充。
+//   - exit：退出进程的函数；为 nil 时使用 SetExitFunc 设置的函数。
+func runExit(event ExitEvent, exit func(code int)) {
+	if capturing() {
+		exitState.Lock()
+		event.Code = exitState.code
+		exitState.Unlock()
+		panic(&FatalError{ExitEvent: event})
+	}
+
+	exitRunLock.Lock()
+	defer exitRunLock.Unlock()
+
+	exitState.Lock()
+	hooks := append([]exitHookEntry(nil), exitState.hooks...)
+	event.Code = exitState.code
+	timeout := exitState.timeout
+	if nil == exit {
+		exit = exitState.exit
+	}
+	exitState.Unlock()
+
+	runExitHooks(hooks, event, timeout)
+	exit(event.Code)
+}
+
+// capturing 返回当前 goroutine 是否正在执行 CaptureFatal。
+//
+// 参数：无。
+//
+// 返回：
+//   - bool：当前 goroutine 处于 CaptureFatal 中时返回 true。
+func capturing() bool {
+	captures.Lock()
+	empty := 0 == len(captures.depth)
+	captures.Unlock()
+	if empty {
+		return false
+	}
+
+	id := goroutineID()
+	captures.Lock()
+	defer captures.Unlock()
+	return captures.depth[id] > 0
+}
+
+// goroutineID 从调用栈头部解析当前 goroutine 的编号。
+//
+// runtime/goroutine 依赖本包，这里单独实现；只在 CaptureFatal 与其期间的 Fatal 中调用，不影响常规日志路径。
+//
+// 参数：无。
+//
+// 返回：
+//   - int64：goroutine 编号；解析失败时返回 0。
+func goroutineID() int64 {
+	var buf [64]byte
+	// 调用栈头部格式为 "goroutine 123 [running]:"。
+	header := bytes.TrimPrefix(buf[:runtime.Stack(buf[:], false)], []byte("goroutine "))
+	if i := bytes.IndexByte(header, ' '); i > 0 {
+		header = header[:i]
+	}
+	id, _ := strconv.ParseInt(string(header), 10, 64)
+	return id
+}
+
+// runExitHooks 按注册逆序执行退出钩子，总耗时不超过 timeout。
+//
+// 参数：
+//   - hooks：已注册的退出钩子。
+//   - event：退出流程描述。
+//   - timeout：全部钩子的总超时时间。
+func runExitHooks(hooks []exitHookEntry, event ExitEvent, timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	for i := len(hooks) - 1; i >= 0; i-- {
+		entry := hooks[i]
+		done := make(chan error, 1)
+		go func() {
+			defer func() {
+				if r := recover(); nil != r {
+					done <- fmt.Errorf("panic：%v", r)
+				}
+			}()
+			done <- entry.hook(ctx, event)
+		}()
+
+		select {
+		case err := <-done:
+			if nil != err {
+				_, _ = fmt.Fprintf(os.Stderr, "执行退出钩子 %s 失败：%v\n", entry.name, err)
+			}
+		case <-ctx.Done():
+			_, _ = fmt.Fprintf(os.Stderr, "执行退出钩子 %s 超时，跳过剩余 %d 个钩子\n", entry.name, i)
+			return
+		}
+	}
+}
@@ -0,0 +1,336 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package log
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCaptureFatal_ConvertsFatalToError 验证 CaptureFatal 将各实现的 Fatal 转换为错误且不执行退出钩子。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestCaptureFatal_ConvertsFatalToError(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		act         func(t *testing.T)
+		wantMessage string
+	}{
+		{
+			name:        "success/std-fatal",
+			description: "验证 StdLogger.Fatal 被转换为 FatalError。",
+			act: func(t *testing.T) {
+				logger, _ := newBufferedStdLogger(t, InfoLevel)
+				logger.Fatal("std", "-event")
+			},
+			wantMessage: "std-event",
+		},
+		{
+			name:        "success/std-fatalf",
+			description: "验证 StdLogger.Fatalf 被转换为 FatalError。",
+			act: func(t *testing.T) {
+				logger, _ := newBufferedStdLogger(t, InfoLevel)
+				logger.WithField("k", "v").Fatalf("std-%d", 1)
+			},
+			wantMessage: "std-1",
+		},
+		{
+			name:        "success/logrus-fatalf",
+			description: "验证 LogrusLogger.Fatalf 被转换为 FatalError 且不调用 Logrus 的 ExitFunc。",
+			act: func(t *testing.T) {
+				loggerInterface, err := NewLogrusLogger(WithFormatter(&logrus.JSONFormatter{}))
+				require.NoError(t, err)
+				logger := loggerInterface.(*LogrusLogger)
+				logger.logger.Logger.SetOutput(&bytes.Buffer{})
+				logger.logger.Logger.ExitFunc = func(code int) {
+					t.Errorf("不应调用 ExitFunc：%d", code)
+				}
+				logger.Fatalf("logrus-%s", "event")
+			},
+			wantMessage: "logrus-event",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+			preserveExitState(t)
+			SetExitCode(3)
+
+			var order []string
+			RegisterExitHook("db", func(ctx context.Context, event ExitEvent) error {
+				order = append(order, "db")
+				return nil
+			})
+			SetExitFunc(func(code int) {
+				t.Errorf("不应调用退出函数：%d", code)
+			})
+
+			reached := false
+			err := CaptureFatal(func() {
+				tt.act(t)
+				reached = true
+			})
+
+			var fatalErr *FatalError
+			require.ErrorAs(t, err, &fatalErr)
+			assert.Equal(t, ExitReasonFatal, fatalErr.Reason)
+			assert.Equal(t, 3, fatalErr.Code)
+			assert.Equal(t, tt.wantMessage, fatalErr.Message)
+			assert.False(t, reached, "Fatal 之后的代码不应继续执行。")
+			assert.Empty(t, order, "捕获期间不应执行退出钩子。")
+		})
+	}
+}
+
+// TestCaptureFatal_Boundaries 验证未触发 Fatal 时返回 nil，其它 panic 继续传播。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestCaptureFatal_Boundaries(t *testing.T) {
+	assert.NoError(t, CaptureFatal(func() {}))
+	assert.PanicsWithValue(t, "boom", func() {
+		_ = CaptureFatal(func() { panic("boom") })
+	})
+	assert.NoError(t, CaptureFatal(func() {
+		assert.NoError(t, CaptureFatal(func() {}))
+		assert.True(t, capturing(), "内层返回后外层捕获仍应生效。")
+	}))
+	assert.False(t, capturing())
+	assert.Empty(t, captures.depth)
+}
+
+// TestCaptureFatal_OtherGoroutine 验证捕获期间其它 goroutine 中的 Fatal 不被捕获，照常执行退出钩子并退出。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestCaptureFatal_OtherGoroutine(t *testing.T) {
+	preserveExitState(t)
+	SetExitCode(5)
+
+	var (
+		mu    sync.Mutex
+		hooks []string
+		codes []int
+	)
+	RegisterExitHook("flush", func(ctx context.Context, event ExitEvent) error {
+		mu.Lock()
+		defer mu.Unlock()
+		hooks = append(hooks, event.Message)
+		return nil
+	})
+	SetExitFunc(func(code int) {
+		mu.Lock()
+		defer mu.Unlock()
+		codes = append(codes, code)
+	})
+
+	logger, _ := newBufferedStdLogger(t, InfoLevel)
+	err := CaptureFatal(func() {
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			logger.Fatal("background")
+		}()
+		<-done
+		logger.Fatal("captured")
+	})
+
+	var fatalErr *FatalError
+	require.ErrorAs(t, err, &fatalErr)
+	assert.Equal(t, "captured", fatalErr.Message)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"background"}, hooks)
+	assert.Equal(t, []int{5}, codes)
+}
+
+// TestFatal_UsesConfiguredExitFunc 验证未处于 CaptureFatal 时 Fatal 使用配置的退出函数和退出码。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestFatal_UsesConfiguredExitFunc(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		newLogger   func(t *testing.T) Logger
+	}{
+		{
+			name:        "success/std",
+			description: "验证 StdLogger 使用 SetExitFunc 设置的退出函数。",
+			newLogger: func(t *testing.T) Logger {
+				logger, _ := newBufferedStdLogger(t, InfoLevel)
+				return logger
+			},
+		},
+		{
+			name:        "success/logrus",
+			description: "验证 LogrusLogger 默认的 ExitFunc 委托到 SetExitFunc 设置的退出函数。",
+			newLogger: func(t *testing.T) Logger {
+				logger, err := NewLogrusLogger()
+				require.NoError(t, err)
+				logger.(*LogrusLogger).logger.Logger.SetOutput(&bytes.Buffer{})
+				return logger
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+			preserveExitState(t)
+
+			var codes []int
+			SetExitCode(7)
+			SetExitFunc(func(code int) { codes = append(codes, code) })
+
+			tt.newLogger(t).Fatal("exit")
+			assert.Equal(t, []int{7}, codes)
+		})
+	}
+}
+
+// TestExitOnPanic_LogsAndRunsHooks 验证 ExitOnPanic 记录 panic 并以结构化事件执行退出钩子。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestExitOnPanic_LogsAndRunsHooks(t *testing.T) {
+	preserveExitState(t)
+	preserveGlobalLogger(t)
+	logger, buffer := newBufferedStdLogger(t, InfoLevel)
+	SetLogger(logger)
+
+	var (
+		event ExitEvent
+		codes []int
+	)
+	RegisterExitHook("capture", func(ctx context.Context, e ExitEvent) error {
+		event = e
+		return nil
+	})
+	SetExitFunc(func(code int) { codes = append(codes, code) })
+
+	func() {
+		defer ExitOnPanic()
+		panic(errors.New("panic-event"))
+	}()
+
+	assert.Equal(t, []int{1}, codes)
+	assert.Equal(t, ExitReasonPanic, event.Reason)
+	assert.Equal(t, "panic-event", event.Message)
+	assert.EqualError(t, event.Panic.(error), "panic-event")
+	assert.Contains(t, string(event.Stack), "TestExitOnPanic_LogsAndRunsHooks")
+	assert.Contains(t, buffer.String(), "panic=panic-event")
+
+	// CaptureFatal 中转换为 FatalError。
+	err := CaptureFatal(func() {
+		defer ExitOnPanic()
+		panic("captured-panic")
+	})
+	var fatalErr *FatalError
+	require.ErrorAs(t, err, &fatalErr)
+	assert.Equal(t, ExitReasonPanic, fatalErr.Reason)
+	assert.Equal(t, "captured-panic", fatalErr.Panic)
+
+	// 未发生 panic 时不做任何处理。
+	assert.NoError(t, CaptureFatal(func() {
+		defer ExitOnPanic()
+	}))
+}
+
+// TestRegisterExitHook_ErrorsTimeoutAndUnregister 验证钩子错误隔离、总超时和注销行为。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestRegisterExitHook_ErrorsTimeoutAndUnregister(t *testing.T) {
+	preserveExitState(t)
+	SetExitHookTimeout(50 * time.Millisecond)
+
+	var (
+		mu    sync.Mutex
+		order []string
+	)
+	record := func(name string) {
+		mu.Lock()
+		defer mu.Unlock()
+		order = append(order, name)
+	}
+
+	RegisterExitHook("skipped", func(ctx context.Context, event ExitEvent) error {
+		record("skipped")
+		return nil
+	})
+	block := make(chan struct{})
+	t.Cleanup(func() { close(block) })
+	RegisterExitHook("slow", func(ctx context.Context, event ExitEvent) error {
+		<-block
+		return nil
+	})
+	RegisterExitHook("panic", func(ctx context.Context, event ExitEvent) error {
+		record("panic")
+		panic("hook panic")
+	})
+	RegisterExitHook("error", func(ctx context.Context, event ExitEvent) error {
+		record("error")
+		return errors.New("hook error")
+	})
+	unregister := RegisterExitHook("removed", func(ctx context.Context, event ExitEvent) error {
+		record("removed")
+		return nil
+	})
+	unregister()
+	unregister()
+	RegisterExitHook("nil", nil)()
+
+	exited := false
+	SetExitFunc(func(code int) { exited = true })
+
+	start := time.Now()
+	fatalExit("timeout", nil)
+	assert.True(t, exited)
+	assert.Less(t, time.Since(start), time.Second)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"error", "panic"}, order)
+}
+
+// preserveExitState 保存并在测试结束后恢复退出钩子和退出配置。
+//
+// 参数：
+//   - t: 测试上下文，用于注册清理函数并标记辅助函数调用栈。
+func preserveExitState(t *testing.T) {
+	t.Helper()
+
+	exitState.Lock()
+	hooks := exitState.hooks
+	code := exitState.code
+	timeout := exitState.timeout
+	exit := exitState.exit
+	exitState.hooks = nil
+	exitState.Unlock()
+
+	t.Cleanup(func() {
+		exitState.Lock()
+		defer exitState.Unlock()
+		exitState.hooks = hooks
+		exitState.code = code
+		exitState.timeout = timeout
+		exitState.exit = exit
+	})
+}
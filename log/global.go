@@ -21,6 +21,13 @@ const (
 	// LogTypeLogrus 表示 Logrus 日志类型。
 	// 使用 Logrus 库实现，提供丰富的日志功能，包括结构化日志、多种输出格式等。
 	LogTypeLogrus LogType = "logrus"
+
+	// LogTypeOTel 表示 OpenTelemetry 日志类型。
+	// 将日志作为 OpenTelemetry 日志记录发送给全局 LoggerProvider，适合接入 OTLP 日志管道。
+	LogTypeOTel LogType = "otel"
+
+	// logTypeSink 表示 Output 为平台日志输出目标时 NewLogger 内部使用的日志类型。
+	logTypeSink LogType = "sink"
 )
 
 var (
@@ -37,6 +44,7 @@ type (
 	//   - LogTypeConsole：使用标准库日志并输出到标准输出。
 	//   - LogTypeStd：使用标准库日志，可写入标准输出或指定文件。
 	//   - LogTypeLogrus：使用 Logrus 日志实现，支持格式化和文件轮转配置。
+	//   - LogTypeOTel：发送到 OpenTelemetry 全局 LoggerProvider。
 	LogType string
 )
 
@@ -181,7 +189,7 @@ func Errorf(format string, args ...interface{}) {
 }
 
 // Fatal 使用全局日志实例记录致命错误级别的日志。
-// 记录日志后会导致程序退出。
+// 记录日志后执行退出钩子并退出程序，详见 Logger.Fatal。
 //
 // 参数：
 //   - args：要记录的内容，支持任意类型的值。
@@ -190,7 +198,7 @@ func Fatal(args ...interface{}) {
 }
 
 // Fatalf 使用全局日志实例记录格式化的致命错误级别日志。
-// 记录日志后会导致程序退出。
+// 记录日志后执行退出钩子并退出程序，详见 Logger.Fatal。
 //
 // 参数：
 //   - format：格式化字符串。
@@ -221,3 +229,64 @@ func WithField(key string, value interface{}) Logger {
 func WithFields(fields map[string]interface{}) Logger {
 	return GetLogger().WithFields(fields)
 }
+
+// SetModuleLevel 设置全局日志实例中指定模块的日志级别。
+//
+// 如果全局 Logger 尚不支持按模块设置级别，会先以 NewModuleLogger 包装并替换全局实例，
+// 原有的日志级别作为默认级别；之后通过 WithField(ModuleField, module) 派生的 Logger 按模块级别过滤。
+//
+// 参数：
+//   - module：模块名，以 / 分隔层级，例如 database/sql。
+//   - level：日志过滤级别。
+func SetModuleLevel(module string, level Level) {
+	globalModuleLeveler().SetModuleLevel(module, level)
+}
+
+// ResetModuleLevel 移除全局日志实例中指定模块单独设置的日志级别。
+//
+// 参数：
+//   - module：模块名，移除后该模块沿用上级模块或默认级别。
+func ResetModuleLevel(module string) {
+	globalModuleLeveler().ResetModuleLevel(module)
+}
+
+// GetModuleLevel 获取全局日志实例中指定模块生效的日志级别。
+//
+// 参数：
+//   - module：模块名。
+//
+// 返回：
+//   - Level：模块自身、最近的上级模块或默认级别中最先找到的级别。
+func GetModuleLevel(module string) Level {
+	if leveler, ok := GetLogger().(ModuleLeveler); ok {
+		return leveler.ModuleLevel(module)
+	}
+	return GetLevel()
+}
+
+// globalModuleLeveler 返回支持按模块设置级别的全局日志实例，必要时包装并替换全局实例。
+//
+// 参数：无。
+//
+// 返回：
+//   - ModuleLeveler：全局日志实例。
+func globalModuleLeveler() ModuleLeveler {
+	logger := GetLogger()
+	if leveler, ok := logger.(ModuleLeveler); ok {
+		return leveler
+	}
+
+	globalLoggerLock.Lock()
+	defer globalLoggerLock.Unlock()
+
+	// 加锁期间全局实例可能已被其它调用替换。
+	if leveler, ok := globalLogger.(ModuleLeveler); ok {
+		return leveler
+	}
+	if nil == globalLogger {
+		globalLogger = logger
+	}
+	ml := NewModuleLogger(globalLogger, nil)
+	globalLogger = ml
+	return ml
+}
@@ -0,0 +1,96 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package log
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+type (
+	// LazyValue 是延迟求值的日志字段值，由 Lazy 创建。
+	//
+	// 作为 WithField、WithFields 的字段值时，只有日志级别启用、日志真正输出时才会调用求值函数，
+	// 适合附加序列化大结构体、拼接 SQL 等开销较大的调试信息。求值函数最多执行一次，结果被缓存，
+	// 派生出的多个 Logger 或多个输出目标共享同一结果。LazyValue 实现 fmt.Stringer 与 json.Marshaler，
+	// 文本与 JSON 格式的输出都会触发求值。
+	LazyValue struct {
+		// fn 是求值函数。
+		fn func() interface{}
+		// once 保证求值函数最多执行一次。
+		once sync.Once
+		// value 是求值结果。
+		value interface{}
+	}
+)
+
+// Lazy 创建延迟求值的日志字段值。
+//
+// 示例：
+//
+//	logger.WithField("request", log.Lazy(func() interface{} {
+//		return dumpRequest(req)
+//	})).Debug("收到请求")
+//
+// 参数：
+//   - fn：求值函数，只在日志输出时调用；为 nil 时字段值为 nil。
+//
+// 返回：
+//   - *LazyValue：可作为字段值使用的延迟值。
+func Lazy(fn func() interface{}) *LazyValue {
+	return &LazyValue{fn: fn}
+}
+
+// Value 返回求值结果，首次调用时执行求值函数。
+//
+// 返回：
+//   - interface{}：求值函数的返回值；接收者或求值函数为 nil 时返回 nil。
+func (v *LazyValue) Value() interface{} {
+	if nil == v {
+		return nil
+	}
+	v.once.Do(func() {
+		if nil != v.fn {
+			v.value = v.fn()
+		}
+	})
+	return v.value
+}
+
+// String 实现 fmt.Stringer，按 %v 格式化求值结果。
+//
+// 返回：
+//   - string：求值结果的字符串表示。
+func (v *LazyValue) String() string {
+	return fmt.Sprint(v.Value())
+}
+
+// MarshalJSON 实现 json.Marshaler，编码求值结果。
+//
+// 返回：
+//   - []byte：求值结果的 JSON 编码；结果为 error 时编码其错误消息。
+//   - error：编码失败时返回错误。
+func (v *LazyValue) MarshalJSON() ([]byte, error) {
+	value := v.Value()
+	if err, ok := value.(error); ok {
+		return json.Marshal(err.Error())
+	}
+	return json.Marshal(value)
+}
+
+// resolveLazy 将延迟值替换为求值结果，其它值原样返回。
+//
+// 参数：
+//   - value：字段值。
+//
+// 返回：
+//   - interface{}：字段值为 *LazyValue 时返回求值结果，否则返回 value。
+func resolveLazy(value interface{}) interface{} {
+	if lazy, ok := value.(*LazyValue); ok {
+		return lazy.Value()
+	}
+	return value
+}
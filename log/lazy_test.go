@@ -0,0 +1,133 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	otellog "go.opentelemetry.io/otel/log"
+)
+
+// countingLazy 返回记录求值次数的延迟值。
+//
+// 参数：
+//   - value: 求值结果。
+//
+// 返回：
+//   - *LazyValue: 延迟值。
+//   - *atomic.Int32: 求值次数。
+func countingLazy(value interface{}) (*LazyValue, *atomic.Int32) {
+	calls := &atomic.Int32{}
+	return Lazy(func() interface{} {
+		calls.Add(1)
+		return value
+	}), calls
+}
+
+// TestLazyValue 验证延迟值的求值、缓存与编码。
+func TestLazyValue(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		give        *LazyValue
+		wantValue   interface{}
+		wantString  string
+		wantJSON    string
+	}{
+		{name: "success/struct", description: "验证结构体结果。", give: Lazy(func() interface{} { return struct{ A int }{A: 1} }), wantValue: struct{ A int }{A: 1}, wantString: "{1}", wantJSON: `{"A":1}`},
+		{name: "success/error", description: "验证 error 结果按错误消息编码。", give: Lazy(func() interface{} { return errors.New("boom") }), wantValue: errors.New("boom"), wantString: "boom", wantJSON: `"boom"`},
+		{name: "success/nil-func", description: "验证求值函数为 nil。", give: Lazy(nil), wantString: "<nil>", wantJSON: "null"},
+		{name: "success/nil-receiver", description: "验证接收者为 nil。", give: nil, wantString: "<nil>", wantJSON: "null"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			assert.Equal(t, tt.wantValue, tt.give.Value())
+			assert.Equal(t, tt.wantString, tt.give.String())
+			got, err := tt.give.MarshalJSON()
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.wantJSON, string(got))
+		})
+	}
+
+	t.Run("once", func(t *testing.T) {
+		t.Log("验证求值函数只执行一次。")
+
+		lazy, calls := countingLazy("v")
+		for i := 0; i < 3; i++ {
+			assert.Equal(t, "v", lazy.Value())
+			assert.Equal(t, "v", lazy.String())
+		}
+		assert.Equal(t, int32(1), calls.Load())
+	})
+}
+
+// TestLazy_StdLogger 验证 StdLogger 只在级别启用时求值。
+func TestLazy_StdLogger(t *testing.T) {
+	logger, buffer := newBufferedStdLogger(t, InfoLevel)
+	lazy, calls := countingLazy("expensive")
+
+	entry := logger.WithField("dump", lazy)
+	entry.Debug("skipped")
+	assert.Equal(t, int32(0), calls.Load())
+	assert.Empty(t, buffer.String())
+
+	entry.Info("emitted")
+	assert.Equal(t, int32(1), calls.Load())
+	assert.Contains(t, buffer.String(), "dump=expensive")
+}
+
+// TestLazy_LogrusLogger 验证 LogrusLogger 只在级别启用时求值，并以 JSON 输出结果。
+func TestLazy_LogrusLogger(t *testing.T) {
+	loggerInterface, err := NewLogrusLogger(
+		WithFormatter(&logrus.JSONFormatter{}),
+		WithLogrusLevel(InfoLevel),
+	)
+	require.NoError(t, err)
+	logger, ok := loggerInterface.(*LogrusLogger)
+	require.True(t, ok)
+	buffer := &bytes.Buffer{}
+	logger.logger.Logger.SetOutput(buffer)
+
+	lazy, calls := countingLazy(map[string]int{"rows": 3})
+	entry := logger.WithFields(map[string]interface{}{"result": lazy})
+	entry.Debug("skipped")
+	assert.Equal(t, int32(0), calls.Load())
+	assert.Empty(t, buffer.String())
+
+	entry.Info("emitted")
+	assert.Equal(t, int32(1), calls.Load())
+	var got map[string]interface{}
+	require.NoError(t, json.Unmarshal(buffer.Bytes(), &got))
+	assert.Equal(t, map[string]interface{}{"rows": float64(3)}, got["result"])
+}
+
+// TestLazy_OTelLogger 验证 OTelLogger 使用求值结果的类型生成属性。
+func TestLazy_OTelLogger(t *testing.T) {
+	recorder := &otelRecorder{}
+	logger := NewOTelLogger(WithOTelProvider(recorder), WithOTelLevel(InfoLevel))
+	lazy, calls := countingLazy(int64(42))
+
+	entry := logger.WithField("count", lazy)
+	entry.Debug("skipped")
+	assert.Equal(t, int32(0), calls.Load())
+	assert.Empty(t, recorder.Records())
+
+	entry.Info("emitted")
+	assert.Equal(t, int32(1), calls.Load())
+	records := recorder.Records()
+	require.Len(t, records, 1)
+	assert.True(t, otellog.Int64Value(42).Equal(otelAttributes(records[0].record)["count"]))
+}
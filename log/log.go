@@ -6,6 +6,7 @@ package log
 
 import (
 	"fmt"
+	"os"
 	"time"
 )
 
@@ -151,7 +152,8 @@ type (
 
 		// Fatal 记录致命错误级别的日志。
 		// 参数 args 支持任意类型的值，这些值会被转换为字符串并连接。
-		// 记录日志后会导致程序以状态码 1 退出。
+		// 记录日志后执行 RegisterExitHook 注册的退出钩子，并以 SetExitCode 设置的状态码（默认为 1）退出；
+		// 在 CaptureFatal 中调用时转换为错误而不退出。
 		// 这个方法应该只在程序无法继续运行时使用。
 		//
 		// 参数：
@@ -161,7 +163,8 @@ type (
 		// Fatalf 记录格式化的致命错误级别日志。
 		// 参数 format 是格式化字符串，args 是对应的参数。
 		// 支持标准的 Printf 风格的格式化。
-		// 记录日志后会导致程序以状态码 1 退出。
+		// 记录日志后执行 RegisterExitHook 注册的退出钩子，并以 SetExitCode 设置的状态码（默认为 1）退出；
+		// 在 CaptureFatal 中调用时转换为错误而不退出。
 		//
 		// 参数：
 		//   - format：格式化字符串。
@@ -203,6 +206,7 @@ type (
 		//   - LogTypeConsole：使用标准库日志并输出到标准输出。
 		//   - LogTypeStd：使用标准库日志，可写入标准输出或指定文件。
 		//   - LogTypeLogrus：使用 Logrus 日志实现，支持格式化和文件轮转配置。
+		//   - LogTypeOTel：发送到 OpenTelemetry 全局 LoggerProvider。
 		Type LogType
 		// Level 指定日志过滤级别。可选值包括：
 		//   - DebugLevel：输出调试及以上级别日志。
@@ -211,7 +215,7 @@ type (
 		//   - ErrorLevel：输出错误及以上级别日志。
 		//   - FatalLevel：输出致命错误日志。
 		Level Level
-		// Output 指定日志文件路径。空字符串表示写入标准输出。
+		// Output 指定日志文件路径或 journald、eventlog 等平台日志输出目标。空字符串表示写入标准输出。
 		Output string
 		// EnableRotate 控制 Logrus 文件输出是否启用日志轮转。
 		EnableRotate bool
@@ -225,6 +229,16 @@ type (
 		//   - TextFormat：使用文本格式输出。
 		//   - JSONFormat：使用 JSON 格式输出。
 		FormatType LoggerFormatType
+		// DedupWindow 指定折叠连续重复日志的时间窗口。小于等于 0 表示不去重。
+		DedupWindow time.Duration
+		// ModuleLevels 指定按模块使用的日志级别。为 nil 时不按模块过滤，Level 即为全部日志的级别。
+		ModuleLevels map[string]Level
+		// BufferSize 指定 LogTypeStd、LogTypeConsole 与 LogTypeLogrus 输出的缓冲区大小。小于等于 0 表示不缓冲，直接写出。
+		BufferSize int
+		// FlushInterval 指定缓冲输出的定时刷新间隔，小于等于 0 表示不定时刷新。仅在 BufferSize 大于 0 时生效。
+		FlushInterval time.Duration
+		// SyncSignals 指定触发缓冲输出同步的信号，为空时不监听信号。仅在 BufferSize 大于 0 时生效。
+		SyncSignals []os.Signal
 	}
 
 	// Option 定义日志配置修改函数。
@@ -289,6 +303,7 @@ func ParseLevel(level string) (Level, error) {
 //   - LogTypeConsole：使用标准库日志并强制输出到标准输出。
 //   - LogTypeStd：使用标准库日志，可按 Output 写入文件或标准输出。
 //   - LogTypeLogrus：使用 Logrus 日志实现，支持格式化和文件轮转配置。
+//   - LogTypeOTel：发送到 OpenTelemetry 全局 LoggerProvider，忽略 Output、轮转和格式配置。
 //
 // 返回：
 //   - Option：应用于 LoggerOptions 的配置选项。
@@ -331,10 +346,16 @@ func WithLevel(level Level) Option {
 	}
 }
 
-// WithOutput 设置日志输出路径。
+// WithOutput 设置日志输出路径或平台日志输出目标。
+//
+// 除文件路径外，LogTypeStd 和 LogTypeLogrus 还支持以下平台日志输出目标，冒号后的名称可省略，默认为可执行文件名：
+//   - OutputJournald：写作 journald 或 journald:标识符，通过原生协议写入 systemd journald，见 NewJournaldLogger。
+//   - OutputEventLog：写作 eventlog 或 eventlog:事件源，写入 Windows 事件日志，见 NewEventLogLogger。
+//
+// 使用平台日志输出目标时，Logrus 的轮转与格式配置不再生效。
 //
 // 参数：
-//   - output：日志文件路径；空字符串表示输出到标准输出。
+//   - output：日志文件路径或平台日志输出目标；空字符串表示输出到标准输出。
 //
 // 返回：
 //   - Option：应用于 LoggerOptions 的配置选项。
@@ -383,6 +404,27 @@ func WithMaxAge(duration time.Duration) Option {
 	}
 }
 
+// WithBufferedOutput 设置以 BufferedWriter 批量写出日志。
+//
+// 缓冲输出在记录 Error、Fatal 级别日志后、执行退出钩子时以及收到 signals 中的信号时同步落盘，
+// 详见 BufferedWriter 与 WithSyncOnSignal。程序正常结束前应调用 SyncBuffers 写出剩余日志。
+// 只对 LogTypeStd、LogTypeConsole 与 LogTypeLogrus 生效，平台日志输出目标与 LogTypeOTel 忽略该配置。
+//
+// 参数：
+//   - size：缓冲区字节数；小于等于 0 表示不缓冲。
+//   - flushInterval：定时刷新间隔；小于等于 0 表示不定时刷新。
+//   - signals：触发同步的信号，例如 os.Interrupt 与 syscall.SIGTERM；未传入时不监听信号。
+//
+// 返回：
+//   - Option：应用于 LoggerOptions 的配置选项。
+func WithBufferedOutput(size int, flushInterval time.Duration, signals ...os.Signal) Option {
+	return func(opts *LoggerOptions) {
+		opts.BufferSize = size
+		opts.FlushInterval = flushInterval
+		opts.SyncSignals = signals
+	}
+}
+
 // NewLogger 创建一个新的日志实例。
 //
 // 未传入 options 时使用标准库日志实现、InfoLevel、标准输出、JSONFormat 以及
@@ -393,7 +435,8 @@ func WithMaxAge(duration time.Duration) Option {
 //
 // 返回：
 //   - Logger：初始化完成的日志实例。
-//   - error：日志类型不受支持、文件输出路径创建失败、文件打开失败或 Logrus 轮转 writer 创建失败时返回错误。
+//   - error：日志类型不受支持、文件输出路径创建失败、文件打开失败、Logrus 轮转 writer 创建失败，
+//     或平台日志输出目标不可用时返回错误。
 func NewLogger(options ...Option) (Logger, error) {
 	// 默认配置。
 	opts := &LoggerOptions{
@@ -414,7 +457,15 @@ func NewLogger(options ...Option) (Logger, error) {
 	var logger Logger
 	var err error
 
-	switch opts.Type {
+	// 平台日志输出目标替代文件输出，只对 LogTypeStd 和 LogTypeLogrus 生效。
+	logType := opts.Type
+	if target, _ := parseSinkTarget(opts.Output); "" != target && (LogTypeStd == logType || LogTypeLogrus == logType) {
+		logType = logTypeSink
+	}
+
+	switch logType {
+	case logTypeSink:
+		logger, err = newSinkTarget(opts.Output)
 	case LogTypeConsole:
 		logger, err = NewStdLogger("")
 	case LogTypeStd:
@@ -442,16 +493,49 @@ func NewLogger(options ...Option) (Logger, error) {
 		}
 
 		logger, err = NewLogrusLogger(logrusOpts...)
+	case LogTypeOTel:
+		logger = NewOTelLogger(WithOTelLevel(opts.Level))
 	default:
 		return nil, fmt.Errorf("不支持的日志类型：%s", opts.Type)
 	}
 
 	if nil != err {
-		return nil, fmt.Errorf("创建日志实例失败：%v", err)
+		return nil, fmt.Errorf("创建日志实例失败：%w", err)
 	}
 
 	// 设置日志级别。
 	logger.SetLevel(opts.Level)
 
+	if opts.BufferSize > 0 {
+		bufferOutput(logger, opts)
+	}
+
+	logger = NewDedupLogger(logger, opts.DedupWindow)
+	if nil != opts.ModuleLevels {
+		logger = NewModuleLogger(logger, opts.ModuleLevels)
+	}
+
 	return logger, nil
 }
+
+// bufferOutput 把标准库与 Logrus 日志实例的输出替换为 BufferedWriter，其它实现保持不变。
+//
+// 参数：
+//   - logger：NewLogger 创建的日志实例。
+//   - opts：包含缓冲区大小、定时刷新间隔与同步信号的日志配置。
+func bufferOutput(logger Logger, opts *LoggerOptions) {
+	bufferedOpts := []BufferedWriterOption{
+		WithBufferSize(opts.BufferSize),
+		WithFlushInterval(opts.FlushInterval),
+	}
+	if len(opts.SyncSignals) > 0 {
+		bufferedOpts = append(bufferedOpts, WithSyncOnSignal(opts.SyncSignals...))
+	}
+
+	switch l := logger.(type) {
+	case *StdLogger:
+		l.logger.SetOutput(NewBufferedWriter(l.logger.Writer(), bufferedOpts...))
+	case *LogrusLogger:
+		l.logger.Logger.SetOutput(NewBufferedWriter(l.logger.Logger.Out, bufferedOpts...))
+	}
+}
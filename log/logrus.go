@@ -5,6 +5,7 @@
 package log
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"time"
@@ -280,6 +281,9 @@ func NewLogrusLogger(opts ...LogrusOption) (Logger, error) {
 	// 设置日志级别。
 	log.SetLevel(options.Level)
 
+	// 退出时委托到包级退出函数，使 SetExitFunc 同样作用于 Logrus。
+	log.ExitFunc = callExitFunc
+
 	return &LogrusLogger{
 		logger: logrus.NewEntry(log),
 	}, nil
@@ -366,6 +370,7 @@ func (l *LogrusLogger) Warnf(format string, args ...interface{}) {
 //   - args：要记录的内容，支持任意类型的值。
 func (l *LogrusLogger) Error(args ...interface{}) {
 	l.logger.Error(args...)
+	syncOutput(l.logger.Logger.Out)
 }
 
 // Errorf 实现 Logger 接口的格式化错误级别日志记录。
@@ -375,25 +380,32 @@ func (l *LogrusLogger) Error(args ...interface{}) {
 //   - args：格式化参数。
 func (l *LogrusLogger) Errorf(format string, args ...interface{}) {
 	l.logger.Errorf(format, args...)
+	syncOutput(l.logger.Logger.Out)
 }
 
 // Fatal 实现 Logger 接口的致命错误级别日志记录。
-// 记录日志后会导致程序以状态码 1 退出。
+// 记录日志后执行 RegisterExitHook 注册的退出钩子，并以 SetExitCode 设置的状态码（默认为 1）退出；
+// 在 CaptureFatal 中调用时转换为错误而不退出。
 //
 // 参数：
 //   - args：要记录的内容，支持任意类型的值。
 func (l *LogrusLogger) Fatal(args ...interface{}) {
-	l.logger.Fatal(args...)
+	l.logger.Log(logrus.FatalLevel, args...)
+	syncOutput(l.logger.Logger.Out)
+	fatalExit(fmt.Sprint(args...), l.logger.Logger.Exit)
 }
 
 // Fatalf 实现 Logger 接口的格式化致命错误级别日志记录。
-// 记录日志后会导致程序以状态码 1 退出。
+// 记录日志后执行 RegisterExitHook 注册的退出钩子，并以 SetExitCode 设置的状态码（默认为 1）退出；
+// 在 CaptureFatal 中调用时转换为错误而不退出。
 //
 // 参数：
 //   - format：格式化字符串。
 //   - args：格式化参数。
 func (l *LogrusLogger) Fatalf(format string, args ...interface{}) {
-	l.logger.Fatalf(format, args...)
+	l.logger.Logf(logrus.FatalLevel, format, args...)
+	syncOutput(l.logger.Logger.Out)
+	fatalExit(fmt.Sprintf(format, args...), l.logger.Logger.Exit)
 }
 
 // WithField 实现 Logger 接口的单字段添加方法。
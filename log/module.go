@@ -0,0 +1,407 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package log
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+const (
+	// ModuleField 是标识日志所属模块的字段名。
+	//
+	// 通过 WithField(ModuleField, "database/sql") 派生的 ModuleLogger 按该模块的级别过滤日志。
+	ModuleField = "module"
+
+	// moduleSeparator 是模块层级的分隔符。
+	moduleSeparator = "/"
+)
+
+var (
+	_ Logger        = (*ModuleLogger)(nil)
+	_ ModuleLeveler = (*ModuleLogger)(nil)
+	_ ContextLogger = (*ModuleLogger)(nil)
+)
+
+type (
+	// ModuleLeveler 定义按模块设置日志级别的能力。
+	//
+	// 模块名以 / 分隔层级，未单独设置级别的模块沿用最近的上级模块的级别，
+	// 例如设置 database 后 database/sql 与 database/sql/driver 都使用该级别，直到为它们单独设置。
+	ModuleLeveler interface {
+		// SetModuleLevel 设置模块及其未单独设置的下级模块的日志级别。
+		//
+		// 参数：
+		//   - module：模块名，首尾的 / 会被忽略；空字符串表示默认级别。
+		//   - level：日志级别。
+		SetModuleLevel(module string, level Level)
+
+		// ResetModuleLevel 移除模块单独设置的日志级别，使其重新沿用上级模块的级别。
+		//
+		// 参数：
+		//   - module：模块名；空字符串不会被移除。
+		ResetModuleLevel(module string)
+
+		// ModuleLevel 返回模块当前生效的日志级别。
+		//
+		// 参数：
+		//   - module：模块名。
+		//
+		// 返回：
+		//   - Level：模块自身、最近的上级模块或默认级别中最先找到的级别。
+		ModuleLevel(module string) Level
+	}
+
+	// ModuleLogger 实现了 Logger 接口，按 module 字段为不同模块使用独立的日志级别。
+	//
+	// ModuleLogger 接管级别过滤，创建时把下游 Logger 的级别作为默认级别，并将下游级别调整为 DebugLevel；
+	// 因此应通过 ModuleLogger 而不是下游 Logger 调整级别。通过 WithField、WithFields 派生的实例共享级别配置，
+	// 运行时调用 SetModuleLevel 会立即作用于所有已派生的实例。
+	ModuleLogger struct {
+		// next 是实际输出日志的下游 Logger。
+		next Logger
+		// module 是当前实例所属的模块，空字符串表示未指定模块。
+		module string
+		// state 是所有派生实例共享的级别配置。
+		state *moduleState
+	}
+
+	// moduleState 保存 ModuleLogger 及其派生实例共享的级别配置。
+	moduleState struct {
+		// mu 保护 levels。
+		mu sync.RWMutex
+		// levels 是按模块名单独设置的级别，空字符串对应默认级别。
+		levels map[string]Level
+	}
+)
+
+// WithModuleLevels 设置 NewLogger 创建的日志实例按模块使用的日志级别。
+//
+// 设置后 NewLogger 返回 ModuleLogger，WithLevel 设置的级别作为默认级别。传入空映射同样会启用模块级别，
+// 便于之后在运行时通过 SetModuleLevel 调整。
+//
+// 参数：
+//   - levels：模块名到日志级别的映射，映射会在应用选项时复制。
+//
+// 返回：
+//   - Option：应用于 LoggerOptions 的配置选项。
+func WithModuleLevels(levels map[string]Level) Option {
+	return func(opts *LoggerOptions) {
+		opts.ModuleLevels = make(map[string]Level, len(levels))
+		for module, level := range levels {
+			opts.ModuleLevels[module] = level
+		}
+	}
+}
+
+// NewModuleLogger 创建按模块过滤日志级别的 ModuleLogger。
+//
+// 如果 next 已经是 ModuleLogger，直接在其上设置 levels 并返回，不会重复包装。
+//
+// 参数：
+//   - next：实际输出日志的下游 Logger，其当前级别作为默认级别。
+//   - levels：模块名到日志级别的初始映射，可以为 nil。
+//
+// 返回：
+//   - *ModuleLogger：按模块过滤级别的日志实例。
+func NewModuleLogger(next Logger, levels map[string]Level) *ModuleLogger {
+	if ml, ok := next.(*ModuleLogger); ok {
+		for module, level := range levels {
+			ml.SetModuleLevel(module, level)
+		}
+		return ml
+	}
+
+	state := &moduleState{
+		levels: map[string]Level{"": next.GetLevel()},
+	}
+	for module, level := range levels {
+		state.levels[normalizeModule(module)] = level
+	}
+	next.SetLevel(DebugLevel)
+
+	return &ModuleLogger{
+		next:  next,
+		state: state,
+	}
+}
+
+// SetModuleLevel 实现 ModuleLeveler 接口的模块级别设置方法。
+//
+// 参数：
+//   - module：模块名，首尾的 / 会被忽略；空字符串表示默认级别。
+//   - level：日志级别。
+func (l *ModuleLogger) SetModuleLevel(module string, level Level) {
+	l.state.mu.Lock()
+	defer l.state.mu.Unlock()
+
+	l.state.levels[normalizeModule(module)] = level
+}
+
+// ResetModuleLevel 实现 ModuleLeveler 接口的模块级别移除方法。
+//
+// 参数：
+//   - module：模块名；空字符串不会被移除。
+func (l *ModuleLogger) ResetModuleLevel(module string) {
+	module = normalizeModule(module)
+	if "" == module {
+		return
+	}
+
+	l.state.mu.Lock()
+	defer l.state.mu.Unlock()
+
+	delete(l.state.levels, module)
+}
+
+// ModuleLevel 实现 ModuleLeveler 接口的模块生效级别获取方法。
+//
+// 参数：
+//   - module：模块名。
+//
+// 返回：
+//   - Level：模块自身、最近的上级模块或默认级别中最先找到的级别。
+func (l *ModuleLogger) ModuleLevel(module string) Level {
+	return l.state.level(normalizeModule(module))
+}
+
+// ModuleLevels 返回所有单独设置的模块级别。
+//
+// 参数：无。
+//
+// 返回：
+//   - map[string]Level：模块名到级别的映射副本，空字符串对应默认级别。
+func (l *ModuleLogger) ModuleLevels() map[string]Level {
+	l.state.mu.RLock()
+	defer l.state.mu.RUnlock()
+
+	levels := make(map[string]Level, len(l.state.levels))
+	for module, level := range l.state.levels {
+		levels[module] = level
+	}
+	return levels
+}
+
+// SetLevel 实现 Logger 接口的日志级别设置方法。
+//
+// 参数：
+//   - level：日志级别；设置到当前实例所属的模块，未指定模块时设置默认级别。
+func (l *ModuleLogger) SetLevel(level Level) {
+	l.SetModuleLevel(l.module, level)
+}
+
+// GetLevel 实现 Logger 接口的日志级别获取方法。
+//
+// 返回：
+//   - Level：当前实例所属模块生效的日志级别。
+func (l *ModuleLogger) GetLevel() Level {
+	return l.state.level(l.module)
+}
+
+// Debug 实现 Logger 接口的调试级别日志记录。
+//
+// 参数：
+//   - args：要记录的内容，支持任意类型的值。
+func (l *ModuleLogger) Debug(args ...interface{}) {
+	if l.enabled(DebugLevel) {
+		l.next.Debug(args...)
+	}
+}
+
+// Debugf 实现 Logger 接口的格式化调试级别日志记录。
+//
+// 参数：
+//   - format：格式化字符串。
+//   - args：格式化参数。
+func (l *ModuleLogger) Debugf(format string, args ...interface{}) {
+	if l.enabled(DebugLevel) {
+		l.next.Debugf(format, args...)
+	}
+}
+
+// Info 实现 Logger 接口的信息级别日志记录。
+//
+// 参数：
+//   - args：要记录的内容，支持任意类型的值。
+func (l *ModuleLogger) Info(args ...interface{}) {
+	if l.enabled(InfoLevel) {
+		l.next.Info(args...)
+	}
+}
+
+// Infof 实现 Logger 接口的格式化信息级别日志记录。
+//
+// 参数：
+//   - format：格式化字符串。
+//   - args：格式化参数。
+func (l *ModuleLogger) Infof(format string, args ...interface{}) {
+	if l.enabled(InfoLevel) {
+		l.next.Infof(format, args...)
+	}
+}
+
+// Warn 实现 Logger 接口的警告级别日志记录。
+//
+// 参数：
+//   - args：要记录的内容，支持任意类型的值。
+func (l *ModuleLogger) Warn(args ...interface{}) {
+	if l.enabled(WarnLevel) {
+		l.next.Warn(args...)
+	}
+}
+
+// Warnf 实现 Logger 接口的格式化警告级别日志记录。
+//
+// 参数：
+//   - format：格式化字符串。
+//   - args：格式化参数。
+func (l *ModuleLogger) Warnf(format string, args ...interface{}) {
+	if l.enabled(WarnLevel) {
+		l.next.Warnf(format, args...)
+	}
+}
+
+// Error 实现 Logger 接口的错误级别日志记录。
+//
+// 参数：
+//   - args：要记录的内容，支持任意类型的值。
+func (l *ModuleLogger) Error(args ...interface{}) {
+	if l.enabled(ErrorLevel) {
+		l.next.Error(args...)
+	}
+}
+
+// Errorf 实现 Logger 接口的格式化错误级别日志记录。
+//
+// 参数：
+//   - format：格式化字符串。
+//   - args：格式化参数。
+func (l *ModuleLogger) Errorf(format string, args ...interface{}) {
+	if l.enabled(ErrorLevel) {
+		l.next.Errorf(format, args...)
+	}
+}
+
+// Fatal 实现 Logger 接口的致命错误级别日志记录。
+// Fatal 不受模块级别过滤，总是交由下游 Logger 记录并退出。
+//
+// 参数：
+//   - args：要记录的内容，支持任意类型的值。
+func (l *ModuleLogger) Fatal(args ...interface{}) {
+	l.next.Fatal(args...)
+}
+
+// Fatalf 实现 Logger 接口的格式化致命错误级别日志记录。
+// Fatalf 不受模块级别过滤，总是交由下游 Logger 记录并退出。
+//
+// 参数：
+//   - format：格式化字符串。
+//   - args：格式化参数。
+func (l *ModuleLogger) Fatalf(format string, args ...interface{}) {
+	l.next.Fatalf(format, args...)
+}
+
+// WithField 实现 Logger 接口的单字段添加方法。
+//
+// 参数：
+//   - key：字段名；为 ModuleField 时新实例归属于 value 表示的模块。
+//   - value：字段值。
+//
+// 返回：
+//   - Logger：包含新字段并共享级别配置的新 Logger 实例。
+func (l *ModuleLogger) WithField(key string, value interface{}) Logger {
+	module := l.module
+	if ModuleField == key {
+		module = normalizeModule(fmt.Sprint(value))
+	}
+	return &ModuleLogger{
+		next:   l.next.WithField(key, value),
+		module: module,
+		state:  l.state,
+	}
+}
+
+// WithFields 实现 Logger 接口的多字段添加方法。
+//
+// 参数：
+//   - fields：要添加的字段映射；包含 ModuleField 时新实例归属于对应的模块。
+//
+// 返回：
+//   - Logger：包含新字段并共享级别配置的新 Logger 实例。
+func (l *ModuleLogger) WithFields(fields map[string]interface{}) Logger {
+	module := l.module
+	if value, ok := fields[ModuleField]; ok {
+		module = normalizeModule(fmt.Sprint(value))
+	}
+	return &ModuleLogger{
+		next:   l.next.WithFields(fields),
+		module: module,
+		state:  l.state,
+	}
+}
+
+// WithContext 实现 ContextLogger 接口的上下文绑定方法。
+// 下游 Logger 未实现 ContextLogger 时忽略 ctx。
+//
+// 参数：
+//   - ctx：记录日志时使用的上下文。
+//
+// 返回：
+//   - Logger：绑定 ctx 并共享级别配置的新 Logger 实例。
+func (l *ModuleLogger) WithContext(ctx context.Context) Logger {
+	return &ModuleLogger{
+		next:   WithContext(l.next, ctx),
+		module: l.module,
+		state:  l.state,
+	}
+}
+
+// enabled 判断指定级别的日志在当前模块下是否输出。
+//
+// 参数：
+//   - level：日志级别。
+//
+// 返回：
+//   - bool：level 不低于模块生效级别时返回 true。
+func (l *ModuleLogger) enabled(level Level) bool {
+	return level >= l.state.level(l.module)
+}
+
+// level 按模块层级查找生效的日志级别。
+//
+// 参数：
+//   - module：已规范化的模块名。
+//
+// 返回：
+//   - Level：模块自身、最近的上级模块或默认级别中最先找到的级别。
+func (s *moduleState) level(module string) Level {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for "" != module {
+		if level, ok := s.levels[module]; ok {
+			return level
+		}
+		idx := strings.LastIndex(module, moduleSeparator)
+		if idx < 0 {
+			break
+		}
+		module = module[:idx]
+	}
+	return s.levels[""]
+}
+
+// normalizeModule 去除模块名首尾的分隔符与空白。
+//
+// 参数：
+//   - module：模块名。
+//
+// 返回：
+//   - string：规范化后的模块名。
+func normalizeModule(module string) string {
+	return strings.Trim(strings.TrimSpace(module), moduleSeparator)
+}
@@ -0,0 +1,203 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package log
+
+import (
+	stdlog "log"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newModuleTestLogger 创建写入内存缓冲区的模块日志实例。
+//
+// 参数：
+//   - levels: 模块级别的初始映射。
+//
+// 返回：
+//   - *ModuleLogger: 模块日志实例，默认级别为 InfoLevel。
+//   - *syncBuffer: 日志输出缓冲区，每行不含时间戳。
+func newModuleTestLogger(levels map[string]Level) (*ModuleLogger, *syncBuffer) {
+	buf := &syncBuffer{}
+	next := &StdLogger{
+		logger: stdlog.New(buf, "", 0),
+		fields: make(map[string]interface{}),
+		level:  InfoLevel,
+	}
+	return NewModuleLogger(next, levels), buf
+}
+
+// TestModuleLogger_Filter 验证按模块层级查找生效级别并过滤日志。
+func TestModuleLogger_Filter(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		levels      map[string]Level
+		run         func(l Logger)
+		want        []string
+	}{
+		{
+			name:        "success/module-debug-rest-info",
+			description: "验证为 database/sql 设置 DebugLevel 后其它模块仍为 InfoLevel。",
+			levels:      map[string]Level{"database/sql": DebugLevel},
+			run: func(l Logger) {
+				l.WithField(ModuleField, "database/sql").Debug("query")
+				l.WithField(ModuleField, "http").Debug("request")
+				l.Debug("root")
+				l.Info("started")
+			},
+			want: []string{"[DEBUG] [module=database/sql] query", "[INFO] started"},
+		},
+		{
+			name:        "success/inherit-parent",
+			description: "验证未单独设置的子模块沿用最近的上级模块级别。",
+			levels:      map[string]Level{"database": ErrorLevel, "database/sql/driver": DebugLevel},
+			run: func(l Logger) {
+				l.WithField(ModuleField, "database/sql").Warn("slow")
+				l.WithField(ModuleField, "database/sql").Error("broken")
+				l.WithField(ModuleField, "database/sql/driver").Debug("dial")
+			},
+			want: []string{"[ERROR] [module=database/sql] broken", "[DEBUG] [module=database/sql/driver] dial"},
+		},
+		{
+			name:        "success/with-fields",
+			description: "验证通过 WithFields 指定模块同样生效，其它字段不改变所属模块。",
+			levels:      map[string]Level{"cache": DebugLevel},
+			run: func(l Logger) {
+				l.WithFields(map[string]interface{}{ModuleField: "cache"}).Debugf("miss %d", 1)
+				l.WithFields(map[string]interface{}{"key": "k"}).Debug("hidden")
+			},
+			want: []string{"[DEBUG] [module=cache] miss 1"},
+		},
+		{
+			name:        "boundary/prefix-not-parent",
+			description: "验证模块名前缀相同但不属于同一层级时不继承级别。",
+			levels:      map[string]Level{"data": DebugLevel},
+			run: func(l Logger) {
+				l.WithField(ModuleField, "database").Debug("hidden")
+				l.WithField(ModuleField, "data/x").Debug("shown")
+			},
+			want: []string{"[DEBUG] [module=data/x] shown"},
+		},
+		{
+			name:        "boundary/normalize-separator",
+			description: "验证模块名首尾的分隔符被忽略。",
+			levels:      map[string]Level{"/database/sql/": DebugLevel},
+			run: func(l Logger) {
+				l.WithField(ModuleField, "database/sql/").Debug("query")
+			},
+			want: []string{"[DEBUG] [module=database/sql/] query"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			l, buf := newModuleTestLogger(tt.levels)
+			tt.run(l)
+			assert.Equal(t, tt.want, buf.lines())
+		})
+	}
+}
+
+// TestModuleLogger_RuntimeChange 验证运行时调整级别立即作用于已派生的实例。
+func TestModuleLogger_RuntimeChange(t *testing.T) {
+	l, buf := newModuleTestLogger(nil)
+	sqlLogger := l.WithField(ModuleField, "database/sql")
+
+	sqlLogger.Debug("before")
+	l.SetModuleLevel("database", DebugLevel)
+	sqlLogger.Debug("after")
+	assert.Equal(t, DebugLevel, sqlLogger.GetLevel())
+	assert.Equal(t, DebugLevel, sqlLogger.WithField("id", 1).GetLevel())
+	assert.Equal(t, InfoLevel, l.GetLevel())
+
+	sqlLogger.SetLevel(WarnLevel)
+	assert.Equal(t, WarnLevel, l.ModuleLevel("database/sql"))
+	assert.Equal(t, DebugLevel, l.ModuleLevel("database"))
+
+	l.ResetModuleLevel("database/sql")
+	l.ResetModuleLevel("")
+	assert.Equal(t, DebugLevel, sqlLogger.GetLevel())
+	assert.Equal(t, map[string]Level{"": InfoLevel, "database": DebugLevel}, l.ModuleLevels())
+
+	l.SetLevel(ErrorLevel)
+	l.ResetModuleLevel("database")
+	sqlLogger.Warn("hidden")
+	sqlLogger.Error("shown")
+
+	assert.Equal(t, []string{"[DEBUG] [module=database/sql] after", "[ERROR] [module=database/sql] shown"}, buf.lines())
+}
+
+// TestModuleLogger_Concurrent 验证并发记录日志与调整级别时没有数据竞争。
+func TestModuleLogger_Concurrent(t *testing.T) {
+	l, _ := newModuleTestLogger(nil)
+	sqlLogger := l.WithField(ModuleField, "database/sql")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				sqlLogger.Debug("query")
+			}
+		}()
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				l.SetModuleLevel("database", Level(i%4))
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+// TestNewLogger_WithModuleLevels 验证 WithModuleLevels 选项控制 NewLogger 是否包装模块日志实例。
+func TestNewLogger_WithModuleLevels(t *testing.T) {
+	levels := map[string]Level{"database/sql": DebugLevel}
+	logger, err := NewLogger(WithLogType(LogTypeConsole), WithLevel(WarnLevel), WithModuleLevels(levels))
+	require.NoError(t, err)
+	require.IsType(t, &ModuleLogger{}, logger)
+	assert.Equal(t, WarnLevel, logger.GetLevel())
+	assert.Equal(t, DebugLevel, logger.WithField(ModuleField, "database/sql").GetLevel())
+
+	levels["database/sql"] = ErrorLevel
+	assert.Equal(t, DebugLevel, logger.(*ModuleLogger).ModuleLevel("database/sql"), "选项应复制传入的映射")
+
+	logger, err = NewLogger(WithLogType(LogTypeConsole))
+	require.NoError(t, err)
+	assert.IsType(t, &StdLogger{}, logger)
+}
+
+// TestGlobalModuleLevel 验证全局模块级别函数按需包装全局日志实例。
+func TestGlobalModuleLevel(t *testing.T) {
+	original := GetLogger()
+	defer SetLogger(original)
+
+	buf := &syncBuffer{}
+	SetLogger(&StdLogger{
+		logger: stdlog.New(buf, "", 0),
+		fields: make(map[string]interface{}),
+		level:  InfoLevel,
+	})
+
+	assert.Equal(t, InfoLevel, GetModuleLevel("database/sql"))
+	SetModuleLevel("database/sql", DebugLevel)
+	require.IsType(t, &ModuleLogger{}, GetLogger())
+	assert.Equal(t, DebugLevel, GetModuleLevel("database/sql/driver"))
+	assert.Equal(t, InfoLevel, GetLevel())
+
+	WithField(ModuleField, "database/sql").Debug("query")
+	WithField(ModuleField, "http").Debug("hidden")
+
+	ResetModuleLevel("database/sql")
+	assert.Equal(t, InfoLevel, GetModuleLevel("database/sql"))
+	assert.Equal(t, []string{"[DEBUG] [module=database/sql] query"}, buf.lines())
+}
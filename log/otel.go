@@ -0,0 +1,494 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package log
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	otellog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/global"
+)
+
+const (
+	// defaultOTelName 是 OTelLogger 默认使用的 instrumentation scope 名称。
+	defaultOTelName = "github.com/fsyyft-go/kit/log"
+)
+
+var (
+	// otelSeverityMap 定义了自定义日志级别到 OpenTelemetry 日志严重性的映射。
+	otelSeverityMap = map[Level]otellog.Severity{
+		DebugLevel: otellog.SeverityDebug,
+		InfoLevel:  otellog.SeverityInfo,
+		WarnLevel:  otellog.SeverityWarn,
+		ErrorLevel: otellog.SeverityError,
+		FatalLevel: otellog.SeverityFatal,
+	}
+)
+
+var (
+	_ ContextLogger = (*OTelLogger)(nil)
+)
+
+type (
+	// ContextLogger 是可以绑定 context.Context 的 Logger。
+	//
+	// 绑定后的 Logger 在记录日志时携带该 context，OTelLogger 会将其传给 OpenTelemetry，
+	// 由 SDK 从中提取 trace ID 与 span ID 实现日志与链路的关联。
+	ContextLogger interface {
+		Logger
+
+		// WithContext 返回绑定 ctx 的新 Logger 实例，原实例不受影响。
+		//
+		// 参数：
+		//   - ctx：记录日志时使用的上下文。
+		//
+		// 返回：
+		//   - Logger：绑定 ctx 的新 Logger 实例。
+		WithContext(ctx context.Context) Logger
+	}
+
+	// OTelLogger 实现了 Logger 接口，将日志作为 OpenTelemetry 日志记录发送给 LoggerProvider。
+	//
+	// 每条日志映射为一条 Record：级别映射为 Severity 与 SeverityText，消息作为 Body，
+	// WithField、WithFields 添加的字段转换为 Record 属性。通过 WithContext 绑定携带 span 的上下文后，
+	// SDK 会为日志记录关联 trace ID 与 span ID。配合 OTLP exporter 使用时，日志即可进入统一的 OTLP 管道。
+	OTelLogger struct {
+		// logger 是 OpenTelemetry 的日志实例。
+		logger otellog.Logger
+		// ctx 是记录日志时传给 OpenTelemetry 的上下文。
+		ctx context.Context
+		// fields 是当前实例累积的字段。
+		fields map[string]interface{}
+		// level 是当前实例的日志级别。
+		level Level
+	}
+
+	// OTelLoggerOptions 包含了 OTelLogger 的所有配置选项。
+	OTelLoggerOptions struct {
+		// Provider 指定创建 OpenTelemetry Logger 的 LoggerProvider。nil 表示使用全局 LoggerProvider。
+		Provider otellog.LoggerProvider
+		// Name 指定 instrumentation scope 名称。
+		Name string
+		// Version 指定 instrumentation scope 版本，空字符串表示不设置。
+		Version string
+		// Level 指定日志过滤级别。
+		Level Level
+	}
+
+	// OTelOption 定义了 OTelLogger 的配置选项函数类型。
+	OTelOption func(*OTelLoggerOptions)
+)
+
+// WithOTelProvider 设置 OTelLogger 使用的 LoggerProvider。
+//
+// 参数：
+//   - provider：OpenTelemetry LoggerProvider，通常为配置了 OTLP exporter 的 SDK LoggerProvider；
+//     nil 表示使用 go.opentelemetry.io/otel/log/global 中注册的全局 LoggerProvider。
+//
+// 返回：
+//   - OTelOption：返回一个配置选项函数。
+func WithOTelProvider(provider otellog.LoggerProvider) OTelOption {
+	return func(o *OTelLoggerOptions) {
+		o.Provider = provider
+	}
+}
+
+// WithOTelName 设置 OTelLogger 的 instrumentation scope 名称。
+//
+// 参数：
+//   - name：instrumentation scope 名称，空字符串表示使用默认名称。
+//
+// 返回：
+//   - OTelOption：返回一个配置选项函数。
+func WithOTelName(name string) OTelOption {
+	return func(o *OTelLoggerOptions) {
+		o.Name = name
+	}
+}
+
+// WithOTelVersion 设置 OTelLogger 的 instrumentation scope 版本。
+//
+// 参数：
+//   - version：instrumentation scope 版本。
+//
+// 返回：
+//   - OTelOption：返回一个配置选项函数。
+func WithOTelVersion(version string) OTelOption {
+	return func(o *OTelLoggerOptions) {
+		o.Version = version
+	}
+}
+
+// WithOTelLevel 设置 OTelLogger 的日志级别。
+//
+// 参数：
+//   - level：日志级别，低于该级别的日志不会发送。
+//
+// 返回：
+//   - OTelOption：返回一个配置选项函数。
+func WithOTelLevel(level Level) OTelOption {
+	return func(o *OTelLoggerOptions) {
+		o.Level = level
+	}
+}
+
+// NewOTelLogger 创建一个新的 OTelLogger 实例。
+//
+// 未设置 WithOTelProvider 时使用全局 LoggerProvider；全局 LoggerProvider 未注册前日志会被丢弃，
+// 注册后已创建的实例会自动转发到新的 LoggerProvider。
+//
+// 参数：
+//   - opts：可选的配置选项列表。
+//
+// 返回：
+//   - *OTelLogger：返回创建的日志实例。
+func NewOTelLogger(opts ...OTelOption) *OTelLogger {
+	options := OTelLoggerOptions{
+		Name:  defaultOTelName,
+		Level: InfoLevel,
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	provider := options.Provider
+	if nil == provider {
+		provider = global.GetLoggerProvider()
+	}
+	name := options.Name
+	if "" == name {
+		name = defaultOTelName
+	}
+	var loggerOpts []otellog.LoggerOption
+	if "" != options.Version {
+		loggerOpts = append(loggerOpts, otellog.WithInstrumentationVersion(options.Version))
+	}
+
+	return &OTelLogger{
+		logger: provider.Logger(name, loggerOpts...),
+		ctx:    context.Background(),
+		fields: make(map[string]interface{}),
+		level:  options.Level,
+	}
+}
+
+// WithContext 返回一个注入了指定上下文的 Logger。
+//
+// 如果 logger 实现了 ContextLogger，返回其 WithContext 的结果；否则原样返回 logger。
+//
+// 参数：
+//   - logger：日志实例。
+//   - ctx：记录日志时使用的上下文，通常携带当前 span。
+//
+// 返回：
+//   - Logger：绑定 ctx 的 Logger 实例。
+func WithContext(logger Logger, ctx context.Context) Logger {
+	if cl, ok := logger.(ContextLogger); ok {
+		return cl.WithContext(ctx)
+	}
+	return logger
+}
+
+// SetLevel 实现 Logger 接口的日志级别设置。
+//
+// 参数：
+//   - level：要设置的日志级别。
+func (l *OTelLogger) SetLevel(level Level) {
+	l.level = level
+}
+
+// GetLevel 实现 Logger 接口的日志级别获取。
+//
+// 返回值：
+//   - Level：当前的日志级别。
+func (l *OTelLogger) GetLevel() Level {
+	return l.level
+}
+
+// Debug 实现 Logger 接口的调试级别日志记录。
+//
+// 参数：
+//   - args：要记录的内容，支持任意类型的值。
+func (l *OTelLogger) Debug(args ...interface{}) {
+	l.log(DebugLevel, args...)
+}
+
+// Debugf 实现 Logger 接口的格式化调试级别日志记录。
+//
+// 参数：
+//   - format：格式化字符串。
+//   - args：格式化参数。
+func (l *OTelLogger) Debugf(format string, args ...interface{}) {
+	l.logf(DebugLevel, format, args...)
+}
+
+// Info 实现 Logger 接口的信息级别日志记录。
+//
+// 参数：
+//   - args：要记录的内容，支持任意类型的值。
+func (l *OTelLogger) Info(args ...interface{}) {
+	l.log(InfoLevel, args...)
+}
+
+// Infof 实现 Logger 接口的格式化信息级别日志记录。
+//
+// 参数：
+//   - format：格式化字符串。
+//   - args：格式化参数。
+func (l *OTelLogger) Infof(format string, args ...interface{}) {
+	l.logf(InfoLevel, format, args...)
+}
+
+// Warn 实现 Logger 接口的警告级别日志记录。
+//
+// 参数：
+//   - args：要记录的内容，支持任意类型的值。
+func (l *OTelLogger) Warn(args ...interface{}) {
+	l.log(WarnLevel, args...)
+}
+
+// Warnf 实现 Logger 接口的格式化警告级别日志记录。
+//
+// 参数：
+//   - format：格式化字符串。
+//   - args：格式化参数。
+func (l *OTelLogger) Warnf(format string, args ...interface{}) {
+	l.logf(WarnLevel, format, args...)
+}
+
+// Error 实现 Logger 接口的错误级别日志记录。
+//
+// 参数：
+//   - args：要记录的内容，支持任意类型的值。
+func (l *OTelLogger) Error(args ...interface{}) {
+	l.log(ErrorLevel, args...)
+}
+
+// Errorf 实现 Logger 接口的格式化错误级别日志记录。
+//
+// 参数：
+//   - format：格式化字符串。
+//   - args：格式化参数。
+func (l *OTelLogger) Errorf(format string, args ...interface{}) {
+	l.logf(ErrorLevel, format, args...)
+}
+
+// Fatal 实现 Logger 接口的致命错误级别日志记录。
+// 记录日志后执行 RegisterExitHook 注册的退出钩子，并以 SetExitCode 设置的状态码（默认为 1）退出；
+// 在 CaptureFatal 中调用时转换为错误而不退出。使用批量处理器时，应通过退出钩子关闭 LoggerProvider 以发送剩余日志。
+//
+// 参数：
+//   - args：要记录的内容，支持任意类型的值。
+func (l *OTelLogger) Fatal(args ...interface{}) {
+	l.log(FatalLevel, args...)
+	fatalExit(fmt.Sprint(args...), nil)
+}
+
+// Fatalf 实现 Logger 接口的格式化致命错误级别日志记录。
+// 记录日志后执行 RegisterExitHook 注册的退出钩子，并以 SetExitCode 设置的状态码（默认为 1）退出；
+// 在 CaptureFatal 中调用时转换为错误而不退出。使用批量处理器时，应通过退出钩子关闭 LoggerProvider 以发送剩余日志。
+//
+// 参数：
+//   - format：格式化字符串。
+//   - args：格式化参数。
+func (l *OTelLogger) Fatalf(format string, args ...interface{}) {
+	l.logf(FatalLevel, format, args...)
+	fatalExit(fmt.Sprintf(format, args...), nil)
+}
+
+// WithField 实现 Logger 接口的单字段添加方法。
+//
+// 参数：
+//   - key：字段名。
+//   - value：字段值。
+//
+// 返回值：
+//   - Logger：返回一个包含新字段的新 Logger 实例。
+func (l *OTelLogger) WithField(key string, value interface{}) Logger {
+	return l.WithFields(map[string]interface{}{key: value})
+}
+
+// WithFields 实现 Logger 接口的多字段添加方法。
+//
+// 参数：
+//   - fields：要添加的字段映射。
+//
+// 返回值：
+//   - Logger：返回一个包含所有字段的新 Logger 实例。
+func (l *OTelLogger) WithFields(fields map[string]interface{}) Logger {
+	newFields := make(map[string]interface{}, len(l.fields)+len(fields))
+	for k, v := range l.fields {
+		newFields[k] = v
+	}
+	for k, v := range fields {
+		newFields[k] = v
+	}
+	return &OTelLogger{
+		logger: l.logger,
+		ctx:    l.ctx,
+		fields: newFields,
+		level:  l.level,
+	}
+}
+
+// WithContext 实现 ContextLogger 接口的上下文绑定方法。
+//
+// 参数：
+//   - ctx：记录日志时使用的上下文；nil 表示 context.Background()。
+//
+// 返回值：
+//   - Logger：返回一个绑定 ctx 的新 Logger 实例。
+func (l *OTelLogger) WithContext(ctx context.Context) Logger {
+	if nil == ctx {
+		ctx = context.Background()
+	}
+	return &OTelLogger{
+		logger: l.logger,
+		ctx:    ctx,
+		fields: l.fields,
+		level:  l.level,
+	}
+}
+
+// enabled 检查指定级别的日志是否需要发送。
+//
+// 参数：
+//   - level：要检查的日志级别。
+//
+// 返回值：
+//   - bool：级别不低于当前级别且 OpenTelemetry Logger 接受该严重性时返回 true。
+func (l *OTelLogger) enabled(level Level) bool {
+	if level < l.level {
+		return false
+	}
+	return l.logger.Enabled(l.ctx, otellog.EnabledParameters{Severity: otelSeverityMap[level]})
+}
+
+// log 记录普通日志。
+//
+// 参数：
+//   - level：日志级别。
+//   - args：要记录的内容。
+func (l *OTelLogger) log(level Level, args ...interface{}) {
+	if !l.enabled(level) {
+		return
+	}
+	l.emit(level, fmt.Sprint(args...))
+}
+
+// logf 记录格式化日志。
+//
+// 参数：
+//   - level：日志级别。
+//   - format：格式化字符串。
+//   - args：格式化参数。
+func (l *OTelLogger) logf(level Level, format string, args ...interface{}) {
+	if !l.enabled(level) {
+		return
+	}
+	l.emit(level, fmt.Sprintf(format, args...))
+}
+
+// emit 构造 OpenTelemetry 日志记录并发送。
+//
+// 参数：
+//   - level：日志级别。
+//   - msg：日志消息。
+func (l *OTelLogger) emit(level Level, msg string) {
+	var record otellog.Record
+	record.SetTimestamp(time.Now())
+	record.SetSeverity(otelSeverityMap[level])
+	record.SetSeverityText(strings.ToUpper(level.String()))
+	record.SetBody(otellog.StringValue(msg))
+
+	// 按字段名排序，保证属性顺序稳定。
+	keys := make([]string, 0, len(l.fields))
+	for k := range l.fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		value := resolveLazy(l.fields[k])
+		if err, ok := value.(error); ok && nil == record.Err() {
+			record.SetErr(err)
+		}
+		record.AddAttributes(otellog.KeyValue{Key: k, Value: otelValue(value)})
+	}
+
+	l.logger.Emit(l.ctx, record)
+}
+
+// otelValue 将字段值转换为 OpenTelemetry 日志属性值。
+//
+// 参数：
+//   - value：字段值。
+//
+// 返回值：
+//   - otellog.Value：基础类型保留原类型，超出 int64 范围的无符号整数、错误、时间和其他类型转换为字符串。
+func otelValue(value interface{}) otellog.Value {
+	switch v := value.(type) {
+	case nil:
+		return otellog.Value{}
+	case string:
+		return otellog.StringValue(v)
+	case bool:
+		return otellog.BoolValue(v)
+	case int:
+		return otellog.IntValue(v)
+	case int8:
+		return otellog.Int64Value(int64(v))
+	case int16:
+		return otellog.Int64Value(int64(v))
+	case int32:
+		return otellog.Int64Value(int64(v))
+	case int64:
+		return otellog.Int64Value(v)
+	case uint8:
+		return otellog.Int64Value(int64(v))
+	case uint16:
+		return otellog.Int64Value(int64(v))
+	case uint32:
+		return otellog.Int64Value(int64(v))
+	case uint:
+		return otelUint64Value(uint64(v))
+	case uint64:
+		return otelUint64Value(v)
+	case float32:
+		return otellog.Float64Value(float64(v))
+	case float64:
+		return otellog.Float64Value(v)
+	case []byte:
+		return otellog.BytesValue(v)
+	case time.Duration:
+		return otellog.StringValue(v.String())
+	case time.Time:
+		return otellog.StringValue(v.Format(time.RFC3339Nano))
+	case error:
+		return otellog.StringValue(v.Error())
+	case fmt.Stringer:
+		return otellog.StringValue(v.String())
+	default:
+		return otellog.StringValue(fmt.Sprint(v))
+	}
+}
+
+// otelUint64Value 将无符号整数转换为 OpenTelemetry 日志属性值。
+//
+// 参数：
+//   - v：无符号整数。
+//
+// 返回值：
+//   - otellog.Value：不超过 math.MaxInt64 时为整数值，否则为十进制字符串。
+func otelUint64Value(v uint64) otellog.Value {
+	if v > math.MaxInt64 {
+		return otellog.StringValue(fmt.Sprint(v))
+	}
+	return otellog.Int64Value(int64(v))
+}
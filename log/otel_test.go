@@ -0,0 +1,301 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package log
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	otellog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/embedded"
+	"go.opentelemetry.io/otel/log/global"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type (
+	// otelRecorder 是记录所有日志记录的测试用 LoggerProvider。
+	otelRecorder struct {
+		embedded.LoggerProvider
+
+		// minSeverity 是 Enabled 接受的最低严重性。
+		minSeverity otellog.Severity
+
+		mu      sync.Mutex
+		records []otelRecorded
+	}
+
+	// otelRecorded 保存一次 Emit 调用的参数。
+	otelRecorded struct {
+		scope   string
+		version string
+		ctx     context.Context
+		record  otellog.Record
+	}
+
+	// otelRecordingLogger 是 otelRecorder 创建的 Logger。
+	otelRecordingLogger struct {
+		embedded.Logger
+
+		scope    string
+		version  string
+		recorder *otelRecorder
+	}
+)
+
+// Logger 实现 otellog.LoggerProvider 接口。
+func (r *otelRecorder) Logger(name string, options ...otellog.LoggerOption) otellog.Logger {
+	cfg := otellog.NewLoggerConfig(options...)
+	return &otelRecordingLogger{scope: name, version: cfg.InstrumentationVersion(), recorder: r}
+}
+
+// Records 返回已记录的日志记录副本。
+func (r *otelRecorder) Records() []otelRecorded {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]otelRecorded(nil), r.records...)
+}
+
+// Emit 实现 otellog.Logger 接口。
+func (l *otelRecordingLogger) Emit(ctx context.Context, record otellog.Record) {
+	l.recorder.mu.Lock()
+	defer l.recorder.mu.Unlock()
+	l.recorder.records = append(l.recorder.records, otelRecorded{
+		scope:   l.scope,
+		version: l.version,
+		ctx:     ctx,
+		record:  record.Clone(),
+	})
+}
+
+// Enabled 实现 otellog.Logger 接口。
+func (l *otelRecordingLogger) Enabled(_ context.Context, param otellog.EnabledParameters) bool {
+	return param.Severity >= l.recorder.minSeverity
+}
+
+// otelAttributes 将日志记录的属性转换为映射。
+//
+// 参数：
+//   - record: 日志记录。
+//
+// 返回：
+//   - map[string]otellog.Value: 属性名到属性值的映射。
+func otelAttributes(record otellog.Record) map[string]otellog.Value {
+	attrs := make(map[string]otellog.Value, record.AttributesLen())
+	record.WalkAttributes(func(kv otellog.KeyValue) bool {
+		attrs[kv.Key] = kv.Value
+		return true
+	})
+	return attrs
+}
+
+// TestOTelLogger_Record 验证级别、消息、字段和作用域到 OpenTelemetry 日志记录的映射。
+func TestOTelLogger_Record(t *testing.T) {
+	tests := []struct {
+		name         string
+		description  string
+		run          func(l Logger)
+		wantSeverity otellog.Severity
+		wantText     string
+		wantBody     string
+		wantAttrs    map[string]otellog.Value
+	}{
+		{
+			name:         "success/info",
+			description:  "验证 Info 映射为 SeverityInfo，参数按 fmt.Sprint 拼接为消息。",
+			run:          func(l Logger) { l.Info("hello ", 42) },
+			wantSeverity: otellog.SeverityInfo,
+			wantText:     "INFO",
+			wantBody:     "hello 42",
+			wantAttrs:    map[string]otellog.Value{},
+		},
+		{
+			name:         "success/debugf",
+			description:  "验证 Debugf 映射为 SeverityDebug 并格式化消息。",
+			run:          func(l Logger) { l.Debugf("id=%d", 7) },
+			wantSeverity: otellog.SeverityDebug,
+			wantText:     "DEBUG",
+			wantBody:     "id=7",
+			wantAttrs:    map[string]otellog.Value{},
+		},
+		{
+			name:        "success/fields",
+			description: "验证字段按类型转换为日志属性。",
+			run: func(l Logger) {
+				l.WithField("user", "alice").WithFields(map[string]interface{}{
+					"count":   3,
+					"ratio":   0.5,
+					"ok":      true,
+					"raw":     []byte("ab"),
+					"elapsed": 1500 * time.Millisecond,
+					"big":     uint64(math.MaxUint64),
+					"small":   uint32(9),
+					"nil":     nil,
+				}).Warnf("slow %s", "query")
+			},
+			wantSeverity: otellog.SeverityWarn,
+			wantText:     "WARN",
+			wantBody:     "slow query",
+			wantAttrs: map[string]otellog.Value{
+				"user":    otellog.StringValue("alice"),
+				"count":   otellog.IntValue(3),
+				"ratio":   otellog.Float64Value(0.5),
+				"ok":      otellog.BoolValue(true),
+				"raw":     otellog.BytesValue([]byte("ab")),
+				"elapsed": otellog.StringValue("1.5s"),
+				"big":     otellog.StringValue("18446744073709551615"),
+				"small":   otellog.Int64Value(9),
+				"nil":     {},
+			},
+		},
+		{
+			name:         "success/error-field",
+			description:  "验证 error 类型字段转换为字符串属性。",
+			run:          func(l Logger) { l.WithField("error", errors.New("boom")).Error("failed") },
+			wantSeverity: otellog.SeverityError,
+			wantText:     "ERROR",
+			wantBody:     "failed",
+			wantAttrs:    map[string]otellog.Value{"error": otellog.StringValue("boom")},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			recorder := &otelRecorder{}
+			logger := NewOTelLogger(
+				WithOTelProvider(recorder),
+				WithOTelName("kit/test"),
+				WithOTelVersion("v1.0.0"),
+				WithOTelLevel(DebugLevel),
+			)
+			tt.run(logger)
+
+			records := recorder.Records()
+			require.Len(t, records, 1)
+			got := records[0]
+			assert.Equal(t, "kit/test", got.scope)
+			assert.Equal(t, "v1.0.0", got.version)
+			assert.Equal(t, tt.wantSeverity, got.record.Severity())
+			assert.Equal(t, tt.wantText, got.record.SeverityText())
+			assert.Equal(t, tt.wantBody, got.record.Body().AsString())
+			assert.False(t, got.record.Timestamp().IsZero())
+
+			attrs := otelAttributes(got.record)
+			require.Len(t, attrs, len(tt.wantAttrs))
+			for k, want := range tt.wantAttrs {
+				assert.True(t, want.Equal(attrs[k]), "attribute %s: got %v, want %v", k, attrs[k], want)
+			}
+		})
+	}
+}
+
+// TestOTelLogger_Level 验证级别过滤以及 OpenTelemetry Logger 的 Enabled 判断。
+func TestOTelLogger_Level(t *testing.T) {
+	recorder := &otelRecorder{}
+	logger := NewOTelLogger(WithOTelProvider(recorder))
+	assert.Equal(t, InfoLevel, logger.GetLevel())
+
+	logger.Debug("hidden")
+	logger.Info("shown")
+	logger.SetLevel(ErrorLevel)
+	logger.Warn("hidden")
+	logger.Errorf("shown %d", 2)
+
+	recorder.minSeverity = otellog.SeverityFatal
+	logger.Error("rejected by provider")
+
+	records := recorder.Records()
+	require.Len(t, records, 2)
+	assert.Equal(t, "shown", records[0].record.Body().AsString())
+	assert.Equal(t, "shown 2", records[1].record.Body().AsString())
+	assert.Equal(t, defaultOTelName, records[0].scope)
+	assert.Empty(t, records[0].version)
+}
+
+// TestOTelLogger_Context 验证绑定的上下文传递给 Emit，用于关联 trace 与 span。
+func TestOTelLogger_Context(t *testing.T) {
+	recorder := &otelRecorder{}
+	logger := NewOTelLogger(WithOTelProvider(recorder))
+
+	spanCtx := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1, 2, 3},
+		SpanID:     trace.SpanID{4, 5, 6},
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), spanCtx)
+
+	logger.Info("no span")
+	WithContext(logger, ctx).WithField("k", "v").Info("with span")
+	logger.WithContext(nil).Info("nil context") //nolint:staticcheck // 验证 nil 上下文被替换为 Background。
+
+	records := recorder.Records()
+	require.Len(t, records, 3)
+	assert.False(t, trace.SpanContextFromContext(records[0].ctx).IsValid())
+	assert.Equal(t, spanCtx, trace.SpanContextFromContext(records[1].ctx))
+	assert.Equal(t, otellog.StringValue("v"), otelAttributes(records[1].record)["k"])
+	assert.NotNil(t, records[2].ctx)
+
+	// 未实现 ContextLogger 的 Logger 原样返回。
+	std, err := NewStdLogger("")
+	require.NoError(t, err)
+	assert.Same(t, std, WithContext(std, ctx))
+}
+
+// TestOTelLogger_Fatal 验证 Fatal 发送 SeverityFatal 日志后进入退出流程。
+func TestOTelLogger_Fatal(t *testing.T) {
+	recorder := &otelRecorder{}
+	logger := NewOTelLogger(WithOTelProvider(recorder))
+
+	err := CaptureFatal(func() { logger.Fatal("bye") })
+	require.Error(t, err)
+	err = CaptureFatal(func() { logger.Fatalf("bye %d", 2) })
+	require.Error(t, err)
+
+	records := recorder.Records()
+	require.Len(t, records, 2)
+	assert.Equal(t, otellog.SeverityFatal, records[0].record.Severity())
+	assert.Equal(t, "FATAL", records[0].record.SeverityText())
+	assert.Equal(t, "bye 2", records[1].record.Body().AsString())
+}
+
+// TestNewLogger_OTel 验证 LogTypeOTel 使用全局 LoggerProvider，且上下文可穿透去重与模块包装。
+func TestNewLogger_OTel(t *testing.T) {
+	recorder := &otelRecorder{}
+	global.SetLoggerProvider(recorder)
+
+	logger, err := NewLogger(
+		WithLogType(LogTypeOTel),
+		WithLevel(DebugLevel),
+		WithDedup(time.Minute),
+		WithModuleLevels(map[string]Level{"db": WarnLevel}),
+	)
+	require.NoError(t, err)
+
+	spanCtx := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{9},
+		SpanID:  trace.SpanID{8},
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), spanCtx)
+
+	WithContext(logger, ctx).Debug("traced")
+	WithContext(logger.WithField(ModuleField, "db"), ctx).Info("filtered")
+	WithContext(logger.WithField(ModuleField, "db"), ctx).Warn("kept")
+
+	records := recorder.Records()
+	require.Len(t, records, 2)
+	assert.Equal(t, "traced", records[0].record.Body().AsString())
+	assert.Equal(t, spanCtx, trace.SpanContextFromContext(records[0].ctx))
+	assert.Equal(t, "kept", records[1].record.Body().AsString())
+	assert.Equal(t, otellog.StringValue("db"), otelAttributes(records[1].record)[ModuleField])
+	assert.Equal(t, spanCtx, trace.SpanContextFromContext(records[1].ctx))
+}
@@ -0,0 +1,364 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package log
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	// OutputJournald 是写入 systemd journald 的输出目标，可写作 journald:标识符 指定 SYSLOG_IDENTIFIER。
+	OutputJournald = "journald"
+	// OutputEventLog 是写入 Windows 事件日志的输出目标，可写作 eventlog:事件源 指定事件源名称。
+	OutputEventLog = "eventlog"
+)
+
+var (
+	// ErrSinkUnsupported 表示当前平台不支持该输出目标，例如在非 Windows 平台使用 OutputEventLog。
+	//
+	// 调用方可以使用 errors.Is 判断该错误。
+	ErrSinkUnsupported = errors.New("当前平台不支持该日志输出目标。")
+)
+
+var (
+	_ Logger = (*SinkLogger)(nil)
+
+	// eventLogIDMap 定义了自定义日志级别到 Windows 事件 ID 的映射，便于在事件查看器中按级别筛选。
+	eventLogIDMap = map[Level]uint32{
+		DebugLevel: 1,
+		InfoLevel:  2,
+		WarnLevel:  3,
+		ErrorLevel: 4,
+		FatalLevel: 5,
+	}
+)
+
+type (
+	// sink 是平台原生日志系统的写入端。
+	sink interface {
+		// write 写入一条日志。
+		//
+		// 参数：
+		//   - level：日志级别。
+		//   - msg：日志消息。
+		//   - fields：已解析延迟求值的结构化字段，按名称排序。
+		//
+		// 返回：
+		//   - error：写入失败时返回错误。
+		write(level Level, msg string, fields []sinkField) error
+
+		// close 释放写入端持有的连接或句柄。
+		//
+		// 返回：
+		//   - error：释放失败时返回错误。
+		close() error
+	}
+
+	// sinkField 是写入平台日志系统的一个结构化字段。
+	sinkField struct {
+		key   string
+		value interface{}
+	}
+
+	// sinkShared 是 SinkLogger 及其 WithField 派生实例共享的状态。
+	sinkShared struct {
+		// sink 是平台日志系统的写入端。
+		sink sink
+		// closeOnce 保证写入端只释放一次。
+		closeOnce sync.Once
+		// closeErr 是释放写入端返回的错误。
+		closeErr error
+	}
+
+	// SinkLogger 实现了 Logger 接口，将日志写入 journald、Windows 事件日志等平台原生日志系统。
+	//
+	// 适用于部署在容器平台之外、无法依赖标准输出采集日志的服务。写入失败时日志会以文本形式输出到标准错误，
+	// 避免丢失。派生实例共享同一个写入端，任一实例调用 Close 后全部实例停止写入平台日志系统。
+	SinkLogger struct {
+		// shared 是共享的写入端。
+		shared *sinkShared
+		// fields 是当前实例累积的字段。
+		fields map[string]interface{}
+		// level 是当前实例的日志级别。
+		level Level
+	}
+)
+
+// newSinkLogger 使用写入端创建 SinkLogger。
+//
+// 参数：
+//   - s：平台日志系统的写入端。
+//
+// 返回：
+//   - *SinkLogger：级别为 InfoLevel 的日志实例。
+func newSinkLogger(s sink) *SinkLogger {
+	return &SinkLogger{
+		shared: &sinkShared{sink: s},
+		fields: make(map[string]interface{}),
+		level:  InfoLevel,
+	}
+}
+
+// parseSinkTarget 解析 WithOutput 设置的平台日志输出目标。
+//
+// 参数：
+//   - output：输出目标，形如 journald、journald:myapp、eventlog 或 eventlog:MyService。
+//
+// 返回：
+//   - string：输出目标类型 OutputJournald 或 OutputEventLog；不是平台日志输出目标时为空字符串。
+//   - string：冒号后的名称；未指定时为当前可执行文件的名称。
+func parseSinkTarget(output string) (string, string) {
+	target, name, _ := strings.Cut(output, ":")
+	if OutputJournald != target && OutputEventLog != target {
+		return "", ""
+	}
+	if "" == name {
+		name = filepath.Base(os.Args[0])
+	}
+	return target, name
+}
+
+// newSinkTarget 根据 WithOutput 设置的输出目标创建平台日志实例。
+//
+// 参数：
+//   - output：输出目标。
+//
+// 返回：
+//   - Logger：平台日志实例；output 不是平台日志输出目标时为 nil。
+//   - error：连接平台日志系统失败或当前平台不支持时返回错误。
+func newSinkTarget(output string) (Logger, error) {
+	target, name := parseSinkTarget(output)
+	switch target {
+	case OutputJournald:
+		return NewJournaldLogger(WithJournaldIdentifier(name))
+	case OutputEventLog:
+		return NewEventLogLogger(name)
+	default:
+		return nil, nil
+	}
+}
+
+// Close 释放写入端持有的连接或句柄。
+//
+// 重复调用 Close 返回第一次调用的结果；关闭后的日志输出到标准错误。
+//
+// 返回值：
+//   - error：释放失败时返回错误。
+func (l *SinkLogger) Close() error {
+	l.shared.closeOnce.Do(func() {
+		l.shared.closeErr = l.shared.sink.close()
+	})
+	return l.shared.closeErr
+}
+
+// SetLevel 实现 Logger 接口的日志级别设置。
+//
+// 参数：
+//   - level：要设置的日志级别。
+func (l *SinkLogger) SetLevel(level Level) {
+	l.level = level
+}
+
+// GetLevel 实现 Logger 接口的日志级别获取。
+//
+// 返回值：
+//   - Level：当前的日志级别。
+func (l *SinkLogger) GetLevel() Level {
+	return l.level
+}
+
+// Debug 实现 Logger 接口的调试级别日志记录。
+//
+// 参数：
+//   - args：要记录的内容，支持任意类型的值。
+func (l *SinkLogger) Debug(args ...interface{}) {
+	l.log(DebugLevel, args...)
+}
+
+// Debugf 实现 Logger 接口的格式化调试级别日志记录。
+//
+// 参数：
+//   - format：格式化字符串。
+//   - args：格式化参数。
+func (l *SinkLogger) Debugf(format string, args ...interface{}) {
+	l.logf(DebugLevel, format, args...)
+}
+
+// Info 实现 Logger 接口的信息级别日志记录。
+//
+// 参数：
+//   - args：要记录的内容，支持任意类型的值。
+func (l *SinkLogger) Info(args ...interface{}) {
+	l.log(InfoLevel, args...)
+}
+
+// Infof 实现 Logger 接口的格式化信息级别日志记录。
+//
+// 参数：
+//   - format：格式化字符串。
+//   - args：格式化参数。
+func (l *SinkLogger) Infof(format string, args ...interface{}) {
+	l.logf(InfoLevel, format, args...)
+}
+
+// Warn 实现 Logger 接口的警告级别日志记录。
+//
+// 参数：
+//   - args：要记录的内容，支持任意类型的值。
+func (l *SinkLogger) Warn(args ...interface{}) {
+	l.log(WarnLevel, args...)
+}
+
+// Warnf 实现 Logger 接口的格式化警告级别日志记录。
+//
+// 参数：
+//   - format：格式化字符串。
+//   - args：格式化参数。
+func (l *SinkLogger) Warnf(format string, args ...interface{}) {
+	l.logf(WarnLevel, format, args...)
+}
+
+// Error 实现 Logger 接口的错误级别日志记录。
+//
+// 参数：
+//   - args：要记录的内容，支持任意类型的值。
+func (l *SinkLogger) Error(args ...interface{}) {
+	l.log(ErrorLevel, args...)
+}
+
+// Errorf 实现 Logger 接口的格式化错误级别日志记录。
+//
+// 参数：
+//   - format：格式化字符串。
+//   - args：格式化参数。
+func (l *SinkLogger) Errorf(format string, args ...interface{}) {
+	l.logf(ErrorLevel, format, args...)
+}
+
+// Fatal 实现 Logger 接口的致命错误级别日志记录。
+// 记录日志后执行 RegisterExitHook 注册的退出钩子，并以 SetExitCode 设置的状态码（默认为 1）退出；
+// 在 CaptureFatal 中调用时转换为错误而不退出。
+//
+// 参数：
+//   - args：要记录的内容，支持任意类型的值。
+func (l *SinkLogger) Fatal(args ...interface{}) {
+	l.log(FatalLevel, args...)
+	fatalExit(fmt.Sprint(args...), nil)
+}
+
+// Fatalf 实现 Logger 接口的格式化致命错误级别日志记录。
+// 记录日志后执行 RegisterExitHook 注册的退出钩子，并以 SetExitCode 设置的状态码（默认为 1）退出；
+// 在 CaptureFatal 中调用时转换为错误而不退出。
+//
+// 参数：
+//   - format：格式化字符串。
+//   - args：格式化参数。
+func (l *SinkLogger) Fatalf(format string, args ...interface{}) {
+	l.logf(FatalLevel, format, args...)
+	fatalExit(fmt.Sprintf(format, args...), nil)
+}
+
+// WithField 实现 Logger 接口的单字段添加方法。
+//
+// 参数：
+//   - key：字段名。
+//   - value：字段值。
+//
+// 返回值：
+//   - Logger：返回一个包含新字段的新 Logger 实例。
+func (l *SinkLogger) WithField(key string, value interface{}) Logger {
+	return l.WithFields(map[string]interface{}{key: value})
+}
+
+// WithFields 实现 Logger 接口的多字段添加方法。
+//
+// 参数：
+//   - fields：要添加的字段映射。
+//
+// 返回值：
+//   - Logger：返回一个包含所有字段的新 Logger 实例，与原实例共享写入端。
+func (l *SinkLogger) WithFields(fields map[string]interface{}) Logger {
+	newFields := make(map[string]interface{}, len(l.fields)+len(fields))
+	for k, v := range l.fields {
+		newFields[k] = v
+	}
+	for k, v := range fields {
+		newFields[k] = v
+	}
+	return &SinkLogger{
+		shared: l.shared,
+		fields: newFields,
+		level:  l.level,
+	}
+}
+
+// log 记录普通日志。
+//
+// 参数：
+//   - level：日志级别。
+//   - args：要记录的内容。
+func (l *SinkLogger) log(level Level, args ...interface{}) {
+	if level < l.level {
+		return
+	}
+	l.emit(level, fmt.Sprint(args...))
+}
+
+// logf 记录格式化日志。
+//
+// 参数：
+//   - level：日志级别。
+//   - format：格式化字符串。
+//   - args：格式化参数。
+func (l *SinkLogger) logf(level Level, format string, args ...interface{}) {
+	if level < l.level {
+		return
+	}
+	l.emit(level, fmt.Sprintf(format, args...))
+}
+
+// emit 写入平台日志系统，写入失败（包括已关闭）时输出到标准错误。
+//
+// 参数：
+//   - level：日志级别。
+//   - msg：日志消息。
+func (l *SinkLogger) emit(level Level, msg string) {
+	// 按字段名排序，保证字段顺序稳定。
+	fields := make([]sinkField, 0, len(l.fields))
+	for k, v := range l.fields {
+		fields = append(fields, sinkField{key: k, value: resolveLazy(v)})
+	}
+	sort.Slice(fields, func(i, j int) bool { return fields[i].key < fields[j].key })
+
+	err := l.shared.sink.write(level, msg, fields)
+	if nil == err {
+		return
+	}
+	_, _ = fmt.Fprintf(os.Stderr, "%s %s%s (sink error: %v)\n", strings.ToUpper(level.String()), formatSinkFields(fields), msg, err)
+}
+
+// formatSinkFields 将字段格式化为 [k=v ...] 形式的文本。
+//
+// 参数：
+//   - fields：已排序的字段。
+//
+// 返回值：
+//   - string：字段文本，末尾带一个空格；没有字段时为空字符串。
+func formatSinkFields(fields []sinkField) string {
+	if 0 == len(fields) {
+		return ""
+	}
+	parts := make([]string, 0, len(fields))
+	for _, f := range fields {
+		parts = append(parts, fmt.Sprintf("%s=%v", f.key, f.value))
+	}
+	return "[" + strings.Join(parts, " ") + "] "
+}
@@ -0,0 +1,25 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+//go:build !windows
+
+package log
+
+import (
+	"fmt"
+)
+
+// NewEventLogLogger 创建一个写入 Windows 事件日志的日志实例。
+//
+// 非 Windows 平台没有事件日志，始终返回 ErrSinkUnsupported。
+//
+// 参数：
+//   - source：事件源名称。
+//
+// 返回值：
+//   - *SinkLogger：始终为 nil。
+//   - error：包装了 ErrSinkUnsupported 的错误。
+func NewEventLogLogger(source string) (*SinkLogger, error) {
+	return nil, fmt.Errorf("%w: %s:%s", ErrSinkUnsupported, OutputEventLog, source)
+}
@@ -0,0 +1,73 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+//go:build windows
+
+package log
+
+import (
+	"fmt"
+
+	"golang.org/x/sys/windows/svc/eventlog"
+)
+
+type (
+	// eventLogSink 将日志写入 Windows 事件日志。
+	eventLogSink struct {
+		// log 是已打开的事件源句柄。
+		log *eventlog.Log
+	}
+)
+
+// NewEventLogLogger 创建一个写入 Windows 事件日志的日志实例。
+//
+// 级别映射为事件类型：Debug、Info 写为信息，Warn 写为警告，Error、Fatal 写为错误；
+// 事件 ID 按级别区分（Debug=1、Info=2、Warn=3、Error=4、Fatal=5），字段以 [k=v ...] 形式附加在消息前。
+// 事件源需要预先注册，例如以管理员身份调用
+// eventlog.InstallAsEventCreate(source, eventlog.Error|eventlog.Warning|eventlog.Info)，
+// 未注册的事件源写入的事件在事件查看器中会缺少描述。
+//
+// 参数：
+//   - source：事件源名称。
+//
+// 返回值：
+//   - *SinkLogger：返回创建的日志实例，级别为 InfoLevel。
+//   - error：打开事件源失败时返回错误。
+func NewEventLogLogger(source string) (*SinkLogger, error) {
+	l, err := eventlog.Open(source)
+	if nil != err {
+		return nil, fmt.Errorf("打开 Windows 事件源 %s 失败：%w", source, err)
+	}
+	return newSinkLogger(&eventLogSink{log: l}), nil
+}
+
+// write 实现 sink 接口，按级别写入对应类型的事件。
+//
+// 参数：
+//   - level：日志级别。
+//   - msg：日志消息。
+//   - fields：已排序的结构化字段。
+//
+// 返回值：
+//   - error：写入失败时返回错误。
+func (s *eventLogSink) write(level Level, msg string, fields []sinkField) error {
+	eid := eventLogIDMap[level]
+	msg = formatSinkFields(fields) + msg
+	switch level {
+	case WarnLevel:
+		return s.log.Warning(eid, msg)
+	case ErrorLevel, FatalLevel:
+		return s.log.Error(eid, msg)
+	default:
+		return s.log.Info(eid, msg)
+	}
+}
+
+// close 实现 sink 接口，关闭事件源句柄。
+//
+// 返回值：
+//   - error：关闭失败时返回错误。
+func (s *eventLogSink) close() error {
+	return s.log.Close()
+}
@@ -0,0 +1,230 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package log
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// journaldFieldMaxLen 是 journald 字段名的最大长度。
+	journaldFieldMaxLen = 64
+)
+
+var (
+	// defaultJournaldSocket 是 journald 原生协议的默认套接字路径。
+	defaultJournaldSocket = "/run/systemd/journal/socket"
+
+	// journaldPriorityMap 定义了自定义日志级别到 syslog 优先级的映射。
+	journaldPriorityMap = map[Level]int{
+		DebugLevel: 7, // LOG_DEBUG
+		InfoLevel:  6, // LOG_INFO
+		WarnLevel:  4, // LOG_WARNING
+		ErrorLevel: 3, // LOG_ERR
+		FatalLevel: 2, // LOG_CRIT
+	}
+
+	// journaldReservedFields 是 SinkLogger 自行写入的 journald 字段，同名的结构化字段会加 F_ 前缀。
+	journaldReservedFields = map[string]bool{
+		"MESSAGE":           true,
+		"PRIORITY":          true,
+		"SYSLOG_IDENTIFIER": true,
+	}
+)
+
+type (
+	// JournaldOption 定义了 NewJournaldLogger 的配置选项函数类型。
+	JournaldOption func(*journaldOptions)
+
+	// journaldOptions 包含了 journald 写入端的配置。
+	journaldOptions struct {
+		// identifier 是写入 SYSLOG_IDENTIFIER 的标识符，为空时不写入。
+		identifier string
+		// socket 是 journald 原生协议的套接字路径。
+		socket string
+	}
+
+	// journaldSink 通过原生协议将日志写入 journald。
+	journaldSink struct {
+		// conn 是连接到 journald 套接字的数据报连接。
+		conn *net.UnixConn
+		// identifier 是写入 SYSLOG_IDENTIFIER 的标识符。
+		identifier string
+	}
+)
+
+// WithJournaldIdentifier 设置写入 SYSLOG_IDENTIFIER 的标识符，journalctl -t 按该标识符过滤。
+//
+// 参数：
+//   - identifier：标识符；空字符串表示不写入。
+//
+// 返回：
+//   - JournaldOption：返回一个配置选项函数。
+func WithJournaldIdentifier(identifier string) JournaldOption {
+	return func(o *journaldOptions) {
+		o.identifier = identifier
+	}
+}
+
+// WithJournaldSocket 设置 journald 原生协议的套接字路径。
+//
+// 参数：
+//   - socket：套接字路径；空字符串表示使用默认路径 /run/systemd/journal/socket。
+//
+// 返回：
+//   - JournaldOption：返回一个配置选项函数。
+func WithJournaldSocket(socket string) JournaldOption {
+	return func(o *journaldOptions) {
+		o.socket = socket
+	}
+}
+
+// NewJournaldLogger 创建一个写入 systemd journald 的日志实例。
+//
+// 日志通过 journald 原生协议发送：级别映射为 PRIORITY（Debug=7、Info=6、Warn=4、Error=3、Fatal=2），
+// 消息写入 MESSAGE，WithField、WithFields 添加的字段转换为大写的 journald 字段，名称中字母、数字以外的字符
+// 替换为下划线，例如 request_id 写入 REQUEST_ID、http.method 写入 HTTP_METHOD，可通过 journalctl REQUEST_ID=... 过滤。
+// 单条日志受套接字数据报大小限制，超出时写入失败并输出到标准错误。
+//
+// 参数：
+//   - opts：可选的配置选项列表。
+//
+// 返回值：
+//   - *SinkLogger：返回创建的日志实例，级别为 InfoLevel。
+//   - error：连接 journald 套接字失败时返回错误，常见原因是系统未运行 systemd。
+func NewJournaldLogger(opts ...JournaldOption) (*SinkLogger, error) {
+	options := journaldOptions{socket: defaultJournaldSocket}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if "" == options.socket {
+		options.socket = defaultJournaldSocket
+	}
+
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: options.socket, Net: "unixgram"})
+	if nil != err {
+		return nil, fmt.Errorf("连接 journald 失败：%w", err)
+	}
+	return newSinkLogger(&journaldSink{conn: conn, identifier: options.identifier}), nil
+}
+
+// write 实现 sink 接口，将一条日志编码为一个 journald 数据报发送。
+//
+// 参数：
+//   - level：日志级别。
+//   - msg：日志消息。
+//   - fields：已排序的结构化字段。
+//
+// 返回值：
+//   - error：发送失败时返回错误。
+func (s *journaldSink) write(level Level, msg string, fields []sinkField) error {
+	var buf bytes.Buffer
+	appendJournaldField(&buf, "MESSAGE", msg)
+	appendJournaldField(&buf, "PRIORITY", strconv.Itoa(journaldPriorityMap[level]))
+	if "" != s.identifier {
+		appendJournaldField(&buf, "SYSLOG_IDENTIFIER", s.identifier)
+	}
+	for _, f := range fields {
+		name := journaldFieldName(f.key)
+		if "" == name {
+			continue
+		}
+		appendJournaldField(&buf, name, journaldFieldValue(f.value))
+	}
+
+	_, err := s.conn.Write(buf.Bytes())
+	return err
+}
+
+// close 实现 sink 接口，关闭 journald 连接。
+//
+// 返回值：
+//   - error：关闭失败时返回错误。
+func (s *journaldSink) close() error {
+	return s.conn.Close()
+}
+
+// appendJournaldField 按 journald 原生协议追加一个字段。
+//
+// 不含换行的值编码为 NAME=value\n；含换行的值编码为 NAME\n、8 字节小端长度、值与 \n。
+//
+// 参数：
+//   - buf：数据报缓冲区。
+//   - name：合法的 journald 字段名。
+//   - value：字段值。
+func appendJournaldField(buf *bytes.Buffer, name, value string) {
+	buf.WriteString(name)
+	if !strings.Contains(value, "\n") {
+		buf.WriteByte('=')
+		buf.WriteString(value)
+		buf.WriteByte('\n')
+		return
+	}
+	buf.WriteByte('\n')
+	_ = binary.Write(buf, binary.LittleEndian, uint64(len(value)))
+	buf.WriteString(value)
+	buf.WriteByte('\n')
+}
+
+// journaldFieldName 将字段名转换为合法的 journald 字段名。
+//
+// journald 字段名只能包含大写字母、数字和下划线，不能以下划线或数字开头，最长 64 个字符；
+// 与 MESSAGE、PRIORITY、SYSLOG_IDENTIFIER 同名或以数字开头时加 F_ 前缀。
+//
+// 参数：
+//   - key：原始字段名。
+//
+// 返回值：
+//   - string：转换后的字段名；转换后为空时返回空字符串，调用方应跳过该字段。
+func journaldFieldName(key string) string {
+	var b strings.Builder
+	for _, r := range strings.ToUpper(key) {
+		if ('A' <= r && r <= 'Z') || ('0' <= r && r <= '9') {
+			b.WriteRune(r)
+		} else {
+			b.WriteByte('_')
+		}
+	}
+	name := strings.TrimLeft(b.String(), "_")
+	if "" == name {
+		return ""
+	}
+	if ('0' <= name[0] && name[0] <= '9') || journaldReservedFields[name] {
+		// 以数字开头的字段名会被 journald 丢弃，与内置字段同名时会覆盖消息或级别，加前缀保留该字段。
+		name = "F_" + name
+	}
+	if len(name) > journaldFieldMaxLen {
+		name = name[:journaldFieldMaxLen]
+	}
+	return name
+}
+
+// journaldFieldValue 将字段值转换为字符串。
+//
+// 参数：
+//   - value：字段值。
+//
+// 返回值：
+//   - string：时间使用 RFC3339Nano，其余使用 fmt 的默认格式。
+func journaldFieldValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case error:
+		return v.Error()
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,281 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package log
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// journaldServer 是接收 journald 原生协议数据报的测试服务端。
+type journaldServer struct {
+	conn *net.UnixConn
+	path string
+}
+
+// newJournaldServer 在临时目录创建 journald 测试套接字，测试结束后自动关闭。
+func newJournaldServer(t *testing.T) *journaldServer {
+	t.Helper()
+
+	if "windows" == runtime.GOOS {
+		t.Skip("windows 不支持 unixgram 套接字")
+	}
+	// 套接字路径有长度限制，不使用可能很长的 t.TempDir()。
+	dir, err := os.MkdirTemp("", "jd")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+
+	path := filepath.Join(dir, "socket")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return &journaldServer{conn: conn, path: path}
+}
+
+// receive 读取一个数据报并解析为字段映射。
+func (s *journaldServer) receive(t *testing.T) map[string]string {
+	t.Helper()
+
+	buf := make([]byte, 64<<10)
+	require.NoError(t, s.conn.SetReadDeadline(time.Now().Add(time.Second)))
+	n, err := s.conn.Read(buf)
+	require.NoError(t, err)
+
+	fields := make(map[string]string)
+	data := buf[:n]
+	for len(data) > 0 {
+		line := bytes.IndexByte(data, '\n')
+		require.GreaterOrEqual(t, line, 0)
+		if eq := bytes.IndexByte(data[:line], '='); eq >= 0 {
+			fields[string(data[:eq])] = string(data[eq+1 : line])
+			data = data[line+1:]
+			continue
+		}
+		name := string(data[:line])
+		data = data[line+1:]
+		size := binary.LittleEndian.Uint64(data[:8])
+		fields[name] = string(data[8 : 8+size])
+		data = data[8+size+1:]
+	}
+	return fields
+}
+
+// TestJournaldLogger 验证 journald 日志按原生协议发送消息、优先级、标识符与结构化字段。
+func TestJournaldLogger(t *testing.T) {
+	server := newJournaldServer(t)
+	logger, err := NewJournaldLogger(WithJournaldIdentifier("kit-test"), WithJournaldSocket(server.path))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = logger.Close() })
+	logger.SetLevel(DebugLevel)
+
+	tests := []struct {
+		name        string
+		description string
+		log         func()
+		want        map[string]string
+	}{
+		{
+			name:        "success/fields",
+			description: "验证字段名转换为大写 journald 字段，非法字符替换为下划线。",
+			log: func() {
+				logger.WithFields(map[string]interface{}{
+					"request_id":  "r-1",
+					"http.method": "GET",
+					"err":         errors.New("boom"),
+				}).Info("hello")
+			},
+			want: map[string]string{
+				"MESSAGE":           "hello",
+				"PRIORITY":          "6",
+				"SYSLOG_IDENTIFIER": "kit-test",
+				"REQUEST_ID":        "r-1",
+				"HTTP_METHOD":       "GET",
+				"ERR":               "boom",
+			},
+		},
+		{
+			name:        "success/multiline",
+			description: "验证含换行的消息使用二进制长度编码。",
+			log:         func() { logger.Errorf("line1\nline%d", 2) },
+			want: map[string]string{
+				"MESSAGE":           "line1\nline2",
+				"PRIORITY":          "3",
+				"SYSLOG_IDENTIFIER": "kit-test",
+			},
+		},
+		{
+			name:        "boundary/reserved-and-invalid-names",
+			description: "验证与内置字段同名或以数字开头的字段加 F_ 前缀，转换后为空的字段被跳过。",
+			log: func() {
+				logger.WithFields(map[string]interface{}{
+					"message": "override",
+					"1st":     1,
+					"__":      "dropped",
+				}).Warn("kept")
+			},
+			want: map[string]string{
+				"MESSAGE":           "kept",
+				"PRIORITY":          "4",
+				"SYSLOG_IDENTIFIER": "kit-test",
+				"F_MESSAGE":         "override",
+				"F_1ST":             "1",
+			},
+		},
+		{
+			name:        "success/debug-priority",
+			description: "验证 Debug 级别映射为 PRIORITY 7，延迟求值的字段在发送时解析。",
+			log: func() {
+				logger.WithField("lazy", Lazy(func() interface{} { return "resolved" })).Debug("dbg")
+			},
+			want: map[string]string{
+				"MESSAGE":           "dbg",
+				"PRIORITY":          "7",
+				"SYSLOG_IDENTIFIER": "kit-test",
+				"LAZY":              "resolved",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			tt.log()
+			assert.Equal(t, tt.want, server.receive(t))
+		})
+	}
+}
+
+// TestJournaldLogger_LevelAndClose 验证级别过滤，以及关闭后日志输出到标准错误。
+func TestJournaldLogger_LevelAndClose(t *testing.T) {
+	server := newJournaldServer(t)
+	logger, err := NewJournaldLogger(WithJournaldSocket(server.path))
+	require.NoError(t, err)
+	assert.Equal(t, InfoLevel, logger.GetLevel())
+
+	logger.Debug("filtered")
+	logger.Info("kept")
+	fields := server.receive(t)
+	assert.Equal(t, "kept", fields["MESSAGE"])
+	assert.NotContains(t, fields, "SYSLOG_IDENTIFIER")
+
+	require.NoError(t, logger.Close())
+	require.NoError(t, logger.Close(), "重复关闭返回第一次的结果")
+
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	stderr := os.Stderr
+	os.Stderr = w
+	logger.WithField("k", "v").Warn("after close")
+	os.Stderr = stderr
+	require.NoError(t, w.Close())
+	out, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(out), "WARN [k=v] after close (sink error: "), string(out))
+}
+
+// TestJournaldLogger_Fatal 验证 Fatal 以 PRIORITY 2 发送后进入退出流程。
+func TestJournaldLogger_Fatal(t *testing.T) {
+	server := newJournaldServer(t)
+	logger, err := NewJournaldLogger(WithJournaldSocket(server.path))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = logger.Close() })
+
+	require.Error(t, CaptureFatal(func() { logger.Fatal("bye") }))
+	fields := server.receive(t)
+	assert.Equal(t, "bye", fields["MESSAGE"])
+	assert.Equal(t, "2", fields["PRIORITY"])
+}
+
+// TestNewLogger_Sink 验证 WithOutput 的平台日志输出目标。
+func TestNewLogger_Sink(t *testing.T) {
+	server := newJournaldServer(t)
+	socket := defaultJournaldSocket
+	defaultJournaldSocket = server.path
+	t.Cleanup(func() { defaultJournaldSocket = socket })
+
+	tests := []struct {
+		name        string
+		description string
+		opts        []Option
+		wantErr     error
+		wantIdent   string
+	}{
+		{
+			name:        "success/std-journald",
+			description: "验证 LogTypeStd 的 journald:标识符 输出目标写入 journald。",
+			opts:        []Option{WithOutput("journald:app"), WithLevel(WarnLevel)},
+			wantIdent:   "app",
+		},
+		{
+			name:        "success/logrus-journald-default-identifier",
+			description: "验证 LogTypeLogrus 同样支持 journald，省略标识符时使用可执行文件名。",
+			opts:        []Option{WithLogType(LogTypeLogrus), WithOutput(OutputJournald), WithLevel(WarnLevel)},
+			wantIdent:   filepath.Base(os.Args[0]),
+		},
+		{
+			name:        "error/eventlog-unsupported",
+			description: "验证非 Windows 平台使用 eventlog 返回 ErrSinkUnsupported。",
+			opts:        []Option{WithOutput("eventlog:App")},
+			wantErr:     ErrSinkUnsupported,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			logger, err := NewLogger(tt.opts...)
+			if nil != tt.wantErr {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			logger.Info("filtered")
+			logger.Warn("kept")
+			fields := server.receive(t)
+			assert.Equal(t, "kept", fields["MESSAGE"])
+			assert.Equal(t, tt.wantIdent, fields["SYSLOG_IDENTIFIER"])
+		})
+	}
+}
+
+// TestParseSinkTarget 验证平台日志输出目标的解析。
+func TestParseSinkTarget(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		output      string
+		wantTarget  string
+		wantName    string
+	}{
+		{name: "success/journald-name", description: "验证 journald:名称 解析出标识符。", output: "journald:app", wantTarget: OutputJournald, wantName: "app"},
+		{name: "success/eventlog-name", description: "验证 eventlog:名称 解析出事件源。", output: "eventlog:My Service", wantTarget: OutputEventLog, wantName: "My Service"},
+		{name: "boundary/file-path", description: "验证普通文件路径不是平台日志输出目标。", output: "/var/log/journald.log"},
+		{name: "boundary/empty", description: "验证空字符串不是平台日志输出目标。", output: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			target, name := parseSinkTarget(tt.output)
+			assert.Equal(t, tt.wantTarget, target)
+			assert.Equal(t, tt.wantName, name)
+		})
+	}
+}
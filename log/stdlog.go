@@ -210,6 +210,7 @@ func (l *StdLogger) Warnf(format string, args ...interface{}) {
 //   - args：要记录的内容，支持任意类型的值。
 func (l *StdLogger) Error(args ...interface{}) {
 	l.log(ErrorLevel, "[ERROR]", args...)
+	syncOutput(l.logger.Writer())
 }
 
 // Errorf 实现 Logger 接口的格式化错误级别日志记录。
@@ -219,27 +220,32 @@ func (l *StdLogger) Error(args ...interface{}) {
 //   - args：格式化参数。
 func (l *StdLogger) Errorf(format string, args ...interface{}) {
 	l.logf(ErrorLevel, "[ERROR]", format, args...)
+	syncOutput(l.logger.Writer())
 }
 
 // Fatal 实现 Logger 接口的致命错误级别日志记录。
-// 记录日志后会导致程序以状态码 1 退出。
+// 记录日志后执行 RegisterExitHook 注册的退出钩子，并以 SetExitCode 设置的状态码（默认为 1）退出；
+// 在 CaptureFatal 中调用时转换为错误而不退出。
 //
 // 参数：
 //   - args：要记录的内容，支持任意类型的值。
 func (l *StdLogger) Fatal(args ...interface{}) {
 	l.log(FatalLevel, "[FATAL]", args...)
-	os.Exit(1)
+	syncOutput(l.logger.Writer())
+	fatalExit(fmt.Sprint(args...), nil)
 }
 
 // Fatalf 实现 Logger 接口的格式化致命错误级别日志记录。
-// 记录日志后会导致程序以状态码 1 退出。
+// 记录日志后执行 RegisterExitHook 注册的退出钩子，并以 SetExitCode 设置的状态码（默认为 1）退出；
+// 在 CaptureFatal 中调用时转换为错误而不退出。
 //
 // 参数：
 //   - format：格式化字符串。
 //   - args：格式化参数。
 func (l *StdLogger) Fatalf(format string, args ...interface{}) {
 	l.logf(FatalLevel, "[FATAL]", format, args...)
-	os.Exit(1)
+	syncOutput(l.logger.Writer())
+	fatalExit(fmt.Sprintf(format, args...), nil)
 }
 
 // WithField 实现 Logger 接口的单字段添加方法。
@@ -8,6 +8,7 @@ import (
 	"bytes"
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"io"
 	"net"
@@ -17,6 +18,7 @@ import (
 	"sync"
 	"time"
 
+	kitconfig "github.com/fsyyft-go/kit/config"
 	kitlog "github.com/fsyyft-go/kit/log"
 )
 
@@ -32,64 +34,83 @@ type (
 		// 参数：
 		//   - ctx: 传递给 HookContext 的上下文。
 		//   - req: 待发送的 HTTP 请求对象。
+		//   - opts: 仅作用于本次请求的配置覆盖，例如 WithTimeoutOverride 和 WithoutHooks。
 		//
 		// 返回：
 		//   - *http.Response: HTTP 响应对象；非 nil 时调用方负责关闭 Body。
 		//   - error: Hook Before 失败或底层 HTTP 请求失败时返回错误；Hook After 的错误会被忽略。
-		Do(ctx context.Context, req *http.Request) (*http.Response, error)
+		Do(ctx context.Context, req *http.Request, opts ...RequestOption) (*http.Response, error)
 		// Head 发送 HTTP HEAD 请求。
 		//
 		// 参数：
 		//   - ctx: 请求上下文，用于创建 HTTP 请求并控制其生命周期。
 		//   - url: 请求地址。
+		//   - opts: 仅作用于本次请求的配置覆盖，例如 WithTimeoutOverride 和 WithoutHooks。
 		//
 		// 返回：
 		//   - *http.Response: HTTP 响应对象；非 nil 时调用方负责关闭 Body。
 		//   - error: 请求创建失败、Hook Before 失败或底层 HTTP 请求失败时返回错误。
-		Head(ctx context.Context, url string) (*http.Response, error)
+		Head(ctx context.Context, url string, opts ...RequestOption) (*http.Response, error)
 		// Get 发送 HTTP GET 请求。
 		//
 		// 参数：
 		//   - ctx: 请求上下文，用于创建 HTTP 请求并控制其生命周期。
 		//   - url: 请求地址。
+		//   - opts: 仅作用于本次请求的配置覆盖，例如 WithTimeoutOverride 和 WithoutHooks。
 		//
 		// 返回：
 		//   - *http.Response: HTTP 响应对象；非 nil 时调用方负责关闭 Body。
 		//   - error: 请求创建失败、Hook Before 失败或底层 HTTP 请求失败时返回错误。
-		Get(ctx context.Context, url string) (*http.Response, error)
+		Get(ctx context.Context, url string, opts ...RequestOption) (*http.Response, error)
 		// Post 发送 HTTP POST 请求。
 		//
 		// 参数：
 		//   - ctx: 请求上下文，用于创建 HTTP 请求并控制其生命周期。
 		//   - url: 请求地址。
 		//   - body: 请求体；可为 nil。
+		//   - opts: 仅作用于本次请求的配置覆盖，例如 WithTimeoutOverride 和 WithoutHooks。
 		//
 		// 返回：
 		//   - *http.Response: HTTP 响应对象；非 nil 时调用方负责关闭 Body。
 		//   - error: 请求创建失败、Hook Before 失败或底层 HTTP 请求失败时返回错误。
-		Post(ctx context.Context, url string, body io.Reader) (*http.Response, error)
+		Post(ctx context.Context, url string, body io.Reader, opts ...RequestOption) (*http.Response, error)
 		// PostForm 发送 application/x-www-form-urlencoded 表单 POST 请求。
 		//
 		// 参数：
 		//   - ctx: 请求上下文，用于创建 HTTP 请求并控制其生命周期。
 		//   - url: 请求地址。
 		//   - data: 表单数据，会通过 url.Values.Encode 编码到请求体。
+		//   - opts: 仅作用于本次请求的配置覆盖，例如 WithTimeoutOverride 和 WithoutHooks。
 		//
 		// 返回：
 		//   - *http.Response: HTTP 响应对象；非 nil 时调用方负责关闭 Body。
 		//   - error: 请求创建失败、Hook Before 失败或底层 HTTP 请求失败时返回错误。
-		PostForm(ctx context.Context, url string, data url.Values) (*http.Response, error)
+		PostForm(ctx context.Context, url string, data url.Values, opts ...RequestOption) (*http.Response, error)
 		// PostJSON 发送 application/json POST 请求。
 		//
 		// 参数：
 		//   - ctx: 请求上下文，用于创建 HTTP 请求并控制其生命周期。
 		//   - url: 请求地址。
 		//   - data: 待编码为 JSON 的请求体数据。
+		//   - opts: 仅作用于本次请求的配置覆盖，例如 WithTimeoutOverride 和 WithoutHooks。
 		//
 		// 返回：
 		//   - *http.Response: HTTP 响应对象；非 nil 时调用方负责关闭 Body。
 		//   - error: JSON 编码失败、请求创建失败、Hook Before 失败或底层 HTTP 请求失败时返回错误。
-		PostJSON(ctx context.Context, url string, data any) (*http.Response, error)
+		PostJSON(ctx context.Context, url string, data any, opts ...RequestOption) (*http.Response, error)
+		// GetNDJSON 发送 HTTP GET 请求并逐行消费 NDJSON 响应。
+		//
+		// 默认取消客户端的总超时，流的生命周期由 ctx 控制；响应体在返回前关闭。
+		//
+		// 参数：
+		//   - ctx: 请求上下文，取消后停止读取。
+		//   - url: 请求地址。
+		//   - fn: 每个 JSON 值的回调；返回错误时停止读取并原样返回该错误。
+		//   - opts: 仅作用于本次请求的配置覆盖，例如 WithTimeoutOverride 和 WithoutHooks。
+		//
+		// 返回：
+		//   - error: 请求失败、响应状态码不是 2xx、某行不是合法 JSON 或 fn 返回错误时返回错误。
+		GetNDJSON(ctx context.Context, url string, fn func(json.RawMessage) error, opts ...RequestOption) error
 	}
 
 	// client 为 HTTP 客户端的具体实现。
@@ -99,16 +120,33 @@ type (
 		name                string                                // 客户端名称。
 		timeout             time.Duration                         // 超时时间。
 		traceEnable         bool                                  // 开启追踪。
+		traceRegistry       *kitconfig.DebugRegistry              // 追踪采样使用的调试开关注册表。
 		proxy               func(*http.Request) (*url.URL, error) // 网络代理配置。
 		maxConnsPerHost     int                                   // 每主机最大连接数。
 		maxIdleConnsPerHost int                                   // 每主机最大空闲连接数。
 		maxIdleConns        int                                   // 全局最大空闲连接数。
+		downloadRate        int64                                 // 下载限速，单位为字节每秒。
+		uploadRate          int64                                 // 上传限速，单位为字节每秒。
+		compressThreshold   int64                                 // 触发请求体压缩的最小字节数。
+		maxResponseBytes    int64                                 // 响应体允许读取的最大字节数。
+
+		decoders            map[string]Decoder  // 响应体解码器。
+		decoderOrder        []string            // 解码器注册顺序。
+		compressionReporter CompressionReporter // 压缩大小报告回调。
 
 		transport *http.Transport // 传输层配置。
 
-		hook     Hook          // 钩子实现。
-		logSlow  time.Duration // 慢请求阈值。
-		logError bool          // 是否记录错误。
+		tlsCertificates []tls.Certificate // mTLS 客户端证书。
+		rootCAs         *x509.CertPool    // 校验服务端证书的根证书池。
+		serverName      string            // TLS 握手使用的服务端名称。
+		tlsErr          error             // 证书加载错误，非 nil 时所有请求返回该错误。
+
+		hook        Hook          // 钩子实现。
+		signHooks   []Hook        // 请求签名钩子，追加在其他钩子之后执行。
+		debugHooks  []Hook        // 调试记录钩子，追加在签名钩子之后执行。
+		mirrorHooks []Hook        // 影子流量钩子，追加在调试记录钩子之后执行。
+		logSlow     time.Duration // 慢请求阈值。
+		logError    bool          // 是否记录错误。
 
 		logger kitlog.Logger // 日志记录器。
 
@@ -120,8 +158,9 @@ type (
 //
 // 当未显式提供 Transport 时，NewClient 会构造默认 http.Transport，并将
 // TLSClientConfig.InsecureSkipVerify 设为 true，也就是默认跳过 TLS 证书校验；
-// 如需启用证书校验，调用方必须通过 WithTransport 显式提供自定义 Transport 并调整 TLS 配置。
-// 当未显式提供 Hook 时，会按 logSlow、traceEnable 和 logError 选项自动组装默认 HookManager。
+// 通过 WithRootCAs 或 WithRootCAFiles 设置根证书后启用证书校验，WithClientCertificate 与 WithServerName
+// 分别配置 mTLS 客户端证书与 SNI，无需自行构造 Transport。
+// 当未显式提供 Hook 时，会按 logSlow、traceEnable（或 WithTraceSampling）和 logError 选项自动组装默认 HookManager。
 //
 // 参数：
 //   - opts: 用于覆盖默认超时、连接池、Transport、Hook 和日志配置的可选项，按传入顺序应用。
@@ -153,11 +192,9 @@ func NewClient(opts ...Option) Client {
 				Timeout:   dialTimeoutDefault,
 				KeepAlive: dialKeepAliveDefault,
 			}).DialContext,
-			ForceAttemptHTTP2: forceAttemptHTTP2Default,
-			IdleConnTimeout:   idleConnTimeoutDefault,
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: tlsInsecureSkipVerifyDefault,
-			},
+			ForceAttemptHTTP2:     forceAttemptHTTP2Default,
+			IdleConnTimeout:       idleConnTimeoutDefault,
+			TLSClientConfig:       c.tlsConfig(),
 			TLSHandshakeTimeout:   tlsHandshakeTimeoutDefault,
 			ExpectContinueTimeout: expectContinueTimeoutDefault,
 			MaxIdleConns:          c.maxIdleConns,
@@ -172,7 +209,10 @@ func NewClient(opts ...Option) Client {
 			ls := NewSlowHook(c.logger, c.logSlow)
 			hm.AddHook(ls)
 		}
-		if c.traceEnable {
+		if nil != c.traceRegistry {
+			th := NewSampledTraceHook(c.logger, c.traceRegistry)
+			hm.AddHook(th)
+		} else if c.traceEnable {
 			th := NewTraceHook(c.logger)
 			hm.AddHook(th)
 		}
@@ -183,9 +223,28 @@ func NewClient(opts ...Option) Client {
 		c.hook = hm
 	}
 
+	if len(c.signHooks) > 0 || len(c.debugHooks) > 0 || len(c.mirrorHooks) > 0 {
+		// 签名钩子排在其他钩子之后，保证签名覆盖其他钩子修改后的最终请求；调试记录钩子记录签名后的请求，
+		// 且 After 先于日志钩子执行，使日志可以读取完整的记录；影子流量钩子排在最后，复制签名后的请求。
+		hm := NewHookManager()
+		hm.AddHook(c.hook)
+		for _, sh := range c.signHooks {
+			hm.AddHook(sh)
+		}
+		for _, dh := range c.debugHooks {
+			hm.AddHook(dh)
+		}
+		for _, mh := range c.mirrorHooks {
+			hm.AddHook(mh)
+		}
+		c.hook = hm
+	}
+
+	transport := newDecompressTransport(newRateLimitTransport(c.transport, c.downloadRate, c.uploadRate), c.decoders, c.decoderOrder, c.compressionReporter)
 	c.client = &http.Client{
-		Timeout:   c.timeout,
-		Transport: c.transport,
+		Timeout: c.timeout,
+		// 大小限制包在解压之外，限制的是解压后的内容。
+		Transport: newLimitTransport(transport, c.maxResponseBytes),
 	}
 
 	return c
@@ -198,23 +257,34 @@ func NewClient(opts ...Option) Client {
 // 参数：
 //   - ctx: 传递给 HookContext 的上下文。
 //   - req: 待发送的 HTTP 请求对象。
+//   - opts: 仅作用于本次请求的配置覆盖，例如 WithTimeoutOverride 和 WithoutHooks。
 //
 // 返回：
 //   - *http.Response: HTTP 响应对象；非 nil 时调用方负责关闭 Body。
-//   - error: Hook Before 失败或底层 HTTP 请求失败时返回错误；Hook After 的错误会被忽略。
-func (c *client) Do(ctx context.Context, req *http.Request) (*http.Response, error) {
-	if nil != c.hook {
+//   - error: TLS 证书加载失败、请求体压缩失败、Hook Before 失败或底层 HTTP 请求失败时返回错误；Hook After 的错误会被忽略。
+func (c *client) Do(ctx context.Context, req *http.Request, opts ...RequestOption) (*http.Response, error) {
+	if nil != c.tlsErr {
+		return nil, c.tlsErr
+	}
+	req, err := c.compressRequest(req)
+	if nil != err {
+		return nil, err
+	}
+
+	ro := newRequestOptions(opts)
+	httpClient := ro.httpClient(c.client)
+	if hook := ro.hook(c.hook); nil != hook {
 		hc := NewHookContext(ctx, req.Method, req.URL.String(), req)
-		if err := c.hook.Before(hc); nil != err {
+		if err := hook.Before(hc); nil != err {
 			// Before 失败时请求尚未发送，直接返回该错误，避免带着不完整的 Hook 状态继续执行。
 			return nil, err
 		}
-		resp, err := c.client.Do(hc.Request())
+		resp, err := httpClient.Do(hc.Request())
 		hc.SetResult(resp, err)
-		_ = c.hook.After(hc) // 请求已经完成，After 只做收尾观察逻辑，不覆盖原始响应和错误。
+		_ = hook.After(hc) // 请求已经完成，After 只做收尾观察逻辑，不覆盖原始响应和错误。
 		return resp, err
 	} else {
-		return c.client.Do(req)
+		return httpClient.Do(req)
 	}
 }
 
@@ -223,17 +293,18 @@ func (c *client) Do(ctx context.Context, req *http.Request) (*http.Response, err
 // 参数：
 //   - ctx: 请求上下文，用于创建 HTTP 请求并控制其生命周期。
 //   - url: 请求地址。
+//   - opts: 仅作用于本次请求的配置覆盖，例如 WithTimeoutOverride 和 WithoutHooks。
 //
 // 返回：
 //   - *http.Response: HTTP 响应对象；非 nil 时调用方负责关闭 Body。
 //   - error: 请求创建失败、Hook Before 失败或底层 HTTP 请求失败时返回错误。
-func (c *client) Head(ctx context.Context, url string) (*http.Response, error) {
+func (c *client) Head(ctx context.Context, url string, opts ...RequestOption) (*http.Response, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	return c.Do(ctx, req)
+	return c.Do(ctx, req, opts...)
 }
 
 // Get 发送 HTTP GET 请求。
@@ -241,17 +312,18 @@ func (c *client) Head(ctx context.Context, url string) (*http.Response, error) {
 // 参数：
 //   - ctx: 请求上下文，用于创建 HTTP 请求并控制其生命周期。
 //   - url: 请求地址。
+//   - opts: 仅作用于本次请求的配置覆盖，例如 WithTimeoutOverride 和 WithoutHooks。
 //
 // 返回：
 //   - *http.Response: HTTP 响应对象；非 nil 时调用方负责关闭 Body。
 //   - error: 请求创建失败、Hook Before 失败或底层 HTTP 请求失败时返回错误。
-func (c *client) Get(ctx context.Context, url string) (*http.Response, error) {
+func (c *client) Get(ctx context.Context, url string, opts ...RequestOption) (*http.Response, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	return c.Do(ctx, req)
+	return c.Do(ctx, req, opts...)
 }
 
 // Post 发送 HTTP POST 请求。
@@ -260,17 +332,18 @@ func (c *client) Get(ctx context.Context, url string) (*http.Response, error) {
 //   - ctx: 请求上下文，用于创建 HTTP 请求并控制其生命周期。
 //   - url: 请求地址。
 //   - body: 请求体；可为 nil。
+//   - opts: 仅作用于本次请求的配置覆盖，例如 WithTimeoutOverride 和 WithoutHooks。
 //
 // 返回：
 //   - *http.Response: HTTP 响应对象；非 nil 时调用方负责关闭 Body。
 //   - error: 请求创建失败、Hook Before 失败或底层 HTTP 请求失败时返回错误。
-func (c *client) Post(ctx context.Context, url string, body io.Reader) (*http.Response, error) {
+func (c *client) Post(ctx context.Context, url string, body io.Reader, opts ...RequestOption) (*http.Response, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, body)
 	if err != nil {
 		return nil, err
 	}
 
-	return c.Do(ctx, req)
+	return c.Do(ctx, req, opts...)
 }
 
 // PostForm 发送 application/x-www-form-urlencoded 表单 POST 请求。
@@ -279,18 +352,19 @@ func (c *client) Post(ctx context.Context, url string, body io.Reader) (*http.Re
 //   - ctx: 请求上下文，用于创建 HTTP 请求并控制其生命周期。
 //   - url: 请求地址。
 //   - data: 表单数据，会通过 url.Values.Encode 编码到请求体。
+//   - opts: 仅作用于本次请求的配置覆盖，例如 WithTimeoutOverride 和 WithoutHooks。
 //
 // 返回：
 //   - *http.Response: HTTP 响应对象；非 nil 时调用方负责关闭 Body。
 //   - error: 请求创建失败、Hook Before 失败或底层 HTTP 请求失败时返回错误。
-func (c *client) PostForm(ctx context.Context, url string, data url.Values) (*http.Response, error) {
+func (c *client) PostForm(ctx context.Context, url string, data url.Values, opts ...RequestOption) (*http.Response, error) {
 	body := strings.NewReader(data.Encode())
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, body)
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
-	return c.Do(ctx, req)
+	return c.Do(ctx, req, opts...)
 }
 
 // PostJSON 发送 application/json POST 请求。
@@ -299,11 +373,12 @@ func (c *client) PostForm(ctx context.Context, url string, data url.Values) (*ht
 //   - ctx: 请求上下文，用于创建 HTTP 请求并控制其生命周期。
 //   - url: 请求地址。
 //   - data: 待编码为 JSON 的请求体数据。
+//   - opts: 仅作用于本次请求的配置覆盖，例如 WithTimeoutOverride 和 WithoutHooks。
 //
 // 返回：
 //   - *http.Response: HTTP 响应对象；非 nil 时调用方负责关闭 Body。
 //   - error: JSON 编码失败、请求创建失败、Hook Before 失败或底层 HTTP 请求失败时返回错误。
-func (c *client) PostJSON(ctx context.Context, url string, data any) (*http.Response, error) {
+func (c *client) PostJSON(ctx context.Context, url string, data any, opts ...RequestOption) (*http.Response, error) {
 	jsonBytes, err := json.Marshal(data)
 	if err != nil {
 		return nil, err
@@ -314,7 +389,7 @@ func (c *client) PostJSON(ctx context.Context, url string, data any) (*http.Resp
 		return nil, err
 	}
 	req.Header.Set("Content-Type", "application/json; charset=utf-8")
-	return c.Do(ctx, req)
+	return c.Do(ctx, req, opts...)
 }
 
 var (
@@ -352,12 +427,13 @@ func clientDef() Client {
 // 参数：
 //   - ctx: 传递给 HookContext 的上下文。
 //   - req: 待发送的 HTTP 请求对象。
+//   - opts: 仅作用于本次请求的配置覆盖，例如 WithTimeoutOverride 和 WithoutHooks。
 //
 // 返回：
 //   - *http.Response: HTTP 响应对象；非 nil 时调用方负责关闭 Body。
 //   - error: Hook Before 失败或底层 HTTP 请求失败时返回错误；Hook After 的错误会被忽略。
-func Do(ctx context.Context, req *http.Request) (*http.Response, error) {
-	return clientDef().Do(ctx, req)
+func Do(ctx context.Context, req *http.Request, opts ...RequestOption) (*http.Response, error) {
+	return clientDef().Do(ctx, req, opts...)
 }
 
 // Head 使用全局默认客户端发送 HTTP HEAD 请求。
@@ -365,12 +441,13 @@ func Do(ctx context.Context, req *http.Request) (*http.Response, error) {
 // 参数：
 //   - ctx: 请求上下文，用于创建 HTTP 请求并控制其生命周期。
 //   - url: 请求地址。
+//   - opts: 仅作用于本次请求的配置覆盖，例如 WithTimeoutOverride 和 WithoutHooks。
 //
 // 返回：
 //   - *http.Response: HTTP 响应对象；非 nil 时调用方负责关闭 Body。
 //   - error: 请求创建失败、Hook Before 失败或底层 HTTP 请求失败时返回错误。
-func Head(ctx context.Context, url string) (*http.Response, error) {
-	return clientDef().Head(ctx, url)
+func Head(ctx context.Context, url string, opts ...RequestOption) (*http.Response, error) {
+	return clientDef().Head(ctx, url, opts...)
 }
 
 // Get 使用全局默认客户端发送 HTTP GET 请求。
@@ -378,12 +455,13 @@ func Head(ctx context.Context, url string) (*http.Response, error) {
 // 参数：
 //   - ctx: 请求上下文，用于创建 HTTP 请求并控制其生命周期。
 //   - url: 请求地址。
+//   - opts: 仅作用于本次请求的配置覆盖，例如 WithTimeoutOverride 和 WithoutHooks。
 //
 // 返回：
 //   - *http.Response: HTTP 响应对象；非 nil 时调用方负责关闭 Body。
 //   - error: 请求创建失败、Hook Before 失败或底层 HTTP 请求失败时返回错误。
-func Get(ctx context.Context, url string) (*http.Response, error) {
-	return clientDef().Get(ctx, url)
+func Get(ctx context.Context, url string, opts ...RequestOption) (*http.Response, error) {
+	return clientDef().Get(ctx, url, opts...)
 }
 
 // Post 使用全局默认客户端发送 HTTP POST 请求。
@@ -392,12 +470,13 @@ func Get(ctx context.Context, url string) (*http.Response, error) {
 //   - ctx: 请求上下文，用于创建 HTTP 请求并控制其生命周期。
 //   - url: 请求地址。
 //   - body: 请求体；可为 nil。
+//   - opts: 仅作用于本次请求的配置覆盖，例如 WithTimeoutOverride 和 WithoutHooks。
 //
 // 返回：
 //   - *http.Response: HTTP 响应对象；非 nil 时调用方负责关闭 Body。
 //   - error: 请求创建失败、Hook Before 失败或底层 HTTP 请求失败时返回错误。
-func Post(ctx context.Context, url string, body io.Reader) (*http.Response, error) {
-	return clientDef().Post(ctx, url, body)
+func Post(ctx context.Context, url string, body io.Reader, opts ...RequestOption) (*http.Response, error) {
+	return clientDef().Post(ctx, url, body, opts...)
 }
 
 // PostForm 使用全局默认客户端发送 application/x-www-form-urlencoded 表单 POST 请求。
@@ -406,12 +485,13 @@ func Post(ctx context.Context, url string, body io.Reader) (*http.Response, erro
 //   - ctx: 请求上下文，用于创建 HTTP 请求并控制其生命周期。
 //   - url: 请求地址。
 //   - data: 表单数据，会通过 url.Values.Encode 编码到请求体。
+//   - opts: 仅作用于本次请求的配置覆盖，例如 WithTimeoutOverride 和 WithoutHooks。
 //
 // 返回：
 //   - *http.Response: HTTP 响应对象；非 nil 时调用方负责关闭 Body。
 //   - error: 请求创建失败、Hook Before 失败或底层 HTTP 请求失败时返回错误。
-func PostForm(ctx context.Context, url string, data url.Values) (*http.Response, error) {
-	return clientDef().PostForm(ctx, url, data)
+func PostForm(ctx context.Context, url string, data url.Values, opts ...RequestOption) (*http.Response, error) {
+	return clientDef().PostForm(ctx, url, data, opts...)
 }
 
 // PostJSON 使用全局默认客户端发送 application/json POST 请求。
@@ -420,10 +500,11 @@ func PostForm(ctx context.Context, url string, data url.Values) (*http.Response,
 //   - ctx: 请求上下文，用于创建 HTTP 请求并控制其生命周期。
 //   - url: 请求地址。
 //   - data: 待编码为 JSON 的请求体数据。
+//   - opts: 仅作用于本次请求的配置覆盖，例如 WithTimeoutOverride 和 WithoutHooks。
 //
 // 返回：
 //   - *http.Response: HTTP 响应对象；非 nil 时调用方负责关闭 Body。
 //   - error: JSON 编码失败、请求创建失败、Hook Before 失败或底层 HTTP 请求失败时返回错误。
-func PostJSON(ctx context.Context, url string, data any) (*http.Response, error) {
-	return clientDef().PostJSON(ctx, url, data)
+func PostJSON(ctx context.Context, url string, data any, opts ...RequestOption) (*http.Response, error) {
+	return clientDef().PostJSON(ctx, url, data, opts...)
 }
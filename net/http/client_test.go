@@ -238,9 +238,10 @@ func TestNewClient_Configuration(t *testing.T) {
 			},
 			assert: func(t *testing.T, c *client) {
 				assert.Same(t, customTransport, c.transport)
-				transport, ok := c.client.Transport.(*stdhttp.Transport)
+				// 内置响应解码包装在调用方的 Transport 之外。
+				transport, ok := c.client.Transport.(*decompressTransport)
 				require.True(t, ok)
-				assert.Same(t, customTransport, transport)
+				assert.Same(t, customTransport, transport.base)
 				assert.Same(t, customHook, c.hook)
 			},
 		},
@@ -680,7 +681,7 @@ type fakeClientCall struct {
 // 返回：
 //   - *http.Response: 固定的成功响应。
 //   - error: 始终为 nil。
-func (f *fakeClient) Do(ctx context.Context, req *stdhttp.Request) (*stdhttp.Response, error) {
+func (f *fakeClient) Do(ctx context.Context, req *stdhttp.Request, opts ...RequestOption) (*stdhttp.Response, error) {
 	body := ""
 	if req.Body != nil {
 		data, _ := io.ReadAll(req.Body)
@@ -706,7 +707,7 @@ func (f *fakeClient) Do(ctx context.Context, req *stdhttp.Request) (*stdhttp.Res
 // 返回：
 //   - *http.Response: 固定的成功响应。
 //   - error: 始终为 nil。
-func (f *fakeClient) Head(ctx context.Context, targetURL string) (*stdhttp.Response, error) {
+func (f *fakeClient) Head(ctx context.Context, targetURL string, opts ...RequestOption) (*stdhttp.Response, error) {
 	f.calls = append(f.calls, fakeClientCall{Operation: "Head", Method: stdhttp.MethodHead, URL: targetURL})
 	return newFakeResponse(), nil
 }
@@ -722,7 +723,7 @@ func (f *fakeClient) Head(ctx context.Context, targetURL string) (*stdhttp.Respo
 // 返回：
 //   - *http.Response: 固定的成功响应。
 //   - error: 始终为 nil。
-func (f *fakeClient) Get(ctx context.Context, targetURL string) (*stdhttp.Response, error) {
+func (f *fakeClient) Get(ctx context.Context, targetURL string, opts ...RequestOption) (*stdhttp.Response, error) {
 	f.calls = append(f.calls, fakeClientCall{Operation: "Get", Method: stdhttp.MethodGet, URL: targetURL})
 	return newFakeResponse(), nil
 }
@@ -739,7 +740,7 @@ func (f *fakeClient) Get(ctx context.Context, targetURL string) (*stdhttp.Respon
 // 返回：
 //   - *http.Response: 固定的成功响应。
 //   - error: 始终为 nil。
-func (f *fakeClient) Post(ctx context.Context, targetURL string, body io.Reader) (*stdhttp.Response, error) {
+func (f *fakeClient) Post(ctx context.Context, targetURL string, body io.Reader, opts ...RequestOption) (*stdhttp.Response, error) {
 	data, _ := io.ReadAll(body)
 	f.calls = append(f.calls, fakeClientCall{Operation: "Post", Method: stdhttp.MethodPost, URL: targetURL, Body: string(data)})
 	return newFakeResponse(), nil
@@ -757,7 +758,7 @@ func (f *fakeClient) Post(ctx context.Context, targetURL string, body io.Reader)
 // 返回：
 //   - *http.Response: 固定的成功响应。
 //   - error: 始终为 nil。
-func (f *fakeClient) PostForm(ctx context.Context, targetURL string, data url.Values) (*stdhttp.Response, error) {
+func (f *fakeClient) PostForm(ctx context.Context, targetURL string, data url.Values, opts ...RequestOption) (*stdhttp.Response, error) {
 	f.calls = append(f.calls, fakeClientCall{Operation: "PostForm", Method: stdhttp.MethodPost, URL: targetURL, Form: data})
 	return newFakeResponse(), nil
 }
@@ -774,11 +775,27 @@ func (f *fakeClient) PostForm(ctx context.Context, targetURL string, data url.Va
 // 返回：
 //   - *http.Response: 固定的成功响应。
 //   - error: 始终为 nil。
-func (f *fakeClient) PostJSON(ctx context.Context, targetURL string, data any) (*stdhttp.Response, error) {
+func (f *fakeClient) PostJSON(ctx context.Context, targetURL string, data any, opts ...RequestOption) (*stdhttp.Response, error) {
 	f.calls = append(f.calls, fakeClientCall{Operation: "PostJSON", Method: stdhttp.MethodPost, URL: targetURL, JSON: data})
 	return newFakeResponse(), nil
 }
 
+// GetNDJSON 记录全局 GetNDJSON 包装函数传入的 URL，并回调一个固定的 JSON 值。
+//
+// 该辅助方法实现 Client 接口，用于验证包级 GetNDJSON 函数的委托行为。
+//
+// 参数：
+//   - ctx: 请求上下文，本 fake 不读取该值。
+//   - targetURL: 调用方传入的请求地址。
+//   - fn: 调用方传入的回调。
+//
+// 返回：
+//   - error: fn 返回的错误。
+func (f *fakeClient) GetNDJSON(ctx context.Context, targetURL string, fn func(json.RawMessage) error, opts ...RequestOption) error {
+	f.calls = append(f.calls, fakeClientCall{Operation: "GetNDJSON", Method: stdhttp.MethodGet, URL: targetURL})
+	return fn(json.RawMessage(`"fake"`))
+}
+
 // newFakeResponse 构造 fakeClient 使用的固定 HTTP 响应。
 //
 // 该辅助函数为全局函数委托测试提供可关闭的响应体，避免测试泄漏资源。
@@ -0,0 +1,461 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package http
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	// CompressionDirectionRequest 表示压缩请求体。
+	CompressionDirectionRequest = "request"
+	// CompressionDirectionResponse 表示解压响应体。
+	CompressionDirectionResponse = "response"
+
+	// headerAcceptEncoding 是声明可接受内容编码的请求头。
+	headerAcceptEncoding = "Accept-Encoding"
+	// headerContentEncoding 是声明实体内容编码的头。
+	headerContentEncoding = "Content-Encoding"
+	// headerContentLength 是声明实体长度的头。
+	headerContentLength = "Content-Length"
+
+	// encodingGzip 是 gzip 内容编码名称。
+	encodingGzip = "gzip"
+	// encodingDeflate 是 deflate 内容编码名称，按 HTTP 规范为 zlib 格式。
+	encodingDeflate = "deflate"
+	// encodingBrotli 是 br 内容编码名称。
+	encodingBrotli = "br"
+	// encodingZstd 是 zstd 内容编码名称。
+	encodingZstd = "zstd"
+	// encodingIdentity 表示未编码。
+	encodingIdentity = "identity"
+)
+
+var (
+	_ http.RoundTripper = (*decompressTransport)(nil)
+	_ io.ReadCloser     = (*decodedBody)(nil)
+
+	// builtinEncodings 是内置解码器支持的编码，按写入 Accept-Encoding 的顺序排列。
+	builtinEncodings = []string{encodingBrotli, encodingZstd, encodingGzip, encodingDeflate}
+)
+
+type (
+	// Decoder 创建某种内容编码的解码读取器。
+	//
+	// 参数：
+	//   - r: 编码后的数据流。
+	//
+	// 返回：
+	//   - io.ReadCloser: 输出解码后数据的读取器，Close 时释放解码器资源，不需要关闭 r。
+	//   - error: 无法识别数据流头部等原因导致创建失败时返回错误。
+	Decoder func(r io.Reader) (io.ReadCloser, error)
+
+	// CompressionReport 描述一次请求体压缩或响应体解压的大小。
+	CompressionReport struct {
+		Direction      string // 方向，取值为 CompressionDirectionRequest 或 CompressionDirectionResponse。
+		Encoding       string // 内容编码，多层编码时为响应头中的原值，例如 "gzip" 或 "br"。
+		OriginalSize   int64  // 未压缩的字节数；响应方向为调用方实际读取到的字节数。
+		CompressedSize int64  // 压缩后的字节数；响应方向为从网络实际读取的字节数。
+	}
+
+	// CompressionReporter 接收压缩与解压的大小报告。
+	//
+	// 请求方向在请求体压缩完成、发送之前调用；响应方向在响应体读到末尾或被关闭时调用一次，
+	// 因此调用方未读完就关闭响应体时，报告的是已读取部分的大小。回调可能被并发调用。
+	//
+	// 参数：
+	//   - req: 对应的请求，可通过 req.Context() 取得请求上下文。
+	//   - report: 大小报告。
+	CompressionReporter func(req *http.Request, report CompressionReport)
+
+	// decompressTransport 声明可接受的内容编码，并透明解码响应体的 RoundTripper。
+	decompressTransport struct {
+		base     http.RoundTripper   // 实际发送请求的传输层。
+		decoders map[string]Decoder  // 按编码名称索引的解码器。
+		accept   string              // 写入 Accept-Encoding 的值。
+		reporter CompressionReporter // 大小报告回调，可为 nil。
+	}
+
+	// countingReader 统计读取字节数的读取器。
+	countingReader struct {
+		r io.Reader // 原始读取器。
+		n int64     // 已读取的字节数。
+	}
+
+	// decodedBody 是解码后的响应体，读到末尾或关闭时报告大小。
+	decodedBody struct {
+		decoded io.Reader                        // 最外层解码读取器。
+		closers []io.Closer                      // 按创建顺序保存的解码器。
+		body    io.ReadCloser                    // 原始响应体。
+		wire    *countingReader                  // 统计原始响应体读取量的读取器。
+		read    int64                            // 调用方已读取的解码后字节数。
+		report  func(original, compressed int64) // 报告解码后与网络读取的字节数，可为 nil。
+		once    sync.Once                        // 保证只报告一次。
+	}
+)
+
+// WithRequestCompression 使用 gzip 压缩长度不小于阈值的请求体。
+//
+// 压缩在 Hook 执行之前完成，因此 [WithSigner] 等 Hook 看到的是压缩后的请求体，签名覆盖实际发送的内容。
+// 请求会被设置 Content-Encoding: gzip 并更新 Content-Length 与 GetBody，原始请求不会被修改。
+// 只有 ContentLength 不小于阈值的请求才会压缩，长度未知的流式请求体以及已设置 Content-Encoding 的请求原样发送。
+//
+// 参数：
+//   - threshold: 触发压缩的最小请求体字节数；小于等于 0 表示不压缩。
+//
+// 返回：
+//   - Option: 应用于 [NewClient] 的请求压缩配置项。
+func WithRequestCompression(threshold int64) Option {
+	return func(c *client) {
+		c.compressThreshold = threshold
+	}
+}
+
+// WithResponseDecoder 注册响应体内容编码的解码器，扩展或替换客户端内置的解码器。
+//
+// 客户端内置 br、zstd、gzip 与 deflate 解码器：为未设置 Accept-Encoding 的请求声明已注册的编码以及全部内置编码，
+// 并按响应的 Content-Encoding 逐层解码，解码后删除 Content-Encoding 与 Content-Length 响应头并将
+// Response.Uncompressed 置为 true。调用方自行设置 Accept-Encoding 的请求、包含未知编码的响应以及 HEAD 请求的
+// 响应保持原样。
+//
+// 参数：
+//   - encoding: 内容编码名称，不区分大小写；与内置编码同名时覆盖内置实现。
+//   - decoder: 解码器；为 nil 时忽略该选项。
+//
+// 返回：
+//   - Option: 应用于 [NewClient] 的响应解码配置项。
+func WithResponseDecoder(encoding string, decoder Decoder) Option {
+	return func(c *client) {
+		encoding = strings.ToLower(strings.TrimSpace(encoding))
+		if "" == encoding || nil == decoder {
+			return
+		}
+		if nil == c.decoders {
+			c.decoders = make(map[string]Decoder)
+		}
+		if _, ok := c.decoders[encoding]; !ok {
+			c.decoderOrder = append(c.decoderOrder, encoding)
+		}
+		c.decoders[encoding] = decoder
+	}
+}
+
+// WithCompressionReporter 设置压缩与解压的大小报告回调。
+//
+// 参数：
+//   - reporter: 大小报告回调；为 nil 时不报告。
+//
+// 返回：
+//   - Option: 应用于 [NewClient] 的大小报告配置项。
+func WithCompressionReporter(reporter CompressionReporter) Option {
+	return func(c *client) {
+		c.compressionReporter = reporter
+	}
+}
+
+// compressRequest 按配置压缩请求体。
+//
+// 参数：
+//   - req: 待发送的请求。
+//
+// 返回：
+//   - *http.Request: 压缩后的请求副本；无需压缩时返回 req 本身。
+//   - error: 读取或压缩请求体失败时返回错误。
+func (c *client) compressRequest(req *http.Request) (*http.Request, error) {
+	if c.compressThreshold <= 0 || nil == req.Body || http.NoBody == req.Body ||
+		req.ContentLength < c.compressThreshold || "" != req.Header.Get(headerContentEncoding) {
+		return req, nil
+	}
+
+	raw, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if nil != err {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(raw); nil != err {
+		return nil, err
+	}
+	if err := zw.Close(); nil != err {
+		return nil, err
+	}
+
+	data := buf.Bytes()
+	r := req.Clone(req.Context())
+	r.Body = io.NopCloser(bytes.NewReader(data))
+	r.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(data)), nil
+	}
+	r.ContentLength = int64(len(data))
+	r.Header.Set(headerContentEncoding, encodingGzip)
+
+	if nil != c.compressionReporter {
+		c.compressionReporter(r, CompressionReport{
+			Direction:      CompressionDirectionRequest,
+			Encoding:       encodingGzip,
+			OriginalSize:   int64(len(raw)),
+			CompressedSize: int64(len(data)),
+		})
+	}
+	return r, nil
+}
+
+// newDecompressTransport 创建解码响应体的 RoundTripper。
+//
+// 参数：
+//   - base: 实际发送请求的传输层。
+//   - decoders: 调用方注册的解码器，可为 nil。
+//   - order: 调用方注册编码的顺序，决定 Accept-Encoding 中的先后。
+//   - reporter: 大小报告回调，可为 nil。
+//
+// 返回：
+//   - http.RoundTripper: 使用内置解码器与注册解码器的 RoundTripper。
+func newDecompressTransport(base http.RoundTripper, decoders map[string]Decoder, order []string, reporter CompressionReporter) http.RoundTripper {
+	all := map[string]Decoder{
+		encodingBrotli:  decodeBrotli,
+		encodingZstd:    decodeZstd,
+		encodingGzip:    decodeGzip,
+		encodingDeflate: decodeDeflate,
+	}
+	accept := make([]string, 0, len(order)+len(builtinEncodings))
+	for _, encoding := range order {
+		all[encoding] = decoders[encoding]
+		accept = append(accept, encoding)
+	}
+	for _, encoding := range builtinEncodings {
+		if _, ok := decoders[encoding]; !ok {
+			accept = append(accept, encoding)
+		}
+	}
+
+	return &decompressTransport{
+		base:     base,
+		decoders: all,
+		accept:   strings.Join(accept, ", "),
+		reporter: reporter,
+	}
+}
+
+// RoundTrip 声明可接受的内容编码后发送请求，并解码响应体。
+//
+// 参数：
+//   - req: 待发送的请求；不会被修改。
+//
+// 返回：
+//   - *http.Response: 响应体已解码的响应。
+//   - error: 底层传输层返回的错误，或创建解码器失败的错误。
+func (t *decompressTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if "" != req.Header.Get(headerAcceptEncoding) {
+		return t.base.RoundTrip(req)
+	}
+	// RoundTripper 不应修改原始请求，这里使用浅拷贝并复制请求头。
+	r := new(http.Request)
+	*r = *req
+	r.Header = req.Header.Clone()
+	if nil == r.Header {
+		r.Header = make(http.Header)
+	}
+	r.Header.Set(headerAcceptEncoding, t.accept)
+
+	resp, err := t.base.RoundTrip(r)
+	if nil != err || http.MethodHead == req.Method || nil == resp.Body || http.NoBody == resp.Body {
+		return resp, err
+	}
+	contentEncoding := resp.Header.Get(headerContentEncoding)
+	encodings := t.encodings(contentEncoding)
+	if 0 == len(encodings) {
+		return resp, nil
+	}
+
+	wire := &countingReader{r: resp.Body}
+	body := &decodedBody{decoded: wire, body: resp.Body, wire: wire}
+	// 多层编码按应用顺序列出，解码时从最后一层开始。
+	for i := len(encodings) - 1; i >= 0; i-- {
+		rc, err := t.decoders[encodings[i]](body.decoded)
+		if nil != err {
+			_ = body.closeAll()
+			return nil, err
+		}
+		body.decoded = rc
+		body.closers = append(body.closers, rc)
+	}
+	if nil != t.reporter {
+		body.report = func(original, compressed int64) {
+			t.reporter(req, CompressionReport{
+				Direction:      CompressionDirectionResponse,
+				Encoding:       contentEncoding,
+				OriginalSize:   original,
+				CompressedSize: compressed,
+			})
+		}
+	}
+
+	resp.Body = body
+	resp.Header.Del(headerContentEncoding)
+	resp.Header.Del(headerContentLength)
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+	return resp, nil
+}
+
+// encodings 解析 Content-Encoding 响应头。
+//
+// 参数：
+//   - contentEncoding: Content-Encoding 响应头的值。
+//
+// 返回：
+//   - []string: 需要解码的编码，已忽略 identity；为空或包含未知的编码时返回 nil。
+func (t *decompressTransport) encodings(contentEncoding string) []string {
+	var encodings []string
+	for _, encoding := range strings.Split(contentEncoding, ",") {
+		encoding = strings.ToLower(strings.TrimSpace(encoding))
+		if "" == encoding || encodingIdentity == encoding {
+			continue
+		}
+		if _, ok := t.decoders[encoding]; !ok {
+			return nil
+		}
+		encodings = append(encodings, encoding)
+	}
+	return encodings
+}
+
+// decodeBrotli 是内置的 br 解码器。
+//
+// 参数：
+//   - r: br 编码的数据流。
+//
+// 返回：
+//   - io.ReadCloser: br 解码读取器，数据无效时在读取时返回错误。
+//   - error: 始终为 nil。
+func decodeBrotli(r io.Reader) (io.ReadCloser, error) {
+	return io.NopCloser(brotli.NewReader(r)), nil
+}
+
+// decodeZstd 是内置的 zstd 解码器。
+//
+// 使用单线程解码，避免每个响应额外启动解码 goroutine；Close 时释放解码器。
+//
+// 参数：
+//   - r: zstd 编码的数据流。
+//
+// 返回：
+//   - io.ReadCloser: zstd 解码读取器，数据无效时在读取时返回错误。
+//   - error: 创建解码器失败时返回错误。
+func decodeZstd(r io.Reader) (io.ReadCloser, error) {
+	d, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+	if nil != err {
+		return nil, err
+	}
+	return d.IOReadCloser(), nil
+}
+
+// decodeGzip 是内置的 gzip 解码器。
+//
+// 参数：
+//   - r: gzip 编码的数据流。
+//
+// 返回：
+//   - io.ReadCloser: gzip 解码读取器。
+//   - error: gzip 头部无效时返回错误。
+func decodeGzip(r io.Reader) (io.ReadCloser, error) {
+	return gzip.NewReader(r)
+}
+
+// decodeDeflate 是内置的 deflate 解码器，按 HTTP 规范解析 zlib 格式。
+//
+// 参数：
+//   - r: zlib 编码的数据流。
+//
+// 返回：
+//   - io.ReadCloser: zlib 解码读取器。
+//   - error: zlib 头部无效时返回错误。
+func decodeDeflate(r io.Reader) (io.ReadCloser, error) {
+	return zlib.NewReader(r)
+}
+
+// Read 读取原始数据并累计字节数。
+//
+// 参数：
+//   - p: 读取缓冲区。
+//
+// 返回：
+//   - int: 实际读取的字节数。
+//   - error: 原始读取器的错误。
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.n += int64(n)
+	return n, err
+}
+
+// Read 读取解码后的数据，读到末尾时报告大小。
+//
+// 参数：
+//   - p: 读取缓冲区。
+//
+// 返回：
+//   - int: 实际读取的字节数。
+//   - error: 解码器或原始响应体的错误。
+func (b *decodedBody) Read(p []byte) (int, error) {
+	n, err := b.decoded.Read(p)
+	b.read += int64(n)
+	if io.EOF == err {
+		b.emit()
+	}
+	return n, err
+}
+
+// Close 关闭解码器与原始响应体，并在尚未报告时报告已读取部分的大小。
+//
+// 参数：无。
+//
+// 返回：
+//   - error: 解码器或原始响应体关闭时的第一个错误。
+func (b *decodedBody) Close() error {
+	b.emit()
+	return b.closeAll()
+}
+
+// emit 报告一次大小。
+//
+// 参数：无。
+//
+// 返回：无。
+func (b *decodedBody) emit() {
+	b.once.Do(func() {
+		if nil != b.report {
+			b.report(b.read, b.wire.n)
+		}
+	})
+}
+
+// closeAll 从外到内关闭解码器，最后关闭原始响应体。
+//
+// 参数：无。
+//
+// 返回：
+//   - error: 第一个关闭错误。
+func (b *decodedBody) closeAll() error {
+	var first error
+	for i := len(b.closers) - 1; i >= 0; i-- {
+		if err := b.closers[i].Close(); nil != err && nil == first {
+			first = err
+		}
+	}
+	if err := b.body.Close(); nil != err && nil == first {
+		first = err
+	}
+	return first
+}
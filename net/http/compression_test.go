@@ -0,0 +1,317 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package http
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"encoding/base64"
+	"io"
+	stdhttp "net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// reportRecorder 记录 CompressionReporter 收到的报告。
+type reportRecorder struct {
+	mu      sync.Mutex
+	reports []CompressionReport
+}
+
+// record 实现 CompressionReporter。
+func (r *reportRecorder) record(_ *stdhttp.Request, report CompressionReport) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.reports = append(r.reports, report)
+}
+
+// all 返回已记录的报告副本。
+func (r *reportRecorder) all() []CompressionReport {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]CompressionReport(nil), r.reports...)
+}
+
+// decodeBase64 是测试使用的 "b64" 内容编码解码器。
+func decodeBase64(r io.Reader) (io.ReadCloser, error) {
+	return io.NopCloser(base64.NewDecoder(base64.StdEncoding, r)), nil
+}
+
+// gzipBytes 返回 gzip 压缩后的数据。
+func gzipBytes(t *testing.T, data []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	_, err := zw.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+// brotliBytes 返回 br 压缩后的数据。
+func brotliBytes(t *testing.T, data []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	bw := brotli.NewWriter(&buf)
+	_, err := bw.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, bw.Close())
+	return buf.Bytes()
+}
+
+// zstdBytes 返回 zstd 压缩后的数据。
+func zstdBytes(t *testing.T, data []byte) []byte {
+	t.Helper()
+	zw, err := zstd.NewWriter(nil)
+	require.NoError(t, err)
+	defer zw.Close()
+	return zw.EncodeAll(data, nil)
+}
+
+// TestWithRequestCompression 验证请求体压缩阈值、请求头与大小报告。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestWithRequestCompression(t *testing.T) {
+	server := httptest.NewServer(stdhttp.HandlerFunc(func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+		var body io.Reader = r.Body
+		if encodingGzip == r.Header.Get(headerContentEncoding) {
+			zr, err := gzip.NewReader(r.Body)
+			if nil != err {
+				w.WriteHeader(stdhttp.StatusBadRequest)
+				return
+			}
+			body = zr
+		}
+		data, _ := io.ReadAll(body)
+		w.Header().Set("X-Content-Encoding", r.Header.Get(headerContentEncoding))
+		_, _ = w.Write(data)
+	}))
+	t.Cleanup(server.Close)
+
+	large := strings.Repeat("compress me ", 200)
+
+	tests := []struct {
+		name        string
+		description string
+		body        string
+		encoding    string
+		wantGzip    bool
+	}{
+		{name: "success/above-threshold", description: "验证达到阈值的请求体被压缩。", body: large, wantGzip: true},
+		{name: "boundary/below-threshold", description: "验证未达到阈值的请求体原样发送。", body: "small"},
+		{name: "boundary/already-encoded", description: "验证已设置 Content-Encoding 的请求不再压缩。", body: large, encoding: "identity"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			recorder := &reportRecorder{}
+			c := NewClient(WithLogError(false), WithRequestCompression(1024), WithCompressionReporter(recorder.record))
+			req, err := stdhttp.NewRequestWithContext(context.Background(), stdhttp.MethodPost, server.URL, strings.NewReader(tt.body))
+			require.NoError(t, err)
+			if "" != tt.encoding {
+				req.Header.Set(headerContentEncoding, tt.encoding)
+			}
+
+			resp, err := c.Do(context.Background(), req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			data, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.body, string(data))
+			assert.Equal(t, tt.encoding, req.Header.Get(headerContentEncoding), "原始请求不应被修改")
+			if !tt.wantGzip {
+				assert.Equal(t, tt.encoding, resp.Header.Get("X-Content-Encoding"))
+				assert.Empty(t, recorder.all())
+				return
+			}
+			assert.Equal(t, encodingGzip, resp.Header.Get("X-Content-Encoding"))
+			reports := recorder.all()
+			require.Len(t, reports, 1)
+			assert.Equal(t, CompressionDirectionRequest, reports[0].Direction)
+			assert.Equal(t, int64(len(large)), reports[0].OriginalSize)
+			assert.Less(t, reports[0].CompressedSize, reports[0].OriginalSize)
+		})
+	}
+}
+
+// TestWithResponseDecoder 验证自定义解码器、内置解码器与多层编码。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestWithResponseDecoder(t *testing.T) {
+	plain := []byte(strings.Repeat("hello decoder ", 100))
+	var deflated bytes.Buffer
+	zw := zlib.NewWriter(&deflated)
+	_, _ = zw.Write(plain)
+	_ = zw.Close()
+
+	bodies := map[string][]byte{
+		"b64":       []byte(base64.StdEncoding.EncodeToString(plain)),
+		"gzip":      gzipBytes(t, plain),
+		"deflate":   deflated.Bytes(),
+		"br":        brotliBytes(t, plain),
+		"zstd":      zstdBytes(t, plain),
+		"zstd, br":  brotliBytes(t, zstdBytes(t, plain)),
+		"gzip, b64": []byte(base64.StdEncoding.EncodeToString(gzipBytes(t, plain))),
+		"unknown":   plain,
+	}
+	var acceptMu sync.Mutex
+	var accepts []string
+	server := httptest.NewServer(stdhttp.HandlerFunc(func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+		acceptMu.Lock()
+		accepts = append(accepts, r.Header.Get(headerAcceptEncoding))
+		acceptMu.Unlock()
+		encoding := r.URL.Query().Get("encoding")
+		w.Header().Set(headerContentEncoding, encoding)
+		_, _ = w.Write(bodies[encoding])
+	}))
+	t.Cleanup(server.Close)
+
+	tests := []struct {
+		name         string
+		description  string
+		encoding     string
+		wantDecoded  bool
+		wantEncoding string
+	}{
+		{name: "success/custom", description: "验证自定义编码被解码。", encoding: "b64", wantDecoded: true},
+		{name: "success/gzip", description: "验证内置 gzip 解码。", encoding: "gzip", wantDecoded: true},
+		{name: "success/deflate", description: "验证内置 deflate 解码。", encoding: "deflate", wantDecoded: true},
+		{name: "success/br", description: "验证内置 br 解码。", encoding: "br", wantDecoded: true},
+		{name: "success/zstd", description: "验证内置 zstd 解码。", encoding: "zstd", wantDecoded: true},
+		{name: "success/layered-builtin", description: "验证内置编码组成的多层编码。", encoding: "zstd, br", wantDecoded: true},
+		{name: "success/layered", description: "验证多层编码按相反顺序解码。", encoding: "gzip, b64", wantDecoded: true},
+		{name: "boundary/unknown", description: "验证未注册编码的响应保持原样。", encoding: "unknown", wantEncoding: "unknown"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			recorder := &reportRecorder{}
+			c := NewClient(WithLogError(false), WithResponseDecoder("B64", decodeBase64), WithCompressionReporter(recorder.record))
+			req, err := stdhttp.NewRequestWithContext(context.Background(), stdhttp.MethodGet, server.URL+"?encoding="+strings.ReplaceAll(tt.encoding, " ", "+"), nil)
+			require.NoError(t, err)
+
+			resp, err := c.Do(context.Background(), req)
+			require.NoError(t, err)
+			data, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+
+			assert.Equal(t, plain, data)
+			assert.Equal(t, tt.wantEncoding, resp.Header.Get(headerContentEncoding))
+			assert.Equal(t, tt.wantDecoded, resp.Uncompressed)
+			assert.Empty(t, req.Header.Get(headerAcceptEncoding), "原始请求不应被修改")
+			if !tt.wantDecoded {
+				assert.Empty(t, recorder.all())
+				return
+			}
+			reports := recorder.all()
+			require.Len(t, reports, 1, "读到末尾与关闭只报告一次")
+			assert.Equal(t, CompressionReport{
+				Direction:      CompressionDirectionResponse,
+				Encoding:       tt.encoding,
+				OriginalSize:   int64(len(plain)),
+				CompressedSize: int64(len(bodies[tt.encoding])),
+			}, reports[0])
+		})
+	}
+
+	acceptMu.Lock()
+	defer acceptMu.Unlock()
+	require.NotEmpty(t, accepts)
+	assert.Equal(t, "b64, br, zstd, gzip, deflate", accepts[0])
+}
+
+// TestNewClient_BuiltinDecoders 验证未注册解码器时客户端同样声明并解码内置编码。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestNewClient_BuiltinDecoders(t *testing.T) {
+	plain := []byte(strings.Repeat("hello builtin ", 100))
+	bodies := map[string][]byte{
+		"br":   brotliBytes(t, plain),
+		"zstd": zstdBytes(t, plain),
+	}
+	server := httptest.NewServer(stdhttp.HandlerFunc(func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+		encoding := r.URL.Query().Get("encoding")
+		if !strings.Contains(r.Header.Get(headerAcceptEncoding), encoding) {
+			w.WriteHeader(stdhttp.StatusNotAcceptable)
+			return
+		}
+		w.Header().Set(headerContentEncoding, encoding)
+		_, _ = w.Write(bodies[encoding])
+	}))
+	t.Cleanup(server.Close)
+
+	c := NewClient(WithLogError(false))
+	for _, encoding := range []string{"br", "zstd"} {
+		encoding := encoding
+		t.Run(encoding, func(t *testing.T) {
+			t.Logf("验证默认客户端解码 %s 响应。", encoding)
+
+			resp, err := c.Get(context.Background(), server.URL+"?encoding="+encoding)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			require.Equal(t, stdhttp.StatusOK, resp.StatusCode)
+			data, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.Equal(t, plain, data)
+			assert.True(t, resp.Uncompressed)
+			assert.Empty(t, resp.Header.Get(headerContentEncoding))
+		})
+	}
+}
+
+// TestWithResponseDecoder_Passthrough 验证调用方自行设置 Accept-Encoding 与解码失败的处理。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestWithResponseDecoder_Passthrough(t *testing.T) {
+	server := httptest.NewServer(stdhttp.HandlerFunc(func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+		w.Header().Set(headerContentEncoding, encodingGzip)
+		_, _ = w.Write([]byte("not gzip"))
+	}))
+	t.Cleanup(server.Close)
+
+	c := NewClient(WithLogError(false), WithResponseDecoder("b64", decodeBase64))
+
+	t.Run("explicit-accept", func(t *testing.T) {
+		t.Log("验证调用方设置 Accept-Encoding 时不解码。")
+
+		req, err := stdhttp.NewRequestWithContext(context.Background(), stdhttp.MethodGet, server.URL, nil)
+		require.NoError(t, err)
+		req.Header.Set(headerAcceptEncoding, encodingGzip)
+		resp, err := c.Do(context.Background(), req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		data, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, "not gzip", string(data))
+		assert.Equal(t, encodingGzip, resp.Header.Get(headerContentEncoding))
+	})
+
+	t.Run("invalid-body", func(t *testing.T) {
+		t.Log("验证解码器创建失败时返回错误。")
+
+		_, err := c.Get(context.Background(), server.URL)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,711 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package http
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+var (
+	// 断言 DebugHook 实现 Hook 接口。
+	_ Hook = (*DebugHook)(nil)
+)
+
+const (
+	// RedactedValue 是脱敏后的请求头、查询参数和表单字段的取值。
+	RedactedValue = "REDACTED"
+
+	// hookValueDebugRecord 是 DebugHook 在 Before 阶段写入 HookContext 的调试记录键。
+	hookValueDebugRecord = "kit.debug_record"
+
+	// harVersion 是 HAR 导出使用的规范版本。
+	harVersion = "1.2"
+	// harCreatorName 是 HAR 导出中 creator 字段的名称。
+	harCreatorName = "github.com/fsyyft-go/kit/net/http"
+)
+
+// 以下为 DebugHook 的默认参数配置，可通过 DebugOption 覆盖。
+var (
+	// debugRedactHeadersDefault 为默认脱敏的请求头和响应头。
+	debugRedactHeadersDefault = []string{
+		"Authorization",
+		"Proxy-Authorization",
+		"Cookie",
+		"Set-Cookie",
+		"X-Api-Key",
+		"X-Auth-Token",
+		"X-Amz-Security-Token",
+	}
+	// debugRedactQueryDefault 为默认脱敏的查询参数和表单字段，比较时不区分大小写。
+	debugRedactQueryDefault = []string{
+		"access_token",
+		"api_key",
+		"apikey",
+		"password",
+		"secret",
+		"signature",
+		"token",
+		"X-Amz-Credential",
+		"X-Amz-Security-Token",
+		"X-Amz-Signature",
+	}
+	// debugMaxBodySizeDefault 为记录请求体和响应体的最大字节数默认值。
+	debugMaxBodySizeDefault int64 = 64 << 10
+)
+
+type (
+	// DebugOption 定义修改 DebugHook 配置的函数。
+	DebugOption func(h *DebugHook)
+
+	// DebugHook 在请求发送前记录脱敏后的请求快照，并可在请求完成后补充响应，用于复现失败的调用。
+	//
+	// 记录通过 [DebugRecordFrom] 从 HookContext 读取，可渲染为 curl 命令或 HAR 条目。
+	// 注册到客户端后，慢请求日志和错误日志会附带 curl 字段。所有方法都可以并发调用。
+	DebugHook struct {
+		// redactHeaders 是需要脱敏的规范化请求头名称。
+		redactHeaders map[string]struct{}
+		// redactQuery 是需要脱敏的小写查询参数和表单字段名称。
+		redactQuery map[string]struct{}
+		// maxBodySize 是记录请求体和响应体的最大字节数，负值表示不限制。
+		maxBodySize int64
+		// response 表示是否在 After 阶段记录响应。
+		response bool
+	}
+
+	// DebugRecord 是一次请求的脱敏快照，请求头、查询参数和表单字段中的敏感值已替换为 RedactedValue。
+	DebugRecord struct {
+		// StartedAt 是请求开始时间。
+		StartedAt time.Time
+		// Duration 是请求耗时，请求完成前为 0。
+		Duration time.Duration
+		// Method 是 HTTP 方法。
+		Method string
+		// URL 是脱敏后的请求地址。
+		URL string
+		// Host 是与 URL 主机不同的 Host 请求头，相同时为空。
+		Host string
+		// Proto 是请求协议版本，例如 HTTP/1.1。
+		Proto string
+		// Header 是脱敏后的请求头。
+		Header http.Header
+		// Body 是请求体内容，表单请求体中的敏感字段已脱敏。
+		Body []byte
+		// BodyOmitted 表示请求体超过上限或无法读取，未被记录。
+		BodyOmitted bool
+		// Error 是请求返回的原始错误信息，请求成功时为空。
+		Error string
+		// Response 是响应快照；未启用 WithDebugResponse 或请求失败时为 nil。
+		Response *DebugResponse
+	}
+
+	// DebugResponse 是响应的脱敏快照。
+	DebugResponse struct {
+		// StatusCode 是响应状态码。
+		StatusCode int
+		// Status 是响应状态文本，例如 "200 OK"。
+		Status string
+		// Proto 是响应协议版本。
+		Proto string
+		// Header 是脱敏后的响应头。
+		Header http.Header
+		// Body 是响应体内容。
+		Body []byte
+		// BodyOmitted 表示响应体长度未知、超过上限或无法读取，未被记录。
+		BodyOmitted bool
+	}
+
+	// HAR 是 HTTP Archive 1.2 的顶层对象，可直接序列化为 .har 文件。
+	HAR struct {
+		Log HARLog `json:"log"`
+	}
+
+	// HARLog 是 HAR 的 log 对象。
+	HARLog struct {
+		Version string     `json:"version"`
+		Creator HARCreator `json:"creator"`
+		Entries []HAREntry `json:"entries"`
+	}
+
+	// HARCreator 是生成 HAR 的程序信息。
+	HARCreator struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+	}
+
+	// HAREntry 是 HAR 中的一次请求。
+	HAREntry struct {
+		StartedDateTime string      `json:"startedDateTime"`
+		Time            float64     `json:"time"`
+		Request         HARRequest  `json:"request"`
+		Response        HARResponse `json:"response"`
+		Cache           struct{}    `json:"cache"`
+		Timings         HARTimings  `json:"timings"`
+		Comment         string      `json:"comment,omitempty"`
+	}
+
+	// HARRequest 是 HAR 条目的请求部分。
+	HARRequest struct {
+		Method      string         `json:"method"`
+		URL         string         `json:"url"`
+		HTTPVersion string         `json:"httpVersion"`
+		Cookies     []HARNameValue `json:"cookies"`
+		Headers     []HARNameValue `json:"headers"`
+		QueryString []HARNameValue `json:"queryString"`
+		PostData    *HARPostData   `json:"postData,omitempty"`
+		HeadersSize int            `json:"headersSize"`
+		BodySize    int            `json:"bodySize"`
+	}
+
+	// HARResponse 是 HAR 条目的响应部分；请求失败或未记录响应时状态码为 0。
+	HARResponse struct {
+		Status      int            `json:"status"`
+		StatusText  string         `json:"statusText"`
+		HTTPVersion string         `json:"httpVersion"`
+		Cookies     []HARNameValue `json:"cookies"`
+		Headers     []HARNameValue `json:"headers"`
+		Content     HARContent     `json:"content"`
+		RedirectURL string         `json:"redirectURL"`
+		HeadersSize int            `json:"headersSize"`
+		BodySize    int            `json:"bodySize"`
+	}
+
+	// HARNameValue 是 HAR 中的名称-值对，用于请求头、查询参数和 Cookie。
+	HARNameValue struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	}
+
+	// HARPostData 是 HAR 请求的请求体。
+	HARPostData struct {
+		MimeType string `json:"mimeType"`
+		Text     string `json:"text"`
+		Comment  string `json:"comment,omitempty"`
+	}
+
+	// HARContent 是 HAR 响应的响应体，非 UTF-8 内容以 base64 编码。
+	HARContent struct {
+		Size     int    `json:"size"`
+		MimeType string `json:"mimeType"`
+		Text     string `json:"text,omitempty"`
+		Encoding string `json:"encoding,omitempty"`
+		Comment  string `json:"comment,omitempty"`
+	}
+
+	// HARTimings 是 HAR 条目的耗时，单位为毫秒；本包只记录总耗时，计入 wait。
+	HARTimings struct {
+		Send    float64 `json:"send"`
+		Wait    float64 `json:"wait"`
+		Receive float64 `json:"receive"`
+	}
+)
+
+// WithDebugRedactHeaders 追加需要脱敏的请求头和响应头。
+//
+// 默认脱敏 Authorization、Proxy-Authorization、Cookie、Set-Cookie、X-Api-Key、X-Auth-Token 和 X-Amz-Security-Token。
+//
+// 参数：
+//   - names: 请求头名称，不区分大小写。
+//
+// 返回：
+//   - DebugOption: 应用于 [NewDebugHook] 的脱敏配置项。
+func WithDebugRedactHeaders(names ...string) DebugOption {
+	return func(h *DebugHook) {
+		for _, name := range names {
+			h.redactHeaders[http.CanonicalHeaderKey(name)] = struct{}{}
+		}
+	}
+}
+
+// WithDebugRedactQuery 追加需要脱敏的查询参数和表单字段。
+//
+// 默认脱敏 access_token、api_key、apikey、password、secret、signature、token 以及 SigV4 预签名参数。
+//
+// 参数：
+//   - keys: 参数名称，不区分大小写。
+//
+// 返回：
+//   - DebugOption: 应用于 [NewDebugHook] 的脱敏配置项。
+func WithDebugRedactQuery(keys ...string) DebugOption {
+	return func(h *DebugHook) {
+		for _, key := range keys {
+			h.redactQuery[strings.ToLower(key)] = struct{}{}
+		}
+	}
+}
+
+// WithDebugMaxBodySize 设置记录请求体和响应体的最大字节数。
+//
+// 超过上限的请求体和响应体不记录，请求和响应仍保持完整。
+//
+// 参数：
+//   - size: 最大字节数；0 表示不记录请求体和响应体，负值表示不限制。
+//
+// 返回：
+//   - DebugOption: 应用于 [NewDebugHook] 的请求体大小配置项。
+func WithDebugMaxBodySize(size int64) DebugOption {
+	return func(h *DebugHook) {
+		h.maxBodySize = size
+	}
+}
+
+// WithDebugResponse 设置是否在请求完成后记录响应。
+//
+// 响应体只在 Content-Length 已知且不超过上限时记录，读取的内容会重新拼接回响应体，
+// 长度未知的流式响应（例如 SSE、NDJSON）只记录状态码和响应头，避免阻塞调用方。
+//
+// 参数：
+//   - enable: 为 true 时记录响应。
+//
+// 返回：
+//   - DebugOption: 应用于 [NewDebugHook] 的响应记录配置项。
+func WithDebugResponse(enable bool) DebugOption {
+	return func(h *DebugHook) {
+		h.response = enable
+	}
+}
+
+// NewDebugHook 创建记录请求快照的调试 Hook。
+//
+// 参数：
+//   - opts: 脱敏名单、请求体上限和响应记录等配置。
+//
+// 返回：
+//   - *DebugHook: 可通过 [WithDebug] 或 HookManager 注册的调试 Hook。
+func NewDebugHook(opts ...DebugOption) *DebugHook {
+	h := &DebugHook{
+		redactHeaders: make(map[string]struct{}),
+		redactQuery:   make(map[string]struct{}),
+		maxBodySize:   debugMaxBodySizeDefault,
+	}
+	WithDebugRedactHeaders(debugRedactHeadersDefault...)(h)
+	WithDebugRedactQuery(debugRedactQueryDefault...)(h)
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// DebugRecordFrom 从 HookContext 读取 DebugHook 记录的请求快照。
+//
+// 参数：
+//   - ctx: 当前 HTTP Hook 上下文。
+//
+// 返回：
+//   - *DebugRecord: 请求快照；After 阶段读取时已补充耗时、错误和响应。
+//   - bool: 请求经过 DebugHook 时返回 true。
+func DebugRecordFrom(ctx *HookContext) (*DebugRecord, bool) {
+	if nil == ctx {
+		return nil, false
+	}
+	value, ok := ctx.GetHookValue(hookValueDebugRecord)
+	if !ok {
+		return nil, false
+	}
+	record, ok := value.(*DebugRecord)
+	return record, ok
+}
+
+// Before 记录脱敏后的请求快照。
+//
+// 请求体没有 GetBody 时会被读入内存并替换为可重复读取的副本；请求体超过上限时不记录，
+// 已读取的部分会与剩余内容重新拼接，主请求仍发送完整的请求体。
+//
+// 参数：
+//   - ctx: 当前 HTTP Hook 上下文。
+//
+// 返回：
+//   - error: 固定返回 nil，记录失败不会影响请求。
+func (h *DebugHook) Before(ctx *HookContext) error {
+	req := ctx.Request()
+	record := &DebugRecord{
+		StartedAt: ctx.StartTime(),
+		Method:    req.Method,
+		URL:       h.redactURL(req.URL),
+		Proto:     req.Proto,
+		Header:    h.redactHeader(req.Header),
+	}
+	if "" == record.Proto {
+		record.Proto = "HTTP/1.1"
+	}
+	if "" != req.Host && req.Host != req.URL.Host {
+		record.Host = req.Host
+	}
+
+	body, ok := h.snapshotBody(req)
+	record.BodyOmitted = !ok
+	if ok && len(body) > 0 {
+		record.Body = h.redactForm(req.Header.Get("Content-Type"), body)
+	}
+	ctx.SetHookValue(hookValueDebugRecord, record)
+	return nil
+}
+
+// After 为请求快照补充耗时和错误，启用 WithDebugResponse 时记录响应。
+//
+// 参数：
+//   - ctx: 当前 HTTP Hook 上下文，用于读取 Before 阶段保存的快照和原始响应。
+//
+// 返回：
+//   - error: 固定返回 nil。
+func (h *DebugHook) After(ctx *HookContext) error {
+	record, ok := DebugRecordFrom(ctx)
+	if !ok {
+		return nil
+	}
+	record.Duration = ctx.Duration()
+	if err := ctx.OriginError(); nil != err {
+		record.Error = err.Error()
+	}
+
+	resp := ctx.originResult
+	if !h.response || nil == resp {
+		return nil
+	}
+	response := &DebugResponse{
+		StatusCode:  resp.StatusCode,
+		Status:      resp.Status,
+		Proto:       resp.Proto,
+		Header:      h.redactHeader(resp.Header),
+		BodyOmitted: true,
+	}
+	if nil == resp.Body || http.NoBody == resp.Body || 0 == resp.ContentLength {
+		response.BodyOmitted = false
+	} else if resp.ContentLength > 0 && (h.maxBodySize < 0 || resp.ContentLength <= h.maxBodySize) {
+		body, ok := readLimited(resp.Body, h.maxBodySize)
+		// 无论是否读取成功，都把已读取的部分与剩余内容重新拼接，调用方仍读取完整的响应体。
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+		if ok {
+			response.Body = body
+			response.BodyOmitted = false
+		}
+	}
+	record.Response = response
+	return nil
+}
+
+// snapshotBody 读取请求体内容，并保证请求仍能发送完整的请求体。
+//
+// 参数：
+//   - req: 当前请求。
+//
+// 返回：
+//   - []byte: 请求体内容；没有请求体时为 nil。
+//   - bool: 请求体是否被记录。
+func (h *DebugHook) snapshotBody(req *http.Request) ([]byte, bool) {
+	if nil == req.Body || http.NoBody == req.Body {
+		return nil, true
+	}
+	if 0 == h.maxBodySize || (h.maxBodySize > 0 && req.ContentLength > h.maxBodySize) {
+		return nil, false
+	}
+
+	if nil != req.GetBody {
+		// GetBody 返回独立的副本，无需改动请求的请求体。
+		rc, err := req.GetBody()
+		if nil != err {
+			return nil, false
+		}
+		defer func() { _ = rc.Close() }()
+		return readLimited(rc, h.maxBodySize)
+	}
+
+	body, ok := readLimited(req.Body, h.maxBodySize)
+	if !ok {
+		// 超过上限或读取失败，把已读取的部分与剩余内容重新拼接，交由请求继续发送。
+		req.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(body), req.Body), req.Body}
+		return nil, false
+	}
+	_ = req.Body.Close()
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	return body, true
+}
+
+// redactHeader 复制请求头并替换敏感请求头的取值。
+//
+// 参数：
+//   - header: 原始请求头或响应头。
+//
+// 返回：
+//   - http.Header: 脱敏后的副本。
+func (h *DebugHook) redactHeader(header http.Header) http.Header {
+	redacted := header.Clone()
+	if nil == redacted {
+		return http.Header{}
+	}
+	for name, values := range redacted {
+		if _, ok := h.redactHeaders[http.CanonicalHeaderKey(name)]; ok {
+			redacted[name] = make([]string, len(values))
+			for i := range values {
+				redacted[name][i] = RedactedValue
+			}
+		}
+	}
+	return redacted
+}
+
+// redactURL 返回替换了密码和敏感查询参数的地址。
+//
+// 参数：
+//   - u: 原始请求地址。
+//
+// 返回：
+//   - string: 脱敏后的地址，查询参数保留原始顺序。
+func (h *DebugHook) redactURL(u *url.URL) string {
+	redacted := *u
+	if nil != u.User {
+		if _, ok := u.User.Password(); ok {
+			redacted.User = url.UserPassword(u.User.Username(), RedactedValue)
+		}
+	}
+	redacted.RawQuery = h.redactPairs(u.RawQuery)
+	return redacted.String()
+}
+
+// redactForm 对 application/x-www-form-urlencoded 请求体中的敏感字段脱敏。
+//
+// 参数：
+//   - contentType: 请求的 Content-Type。
+//   - body: 请求体内容。
+//
+// 返回：
+//   - []byte: 表单请求体返回脱敏后的副本，其他类型原样返回。
+func (h *DebugHook) redactForm(contentType string, body []byte) []byte {
+	if mediaType, _, err := mime.ParseMediaType(contentType); nil != err || "application/x-www-form-urlencoded" != mediaType {
+		return body
+	}
+	return []byte(h.redactPairs(string(body)))
+}
+
+// redactPairs 替换 URL 编码的键值对中敏感字段的取值。
+//
+// 参数：
+//   - raw: 形如 a=1&b=2 的 URL 编码字符串。
+//
+// 返回：
+//   - string: 脱敏后的字符串，未命中的键值对保持原样。
+func (h *DebugHook) redactPairs(raw string) string {
+	if "" == raw {
+		return raw
+	}
+	pairs := strings.Split(raw, "&")
+	for i, pair := range pairs {
+		key, _, found := strings.Cut(pair, "=")
+		if !found {
+			continue
+		}
+		if unescaped, err := url.QueryUnescape(key); nil == err {
+			key = unescaped
+		}
+		if _, ok := h.redactQuery[strings.ToLower(key)]; ok {
+			pairs[i] = pair[:strings.IndexByte(pair, '=')+1] + RedactedValue
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+// Curl 把请求快照渲染为单行 curl 命令，参数使用 POSIX shell 单引号转义。
+//
+// 请求头按名称排序；请求体未被记录时命令末尾附带注释说明。
+//
+// 返回：
+//   - string: 可直接粘贴到 shell 执行的 curl 命令。
+func (r *DebugRecord) Curl() string {
+	args := []string{"curl"}
+	switch r.Method {
+	case "", http.MethodGet:
+	case http.MethodHead:
+		args = append(args, "--head")
+	default:
+		args = append(args, "-X", r.Method)
+	}
+	args = append(args, shellQuote(r.URL))
+
+	if "" != r.Host {
+		args = append(args, "-H", shellQuote("Host: "+r.Host))
+	}
+	for _, kv := range sortedHeader(r.Header) {
+		args = append(args, "-H", shellQuote(kv.Name+": "+kv.Value))
+	}
+	if len(r.Body) > 0 {
+		args = append(args, "--data-binary", shellQuote(string(r.Body)))
+	}
+	if r.BodyOmitted {
+		args = append(args, "# request body omitted")
+	}
+	return strings.Join(args, " ")
+}
+
+// HAREntry 把请求快照转换为 HAR 条目。
+//
+// 返回：
+//   - HAREntry: HAR 1.2 条目；没有响应快照时响应状态码为 0，请求错误写入 comment。
+func (r *DebugRecord) HAREntry() HAREntry {
+	entry := HAREntry{
+		StartedDateTime: r.StartedAt.Format(time.RFC3339Nano),
+		Time:            durationMillis(r.Duration),
+		Request: HARRequest{
+			Method:      r.Method,
+			URL:         r.URL,
+			HTTPVersion: r.Proto,
+			Cookies:     []HARNameValue{},
+			Headers:     sortedHeader(r.Header),
+			QueryString: []HARNameValue{},
+			HeadersSize: -1,
+			BodySize:    len(r.Body),
+		},
+		Response: HARResponse{
+			Cookies: []HARNameValue{},
+			Headers: []HARNameValue{},
+			// 未记录响应时大小未知，按 HAR 规范使用 -1。
+			HeadersSize: -1,
+			BodySize:    -1,
+		},
+		Timings: HARTimings{Wait: durationMillis(r.Duration)},
+		Comment: r.Error,
+	}
+	if "" != r.Host {
+		entry.Request.Headers = append([]HARNameValue{{Name: "Host", Value: r.Host}}, entry.Request.Headers...)
+	}
+	if u, err := url.Parse(r.URL); nil == err {
+		for _, pair := range strings.Split(u.RawQuery, "&") {
+			if "" == pair {
+				continue
+			}
+			key, value, _ := strings.Cut(pair, "=")
+			if unescaped, err := url.QueryUnescape(key); nil == err {
+				key = unescaped
+			}
+			if unescaped, err := url.QueryUnescape(value); nil == err {
+				value = unescaped
+			}
+			entry.Request.QueryString = append(entry.Request.QueryString, HARNameValue{Name: key, Value: value})
+		}
+	}
+	if len(r.Body) > 0 || r.BodyOmitted {
+		entry.Request.PostData = &HARPostData{MimeType: r.Header.Get("Content-Type"), Text: string(r.Body)}
+		if r.BodyOmitted {
+			entry.Request.BodySize = -1
+			entry.Request.PostData.Comment = "request body omitted"
+		}
+	}
+
+	if resp := r.Response; nil != resp {
+		entry.Response.Status = resp.StatusCode
+		entry.Response.StatusText = http.StatusText(resp.StatusCode)
+		if _, text, ok := strings.Cut(resp.Status, " "); ok {
+			// Status 形如 "200 OK"，去掉状态码后即为状态文本。
+			entry.Response.StatusText = text
+		}
+		entry.Response.HTTPVersion = resp.Proto
+		entry.Response.Headers = sortedHeader(resp.Header)
+		entry.Response.RedirectURL = resp.Header.Get("Location")
+		entry.Response.Content = HARContent{Size: len(resp.Body), MimeType: resp.Header.Get("Content-Type")}
+		entry.Response.BodySize = len(resp.Body)
+		if resp.BodyOmitted {
+			entry.Response.Content.Comment = "response body omitted"
+			entry.Response.BodySize = -1
+		} else if utf8.Valid(resp.Body) {
+			entry.Response.Content.Text = string(resp.Body)
+		} else {
+			entry.Response.Content.Text = base64.StdEncoding.EncodeToString(resp.Body)
+			entry.Response.Content.Encoding = "base64"
+		}
+	}
+	return entry
+}
+
+// NewHAR 把多个请求快照组装为 HAR 文档。
+//
+// 参数：
+//   - records: 请求快照，nil 会被忽略。
+//
+// 返回：
+//   - HAR: 可通过 encoding/json 序列化为 .har 文件的文档。
+func NewHAR(records ...*DebugRecord) HAR {
+	har := HAR{Log: HARLog{
+		Version: harVersion,
+		Creator: HARCreator{Name: harCreatorName, Version: harVersion},
+		Entries: make([]HAREntry, 0, len(records)),
+	}}
+	for _, record := range records {
+		if nil != record {
+			har.Log.Entries = append(har.Log.Entries, record.HAREntry())
+		}
+	}
+	return har
+}
+
+// HAR 把请求快照序列化为只包含一个条目的 HAR JSON 文档。
+//
+// 返回：
+//   - []byte: HAR JSON 内容，可保存为 .har 文件后导入浏览器开发者工具。
+//   - error: 序列化失败时返回错误。
+func (r *DebugRecord) HAR() ([]byte, error) {
+	return json.Marshal(NewHAR(r))
+}
+
+// sortedHeader 把请求头转换为按名称排序的名称-值对。
+//
+// 参数：
+//   - header: 请求头或响应头。
+//
+// 返回：
+//   - []HARNameValue: 每个取值一项，名称按字典序排序。
+func sortedHeader(header http.Header) []HARNameValue {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	pairs := make([]HARNameValue, 0, len(names))
+	for _, name := range names {
+		for _, value := range header[name] {
+			pairs = append(pairs, HARNameValue{Name: name, Value: value})
+		}
+	}
+	return pairs
+}
+
+// shellQuote 使用 POSIX shell 单引号转义字符串。
+//
+// 参数：
+//   - s: 原始字符串。
+//
+// 返回：
+//   - string: 单引号包裹的字符串，内部的单引号先结束引号、转义后再重新开始引号。
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// durationMillis 把耗时转换为毫秒。
+//
+// 参数：
+//   - d: 耗时。
+//
+// 返回：
+//   - float64: 毫秒数。
+func durationMillis(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
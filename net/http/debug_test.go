@@ -0,0 +1,356 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	stdhttp "net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordHook 在 After 阶段保存 DebugHook 的记录，用于验证记录先于外层 Hook 完成。
+type recordHook struct {
+	record *DebugRecord
+}
+
+// Before 不做处理。
+func (h *recordHook) Before(*HookContext) error {
+	return nil
+}
+
+// After 保存 DebugHook 的记录。
+func (h *recordHook) After(ctx *HookContext) error {
+	h.record, _ = DebugRecordFrom(ctx)
+	return nil
+}
+
+// TestDebugHook_Record 验证 DebugHook 记录脱敏后的请求快照，且不影响实际发送的请求。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestDebugHook_Record(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		opts        []DebugOption
+		url         string
+		header      map[string]string
+		body        func() io.Reader
+		assert      func(t *testing.T, record *DebugRecord)
+	}{
+		{
+			name:        "success/redact-header-and-query",
+			description: "验证默认名单中的请求头、查询参数和 URL 密码被脱敏，其余内容与查询参数顺序保持不变。",
+			url:         "/items?b=2&Token=abc&a=1&X-Amz-Signature=sig",
+			header:      map[string]string{"Authorization": "Bearer secret", "X-Trace": "t1"},
+			body:        func() io.Reader { return strings.NewReader(`{"id":1}`) },
+			assert: func(t *testing.T, record *DebugRecord) {
+				assert.True(t, strings.HasSuffix(record.URL, "/items?b=2&Token=REDACTED&a=1&X-Amz-Signature=REDACTED"), record.URL)
+				assert.Contains(t, record.URL, "user:REDACTED@")
+				assert.Equal(t, RedactedValue, record.Header.Get("Authorization"))
+				assert.Equal(t, "t1", record.Header.Get("X-Trace"))
+				assert.Equal(t, `{"id":1}`, string(record.Body))
+				assert.False(t, record.BodyOmitted)
+			},
+		},
+		{
+			name:        "success/custom-redaction-and-form",
+			description: "验证自定义名单生效，表单请求体中的敏感字段被脱敏。",
+			opts:        []DebugOption{WithDebugRedactHeaders("x-tenant-key"), WithDebugRedactQuery("PIN")},
+			url:         "/login?pin=1234",
+			header:      map[string]string{"X-Tenant-Key": "k", "Content-Type": "application/x-www-form-urlencoded; charset=utf-8"},
+			body:        func() io.Reader { return strings.NewReader("user=alice&password=p%40ss&pin=0000") },
+			assert: func(t *testing.T, record *DebugRecord) {
+				assert.True(t, strings.HasSuffix(record.URL, "/login?pin=REDACTED"), record.URL)
+				assert.Equal(t, RedactedValue, record.Header.Get("X-Tenant-Key"))
+				assert.Equal(t, "user=alice&password=REDACTED&pin=REDACTED", string(record.Body))
+			},
+		},
+		{
+			name:        "success/body-without-get-body",
+			description: "验证没有 GetBody 的请求体被读入内存后，记录和实际请求都包含完整内容。",
+			url:         "/stream",
+			body:        func() io.Reader { return io.NopCloser(strings.NewReader("stream")) },
+			assert: func(t *testing.T, record *DebugRecord) {
+				assert.Equal(t, "stream", string(record.Body))
+			},
+		},
+		{
+			name:        "boundary/body-too-large",
+			description: "验证请求体超过上限时不记录请求体，实际请求仍发送完整内容。",
+			opts:        []DebugOption{WithDebugMaxBodySize(4)},
+			url:         "/large",
+			body:        func() io.Reader { return io.NopCloser(strings.NewReader("too large")) },
+			assert: func(t *testing.T, record *DebugRecord) {
+				assert.Nil(t, record.Body)
+				assert.True(t, record.BodyOmitted)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			primary := newPrimaryServer(t)
+			hook := &recordHook{}
+			c := NewClient(WithHook(hook), WithDebug(NewDebugHook(tt.opts...)))
+
+			target := strings.Replace(primary.URL, "http://", "http://user:pass@", 1) + tt.url
+			body, err := io.ReadAll(tt.body())
+			require.NoError(t, err)
+			req, err := stdhttp.NewRequestWithContext(context.Background(), stdhttp.MethodPost, target, tt.body())
+			require.NoError(t, err)
+			for name, value := range tt.header {
+				req.Header.Set(name, value)
+			}
+			resp, err := c.Do(context.Background(), req)
+			require.NoError(t, err)
+			assert.Equal(t, "primary:"+string(body), readBody(t, resp))
+			assert.Equal(t, tt.header["Authorization"], req.Header.Get("Authorization"), "原请求头不被修改")
+
+			require.NotNil(t, hook.record)
+			assert.Equal(t, stdhttp.MethodPost, hook.record.Method)
+			assert.Positive(t, hook.record.Duration)
+			assert.Nil(t, hook.record.Response, "未启用 WithDebugResponse 时不记录响应")
+			tt.assert(t, hook.record)
+		})
+	}
+}
+
+// TestDebugHook_Response 验证启用 WithDebugResponse 后记录响应，且调用方仍读取完整的响应体。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestDebugHook_Response(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		opts        []DebugOption
+		handler     stdhttp.HandlerFunc
+		assert      func(t *testing.T, resp *DebugResponse)
+	}{
+		{
+			name:        "success/known-length",
+			description: "验证长度已知的响应体被记录，敏感响应头被脱敏。",
+			handler: func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+				stdhttp.SetCookie(w, &stdhttp.Cookie{Name: "session", Value: "s1"})
+				w.Header().Set("Content-Type", "text/plain")
+				w.WriteHeader(stdhttp.StatusTeapot)
+				_, _ = w.Write([]byte("short and stout"))
+			},
+			assert: func(t *testing.T, resp *DebugResponse) {
+				assert.Equal(t, stdhttp.StatusTeapot, resp.StatusCode)
+				assert.Equal(t, RedactedValue, resp.Header.Get("Set-Cookie"))
+				assert.Equal(t, "short and stout", string(resp.Body))
+				assert.False(t, resp.BodyOmitted)
+			},
+		},
+		{
+			name:        "boundary/unknown-length",
+			description: "验证长度未知的流式响应只记录状态码和响应头。",
+			handler: func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+				_, _ = w.Write([]byte("short and stout"))
+				w.(stdhttp.Flusher).Flush()
+			},
+			assert: func(t *testing.T, resp *DebugResponse) {
+				assert.Equal(t, stdhttp.StatusOK, resp.StatusCode)
+				assert.Nil(t, resp.Body)
+				assert.True(t, resp.BodyOmitted)
+			},
+		},
+		{
+			name:        "boundary/too-large",
+			description: "验证超过上限的响应体不被记录。",
+			opts:        []DebugOption{WithDebugMaxBodySize(4)},
+			handler: func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+				_, _ = w.Write([]byte("short and stout"))
+			},
+			assert: func(t *testing.T, resp *DebugResponse) {
+				assert.Nil(t, resp.Body)
+				assert.True(t, resp.BodyOmitted)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			server := httptest.NewServer(tt.handler)
+			t.Cleanup(server.Close)
+			hook := &recordHook{}
+			opts := append([]DebugOption{WithDebugResponse(true)}, tt.opts...)
+			c := NewClient(WithHook(hook), WithDebug(NewDebugHook(opts...)))
+
+			resp, err := c.Get(context.Background(), server.URL)
+			require.NoError(t, err)
+			assert.Equal(t, "short and stout", readBody(t, resp))
+			require.NotNil(t, hook.record)
+			require.NotNil(t, hook.record.Response)
+			tt.assert(t, hook.record.Response)
+		})
+	}
+}
+
+// TestDebugHook_Error 验证请求失败时记录错误信息，不记录响应。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestDebugHook_Error(t *testing.T) {
+	t.Log("验证请求失败时 DebugRecord 记录错误信息，HAR 条目在 comment 中携带该错误。")
+
+	server := httptest.NewServer(stdhttp.NotFoundHandler())
+	server.Close()
+	hook := &recordHook{}
+	c := NewClient(WithHook(hook), WithDebug(NewDebugHook(WithDebugResponse(true))))
+
+	_, err := c.Get(context.Background(), server.URL)
+	require.Error(t, err)
+	require.NotNil(t, hook.record)
+	assert.NotEmpty(t, hook.record.Error)
+	assert.Nil(t, hook.record.Response)
+	assert.Equal(t, hook.record.Error, hook.record.HAREntry().Comment)
+	assert.Zero(t, hook.record.HAREntry().Response.Status)
+}
+
+// TestDebugRecord_Curl 验证请求快照渲染为 curl 命令。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestDebugRecord_Curl(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		record      DebugRecord
+		want        string
+	}{
+		{
+			name:        "success/get",
+			description: "验证 GET 请求不输出 -X，请求头按名称排序。",
+			record: DebugRecord{
+				Method: stdhttp.MethodGet,
+				URL:    "https://example.test/items?a=1",
+				Header: stdhttp.Header{"X-B": {"2"}, "X-A": {"1"}},
+			},
+			want: `curl 'https://example.test/items?a=1' -H 'X-A: 1' -H 'X-B: 2'`,
+		},
+		{
+			name:        "success/post-with-quote",
+			description: "验证请求体中的单引号被正确转义，Host 与 URL 不同时输出 Host 请求头。",
+			record: DebugRecord{
+				Method: stdhttp.MethodPost,
+				URL:    "http://10.0.0.1/api",
+				Host:   "api.example.test",
+				Header: stdhttp.Header{"Content-Type": {"application/json"}},
+				Body:   []byte(`{"name":"O'Neil"}`),
+			},
+			want: `curl -X POST 'http://10.0.0.1/api' -H 'Host: api.example.test' -H 'Content-Type: application/json' --data-binary '{"name":"O'\''Neil"}'`,
+		},
+		{
+			name:        "boundary/head-and-omitted-body",
+			description: "验证 HEAD 请求使用 --head，未记录的请求体以注释说明。",
+			record: DebugRecord{
+				Method:      stdhttp.MethodHead,
+				URL:         "http://example.test/",
+				BodyOmitted: true,
+			},
+			want: `curl --head 'http://example.test/' # request body omitted`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			assert.Equal(t, tt.want, tt.record.Curl())
+		})
+	}
+}
+
+// TestDebugRecord_HAR 验证请求快照导出为 HAR 文档。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestDebugRecord_HAR(t *testing.T) {
+	t.Log("验证 HAR 文档包含版本、请求、查询参数、请求体、响应与耗时，非 UTF-8 响应体以 base64 编码。")
+
+	started := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	record := &DebugRecord{
+		StartedAt: started,
+		Duration:  1500 * time.Microsecond,
+		Method:    stdhttp.MethodPost,
+		URL:       "https://example.test/items?q=a%20b&token=REDACTED",
+		Proto:     "HTTP/1.1",
+		Header:    stdhttp.Header{"Content-Type": {"application/json"}},
+		Body:      []byte(`{"id":1}`),
+		Response: &DebugResponse{
+			StatusCode: stdhttp.StatusCreated,
+			Status:     "201 Created",
+			Proto:      "HTTP/1.1",
+			Header:     stdhttp.Header{"Content-Type": {"application/octet-stream"}},
+			Body:       []byte{0xff, 0x00},
+		},
+	}
+
+	data, err := record.HAR()
+	require.NoError(t, err)
+	var har HAR
+	require.NoError(t, json.Unmarshal(data, &har))
+	assert.Equal(t, "1.2", har.Log.Version)
+	require.Len(t, har.Log.Entries, 1)
+
+	entry := har.Log.Entries[0]
+	assert.Equal(t, "2025-01-02T03:04:05Z", entry.StartedDateTime)
+	assert.InDelta(t, 1.5, entry.Time, 1e-9)
+	assert.InDelta(t, 1.5, entry.Timings.Wait, 1e-9)
+	assert.Equal(t, stdhttp.MethodPost, entry.Request.Method)
+	assert.Equal(t, []HARNameValue{{Name: "q", Value: "a b"}, {Name: "token", Value: "REDACTED"}}, entry.Request.QueryString)
+	assert.Equal(t, []HARNameValue{{Name: "Content-Type", Value: "application/json"}}, entry.Request.Headers)
+	require.NotNil(t, entry.Request.PostData)
+	assert.Equal(t, `{"id":1}`, entry.Request.PostData.Text)
+	assert.Equal(t, stdhttp.StatusCreated, entry.Response.Status)
+	assert.Equal(t, "Created", entry.Response.StatusText)
+	assert.Equal(t, "base64", entry.Response.Content.Encoding)
+	assert.Equal(t, "/wA=", entry.Response.Content.Text)
+	assert.Equal(t, 2, entry.Response.Content.Size)
+
+	assert.Empty(t, NewHAR(nil).Log.Entries)
+}
+
+// TestDebugHook_HookManager 验证直接注册到 HookManager 的 DebugHook 在 After 阶段补充记录。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestDebugHook_HookManager(t *testing.T) {
+	t.Log("验证 DebugHook 的 After 先于之前注册的 Hook 执行并写入错误，未经过 DebugHook 的上下文读取不到记录。")
+
+	req := httptest.NewRequest(stdhttp.MethodGet, "http://example.test/?token=abc", nil)
+	hm := NewHookManager()
+	hm.AddHook(&recordHook{})
+	hm.AddHook(NewDebugHook())
+
+	hookCtx := NewHookContext(t.Context(), req.Method, req.URL.String(), req)
+	require.NoError(t, hm.Before(hookCtx))
+	hookCtx.SetResult(nil, errors.New("request failed"))
+	require.NoError(t, hm.After(hookCtx))
+
+	record, ok := DebugRecordFrom(hookCtx)
+	require.True(t, ok)
+	assert.Equal(t, "request failed", record.Error)
+	assert.Equal(t, `curl 'http://example.test/?token=REDACTED'`, record.Curl())
+
+	_, ok = DebugRecordFrom(NewHookContext(t.Context(), req.Method, req.URL.String(), req))
+	assert.False(t, ok)
+}
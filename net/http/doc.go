@@ -4,9 +4,25 @@
 
 // Package http 提供可配置的 HTTP client、请求 Hook，以及 HTTPS 证书辅助函数。
 //
-// NewClient 基于标准库 http.Client 组装超时、连接池、代理和日志/trace Hook。
+// NewClient 基于标准库 http.Client 组装超时、连接池、代理和日志/trace Hook；WithTraceSampling 使 trace Hook
+// 按 config.DebugRegistry 的 TraceSampleRate 采样，采样率可在运行时修改。
 // 当未通过 WithTransport 显式提供自定义 Transport 时，默认 Transport 会将
 // TLSClientConfig.InsecureSkipVerify 设为 true，也就是默认跳过 TLS 证书校验；
-// 如需启用证书校验，调用方需要通过 WithTransport 显式调整 TLS 配置。
+// 通过 WithRootCAs 或 WithRootCAFiles 设置根证书后启用证书校验，WithClientCertificate 配置 mTLS 客户端证书，
+// WithServerName 设置 SNI 与证书主机名校验使用的名称；证书加载失败时请求返回 ErrInvalidTLSConfig。
+// WithSigner 通过 Hook 链为请求签名，内置 HMACSigner 与兼容 AWS SigV4 的 SigV4Signer，
+// 凭证由 CredentialsProvider 提供，并可根据响应 Date 头校正时钟偏移。
+// 各请求方法接受仅作用于本次调用的 RequestOption：WithTimeoutOverride 覆盖总超时时间，
+// WithoutHooks 跳过全部或指定的 Hook，使同一个客户端可以同时服务延迟敏感接口和批量接口。
+// GetNDJSON 与 DecodeNDJSON 逐行消费 NDJSON 流式响应；SSEClient 订阅 Server-Sent Events，
+// 断线后按服务端 retry 字段自动重连并通过 Last-Event-ID 续传。
+// WithDebug 记录脱敏后的请求与响应快照，DebugRecordFrom 从 HookContext 读取记录并导出为 curl 命令或 HAR 条目，
+// 慢请求日志和错误日志会附带 curl 字段。
+// WithMirror 按比例把请求异步复制到影子服务，并发受限、响应被丢弃，不影响主请求的耗时与结果。
+// WithRateLimit 以令牌桶包装请求体和响应体，限制同一客户端的上传与下载带宽。
+// WithMaxResponseBytes 限制响应体（解压后）可读取的字节数，DecodeJSONLimited 在读取上限内解码 JSON 响应，
+// 超限时均返回 ErrResponseTooLarge。
+// WithRequestCompression 按阈值 gzip 压缩请求体；客户端内置 br、zstd、gzip、deflate 响应解码器并透明解码响应体，
+// WithResponseDecoder 注册其它编码或替换内置实现，WithCompressionReporter 报告压缩前后的大小。
 // GetCertificates 与 GetCertificatesExpirestime 用于发起 HTTPS 请求并提取对端证书链及剩余有效期。
 package http
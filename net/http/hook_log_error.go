@@ -28,7 +28,7 @@ func (h *logErrorHook) Before(ctx *HookContext) error {
 	return nil
 }
 
-// After 在请求返回原始错误时异步写入错误日志。
+// After 在请求返回原始错误时异步写入错误日志；请求经过 DebugHook 时附带 curl 字段。
 //
 // 参数：
 //   - ctx: 当前 HTTP Hook 上下文，用于读取原始错误和请求 URL。
@@ -38,8 +38,12 @@ func (h *logErrorHook) Before(ctx *HookContext) error {
 func (h *logErrorHook) After(ctx *HookContext) error {
 	if nil != ctx.OriginError() {
 		// 不等待日志任务执行完成，协程池提交失败也不改变原始请求结果。
+		logger := h.logger
+		if record, ok := DebugRecordFrom(ctx); ok {
+			logger = logger.WithField("curl", record.Curl())
+		}
 		_ = kitgoroutine.Submit(func() {
-			h.logger.
+			logger.
 				WithField("error", ctx.OriginError()).
 				WithField("url", ctx.Request().URL.String()).
 				Error("")
@@ -33,7 +33,7 @@ func (h *slowHook) Before(ctx *HookContext) error {
 	return nil
 }
 
-// After 在请求耗时超过阈值时异步写入慢请求日志。
+// After 在请求耗时超过阈值时异步写入慢请求日志；请求经过 DebugHook 时附带 curl 字段。
 //
 // 参数：
 //   - ctx: 当前 HTTP Hook 上下文，用于读取请求耗时和 URL。
@@ -43,8 +43,12 @@ func (h *slowHook) Before(ctx *HookContext) error {
 func (h *slowHook) After(ctx *HookContext) error {
 	if ctx.Duration() > h.threshold {
 		// 不等待日志任务执行完成，协程池提交失败也不改变原始请求结果。
+		logger := h.logger
+		if record, ok := DebugRecordFrom(ctx); ok {
+			logger = logger.WithField("curl", record.Curl())
+		}
 		_ = kitgoroutine.Submit(func() {
-			h.logger.
+			logger.
 				WithField("duration", ctx.Duration()).
 				WithField("url", ctx.Request().URL.String()).
 				Warn("")
@@ -18,6 +18,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	kitconfig "github.com/fsyyft-go/kit/config"
 	kitlog "github.com/fsyyft-go/kit/log"
 )
 
@@ -340,6 +341,52 @@ func TestTraceHook_BeforeAndAfter(t *testing.T) {
 	assert.NoError(t, hook.After(hookCtx))
 }
 
+// TestSampledTraceHook 验证采样 trace Hook 按调试开关的采样率决定是否注入 httptrace。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestSampledTraceHook(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		rate        float64
+		wantTraced  bool
+	}{
+		{name: "success/rate-one", description: "验证采样率为 1 时每个请求都被追踪。", rate: 1, wantTraced: true},
+		{name: "success/rate-zero", description: "验证采样率为 0 时请求不被追踪，After 不输出日志。", rate: 0, wantTraced: false},
+	}
+
+	logger, err := kitlog.NewStdLogger("")
+	require.NoError(t, err)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			registry := kitconfig.NewDebugRegistry(kitconfig.DebugFlags{TraceSampleRate: tt.rate})
+			hook := NewSampledTraceHook(logger, registry)
+			req := httptest.NewRequest(stdhttp.MethodGet, "https://example.test", nil)
+			hookCtx := NewHookContext(t.Context(), req.Method, req.URL.String(), req)
+
+			require.NoError(t, hook.Before(hookCtx))
+			_, traced := hookCtx.GetHookValue("traceInfo")
+			assert.Equal(t, tt.wantTraced, traced)
+			assert.Equal(t, tt.wantTraced, nil != httptrace.ContextClientTrace(hookCtx.Request().Context()))
+			assert.NoError(t, hook.After(hookCtx))
+		})
+	}
+
+	// 采样率在运行时修改后立即生效。
+	registry := kitconfig.NewDebugRegistry(kitconfig.DebugFlags{})
+	hook := NewSampledTraceHook(logger, registry)
+	registry.Update(func(f *kitconfig.DebugFlags) { f.TraceSampleRate = 1 })
+	req := httptest.NewRequest(stdhttp.MethodGet, "https://example.test", nil)
+	hookCtx := NewHookContext(t.Context(), req.Method, req.URL.String(), req)
+	require.NoError(t, hook.Before(hookCtx))
+	_, traced := hookCtx.GetHookValue("traceInfo")
+	assert.True(t, traced)
+}
+
 // TestBuiltInHooks_BasicBehavior 验证内置慢请求与错误日志 Hook 的基础行为。
 //
 // 该测试不依赖日志输出内容，仅验证构造函数保存阈值、Before 无副作用、After 在慢请求或错误请求场景下稳定返回 nil。
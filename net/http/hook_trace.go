@@ -6,10 +6,12 @@ package http
 
 import (
 	"crypto/tls"
+	"math/rand/v2"
 	"net/http/httptrace"
 	"net/textproto"
 	"time"
 
+	kitconfig "github.com/fsyyft-go/kit/config"
 	kitlog "github.com/fsyyft-go/kit/log"
 	kitgoroutine "github.com/fsyyft-go/kit/runtime/goroutine"
 )
@@ -18,6 +20,7 @@ type (
 	// traceHook 通过 httptrace 采集一次 HTTP 请求的关键阶段耗时。
 	traceHook struct {
 		logger kitlog.Logger // logger 是输出 trace 调试日志时使用的日志记录器。
+		sample func() bool   // sample 判断本次请求是否追踪，为 nil 时追踪所有请求。
 	}
 
 	// traceInfo 保存 httptrace.ClientTrace 回调采集到的时间点和事件数据。
@@ -108,7 +111,7 @@ func (i *traceInfo) TLSUseTime() time.Duration {
 	return i.TimeTLSHandshakeDone.Sub(i.TimeTLSHandshakeStart)
 }
 
-// Before 在请求发送前注入 httptrace.ClientTrace。
+// Before 在请求发送前注入 httptrace.ClientTrace；设置了采样时，未被采样的请求不做处理。
 //
 // 参数：
 //   - ctx: 当前 HTTP Hook 上下文，Before 会替换其中请求对象的 Context。
@@ -116,6 +119,11 @@ func (i *traceInfo) TLSUseTime() time.Duration {
 // 返回：
 //   - error: 固定返回 nil。
 func (h *traceHook) Before(ctx *HookContext) error {
+	// 未被采样的请求不注入 ClientTrace，After 也不会输出日志。
+	if nil != h.sample && !h.sample() {
+		return nil
+	}
+
 	traceInfo := &traceInfo{
 		TimeConnectStart:    make([]time.Time, 0),
 		NetworkConnectStart: make([]string, 0),
@@ -269,3 +277,26 @@ func NewTraceHook(logger kitlog.Logger) *traceHook {
 	}
 	return h
 }
+
+// NewSampledTraceHook 创建一个按调试开关采样的 trace Hook。
+//
+// 每个请求以 registry.TraceSampleRate 的概率被追踪，采样率在运行时修改后立即生效；
+// 采样率为 0 时不追踪任何请求，开销只有一次原子读取。其余行为与 [NewTraceHook] 相同。
+//
+// 参数：
+//   - logger: 输出 trace 调试日志时使用的日志记录器。
+//   - registry: 提供采样率的调试开关注册表；为 nil 时使用 config.DefaultDebugRegistry。
+//
+// 返回：
+//   - *traceHook: 可注册到 HookManager 的 trace Hook。
+func NewSampledTraceHook(logger kitlog.Logger, registry *kitconfig.DebugRegistry) *traceHook {
+	if nil == registry {
+		registry = kitconfig.DefaultDebugRegistry
+	}
+	h := NewTraceHook(logger)
+	h.sample = func() bool {
+		rate := registry.TraceSampleRate()
+		return rate >= 1 || (rate > 0 && rand.Float64() < rate)
+	}
+	return h
+}
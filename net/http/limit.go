@@ -0,0 +1,165 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+const (
+	// maxJSONBytesDefault 是 DecodeJSONLimited 未指定上限时允许读取的字节数。
+	maxJSONBytesDefault = 10 << 20
+)
+
+var (
+	_ http.RoundTripper = (*limitTransport)(nil)
+	_ io.ReadCloser     = (*limitedBody)(nil)
+
+	// ErrResponseTooLarge 表示响应体超过了允许读取的字节数。
+	//
+	// 可能由 WithMaxResponseBytes 限制的请求或 DecodeJSONLimited 返回，调用方可以使用 errors.Is 判断该错误。
+	ErrResponseTooLarge = errors.New("http response body too large")
+)
+
+type (
+	// limitTransport 限制响应体可读取字节数的 RoundTripper。
+	limitTransport struct {
+		base     http.RoundTripper // 实际发送请求的传输层。
+		maxBytes int64             // 响应体允许读取的最大字节数。
+	}
+
+	// limitedBody 读取超过上限时返回 ErrResponseTooLarge 的 io.ReadCloser。
+	limitedBody struct {
+		body      io.ReadCloser // 原始响应体。
+		maxBytes  int64         // 允许读取的最大字节数。
+		remaining int64         // 剩余可读取的字节数。
+	}
+)
+
+// WithMaxResponseBytes 限制客户端读取响应体的字节数。
+//
+// 响应头声明的 Content-Length 超过上限时，请求直接返回包装了 ErrResponseTooLarge 的错误且不读取响应体；
+// 未声明长度（例如分块传输）时，读取超过上限的部分会返回 ErrResponseTooLarge。上限作用于解压后的内容，
+// 可以同时防御超大响应和压缩炸弹。GetNDJSON 等流式接口同样受该上限约束。
+//
+// 参数：
+//   - maxBytes: 单个响应体允许读取的最大字节数；小于等于 0 表示不限制。
+//
+// 返回：
+//   - Option: 应用于 [NewClient] 的响应体大小限制配置项。
+func WithMaxResponseBytes(maxBytes int64) Option {
+	return func(c *client) {
+		c.maxResponseBytes = maxBytes
+	}
+}
+
+// DecodeJSONLimited 读取不超过 maxBytes 字节的响应体并解码为 JSON。
+//
+// 适合解码来自不可信服务的响应：Content-Length 超过上限时不读取响应体，未声明长度时最多读取 maxBytes+1 字节，
+// 避免一次性分配无界内存。DecodeJSONLimited 不检查状态码，也不关闭响应体，调用方仍负责关闭 Body。
+//
+// 参数：
+//   - resp: 待解码的响应。
+//   - out: 解码目标，语义同 json.Unmarshal。
+//   - maxBytes: 允许读取的最大字节数；小于等于 0 时使用 10 MiB。
+//
+// 返回：
+//   - error: 响应体超过上限时返回包装了 ErrResponseTooLarge 的错误；读取失败或不是合法 JSON 时返回对应错误。
+func DecodeJSONLimited(resp *http.Response, out any, maxBytes int64) error {
+	if maxBytes <= 0 {
+		maxBytes = maxJSONBytesDefault
+	}
+	if nil == resp || nil == resp.Body {
+		return errors.New("http response body is nil")
+	}
+	if resp.ContentLength > maxBytes {
+		return fmt.Errorf("%w: content length %d exceeds %d", ErrResponseTooLarge, resp.ContentLength, maxBytes)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxBytes+1))
+	if nil != err {
+		return err
+	}
+	if int64(len(data)) > maxBytes {
+		return fmt.Errorf("%w: exceeds %d bytes", ErrResponseTooLarge, maxBytes)
+	}
+	return json.Unmarshal(data, out)
+}
+
+// newLimitTransport 创建限制响应体大小的 RoundTripper。
+//
+// 参数：
+//   - base: 实际发送请求的传输层。
+//   - maxBytes: 响应体允许读取的最大字节数；小于等于 0 表示不限制。
+//
+// 返回：
+//   - http.RoundTripper: 不限制时直接返回 base。
+func newLimitTransport(base http.RoundTripper, maxBytes int64) http.RoundTripper {
+	if maxBytes <= 0 {
+		return base
+	}
+	return &limitTransport{base: base, maxBytes: maxBytes}
+}
+
+// RoundTrip 发送请求，并为响应体套上限制读取字节数的读取器。
+//
+// 参数：
+//   - req: 待发送的请求；不会被修改。
+//
+// 返回：
+//   - *http.Response: 响应体已限制大小的响应。
+//   - error: 底层传输层返回的错误，或 Content-Length 超过上限时包装了 ErrResponseTooLarge 的错误。
+func (t *limitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if nil != err {
+		return resp, err
+	}
+	if resp.ContentLength > t.maxBytes {
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("%w: content length %d exceeds %d", ErrResponseTooLarge, resp.ContentLength, t.maxBytes)
+	}
+	if nil != resp.Body && http.NoBody != resp.Body {
+		resp.Body = &limitedBody{body: resp.Body, maxBytes: t.maxBytes, remaining: t.maxBytes}
+	}
+	return resp, nil
+}
+
+// Read 读取响应体，超过上限时返回 ErrResponseTooLarge。
+//
+// 参数：
+//   - p: 读取缓冲区。
+//
+// 返回：
+//   - int: 读取的字节数，不会超过剩余额度。
+//   - error: 读取失败时返回底层错误；额度用尽后仍有数据时返回包装了 ErrResponseTooLarge 的错误。
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.remaining <= 0 {
+		// 额度用尽后探测一个字节，区分恰好读完与超出上限。
+		var probe [1]byte
+		n, err := b.body.Read(probe[:])
+		if n > 0 {
+			return 0, fmt.Errorf("%w: exceeds %d bytes", ErrResponseTooLarge, b.maxBytes)
+		}
+		return 0, err
+	}
+	if int64(len(p)) > b.remaining {
+		p = p[:b.remaining]
+	}
+	n, err := b.body.Read(p)
+	b.remaining -= int64(n)
+	return n, err
+}
+
+// Close 关闭原始响应体。
+//
+// 返回：
+//   - error: 原始响应体关闭时返回的错误。
+func (b *limitedBody) Close() error {
+	return b.body.Close()
+}
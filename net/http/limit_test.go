@@ -0,0 +1,226 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package http
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	stdhttp "net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newSizedServer 创建按查询参数返回指定大小响应的测试服务。
+//
+// 查询参数 n 为响应体字节数，chunked=1 时不声明 Content-Length，gzip=1 时返回 gzip 压缩的响应体。
+//
+// 参数：
+//   - t: 测试上下文，用于注册清理逻辑。
+//
+// 返回：
+//   - *httptest.Server: 测试服务。
+func newSizedServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(stdhttp.HandlerFunc(func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+		n, _ := strconv.Atoi(r.URL.Query().Get("n"))
+		body := []byte(`"` + strings.Repeat("x", n-2) + `"`)
+		if "1" == r.URL.Query().Get("gzip") {
+			var buf bytes.Buffer
+			zw := gzip.NewWriter(&buf)
+			_, _ = zw.Write(body)
+			_ = zw.Close()
+			body = buf.Bytes()
+			w.Header().Set("Content-Encoding", "gzip")
+		}
+		if "1" == r.URL.Query().Get("chunked") {
+			w.WriteHeader(stdhttp.StatusOK)
+			for i := 0; i < len(body); i += 100 {
+				end := min(i+100, len(body))
+				_, _ = w.Write(body[i:end])
+				w.(stdhttp.Flusher).Flush()
+			}
+			return
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+		_, _ = w.Write(body)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// TestWithMaxResponseBytes 验证客户端响应体大小限制对声明长度、分块传输和压缩响应的处理。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestWithMaxResponseBytes(t *testing.T) {
+	server := newSizedServer(t)
+
+	tests := []struct {
+		name        string
+		description string
+		query       string
+		maxBytes    int64
+		wantDoErr   bool
+		wantReadErr bool
+		wantLen     int
+	}{
+		{
+			name:        "success/exact-limit",
+			description: "验证响应体恰好等于上限时可以完整读取。",
+			query:       "n=1000",
+			maxBytes:    1000,
+			wantLen:     1000,
+		},
+		{
+			name:        "success/chunked-exact-limit",
+			description: "验证未声明长度且恰好等于上限时可以完整读取。",
+			query:       "n=1000&chunked=1",
+			maxBytes:    1000,
+			wantLen:     1000,
+		},
+		{
+			name:        "success/unlimited",
+			description: "验证上限为 0 时不限制。",
+			query:       "n=5000",
+			wantLen:     5000,
+		},
+		{
+			name:        "error/content-length",
+			description: "验证 Content-Length 超过上限时 Do 直接返回 ErrResponseTooLarge。",
+			query:       "n=1001",
+			maxBytes:    1000,
+			wantDoErr:   true,
+		},
+		{
+			name:        "error/chunked",
+			description: "验证未声明长度时读取超过上限返回 ErrResponseTooLarge。",
+			query:       "n=5000&chunked=1",
+			maxBytes:    1000,
+			wantReadErr: true,
+		},
+		{
+			name:        "error/gzip-bomb",
+			description: "验证上限作用于解压后的内容，压缩后很小的响应同样被拒绝。",
+			query:       "n=100000&gzip=1",
+			maxBytes:    1000,
+			wantReadErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			c := NewClient(WithMaxResponseBytes(tt.maxBytes), WithLogError(false))
+			resp, err := c.Get(context.Background(), server.URL+"?"+tt.query)
+			if tt.wantDoErr {
+				require.ErrorIs(t, err, ErrResponseTooLarge)
+				assert.Nil(t, resp)
+				return
+			}
+			require.NoError(t, err)
+			defer func() { _ = resp.Body.Close() }()
+
+			body, err := io.ReadAll(resp.Body)
+			if tt.wantReadErr {
+				require.ErrorIs(t, err, ErrResponseTooLarge)
+				assert.LessOrEqual(t, int64(len(body)), tt.maxBytes)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, body, tt.wantLen)
+		})
+	}
+}
+
+// TestDecodeJSONLimited 验证受限 JSON 解码的上限判断与错误路径。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestDecodeJSONLimited(t *testing.T) {
+	server := newSizedServer(t)
+
+	tests := []struct {
+		name        string
+		description string
+		query       string
+		maxBytes    int64
+		wantErrIs   error
+		wantLen     int
+	}{
+		{
+			name:        "success/within-limit",
+			description: "验证响应体不超过上限时正常解码。",
+			query:       "n=100",
+			maxBytes:    100,
+			wantLen:     98,
+		},
+		{
+			name:        "success/default-limit",
+			description: "验证上限小于等于 0 时使用默认的 10 MiB。",
+			query:       "n=100&chunked=1",
+			wantLen:     98,
+		},
+		{
+			name:        "error/content-length",
+			description: "验证 Content-Length 超过上限时不读取响应体。",
+			query:       "n=101",
+			maxBytes:    100,
+			wantErrIs:   ErrResponseTooLarge,
+		},
+		{
+			name:        "error/chunked",
+			description: "验证未声明长度时读取超过上限返回 ErrResponseTooLarge。",
+			query:       "n=1000&chunked=1",
+			maxBytes:    100,
+			wantErrIs:   ErrResponseTooLarge,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			resp, err := stdhttp.Get(server.URL + "?" + tt.query)
+			require.NoError(t, err)
+			defer func() { _ = resp.Body.Close() }()
+
+			var out string
+			err = DecodeJSONLimited(resp, &out, tt.maxBytes)
+			if nil != tt.wantErrIs {
+				require.ErrorIs(t, err, tt.wantErrIs)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, out, tt.wantLen)
+		})
+	}
+
+	t.Run("error/invalid-json", func(t *testing.T) {
+		t.Log("验证响应体不是合法 JSON 时返回解码错误。")
+
+		resp := &stdhttp.Response{Body: io.NopCloser(strings.NewReader(`{"a":`)), ContentLength: -1}
+		var out map[string]any
+		var syntaxErr *json.SyntaxError
+		err := DecodeJSONLimited(resp, &out, 100)
+		require.ErrorAs(t, err, &syntaxErr)
+		assert.NotErrorIs(t, err, ErrResponseTooLarge)
+	})
+
+	t.Run("error/nil-response", func(t *testing.T) {
+		t.Log("验证响应为 nil 时返回错误。")
+
+		require.Error(t, DecodeJSONLimited(nil, &struct{}{}, 100))
+	})
+}
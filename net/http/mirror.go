@@ -0,0 +1,436 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package http
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	kitlog "github.com/fsyyft-go/kit/log"
+	kitgoroutine "github.com/fsyyft-go/kit/runtime/goroutine"
+)
+
+var (
+	// ErrInvalidMirrorURL 表示影子流量的目标地址不是包含协议和主机的绝对 URL。
+	ErrInvalidMirrorURL = errors.New("invalid mirror base url")
+
+	// 断言 MirrorHook 实现 Hook 接口。
+	_ Hook = (*MirrorHook)(nil)
+)
+
+const (
+	// MirrorRequestHeader 是影子请求携带的请求头，影子服务可据此识别复制流量并避免产生副作用。
+	MirrorRequestHeader = "X-Mirror-Request"
+
+	// hookValueMirrorRequest 是 MirrorHook 在 Before 阶段写入 HookContext 的请求快照键。
+	hookValueMirrorRequest = "kit.mirror_request"
+)
+
+// 以下为 MirrorHook 的默认参数配置，可通过 MirrorOption 覆盖。
+var (
+	// mirrorPercentDefault 为默认复制比例，单位为百分比。
+	mirrorPercentDefault = 100.0
+	// mirrorConcurrencyDefault 为同时进行的影子请求数上限默认值。
+	mirrorConcurrencyDefault = 16
+	// mirrorTimeoutDefault 为单个影子请求的超时时间默认值。
+	mirrorTimeoutDefault = 5 * time.Second
+	// mirrorMaxBodySizeDefault 为可复制请求体的最大字节数默认值。
+	mirrorMaxBodySizeDefault int64 = 1 << 20
+)
+
+type (
+	// MirrorOption 定义修改 MirrorHook 配置的函数。
+	MirrorOption func(h *MirrorHook)
+
+	// MirrorStats 是 MirrorHook 的累计计数。
+	MirrorStats struct {
+		// Mirrored 是已发出的影子请求数。
+		Mirrored int64
+		// Dropped 是因并发已达上限而放弃的影子请求数。
+		Dropped int64
+		// Failed 是发送失败的影子请求数，包含构造请求失败和网络错误，不区分响应状态码。
+		Failed int64
+	}
+
+	// MirrorHook 按比例把请求异步复制到影子服务，用生产流量验证新后端。
+	//
+	// 被采样的请求在 Before 阶段保存请求头和请求体的快照，主请求完成后在 After 阶段异步发出影子请求，
+	// 影子请求的响应会被读取并丢弃。影子请求数达到并发上限时直接放弃本次复制而不是等待，
+	// 因此主请求的耗时和结果不受影子服务影响。所有方法都可以并发调用。
+	MirrorHook struct {
+		// target 是影子服务的基础地址，请求路径会追加在其路径之后。
+		target *url.URL
+		// percent 是复制比例，取值范围为 [0, 100]。
+		percent float64
+		// timeout 是单个影子请求的超时时间，非正值表示不设置超时。
+		timeout time.Duration
+		// maxBodySize 是可复制请求体的最大字节数，超过时不复制该请求。
+		maxBodySize int64
+		// client 是发送影子请求的标准库客户端。
+		client *http.Client
+		// logger 记录影子请求失败的日志记录器。
+		logger kitlog.Logger
+		// sem 限制同时进行的影子请求数。
+		sem chan struct{}
+		// wg 跟踪进行中的影子请求，供 Wait 等待。
+		wg sync.WaitGroup
+
+		mirrored atomic.Int64
+		dropped  atomic.Int64
+		failed   atomic.Int64
+	}
+
+	// mirrorRequest 是被采样请求在 Before 阶段保存的快照。
+	mirrorRequest struct {
+		method string
+		url    *url.URL
+		header http.Header
+		// body 是请求体内容，为 nil 表示请求没有请求体。
+		body []byte
+	}
+)
+
+// WithMirrorPercent 设置复制比例。
+//
+// 参数：
+//   - percent: 复制比例，单位为百分比；小于 0 按 0 处理，大于 100 按 100 处理。
+//
+// 返回：
+//   - MirrorOption: 应用于 [NewMirrorHook] 的复制比例配置项。
+func WithMirrorPercent(percent float64) MirrorOption {
+	return func(h *MirrorHook) {
+		h.percent = min(max(percent, 0), 100)
+	}
+}
+
+// WithMirrorConcurrency 设置同时进行的影子请求数上限。
+//
+// 参数：
+//   - n: 并发上限；非正值时保留默认值。
+//
+// 返回：
+//   - MirrorOption: 应用于 [NewMirrorHook] 的并发上限配置项。
+func WithMirrorConcurrency(n int) MirrorOption {
+	return func(h *MirrorHook) {
+		if n > 0 {
+			h.sem = make(chan struct{}, n)
+		}
+	}
+}
+
+// WithMirrorTimeout 设置单个影子请求的超时时间。
+//
+// 影子请求不继承主请求的取消信号，主请求结束后仍会继续执行，直到完成或超时。
+//
+// 参数：
+//   - timeout: 超时时间；非正值表示不设置超时。
+//
+// 返回：
+//   - MirrorOption: 应用于 [NewMirrorHook] 的超时时间配置项。
+func WithMirrorTimeout(timeout time.Duration) MirrorOption {
+	return func(h *MirrorHook) {
+		h.timeout = timeout
+	}
+}
+
+// WithMirrorMaxBodySize 设置可复制请求体的最大字节数。
+//
+// 请求体在 Before 阶段读入内存，超过上限的请求不会被复制，主请求仍会发送完整的请求体。
+//
+// 参数：
+//   - size: 最大字节数；负值表示不限制。
+//
+// 返回：
+//   - MirrorOption: 应用于 [NewMirrorHook] 的请求体大小配置项。
+func WithMirrorMaxBodySize(size int64) MirrorOption {
+	return func(h *MirrorHook) {
+		h.maxBodySize = size
+	}
+}
+
+// WithMirrorClient 设置发送影子请求的标准库客户端。
+//
+// 默认客户端使用 http.DefaultTransport，与主请求的连接池相互独立。
+//
+// 参数：
+//   - client: 发送影子请求的客户端；为 nil 时保留默认客户端。
+//
+// 返回：
+//   - MirrorOption: 应用于 [NewMirrorHook] 的客户端配置项。
+func WithMirrorClient(client *http.Client) MirrorOption {
+	return func(h *MirrorHook) {
+		if nil != client {
+			h.client = client
+		}
+	}
+}
+
+// WithMirrorLogger 设置记录影子请求失败的日志记录器。
+//
+// 参数：
+//   - logger: 日志记录器；为 nil 时保留默认日志记录器。
+//
+// 返回：
+//   - MirrorOption: 应用于 [NewMirrorHook] 的日志配置项。
+func WithMirrorLogger(logger kitlog.Logger) MirrorOption {
+	return func(h *MirrorHook) {
+		if nil != logger {
+			h.logger = logger
+		}
+	}
+}
+
+// NewMirrorHook 创建把请求复制到 baseURL 的影子流量 Hook。
+//
+// 影子请求的地址由 baseURL 的协议、主机和路径前缀与原请求的路径、查询参数拼接而成，
+// 请求头从原请求复制并追加 MirrorRequestHeader。
+//
+// 参数：
+//   - baseURL: 影子服务的基础地址，例如 http://shadow.internal:8080/prefix。
+//   - opts: 复制比例、并发上限、超时时间等配置。
+//
+// 返回：
+//   - *MirrorHook: 可通过 [WithMirror] 或 HookManager 注册的影子流量 Hook。
+//   - error: baseURL 无法解析或缺少协议、主机时返回包装 ErrInvalidMirrorURL 的错误。
+func NewMirrorHook(baseURL string, opts ...MirrorOption) (*MirrorHook, error) {
+	target, err := url.Parse(baseURL)
+	if nil != err {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidMirrorURL, err)
+	}
+	if "" == target.Scheme || "" == target.Host {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidMirrorURL, baseURL)
+	}
+
+	h := &MirrorHook{
+		target:      target,
+		percent:     mirrorPercentDefault,
+		timeout:     mirrorTimeoutDefault,
+		maxBodySize: mirrorMaxBodySizeDefault,
+		client:      &http.Client{},
+		logger:      kitlog.GetLogger(),
+		sem:         make(chan struct{}, mirrorConcurrencyDefault),
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	h.logger = h.logger.WithField("hook", "mirror")
+	return h, nil
+}
+
+// Before 按复制比例采样请求，并保存被采样请求的请求头和请求体快照。
+//
+// 请求体没有 GetBody 时会被读入内存并替换为可重复读取的副本；请求体超过上限时不复制该请求，
+// 已读取的部分会与剩余内容重新拼接，主请求仍发送完整的请求体。
+//
+// 参数：
+//   - ctx: 当前 HTTP Hook 上下文。
+//
+// 返回：
+//   - error: 固定返回 nil，复制失败不会影响主请求。
+func (h *MirrorHook) Before(ctx *HookContext) error {
+	if h.percent <= 0 || (h.percent < 100 && rand.Float64()*100 >= h.percent) {
+		return nil
+	}
+
+	req := ctx.Request()
+	body, ok := h.snapshotBody(req)
+	if !ok {
+		return nil
+	}
+	ctx.SetHookValue(hookValueMirrorRequest, &mirrorRequest{
+		method: req.Method,
+		url:    h.targetURL(req.URL),
+		header: req.Header.Clone(),
+		body:   body,
+	})
+	return nil
+}
+
+// After 在主请求完成后异步发出影子请求；并发已达上限时放弃本次复制。
+//
+// 参数：
+//   - ctx: 当前 HTTP Hook 上下文，用于读取 Before 阶段保存的快照。
+//
+// 返回：
+//   - error: 固定返回 nil。
+func (h *MirrorHook) After(ctx *HookContext) error {
+	value, ok := ctx.GetHookValue(hookValueMirrorRequest)
+	if !ok {
+		return nil
+	}
+	snapshot := value.(*mirrorRequest)
+
+	select {
+	case h.sem <- struct{}{}:
+	default:
+		h.dropped.Add(1)
+		return nil
+	}
+
+	// 影子请求不继承主请求的取消信号，但保留上下文中的链路追踪等数据。
+	parent := context.WithoutCancel(ctx.Request().Context())
+	h.wg.Add(1)
+	kitgoroutine.SafeGo(func() {
+		defer h.wg.Done()
+		defer func() { <-h.sem }()
+		h.send(parent, snapshot)
+	})
+	return nil
+}
+
+// Stats 返回累计计数。
+//
+// 返回：
+//   - MirrorStats: 已发出、已放弃和失败的影子请求数。
+func (h *MirrorHook) Stats() MirrorStats {
+	return MirrorStats{
+		Mirrored: h.mirrored.Load(),
+		Dropped:  h.dropped.Load(),
+		Failed:   h.failed.Load(),
+	}
+}
+
+// Wait 等待当前进行中的影子请求全部结束，通常在关闭服务前调用。
+func (h *MirrorHook) Wait() {
+	h.wg.Wait()
+}
+
+// snapshotBody 读取请求体内容，并保证主请求仍能发送完整的请求体。
+//
+// 参数：
+//   - req: 主请求。
+//
+// 返回：
+//   - []byte: 请求体内容；没有请求体时为 nil。
+//   - bool: 请求体是否可以复制。
+func (h *MirrorHook) snapshotBody(req *http.Request) ([]byte, bool) {
+	if nil == req.Body || http.NoBody == req.Body {
+		return nil, true
+	}
+	if h.maxBodySize >= 0 && req.ContentLength > h.maxBodySize {
+		return nil, false
+	}
+
+	if nil != req.GetBody {
+		// GetBody 返回独立的副本，无需改动主请求的请求体。
+		rc, err := req.GetBody()
+		if nil != err {
+			return nil, false
+		}
+		defer func() { _ = rc.Close() }()
+		body, ok := readLimited(rc, h.maxBodySize)
+		return body, ok
+	}
+
+	body, ok := readLimited(req.Body, h.maxBodySize)
+	if !ok {
+		// 超过上限或读取失败，把已读取的部分与剩余内容重新拼接，交由主请求继续发送。
+		req.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(body), req.Body), req.Body}
+		return nil, false
+	}
+	_ = req.Body.Close()
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	return body, true
+}
+
+// readLimited 读取 r 的全部内容，最多读取 limit+1 个字节。
+//
+// 参数：
+//   - r: 数据来源。
+//   - limit: 最大字节数；负值表示不限制。
+//
+// 返回：
+//   - []byte: 已读取的内容。
+//   - bool: 读取成功且未超过上限时返回 true。
+func readLimited(r io.Reader, limit int64) ([]byte, bool) {
+	if limit >= 0 {
+		r = io.LimitReader(r, limit+1)
+	}
+	body, err := io.ReadAll(r)
+	if nil != err || (limit >= 0 && int64(len(body)) > limit) {
+		return body, false
+	}
+	return body, true
+}
+
+// targetURL 把原请求地址改写为影子服务地址。
+//
+// 参数：
+//   - u: 原请求地址。
+//
+// 返回：
+//   - *url.URL: 使用影子服务协议、主机和路径前缀，保留原请求路径与查询参数的地址。
+func (h *MirrorHook) targetURL(u *url.URL) *url.URL {
+	target := *h.target
+	target.Path = strings.TrimSuffix(h.target.Path, "/") + "/" + strings.TrimPrefix(u.Path, "/")
+	target.RawPath = ""
+	target.RawQuery = u.RawQuery
+	target.Fragment = ""
+	return &target
+}
+
+// send 发出影子请求并丢弃响应。
+//
+// 参数：
+//   - parent: 影子请求的父上下文。
+//   - snapshot: Before 阶段保存的请求快照。
+func (h *MirrorHook) send(parent context.Context, snapshot *mirrorRequest) {
+	ctx := parent
+	if h.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(parent, h.timeout)
+		defer cancel()
+	}
+
+	var body io.Reader
+	if nil != snapshot.body {
+		body = bytes.NewReader(snapshot.body)
+	}
+	req, err := http.NewRequestWithContext(ctx, snapshot.method, snapshot.url.String(), body)
+	if nil != err {
+		h.fail(snapshot, err)
+		return
+	}
+	req.Header = snapshot.header
+	req.Header.Set(MirrorRequestHeader, "1")
+
+	h.mirrored.Add(1)
+	resp, err := h.client.Do(req)
+	if nil != err {
+		h.fail(snapshot, err)
+		return
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+}
+
+// fail 记录一次影子请求失败。
+//
+// 参数：
+//   - snapshot: 失败的请求快照。
+//   - err: 失败原因。
+func (h *MirrorHook) fail(snapshot *mirrorRequest, err error) {
+	h.failed.Add(1)
+	h.logger.
+		WithField("url", snapshot.url.String()).
+		WithField("error", err).
+		Debug("mirror request failed")
+}
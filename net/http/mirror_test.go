@@ -0,0 +1,265 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package http
+
+import (
+	"context"
+	"io"
+	stdhttp "net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mirroredRequest 是影子服务收到的一次请求。
+type mirroredRequest struct {
+	method string
+	uri    string
+	header stdhttp.Header
+	body   string
+}
+
+// shadowServer 记录收到的影子请求，并可阻塞响应以模拟慢后端。
+type shadowServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	requests []mirroredRequest
+	// block 非 nil 时，处理请求前等待其关闭。
+	block chan struct{}
+}
+
+// newShadowServer 创建测试用影子服务，测试结束后自动关闭。
+//
+// 参数：
+//   - t: 测试上下文，用于注册清理函数。
+//   - block: 非 nil 时每个请求都会等待其关闭后再响应。
+//
+// 返回：
+//   - *shadowServer: 影子服务。
+func newShadowServer(t *testing.T, block chan struct{}) *shadowServer {
+	t.Helper()
+
+	s := &shadowServer{block: block}
+	s.Server = httptest.NewServer(stdhttp.HandlerFunc(func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+		body, _ := io.ReadAll(r.Body)
+		s.mu.Lock()
+		s.requests = append(s.requests, mirroredRequest{method: r.Method, uri: r.RequestURI, header: r.Header.Clone(), body: string(body)})
+		s.mu.Unlock()
+		if nil != s.block {
+			<-s.block
+		}
+		_, _ = w.Write([]byte("shadow"))
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// received 返回影子服务已收到的请求。
+//
+// 返回：
+//   - []mirroredRequest: 已收到的请求。
+func (s *shadowServer) received() []mirroredRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]mirroredRequest(nil), s.requests...)
+}
+
+// newPrimaryServer 创建回显请求体的主服务，测试结束后自动关闭。
+//
+// 参数：
+//   - t: 测试上下文，用于注册清理函数。
+//
+// 返回：
+//   - *httptest.Server: 主服务。
+func newPrimaryServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(stdhttp.HandlerFunc(func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+		body, _ := io.ReadAll(r.Body)
+		_, _ = w.Write(append([]byte("primary:"), body...))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// readBody 读取并关闭响应体。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+//   - resp: 响应。
+//
+// 返回：
+//   - string: 响应体内容。
+func readBody(t *testing.T, resp *stdhttp.Response) string {
+	t.Helper()
+
+	defer func() { _ = resp.Body.Close() }()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+// TestNewMirrorHook_InvalidURL 验证影子服务地址必须是绝对 URL。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestNewMirrorHook_InvalidURL(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		baseURL     string
+	}{
+		{name: "error/relative", description: "验证缺少协议和主机的地址返回 ErrInvalidMirrorURL。", baseURL: "/shadow"},
+		{name: "error/unparsable", description: "验证无法解析的地址返回 ErrInvalidMirrorURL。", baseURL: "http://[::1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			_, err := NewMirrorHook(tt.baseURL)
+			assert.ErrorIs(t, err, ErrInvalidMirrorURL)
+		})
+	}
+}
+
+// TestClient_WithMirror 验证客户端把请求复制到影子服务。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestClient_WithMirror(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		opts        []MirrorOption
+		prefix      string
+		body        func() io.Reader
+		// wantBody 是影子服务期望收到的请求体，nil 表示不应收到请求。
+		wantBody *string
+		// wantPrimary 是主服务期望回显的请求体。
+		wantPrimary string
+	}{
+		{
+			name:        "success/copy-request",
+			description: "验证影子请求保留方法、路径前缀、查询参数、请求头和请求体，并携带 MirrorRequestHeader。",
+			prefix:      "/v2/",
+			body:        func() io.Reader { return strings.NewReader("payload") },
+			wantBody:    new("payload"),
+			wantPrimary: "payload",
+		},
+		{
+			name:        "success/body-without-get-body",
+			description: "验证没有 GetBody 的请求体被读入内存后，主请求和影子请求都收到完整内容。",
+			body:        func() io.Reader { return io.NopCloser(strings.NewReader("stream")) },
+			wantBody:    new("stream"),
+			wantPrimary: "stream",
+		},
+		{
+			name:        "boundary/percent-zero",
+			description: "验证复制比例为 0 时不发出影子请求。",
+			opts:        []MirrorOption{WithMirrorPercent(0)},
+			body:        func() io.Reader { return strings.NewReader("payload") },
+			wantPrimary: "payload",
+		},
+		{
+			name:        "boundary/body-too-large",
+			description: "验证请求体超过上限时不复制，主请求仍发送完整的请求体。",
+			opts:        []MirrorOption{WithMirrorMaxBodySize(4)},
+			body:        func() io.Reader { return io.NopCloser(strings.NewReader("too large")) },
+			wantPrimary: "too large",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			primary := newPrimaryServer(t)
+			shadow := newShadowServer(t, nil)
+			mirror, err := NewMirrorHook(shadow.URL+tt.prefix, tt.opts...)
+			require.NoError(t, err)
+			c := NewClient(WithLogSlow(0), WithLogError(false), WithMirror(mirror))
+
+			req, err := stdhttp.NewRequestWithContext(context.Background(), stdhttp.MethodPut, primary.URL+"/items/1?q=a%20b", tt.body())
+			require.NoError(t, err)
+			req.Header.Set("X-Trace", "abc")
+			resp, err := c.Do(context.Background(), req)
+			require.NoError(t, err)
+			assert.Equal(t, "primary:"+tt.wantPrimary, readBody(t, resp))
+			mirror.Wait()
+
+			received := shadow.received()
+			if nil == tt.wantBody {
+				assert.Empty(t, received)
+				assert.Zero(t, mirror.Stats().Mirrored)
+				return
+			}
+			require.Len(t, received, 1)
+			assert.Equal(t, stdhttp.MethodPut, received[0].method)
+			assert.Equal(t, strings.TrimSuffix(tt.prefix, "/")+"/items/1?q=a%20b", received[0].uri)
+			assert.Equal(t, "abc", received[0].header.Get("X-Trace"))
+			assert.Equal(t, "1", received[0].header.Get(MirrorRequestHeader))
+			assert.Equal(t, *tt.wantBody, received[0].body)
+			assert.Equal(t, MirrorStats{Mirrored: 1}, mirror.Stats())
+		})
+	}
+}
+
+// TestMirrorHook_Concurrency 验证影子服务变慢时主请求不受影响，超过并发上限的复制被放弃。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestMirrorHook_Concurrency(t *testing.T) {
+	t.Log("验证影子请求阻塞时主请求立即返回，并发上限为 1 时其余复制计入 Dropped。")
+
+	primary := newPrimaryServer(t)
+	block := make(chan struct{})
+	shadow := newShadowServer(t, block)
+	mirror, err := NewMirrorHook(shadow.URL, WithMirrorConcurrency(1), WithMirrorTimeout(time.Minute))
+	require.NoError(t, err)
+	c := NewClient(WithLogSlow(0), WithLogError(false), WithMirror(mirror))
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		resp, err := c.Get(context.Background(), primary.URL)
+		require.NoError(t, err)
+		assert.Equal(t, "primary:", readBody(t, resp))
+	}
+	assert.Less(t, time.Since(start), 5*time.Second)
+	assert.Equal(t, int64(2), mirror.Stats().Dropped)
+
+	close(block)
+	mirror.Wait()
+	assert.Len(t, shadow.received(), 1)
+	assert.Equal(t, MirrorStats{Mirrored: 1, Dropped: 2}, mirror.Stats())
+}
+
+// TestMirrorHook_Failure 验证影子服务不可用时只计入 Failed，主请求正常返回。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestMirrorHook_Failure(t *testing.T) {
+	t.Log("验证影子请求失败不影响主请求，并计入 Failed。")
+
+	primary := newPrimaryServer(t)
+	shadow := httptest.NewServer(stdhttp.NotFoundHandler())
+	shadowURL := shadow.URL
+	shadow.Close()
+
+	mirror, err := NewMirrorHook(shadowURL, WithMirrorTimeout(time.Second))
+	require.NoError(t, err)
+	c := NewClient(WithLogSlow(0), WithLogError(false), WithMirror(mirror))
+
+	resp, err := c.Get(context.Background(), primary.URL)
+	require.NoError(t, err)
+	assert.Equal(t, "primary:", readBody(t, resp))
+	mirror.Wait()
+	assert.Equal(t, MirrorStats{Mirrored: 1, Failed: 1}, mirror.Stats())
+}
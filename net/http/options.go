@@ -9,6 +9,7 @@ import (
 	"net/url"
 	"time"
 
+	kitconfig "github.com/fsyyft-go/kit/config"
 	kitlog "github.com/fsyyft-go/kit/log"
 )
 
@@ -106,6 +107,25 @@ func WithTraceEnable(enable bool) Option {
 	}
 }
 
+// WithTraceSampling 为默认 HookManager 注入按调试开关采样的 traceHook。
+//
+// 仅在未通过 [WithHook] 提供自定义 Hook 时生效；设置后取代 [WithTraceEnable] 注入的 traceHook，
+// 请求按 registry 的 TraceSampleRate 采样，采样率可在运行时修改，见 [NewSampledTraceHook]。
+//
+// 参数：
+//   - registry: 提供采样率的调试开关注册表；为 nil 时使用 config.DefaultDebugRegistry。
+//
+// 返回：
+//   - Option: 应用于 [NewClient] 的 traceHook 采样配置项。
+func WithTraceSampling(registry *kitconfig.DebugRegistry) Option {
+	return func(c *client) {
+		if nil == registry {
+			registry = kitconfig.DefaultDebugRegistry
+		}
+		c.traceRegistry = registry
+	}
+}
+
 // WithProxy 设置 HTTP 客户端代理函数。
 //
 // 该选项只在使用 NewClient 内置 Transport 时生效；通过 [WithTransport] 提供自定义 Transport 后，代理行为由自定义 Transport 决定。
@@ -240,3 +260,56 @@ func WithLogger(logger kitlog.Logger) Option {
 		c.logger = logger
 	}
 }
+
+// WithSigner 为客户端的所有请求启用签名。
+//
+// 签名 Hook 总是追加在默认 HookManager 或 WithHook 提供的自定义 Hook 之后，Before 阶段最后执行，
+// 保证签名覆盖最终的请求内容。多次调用会按顺序注册多个签名 Hook。
+//
+// 参数：
+//   - signer: 签名实现，例如 NewHMACSigner 或 NewSigV4Signer 的返回值。
+//   - opts: 时钟偏移等签名 Hook 配置。
+//
+// 返回：
+//   - Option: 应用于 [NewClient] 的签名配置项。
+func WithSigner(signer Signer, opts ...SignHookOption) Option {
+	return func(c *client) {
+		c.signHooks = append(c.signHooks, NewSignHook(signer, opts...))
+	}
+}
+
+// WithDebug 为客户端的所有请求记录可复现的调试快照。
+//
+// 调试记录 Hook 追加在签名 Hook 之后，记录签名后最终发送的请求；其 After 先于默认日志 Hook 执行，
+// 慢请求日志和错误日志会附带脱敏后的 curl 命令。自定义 Hook 可通过 [DebugRecordFrom] 读取记录。
+//
+// 参数：
+//   - debug: 由 NewDebugHook 创建的调试记录 Hook；为 nil 时忽略。
+//
+// 返回：
+//   - Option: 应用于 [NewClient] 的调试记录配置项。
+func WithDebug(debug *DebugHook) Option {
+	return func(c *client) {
+		if nil != debug {
+			c.debugHooks = append(c.debugHooks, debug)
+		}
+	}
+}
+
+// WithMirror 把客户端的请求按比例复制到影子服务。
+//
+// 影子流量 Hook 追加在签名 Hook 之后，复制的是签名后最终发送的请求；影子请求在主请求完成后异步发送，
+// 不影响主请求的耗时和结果。多次调用会按顺序注册多个影子流量 Hook。
+//
+// 参数：
+//   - mirror: 由 NewMirrorHook 创建的影子流量 Hook；为 nil 时忽略。
+//
+// 返回：
+//   - Option: 应用于 [NewClient] 的影子流量配置项。
+func WithMirror(mirror *MirrorHook) Option {
+	return func(c *client) {
+		if nil != mirror {
+			c.mirrorHooks = append(c.mirrorHooks, mirror)
+		}
+	}
+}
@@ -0,0 +1,261 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package http
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var (
+	_ http.RoundTripper = (*rateLimitTransport)(nil)
+	_ io.ReadCloser     = (*rateLimitedBody)(nil)
+)
+
+type (
+	// bandwidthLimiter 是按字节计量的令牌桶，同一客户端的所有请求共享同一个实例。
+	//
+	// 令牌允许透支：读取完成后再扣减实际字节数，余额为负时等待令牌补足，保证长期平均速率不超过 rate。
+	bandwidthLimiter struct {
+		mu     sync.Mutex // 保护 tokens 与 last。
+		rate   float64    // 每秒补充的字节数。
+		burst  int        // 桶容量，同时也是单次读取的最大字节数。
+		tokens float64    // 当前可用的字节数，可为负数。
+		last   time.Time  // 上次补充令牌的时间。
+	}
+
+	// rateLimitTransport 为请求体和响应体套上限速读取器的 RoundTripper。
+	rateLimitTransport struct {
+		base     http.RoundTripper // 实际发送请求的传输层。
+		download *bandwidthLimiter // 响应体限速器；为 nil 时不限速。
+		upload   *bandwidthLimiter // 请求体限速器；为 nil 时不限速。
+	}
+
+	// rateLimitedBody 是按令牌桶限速读取的 io.ReadCloser。
+	rateLimitedBody struct {
+		ctx     context.Context   // 等待令牌时使用的上下文，取消后立即返回错误。
+		body    io.ReadCloser     // 被限速的原始数据流。
+		limiter *bandwidthLimiter // 令牌桶。
+	}
+)
+
+// WithRateLimit 限制客户端的上传和下载带宽。
+//
+// 上传与下载分别使用独立的令牌桶，每个方向的长期平均速率均不超过 bytesPerSecond，同一客户端的所有并发请求
+// 共享该额度，适合批处理任务避免占满共享出口带宽。限速通过包装请求体和响应体实现，请求头与连接建立不计入；
+// 等待令牌时会响应请求上下文的取消。
+//
+// 参数：
+//   - bytesPerSecond: 每个方向每秒允许传输的字节数；小于等于 0 表示不限速。
+//
+// 返回：
+//   - Option: 应用于 [NewClient] 的带宽限速配置项。
+func WithRateLimit(bytesPerSecond int64) Option {
+	return func(c *client) {
+		c.downloadRate = bytesPerSecond
+		c.uploadRate = bytesPerSecond
+	}
+}
+
+// WithDownloadRateLimit 仅限制客户端读取响应体的带宽。
+//
+// 参数：
+//   - bytesPerSecond: 每秒允许读取的字节数；小于等于 0 表示不限速。
+//
+// 返回：
+//   - Option: 应用于 [NewClient] 的下载限速配置项。
+func WithDownloadRateLimit(bytesPerSecond int64) Option {
+	return func(c *client) {
+		c.downloadRate = bytesPerSecond
+	}
+}
+
+// WithUploadRateLimit 仅限制客户端发送请求体的带宽。
+//
+// 参数：
+//   - bytesPerSecond: 每秒允许发送的字节数；小于等于 0 表示不限速。
+//
+// 返回：
+//   - Option: 应用于 [NewClient] 的上传限速配置项。
+func WithUploadRateLimit(bytesPerSecond int64) Option {
+	return func(c *client) {
+		c.uploadRate = bytesPerSecond
+	}
+}
+
+// newRateLimitTransport 创建带宽限速的 RoundTripper。
+//
+// 参数：
+//   - base: 实际发送请求的传输层。
+//   - downloadRate: 下载速率，单位为字节每秒；小于等于 0 表示不限速。
+//   - uploadRate: 上传速率，单位为字节每秒；小于等于 0 表示不限速。
+//
+// 返回：
+//   - http.RoundTripper: 两个方向均不限速时直接返回 base。
+func newRateLimitTransport(base http.RoundTripper, downloadRate, uploadRate int64) http.RoundTripper {
+	if downloadRate <= 0 && uploadRate <= 0 {
+		return base
+	}
+	return &rateLimitTransport{
+		base:     base,
+		download: newBandwidthLimiter(downloadRate),
+		upload:   newBandwidthLimiter(uploadRate),
+	}
+}
+
+// RoundTrip 为请求体和响应体套上限速读取器后发送请求。
+//
+// 参数：
+//   - req: 待发送的请求；不会被修改。
+//
+// 返回：
+//   - *http.Response: 响应体已限速的响应。
+//   - error: 底层传输层返回的错误。
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	if nil != t.upload && nil != req.Body && http.NoBody != req.Body {
+		// RoundTripper 不应修改原始请求，这里使用浅拷贝替换请求体。
+		r := new(http.Request)
+		*r = *req
+		r.Body = newRateLimitedBody(ctx, req.Body, t.upload)
+		if nil != req.GetBody {
+			getBody := req.GetBody
+			r.GetBody = func() (io.ReadCloser, error) {
+				body, err := getBody()
+				if nil != err {
+					return nil, err
+				}
+				return newRateLimitedBody(ctx, body, t.upload), nil
+			}
+		}
+		req = r
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if nil != err {
+		return resp, err
+	}
+	if nil != t.download && nil != resp.Body && http.NoBody != resp.Body {
+		resp.Body = newRateLimitedBody(ctx, resp.Body, t.download)
+	}
+	return resp, nil
+}
+
+// newBandwidthLimiter 创建桶容量为一秒流量的令牌桶，初始时桶为满。
+//
+// 参数：
+//   - bytesPerSecond: 每秒补充的字节数。
+//
+// 返回：
+//   - *bandwidthLimiter: 令牌桶；bytesPerSecond 小于等于 0 时返回 nil。
+func newBandwidthLimiter(bytesPerSecond int64) *bandwidthLimiter {
+	if bytesPerSecond <= 0 {
+		return nil
+	}
+	burst := bytesPerSecond
+	if maxInt := int64(int(^uint(0) >> 1)); burst > maxInt {
+		burst = maxInt
+	}
+	return &bandwidthLimiter{
+		rate:   float64(bytesPerSecond),
+		burst:  int(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// reserve 扣减 n 个令牌并返回需要等待的时长。
+//
+// 参数：
+//   - n: 本次传输的字节数。
+//
+// 返回：
+//   - time.Duration: 令牌余额恢复为非负所需的时间；无需等待时为 0。
+func (l *bandwidthLimiter) reserve(n int) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > float64(l.burst) {
+		l.tokens = float64(l.burst)
+	}
+	l.last = now
+	l.tokens -= float64(n)
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// wait 扣减 n 个令牌，余额不足时阻塞直到令牌补足或 ctx 结束。
+//
+// 参数：
+//   - ctx: 控制等待的上下文。
+//   - n: 本次传输的字节数。
+//
+// 返回：
+//   - error: ctx 在等待期间结束时返回 ctx.Err()。
+func (l *bandwidthLimiter) wait(ctx context.Context, n int) error {
+	delay := l.reserve(n)
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// newRateLimitedBody 创建限速读取器。
+//
+// 参数：
+//   - ctx: 等待令牌时使用的上下文。
+//   - body: 原始数据流。
+//   - limiter: 令牌桶。
+//
+// 返回：
+//   - *rateLimitedBody: 限速读取器，Close 时关闭 body。
+func newRateLimitedBody(ctx context.Context, body io.ReadCloser, limiter *bandwidthLimiter) *rateLimitedBody {
+	return &rateLimitedBody{ctx: ctx, body: body, limiter: limiter}
+}
+
+// Read 读取不超过桶容量的数据，并按实际读取的字节数等待令牌。
+//
+// 参数：
+//   - p: 读取缓冲区。
+//
+// 返回：
+//   - int: 实际读取的字节数。
+//   - error: 原始数据流的错误，或等待令牌期间上下文结束的错误。
+func (b *rateLimitedBody) Read(p []byte) (int, error) {
+	if len(p) > b.limiter.burst {
+		p = p[:b.limiter.burst]
+	}
+	n, err := b.body.Read(p)
+	if n > 0 {
+		if waitErr := b.limiter.wait(b.ctx, n); nil != waitErr {
+			return n, waitErr
+		}
+	}
+	return n, err
+}
+
+// Close 关闭原始数据流。
+//
+// 参数：无。
+//
+// 返回：
+//   - error: 原始数据流关闭时的错误。
+func (b *rateLimitedBody) Close() error {
+	return b.body.Close()
+}
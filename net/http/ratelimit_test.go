@@ -0,0 +1,145 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package http
+
+import (
+	"bytes"
+	"context"
+	"io"
+	stdhttp "net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestWithRateLimit_Throttles 验证限速选项对下载和上传的实际耗时影响。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestWithRateLimit_Throttles(t *testing.T) {
+	payload := strings.Repeat("x", 15*1024)
+	server := httptest.NewServer(stdhttp.HandlerFunc(func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if len(body) > 0 {
+			_, _ = w.Write(body)
+			return
+		}
+		_, _ = w.Write([]byte(payload))
+	}))
+	t.Cleanup(server.Close)
+
+	tests := []struct {
+		name        string
+		description string
+		opts        []Option
+		upload      bool
+		minDuration time.Duration
+		maxDuration time.Duration
+	}{
+		{
+			name:        "success/download-throttled",
+			description: "验证 10KiB/s 下载 15KiB 响应至少耗时约 0.5 秒（首秒额度为桶容量）。",
+			opts:        []Option{WithRateLimit(10 * 1024)},
+			minDuration: 400 * time.Millisecond,
+			maxDuration: 3 * time.Second,
+		},
+		{
+			name:        "success/upload-throttled",
+			description: "验证仅限制上传时请求体发送受限速影响。",
+			opts:        []Option{WithUploadRateLimit(10 * 1024)},
+			upload:      true,
+			minDuration: 400 * time.Millisecond,
+			maxDuration: 3 * time.Second,
+		},
+		{
+			name:        "boundary/upload-only-download-free",
+			description: "验证仅限制上传时下载不受影响。",
+			opts:        []Option{WithUploadRateLimit(10 * 1024)},
+			maxDuration: 300 * time.Millisecond,
+		},
+		{
+			name:        "boundary/disabled",
+			description: "验证限速值小于等于 0 时不包装 Transport。",
+			opts:        []Option{WithRateLimit(0)},
+			maxDuration: 300 * time.Millisecond,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			c := NewClient(append([]Option{WithLogError(false)}, tt.opts...)...)
+			ctx := context.Background()
+
+			start := time.Now()
+			var (
+				resp *stdhttp.Response
+				err  error
+			)
+			if tt.upload {
+				resp, err = c.Post(ctx, server.URL, bytes.NewReader([]byte(payload)))
+			} else {
+				resp, err = c.Get(ctx, server.URL)
+			}
+			require.NoError(t, err)
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+			elapsed := time.Since(start)
+
+			assert.Equal(t, payload, string(body))
+			assert.GreaterOrEqual(t, elapsed, tt.minDuration)
+			assert.Less(t, elapsed, tt.maxDuration)
+		})
+	}
+}
+
+// TestWithRateLimit_ContextCancel 验证等待令牌期间取消上下文会立即中止读取。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestWithRateLimit_ContextCancel(t *testing.T) {
+	server := httptest.NewServer(stdhttp.HandlerFunc(func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+		_, _ = w.Write(bytes.Repeat([]byte("y"), 64*1024))
+	}))
+	t.Cleanup(server.Close)
+
+	c := NewClient(WithLogError(false), WithDownloadRateLimit(1024))
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	resp, err := c.Get(ctx, server.URL)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	start := time.Now()
+	_, err = io.ReadAll(resp.Body)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 2*time.Second)
+}
+
+// TestBandwidthLimiter_Reserve 验证令牌桶的透支与补充计算。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestBandwidthLimiter_Reserve(t *testing.T) {
+	assert.Nil(t, newBandwidthLimiter(0))
+
+	l := newBandwidthLimiter(1000)
+	assert.Equal(t, 1000, l.burst)
+	assert.Zero(t, l.reserve(1000))
+
+	delay := l.reserve(500)
+	assert.InDelta(t, float64(500*time.Millisecond), float64(delay), float64(50*time.Millisecond))
+
+	base := stdhttp.DefaultTransport
+	assert.Equal(t, base, newRateLimitTransport(base, 0, -1))
+}
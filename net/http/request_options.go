@@ -0,0 +1,163 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package http
+
+import (
+	"net/http"
+	"reflect"
+	"time"
+)
+
+type (
+	// RequestOption 定义覆盖单次请求配置的函数。
+	//
+	// RequestOption 由 Client 的各请求方法按传入顺序执行，只影响本次调用，不会修改共享的客户端配置，
+	// 便于同一个客户端同时服务延迟敏感接口和批量接口。
+	//
+	// 参数：
+	//   - o: 本次请求的配置覆盖，由请求方法创建并传入。
+	RequestOption func(o *requestOptions)
+
+	// requestOptions 保存单次请求对客户端配置的覆盖。
+	requestOptions struct {
+		timeout         time.Duration // 覆盖后的总超时时间。
+		timeoutOverride bool          // 是否覆盖总超时时间。
+		skipAllHooks    bool          // 是否跳过全部 Hook。
+		skipHooks       []Hook        // 需要跳过的 Hook。
+	}
+)
+
+// WithTimeoutOverride 覆盖本次请求的总超时时间。
+//
+// timeout 替代 [WithTimeout] 设置的客户端超时，既可以缩短也可以延长；取值语义与 http.Client.Timeout 保持一致，
+// 非正值表示本次请求不设置整体超时。请求仍共享客户端的 Transport 和连接池。
+//
+// 参数：
+//   - timeout: 本次请求的总超时时间。
+//
+// 返回：
+//   - RequestOption: 应用于单次请求的超时配置项。
+func WithTimeoutOverride(timeout time.Duration) RequestOption {
+	return func(o *requestOptions) {
+		o.timeout = timeout
+		o.timeoutOverride = true
+	}
+}
+
+// WithoutHooks 在本次请求中跳过指定的 Hook。
+//
+// 未传入任何 Hook 时跳过客户端的全部 Hook，包括 [WithSigner] 注册的签名 Hook。传入 Hook 时按实例比较，
+// 会递归进入 HookManager 跳过与之相同的 Hook，其余 Hook 仍按原有顺序执行；多次使用时跳过的 Hook 会累加。
+//
+// 参数：
+//   - hooks: 需要跳过的 Hook 实例；为空时跳过全部 Hook。
+//
+// 返回：
+//   - RequestOption: 应用于单次请求的 Hook 过滤配置项。
+func WithoutHooks(hooks ...Hook) RequestOption {
+	return func(o *requestOptions) {
+		if 0 == len(hooks) {
+			o.skipAllHooks = true
+			return
+		}
+		o.skipHooks = append(o.skipHooks, hooks...)
+	}
+}
+
+// newRequestOptions 按传入顺序应用单次请求的配置覆盖。
+//
+// 参数：
+//   - opts: 单次请求的配置项；nil 项会被忽略。
+//
+// 返回：
+//   - *requestOptions: 应用后的配置覆盖。
+func newRequestOptions(opts []RequestOption) *requestOptions {
+	o := &requestOptions{}
+	for _, opt := range opts {
+		if nil != opt {
+			opt(o)
+		}
+	}
+	return o
+}
+
+// httpClient 返回本次请求使用的标准库 HTTP 客户端。
+//
+// 参数：
+//   - base: 客户端共享的标准库 HTTP 客户端。
+//
+// 返回：
+//   - *http.Client: 未覆盖超时时直接返回 base，否则返回共享 Transport 的浅拷贝。
+func (o *requestOptions) httpClient(base *http.Client) *http.Client {
+	if !o.timeoutOverride {
+		return base
+	}
+	hc := *base
+	hc.Timeout = o.timeout
+	return &hc
+}
+
+// hook 返回本次请求实际执行的 Hook。
+//
+// 参数：
+//   - base: 客户端配置的 Hook。
+//
+// 返回：
+//   - Hook: 过滤后的 Hook；全部被跳过时返回 nil。
+func (o *requestOptions) hook(base Hook) Hook {
+	if o.skipAllHooks {
+		return nil
+	}
+	if 0 == len(o.skipHooks) || nil == base {
+		return base
+	}
+	return o.filterHook(base)
+}
+
+// filterHook 从 Hook 中移除需要跳过的实例。
+//
+// 遇到 HookManager 时会构造新的 HookManager 保存剩余的 Hook，不会修改客户端共享的 HookManager。
+//
+// 参数：
+//   - hook: 待过滤的 Hook。
+//
+// 返回：
+//   - Hook: 过滤后的 Hook；hook 本身需要跳过时返回 nil。
+func (o *requestOptions) filterHook(hook Hook) Hook {
+	if o.skipped(hook) {
+		return nil
+	}
+	hm, ok := hook.(*HookManager)
+	if !ok || nil == hm {
+		return hook
+	}
+	filtered := NewHookManager()
+	for _, h := range hm.hooks {
+		if fh := o.filterHook(h); nil != fh {
+			filtered.AddHook(fh)
+		}
+	}
+	return filtered
+}
+
+// skipped 判断 Hook 是否需要跳过。
+//
+// 参数：
+//   - hook: 待判断的 Hook。
+//
+// 返回：
+//   - bool: hook 与任一需要跳过的实例相同时返回 true；不可比较的 Hook 始终返回 false。
+func (o *requestOptions) skipped(hook Hook) bool {
+	t := reflect.TypeOf(hook)
+	if nil == t || !t.Comparable() {
+		return false
+	}
+	for _, skip := range o.skipHooks {
+		if hook == skip {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,168 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package http
+
+import (
+	stdhttp "net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestClient_WithoutHooks 验证单次请求可以跳过全部或部分 Hook，且不影响客户端共享的 Hook 配置。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestClient_WithoutHooks(t *testing.T) {
+	server := httptest.NewServer(stdhttp.HandlerFunc(func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+		w.WriteHeader(stdhttp.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	tests := []struct {
+		name        string
+		description string
+		giveOpts    func(first, second, nested *recordingHook) []RequestOption
+		wantCalls   [3]int32
+	}{
+		{
+			name:        "success/no-override",
+			description: "验证未传入请求级配置时执行全部 Hook。",
+			giveOpts:    func(first, second, nested *recordingHook) []RequestOption { return nil },
+			wantCalls:   [3]int32{1, 1, 1},
+		},
+		{
+			name:        "success/skip-all",
+			description: "验证 WithoutHooks 不带参数时跳过全部 Hook。",
+			giveOpts: func(first, second, nested *recordingHook) []RequestOption {
+				return []RequestOption{WithoutHooks()}
+			},
+			wantCalls: [3]int32{0, 0, 0},
+		},
+		{
+			name:        "success/skip-selected",
+			description: "验证 WithoutHooks 只跳过指定的 Hook，其余 Hook 照常执行。",
+			giveOpts: func(first, second, nested *recordingHook) []RequestOption {
+				return []RequestOption{WithoutHooks(first)}
+			},
+			wantCalls: [3]int32{0, 1, 1},
+		},
+		{
+			name:        "success/skip-nested",
+			description: "验证 WithoutHooks 会递归进入嵌套的 HookManager，并累加多次传入的 Hook。",
+			giveOpts: func(first, second, nested *recordingHook) []RequestOption {
+				return []RequestOption{WithoutHooks(nested), WithoutHooks(second)}
+			},
+			wantCalls: [3]int32{1, 0, 0},
+		},
+		{
+			name:        "boundary/unknown-hook",
+			description: "验证跳过未注册的 Hook 时不影响已注册的 Hook。",
+			giveOpts: func(first, second, nested *recordingHook) []RequestOption {
+				return []RequestOption{WithoutHooks(&recordingHook{}), nil}
+			},
+			wantCalls: [3]int32{1, 1, 1},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			first, second, nested := &recordingHook{}, &recordingHook{}, &recordingHook{}
+			inner := NewHookManager()
+			inner.AddHook(nested)
+			hm := NewHookManager()
+			hm.AddHook(first)
+			hm.AddHook(second)
+			hm.AddHook(inner)
+			client := NewClient(WithHook(hm))
+
+			resp, err := client.Get(t.Context(), server.URL, tt.giveOpts(first, second, nested)...)
+			require.NoError(t, err)
+			closeResponseBody(t, resp)
+
+			assert.Equal(t, tt.wantCalls, [3]int32{first.beforeCalls.Load(), second.beforeCalls.Load(), nested.beforeCalls.Load()})
+			assert.Equal(t, tt.wantCalls, [3]int32{first.afterCalls.Load(), second.afterCalls.Load(), nested.afterCalls.Load()})
+
+			// 请求级配置不会修改客户端共享的 HookManager，后续请求仍执行全部 Hook。
+			resp, err = client.Get(t.Context(), server.URL)
+			require.NoError(t, err)
+			closeResponseBody(t, resp)
+			assert.Equal(t, tt.wantCalls[0]+1, first.beforeCalls.Load())
+			assert.Equal(t, tt.wantCalls[2]+1, nested.beforeCalls.Load())
+		})
+	}
+}
+
+// TestClient_WithTimeoutOverride 验证单次请求可以缩短或延长客户端总超时时间。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestClient_WithTimeoutOverride(t *testing.T) {
+	server := newRequestEchoServer(t)
+
+	tests := []struct {
+		name         string
+		description  string
+		giveTimeout  time.Duration
+		giveOverride []RequestOption
+		wantErr      bool
+		wantStatus   int
+	}{
+		{
+			name:         "success/extend-timeout",
+			description:  "验证请求级超时可以延长客户端超时，使慢请求正常完成。",
+			giveTimeout:  50 * time.Millisecond,
+			giveOverride: []RequestOption{WithTimeoutOverride(5 * time.Second)},
+			wantStatus:   stdhttp.StatusGatewayTimeout,
+		},
+		{
+			name:         "error/shorten-timeout",
+			description:  "验证请求级超时可以缩短客户端超时，使慢请求提前失败。",
+			giveTimeout:  5 * time.Second,
+			giveOverride: []RequestOption{WithTimeoutOverride(50 * time.Millisecond)},
+			wantErr:      true,
+		},
+		{
+			name:         "boundary/disable-timeout",
+			description:  "验证非正值的请求级超时表示本次请求不设置整体超时。",
+			giveTimeout:  50 * time.Millisecond,
+			giveOverride: []RequestOption{WithTimeoutOverride(0)},
+			wantStatus:   stdhttp.StatusGatewayTimeout,
+		},
+		{
+			name:         "error/last-override-wins",
+			description:  "验证多次覆盖超时时以最后一次为准。",
+			giveTimeout:  5 * time.Second,
+			giveOverride: []RequestOption{WithTimeoutOverride(0), WithTimeoutOverride(50 * time.Millisecond)},
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			c := NewClient(WithTimeout(tt.giveTimeout)).(*client)
+
+			resp, err := c.Get(t.Context(), server.URL+"/timeout", tt.giveOverride...)
+			defer closeResponseBody(t, resp)
+
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantStatus, resp.StatusCode)
+			}
+			assert.Equal(t, tt.giveTimeout, c.client.Timeout)
+		})
+	}
+}
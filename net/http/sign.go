@@ -0,0 +1,286 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package http
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	// ErrMissingCredentials 表示凭证提供者返回了缺少访问密钥标识或密钥的凭证。
+	ErrMissingCredentials = errors.New("signing credentials are missing access key id or secret")
+
+	// 断言 signHook 实现 Hook 接口。
+	_ Hook = (*signHook)(nil)
+)
+
+const (
+	// HookValueSignClockSkew 是签名 Hook 写入 HookContext 的共享数据键。
+	//
+	// 对应的值为签名时使用的 time.Duration 时钟偏移量，正值表示本地时钟落后于服务端。
+	HookValueSignClockSkew = "kit.sign_clock_skew"
+
+	// emptyPayloadHash 是空请求体的 SHA-256 十六进制摘要。
+	emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+)
+
+type (
+	// Credentials 是请求签名使用的密钥对。
+	Credentials struct {
+		// AccessKeyID 是公开的访问密钥标识。
+		AccessKeyID string
+		// SecretAccessKey 是用于计算签名的私密密钥。
+		SecretAccessKey string
+		// SessionToken 是可选的临时会话令牌，SigV4 签名时写入 X-Amz-Security-Token。
+		SessionToken string
+	}
+
+	// CredentialsProvider 为每次签名提供凭证。
+	//
+	// 实现可以缓存并按需刷新临时凭证；Retrieve 会在每个请求签名前调用，应当是并发安全的。
+	CredentialsProvider interface {
+		// Retrieve 返回当前可用的凭证。
+		//
+		// 参数：
+		//   - ctx: 当前请求的上下文。
+		//
+		// 返回：
+		//   - Credentials: 签名凭证。
+		//   - error: 获取凭证失败时返回错误，请求会被中止。
+		Retrieve(ctx context.Context) (Credentials, error)
+	}
+
+	// CredentialsProviderFunc 将普通函数适配为 CredentialsProvider。
+	CredentialsProviderFunc func(ctx context.Context) (Credentials, error)
+
+	// Signer 对即将发送的请求进行签名。
+	//
+	// Sign 直接修改 req 的请求头或查询参数；payloadHash 是请求体 SHA-256 的十六进制摘要，
+	// now 是已经校正时钟偏移后的签名时间。
+	Signer interface {
+		// Sign 为请求写入签名信息。
+		//
+		// 参数：
+		//   - ctx: 当前请求的上下文。
+		//   - req: 待签名的请求。
+		//   - payloadHash: 请求体的 SHA-256 十六进制摘要。
+		//   - now: 签名时间。
+		//
+		// 返回：
+		//   - error: 获取凭证或签名失败时返回错误。
+		Sign(ctx context.Context, req *http.Request, payloadHash string, now time.Time) error
+	}
+
+	// SignHookOption 定义修改签名 Hook 配置的函数。
+	SignHookOption func(h *signHook)
+
+	// signHook 在请求发送前调用 Signer 签名，并可根据响应 Date 头校正时钟偏移。
+	signHook struct {
+		// signer 是实际写入签名的实现。
+		signer Signer
+		// now 返回本地当前时间。
+		now func() time.Time
+		// skew 是当前生效的时钟偏移量，单位为纳秒。
+		skew atomic.Int64
+		// skewThreshold 是自动校正时钟偏移的阈值；非正值表示不自动校正。
+		skewThreshold time.Duration
+	}
+)
+
+// StaticCredentials 返回始终提供同一组凭证的 CredentialsProvider。
+//
+// 参数：
+//   - accessKeyID: 访问密钥标识。
+//   - secretAccessKey: 私密密钥。
+//
+// 返回：
+//   - CredentialsProvider: 固定凭证提供者。
+func StaticCredentials(accessKeyID, secretAccessKey string) CredentialsProvider {
+	creds := Credentials{AccessKeyID: accessKeyID, SecretAccessKey: secretAccessKey}
+	return CredentialsProviderFunc(func(ctx context.Context) (Credentials, error) {
+		return creds, nil
+	})
+}
+
+// Retrieve 调用函数本身返回凭证。
+//
+// 参数：
+//   - ctx: 当前请求的上下文。
+//
+// 返回：
+//   - Credentials: 签名凭证。
+//   - error: 函数返回的错误。
+func (f CredentialsProviderFunc) Retrieve(ctx context.Context) (Credentials, error) {
+	return f(ctx)
+}
+
+// WithSignClock 设置签名 Hook 读取本地时间的函数，主要用于测试。
+//
+// 参数：
+//   - now: 返回当前时间的函数；为 nil 时使用 time.Now。
+//
+// 返回：
+//   - SignHookOption: 应用于 NewSignHook 的配置项。
+func WithSignClock(now func() time.Time) SignHookOption {
+	return func(h *signHook) {
+		if nil != now {
+			h.now = now
+		}
+	}
+}
+
+// WithSignClockSkew 设置签名时间相对本地时钟的初始偏移量。
+//
+// 已知本地时钟与服务端存在固定偏差时使用；正值表示签名时间比本地时钟晚。
+//
+// 参数：
+//   - skew: 时钟偏移量。
+//
+// 返回：
+//   - SignHookOption: 应用于 NewSignHook 的配置项。
+func WithSignClockSkew(skew time.Duration) SignHookOption {
+	return func(h *signHook) {
+		h.skew.Store(int64(skew))
+	}
+}
+
+// WithSignSkewCorrection 启用基于响应 Date 头的时钟偏移自动校正。
+//
+// 请求完成后，若服务端 Date 头与本地时间相差超过 threshold，后续请求会按该差值调整
+// 签名时间；差值不超过 threshold 时偏移量归零。Date 头只有秒级精度，threshold 应明显大于 1 秒。
+//
+// 参数：
+//   - threshold: 触发校正的最小偏差；非正值表示不自动校正。
+//
+// 返回：
+//   - SignHookOption: 应用于 NewSignHook 的配置项。
+func WithSignSkewCorrection(threshold time.Duration) SignHookOption {
+	return func(h *signHook) {
+		h.skewThreshold = threshold
+	}
+}
+
+// NewSignHook 创建在请求发送前调用 signer 签名的 Hook。
+//
+// Before 会读取并还原请求体以计算 SHA-256 摘要，然后以校正后的时间调用 signer；签名失败时
+// 请求被中止。签名 Hook 应注册在其他会修改请求的 Hook 之后，保证签名覆盖最终的请求内容。
+//
+// 参数：
+//   - signer: 签名实现。
+//   - opts: 时钟相关的可选配置。
+//
+// 返回：
+//   - Hook: 可注册到 HookManager 的签名 Hook。
+func NewSignHook(signer Signer, opts ...SignHookOption) Hook {
+	h := &signHook{
+		signer: signer,
+		now:    time.Now,
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// Before 计算请求体摘要并为请求签名。
+//
+// 参数：
+//   - ctx: 当前 HTTP Hook 上下文。
+//
+// 返回：
+//   - error: 读取请求体或签名失败时返回错误。
+func (h *signHook) Before(ctx *HookContext) error {
+	req := ctx.Request()
+	payloadHash, err := hashRequestBody(req)
+	if nil != err {
+		return err
+	}
+	skew := time.Duration(h.skew.Load())
+	ctx.SetHookValue(HookValueSignClockSkew, skew)
+	return h.signer.Sign(ctx, req, payloadHash, h.now().Add(skew).UTC())
+}
+
+// After 根据响应 Date 头更新时钟偏移量。
+//
+// 参数：
+//   - ctx: 当前 HTTP Hook 上下文。
+//
+// 返回：
+//   - error: 固定返回 nil。
+func (h *signHook) After(ctx *HookContext) error {
+	if h.skewThreshold <= 0 {
+		return nil
+	}
+	resp, ok := ctx.OriginResult().(*http.Response)
+	if !ok || nil == resp {
+		return nil
+	}
+	serverTime, err := http.ParseTime(resp.Header.Get("Date"))
+	if nil != err {
+		return nil
+	}
+	skew := serverTime.Sub(h.now())
+	if skew < h.skewThreshold && skew > -h.skewThreshold {
+		skew = 0
+	}
+	h.skew.Store(int64(skew))
+	return nil
+}
+
+// hashRequestBody 读取请求体并计算 SHA-256 十六进制摘要。
+//
+// 读取后请求体被替换为内存副本，并设置 GetBody 以支持重定向和重试。
+//
+// 参数：
+//   - req: 待签名的请求。
+//
+// 返回：
+//   - string: 请求体的 SHA-256 十六进制摘要；没有请求体时为空内容的摘要。
+//   - error: 读取请求体失败时返回错误。
+func hashRequestBody(req *http.Request) (string, error) {
+	if nil == req.Body || http.NoBody == req.Body {
+		return emptyPayloadHash, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if nil != err {
+		return "", err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	req.ContentLength = int64(len(body))
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// retrieveCredentials 获取并校验签名凭证。
+//
+// 参数：
+//   - ctx: 当前请求的上下文。
+//   - provider: 凭证提供者。
+//
+// 返回：
+//   - Credentials: 签名凭证。
+//   - error: 获取失败或凭证不完整时返回错误。
+func retrieveCredentials(ctx context.Context, provider CredentialsProvider) (Credentials, error) {
+	creds, err := provider.Retrieve(ctx)
+	if nil != err {
+		return Credentials{}, err
+	}
+	if "" == creds.AccessKeyID || "" == creds.SecretAccessKey {
+		return Credentials{}, ErrMissingCredentials
+	}
+	return creds, nil
+}
@@ -0,0 +1,152 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package http
+
+import (
+	"context"
+	"crypto/hmac"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// HMACAlgorithm 是 HMACSigner 写入 Authorization 请求头的算法标识。
+	HMACAlgorithm = "KIT-HMAC-SHA256"
+	// HMACDateHeader 是 HMACSigner 写入签名时间的请求头，格式为 20060102T150405Z。
+	HMACDateHeader = "X-Kit-Date"
+	// HMACContentSHA256Header 是 HMACSigner 写入请求体 SHA-256 摘要的请求头。
+	HMACContentSHA256Header = "X-Kit-Content-Sha256"
+)
+
+var (
+	// 断言 HMACSigner 实现 Signer 接口。
+	_ Signer = (*HMACSigner)(nil)
+)
+
+type (
+	// HMACSigner 实现基于请求头和请求体摘要的 HMAC-SHA256 签名。
+	//
+	// 待签名字符串由以下各行组成，以换行分隔：
+	//
+	//	KIT-HMAC-SHA256
+	//	<X-Kit-Date>
+	//	<方法>
+	//	<已转义路径>
+	//	<规范化查询字符串>
+	//	<规范化请求头块>
+	//	<参与签名的请求头名称，以分号分隔>
+	//	<请求体 SHA-256 十六进制摘要>
+	//
+	// 参与签名的请求头包括 host、content-type、所有 X-Kit-* 请求头以及通过 WithSignedHeaders
+	// 指定的请求头。签名结果以小写十六进制写入 Authorization 请求头：
+	// "KIT-HMAC-SHA256 Credential=<AccessKeyID>, SignedHeaders=<names>, Signature=<hex>"。
+	HMACSigner struct {
+		// provider 提供签名凭证。
+		provider CredentialsProvider
+		// options 是可选签名配置。
+		options signerOptions
+	}
+)
+
+// NewHMACSigner 创建 HMAC-SHA256 签名器。
+//
+// 参数：
+//   - provider: 凭证提供者。
+//   - opts: 可选签名配置；WithContentSHA256Header 对本签名器无效。
+//
+// 返回：
+//   - *HMACSigner: HMAC 签名器。
+func NewHMACSigner(provider CredentialsProvider, opts ...SignerOption) *HMACSigner {
+	s := &HMACSigner{provider: provider}
+	for _, opt := range opts {
+		opt(&s.options)
+	}
+	return s
+}
+
+// Sign 为请求写入 X-Kit-Date、X-Kit-Content-Sha256 和 Authorization 请求头。
+//
+// 参数：
+//   - ctx: 当前请求的上下文。
+//   - req: 待签名的请求。
+//   - payloadHash: 请求体的 SHA-256 十六进制摘要。
+//   - now: 签名时间。
+//
+// 返回：
+//   - error: 获取凭证失败时返回错误。
+func (s *HMACSigner) Sign(ctx context.Context, req *http.Request, payloadHash string, now time.Time) error {
+	creds, err := retrieveCredentials(ctx, s.provider)
+	if nil != err {
+		return err
+	}
+
+	date := now.UTC().Format(sigV4TimeFormat)
+	req.Header.Set(HMACDateHeader, date)
+	req.Header.Set(HMACContentSHA256Header, payloadHash)
+
+	signature, signedHeaders := s.signature(req, creds.SecretAccessKey, payloadHash)
+	req.Header.Set("Authorization", HMACAlgorithm+
+		" Credential="+creds.AccessKeyID+
+		", SignedHeaders="+signedHeaders+
+		", Signature="+signature)
+	return nil
+}
+
+// Verify 使用 secret 重新计算签名并与请求中的 Authorization 比较，供服务端或测试校验。
+//
+// Verify 按当前签名器的配置确定参与签名的请求头，服务端应使用与客户端相同的 WithSignedHeaders 配置；
+// Verify 不检查签名时间的有效期，调用方应自行比较 X-Kit-Date 与当前时间。
+//
+// 参数：
+//   - req: 已签名的请求，请求体摘要取自 X-Kit-Content-Sha256 请求头。
+//   - secret: 与 Credential 对应的私密密钥。
+//
+// 返回：
+//   - bool: 签名一致时返回 true。
+func (s *HMACSigner) Verify(req *http.Request, secret string) bool {
+	auth := req.Header.Get("Authorization")
+	idx := strings.Index(auth, "Signature=")
+	if !strings.HasPrefix(auth, HMACAlgorithm+" ") || idx < 0 {
+		return false
+	}
+	got, err := hex.DecodeString(auth[idx+len("Signature="):])
+	if nil != err {
+		return false
+	}
+	want, _ := s.signature(req, secret, req.Header.Get(HMACContentSHA256Header))
+	wantBytes, _ := hex.DecodeString(want)
+	return hmac.Equal(got, wantBytes)
+}
+
+// signature 计算请求的签名。
+//
+// 参数：
+//   - req: 待签名的请求。
+//   - secret: 私密密钥。
+//   - payloadHash: 请求体摘要。
+//
+// 返回：
+//   - string: 小写十六进制签名。
+//   - string: 以分号分隔的参与签名请求头名称。
+func (s *HMACSigner) signature(req *http.Request, secret, payloadHash string) (string, string) {
+	names := signedHeaderNames(req, func(name string) bool {
+		return "content-type" == name || strings.HasPrefix(name, "x-kit-")
+	}, s.options.signedHeaders)
+	signedHeaders := strings.Join(names, ";")
+
+	stringToSign := strings.Join([]string{
+		HMACAlgorithm,
+		req.Header.Get(HMACDateHeader),
+		req.Method,
+		canonicalPath(req.URL),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders(req, names),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+	return hex.EncodeToString(hmacSHA256([]byte(secret), stringToSign)), signedHeaders
+}
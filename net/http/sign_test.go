@@ -0,0 +1,214 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package http
+
+import (
+	"context"
+	"errors"
+	"io"
+	stdhttp "net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSigV4Signer_KnownVector 验证 SigV4Signer 对 AWS 官方示例请求生成相同的签名。
+//
+// 示例来自 AWS Signature Version 4 文档中的 IAM ListUsers 请求。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestSigV4Signer_KnownVector(t *testing.T) {
+	req, err := stdhttp.NewRequest(stdhttp.MethodGet, "https://iam.amazonaws.com/?Action=ListUsers&Version=2010-05-08", nil)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+
+	signer := NewSigV4Signer(StaticCredentials("AKIDEXAMPLE", "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY"), "us-east-1", "iam")
+	now := time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC)
+	require.NoError(t, signer.Sign(context.Background(), req, emptyPayloadHash, now))
+
+	assert.Equal(t, "20150830T123600Z", req.Header.Get("X-Amz-Date"))
+	assert.Equal(t,
+		"AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/iam/aws4_request, "+
+			"SignedHeaders=content-type;host;x-amz-date, "+
+			"Signature=5d672d79c15b13162d9279b0855cfba6789a8edb4c82c400e06b5924a6f2b5d7",
+		req.Header.Get("Authorization"))
+}
+
+// TestSigV4Signer_Options 验证会话令牌、请求体摘要请求头和额外签名请求头的处理。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestSigV4Signer_Options(t *testing.T) {
+	provider := CredentialsProviderFunc(func(ctx context.Context) (Credentials, error) {
+		return Credentials{AccessKeyID: "id", SecretAccessKey: "secret", SessionToken: "token"}, nil
+	})
+	req, err := stdhttp.NewRequest(stdhttp.MethodPut, "https://bucket.s3.amazonaws.com/a%20b.txt?x=1 2", nil)
+	require.NoError(t, err)
+	req.Header.Set("X-Trace", "abc")
+
+	signer := NewSigV4Signer(provider, "eu-west-1", "s3", WithContentSHA256Header(true), WithSignedHeaders("X-Trace", "X-Absent"))
+	require.NoError(t, signer.Sign(context.Background(), req, "deadbeef", time.Now()))
+
+	assert.Equal(t, "token", req.Header.Get("X-Amz-Security-Token"))
+	assert.Equal(t, "deadbeef", req.Header.Get("X-Amz-Content-Sha256"))
+	assert.Contains(t, req.Header.Get("Authorization"),
+		"SignedHeaders=host;x-amz-content-sha256;x-amz-date;x-amz-security-token;x-trace,")
+	assert.Equal(t, "x=1%202", canonicalQuery(req.URL.Query()))
+	assert.Equal(t, "/a%20b.txt", canonicalPath(req.URL))
+}
+
+// TestSigner_CredentialErrors 验证凭证获取失败或凭证不完整时签名失败。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestSigner_CredentialErrors(t *testing.T) {
+	errProvider := errors.New("provider failed")
+
+	tests := []struct {
+		name         string
+		description  string
+		giveProvider CredentialsProvider
+		wantErr      error
+	}{
+		{
+			name:        "error/provider",
+			description: "验证凭证提供者的错误原样返回。",
+			giveProvider: CredentialsProviderFunc(func(ctx context.Context) (Credentials, error) {
+				return Credentials{}, errProvider
+			}),
+			wantErr: errProvider,
+		},
+		{
+			name:         "error/missing-secret",
+			description:  "验证缺少密钥时返回 ErrMissingCredentials。",
+			giveProvider: StaticCredentials("id", ""),
+			wantErr:      ErrMissingCredentials,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			for _, signer := range []Signer{
+				NewHMACSigner(tt.giveProvider),
+				NewSigV4Signer(tt.giveProvider, "r", "s"),
+			} {
+				req := httptest.NewRequest(stdhttp.MethodGet, "http://example.com/", nil)
+				assert.ErrorIs(t, signer.Sign(context.Background(), req, emptyPayloadHash, time.Now()), tt.wantErr)
+				assert.Empty(t, req.Header.Get("Authorization"))
+			}
+		})
+	}
+}
+
+// TestClient_WithSignerHMAC 验证客户端签名请求后服务端可以校验签名，且请求体保持完整。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestClient_WithSignerHMAC(t *testing.T) {
+	signer := NewHMACSigner(StaticCredentials("app", "s3cr3t"), WithSignedHeaders("X-Request-Id"))
+
+	var (
+		mu       sync.Mutex
+		verified []bool
+		bodies   []string
+	)
+	server := httptest.NewServer(stdhttp.HandlerFunc(func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		verified = append(verified, signer.Verify(r, "s3cr3t"), !signer.Verify(r, "wrong"))
+		bodies = append(bodies, string(body))
+		mu.Unlock()
+		assert.Equal(t, sha256Hex(body), r.Header.Get(HMACContentSHA256Header))
+		if "/orders" == r.URL.Path {
+			assert.Contains(t, r.Header.Get("Authorization"), "SignedHeaders=content-type;host;x-kit-content-sha256;x-kit-date;x-request-id,")
+		}
+		w.WriteHeader(stdhttp.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+
+	hook := &recordingHook{}
+	c := NewClient(WithHook(hook), WithSigner(signer))
+
+	req, err := stdhttp.NewRequest(stdhttp.MethodPost, server.URL+"/orders?b=2&a=1", strings.NewReader(`{"id":1}`))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Request-Id", "r-1")
+
+	resp, err := c.Do(context.Background(), req)
+	require.NoError(t, err)
+	closeResponseBody(t, resp)
+
+	resp, err = c.Get(context.Background(), server.URL+"/ping")
+	require.NoError(t, err)
+	closeResponseBody(t, resp)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []bool{true, true, true, true}, verified)
+	assert.Equal(t, []string{`{"id":1}`, ""}, bodies)
+	assert.Equal(t, int32(2), hook.beforeCalls.Load(), "自定义 Hook 应与签名 Hook 一起执行。")
+}
+
+// TestSignHook_SkewCorrection 验证签名 Hook 根据响应 Date 头校正后续请求的签名时间。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestSignHook_SkewCorrection(t *testing.T) {
+	local := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	server := local.Add(10 * time.Minute)
+
+	var (
+		mu    sync.Mutex
+		dates []string
+	)
+	ts := httptest.NewServer(stdhttp.HandlerFunc(func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		dates = append(dates, r.Header.Get(HMACDateHeader))
+		w.Header().Set("Date", server.Format(stdhttp.TimeFormat))
+	}))
+	t.Cleanup(ts.Close)
+
+	c := NewClient(
+		WithHook(NewHookManager()),
+		WithSigner(NewHMACSigner(StaticCredentials("id", "secret")),
+			WithSignClock(func() time.Time { return local }),
+			WithSignClockSkew(time.Minute),
+			WithSignSkewCorrection(30*time.Second)),
+	)
+
+	for i := 0; i < 2; i++ {
+		resp, err := c.Get(context.Background(), ts.URL)
+		require.NoError(t, err)
+		closeResponseBody(t, resp)
+	}
+
+	mu.Lock()
+	assert.Equal(t, []string{
+		local.Add(time.Minute).Format(sigV4TimeFormat),
+		server.Format(sigV4TimeFormat),
+	}, dates)
+
+	// 偏差小于阈值时偏移量归零。
+	server = local.Add(time.Second)
+	mu.Unlock()
+	for i := 0; i < 2; i++ {
+		resp, err := c.Get(context.Background(), ts.URL)
+		require.NoError(t, err)
+		closeResponseBody(t, resp)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, local.Format(sigV4TimeFormat), dates[3])
+}
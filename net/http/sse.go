@@ -0,0 +1,389 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package http
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrSSEMaxRetries 表示 SSE 连续重连失败的次数超过了 WithSSEMaxRetries 设置的上限。
+	ErrSSEMaxRetries = errors.New("sse reconnect attempts exhausted")
+)
+
+const (
+	// contentTypeEventStream 是 SSE 响应的 Content-Type。
+	contentTypeEventStream = "text/event-stream"
+	// headerLastEventID 是重连时携带最后事件标识的请求头。
+	headerLastEventID = "Last-Event-ID"
+
+	// sseRetryDefault 是服务端未通过 retry 字段指定时的默认重连间隔。
+	sseRetryDefault = 3 * time.Second
+	// sseEventDefault 是未指定 event 字段时的事件类型。
+	sseEventDefault = "message"
+)
+
+type (
+	// SSEEvent 表示一个 Server-Sent Events 事件。
+	SSEEvent struct {
+		// ID 是事件分发时的最后事件标识，未设置过 id 字段时为空。
+		ID string
+		// Event 是事件类型，未指定时为 message。
+		Event string
+		// Data 是事件数据，多个 data 行之间以换行符连接。
+		Data string
+	}
+
+	// SSEOption 定义配置 SSEClient 的函数。
+	//
+	// 参数：
+	//   - s: 待配置的 SSE 客户端。
+	SSEOption func(s *SSEClient)
+
+	// SSEClient 是支持自动重连与 Last-Event-ID 续传的 Server-Sent Events 客户端。
+	//
+	// 连接断开后，SSEClient 按服务端 retry 字段或 WithSSERetry 设置的间隔重连，并在请求头中携带最后收到的事件标识，
+	// 由服务端从断点继续推送。同一个 SSEClient 不应并发调用 Subscribe。
+	SSEClient struct {
+		client         Client          // 发送请求的 HTTP 客户端。
+		url            string          // 事件流地址。
+		header         http.Header     // 每次连接附加的请求头。
+		maxRetries     int             // 连续重连失败的上限，非正值表示不限制。
+		requestOptions []RequestOption // 每次连接使用的请求级配置覆盖。
+
+		mu          sync.Mutex    // 保护 lastEventID 和 retry。
+		lastEventID string        // 最后收到的事件标识。
+		retry       time.Duration // 当前重连间隔。
+	}
+
+	// sseRetryableError 标记可以通过重连恢复的错误。
+	sseRetryableError struct {
+		err error // 原始错误。
+	}
+)
+
+// WithSSEClient 设置 SSEClient 发送请求使用的 HTTP 客户端。
+//
+// 参数：
+//   - c: HTTP 客户端；为 nil 时使用全局默认客户端。
+//
+// 返回：
+//   - SSEOption: SSE 客户端配置项。
+func WithSSEClient(c Client) SSEOption {
+	return func(s *SSEClient) {
+		s.client = c
+	}
+}
+
+// WithSSEHeader 为每次连接添加请求头，例如认证信息。
+//
+// 参数：
+//   - key: 请求头名称。
+//   - value: 请求头取值。
+//
+// 返回：
+//   - SSEOption: SSE 客户端配置项。
+func WithSSEHeader(key, value string) SSEOption {
+	return func(s *SSEClient) {
+		s.header.Add(key, value)
+	}
+}
+
+// WithSSELastEventID 设置首次连接携带的 Last-Event-ID，用于进程重启后从持久化的位置续传。
+//
+// 参数：
+//   - id: 最后事件标识。
+//
+// 返回：
+//   - SSEOption: SSE 客户端配置项。
+func WithSSELastEventID(id string) SSEOption {
+	return func(s *SSEClient) {
+		s.lastEventID = id
+	}
+}
+
+// WithSSERetry 设置初始重连间隔，服务端通过 retry 字段下发的间隔会覆盖该值。
+//
+// 参数：
+//   - retry: 重连间隔；非正值时使用默认的 3 秒。
+//
+// 返回：
+//   - SSEOption: SSE 客户端配置项。
+func WithSSERetry(retry time.Duration) SSEOption {
+	return func(s *SSEClient) {
+		if retry > 0 {
+			s.retry = retry
+		}
+	}
+}
+
+// WithSSEMaxRetries 设置连续重连失败的上限。
+//
+// 连接成功并收到至少一个事件后计数清零。
+//
+// 参数：
+//   - maxRetries: 连续失败上限；非正值表示不限制，默认不限制。
+//
+// 返回：
+//   - SSEOption: SSE 客户端配置项。
+func WithSSEMaxRetries(maxRetries int) SSEOption {
+	return func(s *SSEClient) {
+		s.maxRetries = maxRetries
+	}
+}
+
+// WithSSERequestOptions 设置每次连接使用的请求级配置覆盖，例如 WithoutHooks。
+//
+// SSEClient 默认通过 WithTimeoutOverride(0) 取消客户端的总超时，这里传入的配置在其后应用。
+//
+// 参数：
+//   - opts: 请求级配置覆盖。
+//
+// 返回：
+//   - SSEOption: SSE 客户端配置项。
+func WithSSERequestOptions(opts ...RequestOption) SSEOption {
+	return func(s *SSEClient) {
+		s.requestOptions = append(s.requestOptions, opts...)
+	}
+}
+
+// NewSSEClient 创建订阅指定地址的 SSE 客户端。
+//
+// 参数：
+//   - url: 事件流地址。
+//   - opts: 可选配置项，按传入顺序应用。
+//
+// 返回：
+//   - *SSEClient: SSE 客户端实例。
+func NewSSEClient(url string, opts ...SSEOption) *SSEClient {
+	s := &SSEClient{
+		url:    url,
+		header: make(http.Header),
+		retry:  sseRetryDefault,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// LastEventID 返回最后收到的事件标识，可用于持久化续传位置。
+//
+// 参数：无。
+//
+// 返回：
+//   - string: 最后事件标识；尚未收到带 id 的事件时为 WithSSELastEventID 设置的值。
+func (s *SSEClient) LastEventID() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.lastEventID
+}
+
+// Subscribe 连接事件流并依次回调事件，断线后自动重连，直到 ctx 取消或出现不可恢复的错误。
+//
+// 网络错误、连接中断、5xx 与 429 响应会在等待重连间隔后重连；服务端返回 204 表示不再需要重连，Subscribe 返回 nil；
+// 其它非 200 状态码或 Content-Type 不是 text/event-stream 时立即返回包装了 ErrUnexpectedStatus 的错误。
+//
+// 参数：
+//   - ctx: 订阅上下文，取消后断开连接并返回 ctx.Err()。
+//   - fn: 事件回调，在 Subscribe 所在 goroutine 中顺序执行；返回错误时断开连接并原样返回该错误。
+//
+// 返回：
+//   - error: 订阅结束的原因。
+func (s *SSEClient) Subscribe(ctx context.Context, fn func(*SSEEvent) error) error {
+	failures := 0
+	for {
+		received, err := s.connect(ctx, fn)
+		if nil != ctx.Err() {
+			return ctx.Err()
+		}
+		var retryable *sseRetryableError
+		if !errors.As(err, &retryable) {
+			// 回调错误、不可重连的响应以及服务端要求停止（nil）都直接返回。
+			return err
+		}
+
+		if received {
+			failures = 0
+		}
+		failures++
+		if s.maxRetries > 0 && failures > s.maxRetries {
+			return fmt.Errorf("%w: %v", ErrSSEMaxRetries, retryable.err)
+		}
+
+		timer := time.NewTimer(s.retryInterval())
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// Error 返回原始错误信息。
+//
+// 参数：无。
+//
+// 返回：
+//   - string: 原始错误信息。
+func (e *sseRetryableError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap 返回原始错误。
+//
+// 参数：无。
+//
+// 返回：
+//   - error: 原始错误。
+func (e *sseRetryableError) Unwrap() error {
+	return e.err
+}
+
+// connect 建立一次连接并读取事件直到连接结束。
+//
+// 参数：
+//   - ctx: 订阅上下文。
+//   - fn: 事件回调。
+//
+// 返回：
+//   - bool: 本次连接是否分发过事件。
+//   - error: 可重连的错误以 *sseRetryableError 返回；服务端返回 204 时为 nil。
+func (s *SSEClient) connect(ctx context.Context, fn func(*SSEEvent) error) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if nil != err {
+		return false, err
+	}
+	for key, values := range s.header {
+		req.Header[key] = append([]string(nil), values...)
+	}
+	req.Header.Set("Accept", contentTypeEventStream)
+	req.Header.Set("Cache-Control", "no-cache")
+	if id := s.LastEventID(); "" != id {
+		req.Header.Set(headerLastEventID, id)
+	}
+
+	c := s.client
+	if nil == c {
+		c = clientDef()
+	}
+	resp, err := c.Do(ctx, req, append([]RequestOption{WithTimeoutOverride(0)}, s.requestOptions...)...)
+	if nil != err {
+		return false, &sseRetryableError{err: err}
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch {
+	case http.StatusNoContent == resp.StatusCode:
+		return false, nil
+	case http.StatusTooManyRequests == resp.StatusCode || resp.StatusCode >= http.StatusInternalServerError:
+		return false, &sseRetryableError{err: fmt.Errorf("%w: %d", ErrUnexpectedStatus, resp.StatusCode)}
+	case http.StatusOK != resp.StatusCode:
+		return false, fmt.Errorf("%w: %d", ErrUnexpectedStatus, resp.StatusCode)
+	}
+	if mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")); contentTypeEventStream != mediaType {
+		return false, fmt.Errorf("%w: content type %q", ErrUnexpectedStatus, resp.Header.Get("Content-Type"))
+	}
+
+	return s.read(resp.Body, fn)
+}
+
+// read 按 SSE 规范解析事件流并分发事件。
+//
+// 参数：
+//   - r: 响应体。
+//   - fn: 事件回调。
+//
+// 返回：
+//   - bool: 是否分发过事件。
+//   - error: 回调错误原样返回；流结束或读取失败以 *sseRetryableError 返回。
+func (s *SSEClient) read(r io.Reader, fn func(*SSEEvent) error) (bool, error) {
+	br := bufio.NewReader(r)
+	received := false
+	var event string
+	var data strings.Builder
+	hasData := false
+
+	for {
+		line, err := br.ReadString('\n')
+		if nil != err {
+			// 流结束时丢弃未以空行结束的事件。
+			if errors.Is(err, io.EOF) {
+				err = io.ErrUnexpectedEOF
+			}
+			return received, &sseRetryableError{err: err}
+		}
+		line = strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r")
+
+		if "" == line {
+			if hasData {
+				received = true
+				if "" == event {
+					event = sseEventDefault
+				}
+				if errFn := fn(&SSEEvent{ID: s.LastEventID(), Event: event, Data: data.String()}); nil != errFn {
+					return received, errFn
+				}
+			}
+			event = ""
+			data.Reset()
+			hasData = false
+			continue
+		}
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "event":
+			event = value
+		case "data":
+			if hasData {
+				data.WriteByte('\n')
+			}
+			data.WriteString(value)
+			hasData = true
+		case "id":
+			if !strings.ContainsRune(value, 0) {
+				s.mu.Lock()
+				s.lastEventID = value
+				s.mu.Unlock()
+			}
+		case "retry":
+			if ms, errAtoi := strconv.Atoi(value); nil == errAtoi && ms >= 0 {
+				s.mu.Lock()
+				s.retry = time.Duration(ms) * time.Millisecond
+				s.mu.Unlock()
+			}
+		}
+	}
+}
+
+// retryInterval 返回当前的重连间隔。
+//
+// 参数：无。
+//
+// 返回：
+//   - time.Duration: 重连间隔。
+func (s *SSEClient) retryInterval() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.retry
+}
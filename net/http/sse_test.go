@@ -0,0 +1,200 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	stdhttp "net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newSSETestClient 创建不带日志和追踪 Hook 的测试客户端。
+//
+// 返回：
+//   - Client: 测试用 HTTP 客户端。
+func newSSETestClient() Client {
+	return NewClient(WithLogSlow(0), WithLogError(false), WithTraceEnable(false))
+}
+
+// TestSSEClient_Parse 验证事件流按规范解析多行数据、事件类型、注释、id 与 retry 字段。
+//
+// 参数：
+//   - t: 测试上下文。
+func TestSSEClient_Parse(t *testing.T) {
+	stream := strings.Join([]string{
+		": comment",
+		"retry: 10",
+		"data: first",
+		"data:second",
+		"",
+		"event: update",
+		"id: 7",
+		"data: {\"v\":1}",
+		"",
+		"id",
+		"data",
+		"",
+		"data: ignored-without-blank-line",
+	}, "\r\n")
+
+	server := httptest.NewServer(stdhttp.HandlerFunc(func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+		assert.Equal(t, contentTypeEventStream, r.Header.Get("Accept"))
+		assert.Equal(t, "token", r.Header.Get("Authorization"))
+		assert.Equal(t, "5", r.Header.Get(headerLastEventID))
+		w.Header().Set("Content-Type", "text/event-stream; charset=utf-8")
+		_, _ = w.Write([]byte(stream))
+	}))
+	t.Cleanup(server.Close)
+
+	errStop := errors.New("stop")
+	s := NewSSEClient(server.URL,
+		WithSSEClient(newSSETestClient()),
+		WithSSEHeader("Authorization", "token"),
+		WithSSELastEventID("5"),
+	)
+	var events []SSEEvent
+	err := s.Subscribe(t.Context(), func(e *SSEEvent) error {
+		events = append(events, *e)
+		if 3 == len(events) {
+			return errStop
+		}
+		return nil
+	})
+
+	require.ErrorIs(t, err, errStop)
+	assert.Equal(t, []SSEEvent{
+		{ID: "5", Event: "message", Data: "first\nsecond"},
+		{ID: "7", Event: "update", Data: `{"v":1}`},
+		{ID: "", Event: "message", Data: ""},
+	}, events)
+	assert.Equal(t, "", s.LastEventID())
+	assert.Equal(t, 10*time.Millisecond, s.retryInterval())
+}
+
+// TestSSEClient_Reconnect 验证断线后按 retry 间隔重连并携带 Last-Event-ID。
+//
+// 参数：
+//   - t: 测试上下文。
+func TestSSEClient_Reconnect(t *testing.T) {
+	var mu sync.Mutex
+	var lastEventIDs []string
+	connections := 0
+
+	server := httptest.NewServer(stdhttp.HandlerFunc(func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+		mu.Lock()
+		connections++
+		n := connections
+		lastEventIDs = append(lastEventIDs, r.Header.Get(headerLastEventID))
+		mu.Unlock()
+
+		switch n {
+		case 2:
+			// 服务端暂时不可用，客户端应继续重连。
+			w.WriteHeader(stdhttp.StatusServiceUnavailable)
+			return
+		case 4:
+			// 服务端要求停止重连。
+			w.WriteHeader(stdhttp.StatusNoContent)
+			return
+		}
+		w.Header().Set("Content-Type", contentTypeEventStream)
+		_, _ = fmt.Fprintf(w, "retry: 1\nid: %d\ndata: event-%d\n\n", n, n)
+	}))
+	t.Cleanup(server.Close)
+
+	s := NewSSEClient(server.URL, WithSSEClient(newSSETestClient()), WithSSERetry(time.Hour))
+	var data []string
+	err := s.Subscribe(t.Context(), func(e *SSEEvent) error {
+		data = append(data, e.Data)
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"event-1", "event-3"}, data)
+	assert.Equal(t, []string{"", "1", "1", "3"}, lastEventIDs)
+	assert.Equal(t, "3", s.LastEventID())
+}
+
+// TestSSEClient_Errors 验证不可恢复的响应、重连上限与上下文取消。
+//
+// 参数：
+//   - t: 测试上下文。
+func TestSSEClient_Errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		giveHandler stdhttp.HandlerFunc
+		giveOptions []SSEOption
+		giveTimeout time.Duration
+		wantErr     error
+	}{
+		{
+			name:        "error/client-status",
+			description: "验证 4xx 响应不重连并返回 ErrUnexpectedStatus。",
+			giveHandler: func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+				w.WriteHeader(stdhttp.StatusUnauthorized)
+			},
+			wantErr: ErrUnexpectedStatus,
+		},
+		{
+			name:        "error/content-type",
+			description: "验证 Content-Type 不是 text/event-stream 时返回 ErrUnexpectedStatus。",
+			giveHandler: func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte("{}"))
+			},
+			wantErr: ErrUnexpectedStatus,
+		},
+		{
+			name:        "error/max-retries",
+			description: "验证连续失败超过上限时返回 ErrSSEMaxRetries。",
+			giveHandler: func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+				w.WriteHeader(stdhttp.StatusBadGateway)
+			},
+			giveOptions: []SSEOption{WithSSERetry(time.Millisecond), WithSSEMaxRetries(2)},
+			wantErr:     ErrSSEMaxRetries,
+		},
+		{
+			name:        "error/context-canceled",
+			description: "验证等待重连期间上下文取消时返回上下文错误。",
+			giveHandler: func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+				w.WriteHeader(stdhttp.StatusTooManyRequests)
+			},
+			giveOptions: []SSEOption{WithSSERetry(time.Hour), WithSSERequestOptions(WithoutHooks())},
+			giveTimeout: 50 * time.Millisecond,
+			wantErr:     context.DeadlineExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			server := httptest.NewServer(tt.giveHandler)
+			t.Cleanup(server.Close)
+
+			ctx := t.Context()
+			if tt.giveTimeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.giveTimeout)
+				defer cancel()
+			}
+
+			opts := append([]SSEOption{WithSSEClient(newSSETestClient())}, tt.giveOptions...)
+			err := NewSSEClient(server.URL, opts...).Subscribe(ctx, func(*SSEEvent) error { return nil })
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package http
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+var (
+	// ErrUnexpectedStatus 表示流式响应返回了无法处理的 HTTP 状态码。
+	ErrUnexpectedStatus = errors.New("unexpected http status")
+
+	// ErrInvalidNDJSON 表示 NDJSON 流中存在不是合法 JSON 的行。
+	ErrInvalidNDJSON = errors.New("invalid ndjson line")
+)
+
+const (
+	// contentTypeNDJSON 是 NDJSON 请求使用的 Accept 类型。
+	contentTypeNDJSON = "application/x-ndjson"
+)
+
+// DecodeNDJSON 逐行读取 NDJSON（换行分隔的 JSON）流并依次回调。
+//
+// 每行去除首尾空白后按一个 JSON 值处理，空行会被跳过；行长度不受限制，最后一行可以没有换行符。
+// 传给 fn 的 json.RawMessage 在回调返回后仍然有效。
+//
+// 参数：
+//   - r: NDJSON 数据流。
+//   - fn: 每个 JSON 值的回调；返回错误时停止读取并原样返回该错误。
+//
+// 返回：
+//   - error: 读取失败、某行不是合法 JSON（包装 ErrInvalidNDJSON 并带行号）或 fn 返回错误时返回；正常读完返回 nil。
+func DecodeNDJSON(r io.Reader, fn func(json.RawMessage) error) error {
+	br := bufio.NewReader(r)
+	for line := 1; ; line++ {
+		data, err := br.ReadBytes('\n')
+		if nil != err && !errors.Is(err, io.EOF) {
+			return err
+		}
+		if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 {
+			if !json.Valid(trimmed) {
+				return fmt.Errorf("%w: line %d", ErrInvalidNDJSON, line)
+			}
+			if errFn := fn(json.RawMessage(trimmed)); nil != errFn {
+				return errFn
+			}
+		}
+		if nil != err {
+			return nil
+		}
+	}
+}
+
+// GetNDJSON 发送 HTTP GET 请求并逐行消费 NDJSON 响应。
+//
+// 请求携带 `Accept: application/x-ndjson`，默认通过 WithTimeoutOverride(0) 取消客户端的总超时，
+// 流的生命周期由 ctx 控制；opts 中显式传入的 WithTimeoutOverride 会覆盖该默认值。
+//
+// 参数：
+//   - ctx: 请求上下文，取消后停止读取。
+//   - url: 请求地址。
+//   - fn: 每个 JSON 值的回调，语义同 DecodeNDJSON。
+//   - opts: 仅作用于本次请求的配置覆盖。
+//
+// 返回：
+//   - error: 请求失败、响应状态码不是 2xx（包装 ErrUnexpectedStatus）或解码失败时返回错误。
+func (c *client) GetNDJSON(ctx context.Context, url string, fn func(json.RawMessage) error, opts ...RequestOption) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if nil != err {
+		return err
+	}
+	req.Header.Set("Accept", contentTypeNDJSON)
+
+	resp, err := c.Do(ctx, req, append([]RequestOption{WithTimeoutOverride(0)}, opts...)...)
+	if nil != err {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("%w: %d", ErrUnexpectedStatus, resp.StatusCode)
+	}
+	return DecodeNDJSON(resp.Body, fn)
+}
+
+// GetNDJSON 使用全局默认客户端发送 HTTP GET 请求并逐行消费 NDJSON 响应。
+//
+// 参数：
+//   - ctx: 请求上下文，取消后停止读取。
+//   - url: 请求地址。
+//   - fn: 每个 JSON 值的回调，语义同 DecodeNDJSON。
+//   - opts: 仅作用于本次请求的配置覆盖。
+//
+// 返回：
+//   - error: 请求失败、响应状态码不是 2xx 或解码失败时返回错误。
+func GetNDJSON(ctx context.Context, url string, fn func(json.RawMessage) error, opts ...RequestOption) error {
+	return clientDef().GetNDJSON(ctx, url, fn, opts...)
+}
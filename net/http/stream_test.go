@@ -0,0 +1,145 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	stdhttp "net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDecodeNDJSON 验证 NDJSON 流的逐行解码、空行跳过与错误处理。
+//
+// 参数：
+//   - t: 测试上下文。
+func TestDecodeNDJSON(t *testing.T) {
+	errStop := errors.New("stop")
+
+	tests := []struct {
+		name        string
+		description string
+		giveInput   string
+		giveStopAt  int
+		wantValues  []string
+		wantErr     error
+	}{
+		{
+			name:        "success/lines",
+			description: "验证按行回调并跳过空行，最后一行可以没有换行符。",
+			giveInput:   "{\"a\":1}\r\n\n  [1,2]  \n\"s\"",
+			wantValues:  []string{`{"a":1}`, `[1,2]`, `"s"`},
+		},
+		{
+			name:        "boundary/empty",
+			description: "验证空输入不回调且返回 nil。",
+			giveInput:   "",
+		},
+		{
+			name:        "error/invalid-line",
+			description: "验证非法 JSON 行返回 ErrInvalidNDJSON，之前的行已回调。",
+			giveInput:   "1\n{bad\n2\n",
+			wantValues:  []string{"1"},
+			wantErr:     ErrInvalidNDJSON,
+		},
+		{
+			name:        "error/callback",
+			description: "验证回调返回错误时停止读取并原样返回。",
+			giveInput:   "1\n2\n3\n",
+			giveStopAt:  2,
+			wantValues:  []string{"1", "2"},
+			wantErr:     errStop,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			var values []string
+			err := DecodeNDJSON(strings.NewReader(tt.giveInput), func(m json.RawMessage) error {
+				values = append(values, string(m))
+				if len(values) == tt.giveStopAt {
+					return errStop
+				}
+				return nil
+			})
+
+			assert.Equal(t, tt.wantValues, values)
+			if nil != tt.wantErr {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	t.Run("error/read", func(t *testing.T) {
+		errRead := errors.New("read failed")
+		err := DecodeNDJSON(iotest.ErrReader(errRead), func(json.RawMessage) error { return nil })
+		assert.ErrorIs(t, err, errRead)
+	})
+}
+
+// TestClient_GetNDJSON 验证 GetNDJSON 的请求头、超时覆盖与状态码处理。
+//
+// 参数：
+//   - t: 测试上下文。
+func TestClient_GetNDJSON(t *testing.T) {
+	server := httptest.NewServer(stdhttp.HandlerFunc(func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+		if "/missing" == r.URL.Path {
+			w.WriteHeader(stdhttp.StatusNotFound)
+			return
+		}
+		assert.Equal(t, contentTypeNDJSON, r.Header.Get("Accept"))
+		w.Header().Set("Content-Type", contentTypeNDJSON)
+		flusher := w.(stdhttp.Flusher)
+		for _, line := range []string{`{"n":1}`, `{"n":2}`} {
+			_, _ = w.Write([]byte(line + "\n"))
+			flusher.Flush()
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	c := NewClient(WithLogSlow(0), WithLogError(false), WithTraceEnable(false))
+
+	var got []int
+	err := c.GetNDJSON(t.Context(), server.URL, func(m json.RawMessage) error {
+		var v struct{ N int }
+		require.NoError(t, json.Unmarshal(m, &v))
+		got = append(got, v.N)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, got)
+
+	err = c.GetNDJSON(t.Context(), server.URL+"/missing", func(json.RawMessage) error { return nil })
+	assert.ErrorIs(t, err, ErrUnexpectedStatus)
+
+	err = c.GetNDJSON(t.Context(), "://bad", func(json.RawMessage) error { return nil })
+	assert.Error(t, err)
+
+	// 包级函数委托给默认客户端。
+	originalClientDefault := clientDefault
+	fake := &fakeClient{}
+	clientDefault = fake
+	t.Cleanup(func() {
+		clientDefault = originalClientDefault
+	})
+	var fakeValue string
+	require.NoError(t, GetNDJSON(t.Context(), "http://example.test/ndjson", func(m json.RawMessage) error {
+		fakeValue = string(m)
+		return nil
+	}))
+	assert.Equal(t, `"fake"`, fakeValue)
+	assert.Equal(t, fakeClientCall{Operation: "GetNDJSON", Method: stdhttp.MethodGet, URL: "http://example.test/ndjson"}, fake.calls[0])
+}
@@ -0,0 +1,133 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package http
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+)
+
+var (
+	// ErrInvalidTLSConfig 表示 WithClientCertificate 或 WithRootCAFiles 提供的证书无法加载。
+	//
+	// 加载失败不会让 NewClient 失败，而是由该客户端的每次请求返回包装了该错误的错误，避免在未完成 mTLS 配置时发出请求。
+	ErrInvalidTLSConfig = errors.New("invalid tls configuration")
+)
+
+// WithClientCertificate 设置 mTLS 使用的客户端证书。
+//
+// 证书与私钥在 NewClient 时加载，该选项只在使用 NewClient 内置 Transport 时生效；
+// 多次调用会追加多张证书，握手时由标准库按服务端要求选择。
+//
+// 参数：
+//   - certFile: PEM 格式的证书文件路径，可以包含中间证书。
+//   - keyFile: PEM 格式的私钥文件路径。
+//
+// 返回：
+//   - Option: 应用于 [NewClient] 的 TLS 配置项；文件无法加载时客户端的请求返回 [ErrInvalidTLSConfig]。
+func WithClientCertificate(certFile, keyFile string) Option {
+	return func(c *client) {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if nil != err {
+			c.setTLSError(fmt.Errorf("%w: load client certificate %s: %v", ErrInvalidTLSConfig, certFile, err))
+			return
+		}
+		c.tlsCertificates = append(c.tlsCertificates, cert)
+	}
+}
+
+// WithRootCAs 设置校验服务端证书使用的根证书池。
+//
+// 设置非 nil 的根证书池后，内置 Transport 会启用服务端证书校验，不再沿用默认的跳过校验行为。
+// 该选项只在使用 NewClient 内置 Transport 时生效，会替换之前通过 WithRootCAs 或 WithRootCAFiles 设置的根证书。
+//
+// 参数：
+//   - pool: 根证书池，例如 x509.SystemCertPool 的返回值；为 nil 时恢复默认的跳过校验行为。
+//
+// 返回：
+//   - Option: 应用于 [NewClient] 的 TLS 配置项。
+func WithRootCAs(pool *x509.CertPool) Option {
+	return func(c *client) {
+		c.rootCAs = pool
+	}
+}
+
+// WithRootCAFiles 从 PEM 文件加载校验服务端证书使用的根证书。
+//
+// 证书追加到已通过 WithRootCAs 或 WithRootCAFiles 设置的根证书池中，没有时新建一个不含系统根证书的证书池；
+// 设置后内置 Transport 会启用服务端证书校验。该选项只在使用 NewClient 内置 Transport 时生效。
+//
+// 参数：
+//   - files: PEM 格式的 CA 证书文件路径，每个文件可以包含多张证书。
+//
+// 返回：
+//   - Option: 应用于 [NewClient] 的 TLS 配置项；文件无法读取或不包含证书时客户端的请求返回 [ErrInvalidTLSConfig]。
+func WithRootCAFiles(files ...string) Option {
+	return func(c *client) {
+		pool := c.rootCAs
+		if nil == pool {
+			pool = x509.NewCertPool()
+		} else {
+			// 复制一份，避免修改调用方通过 WithRootCAs 传入的证书池。
+			pool = pool.Clone()
+		}
+		for _, file := range files {
+			data, err := os.ReadFile(file)
+			if nil != err {
+				c.setTLSError(fmt.Errorf("%w: read root ca %s: %v", ErrInvalidTLSConfig, file, err))
+				return
+			}
+			if !pool.AppendCertsFromPEM(data) {
+				c.setTLSError(fmt.Errorf("%w: no certificates found in root ca %s", ErrInvalidTLSConfig, file))
+				return
+			}
+		}
+		c.rootCAs = pool
+	}
+}
+
+// WithServerName 设置 TLS 握手使用的服务端名称。
+//
+// 该名称用于 SNI 和服务端证书的主机名校验，适用于通过 IP 或内部域名访问、但证书签发给其他名称的服务。
+// 该选项只在使用 NewClient 内置 Transport 时生效；为空时使用请求 URL 中的主机名。
+//
+// 参数：
+//   - serverName: 服务端名称。
+//
+// 返回：
+//   - Option: 应用于 [NewClient] 的 TLS 配置项。
+func WithServerName(serverName string) Option {
+	return func(c *client) {
+		c.serverName = serverName
+	}
+}
+
+// setTLSError 记录第一个 TLS 配置错误。
+//
+// 参数：
+//   - err: TLS 配置错误。
+func (c *client) setTLSError(err error) {
+	if nil == c.tlsErr {
+		c.tlsErr = err
+	}
+}
+
+// tlsConfig 构造内置 Transport 使用的 TLS 配置。
+//
+// 参数：无。
+//
+// 返回：
+//   - *tls.Config: 包含客户端证书、根证书与服务端名称的 TLS 配置；设置了根证书时启用服务端证书校验。
+func (c *client) tlsConfig() *tls.Config {
+	return &tls.Config{
+		InsecureSkipVerify: tlsInsecureSkipVerifyDefault && nil == c.rootCAs,
+		Certificates:       c.tlsCertificates,
+		RootCAs:            c.rootCAs,
+		ServerName:         c.serverName,
+	}
+}
@@ -0,0 +1,252 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package http
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"log"
+	"math/big"
+	stdhttp "net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testPKI 保存 mTLS 测试使用的 CA、服务端与客户端证书文件。
+type testPKI struct {
+	caFile     string          // caFile 为 CA 证书 PEM 文件路径。
+	caPool     *x509.CertPool  // caPool 为仅包含 CA 证书的证书池。
+	serverCert tls.Certificate // serverCert 为 CA 签发的服务端证书。
+	clientCert string          // clientCert 为客户端证书 PEM 文件路径。
+	clientKey  string          // clientKey 为客户端私钥 PEM 文件路径。
+}
+
+// newTestPKI 生成自签名 CA，并签发服务端与客户端证书。
+//
+// 参数：
+//   - t: 测试上下文，用于创建临时目录并报告生成失败。
+//   - serverName: 服务端证书中的 DNS 名称。
+//
+// 返回：
+//   - *testPKI: 证书文件与证书池。
+func newTestPKI(t *testing.T, serverName string) *testPKI {
+	t.Helper()
+
+	dir := t.TempDir()
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "kit test ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	require.NoError(t, err)
+	caCert, err := x509.ParseCertificate(caDER)
+	require.NoError(t, err)
+
+	issue := func(serial int64, cn string, usage x509.ExtKeyUsage, dnsNames []string) ([]byte, []byte) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(serial),
+			Subject:      pkix.Name{CommonName: cn},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+			DNSNames:     dnsNames,
+			KeyUsage:     x509.KeyUsageDigitalSignature,
+			ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, caCert, &key.PublicKey, caKey)
+		require.NoError(t, err)
+		keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+		require.NoError(t, err)
+		return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+			pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})
+	}
+
+	p := &testPKI{
+		caFile:     filepath.Join(dir, "ca.pem"),
+		caPool:     x509.NewCertPool(),
+		clientCert: filepath.Join(dir, "client.pem"),
+		clientKey:  filepath.Join(dir, "client-key.pem"),
+	}
+	p.caPool.AddCert(caCert)
+	require.NoError(t, os.WriteFile(p.caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER}), 0o600))
+
+	serverPEM, serverKeyPEM := issue(2, serverName, x509.ExtKeyUsageServerAuth, []string{serverName})
+	p.serverCert, err = tls.X509KeyPair(serverPEM, serverKeyPEM)
+	require.NoError(t, err)
+
+	clientPEM, clientKeyPEM := issue(3, "kit-client", x509.ExtKeyUsageClientAuth, nil)
+	require.NoError(t, os.WriteFile(p.clientCert, clientPEM, 0o600))
+	require.NoError(t, os.WriteFile(p.clientKey, clientKeyPEM, 0o600))
+
+	return p
+}
+
+// newMTLSServer 启动要求并校验客户端证书的本地 TLS 服务，响应体为客户端证书的 CommonName。
+//
+// 参数：
+//   - t: 测试上下文，用于注册服务关闭清理逻辑。
+//   - p: 测试证书。
+//
+// 返回：
+//   - *httptest.Server: 已启动的 mTLS 测试服务。
+func newMTLSServer(t *testing.T, p *testPKI) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewUnstartedServer(stdhttp.HandlerFunc(func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+		_, _ = w.Write([]byte(r.TLS.PeerCertificates[0].Subject.CommonName))
+	}))
+	server.TLS = &tls.Config{
+		Certificates: []tls.Certificate{p.serverCert},
+		ClientCAs:    p.caPool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+	}
+	// 握手失败的用例会触发服务端错误日志，测试中丢弃。
+	server.Config.ErrorLog = log.New(io.Discard, "", 0)
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// TestClient_MutualTLS 验证客户端证书、根证书与服务端名称选项配置的 mTLS 请求。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestClient_MutualTLS(t *testing.T) {
+	const serverName = "internal.kit.test"
+	p := newTestPKI(t, serverName)
+	server := newMTLSServer(t, p)
+
+	badCA := filepath.Join(t.TempDir(), "bad.pem")
+	require.NoError(t, os.WriteFile(badCA, []byte("not a certificate"), 0o600))
+
+	tests := []struct {
+		name        string
+		description string
+		opts        []Option
+		wantBody    string
+		wantErrIs   error
+		wantErr     bool
+	}{
+		{
+			name:        "success/ca-files",
+			description: "验证通过 PEM 文件加载根证书并携带客户端证书完成 mTLS 请求。",
+			opts:        []Option{WithClientCertificate(p.clientCert, p.clientKey), WithRootCAFiles(p.caFile), WithServerName(serverName)},
+			wantBody:    "kit-client",
+		},
+		{
+			name:        "success/ca-pool",
+			description: "验证通过证书池设置根证书。",
+			opts:        []Option{WithClientCertificate(p.clientCert, p.clientKey), WithRootCAs(p.caPool), WithServerName(serverName)},
+			wantBody:    "kit-client",
+		},
+		{
+			name:        "success/insecure-default",
+			description: "验证未设置根证书时沿用默认的跳过服务端证书校验。",
+			opts:        []Option{WithClientCertificate(p.clientCert, p.clientKey)},
+			wantBody:    "kit-client",
+		},
+		{
+			name:        "error/missing-client-certificate",
+			description: "验证未提供客户端证书时服务端拒绝握手。",
+			opts:        []Option{WithRootCAFiles(p.caFile), WithServerName(serverName)},
+			wantErr:     true,
+		},
+		{
+			name:        "error/untrusted-server",
+			description: "验证根证书池不包含服务端 CA 时校验失败。",
+			opts:        []Option{WithClientCertificate(p.clientCert, p.clientKey), WithRootCAs(x509.NewCertPool()), WithServerName(serverName)},
+			wantErr:     true,
+		},
+		{
+			name:        "error/server-name-mismatch",
+			description: "验证服务端名称与证书不匹配时校验失败。",
+			opts:        []Option{WithClientCertificate(p.clientCert, p.clientKey), WithRootCAFiles(p.caFile), WithServerName("other.kit.test")},
+			wantErr:     true,
+		},
+		{
+			name:        "error/client-certificate-file",
+			description: "验证客户端证书文件不存在时请求返回 ErrInvalidTLSConfig。",
+			opts:        []Option{WithClientCertificate(filepath.Join(t.TempDir(), "missing.pem"), p.clientKey)},
+			wantErrIs:   ErrInvalidTLSConfig,
+		},
+		{
+			name:        "error/root-ca-file",
+			description: "验证根证书文件不包含证书时请求返回 ErrInvalidTLSConfig。",
+			opts:        []Option{WithClientCertificate(p.clientCert, p.clientKey), WithRootCAFiles(p.caFile, badCA)},
+			wantErrIs:   ErrInvalidTLSConfig,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			c := NewClient(append([]Option{WithLogError(false), WithTimeout(5 * time.Second)}, tt.opts...)...)
+			resp, err := c.Get(context.Background(), server.URL, WithoutHooks())
+			if nil != tt.wantErrIs {
+				assert.ErrorIs(t, err, tt.wantErrIs)
+				assert.Nil(t, resp)
+				return
+			}
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			defer func() { _ = resp.Body.Close() }()
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantBody, string(body))
+		})
+	}
+}
+
+// TestClient_TLSConfig 验证内置 Transport 的 TLS 配置。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestClient_TLSConfig(t *testing.T) {
+	c := NewClient().(*client)
+	cfg := c.transport.TLSClientConfig
+	assert.True(t, cfg.InsecureSkipVerify)
+	assert.Nil(t, cfg.RootCAs)
+	assert.Empty(t, cfg.ServerName)
+
+	pool := x509.NewCertPool()
+	c = NewClient(WithRootCAs(pool), WithServerName("svc.internal")).(*client)
+	cfg = c.transport.TLSClientConfig
+	assert.False(t, cfg.InsecureSkipVerify)
+	assert.Same(t, pool, cfg.RootCAs)
+	assert.Equal(t, "svc.internal", cfg.ServerName)
+
+	// 自定义 Transport 时 TLS 选项不生效。
+	transport := &stdhttp.Transport{}
+	c = NewClient(WithTransport(transport), WithServerName("svc.internal")).(*client)
+	assert.Same(t, transport, c.transport)
+	assert.Nil(t, c.transport.TLSClientConfig)
+}
@@ -8,6 +8,21 @@
 // 因此单条消息的 payload 最大为 uint16 上限。调用方可以通过 Message、MessageFactory
 // 和 FactoryRegister 扩展自定义消息类型；内置实现提供心跳消息、单字符串消息以及与该协议配套的 Scanner。
 //
-// WrapConn 会把 net.Conn 包装为按上述协议收发消息的连接，并可按给定间隔发送心跳包。
+// WrapConn 会把 net.Conn 包装为按上述协议收发消息的连接，并可按给定间隔发送心跳包；
+// WithReadIdleTimeout、WithWriteIdleTimeout 和 WithMaxLifetime 配置独立于心跳的空闲回收与最大存活时长，
+// WithOnTimeoutClose 在连接因超时关闭时回调 CloseReason。
+// 连接建立后可通过 HELLO/CAPABILITIES 控制消息协商协议版本、压缩、加密与最大帧长度，
+// 协商结果由 Conn.Capabilities 暴露。
+// Conn.NegotiatedClose 通过 CLOSE/CLOSE_ACK 控制消息协商关闭：双方停止接受新消息并写出已入队消息，
+// 发起方收到确认后断开连接，Conn.CloseReason 区分协商关闭与其它原因的断开。
+// WithFrameValidator 启用严格校验模式，在分发前检查 payload 长度、消息类型与帧头部，
+// 拒绝时返回 *FrameError 并按原因计数；FuzzScannerRoundTrip 与 FuzzPackRoundTrip 是可供下游复用的模糊测试目标。
+// Router 按消息类型把连接收到的消息分发给 Handler，并按连接级、全局、类型级三层组合 Middleware，
+// Recovery 与 Logging 是内置的 panic 恢复与日志中间件。
+// WithTracerProvider 与 WithConnLogger 为连接启用可选的观测：除心跳外的每条消息收发创建 OpenTelemetry span，
+// 并以带 conn_id 的结构化日志记录收发与连接生命周期；SendMessageContext 以调用方 span 作为发送 span 的父 span。
+// 两者都未设置时收发路径不产生额外开销。
+// PackMessage 与 UnpackMessage 提供与传输无关的单条消息封包与还原；Dial 支持 tcp 与 unix 域套接字，
+// PacketConn 在 udp 与 unixgram 上按数据报收发消息，WithSequence 启用序列号以统计丢包并丢弃重复与乱序的数据报。
 // 连接上的并发、生命周期和共享 channel 约束以 Conn 及其方法文档为准。
 package message
@@ -0,0 +1,113 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package message
+
+import (
+	cockroachdberrors "github.com/cockroachdb/errors"
+)
+
+var (
+	// 断言 closeMessage 实现 Message 接口。
+	_ Message = (*closeMessage)(nil)
+)
+
+type (
+	// closeMessage 是协商关闭使用的控制消息，同时承载关闭意图与关闭确认两种消息类型。
+	//
+	// 两种消息都不携带 payload；解包时忽略 payload 内容，以便后续版本追加字段。
+	closeMessage struct {
+		messageType MessageType // 消息类型，只能是 CloseMessageType 或 CloseAckMessageType。
+	}
+)
+
+// MessageType 返回消息类型。
+//
+// 参数：无。
+//
+// 返回：
+//   - MessageType: 当前消息的协议类型。
+func (m *closeMessage) MessageType() MessageType {
+	return m.messageType
+}
+
+// Pack 返回空 payload。
+//
+// 参数：无。
+//
+// 返回：
+//   - []byte: 长度为 0 的 payload。
+//   - error: 始终为 nil。
+func (m *closeMessage) Pack() ([]byte, error) {
+	return []byte{}, nil
+}
+
+// Unpack 忽略 payload 内容。
+//
+// 参数：
+//   - payload: 待解码的 payload，内容不会被读取。
+//
+// 返回：
+//   - error: 始终为 nil。
+func (m *closeMessage) Unpack(payload []byte) error {
+	return nil
+}
+
+// NewCloseMessage 创建表示关闭意图的控制消息。
+//
+// 通常由 [Conn.NegotiatedClose] 内部发送，调用方无需直接使用。
+//
+// 参数：无。
+//
+// 返回：
+//   - *closeMessage: 新创建的关闭意图消息实例。
+func NewCloseMessage() *closeMessage {
+	return &closeMessage{
+		messageType: CloseMessageType,
+	}
+}
+
+// NewCloseAckMessage 创建确认关闭的控制消息。
+//
+// 通常由连接在收到关闭意图消息后内部发送，调用方无需直接使用。
+//
+// 参数：无。
+//
+// 返回：
+//   - *closeMessage: 新创建的关闭确认消息实例。
+func NewCloseAckMessage() *closeMessage {
+	return &closeMessage{
+		messageType: CloseAckMessageType,
+	}
+}
+
+// GenerateCloseMessage 根据消息类型和 payload 生成关闭意图或关闭确认消息。
+//
+// messageType 必须等于 [CloseMessageType] 或 [CloseAckMessageType]，payload 不能为 nil，允许为空。
+//
+// 参数：
+//   - messageType: 目标消息类型。
+//   - payload: 消息 payload。
+//
+// 返回：
+//   - Message: 生成的关闭控制消息实例。
+//   - error: messageType 不匹配或 payload 为 nil 时返回错误。
+func GenerateCloseMessage(messageType MessageType, payload []byte) (Message, error) {
+	var m *closeMessage
+	var err error
+
+	if messageType != CloseMessageType && messageType != CloseAckMessageType {
+		err = cockroachdberrors.Newf("消息类型 %[1]d 不是关闭控制消息类型。", messageType)
+	} else if nil == payload {
+		err = cockroachdberrors.Newf("有效负载不能为空。")
+	} else {
+		m = &closeMessage{
+			messageType: messageType,
+		}
+
+		err = m.Unpack(payload)
+	}
+
+	return m, err
+}
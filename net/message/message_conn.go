@@ -9,14 +9,15 @@ import (
 	"bytes"
 	"context"
 	"encoding/binary"
-	"math"
 	"net"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	cockroachdberrors "github.com/cockroachdb/errors"
+	"go.opentelemetry.io/otel/trace"
 
+	kitlog "github.com/fsyyft-go/kit/log"
 	kitgoroutine "github.com/fsyyft-go/kit/runtime/goroutine"
 )
 
@@ -119,6 +120,17 @@ type (
 		// 返回：
 		//   - error: 连接已关闭，或消息在入队前因收到关闭通知而被拒绝时返回错误。
 		SendMessage(Message) error
+		// SendMessageContext 将消息放入内部发送队列，并以 ctx 中的 span 作为发送 span 的父 span。
+		//
+		// 未通过 [WithTracerProvider] 启用追踪时与 SendMessage 相同，ctx 只用于关联追踪，不控制入队等待。
+		//
+		// 参数：
+		//   - context.Context: 调用方上下文，例如消息处理函数收到的 ctx。
+		//   - Message: 待异步发送的消息；调用方应保证其非 nil。
+		//
+		// 返回：
+		//   - error: 与 SendMessage 相同。
+		SendMessageContext(context.Context, Message) error
 		// Message 返回连接的共享接收 channel。
 		//
 		// 该 channel 只创建一次；多个消费者同时读取时会竞争消费消息。
@@ -129,6 +141,59 @@ type (
 		// 返回：
 		//   - <-chan Message: 共享的只读消息通道。
 		Message() <-chan Message
+		// Hello 记录本端协议能力并向对端发送握手消息。
+		//
+		// 握手消息与能力确认消息由内部接收流程处理，不会投递到 [Conn.Message] 返回的 channel。
+		// 未调用 Hello 的一端在收到对端握手时会使用 [DefaultCapabilities] 参与协商。
+		//
+		// 参数：
+		//   - Capabilities: 本端协议能力。
+		//
+		// 返回：
+		//   - error: 连接已关闭导致握手消息无法入队时返回错误。
+		Hello(Capabilities) error
+		// Capabilities 返回协商后的协议能力。
+		//
+		// 参数：无。
+		//
+		// 返回：
+		//   - Capabilities: 协商后的协议能力；尚未完成协商时为零值。
+		//   - bool: 是否已经完成协商。
+		Capabilities() (Capabilities, bool)
+		// Negotiated 返回协商完成通知 channel。
+		//
+		// 首次完成协商时该 channel 会被关闭；连接关闭不会关闭该 channel。
+		//
+		// 参数：无。
+		//
+		// 返回：
+		//   - <-chan struct{}: 协商完成时关闭的只读通道。
+		Negotiated() <-chan struct{}
+		// NegotiatedClose 与对端协商关闭连接。
+		//
+		// 调用后 SendMessage 不再接受新消息；关闭意图消息排在已入队消息之后发送，对端写出其已入队消息后回复确认，
+		// 本端收到确认时关闭底层连接。等待期间仍会继续接收并投递对端的消息。
+		//
+		// 参数：
+		//   - time.Duration: 等待对端确认的超时时间；小于等于 0 时使用 5 秒。
+		//
+		// 返回：
+		//   - error: 连接已关闭、等待超时，或在收到确认前连接被其它原因关闭时返回错误；超时时仍会关闭连接。
+		NegotiatedClose(time.Duration) error
+		// CloseReason 返回连接被关闭的原因。
+		//
+		// 参数：无。
+		//
+		// 返回：
+		//   - CloseReason: 超时关闭或协商关闭的原因；连接未关闭，或因 Close、读写失败等其它原因关闭时返回 0。
+		CloseReason() CloseReason
+		// ID 返回连接标识，用于日志与 span 属性。
+		//
+		// 参数：无。
+		//
+		// 返回：
+		//   - string: [WithConnID] 设置的标识，未设置时为进程内递增的序号。
+		ID() string
 	}
 	// conn 将底层 net.Conn 包装为按本包协议异步收发消息的连接，
 	// 同时实现 [Conn] 和 [net.Conn]。
@@ -142,12 +207,40 @@ type (
 		closed       atomic.Bool   // 标记连接是否已进入关闭状态；true 后不会恢复。
 		closedLocker sync.Locker   // 串行化首次关闭流程，避免并发重复关闭底层连接和通道。
 		closedNotify chan struct{} // 首次关闭时关闭，用于通知内部 goroutine 退出。
+		closeReason  atomic.Int64  // 首次关闭时记录的 CloseReason；0 表示未关闭或未指明原因。
+
+		workers sync.WaitGroup // 跟踪 Start 提交的内部任务，供 wait 等待其全部退出。
+
+		closing        atomic.Bool   // 进入协商关闭流程后为 true，SendMessage 不再接受新消息。
+		peerClosing    atomic.Bool   // 收到对端关闭意图消息后为 true。
+		closeAcked     chan struct{} // 收到对端关闭确认时关闭。
+		closeAckedOnce sync.Once     // 保证 closeAcked 只关闭一次。
 
 		messageRead       chan Message // 供 Message 返回的共享接收 channel，首次 Close 时关闭。
 		messageReadLocker sync.RWMutex // 协调接收 goroutine 投递消息与 Close 关闭 messageRead。
 		messageWrite      chan Message // 内部异步发送队列，由 SendMessage 入队、send 出队；Close 不关闭该通道。
 
 		heartbeatInterval time.Duration // 大于 0 时，Start 会按该间隔额外启动心跳发送循环；小于等于 0 时禁用心跳。
+
+		readIdleTimeout  time.Duration           // 读空闲超时；0 表示按心跳间隔取默认值，小于 0 表示不检查。
+		writeIdleTimeout time.Duration           // 写空闲超时；小于等于 0 表示不检查。
+		maxLifetime      time.Duration           // 从 Start 起的最大存活时长；小于等于 0 表示不限制。
+		onTimeoutClose   func(Conn, CloseReason) // 超时关闭连接后的回调；为 nil 时不回调。
+		startedAt        time.Time               // Start 被调用的时间，Start 之后只读。
+		lastRead         atomic.Int64            // 最近一次收到消息的 UnixNano 时间。
+		lastWrite        atomic.Int64            // 最近一次成功写出数据包的 UnixNano 时间。
+
+		localCapabilities atomic.Pointer[Capabilities] // 本端协议能力；为 nil 时使用 DefaultCapabilities。
+		negotiated        atomic.Pointer[Capabilities] // 协商后的协议能力；为 nil 时表示尚未完成协商。
+		negotiatedNotify  chan struct{}                // 首次完成协商时关闭。
+		negotiatedOnce    sync.Once                    // 保证 negotiatedNotify 只关闭一次。
+
+		frameValidator FrameValidator          // 严格校验模式使用的帧校验器；为 nil 时不校验。
+		onFrameReject  func(Conn, *FrameError) // 严格校验拒绝帧并关闭连接后的回调；为 nil 时不回调。
+
+		id     string        // 连接标识，用于日志与 span 属性。
+		tracer trace.Tracer  // 创建收发 span 的 Tracer；为 nil 时不追踪。
+		logger kitlog.Logger // 输出连接与消息日志的日志实例；为 nil 时不输出。
 	}
 )
 
@@ -167,21 +260,54 @@ func (c *conn) Closed() bool {
 //
 // Start 不会自行去重，调用方只应调用一次。传入的上下文结束或连接关闭后，
 // 已成功启动的内部任务会退出；heartbeatInterval 大于 0 时，
-// Start 会额外提交定时心跳发送任务。任务提交通过包级 goroutine 池完成，
+// Start 会额外提交定时心跳发送任务。读空闲、写空闲和最大存活时长从 Start 起计算，
+// 显式配置任一超时时 Start 会额外提交超时检查任务。任务提交通过包级 goroutine 池完成，
 // 提交失败时当前签名不会向调用方返回错误。
 //
 // 参数：
 //   - ctx: 控制内部 goroutine 生命周期的上下文，不能为空。
 func (c *conn) Start(ctx context.Context) {
-	_ = kitgoroutine.Submit(func() { c.send(ctx) })    // 启动发送消息的 goroutine。
-	_ = kitgoroutine.Submit(func() { c.receive(ctx) }) // 启动接收消息的 goroutine。
+	c.startedAt = time.Now()
+	c.lastRead.Store(c.startedAt.UnixNano())
+	c.lastWrite.Store(c.startedAt.UnixNano())
+	c.logStart()
+
+	c.submit(func() { c.send(ctx) })    // 启动发送消息的 goroutine。
+	c.submit(func() { c.receive(ctx) }) // 启动接收消息的 goroutine。
+
+	if c.readIdleTimeout > 0 || c.writeIdleTimeout > 0 || c.maxLifetime > 0 {
+		c.submit(func() { c.watchTimeout(ctx) }) // 启动超时检查的 goroutine。
+	}
 
 	if c.heartbeatInterval > 0 {
 		ticker := time.NewTicker(c.heartbeatInterval)
-		_ = kitgoroutine.Submit(func() { c.sendHeartbeat(ctx, ticker) }) // 启动定时发送心跳包的 goroutine。
+		c.submit(func() { c.sendHeartbeat(ctx, ticker) }) // 启动定时发送心跳包的 goroutine。
+	}
+}
+
+// submit 通过包级 goroutine 池提交内部任务，并在 workers 中登记，提交失败时撤销登记。
+//
+// 参数：
+//   - task: 待执行的内部任务。
+func (c *conn) submit(task func()) {
+	c.workers.Add(1)
+	if nil != kitgoroutine.Submit(func() {
+		defer c.workers.Done()
+		task()
+	}) {
+		c.workers.Done()
 	}
 }
 
+// wait 阻塞直到 Start 提交的内部任务全部退出。
+//
+// 内部任务在 ctx 结束或连接关闭后退出，调用方应先取消 ctx 或调用 Close，否则 wait 可能一直阻塞。
+//
+// 参数：无。
+func (c *conn) wait() {
+	c.workers.Wait()
+}
+
 // SendMessage 将消息放入内部发送队列。
 //
 // SendMessage 可与 Close 和 Closed 并发调用。返回 nil 仅表示消息已入队，
@@ -191,12 +317,35 @@ func (c *conn) Start(ctx context.Context) {
 //   - message: 待异步发送的消息；调用方应保证其非 nil。
 //
 // 返回：
-//   - error: 连接已关闭，或消息在入队前因收到关闭通知而被拒绝时返回错误。
+//   - error: 连接已关闭、正在协商关闭，或消息在入队前因收到关闭通知而被拒绝时返回错误。
 func (c *conn) SendMessage(message Message) error {
+	return c.SendMessageContext(context.Background(), message)
+}
+
+// SendMessageContext 将消息放入内部发送队列，并以 ctx 中的 span 作为发送 span 的父 span。
+//
+// 启用追踪或日志时，除心跳外的消息会被包装后入队，写出后结束发送 span 并记录日志；
+// 入队失败时立即以错误结束 span。ctx 只用于关联追踪，不控制入队等待。
+//
+// 参数：
+//   - ctx: 调用方上下文，例如消息处理函数收到的 ctx。
+//   - message: 待异步发送的消息；调用方应保证其非 nil。
+//
+// 返回：
+//   - error: 连接已关闭、正在协商关闭，或消息在入队前因收到关闭通知而被拒绝时返回错误。
+func (c *conn) SendMessageContext(ctx context.Context, message Message) error {
 	var err error
+	var traced *tracedMessage
+
+	if c.observed(message) {
+		traced = c.traceSend(ctx, message)
+		message = traced
+	}
 
 	if c.Closed() {
 		err = cockroachdberrors.Newf("连接已经关闭。")
+	} else if c.closing.Load() {
+		err = cockroachdberrors.Newf("连接正在关闭。")
 	} else {
 		select {
 		case <-c.closedNotify:
@@ -206,6 +355,10 @@ func (c *conn) SendMessage(message Message) error {
 		}
 	}
 
+	if nil != err && nil != traced {
+		c.finishSend(traced, 0, err)
+	}
+
 	return err
 }
 
@@ -222,6 +375,48 @@ func (c *conn) Message() <-chan Message {
 	return c.messageRead
 }
 
+// Hello 记录本端协议能力并向对端发送握手消息。
+//
+// 握手消息与能力确认消息由内部接收流程处理，不会投递到 [Conn.Message] 返回的 channel。
+// 未调用 Hello 的一端在收到对端握手时会使用 [DefaultCapabilities] 参与协商。
+//
+// 参数：
+//   - capabilities: 本端协议能力。
+//
+// 返回：
+//   - error: 连接已关闭导致握手消息无法入队时返回错误。
+func (c *conn) Hello(capabilities Capabilities) error {
+	c.localCapabilities.Store(&capabilities)
+
+	return c.SendMessage(NewHelloMessage(capabilities))
+}
+
+// Capabilities 返回协商后的协议能力。
+//
+// 参数：无。
+//
+// 返回：
+//   - Capabilities: 协商后的协议能力；尚未完成协商时为零值。
+//   - bool: 是否已经完成协商。
+func (c *conn) Capabilities() (Capabilities, bool) {
+	if negotiated := c.negotiated.Load(); nil != negotiated {
+		return *negotiated, true
+	}
+	return Capabilities{}, false
+}
+
+// Negotiated 返回协商完成通知 channel。
+//
+// 首次完成协商时该 channel 会被关闭；连接关闭不会关闭该 channel。
+//
+// 参数：无。
+//
+// 返回：
+//   - <-chan struct{}: 协商完成时关闭的只读通道。
+func (c *conn) Negotiated() <-chan struct{} {
+	return c.negotiatedNotify
+}
+
 // Read 从底层连接读取原始协议字节流。
 //
 // 该方法直接委托给底层 net.Conn，不参与本类型的消息拆包流程。
@@ -260,6 +455,31 @@ func (c *conn) Write(b []byte) (n int, err error) {
 // 返回：
 //   - error: 首次关闭底层连接时返回的错误；连接已关闭时返回 nil。
 func (c *conn) Close() error {
+	_, err := c.close()
+	return err
+}
+
+// close 关闭连接并通知内部 goroutine 退出，不记录关闭原因。
+//
+// 参数：无。
+//
+// 返回：
+//   - bool: 本次调用是否首次关闭了连接。
+//   - error: 首次关闭底层连接时返回的错误；连接已关闭时返回 nil。
+func (c *conn) close() (bool, error) {
+	return c.closeWithReason(0)
+}
+
+// closeWithReason 关闭连接并通知内部 goroutine 退出，供 Close、超时关闭和协商关闭共用。
+//
+// 参数：
+//   - reason: 首次关闭时记录的关闭原因；0 表示未指明原因。
+//
+// 返回：
+//   - bool: 本次调用是否首次关闭了连接。
+//   - error: 首次关闭底层连接时返回的错误；连接已关闭时返回 nil。
+func (c *conn) closeWithReason(reason CloseReason) (bool, error) {
+	var closed bool
 	var err error
 
 	if !c.Closed() {
@@ -268,6 +488,8 @@ func (c *conn) Close() error {
 		defer c.closedLocker.Unlock()
 
 		if !c.Closed() {
+			closed = true
+			c.closeReason.Store(int64(reason))
 			c.closed.Store(true)
 			close(c.closedNotify) // 通知发送、接收和心跳 goroutine 退出，避免关闭 messageWrite 后并发发送 panic。
 
@@ -276,10 +498,12 @@ func (c *conn) Close() error {
 			c.messageReadLocker.Lock()
 			close(c.messageRead) // 等待接收 goroutine 完成可能的投递后，再关闭消息读取通道。
 			c.messageReadLocker.Unlock()
+
+			c.logClose(reason, err)
 		}
 	}
 
-	return err
+	return closed, err
 }
 
 // LocalAddr 返回底层连接的本地网络地址。
@@ -343,9 +567,7 @@ func (c *conn) SetWriteDeadline(t time.Time) error {
 
 // pack 将消息编码为本包协议定义的完整数据包。
 //
-// 返回结果依次包含 2 字节消息类型、2 字节 payload 长度和 payload 本体，
-// 其中消息类型与长度字段均使用大端序编码。payload 长度超过 uint16 上限时返回错误。
-// message.Pack 及二进制写入错误会被包装后返回。
+// 编码规则与错误语义同 [PackMessage]。
 //
 // 参数：
 //   - message: 待封包的消息；必须非 nil，否则调用 Pack 或 MessageType 时会 panic。
@@ -354,46 +576,7 @@ func (c *conn) SetWriteDeadline(t time.Time) error {
 //   - []byte: 封包成功后的完整协议数据包；失败时为 nil。
 //   - error: message.Pack 失败、payload 长度超限，或协议头与 payload 写入失败时返回错误。
 func (c *conn) pack(message Message) ([]byte, error) {
-	// 定义最终返回的数据包字节数组和错误变量。
-	var data []byte
-	var err error
-
-	// 创建一个字节缓冲区，用于顺序写入消息各字段。
-	buf := &bytes.Buffer{}
-
-	// 步骤 1：调用消息的 Pack 方法获取 payload 数据。
-	// 若 payload 封包失败，则直接返回错误。
-	if payload, errPayload := message.Pack(); nil != errPayload {
-		// 封包 payload 失败，进行错误包装并返回。
-		err = cockroachdberrors.Wrap(errPayload, "消息负载封包出现错误。")
-		// 步骤 2：独立校验 payload 长度是否超过 uint16 最大值（65535）。
-		// 若超出限制，直接返回错误，不再进行后续写入操作。
-	} else if payLoadLength := uint64(len(payload)); payLoadLength > math.MaxUint16 {
-		// payload 长度超限，返回详细错误信息。
-		err = cockroachdberrors.Newf("消息负载长度 %[1]d 超过 uint16 最大值 %[2]d。", payLoadLength, math.MaxUint16)
-		// 步骤 3：写入消息类型字段（2 字节，uint16，BigEndian）。
-		// 若写入失败，则返回错误。
-	} else if errWriteType := binaryWrite(buf, binary.BigEndian, message.MessageType()); nil != errWriteType {
-		// 写入消息类型失败，进行错误包装并返回。
-		err = cockroachdberrors.Wrap(errWriteType, "消息类型封包出现错误。")
-		// 步骤 4：写入 payload 长度字段（2 字节，uint16，BigEndian）。
-		// 此时 payload 长度已保证不超限。
-		// 若写入失败，则返回错误。
-	} else if errWriteLen := binaryWrite(buf, binary.BigEndian, uint16(payLoadLength)); nil != errWriteLen { //nolint:gosec
-		// 写入 payload 长度失败，进行错误包装并返回。
-		err = cockroachdberrors.Wrap(errWriteLen, "消息负载长度封包出现错误。")
-		// 步骤 5：写入 payload 数据本体。
-		// 若写入失败，则返回错误。
-	} else if errWritePayload := binaryWrite(buf, binary.BigEndian, payload); nil != errWritePayload {
-		// 写入 payload 数据失败，进行错误包装并返回。
-		err = cockroachdberrors.Wrap(errWritePayload, "消息负载封包出现错误。")
-		// 步骤 6：所有字段写入成功，将缓冲区内容作为最终数据包返回。
-	} else {
-		data = buf.Bytes()
-	}
-
-	// 返回完整的数据包字节数组和错误信息。
-	return data, err
+	return PackMessage(message)
 }
 
 // send 持续消费内部发送队列并将消息写入底层连接。
@@ -415,15 +598,27 @@ LoopSend:
 		case <-c.closedNotify:
 			break LoopSend
 		case tmp, ok := <-c.messageWrite:
+			traced, _ := tmp.(*tracedMessage)
 			if !ok {
 				_ = c.Close()
 				break LoopSend
 			} else if pack, errPack := c.pack(tmp); nil != errPack {
+				if nil != traced {
+					c.finishSend(traced, 0, errPack)
+				}
 				_ = c.Close()
 				break LoopSend
 			} else if _, errWrite := c.Write(pack); nil != errWrite {
-				_ = c.Close()
+				if nil != traced {
+					c.finishSend(traced, len(pack), errWrite)
+				}
+				_, _ = c.closeWithReason(c.peerCloseReason())
 				break LoopSend
+			} else {
+				c.lastWrite.Store(time.Now().UnixNano())
+				if nil != traced {
+					c.finishSend(traced, len(pack), nil)
+				}
 			}
 		}
 	}
@@ -456,8 +651,13 @@ func (c *conn) generateMessage(scanner *bufio.Scanner) (Message, error) {
 		err = cockroachdberrors.Wrap(errScanner, "扫描出错。")
 		// 调用 scanner.Scan()，尝试扫描下一个 token（即一条完整消息包）。
 	} else if !scanner.Scan() {
-		// 如果 scanner.Scan() 返回 false，说明数据流已结束或无更多消息，返回明确错误。
-		err = cockroachdberrors.Newf("数据流已结束或无更多消息。")
+		if errScanner := scanner.Err(); nil != errScanner {
+			// 扫描过程中出现错误，例如严格校验拒绝了消息帧，进行错误包装并返回。
+			err = cockroachdberrors.Wrap(errScanner, "扫描出错。")
+		} else {
+			// 如果 scanner.Scan() 返回 false，说明数据流已结束或无更多消息，返回明确错误。
+			err = cockroachdberrors.Newf("数据流已结束或无更多消息。")
+		}
 	} else {
 		// 获取扫描到的字节数据。
 		data := scanner.Bytes()
@@ -485,27 +685,94 @@ func (c *conn) generateMessage(scanner *bufio.Scanner) (Message, error) {
 	return message, err
 }
 
+// setNegotiated 保存协商结果并在首次保存时发出通知。
+//
+// 参数：
+//   - capabilities: 协商后的协议能力。
+func (c *conn) setNegotiated(capabilities Capabilities) {
+	c.negotiated.Store(&capabilities)
+	c.negotiatedOnce.Do(func() { close(c.negotiatedNotify) })
+}
+
+// handleControlMessage 处理由连接自身消费的协议控制消息。
+//
+// 收到握手消息时，使用本端能力与对端能力协商，保存结果并回复能力确认消息；
+// 收到能力确认消息时，直接保存对端给出的协商结果。收到关闭意图消息时停止接受新消息并回复关闭确认；
+// 收到关闭确认时通知等待中的 NegotiatedClose。nil 及其它消息不做处理。
+//
+// 参数：
+//   - message: 接收流程还原出的消息。
+//
+// 返回：
+//   - bool: message 为控制消息并已被消费时返回 true。
+//   - error: 协商失败或回复能力确认消息失败时返回错误。
+func (c *conn) handleControlMessage(message Message) (bool, error) {
+	var handled bool
+	var err error
+
+	if nil == message {
+		return handled, err
+	}
+
+	switch message.MessageType() {
+	case HelloMessageType:
+		handled = true
+		if hello, ok := message.(HelloMessage); !ok {
+			err = cockroachdberrors.Newf("握手消息类型 %[1]T 未实现 HelloMessage。", message)
+		} else {
+			local := DefaultCapabilities()
+			if stored := c.localCapabilities.Load(); nil != stored {
+				local = *stored
+			}
+			if negotiated, errNegotiate := NegotiateCapabilities(local, hello.Capabilities()); nil != errNegotiate {
+				err = cockroachdberrors.Wrap(errNegotiate, "协议能力协商失败。")
+			} else {
+				c.setNegotiated(negotiated)
+				err = c.SendMessage(NewCapabilitiesMessage(negotiated))
+			}
+		}
+	case CapabilitiesMessageType:
+		handled = true
+		if capabilities, ok := message.(HelloMessage); !ok {
+			err = cockroachdberrors.Newf("能力确认消息类型 %[1]T 未实现 HelloMessage。", message)
+		} else {
+			c.setNegotiated(capabilities.Capabilities())
+		}
+	case CloseMessageType:
+		handled = true
+		// 不再接受新消息，确认消息排在已入队消息之后发送，由发起方关闭底层连接。
+		c.peerClosing.Store(true)
+		c.closing.Store(true)
+		select {
+		case <-c.closedNotify:
+			err = cockroachdberrors.Newf("连接已经关闭。")
+		case c.messageWrite <- NewCloseAckMessage():
+		}
+	case CloseAckMessageType:
+		handled = true
+		c.closeAckedOnce.Do(func() { close(c.closeAcked) })
+	}
+
+	return handled, err
+}
+
 // receive 持续从底层连接读取协议包并投递到共享消息通道。
 //
 // receive 使用 NewScanner 拆分完整协议包，并通过 generateMessage 还原消息。
+// 握手与能力确认等控制消息由 handleControlMessage 消费，不会投递到共享消息通道，协商失败时会主动关闭连接。
+// 通过 [WithFrameValidator] 启用严格校验时，校验失败的帧不会被分发，连接会被关闭并执行 [WithOnFrameReject] 设置的回调。
 // ctx 结束、连接收到关闭通知、消息解析失败、投递前观察到连接关闭，
-// 或完成一次扫描后发现距离上次成功投递消息已超过超时阈值时，receive 会退出；
+// 或完成一次扫描后发现距离上次成功投递消息已超过读空闲超时时，receive 会退出；
 // 其中除收到关闭通知以及投递前观察到连接已关闭外，其余异常路径都会主动关闭连接。
-// 未配置心跳时超时阈值为 5 秒；配置心跳时为 heartbeatInterval 的 2 倍。
+// 读空闲超时的默认值与配置方式见 [WithReadIdleTimeout]；显式配置时，阻塞在读取上的
+// 空闲连接由 watchTimeout 关闭。
 //
 // 参数：
 //   - ctx: 控制接收循环生命周期的上下文，不能为空。
 func (c *conn) receive(ctx context.Context) {
-	scanner := NewScanner(c)
+	scanner := c.newScanner()
 	lastReceived := time.Now()
-
-	// 默认超时时间为 5 秒。
-	timeoutDuration := int64(5000)
-	if c.heartbeatInterval > 0 {
-		// 如果有发送心跳，则默认超时时间为发送心跳的 2 倍时长。
-		// 心跳是双向的，两倍足够了。
-		timeoutDuration = (c.heartbeatInterval * 2).Milliseconds()
-	}
+	readIdleTimeout := c.effectiveReadIdleTimeout()
 
 LoopReceive:
 	for {
@@ -516,25 +783,44 @@ LoopReceive:
 		case <-c.closedNotify:
 			break LoopReceive
 		default:
-			if tmp, errGenerate := c.generateMessage(scanner); nil != errGenerate {
-				_ = c.Close()
+			tmp, errGenerate := c.generateMessage(scanner)
+			if nil != errGenerate {
+				if closed, _ := c.closeWithReason(c.peerCloseReason()); closed {
+					c.notifyFrameReject(errGenerate)
+				}
 				break LoopReceive
-			} else if s := time.Since(lastReceived).Milliseconds(); s > timeoutDuration {
+			} else if readIdleTimeout > 0 && time.Since(lastReceived) > readIdleTimeout {
+				c.timeoutClose(CloseReasonReadIdle)
+				break LoopReceive
+			}
+
+			// 未启用追踪与日志时 observe 为空回调，不产生额外开销。
+			observe := c.observeReceive(tmp, len(scanner.Bytes()))
+			if handled, errControl := c.handleControlMessage(tmp); nil != errControl {
+				observe(errControl)
 				_ = c.Close()
 				break LoopReceive
+			} else if handled {
+				observe(nil)
+				lastReceived = time.Now()
+				c.lastRead.Store(lastReceived.UnixNano())
 			} else if nil != tmp {
 				c.messageReadLocker.RLock()
 				if c.Closed() {
 					c.messageReadLocker.RUnlock()
+					observe(errConnClosed)
 					break LoopReceive
 				}
 				select {
 				case <-c.closedNotify:
 					c.messageReadLocker.RUnlock()
+					observe(errConnClosed)
 					break LoopReceive
 				case c.messageRead <- tmp:
 					c.messageReadLocker.RUnlock()
+					observe(nil)
 					lastReceived = time.Now()
+					c.lastRead.Store(lastReceived.UnixNano())
 				}
 			}
 		}
@@ -589,14 +875,18 @@ LoopHeartbeat:
 // 返回的连接会创建容量为 5120 的接收与发送队列，但不会自动启动后台任务；
 // 调用方需要显式调用 [Conn.Start] 启动读写循环，且 Start 只应调用一次。
 // heartbeatInterval 大于 0 时，Start 会额外提交定时心跳发送任务。
+// 读空闲超时、写空闲超时、最大存活时长和超时关闭回调通过 opts 配置，
+// 未配置时读空闲超时为心跳间隔的 2 倍，未配置心跳时为 5 秒，并只在收到数据包后检查。
 //
 // 参数：
 //   - c: 待包装的底层网络连接，必须非 nil；调用方负责保证其满足所需的 net.Conn 语义，传入 nil 会导致后续使用时 panic。
 //   - heartbeatInterval: 心跳发送间隔；小于等于 0 时不会启动心跳任务。
+//   - opts: 可选的连接配置，例如 [WithReadIdleTimeout]、[WithWriteIdleTimeout]、[WithMaxLifetime]、[WithOnTimeoutClose]、[WithFrameValidator]、
+//     [WithTracerProvider] 和 [WithConnLogger]。
 //
 // 返回：
 //   - *conn: 包装后的协议连接实例，初始处于未关闭状态；调用方应在不再使用时调用 Close。
-func WrapConn(c net.Conn, heartbeatInterval time.Duration) *conn {
+func WrapConn(c net.Conn, heartbeatInterval time.Duration, opts ...ConnOption) *conn {
 	newConn := &conn{
 		conn:              c,
 		closedLocker:      &sync.Mutex{},
@@ -604,6 +894,12 @@ func WrapConn(c net.Conn, heartbeatInterval time.Duration) *conn {
 		messageRead:       make(chan Message, 5120), // 读取消息通道，缓冲区 5120。
 		messageWrite:      make(chan Message, 5120), // 发送消息通道，缓冲区 5120。
 		heartbeatInterval: heartbeatInterval,
+		negotiatedNotify:  make(chan struct{}),
+		id:                nextConnID(),
+		closeAcked:        make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(newConn)
 	}
 
 	return newConn
@@ -0,0 +1,111 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package message
+
+import (
+	"time"
+
+	cockroachdberrors "github.com/cockroachdb/errors"
+)
+
+const (
+	// defaultNegotiatedCloseTimeout 是 NegotiatedClose 未指定超时时等待对端确认的时长。
+	defaultNegotiatedCloseTimeout = 5 * time.Second
+)
+
+var (
+	// ErrNegotiatedCloseTimeout 表示协商关闭在超时前没有收到对端确认。
+	ErrNegotiatedCloseTimeout = cockroachdberrors.New("等待对端确认关闭超时。")
+)
+
+// NegotiatedClose 与对端协商关闭连接。
+//
+// 首次调用时连接进入关闭流程，SendMessage 不再接受新消息，关闭意图消息排在已入队消息之后发送；
+// 对端收到后同样停止接受新消息，写出其已入队的消息后回复确认，本端收到确认时关闭底层连接，
+// 关闭原因为 [CloseReasonNegotiated]。对端的关闭原因为 [CloseReasonPeerClosed]。
+// 等待期间仍会继续接收并投递对端的消息。对端已发起协商关闭，或本端已有调用在等待时，
+// 本次调用不再发送关闭意图，只等待流程结束。
+//
+// 参数：
+//   - timeout: 等待对端确认的超时时间；小于等于 0 时使用 5 秒。
+//
+// 返回：
+//   - error: 连接已关闭、在收到确认前连接被其它原因关闭时返回错误；超时时关闭连接并返回 [ErrNegotiatedCloseTimeout]。
+func (c *conn) NegotiatedClose(timeout time.Duration) error {
+	if c.Closed() {
+		return cockroachdberrors.Newf("连接已经关闭。")
+	}
+	if timeout <= 0 {
+		timeout = defaultNegotiatedCloseTimeout
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	// 只有首次进入关闭流程的调用发送关闭意图，入队顺序保证已入队消息先于关闭意图写出。
+	if c.closing.CompareAndSwap(false, true) {
+		select {
+		case <-c.closedNotify:
+			return c.negotiatedCloseResult()
+		case <-timer.C:
+			_ = c.Close()
+			return cockroachdberrors.WithStack(ErrNegotiatedCloseTimeout)
+		case c.messageWrite <- NewCloseMessage():
+		}
+	}
+
+	select {
+	case <-c.closeAcked:
+		if _, err := c.closeWithReason(CloseReasonNegotiated); nil != err {
+			return cockroachdberrors.Wrap(err, "关闭底层连接出现错误。")
+		}
+		return c.negotiatedCloseResult()
+	case <-c.closedNotify:
+		return c.negotiatedCloseResult()
+	case <-timer.C:
+		_ = c.Close()
+		return cockroachdberrors.WithStack(ErrNegotiatedCloseTimeout)
+	}
+}
+
+// CloseReason 返回连接被关闭的原因。
+//
+// 参数：无。
+//
+// 返回：
+//   - CloseReason: 超时关闭或协商关闭的原因；连接未关闭，或因 Close、读写失败等其它原因关闭时返回 0。
+func (c *conn) CloseReason() CloseReason {
+	return CloseReason(c.closeReason.Load())
+}
+
+// peerCloseReason 返回读写失败关闭连接时应记录的原因。
+//
+// 对端发起协商关闭后，由对端关闭底层连接导致的读写失败属于预期内的关闭。
+//
+// 参数：无。
+//
+// 返回：
+//   - CloseReason: 已收到对端关闭意图时返回 CloseReasonPeerClosed，否则返回 0。
+func (c *conn) peerCloseReason() CloseReason {
+	if c.peerClosing.Load() {
+		return CloseReasonPeerClosed
+	}
+	return 0
+}
+
+// negotiatedCloseResult 根据连接的关闭原因判断协商关闭是否完成。
+//
+// 参数：无。
+//
+// 返回：
+//   - error: 关闭原因不是 CloseReasonNegotiated 或 CloseReasonPeerClosed 时返回错误。
+func (c *conn) negotiatedCloseResult() error {
+	switch c.CloseReason() {
+	case CloseReasonNegotiated, CloseReasonPeerClosed:
+		return nil
+	default:
+		return cockroachdberrors.Newf("协商关闭完成前连接已经关闭，关闭原因：%[1]s。", c.CloseReason())
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package message
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGenerateCloseMessage 验证关闭控制消息的生成与封包。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestGenerateCloseMessage(t *testing.T) {
+	tests := []struct {
+		name            string
+		description     string
+		giveMessageType MessageType
+		givePayload     []byte
+		wantErr         bool
+	}{
+		{name: "success/close", description: "验证关闭意图消息类型可以生成消息实例。", giveMessageType: CloseMessageType, givePayload: []byte{}},
+		{name: "success/close-ack", description: "验证关闭确认消息类型可以生成消息实例。", giveMessageType: CloseAckMessageType, givePayload: []byte{}},
+		{name: "success/extra-payload", description: "验证多余的 payload 会被忽略。", giveMessageType: CloseMessageType, givePayload: []byte{1, 2}},
+		{name: "error/type-mismatch", description: "验证非关闭控制消息类型会被拒绝。", giveMessageType: HelloMessageType, givePayload: []byte{}, wantErr: true},
+		{name: "error/nil-payload", description: "验证 nil payload 会被拒绝。", giveMessageType: CloseMessageType, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			msg, err := GenerateCloseMessage(tt.giveMessageType, tt.givePayload)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.giveMessageType, msg.MessageType())
+			payload, err := msg.Pack()
+			require.NoError(t, err)
+			assert.Empty(t, payload)
+		})
+	}
+
+	assert.Equal(t, CloseMessageType, NewCloseMessage().MessageType())
+	assert.Equal(t, CloseAckMessageType, NewCloseAckMessage().MessageType())
+	assert.True(t, FactoryRegistered(CloseMessageType))
+	assert.True(t, FactoryRegistered(CloseAckMessageType))
+}
+
+// TestConn_NegotiatedClose 验证协商关闭会先送达已入队消息，再由发起方在收到确认后关闭连接。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestConn_NegotiatedClose(t *testing.T) {
+	leftRaw, rightRaw := netPipe(t)
+	left := WrapConn(leftRaw, 0)
+	right := WrapConn(rightRaw, 0)
+	startConns(t, left, right)
+
+	for _, text := range []string{"a", "b", "c"} {
+		require.NoError(t, left.SendMessage(NewSingleStringMessage(text)))
+	}
+
+	received := make(chan []string, 1)
+	go func() {
+		var texts []string
+		for msg := range right.Message() {
+			texts = append(texts, msg.(SingleStringMessage).Message())
+		}
+		received <- texts
+	}()
+
+	require.NoError(t, left.NegotiatedClose(time.Second))
+	assert.True(t, left.Closed())
+	assert.Equal(t, CloseReasonNegotiated, left.CloseReason())
+	assert.Error(t, left.SendMessage(NewSingleStringMessage("late")))
+	assert.Error(t, left.NegotiatedClose(time.Second))
+
+	select {
+	case texts := <-received:
+		assert.Equal(t, []string{"a", "b", "c"}, texts)
+	case <-time.After(time.Second):
+		require.Fail(t, "timed out waiting for peer to close")
+	}
+	assert.True(t, right.Closed())
+	assert.Equal(t, CloseReasonPeerClosed, right.CloseReason())
+	assert.Error(t, right.SendMessage(NewSingleStringMessage("late")))
+}
+
+// TestConn_NegotiatedCloseTimeout 验证对端不回复确认时按超时关闭连接。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestConn_NegotiatedCloseTimeout(t *testing.T) {
+	leftRaw, rightRaw := netPipe(t)
+	left := WrapConn(leftRaw, 0)
+
+	// 对端只读取数据，不回复确认。
+	go func() { _, _ = io.Copy(io.Discard, rightRaw) }()
+	startConns(t, left)
+
+	err := left.NegotiatedClose(30 * time.Millisecond)
+	assert.ErrorIs(t, err, ErrNegotiatedCloseTimeout)
+	assert.True(t, left.Closed())
+	assert.Equal(t, CloseReason(0), left.CloseReason())
+}
+
+// TestConn_NegotiatedCloseAfterClose 验证连接关闭后无法再协商关闭，且非协商关闭不记录关闭原因。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestConn_NegotiatedCloseAfterClose(t *testing.T) {
+	wrapped := WrapConn(newScriptedConn(nil), 0)
+	require.NoError(t, wrapped.Close())
+
+	assert.Error(t, wrapped.NegotiatedClose(time.Second))
+	assert.Equal(t, CloseReason(0), wrapped.CloseReason())
+}
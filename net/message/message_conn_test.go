@@ -1091,3 +1091,133 @@ func netPipe(t *testing.T) (net.Conn, net.Conn) {
 
 	return left, right
 }
+
+// startConns 启动连接，并在测试结束时取消上下文、关闭连接并等待内部任务全部退出。
+//
+// 测试会临时替换 binaryWrite 等包级变量，等待内部任务退出可以避免上一个测试遗留的 goroutine 与之产生数据竞争。
+//
+// 参数：
+//   - t: 测试上下文，用于注册清理逻辑。
+//   - conns: 待启动的连接。
+func startConns(t *testing.T, conns ...*conn) {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	for _, c := range conns {
+		c.Start(ctx)
+	}
+	t.Cleanup(func() {
+		cancel()
+		for _, c := range conns {
+			_ = c.Close()
+			c.wait()
+		}
+	})
+}
+
+// TestConn_HelloNegotiation 验证双方通过握手消息完成能力协商。
+//
+// 该测试覆盖双方均调用 Hello 与仅一方调用 Hello 两种路径，确保控制消息不会投递给调用方，且双方得到相同协商结果。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestConn_HelloNegotiation(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		giveLeft    *Capabilities
+		giveRight   *Capabilities
+		want        Capabilities
+	}{
+		{
+			name:        "success/both-hello",
+			description: "验证双方都发送握手时，协商结果为双方能力交集。",
+			giveLeft:    &Capabilities{Version: 2, Compression: CompressionGzip | CompressionDeflate, MaxFrameSize: 2048},
+			giveRight:   &Capabilities{Version: 1, Compression: CompressionGzip, MaxFrameSize: 4096},
+			want:        Capabilities{Version: 1, Compression: CompressionGzip, MaxFrameSize: 2048},
+		},
+		{
+			name:        "compatibility/one-side-hello",
+			description: "验证仅一端发送握手时，另一端使用默认能力参与协商。",
+			giveLeft:    &Capabilities{Version: 1, Compression: CompressionGzip, MaxFrameSize: 1024},
+			want:        Capabilities{Version: 1, MaxFrameSize: 1024},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			leftRaw, rightRaw := netPipe(t)
+			left := WrapConn(leftRaw, 0)
+			right := WrapConn(rightRaw, 0)
+			ctx, cancel := context.WithCancel(context.Background())
+			t.Cleanup(cancel)
+			t.Cleanup(func() { _ = left.Close() })
+			t.Cleanup(func() { _ = right.Close() })
+
+			_, ok := left.Capabilities()
+			assert.False(t, ok)
+
+			left.Start(ctx)
+			right.Start(ctx)
+
+			if nil != tt.giveLeft {
+				require.NoError(t, left.Hello(*tt.giveLeft))
+			}
+			if nil != tt.giveRight {
+				require.NoError(t, right.Hello(*tt.giveRight))
+			}
+
+			for _, c := range []*conn{left, right} {
+				select {
+				case <-c.Negotiated():
+				case <-time.After(time.Second):
+					require.Fail(t, "timed out waiting for negotiation")
+				}
+				got, ok := c.Capabilities()
+				require.True(t, ok)
+				assert.Equal(t, tt.want, got)
+			}
+
+			require.NoError(t, left.SendMessage(NewSingleStringMessage("after-hello")))
+			select {
+			case got := <-right.Message():
+				singleString, ok := got.(SingleStringMessage)
+				require.True(t, ok)
+				assert.Equal(t, "after-hello", singleString.Message())
+			case <-time.After(time.Second):
+				require.Fail(t, "timed out waiting for message after hello")
+			}
+		})
+	}
+}
+
+// TestConn_HelloNegotiationFailureClosesConn 验证对端声明不兼容版本时接收流程会关闭连接。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestConn_HelloNegotiationFailureClosesConn(t *testing.T) {
+	// 验证版本为 0 的握手消息会导致协商失败并关闭连接。
+	payload, err := NewHelloMessage(Capabilities{}).Pack()
+	require.NoError(t, err)
+	base := newScriptedConn(buildTestPacket(t, HelloMessageType, payload))
+	wrapped := WrapConn(base, 0)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		wrapped.receive(context.Background())
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		require.Fail(t, "timed out waiting for receive loop to stop")
+	}
+
+	assert.True(t, wrapped.Closed())
+	_, ok := wrapped.Capabilities()
+	assert.False(t, ok)
+}
@@ -0,0 +1,221 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package message
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	// CloseReasonReadIdle 表示连接因超过读空闲超时没有收到消息而被关闭。
+	CloseReasonReadIdle CloseReason = iota + 1
+	// CloseReasonWriteIdle 表示连接因超过写空闲超时没有写出数据而被关闭。
+	CloseReasonWriteIdle
+	// CloseReasonMaxLifetime 表示连接因达到最大存活时长而被关闭。
+	CloseReasonMaxLifetime
+	// CloseReasonNegotiated 表示本端发起协商关闭并收到对端确认后关闭连接。
+	CloseReasonNegotiated
+	// CloseReasonPeerClosed 表示对端发起协商关闭，本端确认后由对端关闭连接。
+	CloseReasonPeerClosed
+)
+
+const (
+	// defaultReadIdleTimeout 是未配置心跳且未设置读空闲超时时使用的读空闲超时。
+	defaultReadIdleTimeout = 5 * time.Second
+	// heartbeatReadIdleFactor 是配置心跳且未设置读空闲超时时，读空闲超时相对心跳间隔的倍数。
+	// 心跳是双向的，两倍间隔内至少应收到一次对端心跳。
+	heartbeatReadIdleFactor = 2
+)
+
+type (
+	// CloseReason 标识连接被超时机制或协商关闭流程关闭的原因。
+	CloseReason int
+
+	// ConnOption 定义 WrapConn 的连接配置选项。
+	ConnOption func(*conn)
+)
+
+// String 返回关闭原因的文本表示。
+//
+// 参数：无。
+//
+// 返回：
+//   - string: 关闭原因名称；未知值返回 "unknown"。
+func (r CloseReason) String() string {
+	switch r {
+	case CloseReasonReadIdle:
+		return "read_idle"
+	case CloseReasonWriteIdle:
+		return "write_idle"
+	case CloseReasonMaxLifetime:
+		return "max_lifetime"
+	case CloseReasonNegotiated:
+		return "negotiated"
+	case CloseReasonPeerClosed:
+		return "peer_closed"
+	default:
+		return "unknown"
+	}
+}
+
+// WithReadIdleTimeout 设置读空闲超时。
+//
+// 超过该时长没有收到任何消息（包括心跳和控制消息）时，连接会被关闭；阻塞在读取上的
+// 空闲连接也会被 Start 启动的超时检查主动关闭。
+//
+// 未设置时保持兼容行为：配置心跳的连接使用心跳间隔的 2 倍，未配置心跳的连接使用 5 秒，
+// 且只在收到下一个数据包后检查，不会主动关闭阻塞在读取上的连接。
+//
+// 参数：
+//   - timeout: 读空闲超时；小于 0 时不检查读空闲，等于 0 时使用默认值。
+//
+// 返回：
+//   - ConnOption: 设置读空闲超时的配置函数。
+func WithReadIdleTimeout(timeout time.Duration) ConnOption {
+	return func(c *conn) {
+		c.readIdleTimeout = timeout
+	}
+}
+
+// WithWriteIdleTimeout 设置写空闲超时。
+//
+// 超过该时长没有向底层连接写出任何数据（包括心跳）时，连接会被关闭。
+// 默认不检查写空闲。
+//
+// 参数：
+//   - timeout: 写空闲超时；小于等于 0 时不检查写空闲。
+//
+// 返回：
+//   - ConnOption: 设置写空闲超时的配置函数。
+func WithWriteIdleTimeout(timeout time.Duration) ConnOption {
+	return func(c *conn) {
+		c.writeIdleTimeout = timeout
+	}
+}
+
+// WithMaxLifetime 设置连接从 Start 起的最大存活时长。
+//
+// 达到该时长后，无论连接是否活跃都会被关闭，便于负载均衡场景下定期重建连接。默认不限制。
+//
+// 参数：
+//   - lifetime: 最大存活时长；小于等于 0 时不限制。
+//
+// 返回：
+//   - ConnOption: 设置最大存活时长的配置函数。
+func WithMaxLifetime(lifetime time.Duration) ConnOption {
+	return func(c *conn) {
+		c.maxLifetime = lifetime
+	}
+}
+
+// WithOnTimeoutClose 设置连接被超时机制关闭后的回调。
+//
+// 回调只在超时检查首次关闭连接时执行一次，在内部 goroutine 中同步调用；
+// 连接已被其它路径关闭时不会执行。
+//
+// 参数：
+//   - fn: 超时关闭回调，参数为被关闭的连接和关闭原因；为 nil 时不回调。
+//
+// 返回：
+//   - ConnOption: 设置超时关闭回调的配置函数。
+func WithOnTimeoutClose(fn func(Conn, CloseReason)) ConnOption {
+	return func(c *conn) {
+		c.onTimeoutClose = fn
+	}
+}
+
+// effectiveReadIdleTimeout 返回实际生效的读空闲超时。
+//
+// 参数：无。
+//
+// 返回：
+//   - time.Duration: 读空闲超时；小于等于 0 表示不检查读空闲。
+func (c *conn) effectiveReadIdleTimeout() time.Duration {
+	switch {
+	case 0 != c.readIdleTimeout:
+		return c.readIdleTimeout
+	case c.heartbeatInterval > 0:
+		return c.heartbeatInterval * heartbeatReadIdleFactor
+	default:
+		return defaultReadIdleTimeout
+	}
+}
+
+// timeoutClose 因超时关闭连接，并在首次关闭时执行超时关闭回调。
+//
+// 参数：
+//   - reason: 关闭原因。
+func (c *conn) timeoutClose(reason CloseReason) {
+	if closed, _ := c.closeWithReason(reason); closed && nil != c.onTimeoutClose {
+		c.onTimeoutClose(c, reason)
+	}
+}
+
+// expired 检查连接是否已超时，并返回距离最近一次可能超时的等待时长。
+//
+// 参数：
+//   - now: 当前时间。
+//
+// 返回：
+//   - CloseReason: 已超时时返回关闭原因，否则返回 0。
+//   - time.Duration: 未超时时距离最近一次可能超时的时长；未启用任何超时时为 0。
+func (c *conn) expired(now time.Time) (CloseReason, time.Duration) {
+	var wait time.Duration
+	check := func(reason CloseReason, timeout time.Duration, since time.Time) CloseReason {
+		if timeout <= 0 {
+			return 0
+		}
+		remaining := timeout - now.Sub(since)
+		if remaining <= 0 {
+			return reason
+		}
+		if 0 == wait || remaining < wait {
+			wait = remaining
+		}
+		return 0
+	}
+
+	if reason := check(CloseReasonMaxLifetime, c.maxLifetime, c.startedAt); 0 != reason {
+		return reason, 0
+	}
+	if reason := check(CloseReasonReadIdle, c.readIdleTimeout, time.Unix(0, c.lastRead.Load())); 0 != reason {
+		return reason, 0
+	}
+	if reason := check(CloseReasonWriteIdle, c.writeIdleTimeout, time.Unix(0, c.lastWrite.Load())); 0 != reason {
+		return reason, 0
+	}
+	return 0, wait
+}
+
+// watchTimeout 在显式配置的读空闲、写空闲或最大存活时长到期时关闭连接。
+//
+// 每次等待到最近一次可能超时的时刻再重新检查，期间收发活动会推迟下一次超时。
+// ctx 结束或连接收到关闭通知时退出。
+//
+// 参数：
+//   - ctx: 控制超时检查生命周期的上下文，不能为空。
+func (c *conn) watchTimeout(ctx context.Context) {
+	for {
+		reason, wait := c.expired(time.Now())
+		if 0 != reason {
+			c.timeoutClose(reason)
+			return
+		}
+		if 0 == wait {
+			return
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-c.closedNotify:
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
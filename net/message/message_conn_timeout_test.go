@@ -0,0 +1,184 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package message
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	kitgoroutine "github.com/fsyyft-go/kit/runtime/goroutine"
+)
+
+// TestCloseReason_String 验证关闭原因的文本表示。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestCloseReason_String(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		giveReason  CloseReason
+		want        string
+	}{
+		{name: "success/read-idle", description: "验证读空闲原因的名称。", giveReason: CloseReasonReadIdle, want: "read_idle"},
+		{name: "success/write-idle", description: "验证写空闲原因的名称。", giveReason: CloseReasonWriteIdle, want: "write_idle"},
+		{name: "success/max-lifetime", description: "验证最大存活时长原因的名称。", giveReason: CloseReasonMaxLifetime, want: "max_lifetime"},
+		{name: "success/negotiated", description: "验证本端协商关闭原因的名称。", giveReason: CloseReasonNegotiated, want: "negotiated"},
+		{name: "success/peer-closed", description: "验证对端协商关闭原因的名称。", giveReason: CloseReasonPeerClosed, want: "peer_closed"},
+		{name: "boundary/unknown", description: "验证未知原因返回 unknown。", giveReason: CloseReason(0), want: "unknown"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			assert.Equal(t, tt.want, tt.giveReason.String())
+		})
+	}
+}
+
+// TestConn_EffectiveReadIdleTimeout 验证读空闲超时的默认值与显式配置。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestConn_EffectiveReadIdleTimeout(t *testing.T) {
+	tests := []struct {
+		name          string
+		description   string
+		giveHeartbeat time.Duration
+		giveOpts      []ConnOption
+		want          time.Duration
+	}{
+		{name: "success/default-without-heartbeat", description: "验证未配置心跳时默认读空闲超时为 5 秒。", want: 5 * time.Second},
+		{name: "success/default-with-heartbeat", description: "验证配置心跳时默认读空闲超时为心跳间隔的 2 倍。", giveHeartbeat: time.Second, want: 2 * time.Second},
+		{name: "success/explicit", description: "验证显式配置覆盖基于心跳的默认值。", giveHeartbeat: time.Second, giveOpts: []ConnOption{WithReadIdleTimeout(30 * time.Second)}, want: 30 * time.Second},
+		{name: "boundary/disabled", description: "验证负值表示不检查读空闲。", giveOpts: []ConnOption{WithReadIdleTimeout(-1)}, want: -1},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			wrapped := WrapConn(newScriptedConn(nil), tt.giveHeartbeat, tt.giveOpts...)
+
+			assert.Equal(t, tt.want, wrapped.effectiveReadIdleTimeout())
+		})
+	}
+}
+
+// TestConn_TimeoutClose 验证超时检查会按配置关闭空闲或超龄连接并回调关闭原因。
+//
+// 该测试使用 net.Pipe 建立本地内存连接，对端不发送任何数据，使接收循环阻塞在读取上，
+// 确保连接由超时检查主动关闭，并在测试结束时确认收发循环和超时检查均已退出。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestConn_TimeoutClose(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		giveOpts    []ConnOption
+		wantReason  CloseReason
+	}{
+		{
+			name:        "success/read-idle",
+			description: "验证对端长时间不发送消息时按读空闲超时关闭连接。",
+			giveOpts:    []ConnOption{WithReadIdleTimeout(20 * time.Millisecond)},
+			wantReason:  CloseReasonReadIdle,
+		},
+		{
+			name:        "success/write-idle",
+			description: "验证长时间没有写出数据时按写空闲超时关闭连接。",
+			giveOpts:    []ConnOption{WithReadIdleTimeout(-1), WithWriteIdleTimeout(20 * time.Millisecond)},
+			wantReason:  CloseReasonWriteIdle,
+		},
+		{
+			name:        "success/max-lifetime",
+			description: "验证达到最大存活时长时关闭连接，且最大存活时长优先于更长的空闲超时。",
+			giveOpts:    []ConnOption{WithReadIdleTimeout(time.Hour), WithMaxLifetime(20 * time.Millisecond)},
+			wantReason:  CloseReasonMaxLifetime,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+			kitgoroutine.VerifyNoneLeaked(t)
+
+			leftRaw, _ := netPipe(t)
+			reasons := make(chan CloseReason, 2)
+			opts := append([]ConnOption{WithOnTimeoutClose(func(c Conn, reason CloseReason) {
+				assert.True(t, c.Closed())
+				reasons <- reason
+			})}, tt.giveOpts...)
+			wrapped := WrapConn(leftRaw, 0, opts...)
+			ctx, cancel := context.WithCancel(context.Background())
+			t.Cleanup(cancel)
+
+			wrapped.Start(ctx)
+
+			select {
+			case got := <-reasons:
+				assert.Equal(t, tt.wantReason, got)
+			case <-time.After(time.Second):
+				require.Fail(t, "timed out waiting for timeout close")
+			}
+			assert.True(t, wrapped.Closed())
+			_, ok := <-wrapped.Message()
+			assert.False(t, ok)
+		})
+	}
+}
+
+// TestConn_TimeoutDeferredByActivity 验证持续收到消息会推迟读空闲超时。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestConn_TimeoutDeferredByActivity(t *testing.T) {
+	leftRaw, rightRaw := netPipe(t)
+	wrapped := WrapConn(leftRaw, 0, WithReadIdleTimeout(60*time.Millisecond))
+	peer := WrapConn(rightRaw, 0, WithReadIdleTimeout(-1))
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	t.Cleanup(func() { _ = wrapped.Close() })
+	t.Cleanup(func() { _ = peer.Close() })
+
+	wrapped.Start(ctx)
+	peer.Start(ctx)
+
+	// 总时长超过读空闲超时数倍，但每条消息的间隔都小于读空闲超时。
+	for i := 0; i < 6; i++ {
+		time.Sleep(20 * time.Millisecond)
+		require.NoError(t, peer.SendMessage(NewSingleStringMessage("keepalive")))
+		select {
+		case _, ok := <-wrapped.Message():
+			require.True(t, ok, "connection closed while peer was sending messages")
+		case <-time.After(time.Second):
+			require.Fail(t, "timed out waiting for keepalive message")
+		}
+	}
+	assert.False(t, wrapped.Closed())
+}
+
+// TestConn_TimeoutCallbackSkippedWhenAlreadyClosed 验证连接已被其它路径关闭时不执行超时回调。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestConn_TimeoutCallbackSkippedWhenAlreadyClosed(t *testing.T) {
+	called := false
+	wrapped := WrapConn(newScriptedConn(nil), 0, WithOnTimeoutClose(func(Conn, CloseReason) { called = true }))
+	require.NoError(t, wrapped.Close())
+
+	wrapped.timeoutClose(CloseReasonReadIdle)
+
+	assert.False(t, called)
+}
@@ -106,6 +106,20 @@ func (f *messageFactory) Generate(messageType MessageType, payload []byte) (Mess
 	return message, err
 }
 
+// Registered 返回消息类型是否已经注册。
+//
+// 与 Generate 相同，Registered 依赖底层 map 读取，通常应在完成注册后再并发调用。
+//
+// 参数：
+//   - messageType: 待检查的消息类型。
+//
+// 返回：
+//   - bool: 消息类型已注册时返回 true。
+func (f *messageFactory) Registered(messageType MessageType) bool {
+	_, exists := f.funcs[messageType]
+	return exists
+}
+
 // NewMessageFactory 创建新的消息工厂实例。
 //
 // 返回的工厂可并发调用 Register；Generate 依赖底层 map 读取，通常应在完成注册后再供并发生成使用。
@@ -147,3 +161,17 @@ func FactoryRegister(messageType MessageType, messageFunc GenerateMessageFunc) e
 func FactoryGenerate(messageType MessageType, payload []byte) (Message, error) {
 	return defaultFactory.Generate(messageType, payload)
 }
+
+// FactoryRegistered 返回消息类型是否已经注册到默认工厂。
+//
+// 参数：
+//   - messageType: 待检查的消息类型。
+//
+// 返回：
+//   - bool: 消息类型已注册到默认工厂时返回 true。
+func FactoryRegistered(messageType MessageType) bool {
+	if registry, ok := defaultFactory.(interface{ Registered(MessageType) bool }); ok {
+		return registry.Registered(messageType)
+	}
+	return false
+}
@@ -0,0 +1,98 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package message
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+
+	cockroachdberrors "github.com/cockroachdb/errors"
+)
+
+// PackMessage 将消息编码为本包协议定义的完整数据包。
+//
+// 返回结果依次包含 2 字节消息类型、2 字节 payload 长度和 payload 本体，
+// 其中消息类型与长度字段均使用大端序编码。payload 长度超过 uint16 上限时返回错误。
+// message.Pack 及二进制写入错误会被包装后返回。流式连接与数据报连接使用同一种帧格式，
+// 调用方也可以直接使用本函数在其它传输上承载消息。
+//
+// 参数：
+//   - message: 待封包的消息；必须非 nil，否则调用 Pack 或 MessageType 时会 panic。
+//
+// 返回：
+//   - []byte: 封包成功后的完整协议数据包；失败时为 nil。
+//   - error: message.Pack 失败、payload 长度超限，或协议头与 payload 写入失败时返回错误。
+func PackMessage(message Message) ([]byte, error) {
+	// 定义最终返回的数据包字节数组和错误变量。
+	var data []byte
+	var err error
+
+	// 创建一个字节缓冲区，用于顺序写入消息各字段。
+	buf := &bytes.Buffer{}
+
+	// 步骤 1：调用消息的 Pack 方法获取 payload 数据。
+	// 若 payload 封包失败，则直接返回错误。
+	if payload, errPayload := message.Pack(); nil != errPayload {
+		// 封包 payload 失败，进行错误包装并返回。
+		err = cockroachdberrors.Wrap(errPayload, "消息负载封包出现错误。")
+		// 步骤 2：独立校验 payload 长度是否超过 uint16 最大值（65535）。
+		// 若超出限制，直接返回错误，不再进行后续写入操作。
+	} else if payLoadLength := uint64(len(payload)); payLoadLength > math.MaxUint16 {
+		// payload 长度超限，返回详细错误信息。
+		err = cockroachdberrors.Newf("消息负载长度 %[1]d 超过 uint16 最大值 %[2]d。", payLoadLength, math.MaxUint16)
+		// 步骤 3：写入消息类型字段（2 字节，uint16，BigEndian）。
+		// 若写入失败，则返回错误。
+	} else if errWriteType := binaryWrite(buf, binary.BigEndian, message.MessageType()); nil != errWriteType {
+		// 写入消息类型失败，进行错误包装并返回。
+		err = cockroachdberrors.Wrap(errWriteType, "消息类型封包出现错误。")
+		// 步骤 4：写入 payload 长度字段（2 字节，uint16，BigEndian）。
+		// 此时 payload 长度已保证不超限。
+		// 若写入失败，则返回错误。
+	} else if errWriteLen := binaryWrite(buf, binary.BigEndian, uint16(payLoadLength)); nil != errWriteLen { //nolint:gosec
+		// 写入 payload 长度失败，进行错误包装并返回。
+		err = cockroachdberrors.Wrap(errWriteLen, "消息负载长度封包出现错误。")
+		// 步骤 5：写入 payload 数据本体。
+		// 若写入失败，则返回错误。
+	} else if errWritePayload := binaryWrite(buf, binary.BigEndian, payload); nil != errWritePayload {
+		// 写入 payload 数据失败，进行错误包装并返回。
+		err = cockroachdberrors.Wrap(errWritePayload, "消息负载封包出现错误。")
+		// 步骤 6：所有字段写入成功，将缓冲区内容作为最终数据包返回。
+	} else {
+		data = buf.Bytes()
+	}
+
+	// 返回完整的数据包字节数组和错误信息。
+	return data, err
+}
+
+// UnpackMessage 将一个完整的协议数据包还原为消息实例。
+//
+// frame 必须恰好包含一条消息：头部声明的 payload 长度与实际长度不一致时返回错误，
+// 适用于数据报等按包划分消息边界的传输；字节流应使用 [NewScanner] 先拆分出完整数据包。
+// 消息通过默认工厂生成，payload 为 frame 的子切片，生成函数需要保留时应自行复制。
+//
+// 参数：
+//   - frame: 包含 4 字节头部与 payload 的完整协议数据包。
+//
+// 返回：
+//   - Message: 还原出的消息实例；失败时为 nil。
+//   - error: 数据包不完整、长度字段不一致，或调用 FactoryGenerate 还原消息失败时返回错误。
+func UnpackMessage(frame []byte) (Message, error) {
+	var message Message
+	var err error
+
+	if len(frame) < messageHeaderLength {
+		err = cockroachdberrors.Newf("数据包长度 %[1]d 不足协议头部长度 %[2]d。", len(frame), messageHeaderLength)
+	} else if length := int(binary.BigEndian.Uint16(frame[2:messageHeaderLength])); length != len(frame)-messageHeaderLength {
+		err = cockroachdberrors.Newf("数据包声明的负载长度 %[1]d 与实际长度 %[2]d 不一致。", length, len(frame)-messageHeaderLength)
+	} else if msg, errGenerate := FactoryGenerate(MessageType(binary.BigEndian.Uint16(frame[:2])), frame[messageHeaderLength:]); nil != errGenerate {
+		err = cockroachdberrors.Wrap(errGenerate, "数据包转消息发生异常。")
+	} else {
+		message = msg
+	}
+
+	return message, err
+}
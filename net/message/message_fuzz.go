@@ -0,0 +1,206 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package message
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+
+	cockroachdberrors "github.com/cockroachdb/errors"
+)
+
+var (
+	// 断言 rawMessage 实现 Message 接口。
+	_ Message = (*rawMessage)(nil)
+)
+
+type (
+	// rawMessage 是只携带消息类型与原始 payload 的消息，用于模糊测试中重新封包。
+	rawMessage struct {
+		messageType MessageType // 消息类型。
+		payload     []byte      // 原始 payload。
+	}
+)
+
+// MessageType 返回消息的协议类型。
+//
+// 参数：无。
+//
+// 返回：
+//   - MessageType: 当前消息的协议类型。
+func (m *rawMessage) MessageType() MessageType {
+	return m.messageType
+}
+
+// Pack 原样返回 payload。
+//
+// 参数：无。
+//
+// 返回：
+//   - []byte: 原始 payload。
+//   - error: 始终为 nil。
+func (m *rawMessage) Pack() ([]byte, error) {
+	return m.payload, nil
+}
+
+// Unpack 原样保存 payload。
+//
+// 参数：
+//   - payload: 原始 payload。
+//
+// 返回：
+//   - error: 始终为 nil。
+func (m *rawMessage) Unpack(payload []byte) error {
+	m.payload = append([]byte(nil), payload...)
+	return nil
+}
+
+// AddScannerFuzzSeeds 向模糊测试添加 [FuzzScannerRoundTrip] 使用的种子语料。
+//
+// 种子覆盖空输入、内置消息、多帧拼接、不完整头部、不完整 payload 与未知类型。
+//
+// 参数：
+//   - f: 模糊测试上下文。
+func AddScannerFuzzSeeds(f *testing.F) {
+	heartbeat, _ := (&conn{}).pack(NewHeartbeatMessage(1))
+	single, _ := (&conn{}).pack(NewSingleStringMessage("hello"))
+	hello, _ := (&conn{}).pack(NewHelloMessage(DefaultCapabilities()))
+
+	f.Add([]byte{})
+	f.Add(heartbeat)
+	f.Add(append(append(append([]byte{}, single...), heartbeat...), hello...))
+	f.Add([]byte{0x00, 0x09, 0x00})
+	f.Add(append(append([]byte{}, single...), 0x00, 0x09, 0x00, 0x10, 'a'))
+	f.Add([]byte{0xff, 0xff, 0x00, 0x01, 0x00})
+	f.Add([]byte{0x00, 0x09, 0xff, 0xff})
+}
+
+// FuzzScannerRoundTrip 校验任意字节流经 Scanner 拆分后再封包能够还原原始字节。
+//
+// 下游可以在自己的模糊测试中直接复用：
+//
+//	func FuzzScanner(f *testing.F) {
+//		message.AddScannerFuzzSeeds(f)
+//		f.Fuzz(message.FuzzScannerRoundTrip)
+//	}
+//
+// 校验内容包括：拆分出的帧依次拼接等于输入前缀，每帧重新封包后与原始字节一致，
+// 剩余字节不足一帧；严格 Scanner 接受的帧是普通 Scanner 结果的前缀，拒绝时返回 *FrameError。
+//
+// 参数：
+//   - t: 测试上下文。
+//   - data: 任意输入字节流。
+func FuzzScannerRoundTrip(t *testing.T, data []byte) {
+	var frames [][]byte
+	consumed := 0
+	scanner := NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		frame := append([]byte(nil), scanner.Bytes()...)
+		if !bytes.Equal(frame, data[consumed:consumed+len(frame)]) {
+			t.Fatalf("帧与输入在偏移 %d 处不一致。", consumed)
+		}
+		if len(frame) < messageHeaderLength || int(binary.BigEndian.Uint16(frame[2:4])) != len(frame)-messageHeaderLength {
+			t.Fatalf("帧长度 %d 与头部声明不一致。", len(frame))
+		}
+
+		message := &rawMessage{messageType: MessageType(binary.BigEndian.Uint16(frame[:2]))}
+		if err := message.Unpack(frame[messageHeaderLength:]); nil != err {
+			t.Fatalf("解包失败：%v", err)
+		}
+		packed, err := (&conn{}).pack(message)
+		if nil != err {
+			t.Fatalf("重新封包失败：%v", err)
+		}
+		if !bytes.Equal(packed, frame) {
+			t.Fatalf("重新封包结果与原始帧不一致：%x != %x", packed, frame)
+		}
+
+		frames = append(frames, frame)
+		consumed += len(frame)
+	}
+	if err := scanner.Err(); nil != err {
+		t.Fatalf("普通 Scanner 返回错误：%v", err)
+	}
+	if rest := data[consumed:]; len(rest) >= messageHeaderLength && int(binary.BigEndian.Uint16(rest[2:4]))+messageHeaderLength <= len(rest) {
+		t.Fatalf("剩余 %d 字节包含完整帧却未被拆分。", len(rest))
+	}
+
+	strict := NewStrictScanner(bytes.NewReader(data), NewFrameValidator())
+	accepted := 0
+	for strict.Scan() {
+		if accepted >= len(frames) || !bytes.Equal(strict.Bytes(), frames[accepted]) {
+			t.Fatalf("严格 Scanner 的第 %d 帧与普通 Scanner 不一致。", accepted)
+		}
+		accepted++
+	}
+	if err := strict.Err(); nil != err {
+		var frameErr *FrameError
+		if !cockroachdberrors.As(err, &frameErr) {
+			t.Fatalf("严格 Scanner 返回非 FrameError 错误：%v", err)
+		}
+	} else if accepted != len(frames) || consumed != len(data) {
+		t.Fatalf("严格 Scanner 未报错却没有接受全部输入：接受 %d/%d 帧，消费 %d/%d 字节。", accepted, len(frames), consumed, len(data))
+	}
+}
+
+// AddPackFuzzSeeds 向模糊测试添加 [FuzzPackRoundTrip] 使用的种子语料。
+//
+// 参数：
+//   - f: 模糊测试上下文。
+func AddPackFuzzSeeds(f *testing.F) {
+	f.Add(uint16(HeartbeatMessageType), []byte{0, 0, 0, 0, 0, 0, 0, 1})
+	f.Add(uint16(SingleStringMessageType), []byte("hello"))
+	f.Add(uint16(0), []byte{})
+	f.Add(uint16(math.MaxUint16), bytes.Repeat([]byte{0xff}, 256))
+}
+
+// FuzzPackRoundTrip 校验任意消息类型与 payload 封包后能被 Scanner 完整拆分并还原。
+//
+// 下游可以在自己的模糊测试中直接复用：
+//
+//	func FuzzPack(f *testing.F) {
+//		message.AddPackFuzzSeeds(f)
+//		f.Fuzz(message.FuzzPackRoundTrip)
+//	}
+//
+// 参数：
+//   - t: 测试上下文。
+//   - messageType: 消息类型。
+//   - payload: 任意 payload；超过 uint16 上限时要求封包失败。
+func FuzzPackRoundTrip(t *testing.T, messageType uint16, payload []byte) {
+	data, err := (&conn{}).pack(&rawMessage{messageType: MessageType(messageType), payload: payload})
+	if len(payload) > math.MaxUint16 {
+		if nil == err {
+			t.Fatalf("payload 长度 %d 超限时封包应失败。", len(payload))
+		}
+		return
+	}
+	if nil != err {
+		t.Fatalf("封包失败：%v", err)
+	}
+	if len(data) != messageHeaderLength+len(payload) {
+		t.Fatalf("封包长度 %d 与期望 %d 不一致。", len(data), messageHeaderLength+len(payload))
+	}
+
+	scanner := NewStrictScanner(bytes.NewReader(data), NewFrameValidator(WithKnownTypes(MessageType(messageType))))
+	if !scanner.Scan() {
+		t.Fatalf("封包结果无法被拆分：%v", scanner.Err())
+	}
+	frame := scanner.Bytes()
+	if !bytes.Equal(frame, data) {
+		t.Fatalf("拆分结果与封包结果不一致：%x != %x", frame, data)
+	}
+	if got := MessageType(binary.BigEndian.Uint16(frame[:2])); got != MessageType(messageType) {
+		t.Fatalf("消息类型 %d 与期望 %d 不一致。", got, messageType)
+	}
+	if !bytes.Equal(frame[messageHeaderLength:], payload) {
+		t.Fatalf("payload 与原始数据不一致。")
+	}
+	if scanner.Scan() {
+		t.Fatalf("单条消息被拆分出多余的帧。")
+	}
+}
@@ -0,0 +1,27 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package message
+
+import (
+	"testing"
+)
+
+// FuzzScanner 对 Scanner 拆分与重新封包的往返进行模糊测试。
+//
+// 参数：
+//   - f: 模糊测试上下文。
+func FuzzScanner(f *testing.F) {
+	AddScannerFuzzSeeds(f)
+	f.Fuzz(FuzzScannerRoundTrip)
+}
+
+// FuzzPack 对封包与 Scanner 拆分的往返进行模糊测试。
+//
+// 参数：
+//   - f: 模糊测试上下文。
+func FuzzPack(f *testing.F) {
+	AddPackFuzzSeeds(f)
+	f.Fuzz(FuzzPackRoundTrip)
+}
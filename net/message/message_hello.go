@@ -0,0 +1,315 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package message
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+
+	cockroachdberrors "github.com/cockroachdb/errors"
+)
+
+var (
+	// 断言 helloMessage 实现 Message 和 HelloMessage 接口。
+	_ Message      = (*helloMessage)(nil)
+	_ HelloMessage = (*helloMessage)(nil)
+)
+
+const (
+	// ProtocolVersion 表示当前实现支持的最高协议版本。
+	ProtocolVersion uint16 = 1
+	// MinProtocolVersion 表示当前实现仍可兼容的最低协议版本。
+	MinProtocolVersion uint16 = 1
+
+	// helloPayloadLength 表示握手消息 payload 的固定长度：版本、压缩、加密和最大帧长度各占 2 字节。
+	helloPayloadLength = 8
+)
+
+const (
+	// CompressionGzip 表示支持 gzip 压缩。
+	CompressionGzip CompressionFlag = 1 << iota
+	// CompressionDeflate 表示支持 deflate 压缩。
+	CompressionDeflate
+	// CompressionSnappy 表示支持 snappy 压缩。
+	CompressionSnappy
+)
+
+const (
+	// EncryptionAES128GCM 表示支持 AES-128-GCM 加密。
+	EncryptionAES128GCM EncryptionFlag = 1 << iota
+	// EncryptionAES256GCM 表示支持 AES-256-GCM 加密。
+	EncryptionAES256GCM
+	// EncryptionChaCha20Poly1305 表示支持 ChaCha20-Poly1305 加密。
+	EncryptionChaCha20Poly1305
+)
+
+type (
+	// CompressionFlag 以位标记表示一组压缩算法。
+	//
+	// 本包只负责协商，不实现具体压缩；协商结果为双方位标记的交集。
+	CompressionFlag uint16
+
+	// EncryptionFlag 以位标记表示一组加密算法。
+	//
+	// 本包只负责协商，不实现具体加密；协商结果为双方位标记的交集。
+	EncryptionFlag uint16
+
+	// Capabilities 描述连接一端支持的协议能力。
+	//
+	// MaxFrameSize 为 0 时表示使用协议允许的最大 payload 长度，即 uint16 上限。
+	Capabilities struct {
+		Version      uint16          // 协议版本。
+		Compression  CompressionFlag // 支持的压缩算法集合。
+		Encryption   EncryptionFlag  // 支持的加密算法集合。
+		MaxFrameSize uint16          // 可接收的最大 payload 长度。
+	}
+
+	// HelloMessage 表示连接建立时交换的握手或能力确认消息。
+	//
+	// [HelloMessageType] 携带发送方本地能力；[CapabilitiesMessageType] 携带发送方协商后的结果。
+	HelloMessage interface {
+		// Capabilities 返回消息携带的协议能力。
+		//
+		// 参数：无。
+		//
+		// 返回：
+		//   - Capabilities: 消息中的协议能力。
+		Capabilities() Capabilities
+	}
+
+	// helloMessage 是 [HelloMessage] 的默认实现，同时承载 HELLO 与 CAPABILITIES 两种消息类型。
+	helloMessage struct {
+		messageType  MessageType  // 消息类型，只能是 HelloMessageType 或 CapabilitiesMessageType。
+		capabilities Capabilities // 消息携带的协议能力。
+	}
+)
+
+// Has 判断压缩算法集合是否包含指定算法。
+//
+// 参数：
+//   - flag: 待检查的压缩算法。
+//
+// 返回：
+//   - bool: 集合包含 flag 中所有位时返回 true。
+func (f CompressionFlag) Has(flag CompressionFlag) bool {
+	return f&flag == flag
+}
+
+// Has 判断加密算法集合是否包含指定算法。
+//
+// 参数：
+//   - flag: 待检查的加密算法。
+//
+// 返回：
+//   - bool: 集合包含 flag 中所有位时返回 true。
+func (f EncryptionFlag) Has(flag EncryptionFlag) bool {
+	return f&flag == flag
+}
+
+// FrameSize 返回实际生效的最大 payload 长度。
+//
+// 参数：无。
+//
+// 返回：
+//   - int: MaxFrameSize 为 0 时返回 uint16 上限，否则返回 MaxFrameSize。
+func (c Capabilities) FrameSize() int {
+	if 0 == c.MaxFrameSize {
+		return math.MaxUint16
+	}
+	return int(c.MaxFrameSize)
+}
+
+// DefaultCapabilities 返回当前实现的默认协议能力。
+//
+// 默认能力只声明协议版本，不声明任何压缩或加密算法，最大帧长度使用协议上限。
+//
+// 参数：无。
+//
+// 返回：
+//   - Capabilities: 默认协议能力。
+func DefaultCapabilities() Capabilities {
+	return Capabilities{
+		Version:      ProtocolVersion,
+		MaxFrameSize: math.MaxUint16,
+	}
+}
+
+// NegotiateCapabilities 根据本端与对端能力计算协商结果。
+//
+// 协商结果取双方版本的较小值、压缩与加密算法的交集，以及双方最大帧长度的较小值。
+// 该运算满足交换律，因此双方独立计算可以得到相同结果。
+//
+// 参数：
+//   - local: 本端能力。
+//   - remote: 对端能力。
+//
+// 返回：
+//   - Capabilities: 协商后的能力。
+//   - error: 任一方版本低于 MinProtocolVersion 时返回错误。
+func NegotiateCapabilities(local, remote Capabilities) (Capabilities, error) {
+	var negotiated Capabilities
+	var err error
+
+	if local.Version < MinProtocolVersion {
+		err = cockroachdberrors.Newf("本端协议版本 %[1]d 低于最低兼容版本 %[2]d。", local.Version, MinProtocolVersion)
+	} else if remote.Version < MinProtocolVersion {
+		err = cockroachdberrors.Newf("对端协议版本 %[1]d 低于最低兼容版本 %[2]d。", remote.Version, MinProtocolVersion)
+	} else {
+		negotiated = Capabilities{
+			Version:      min(local.Version, remote.Version),
+			Compression:  local.Compression & remote.Compression,
+			Encryption:   local.Encryption & remote.Encryption,
+			MaxFrameSize: uint16(min(local.FrameSize(), remote.FrameSize())), //nolint:gosec
+		}
+	}
+
+	return negotiated, err
+}
+
+// MessageType 返回消息类型。
+//
+// 参数：无。
+//
+// 返回：
+//   - MessageType: 当前消息的协议类型。
+func (m *helloMessage) MessageType() MessageType {
+	return m.messageType
+}
+
+// Pack 将协议能力编码为 8 字节大端序 payload。
+//
+// payload 依次包含版本、压缩算法、加密算法和最大帧长度，每个字段 2 字节。
+//
+// 参数：无。
+//
+// 返回：
+//   - []byte: 按大端序编码后的能力 payload。
+//   - error: 编码失败或发生 panic 恢复时返回错误。
+func (m *helloMessage) Pack() (msg []byte, err error) {
+	defer func() {
+		if r := recover(); nil != r {
+			err = cockroachdberrors.Newf("封包过程发生异常：%[1]v。", r)
+		}
+	}()
+
+	buf := &bytes.Buffer{}
+	fields := []uint16{
+		m.capabilities.Version,
+		uint16(m.capabilities.Compression),
+		uint16(m.capabilities.Encryption),
+		m.capabilities.MaxFrameSize,
+	}
+	if errWrite := binaryWrite(buf, binary.BigEndian, fields); nil != errWrite {
+		err = cockroachdberrors.Wrap(errWrite, "封包过程发生异常。")
+	} else {
+		msg = buf.Bytes()
+	}
+
+	return msg, err
+}
+
+// Unpack 从 payload 的前 8 字节还原协议能力。
+//
+// payload 少于 8 字节时返回错误；多余字节会被忽略，以便后续版本追加字段。
+//
+// 参数：
+//   - payload: 待解码的能力 payload。
+//
+// 返回：
+//   - error: 解码失败或发生 panic 恢复时返回错误。
+func (m *helloMessage) Unpack(payload []byte) (err error) {
+	defer func() {
+		if r := recover(); nil != r {
+			err = cockroachdberrors.Newf("解包过程发生异常：%[1]v。", r)
+		}
+	}()
+
+	fields := make([]uint16, helloPayloadLength/2)
+	if errRead := binaryRead(bytes.NewReader(payload), binary.BigEndian, fields); nil != errRead {
+		err = cockroachdberrors.Wrap(errRead, "解包过程发生异常。")
+	} else {
+		m.capabilities = Capabilities{
+			Version:      fields[0],
+			Compression:  CompressionFlag(fields[1]),
+			Encryption:   EncryptionFlag(fields[2]),
+			MaxFrameSize: fields[3],
+		}
+	}
+
+	return err
+}
+
+// Capabilities 返回消息携带的协议能力。
+//
+// 参数：无。
+//
+// 返回：
+//   - Capabilities: 消息中的协议能力。
+func (m *helloMessage) Capabilities() Capabilities {
+	return m.capabilities
+}
+
+// NewHelloMessage 创建携带本端能力的握手消息。
+//
+// 参数：
+//   - capabilities: 本端协议能力。
+//
+// 返回：
+//   - *helloMessage: 新创建的握手消息实例。
+func NewHelloMessage(capabilities Capabilities) *helloMessage {
+	m := &helloMessage{
+		messageType:  HelloMessageType,
+		capabilities: capabilities,
+	}
+
+	return m
+}
+
+// NewCapabilitiesMessage 创建携带协商结果的能力确认消息。
+//
+// 参数：
+//   - capabilities: 协商后的协议能力。
+//
+// 返回：
+//   - *helloMessage: 新创建的能力确认消息实例。
+func NewCapabilitiesMessage(capabilities Capabilities) *helloMessage {
+	m := &helloMessage{
+		messageType:  CapabilitiesMessageType,
+		capabilities: capabilities,
+	}
+
+	return m
+}
+
+// GenerateHelloMessage 根据消息类型和 payload 生成握手或能力确认消息。
+//
+// messageType 必须等于 [HelloMessageType] 或 [CapabilitiesMessageType]，payload 不能为空。
+//
+// 参数：
+//   - messageType: 目标消息类型。
+//   - payload: 待解码的能力 payload。
+//
+// 返回：
+//   - Message: 生成的握手或能力确认消息实例。
+//   - error: messageType 不匹配、payload 为 nil 或解码失败时返回错误。
+func GenerateHelloMessage(messageType MessageType, payload []byte) (Message, error) {
+	var m *helloMessage
+	var err error
+
+	if messageType != HelloMessageType && messageType != CapabilitiesMessageType {
+		err = cockroachdberrors.Newf("消息类型 %[1]d 不是握手或能力确认消息类型。", messageType)
+	} else if nil == payload {
+		err = cockroachdberrors.Newf("有效负载不能为空。")
+	} else {
+		m = &helloMessage{
+			messageType: messageType,
+		}
+
+		err = m.Unpack(payload)
+	}
+
+	return m, err
+}
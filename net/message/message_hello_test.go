@@ -0,0 +1,288 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package message
+
+import (
+	"encoding/binary"
+	"io"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestHelloMessage_PackUnpack 验证握手消息的封包与解包契约。
+//
+// 该测试覆盖默认能力、完整能力和零值能力，确保 payload 始终为 8 字节大端序编码并可等价还原。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestHelloMessage_PackUnpack(t *testing.T) {
+	tests := []struct {
+		name             string
+		description      string
+		giveCapabilities Capabilities
+	}{
+		{
+			name:             "success/default-capabilities",
+			description:      "验证默认能力可以封包并被等价解包。",
+			giveCapabilities: DefaultCapabilities(),
+		},
+		{
+			name:        "success/full-capabilities",
+			description: "验证携带压缩、加密和帧长度的能力可以封包并被等价解包。",
+			giveCapabilities: Capabilities{
+				Version:      3,
+				Compression:  CompressionGzip | CompressionSnappy,
+				Encryption:   EncryptionAES256GCM,
+				MaxFrameSize: 4096,
+			},
+		},
+		{
+			name:             "boundary/zero-capabilities",
+			description:      "验证零值能力作为边界值被保留。",
+			giveCapabilities: Capabilities{},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			msg := NewHelloMessage(tt.giveCapabilities)
+			payload, err := msg.Pack()
+
+			require.NoError(t, err)
+			require.Len(t, payload, helloPayloadLength)
+			assert.Equal(t, tt.giveCapabilities.Version, binary.BigEndian.Uint16(payload[0:2]))
+			assert.Equal(t, HelloMessageType, msg.MessageType())
+
+			unpacked := NewCapabilitiesMessage(Capabilities{})
+			require.NoError(t, unpacked.Unpack(payload))
+			assert.Equal(t, CapabilitiesMessageType, unpacked.MessageType())
+			assert.Equal(t, tt.giveCapabilities, unpacked.Capabilities())
+		})
+	}
+}
+
+// TestHelloMessage_Unpack 验证握手消息解包对 payload 长度的处理。
+//
+// 该测试覆盖不足长度与携带扩展字节的 payload，确保旧版本可以兼容后续追加字段。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestHelloMessage_Unpack(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		givePayload []byte
+		wantVersion uint16
+		wantErr     bool
+	}{
+		{
+			name:        "error/short-payload",
+			description: "验证长度不足 8 字节的 payload 会返回解包错误。",
+			givePayload: []byte{0x00, 0x01, 0x00},
+			wantErr:     true,
+		},
+		{
+			name:        "compatibility/extra-trailing-bytes",
+			description: "验证解包仅消费前 8 字节并容忍后续扩展字节。",
+			givePayload: []byte{0x00, 0x02, 0x00, 0x00, 0x00, 0x00, 0x10, 0x00, 0xAA},
+			wantVersion: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			msg := NewHelloMessage(Capabilities{})
+			err := msg.Unpack(tt.givePayload)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantVersion, msg.Capabilities().Version)
+		})
+	}
+}
+
+// TestHelloMessage_Errors 验证握手消息对 panic 和二进制写入错误的错误化处理。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestHelloMessage_Errors(t *testing.T) {
+	// 验证 nil receiver 的 Pack 和 Unpack 会被 recover 并返回错误。
+	var nilMsg *helloMessage
+	_, err := nilMsg.Pack()
+	require.Error(t, err)
+	require.Error(t, nilMsg.Unpack(make([]byte, helloPayloadLength)))
+
+	// 验证二进制写入失败时，Pack 返回封包错误而不是静默成功。
+	replaceBinaryWrite(t, func(io.Writer, binary.ByteOrder, any) error {
+		return errTestBinaryWrite
+	})
+	payload, err := NewHelloMessage(DefaultCapabilities()).Pack()
+
+	require.ErrorIs(t, err, errTestBinaryWrite)
+	assert.Nil(t, payload)
+}
+
+// TestNegotiateCapabilities 验证能力协商取版本和帧长度较小值、算法交集，并拒绝过低版本。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestNegotiateCapabilities(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		giveLocal   Capabilities
+		giveRemote  Capabilities
+		want        Capabilities
+		wantErr     bool
+	}{
+		{
+			name:        "success/intersection",
+			description: "验证协商结果为双方能力的交集。",
+			giveLocal: Capabilities{
+				Version:      2,
+				Compression:  CompressionGzip | CompressionDeflate,
+				Encryption:   EncryptionAES128GCM | EncryptionAES256GCM,
+				MaxFrameSize: 8192,
+			},
+			giveRemote: Capabilities{
+				Version:      1,
+				Compression:  CompressionGzip | CompressionSnappy,
+				Encryption:   EncryptionAES256GCM,
+				MaxFrameSize: 1024,
+			},
+			want: Capabilities{
+				Version:      1,
+				Compression:  CompressionGzip,
+				Encryption:   EncryptionAES256GCM,
+				MaxFrameSize: 1024,
+			},
+		},
+		{
+			name:        "boundary/zero-frame-size-means-protocol-max",
+			description: "验证 MaxFrameSize 为 0 时按协议上限参与协商。",
+			giveLocal:   Capabilities{Version: 1},
+			giveRemote:  Capabilities{Version: 1, MaxFrameSize: 512},
+			want:        Capabilities{Version: 1, MaxFrameSize: 512},
+		},
+		{
+			name:        "boundary/both-zero-frame-size",
+			description: "验证双方都未声明帧长度时使用协议上限。",
+			giveLocal:   Capabilities{Version: 1},
+			giveRemote:  Capabilities{Version: 1},
+			want:        Capabilities{Version: 1, MaxFrameSize: math.MaxUint16},
+		},
+		{
+			name:        "error/local-version-too-low",
+			description: "验证本端版本低于最低兼容版本时返回错误。",
+			giveLocal:   Capabilities{},
+			giveRemote:  DefaultCapabilities(),
+			wantErr:     true,
+		},
+		{
+			name:        "error/remote-version-too-low",
+			description: "验证对端版本低于最低兼容版本时返回错误。",
+			giveLocal:   DefaultCapabilities(),
+			giveRemote:  Capabilities{},
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			got, err := NegotiateCapabilities(tt.giveLocal, tt.giveRemote)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+
+			reversed, err := NegotiateCapabilities(tt.giveRemote, tt.giveLocal)
+			require.NoError(t, err)
+			assert.Equal(t, got, reversed)
+			assert.True(t, got.Compression.Has(tt.want.Compression))
+			assert.True(t, got.Encryption.Has(tt.want.Encryption))
+		})
+	}
+}
+
+// TestGenerateHelloMessage 验证握手消息生成函数对类型和 payload 的校验。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestGenerateHelloMessage(t *testing.T) {
+	payload, err := NewHelloMessage(DefaultCapabilities()).Pack()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name            string
+		description     string
+		giveMessageType MessageType
+		givePayload     []byte
+		wantErr         bool
+	}{
+		{
+			name:            "success/hello",
+			description:     "验证握手消息类型可以生成消息实例。",
+			giveMessageType: HelloMessageType,
+			givePayload:     payload,
+		},
+		{
+			name:            "success/capabilities",
+			description:     "验证能力确认消息类型可以生成消息实例。",
+			giveMessageType: CapabilitiesMessageType,
+			givePayload:     payload,
+		},
+		{
+			name:            "error/type-mismatch",
+			description:     "验证非握手类型会被拒绝。",
+			giveMessageType: SingleStringMessageType,
+			givePayload:     payload,
+			wantErr:         true,
+		},
+		{
+			name:            "error/nil-payload",
+			description:     "验证 nil payload 会被拒绝。",
+			giveMessageType: HelloMessageType,
+			wantErr:         true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			msg, err := GenerateHelloMessage(tt.giveMessageType, tt.givePayload)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.giveMessageType, msg.MessageType())
+			hello, ok := msg.(HelloMessage)
+			require.True(t, ok)
+			assert.Equal(t, DefaultCapabilities(), hello.Capabilities())
+		})
+	}
+}
@@ -0,0 +1,302 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package message
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	cockroachdberrors "github.com/cockroachdb/errors"
+
+	kitlog "github.com/fsyyft-go/kit/log"
+)
+
+var (
+	// ErrHandlerNotFound 表示消息类型没有注册处理函数。
+	//
+	// 调用方可以使用 errors.Is 判断该错误。
+	ErrHandlerNotFound = cockroachdberrors.New("消息类型没有注册处理函数。")
+	// ErrHandlerPanic 表示处理函数发生 panic，由 Recovery 中间件转换为错误。
+	//
+	// 调用方可以使用 errors.Is 判断该错误。
+	ErrHandlerPanic = cockroachdberrors.New("消息处理函数发生 panic。")
+)
+
+type (
+	// Handler 定义处理单条消息的函数。
+	//
+	// 参数：
+	//   - context.Context: 本次处理的上下文，来自 Router.Serve 的 ctx。
+	//   - Conn: 收到消息的连接，可用于回复消息。
+	//   - Message: 待处理的消息。
+	//
+	// 返回：
+	//   - error: 处理失败时返回错误，交由 WithErrorHandler 设置的回调处理。
+	Handler func(context.Context, Conn, Message) error
+
+	// Middleware 包装 Handler 以添加日志、指标、鉴权、panic 恢复等横切逻辑，与 kratos 中间件的组合方式一致。
+	//
+	// 参数：
+	//   - Handler: 被包装的下一个处理函数。
+	//
+	// 返回：
+	//   - Handler: 包装后的处理函数。
+	Middleware func(Handler) Handler
+
+	// RouterOption 定义 Router 的配置修改函数。
+	//
+	// 参数：
+	//   - *Router: 待修改的 Router 实例。
+	RouterOption func(*Router)
+
+	// Router 按消息类型将连接收到的消息分发给处理函数，并按层级组合中间件。
+	//
+	// 一条消息依次经过 Serve 传入的连接级中间件、Use 注册的全局中间件、Handle 传入的类型级中间件，
+	// 最后到达处理函数；同一层级中先传入的中间件位于外层。Use 与 Handle 可与 Serve 并发调用，
+	// 新注册的中间件与处理函数对之后分发的消息生效。
+	Router struct {
+		mu          sync.RWMutex            // 保护 middlewares 与 handlers。
+		middlewares []Middleware            // Use 注册的全局中间件。
+		handlers    map[MessageType]Handler // 已组合类型级中间件的处理函数。
+
+		notFound Handler                    // 消息类型未注册时使用的处理函数。
+		onError  func(Conn, Message, error) // 处理失败后的回调；为 nil 时忽略错误。
+	}
+)
+
+// Chain 将多个中间件组合为一个中间件。
+//
+// 先传入的中间件位于外层，即 Chain(a, b)(h) 的执行顺序为 a → b → h → b → a。
+//
+// 参数：
+//   - m: 待组合的中间件，nil 会被跳过。
+//
+// 返回：
+//   - Middleware: 组合后的中间件；未传入中间件时原样返回处理函数。
+func Chain(m ...Middleware) Middleware {
+	return func(next Handler) Handler {
+		for i := len(m) - 1; i >= 0; i-- {
+			if nil != m[i] {
+				next = m[i](next)
+			}
+		}
+		return next
+	}
+}
+
+// WithNotFoundHandler 设置消息类型未注册时使用的处理函数。
+//
+// 未设置时，心跳消息被静默忽略，其它消息返回 ErrHandlerNotFound。
+// 全局与连接级中间件同样作用于该处理函数。
+//
+// 参数：
+//   - h: 兜底处理函数，为 nil 时保留默认行为。
+//
+// 返回：
+//   - RouterOption: 用于设置兜底处理函数的选项函数。
+func WithNotFoundHandler(h Handler) RouterOption {
+	return func(r *Router) {
+		if nil != h {
+			r.notFound = h
+		}
+	}
+}
+
+// WithErrorHandler 设置处理失败后的回调。
+//
+// 回调在 Serve 的分发协程中同步执行，可以在回调中记录日志或调用 Conn.Close 断开连接。
+//
+// 参数：
+//   - fn: 处理失败后的回调，参数为连接、消息与处理函数返回的错误；为 nil 时忽略错误。
+//
+// 返回：
+//   - RouterOption: 用于设置错误回调的选项函数。
+func WithErrorHandler(fn func(Conn, Message, error)) RouterOption {
+	return func(r *Router) {
+		r.onError = fn
+	}
+}
+
+// NewRouter 创建消息路由。
+//
+// 参数：
+//   - opts: 可选配置项。
+//
+// 返回：
+//   - *Router: 尚未注册处理函数的路由。
+func NewRouter(opts ...RouterOption) *Router {
+	r := &Router{
+		handlers: make(map[MessageType]Handler),
+		notFound: notFoundHandler,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Use 注册全局中间件，作用于所有消息类型。
+//
+// 参数：
+//   - m: 待注册的中间件，按传入顺序由外到内组合。
+func (r *Router) Use(m ...Middleware) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.middlewares = append(r.middlewares, m...)
+}
+
+// Handle 注册消息类型的处理函数。
+//
+// 参数：
+//   - messageType: 待处理的消息类型。
+//   - h: 处理函数。
+//   - m: 仅作用于该消息类型的中间件，按传入顺序由外到内组合。
+//
+// 返回：
+//   - error: 消息类型已注册或 h 为空时返回错误。
+func (r *Router) Handle(messageType MessageType, h Handler, m ...Middleware) error {
+	var err error
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.handlers[messageType]; exists {
+		err = cockroachdberrors.Newf("类型 %[1]d 的处理函数已经存在。", messageType)
+	} else if nil == h {
+		err = cockroachdberrors.Newf("处理函数不允许为空。")
+	} else {
+		r.handlers[messageType] = Chain(m...)(h)
+	}
+
+	return err
+}
+
+// Dispatch 将单条消息交给对应的处理函数，经过全局中间件与类型级中间件。
+//
+// 参数：
+//   - ctx: 本次处理的上下文。
+//   - c: 收到消息的连接。
+//   - message: 待处理的消息，不能为 nil。
+//
+// 返回：
+//   - error: 处理函数或中间件返回的错误。
+func (r *Router) Dispatch(ctx context.Context, c Conn, message Message) error {
+	r.mu.RLock()
+	h, exists := r.handlers[message.MessageType()]
+	if !exists {
+		h = r.notFound
+	}
+	h = Chain(r.middlewares...)(h)
+	r.mu.RUnlock()
+
+	return h(ctx, c, message)
+}
+
+// Serve 从连接读取消息并逐条分发，直到连接关闭或 ctx 结束。
+//
+// 消息在调用 Serve 的协程中按接收顺序串行处理；需要并发处理时可在中间件或处理函数中自行派发。
+// Serve 不会调用 Conn.Start，调用方应先启动连接。
+//
+// 参数：
+//   - ctx: 控制分发循环生命周期的上下文，同时作为每条消息的处理上下文。
+//   - c: 已启动的连接。
+//   - m: 仅作用于该连接的中间件，位于全局中间件外层。
+//
+// 返回：
+//   - error: 连接关闭导致接收 channel 关闭时返回 nil；ctx 结束时返回 ctx.Err()。
+func (r *Router) Serve(ctx context.Context, c Conn, m ...Middleware) error {
+	h := Chain(m...)(r.Dispatch)
+	messages := c.Message()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case message, ok := <-messages:
+			if !ok {
+				return nil
+			}
+			if nil == message {
+				continue
+			}
+			if err := h(ctx, c, message); nil != err && nil != r.onError {
+				r.onError(c, message, err)
+			}
+		}
+	}
+}
+
+// notFoundHandler 是默认的兜底处理函数。
+//
+// 参数：
+//   - ctx: 本次处理的上下文。
+//   - c: 收到消息的连接。
+//   - message: 没有注册处理函数的消息。
+//
+// 返回：
+//   - error: 心跳消息返回 nil，其它消息返回包装了 ErrHandlerNotFound 的错误。
+func notFoundHandler(_ context.Context, _ Conn, message Message) error {
+	if HeartbeatMessageType == message.MessageType() {
+		return nil
+	}
+	return cockroachdberrors.Wrapf(ErrHandlerNotFound, "类型 %[1]d", message.MessageType())
+}
+
+// Recovery 返回将处理函数 panic 转换为错误的中间件。
+//
+// 应放在中间件链的最外层，使其它中间件中的 panic 同样被恢复。
+//
+// 参数：无。
+//
+// 返回：
+//   - Middleware: 发生 panic 时返回包装了 ErrHandlerPanic 的错误，错误中携带 panic 值与调用栈。
+func Recovery() Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, c Conn, message Message) (err error) {
+			defer func() {
+				if rec := recover(); nil != rec {
+					err = cockroachdberrors.Wrapf(ErrHandlerPanic, "类型 %[1]d：%[2]v", message.MessageType(), rec)
+				}
+			}()
+			return next(ctx, c, message)
+		}
+	}
+}
+
+// Logging 返回记录消息处理结果的中间件。
+//
+// 处理成功时以 Debug 级别输出，失败时以 Error 级别输出；日志字段包括消息类型、对端地址与处理耗时。
+//
+// 参数：
+//   - logger: 日志实例，为 nil 时中间件不做任何操作。
+//
+// 返回：
+//   - Middleware: 日志中间件。
+func Logging(logger kitlog.Logger) Middleware {
+	return func(next Handler) Handler {
+		if nil == logger {
+			return next
+		}
+		return func(ctx context.Context, c Conn, message Message) error {
+			start := time.Now()
+			err := next(ctx, c, message)
+
+			fields := map[string]interface{}{
+				"message_type": message.MessageType(),
+				"latency":      time.Since(start).String(),
+			}
+			if nil != c && nil != c.RemoteAddr() {
+				fields["remote_addr"] = c.RemoteAddr().String()
+			}
+			if nil != err {
+				fields["error"] = err.Error()
+				logger.WithFields(fields).Error("消息处理失败")
+			} else {
+				logger.WithFields(fields).Debug("消息处理完成")
+			}
+			return err
+		}
+	}
+}
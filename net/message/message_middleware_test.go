@@ -0,0 +1,235 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package message
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	kitlog "github.com/fsyyft-go/kit/log"
+)
+
+// stubConn 是只提供接收 channel 与对端地址的 Conn 测试替身，其它方法未实现。
+type stubConn struct {
+	Conn
+
+	messages chan Message
+}
+
+// Message 返回测试用的接收 channel。
+func (c *stubConn) Message() <-chan Message {
+	return c.messages
+}
+
+// RemoteAddr 返回固定的对端地址。
+func (c *stubConn) RemoteAddr() net.Addr {
+	return &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 9000}
+}
+
+// fieldsLogger 记录 WithFields 字段与输出级别的日志替身。
+type fieldsLogger struct {
+	kitlog.Logger
+
+	mu     sync.Mutex
+	fields map[string]interface{}
+	levels []string
+}
+
+// WithFields 记录字段并返回自身。
+func (l *fieldsLogger) WithFields(fields map[string]interface{}) kitlog.Logger {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.fields = fields
+	return l
+}
+
+// Debug 记录 Debug 级别输出。
+func (l *fieldsLogger) Debug(_ ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.levels = append(l.levels, "debug")
+}
+
+// Error 记录 Error 级别输出。
+func (l *fieldsLogger) Error(_ ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.levels = append(l.levels, "error")
+}
+
+// traceMiddleware 返回把名称追加到 trace 的中间件，用于断言执行顺序。
+func traceMiddleware(trace *[]string, name string) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, c Conn, message Message) error {
+			*trace = append(*trace, name+">")
+			err := next(ctx, c, message)
+			*trace = append(*trace, "<"+name)
+			return err
+		}
+	}
+}
+
+// TestChain 验证中间件按传入顺序由外到内组合。
+func TestChain(t *testing.T) {
+	var trace []string
+	h := Chain(traceMiddleware(&trace, "a"), nil, traceMiddleware(&trace, "b"))(func(context.Context, Conn, Message) error {
+		trace = append(trace, "h")
+		return nil
+	})
+
+	require.NoError(t, h(context.Background(), nil, NewSingleStringMessage("x")))
+	assert.Equal(t, []string{"a>", "b>", "h", "<b", "<a"}, trace)
+
+	h = Chain()(func(context.Context, Conn, Message) error { return errors.New("raw") })
+	assert.EqualError(t, h(context.Background(), nil, NewSingleStringMessage("x")), "raw")
+}
+
+// TestRouter_Handle 验证处理函数注册的校验。
+func TestRouter_Handle(t *testing.T) {
+	r := NewRouter()
+	noop := func(context.Context, Conn, Message) error { return nil }
+
+	require.NoError(t, r.Handle(SingleStringMessageType, noop))
+	assert.Error(t, r.Handle(SingleStringMessageType, noop))
+	assert.Error(t, r.Handle(MessageType(0x10), nil))
+}
+
+// TestRouter_Dispatch 验证分发顺序、兜底处理与错误返回。
+func TestRouter_Dispatch(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		description string
+		give        Message
+		wantTrace   []string
+		wantErr     error
+	}{
+		{
+			name:        "success/registered",
+			description: "验证已注册类型依次经过全局与类型级中间件。",
+			give:        NewSingleStringMessage("x"),
+			wantTrace:   []string{"global>", "typed>", "handler", "<typed", "<global"},
+		},
+		{
+			name:        "success/heartbeat",
+			description: "验证未注册的心跳消息被默认兜底处理忽略。",
+			give:        NewHeartbeatMessage(1),
+			wantTrace:   []string{"global>", "<global"},
+		},
+		{
+			name:        "error/not-found",
+			description: "验证未注册的其它类型返回 ErrHandlerNotFound。",
+			give:        NewHelloMessage(DefaultCapabilities()),
+			wantTrace:   []string{"global>", "<global"},
+			wantErr:     ErrHandlerNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			var trace []string
+			r := NewRouter()
+			r.Use(traceMiddleware(&trace, "global"))
+			require.NoError(t, r.Handle(SingleStringMessageType, func(context.Context, Conn, Message) error {
+				trace = append(trace, "handler")
+				return nil
+			}, traceMiddleware(&trace, "typed")))
+
+			err := r.Dispatch(ctx, nil, tt.give)
+			if nil != tt.wantErr {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantTrace, trace)
+		})
+	}
+}
+
+// TestRouter_Serve 验证连接级中间件、错误回调与退出条件。
+func TestRouter_Serve(t *testing.T) {
+	t.Run("closed", func(t *testing.T) {
+		t.Log("验证接收 channel 关闭后返回 nil，并对失败的消息调用错误回调。")
+
+		var trace []string
+		var failed []MessageType
+		r := NewRouter(WithErrorHandler(func(_ Conn, message Message, err error) {
+			assert.ErrorIs(t, err, ErrHandlerNotFound)
+			failed = append(failed, message.MessageType())
+		}))
+		require.NoError(t, r.Handle(SingleStringMessageType, func(context.Context, Conn, Message) error {
+			trace = append(trace, "handler")
+			return nil
+		}))
+
+		c := &stubConn{messages: make(chan Message, 3)}
+		c.messages <- NewSingleStringMessage("x")
+		c.messages <- nil
+		c.messages <- NewHelloMessage(DefaultCapabilities())
+		close(c.messages)
+
+		require.NoError(t, r.Serve(context.Background(), c, traceMiddleware(&trace, "conn")))
+		assert.Equal(t, []string{"conn>", "handler", "<conn", "conn>", "<conn"}, trace)
+		assert.Equal(t, []MessageType{HelloMessageType}, failed)
+	})
+
+	t.Run("context", func(t *testing.T) {
+		t.Log("验证 ctx 结束时返回 ctx.Err()。")
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		err := NewRouter().Serve(ctx, &stubConn{messages: make(chan Message)})
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+// TestRecovery 验证 panic 被转换为错误。
+func TestRecovery(t *testing.T) {
+	h := Recovery()(func(context.Context, Conn, Message) error {
+		panic("boom")
+	})
+	err := h(context.Background(), nil, NewSingleStringMessage("x"))
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrHandlerPanic)
+	assert.Contains(t, err.Error(), "boom")
+
+	h = Recovery()(func(context.Context, Conn, Message) error { return nil })
+	assert.NoError(t, h(context.Background(), nil, NewSingleStringMessage("x")))
+}
+
+// TestLogging 验证日志字段与级别。
+func TestLogging(t *testing.T) {
+	logger := &fieldsLogger{}
+	c := &stubConn{}
+	wantErr := errors.New("failed")
+
+	ok := Logging(logger)(func(context.Context, Conn, Message) error { return nil })
+	require.NoError(t, ok(context.Background(), c, NewSingleStringMessage("x")))
+	assert.Equal(t, SingleStringMessageType, logger.fields["message_type"])
+	assert.Equal(t, "127.0.0.1:9000", logger.fields["remote_addr"])
+	assert.Contains(t, logger.fields, "latency")
+
+	fail := Logging(logger)(func(context.Context, Conn, Message) error {
+		time.Sleep(time.Millisecond)
+		return wantErr
+	})
+	assert.ErrorIs(t, fail(context.Background(), c, NewSingleStringMessage("x")), wantErr)
+	assert.Equal(t, "failed", logger.fields["error"])
+	assert.Equal(t, []string{"debug", "error"}, logger.levels)
+
+	passthrough := Logging(nil)(func(context.Context, Conn, Message) error { return wantErr })
+	assert.ErrorIs(t, passthrough(context.Background(), c, NewSingleStringMessage("x")), wantErr)
+}
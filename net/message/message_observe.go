@@ -0,0 +1,305 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package message
+
+import (
+	"context"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	cockroachdberrors "github.com/cockroachdb/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	kitlog "github.com/fsyyft-go/kit/log"
+)
+
+const (
+	// instrumentationName 是创建 Tracer 时使用的插桩库名称。
+	instrumentationName = "github.com/fsyyft-go/kit/net/message"
+	// spanNameSend 是发送消息 span 的名称。
+	spanNameSend = "message.send"
+	// spanNameReceive 是接收消息 span 的名称。
+	spanNameReceive = "message.receive"
+)
+
+var (
+	// AttributeConnID 是记录连接标识的 span 属性键，日志字段使用同名的 conn_id。
+	AttributeConnID = attribute.Key("message.conn.id")
+	// AttributeMessageType 是记录消息类型的 span 属性键。
+	AttributeMessageType = attribute.Key("message.type")
+	// AttributeMessageSize 是记录数据包字节数（含 4 字节头部）的 span 属性键。
+	AttributeMessageSize = attribute.Key("message.size")
+
+	// connSeq 是未通过 WithConnID 指定标识时生成连接标识使用的序号。
+	connSeq atomic.Uint64
+
+	// errConnClosed 表示收到的消息因连接关闭未能投递，用于结束接收 span。
+	errConnClosed = cockroachdberrors.New("连接已经关闭，消息未投递。")
+
+	// noopObserve 是未启用观测时 observeReceive 返回的空回调，避免为每条消息分配闭包。
+	noopObserve = func(error) {}
+)
+
+type (
+	// tracedMessage 是启用观测时放入发送队列的消息，携带入队时间与发送 span。
+	//
+	// 嵌入 Message 使 pack 无需区分普通消息；send 写出后据此结束 span 并记录日志。
+	tracedMessage struct {
+		Message
+
+		span     trace.Span // 发送 span；未启用追踪时为 nil。
+		enqueued time.Time  // 入队时间。
+	}
+)
+
+// WithTracerProvider 为连接启用 OpenTelemetry 追踪。
+//
+// 启用后，除心跳外的每条消息在 SendMessageContext 入队时创建 message.send span，写入底层连接后结束，
+// span 时长包含排队与写出耗时；收到的消息在解码后创建 message.receive span，交给 Message channel
+// 或控制消息处理完成后结束。span 属性包括连接标识、消息类型与数据包字节数，失败时记录错误。
+// 连接关闭时仍在队列中的消息不会结束其 span。本包尚无请求-响应式的调用 API，因此不记录确认延迟。
+//
+// 参数：
+//   - provider: 创建 Tracer 使用的 TracerProvider；为 nil 时不启用追踪。
+//
+// 返回：
+//   - ConnOption: 启用追踪的配置函数。
+func WithTracerProvider(provider trace.TracerProvider) ConnOption {
+	return func(c *conn) {
+		if nil == provider {
+			c.tracer = nil
+			return
+		}
+		c.tracer = provider.Tracer(instrumentationName)
+	}
+}
+
+// WithConnLogger 为连接启用结构化日志。
+//
+// 启用后，连接启动与关闭以 Info 级别输出，除心跳外的每条消息的收发以 Debug 级别输出，
+// 写出失败以 Warn 级别输出；日志字段包括 conn_id、message_type、size、latency 与对端地址。
+// 日志实例的级别高于 Debug 时不会为每条消息构造字段。
+//
+// 参数：
+//   - logger: 日志实例；为 nil 时不输出日志。
+//
+// 返回：
+//   - ConnOption: 启用日志的配置函数。
+func WithConnLogger(logger kitlog.Logger) ConnOption {
+	return func(c *conn) {
+		c.logger = logger
+	}
+}
+
+// WithConnID 设置连接标识，用于日志与 span 属性。
+//
+// 未设置时使用进程内递增的序号，适合单进程内区分连接；需要跨进程关联时可传入会话 ID 等业务标识。
+//
+// 参数：
+//   - id: 连接标识；为空字符串时保留默认值。
+//
+// 返回：
+//   - ConnOption: 设置连接标识的配置函数。
+func WithConnID(id string) ConnOption {
+	return func(c *conn) {
+		if "" != id {
+			c.id = id
+		}
+	}
+}
+
+// nextConnID 生成默认的连接标识。
+//
+// 参数：无。
+//
+// 返回：
+//   - string: 进程内递增的十进制序号。
+func nextConnID() string {
+	return strconv.FormatUint(connSeq.Add(1), 10)
+}
+
+// ID 返回连接标识。
+//
+// 参数：无。
+//
+// 返回：
+//   - string: WithConnID 设置的标识，未设置时为进程内递增的序号。
+func (c *conn) ID() string {
+	return c.id
+}
+
+// observed 判断是否需要观测该消息。
+//
+// 参数：
+//   - message: 待观测的消息。
+//
+// 返回：
+//   - bool: 启用了追踪或日志且消息不是心跳时返回 true。
+func (c *conn) observed(message Message) bool {
+	return (nil != c.tracer || nil != c.logger) && nil != message && HeartbeatMessageType != message.MessageType()
+}
+
+// debugEnabled 判断日志实例是否会输出 Debug 级别日志。
+//
+// 参数：无。
+//
+// 返回：
+//   - bool: 已设置日志实例且其级别不高于 Debug 时返回 true。
+func (c *conn) debugEnabled() bool {
+	return nil != c.logger && c.logger.GetLevel() <= kitlog.DebugLevel
+}
+
+// logFields 返回连接级别的日志字段。
+//
+// 参数：无。
+//
+// 返回：
+//   - map[string]interface{}: 包含 conn_id 与可用对端地址的字段。
+func (c *conn) logFields() map[string]interface{} {
+	fields := map[string]interface{}{"conn_id": c.id}
+	if addr := c.conn.RemoteAddr(); nil != addr {
+		fields["remote_addr"] = addr.String()
+	}
+	return fields
+}
+
+// traceSend 在消息入队前创建发送 span，并包装为 tracedMessage。
+//
+// 参数：
+//   - ctx: 调用方上下文，其中的 span 作为父 span。
+//   - message: 待发送的消息，调用方已通过 observed 判断需要观测。
+//
+// 返回：
+//   - *tracedMessage: 携带入队时间与 span 的消息。
+func (c *conn) traceSend(ctx context.Context, message Message) *tracedMessage {
+	traced := &tracedMessage{Message: message, enqueued: time.Now()}
+	if nil != c.tracer {
+		_, traced.span = c.tracer.Start(ctx, spanNameSend,
+			trace.WithSpanKind(trace.SpanKindProducer),
+			trace.WithAttributes(
+				AttributeConnID.String(c.id),
+				AttributeMessageType.Int(int(message.MessageType())),
+			),
+		)
+	}
+	return traced
+}
+
+// finishSend 在消息写出或被拒绝后结束发送 span 并记录日志。
+//
+// 参数：
+//   - traced: 由 traceSend 包装的消息。
+//   - size: 写出的数据包字节数；未封包时为 0。
+//   - err: 入队、封包或写出失败时的错误。
+func (c *conn) finishSend(traced *tracedMessage, size int, err error) {
+	if nil != traced.span {
+		if size > 0 {
+			traced.span.SetAttributes(AttributeMessageSize.Int(size))
+		}
+		if nil != err {
+			traced.span.RecordError(err)
+			traced.span.SetStatus(codes.Error, err.Error())
+		}
+		traced.span.End()
+	}
+
+	if nil == c.logger || (nil == err && !c.debugEnabled()) {
+		return
+	}
+	fields := c.logFields()
+	fields["message_type"] = traced.MessageType()
+	fields["size"] = size
+	fields["latency"] = time.Since(traced.enqueued).String()
+	if nil != err {
+		fields["error"] = err.Error()
+		c.logger.WithFields(fields).Warn("消息发送失败")
+		return
+	}
+	c.logger.WithFields(fields).Debug("消息已发送")
+}
+
+// observeReceive 为收到的消息创建接收 span，并返回处理完成后调用的回调。
+//
+// 参数：
+//   - message: 解码得到的消息。
+//   - size: 数据包字节数，含 4 字节头部。
+//
+// 返回：
+//   - func(error): 消息投递或控制消息处理完成后调用，参数为处理错误；未启用观测时为空回调。
+func (c *conn) observeReceive(message Message, size int) func(error) {
+	if !c.observed(message) {
+		return noopObserve
+	}
+
+	received := time.Now()
+	var span trace.Span
+	if nil != c.tracer {
+		_, span = c.tracer.Start(context.Background(), spanNameReceive,
+			trace.WithSpanKind(trace.SpanKindConsumer),
+			trace.WithAttributes(
+				AttributeConnID.String(c.id),
+				AttributeMessageType.Int(int(message.MessageType())),
+				AttributeMessageSize.Int(size),
+			),
+		)
+	}
+
+	return func(err error) {
+		if nil != span {
+			if nil != err {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			span.End()
+		}
+		if !c.debugEnabled() {
+			return
+		}
+		fields := c.logFields()
+		fields["message_type"] = message.MessageType()
+		fields["size"] = size
+		fields["latency"] = time.Since(received).String()
+		if nil != err {
+			fields["error"] = err.Error()
+		}
+		c.logger.WithFields(fields).Debug("消息已接收")
+	}
+}
+
+// logStart 记录连接启动日志。
+//
+// 参数：无。
+func (c *conn) logStart() {
+	if nil == c.logger {
+		return
+	}
+	fields := c.logFields()
+	if addr := c.conn.LocalAddr(); nil != addr {
+		fields["local_addr"] = addr.String()
+	}
+	c.logger.WithFields(fields).Info("连接已启动")
+}
+
+// logClose 记录连接关闭日志。
+//
+// 参数：
+//   - reason: 关闭原因；为 0 时不输出 reason 字段。
+//   - err: 关闭底层连接时返回的错误。
+func (c *conn) logClose(reason CloseReason, err error) {
+	if nil == c.logger {
+		return
+	}
+	fields := c.logFields()
+	if 0 != reason {
+		fields["reason"] = reason.String()
+	}
+	if nil != err {
+		fields["error"] = err.Error()
+	}
+	c.logger.WithFields(fields).Info("连接已关闭")
+}
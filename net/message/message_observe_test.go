@@ -0,0 +1,291 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package message
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	kitlog "github.com/fsyyft-go/kit/log"
+)
+
+// logEntry 是 recordLogger 记录的一条日志。
+type logEntry struct {
+	level   string
+	message string
+	fields  map[string]interface{}
+}
+
+// recordLogger 记录每条日志的级别、消息与字段的日志替身，其它方法未实现。
+type recordLogger struct {
+	kitlog.Logger
+
+	level  kitlog.Level
+	fields map[string]interface{}
+	sink   *logSink
+}
+
+// logSink 是 recordLogger 及其 WithFields 派生实例共享的日志记录。
+type logSink struct {
+	mu      sync.Mutex
+	entries []logEntry
+}
+
+// newRecordLogger 创建指定级别的日志替身。
+func newRecordLogger(level kitlog.Level) *recordLogger {
+	return &recordLogger{level: level, sink: &logSink{}}
+}
+
+// GetLevel 返回日志级别。
+func (l *recordLogger) GetLevel() kitlog.Level {
+	return l.level
+}
+
+// WithFields 返回携带字段的新实例。
+func (l *recordLogger) WithFields(fields map[string]interface{}) kitlog.Logger {
+	return &recordLogger{level: l.level, fields: fields, sink: l.sink}
+}
+
+// Debug 记录 Debug 级别日志。
+func (l *recordLogger) Debug(args ...interface{}) { l.record("debug", args) }
+
+// Info 记录 Info 级别日志。
+func (l *recordLogger) Info(args ...interface{}) { l.record("info", args) }
+
+// Warn 记录 Warn 级别日志。
+func (l *recordLogger) Warn(args ...interface{}) { l.record("warn", args) }
+
+// record 追加一条日志。
+func (l *recordLogger) record(level string, args []interface{}) {
+	l.sink.mu.Lock()
+	defer l.sink.mu.Unlock()
+	message := ""
+	if len(args) > 0 {
+		message, _ = args[0].(string)
+	}
+	l.sink.entries = append(l.sink.entries, logEntry{level: level, message: message, fields: l.fields})
+}
+
+// find 返回第一条指定消息的日志。
+func (l *recordLogger) find(message string) (logEntry, bool) {
+	l.sink.mu.Lock()
+	defer l.sink.mu.Unlock()
+	for _, entry := range l.sink.entries {
+		if message == entry.message {
+			return entry, true
+		}
+	}
+	return logEntry{}, false
+}
+
+// spanAttrs 把 span 属性转换为 map，便于断言。
+func spanAttrs(span sdktrace.ReadOnlySpan) map[string]interface{} {
+	attrs := make(map[string]interface{})
+	for _, kv := range span.Attributes() {
+		attrs[string(kv.Key)] = kv.Value.AsInterface()
+	}
+	return attrs
+}
+
+// endedSpans 等待 recorder 中出现至少 n 个已结束的 span。
+func endedSpans(t *testing.T, recorder *tracetest.SpanRecorder, n int) []sdktrace.ReadOnlySpan {
+	t.Helper()
+
+	require.Eventually(t, func() bool { return len(recorder.Ended()) >= n }, time.Second, 5*time.Millisecond)
+	return recorder.Ended()
+}
+
+// TestConn_ObserveSendReceive 验证启用追踪与日志后，消息收发产生 span 与结构化日志。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestConn_ObserveSendReceive(t *testing.T) {
+	t.Log("验证发送 span 以调用方 span 为父 span，收发 span 与日志携带连接标识、消息类型与字节数，心跳不产生 span 与日志。")
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	leftLogger := newRecordLogger(kitlog.DebugLevel)
+	rightLogger := newRecordLogger(kitlog.DebugLevel)
+
+	leftRaw, rightRaw := netPipe(t)
+	left := WrapConn(leftRaw, 0, WithTracerProvider(tp), WithConnLogger(leftLogger), WithConnID("left"))
+	right := WrapConn(rightRaw, 0, WithTracerProvider(tp), WithConnLogger(rightLogger), WithConnID("right"))
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	t.Cleanup(func() { _ = right.Close() })
+
+	left.Start(ctx)
+	right.Start(ctx)
+
+	require.NoError(t, left.SendMessage(NewHeartbeatMessage(1)))
+	parentCtx, parent := tp.Tracer("test").Start(context.Background(), "handler")
+	require.NoError(t, left.SendMessageContext(parentCtx, NewSingleStringMessage("hello")))
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-right.Message():
+		case <-time.After(time.Second):
+			require.Fail(t, "timed out waiting for message")
+		}
+	}
+	parent.End()
+
+	spans := endedSpans(t, recorder, 3)
+	byName := make(map[string]sdktrace.ReadOnlySpan)
+	for _, span := range spans {
+		byName[span.Name()] = span
+	}
+	require.Len(t, byName, 3, "心跳不产生 span")
+
+	// 单字符串消息的数据包为 4 字节头部加字符串内容。
+	send := byName[spanNameSend]
+	require.NotNil(t, send)
+	assert.Equal(t, trace.SpanKindProducer, send.SpanKind())
+	assert.Equal(t, parent.SpanContext().SpanID(), send.Parent().SpanID())
+	assert.Equal(t, map[string]interface{}{
+		string(AttributeConnID):      "left",
+		string(AttributeMessageType): int64(SingleStringMessageType),
+		string(AttributeMessageSize): int64(4 + len("hello")),
+	}, spanAttrs(send))
+
+	receive := byName[spanNameReceive]
+	require.NotNil(t, receive)
+	assert.Equal(t, trace.SpanKindConsumer, receive.SpanKind())
+	assert.Equal(t, "right", spanAttrs(receive)[string(AttributeConnID)])
+	assert.Equal(t, int64(4+len("hello")), spanAttrs(receive)[string(AttributeMessageSize)])
+
+	started, ok := leftLogger.find("连接已启动")
+	require.True(t, ok)
+	assert.Equal(t, "info", started.level)
+	assert.Equal(t, "left", started.fields["conn_id"])
+	sent, ok := leftLogger.find("消息已发送")
+	require.True(t, ok)
+	assert.Equal(t, SingleStringMessageType, sent.fields["message_type"])
+	assert.Equal(t, 4+len("hello"), sent.fields["size"])
+	assert.Contains(t, sent.fields, "latency")
+	received, ok := rightLogger.find("消息已接收")
+	require.True(t, ok)
+	assert.Equal(t, "right", received.fields["conn_id"])
+
+	require.NoError(t, left.Close())
+	closed, ok := leftLogger.find("连接已关闭")
+	require.True(t, ok)
+	assert.Equal(t, "info", closed.level)
+	assert.NotContains(t, closed.fields, "reason", "Close 关闭时没有关闭原因")
+}
+
+// TestConn_ObserveOptions 验证观测选项的级别控制、失败记录与连接标识。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestConn_ObserveOptions(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		run         func(t *testing.T)
+	}{
+		{
+			name:        "success/info-level-skips-message-logs",
+			description: "验证日志级别高于 Debug 时只输出连接启动与关闭日志。",
+			run: func(t *testing.T) {
+				logger := newRecordLogger(kitlog.InfoLevel)
+				leftRaw, rightRaw := netPipe(t)
+				left := WrapConn(leftRaw, 0, WithConnLogger(logger))
+				right := WrapConn(rightRaw, 0)
+				ctx, cancel := context.WithCancel(context.Background())
+				defer cancel()
+				left.Start(ctx)
+				right.Start(ctx)
+
+				require.NoError(t, left.SendMessage(NewSingleStringMessage("quiet")))
+				<-right.Message()
+				require.NoError(t, left.Close())
+
+				_, ok := logger.find("消息已发送")
+				assert.False(t, ok)
+				_, ok = logger.find("连接已启动")
+				assert.True(t, ok)
+				_, ok = logger.find("连接已关闭")
+				assert.True(t, ok)
+			},
+		},
+		{
+			name:        "error/send-on-closed",
+			description: "验证向已关闭连接发送时以错误结束发送 span 并输出 Warn 日志。",
+			run: func(t *testing.T) {
+				recorder := tracetest.NewSpanRecorder()
+				tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+				logger := newRecordLogger(kitlog.InfoLevel)
+				wrapped := WrapConn(newScriptedConn(nil), 0, WithTracerProvider(tp), WithConnLogger(logger))
+				require.NoError(t, wrapped.Close())
+
+				require.Error(t, wrapped.SendMessage(NewSingleStringMessage("late")))
+				spans := endedSpans(t, recorder, 1)
+				assert.Equal(t, codes.Error, spans[0].Status().Code)
+				failed, ok := logger.find("消息发送失败")
+				require.True(t, ok)
+				assert.Equal(t, "warn", failed.level)
+				assert.Contains(t, failed.fields, "error")
+			},
+		},
+		{
+			name:        "success/close-reason",
+			description: "验证超时关闭时关闭日志携带关闭原因。",
+			run: func(t *testing.T) {
+				logger := newRecordLogger(kitlog.InfoLevel)
+				wrapped := WrapConn(newScriptedConn(nil), 0, WithConnLogger(logger))
+				wrapped.timeoutClose(CloseReasonMaxLifetime)
+
+				closed, ok := logger.find("连接已关闭")
+				require.True(t, ok)
+				assert.Equal(t, "max_lifetime", closed.fields["reason"])
+			},
+		},
+		{
+			name:        "success/conn-id",
+			description: "验证默认连接标识递增，WithConnID 覆盖默认值，空字符串保留默认值。",
+			run: func(t *testing.T) {
+				first := WrapConn(newScriptedConn(nil), 0)
+				second := WrapConn(newScriptedConn(nil), 0, WithConnID(""))
+				firstID, err := strconv.ParseUint(first.ID(), 10, 64)
+				require.NoError(t, err)
+				secondID, err := strconv.ParseUint(second.ID(), 10, 64)
+				require.NoError(t, err)
+				assert.Greater(t, secondID, firstID)
+
+				assert.Equal(t, "session-1", WrapConn(newScriptedConn(nil), 0, WithConnID("session-1")).ID())
+			},
+		},
+		{
+			name:        "boundary/disabled",
+			description: "验证未启用追踪与日志时消息不被包装，nil TracerProvider 关闭追踪。",
+			run: func(t *testing.T) {
+				wrapped := WrapConn(newScriptedConn(nil), 0, WithTracerProvider(nil))
+				assert.False(t, wrapped.observed(NewSingleStringMessage("x")))
+				require.NoError(t, wrapped.SendMessage(NewSingleStringMessage("x")))
+				_, traced := (<-wrapped.messageWrite).(*tracedMessage)
+				assert.False(t, traced)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			tt.run(t)
+		})
+	}
+}
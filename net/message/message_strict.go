@@ -0,0 +1,392 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package message
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"sync/atomic"
+
+	cockroachdberrors "github.com/cockroachdb/errors"
+)
+
+var (
+	// 断言 frameValidator 实现 FrameValidator 接口。
+	_ FrameValidator = (*frameValidator)(nil)
+	// 断言 *FrameError 实现 error 接口。
+	_ error = (*FrameError)(nil)
+)
+
+const (
+	// FrameErrorMalformedHeader 表示帧头部不完整，或头部声明的 payload 长度与实际长度不一致。
+	FrameErrorMalformedHeader FrameErrorKind = iota + 1
+	// FrameErrorPayloadTooLarge 表示头部声明的 payload 长度超过校验器允许的上限。
+	FrameErrorPayloadTooLarge
+	// FrameErrorUnknownType 表示帧的消息类型不在已知类型集合中。
+	FrameErrorUnknownType
+)
+
+type (
+	// FrameErrorKind 标识严格校验拒绝消息帧的原因。
+	FrameErrorKind int
+
+	// FrameError 表示严格校验拒绝消息帧时返回的错误。
+	//
+	// 调用方可以使用 errors.As 从连接或 Scanner 返回的错误中取出 FrameError 并按 Kind 区分原因。
+	FrameError struct {
+		Kind        FrameErrorKind // 拒绝原因。
+		MessageType MessageType    // 头部中的消息类型；头部不完整时为 0。
+		Length      int            // 头部声明的 payload 长度；头部不完整时为已收到的字节数。
+		Limit       int            // 校验器允许的最大 payload 长度。
+	}
+
+	// FrameRejects 汇总严格校验按原因拒绝的消息帧数量。
+	FrameRejects struct {
+		MalformedHeader uint64 // 因头部不合法被拒绝的帧数量。
+		PayloadTooLarge uint64 // 因 payload 超长被拒绝的帧数量。
+		UnknownType     uint64 // 因消息类型未知被拒绝的帧数量。
+	}
+
+	// FrameValidator 定义在分发前校验消息帧的契约。
+	//
+	// 同一个校验器可以在多个连接与 Scanner 之间共享，所有方法都可以并发调用。
+	FrameValidator interface {
+		// ValidateHeader 校验帧头部中的消息类型与 payload 长度。
+		//
+		// 参数：
+		//   - header: 帧的前 4 个字节，多余的字节会被忽略。
+		//
+		// 返回：
+		//   - error: 校验失败时返回 *FrameError，并计入对应的拒绝计数。
+		ValidateHeader(header []byte) error
+
+		// Validate 校验完整的消息帧。
+		//
+		// 在 ValidateHeader 的基础上，还要求头部声明的 payload 长度与帧的实际长度一致。
+		//
+		// 参数：
+		//   - frame: 包含头部与 payload 的完整消息帧。
+		//
+		// 返回：
+		//   - error: 校验失败时返回 *FrameError，并计入对应的拒绝计数。
+		Validate(frame []byte) error
+
+		// Rejects 返回按原因汇总的拒绝计数。
+		//
+		// 参数：无。
+		//
+		// 返回：
+		//   - FrameRejects: 当前的拒绝计数快照。
+		Rejects() FrameRejects
+	}
+
+	// FrameValidatorOption 定义 NewFrameValidator 的配置选项。
+	FrameValidatorOption func(*frameValidator)
+
+	// frameValidator 是 [FrameValidator] 的默认实现。
+	frameValidator struct {
+		maxPayloadLength int                      // 允许的最大 payload 长度。
+		knownTypes       map[MessageType]struct{} // 已知消息类型集合；为 nil 时以默认工厂的注册结果为准。
+
+		malformedHeader atomic.Uint64 // 因头部不合法被拒绝的帧数量。
+		payloadTooLarge atomic.Uint64 // 因 payload 超长被拒绝的帧数量。
+		unknownType     atomic.Uint64 // 因消息类型未知被拒绝的帧数量。
+	}
+)
+
+// String 返回拒绝原因的文本表示。
+//
+// 参数：无。
+//
+// 返回：
+//   - string: 拒绝原因名称；未知值返回 "unknown"。
+func (k FrameErrorKind) String() string {
+	switch k {
+	case FrameErrorMalformedHeader:
+		return "malformed_header"
+	case FrameErrorPayloadTooLarge:
+		return "payload_too_large"
+	case FrameErrorUnknownType:
+		return "unknown_type"
+	default:
+		return "unknown"
+	}
+}
+
+// Error 返回错误描述。
+//
+// 参数：无。
+//
+// 返回：
+//   - string: 包含拒绝原因、消息类型、payload 长度与上限的错误描述。
+func (e *FrameError) Error() string {
+	return fmt.Sprintf("消息帧校验失败（%[1]s）：类型 %[2]d，长度 %[3]d，上限 %[4]d。", e.Kind, e.MessageType, e.Length, e.Limit)
+}
+
+// Total 返回各原因拒绝数量之和。
+//
+// 参数：无。
+//
+// 返回：
+//   - uint64: 被拒绝的帧总数。
+func (r FrameRejects) Total() uint64 {
+	return r.MalformedHeader + r.PayloadTooLarge + r.UnknownType
+}
+
+// WithMaxPayloadLength 设置允许的最大 payload 长度。
+//
+// 参数：
+//   - length: 最大 payload 长度；小于等于 0 或超过 uint16 上限时使用 uint16 上限。
+//
+// 返回：
+//   - FrameValidatorOption: 设置最大 payload 长度的配置函数。
+func WithMaxPayloadLength(length int) FrameValidatorOption {
+	return func(v *frameValidator) {
+		if length <= 0 || length > math.MaxUint16 {
+			length = math.MaxUint16
+		}
+		v.maxPayloadLength = length
+	}
+}
+
+// WithKnownTypes 设置已知消息类型集合。
+//
+// 未设置时以默认工厂中已注册的消息类型为准，即通过 FactoryRegister 注册过的类型都视为已知。
+//
+// 参数：
+//   - types: 已知消息类型列表；多次调用时取并集。
+//
+// 返回：
+//   - FrameValidatorOption: 设置已知消息类型的配置函数。
+func WithKnownTypes(types ...MessageType) FrameValidatorOption {
+	return func(v *frameValidator) {
+		if nil == v.knownTypes {
+			v.knownTypes = make(map[MessageType]struct{}, len(types))
+		}
+		for _, messageType := range types {
+			v.knownTypes[messageType] = struct{}{}
+		}
+	}
+}
+
+// NewFrameValidator 创建严格模式的消息帧校验器。
+//
+// 默认允许的最大 payload 长度为 uint16 上限，已知消息类型以默认工厂的注册结果为准。
+//
+// 参数：
+//   - opts: 可选配置，例如 [WithMaxPayloadLength] 和 [WithKnownTypes]。
+//
+// 返回：
+//   - *frameValidator: 新创建的校验器，可在多个连接之间共享。
+func NewFrameValidator(opts ...FrameValidatorOption) *frameValidator {
+	v := &frameValidator{
+		maxPayloadLength: math.MaxUint16,
+	}
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	return v
+}
+
+// ValidateHeader 校验帧头部中的消息类型与 payload 长度。
+//
+// 参数：
+//   - header: 帧的前 4 个字节，多余的字节会被忽略。
+//
+// 返回：
+//   - error: 校验失败时返回 *FrameError，并计入对应的拒绝计数。
+func (v *frameValidator) ValidateHeader(header []byte) error {
+	if len(header) < messageHeaderLength {
+		return v.reject(&FrameError{Kind: FrameErrorMalformedHeader, Length: len(header)})
+	}
+
+	messageType := MessageType(binary.BigEndian.Uint16(header[:2]))
+	length := int(binary.BigEndian.Uint16(header[2:messageHeaderLength]))
+	if length > v.maxPayloadLength {
+		return v.reject(&FrameError{Kind: FrameErrorPayloadTooLarge, MessageType: messageType, Length: length})
+	}
+	if !v.known(messageType) {
+		return v.reject(&FrameError{Kind: FrameErrorUnknownType, MessageType: messageType, Length: length})
+	}
+
+	return nil
+}
+
+// Validate 校验完整的消息帧。
+//
+// 参数：
+//   - frame: 包含头部与 payload 的完整消息帧。
+//
+// 返回：
+//   - error: 校验失败时返回 *FrameError，并计入对应的拒绝计数。
+func (v *frameValidator) Validate(frame []byte) error {
+	if len(frame) >= messageHeaderLength {
+		if length := int(binary.BigEndian.Uint16(frame[2:messageHeaderLength])); length != len(frame)-messageHeaderLength {
+			return v.reject(&FrameError{
+				Kind:        FrameErrorMalformedHeader,
+				MessageType: MessageType(binary.BigEndian.Uint16(frame[:2])),
+				Length:      length,
+			})
+		}
+	}
+
+	return v.ValidateHeader(frame)
+}
+
+// Rejects 返回按原因汇总的拒绝计数。
+//
+// 参数：无。
+//
+// 返回：
+//   - FrameRejects: 当前的拒绝计数快照。
+func (v *frameValidator) Rejects() FrameRejects {
+	return FrameRejects{
+		MalformedHeader: v.malformedHeader.Load(),
+		PayloadTooLarge: v.payloadTooLarge.Load(),
+		UnknownType:     v.unknownType.Load(),
+	}
+}
+
+// known 返回消息类型是否为已知类型。
+//
+// 参数：
+//   - messageType: 待检查的消息类型。
+//
+// 返回：
+//   - bool: 消息类型已知时返回 true。
+func (v *frameValidator) known(messageType MessageType) bool {
+	if nil == v.knownTypes {
+		return FactoryRegistered(messageType)
+	}
+	_, ok := v.knownTypes[messageType]
+	return ok
+}
+
+// reject 记录拒绝计数并补全错误中的上限。
+//
+// 参数：
+//   - err: 描述拒绝原因的错误。
+//
+// 返回：
+//   - error: 补全上限后的 err。
+func (v *frameValidator) reject(err *FrameError) error {
+	err.Limit = v.maxPayloadLength
+	switch err.Kind {
+	case FrameErrorMalformedHeader:
+		v.malformedHeader.Add(1)
+	case FrameErrorPayloadTooLarge:
+		v.payloadTooLarge.Add(1)
+	case FrameErrorUnknownType:
+		v.unknownType.Add(1)
+	}
+	return err
+}
+
+// strictScanMessage 创建在拆分消息包前校验帧头部的 [bufio.SplitFunc]。
+//
+// 收到完整的 4 字节头部后立即校验，超长或类型未知的帧不会等待 payload 到达；
+// 输入结束时残留的不完整帧按头部不合法拒绝。
+//
+// 参数：
+//   - validator: 使用的帧校验器。
+//
+// 返回：
+//   - bufio.SplitFunc: 校验失败时返回 *FrameError 的拆分函数。
+func strictScanMessage(validator FrameValidator) bufio.SplitFunc {
+	return func(data []byte, atEOF bool) (int, []byte, error) {
+		if len(data) >= messageHeaderLength {
+			if err := validator.ValidateHeader(data); nil != err {
+				return 0, nil, err
+			}
+		}
+
+		advance, token, err := scanMessage(data, atEOF)
+		if nil == err && nil == token && atEOF && len(data) > 0 {
+			err = validator.Validate(data)
+		}
+		return advance, token, err
+	}
+}
+
+// NewStrictScanner 创建按本包协议拆分消息包并执行严格校验的 [bufio.Scanner]。
+//
+// 校验失败时 Scan 返回 false，Err 返回 *FrameError。
+//
+// 参数：
+//   - r: 提供协议字节流的输入源。
+//   - validator: 使用的帧校验器；为 nil 时使用 NewFrameValidator 的默认配置。
+//
+// 返回：
+//   - *bufio.Scanner: 按本包协议拆分并校验消息包的 Scanner。
+func NewStrictScanner(r io.Reader, validator FrameValidator) *bufio.Scanner {
+	if nil == validator {
+		validator = NewFrameValidator()
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, messageHeaderLength), maxMessagePacketLength)
+	scanner.Split(strictScanMessage(validator))
+
+	return scanner
+}
+
+// WithFrameValidator 为连接启用严格校验模式。
+//
+// 启用后，接收流程在分发前使用 validator 校验每个帧，校验失败时关闭连接；
+// 拒绝计数可通过 validator 的 Rejects 方法读取。
+//
+// 参数：
+//   - validator: 使用的帧校验器；为 nil 时不启用严格校验。
+//
+// 返回：
+//   - ConnOption: 设置帧校验器的配置函数。
+func WithFrameValidator(validator FrameValidator) ConnOption {
+	return func(c *conn) {
+		c.frameValidator = validator
+	}
+}
+
+// WithOnFrameReject 设置严格校验拒绝消息帧并关闭连接后的回调。
+//
+// 回调在内部接收 goroutine 中同步调用，每个连接最多执行一次。
+//
+// 参数：
+//   - fn: 拒绝回调，参数为被关闭的连接和拒绝错误；为 nil 时不回调。
+//
+// 返回：
+//   - ConnOption: 设置拒绝回调的配置函数。
+func WithOnFrameReject(fn func(Conn, *FrameError)) ConnOption {
+	return func(c *conn) {
+		c.onFrameReject = fn
+	}
+}
+
+// newScanner 根据连接配置创建接收流程使用的 Scanner。
+//
+// 参数：无。
+//
+// 返回：
+//   - *bufio.Scanner: 配置帧校验器时返回严格 Scanner，否则返回普通 Scanner。
+func (c *conn) newScanner() *bufio.Scanner {
+	if nil != c.frameValidator {
+		return NewStrictScanner(c, c.frameValidator)
+	}
+	return NewScanner(c)
+}
+
+// notifyFrameReject 在 err 为严格校验错误时执行拒绝回调。
+//
+// 参数：
+//   - err: 接收流程返回的错误。
+func (c *conn) notifyFrameReject(err error) {
+	var frameErr *FrameError
+	if nil != c.onFrameReject && cockroachdberrors.As(err, &frameErr) {
+		c.onFrameReject(c, frameErr)
+	}
+}
@@ -0,0 +1,276 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package message
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFrameErrorKind_String 验证拒绝原因的文本表示。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestFrameErrorKind_String(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		giveKind    FrameErrorKind
+		want        string
+	}{
+		{name: "success/malformed-header", description: "验证头部不合法原因的名称。", giveKind: FrameErrorMalformedHeader, want: "malformed_header"},
+		{name: "success/payload-too-large", description: "验证 payload 超长原因的名称。", giveKind: FrameErrorPayloadTooLarge, want: "payload_too_large"},
+		{name: "success/unknown-type", description: "验证类型未知原因的名称。", giveKind: FrameErrorUnknownType, want: "unknown_type"},
+		{name: "boundary/unknown", description: "验证未知原因返回 unknown。", giveKind: FrameErrorKind(0), want: "unknown"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			assert.Equal(t, tt.want, tt.giveKind.String())
+		})
+	}
+}
+
+// TestFrameValidator_Validate 验证完整帧的严格校验与拒绝计数。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestFrameValidator_Validate(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		giveOpts    []FrameValidatorOption
+		giveFrame   []byte
+		wantErr     *FrameError
+		wantRejects FrameRejects
+	}{
+		{
+			name:        "success/registered-type",
+			description: "验证未指定已知类型时，默认工厂中已注册的类型可以通过校验。",
+			giveFrame:   buildTestPacket(t, SingleStringMessageType, []byte{0x00, 0x01, 'a'}),
+		},
+		{
+			name:        "success/explicit-known-type",
+			description: "验证显式指定的已知类型可以通过校验，即使默认工厂中未注册。",
+			giveOpts:    []FrameValidatorOption{WithKnownTypes(0x7001), WithKnownTypes(0x7002)},
+			giveFrame:   buildTestPacket(t, 0x7002, []byte("x")),
+		},
+		{
+			name:        "error/short-header",
+			description: "验证不足 4 字节的帧按头部不合法拒绝。",
+			giveFrame:   []byte{0x00, 0x09, 0x00},
+			wantErr:     &FrameError{Kind: FrameErrorMalformedHeader, Length: 3, Limit: math.MaxUint16},
+			wantRejects: FrameRejects{MalformedHeader: 1},
+		},
+		{
+			name:        "error/length-mismatch",
+			description: "验证头部声明的长度与实际 payload 长度不一致时按头部不合法拒绝。",
+			giveFrame:   []byte{0x00, 0x09, 0x00, 0x05, 'a'},
+			wantErr:     &FrameError{Kind: FrameErrorMalformedHeader, MessageType: SingleStringMessageType, Length: 5, Limit: math.MaxUint16},
+			wantRejects: FrameRejects{MalformedHeader: 1},
+		},
+		{
+			name:        "error/payload-too-large",
+			description: "验证 payload 超过配置的上限时拒绝。",
+			giveOpts:    []FrameValidatorOption{WithMaxPayloadLength(4)},
+			giveFrame:   buildTestPacket(t, SingleStringMessageType, []byte("hello")),
+			wantErr:     &FrameError{Kind: FrameErrorPayloadTooLarge, MessageType: SingleStringMessageType, Length: 5, Limit: 4},
+			wantRejects: FrameRejects{PayloadTooLarge: 1},
+		},
+		{
+			name:        "error/unknown-type",
+			description: "验证默认工厂中未注册的类型被拒绝。",
+			giveFrame:   buildTestPacket(t, 0x7fff, []byte{}),
+			wantErr:     &FrameError{Kind: FrameErrorUnknownType, MessageType: 0x7fff, Length: 0, Limit: math.MaxUint16},
+			wantRejects: FrameRejects{UnknownType: 1},
+		},
+		{
+			name:        "boundary/invalid-max-payload-length",
+			description: "验证非正的上限回退为 uint16 上限。",
+			giveOpts:    []FrameValidatorOption{WithMaxPayloadLength(0)},
+			giveFrame:   buildTestPacket(t, SingleStringMessageType, make([]byte, math.MaxUint16)),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			validator := NewFrameValidator(tt.giveOpts...)
+			err := validator.Validate(tt.giveFrame)
+			if nil == tt.wantErr {
+				require.NoError(t, err)
+			} else {
+				var frameErr *FrameError
+				require.True(t, errors.As(err, &frameErr))
+				assert.Equal(t, tt.wantErr, frameErr)
+				assert.Contains(t, err.Error(), tt.wantErr.Kind.String())
+			}
+			assert.Equal(t, tt.wantRejects, validator.Rejects())
+			assert.Equal(t, tt.wantRejects.Total(), validator.Rejects().Total())
+		})
+	}
+}
+
+// TestNewStrictScanner 验证严格 Scanner 在拆分前校验帧头部。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestNewStrictScanner(t *testing.T) {
+	valid := buildTestPacket(t, SingleStringMessageType, []byte{0x00, 0x01, 'a'})
+
+	tests := []struct {
+		name        string
+		description string
+		giveData    []byte
+		giveOpts    []FrameValidatorOption
+		wantFrames  int
+		wantKind    FrameErrorKind
+	}{
+		{
+			name:        "success/all-valid",
+			description: "验证全部合法的帧都被拆分出来。",
+			giveData:    append(append([]byte{}, valid...), valid...),
+			wantFrames:  2,
+		},
+		{
+			name:        "error/oversized-header-before-payload",
+			description: "验证超长帧在 payload 到达前即被拒绝，之前的合法帧仍然返回。",
+			giveData:    append(append([]byte{}, valid...), 0x00, 0x09, 0xff, 0xff),
+			giveOpts:    []FrameValidatorOption{WithMaxPayloadLength(16)},
+			wantFrames:  1,
+			wantKind:    FrameErrorPayloadTooLarge,
+		},
+		{
+			name:        "error/unknown-type",
+			description: "验证未知类型的帧被拒绝。",
+			giveData:    buildTestPacket(t, 0x7fff, []byte("x")),
+			wantKind:    FrameErrorUnknownType,
+		},
+		{
+			name:        "error/truncated-tail",
+			description: "验证输入结束时残留的不完整帧按头部不合法拒绝。",
+			giveData:    append(append([]byte{}, valid...), valid[:6]...),
+			wantFrames:  1,
+			wantKind:    FrameErrorMalformedHeader,
+		},
+		{
+			name:        "error/truncated-header",
+			description: "验证输入结束时残留的不完整头部按头部不合法拒绝。",
+			giveData:    valid[:2],
+			wantKind:    FrameErrorMalformedHeader,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			validator := NewFrameValidator(tt.giveOpts...)
+			scanner := NewStrictScanner(bytes.NewReader(tt.giveData), validator)
+			frames := 0
+			for scanner.Scan() {
+				assert.Equal(t, valid, scanner.Bytes())
+				frames++
+			}
+			assert.Equal(t, tt.wantFrames, frames)
+
+			if 0 == tt.wantKind {
+				require.NoError(t, scanner.Err())
+				assert.Zero(t, validator.Rejects().Total())
+				return
+			}
+			var frameErr *FrameError
+			require.True(t, errors.As(scanner.Err(), &frameErr))
+			assert.Equal(t, tt.wantKind, frameErr.Kind)
+			assert.Equal(t, uint64(1), validator.Rejects().Total())
+		})
+	}
+
+	// 未传入校验器时使用默认配置。
+	scanner := NewStrictScanner(bytes.NewReader(valid), nil)
+	require.True(t, scanner.Scan())
+	assert.False(t, scanner.Scan())
+	assert.NoError(t, scanner.Err())
+}
+
+// TestConn_StrictValidationRejectsFrame 验证启用严格校验的连接拒绝非法帧、关闭连接并回调拒绝错误。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestConn_StrictValidationRejectsFrame(t *testing.T) {
+	valid := buildTestPacket(t, SingleStringMessageType, []byte{0x00, 0x01, 'a'})
+	invalid := buildTestPacket(t, 0x7fff, []byte("x"))
+	base := newScriptedConn(append(append([]byte{}, valid...), invalid...))
+
+	validator := NewFrameValidator()
+	rejects := make(chan *FrameError, 2)
+	wrapped := WrapConn(base, 0,
+		WithFrameValidator(validator),
+		WithOnFrameReject(func(c Conn, err *FrameError) {
+			assert.True(t, c.Closed())
+			rejects <- err
+		}),
+	)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		wrapped.receive(context.Background())
+	}()
+
+	select {
+	case got := <-rejects:
+		assert.Equal(t, FrameErrorUnknownType, got.Kind)
+		assert.Equal(t, MessageType(0x7fff), got.MessageType)
+	case <-time.After(time.Second):
+		require.Fail(t, "timed out waiting for frame reject")
+	}
+	<-done
+
+	got, ok := <-wrapped.Message()
+	require.True(t, ok)
+	assert.Equal(t, SingleStringMessageType, got.MessageType())
+	_, ok = <-wrapped.Message()
+	assert.False(t, ok)
+	assert.Equal(t, FrameRejects{UnknownType: 1}, validator.Rejects())
+	assert.Empty(t, rejects)
+}
+
+// TestConn_WithoutStrictValidation 验证未启用严格校验时接收流程不执行拒绝回调。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestConn_WithoutStrictValidation(t *testing.T) {
+	base := newScriptedConn(buildTestPacket(t, 0x7fff, []byte("x")))
+	called := false
+	wrapped := WrapConn(base, 0, WithOnFrameReject(func(Conn, *FrameError) { called = true }))
+
+	wrapped.receive(context.Background())
+
+	assert.True(t, wrapped.Closed())
+	assert.False(t, called)
+}
+
+// TestFactoryRegistered 验证默认工厂的注册检查。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestFactoryRegistered(t *testing.T) {
+	assert.True(t, FactoryRegistered(HeartbeatMessageType))
+	assert.False(t, FactoryRegistered(0x7fff))
+}
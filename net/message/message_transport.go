@@ -0,0 +1,504 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package message
+
+import (
+	"context"
+	"encoding/binary"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	cockroachdberrors "github.com/cockroachdb/errors"
+)
+
+const (
+	// sequenceLength 表示启用序列号时数据报前缀的长度。
+	sequenceLength = 4
+	// maxDatagramLength 表示读取数据报时使用的缓冲区长度，可容纳序列号前缀与最大完整消息包。
+	maxDatagramLength = sequenceLength + maxMessagePacketLength
+	// sequenceResetWindow 表示序列号回退超过该值时视为对端重新开始计数。
+	sequenceResetWindow = 1024
+)
+
+var (
+	// ErrPacketNetwork 表示向 Dial 传入了数据报网络，数据报网络应使用 ListenPacket 或 DialPacket。
+	ErrPacketNetwork = cockroachdberrors.New("数据报网络应使用 ListenPacket 或 DialPacket。")
+	// ErrStreamNetwork 表示向 ListenPacket 或 DialPacket 传入了字节流网络，字节流网络应使用 Dial 或 WrapConn。
+	ErrStreamNetwork = cockroachdberrors.New("字节流网络应使用 Dial 或 WrapConn。")
+)
+
+type (
+	// PacketOption 定义 WrapPacketConn、ListenPacket 与 DialPacket 的配置选项。
+	PacketOption func(*PacketConn)
+
+	// PacketStats 汇总数据报连接的收发与丢弃计数。
+	PacketStats struct {
+		Sent     uint64 // 成功写出的数据报数量。
+		Received uint64 // 成功还原并返回给调用方的消息数量。
+		Lost     uint64 // 按序列号间隔推断丢失的数据报数量；未启用序列号时为 0。
+		Late     uint64 // 序列号不大于该对端已接受的最大序列号而被丢弃的重复或乱序数据报数量。
+		Invalid  uint64 // 缺少序列号、未通过帧校验或无法还原为消息而被丢弃的数据报数量。
+	}
+
+	// PacketConn 按数据报收发本包协议消息，每个数据报承载一条完整消息。
+	//
+	// 帧格式与 [Conn] 相同；启用 [WithSequence] 时每个数据报前附加 4 字节大端序序列号，
+	// 接收端据此统计丢包并丢弃重复与乱序到达的数据报，收发两端必须使用相同的配置。
+	// 数据报不保证送达与顺序，因此 HELLO 协商、心跳超时、协商关闭等依赖可靠字节流的机制不适用，
+	// 这些控制消息会像普通消息一样返回给调用方。
+	//
+	// ReadMessage 与 WriteMessageTo 可以并发调用；多个 goroutine 同时调用 ReadMessage 时会竞争读取数据报。
+	// PacketConn 的零值不可用，应通过 [WrapPacketConn]、[ListenPacket] 或 [DialPacket] 创建。
+	PacketConn struct {
+		conn   net.PacketConn // 承载数据报的底层连接。
+		dialed net.Conn       // DialPacket 创建的已连接套接字，WriteMessage 通过它写出；为 nil 时只能指定地址发送。
+
+		sequence       bool           // 是否在数据报前附加序列号。
+		frameValidator FrameValidator // 严格校验使用的帧校验器；为 nil 时不校验。
+
+		sequenceLocker sync.Mutex        // 保护 sendSequence 与 recvSequence。
+		sendSequence   map[string]uint32 // 按对端地址记录的下一个发送序列号。
+		recvSequence   map[string]uint32 // 按对端地址记录的已接受的最大序列号。
+
+		readLocker sync.Mutex // 串行化对 readBuffer 的使用。
+		readBuffer []byte     // 读取数据报的缓冲区。
+
+		sent     atomic.Uint64 // 成功写出的数据报数量。
+		received atomic.Uint64 // 成功返回给调用方的消息数量。
+		lost     atomic.Uint64 // 推断丢失的数据报数量。
+		late     atomic.Uint64 // 因重复或乱序被丢弃的数据报数量。
+		invalid  atomic.Uint64 // 因格式不合法被丢弃的数据报数量。
+	}
+)
+
+// WithSequence 设置是否在数据报前附加序列号。
+//
+// 序列号按对端地址分别递增，接收端据此推断丢包数量，并丢弃序列号不大于已接受最大值的数据报；
+// 序列号回退超过 1024 时视为对端重启，重新开始计数。
+// 启用后收发两端必须同时启用，否则对端无法正确解析数据报。
+//
+// 参数：
+//   - enabled: 为 true 时启用序列号。
+//
+// 返回：
+//   - PacketOption: 设置序列号的配置函数。
+func WithSequence(enabled bool) PacketOption {
+	return func(c *PacketConn) {
+		c.sequence = enabled
+	}
+}
+
+// WithPacketFrameValidator 为数据报连接启用严格校验模式。
+//
+// 未通过校验的数据报被丢弃并计入 [PacketStats].Invalid，拒绝原因由校验器的 Rejects 汇总；
+// 与字节流连接不同，单个数据报不合法不会关闭连接。
+//
+// 参数：
+//   - validator: 帧校验器，通常由 [NewFrameValidator] 创建；为 nil 时不校验。
+//
+// 返回：
+//   - PacketOption: 设置帧校验器的配置函数。
+func WithPacketFrameValidator(validator FrameValidator) PacketOption {
+	return func(c *PacketConn) {
+		c.frameValidator = validator
+	}
+}
+
+// Dial 连接字节流网络地址，并包装为按本包协议收发消息的连接。
+//
+// 支持 tcp、tcp4、tcp6 与 unix 网络；unix 域套接字适合同机进程间的低延迟通信，
+// 服务端可以使用 net.Listen("unix", path) 接受连接后调用 [WrapConn]。
+//
+// 参数：
+//   - ctx: 控制连接建立过程的上下文，不影响连接建立后的生命周期。
+//   - network: 网络类型。
+//   - address: 网络地址；unix 网络为套接字文件路径。
+//   - heartbeatInterval: 心跳发送间隔，语义同 [WrapConn]。
+//   - opts: 可选的连接配置，语义同 [WrapConn]。
+//
+// 返回：
+//   - *conn: 包装后的协议连接，调用方需要调用 Start 启动收发。
+//   - error: network 为数据报网络时返回 ErrPacketNetwork，不支持的网络或连接失败时返回对应错误。
+func Dial(ctx context.Context, network, address string, heartbeatInterval time.Duration, opts ...ConnOption) (*conn, error) {
+	if isPacketNetwork(network) {
+		return nil, cockroachdberrors.Wrapf(ErrPacketNetwork, "网络类型 %[1]s。", network)
+	}
+	if !isStreamNetwork(network) {
+		return nil, cockroachdberrors.Newf("不支持的网络类型 %[1]s。", network)
+	}
+
+	c, err := (&net.Dialer{}).DialContext(ctx, network, address)
+	if nil != err {
+		return nil, cockroachdberrors.Wrap(err, "建立连接失败。")
+	}
+
+	return WrapConn(c, heartbeatInterval, opts...), nil
+}
+
+// WrapPacketConn 将底层 net.PacketConn 包装为按数据报收发消息的连接。
+//
+// 参数：
+//   - pc: 待包装的底层数据报连接，必须非 nil。
+//   - opts: 可选的数据报连接配置，例如 [WithSequence] 与 [WithPacketFrameValidator]。
+//
+// 返回：
+//   - *PacketConn: 包装后的数据报连接；调用方应在不再使用时调用 Close。
+func WrapPacketConn(pc net.PacketConn, opts ...PacketOption) *PacketConn {
+	c := &PacketConn{
+		conn:         pc,
+		sendSequence: make(map[string]uint32),
+		recvSequence: make(map[string]uint32),
+		readBuffer:   make([]byte, maxDatagramLength),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// ListenPacket 监听数据报网络地址，并包装为按数据报收发消息的连接。
+//
+// 参数：
+//   - network: 网络类型，支持 udp、udp4、udp6 与 unixgram。
+//   - address: 本地地址；unixgram 网络为套接字文件路径。
+//   - opts: 可选的数据报连接配置。
+//
+// 返回：
+//   - *PacketConn: 数据报连接，通过 WriteMessageTo 向指定地址发送消息。
+//   - error: network 为字节流网络时返回 ErrStreamNetwork，不支持的网络或监听失败时返回对应错误。
+func ListenPacket(network, address string, opts ...PacketOption) (*PacketConn, error) {
+	if err := checkPacketNetwork(network); nil != err {
+		return nil, err
+	}
+
+	pc, err := net.ListenPacket(network, address)
+	if nil != err {
+		return nil, cockroachdberrors.Wrap(err, "监听数据报地址失败。")
+	}
+
+	return WrapPacketConn(pc, opts...), nil
+}
+
+// DialPacket 连接数据报网络地址，并包装为按数据报收发消息的连接。
+//
+// 返回的连接只与 address 通信，可以直接调用 WriteMessage 发送。
+// unixgram 网络的客户端没有本地地址，对端无法回复；需要双向通信时应使用 ListenPacket 绑定本地路径。
+//
+// 参数：
+//   - network: 网络类型，支持 udp、udp4、udp6 与 unixgram。
+//   - address: 对端地址。
+//   - opts: 可选的数据报连接配置。
+//
+// 返回：
+//   - *PacketConn: 已连接的数据报连接。
+//   - error: network 为字节流网络时返回 ErrStreamNetwork，不支持的网络或连接失败时返回对应错误。
+func DialPacket(network, address string, opts ...PacketOption) (*PacketConn, error) {
+	if err := checkPacketNetwork(network); nil != err {
+		return nil, err
+	}
+
+	c, err := net.Dial(network, address)
+	if nil != err {
+		return nil, cockroachdberrors.Wrap(err, "建立连接失败。")
+	}
+	pc, ok := c.(net.PacketConn)
+	if !ok {
+		_ = c.Close()
+		return nil, cockroachdberrors.Newf("连接类型 %[1]T 未实现 net.PacketConn。", c)
+	}
+
+	packetConn := WrapPacketConn(pc, opts...)
+	packetConn.dialed = c
+	return packetConn, nil
+}
+
+// WriteMessage 向 DialPacket 连接的对端发送消息。
+//
+// 参数：
+//   - message: 待发送的消息；调用方应保证其非 nil。
+//
+// 返回：
+//   - error: 连接不是由 DialPacket 创建、封包失败或写出失败时返回错误。
+func (c *PacketConn) WriteMessage(message Message) error {
+	if nil == c.dialed {
+		return cockroachdberrors.Newf("连接未指定对端地址，应使用 WriteMessageTo。")
+	}
+	return c.WriteMessageTo(message, nil)
+}
+
+// WriteMessageTo 向指定地址发送一条消息，消息封包后作为一个数据报写出。
+//
+// 完整数据报超过传输层上限（UDP 约为 65507 字节）时由底层连接返回错误。
+//
+// 参数：
+//   - message: 待发送的消息；调用方应保证其非 nil。
+//   - addr: 对端地址；为 nil 时发送给 DialPacket 连接的对端。
+//
+// 返回：
+//   - error: 封包失败、未指定对端地址或写出失败时返回错误。
+func (c *PacketConn) WriteMessageTo(message Message, addr net.Addr) error {
+	var peer net.Addr
+	if nil != addr {
+		peer = addr
+	} else if nil != c.dialed {
+		peer = c.dialed.RemoteAddr()
+	} else {
+		return cockroachdberrors.Newf("对端地址不能为空。")
+	}
+
+	frame, err := PackMessage(message)
+	if nil != err {
+		return err
+	}
+
+	packet := frame
+	if c.sequence {
+		packet = make([]byte, sequenceLength, sequenceLength+len(frame))
+		binary.BigEndian.PutUint32(packet, c.nextSendSequence(peer))
+		packet = append(packet, frame...)
+	}
+
+	// 已连接的 UDP 套接字不允许 WriteTo，发送给连接对端时使用 Write。
+	if nil != c.dialed && nil == addr {
+		_, err = c.dialed.Write(packet)
+	} else {
+		_, err = c.conn.WriteTo(packet, addr)
+	}
+	if nil != err {
+		return cockroachdberrors.Wrap(err, "写出数据报失败。")
+	}
+
+	c.sent.Add(1)
+	return nil
+}
+
+// ReadMessage 读取下一条消息。
+//
+// 缺少序列号、未通过帧校验、无法还原为消息，以及重复或乱序到达的数据报被丢弃并计数，
+// ReadMessage 继续等待下一个数据报，直到成功还原一条消息或读取出错。
+//
+// 参数：无。
+//
+// 返回：
+//   - Message: 还原出的消息。
+//   - net.Addr: 发送方地址。
+//   - error: 底层连接读取失败时返回错误，例如连接已关闭或超过读截止时间。
+func (c *PacketConn) ReadMessage() (Message, net.Addr, error) {
+	c.readLocker.Lock()
+	defer c.readLocker.Unlock()
+
+	for {
+		n, addr, err := c.conn.ReadFrom(c.readBuffer)
+		if nil != err {
+			return nil, addr, cockroachdberrors.Wrap(err, "读取数据报失败。")
+		}
+
+		packet := c.readBuffer[:n]
+		var sequence uint32
+		if c.sequence {
+			if n < sequenceLength {
+				c.invalid.Add(1)
+				continue
+			}
+			sequence = binary.BigEndian.Uint32(packet)
+			packet = packet[sequenceLength:]
+		}
+
+		// 生成函数可能直接引用 payload，缓冲区会被下一次读取覆盖，因此先复制。
+		frame := append([]byte(nil), packet...)
+		if nil != c.frameValidator {
+			if errValidate := c.frameValidator.Validate(frame); nil != errValidate {
+				c.invalid.Add(1)
+				continue
+			}
+		}
+		message, errUnpack := UnpackMessage(frame)
+		if nil != errUnpack {
+			c.invalid.Add(1)
+			continue
+		}
+		if c.sequence && !c.acceptSequence(addr, sequence) {
+			c.late.Add(1)
+			continue
+		}
+
+		c.received.Add(1)
+		return message, addr, nil
+	}
+}
+
+// Stats 返回收发与丢弃计数的快照。
+//
+// 参数：无。
+//
+// 返回：
+//   - PacketStats: 当前的计数快照。
+func (c *PacketConn) Stats() PacketStats {
+	return PacketStats{
+		Sent:     c.sent.Load(),
+		Received: c.received.Load(),
+		Lost:     c.lost.Load(),
+		Late:     c.late.Load(),
+		Invalid:  c.invalid.Load(),
+	}
+}
+
+// Close 关闭底层连接，阻塞中的 ReadMessage 会返回错误。
+//
+// 参数：无。
+//
+// 返回：
+//   - error: 底层连接关闭失败时返回错误。
+func (c *PacketConn) Close() error {
+	return c.conn.Close()
+}
+
+// LocalAddr 返回底层连接的本地网络地址。
+//
+// 参数：无。
+//
+// 返回：
+//   - net.Addr: 本地网络地址。
+func (c *PacketConn) LocalAddr() net.Addr {
+	return c.conn.LocalAddr()
+}
+
+// SetDeadline 设置底层连接的读写截止时间。
+//
+// 参数：
+//   - t: 截止时间；零值表示取消已设置的读写截止时间。
+//
+// 返回：
+//   - error: 底层连接设置截止时间失败时返回错误。
+func (c *PacketConn) SetDeadline(t time.Time) error {
+	return c.conn.SetDeadline(t)
+}
+
+// SetReadDeadline 设置底层连接的读截止时间，超过后 ReadMessage 返回错误。
+//
+// 参数：
+//   - t: 截止时间；零值表示取消已设置的读截止时间。
+//
+// 返回：
+//   - error: 底层连接设置读截止时间失败时返回错误。
+func (c *PacketConn) SetReadDeadline(t time.Time) error {
+	return c.conn.SetReadDeadline(t)
+}
+
+// SetWriteDeadline 设置底层连接的写截止时间。
+//
+// 参数：
+//   - t: 截止时间；零值表示取消已设置的写截止时间。
+//
+// 返回：
+//   - error: 底层连接设置写截止时间失败时返回错误。
+func (c *PacketConn) SetWriteDeadline(t time.Time) error {
+	return c.conn.SetWriteDeadline(t)
+}
+
+// nextSendSequence 返回发送给对端的下一个序列号。
+//
+// 参数：
+//   - peer: 对端地址。
+//
+// 返回：
+//   - uint32: 序列号，从 0 开始按对端递增并在溢出后回绕。
+func (c *PacketConn) nextSendSequence(peer net.Addr) uint32 {
+	c.sequenceLocker.Lock()
+	defer c.sequenceLocker.Unlock()
+
+	key := peer.String()
+	sequence := c.sendSequence[key]
+	c.sendSequence[key] = sequence + 1
+	return sequence
+}
+
+// acceptSequence 按对端记录序列号并统计丢包。
+//
+// 序列号按回绕算术比较：比已接受最大值大 n 时接受并计入 n-1 个丢包，不大于最大值时拒绝；
+// 回退超过 sequenceResetWindow 时视为对端重启后重新计数，接受且不计算丢包。
+// 对端的第一个数据报总是被接受，不计算丢包。
+//
+// 参数：
+//   - peer: 对端地址。
+//   - sequence: 数据报携带的序列号。
+//
+// 返回：
+//   - bool: 数据报应被接受时返回 true。
+func (c *PacketConn) acceptSequence(peer net.Addr, sequence uint32) bool {
+	c.sequenceLocker.Lock()
+	defer c.sequenceLocker.Unlock()
+
+	key := ""
+	if nil != peer {
+		key = peer.String()
+	}
+	last, exists := c.recvSequence[key]
+	if exists {
+		gap := int32(sequence - last) //nolint:gosec
+		if gap <= 0 && gap > -sequenceResetWindow {
+			return false
+		}
+		if gap > 0 {
+			c.lost.Add(uint64(gap - 1))
+		}
+	}
+	c.recvSequence[key] = sequence
+	return true
+}
+
+// checkPacketNetwork 检查网络类型是否为支持的数据报网络。
+//
+// 参数：
+//   - network: 网络类型。
+//
+// 返回：
+//   - error: 字节流网络返回 ErrStreamNetwork，其它不支持的网络返回错误。
+func checkPacketNetwork(network string) error {
+	if isStreamNetwork(network) {
+		return cockroachdberrors.Wrapf(ErrStreamNetwork, "网络类型 %[1]s。", network)
+	}
+	if !isPacketNetwork(network) {
+		return cockroachdberrors.Newf("不支持的网络类型 %[1]s。", network)
+	}
+	return nil
+}
+
+// isStreamNetwork 返回网络类型是否为支持的字节流网络。
+//
+// unixpacket 虽然面向连接，但读取缓冲区不足时会截断数据包，不适用于按字节流拆分消息，因此不在支持范围内。
+//
+// 参数：
+//   - network: 网络类型。
+//
+// 返回：
+//   - bool: 网络类型为 tcp、tcp4、tcp6 或 unix 时返回 true。
+func isStreamNetwork(network string) bool {
+	switch network {
+	case "tcp", "tcp4", "tcp6", "unix":
+		return true
+	default:
+		return false
+	}
+}
+
+// isPacketNetwork 返回网络类型是否为支持的数据报网络。
+//
+// 参数：
+//   - network: 网络类型。
+//
+// 返回：
+//   - bool: 网络类型为 udp、udp4、udp6 或 unixgram 时返回 true。
+func isPacketNetwork(network string) bool {
+	switch network {
+	case "udp", "udp4", "udp6", "unixgram":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,234 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package message
+
+import (
+	"context"
+	"encoding/binary"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPackUnpackMessage 验证导出的封包与解包函数。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestPackUnpackMessage(t *testing.T) {
+	frame, err := PackMessage(NewSingleStringMessage("hello"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		description string
+		giveFrame   []byte
+		wantMessage string
+		wantErr     bool
+	}{
+		{name: "success/round-trip", description: "验证封包结果可以还原为原消息。", giveFrame: frame, wantMessage: "hello"},
+		{name: "error/short-header", description: "验证不足 4 字节的数据包会被拒绝。", giveFrame: frame[:3], wantErr: true},
+		{name: "error/truncated", description: "验证 payload 不完整的数据包会被拒绝。", giveFrame: frame[:len(frame)-1], wantErr: true},
+		{name: "error/trailing", description: "验证携带多余字节的数据包会被拒绝。", giveFrame: append(append([]byte(nil), frame...), 0), wantErr: true},
+		{name: "error/unknown-type", description: "验证未注册的消息类型会被拒绝。", giveFrame: []byte{0xff, 0xfe, 0x00, 0x00}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			message, err := UnpackMessage(tt.giveFrame)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, message)
+				return
+			}
+			require.NoError(t, err)
+			require.Implements(t, (*SingleStringMessage)(nil), message)
+			assert.Equal(t, tt.wantMessage, message.(SingleStringMessage).Message())
+		})
+	}
+}
+
+// TestDialUnix 验证 Dial 通过 unix 域套接字建立按本包协议收发消息的连接。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestDialUnix(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "message.sock")
+	listener, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	defer func() { _ = listener.Close() }()
+
+	accepted := make(chan net.Conn, 1)
+	go func() {
+		c, errAccept := listener.Accept()
+		if nil == errAccept {
+			accepted <- c
+		}
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client, err := Dial(ctx, "unix", path, 0)
+	require.NoError(t, err)
+	defer func() { _ = client.Close() }()
+	server := WrapConn(<-accepted, 0)
+	defer func() { _ = server.Close() }()
+	client.Start(ctx)
+	server.Start(ctx)
+
+	require.NoError(t, client.SendMessage(NewSingleStringMessage("ipc")))
+	select {
+	case message := <-server.Message():
+		require.Implements(t, (*SingleStringMessage)(nil), message)
+		assert.Equal(t, "ipc", message.(SingleStringMessage).Message())
+	case <-time.After(time.Second):
+		t.Fatal("等待消息超时。")
+	}
+}
+
+// TestTransportNetwork 验证字节流与数据报入口对网络类型的检查。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestTransportNetwork(t *testing.T) {
+	t.Run("error/dial-packet-network", func(t *testing.T) {
+		t.Log("验证 Dial 拒绝数据报网络并返回 ErrPacketNetwork。")
+
+		_, err := Dial(context.Background(), "udp", "127.0.0.1:1", 0)
+		assert.ErrorIs(t, err, ErrPacketNetwork)
+	})
+
+	t.Run("error/listen-stream-network", func(t *testing.T) {
+		t.Log("验证 ListenPacket 与 DialPacket 拒绝字节流网络并返回 ErrStreamNetwork。")
+
+		_, err := ListenPacket("tcp", "127.0.0.1:0")
+		assert.ErrorIs(t, err, ErrStreamNetwork)
+		_, err = DialPacket("unix", "/tmp/none.sock")
+		assert.ErrorIs(t, err, ErrStreamNetwork)
+	})
+
+	t.Run("error/unsupported", func(t *testing.T) {
+		t.Log("验证不支持的网络类型会被拒绝。")
+
+		_, err := Dial(context.Background(), "unixpacket", "/tmp/none.sock", 0)
+		assert.Error(t, err)
+		_, err = ListenPacket("ip4:icmp", "127.0.0.1")
+		assert.Error(t, err)
+	})
+}
+
+// TestPacketConn 验证数据报连接的收发、序列号与丢弃计数。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestPacketConn(t *testing.T) {
+	t.Run("success/udp", func(t *testing.T) {
+		t.Log("验证 DialPacket 与 ListenPacket 之间按数据报收发消息并能回复发送方。")
+
+		server, err := ListenPacket("udp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer func() { _ = server.Close() }()
+		client, err := DialPacket("udp", server.LocalAddr().String())
+		require.NoError(t, err)
+		defer func() { _ = client.Close() }()
+		require.NoError(t, server.SetReadDeadline(time.Now().Add(time.Second)))
+		require.NoError(t, client.SetReadDeadline(time.Now().Add(time.Second)))
+
+		require.NoError(t, client.WriteMessage(NewHeartbeatMessage(7)))
+		message, addr, err := server.ReadMessage()
+		require.NoError(t, err)
+		require.Implements(t, (*HeartbeatMessage)(nil), message)
+		assert.Equal(t, uint64(7), message.(HeartbeatMessage).SerialNumber())
+
+		require.NoError(t, server.WriteMessageTo(NewSingleStringMessage("pong"), addr))
+		message, _, err = client.ReadMessage()
+		require.NoError(t, err)
+		assert.Equal(t, "pong", message.(SingleStringMessage).Message())
+
+		assert.Equal(t, PacketStats{Sent: 1, Received: 1}, client.Stats())
+		assert.Equal(t, PacketStats{Sent: 1, Received: 1}, server.Stats())
+		assert.Error(t, server.WriteMessage(NewHeartbeatMessage(1)))
+	})
+
+	t.Run("success/sequence", func(t *testing.T) {
+		t.Log("验证启用序列号后统计丢包，丢弃重复、乱序与格式不合法的数据报，并识别对端重启。")
+
+		validator := NewFrameValidator()
+		server, err := ListenPacket("udp", "127.0.0.1:0", WithSequence(true), WithPacketFrameValidator(validator))
+		require.NoError(t, err)
+		defer func() { _ = server.Close() }()
+		raw, err := net.Dial("udp", server.LocalAddr().String())
+		require.NoError(t, err)
+		defer func() { _ = raw.Close() }()
+
+		frame, err := PackMessage(NewSingleStringMessage("m"))
+		require.NoError(t, err)
+		write := func(sequence uint32, frame []byte) {
+			packet := binary.BigEndian.AppendUint32(nil, sequence)
+			_, errWrite := raw.Write(append(packet, frame...))
+			require.NoError(t, errWrite)
+		}
+
+		write(5000, frame)
+		write(5003, frame)                          // 丢失 5001、5002。
+		write(5002, frame)                          // 乱序到达，丢弃。
+		write(5003, frame)                          // 重复，丢弃。
+		write(5004, []byte{0xff, 0xfe, 0x00, 0x00}) // 未知类型，丢弃。
+		_, err = raw.Write([]byte{0x00})            // 缺少序列号，丢弃。
+		require.NoError(t, err)
+		write(5005, frame)
+		write(0, frame) // 对端重启。
+
+		require.NoError(t, server.SetReadDeadline(time.Now().Add(time.Second)))
+		for range 4 {
+			_, _, err = server.ReadMessage()
+			require.NoError(t, err)
+		}
+		// 被丢弃的 5004 没有更新序列号，收到 5005 时同样计为丢失。
+		assert.Equal(t, PacketStats{Received: 4, Lost: 3, Late: 2, Invalid: 2}, server.Stats())
+		assert.Equal(t, uint64(1), validator.Rejects().UnknownType)
+	})
+
+	t.Run("success/unixgram", func(t *testing.T) {
+		t.Log("验证 unixgram 网络与序列号往返。")
+
+		dir := t.TempDir()
+		server, err := ListenPacket("unixgram", filepath.Join(dir, "server.sock"), WithSequence(true))
+		require.NoError(t, err)
+		defer func() { _ = server.Close() }()
+		client, err := ListenPacket("unixgram", filepath.Join(dir, "client.sock"), WithSequence(true))
+		require.NoError(t, err)
+		defer func() { _ = client.Close() }()
+
+		for i := range 3 {
+			require.NoError(t, client.WriteMessageTo(NewHeartbeatMessage(uint64(i)), server.LocalAddr()))
+		}
+		require.NoError(t, server.SetReadDeadline(time.Now().Add(time.Second)))
+		for i := range 3 {
+			message, addr, err := server.ReadMessage()
+			require.NoError(t, err)
+			assert.Equal(t, uint64(i), message.(HeartbeatMessage).SerialNumber())
+			assert.Equal(t, client.LocalAddr().String(), addr.String())
+		}
+		assert.Equal(t, PacketStats{Received: 3}, server.Stats())
+	})
+
+	t.Run("error/closed", func(t *testing.T) {
+		t.Log("验证关闭后 ReadMessage 返回错误。")
+
+		server, err := ListenPacket("udp", "127.0.0.1:0")
+		require.NoError(t, err)
+		require.NoError(t, server.Close())
+		_, _, err = server.ReadMessage()
+		assert.Error(t, err)
+	})
+}
@@ -10,8 +10,12 @@ type (
 	// 内置消息类型包括：
 	//   - HeartbeatMessageType: 心跳消息类型。
 	//   - SingleStringMessageType: 仅携带单个字符串 payload 的消息类型。
+	//   - HelloMessageType: 连接建立时交换本端能力的握手消息类型。
+	//   - CapabilitiesMessageType: 确认协商结果的能力消息类型。
+	//   - CloseMessageType: 协商关闭时发送的关闭意图消息类型。
+	//   - CloseAckMessageType: 确认对端关闭意图的消息类型。
 	//
-	// 调用方可通过 FactoryRegister 注册其它 uint16 值作为自定义消息类型。
+	// 0x80 至 0x8F 保留给协议控制消息使用。调用方可通过 FactoryRegister 注册其它 uint16 值作为自定义消息类型。
 	MessageType uint16
 )
 
@@ -20,9 +24,17 @@ const (
 	HeartbeatMessageType MessageType = 0x80
 	// SingleStringMessageType 表示仅携带单个字符串 payload 的消息类型。
 	SingleStringMessageType MessageType = 0x09
+	// HelloMessageType 表示连接建立时交换本端能力的握手消息类型。
+	HelloMessageType MessageType = 0x81
+	// CapabilitiesMessageType 表示确认协商结果的能力消息类型。
+	CapabilitiesMessageType MessageType = 0x82
+	// CloseMessageType 表示协商关闭时发送的关闭意图消息类型。
+	CloseMessageType MessageType = 0x83
+	// CloseAckMessageType 表示确认对端关闭意图的消息类型。
+	CloseAckMessageType MessageType = 0x84
 )
 
-// init 注册心跳消息和简单字符串消息的生成方法到默认工厂。
+// init 注册心跳消息、简单字符串消息、能力协商消息和关闭控制消息的生成方法到默认工厂。
 //
 // 参数：无。
 func init() {
@@ -32,4 +44,16 @@ func init() {
 	if err := FactoryRegister(SingleStringMessageType, GenerateSingleStringMessage); nil != err {
 		panic(err)
 	}
+	if err := FactoryRegister(HelloMessageType, GenerateHelloMessage); nil != err {
+		panic(err)
+	}
+	if err := FactoryRegister(CapabilitiesMessageType, GenerateHelloMessage); nil != err {
+		panic(err)
+	}
+	if err := FactoryRegister(CloseMessageType, GenerateCloseMessage); nil != err {
+		panic(err)
+	}
+	if err := FactoryRegister(CloseAckMessageType, GenerateCloseMessage); nil != err {
+		panic(err)
+	}
 }
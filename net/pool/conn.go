@@ -0,0 +1,75 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package pool
+
+import (
+	"io"
+	"sync/atomic"
+)
+
+type (
+	// PooledConn 是从连接池借出的连接。
+	//
+	// 使用完毕后必须调用 Release 归还或调用 Discard 丢弃，二者只有首次调用生效。
+	// 归还后不得继续使用 Conn 返回的底层连接。
+	PooledConn[C io.Closer] struct {
+		pool     *Pool[C]    // 所属连接池。
+		entry    *entry[C]   // 连接及其生命周期信息。
+		returned atomic.Bool // 是否已归还或丢弃。
+	}
+)
+
+// newPooledConn 创建借出的连接。
+//
+// 参数：
+//   - p: 所属连接池。
+//   - e: 借出的连接。
+//
+// 返回：
+//   - *PooledConn[C]: 借出的连接。
+func newPooledConn[C io.Closer](p *Pool[C], e *entry[C]) *PooledConn[C] {
+	return &PooledConn[C]{pool: p, entry: e}
+}
+
+// Conn 返回底层连接。
+//
+// 参数：无。
+//
+// 返回：
+//   - C: 底层连接。
+func (c *PooledConn[C]) Conn() C {
+	return c.entry.conn
+}
+
+// Release 将连接归还连接池。
+//
+// 底层连接实现了 Closed() bool 且已关闭时按 Discard 处理。
+//
+// 参数：无。
+func (c *PooledConn[C]) Release() {
+	if !c.returned.CompareAndSwap(false, true) {
+		return
+	}
+	if checker, ok := any(c.entry.conn).(closedChecker); ok && checker.Closed() {
+		c.pool.discard(c.entry, nil)
+		return
+	}
+	c.pool.put(c.entry)
+}
+
+// Discard 关闭底层连接并释放其在连接池中占用的名额。
+//
+// 连接出现读写错误或协议状态不可恢复时应调用 Discard 而不是 Release。
+//
+// 参数：无。
+//
+// 返回：
+//   - error: 关闭底层连接时的错误；重复调用返回 nil。
+func (c *PooledConn[C]) Discard() error {
+	if !c.returned.CompareAndSwap(false, true) {
+		return nil
+	}
+	return c.pool.discard(c.entry, nil)
+}
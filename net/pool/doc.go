@@ -0,0 +1,15 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+// Package pool 提供泛型的客户端连接池，适用于 net/message 客户端及其他基于原始 TCP 的协议。
+//
+// New 接受一个 DialFunc 创建 Pool，连接类型可以是任意实现 io.Closer 的类型，例如 net.Conn
+// 或 net/message 的 Conn。WithMaxIdle 与 WithMaxActive 限制空闲与打开的连接数，达到上限时
+// Get 按到达顺序等待其他连接归还或关闭，直到传入的上下文结束。
+// WithIdleTimeout 与 WithMaxLifetime 配置连接过期规则，过期连接在借出时或由后台回收任务关闭；
+// WithHealthCheck 在借出空闲连接前执行健康检查，实现了 Closed() bool 的连接在已关闭时总会被丢弃。
+//
+// Get 返回的 PooledConn 使用完毕后必须调用 Release 归还或 Discard 丢弃；
+// Stats 返回打开、空闲、借出、等待的连接数以及拨号、复用、等待、回收等累计计数。
+package pool
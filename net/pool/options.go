@@ -0,0 +1,176 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package pool
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+type (
+	// HealthCheckFunc 定义借出空闲连接前执行的健康检查函数。
+	//
+	// 参数：
+	//   - ctx: 调用 Get 时传入的上下文。
+	//   - conn: 待检查的底层连接，可按实际类型断言后使用。
+	//
+	// 返回：
+	//   - error: 连接不可用时返回错误，连接池会关闭该连接并继续获取。
+	HealthCheckFunc func(ctx context.Context, conn io.Closer) error
+
+	// Option 定义修改连接池配置的函数。
+	//
+	// Option 由 [New] 按传入顺序执行，后传入的配置可覆盖先前写入的同一字段。
+	//
+	// 参数：
+	//   - o: 待修改的连接池配置。
+	Option func(o *options)
+
+	// options 保存连接池的配置。
+	options struct {
+		maxIdle          int             // 最大空闲连接数。
+		maxActive        int             // 最大打开连接数，0 表示不限制。
+		idleTimeout      time.Duration   // 空闲超时时长，0 表示不因空闲关闭。
+		maxLifetime      time.Duration   // 连接最大存活时长，0 表示不限制。
+		reapInterval     time.Duration   // 后台回收空闲连接的间隔，0 表示按超时配置推导。
+		healthCheck      HealthCheckFunc // 借出前的健康检查函数。
+		healthCheckAfter time.Duration   // 空闲超过该时长的连接才执行健康检查。
+	}
+)
+
+// 以下为连接池的默认参数配置。
+// 可通过 Option 机制覆盖。
+var (
+	// maxIdleDefault 为最大空闲连接数默认值。
+	maxIdleDefault = 2
+	// minReapIntervalDefault 为推导回收间隔时的下限。
+	minReapIntervalDefault = 10 * time.Millisecond
+)
+
+// WithMaxIdle 设置连接池保留的最大空闲连接数。
+//
+// 归还连接时若空闲连接数已达上限，该连接会被直接关闭。
+//
+// 参数：
+//   - n: 最大空闲连接数；小于 0 时按 0 处理，即不保留空闲连接。
+//
+// 返回：
+//   - Option: 应用于 [New] 的配置项。
+func WithMaxIdle(n int) Option {
+	return func(o *options) {
+		if n < 0 {
+			n = 0
+		}
+		o.maxIdle = n
+	}
+}
+
+// WithMaxActive 设置连接池同时打开的最大连接数，包括空闲、借出与正在拨号的连接。
+//
+// 达到上限后 Get 会等待其他连接归还或关闭，直到传入的上下文结束。
+//
+// 参数：
+//   - n: 最大打开连接数；小于等于 0 表示不限制。
+//
+// 返回：
+//   - Option: 应用于 [New] 的配置项。
+func WithMaxActive(n int) Option {
+	return func(o *options) {
+		if n < 0 {
+			n = 0
+		}
+		o.maxActive = n
+	}
+}
+
+// WithIdleTimeout 设置空闲连接的超时时长。
+//
+// 空闲超过该时长的连接会在借出时或由后台回收任务关闭。
+//
+// 参数：
+//   - d: 空闲超时时长；小于等于 0 表示不因空闲关闭。
+//
+// 返回：
+//   - Option: 应用于 [New] 的配置项。
+func WithIdleTimeout(d time.Duration) Option {
+	return func(o *options) {
+		o.idleTimeout = d
+	}
+}
+
+// WithMaxLifetime 设置连接自拨号成功起的最大存活时长。
+//
+// 超过该时长的连接在借出、归还时或由后台回收任务关闭，已借出的连接不会被强制关闭。
+//
+// 参数：
+//   - d: 最大存活时长；小于等于 0 表示不限制。
+//
+// 返回：
+//   - Option: 应用于 [New] 的配置项。
+func WithMaxLifetime(d time.Duration) Option {
+	return func(o *options) {
+		o.maxLifetime = d
+	}
+}
+
+// WithReapInterval 设置后台回收空闲连接的执行间隔。
+//
+// 未设置时取空闲超时与最大存活时长中较小值的一半；两者都未设置时不启动后台回收。
+//
+// 参数：
+//   - d: 回收间隔；小于等于 0 表示按超时配置推导。
+//
+// 返回：
+//   - Option: 应用于 [New] 的配置项。
+func WithReapInterval(d time.Duration) Option {
+	return func(o *options) {
+		o.reapInterval = d
+	}
+}
+
+// WithHealthCheck 设置借出空闲连接前执行的健康检查。
+//
+// 实现了 Closed() bool 的连接（例如 net/message 的 Conn）在已关闭时总会被丢弃，
+// 与是否配置健康检查无关。
+//
+// 参数：
+//   - fn: 健康检查函数；为 nil 时不执行。
+//   - idleAfter: 仅对空闲时长不小于该值的连接执行检查；小于等于 0 表示每次借出都检查。
+//
+// 返回：
+//   - Option: 应用于 [New] 的配置项。
+func WithHealthCheck(fn HealthCheckFunc, idleAfter time.Duration) Option {
+	return func(o *options) {
+		o.healthCheck = fn
+		o.healthCheckAfter = idleAfter
+	}
+}
+
+// interval 计算后台回收任务的执行间隔。
+//
+// 参数：无。
+//
+// 返回：
+//   - time.Duration: 回收间隔；为 0 表示无需启动后台回收。
+func (o *options) interval() time.Duration {
+	if o.reapInterval > 0 {
+		return o.reapInterval
+	}
+
+	var shortest time.Duration
+	for _, d := range []time.Duration{o.idleTimeout, o.maxLifetime} {
+		if d > 0 && (0 == shortest || d < shortest) {
+			shortest = d
+		}
+	}
+	if 0 == shortest {
+		return 0
+	}
+	if shortest /= 2; shortest < minReapIntervalDefault {
+		shortest = minReapIntervalDefault
+	}
+	return shortest
+}
@@ -0,0 +1,496 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package pool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrPoolClosed 表示连接池已关闭。
+	ErrPoolClosed = errors.New("pool: closed")
+	// ErrNilDialFunc 表示创建连接池时未提供拨号函数。
+	ErrNilDialFunc = errors.New("pool: nil dial func")
+)
+
+type (
+	// DialFunc 定义建立新连接的拨号函数。
+	//
+	// 参数：
+	//   - ctx: 调用 Get 时传入的上下文，拨号应在其取消或超时后尽快返回。
+	//
+	// 返回：
+	//   - C: 新建立的连接。
+	//   - error: 拨号失败时返回错误。
+	DialFunc[C io.Closer] func(ctx context.Context) (C, error)
+
+	// Stats 描述连接池在某一时刻的状态与累计计数。
+	Stats struct {
+		Active              int           // 当前打开的连接数，包括空闲、借出与正在拨号的连接。
+		Idle                int           // 当前空闲连接数。
+		InUse               int           // 当前借出或正在拨号的连接数。
+		Waiting             int           // 当前等待可用连接的调用数。
+		Dials               uint64        // 累计拨号成功次数。
+		DialErrors          uint64        // 累计拨号失败次数。
+		Hits                uint64        // 累计复用空闲连接的次数。
+		WaitCount           uint64        // 累计因达到最大连接数而等待的次数。
+		WaitDuration        time.Duration // 累计等待时长。
+		WaitTimeouts        uint64        // 累计因上下文结束而放弃等待的次数。
+		HealthCheckFailures uint64        // 累计健康检查失败而关闭的连接数。
+		IdleClosed          uint64        // 累计因空闲超时或超出最大空闲数而关闭的连接数。
+		LifetimeClosed      uint64        // 累计因超过最大存活时长而关闭的连接数。
+	}
+
+	// Pool 是泛型的客户端连接池。
+	//
+	// Pool 可以管理任意实现 io.Closer 的连接，例如 net.Conn 或 net/message 的 Conn。
+	// Pool 的所有方法都是并发安全的。
+	Pool[C io.Closer] struct {
+		dial DialFunc[C] // 拨号函数。
+		opts options     // 连接池配置。
+
+		mu      sync.Mutex           // 保护以下字段。
+		idle    []*entry[C]          // 空闲连接，末尾为最近归还的连接。
+		active  int                  // 当前打开的连接数，包括正在拨号的连接。
+		waiters []chan waitResult[C] // 按到达顺序排列的等待者。
+		closed  bool                 // 连接池是否已关闭。
+		stats   Stats                // 累计计数，Active、Idle、InUse 与 Waiting 在读取时计算。
+
+		stop chan struct{}  // 通知后台回收任务退出。
+		done sync.WaitGroup // 等待后台回收任务退出。
+	}
+
+	// entry 保存连接及其生命周期信息。
+	entry[C io.Closer] struct {
+		conn      C         // 底层连接。
+		createdAt time.Time // 拨号成功的时间。
+		idleAt    time.Time // 最近一次归还的时间。
+	}
+
+	// waitResult 是交给等待者的结果。
+	//
+	// entry 非 nil 时表示直接移交的连接；entry 为 nil 且 closed 为 false 时表示移交了一个拨号名额；
+	// closed 为 true 时表示连接池已关闭。
+	waitResult[C io.Closer] struct {
+		entry  *entry[C] // 移交的连接。
+		closed bool      // 连接池是否已关闭。
+	}
+
+	// closedChecker 是可以报告自身是否已关闭的连接，例如 net/message 的 Conn。
+	closedChecker interface {
+		Closed() bool
+	}
+)
+
+// New 创建连接池。
+//
+// 配置了空闲超时或最大存活时长时，会启动后台回收任务，调用方应在不再使用时调用 Close。
+//
+// 参数：
+//   - dial: 建立新连接的拨号函数，不能为 nil。
+//   - opts: 连接池配置项。
+//
+// 返回：
+//   - *Pool[C]: 新创建的连接池。
+//   - error: dial 为 nil 时返回 ErrNilDialFunc。
+func New[C io.Closer](dial DialFunc[C], opts ...Option) (*Pool[C], error) {
+	if nil == dial {
+		return nil, ErrNilDialFunc
+	}
+
+	p := &Pool[C]{
+		dial: dial,
+		opts: options{maxIdle: maxIdleDefault},
+		stop: make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(&p.opts)
+	}
+
+	if interval := p.opts.interval(); interval > 0 {
+		p.done.Add(1)
+		go p.reaper(interval)
+	}
+
+	return p, nil
+}
+
+// Get 从连接池获取一个连接。
+//
+// 优先复用最近归还的空闲连接，复用前会丢弃已过期、已关闭或健康检查失败的连接；
+// 没有空闲连接且未达到最大连接数时拨号新建；达到最大连接数时等待其他连接归还或关闭，
+// 直到 ctx 结束。
+//
+// 参数：
+//   - ctx: 控制等待与拨号的上下文。
+//
+// 返回：
+//   - *PooledConn[C]: 借出的连接，使用完毕后必须调用 Release 或 Discard。
+//   - error: 连接池已关闭时返回 ErrPoolClosed，等待超时返回 ctx.Err()，拨号失败返回包装后的拨号错误。
+func (p *Pool[C]) Get(ctx context.Context) (*PooledConn[C], error) {
+	for {
+		p.mu.Lock()
+		if p.closed {
+			p.mu.Unlock()
+			return nil, ErrPoolClosed
+		}
+
+		if n := len(p.idle); n > 0 {
+			e := p.idle[n-1]
+			p.idle[n-1] = nil
+			p.idle = p.idle[:n-1]
+			p.mu.Unlock()
+
+			if p.usable(ctx, e) {
+				p.mu.Lock()
+				p.stats.Hits++
+				p.mu.Unlock()
+				return newPooledConn(p, e), nil
+			}
+			continue
+		}
+
+		if p.opts.maxActive <= 0 || p.active < p.opts.maxActive {
+			p.active++
+			p.mu.Unlock()
+			return p.dialConn(ctx)
+		}
+
+		wait := make(chan waitResult[C], 1)
+		p.waiters = append(p.waiters, wait)
+		p.stats.WaitCount++
+		p.mu.Unlock()
+
+		start := time.Now()
+		select {
+		case result := <-wait:
+			p.addWaitDuration(time.Since(start))
+			if result.closed {
+				return nil, ErrPoolClosed
+			}
+			if nil == result.entry {
+				p.mu.Lock()
+				closed := p.closed
+				p.mu.Unlock()
+				if closed {
+					p.releaseSlot()
+					return nil, ErrPoolClosed
+				}
+				return p.dialConn(ctx)
+			}
+			return newPooledConn(p, result.entry), nil
+		case <-ctx.Done():
+			p.mu.Lock()
+			removed := p.removeWaiter(wait)
+			p.stats.WaitDuration += time.Since(start)
+			p.stats.WaitTimeouts++
+			p.mu.Unlock()
+
+			if !removed {
+				// 结果已经或即将移交给当前调用，需要转交给下一位等待者或归还连接池。
+				result := <-wait
+				switch {
+				case result.closed:
+				case nil == result.entry:
+					p.releaseSlot()
+				default:
+					p.put(result.entry)
+				}
+			}
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// Stats 返回连接池当前的状态与累计计数。
+//
+// 参数：无。
+//
+// 返回：
+//   - Stats: 连接池状态快照。
+func (p *Pool[C]) Stats() Stats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	stats := p.stats
+	stats.Active = p.active
+	stats.Idle = len(p.idle)
+	stats.InUse = p.active - len(p.idle)
+	stats.Waiting = len(p.waiters)
+	return stats
+}
+
+// Close 关闭连接池。
+//
+// 关闭后空闲连接立即关闭，等待中的 Get 返回 ErrPoolClosed，
+// 已借出的连接在 Release 或 Discard 时关闭。重复调用返回 nil。
+//
+// 参数：无。
+//
+// 返回：
+//   - error: 关闭空闲连接时产生的错误。
+func (p *Pool[C]) Close() error {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return nil
+	}
+	p.closed = true
+	idle := p.idle
+	p.idle = nil
+	p.active -= len(idle)
+	waiters := p.waiters
+	p.waiters = nil
+	p.mu.Unlock()
+
+	close(p.stop)
+	p.done.Wait()
+
+	for _, wait := range waiters {
+		wait <- waitResult[C]{closed: true}
+	}
+
+	var errs []error
+	for _, e := range idle {
+		if err := e.conn.Close(); nil != err {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// dialConn 使用已占用的名额拨号新建连接。
+//
+// 参数：
+//   - ctx: 控制拨号的上下文。
+//
+// 返回：
+//   - *PooledConn[C]: 新建的连接。
+//   - error: 拨号失败时返回包装后的错误，并释放占用的名额。
+func (p *Pool[C]) dialConn(ctx context.Context) (*PooledConn[C], error) {
+	conn, err := p.dial(ctx)
+	if nil != err {
+		p.mu.Lock()
+		p.stats.DialErrors++
+		p.mu.Unlock()
+		p.releaseSlot()
+		return nil, fmt.Errorf("pool: dial: %w", err)
+	}
+
+	p.mu.Lock()
+	p.stats.Dials++
+	p.mu.Unlock()
+
+	now := time.Now()
+	return newPooledConn(p, &entry[C]{conn: conn, createdAt: now, idleAt: now}), nil
+}
+
+// usable 检查空闲连接是否可以借出，不可用时关闭连接并释放名额。
+//
+// 参数：
+//   - ctx: 调用 Get 时传入的上下文。
+//   - e: 待检查的空闲连接。
+//
+// 返回：
+//   - bool: 连接可以借出时返回 true。
+func (p *Pool[C]) usable(ctx context.Context, e *entry[C]) bool {
+	now := time.Now()
+	if p.expired(e, now) {
+		return false
+	}
+	if checker, ok := any(e.conn).(closedChecker); ok && checker.Closed() {
+		p.discard(e, nil)
+		return false
+	}
+	if nil != p.opts.healthCheck && now.Sub(e.idleAt) >= p.opts.healthCheckAfter {
+		if err := p.opts.healthCheck(ctx, e.conn); nil != err {
+			p.discard(e, &p.stats.HealthCheckFailures)
+			return false
+		}
+	}
+	return true
+}
+
+// expired 检查连接是否超过空闲超时或最大存活时长，超过时关闭连接并释放名额。
+//
+// 参数：
+//   - e: 待检查的连接。
+//   - now: 当前时间。
+//
+// 返回：
+//   - bool: 连接已过期并被关闭时返回 true。
+func (p *Pool[C]) expired(e *entry[C], now time.Time) bool {
+	if p.opts.maxLifetime > 0 && now.Sub(e.createdAt) >= p.opts.maxLifetime {
+		p.discard(e, &p.stats.LifetimeClosed)
+		return true
+	}
+	if p.opts.idleTimeout > 0 && now.Sub(e.idleAt) >= p.opts.idleTimeout {
+		p.discard(e, &p.stats.IdleClosed)
+		return true
+	}
+	return false
+}
+
+// put 将连接归还连接池。
+//
+// 有等待者时直接移交；连接池已关闭、连接已过期或空闲连接数已达上限时关闭连接。
+//
+// 参数：
+//   - e: 归还的连接。
+func (p *Pool[C]) put(e *entry[C]) {
+	now := time.Now()
+	if p.opts.maxLifetime > 0 && now.Sub(e.createdAt) >= p.opts.maxLifetime {
+		p.discard(e, &p.stats.LifetimeClosed)
+		return
+	}
+
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		p.discard(e, nil)
+		return
+	}
+	if len(p.waiters) > 0 {
+		wait := p.popWaiter()
+		p.mu.Unlock()
+		wait <- waitResult[C]{entry: e}
+		return
+	}
+	if len(p.idle) >= p.opts.maxIdle {
+		p.mu.Unlock()
+		p.discard(e, &p.stats.IdleClosed)
+		return
+	}
+	e.idleAt = now
+	p.idle = append(p.idle, e)
+	p.mu.Unlock()
+}
+
+// discard 关闭连接并释放其占用的名额。
+//
+// 参数：
+//   - e: 待关闭的连接。
+//   - counter: 需要递增的累计计数，可以为 nil。
+//
+// 返回：
+//   - error: 关闭底层连接时的错误。
+func (p *Pool[C]) discard(e *entry[C], counter *uint64) error {
+	err := e.conn.Close()
+
+	if nil != counter {
+		p.mu.Lock()
+		*counter++
+		p.mu.Unlock()
+	}
+	p.releaseSlot()
+	return err
+}
+
+// releaseSlot 释放一个连接名额；有等待者时把名额移交给最早的等待者。
+//
+// 参数：无。
+func (p *Pool[C]) releaseSlot() {
+	p.mu.Lock()
+	if !p.closed && len(p.waiters) > 0 {
+		wait := p.popWaiter()
+		p.mu.Unlock()
+		wait <- waitResult[C]{}
+		return
+	}
+	p.active--
+	p.mu.Unlock()
+}
+
+// popWaiter 取出最早的等待者，调用方必须持有 p.mu。
+//
+// 参数：无。
+//
+// 返回：
+//   - chan waitResult[C]: 最早的等待者。
+func (p *Pool[C]) popWaiter() chan waitResult[C] {
+	wait := p.waiters[0]
+	p.waiters[0] = nil
+	p.waiters = p.waiters[1:]
+	return wait
+}
+
+// removeWaiter 从等待队列中移除指定等待者，调用方必须持有 p.mu。
+//
+// 参数：
+//   - wait: 待移除的等待者。
+//
+// 返回：
+//   - bool: 等待者仍在队列中并被移除时返回 true；已被取出时返回 false。
+func (p *Pool[C]) removeWaiter(wait chan waitResult[C]) bool {
+	for i, w := range p.waiters {
+		if w == wait {
+			p.waiters = append(p.waiters[:i], p.waiters[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// addWaitDuration 累加等待时长。
+//
+// 参数：
+//   - d: 本次等待时长。
+func (p *Pool[C]) addWaitDuration(d time.Duration) {
+	p.mu.Lock()
+	p.stats.WaitDuration += d
+	p.mu.Unlock()
+}
+
+// reaper 按固定间隔回收过期的空闲连接，直到连接池关闭。
+//
+// 参数：
+//   - interval: 回收间隔。
+func (p *Pool[C]) reaper(interval time.Duration) {
+	defer p.done.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+			p.reap(time.Now())
+		}
+	}
+}
+
+// reap 关闭所有已过期的空闲连接。
+//
+// 参数：
+//   - now: 判断过期使用的当前时间。
+func (p *Pool[C]) reap(now time.Time) {
+	p.mu.Lock()
+	var stale []*entry[C]
+	kept := p.idle[:0]
+	for _, e := range p.idle {
+		if (p.opts.maxLifetime > 0 && now.Sub(e.createdAt) >= p.opts.maxLifetime) ||
+			(p.opts.idleTimeout > 0 && now.Sub(e.idleAt) >= p.opts.idleTimeout) {
+			stale = append(stale, e)
+			continue
+		}
+		kept = append(kept, e)
+	}
+	for i := len(kept); i < len(p.idle); i++ {
+		p.idle[i] = nil
+	}
+	p.idle = kept
+	p.mu.Unlock()
+
+	for _, e := range stale {
+		p.expired(e, now)
+	}
+}
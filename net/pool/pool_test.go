@@ -0,0 +1,545 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package pool
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	kitmessage "github.com/fsyyft-go/kit/net/message"
+)
+
+type (
+	// fakeConn 是记录关闭状态的测试连接。
+	fakeConn struct {
+		id     int64       // 拨号序号。
+		closed atomic.Bool // 是否已关闭。
+	}
+
+	// fakeDialer 是生成 fakeConn 的测试拨号器。
+	fakeDialer struct {
+		mu    sync.Mutex  // 保护 conns。
+		next  int64       // 下一个拨号序号。
+		err   error       // 非 nil 时拨号失败。
+		conns []*fakeConn // 已拨号的连接。
+	}
+)
+
+// Close 标记连接已关闭。
+//
+// 参数：无。
+//
+// 返回：
+//   - error: 始终为 nil。
+func (c *fakeConn) Close() error {
+	c.closed.Store(true)
+	return nil
+}
+
+// Closed 返回连接是否已关闭。
+//
+// 参数：无。
+//
+// 返回：
+//   - bool: 连接已关闭时返回 true。
+func (c *fakeConn) Closed() bool {
+	return c.closed.Load()
+}
+
+// dial 创建新的 fakeConn。
+//
+// 参数：
+//   - ctx: 拨号上下文。
+//
+// 返回：
+//   - *fakeConn: 新建的连接。
+//   - error: 配置了拨号错误时返回该错误。
+func (d *fakeDialer) dial(ctx context.Context) (*fakeConn, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if nil != d.err {
+		return nil, d.err
+	}
+	d.next++
+	conn := &fakeConn{id: d.next}
+	d.conns = append(d.conns, conn)
+	return conn, nil
+}
+
+// TestNew 验证连接池的创建与参数校验。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		giveDial    DialFunc[*fakeConn]
+		giveOpts    []Option
+		wantErr     error
+		wantMaxIdle int
+		wantReap    time.Duration
+	}{
+		{
+			name:        "success/defaults",
+			description: "验证默认配置保留 2 个空闲连接且不启动后台回收。",
+			giveDial:    (&fakeDialer{}).dial,
+			wantMaxIdle: 2,
+		},
+		{
+			name:        "success/derived-reap-interval",
+			description: "验证回收间隔取空闲超时与最大存活时长中较小值的一半。",
+			giveDial:    (&fakeDialer{}).dial,
+			giveOpts:    []Option{WithMaxIdle(5), WithIdleTimeout(time.Minute), WithMaxLifetime(time.Hour)},
+			wantMaxIdle: 5,
+			wantReap:    30 * time.Second,
+		},
+		{
+			name:        "success/explicit-reap-interval",
+			description: "验证显式设置的回收间隔优先。",
+			giveDial:    (&fakeDialer{}).dial,
+			giveOpts:    []Option{WithIdleTimeout(time.Minute), WithReapInterval(time.Second)},
+			wantMaxIdle: 2,
+			wantReap:    time.Second,
+		},
+		{
+			name:        "boundary/min-reap-interval",
+			description: "验证推导出的回收间隔不低于下限，负数空闲数按 0 处理。",
+			giveDial:    (&fakeDialer{}).dial,
+			giveOpts:    []Option{WithMaxIdle(-1), WithMaxLifetime(time.Millisecond)},
+			wantMaxIdle: 0,
+			wantReap:    minReapIntervalDefault,
+		},
+		{
+			name:        "error/nil-dial",
+			description: "验证拨号函数为 nil 时返回 ErrNilDialFunc。",
+			wantErr:     ErrNilDialFunc,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			p, err := New(tt.giveDial, tt.giveOpts...)
+			if nil != tt.wantErr {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, p)
+				return
+			}
+			require.NoError(t, err)
+			defer func() { assert.NoError(t, p.Close()) }()
+
+			assert.Equal(t, tt.wantMaxIdle, p.opts.maxIdle)
+			assert.Equal(t, tt.wantReap, p.opts.interval())
+		})
+	}
+}
+
+// TestPool_GetRelease 验证借出、归还、复用与丢弃连接时的计数。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestPool_GetRelease(t *testing.T) {
+	dialer := &fakeDialer{}
+	p, err := New(dialer.dial, WithMaxIdle(1))
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, p.Close()) }()
+
+	ctx := context.Background()
+	first, err := p.Get(ctx)
+	require.NoError(t, err)
+	second, err := p.Get(ctx)
+	require.NoError(t, err)
+	assert.NotSame(t, first.Conn(), second.Conn())
+	assert.Equal(t, Stats{Active: 2, InUse: 2, Dials: 2}, p.Stats())
+
+	first.Release()
+	first.Release()
+	second.Release()
+	assert.False(t, first.Conn().Closed())
+	assert.True(t, second.Conn().Closed(), "超过最大空闲数的连接应被关闭")
+	assert.Equal(t, Stats{Active: 1, Idle: 1, Dials: 2, IdleClosed: 1}, p.Stats())
+
+	reused, err := p.Get(ctx)
+	require.NoError(t, err)
+	assert.Same(t, first.Conn(), reused.Conn())
+	require.NoError(t, reused.Discard())
+	require.NoError(t, reused.Discard())
+	reused.Release()
+	assert.True(t, reused.Conn().Closed())
+	assert.Equal(t, Stats{Dials: 2, Hits: 1, IdleClosed: 1}, p.Stats())
+}
+
+// TestPool_GetDiscardsUnusable 验证借出前丢弃已关闭、已过期或健康检查失败的空闲连接。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestPool_GetDiscardsUnusable(t *testing.T) {
+	unhealthy := errors.New("unhealthy")
+
+	tests := []struct {
+		name        string
+		description string
+		giveOpts    []Option
+		giveBefore  func(conn *fakeConn)
+		wantStats   Stats
+	}{
+		{
+			name:        "success/reuse",
+			description: "验证可用的空闲连接被复用。",
+			wantStats:   Stats{Active: 1, InUse: 1, Dials: 1, Hits: 1},
+		},
+		{
+			name:        "error/closed",
+			description: "验证空闲期间已关闭的连接被丢弃并重新拨号。",
+			giveBefore:  func(conn *fakeConn) { _ = conn.Close() },
+			wantStats:   Stats{Active: 1, InUse: 1, Dials: 2},
+		},
+		{
+			name:        "error/health-check",
+			description: "验证健康检查失败的连接被丢弃并计数。",
+			giveOpts: []Option{WithHealthCheck(func(ctx context.Context, conn io.Closer) error {
+				if 1 == conn.(*fakeConn).id {
+					return unhealthy
+				}
+				return nil
+			}, 0)},
+			wantStats: Stats{Active: 1, InUse: 1, Dials: 2, HealthCheckFailures: 1},
+		},
+		{
+			name:        "boundary/health-check-skipped",
+			description: "验证空闲时间未达到阈值的连接跳过健康检查。",
+			giveOpts: []Option{WithHealthCheck(func(ctx context.Context, conn io.Closer) error {
+				return unhealthy
+			}, time.Hour)},
+			wantStats: Stats{Active: 1, InUse: 1, Dials: 1, Hits: 1},
+		},
+		{
+			name:        "error/idle-timeout",
+			description: "验证空闲超时的连接在借出时被关闭。",
+			giveOpts:    []Option{WithIdleTimeout(time.Millisecond), WithReapInterval(time.Hour)},
+			giveBefore:  func(*fakeConn) { time.Sleep(5 * time.Millisecond) },
+			wantStats:   Stats{Active: 1, InUse: 1, Dials: 2, IdleClosed: 1},
+		},
+		{
+			name:        "error/max-lifetime",
+			description: "验证超过最大存活时长的连接在借出时被关闭。",
+			giveOpts:    []Option{WithMaxLifetime(time.Millisecond), WithReapInterval(time.Hour)},
+			giveBefore:  func(*fakeConn) { time.Sleep(5 * time.Millisecond) },
+			wantStats:   Stats{Active: 1, InUse: 1, Dials: 2, LifetimeClosed: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			dialer := &fakeDialer{}
+			p, err := New(dialer.dial, tt.giveOpts...)
+			require.NoError(t, err)
+			defer func() { assert.NoError(t, p.Close()) }()
+
+			conn, err := p.Get(context.Background())
+			require.NoError(t, err)
+			conn.Release()
+			if nil != tt.giveBefore {
+				tt.giveBefore(conn.Conn())
+			}
+
+			got, err := p.Get(context.Background())
+			require.NoError(t, err)
+			assert.False(t, got.Conn().Closed())
+			assert.Equal(t, tt.wantStats, p.Stats())
+			got.Release()
+		})
+	}
+}
+
+// TestPool_DialError 验证拨号失败时返回包装后的错误并释放名额。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestPool_DialError(t *testing.T) {
+	refused := errors.New("refused")
+	dialer := &fakeDialer{err: refused}
+	p, err := New(dialer.dial, WithMaxActive(1))
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, p.Close()) }()
+
+	_, err = p.Get(context.Background())
+	assert.ErrorIs(t, err, refused)
+	assert.Equal(t, Stats{DialErrors: 1}, p.Stats())
+
+	dialer.mu.Lock()
+	dialer.err = nil
+	dialer.mu.Unlock()
+	conn, err := p.Get(context.Background())
+	require.NoError(t, err, "拨号失败后名额应已释放")
+	conn.Release()
+}
+
+// TestPool_WaitWithContext 验证达到最大连接数后等待归还、名额移交与上下文超时。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestPool_WaitWithContext(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		giveReturn  func(conn *PooledConn[*fakeConn])
+		wantSame    bool
+		wantDials   uint64
+	}{
+		{
+			name:        "success/handoff-conn",
+			description: "验证归还的连接直接移交给等待者。",
+			giveReturn:  func(conn *PooledConn[*fakeConn]) { conn.Release() },
+			wantSame:    true,
+			wantDials:   1,
+		},
+		{
+			name:        "success/handoff-slot",
+			description: "验证丢弃连接后名额移交给等待者重新拨号。",
+			giveReturn:  func(conn *PooledConn[*fakeConn]) { _ = conn.Discard() },
+			wantDials:   2,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			dialer := &fakeDialer{}
+			p, err := New(dialer.dial, WithMaxActive(1))
+			require.NoError(t, err)
+			defer func() { assert.NoError(t, p.Close()) }()
+
+			held, err := p.Get(context.Background())
+			require.NoError(t, err)
+
+			type result struct {
+				conn *PooledConn[*fakeConn]
+				err  error
+			}
+			results := make(chan result, 1)
+			go func() {
+				conn, err := p.Get(context.Background())
+				results <- result{conn: conn, err: err}
+			}()
+			require.Eventually(t, func() bool { return 1 == p.Stats().Waiting }, time.Second, time.Millisecond)
+
+			tt.giveReturn(held)
+			got := <-results
+			require.NoError(t, got.err)
+			assert.Equal(t, tt.wantSame, held.Conn() == got.conn.Conn())
+
+			stats := p.Stats()
+			assert.Equal(t, 1, stats.Active)
+			assert.Equal(t, uint64(1), stats.WaitCount)
+			assert.Equal(t, tt.wantDials, stats.Dials)
+			assert.Positive(t, stats.WaitDuration)
+			got.conn.Release()
+		})
+	}
+
+	// 等待超时。
+	p, err := New((&fakeDialer{}).dial, WithMaxActive(1))
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, p.Close()) }()
+	held, err := p.Get(context.Background())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = p.Get(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	stats := p.Stats()
+	assert.Equal(t, uint64(1), stats.WaitTimeouts)
+	assert.Zero(t, stats.Waiting)
+	held.Release()
+	assert.Equal(t, 1, p.Stats().Idle, "无等待者时连接应进入空闲列表")
+}
+
+// TestPool_Close 验证关闭连接池后的行为。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestPool_Close(t *testing.T) {
+	dialer := &fakeDialer{}
+	p, err := New(dialer.dial, WithMaxActive(2))
+	require.NoError(t, err)
+
+	idle, err := p.Get(context.Background())
+	require.NoError(t, err)
+	held, err := p.Get(context.Background())
+	require.NoError(t, err)
+	idle.Release()
+
+	// 占满名额后加入一个等待者。
+	refill, err := p.Get(context.Background())
+	require.NoError(t, err)
+	waitErr := make(chan error, 1)
+	go func() {
+		_, err := p.Get(context.Background())
+		waitErr <- err
+	}()
+	require.Eventually(t, func() bool { return 1 == p.Stats().Waiting }, time.Second, time.Millisecond)
+	refill.Release()
+	require.NoError(t, <-waitErr)
+
+	go func() {
+		_, err := p.Get(context.Background())
+		waitErr <- err
+	}()
+	require.Eventually(t, func() bool { return 1 == p.Stats().Waiting }, time.Second, time.Millisecond)
+
+	require.NoError(t, p.Close())
+	require.NoError(t, p.Close())
+	assert.ErrorIs(t, <-waitErr, ErrPoolClosed)
+
+	_, err = p.Get(context.Background())
+	assert.ErrorIs(t, err, ErrPoolClosed)
+
+	held.Release()
+	assert.True(t, held.Conn().Closed(), "关闭后归还的连接应被关闭")
+	assert.Equal(t, 1, p.Stats().Active, "仍有一个借出的连接未归还")
+}
+
+// TestPool_Reaper 验证后台回收任务关闭过期的空闲连接。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestPool_Reaper(t *testing.T) {
+	dialer := &fakeDialer{}
+	p, err := New(dialer.dial, WithIdleTimeout(20*time.Millisecond), WithReapInterval(5*time.Millisecond))
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, p.Close()) }()
+
+	first, err := p.Get(context.Background())
+	require.NoError(t, err)
+	second, err := p.Get(context.Background())
+	require.NoError(t, err)
+	first.Release()
+	second.Release()
+
+	require.Eventually(t, func() bool { return 0 == p.Stats().Active }, time.Second, time.Millisecond)
+	assert.True(t, first.Conn().Closed())
+	assert.True(t, second.Conn().Closed())
+	assert.Equal(t, uint64(2), p.Stats().IdleClosed)
+}
+
+// TestPool_Concurrent 验证并发借还时不超过最大连接数。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestPool_Concurrent(t *testing.T) {
+	const maxActive = 3
+
+	dialer := &fakeDialer{}
+	p, err := New(dialer.dial, WithMaxActive(maxActive), WithMaxIdle(1))
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, p.Close()) }()
+
+	var inUse, peak atomic.Int64
+	var wg sync.WaitGroup
+	for i := 0; i < 32; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				conn, err := p.Get(context.Background())
+				if !assert.NoError(t, err) {
+					return
+				}
+				if n := inUse.Add(1); n > peak.Load() {
+					peak.Store(n)
+				}
+				inUse.Add(-1)
+				if 0 == (i+j)%5 {
+					_ = conn.Discard()
+				} else {
+					conn.Release()
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	assert.LessOrEqual(t, peak.Load(), int64(maxActive))
+	stats := p.Stats()
+	assert.Zero(t, stats.InUse)
+	assert.Zero(t, stats.Waiting)
+	assert.LessOrEqual(t, stats.Idle, 1)
+}
+
+// TestPool_MessageConn 验证连接池可以管理基于 TCP 的 net/message 连接。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestPool_MessageConn(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer func() { _ = listener.Close() }()
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if nil != err {
+				return
+			}
+			go func() { _, _ = io.Copy(conn, conn) }()
+		}
+	}()
+
+	p, err := New(func(ctx context.Context) (kitmessage.Conn, error) {
+		var dialer net.Dialer
+		conn, err := dialer.DialContext(ctx, "tcp", listener.Addr().String())
+		if nil != err {
+			return nil, err
+		}
+		wrapped := kitmessage.WrapConn(conn, 0)
+		wrapped.Start(context.Background())
+		return wrapped, nil
+	}, WithMaxActive(1))
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, p.Close()) }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	pooled, err := p.Get(ctx)
+	require.NoError(t, err)
+	require.NoError(t, pooled.Conn().SendMessage(kitmessage.NewSingleStringMessage("ping")))
+	select {
+	case msg := <-pooled.Conn().Message():
+		assert.Equal(t, "ping", msg.(kitmessage.SingleStringMessage).Message())
+	case <-ctx.Done():
+		require.Fail(t, "timed out waiting for echo")
+	}
+	pooled.Release()
+
+	reused, err := p.Get(ctx)
+	require.NoError(t, err)
+	assert.Same(t, pooled.Conn(), reused.Conn())
+
+	// 已关闭的 message 连接归还时会被丢弃。
+	require.NoError(t, reused.Conn().Close())
+	reused.Release()
+	assert.Equal(t, Stats{Dials: 1, Hits: 1}, p.Stats())
+}
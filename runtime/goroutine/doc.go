@@ -2,15 +2,27 @@
 //
 // Licensed under the MIT License. See LICENSE file in the project root for full license information.
 
-// Package goroutine 提供 goroutine ID 读取工具和基于 ants 的协程池封装。
+// Package goroutine 提供 goroutine ID 读取工具、基于 ants 的协程池封装、有界并行助手和测试用的泄漏检测。
 //
 // GetGoID 会按当前架构和 Go 版本选择快速路径；在未提供快速路径的平台上会退回到基于
 // runtime.Stack 的慢速解析实现，调用方也可显式使用 GetGoIDSlow。amd64 构建下，
 // Offset 返回快速路径使用的 runtime.g.goid 字段偏移。NewGoroutinePool 用于创建独立
 // 协程池实例，返回的 cleanup 负责停止指标采集协程并释放底层 ants.Pool 资源；包级
-// Submit 会惰性创建并复用默认池，在任务 panic 时 recover 并记录日志，不会把 panic
+// Submit 会惰性创建并复用默认池，在任务 panic 时 recover 并报告，不会把 panic
 // 继续向调用方传播。
 //
+// PanicReporter 集中汇总各处恢复的 panic：SafeGo、包级 Submit 与默认的协程池 panic 回调
+// 都通过 ReportPanic 报告给包级默认汇总器，汇总器按栈签名去重后周期性输出汇总日志，并通过
+// MetricPanicTotal 暴露计数，为运维提供“某处在 panic”的统一信号。MetricPanicTotal 与 MetricPanicDropped
+// 不会自动注册，需要调用方注册到所用的 Registerer。
+//
+// Map、ForEach 与 ForEachIndex 以有界并发处理一组输入：Map 按输入顺序返回结果，默认在首个错误后
+// 停止派发并取消传给任务的 context，WithContinueOnError 可改为执行全部任务并合并错误；任务中的 panic
+// 被恢复为 *PanicError 返回，而不是报告给 panic 汇总器。
+//
+// VerifyNoneLeaked 供测试使用：在测试开始时记录现有 goroutine，并在测试结束时经过稳定
+// 等待后报告仍残留的新增 goroutine 及其栈，可通过 WithLeakAllow 放行预期常驻的 goroutine。
+//
 // 本包的快速路径依赖 runtime 内部结构、汇编实现和按 Go 版本维护的偏移信息；升级
 // Go 版本或切换目标架构后需要重新验证对应实现。
 package goroutine
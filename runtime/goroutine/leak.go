@@ -0,0 +1,323 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package goroutine
+
+import (
+	"fmt"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// 泄漏检测的默认配置值。
+var (
+	// leakRetriesDefault 定义判定泄漏前重新检查的默认次数。
+	leakRetriesDefault = 50
+	// leakIntervalDefault 定义两次检查之间的默认等待时长。
+	leakIntervalDefault = 10 * time.Millisecond
+	// leakIgnoreDefault 定义默认忽略的栈顶函数前缀。
+	// 这些 goroutine 由 testing 框架或 runtime 按需启动，或是协程池中等待任务的空闲 worker、
+	// 指标采集协程与惰性创建的默认 panic 汇总协程，与被测代码无关；协程池 worker 正在执行的任务栈顶不在 ants 包中，仍会被检测。
+	leakIgnoreDefault = []string{
+		"testing.",
+		"os/signal.signal_recv",
+		"os/signal.loop",
+		"runtime.ensureSigM",
+		"runtime.ReadTrace",
+		"github.com/panjf2000/ants/v2.",
+		"github.com/fsyyft-go/kit/runtime/goroutine.stat",
+		"github.com/fsyyft-go/kit/runtime/goroutine.(*PanicReporter).run",
+	}
+)
+
+type (
+	// LeakOption 定义 goroutine 泄漏检测的配置修改函数。
+	//
+	// 参数：
+	//   - c：待修改的泄漏检测配置实例。
+	LeakOption func(c *leakChecker)
+
+	// leakChecker 保存一次泄漏检测的配置。
+	leakChecker struct {
+		// allow 是允许存在的 goroutine 栈匹配模式。
+		allow []string
+		// patterns 是 allow 编译后的正则表达式。
+		patterns []*regexp.Regexp
+		// retries 是判定泄漏前重新检查的次数。
+		retries int
+		// interval 是两次检查之间的等待时长。
+		interval time.Duration
+	}
+
+	// goroutineRecord 表示从 runtime.Stack 输出中解析出的单个 goroutine。
+	goroutineRecord struct {
+		// id 是 goroutine ID。
+		id int64
+		// state 是 goroutine 状态，例如 "chan receive"。
+		state string
+		// function 是栈顶函数名。
+		function string
+		// creator 是创建该 goroutine 的函数名；主 goroutine 为空。
+		creator string
+		// stack 是该 goroutine 的完整栈文本。
+		stack string
+	}
+)
+
+// WithLeakAllow 添加允许存在的 goroutine 栈匹配模式。
+//
+// 模式为正则表达式，与 goroutine 的完整栈文本匹配，命中任一模式的 goroutine 不视为泄漏；
+// 多次调用时模式会累加。无效的模式会使 VerifyNoneLeaked 调用 Fatalf。
+//
+// 参数：
+//   - patterns：允许存在的 goroutine 栈匹配模式，例如 "github.com/panjf2000/ants"。
+//
+// 返回：
+//   - LeakOption：添加允许模式的配置函数。
+func WithLeakAllow(patterns ...string) LeakOption {
+	return func(c *leakChecker) {
+		c.allow = append(c.allow, patterns...)
+	}
+}
+
+// WithLeakRetries 设置判定泄漏前的稳定等待。
+//
+// 发现新增 goroutine 后，最多再重新检查 retries 次，每次间隔 interval，
+// 给正在退出的 goroutine 留出结束时间。默认重新检查 50 次，每次间隔 10ms。
+//
+// 参数：
+//   - retries：重新检查的次数；小于 0 时按 0 处理，即只检查一次。
+//   - interval：两次检查之间的等待时长；小于等于 0 时保留默认值。
+//
+// 返回：
+//   - LeakOption：设置稳定等待的配置函数。
+func WithLeakRetries(retries int, interval time.Duration) LeakOption {
+	return func(c *leakChecker) {
+		c.retries = max(retries, 0)
+		if interval > 0 {
+			c.interval = interval
+		}
+	}
+}
+
+// VerifyNoneLeaked 检查测试结束时是否有新增的 goroutine 残留。
+//
+// 应在测试开始时调用：VerifyNoneLeaked 会立即记录当前存在的 goroutine，并通过 t.Cleanup
+// 在测试及其子测试、以及之后注册的清理函数全部结束后再次检查。测试期间新启动且仍未退出的
+// goroutine 在稳定等待后依旧存在时，以 t.Errorf 报告，并附带每个 goroutine 的 ID、状态、
+// 创建者和完整栈。
+//
+// 该检查基于进程内全部 goroutine 的快照，不适合与 t.Parallel 并行执行的测试一起使用，
+// 否则其他测试启动的 goroutine 会被误报。
+//
+// 参数：
+//   - t：当前测试，*testing.T 和 *testing.B 均可。
+//   - opts：允许模式、稳定等待等可选配置。
+func VerifyNoneLeaked(t testing.TB, opts ...LeakOption) {
+	t.Helper()
+
+	c := &leakChecker{
+		retries:  leakRetriesDefault,
+		interval: leakIntervalDefault,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	for _, pattern := range c.allow {
+		re, err := regexp.Compile(pattern)
+		if nil != err {
+			t.Fatalf("goroutine 泄漏检测的允许模式无效：%v", err)
+			return
+		}
+		c.patterns = append(c.patterns, re)
+	}
+
+	baseline := make(map[int64]struct{})
+	for _, g := range snapshotGoroutines() {
+		baseline[g.id] = struct{}{}
+	}
+
+	t.Cleanup(func() {
+		t.Helper()
+		if leaked := c.wait(baseline); len(leaked) > 0 {
+			t.Errorf("%s", formatLeaked(leaked))
+		}
+	})
+}
+
+// wait 在稳定等待期内反复检查新增的 goroutine。
+//
+// 参数：
+//   - baseline：测试开始时存在的 goroutine ID 集合。
+//
+// 返回：
+//   - []goroutineRecord：稳定等待结束后仍残留的 goroutine；没有泄漏时为空。
+func (c *leakChecker) wait(baseline map[int64]struct{}) []goroutineRecord {
+	for attempt := 0; ; attempt++ {
+		leaked := c.find(baseline)
+		if 0 == len(leaked) || attempt >= c.retries {
+			return leaked
+		}
+		time.Sleep(c.interval)
+	}
+}
+
+// find 返回不在基线中且不被忽略的 goroutine。
+//
+// 参数：
+//   - baseline：测试开始时存在的 goroutine ID 集合。
+//
+// 返回：
+//   - []goroutineRecord：新增且未被忽略的 goroutine。
+func (c *leakChecker) find(baseline map[int64]struct{}) []goroutineRecord {
+	records := snapshotGoroutines()
+	var leaked []goroutineRecord
+	// runtime.Stack 的第一个记录总是当前 goroutine，也就是执行检查的 goroutine。
+	for i, g := range records {
+		if 0 == i {
+			continue
+		}
+		if _, ok := baseline[g.id]; ok || c.ignored(g) {
+			continue
+		}
+		leaked = append(leaked, g)
+	}
+	return leaked
+}
+
+// ignored 判断 goroutine 是否被默认规则或允许模式忽略。
+//
+// 参数：
+//   - g：待判断的 goroutine。
+//
+// 返回：
+//   - bool：需要忽略时返回 true。
+func (c *leakChecker) ignored(g goroutineRecord) bool {
+	for _, prefix := range leakIgnoreDefault {
+		if strings.HasPrefix(g.function, prefix) {
+			return true
+		}
+	}
+	for _, re := range c.patterns {
+		if re.MatchString(g.stack) {
+			return true
+		}
+	}
+	return false
+}
+
+// snapshotGoroutines 返回当前进程全部 goroutine 的快照。
+//
+// 参数：无。
+//
+// 返回：
+//   - []goroutineRecord：解析后的 goroutine 列表，第一个元素为当前 goroutine。
+func snapshotGoroutines() []goroutineRecord {
+	buf := make([]byte, 64<<10)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			return parseGoroutines(string(buf[:n]))
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+}
+
+// parseGoroutines 解析 runtime.Stack 输出的全部 goroutine 栈。
+//
+// 参数：
+//   - dump：runtime.Stack(buf, true) 的输出。
+//
+// 返回：
+//   - []goroutineRecord：按输出顺序排列的 goroutine；无法解析的记录会被跳过。
+func parseGoroutines(dump string) []goroutineRecord {
+	var records []goroutineRecord
+	for _, block := range strings.Split(strings.TrimSpace(dump), "\n\n") {
+		if g, ok := parseGoroutine(block); ok {
+			records = append(records, g)
+		}
+	}
+	return records
+}
+
+// parseGoroutine 解析单个 goroutine 的栈文本。
+//
+// 栈文本首行形如 "goroutine 18 [chan receive, 2 minutes]:"，第二行为栈顶函数调用，
+// 以 "created by" 开头的行记录创建者。
+//
+// 参数：
+//   - block：单个 goroutine 的栈文本。
+//
+// 返回：
+//   - goroutineRecord：解析结果。
+//   - bool：首行格式无法识别时返回 false。
+func parseGoroutine(block string) (goroutineRecord, bool) {
+	lines := strings.Split(block, "\n")
+	header, ok := strings.CutPrefix(lines[0], "goroutine ")
+	if !ok {
+		return goroutineRecord{}, false
+	}
+	idText, rest, ok := strings.Cut(header, " ")
+	if !ok {
+		return goroutineRecord{}, false
+	}
+	id, err := strconv.ParseInt(idText, 10, 64)
+	if nil != err {
+		return goroutineRecord{}, false
+	}
+
+	g := goroutineRecord{id: id, stack: block}
+	if start, end := strings.Index(rest, "["), strings.Index(rest, "]"); start >= 0 && end > start {
+		g.state, _, _ = strings.Cut(rest[start+1:end], ",")
+	}
+	if len(lines) > 1 {
+		g.function = functionName(lines[1])
+	}
+	for _, line := range lines[1:] {
+		if creator, ok := strings.CutPrefix(line, "created by "); ok {
+			creator, _, _ = strings.Cut(creator, " in goroutine ")
+			g.creator = creator
+			break
+		}
+	}
+	return g, true
+}
+
+// functionName 从栈帧的函数调用行中提取函数名。
+//
+// 参数：
+//   - line：形如 "pkg.fn(0x1, 0x2)" 的函数调用行。
+//
+// 返回：
+//   - string：去掉参数列表后的函数名。
+func functionName(line string) string {
+	if i := strings.LastIndex(line, "("); i > 0 {
+		return line[:i]
+	}
+	return line
+}
+
+// formatLeaked 生成泄漏 goroutine 的报告文本。
+//
+// 参数：
+//   - leaked：泄漏的 goroutine 列表。
+//
+// 返回：
+//   - string：包含每个 goroutine 编号、ID、状态、创建者和完整栈的报告。
+func formatLeaked(leaked []goroutineRecord) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "发现 %d 个泄漏的 goroutine：", len(leaked))
+	for i, g := range leaked {
+		creator := g.creator
+		if "" == creator {
+			creator = "unknown"
+		}
+		fmt.Fprintf(&sb, "\n\n[%d] goroutine %d [%s]，栈顶 %s，创建者 %s：\n%s", i+1, g.id, g.state, g.function, creator, g.stack)
+	}
+	return sb.String()
+}
@@ -0,0 +1,256 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package goroutine
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingTB 是记录 Cleanup、Errorf 和 Fatalf 调用的 testing.TB 测试替身。
+//
+// 嵌入真实的 testing.TB 以满足接口的未导出方法，其余方法委托给被嵌入的实例。
+type recordingTB struct {
+	testing.TB
+	cleanups []func()
+	errors   []string
+	fatals   []string
+}
+
+// Helper 忽略辅助函数标记。
+func (tb *recordingTB) Helper() {}
+
+// Cleanup 记录清理函数，由 runCleanups 手动执行。
+//
+// 参数：
+//   - fn: 待记录的清理函数。
+func (tb *recordingTB) Cleanup(fn func()) {
+	tb.cleanups = append(tb.cleanups, fn)
+}
+
+// Errorf 记录错误信息。
+//
+// 参数：
+//   - format: 格式化模板。
+//   - args: 格式化参数。
+func (tb *recordingTB) Errorf(format string, args ...any) {
+	tb.errors = append(tb.errors, fmt.Sprintf(format, args...))
+}
+
+// Fatalf 记录致命错误信息，不终止当前 goroutine。
+//
+// 参数：
+//   - format: 格式化模板。
+//   - args: 格式化参数。
+func (tb *recordingTB) Fatalf(format string, args ...any) {
+	tb.fatals = append(tb.fatals, fmt.Sprintf(format, args...))
+}
+
+// runCleanups 按注册逆序执行记录的清理函数。
+func (tb *recordingTB) runCleanups() {
+	for i := len(tb.cleanups) - 1; i >= 0; i-- {
+		tb.cleanups[i]()
+	}
+}
+
+// leakBlockedWorker 通知已启动后阻塞直到 release 关闭，用于在栈中留下可识别的函数名。
+//
+// 参数：
+//   - started: 开始运行后关闭的通道。
+//   - release: 关闭后让 goroutine 退出的通道。
+func leakBlockedWorker(started chan<- struct{}, release <-chan struct{}) {
+	close(started)
+	<-release
+}
+
+// startBlockedWorker 启动 leakBlockedWorker 并等待其开始运行。
+//
+// 参数：
+//   - release: 关闭后让 goroutine 退出的通道。
+func startBlockedWorker(release <-chan struct{}) {
+	started := make(chan struct{})
+	go leakBlockedWorker(started, release)
+	<-started
+}
+
+// TestVerifyNoneLeaked 验证泄漏检测对新增、已退出、延迟退出和被允许的 goroutine 的判断。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestVerifyNoneLeaked(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		giveOpts    []LeakOption
+		giveStart   func(release chan struct{})
+		wantLeak    bool
+		wantCreator string
+		wantFatal   bool
+	}{
+		{
+			name:        "success/no-goroutine",
+			description: "验证测试期间没有新增 goroutine 时不报告泄漏。",
+			giveStart:   func(release chan struct{}) {},
+		},
+		{
+			name:        "success/goroutine-exited",
+			description: "验证测试期间启动但已退出的 goroutine 不视为泄漏。",
+			giveStart: func(release chan struct{}) {
+				done := make(chan struct{})
+				go func() { close(done) }()
+				<-done
+			},
+		},
+		{
+			name:        "success/stabilized",
+			description: "验证稳定等待期内退出的 goroutine 不视为泄漏。",
+			giveOpts:    []LeakOption{WithLeakRetries(100, 5*time.Millisecond)},
+			giveStart: func(release chan struct{}) {
+				startBlockedWorker(release)
+				time.AfterFunc(30*time.Millisecond, func() { close(release) })
+			},
+		},
+		{
+			name:        "success/allowed",
+			description: "验证命中允许模式的 goroutine 不视为泄漏。",
+			giveOpts:    []LeakOption{WithLeakAllow(`goroutine\.leakBlockedWorker`), WithLeakRetries(0, 0)},
+			giveStart:   func(release chan struct{}) { startBlockedWorker(release) },
+		},
+		{
+			name:        "error/leaked",
+			description: "验证稳定等待后仍残留的 goroutine 以带 ID、状态和栈的报告呈现。",
+			giveOpts:    []LeakOption{WithLeakRetries(2, time.Millisecond)},
+			giveStart:   func(release chan struct{}) { startBlockedWorker(release) },
+			wantLeak:    true,
+			wantCreator: "github.com/fsyyft-go/kit/runtime/goroutine.startBlockedWorker",
+		},
+		{
+			name:        "error/leaked-pool-task",
+			description: "验证忽略协程池空闲 worker 的同时，仍能检测到在协程池中未退出的任务。",
+			giveOpts:    []LeakOption{WithLeakRetries(2, time.Millisecond)},
+			giveStart: func(release chan struct{}) {
+				started := make(chan struct{})
+				require.NoError(t, Submit(func() { leakBlockedWorker(started, release) }))
+				<-started
+			},
+			wantLeak:    true,
+			wantCreator: "github.com/panjf2000/ants/v2.",
+		},
+		{
+			name:        "error/invalid-pattern",
+			description: "验证无效的允许模式会调用 Fatalf 且不注册检查。",
+			giveOpts:    []LeakOption{WithLeakAllow(`(`)},
+			giveStart:   func(release chan struct{}) {},
+			wantFatal:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			tb := &recordingTB{TB: t}
+			release := make(chan struct{})
+			defer func() {
+				select {
+				case <-release:
+				default:
+					close(release)
+				}
+			}()
+
+			VerifyNoneLeaked(tb, tt.giveOpts...)
+			tt.giveStart(release)
+			tb.runCleanups()
+
+			if tt.wantFatal {
+				assert.Len(t, tb.fatals, 1)
+				assert.Empty(t, tb.cleanups)
+				return
+			}
+			assert.Empty(t, tb.fatals)
+			if !tt.wantLeak {
+				assert.Empty(t, tb.errors)
+				return
+			}
+			require.Len(t, tb.errors, 1)
+			assert.Contains(t, tb.errors[0], "发现 1 个泄漏的 goroutine")
+			assert.Contains(t, tb.errors[0], "[chan receive]")
+			assert.Contains(t, tb.errors[0], "栈顶 github.com/fsyyft-go/kit/runtime/goroutine.leakBlockedWorker")
+			assert.Contains(t, tb.errors[0], "创建者 "+tt.wantCreator)
+		})
+	}
+}
+
+// TestVerifyNoneLeaked_RealTest 验证在真实测试中注册检查并在测试结束时通过。
+//
+// 参数：
+//   - t: 测试上下文，用于注册泄漏检查。
+func TestVerifyNoneLeaked_RealTest(t *testing.T) {
+	VerifyNoneLeaked(t)
+
+	release := make(chan struct{})
+	startBlockedWorker(release)
+	t.Cleanup(func() { close(release) })
+}
+
+// TestParseGoroutine 验证对 runtime.Stack 单个 goroutine 栈文本的解析。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestParseGoroutine(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		giveBlock   string
+		wantOK      bool
+		wantRecord  goroutineRecord
+	}{
+		{
+			name:        "success/with-creator",
+			description: "验证解析 ID、状态、栈顶函数和创建者。",
+			giveBlock:   "goroutine 18 [chan receive, 2 minutes]:\nexample.com/pkg.(*conn).loop(0xc000010000)\n\t/src/pkg/conn.go:42 +0x25\ncreated by example.com/pkg.Start in goroutine 7\n\t/src/pkg/conn.go:30 +0x5a",
+			wantOK:      true,
+			wantRecord:  goroutineRecord{id: 18, state: "chan receive", function: "example.com/pkg.(*conn).loop", creator: "example.com/pkg.Start"},
+		},
+		{
+			name:        "success/main-goroutine",
+			description: "验证没有创建者的主 goroutine。",
+			giveBlock:   "goroutine 1 [running]:\nmain.main()\n\t/src/main.go:5 +0x1d",
+			wantOK:      true,
+			wantRecord:  goroutineRecord{id: 1, state: "running", function: "main.main"},
+		},
+		{
+			name:        "error/invalid-header",
+			description: "验证无法识别的首行返回 false。",
+			giveBlock:   "not a goroutine",
+		},
+		{
+			name:        "error/invalid-id",
+			description: "验证无法解析的 ID 返回 false。",
+			giveBlock:   "goroutine x [running]:",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			got, ok := parseGoroutine(tt.giveBlock)
+
+			assert.Equal(t, tt.wantOK, ok)
+			if tt.wantOK {
+				tt.wantRecord.stack = tt.giveBlock
+				assert.Equal(t, tt.wantRecord, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,497 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package goroutine
+
+import (
+	"fmt"
+	"hash/fnv"
+	"runtime"
+	"runtime/debug"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	kitlog "github.com/fsyyft-go/kit/log"
+)
+
+const (
+	// panicFlushIntervalDefault 定义 panic 汇总的默认输出周期。
+	panicFlushIntervalDefault = 10 * time.Second
+	// panicBufferSizeDefault 定义等待汇总的 panic 记录默认缓冲数量。
+	panicBufferSizeDefault = 1024
+	// panicSignatureFrames 定义计算栈签名时使用的最大栈帧数。
+	panicSignatureFrames = 8
+)
+
+var (
+	// MetricPanicTotal 记录被恢复的 panic 次数。
+	//
+	// 标签：
+	//   - location：发生 panic 的函数名，即栈签名的首个栈帧。
+	MetricPanicTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "panic_total",
+		Help:      "recovered goroutine panics by location.",
+	}, []string{"location"})
+
+	// MetricPanicDropped 记录因缓冲已满或汇总器已关闭而未进入汇总日志的 panic 次数，这些 panic 仍计入 MetricPanicTotal。
+	MetricPanicDropped = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "panic_dropped_total",
+		Help:      "recovered goroutine panics dropped from summaries.",
+	})
+
+	// panicReporterDefault 缓存包级默认 panic 汇总器。
+	panicReporterDefault *PanicReporter
+	// panicReporterLocker 保护默认 panic 汇总器的惰性初始化与替换。
+	panicReporterLocker sync.Mutex
+)
+
+type (
+	// PanicReporterOption 定义 panic 汇总器的配置修改函数。
+	//
+	// 参数：
+	//   - r：待修改的 panic 汇总器。
+	PanicReporterOption func(r *PanicReporter)
+
+	// PanicSummary 描述一个汇总周期内栈签名相同的一组 panic。
+	PanicSummary struct {
+		// Signature 是栈签名，由 panic 发生处起的栈帧计算，同一代码位置的 panic 签名相同。
+		Signature string
+		// Location 是发生 panic 的函数名。
+		Location string
+		// Value 是本周期内首个 panic 值的字符串形式。
+		Value string
+		// Stack 是本周期内首个 panic 的完整栈。
+		Stack string
+		// Count 是本周期内该签名的 panic 次数。
+		Count int
+		// Total 是汇总器创建以来该签名的 panic 总次数。
+		Total int64
+		// First 是本周期内首次发生的时间。
+		First time.Time
+		// Last 是本周期内最后一次发生的时间。
+		Last time.Time
+	}
+
+	// PanicReporter 集中收集各处恢复的 panic，按栈签名去重后周期性输出汇总。
+	//
+	// Report 只做计数并把记录非阻塞地放入缓冲，后台协程按周期把同一签名的记录合并为一条 PanicSummary，
+	// 交给汇总回调或写入日志，使运维只需关注一个“某处在 panic”的信号，而不会被重复日志淹没。
+	PanicReporter struct {
+		// interval 是汇总输出周期。
+		interval time.Duration
+		// bufferSize 是等待汇总的记录缓冲数量。
+		bufferSize int
+		// logger 是输出汇总日志使用的日志记录器，为 nil 时使用全局日志记录器。
+		logger kitlog.Logger
+		// handler 是汇总回调，设置后替代日志输出。
+		handler func([]PanicSummary)
+
+		// records 是等待汇总的 panic 记录。
+		records chan panicRecord
+		// totals 是各签名的累计次数。
+		totals map[string]int64
+		// totalsLocker 保护 totals。
+		totalsLocker sync.Mutex
+		// dropped 是未进入汇总的记录数量。
+		dropped atomic.Int64
+		// closed 指示汇总器是否已经关闭。
+		closed atomic.Bool
+		// done 通知后台协程退出。
+		done chan struct{}
+		// stopped 在后台协程输出最后一次汇总并退出后关闭。
+		stopped chan struct{}
+		// closeOnce 保证 Close 只执行一次。
+		closeOnce sync.Once
+	}
+
+	// panicRecord 是一次被恢复的 panic。
+	panicRecord struct {
+		// signature 是栈签名。
+		signature string
+		// location 是发生 panic 的函数名。
+		location string
+		// value 是 panic 值的字符串形式。
+		value string
+		// stack 是完整栈。
+		stack string
+		// at 是发生时间。
+		at time.Time
+	}
+)
+
+// WithPanicFlushInterval 设置 panic 汇总的输出周期。
+//
+// 参数：
+//   - interval：输出周期；小于等于 0 时使用默认的 10 秒。
+//
+// 返回：
+//   - PanicReporterOption：用于更新输出周期的选项函数。
+func WithPanicFlushInterval(interval time.Duration) PanicReporterOption {
+	return func(r *PanicReporter) {
+		if interval > 0 {
+			r.interval = interval
+		}
+	}
+}
+
+// WithPanicBufferSize 设置等待汇总的 panic 记录缓冲数量。
+//
+// 参数：
+//   - size：缓冲数量；缓冲已满时新的记录只计数不进入汇总。小于等于 0 时使用默认的 1024。
+//
+// 返回：
+//   - PanicReporterOption：用于更新缓冲数量的选项函数。
+func WithPanicBufferSize(size int) PanicReporterOption {
+	return func(r *PanicReporter) {
+		if size > 0 {
+			r.bufferSize = size
+		}
+	}
+}
+
+// WithPanicLogger 设置输出汇总日志使用的日志记录器。
+//
+// 参数：
+//   - logger：日志记录器；为 nil 时使用全局日志记录器。
+//
+// 返回：
+//   - PanicReporterOption：用于更新日志记录器的选项函数。
+func WithPanicLogger(logger kitlog.Logger) PanicReporterOption {
+	return func(r *PanicReporter) {
+		r.logger = logger
+	}
+}
+
+// WithPanicSummaryHandler 设置汇总回调，设置后不再写入日志。
+//
+// 参数：
+//   - handler：每个周期收到的汇总按次数从多到少排列；只在周期内有 panic 时调用，在后台协程中串行执行。
+//
+// 返回：
+//   - PanicReporterOption：用于更新汇总回调的选项函数。
+func WithPanicSummaryHandler(handler func([]PanicSummary)) PanicReporterOption {
+	return func(r *PanicReporter) {
+		r.handler = handler
+	}
+}
+
+// NewPanicReporter 创建 panic 汇总器并启动后台汇总协程。
+//
+// 参数：
+//   - opts：可选配置项，按传入顺序覆盖默认配置。
+//
+// 返回：
+//   - *PanicReporter：已启动的 panic 汇总器；不再使用时应调用 Close 输出剩余汇总并停止后台协程。
+func NewPanicReporter(opts ...PanicReporterOption) *PanicReporter {
+	r := &PanicReporter{
+		interval:   panicFlushIntervalDefault,
+		bufferSize: panicBufferSizeDefault,
+		totals:     make(map[string]int64),
+		done:       make(chan struct{}),
+		stopped:    make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	r.records = make(chan panicRecord, r.bufferSize)
+
+	go r.run()
+
+	return r
+}
+
+// Report 记录一次被恢复的 panic。
+//
+// 应在 recover 所在的延迟函数中调用，此时调用栈仍包含发生 panic 的位置，栈签名据此计算。
+// Report 不会阻塞：累计次数和 MetricPanicTotal 总是更新，缓冲已满或汇总器已关闭时记录不进入汇总。
+//
+// 参数：
+//   - value：recover 返回的 panic 值。
+func (r *PanicReporter) Report(value interface{}) {
+	signature, location := panicSignature()
+	record := panicRecord{
+		signature: signature,
+		location:  location,
+		value:     fmt.Sprint(value),
+		stack:     string(debug.Stack()),
+		at:        time.Now(),
+	}
+
+	r.totalsLocker.Lock()
+	r.totals[signature]++
+	r.totalsLocker.Unlock()
+	MetricPanicTotal.WithLabelValues(location).Inc()
+
+	if r.closed.Load() {
+		r.drop()
+		return
+	}
+	select {
+	case r.records <- record:
+	default:
+		r.drop()
+	}
+}
+
+// Counts 返回各栈签名的累计 panic 次数。
+//
+// 参数：无。
+//
+// 返回：
+//   - map[string]int64：栈签名到累计次数的副本。
+func (r *PanicReporter) Counts() map[string]int64 {
+	r.totalsLocker.Lock()
+	defer r.totalsLocker.Unlock()
+
+	counts := make(map[string]int64, len(r.totals))
+	for signature, count := range r.totals {
+		counts[signature] = count
+	}
+	return counts
+}
+
+// Dropped 返回未进入汇总的 panic 次数。
+//
+// 参数：无。
+//
+// 返回：
+//   - int64：因缓冲已满或汇总器已关闭而未进入汇总的次数。
+func (r *PanicReporter) Dropped() int64 {
+	return r.dropped.Load()
+}
+
+// Close 输出缓冲中剩余的汇总并停止后台协程，可重复调用。
+//
+// 参数：无。
+//
+// 返回：
+//   - error：始终返回 nil，便于作为 io.Closer 使用。
+func (r *PanicReporter) Close() error {
+	r.closeOnce.Do(func() {
+		r.closed.Store(true)
+		close(r.done)
+	})
+	<-r.stopped
+	return nil
+}
+
+// drop 记录一次未进入汇总的 panic。
+func (r *PanicReporter) drop() {
+	r.dropped.Add(1)
+	MetricPanicDropped.Inc()
+}
+
+// run 周期性地把缓冲中的记录合并为汇总并输出，收到退出信号后输出剩余记录。
+func (r *PanicReporter) run() {
+	defer close(r.stopped)
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	batch := make(map[string]*PanicSummary)
+	for {
+		select {
+		case record := <-r.records:
+			r.merge(batch, record)
+		case <-ticker.C:
+			batch = r.flush(batch)
+		case <-r.done:
+			for {
+				select {
+				case record := <-r.records:
+					r.merge(batch, record)
+				default:
+					r.flush(batch)
+					return
+				}
+			}
+		}
+	}
+}
+
+// merge 把一条记录合并到当前周期的汇总中。
+//
+// 参数：
+//   - batch：当前周期按签名索引的汇总。
+//   - record：待合并的记录。
+func (r *PanicReporter) merge(batch map[string]*PanicSummary, record panicRecord) {
+	summary, ok := batch[record.signature]
+	if !ok {
+		summary = &PanicSummary{
+			Signature: record.signature,
+			Location:  record.location,
+			Value:     record.value,
+			Stack:     record.stack,
+			First:     record.at,
+		}
+		batch[record.signature] = summary
+	}
+	summary.Count++
+	summary.Last = record.at
+}
+
+// flush 输出当前周期的汇总。
+//
+// 参数：
+//   - batch：当前周期按签名索引的汇总。
+//
+// 返回：
+//   - map[string]*PanicSummary：下一个周期使用的空汇总；batch 为空时原样返回。
+func (r *PanicReporter) flush(batch map[string]*PanicSummary) map[string]*PanicSummary {
+	if len(batch) == 0 {
+		return batch
+	}
+
+	r.totalsLocker.Lock()
+	summaries := make([]PanicSummary, 0, len(batch))
+	for signature, summary := range batch {
+		summary.Total = r.totals[signature]
+		summaries = append(summaries, *summary)
+	}
+	r.totalsLocker.Unlock()
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].Count != summaries[j].Count {
+			return summaries[i].Count > summaries[j].Count
+		}
+		return summaries[i].First.Before(summaries[j].First)
+	})
+
+	if nil != r.handler {
+		r.handler(summaries)
+	} else {
+		logger := r.logger
+		if nil == logger {
+			logger = kitlog.GetLogger()
+		}
+		for _, summary := range summaries {
+			logger.WithFields(map[string]interface{}{
+				"signature": summary.Signature,
+				"location":  summary.Location,
+				"value":     summary.Value,
+				"count":     summary.Count,
+				"total":     summary.Total,
+				"stack":     summary.Stack,
+			}).Error("goroutine panic summary")
+		}
+	}
+	return make(map[string]*PanicSummary)
+}
+
+// panicSignature 根据当前调用栈计算 panic 的栈签名。
+//
+// 调用栈中存在 runtime.gopanic 时从其后的第一个非 runtime 栈帧开始计算，即发生 panic 的位置；
+// 否则从调用 Report 的位置开始计算。
+//
+// 参数：无。
+//
+// 返回：
+//   - string：由最多 8 个栈帧的函数名与首个栈帧的行号计算的十六进制签名。
+//   - string：签名首个栈帧的函数名。
+func panicSignature() (string, string) {
+	pcs := make([]uintptr, 64)
+	// 跳过 runtime.Callers、panicSignature 与 Report。
+	n := runtime.Callers(3, pcs)
+	frames := make([]runtime.Frame, 0, n)
+	iter := runtime.CallersFrames(pcs[:n])
+	start := 0
+	for {
+		frame, more := iter.Next()
+		if frame.Function == "runtime.gopanic" {
+			start = len(frames) + 1
+		}
+		frames = append(frames, frame)
+		if !more {
+			break
+		}
+	}
+
+	h := fnv.New64a()
+	location := ""
+	used := 0
+	for _, frame := range frames[start:] {
+		if used == panicSignatureFrames {
+			break
+		}
+		if strings.HasPrefix(frame.Function, "runtime.") {
+			continue
+		}
+		_, _ = h.Write([]byte(frame.Function))
+		if used == 0 {
+			// 只有首个栈帧带行号：同一函数内不同位置的 panic 分开汇总，调用方的行号变化不影响签名。
+			location = frame.Function
+			_, _ = h.Write([]byte{':'})
+			_, _ = h.Write([]byte(strconv.Itoa(frame.Line)))
+		}
+		_, _ = h.Write([]byte{';'})
+		used++
+	}
+	return strconv.FormatUint(h.Sum64(), 16), location
+}
+
+// DefaultPanicReporter 返回包级默认 panic 汇总器，首次调用时惰性创建。
+//
+// 参数：无。
+//
+// 返回：
+//   - *PanicReporter：包级默认 panic 汇总器；SafeGo、包级 Submit 与默认协程池 panic 回调都向它报告。
+func DefaultPanicReporter() *PanicReporter {
+	panicReporterLocker.Lock()
+	defer panicReporterLocker.Unlock()
+
+	if nil == panicReporterDefault {
+		panicReporterDefault = NewPanicReporter()
+	}
+	return panicReporterDefault
+}
+
+// SetDefaultPanicReporter 替换包级默认 panic 汇总器。
+//
+// 被替换的汇总器不会被关闭，调用方可按需调用其 Close 输出剩余汇总。
+//
+// 参数：
+//   - r：新的默认汇总器；为 nil 时下次使用时重新惰性创建。
+//
+// 返回：
+//   - *PanicReporter：被替换的汇总器，尚未创建时为 nil。
+func SetDefaultPanicReporter(r *PanicReporter) *PanicReporter {
+	panicReporterLocker.Lock()
+	defer panicReporterLocker.Unlock()
+
+	previous := panicReporterDefault
+	panicReporterDefault = r
+	return previous
+}
+
+// ReportPanic 向包级默认 panic 汇总器报告一次被恢复的 panic。
+//
+// 应在 recover 所在的延迟函数中调用，自定义的恢复逻辑可借此接入统一的 panic 汇总。
+//
+// 参数：
+//   - value：recover 返回的 panic 值。
+func ReportPanic(value interface{}) {
+	DefaultPanicReporter().Report(value)
+}
+
+// SafeGo 在新的 goroutine 中执行 fn，fn 发生 panic 时恢复并报告给包级默认 panic 汇总器。
+//
+// 参数：
+//   - fn：要执行的函数。
+func SafeGo(fn func()) {
+	go func() {
+		defer func() {
+			if r := recover(); nil != r {
+				ReportPanic(r)
+			}
+		}()
+		fn()
+	}()
+}
@@ -0,0 +1,273 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package goroutine
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	kitlog "github.com/fsyyft-go/kit/log"
+)
+
+// summaryCollector 收集汇总回调收到的全部汇总。
+type summaryCollector struct {
+	mu        sync.Mutex
+	summaries []PanicSummary
+	calls     int
+}
+
+// handle 作为汇总回调保存收到的汇总。
+func (c *summaryCollector) handle(summaries []PanicSummary) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.summaries = append(c.summaries, summaries...)
+	c.calls++
+}
+
+// snapshot 返回已收到的汇总与回调次数。
+func (c *summaryCollector) snapshot() ([]PanicSummary, int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]PanicSummary(nil), c.summaries...), c.calls
+}
+
+// panicSiteA 在固定位置 panic 并报告给 r。
+func panicSiteA(r *PanicReporter, value interface{}) {
+	defer func() {
+		if v := recover(); nil != v {
+			r.Report(v)
+		}
+	}()
+	panic(value)
+}
+
+// panicSiteB 在另一个固定位置 panic 并报告给 r。
+func panicSiteB(r *PanicReporter) {
+	defer func() {
+		if v := recover(); nil != v {
+			r.Report(v)
+		}
+	}()
+	var m map[string]int
+	m["x"] = 1
+}
+
+// TestPanicReporter_Aggregation 验证 panic 按栈签名去重汇总，并维护累计次数。
+func TestPanicReporter_Aggregation(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		act         func(r *PanicReporter)
+		wantCounts  []int
+		wantTotals  []int64
+		wantValue   string
+	}{
+		{
+			name:        "success/dedup-by-site",
+			description: "验证同一位置的多次 panic 合并为一条汇总，不同位置分别汇总，并按次数从多到少排列。",
+			act: func(r *PanicReporter) {
+				for i := 0; i < 3; i++ {
+					panicSiteA(r, "boom")
+				}
+				panicSiteB(r)
+			},
+			wantCounts: []int{3, 1},
+			wantTotals: []int64{3, 1},
+			wantValue:  "boom",
+		},
+		{
+			name:        "success/value-does-not-split",
+			description: "验证同一位置不同的 panic 值仍属于同一签名，汇总保留首个值。",
+			act: func(r *PanicReporter) {
+				panicSiteA(r, "first")
+				panicSiteA(r, "second")
+			},
+			wantCounts: []int{2},
+			wantTotals: []int64{2},
+			wantValue:  "first",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			collector := &summaryCollector{}
+			reporter := NewPanicReporter(WithPanicFlushInterval(time.Hour), WithPanicSummaryHandler(collector.handle))
+			tt.act(reporter)
+			require.NoError(t, reporter.Close())
+			require.NoError(t, reporter.Close())
+
+			summaries, calls := collector.snapshot()
+			assert.Equal(t, 1, calls)
+			require.Len(t, summaries, len(tt.wantCounts))
+			for i, summary := range summaries {
+				assert.Equal(t, tt.wantCounts[i], summary.Count)
+				assert.Equal(t, tt.wantTotals[i], summary.Total)
+				assert.Equal(t, tt.wantTotals[i], reporter.Counts()[summary.Signature])
+				assert.False(t, summary.Last.Before(summary.First))
+				assert.Contains(t, summary.Stack, "goroutine")
+			}
+			assert.Equal(t, tt.wantValue, summaries[0].Value)
+			assert.True(t, strings.HasSuffix(summaries[0].Location, ".panicSiteA"))
+			assert.Zero(t, reporter.Dropped())
+		})
+	}
+}
+
+// TestPanicReporter_PeriodicFlush 验证汇总按周期输出，累计次数跨周期累加。
+func TestPanicReporter_PeriodicFlush(t *testing.T) {
+	t.Log("验证每个周期只输出该周期内的次数，Total 为创建以来的累计次数。")
+
+	flushed := make(chan []PanicSummary, 4)
+	reporter := NewPanicReporter(
+		WithPanicFlushInterval(10*time.Millisecond),
+		WithPanicSummaryHandler(func(s []PanicSummary) { flushed <- s }),
+	)
+	defer func() { _ = reporter.Close() }()
+
+	panicSiteA(reporter, "boom")
+	first := receiveWithin(t, flushed, "first panic summary")
+	require.Len(t, first, 1)
+	assert.Equal(t, 1, first[0].Count)
+
+	panicSiteA(reporter, "boom")
+	panicSiteA(reporter, "boom")
+	second := receiveWithin(t, flushed, "second panic summary")
+	require.Len(t, second, 1)
+	assert.Equal(t, first[0].Signature, second[0].Signature)
+	assert.Equal(t, int64(3), second[0].Total)
+}
+
+// TestPanicReporter_DropAndMetrics 验证关闭后的报告只计数不汇总，并更新指标。
+func TestPanicReporter_DropAndMetrics(t *testing.T) {
+	t.Log("验证汇总器关闭后报告的 panic 计入累计次数与 MetricPanicTotal，同时计入 Dropped。")
+
+	collector := &summaryCollector{}
+	reporter := NewPanicReporter(WithPanicSummaryHandler(collector.handle))
+	require.NoError(t, reporter.Close())
+
+	droppedBefore := counterValue(t, MetricPanicDropped.Write)
+	for i := 0; i < 2; i++ {
+		panicSiteA(reporter, "late")
+	}
+	for _, count := range reporter.Counts() {
+		assert.Equal(t, int64(2), count)
+	}
+	assert.Equal(t, int64(2), reporter.Dropped())
+	assert.Equal(t, droppedBefore+2, counterValue(t, MetricPanicDropped.Write))
+	_, calls := collector.snapshot()
+	assert.Zero(t, calls)
+
+	location := "github.com/fsyyft-go/kit/runtime/goroutine.panicSiteA"
+	assert.GreaterOrEqual(t, counterValue(t, MetricPanicTotal.WithLabelValues(location).Write), float64(2))
+}
+
+// recordingLogger 记录 WithFields 与 Error 调用，其余方法未实现。
+type recordingLogger struct {
+	kitlog.Logger
+
+	mu      sync.Mutex
+	fields  []map[string]interface{}
+	entries []string
+}
+
+// WithFields 记录字段并返回自身。
+func (l *recordingLogger) WithFields(fields map[string]interface{}) kitlog.Logger {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.fields = append(l.fields, fields)
+	return l
+}
+
+// Error 记录错误日志消息。
+func (l *recordingLogger) Error(args ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = append(l.entries, fmt.Sprint(args...))
+}
+
+// TestPanicReporter_Log 验证未设置汇总回调时以 Error 级别输出汇总日志。
+func TestPanicReporter_Log(t *testing.T) {
+	t.Log("验证汇总日志的消息与位置、次数、panic 值字段。")
+
+	logger := &recordingLogger{}
+	reporter := NewPanicReporter(WithPanicLogger(logger))
+	panicSiteB(reporter)
+	require.NoError(t, reporter.Close())
+
+	require.Equal(t, []string{"goroutine panic summary"}, logger.entries)
+	require.Len(t, logger.fields, 1)
+	fields := logger.fields[0]
+	assert.True(t, strings.HasSuffix(fields["location"].(string), ".panicSiteB"))
+	assert.Equal(t, 1, fields["count"])
+	assert.Equal(t, int64(1), fields["total"])
+	assert.Contains(t, fields["value"], "assignment to entry in nil map")
+}
+
+// TestSafeGo 验证 SafeGo 与包级 Submit 恢复的 panic 都报告给默认汇总器。
+func TestSafeGo(t *testing.T) {
+	t.Log("验证 SafeGo 执行函数并恢复 panic，包级 Submit 的 panic 同样进入默认汇总器。")
+
+	isolateDefaultPoolForTest(t)
+	collector := &summaryCollector{}
+	reporter := isolateDefaultPanicReporterForTest(t, WithPanicFlushInterval(time.Hour), WithPanicSummaryHandler(collector.handle))
+	assert.Same(t, reporter, DefaultPanicReporter())
+
+	var wg sync.WaitGroup
+	wg.Add(3)
+	SafeGo(func() {
+		defer wg.Done()
+	})
+	SafeGo(func() {
+		defer wg.Done()
+		panic("safe go panic")
+	})
+	require.NoError(t, Submit(func() {
+		defer wg.Done()
+		panic("submit panic")
+	}))
+	requireWaitGroupWithin(t, &wg, goroutineTestTimeout, "safe go and submit tasks")
+
+	// wg.Done 在 recover 之前执行，等待报告完成。
+	require.Eventually(t, func() bool {
+		total := int64(0)
+		for _, count := range reporter.Counts() {
+			total += count
+		}
+		return total == 2
+	}, goroutineTestTimeout, time.Millisecond)
+
+	require.NoError(t, reporter.Close())
+	summaries, _ := collector.snapshot()
+	require.Len(t, summaries, 2)
+	values := []string{summaries[0].Value, summaries[1].Value}
+	assert.ElementsMatch(t, []string{"safe go panic", "submit panic"}, values)
+	assert.NotEqual(t, summaries[0].Signature, summaries[1].Signature)
+}
+
+// counterValue 读取 Counter 当前值。
+//
+// 参数：
+//   - t: 测试上下文，用于报告指标读取失败。
+//   - write: 指标的 Write 方法。
+//
+// 返回：
+//   - float64: Counter 当前值。
+func counterValue(t *testing.T, write func(*dto.Metric) error) float64 {
+	t.Helper()
+
+	metric := &dto.Metric{}
+	require.NoError(t, write(metric))
+	require.NotNil(t, metric.Counter)
+	return metric.Counter.GetValue()
+}
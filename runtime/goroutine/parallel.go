@@ -0,0 +1,234 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package goroutine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	// ErrTaskPanic 表示并行任务发生了 panic。
+	//
+	// Map 与 ForEach 把任务中的 panic 恢复为 *PanicError，调用方可以使用 errors.Is 判断该错误，
+	// 或使用 errors.As 取得 panic 值与栈。
+	ErrTaskPanic = errors.New("goroutine: task panicked")
+)
+
+type (
+	// ParallelOption 定义 Map 与 ForEach 的配置修改函数。
+	//
+	// 参数：
+	//   - o：待修改的并行执行配置。
+	ParallelOption func(o *parallelOptions)
+
+	// parallelOptions 是 Map 与 ForEach 的并行执行配置。
+	parallelOptions struct {
+		// continueOnError 指示任务出错后是否继续执行其余任务。
+		continueOnError bool
+	}
+
+	// PanicError 是并行任务中被恢复的 panic。
+	PanicError struct {
+		// Index 是发生 panic 的任务在输入中的下标。
+		Index int
+		// Value 是 recover 返回的 panic 值。
+		Value interface{}
+		// Stack 是发生 panic 时的 goroutine 栈。
+		Stack []byte
+	}
+)
+
+// WithContinueOnError 设置任务出错后是否继续执行其余任务。
+//
+// 默认在首个错误发生后停止派发新任务并取消传给任务的 context，返回首个错误；开启后全部任务都会执行，
+// 返回按输入顺序以 errors.Join 合并的全部错误。
+//
+// 参数：
+//   - continueOnError：为 true 时出错后继续执行其余任务。
+//
+// 返回：
+//   - ParallelOption：用于更新出错策略的选项函数。
+func WithContinueOnError(continueOnError bool) ParallelOption {
+	return func(o *parallelOptions) {
+		o.continueOnError = continueOnError
+	}
+}
+
+// Error 返回 panic 的描述。
+//
+// 参数：无。
+//
+// 返回：
+//   - string：包含任务下标与 panic 值的描述。
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("%s: item %d: %v", ErrTaskPanic, e.Index, e.Value)
+}
+
+// Unwrap 返回 ErrTaskPanic，使 errors.Is(err, ErrTaskPanic) 成立；panic 值本身是 error 时一并返回。
+//
+// 参数：无。
+//
+// 返回：
+//   - []error：ErrTaskPanic，以及 panic 值为 error 时的该值。
+func (e *PanicError) Unwrap() []error {
+	if err, ok := e.Value.(error); ok {
+		return []error{ErrTaskPanic, err}
+	}
+	return []error{ErrTaskPanic}
+}
+
+// Map 以最多 concurrency 个 goroutine 并行地对 items 中的每一项执行 fn，按输入顺序返回结果。
+//
+// 任务中的 panic 被恢复为 *PanicError 并按错误处理，不会使进程崩溃。默认在首个错误发生后停止派发新任务，
+// 并取消传给正在执行任务的 context；可通过 WithContinueOnError 改为执行全部任务。ctx 被取消时同样停止派发，
+// 未执行的任务不会调用 fn。无论是否出错，返回的切片长度都与 items 相同，未执行或出错的项为 R 的零值。
+//
+// 参数：
+//   - ctx：控制整体执行的上下文，派生的子上下文会传给 fn。
+//   - items：输入项。
+//   - concurrency：最大并发数；小于等于 0 时使用 runtime.GOMAXPROCS(0)。
+//   - fn：处理单个输入项的函数。
+//   - opts：可选的并行执行配置。
+//
+// 返回：
+//   - []R：与 items 一一对应的结果。
+//   - error：默认返回首个错误；开启 WithContinueOnError 时返回合并的全部错误；
+//     ctx 被取消导致有任务未执行时包含 context.Cause(ctx)。
+func Map[T, R any](ctx context.Context, items []T, concurrency int, fn func(ctx context.Context, item T) (R, error), opts ...ParallelOption) ([]R, error) {
+	results := make([]R, len(items))
+	err := run(ctx, len(items), concurrency, func(ctx context.Context, i int) error {
+		r, err := fn(ctx, items[i])
+		if nil == err {
+			results[i] = r
+		}
+		return err
+	}, opts)
+	return results, err
+}
+
+// ForEach 以最多 concurrency 个 goroutine 并行地对 items 中的每一项执行 fn。
+//
+// 错误处理、panic 恢复与取消语义与 Map 相同。
+//
+// 参数：
+//   - ctx：控制整体执行的上下文，派生的子上下文会传给 fn。
+//   - items：输入项。
+//   - concurrency：最大并发数；小于等于 0 时使用 runtime.GOMAXPROCS(0)。
+//   - fn：处理单个输入项的函数。
+//   - opts：可选的并行执行配置。
+//
+// 返回：
+//   - error：默认返回首个错误；开启 WithContinueOnError 时返回合并的全部错误；
+//     ctx 被取消导致有任务未执行时包含 context.Cause(ctx)。
+func ForEach[T any](ctx context.Context, items []T, concurrency int, fn func(ctx context.Context, item T) error, opts ...ParallelOption) error {
+	return run(ctx, len(items), concurrency, func(ctx context.Context, i int) error {
+		return fn(ctx, items[i])
+	}, opts)
+}
+
+// ForEachIndex 与 ForEach 相同，但 fn 额外接收输入项的下标，便于把结果写入调用方预先分配的位置。
+//
+// 参数：
+//   - ctx：控制整体执行的上下文，派生的子上下文会传给 fn。
+//   - items：输入项。
+//   - concurrency：最大并发数；小于等于 0 时使用 runtime.GOMAXPROCS(0)。
+//   - fn：处理单个输入项的函数，i 为该项在 items 中的下标。
+//   - opts：可选的并行执行配置。
+//
+// 返回：
+//   - error：语义同 ForEach。
+func ForEachIndex[T any](ctx context.Context, items []T, concurrency int, fn func(ctx context.Context, i int, item T) error, opts ...ParallelOption) error {
+	return run(ctx, len(items), concurrency, func(ctx context.Context, i int) error {
+		return fn(ctx, i, items[i])
+	}, opts)
+}
+
+// run 以最多 concurrency 个 worker 按下标顺序派发 n 个任务。
+//
+// 参数：
+//   - ctx：控制整体执行的上下文。
+//   - n：任务数量。
+//   - concurrency：最大并发数；小于等于 0 时使用 runtime.GOMAXPROCS(0)。
+//   - task：执行下标为 i 的任务。
+//   - opts：并行执行配置。
+//
+// 返回：
+//   - error：按出错策略汇总的错误。
+func run(ctx context.Context, n, concurrency int, task func(ctx context.Context, i int) error, opts []ParallelOption) error {
+	o := &parallelOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if 0 == n {
+		return nil
+	}
+	if concurrency <= 0 {
+		concurrency = runtime.GOMAXPROCS(0)
+	}
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	errs := make([]error, n)
+	var next atomic.Int64
+	var failed atomic.Bool
+	var wg sync.WaitGroup
+	for range min(concurrency, n) {
+		wg.Go(func() {
+			for nil == ctx.Err() {
+				i := int(next.Add(1) - 1)
+				if i >= n {
+					return
+				}
+				if err := safeCall(ctx, i, task); nil != err {
+					errs[i] = err
+					if !o.continueOnError {
+						failed.Store(true)
+						cancel(err)
+					}
+				}
+			}
+		})
+	}
+	wg.Wait()
+
+	// 下标未派发完说明因取消而提前停止。
+	skipped := int(next.Load()) < n
+	if !o.continueOnError {
+		if failed.Load() || skipped {
+			return context.Cause(ctx)
+		}
+		return nil
+	}
+	err := errors.Join(errs...)
+	if skipped {
+		err = errors.Join(err, context.Cause(ctx))
+	}
+	return err
+}
+
+// safeCall 执行下标为 i 的任务，并把 panic 恢复为 *PanicError。
+//
+// 参数：
+//   - ctx：传给任务的上下文。
+//   - i：任务下标。
+//   - task：任务函数。
+//
+// 返回：
+//   - error：任务返回的错误，或 panic 时的 *PanicError。
+func safeCall(ctx context.Context, i int, task func(ctx context.Context, i int) error) (err error) {
+	defer func() {
+		if r := recover(); nil != r {
+			err = &PanicError{Index: i, Value: r, Stack: debug.Stack()}
+		}
+	}()
+	return task(ctx, i)
+}
@@ -0,0 +1,215 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package goroutine
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMap 验证 Map 的结果顺序、出错策略、panic 恢复与取消语义。
+func TestMap(t *testing.T) {
+	errBoom := errors.New("boom")
+	items := []int{1, 2, 3, 4, 5, 6, 7, 8}
+
+	tests := []struct {
+		name        string
+		description string
+		concurrency int
+		opts        []ParallelOption
+		fn          func(ctx context.Context, item int) (int, error)
+		want        []int
+		wantErrIs   []error
+		wantCalls   int32
+	}{
+		{
+			name:        "success/order",
+			description: "验证结果与输入顺序一一对应，与完成顺序无关。",
+			concurrency: 4,
+			fn: func(_ context.Context, item int) (int, error) {
+				time.Sleep(time.Duration(len(items)-item) * time.Millisecond)
+				return item * item, nil
+			},
+			want:      []int{1, 4, 9, 16, 25, 36, 49, 64},
+			wantCalls: 8,
+		},
+		{
+			name:        "success/default-concurrency",
+			description: "验证并发数小于等于 0 时使用 GOMAXPROCS。",
+			fn:          func(_ context.Context, item int) (int, error) { return item, nil },
+			want:        items,
+			wantCalls:   8,
+		},
+		{
+			name:        "error/stop-on-first-error",
+			description: "验证默认在首个错误后停止派发，出错项与未执行项为零值。",
+			concurrency: 1,
+			fn: func(_ context.Context, item int) (int, error) {
+				if 3 == item {
+					return 0, errBoom
+				}
+				return item, nil
+			},
+			want:      []int{1, 2, 0, 0, 0, 0, 0, 0},
+			wantErrIs: []error{errBoom},
+			wantCalls: 3,
+		},
+		{
+			name:        "error/continue-on-error",
+			description: "验证 WithContinueOnError 执行全部任务并合并全部错误。",
+			concurrency: 2,
+			opts:        []ParallelOption{WithContinueOnError(true)},
+			fn: func(_ context.Context, item int) (int, error) {
+				switch item {
+				case 2:
+					return 0, errBoom
+				case 5:
+					return 0, io.EOF
+				}
+				return item, nil
+			},
+			want:      []int{1, 0, 3, 4, 0, 6, 7, 8},
+			wantErrIs: []error{errBoom, io.EOF},
+			wantCalls: 8,
+		},
+		{
+			name:        "error/panic",
+			description: "验证 panic 被恢复为 *PanicError，panic 值为 error 时可用 errors.Is 判断。",
+			concurrency: 2,
+			opts:        []ParallelOption{WithContinueOnError(true)},
+			fn: func(_ context.Context, item int) (int, error) {
+				if 4 == item {
+					panic(errBoom)
+				}
+				return item, nil
+			},
+			want:      []int{1, 2, 3, 0, 5, 6, 7, 8},
+			wantErrIs: []error{ErrTaskPanic, errBoom},
+			wantCalls: 8,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			var calls atomic.Int32
+			got, err := Map(context.Background(), items, tt.concurrency, func(ctx context.Context, item int) (int, error) {
+				calls.Add(1)
+				return tt.fn(ctx, item)
+			}, tt.opts...)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantCalls, calls.Load())
+			if 0 == len(tt.wantErrIs) {
+				require.NoError(t, err)
+				return
+			}
+			for _, target := range tt.wantErrIs {
+				require.ErrorIs(t, err, target)
+			}
+		})
+	}
+}
+
+// TestMap_Concurrency 验证同时执行的任务数不超过并发上限。
+func TestMap_Concurrency(t *testing.T) {
+	var running, peak atomic.Int32
+	items := make([]int, 32)
+	_, err := Map(context.Background(), items, 3, func(_ context.Context, item int) (int, error) {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		return item, nil
+	})
+	require.NoError(t, err)
+	assert.LessOrEqual(t, peak.Load(), int32(3))
+	assert.Equal(t, int32(3), peak.Load())
+}
+
+// TestMap_CancelOnError 验证首个错误会取消传给其他正在执行任务的 context。
+func TestMap_CancelOnError(t *testing.T) {
+	errBoom := errors.New("boom")
+	_, err := Map(context.Background(), []int{0, 1}, 2, func(ctx context.Context, item int) (int, error) {
+		if 0 == item {
+			return 0, errBoom
+		}
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case <-time.After(time.Second):
+			return 0, errors.New("context 未被取消")
+		}
+	})
+	require.ErrorIs(t, err, errBoom)
+}
+
+// TestForEach 验证 ForEach 与 ForEachIndex 的执行、取消与 panic 恢复。
+func TestForEach(t *testing.T) {
+	t.Run("success/index", func(t *testing.T) {
+		t.Log("验证 ForEachIndex 传入正确的下标。")
+
+		items := []string{"a", "b", "c"}
+		got := make([]string, len(items))
+		err := ForEachIndex(context.Background(), items, 2, func(_ context.Context, i int, item string) error {
+			got[i] = item + item
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"aa", "bb", "cc"}, got)
+	})
+
+	t.Run("success/empty", func(t *testing.T) {
+		t.Log("验证输入为空时不调用 fn 且不返回错误。")
+
+		require.NoError(t, ForEach(context.Background(), []int(nil), 2, func(context.Context, int) error {
+			t.Fatal("不应调用 fn")
+			return nil
+		}))
+	})
+
+	t.Run("error/canceled", func(t *testing.T) {
+		t.Log("验证 ctx 已取消时不执行任务并返回取消原因。")
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		var calls atomic.Int32
+		err := ForEach(ctx, []int{1, 2, 3}, 2, func(context.Context, int) error {
+			calls.Add(1)
+			return nil
+		}, WithContinueOnError(true))
+		require.ErrorIs(t, err, context.Canceled)
+		assert.Zero(t, calls.Load())
+	})
+
+	t.Run("error/panic", func(t *testing.T) {
+		t.Log("验证 PanicError 记录下标、panic 值与栈。")
+
+		err := ForEach(context.Background(), []int{1, 2, 3}, 1, func(_ context.Context, item int) error {
+			if 2 == item {
+				panic("bad item")
+			}
+			return nil
+		})
+		var panicErr *PanicError
+		require.ErrorAs(t, err, &panicErr)
+		assert.Equal(t, 1, panicErr.Index)
+		assert.Equal(t, "bad item", panicErr.Value)
+		assert.Contains(t, string(panicErr.Stack), "parallel_test.go")
+		assert.Contains(t, err.Error(), "item 1: bad item")
+	})
+}
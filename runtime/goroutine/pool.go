@@ -10,8 +10,6 @@ import (
 	"time"
 
 	"github.com/panjf2000/ants/v2"
-
-	kitlog "github.com/fsyyft-go/kit/log"
 )
 
 // 默认配置值。
@@ -27,8 +25,8 @@ var (
 	nonBlockingDefault = false
 	// maxBlockingDefault 定义阻塞提交模式下允许等待的最大任务数。
 	maxBlockingDefault = 0
-	// panicHandlerDefault 定义传给底层 ants.Pool 的默认 panic 回调。
-	panicHandlerDefault = func(r interface{}) {}
+	// panicHandlerDefault 定义传给底层 ants.Pool 的默认 panic 回调，将 panic 报告给包级默认 panic 汇总器。
+	panicHandlerDefault = func(r interface{}) { ReportPanic(r) }
 	// metricsDefault 定义默认启用指标采集。
 	metricsDefault = true
 
@@ -201,7 +199,8 @@ func WithMaxBlocking(maxBlocking int) Option {
 // WithPanicHandler 设置底层协程池的 panic 回调。
 //
 // 参数：
-//   - panicHandler：传给 ants.WithPanicHandler 的回调函数，用于处理 worker 执行任务时发生的 panic。
+//   - panicHandler：传给 ants.WithPanicHandler 的回调函数，用于处理 worker 执行任务时发生的 panic；
+//     默认回调调用 ReportPanic，自定义回调如需参与统一汇总应自行调用 ReportPanic。
 //
 // 返回：
 //   - Option：用于更新 panic 回调的选项函数。
@@ -364,14 +363,14 @@ func (p *goroutinePool) IsClosed() bool {
 
 // Submit 将 task 提交到包级默认协程池执行。
 //
-// 首次调用会惰性创建默认池。与显式 GoroutinePool.Submit 不同，包级包装层会 recover task panic 并记录日志，
-// 不会把 panic 继续向调用方传播，也不会通过返回值暴露该 panic。
+// 首次调用会惰性创建默认池。与显式 GoroutinePool.Submit 不同，包级包装层会 recover task panic 并报告给包级默认
+// panic 汇总器，不会把 panic 继续向调用方传播，也不会通过返回值暴露该 panic。
 //
 // 参数：
 //   - task：要提交到包级默认协程池执行的任务函数。
 //
 // 返回：
-//   - error：默认池初始化失败或底层提交失败时返回错误。task panic 会被 recover 并记录日志，不通过返回值暴露。
+//   - error：默认池初始化失败或底层提交失败时返回错误。task panic 会被 recover 并报告给默认 panic 汇总器，不通过返回值暴露。
 func Submit(task func()) error {
 	p, err := defaultPool()
 	if err != nil {
@@ -381,7 +380,7 @@ func Submit(task func()) error {
 	return p.Submit(func() {
 		defer func() {
 			if r := recover(); nil != r {
-				kitlog.Error("goroutine panic", r)
+				ReportPanic(r)
 			}
 		}()
 		task()
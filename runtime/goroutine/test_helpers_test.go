@@ -157,3 +157,23 @@ func isolateDefaultPoolForTest(t *testing.T) {
 		closeGoroutinePoolForTest(t, currentPool)
 	})
 }
+
+// isolateDefaultPanicReporterForTest 使用新的 panic 汇总器替换包级默认汇总器，并在用例结束后恢复。
+//
+// 参数：
+//   - t: 测试上下文，用于注册全局状态恢复和资源清理逻辑。
+//   - opts: 创建替换汇总器使用的配置项。
+//
+// 返回：
+//   - *PanicReporter: 测试期间使用的默认汇总器，用例结束时关闭。
+func isolateDefaultPanicReporterForTest(t *testing.T, opts ...PanicReporterOption) *PanicReporter {
+	t.Helper()
+
+	reporter := NewPanicReporter(opts...)
+	previous := SetDefaultPanicReporter(reporter)
+	t.Cleanup(func() {
+		SetDefaultPanicReporter(previous)
+		_ = reporter.Close()
+	})
+	return reporter
+}
@@ -0,0 +1,14 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+// Package metrics 提供进程资源采样与看门狗。
+//
+// Watchdog 按固定间隔采样 goroutine 数量、堆内存、GC 暂停、文件描述符数量与 CPU 使用率，
+// 写入 MetricProcessCurrent 指标并以 Debug 级别输出日志。WithThreshold 添加阈值规则，
+// 超标时在冷却期约束下执行回调，例如使用 DumpProfile 在 goroutine 数量激增时保存 pprof 剖析。
+//
+// Watchdog 实现 kitruntime.Runner，可与其他组件一起管理生命周期；也可以只调用 Collect 手动采样。
+// 文件描述符数量与 CPU 使用率仅在类 Unix 平台上可用，其他平台上分别为 -1。
+// 本包的 Prometheus 指标不会自动注册，调用方需要自行注册到所用的 Registerer。
+package metrics
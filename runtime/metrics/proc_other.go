@@ -0,0 +1,32 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+//go:build !unix
+
+package metrics
+
+import (
+	"time"
+)
+
+// fdCount 在不支持的平台上返回 -1。
+//
+// 参数：无。
+//
+// 返回：
+//   - int: 始终为 -1。
+func fdCount() int {
+	return -1
+}
+
+// cpuTime 在不支持的平台上报告读取失败。
+//
+// 参数：无。
+//
+// 返回：
+//   - time.Duration: 始终为 0。
+//   - bool: 始终为 false。
+func cpuTime() (time.Duration, bool) {
+	return 0, false
+}
@@ -0,0 +1,47 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+//go:build unix
+
+package metrics
+
+import (
+	"os"
+	"syscall"
+	"time"
+)
+
+// fdDirs 是按顺序尝试的进程文件描述符目录：Linux 使用 /proc/self/fd，macOS 与 BSD 使用 /dev/fd。
+var fdDirs = []string{"/proc/self/fd", "/dev/fd"}
+
+// fdCount 统计进程打开的文件描述符数量。
+//
+// 参数：无。
+//
+// 返回：
+//   - int: 文件描述符数量，不含统计时打开目录所用的描述符；无法读取时返回 -1。
+func fdCount() int {
+	for _, dir := range fdDirs {
+		entries, err := os.ReadDir(dir)
+		if nil == err {
+			return max(len(entries)-1, 0)
+		}
+	}
+	return -1
+}
+
+// cpuTime 返回进程累计使用的用户态与内核态 CPU 时间。
+//
+// 参数：无。
+//
+// 返回：
+//   - time.Duration: 累计 CPU 时间。
+//   - bool: 读取成功时返回 true。
+func cpuTime() (time.Duration, bool) {
+	var usage syscall.Rusage
+	if err := syscall.Getrusage(syscall.RUSAGE_SELF, &usage); nil != err {
+		return 0, false
+	}
+	return time.Duration(usage.Utime.Nano() + usage.Stime.Nano()), true
+}
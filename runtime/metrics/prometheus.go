@@ -0,0 +1,69 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// namespace 定义 Prometheus 指标命名空间。
+	namespace = "kit_runtime"
+	// subsystem 定义 Prometheus 指标子系统名称。
+	subsystem = "process"
+)
+
+var (
+	// MetricProcessCurrent 记录最近一次进程资源采样的结果。
+	//
+	// 标签：
+	//   - name：看门狗名称，对应 WithName 配置。
+	//   - state：指标维度，可选值包括：
+	//     - goroutines：goroutine 数量。
+	//     - heap_alloc_bytes、heap_inuse_bytes、heap_sys_bytes：堆内存字节数。
+	//     - gc_count：已完成的 GC 次数。
+	//     - gc_pause_last_seconds、gc_pause_total_seconds：最近一次与累计的 GC 暂停秒数。
+	//     - fds：打开的文件描述符数量，平台不支持时不写入。
+	//     - cpu_percent：CPU 使用率，平台不支持时不写入。
+	MetricProcessCurrent = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "current",
+		Help:      "process resource usage sampled by the runtime watchdog.",
+	}, []string{"name", "state"})
+
+	// MetricThresholdExceeded 记录阈值被触发的次数，冷却期内被抑制的触发不计入。
+	//
+	// 标签：
+	//   - name：看门狗名称，对应 WithName 配置。
+	//   - threshold：阈值名称，对应 WithThreshold 的 name 参数。
+	MetricThresholdExceeded = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "threshold_exceeded_total",
+		Help:      "number of times a runtime watchdog threshold fired.",
+	}, []string{"name", "threshold"})
+)
+
+// collectProcessMetrics 将采样结果写入指标。
+//
+// 参数：
+//   - name: 看门狗名称。
+//   - s: 采样结果。
+func collectProcessMetrics(name string, s Sample) {
+	MetricProcessCurrent.WithLabelValues(name, "goroutines").Set(float64(s.Goroutines))
+	MetricProcessCurrent.WithLabelValues(name, "heap_alloc_bytes").Set(float64(s.HeapAlloc))
+	MetricProcessCurrent.WithLabelValues(name, "heap_inuse_bytes").Set(float64(s.HeapInuse))
+	MetricProcessCurrent.WithLabelValues(name, "heap_sys_bytes").Set(float64(s.HeapSys))
+	MetricProcessCurrent.WithLabelValues(name, "gc_count").Set(float64(s.NumGC))
+	MetricProcessCurrent.WithLabelValues(name, "gc_pause_last_seconds").Set(s.GCPauseLast.Seconds())
+	MetricProcessCurrent.WithLabelValues(name, "gc_pause_total_seconds").Set(s.GCPauseTotal.Seconds())
+	if s.FDs >= 0 {
+		MetricProcessCurrent.WithLabelValues(name, "fds").Set(float64(s.FDs))
+	}
+	if s.CPUPercent >= 0 {
+		MetricProcessCurrent.WithLabelValues(name, "cpu_percent").Set(s.CPUPercent)
+	}
+}
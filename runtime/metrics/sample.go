@@ -0,0 +1,148 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package metrics
+
+import (
+	"runtime"
+	"time"
+)
+
+type (
+	// Sample 是一次进程资源采样的结果。
+	Sample struct {
+		// Time 是采样时间。
+		Time time.Time
+		// Goroutines 是当前 goroutine 数量。
+		Goroutines int
+		// HeapAlloc 是堆上已分配且尚未释放的字节数。
+		HeapAlloc uint64
+		// HeapInuse 是使用中的堆 span 字节数。
+		HeapInuse uint64
+		// HeapSys 是从操作系统获得的堆内存字节数。
+		HeapSys uint64
+		// NumGC 是已完成的 GC 次数。
+		NumGC uint32
+		// GCPauseLast 是最近一次 GC 的 STW 暂停时长；尚未发生 GC 时为 0。
+		GCPauseLast time.Duration
+		// GCPauseTotal 是进程启动以来 GC 暂停时长的累计值。
+		GCPauseTotal time.Duration
+		// FDs 是进程打开的文件描述符数量；当前平台不支持时为 -1。
+		FDs int
+		// CPUPercent 是距上一次采样期间进程的 CPU 使用率，单核跑满为 100，多核时可超过 100；
+		// 当前平台不支持时为 -1。
+		CPUPercent float64
+	}
+
+	// cpuClock 记录计算 CPU 使用率所需的上一次采样点。
+	cpuClock struct {
+		// wall 是上一次采样的墙钟时间。
+		wall time.Time
+		// cpu 是上一次采样时进程累计的 CPU 时间。
+		cpu time.Duration
+	}
+)
+
+// collect 采集一次进程资源样本。
+//
+// 参数：
+//   - now: 采样时间。
+//   - clock: 上一次的 CPU 采样点，会被更新为本次采样点。
+//
+// 返回：
+//   - Sample: 采样结果。
+func collect(now time.Time, clock *cpuClock) Sample {
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+
+	s := Sample{
+		Time:         now,
+		Goroutines:   runtime.NumGoroutine(),
+		HeapAlloc:    m.HeapAlloc,
+		HeapInuse:    m.HeapInuse,
+		HeapSys:      m.HeapSys,
+		NumGC:        m.NumGC,
+		GCPauseTotal: time.Duration(m.PauseTotalNs),
+		FDs:          fdCount(),
+		CPUPercent:   -1,
+	}
+	if m.NumGC > 0 {
+		s.GCPauseLast = time.Duration(m.PauseNs[(m.NumGC+255)%256])
+	}
+
+	if cpu, ok := cpuTime(); ok {
+		if elapsed := now.Sub(clock.wall); elapsed > 0 {
+			s.CPUPercent = float64(cpu-clock.cpu) / float64(elapsed) * 100
+		} else {
+			s.CPUPercent = 0
+		}
+		clock.wall, clock.cpu = now, cpu
+	}
+	return s
+}
+
+// GoroutinesAbove 返回 goroutine 数量超过阈值时成立的判断函数。
+//
+// 参数：
+//   - n: goroutine 数量阈值。
+//
+// 返回：
+//   - func(Sample) bool: 用于 WithThreshold 的判断函数。
+func GoroutinesAbove(n int) func(Sample) bool {
+	return func(s Sample) bool {
+		return s.Goroutines > n
+	}
+}
+
+// HeapAllocAbove 返回堆分配字节数超过阈值时成立的判断函数。
+//
+// 参数：
+//   - bytes: 堆分配字节数阈值。
+//
+// 返回：
+//   - func(Sample) bool: 用于 WithThreshold 的判断函数。
+func HeapAllocAbove(bytes uint64) func(Sample) bool {
+	return func(s Sample) bool {
+		return s.HeapAlloc > bytes
+	}
+}
+
+// GCPauseAbove 返回最近一次 GC 暂停超过阈值时成立的判断函数。
+//
+// 参数：
+//   - d: GC 暂停时长阈值。
+//
+// 返回：
+//   - func(Sample) bool: 用于 WithThreshold 的判断函数。
+func GCPauseAbove(d time.Duration) func(Sample) bool {
+	return func(s Sample) bool {
+		return s.GCPauseLast > d
+	}
+}
+
+// FDsAbove 返回文件描述符数量超过阈值时成立的判断函数；平台不支持时始终不成立。
+//
+// 参数：
+//   - n: 文件描述符数量阈值。
+//
+// 返回：
+//   - func(Sample) bool: 用于 WithThreshold 的判断函数。
+func FDsAbove(n int) func(Sample) bool {
+	return func(s Sample) bool {
+		return s.FDs > n
+	}
+}
+
+// CPUAbove 返回 CPU 使用率超过阈值时成立的判断函数；平台不支持时始终不成立。
+//
+// 参数：
+//   - percent: CPU 使用率阈值，单核跑满为 100。
+//
+// 返回：
+//   - func(Sample) bool: 用于 WithThreshold 的判断函数。
+func CPUAbove(percent float64) func(Sample) bool {
+	return func(s Sample) bool {
+		return s.CPUPercent > percent
+	}
+}
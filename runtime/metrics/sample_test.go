@@ -0,0 +1,74 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package metrics
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCollect 验证采样结果的基本字段与 CPU 采样点更新。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestCollect(t *testing.T) {
+	runtime.GC()
+	start := time.Now().Add(-time.Second)
+	clock := cpuClock{wall: start}
+
+	s := collect(time.Now(), &clock)
+
+	assert.Positive(t, s.Goroutines)
+	assert.Positive(t, s.HeapAlloc)
+	assert.Positive(t, s.HeapSys)
+	assert.Positive(t, s.NumGC)
+	assert.GreaterOrEqual(t, s.GCPauseTotal, s.GCPauseLast)
+	if _, ok := cpuTime(); ok {
+		assert.GreaterOrEqual(t, s.CPUPercent, float64(0))
+		assert.Equal(t, s.Time, clock.wall)
+		assert.Positive(t, s.FDs)
+	} else {
+		assert.Equal(t, float64(-1), s.CPUPercent)
+		assert.Equal(t, start, clock.wall)
+	}
+}
+
+// TestPredicates 验证内置阈值判断函数。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestPredicates(t *testing.T) {
+	sample := Sample{Goroutines: 100, HeapAlloc: 1 << 20, GCPauseLast: time.Millisecond, FDs: 10, CPUPercent: 50}
+	unsupported := Sample{FDs: -1, CPUPercent: -1}
+
+	tests := []struct {
+		name        string
+		description string
+		predicate   func(Sample) bool
+		give        Sample
+		want        bool
+	}{
+		{name: "success/goroutines", description: "验证 goroutine 数量超过阈值。", predicate: GoroutinesAbove(99), give: sample, want: true},
+		{name: "boundary/goroutines-equal", description: "验证等于阈值时不成立。", predicate: GoroutinesAbove(100), give: sample},
+		{name: "success/heap", description: "验证堆分配超过阈值。", predicate: HeapAllocAbove(1 << 10), give: sample, want: true},
+		{name: "success/gc-pause", description: "验证 GC 暂停未超过阈值。", predicate: GCPauseAbove(time.Second), give: sample},
+		{name: "success/fds", description: "验证文件描述符超过阈值。", predicate: FDsAbove(5), give: sample, want: true},
+		{name: "success/cpu", description: "验证 CPU 使用率超过阈值。", predicate: CPUAbove(10), give: sample, want: true},
+		{name: "boundary/fds-unsupported", description: "验证平台不支持时不成立。", predicate: FDsAbove(0), give: unsupported},
+		{name: "boundary/cpu-unsupported", description: "验证平台不支持时不成立。", predicate: CPUAbove(0), give: unsupported},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			assert.Equal(t, tt.want, tt.predicate(tt.give))
+		})
+	}
+}
@@ -0,0 +1,389 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/pprof"
+	"sync"
+	"time"
+
+	kitlog "github.com/fsyyft-go/kit/log"
+	kitruntime "github.com/fsyyft-go/kit/runtime"
+)
+
+// 默认配置值。
+var (
+	// intervalDefault 定义默认采样间隔。
+	intervalDefault = 10 * time.Second
+	// cooldownDefault 定义阈值触发后的默认冷却期。
+	cooldownDefault = time.Minute
+	// metricsDefault 定义默认写入 Prometheus 指标。
+	metricsDefault = true
+)
+
+var (
+	// 空赋值确保 Watchdog 实现了 Runner 接口。
+	_ kitruntime.Runner = (*Watchdog)(nil)
+
+	// ErrAlreadyStarted 表示看门狗已经启动。
+	//
+	// 调用方可以使用 errors.Is 判断该错误。
+	ErrAlreadyStarted = errors.New("进程看门狗已经启动。")
+)
+
+type (
+	// Option 定义看门狗配置修改函数。
+	//
+	// 参数：
+	//   - w：待修改的看门狗实例。
+	Option func(w *Watchdog)
+
+	// threshold 是一条阈值规则。
+	threshold struct {
+		// name 是阈值名称，用于日志与指标标签。
+		name string
+		// exceeded 判断样本是否超过阈值。
+		exceeded func(Sample) bool
+		// action 是超过阈值时执行的回调。
+		action func(Sample) error
+		// fired 是上一次触发的时间。
+		fired time.Time
+	}
+
+	// Watchdog 按固定间隔采样进程资源，写入 Prometheus 指标与日志，并在超过阈值时执行回调。
+	//
+	// Watchdog 实现 kitruntime.Runner；也可以不启动后台循环，直接调用 Collect 手动采样。
+	// 所有方法均可并发调用。
+	Watchdog struct {
+		// name 是写入指标标签的名称。
+		name string
+		// interval 是采样间隔。
+		interval time.Duration
+		// cooldown 是同一阈值两次触发之间的最短间隔。
+		cooldown time.Duration
+		// metrics 指示是否写入 Prometheus 指标。
+		metrics bool
+		// logger 是采样与告警日志的输出目标，为 nil 时不输出日志。
+		logger kitlog.Logger
+
+		// mu 保护以下可变状态，并串行化采样。
+		mu sync.Mutex
+		// thresholds 是阈值规则。
+		thresholds []*threshold
+		// clock 是计算 CPU 使用率的上一次采样点。
+		clock cpuClock
+		// last 是最近一次采样结果。
+		last Sample
+		// stop 用于通知后台循环退出，为 nil 时表示未启动。
+		stop chan struct{}
+		// done 在后台循环退出时关闭。
+		done chan struct{}
+	}
+)
+
+// WithName 设置看门狗名称。
+//
+// 参数：
+//   - name：写入指标 name 标签和日志字段的名称。
+//
+// 返回：
+//   - Option：用于更新名称的选项函数。
+func WithName(name string) Option {
+	return func(w *Watchdog) {
+		w.name = name
+	}
+}
+
+// WithInterval 设置采样间隔。
+//
+// 参数：
+//   - interval：采样间隔，默认 10 秒；小于等于 0 时保留默认值。
+//
+// 返回：
+//   - Option：用于更新采样间隔的选项函数。
+func WithInterval(interval time.Duration) Option {
+	return func(w *Watchdog) {
+		if interval > 0 {
+			w.interval = interval
+		}
+	}
+}
+
+// WithCooldown 设置同一阈值两次触发之间的最短间隔，避免持续超标时反复执行回调。
+//
+// 参数：
+//   - cooldown：冷却期，默认 1 分钟；为 0 时每次采样超标都会触发，小于 0 时保留默认值。
+//
+// 返回：
+//   - Option：用于更新冷却期的选项函数。
+func WithCooldown(cooldown time.Duration) Option {
+	return func(w *Watchdog) {
+		if cooldown >= 0 {
+			w.cooldown = cooldown
+		}
+	}
+}
+
+// WithMetrics 设置是否写入 Prometheus 指标。
+//
+// 参数：
+//   - metrics：为 true 时每次采样更新 MetricProcessCurrent 与 MetricThresholdExceeded，默认开启。
+//
+// 返回：
+//   - Option：用于更新指标开关的选项函数。
+func WithMetrics(metrics bool) Option {
+	return func(w *Watchdog) {
+		w.metrics = metrics
+	}
+}
+
+// WithLogger 设置日志输出。
+//
+// 每次采样以 Debug 级别输出样本字段，阈值触发以 Warn 级别输出，回调失败以 Error 级别输出。
+//
+// 参数：
+//   - logger：日志实例，为 nil 时不输出日志。
+//
+// 返回：
+//   - Option：用于更新日志输出的选项函数。
+func WithLogger(logger kitlog.Logger) Option {
+	return func(w *Watchdog) {
+		w.logger = logger
+	}
+}
+
+// WithThreshold 添加一条阈值规则。
+//
+// 每次采样后依次判断所有规则，exceeded 成立且距上一次触发已超过冷却期时执行 action。
+// action 在采样协程中同步执行，耗时操作应自行异步处理。
+//
+// 参数：
+//   - name：阈值名称，用于日志和指标标签。
+//   - exceeded：判断样本是否超标，例如 GoroutinesAbove(10000)；为 nil 时忽略该规则。
+//   - action：超标时执行的回调，例如 DumpProfile；可为 nil，此时只记录日志和指标。
+//
+// 返回：
+//   - Option：用于添加阈值规则的选项函数。
+func WithThreshold(name string, exceeded func(Sample) bool, action func(Sample) error) Option {
+	return func(w *Watchdog) {
+		if nil != exceeded {
+			w.thresholds = append(w.thresholds, &threshold{name: name, exceeded: exceeded, action: action})
+		}
+	}
+}
+
+// NewWatchdog 创建进程资源看门狗。
+//
+// 创建时记录 CPU 使用率的起始采样点，第一次采样的 CPU 使用率为创建以来的平均值。
+//
+// 参数：
+//   - opts：可选配置项，按传入顺序覆盖默认配置。
+//
+// 返回：
+//   - *Watchdog：尚未启动的看门狗实例。
+func NewWatchdog(opts ...Option) *Watchdog {
+	w := &Watchdog{
+		interval: intervalDefault,
+		cooldown: cooldownDefault,
+		metrics:  metricsDefault,
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+
+	w.clock.wall = time.Now()
+	if cpu, ok := cpuTime(); ok {
+		w.clock.cpu = cpu
+	}
+	return w
+}
+
+// Start 启动后台采样循环。
+//
+// 启动时立即采样一次，之后按采样间隔采样，直到 Stop 被调用或 ctx 结束。
+//
+// 参数：
+//   - ctx：后台循环的生命周期，结束时循环退出。
+//
+// 返回：
+//   - error：已经启动时返回 ErrAlreadyStarted。
+func (w *Watchdog) Start(ctx context.Context) error {
+	w.mu.Lock()
+	if nil != w.stop {
+		w.mu.Unlock()
+		return ErrAlreadyStarted
+	}
+	stop, done := make(chan struct{}), make(chan struct{})
+	w.stop, w.done = stop, done
+	w.mu.Unlock()
+
+	go w.loop(ctx, stop, done)
+	return nil
+}
+
+// Stop 停止后台采样循环并等待其退出。
+//
+// 未启动时直接返回 nil；停止后可以再次调用 Start。
+//
+// 参数：
+//   - ctx：等待循环退出的截止时间。
+//
+// 返回：
+//   - error：ctx 在循环退出前结束时返回 ctx.Err()。
+func (w *Watchdog) Stop(ctx context.Context) error {
+	w.mu.Lock()
+	stop, done := w.stop, w.done
+	w.stop, w.done = nil, nil
+	w.mu.Unlock()
+
+	if nil == stop {
+		return nil
+	}
+	close(stop)
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Collect 立即采样一次，写入指标与日志并判断阈值。
+//
+// 参数：无。
+//
+// 返回：
+//   - Sample：本次采样结果。
+func (w *Watchdog) Collect() Sample {
+	w.mu.Lock()
+	s := collect(time.Now(), &w.clock)
+	w.last = s
+	var fired []*threshold
+	for _, t := range w.thresholds {
+		if !t.exceeded(s) || (!t.fired.IsZero() && s.Time.Sub(t.fired) < w.cooldown) {
+			continue
+		}
+		t.fired = s.Time
+		fired = append(fired, t)
+	}
+	w.mu.Unlock()
+
+	if w.metrics {
+		collectProcessMetrics(w.name, s)
+	}
+	if nil != w.logger {
+		w.logger.WithFields(map[string]interface{}{
+			"name":          w.name,
+			"goroutines":    s.Goroutines,
+			"heap_alloc":    s.HeapAlloc,
+			"heap_inuse":    s.HeapInuse,
+			"num_gc":        s.NumGC,
+			"gc_pause_last": s.GCPauseLast.String(),
+			"fds":           s.FDs,
+			"cpu_percent":   s.CPUPercent,
+		}).Debug("进程资源采样")
+	}
+
+	for _, t := range fired {
+		w.fire(t, s)
+	}
+	return s
+}
+
+// Last 返回最近一次采样结果。
+//
+// 参数：无。
+//
+// 返回：
+//   - Sample：最近一次采样结果；尚未采样时为零值。
+func (w *Watchdog) Last() Sample {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.last
+}
+
+// loop 按采样间隔执行采样，直到 stop 关闭或 ctx 结束。
+//
+// 参数：
+//   - ctx：后台循环的生命周期。
+//   - stop：退出信号。
+//   - done：循环退出时关闭。
+func (w *Watchdog) loop(ctx context.Context, stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	w.Collect()
+	for {
+		select {
+		case <-ticker.C:
+			w.Collect()
+		case <-stop:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// fire 执行一次阈值触发：记录指标与日志并调用回调。
+//
+// 参数：
+//   - t：被触发的阈值规则。
+//   - s：触发时的样本。
+func (w *Watchdog) fire(t *threshold, s Sample) {
+	if w.metrics {
+		MetricThresholdExceeded.WithLabelValues(w.name, t.name).Inc()
+	}
+	if nil != w.logger {
+		w.logger.Warnf("进程资源超过阈值 %s：goroutines=%d heap_alloc=%d fds=%d cpu=%.1f%%",
+			t.name, s.Goroutines, s.HeapAlloc, s.FDs, s.CPUPercent)
+	}
+	if nil == t.action {
+		return
+	}
+	if err := t.action(s); nil != err && nil != w.logger {
+		w.logger.Errorf("进程资源阈值 %s 的回调执行失败：%v", t.name, err)
+	}
+}
+
+// DumpProfile 返回将 pprof 剖析写入文件的阈值回调。
+//
+// 文件名形如 "goroutine-20250102T150405.000.pprof"，写入 dir 目录；目录不存在时自动创建。
+// 常用于 goroutine 数量激增时保存 goroutine 栈，或内存超标时保存 heap 剖析。
+//
+// 参数：
+//   - dir：输出目录。
+//   - profile：pprof 剖析名称，例如 "goroutine"、"heap"、"allocs"。
+//
+// 返回：
+//   - func(Sample) error：用于 WithThreshold 的回调；剖析不存在或写入失败时返回错误。
+func DumpProfile(dir, profile string) func(Sample) error {
+	return func(s Sample) error {
+		p := pprof.Lookup(profile)
+		if nil == p {
+			return fmt.Errorf("pprof 剖析 %s 不存在", profile)
+		}
+		if err := os.MkdirAll(dir, 0o755); nil != err {
+			return fmt.Errorf("创建剖析目录失败：%w", err)
+		}
+		name := filepath.Join(dir, fmt.Sprintf("%s-%s.pprof", profile, s.Time.Format("20060102T150405.000")))
+		f, err := os.Create(name)
+		if nil != err {
+			return fmt.Errorf("创建剖析文件失败：%w", err)
+		}
+		if err := p.WriteTo(f, 0); nil != err {
+			_ = f.Close()
+			return fmt.Errorf("写入剖析文件失败：%w", err)
+		}
+		return f.Close()
+	}
+}
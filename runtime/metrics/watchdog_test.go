@@ -0,0 +1,132 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package metrics
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestWatchdog_Collect 验证手动采样会写入指标并记录最近一次结果。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestWatchdog_Collect(t *testing.T) {
+	const name = "test-watchdog-collect"
+	t.Cleanup(func() {
+		MetricProcessCurrent.DeletePartialMatch(map[string]string{"name": name})
+	})
+
+	w := NewWatchdog(WithName(name))
+	assert.Zero(t, w.Last())
+
+	s := w.Collect()
+	assert.Equal(t, s, w.Last())
+	assert.Equal(t, float64(s.Goroutines), testutil.ToFloat64(MetricProcessCurrent.WithLabelValues(name, "goroutines")))
+	assert.Equal(t, float64(s.HeapAlloc), testutil.ToFloat64(MetricProcessCurrent.WithLabelValues(name, "heap_alloc_bytes")))
+}
+
+// TestWatchdog_Threshold 验证阈值触发、冷却期与回调错误处理。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestWatchdog_Threshold(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		cooldown    time.Duration
+		exceeded    func(Sample) bool
+		action      error
+		wantFired   int32
+	}{
+		{name: "success/cooldown", description: "验证冷却期内只触发一次。", cooldown: time.Hour, exceeded: GoroutinesAbove(0), wantFired: 1},
+		{name: "success/no-cooldown", description: "验证冷却期为 0 时每次超标都触发。", exceeded: GoroutinesAbove(0), wantFired: 3},
+		{name: "success/not-exceeded", description: "验证未超标时不触发。", cooldown: 0, exceeded: GoroutinesAbove(1 << 30)},
+		{name: "error/action", description: "验证回调失败不影响后续采样。", exceeded: GoroutinesAbove(0), action: errors.New("dump failed"), wantFired: 3},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			var fired atomic.Int32
+			w := NewWatchdog(
+				WithMetrics(false),
+				WithCooldown(tt.cooldown),
+				WithThreshold("goroutines", tt.exceeded, func(Sample) error {
+					fired.Add(1)
+					return tt.action
+				}),
+				WithThreshold("ignored", nil, nil),
+			)
+			for i := 0; i < 3; i++ {
+				w.Collect()
+			}
+			assert.Equal(t, tt.wantFired, fired.Load())
+		})
+	}
+}
+
+// TestWatchdog_StartStop 验证后台循环的启动、重复启动与停止。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestWatchdog_StartStop(t *testing.T) {
+	var samples atomic.Int32
+	w := NewWatchdog(
+		WithMetrics(false),
+		WithInterval(time.Millisecond),
+		WithCooldown(0),
+		WithThreshold("always", func(Sample) bool { return true }, func(Sample) error {
+			samples.Add(1)
+			return nil
+		}),
+	)
+
+	ctx := context.Background()
+	require.NoError(t, w.Stop(ctx))
+	require.NoError(t, w.Start(ctx))
+	assert.ErrorIs(t, w.Start(ctx), ErrAlreadyStarted)
+	assert.Eventually(t, func() bool { return samples.Load() >= 3 }, time.Second, time.Millisecond)
+	require.NoError(t, w.Stop(ctx))
+
+	stopped := samples.Load()
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, stopped, samples.Load())
+
+	cancelCtx, cancel := context.WithCancel(ctx)
+	require.NoError(t, w.Start(cancelCtx))
+	cancel()
+	require.NoError(t, w.Stop(ctx))
+}
+
+// TestDumpProfile 验证 pprof 剖析写入文件。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestDumpProfile(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "profiles")
+	s := Sample{Time: time.Date(2025, 1, 2, 15, 4, 5, 0, time.UTC)}
+
+	require.NoError(t, DumpProfile(dir, "goroutine")(s))
+	info, err := os.Stat(filepath.Join(dir, "goroutine-20250102T150405.000.pprof"))
+	require.NoError(t, err)
+	assert.Positive(t, info.Size())
+
+	err = DumpProfile(dir, "missing")(s)
+	require.Error(t, err)
+	assert.True(t, strings.Contains(err.Error(), "missing"))
+}
@@ -0,0 +1,529 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrInvalidConstraint 表示版本约束表达式不合法。
+	ErrInvalidConstraint = errors.New("semver: invalid constraint")
+)
+
+const (
+	// opEQ 表示等于。
+	opEQ operator = iota
+	// opNE 表示不等于。
+	opNE
+	// opGT 表示大于。
+	opGT
+	// opGE 表示大于等于。
+	opGE
+	// opLT 表示小于。
+	opLT
+	// opLE 表示小于等于。
+	opLE
+)
+
+var (
+	// operatorTokens 是支持的运算符，较长的运算符排在前面以便最长匹配。
+	operatorTokens = []string{">=", "<=", "!=", "==", "~>", ">", "<", "=", "~", "^"}
+)
+
+type (
+	// Constraint 表示一个已解析的版本约束表达式，可以被多个 goroutine 并发使用。
+	//
+	// 表达式由 || 分隔的若干组组成，任一组满足即匹配；组内以空格或逗号分隔的比较条件需要全部满足。
+	// 支持的比较条件：
+	//   - =、==、!=、>、>=、<、<=：与指定版本比较，省略运算符等同于 =。
+	//   - 省略部分或使用 x、X、* 通配的版本：=1.2 与 1.2.x 表示 >=1.2.0 <1.3.0，>1.2 表示 >=1.3.0，
+	//     <=1.2 表示 <1.3.0，* 表示任意版本。
+	//   - ~1.2.3：允许修订号变化，即 >=1.2.3 <1.3.0；~1 表示 >=1.0.0 <2.0.0。~> 与 ~ 相同。
+	//   - ^1.2.3：允许不改变最左侧非零版本号的变化，即 >=1.2.3 <2.0.0；^0.2.3 表示 >=0.2.3 <0.3.0。
+	//   - 1.2 - 2.3：闭区间，即 >=1.2.0 <=2.3 的通配展开。
+	//
+	// 预发布版本默认只在同一组中存在主、次、修订号相同且带预发布标识的比较条件时才可能匹配，
+	// 例如 >=1.2.0-rc.1 匹配 1.2.0-rc.2 但不匹配 1.3.0-beta；需要让预发布版本参与普通比较时使用 CheckPrerelease。
+	Constraint struct {
+		// raw 是原始表达式。
+		raw string
+		// groups 是展开后的比较条件组。
+		groups [][]comparator
+	}
+
+	// comparator 是展开后的单个比较条件。
+	comparator struct {
+		// op 是比较运算符。
+		op operator
+		// version 是比较的目标版本。
+		version Version
+	}
+
+	// operator 是展开后的比较运算符。
+	operator int
+)
+
+// ParseConstraint 解析版本约束表达式。
+//
+// 参数：
+//   - s: 约束表达式，例如 ">=1.2 <2.0"、"^1.4 || ~2.1.0"；空字符串等同于 "*"。
+//
+// 返回：
+//   - *Constraint: 解析结果。
+//   - error: 表达式不合法时返回包装 ErrInvalidConstraint 的错误。
+func ParseConstraint(s string) (*Constraint, error) {
+	c := &Constraint{raw: strings.TrimSpace(s)}
+	for _, part := range strings.Split(c.raw, "||") {
+		// 整个表达式为空时等同于 *，但 || 两侧的空组视为书写错误。
+		if "" != c.raw && "" == strings.TrimSpace(part) {
+			return nil, fmt.Errorf("%w: %q: empty group", ErrInvalidConstraint, s)
+		}
+		group, err := parseGroup(part)
+		if nil != err {
+			return nil, fmt.Errorf("%w: %q: %v", ErrInvalidConstraint, s, err)
+		}
+		c.groups = append(c.groups, group)
+	}
+	return c, nil
+}
+
+// MustParseConstraint 解析版本约束表达式，表达式不合法时 panic。
+//
+// 参数：
+//   - s: 约束表达式。
+//
+// 返回：
+//   - *Constraint: 解析结果。
+func MustParseConstraint(s string) *Constraint {
+	c, err := ParseConstraint(s)
+	if nil != err {
+		panic(err)
+	}
+	return c
+}
+
+// Satisfies 判断版本号是否满足约束表达式，适用于按客户端版本灰度等一次性判断。
+//
+// 需要对同一表达式多次判断时，应使用 ParseConstraint 解析一次后复用。
+//
+// 参数：
+//   - version: 版本号字符串，按 Parse 的规则解析。
+//   - constraint: 约束表达式，按 ParseConstraint 的规则解析。
+//
+// 返回：
+//   - bool: version 满足 constraint 时为 true。
+//   - error: 版本号或表达式不合法时返回错误。
+func Satisfies(version, constraint string) (bool, error) {
+	v, err := Parse(version)
+	if nil != err {
+		return false, err
+	}
+	c, err := ParseConstraint(constraint)
+	if nil != err {
+		return false, err
+	}
+	return c.Check(v), nil
+}
+
+// Check 判断版本是否满足约束，预发布版本按 Constraint 描述的规则处理。
+//
+// 参数：
+//   - v: 待判断的版本。
+//
+// 返回：
+//   - bool: 任一组的全部比较条件满足时为 true。
+func (c *Constraint) Check(v Version) bool {
+	for _, group := range c.groups {
+		if matchGroup(group, v) && (!v.IsPrerelease() || allowsPrerelease(group, v)) {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckPrerelease 判断版本是否满足约束，预发布版本与正式版本一样只按优先级比较。
+//
+// 例如 >=1.2 <2.0 匹配 1.5.0-beta，也匹配低于 2.0.0 的 2.0.0-rc.1。
+//
+// 参数：
+//   - v: 待判断的版本。
+//
+// 返回：
+//   - bool: 任一组的全部比较条件满足时为 true。
+func (c *Constraint) CheckPrerelease(v Version) bool {
+	for _, group := range c.groups {
+		if matchGroup(group, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// String 返回原始约束表达式。
+//
+// 参数：无。
+//
+// 返回：
+//   - string: 去除首尾空白的原始表达式。
+func (c *Constraint) String() string {
+	return c.raw
+}
+
+// MarshalText 实现 encoding.TextMarshaler，输出原始约束表达式。
+//
+// 参数：无。
+//
+// 返回：
+//   - []byte: 原始表达式。
+//   - error: 始终为 nil。
+func (c *Constraint) MarshalText() ([]byte, error) {
+	return []byte(c.raw), nil
+}
+
+// UnmarshalText 实现 encoding.TextUnmarshaler，按 ParseConstraint 的规则解析约束表达式。
+//
+// 参数：
+//   - text: 约束表达式。
+//
+// 返回：
+//   - error: 表达式不合法时返回包装 ErrInvalidConstraint 的错误。
+func (c *Constraint) UnmarshalText(text []byte) error {
+	parsed, err := ParseConstraint(string(text))
+	if nil != err {
+		return err
+	}
+	*c = *parsed
+	return nil
+}
+
+// matchGroup 判断版本是否满足组内全部比较条件。
+//
+// 参数：
+//   - group: 比较条件组。
+//   - v: 待判断的版本。
+//
+// 返回：
+//   - bool: 全部满足时为 true。
+func matchGroup(group []comparator, v Version) bool {
+	for _, cmp := range group {
+		if !cmp.match(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// allowsPrerelease 判断组内是否存在允许该预发布版本的比较条件。
+//
+// 参数：
+//   - group: 比较条件组。
+//   - v: 预发布版本。
+//
+// 返回：
+//   - bool: 存在主、次、修订号与 v 相同且带预发布标识的比较条件时为 true。
+func allowsPrerelease(group []comparator, v Version) bool {
+	core := v.Core()
+	for _, cmp := range group {
+		if cmp.version.IsPrerelease() && cmp.version.Core() == core {
+			return true
+		}
+	}
+	return false
+}
+
+// match 判断版本是否满足比较条件。
+//
+// 参数：
+//   - v: 待判断的版本。
+//
+// 返回：
+//   - bool: 满足时为 true。
+func (c comparator) match(v Version) bool {
+	r := Compare(v, c.version)
+	switch c.op {
+	case opEQ:
+		return 0 == r
+	case opNE:
+		return 0 != r
+	case opGT:
+		return r > 0
+	case opGE:
+		return r >= 0
+	case opLT:
+		return r < 0
+	default:
+		return r <= 0
+	}
+}
+
+// parseGroup 解析 || 之间的一组比较条件。
+//
+// 参数：
+//   - s: 组表达式。
+//
+// 返回：
+//   - []comparator: 展开后的比较条件，为空表示匹配任意版本。
+//   - error: 表达式不合法时返回错误。
+func parseGroup(s string) ([]comparator, error) {
+	tokens := tokenize(s)
+
+	var group []comparator
+	for i := 0; i < len(tokens); i++ {
+		// 闭区间 A - B。
+		if i+2 < len(tokens) && "-" == tokens[i+1] {
+			cmps, err := parseHyphenRange(tokens[i], tokens[i+2])
+			if nil != err {
+				return nil, err
+			}
+			group = append(group, cmps...)
+			i += 2
+			continue
+		}
+
+		cmps, err := parseComparator(tokens[i])
+		if nil != err {
+			return nil, err
+		}
+		group = append(group, cmps...)
+	}
+	return group, nil
+}
+
+// tokenize 将组表达式按空白和逗号切分，并把单独出现的运算符与其后的版本号合并。
+//
+// 参数：
+//   - s: 组表达式。
+//
+// 返回：
+//   - []string: 切分结果。
+func tokenize(s string) []string {
+	fields := strings.Fields(strings.ReplaceAll(s, ",", " "))
+	tokens := make([]string, 0, len(fields))
+	for i := 0; i < len(fields); i++ {
+		if isOperator(fields[i]) && i+1 < len(fields) {
+			tokens = append(tokens, fields[i]+fields[i+1])
+			i++
+			continue
+		}
+		tokens = append(tokens, fields[i])
+	}
+	return tokens
+}
+
+// parseHyphenRange 展开闭区间表达式。
+//
+// 参数：
+//   - lower: 下界版本，可以省略部分或使用通配。
+//   - upper: 上界版本，可以省略部分或使用通配。
+//
+// 返回：
+//   - []comparator: 展开后的比较条件。
+//   - error: 版本不合法时返回错误。
+func parseHyphenRange(lower, upper string) ([]comparator, error) {
+	lo, err := parseComparator(">=" + lower)
+	if nil != err {
+		return nil, err
+	}
+	hi, err := parseComparator("<=" + upper)
+	if nil != err {
+		return nil, err
+	}
+	return append(lo, hi...), nil
+}
+
+// parseComparator 解析并展开单个比较条件。
+//
+// 参数：
+//   - token: 运算符与版本号组成的比较条件，例如 >=1.2、^1.4.0、1.x。
+//
+// 返回：
+//   - []comparator: 展开后的比较条件，为空表示匹配任意版本。
+//   - error: 运算符或版本不合法、或条件不可能被满足时返回错误。
+func parseComparator(token string) ([]comparator, error) {
+	op, rest := splitOperator(token)
+	v, n, err := parseWildcard(rest)
+	if nil != err {
+		return nil, err
+	}
+
+	switch op {
+	case "", "=", "==":
+		switch n {
+		case 0:
+			return nil, nil
+		case 3:
+			return []comparator{{opEQ, v}}, nil
+		default:
+			return []comparator{{opGE, v}, {opLT, bump(v, n)}}, nil
+		}
+	case "!=":
+		if n < 3 {
+			return nil, fmt.Errorf("%q: != requires a full version", token)
+		}
+		return []comparator{{opNE, v}}, nil
+	case ">":
+		switch n {
+		case 0:
+			return nil, fmt.Errorf("%q: matches no version", token)
+		case 3:
+			return []comparator{{opGT, v}}, nil
+		default:
+			return []comparator{{opGE, bump(v, n)}}, nil
+		}
+	case ">=":
+		if 0 == n {
+			return nil, nil
+		}
+		return []comparator{{opGE, v}}, nil
+	case "<":
+		if 0 == n {
+			return nil, fmt.Errorf("%q: matches no version", token)
+		}
+		return []comparator{{opLT, v}}, nil
+	case "<=":
+		switch n {
+		case 0:
+			return nil, nil
+		case 3:
+			return []comparator{{opLE, v}}, nil
+		default:
+			return []comparator{{opLT, bump(v, n)}}, nil
+		}
+	case "~", "~>":
+		if 0 == n {
+			return nil, nil
+		}
+		if 1 == n {
+			return []comparator{{opGE, v}, {opLT, bump(v, 1)}}, nil
+		}
+		return []comparator{{opGE, v}, {opLT, bump(v, 2)}}, nil
+	case "^":
+		if 0 == n {
+			return nil, nil
+		}
+		var upper Version
+		switch {
+		case v.Major > 0 || 1 == n:
+			upper = bump(v, 1)
+		case v.Minor > 0 || 2 == n:
+			upper = bump(v, 2)
+		default:
+			upper = Version{Patch: v.Patch + 1}
+		}
+		return []comparator{{opGE, v}, {opLT, upper}}, nil
+	default:
+		return nil, fmt.Errorf("%q: unknown operator", token)
+	}
+}
+
+// splitOperator 拆分比较条件开头的运算符。
+//
+// 参数：
+//   - token: 比较条件。
+//
+// 返回：
+//   - string: 运算符，没有运算符时为空字符串；无法识别的运算符原样返回以便报错。
+//   - string: 去除运算符后的版本部分。
+func splitOperator(token string) (string, string) {
+	for _, op := range operatorTokens {
+		if strings.HasPrefix(token, op) {
+			rest := token[len(op):]
+			// 形如 >=>1 的连续运算符不合法，交由调用方报错。
+			if "" != rest && strings.ContainsRune("<>=!~^", rune(rest[0])) {
+				return token, ""
+			}
+			return op, rest
+		}
+	}
+	if "" != token && strings.ContainsRune("<>=!~^", rune(token[0])) {
+		return token, ""
+	}
+	return "", token
+}
+
+// parseWildcard 解析可能省略部分或使用 x、X、* 通配的版本号。
+//
+// 参数：
+//   - s: 版本号。
+//
+// 返回：
+//   - Version: 解析结果，省略或通配的部分为 0。
+//   - int: 明确给出的数字段数，0 表示任意版本。
+//   - error: 版本号不合法时返回错误。
+func parseWildcard(s string) (Version, int, error) {
+	rest := strings.TrimPrefix(strings.TrimPrefix(s, "v"), "V")
+	if "" == rest {
+		return Version{}, 0, errors.New("missing version")
+	}
+
+	core := rest
+	if i := strings.IndexAny(rest, "-+"); i >= 0 {
+		core = rest[:i]
+	}
+	parts := strings.Split(core, ".")
+	for i, part := range parts {
+		if !isWildcard(part) {
+			continue
+		}
+		for _, p := range parts[i+1:] {
+			if !isWildcard(p) {
+				return Version{}, 0, fmt.Errorf("%q: wildcard followed by number", s)
+			}
+		}
+		if len(core) != len(rest) {
+			return Version{}, 0, fmt.Errorf("%q: wildcard with prerelease or build", s)
+		}
+		if 0 == i {
+			return Version{}, 0, nil
+		}
+		return parsePartial(strings.Join(parts[:i], "."))
+	}
+	return parsePartial(rest)
+}
+
+// bump 返回省略部分的版本范围的上界。
+//
+// 参数：
+//   - v: 下界版本。
+//   - n: 明确给出的数字段数，1 表示递增主版本号，2 表示递增次版本号。
+//
+// 返回：
+//   - Version: 不含预发布标识的上界版本。
+func bump(v Version, n int) Version {
+	if 1 == n {
+		return Version{Major: v.Major + 1}
+	}
+	return Version{Major: v.Major, Minor: v.Minor + 1}
+}
+
+// isWildcard 判断版本段是否为通配符。
+//
+// 参数：
+//   - s: 版本段。
+//
+// 返回：
+//   - bool: 为 x、X 或 * 时为 true。
+func isWildcard(s string) bool {
+	return "x" == s || "X" == s || "*" == s
+}
+
+// isOperator 判断字符串是否只由一个运算符组成。
+//
+// 参数：
+//   - s: 待判断的字符串。
+//
+// 返回：
+//   - bool: 与某个支持的运算符完全相同时为 true。
+func isOperator(s string) bool {
+	for _, op := range operatorTokens {
+		if op == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,193 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package semver
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestConstraint_Check 验证各类约束表达式的匹配结果。
+func TestConstraint_Check(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		constraint  string
+		match       []string
+		noMatch     []string
+	}{
+		{
+			name:        "success/range",
+			description: "空格分隔的条件需要同时满足，省略的部分按 0 处理。",
+			constraint:  ">=1.2 <2.0",
+			match:       []string{"1.2.0", "1.9.99"},
+			noMatch:     []string{"1.1.9", "2.0.0", "2.0.0-rc.1", "1.5.0-beta"},
+		},
+		{
+			name:        "success/comma-and-spaced-operator",
+			description: "允许逗号分隔以及运算符与版本号之间的空格。",
+			constraint:  ">= 1.2.3, < 1.3",
+			match:       []string{"1.2.3", "1.2.10"},
+			noMatch:     []string{"1.3.0", "1.2.2"},
+		},
+		{
+			name:        "success/or",
+			description: "|| 分隔的任一组满足即匹配。",
+			constraint:  "<1.0 || >=2.1.0",
+			match:       []string{"0.9.0", "2.1.0", "3.0.0"},
+			noMatch:     []string{"1.0.0", "2.0.5"},
+		},
+		{
+			name:        "success/exact-and-not-equal",
+			description: "完整版本的 = 与 !=。",
+			constraint:  "1.2.3 || >=2.0.0 !=2.0.1",
+			match:       []string{"1.2.3", "v1.2.3+build", "2.0.0", "2.0.2"},
+			noMatch:     []string{"1.2.4", "2.0.1"},
+		},
+		{
+			name:        "success/partial-operators",
+			description: "省略部分的 >、<= 与 = 按范围展开。",
+			constraint:  ">1.2 <=2",
+			match:       []string{"1.3.0", "2.9.9"},
+			noMatch:     []string{"1.2.9", "3.0.0"},
+		},
+		{
+			name:        "success/wildcard",
+			description: "x、X 与 * 通配。",
+			constraint:  "1.2.x || 3.X || 4.*",
+			match:       []string{"1.2.0", "1.2.99", "3.5.1", "4.0.0"},
+			noMatch:     []string{"1.3.0", "2.0.0", "5.0.0"},
+		},
+		{
+			name:        "success/tilde",
+			description: "~ 允许修订号变化，只给出主版本号时允许次版本号变化。",
+			constraint:  "~1.2.3 || ~>2.4 || ~3",
+			match:       []string{"1.2.3", "1.2.9", "2.4.0", "2.4.7", "3.9.0"},
+			noMatch:     []string{"1.3.0", "1.2.2", "2.5.0", "4.0.0"},
+		},
+		{
+			name:        "success/caret",
+			description: "^ 允许不改变最左侧非零版本号的变化。",
+			constraint:  "^1.2.3 || ^0.5.1 || ^0.0.7",
+			match:       []string{"1.2.3", "1.99.0", "0.5.9", "0.0.7"},
+			noMatch:     []string{"2.0.0", "1.2.2", "0.6.0", "0.0.8", "0.5.0"},
+		},
+		{
+			name:        "success/caret-partial",
+			description: "^0.0 与 ^0 按给出的段数确定上界。",
+			constraint:  "^0.0",
+			match:       []string{"0.0.0", "0.0.9"},
+			noMatch:     []string{"0.1.0"},
+		},
+		{
+			name:        "success/hyphen",
+			description: "闭区间的上界按通配展开。",
+			constraint:  "1.2.3 - 2.3",
+			match:       []string{"1.2.3", "2.3.9"},
+			noMatch:     []string{"1.2.2", "2.4.0"},
+		},
+		{
+			name:        "success/prerelease-same-core",
+			description: "预发布版本只在存在相同主、次、修订号的预发布条件时匹配。",
+			constraint:  ">=1.2.0-rc.1 <2.0.0",
+			match:       []string{"1.2.0-rc.1", "1.2.0-rc.2", "1.2.0", "1.5.0"},
+			noMatch:     []string{"1.2.0-beta", "1.3.0-beta", "1.2.0-alpha"},
+		},
+		{
+			name:        "boundary/any",
+			description: "空表达式与 * 匹配任意正式版本。",
+			constraint:  "",
+			match:       []string{"0.0.0", "99.0.0"},
+			noMatch:     []string{"1.0.0-rc.1"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			c, err := ParseConstraint(tt.constraint)
+			require.NoError(t, err)
+			for _, v := range tt.match {
+				assert.True(t, c.Check(MustParse(v)), "%q should match %s", tt.constraint, v)
+			}
+			for _, v := range tt.noMatch {
+				assert.False(t, c.Check(MustParse(v)), "%q should not match %s", tt.constraint, v)
+			}
+		})
+	}
+}
+
+// TestConstraint_CheckPrerelease 验证预发布版本按优先级参与比较。
+func TestConstraint_CheckPrerelease(t *testing.T) {
+	c := MustParseConstraint(">=1.2 <2.0")
+	assert.True(t, c.CheckPrerelease(MustParse("1.5.0-beta")))
+	assert.True(t, c.CheckPrerelease(MustParse("2.0.0-rc.1")))
+	assert.False(t, c.CheckPrerelease(MustParse("1.2.0-rc.1")))
+	assert.False(t, c.Check(MustParse("1.5.0-beta")))
+}
+
+// TestParseConstraint_Error 验证不合法的约束表达式。
+func TestParseConstraint_Error(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		give        string
+	}{
+		{name: "error/unknown-operator", description: "不支持的运算符。", give: "=>1.2"},
+		{name: "error/bang", description: "单独的 ! 不是运算符。", give: "!1.2.3"},
+		{name: "error/partial-not-equal", description: "!= 需要完整版本。", give: "!=1.2"},
+		{name: "error/greater-than-any", description: ">* 不可能被满足。", give: ">*"},
+		{name: "error/less-than-any", description: "<* 不可能被满足。", give: "<x"},
+		{name: "error/wildcard-then-number", description: "通配符之后不能出现数字段。", give: "1.x.3"},
+		{name: "error/wildcard-prerelease", description: "通配版本不能带预发布标识。", give: "1.x-rc"},
+		{name: "error/empty-group", description: "|| 两侧不能为空。", give: ">=1.0 ||"},
+		{name: "error/dangling-operator", description: "运算符后缺少版本号。", give: ">="},
+		{name: "error/invalid-version", description: "版本号不合法。", give: ">=1.02"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			_, err := ParseConstraint(tt.give)
+			assert.ErrorIs(t, err, ErrInvalidConstraint)
+		})
+	}
+	assert.Panics(t, func() { MustParseConstraint("=>1") })
+}
+
+// TestSatisfies 验证一次性判断的便捷函数。
+func TestSatisfies(t *testing.T) {
+	ok, err := Satisfies("v2.3.0", ">=2.1 <3")
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	_, err = Satisfies("2.3", ">=2.1")
+	assert.ErrorIs(t, err, ErrInvalidVersion)
+	_, err = Satisfies("2.3.0", ">>2.1")
+	assert.ErrorIs(t, err, ErrInvalidConstraint)
+}
+
+// TestConstraint_Text 验证约束表达式的文本编解码。
+func TestConstraint_Text(t *testing.T) {
+	var cfg struct {
+		MinClient *Constraint `json:"min_client"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(`{"min_client":" ^1.4 "}`), &cfg))
+	assert.Equal(t, "^1.4", cfg.MinClient.String())
+	assert.True(t, cfg.MinClient.Check(MustParse("1.9.0")))
+
+	data, err := json.Marshal(cfg)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"min_client":"^1.4"}`, string(data))
+
+	assert.ErrorIs(t, json.Unmarshal([]byte(`{"min_client":"^^1"}`), &cfg), ErrInvalidConstraint)
+}
@@ -0,0 +1,14 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+// Package semver 提供语义化版本 2.0.0 的解析、比较、排序以及版本约束匹配。
+//
+// Parse 解析 MAJOR.MINOR.PATCH[-PRERELEASE][+BUILD] 格式的版本号，允许 v 前缀；Compare 按规范的优先级规则比较，
+// 预发布版本低于对应的正式版本，构建元数据不参与比较；Sort 与 Max 基于 Compare 排序和取最大值。
+//
+// ParseConstraint 解析 ">=1.2 <2.0"、"^1.4 || ~2.1.0"、"1.2.x" 等约束表达式，Constraint.Check 判断版本是否满足，
+// 适用于客户端版本灰度、插件兼容性检查等场景。预发布版本默认只在约束中显式出现同一版本的预发布标识时匹配，
+// CheckPrerelease 则让预发布版本按优先级参与普通比较。Version 与 Constraint 都实现了 encoding.TextMarshaler
+// 和 encoding.TextUnmarshaler，可以直接作为 JSON、YAML 配置字段。
+package semver
@@ -0,0 +1,441 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrInvalidVersion 表示版本号不符合语义化版本 2.0.0 规范。
+	ErrInvalidVersion = errors.New("semver: invalid version")
+)
+
+type (
+	// Version 表示一个语义化版本号，格式为 MAJOR.MINOR.PATCH[-PRERELEASE][+BUILD]。
+	//
+	// 零值表示 0.0.0。Version 是可比较的值类型，但 == 会区分构建元数据，判断版本先后或相等应使用 Compare。
+	Version struct {
+		// Major 是主版本号。
+		Major uint64
+		// Minor 是次版本号。
+		Minor uint64
+		// Patch 是修订号。
+		Patch uint64
+		// Prerelease 是不含前导 - 的预发布标识，例如 rc.1；为空表示正式版本。
+		Prerelease string
+		// Build 是不含前导 + 的构建元数据，例如 20250101.abcdef；不参与版本比较。
+		Build string
+	}
+)
+
+// Parse 解析语义化版本号。
+//
+// 允许带 v 或 V 前缀，例如 v1.2.3-rc.1+build.5；主、次、修订号必须齐全且不含前导零，预发布与构建元数据的
+// 每段只能包含字母、数字和 -，预发布中的纯数字段不能有前导零。
+//
+// 参数：
+//   - s: 版本号字符串。
+//
+// 返回：
+//   - Version: 解析结果。
+//   - error: 格式不合法时返回包装 ErrInvalidVersion 的错误。
+func Parse(s string) (Version, error) {
+	v, n, err := parsePartial(s)
+	if nil != err {
+		return Version{}, err
+	}
+	if n < 3 {
+		return Version{}, fmt.Errorf("%w: %q: missing minor or patch", ErrInvalidVersion, s)
+	}
+	return v, nil
+}
+
+// MustParse 解析语义化版本号，格式不合法时 panic。
+//
+// 适用于初始化包级变量等版本号为常量的场景。
+//
+// 参数：
+//   - s: 版本号字符串。
+//
+// 返回：
+//   - Version: 解析结果。
+func MustParse(s string) Version {
+	v, err := Parse(s)
+	if nil != err {
+		panic(err)
+	}
+	return v
+}
+
+// IsValid 判断字符串是否为合法的语义化版本号。
+//
+// 参数：
+//   - s: 版本号字符串。
+//
+// 返回：
+//   - bool: Parse 能够成功解析时为 true。
+func IsValid(s string) bool {
+	_, err := Parse(s)
+	return nil == err
+}
+
+// Compare 按语义化版本 2.0.0 的优先级规则比较两个版本。
+//
+// 依次比较主、次、修订号；相同时预发布版本低于正式版本，两个预发布版本按点分隔的标识逐段比较：
+// 纯数字段按数值比较且低于非数字段，非数字段按 ASCII 顺序比较，段数较少的一方在前缀相同时较低。
+// 构建元数据不参与比较。
+//
+// 参数：
+//   - a: 第一个版本。
+//   - b: 第二个版本。
+//
+// 返回：
+//   - int: a 低于 b 时为 -1，高于时为 1，优先级相同时为 0。
+func Compare(a, b Version) int {
+	if c := compareUint(a.Major, b.Major); 0 != c {
+		return c
+	}
+	if c := compareUint(a.Minor, b.Minor); 0 != c {
+		return c
+	}
+	if c := compareUint(a.Patch, b.Patch); 0 != c {
+		return c
+	}
+	return comparePrerelease(a.Prerelease, b.Prerelease)
+}
+
+// Sort 按优先级从低到高原地排序版本，优先级相同的版本保持原有顺序。
+//
+// 参数：
+//   - versions: 待排序的版本。
+func Sort(versions []Version) {
+	slices.SortStableFunc(versions, Compare)
+}
+
+// Max 返回优先级最高的版本。
+//
+// 参数：
+//   - versions: 候选版本。
+//
+// 返回：
+//   - Version: 优先级最高的版本，相同时取先出现的一个。
+//   - bool: versions 非空时为 true。
+func Max(versions []Version) (Version, bool) {
+	if 0 == len(versions) {
+		return Version{}, false
+	}
+	max := versions[0]
+	for _, v := range versions[1:] {
+		if Compare(v, max) > 0 {
+			max = v
+		}
+	}
+	return max, true
+}
+
+// Compare 比较当前版本与 o 的优先级，语义同包级函数 Compare。
+//
+// 参数：
+//   - o: 待比较的版本。
+//
+// 返回：
+//   - int: v 低于 o 时为 -1，高于时为 1，优先级相同时为 0。
+func (v Version) Compare(o Version) int {
+	return Compare(v, o)
+}
+
+// LessThan 判断当前版本的优先级是否低于 o。
+//
+// 参数：
+//   - o: 待比较的版本。
+//
+// 返回：
+//   - bool: v 低于 o 时为 true。
+func (v Version) LessThan(o Version) bool {
+	return Compare(v, o) < 0
+}
+
+// GreaterThan 判断当前版本的优先级是否高于 o。
+//
+// 参数：
+//   - o: 待比较的版本。
+//
+// 返回：
+//   - bool: v 高于 o 时为 true。
+func (v Version) GreaterThan(o Version) bool {
+	return Compare(v, o) > 0
+}
+
+// Equal 判断当前版本与 o 的优先级是否相同，忽略构建元数据。
+//
+// 参数：
+//   - o: 待比较的版本。
+//
+// 返回：
+//   - bool: 优先级相同时为 true。
+func (v Version) Equal(o Version) bool {
+	return 0 == Compare(v, o)
+}
+
+// IsPrerelease 判断当前版本是否为预发布版本。
+//
+// 参数：无。
+//
+// 返回：
+//   - bool: Prerelease 非空时为 true。
+func (v Version) IsPrerelease() bool {
+	return "" != v.Prerelease
+}
+
+// Core 返回去除预发布标识和构建元数据后的版本。
+//
+// 参数：无。
+//
+// 返回：
+//   - Version: 只保留主、次、修订号的版本。
+func (v Version) Core() Version {
+	return Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch}
+}
+
+// String 返回不带 v 前缀的规范字符串。
+//
+// 参数：无。
+//
+// 返回：
+//   - string: 例如 1.2.3-rc.1+build.5。
+func (v Version) String() string {
+	var b strings.Builder
+	b.WriteString(strconv.FormatUint(v.Major, 10))
+	b.WriteByte('.')
+	b.WriteString(strconv.FormatUint(v.Minor, 10))
+	b.WriteByte('.')
+	b.WriteString(strconv.FormatUint(v.Patch, 10))
+	if "" != v.Prerelease {
+		b.WriteByte('-')
+		b.WriteString(v.Prerelease)
+	}
+	if "" != v.Build {
+		b.WriteByte('+')
+		b.WriteString(v.Build)
+	}
+	return b.String()
+}
+
+// MarshalText 实现 encoding.TextMarshaler，使版本号在 JSON、YAML 等格式中以字符串表示。
+//
+// 参数：无。
+//
+// 返回：
+//   - []byte: String 的结果。
+//   - error: 始终为 nil。
+func (v Version) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+// UnmarshalText 实现 encoding.TextUnmarshaler，按 Parse 的规则解析版本号。
+//
+// 参数：
+//   - text: 版本号字符串。
+//
+// 返回：
+//   - error: 格式不合法时返回包装 ErrInvalidVersion 的错误。
+func (v *Version) UnmarshalText(text []byte) error {
+	parsed, err := Parse(string(text))
+	if nil != err {
+		return err
+	}
+	*v = parsed
+	return nil
+}
+
+// parsePartial 解析可能省略次版本号或修订号的版本号。
+//
+// 参数：
+//   - s: 版本号字符串。
+//
+// 返回：
+//   - Version: 解析结果，省略的部分为 0。
+//   - int: 实际给出的数字段数，取值 1 到 3。
+//   - error: 格式不合法时返回包装 ErrInvalidVersion 的错误。
+func parsePartial(s string) (Version, int, error) {
+	var v Version
+	rest := strings.TrimPrefix(strings.TrimPrefix(s, "v"), "V")
+
+	if i := strings.IndexByte(rest, '+'); i >= 0 {
+		v.Build = rest[i+1:]
+		if err := validateIdentifiers(v.Build, false); nil != err {
+			return Version{}, 0, fmt.Errorf("%w: %q: build %v", ErrInvalidVersion, s, err)
+		}
+		rest = rest[:i]
+	}
+	if i := strings.IndexByte(rest, '-'); i >= 0 {
+		v.Prerelease = rest[i+1:]
+		if err := validateIdentifiers(v.Prerelease, true); nil != err {
+			return Version{}, 0, fmt.Errorf("%w: %q: prerelease %v", ErrInvalidVersion, s, err)
+		}
+		rest = rest[:i]
+	}
+
+	parts := strings.Split(rest, ".")
+	if len(parts) > 3 {
+		return Version{}, 0, fmt.Errorf("%w: %q: too many components", ErrInvalidVersion, s)
+	}
+	nums := []*uint64{&v.Major, &v.Minor, &v.Patch}
+	for i, part := range parts {
+		n, err := parseNumber(part)
+		if nil != err {
+			return Version{}, 0, fmt.Errorf("%w: %q: %v", ErrInvalidVersion, s, err)
+		}
+		*nums[i] = n
+	}
+	return v, len(parts), nil
+}
+
+// parseNumber 解析不含前导零的非负整数。
+//
+// 参数：
+//   - s: 数字字符串。
+//
+// 返回：
+//   - uint64: 解析结果。
+//   - error: 为空、含非数字字符、有前导零或溢出时返回错误。
+func parseNumber(s string) (uint64, error) {
+	if "" == s {
+		return 0, errors.New("empty component")
+	}
+	if !isNumeric(s) {
+		return 0, fmt.Errorf("non-numeric component %q", s)
+	}
+	if len(s) > 1 && '0' == s[0] {
+		return 0, fmt.Errorf("leading zero in %q", s)
+	}
+	n, err := strconv.ParseUint(s, 10, 64)
+	if nil != err {
+		return 0, fmt.Errorf("component %q out of range", s)
+	}
+	return n, nil
+}
+
+// validateIdentifiers 校验点分隔的预发布标识或构建元数据。
+//
+// 参数：
+//   - s: 不含前导 - 或 + 的标识串。
+//   - prerelease: 为 true 时额外禁止纯数字段的前导零。
+//
+// 返回：
+//   - error: 存在空段、非法字符或前导零时返回错误。
+func validateIdentifiers(s string, prerelease bool) error {
+	for _, id := range strings.Split(s, ".") {
+		if "" == id {
+			return errors.New("empty identifier")
+		}
+		for i := 0; i < len(id); i++ {
+			c := id[i]
+			if !('0' <= c && c <= '9' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '-' == c) {
+				return fmt.Errorf("invalid character in %q", id)
+			}
+		}
+		if prerelease && len(id) > 1 && '0' == id[0] && isNumeric(id) {
+			return fmt.Errorf("leading zero in %q", id)
+		}
+	}
+	return nil
+}
+
+// comparePrerelease 按语义化版本规范比较预发布标识。
+//
+// 参数：
+//   - a: 第一个预发布标识，空字符串表示正式版本。
+//   - b: 第二个预发布标识，空字符串表示正式版本。
+//
+// 返回：
+//   - int: a 低于 b 时为 -1，高于时为 1，相同时为 0。
+func comparePrerelease(a, b string) int {
+	switch {
+	case a == b:
+		return 0
+	case "" == a:
+		return 1
+	case "" == b:
+		return -1
+	}
+
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		if c := compareIdentifier(as[i], bs[i]); 0 != c {
+			return c
+		}
+	}
+	return compareUint(uint64(len(as)), uint64(len(bs)))
+}
+
+// compareIdentifier 比较单个预发布标识段。
+//
+// 参数：
+//   - a: 第一个标识段。
+//   - b: 第二个标识段。
+//
+// 返回：
+//   - int: a 低于 b 时为 -1，高于时为 1，相同时为 0。
+func compareIdentifier(a, b string) int {
+	an, bn := isNumeric(a), isNumeric(b)
+	switch {
+	case an && bn:
+		// 纯数字段没有前导零，长度较短的数值较小，长度相同时按字典序即可。
+		if c := compareUint(uint64(len(a)), uint64(len(b))); 0 != c {
+			return c
+		}
+		return strings.Compare(a, b)
+	case an:
+		return -1
+	case bn:
+		return 1
+	default:
+		return strings.Compare(a, b)
+	}
+}
+
+// compareUint 比较两个无符号整数。
+//
+// 参数：
+//   - a: 第一个数。
+//   - b: 第二个数。
+//
+// 返回：
+//   - int: a 小于 b 时为 -1，大于时为 1，相等时为 0。
+func compareUint(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// isNumeric 判断字符串是否非空且只包含 ASCII 数字。
+//
+// 参数：
+//   - s: 待判断的字符串。
+//
+// 返回：
+//   - bool: 只包含数字时为 true。
+func isNumeric(s string) bool {
+	if "" == s {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,191 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package semver
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParse 验证版本号解析及格式校验。
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		give        string
+		want        Version
+		wantErr     bool
+	}{
+		{
+			name:        "success/core",
+			description: "解析只有主、次、修订号的版本。",
+			give:        "1.2.3",
+			want:        Version{Major: 1, Minor: 2, Patch: 3},
+		},
+		{
+			name:        "success/v-prefix",
+			description: "允许 v 前缀。",
+			give:        "v10.20.30",
+			want:        Version{Major: 10, Minor: 20, Patch: 30},
+		},
+		{
+			name:        "success/prerelease-build",
+			description: "解析预发布标识与构建元数据，预发布标识中允许 -。",
+			give:        "1.0.0-rc-1.2+build.001",
+			want:        Version{Major: 1, Prerelease: "rc-1.2", Build: "build.001"},
+		},
+		{
+			name:        "success/build-only",
+			description: "构建元数据中的 - 不被当作预发布分隔符。",
+			give:        "1.0.0+exp.sha-5114f85",
+			want:        Version{Major: 1, Build: "exp.sha-5114f85"},
+		},
+		{
+			name:        "error/partial",
+			description: "缺少修订号时报错。",
+			give:        "1.2",
+			wantErr:     true,
+		},
+		{
+			name:        "error/leading-zero",
+			description: "版本号不允许前导零。",
+			give:        "1.02.3",
+			wantErr:     true,
+		},
+		{
+			name:        "error/prerelease-leading-zero",
+			description: "预发布中的纯数字段不允许前导零。",
+			give:        "1.2.3-rc.01",
+			wantErr:     true,
+		},
+		{
+			name:        "error/empty-identifier",
+			description: "预发布中不允许空段。",
+			give:        "1.2.3-rc..1",
+			wantErr:     true,
+		},
+		{
+			name:        "error/invalid-character",
+			description: "构建元数据只允许字母、数字和 -。",
+			give:        "1.2.3+build_1",
+			wantErr:     true,
+		},
+		{
+			name:        "error/too-many-components",
+			description: "数字段超过 3 个时报错。",
+			give:        "1.2.3.4",
+			wantErr:     true,
+		},
+		{
+			name:        "error/overflow",
+			description: "超出 uint64 范围时报错。",
+			give:        "18446744073709551616.0.0",
+			wantErr:     true,
+		},
+		{
+			name:        "boundary/empty",
+			description: "空字符串不是合法版本。",
+			give:        "",
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			got, err := Parse(tt.give)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidVersion)
+				assert.False(t, IsValid(tt.give))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.True(t, IsValid(tt.give))
+		})
+	}
+}
+
+// TestCompare 验证语义化版本规范中的优先级顺序。
+func TestCompare(t *testing.T) {
+	// 语义化版本 2.0.0 规范第 11 条给出的示例，按优先级从低到高排列。
+	ordered := []string{
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"1.0.1",
+		"1.1.0",
+		"2.0.0",
+	}
+	for i := 0; i < len(ordered); i++ {
+		for j := 0; j < len(ordered); j++ {
+			a, b := MustParse(ordered[i]), MustParse(ordered[j])
+			want := 0
+			if i < j {
+				want = -1
+			} else if i > j {
+				want = 1
+			}
+			assert.Equal(t, want, Compare(a, b), "%s vs %s", ordered[i], ordered[j])
+		}
+	}
+
+	assert.True(t, MustParse("1.0.0+a").Equal(MustParse("1.0.0+b")), "构建元数据不参与比较")
+	assert.True(t, MustParse("1.0.0").LessThan(MustParse("1.0.1")))
+	assert.True(t, MustParse("2.0.0").GreaterThan(MustParse("2.0.0-rc.1")))
+}
+
+// TestSort 验证排序与取最大值。
+func TestSort(t *testing.T) {
+	versions := []Version{
+		MustParse("1.10.0"),
+		MustParse("1.2.0"),
+		MustParse("1.2.0-rc.1"),
+		MustParse("0.9.9"),
+		MustParse("1.2.0+b"),
+	}
+	max, ok := Max(versions)
+	require.True(t, ok)
+	assert.Equal(t, "1.10.0", max.String())
+
+	Sort(versions)
+	got := make([]string, 0, len(versions))
+	for _, v := range versions {
+		got = append(got, v.String())
+	}
+	assert.Equal(t, []string{"0.9.9", "1.2.0-rc.1", "1.2.0", "1.2.0+b", "1.10.0"}, got)
+
+	_, ok = Max(nil)
+	assert.False(t, ok)
+}
+
+// TestVersion_Text 验证字符串表示与文本编解码。
+func TestVersion_Text(t *testing.T) {
+	v := MustParse("v1.2.3-rc.1+build.5")
+	assert.Equal(t, "1.2.3-rc.1+build.5", v.String())
+	assert.True(t, v.IsPrerelease())
+	assert.Equal(t, Version{Major: 1, Minor: 2, Patch: 3}, v.Core())
+
+	data, err := json.Marshal(struct{ V Version }{v})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"V":"1.2.3-rc.1+build.5"}`, string(data))
+
+	var decoded struct{ V Version }
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, v, decoded.V)
+	assert.ErrorIs(t, json.Unmarshal([]byte(`{"V":"1.2"}`), &decoded), ErrInvalidVersion)
+
+	assert.Panics(t, func() { MustParse("bad") })
+}
@@ -2,10 +2,17 @@
 //
 // Licensed under the MIT License. See LICENSE file in the project root for full license information.
 
-// Package testing 提供用于在测试中写入带统一前缀日志的辅助函数。
+// Package testing 提供测试中常用的输出、黄金文件、临时资源和环境变量辅助函数。
 //
-// 本包封装 fmt.Println 和 fmt.Printf 的标准输出行为，在每次调用的正文前
-// 添加固定日志前缀，便于从测试输出中识别辅助日志。输出格式、换行规则和
+// Println 和 Printf 封装 fmt.Println 和 fmt.Printf 的标准输出行为，在每次调用的
+// 正文前添加固定日志前缀，便于从测试输出中识别辅助日志。输出格式、换行规则和
 // 格式化诊断遵循 fmt 包语义；本包不接管 *testing.T 日志，也不保证并发调用时
 // 单条日志记录以原子方式写入。
+//
+// AssertGolden 系列函数将结果与 testdata/<name>.golden 比较，使用
+// go test -update-golden 或 KIT_UPDATE_GOLDEN=1 重新生成黄金文件。TempDir、
+// TempFile 和 WriteFile 创建测试结束后自动删除的文件；FreePort、FreePorts 和
+// Listen 分配本地端口；SetEnv、SetEnvs、UnsetEnv 和 Chdir 修改进程级状态并在
+// 测试结束时恢复，调用它们的测试不应并行运行。所有辅助函数接收 testing.TB，
+// 出错时通过 Fatalf 终止当前测试。
 package testing
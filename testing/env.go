@@ -0,0 +1,52 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package testing
+
+import (
+	"os"
+	stdtesting "testing"
+)
+
+// SetEnv 在测试期间设置环境变量，测试结束时恢复原值。
+//
+// 该函数基于 testing.TB.Setenv，因此不能在并行测试中调用。
+//
+// 参数：
+//   - t: 测试上下文。
+//   - key: 环境变量名称。
+//   - value: 环境变量值。
+func SetEnv(t stdtesting.TB, key, value string) {
+	t.Helper()
+	t.Setenv(key, value)
+}
+
+// SetEnvs 在测试期间批量设置环境变量，测试结束时恢复原值。
+//
+// 参数：
+//   - t: 测试上下文。
+//   - envs: 环境变量名称到值的映射。
+func SetEnvs(t stdtesting.TB, envs map[string]string) {
+	t.Helper()
+	for key, value := range envs {
+		t.Setenv(key, value)
+	}
+}
+
+// UnsetEnv 在测试期间删除环境变量，测试结束时恢复原值。
+//
+// 原来不存在的变量在测试结束后仍保持不存在。该函数不能在并行测试中调用。
+//
+// 参数：
+//   - t: 测试上下文。
+//   - key: 环境变量名称。
+func UnsetEnv(t stdtesting.TB, key string) {
+	t.Helper()
+
+	// 先通过 Setenv 注册恢复逻辑并获得并行测试检查，再删除变量。
+	t.Setenv(key, "")
+	if err := os.Unsetenv(key); nil != err {
+		t.Fatalf("删除环境变量 %s 失败：%v", key, err)
+	}
+}
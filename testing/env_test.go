@@ -0,0 +1,43 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package testing
+
+import (
+	"os"
+	stdtesting "testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestEnvScoping 验证环境变量在子测试内生效并在结束后恢复。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestEnvScoping(t *stdtesting.T) {
+	const (
+		existing = "KIT_TESTING_ENV_EXISTING"
+		absent   = "KIT_TESTING_ENV_ABSENT"
+		batch    = "KIT_TESTING_ENV_BATCH"
+	)
+	t.Setenv(existing, "origin")
+	_ = os.Unsetenv(absent)
+
+	t.Run("scoped", func(t *stdtesting.T) {
+		SetEnv(t, absent, "set")
+		SetEnvs(t, map[string]string{batch: "1"})
+		UnsetEnv(t, existing)
+
+		assert.Equal(t, "set", os.Getenv(absent))
+		assert.Equal(t, "1", os.Getenv(batch))
+		_, ok := os.LookupEnv(existing)
+		assert.False(t, ok)
+	})
+
+	assert.Equal(t, "origin", os.Getenv(existing))
+	_, ok := os.LookupEnv(absent)
+	assert.False(t, ok, "原本不存在的变量应恢复为不存在。")
+	_, ok = os.LookupEnv(batch)
+	assert.False(t, ok)
+}
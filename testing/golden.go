@@ -0,0 +1,165 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package testing
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	stdtesting "testing"
+)
+
+const (
+	// goldenDir 是黄金文件所在目录，相对于被测包目录。
+	goldenDir = "testdata"
+	// goldenExt 是黄金文件的扩展名。
+	goldenExt = ".golden"
+	// updateGoldenEnv 是与 -update-golden 标志等价的环境变量名称。
+	updateGoldenEnv = "KIT_UPDATE_GOLDEN"
+)
+
+var (
+	// UpdateGolden 控制黄金文件断言是否改为写入实际结果。
+	//
+	// 通过 go test -update-golden 或设置环境变量 KIT_UPDATE_GOLDEN=1 启用；
+	// 启用后 AssertGolden 系列函数会覆盖 testdata 下的黄金文件而不做比较。
+	UpdateGolden = flag.Bool("update-golden", false, "更新 testdata 目录下的黄金文件")
+)
+
+// GoldenPath 返回名称对应的黄金文件路径。
+//
+// 参数：
+//   - name: 黄金文件名称，可以包含子目录，不含扩展名。
+//
+// 返回：
+//   - string: 形如 testdata/<name>.golden 的相对路径。
+func GoldenPath(name string) string {
+	return filepath.Join(goldenDir, filepath.FromSlash(name)+goldenExt)
+}
+
+// AssertGolden 比较 got 与黄金文件内容，不一致时标记测试失败。
+//
+// 更新模式下（见 UpdateGolden）会创建目录并写入 got，测试不会失败。
+// 黄金文件不存在时测试立即失败，并提示使用 -update-golden 生成。
+//
+// 参数：
+//   - t: 测试上下文。
+//   - name: 黄金文件名称，含义见 GoldenPath。
+//   - got: 实际结果。
+func AssertGolden(t stdtesting.TB, name string, got []byte) {
+	t.Helper()
+
+	path := GoldenPath(name)
+	if updateGolden() {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); nil != err {
+			t.Fatalf("创建黄金文件目录 %s 失败：%v", filepath.Dir(path), err)
+			return
+		}
+		if err := os.WriteFile(path, got, 0o644); nil != err {
+			t.Fatalf("写入黄金文件 %s 失败：%v", path, err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if nil != err {
+		t.Fatalf("读取黄金文件 %s 失败：%v；可使用 -update-golden 生成", path, err)
+		return
+	}
+	if !bytes.Equal(want, got) {
+		t.Errorf("与黄金文件 %s 不一致（可使用 -update-golden 更新）：\n%s", path, diffLine(string(want), string(got)))
+	}
+}
+
+// AssertGoldenString 比较字符串结果与黄金文件内容。
+//
+// 参数：
+//   - t: 测试上下文。
+//   - name: 黄金文件名称，含义见 GoldenPath。
+//   - got: 实际结果。
+func AssertGoldenString(t stdtesting.TB, name string, got string) {
+	t.Helper()
+	AssertGolden(t, name, []byte(got))
+}
+
+// AssertGoldenJSON 将 v 编码为缩进 JSON 后与黄金文件内容比较。
+//
+// 编码结果使用两个空格缩进并以换行结尾，便于在版本库中审阅差异。
+//
+// 参数：
+//   - t: 测试上下文。
+//   - name: 黄金文件名称，含义见 GoldenPath。
+//   - v: 需要编码的值。
+func AssertGoldenJSON(t stdtesting.TB, name string, v interface{}) {
+	t.Helper()
+
+	data, err := json.MarshalIndent(v, "", "  ")
+	if nil != err {
+		t.Fatalf("编码黄金文件 %s 的 JSON 失败：%v", GoldenPath(name), err)
+		return
+	}
+	AssertGolden(t, name, append(data, '\n'))
+}
+
+// updateGolden 判断当前是否处于黄金文件更新模式。
+//
+// 返回：
+//   - bool: -update-golden 标志或 KIT_UPDATE_GOLDEN 环境变量启用时返回 true。
+func updateGolden() bool {
+	if nil != UpdateGolden && *UpdateGolden {
+		return true
+	}
+	switch strings.ToLower(os.Getenv(updateGoldenEnv)) {
+	case "1", "true", "yes":
+		return true
+	}
+	return false
+}
+
+// diffLine 描述 want 与 got 第一处不同的行。
+//
+// 参数：
+//   - want: 期望内容。
+//   - got: 实际内容。
+//
+// 返回：
+//   - string: 包含行号以及期望行、实际行的说明文本。
+func diffLine(want, got string) string {
+	wantLines := strings.Split(want, "\n")
+	gotLines := strings.Split(got, "\n")
+	for i := 0; i < len(wantLines) || i < len(gotLines); i++ {
+		var w, g string
+		if i < len(wantLines) {
+			w = wantLines[i]
+		}
+		if i < len(gotLines) {
+			g = gotLines[i]
+		}
+		if i >= len(wantLines) || i >= len(gotLines) || w != g {
+			return fmt.Sprintf("第 %d 行：\n- want: %s\n+ got:  %s", i+1, quoteLine(w, i < len(wantLines)), quoteLine(g, i < len(gotLines)))
+		}
+	}
+	return ""
+}
+
+// quoteLine 返回用于差异说明的行文本。
+//
+// 参数：
+//   - line: 行内容。
+//   - ok: 该行是否存在。
+//
+// 返回：
+//   - string: 带引号的行内容；行不存在时返回 "<EOF>"。
+func quoteLine(line string, ok bool) string {
+	if !ok {
+		return "<EOF>"
+	}
+	return strconv.Quote(line)
+}
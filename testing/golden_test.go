@@ -0,0 +1,146 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package testing
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	stdtesting "testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type (
+	// recordTB 记录辅助函数报告的失败，而不终止当前测试。
+	recordTB struct {
+		stdtesting.TB
+		errors []string
+		fatals []string
+	}
+)
+
+// Helper 忽略调用栈标记。
+func (r *recordTB) Helper() {}
+
+// Errorf 记录非致命失败。
+//
+// 参数：
+//   - format: 格式字符串。
+//   - args: 格式参数。
+func (r *recordTB) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+// Fatalf 记录致命失败；被测辅助函数在调用后会自行返回。
+//
+// 参数：
+//   - format: 格式字符串。
+//   - args: 格式参数。
+func (r *recordTB) Fatalf(format string, args ...interface{}) {
+	r.fatals = append(r.fatals, fmt.Sprintf(format, args...))
+}
+
+// TestAssertGolden 验证黄金文件的比较、缺失和更新模式。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestAssertGolden(t *stdtesting.T) {
+	tests := []struct {
+		name        string
+		description string
+		giveFiles   map[string]string
+		giveUpdate  bool
+		giveName    string
+		giveGot     string
+		wantErrors  int
+		wantFatals  int
+		wantFile    string
+		wantMessage string
+	}{
+		{
+			name:        "success/equal",
+			description: "验证内容一致时不报告失败。",
+			giveFiles:   map[string]string{"testdata/out.golden": "a\nb\n"},
+			giveName:    "out",
+			giveGot:     "a\nb\n",
+			wantFile:    "a\nb\n",
+		},
+		{
+			name:        "error/mismatch",
+			description: "验证内容不一致时报告第一处不同的行。",
+			giveFiles:   map[string]string{"testdata/nested/out.golden": "a\nb\n"},
+			giveName:    "nested/out",
+			giveGot:     "a\nc\n",
+			wantErrors:  1,
+			wantFile:    "a\nb\n",
+			wantMessage: "第 2 行",
+		},
+		{
+			name:        "error/missing",
+			description: "验证黄金文件不存在时报告致命失败并提示更新标志。",
+			giveName:    "missing",
+			giveGot:     "x",
+			wantFatals:  1,
+			wantMessage: "-update-golden",
+		},
+		{
+			name:        "success/update",
+			description: "验证更新模式写入实际结果并创建目录。",
+			giveUpdate:  true,
+			giveName:    "new/out",
+			giveGot:     "fresh\n",
+			wantFile:    "fresh\n",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *stdtesting.T) {
+			t.Log(tt.description)
+
+			Chdir(t, TempDir(t, tt.giveFiles))
+			UnsetEnv(t, updateGoldenEnv)
+			if tt.giveUpdate {
+				SetEnv(t, updateGoldenEnv, "true")
+			}
+
+			rec := &recordTB{TB: t}
+			AssertGoldenString(rec, tt.giveName, tt.giveGot)
+
+			assert.Len(t, rec.errors, tt.wantErrors)
+			assert.Len(t, rec.fatals, tt.wantFatals)
+			if "" != tt.wantMessage {
+				assert.Contains(t, fmt.Sprint(rec.errors, rec.fatals), tt.wantMessage)
+			}
+			if "" != tt.wantFile {
+				data, err := os.ReadFile(GoldenPath(tt.giveName))
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantFile, string(data))
+			}
+		})
+	}
+}
+
+// TestAssertGoldenJSON 验证 JSON 黄金文件使用缩进格式并以换行结尾。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestAssertGoldenJSON(t *stdtesting.T) {
+	Chdir(t, TempDir(t, map[string]string{
+		"testdata/user.golden": "{\n  \"id\": 1,\n  \"name\": \"kit\"\n}\n",
+	}))
+	UnsetEnv(t, updateGoldenEnv)
+
+	rec := &recordTB{TB: t}
+	AssertGoldenJSON(rec, "user", map[string]interface{}{"name": "kit", "id": 1})
+	assert.Empty(t, rec.errors)
+	assert.Empty(t, rec.fatals)
+
+	AssertGoldenJSON(rec, "user", func() {})
+	assert.Len(t, rec.fatals, 1)
+	assert.Equal(t, filepath.Join("testdata", "user.golden"), GoldenPath("user"))
+}
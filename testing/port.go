@@ -0,0 +1,78 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package testing
+
+import (
+	"net"
+	stdtesting "testing"
+)
+
+// FreePort 返回一个当前可用的本地 TCP 端口。
+//
+// 端口通过监听 127.0.0.1:0 获取后立即释放，因此在被测代码使用前仍可能被其他
+// 进程占用；能够直接接收 net.Listener 的代码应优先使用 Listen。
+//
+// 参数：
+//   - t: 测试上下文。
+//
+// 返回：
+//   - int: 可用端口号。
+func FreePort(t stdtesting.TB) int {
+	t.Helper()
+	return FreePorts(t, 1)[0]
+}
+
+// FreePorts 返回 n 个互不相同的本地 TCP 端口。
+//
+// 所有端口在同时持有监听后统一释放，保证返回值之间不重复。
+//
+// 参数：
+//   - t: 测试上下文。
+//   - n: 端口数量。
+//
+// 返回：
+//   - []int: 可用端口号列表。
+func FreePorts(t stdtesting.TB, n int) []int {
+	t.Helper()
+
+	ports := make([]int, 0, n)
+	listeners := make([]net.Listener, 0, n)
+	defer func() {
+		for _, l := range listeners {
+			_ = l.Close()
+		}
+	}()
+	for i := 0; i < n; i++ {
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		if nil != err {
+			t.Fatalf("分配空闲端口失败：%v", err)
+			return ports
+		}
+		listeners = append(listeners, l)
+		ports = append(ports, l.Addr().(*net.TCPAddr).Port)
+	}
+	return ports
+}
+
+// Listen 在本地随机端口上监听 TCP，测试结束时自动关闭。
+//
+// 参数：
+//   - t: 测试上下文。
+//
+// 返回：
+//   - net.Listener: 监听 127.0.0.1 随机端口的监听器。
+func Listen(t stdtesting.TB) net.Listener {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if nil != err {
+		t.Fatalf("监听本地端口失败：%v", err)
+		return nil
+	}
+	t.Cleanup(func() {
+		_ = l.Close()
+	})
+	return l
+}
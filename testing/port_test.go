@@ -0,0 +1,52 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package testing
+
+import (
+	"net"
+	"strconv"
+	stdtesting "testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFreePorts 验证分配的端口互不相同且可以再次监听。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestFreePorts(t *stdtesting.T) {
+	ports := FreePorts(t, 4)
+	require.Len(t, ports, 4)
+
+	seen := make(map[int]bool)
+	for _, port := range ports {
+		assert.False(t, seen[port], "端口不应重复。")
+		seen[port] = true
+	}
+
+	l, err := net.Listen("tcp", net.JoinHostPort("127.0.0.1", strconv.Itoa(FreePort(t))))
+	require.NoError(t, err)
+	require.NoError(t, l.Close())
+}
+
+// TestListen 验证 Listen 返回可接受连接的监听器。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestListen(t *stdtesting.T) {
+	l := Listen(t)
+
+	go func() {
+		conn, err := l.Accept()
+		if nil == err {
+			_ = conn.Close()
+		}
+	}()
+
+	conn, err := net.Dial("tcp", l.Addr().String())
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+}
@@ -0,0 +1,95 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package testing
+
+import (
+	"os"
+	"path/filepath"
+	stdtesting "testing"
+)
+
+// TempDir 创建临时目录并写入给定文件，测试结束时自动删除。
+//
+// files 的键为相对于临时目录的斜杠分隔路径，值为文件内容；中间目录会自动创建。
+//
+// 参数：
+//   - t: 测试上下文。
+//   - files: 需要预先写入的文件，可以为 nil。
+//
+// 返回：
+//   - string: 临时目录的绝对路径。
+func TempDir(t stdtesting.TB, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for name, content := range files {
+		WriteFile(t, dir, name, content)
+	}
+	return dir
+}
+
+// TempFile 在独立的临时目录中创建一个文件，测试结束时自动删除。
+//
+// 参数：
+//   - t: 测试上下文。
+//   - name: 文件名，例如 "config.yaml"；扩展名会被保留，便于按扩展名识别格式的代码读取。
+//   - content: 文件内容。
+//
+// 返回：
+//   - string: 文件的绝对路径。
+func TempFile(t stdtesting.TB, name string, content string) string {
+	t.Helper()
+	return WriteFile(t, t.TempDir(), name, content)
+}
+
+// WriteFile 在 dir 下写入文件并自动创建中间目录。
+//
+// 写入失败时测试立即失败。
+//
+// 参数：
+//   - t: 测试上下文。
+//   - dir: 目标目录。
+//   - name: 相对于 dir 的斜杠分隔路径。
+//   - content: 文件内容。
+//
+// 返回：
+//   - string: 写入文件的路径。
+func WriteFile(t stdtesting.TB, dir, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); nil != err {
+		t.Fatalf("创建目录 %s 失败：%v", filepath.Dir(path), err)
+		return path
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); nil != err {
+		t.Fatalf("写入文件 %s 失败：%v", path, err)
+	}
+	return path
+}
+
+// Chdir 将工作目录切换到 dir，测试结束时恢复原工作目录。
+//
+// 工作目录是进程级状态，调用该函数的测试不应并行运行。
+//
+// 参数：
+//   - t: 测试上下文。
+//   - dir: 目标目录。
+func Chdir(t stdtesting.TB, dir string) {
+	t.Helper()
+
+	origin, err := os.Getwd()
+	if nil != err {
+		t.Fatalf("获取当前工作目录失败：%v", err)
+		return
+	}
+	if err := os.Chdir(dir); nil != err {
+		t.Fatalf("切换工作目录到 %s 失败：%v", dir, err)
+		return
+	}
+	t.Cleanup(func() {
+		_ = os.Chdir(origin)
+	})
+}
@@ -0,0 +1,73 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package testing
+
+import (
+	"os"
+	"path/filepath"
+	stdtesting "testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestTempDir 验证临时目录按给定内容创建文件并在测试结束后删除。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestTempDir(t *stdtesting.T) {
+	var dir string
+	t.Run("create", func(t *stdtesting.T) {
+		dir = TempDir(t, map[string]string{
+			"a.txt":        "A",
+			"conf/b.yaml":  "b: 1",
+			"deep/x/y/c.z": "",
+		})
+
+		data, err := os.ReadFile(filepath.Join(dir, "conf", "b.yaml"))
+		require.NoError(t, err)
+		assert.Equal(t, "b: 1", string(data))
+		assert.FileExists(t, filepath.Join(dir, "deep", "x", "y", "c.z"))
+	})
+
+	_, err := os.Stat(dir)
+	assert.True(t, os.IsNotExist(err), "子测试结束后临时目录应被删除。")
+}
+
+// TestTempFile 验证临时文件保留文件名并写入内容。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestTempFile(t *stdtesting.T) {
+	path := TempFile(t, "config.json", `{"a":1}`)
+
+	assert.Equal(t, "config.json", filepath.Base(path))
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, `{"a":1}`, string(data))
+}
+
+// TestChdir 验证 Chdir 切换工作目录并在测试结束后恢复。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestChdir(t *stdtesting.T) {
+	origin, err := os.Getwd()
+	require.NoError(t, err)
+
+	t.Run("switch", func(t *stdtesting.T) {
+		dir := TempDir(t, map[string]string{"marker": "1"})
+		Chdir(t, dir)
+		assert.FileExists(t, "marker")
+	})
+
+	current, err := os.Getwd()
+	require.NoError(t, err)
+	assert.Equal(t, origin, current)
+
+	rec := &recordTB{TB: t}
+	Chdir(rec, filepath.Join(origin, "not-exist"))
+	assert.Len(t, rec.fatals, 1)
+}
@@ -0,0 +1,358 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package time
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	stdtime "time"
+)
+
+var (
+	// ErrInvalidHolidayEntry 表示节假日条目的日期无法解析。
+	ErrInvalidHolidayEntry = errors.New("invalid holiday entry")
+	// ErrNoBusinessDay 表示连续 maxNonBusinessDays 天都不是工作日，通常是节假日日历配置错误。
+	ErrNoBusinessDay = errors.New("no business day found")
+
+	// holidayCalendars 以大写地区代码为键保存已注册的节假日日历。
+	holidayCalendars = map[string]HolidayCalendar{
+		RegionChina: mustStaticCalendar(chinaHolidays()...),
+	}
+	// holidayCalendarsMu 保护 holidayCalendars。
+	holidayCalendarsMu sync.RWMutex
+)
+
+const (
+	// RegionChina 是内置中国法定节假日日历的地区代码。
+	RegionChina = "CN"
+
+	// maxNonBusinessDays 是 AddBusinessDays 允许连续跳过的非工作日天数上限。
+	maxNonBusinessDays = 366
+)
+
+type (
+	// HolidayCalendar 定义节假日日历，用于在周末规则之外标记休息日与调休上班日。
+	//
+	// 日期按传入时间所在时区的年月日判断，实现应可安全并发调用。从数据库加载节假日时，既可以实现该接口，
+	// 也可以把查询结果转换为 HolidayEntry 后交给 NewStaticCalendar。
+	HolidayCalendar interface {
+		// Holiday 返回 date 所在日期的节假日名称，ok 为 false 表示不是节假日。
+		Holiday(date stdtime.Time) (name string, ok bool)
+		// IsMakeupWorkday 返回 date 所在日期是否为调休上班日，即原本是周末但需要上班的日期。
+		IsMakeupWorkday(date stdtime.Time) bool
+	}
+
+	// HolidayEntry 描述节假日日历中的一天，是 JSON 与数据库数据的交换格式。
+	HolidayEntry struct {
+		// Date 是 2006-01-02 格式的日期。
+		Date string `json:"date"`
+		// Name 是节假日名称，例如 "春节"；调休上班日通常填写对应节日名称。
+		Name string `json:"name"`
+		// Workday 为 true 表示该日为调休上班日，否则为休息的节假日。
+		Workday bool `json:"workday"`
+	}
+
+	// StaticCalendar 是基于内存日期表的 HolidayCalendar 实现，可安全并发使用。
+	StaticCalendar struct {
+		// mu 保护 holidays 与 workdays。
+		mu sync.RWMutex
+		// holidays 是日期到节假日名称的映射。
+		holidays map[string]string
+		// workdays 是调休上班日到对应节日名称的映射。
+		workdays map[string]string
+	}
+)
+
+var (
+	// _ 确保 StaticCalendar 实现 HolidayCalendar 接口。
+	_ HolidayCalendar = (*StaticCalendar)(nil)
+)
+
+// NewStaticCalendar 创建包含指定条目的节假日日历。
+//
+// 参数：
+//   - entries: 节假日与调休上班日条目，同一日期出现多次时以最后一条为准。
+//
+// 返回：
+//   - *StaticCalendar: 节假日日历。
+//   - error: 日期无法解析时返回 ErrInvalidHolidayEntry。
+func NewStaticCalendar(entries ...HolidayEntry) (*StaticCalendar, error) {
+	c := &StaticCalendar{holidays: make(map[string]string), workdays: make(map[string]string)}
+	if err := c.Add(entries...); nil != err {
+		return nil, err
+	}
+	return c, nil
+}
+
+// LoadHolidayCalendarJSON 从 JSON 数组读取节假日条目并创建日历。
+//
+// JSON 格式为 [{"date": "2025-01-01", "name": "元旦"}, {"date": "2025-01-26", "name": "春节", "workday": true}]。
+//
+// 参数：
+//   - r: JSON 数据来源。
+//
+// 返回：
+//   - *StaticCalendar: 节假日日历。
+//   - error: JSON 解析失败或日期无效时返回错误，日期无效时可通过 errors.Is 判断 ErrInvalidHolidayEntry。
+func LoadHolidayCalendarJSON(r io.Reader) (*StaticCalendar, error) {
+	var entries []HolidayEntry
+	if err := json.NewDecoder(r).Decode(&entries); nil != err {
+		return nil, fmt.Errorf("decode holiday calendar: %w", err)
+	}
+	return NewStaticCalendar(entries...)
+}
+
+// Add 向日历添加条目，已存在的日期会被覆盖，可用于补充新一年的节假日安排。
+//
+// 参数：
+//   - entries: 节假日与调休上班日条目。
+//
+// 返回：
+//   - error: 任一日期无法解析时返回 ErrInvalidHolidayEntry，此时不会添加任何条目。
+func (c *StaticCalendar) Add(entries ...HolidayEntry) error {
+	keys := make([]string, len(entries))
+	for i, entry := range entries {
+		date, err := stdtime.Parse(stdtime.DateOnly, strings.TrimSpace(entry.Date))
+		if nil != err {
+			return fmt.Errorf("%w: date %q", ErrInvalidHolidayEntry, entry.Date)
+		}
+		keys[i] = date.Format(stdtime.DateOnly)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, entry := range entries {
+		if entry.Workday {
+			delete(c.holidays, keys[i])
+			c.workdays[keys[i]] = entry.Name
+		} else {
+			delete(c.workdays, keys[i])
+			c.holidays[keys[i]] = entry.Name
+		}
+	}
+	return nil
+}
+
+// Holiday 返回 date 所在日期的节假日名称。
+//
+// 参数：
+//   - date: 待判断的时间，按其所在时区的日期判断。
+//
+// 返回：
+//   - string: 节假日名称。
+//   - bool: 是否为节假日。
+func (c *StaticCalendar) Holiday(date stdtime.Time) (string, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	name, ok := c.holidays[date.Format(stdtime.DateOnly)]
+	return name, ok
+}
+
+// IsMakeupWorkday 返回 date 所在日期是否为调休上班日。
+//
+// 参数：
+//   - date: 待判断的时间，按其所在时区的日期判断。
+//
+// 返回：
+//   - bool: 是否为调休上班日。
+func (c *StaticCalendar) IsMakeupWorkday(date stdtime.Time) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	_, ok := c.workdays[date.Format(stdtime.DateOnly)]
+	return ok
+}
+
+// RegisterHolidayCalendar 注册或替换指定地区的节假日日历。
+//
+// 参数：
+//   - region: 地区代码，例如 "CN"，不区分大小写。
+//   - cal: 节假日日历，为 nil 时移除该地区的注册。
+func RegisterHolidayCalendar(region string, cal HolidayCalendar) {
+	holidayCalendarsMu.Lock()
+	defer holidayCalendarsMu.Unlock()
+	if nil == cal {
+		delete(holidayCalendars, strings.ToUpper(region))
+		return
+	}
+	holidayCalendars[strings.ToUpper(region)] = cal
+}
+
+// LookupHolidayCalendar 返回指定地区已注册的节假日日历。
+//
+// 参数：
+//   - region: 地区代码，不区分大小写。
+//
+// 返回：
+//   - HolidayCalendar: 已注册的日历。
+//   - bool: 是否已注册。
+func LookupHolidayCalendar(region string) (HolidayCalendar, bool) {
+	holidayCalendarsMu.RLock()
+	defer holidayCalendarsMu.RUnlock()
+	cal, ok := holidayCalendars[strings.ToUpper(region)]
+	return cal, ok
+}
+
+// IsBusinessDay 判断 t 所在日期是否为工作日。
+//
+// 调休上班日总是工作日；否则节假日与周六、周日为非工作日。
+//
+// 参数：
+//   - t: 待判断的时间，按其所在时区的日期判断。
+//   - cal: 节假日日历，为 nil 时只按周末判断。
+//
+// 返回：
+//   - bool: 是否为工作日。
+func IsBusinessDay(t stdtime.Time, cal HolidayCalendar) bool {
+	if nil != cal {
+		if cal.IsMakeupWorkday(t) {
+			return true
+		}
+		if _, ok := cal.Holiday(t); ok {
+			return false
+		}
+	}
+	weekday := t.Weekday()
+	return stdtime.Saturday != weekday && stdtime.Sunday != weekday
+}
+
+// AddBusinessDays 返回 t 之后第 n 个工作日的同一时刻。
+//
+// t 本身不计入；n 为负数时向前查找，为 0 时原样返回 t。日期按 t 所在时区计算并保留时分秒。
+//
+// 参数：
+//   - t: 起始时间。
+//   - n: 工作日数。
+//   - cal: 节假日日历，为 nil 时只跳过周末。
+//
+// 返回：
+//   - stdtime.Time: 目标工作日的同一时刻。
+//   - error: 连续一年以上没有工作日时返回 ErrNoBusinessDay。
+func AddBusinessDays(t stdtime.Time, n int, cal HolidayCalendar) (stdtime.Time, error) {
+	step := 1
+	if n < 0 {
+		step, n = -1, -n
+	}
+
+	skipped := 0
+	for n > 0 {
+		t = t.AddDate(0, 0, step)
+		if IsBusinessDay(t, cal) {
+			n--
+			skipped = 0
+			continue
+		}
+		skipped++
+		if skipped > maxNonBusinessDays {
+			return stdtime.Time{}, fmt.Errorf("%w: %d consecutive days from %s", ErrNoBusinessDay, skipped, t.Format(stdtime.DateOnly))
+		}
+	}
+	return t, nil
+}
+
+// SubBusinessDays 返回 t 之前第 n 个工作日的同一时刻，等价于 AddBusinessDays(t, -n, cal)。
+//
+// 参数：
+//   - t: 起始时间。
+//   - n: 工作日数。
+//   - cal: 节假日日历，为 nil 时只跳过周末。
+//
+// 返回：
+//   - stdtime.Time: 目标工作日的同一时刻。
+//   - error: 连续一年以上没有工作日时返回 ErrNoBusinessDay。
+func SubBusinessDays(t stdtime.Time, n int, cal HolidayCalendar) (stdtime.Time, error) {
+	return AddBusinessDays(t, -n, cal)
+}
+
+// mustStaticCalendar 创建内置节假日日历，条目无效时 panic。
+//
+// 参数：
+//   - entries: 节假日条目。
+//
+// 返回：
+//   - *StaticCalendar: 节假日日历。
+func mustStaticCalendar(entries ...HolidayEntry) *StaticCalendar {
+	c, err := NewStaticCalendar(entries...)
+	if nil != err {
+		panic(err)
+	}
+	return c
+}
+
+// holidayRange 生成从 from 到 to（含）的连续节假日条目。
+//
+// 参数：
+//   - name: 节假日名称。
+//   - from: 开始日期，格式为 2006-01-02。
+//   - to: 结束日期，格式为 2006-01-02。
+//
+// 返回：
+//   - []HolidayEntry: 每天一条的节假日条目。
+func holidayRange(name, from, to string) []HolidayEntry {
+	start, _ := stdtime.Parse(stdtime.DateOnly, from)
+	end, _ := stdtime.Parse(stdtime.DateOnly, to)
+	var entries []HolidayEntry
+	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+		entries = append(entries, HolidayEntry{Date: d.Format(stdtime.DateOnly), Name: name})
+	}
+	return entries
+}
+
+// makeupWorkdays 生成调休上班日条目。
+//
+// 参数：
+//   - name: 对应的节假日名称。
+//   - dates: 调休上班日，格式为 2006-01-02。
+//
+// 返回：
+//   - []HolidayEntry: 调休上班日条目。
+func makeupWorkdays(name string, dates ...string) []HolidayEntry {
+	entries := make([]HolidayEntry, 0, len(dates))
+	for _, date := range dates {
+		entries = append(entries, HolidayEntry{Date: date, Name: name, Workday: true})
+	}
+	return entries
+}
+
+// chinaHolidays 返回内置的中国法定节假日与调休安排，数据来自国务院办公厅发布的放假通知。
+//
+// 返回：
+//   - []HolidayEntry: 2024 年与 2025 年的节假日与调休上班日条目。
+func chinaHolidays() []HolidayEntry {
+	groups := [][]HolidayEntry{
+		// 2024 年。
+		holidayRange("元旦", "2024-01-01", "2024-01-01"),
+		holidayRange("春节", "2024-02-10", "2024-02-17"),
+		makeupWorkdays("春节", "2024-02-04", "2024-02-18"),
+		holidayRange("清明节", "2024-04-04", "2024-04-06"),
+		makeupWorkdays("清明节", "2024-04-07"),
+		holidayRange("劳动节", "2024-05-01", "2024-05-05"),
+		makeupWorkdays("劳动节", "2024-04-28", "2024-05-11"),
+		holidayRange("端午节", "2024-06-10", "2024-06-10"),
+		holidayRange("中秋节", "2024-09-15", "2024-09-17"),
+		makeupWorkdays("中秋节", "2024-09-14"),
+		holidayRange("国庆节", "2024-10-01", "2024-10-07"),
+		makeupWorkdays("国庆节", "2024-09-29", "2024-10-12"),
+		// 2025 年。
+		holidayRange("元旦", "2025-01-01", "2025-01-01"),
+		holidayRange("春节", "2025-01-28", "2025-02-04"),
+		makeupWorkdays("春节", "2025-01-26", "2025-02-08"),
+		holidayRange("清明节", "2025-04-04", "2025-04-06"),
+		holidayRange("劳动节", "2025-05-01", "2025-05-05"),
+		makeupWorkdays("劳动节", "2025-04-27"),
+		holidayRange("端午节", "2025-05-31", "2025-06-02"),
+		holidayRange("国庆节、中秋节", "2025-10-01", "2025-10-08"),
+		makeupWorkdays("国庆节、中秋节", "2025-09-28", "2025-10-11"),
+	}
+
+	var entries []HolidayEntry
+	for _, group := range groups {
+		entries = append(entries, group...)
+	}
+	return entries
+}
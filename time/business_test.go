@@ -0,0 +1,177 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package time
+
+import (
+	"strings"
+	"testing"
+	stdtime "time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// businessDate 返回 UTC 时区中指定日期的 9 点。
+//
+// 参数：
+//   - s: 2006-01-02 格式的日期。
+//
+// 返回：
+//   - stdtime.Time: 对应日期的 9 点。
+func businessDate(s string) stdtime.Time {
+	d, err := stdtime.Parse(stdtime.DateOnly, s)
+	if nil != err {
+		panic(err)
+	}
+	return d.Add(9 * stdtime.Hour)
+}
+
+// TestIsBusinessDay 验证周末、节假日与调休上班日的判断。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestIsBusinessDay(t *testing.T) {
+	cn, ok := LookupHolidayCalendar("cn")
+	require.True(t, ok)
+
+	tests := []struct {
+		name        string
+		description string
+		date        string
+		cal         HolidayCalendar
+		want        bool
+	}{
+		{name: "weekday", description: "验证普通工作日。", date: "2025-03-03", cal: cn, want: true},
+		{name: "weekend", description: "验证普通周末。", date: "2025-03-08", cal: cn, want: false},
+		{name: "holiday", description: "验证工作日中的法定节假日。", date: "2025-01-28", cal: cn, want: false},
+		{name: "makeup", description: "验证周日调休上班。", date: "2025-01-26", cal: cn, want: true},
+		{name: "nil-calendar", description: "验证 nil 日历只按周末判断。", date: "2025-01-28", cal: nil, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			assert.Equal(t, tt.want, IsBusinessDay(businessDate(tt.date), tt.cal))
+		})
+	}
+
+	name, ok := cn.Holiday(businessDate("2025-10-06"))
+	assert.True(t, ok)
+	assert.Equal(t, "国庆节、中秋节", name)
+}
+
+// TestAddBusinessDays 验证按工作日偏移。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestAddBusinessDays(t *testing.T) {
+	cn, _ := LookupHolidayCalendar(RegionChina)
+
+	tests := []struct {
+		name        string
+		description string
+		from        string
+		n           int
+		cal         HolidayCalendar
+		want        string
+	}{
+		{name: "zero", description: "验证 0 个工作日原样返回。", from: "2025-03-08", n: 0, cal: cn, want: "2025-03-08"},
+		{name: "weekend", description: "验证跳过周末。", from: "2025-03-07", n: 1, cal: nil, want: "2025-03-10"},
+		{name: "from-weekend", description: "验证从周末开始时下一个工作日为周一。", from: "2025-03-08", n: 1, cal: nil, want: "2025-03-10"},
+		{name: "spring-festival", description: "验证跳过春节假期并在周六调休日上班。", from: "2025-01-27", n: 1, cal: cn, want: "2025-02-05"},
+		{name: "makeup-saturday", description: "验证调休的周六计为工作日。", from: "2025-02-07", n: 1, cal: cn, want: "2025-02-08"},
+		{name: "backward", description: "验证负数向前查找并跳过节假日。", from: "2025-02-05", n: -2, cal: cn, want: "2025-01-26"},
+		{name: "national-day", description: "验证跨越国庆中秋假期。", from: "2025-09-30", n: 2, cal: cn, want: "2025-10-10"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			got, err := AddBusinessDays(businessDate(tt.from), tt.n, tt.cal)
+			require.NoError(t, err)
+			assert.Equal(t, businessDate(tt.want), got)
+
+			back, err := SubBusinessDays(businessDate(tt.from), -tt.n, tt.cal)
+			require.NoError(t, err)
+			assert.Equal(t, got, back)
+		})
+	}
+}
+
+// TestStaticCalendar 验证日历的加载、覆盖与错误处理。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestStaticCalendar(t *testing.T) {
+	t.Run("success/json", func(t *testing.T) {
+		t.Log("验证从 JSON 加载并注册为新地区。")
+
+		cal, err := LoadHolidayCalendarJSON(strings.NewReader(`[
+			{"date": "2025-12-24", "name": "Christmas Eve"},
+			{"date": "2025-12-27", "name": "Christmas", "workday": true}
+		]`))
+		require.NoError(t, err)
+		RegisterHolidayCalendar("test", cal)
+		defer RegisterHolidayCalendar("TEST", nil)
+
+		got, ok := LookupHolidayCalendar("TEST")
+		require.True(t, ok)
+		assert.False(t, IsBusinessDay(businessDate("2025-12-24"), got))
+		assert.True(t, IsBusinessDay(businessDate("2025-12-27"), got))
+
+		// 后添加的条目覆盖同一日期。
+		require.NoError(t, cal.Add(HolidayEntry{Date: "2025-12-24", Name: "Christmas Eve", Workday: true}))
+		assert.True(t, IsBusinessDay(businessDate("2025-12-24"), got))
+		_, ok = cal.Holiday(businessDate("2025-12-24"))
+		assert.False(t, ok)
+	})
+
+	t.Run("error/invalid-date", func(t *testing.T) {
+		t.Log("验证无效日期返回 ErrInvalidHolidayEntry 且不添加任何条目。")
+
+		cal, err := NewStaticCalendar()
+		require.NoError(t, err)
+		err = cal.Add(HolidayEntry{Date: "2025-01-01"}, HolidayEntry{Date: "2025/01/02"})
+		assert.ErrorIs(t, err, ErrInvalidHolidayEntry)
+		_, ok := cal.Holiday(businessDate("2025-01-01"))
+		assert.False(t, ok)
+
+		_, err = LoadHolidayCalendarJSON(strings.NewReader(`{`))
+		assert.Error(t, err)
+	})
+
+	t.Run("error/no-business-day", func(t *testing.T) {
+		t.Log("验证日历没有工作日时返回 ErrNoBusinessDay 而不是无限循环。")
+
+		_, err := AddBusinessDays(businessDate("2025-01-01"), 1, allHolidays{})
+		assert.ErrorIs(t, err, ErrNoBusinessDay)
+	})
+}
+
+type (
+	// allHolidays 是把每一天都视为节假日的日历。
+	allHolidays struct{}
+)
+
+// Holiday 总是返回节假日。
+//
+// 参数：
+//   - date: 任意时间。
+//
+// 返回：
+//   - string: 固定名称。
+//   - bool: 始终为 true。
+func (allHolidays) Holiday(stdtime.Time) (string, bool) { return "holiday", true }
+
+// IsMakeupWorkday 总是返回 false。
+//
+// 参数：
+//   - date: 任意时间。
+//
+// 返回：
+//   - bool: 始终为 false。
+func (allHolidays) IsMakeupWorkday(stdtime.Time) bool { return false }
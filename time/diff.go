@@ -0,0 +1,96 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package time
+
+import (
+	"strings"
+	"sync"
+	stdtime "time"
+
+	"github.com/dromara/carbon/v2"
+)
+
+const (
+	// relativeMarker 是提取相对时间模板时替换时间量的占位文本。
+	relativeMarker = "\x00"
+)
+
+var (
+	// relativeTemplates 按语言环境缓存 carbon 相对当前时间的措辞模板，值为 relativeTemplate。
+	relativeTemplates sync.Map
+)
+
+type (
+	// relativeTemplate 是 carbon 相对当前时间的措辞模板，%s 为时间量。
+	relativeTemplate struct {
+		// ago 是过去时间的模板，例如 "%s ago"。
+		ago string
+		// fromNow 是将来时间的模板，例如 "%s from now"。
+		fromNow string
+	}
+)
+
+// DiffForHumans 以指定语言输出 t 相对 ref 的易读时间差，例如 "3 天前"、"2 hours from now"。
+//
+// locale 通常取自 i18n.Localizer.Language，使相对时间与界面消息使用同一语言。carbon 不支持该语言时依次尝试
+// 去掉地区等子标签后的语言（如 `en-US` 回退为 `en`）与包默认语言环境 defaultLocale。
+//
+// 参数：
+//   - t: 待描述的时间。
+//   - ref: 参照时间，通常为当前时间。
+//   - locale: carbon 语言环境名称，例如 zh-CN、zh-TW、en、ja；为空时使用 defaultLocale。
+//
+// 返回：
+//   - string: 易读的时间差；所有候选语言均不可用时返回空字符串。
+func DiffForHumans(t, ref stdtime.Time, locale string) string {
+	candidates := []string{defaultLocale}
+	if locale = strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"); "" != locale {
+		candidates = []string{locale}
+		if i := strings.Index(locale, "-"); i > 0 {
+			candidates = append(candidates, locale[:i])
+		}
+		candidates = append(candidates, defaultLocale)
+	}
+
+	for _, candidate := range candidates {
+		lang := carbon.NewLanguage().SetLocale(candidate)
+		if nil != lang.Error {
+			continue
+		}
+		// carbon 传入参照时间时使用 "before/after" 措辞，这里改用相对当前时间的 "ago/from now" 措辞。
+		tmpl := loadRelativeTemplate(candidate)
+		lang.SetResources(map[string]string{"before": tmpl.ago, "after": tmpl.fromNow})
+		return carbon.CreateFromStdTime(t).SetLanguage(lang).DiffForHumans(carbon.CreateFromStdTime(ref))
+	}
+	return ""
+}
+
+// loadRelativeTemplate 返回语言环境相对当前时间的措辞模板。
+//
+// carbon 不公开语言资源，这里把 "year" 的译文替换为占位文本，对相距两年的时间调用相对当前时间的 DiffForHumans，
+// 再把结果中的占位文本还原为 %s。
+//
+// 参数：
+//   - locale: carbon 支持的语言环境名称。
+//
+// 返回：
+//   - relativeTemplate: 过去与将来时间的措辞模板。
+func loadRelativeTemplate(locale string) relativeTemplate {
+	if v, ok := relativeTemplates.Load(locale); ok {
+		return v.(relativeTemplate)
+	}
+
+	extract := func(c *carbon.Carbon) string {
+		lang := carbon.NewLanguage().SetLocale(locale).SetResources(map[string]string{"year": relativeMarker})
+		return strings.Replace(c.SetLanguage(lang).DiffForHumans(), relativeMarker, "%s", 1)
+	}
+	now := carbon.Now()
+	tmpl := relativeTemplate{
+		ago:     extract(now.Copy().SubYears(2)),
+		fromNow: extract(now.Copy().AddYears(2)),
+	}
+	relativeTemplates.Store(locale, tmpl)
+	return tmpl
+}
@@ -0,0 +1,45 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package time
+
+import (
+	"testing"
+	stdtime "time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestDiffForHumans 验证按语言输出易读时间差及语言回退。
+func TestDiffForHumans(t *testing.T) {
+	ref := stdtime.Date(2025, 5, 20, 12, 0, 0, 0, stdtime.UTC)
+	past := ref.Add(-3 * 24 * stdtime.Hour)
+	future := ref.Add(2 * stdtime.Hour)
+
+	tests := []struct {
+		name        string
+		description string
+		t           stdtime.Time
+		locale      string
+		want        string
+	}{
+		{name: "success/en", description: "验证英文输出。", t: past, locale: "en", want: "3 days ago"},
+		{name: "success/zh-CN", description: "验证中文输出。", t: past, locale: "zh-CN", want: "3 天前"},
+		{name: "success/future", description: "验证晚于参照时间时使用相对当前时间的措辞。", t: future, locale: "en", want: "2 hours from now"},
+		{name: "success/far-past", description: "验证跨年的时间差同样相对参照时间计算。", t: ref.AddDate(-3, 0, 0), locale: "en", want: "3 years ago"},
+		{name: "success/now", description: "验证与参照时间相同时输出刚刚。", t: ref, locale: "en", want: "just now"},
+		{name: "fallback/region", description: "验证 carbon 不支持的地区回退到语言子标签。", t: past, locale: "en_US", want: "3 days ago"},
+		{name: "fallback/default", description: "验证不支持的语言回退到默认语言环境。", t: past, locale: "xx", want: "3 天前"},
+		{name: "fallback/empty", description: "验证空语言使用默认语言环境。", t: past, locale: "", want: "3 天前"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			assert.Equal(t, tt.want, DiffForHumans(tt.t, ref, tt.locale))
+		})
+	}
+}
@@ -22,4 +22,31 @@
 // 基于当前时间副本计算，避免在 Carbon 测试时间被冻结时修改全局 frozen now。函数均返回
 // *carbon.Carbon；当 carbon 默认配置无效时，返回值会携带 Carbon 错误，调用方应检查 Error
 // 或 IsInvalid。更完整的解析、格式化和日历能力由 carbon API 提供。
+//
+// SolarToLunar 与 LunarToSolar 基于 carbon 的农历换算表在公历与农历之间转换，按包默认时区的日期
+// 计算；LunarDate 提供农历年月日的中文名称、生肖和传统节日（含除夕），LunarFestival 可直接判断
+// 公历日期是否为农历节日。
+//
+// DiffForHumans 以指定语言输出两个时间的易读差值，语言通常取自 i18n.Localizer.Language；carbon 不支持该语言时
+// 依次回退到语言子标签与 defaultLocale。
+// ParseRelative 则反向解析 "3 days ago"、"next monday"、"下周三"、"两小时后" 等中英文相对时间表达式，
+// 返回默认时区中的具体时间，供命令行工具和搜索过滤条件使用。
+//
+// TimingWheel 是哈希时间轮，以单个 time.Ticker 驱动大量精度要求不高的超时，AfterFunc、Schedule 以及
+// WheelTimer 的 Stop、Reset 均为 O(1)，适合替代为每个连接创建 time.Timer 的做法。
+//
+// Period 表示左闭右开的时间段 [start, end)，由 NewPeriod 按步长创建。All 以 iter.Seq 逐个返回时间点，
+// 按月、年迭代时夹取到月末且不累积漂移；Buckets 按步长切分，Days、Weeks、Months 按自然日、周、月切分并截断首尾；
+// Overlaps、Intersect、Union 用于判断重叠及计算交集、并集，便于调度器枚举时间桶。
+//
+// IsBusinessDay、AddBusinessDays 与 SubBusinessDays 按周末和 HolidayCalendar 计算工作日，调休上班日优先于周末与节假日。
+// 日历按地区通过 RegisterHolidayCalendar 注册、LookupHolidayCalendar 查找，内置 RegionChina 的法定节假日与调休安排；
+// StaticCalendar 可由 HolidayEntry 或 LoadHolidayCalendarJSON 构建，也可以自行实现接口对接数据库。
+//
+// Daily、Weekly 与 NextOccurrence 按时区的本地时刻调度，返回的 LocalSchedule 实现 Scheduler。夏令时切换当天
+// 不存在或出现两次的本地时刻按 DSTPolicy 处理：DSTShift 顺延并只触发一次，DSTSkip 跳过不存在的时刻，
+// DSTRunTwice 在重复时刻触发两次。
+//
+// Stopwatch 是可暂停的秒表，支持分段（Lap）计时，String 以易读的单位输出累计耗时；Measure 执行函数并返回
+// 其耗时与错误，配合 WithMeasureLog 通过 log 包输出 "operation took elapsed" 日志，替代分散的 time.Since 计算。
 package time
@@ -0,0 +1,259 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package time
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	stdtime "time"
+
+	"github.com/dromara/carbon/v2/calendar/lunar"
+)
+
+var (
+	// ErrLunarOutOfRange 表示日期超出农历换算表支持的范围（公历 1900-01-31 至 2101-01-28，即农历 1900 至 2100 年）。
+	ErrLunarOutOfRange = errors.New("lunar date out of range")
+	// ErrInvalidLunarDate 表示农历年月日或闰月标记不存在，例如当年没有该闰月或该月没有三十日。
+	ErrInvalidLunarDate = errors.New("invalid lunar date")
+
+	// zodiacs 是以 year%12 为下标的生肖名称，公元 0 年为猴年。
+	zodiacs = []string{"猴", "鸡", "狗", "猪", "鼠", "牛", "虎", "兔", "龙", "蛇", "马", "羊"}
+
+	// lunarFestivals 是以农历月、日为键的传统节日，闰月不计入节日。除夕按腊月最后一日单独判断。
+	lunarFestivals = map[[2]int]string{
+		{1, 1}:   "春节",
+		{1, 15}:  "元宵节",
+		{2, 2}:   "龙抬头",
+		{3, 3}:   "上巳节",
+		{5, 5}:   "端午节",
+		{7, 7}:   "七夕节",
+		{7, 15}:  "中元节",
+		{8, 15}:  "中秋节",
+		{9, 9}:   "重阳节",
+		{10, 1}:  "寒衣节",
+		{10, 15}: "下元节",
+		{12, 8}:  "腊八节",
+	}
+
+	// lunarMinSolar 是农历换算表支持的最早公历日期，对应农历 1900 年正月初一。
+	lunarMinSolar = stdtime.Date(1900, 1, 31, 0, 0, 0, 0, stdtime.UTC)
+	// lunarMaxSolar 是农历换算表支持的最晚公历日期，对应农历 2100 年腊月最后一日。
+	lunarMaxSolar = stdtime.Date(2101, 1, 28, 0, 0, 0, 0, stdtime.UTC)
+	// chinaStandardTime 是东八区时区，carbon 将农历日期换算为该时区零点对应的时刻。
+	chinaStandardTime = stdtime.FixedZone("CST", 8*60*60)
+
+	// defaultLocation 懒加载 defaultTimezone 对应的时区，加载失败时回退为 UTC。
+	defaultLocation = sync.OnceValue(func() *stdtime.Location {
+		loc, err := stdtime.LoadLocation(defaultTimezone)
+		if nil != err {
+			return stdtime.UTC
+		}
+		return loc
+	})
+)
+
+const (
+	// festivalNewYearsEve 是腊月最后一日的节日名称。
+	festivalNewYearsEve = "除夕"
+)
+
+type (
+	// LunarDate 表示一个农历日期。
+	LunarDate struct {
+		// Year 是农历年，例如 2025。
+		Year int
+		// Month 是农历月，取值 1 至 12；闰月与其前一个月的月份数字相同。
+		Month int
+		// Day 是农历日，取值 1 至 30。
+		Day int
+		// IsLeapMonth 表示该日期是否位于闰月。
+		IsLeapMonth bool
+	}
+)
+
+// SolarToLunar 将公历时间转换为农历日期。
+//
+// t 会先转换到包默认时区（defaultTimezone，默认为 PRC），再按该时区的日期换算，因此同一时刻在不同
+// 时区调用的结果一致。
+//
+// 参数：
+//   - t: 公历时间。
+//
+// 返回：
+//   - LunarDate: 对应的农历日期。
+//   - error: t 为零值或超出支持范围时返回 ErrLunarOutOfRange。
+func SolarToLunar(t stdtime.Time) (LunarDate, error) {
+	if t.IsZero() {
+		return LunarDate{}, fmt.Errorf("%w: zero time", ErrLunarOutOfRange)
+	}
+	t = t.In(defaultLocation())
+	date := stdtime.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, stdtime.UTC)
+	if date.Before(lunarMinSolar) || date.After(lunarMaxSolar) {
+		// 超出范围时 carbon 会越界访问换算表，必须提前拦截。
+		return LunarDate{}, fmt.Errorf("%w: %s", ErrLunarOutOfRange, date.Format(stdtime.DateOnly))
+	}
+	l := lunar.FromStdTime(date)
+	return LunarDate{Year: l.Year(), Month: l.Month(), Day: l.Day(), IsLeapMonth: l.IsLeapMonth()}, nil
+}
+
+// LunarToSolar 将农历日期转换为公历日期。
+//
+// 参数：
+//   - d: 农历日期。
+//
+// 返回：
+//   - time.Time: 对应公历日期在包默认时区的零点。
+//   - error: 年份超出支持范围时返回 ErrLunarOutOfRange；月、日或闰月标记不存在时返回 ErrInvalidLunarDate。
+func LunarToSolar(d LunarDate) (stdtime.Time, error) {
+	l := lunar.NewLunar(d.Year, d.Month, d.Day, d.IsLeapMonth)
+	if !l.IsValid() {
+		return stdtime.Time{}, fmt.Errorf("%w: year %d", ErrLunarOutOfRange, d.Year)
+	}
+	if d.Month < 1 || d.Month > 12 || d.Day < 1 || d.Day > 30 {
+		return stdtime.Time{}, fmt.Errorf("%w: %s", ErrInvalidLunarDate, d.numeric())
+	}
+
+	// carbon 不校验月、日和闰月是否存在，这里通过往返换算校验。
+	g := l.ToGregorian().Time.In(chinaStandardTime)
+	solar := stdtime.Date(g.Year(), g.Month(), g.Day(), 0, 0, 0, 0, defaultLocation())
+	back, err := SolarToLunar(solar)
+	if nil != err || back != d {
+		return stdtime.Time{}, fmt.Errorf("%w: %s", ErrInvalidLunarDate, d.numeric())
+	}
+	return solar, nil
+}
+
+// Zodiac 返回农历年对应的生肖。
+//
+// 参数：
+//   - year: 农历年。
+//
+// 返回：
+//   - string: 生肖名称，例如 "蛇"。
+func Zodiac(year int) string {
+	return zodiacs[(year%12+12)%12]
+}
+
+// LunarFestival 返回公历日期对应的农历传统节日。
+//
+// 参数：
+//   - t: 公历时间，按包默认时区的日期判断。
+//
+// 返回：
+//   - string: 节日名称，例如 "春节"、"中秋节"、"除夕"；不是节日时为空字符串。
+//   - bool: 是节日时为 true；t 超出支持范围时为 false。
+func LunarFestival(t stdtime.Time) (string, bool) {
+	d, err := SolarToLunar(t)
+	if nil != err {
+		return "", false
+	}
+	name := d.Festival()
+	return name, "" != name
+}
+
+// Zodiac 返回农历日期所在年份的生肖。
+//
+// 参数：无。
+//
+// 返回：
+//   - string: 生肖名称。
+func (d LunarDate) Zodiac() string {
+	return Zodiac(d.Year)
+}
+
+// Festival 返回农历日期对应的传统节日。
+//
+// 闰月中的日期不计为节日；腊月最后一日（廿九或三十）为除夕。
+//
+// 参数：无。
+//
+// 返回：
+//   - string: 节日名称；不是节日或日期无效时为空字符串。
+func (d LunarDate) Festival() string {
+	if d.IsLeapMonth {
+		return ""
+	}
+	if name, ok := lunarFestivals[[2]int{d.Month, d.Day}]; ok {
+		return name
+	}
+	if 12 == d.Month && d.Day >= 29 {
+		solar, err := LunarToSolar(d)
+		if nil != err {
+			return ""
+		}
+		next, err := SolarToLunar(solar.AddDate(0, 0, 1))
+		if nil == err && 1 == next.Month && 1 == next.Day {
+			return festivalNewYearsEve
+		}
+	}
+	return ""
+}
+
+// YearName 返回农历年的中文数字写法。
+//
+// 参数：无。
+//
+// 返回：
+//   - string: 例如 "二零二五"；年、月或日超出取值范围时为空字符串。
+func (d LunarDate) YearName() string {
+	return d.carbon().ToYearString()
+}
+
+// MonthName 返回农历月的中文名称。
+//
+// 参数：无。
+//
+// 返回：
+//   - string: 例如 "正月"、"闰六月"、"腊月"；年、月或日超出取值范围时为空字符串。
+func (d LunarDate) MonthName() string {
+	return d.carbon().ToMonthString()
+}
+
+// DayName 返回农历日的中文名称。
+//
+// 参数：无。
+//
+// 返回：
+//   - string: 例如 "初一"、"十五"、"廿九"；年、月或日超出取值范围时为空字符串。
+func (d LunarDate) DayName() string {
+	return d.carbon().ToDayString()
+}
+
+// String 返回农历日期的中文写法。
+//
+// 参数：无。
+//
+// 返回：
+//   - string: 例如 "二零二五年正月初一"；年、月或日超出取值范围时为空字符串。
+func (d LunarDate) String() string {
+	return d.carbon().ToDateString()
+}
+
+// carbon 返回对应的 carbon 农历实例。
+//
+// 参数：无。
+//
+// 返回：
+//   - *lunar.Lunar: carbon 农历实例；月或日超出取值范围时返回 nil，其各名称方法返回空字符串。
+func (d LunarDate) carbon() *lunar.Lunar {
+	if d.Month < 1 || d.Month > 12 || d.Day < 1 || d.Day > 30 {
+		return nil
+	}
+	return lunar.NewLunar(d.Year, d.Month, d.Day, d.IsLeapMonth)
+}
+
+// numeric 返回农历日期的数字写法，用于错误信息。
+//
+// 参数：无。
+//
+// 返回：
+//   - string: 例如 "2025-06-01" 或 "2025-闰06-01"。
+func (d LunarDate) numeric() string {
+	if d.IsLeapMonth {
+		return fmt.Sprintf("%04d-闰%02d-%02d", d.Year, d.Month, d.Day)
+	}
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
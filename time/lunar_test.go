@@ -0,0 +1,188 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package time
+
+import (
+	"testing"
+	stdtime "time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSolarToLunar 验证公历到农历的转换、名称、生肖和节日。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestSolarToLunar(t *testing.T) {
+	loc := defaultLocation()
+
+	tests := []struct {
+		name         string
+		description  string
+		give         stdtime.Time
+		want         LunarDate
+		wantString   string
+		wantZodiac   string
+		wantFestival string
+	}{
+		{
+			name:         "success/spring-festival",
+			description:  "验证 2025-01-29 为乙巳蛇年春节。",
+			give:         stdtime.Date(2025, 1, 29, 10, 0, 0, 0, loc),
+			want:         LunarDate{Year: 2025, Month: 1, Day: 1},
+			wantString:   "二零二五年正月初一",
+			wantZodiac:   "蛇",
+			wantFestival: "春节",
+		},
+		{
+			name:         "success/new-years-eve-29",
+			description:  "验证腊月只有廿九时廿九为除夕。",
+			give:         stdtime.Date(2025, 1, 28, 0, 0, 0, 0, loc),
+			want:         LunarDate{Year: 2024, Month: 12, Day: 29},
+			wantString:   "二零二四年腊月廿九",
+			wantZodiac:   "龙",
+			wantFestival: "除夕",
+		},
+		{
+			name:         "success/new-years-eve-30",
+			description:  "验证腊月有三十时三十为除夕。",
+			give:         stdtime.Date(2024, 2, 9, 0, 0, 0, 0, loc),
+			want:         LunarDate{Year: 2023, Month: 12, Day: 30},
+			wantString:   "二零二三年腊月三十",
+			wantZodiac:   "兔",
+			wantFestival: "除夕",
+		},
+		{
+			name:         "success/mid-autumn",
+			description:  "验证 2024-09-17 为中秋节。",
+			give:         stdtime.Date(2024, 9, 17, 0, 0, 0, 0, loc),
+			want:         LunarDate{Year: 2024, Month: 8, Day: 15},
+			wantString:   "二零二四年八月十五",
+			wantZodiac:   "龙",
+			wantFestival: "中秋节",
+		},
+		{
+			name:        "success/leap-month",
+			description: "验证 2025 年闰六月的日期，且闰月不计入节日。",
+			give:        stdtime.Date(2025, 7, 25, 0, 0, 0, 0, loc),
+			want:        LunarDate{Year: 2025, Month: 6, Day: 1, IsLeapMonth: true},
+			wantString:  "二零二五年闰六月初一",
+			wantZodiac:  "蛇",
+		},
+		{
+			name:         "boundary/other-timezone",
+			description:  "验证其它时区的时间先转换到默认时区再换算。",
+			give:         stdtime.Date(2025, 1, 28, 17, 0, 0, 0, stdtime.UTC),
+			want:         LunarDate{Year: 2025, Month: 1, Day: 1},
+			wantString:   "二零二五年正月初一",
+			wantZodiac:   "蛇",
+			wantFestival: "春节",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			got, err := SolarToLunar(tt.give)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantString, got.String())
+			assert.Equal(t, tt.wantZodiac, got.Zodiac())
+			assert.Equal(t, tt.wantFestival, got.Festival())
+
+			festival, ok := LunarFestival(tt.give)
+			assert.Equal(t, tt.wantFestival, festival)
+			assert.Equal(t, "" != tt.wantFestival, ok)
+
+			solar, err := LunarToSolar(got)
+			require.NoError(t, err)
+			giveLocal := tt.give.In(loc)
+			assert.Equal(t, stdtime.Date(giveLocal.Year(), giveLocal.Month(), giveLocal.Day(), 0, 0, 0, 0, loc), solar)
+		})
+	}
+}
+
+// TestLunarToSolar_Errors 验证超出范围和不存在的农历日期返回错误。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestLunarToSolar_Errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		give        LunarDate
+		wantErr     error
+	}{
+		{
+			name:        "error/year-out-of-range",
+			description: "验证年份超出 1900 至 2100 时返回 ErrLunarOutOfRange。",
+			give:        LunarDate{Year: 1899, Month: 1, Day: 1},
+			wantErr:     ErrLunarOutOfRange,
+		},
+		{
+			name:        "error/month-out-of-range",
+			description: "验证月份超出 1 至 12 时返回 ErrInvalidLunarDate。",
+			give:        LunarDate{Year: 2025, Month: 13, Day: 1},
+			wantErr:     ErrInvalidLunarDate,
+		},
+		{
+			name:        "error/no-such-leap-month",
+			description: "验证当年不存在的闰月返回 ErrInvalidLunarDate。",
+			give:        LunarDate{Year: 2025, Month: 5, Day: 1, IsLeapMonth: true},
+			wantErr:     ErrInvalidLunarDate,
+		},
+		{
+			name:        "error/no-day-30",
+			description: "验证小月的三十日返回 ErrInvalidLunarDate。",
+			give:        LunarDate{Year: 2024, Month: 12, Day: 30},
+			wantErr:     ErrInvalidLunarDate,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			_, err := LunarToSolar(tt.give)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+
+	_, err := SolarToLunar(stdtime.Time{})
+	assert.ErrorIs(t, err, ErrLunarOutOfRange)
+	_, err = SolarToLunar(stdtime.Date(1800, 1, 1, 0, 0, 0, 0, stdtime.UTC))
+	assert.ErrorIs(t, err, ErrLunarOutOfRange)
+	_, err = SolarToLunar(stdtime.Date(2101, 1, 29, 0, 0, 0, 0, defaultLocation()))
+	assert.ErrorIs(t, err, ErrLunarOutOfRange)
+	last, err := SolarToLunar(stdtime.Date(2101, 1, 28, 0, 0, 0, 0, defaultLocation()))
+	require.NoError(t, err)
+	assert.Equal(t, 2100, last.Year)
+	_, ok := LunarFestival(stdtime.Time{})
+	assert.False(t, ok)
+}
+
+// TestLunarDate_Names 验证农历日期的中文名称和生肖。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestLunarDate_Names(t *testing.T) {
+	d := LunarDate{Year: 2020, Month: 12, Day: 21}
+	assert.Equal(t, "二零二零", d.YearName())
+	assert.Equal(t, "腊月", d.MonthName())
+	assert.Equal(t, "廿一", d.DayName())
+	assert.Equal(t, "鼠", d.Zodiac())
+
+	assert.Equal(t, "闰二月", LunarDate{Year: 2023, Month: 2, Day: 10, IsLeapMonth: true}.MonthName())
+	assert.Equal(t, "初十", LunarDate{Year: 2023, Month: 2, Day: 10}.DayName())
+	assert.Empty(t, LunarDate{Year: 2023, Month: 0, Day: 10}.MonthName())
+	assert.Empty(t, LunarDate{Year: 2023, Month: 12, Day: 29}.Festival())
+
+	assert.Equal(t, "猴", Zodiac(2016))
+	assert.Equal(t, "羊", Zodiac(-1))
+}
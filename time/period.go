@@ -0,0 +1,440 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package time
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	stdtime "time"
+
+	"github.com/dromara/carbon/v2"
+)
+
+var (
+	// ErrInvalidPeriod 表示时间段的起止时间无效、结束时间早于开始时间或步长不是正值。
+	ErrInvalidPeriod = errors.New("invalid period")
+)
+
+type (
+	// PeriodStep 是时间段的迭代步长，由 StepDuration、StepDays、StepWeeks、StepMonths 或 StepYears 创建。
+	//
+	// 按日、周计算的步长在本地日历上前进，跨越夏令时切换时保持相同的时分秒；按月、年计算的步长在目标月份
+	// 天数不足时夹取到月末，与 carbon 的 NoOverflow 语义一致。
+	PeriodStep struct {
+		// months 是按自然月计算的步长。
+		months int
+		// days 是按自然日计算的步长。
+		days int
+		// duration 是按绝对时长计算的步长。
+		duration stdtime.Duration
+	}
+
+	// Period 表示左闭右开的时间段 [start, end)，按步长迭代时间点或划分时间桶。
+	//
+	// 迭代的第 i 个时间点由开始时间加上 i 个步长计算，而不是在上一个时间点上累加，因此按月迭代时
+	// 1 月 31 日之后依次为 2 月 28 日（或 29 日）、3 月 31 日，不会逐月漂移。Period 不可变，可以并发使用。
+	// 零值 Period 不可直接使用，调用方应通过 NewPeriod 创建。
+	Period struct {
+		// start 是开始时间（包含）。
+		start stdtime.Time
+		// end 是结束时间（不包含）。
+		end stdtime.Time
+		// step 是迭代步长。
+		step PeriodStep
+	}
+)
+
+// StepDuration 返回按绝对时长前进的步长。
+//
+// 参数：
+//   - d: 步长时长，应为正值。
+//
+// 返回：
+//   - PeriodStep: 按 d 前进的步长。
+func StepDuration(d stdtime.Duration) PeriodStep {
+	return PeriodStep{duration: d}
+}
+
+// StepDays 返回按自然日前进的步长。
+//
+// 参数：
+//   - n: 天数，应为正值。
+//
+// 返回：
+//   - PeriodStep: 按 n 天前进的步长。
+func StepDays(n int) PeriodStep {
+	return PeriodStep{days: n}
+}
+
+// StepWeeks 返回按自然周前进的步长。
+//
+// 参数：
+//   - n: 周数，应为正值。
+//
+// 返回：
+//   - PeriodStep: 按 7*n 天前进的步长。
+func StepWeeks(n int) PeriodStep {
+	return PeriodStep{days: 7 * n}
+}
+
+// StepMonths 返回按自然月前进的步长。
+//
+// 参数：
+//   - n: 月数，应为正值。
+//
+// 返回：
+//   - PeriodStep: 按 n 个月前进的步长。
+func StepMonths(n int) PeriodStep {
+	return PeriodStep{months: n}
+}
+
+// StepYears 返回按自然年前进的步长。
+//
+// 参数：
+//   - n: 年数，应为正值。
+//
+// 返回：
+//   - PeriodStep: 按 12*n 个月前进的步长。
+func StepYears(n int) PeriodStep {
+	return PeriodStep{months: 12 * n}
+}
+
+// String 返回步长的易读表示。
+//
+// 参数：无。
+//
+// 返回：
+//   - string: 例如 "1 month"、"7 days" 或 "1h0m0s"。
+func (s PeriodStep) String() string {
+	switch {
+	case 0 != s.months:
+		return pluralize(s.months, "month")
+	case 0 != s.days:
+		return pluralize(s.days, "day")
+	default:
+		return s.duration.String()
+	}
+}
+
+// NewPeriod 创建左闭右开的时间段 [start, end)。
+//
+// 时间段使用 start 所在的时区计算日、周、月边界，end 会转换到该时区。
+//
+// 参数：
+//   - start: 开始时间（包含）。
+//   - end: 结束时间（不包含），不得早于 start；与 start 相等时表示空时间段。
+//   - step: 迭代步长。
+//
+// 返回：
+//   - *Period: 创建的时间段。
+//   - error: start 或 end 为 nil 或无效、end 早于 start、step 不是正值时返回 ErrInvalidPeriod。
+func NewPeriod(start, end *carbon.Carbon, step PeriodStep) (*Period, error) {
+	if start.IsNil() || end.IsNil() || start.IsInvalid() || end.IsInvalid() {
+		return nil, fmt.Errorf("%w: invalid start or end", ErrInvalidPeriod)
+	}
+	if step.months < 0 || step.days < 0 || step.duration < 0 || (0 == step.months && 0 == step.days && 0 == step.duration) {
+		return nil, fmt.Errorf("%w: step %s must be positive", ErrInvalidPeriod, step)
+	}
+	s := start.StdTime()
+	e := end.StdTime().In(s.Location())
+	if e.Before(s) {
+		return nil, fmt.Errorf("%w: end %s before start %s", ErrInvalidPeriod, e.Format(stdtime.RFC3339), s.Format(stdtime.RFC3339))
+	}
+	return &Period{start: s, end: e, step: step}, nil
+}
+
+// Start 返回开始时间。
+//
+// 参数：无。
+//
+// 返回：
+//   - *carbon.Carbon: 开始时间的新 Carbon 实例，修改它不会影响时间段。
+func (p *Period) Start() *carbon.Carbon {
+	return carbon.CreateFromStdTime(p.start)
+}
+
+// End 返回结束时间。
+//
+// 参数：无。
+//
+// 返回：
+//   - *carbon.Carbon: 结束时间（不包含）的新 Carbon 实例，修改它不会影响时间段。
+func (p *Period) End() *carbon.Carbon {
+	return carbon.CreateFromStdTime(p.end)
+}
+
+// Step 返回迭代步长。
+//
+// 参数：无。
+//
+// 返回：
+//   - PeriodStep: 迭代步长。
+func (p *Period) Step() PeriodStep {
+	return p.step
+}
+
+// Duration 返回时间段的长度。
+//
+// 参数：无。
+//
+// 返回：
+//   - time.Duration: 结束时间与开始时间之差。
+func (p *Period) Duration() stdtime.Duration {
+	return p.end.Sub(p.start)
+}
+
+// IsEmpty 判断时间段是否为空。
+//
+// 参数：无。
+//
+// 返回：
+//   - bool: 开始时间与结束时间相等时为 true。
+func (p *Period) IsEmpty() bool {
+	return !p.start.Before(p.end)
+}
+
+// Contains 判断时间点是否位于时间段内。
+//
+// 参数：
+//   - t: 待判断的时间点；为 nil 或无效时返回 false。
+//
+// 返回：
+//   - bool: start <= t < end 时为 true。
+func (p *Period) Contains(t *carbon.Carbon) bool {
+	if t.IsNil() || t.IsInvalid() {
+		return false
+	}
+	st := t.StdTime()
+	return !st.Before(p.start) && st.Before(p.end)
+}
+
+// All 按步长迭代时间段内的时间点。
+//
+// 第一个时间点为开始时间，之后每个时间点为开始时间加上 i 个步长，直到到达结束时间（不包含）。
+// 每个时间点都是新的 Carbon 实例。
+//
+// 参数：无。
+//
+// 返回：
+//   - iter.Seq[*carbon.Carbon]: 时间点序列，可以用于 for range。
+func (p *Period) All() iter.Seq[*carbon.Carbon] {
+	return func(yield func(*carbon.Carbon) bool) {
+		for t := range p.points() {
+			if !yield(carbon.CreateFromStdTime(t)) {
+				return
+			}
+		}
+	}
+}
+
+// Buckets 按步长把时间段划分为连续的子时间段。
+//
+// 每个子时间段从一个迭代时间点开始，到下一个时间点或结束时间为止，最后一个子时间段可能短于步长。
+// 子时间段沿用本时间段的步长。
+//
+// 参数：无。
+//
+// 返回：
+//   - []*Period: 按时间顺序排列的子时间段；时间段为空时返回 nil。
+func (p *Period) Buckets() []*Period {
+	var buckets []*Period
+	var prev stdtime.Time
+	first := true
+	for t := range p.points() {
+		if !first {
+			buckets = append(buckets, &Period{start: prev, end: t, step: p.step})
+		}
+		prev, first = t, false
+	}
+	if !first {
+		buckets = append(buckets, &Period{start: prev, end: p.end, step: p.step})
+	}
+	return buckets
+}
+
+// Days 按自然日划分时间段。
+//
+// 子时间段以当日零点为边界，首尾两段按时间段的起止时间截断，步长为 1 天。
+//
+// 参数：无。
+//
+// 返回：
+//   - []*Period: 按时间顺序排列的子时间段；时间段为空时返回 nil。
+func (p *Period) Days() []*Period {
+	return p.split(StepDays(1), func(c *carbon.Carbon) *carbon.Carbon { return c.StartOfDay() })
+}
+
+// Weeks 按自然周划分时间段。
+//
+// 子时间段以每周起始日（包默认配置 defaultWeekStartAt，默认为星期一）零点为边界，首尾两段按时间段的
+// 起止时间截断，步长为 1 周。
+//
+// 参数：无。
+//
+// 返回：
+//   - []*Period: 按时间顺序排列的子时间段；时间段为空时返回 nil。
+func (p *Period) Weeks() []*Period {
+	return p.split(StepWeeks(1), func(c *carbon.Carbon) *carbon.Carbon { return c.StartOfWeek() })
+}
+
+// Months 按自然月划分时间段。
+//
+// 子时间段以每月 1 日零点为边界，首尾两段按时间段的起止时间截断，步长为 1 个月。
+//
+// 参数：无。
+//
+// 返回：
+//   - []*Period: 按时间顺序排列的子时间段；时间段为空时返回 nil。
+func (p *Period) Months() []*Period {
+	return p.split(StepMonths(1), func(c *carbon.Carbon) *carbon.Carbon { return c.StartOfMonth() })
+}
+
+// Overlaps 判断两个时间段是否重叠。
+//
+// 时间段左闭右开，首尾相接（一个的结束时间等于另一个的开始时间）不算重叠；空时间段与任何时间段都不重叠。
+//
+// 参数：
+//   - other: 另一个时间段。
+//
+// 返回：
+//   - bool: 两个时间段存在共同的时间点时为 true。
+func (p *Period) Overlaps(other *Period) bool {
+	return p.start.Before(other.end) && other.start.Before(p.end)
+}
+
+// Intersect 返回两个时间段的交集。
+//
+// 参数：
+//   - other: 另一个时间段。
+//
+// 返回：
+//   - *Period: 交集，沿用本时间段的时区与步长；不重叠时为 nil。
+//   - bool: 两个时间段重叠时为 true。
+func (p *Period) Intersect(other *Period) (*Period, bool) {
+	if !p.Overlaps(other) {
+		return nil, false
+	}
+	start, end := p.start, p.end
+	if other.start.After(start) {
+		start = other.start.In(p.start.Location())
+	}
+	if other.end.Before(end) {
+		end = other.end.In(p.start.Location())
+	}
+	return &Period{start: start, end: end, step: p.step}, true
+}
+
+// Union 返回两个时间段的并集。
+//
+// 只有重叠或首尾相接的时间段才能合并为一个连续的时间段。
+//
+// 参数：
+//   - other: 另一个时间段。
+//
+// 返回：
+//   - *Period: 并集，沿用本时间段的时区与步长；两个时间段之间存在间隔时为 nil。
+//   - bool: 两个时间段重叠或首尾相接时为 true。
+func (p *Period) Union(other *Period) (*Period, bool) {
+	if p.start.After(other.end) || other.start.After(p.end) {
+		return nil, false
+	}
+	start, end := p.start, p.end
+	if other.start.Before(start) {
+		start = other.start.In(p.start.Location())
+	}
+	if other.end.After(end) {
+		end = other.end.In(p.start.Location())
+	}
+	return &Period{start: start, end: end, step: p.step}, true
+}
+
+// String 返回时间段的易读表示。
+//
+// 参数：无。
+//
+// 返回：
+//   - string: 形如 "[2025-01-01T00:00:00+08:00, 2025-02-01T00:00:00+08:00) every 1 day" 的字符串。
+func (p *Period) String() string {
+	return fmt.Sprintf("[%s, %s) every %s", p.start.Format(stdtime.RFC3339), p.end.Format(stdtime.RFC3339), p.step)
+}
+
+// points 迭代时间段内的时间点。
+//
+// 参数：无。
+//
+// 返回：
+//   - iter.Seq[stdtime.Time]: 开始时间加上 0、1、2…… 个步长且早于结束时间的时间点。
+func (p *Period) points() iter.Seq[stdtime.Time] {
+	return func(yield func(stdtime.Time) bool) {
+		for i := 0; ; i++ {
+			t := p.step.advance(p.start, i)
+			if !t.Before(p.end) || !yield(t) {
+				return
+			}
+		}
+	}
+}
+
+// split 以 floor 计算的日历边界划分时间段。
+//
+// 参数：
+//   - step: 子时间段的步长，同时用于从一个边界前进到下一个边界。
+//   - floor: 返回时间点所在日历单位的起点，会修改并返回传入的 Carbon 实例。
+//
+// 返回：
+//   - []*Period: 按时间顺序排列的子时间段；时间段为空时返回 nil。
+func (p *Period) split(step PeriodStep, floor func(*carbon.Carbon) *carbon.Carbon) []*Period {
+	if p.IsEmpty() {
+		return nil
+	}
+	base := floor(carbon.CreateFromStdTime(p.start)).StdTime()
+
+	var periods []*Period
+	start := p.start
+	for i := 1; start.Before(p.end); i++ {
+		end := step.advance(base, i)
+		if end.After(p.end) {
+			end = p.end
+		}
+		periods = append(periods, &Period{start: start, end: end, step: step})
+		start = end
+	}
+	return periods
+}
+
+// advance 返回 t 加上 n 个步长后的时间。
+//
+// 参数：
+//   - t: 起始时间。
+//   - n: 步长个数。
+//
+// 返回：
+//   - stdtime.Time: 偏移后的时间。
+func (s PeriodStep) advance(t stdtime.Time, n int) stdtime.Time {
+	switch {
+	case 0 != s.months:
+		return addMonths(t, n*s.months)
+	case 0 != s.days:
+		return t.AddDate(0, 0, n*s.days)
+	default:
+		return t.Add(stdtime.Duration(n) * s.duration)
+	}
+}
+
+// pluralize 返回带英文单位的数量。
+//
+// 参数：
+//   - n: 数量。
+//   - unit: 单数形式的单位。
+//
+// 返回：
+//   - string: n 为 1 时为 "1 unit"，否则为 "n units"。
+func pluralize(n int, unit string) string {
+	if 1 == n {
+		return "1 " + unit
+	}
+	return fmt.Sprintf("%d %ss", n, unit)
+}
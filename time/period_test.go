@@ -0,0 +1,314 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package time
+
+import (
+	"testing"
+	stdtime "time"
+
+	"github.com/dromara/carbon/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mustPeriod 创建测试用的时间段。
+//
+// 参数：
+//   - t: 测试上下文，创建失败时终止测试。
+//   - start: 开始时间。
+//   - end: 结束时间。
+//   - step: 迭代步长。
+//
+// 返回：
+//   - *Period: 创建的时间段。
+func mustPeriod(t *testing.T, start, end stdtime.Time, step PeriodStep) *Period {
+	t.Helper()
+
+	p, err := NewPeriod(carbon.CreateFromStdTime(start), carbon.CreateFromStdTime(end), step)
+	require.NoError(t, err)
+	return p
+}
+
+// formatPoints 把时间点格式化为字符串，便于断言。
+//
+// 参数：
+//   - p: 时间段。
+//
+// 返回：
+//   - []string: 按 2006-01-02 15:04 格式化的迭代时间点。
+func formatPoints(p *Period) []string {
+	var points []string
+	for c := range p.All() {
+		points = append(points, c.StdTime().Format("2006-01-02 15:04"))
+	}
+	return points
+}
+
+// formatPeriods 把子时间段格式化为字符串，便于断言。
+//
+// 参数：
+//   - periods: 子时间段。
+//
+// 返回：
+//   - []string: 形如 "01-01 00:00~01-02 00:00" 的字符串。
+func formatPeriods(periods []*Period) []string {
+	var out []string
+	for _, p := range periods {
+		out = append(out, p.start.Format("01-02 15:04")+"~"+p.end.Format("01-02 15:04"))
+	}
+	return out
+}
+
+// TestNewPeriod 验证时间段的参数校验。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestNewPeriod(t *testing.T) {
+	start := carbon.CreateFromStdTime(stdtime.Date(2025, 1, 1, 0, 0, 0, 0, stdtime.UTC))
+	end := carbon.CreateFromStdTime(stdtime.Date(2025, 1, 2, 0, 0, 0, 0, stdtime.UTC))
+
+	tests := []struct {
+		name        string
+		description string
+		start       *carbon.Carbon
+		end         *carbon.Carbon
+		step        PeriodStep
+		wantErr     bool
+	}{
+		{name: "success/valid", description: "验证合法参数创建成功。", start: start, end: end, step: StepDays(1)},
+		{name: "success/empty", description: "验证起止时间相等时创建空时间段。", start: start, end: start, step: StepDays(1)},
+		{name: "error/reversed", description: "验证结束时间早于开始时间时返回错误。", start: end, end: start, step: StepDays(1), wantErr: true},
+		{name: "error/zero-step", description: "验证零步长返回错误。", start: start, end: end, step: StepDuration(0), wantErr: true},
+		{name: "error/negative-step", description: "验证负步长返回错误。", start: start, end: end, step: StepMonths(-1), wantErr: true},
+		{name: "error/nil", description: "验证 nil 起止时间返回错误。", start: nil, end: end, step: StepDays(1), wantErr: true},
+		{name: "error/invalid", description: "验证无效的 Carbon 返回错误。", start: carbon.Parse("invalid"), end: end, step: StepDays(1), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			p, err := NewPeriod(tt.start, tt.end, tt.step)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidPeriod)
+				assert.Nil(t, p)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.end.StdTime().Sub(tt.start.StdTime()), p.Duration())
+		})
+	}
+}
+
+// TestPeriod_All 验证按不同步长迭代时间点。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestPeriod_All(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		start       stdtime.Time
+		end         stdtime.Time
+		step        PeriodStep
+		want        []string
+	}{
+		{
+			name:        "success/duration",
+			description: "验证按时长迭代且不包含结束时间。",
+			start:       stdtime.Date(2025, 1, 1, 0, 0, 0, 0, stdtime.UTC),
+			end:         stdtime.Date(2025, 1, 1, 1, 0, 0, 0, stdtime.UTC),
+			step:        StepDuration(20 * stdtime.Minute),
+			want:        []string{"2025-01-01 00:00", "2025-01-01 00:20", "2025-01-01 00:40"},
+		},
+		{
+			name:        "success/months-no-drift",
+			description: "验证按月迭代时月末夹取且不逐月漂移。",
+			start:       stdtime.Date(2024, 1, 31, 9, 0, 0, 0, stdtime.UTC),
+			end:         stdtime.Date(2024, 5, 1, 0, 0, 0, 0, stdtime.UTC),
+			step:        StepMonths(1),
+			want:        []string{"2024-01-31 09:00", "2024-02-29 09:00", "2024-03-31 09:00", "2024-04-30 09:00"},
+		},
+		{
+			name:        "success/weeks",
+			description: "验证按周迭代。",
+			start:       stdtime.Date(2025, 3, 3, 0, 0, 0, 0, stdtime.UTC),
+			end:         stdtime.Date(2025, 3, 20, 0, 0, 0, 0, stdtime.UTC),
+			step:        StepWeeks(1),
+			want:        []string{"2025-03-03 00:00", "2025-03-10 00:00", "2025-03-17 00:00"},
+		},
+		{
+			name:        "success/empty",
+			description: "验证空时间段不产生时间点。",
+			start:       stdtime.Date(2025, 3, 3, 0, 0, 0, 0, stdtime.UTC),
+			end:         stdtime.Date(2025, 3, 3, 0, 0, 0, 0, stdtime.UTC),
+			step:        StepYears(1),
+			want:        nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			assert.Equal(t, tt.want, formatPoints(mustPeriod(t, tt.start, tt.end, tt.step)))
+		})
+	}
+
+	t.Run("success/dst", func(t *testing.T) {
+		t.Log("验证按日迭代跨越夏令时切换时保持本地时刻。")
+
+		loc, err := stdtime.LoadLocation("America/New_York")
+		if nil != err {
+			t.Skip("缺少时区数据。")
+		}
+		p := mustPeriod(t, stdtime.Date(2025, 3, 8, 12, 0, 0, 0, loc), stdtime.Date(2025, 3, 11, 0, 0, 0, 0, loc), StepDays(1))
+		assert.Equal(t, []string{"2025-03-08 12:00", "2025-03-09 12:00", "2025-03-10 12:00"}, formatPoints(p))
+		// 2025-03-09 切换为夏令时，当天只有 23 小时。
+		days := p.Buckets()
+		require.Len(t, days, 3)
+		assert.Equal(t, 23*stdtime.Hour, days[0].Duration())
+	})
+
+	t.Run("success/break", func(t *testing.T) {
+		t.Log("验证提前结束迭代。")
+
+		p := mustPeriod(t, stdtime.Date(2025, 1, 1, 0, 0, 0, 0, stdtime.UTC), stdtime.Date(2026, 1, 1, 0, 0, 0, 0, stdtime.UTC), StepDays(1))
+		count := 0
+		for range p.All() {
+			count++
+			if 3 == count {
+				break
+			}
+		}
+		assert.Equal(t, 3, count)
+	})
+}
+
+// TestPeriod_Split 验证按步长与自然日、周、月划分时间段。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestPeriod_Split(t *testing.T) {
+	// 2025-01-30 为星期四。
+	p := mustPeriod(t, stdtime.Date(2025, 1, 30, 12, 0, 0, 0, stdtime.UTC), stdtime.Date(2025, 2, 12, 6, 0, 0, 0, stdtime.UTC), StepDays(5))
+
+	tests := []struct {
+		name        string
+		description string
+		split       func() []*Period
+		want        []string
+	}{
+		{
+			name:        "buckets",
+			description: "验证按步长划分，最后一段短于步长。",
+			split:       p.Buckets,
+			want:        []string{"01-30 12:00~02-04 12:00", "02-04 12:00~02-09 12:00", "02-09 12:00~02-12 06:00"},
+		},
+		{
+			name:        "weeks",
+			description: "验证按周一为起始日划分自然周，首尾截断。",
+			split:       p.Weeks,
+			want:        []string{"01-30 12:00~02-03 00:00", "02-03 00:00~02-10 00:00", "02-10 00:00~02-12 06:00"},
+		},
+		{
+			name:        "months",
+			description: "验证按自然月划分。",
+			split:       p.Months,
+			want:        []string{"01-30 12:00~02-01 00:00", "02-01 00:00~02-12 06:00"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			assert.Equal(t, tt.want, formatPeriods(tt.split()))
+		})
+	}
+
+	t.Run("days", func(t *testing.T) {
+		t.Log("验证按自然日划分，子时间段步长为 1 天。")
+
+		days := p.Days()
+		require.Len(t, days, 14)
+		assert.Equal(t, "01-30 12:00~01-31 00:00", formatPeriods(days[:1])[0])
+		assert.Equal(t, "02-12 00:00~02-12 06:00", formatPeriods(days[13:])[0])
+		assert.Equal(t, "1 day", days[0].Step().String())
+	})
+}
+
+// TestPeriod_SetOperations 验证重叠判断、交集与并集。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestPeriod_SetOperations(t *testing.T) {
+	day := func(d int) stdtime.Time { return stdtime.Date(2025, 1, d, 0, 0, 0, 0, stdtime.UTC) }
+	a := mustPeriod(t, day(1), day(10), StepDays(1))
+
+	tests := []struct {
+		name          string
+		description   string
+		other         *Period
+		wantOverlap   bool
+		wantIntersect string
+		wantUnion     string
+	}{
+		{
+			name:          "overlap",
+			description:   "验证部分重叠的时间段。",
+			other:         mustPeriod(t, day(5), day(15), StepDays(1)),
+			wantOverlap:   true,
+			wantIntersect: "01-05 00:00~01-10 00:00",
+			wantUnion:     "01-01 00:00~01-15 00:00",
+		},
+		{
+			name:          "contained",
+			description:   "验证被包含的时间段。",
+			other:         mustPeriod(t, day(3), day(4), StepDays(1)),
+			wantOverlap:   true,
+			wantIntersect: "01-03 00:00~01-04 00:00",
+			wantUnion:     "01-01 00:00~01-10 00:00",
+		},
+		{
+			name:        "adjacent",
+			description: "验证首尾相接不算重叠，但可以合并。",
+			other:       mustPeriod(t, day(10), day(12), StepDays(1)),
+			wantUnion:   "01-01 00:00~01-12 00:00",
+		},
+		{
+			name:        "disjoint",
+			description: "验证存在间隔的时间段既不重叠也不能合并。",
+			other:       mustPeriod(t, day(11), day(12), StepDays(1)),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			assert.Equal(t, tt.wantOverlap, a.Overlaps(tt.other))
+			assert.Equal(t, tt.wantOverlap, tt.other.Overlaps(a))
+
+			intersect, ok := a.Intersect(tt.other)
+			assert.Equal(t, tt.wantOverlap, ok)
+			if ok {
+				assert.Equal(t, tt.wantIntersect, formatPeriods([]*Period{intersect})[0])
+			}
+
+			union, ok := a.Union(tt.other)
+			assert.Equal(t, "" != tt.wantUnion, ok)
+			if ok {
+				assert.Equal(t, tt.wantUnion, formatPeriods([]*Period{union})[0])
+			}
+		})
+	}
+
+	assert.True(t, a.Contains(carbon.CreateFromStdTime(day(1))))
+	assert.False(t, a.Contains(carbon.CreateFromStdTime(day(10))))
+	assert.False(t, a.Contains(nil))
+	assert.Equal(t, "[2025-01-01T00:00:00Z, 2025-01-10T00:00:00Z) every 1 day", a.String())
+	assert.Equal(t, "2 months", StepMonths(2).String())
+}
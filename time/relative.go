@@ -0,0 +1,415 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package time
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	stdtime "time"
+)
+
+var (
+	// ErrInvalidRelativeTime 表示相对时间表达式无法识别。
+	ErrInvalidRelativeTime = errors.New("invalid relative time")
+
+	// relativeDays 是以日为单位的固定表达式及其相对 base 的天数偏移。
+	relativeDays = map[string]int{
+		"today":                0,
+		"yesterday":            -1,
+		"tomorrow":             1,
+		"day before yesterday": -2,
+		"day after tomorrow":   2,
+		"今天":                   0,
+		"今日":                   0,
+		"昨天":                   -1,
+		"昨日":                   -1,
+		"明天":                   1,
+		"明日":                   1,
+		"前天":                   -2,
+		"后天":                   2,
+		"大前天":                  -3,
+		"大后天":                  3,
+	}
+
+	// relativeUnits 是单位名称到内部单位的映射，英文单位同时接受复数和常见缩写。
+	relativeUnits = map[string]relativeUnit{
+		"second": unitSecond, "sec": unitSecond,
+		"minute": unitMinute, "min": unitMinute,
+		"hour": unitHour, "hr": unitHour, "h": unitHour,
+		"day": unitDay, "d": unitDay,
+		"week": unitWeek, "w": unitWeek,
+		"month": unitMonth,
+		"year":  unitYear, "y": unitYear,
+		"秒": unitSecond, "秒钟": unitSecond,
+		"分": unitMinute, "分钟": unitMinute,
+		"小时": unitHour, "钟头": unitHour,
+		"天": unitDay, "日": unitDay,
+		"周": unitWeek, "星期": unitWeek, "礼拜": unitWeek,
+		"月": unitMonth,
+		"年": unitYear,
+	}
+
+	// englishNumbers 是英文数量词对应的数值。
+	englishNumbers = map[string]int{
+		"a": 1, "an": 1, "one": 1, "two": 2, "three": 3, "four": 4, "five": 5, "six": 6,
+		"seven": 7, "eight": 8, "nine": 9, "ten": 10, "eleven": 11, "twelve": 12,
+	}
+
+	// englishWeekdays 是英文星期名称及缩写对应的星期。
+	englishWeekdays = map[string]stdtime.Weekday{
+		"sunday": stdtime.Sunday, "sun": stdtime.Sunday,
+		"monday": stdtime.Monday, "mon": stdtime.Monday,
+		"tuesday": stdtime.Tuesday, "tue": stdtime.Tuesday, "tues": stdtime.Tuesday,
+		"wednesday": stdtime.Wednesday, "wed": stdtime.Wednesday,
+		"thursday": stdtime.Thursday, "thu": stdtime.Thursday, "thur": stdtime.Thursday, "thurs": stdtime.Thursday,
+		"friday": stdtime.Friday, "fri": stdtime.Friday,
+		"saturday": stdtime.Saturday, "sat": stdtime.Saturday,
+	}
+
+	// chineseWeekdays 是 "周一" 等表达式中星期后缀对应的星期。
+	chineseWeekdays = map[string]stdtime.Weekday{
+		"一": stdtime.Monday, "1": stdtime.Monday,
+		"二": stdtime.Tuesday, "2": stdtime.Tuesday,
+		"三": stdtime.Wednesday, "3": stdtime.Wednesday,
+		"四": stdtime.Thursday, "4": stdtime.Thursday,
+		"五": stdtime.Friday, "5": stdtime.Friday,
+		"六": stdtime.Saturday, "6": stdtime.Saturday,
+		"日": stdtime.Sunday, "天": stdtime.Sunday, "七": stdtime.Sunday, "7": stdtime.Sunday,
+	}
+
+	// chineseDigits 是中文数字对应的数值。
+	chineseDigits = map[rune]int{
+		'零': 0, '〇': 0, '一': 1, '二': 2, '两': 2, '三': 3, '四': 4,
+		'五': 5, '六': 6, '七': 7, '八': 8, '九': 9,
+	}
+
+	// englishAgoPattern 匹配 "3 days ago"、"2 hours later"、"a week from now" 形式的表达式。
+	englishAgoPattern = regexp.MustCompile(`^([0-9]+|[a-z]+) ?([a-z]+?)s? (ago|later|from now)$`)
+	// englishInPattern 匹配 "in 3 days"、"in an hour" 形式的表达式。
+	englishInPattern = regexp.MustCompile(`^in ([0-9]+|[a-z]+) ?([a-z]+?)s?$`)
+	// englishNextPattern 匹配 "next monday"、"last week"、"this friday" 形式的表达式。
+	englishNextPattern = regexp.MustCompile(`^(next|last|this) ([a-z]+)$`)
+	// chineseAgoPattern 匹配 "3天前"、"两小时后"、"十个月以前" 形式的表达式。
+	chineseAgoPattern = regexp.MustCompile(`^([0-9]+|[零〇一二两三四五六七八九十百]+)个?(秒钟?|分钟?|小时|钟头|天|日|周|星期|礼拜|月|年)(前|后|以前|以后|之前|之后)$`)
+	// chineseWeekdayPattern 匹配 "下周三"、"上星期五"、"周日"、"本周一" 形式的表达式。
+	chineseWeekdayPattern = regexp.MustCompile(`^(上上|下下|上个?|下个?|本|这个?)?(周|星期|礼拜)([一二三四五六日天七1-7])$`)
+	// chinesePeriodPattern 匹配 "上周"、"下个月"、"上上个月"、"本月" 形式的表达式。
+	chinesePeriodPattern = regexp.MustCompile(`^(上上|下下|上|下|本|这)个?(周|星期|礼拜|月)$`)
+)
+
+// relativeUnit 是相对时间表达式中的时间单位。
+type relativeUnit int
+
+const (
+	unitSecond relativeUnit = iota
+	unitMinute
+	unitHour
+	unitDay
+	unitWeek
+	unitMonth
+	unitYear
+)
+
+// ParseRelative 解析英文或中文的相对时间表达式，返回相对 base 的具体时间。
+//
+// base 会先转换到包默认时区（defaultTimezone，默认为 PRC），返回值同样位于该时区。支持的表达式包括：
+//   - 当前时刻："now"、"现在"、"刚刚"，返回 base。
+//   - 日期："today"、"yesterday"、"day after tomorrow"、"今天"、"昨天"、"大后天" 等，返回对应日期的零点。
+//   - 偏移："3 days ago"、"in 2 hours"、"a week from now"、"3天前"、"两小时后"、"十个月以后" 等，在 base 上加减，
+//     保留时分秒；按月、年偏移时目标月份天数不足则夹取到月末。
+//   - 星期："monday"、"next monday"、"last friday"、"周一"、"下周三"、"上星期五"、"上上周日" 等，返回本周、下周或上周
+//     对应星期的零点，每周起始日取 defaultWeekStartAt。
+//   - 周期："next week"、"last month"、"next year"、"上周"、"下个月"、"去年"、"明年" 等，在 base 上偏移一个周期并保留时分秒。
+//
+// 表达式不区分大小写，首尾空白与英文单词间的多个空白会被忽略。数量可以是阿拉伯数字、"a"/"an"、one 至 twelve 的英文
+// 单词，或不超过九百九十九的中文数字。
+//
+// 参数：
+//   - s: 相对时间表达式。
+//   - base: 计算的参照时间，通常为当前时间。
+//
+// 返回：
+//   - stdtime.Time: 位于包默认时区的具体时间。
+//   - error: 表达式无法识别时返回 ErrInvalidRelativeTime。
+func ParseRelative(s string, base stdtime.Time) (stdtime.Time, error) {
+	base = base.In(defaultLocation())
+	expr := strings.Join(strings.Fields(strings.ToLower(s)), " ")
+
+	switch expr {
+	case "now", "现在", "刚刚", "此刻":
+		return base, nil
+	case "last week":
+		return base.AddDate(0, 0, -7), nil
+	case "next week":
+		return base.AddDate(0, 0, 7), nil
+	case "last month":
+		return addMonths(base, -1), nil
+	case "next month":
+		return addMonths(base, 1), nil
+	case "last year", "去年":
+		return addMonths(base, -12), nil
+	case "next year", "明年":
+		return addMonths(base, 12), nil
+	case "前年":
+		return addMonths(base, -24), nil
+	case "后年":
+		return addMonths(base, 24), nil
+	}
+	if days, ok := relativeDays[expr]; ok {
+		return startOfDay(base).AddDate(0, 0, days), nil
+	}
+	if t, ok := parseEnglishRelative(expr, base); ok {
+		return t, nil
+	}
+	if t, ok := parseChineseRelative(strings.ReplaceAll(expr, " ", ""), base); ok {
+		return t, nil
+	}
+	return stdtime.Time{}, fmt.Errorf("%w: %q", ErrInvalidRelativeTime, s)
+}
+
+// parseEnglishRelative 解析英文的偏移与星期表达式。
+//
+// 参数：
+//   - expr: 已转为小写并规整空白的表达式。
+//   - base: 位于包默认时区的参照时间。
+//
+// 返回：
+//   - stdtime.Time: 解析得到的时间。
+//   - bool: 表达式是否被识别。
+func parseEnglishRelative(expr string, base stdtime.Time) (stdtime.Time, bool) {
+	if m := englishAgoPattern.FindStringSubmatch(expr); nil != m {
+		n, unit, ok := parseEnglishAmount(m[1], m[2])
+		if !ok {
+			return stdtime.Time{}, false
+		}
+		if "ago" == m[3] {
+			n = -n
+		}
+		return addUnits(base, n, unit), true
+	}
+	if m := englishInPattern.FindStringSubmatch(expr); nil != m {
+		n, unit, ok := parseEnglishAmount(m[1], m[2])
+		if !ok {
+			return stdtime.Time{}, false
+		}
+		return addUnits(base, n, unit), true
+	}
+
+	weeks := 0
+	name := expr
+	if m := englishNextPattern.FindStringSubmatch(expr); nil != m {
+		switch m[1] {
+		case "next":
+			weeks = 1
+		case "last":
+			weeks = -1
+		}
+		name = m[2]
+	}
+	if weekday, ok := englishWeekdays[name]; ok {
+		return weekdayOf(base, weeks, weekday), true
+	}
+	return stdtime.Time{}, false
+}
+
+// parseEnglishAmount 解析英文表达式中的数量与单位。
+//
+// 参数：
+//   - number: 阿拉伯数字或英文数量词。
+//   - unit: 去掉复数后缀的单位名称。
+//
+// 返回：
+//   - int: 数量。
+//   - relativeUnit: 单位。
+//   - bool: 数量与单位是否均可识别。
+func parseEnglishAmount(number, unit string) (int, relativeUnit, bool) {
+	n, ok := englishNumbers[number]
+	if !ok {
+		var err error
+		if n, err = strconv.Atoi(number); nil != err {
+			return 0, 0, false
+		}
+	}
+	u, ok := relativeUnits[unit]
+	if !ok {
+		return 0, 0, false
+	}
+	return n, u, true
+}
+
+// parseChineseRelative 解析中文的偏移、星期与周期表达式。
+//
+// 参数：
+//   - expr: 已去除空白的表达式。
+//   - base: 位于包默认时区的参照时间。
+//
+// 返回：
+//   - stdtime.Time: 解析得到的时间。
+//   - bool: 表达式是否被识别。
+func parseChineseRelative(expr string, base stdtime.Time) (stdtime.Time, bool) {
+	if m := chineseAgoPattern.FindStringSubmatch(expr); nil != m {
+		n, ok := parseChineseNumber(m[1])
+		if !ok {
+			return stdtime.Time{}, false
+		}
+		unit := relativeUnits[m[2]]
+		// "3月前" 易与日期混淆，月份偏移需要写作 "3个月前"。
+		if unitMonth == unit && !strings.Contains(expr, "个") {
+			return stdtime.Time{}, false
+		}
+		if strings.HasSuffix(m[3], "前") {
+			n = -n
+		}
+		return addUnits(base, n, unit), true
+	}
+	if m := chineseWeekdayPattern.FindStringSubmatch(expr); nil != m {
+		return weekdayOf(base, chineseWeekOffset(m[1]), chineseWeekdays[m[3]]), true
+	}
+	if m := chinesePeriodPattern.FindStringSubmatch(expr); nil != m {
+		n := chineseWeekOffset(m[1])
+		if "月" == m[2] {
+			return addMonths(base, n), true
+		}
+		return base.AddDate(0, 0, 7*n), true
+	}
+	return stdtime.Time{}, false
+}
+
+// chineseWeekOffset 把 "上"、"下下" 等前缀转换为周期偏移。
+//
+// 参数：
+//   - prefix: 表达式前缀，可能包含 "个" 字。
+//
+// 返回：
+//   - int: 周期偏移，"上上" 为 -2，"上" 为 -1，"下" 为 1，"下下" 为 2，其余为 0。
+func chineseWeekOffset(prefix string) int {
+	switch strings.TrimSuffix(prefix, "个") {
+	case "上上":
+		return -2
+	case "上":
+		return -1
+	case "下":
+		return 1
+	case "下下":
+		return 2
+	default:
+		return 0
+	}
+}
+
+// parseChineseNumber 解析阿拉伯数字或不超过九百九十九的中文数字。
+//
+// 参数：
+//   - s: 数字字符串，例如 "3"、"两"、"十五"、"二十"、"一百零八"。
+//
+// 返回：
+//   - int: 数值。
+//   - bool: 是否解析成功。
+func parseChineseNumber(s string) (int, bool) {
+	if n, err := strconv.Atoi(s); nil == err {
+		return n, true
+	}
+
+	total, digit, pending := 0, 0, false
+	for _, r := range s {
+		switch r {
+		case '百', '十':
+			scale := 100
+			if '十' == r {
+				scale = 10
+			}
+			// "十五" 中的 "十" 前省略了 "一"。
+			if !pending {
+				digit = 1
+			}
+			total += digit * scale
+			digit, pending = 0, false
+		default:
+			d, ok := chineseDigits[r]
+			// 不支持 "三五" 这类连续的非零数字。
+			if !ok || (pending && 0 != digit) {
+				return 0, false
+			}
+			digit, pending = d, true
+		}
+	}
+	return total + digit, true
+}
+
+// addUnits 在 t 上增加 n 个 unit，n 为负时表示减少。
+//
+// 参数：
+//   - t: 起始时间。
+//   - n: 数量。
+//   - unit: 单位。
+//
+// 返回：
+//   - stdtime.Time: 偏移后的时间。
+func addUnits(t stdtime.Time, n int, unit relativeUnit) stdtime.Time {
+	switch unit {
+	case unitSecond:
+		return t.Add(stdtime.Duration(n) * stdtime.Second)
+	case unitMinute:
+		return t.Add(stdtime.Duration(n) * stdtime.Minute)
+	case unitHour:
+		return t.Add(stdtime.Duration(n) * stdtime.Hour)
+	case unitDay:
+		return t.AddDate(0, 0, n)
+	case unitWeek:
+		return t.AddDate(0, 0, 7*n)
+	case unitMonth:
+		return addMonths(t, n)
+	default:
+		return addMonths(t, 12*n)
+	}
+}
+
+// addMonths 在 t 上增加 n 个自然月，目标月份天数不足时夹取到月末，与 carbon 的 NoOverflow 语义一致。
+//
+// 参数：
+//   - t: 起始时间。
+//   - n: 月数，为负时表示减少。
+//
+// 返回：
+//   - stdtime.Time: 偏移后的时间，保留时分秒。
+func addMonths(t stdtime.Time, n int) stdtime.Time {
+	year, month, day := t.Date()
+	first := stdtime.Date(year, month+stdtime.Month(n), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	if last := first.AddDate(0, 1, -1).Day(); day > last {
+		day = last
+	}
+	return first.AddDate(0, 0, day-1)
+}
+
+// startOfDay 返回 t 所在日期的零点。
+//
+// 参数：
+//   - t: 任意时间。
+//
+// 返回：
+//   - stdtime.Time: t 所在时区中同一日期的零点。
+func startOfDay(t stdtime.Time) stdtime.Time {
+	year, month, day := t.Date()
+	return stdtime.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+// weekdayOf 返回 base 所在周偏移 weeks 周后指定星期的零点，每周起始日取 defaultWeekStartAt。
+//
+// 参数：
+//   - base: 参照时间。
+//   - weeks: 周偏移，0 表示本周，1 表示下周，-1 表示上周。
+//   - weekday: 目标星期。
+//
+// 返回：
+//   - stdtime.Time: 目标日期的零点。
+func weekdayOf(base stdtime.Time, weeks int, weekday stdtime.Weekday) stdtime.Time {
+	weekStart := parseWeekStartAt(defaultWeekStartAt)
+	sinceStart := (int(base.Weekday()) - int(weekStart) + 7) % 7
+	offset := (int(weekday) - int(weekStart) + 7) % 7
+	return startOfDay(base).AddDate(0, 0, 7*weeks-sinceStart+offset)
+}
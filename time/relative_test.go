@@ -0,0 +1,129 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package time
+
+import (
+	"testing"
+	stdtime "time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParseRelative 验证英文与中文相对时间表达式的解析结果。
+//
+// 参照时间为默认时区的 2025-03-12 15:30:00，当天为星期三，每周从星期一开始。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestParseRelative(t *testing.T) {
+	loc := defaultLocation()
+	base := stdtime.Date(2025, 3, 12, 15, 30, 0, 0, loc)
+	at := func(month stdtime.Month, day, hour, minute int) stdtime.Time {
+		return stdtime.Date(2025, month, day, hour, minute, 0, 0, loc)
+	}
+
+	tests := []struct {
+		name        string
+		description string
+		give        string
+		giveBase    stdtime.Time
+		want        stdtime.Time
+	}{
+		{name: "success/now", description: "验证 now 返回参照时间。", give: "now", want: base},
+		{name: "success/zh-now", description: "验证 现在 返回参照时间。", give: "现在", want: base},
+		{name: "success/today", description: "验证 today 返回当天零点。", give: "today", want: at(3, 12, 0, 0)},
+		{name: "success/yesterday", description: "验证 yesterday 返回前一天零点。", give: "yesterday", want: at(3, 11, 0, 0)},
+		{name: "success/day-after-tomorrow", description: "验证多个单词的日期表达式。", give: "day after tomorrow", want: at(3, 14, 0, 0)},
+		{name: "success/zh-yesterday", description: "验证 昨天 返回前一天零点。", give: "昨天", want: at(3, 11, 0, 0)},
+		{name: "success/zh-three-days-before", description: "验证 大前天 返回三天前零点。", give: "大前天", want: at(3, 9, 0, 0)},
+		{name: "success/days-ago", description: "验证 3 days ago 保留时分秒。", give: "3 days ago", want: at(3, 9, 15, 30)},
+		{name: "success/normalize", description: "验证表达式忽略大小写和多余空白。", give: "  3   DAYS   Ago ", want: at(3, 9, 15, 30)},
+		{name: "success/abbreviation", description: "验证单位缩写与数字之间可以没有空格。", give: "3d ago", want: at(3, 9, 15, 30)},
+		{name: "success/in-hours", description: "验证 in 2 hours 向后偏移。", give: "in 2 hours", want: at(3, 12, 17, 30)},
+		{name: "success/an-hour-from-now", description: "验证 an 作为数量 1。", give: "an hour from now", want: at(3, 12, 16, 30)},
+		{name: "success/weeks-later", description: "验证英文数量词与 later 后缀。", give: "two weeks later", want: at(3, 26, 15, 30)},
+		{name: "success/minutes-ago", description: "验证分钟缩写的复数形式。", give: "45 mins ago", want: at(3, 12, 14, 45)},
+		{name: "success/next-monday", description: "验证 next monday 返回下周一零点。", give: "next Monday", want: at(3, 17, 0, 0)},
+		{name: "success/last-friday", description: "验证 last friday 返回上周五零点。", give: "last fri", want: at(3, 7, 0, 0)},
+		{name: "success/bare-weekday", description: "验证单独的星期返回本周对应日期。", give: "monday", want: at(3, 10, 0, 0)},
+		{name: "success/this-sunday", description: "验证 this sunday 返回本周日，每周从星期一开始。", give: "this sunday", want: at(3, 16, 0, 0)},
+		{name: "success/next-week", description: "验证 next week 偏移一周并保留时分秒。", give: "next week", want: at(3, 19, 15, 30)},
+		{name: "success/last-month", description: "验证 last month 偏移一个自然月。", give: "last month", want: at(2, 12, 15, 30)},
+		{name: "success/zh-next-wednesday", description: "验证 下周三 返回下周三零点。", give: "下周三", want: at(3, 19, 0, 0)},
+		{name: "success/zh-last-friday", description: "验证 上星期五 返回上周五零点。", give: "上星期五", want: at(3, 7, 0, 0)},
+		{name: "success/zh-sunday", description: "验证 周日 返回本周日零点。", give: "周日", want: at(3, 16, 0, 0)},
+		{name: "success/zh-two-weeks-before", description: "验证 上上周一 返回两周前的星期一。", give: "上上周一", want: at(2, 24, 0, 0)},
+		{name: "success/zh-next-sunday", description: "验证带 个 字的 下个礼拜天。", give: "下个礼拜天", want: at(3, 23, 0, 0)},
+		{name: "success/zh-hours-later", description: "验证 两小时后 向后偏移。", give: "两小时后", want: at(3, 12, 17, 30)},
+		{name: "success/zh-minutes-ago", description: "验证 十五分钟前 的中文数字。", give: "十五分钟前", want: at(3, 12, 15, 15)},
+		{name: "success/zh-digits", description: "验证阿拉伯数字与空白。", give: "3 天 以前", want: at(3, 9, 15, 30)},
+		{name: "success/zh-months-ago", description: "验证 3个月前 跨年偏移。", give: "3个月前", want: stdtime.Date(2024, 12, 12, 15, 30, 0, 0, loc)},
+		{name: "success/zh-year-later", description: "验证 一年后。", give: "一年后", want: stdtime.Date(2026, 3, 12, 15, 30, 0, 0, loc)},
+		{name: "success/zh-hundred-days", description: "验证 一百零八天后 的中文数字。", give: "一百零八天后", want: at(6, 28, 15, 30)},
+		{name: "success/zh-last-month", description: "验证 上个月 偏移一个自然月。", give: "上个月", want: at(2, 12, 15, 30)},
+		{name: "success/zh-week-after-next", description: "验证 下下周 偏移两周。", give: "下下周", want: at(3, 26, 15, 30)},
+		{name: "success/zh-last-year", description: "验证 去年 偏移一年。", give: "去年", want: stdtime.Date(2024, 3, 12, 15, 30, 0, 0, loc)},
+		{
+			name:        "boundary/month-end",
+			description: "验证按月偏移时目标月份天数不足则夹取到月末。",
+			give:        "last month",
+			giveBase:    at(3, 31, 8, 0),
+			want:        at(2, 28, 8, 0),
+		},
+		{
+			name:        "boundary/other-timezone",
+			description: "验证其它时区的参照时间先转换到默认时区，按默认时区的日期计算。",
+			give:        "today",
+			giveBase:    stdtime.Date(2025, 3, 11, 20, 0, 0, 0, stdtime.UTC),
+			want:        at(3, 12, 0, 0),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			giveBase := base
+			if !tt.giveBase.IsZero() {
+				giveBase = tt.giveBase
+			}
+			got, err := ParseRelative(tt.give, giveBase)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, loc, got.Location())
+		})
+	}
+}
+
+// TestParseRelative_Errors 验证无法识别的表达式返回 ErrInvalidRelativeTime。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestParseRelative_Errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		give        string
+	}{
+		{name: "error/empty", description: "验证空字符串返回错误。", give: " "},
+		{name: "error/unknown-unit", description: "验证未知单位返回错误。", give: "3 fortnights ago"},
+		{name: "error/unknown-number", description: "验证未知数量词返回错误。", give: "many days ago"},
+		{name: "error/unknown-word", description: "验证未知单词返回错误。", give: "someday"},
+		{name: "error/zh-month-without-ge", description: "验证 3月前 易与日期混淆，需要写作 3个月前。", give: "3月前"},
+		{name: "error/zh-invalid-number", description: "验证连续的非零中文数字返回错误。", give: "三五天前"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			_, err := ParseRelative(tt.give, stdtime.Now())
+			assert.ErrorIs(t, err, ErrInvalidRelativeTime)
+		})
+	}
+}
@@ -0,0 +1,248 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package time
+
+import (
+	"errors"
+	"fmt"
+	stdtime "time"
+)
+
+var (
+	// ErrInvalidSchedule 表示本地时刻调度的时、分或星期超出范围。
+	ErrInvalidSchedule = errors.New("invalid schedule")
+)
+
+const (
+	// DSTShift 在本地时刻因夏令时开始而不存在时，顺延到切换后的对应时刻（例如 02:30 顺延为 03:30）；
+	// 本地时刻因夏令时结束而出现两次时，只在第一次出现时触发。这是默认策略。
+	DSTShift DSTPolicy = iota
+	// DSTSkip 在本地时刻不存在时跳过当天；本地时刻出现两次时只在第一次出现时触发。
+	DSTSkip
+	// DSTRunTwice 在本地时刻不存在时与 DSTShift 相同顺延；本地时刻出现两次时两次都触发。
+	DSTRunTwice
+)
+
+const (
+	// maxScheduleScanDays 是查找下一次触发时间时最多检查的天数。
+	maxScheduleScanDays = 400
+)
+
+type (
+	// DSTPolicy 决定本地时刻在夏令时切换当天不存在或出现两次时的处理方式。
+	DSTPolicy int
+
+	// LocalSchedule 是按指定时区本地时刻每天或每周触发的 Scheduler，可直接用于 TimingWheel.Schedule。
+	//
+	// 与按固定间隔计算的调度不同，LocalSchedule 每次根据日历日期重新计算本地时刻，跨越夏令时切换后仍在
+	// 同一墙上时刻触发；切换当天的不存在或重复时刻按 DSTPolicy 处理。LocalSchedule 创建后只读，可安全并发使用。
+	LocalSchedule struct {
+		// hour 是触发的小时，取值 0 至 23。
+		hour int
+		// minute 是触发的分钟，取值 0 至 59。
+		minute int
+		// weekday 是每周触发的星期，daily 为 true 时忽略。
+		weekday stdtime.Weekday
+		// daily 表示每天触发。
+		daily bool
+		// loc 是计算本地时刻的时区。
+		loc *stdtime.Location
+		// policy 是夏令时切换时的处理策略。
+		policy DSTPolicy
+	}
+
+	// ScheduleOption 定义本地时刻调度的可选配置。
+	ScheduleOption func(*LocalSchedule)
+)
+
+var (
+	// _ 确保 LocalSchedule 实现 Scheduler 接口。
+	_ Scheduler = (*LocalSchedule)(nil)
+)
+
+// WithDSTPolicy 设置夏令时切换时的处理策略，默认为 DSTShift。
+//
+// 参数：
+//   - policy: 处理策略。
+//
+// 返回：
+//   - ScheduleOption: 应用于 Daily、Weekly 与 NextOccurrence 的配置项。
+func WithDSTPolicy(policy DSTPolicy) ScheduleOption {
+	return func(s *LocalSchedule) {
+		s.policy = policy
+	}
+}
+
+// Daily 创建每天在 loc 本地时刻 hour:minute 触发的调度。
+//
+// 参数：
+//   - hour: 小时，取值 0 至 23。
+//   - minute: 分钟，取值 0 至 59。
+//   - loc: 时区，为 nil 时使用包默认时区。
+//   - opts: 可选配置，例如 WithDSTPolicy。
+//
+// 返回：
+//   - *LocalSchedule: 本地时刻调度。
+//   - error: 时、分超出范围时返回 ErrInvalidSchedule。
+func Daily(hour, minute int, loc *stdtime.Location, opts ...ScheduleOption) (*LocalSchedule, error) {
+	return newLocalSchedule(true, stdtime.Sunday, hour, minute, loc, opts)
+}
+
+// Weekly 创建每周 weekday 在 loc 本地时刻 hour:minute 触发的调度。
+//
+// 参数：
+//   - weekday: 星期。
+//   - hour: 小时，取值 0 至 23。
+//   - minute: 分钟，取值 0 至 59。
+//   - loc: 时区，为 nil 时使用包默认时区。
+//   - opts: 可选配置，例如 WithDSTPolicy。
+//
+// 返回：
+//   - *LocalSchedule: 本地时刻调度。
+//   - error: 星期、时、分超出范围时返回 ErrInvalidSchedule。
+func Weekly(weekday stdtime.Weekday, hour, minute int, loc *stdtime.Location, opts ...ScheduleOption) (*LocalSchedule, error) {
+	return newLocalSchedule(false, weekday, hour, minute, loc, opts)
+}
+
+// NextOccurrence 返回 after 之后第一次到达 loc 本地时刻 hour:minute 的时间，是 Daily(...).Next(after) 的便捷形式。
+//
+// 参数：
+//   - after: 参照时间，结果严格晚于该时间。
+//   - hour: 小时，取值 0 至 23。
+//   - minute: 分钟，取值 0 至 59。
+//   - loc: 时区，为 nil 时使用包默认时区。
+//   - opts: 可选配置，例如 WithDSTPolicy。
+//
+// 返回：
+//   - stdtime.Time: 下一次触发时间，位于 loc 时区。
+//   - error: 时、分超出范围时返回 ErrInvalidSchedule。
+func NextOccurrence(after stdtime.Time, hour, minute int, loc *stdtime.Location, opts ...ScheduleOption) (stdtime.Time, error) {
+	s, err := Daily(hour, minute, loc, opts...)
+	if nil != err {
+		return stdtime.Time{}, err
+	}
+	return s.Next(after), nil
+}
+
+// Next 返回 prev 之后的下一次触发时间。
+//
+// 参数：
+//   - prev: 上一次触发（或首次调度）的时间。
+//
+// 返回：
+//   - stdtime.Time: 严格晚于 prev 的下一次触发时间，位于调度的时区。
+func (s *LocalSchedule) Next(prev stdtime.Time) stdtime.Time {
+	local := prev.In(s.loc)
+	// 从前一天开始检查，顺延后的时刻可能落在 prev 之后。
+	year, month, day := local.AddDate(0, 0, -1).Date()
+	for i := 0; i < maxScheduleScanDays; i++ {
+		date := stdtime.Date(year, month, day+i, 12, 0, 0, 0, s.loc)
+		if !s.daily && date.Weekday() != s.weekday {
+			continue
+		}
+		for _, t := range s.occurrences(date) {
+			if t.After(prev) {
+				return t
+			}
+		}
+	}
+	return stdtime.Time{}
+}
+
+// String 返回调度的可读描述。
+//
+// 参数：无。
+//
+// 返回：
+//   - string: 形如 "daily 02:30 America/New_York" 或 "weekly Monday 09:00 Asia/Shanghai" 的字符串。
+func (s *LocalSchedule) String() string {
+	if s.daily {
+		return fmt.Sprintf("daily %02d:%02d %s", s.hour, s.minute, s.loc)
+	}
+	return fmt.Sprintf("weekly %s %02d:%02d %s", s.weekday, s.hour, s.minute, s.loc)
+}
+
+// newLocalSchedule 校验参数并创建本地时刻调度。
+//
+// 参数：
+//   - daily: 是否每天触发。
+//   - weekday: 每周触发的星期。
+//   - hour: 小时。
+//   - minute: 分钟。
+//   - loc: 时区，为 nil 时使用包默认时区。
+//   - opts: 可选配置。
+//
+// 返回：
+//   - *LocalSchedule: 本地时刻调度。
+//   - error: 参数超出范围时返回 ErrInvalidSchedule。
+func newLocalSchedule(daily bool, weekday stdtime.Weekday, hour, minute int, loc *stdtime.Location, opts []ScheduleOption) (*LocalSchedule, error) {
+	if hour < 0 || hour > 23 || minute < 0 || minute > 59 {
+		return nil, fmt.Errorf("%w: time %02d:%02d", ErrInvalidSchedule, hour, minute)
+	}
+	if weekday < stdtime.Sunday || weekday > stdtime.Saturday {
+		return nil, fmt.Errorf("%w: weekday %d", ErrInvalidSchedule, weekday)
+	}
+	if nil == loc {
+		loc = defaultLocation()
+	}
+
+	s := &LocalSchedule{hour: hour, minute: minute, weekday: weekday, daily: daily, loc: loc}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s, nil
+}
+
+// occurrences 返回 date 当天本地时刻对应的触发时间，按时间先后排列。
+//
+// 参数：
+//   - date: 日期，只使用其在调度时区中的年月日。
+//
+// 返回：
+//   - []stdtime.Time: 触发时间；本地时刻不存在且策略为 DSTSkip 时为空。
+func (s *LocalSchedule) occurrences(date stdtime.Time) []stdtime.Time {
+	year, month, day := date.Date()
+	// wall 把本地时刻按 UTC 表示，减去时区偏移即为对应的绝对时间。
+	wall := stdtime.Date(year, month, day, s.hour, s.minute, 0, 0, stdtime.UTC)
+	_, before := wall.Add(-12 * stdtime.Hour).In(s.loc).Zone()
+	_, after := wall.Add(12 * stdtime.Hour).In(s.loc).Zone()
+
+	var matches []stdtime.Time
+	for _, offset := range []int{before, after} {
+		t := wall.Add(-stdtime.Duration(offset) * stdtime.Second).In(s.loc)
+		if !sameWallClock(t, wall) || (1 == len(matches) && matches[0].Equal(t)) {
+			continue
+		}
+		matches = append(matches, t)
+	}
+
+	switch {
+	case 0 == len(matches):
+		// 本地时刻位于夏令时开始时跳过的区间，按切换前的偏移计算即为顺延后的时刻。
+		if DSTSkip == s.policy {
+			return nil
+		}
+		return []stdtime.Time{wall.Add(-stdtime.Duration(before) * stdtime.Second).In(s.loc)}
+	case 2 == len(matches) && DSTRunTwice != s.policy:
+		// 本地时刻位于夏令时结束时重复的区间，只保留第一次出现。
+		return matches[:1]
+	default:
+		return matches
+	}
+}
+
+// sameWallClock 判断 t 的本地年月日时分是否与按 UTC 表示的 wall 相同。
+//
+// 参数：
+//   - t: 位于调度时区的时间。
+//   - wall: 按 UTC 表示的本地时刻。
+//
+// 返回：
+//   - bool: 年月日时分是否相同。
+func sameWallClock(t, wall stdtime.Time) bool {
+	y1, m1, d1 := t.Date()
+	y2, m2, d2 := wall.Date()
+	return y1 == y2 && m1 == m2 && d1 == d2 && t.Hour() == wall.Hour() && t.Minute() == wall.Minute()
+}
@@ -0,0 +1,151 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package time
+
+import (
+	"testing"
+	stdtime "time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// loadLocation 加载时区，缺少时区数据时跳过测试。
+//
+// 参数：
+//   - t: 测试上下文。
+//   - name: IANA 时区名称。
+//
+// 返回：
+//   - *stdtime.Location: 时区。
+func loadLocation(t *testing.T, name string) *stdtime.Location {
+	t.Helper()
+
+	loc, err := stdtime.LoadLocation(name)
+	if nil != err {
+		t.Skipf("缺少时区数据：%s", name)
+	}
+	return loc
+}
+
+// TestLocalSchedule_DST 验证夏令时切换当天不存在与重复的本地时刻按策略处理。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestLocalSchedule_DST(t *testing.T) {
+	ny := loadLocation(t, "America/New_York")
+	utc := func(s string) stdtime.Time {
+		v, err := stdtime.Parse(stdtime.RFC3339, s)
+		require.NoError(t, err)
+		return v
+	}
+
+	tests := []struct {
+		name        string
+		description string
+		hour        int
+		minute      int
+		policy      DSTPolicy
+		after       stdtime.Time
+		want        []stdtime.Time
+	}{
+		{
+			name:        "gap/shift",
+			description: "验证夏令时开始当天 02:30 不存在时顺延为 03:30。",
+			hour:        2, minute: 30, policy: DSTShift,
+			after: utc("2025-03-08T12:00:00Z"),
+			want:  []stdtime.Time{utc("2025-03-09T07:30:00Z"), utc("2025-03-10T06:30:00Z")},
+		},
+		{
+			name:        "gap/skip",
+			description: "验证夏令时开始当天 02:30 不存在时跳过当天。",
+			hour:        2, minute: 30, policy: DSTSkip,
+			after: utc("2025-03-08T12:00:00Z"),
+			want:  []stdtime.Time{utc("2025-03-10T06:30:00Z")},
+		},
+		{
+			name:        "overlap/shift",
+			description: "验证夏令时结束当天 01:30 出现两次时只在第一次触发。",
+			hour:        1, minute: 30, policy: DSTShift,
+			after: utc("2025-11-01T12:00:00Z"),
+			want:  []stdtime.Time{utc("2025-11-02T05:30:00Z"), utc("2025-11-03T06:30:00Z")},
+		},
+		{
+			name:        "overlap/run-twice",
+			description: "验证夏令时结束当天 01:30 出现两次时两次都触发。",
+			hour:        1, minute: 30, policy: DSTRunTwice,
+			after: utc("2025-11-01T12:00:00Z"),
+			want:  []stdtime.Time{utc("2025-11-02T05:30:00Z"), utc("2025-11-02T06:30:00Z"), utc("2025-11-03T06:30:00Z")},
+		},
+		{
+			name:        "normal",
+			description: "验证普通日期跨越夏令时后保持同一本地时刻。",
+			hour:        9, minute: 0, policy: DSTShift,
+			after: utc("2025-03-08T15:00:00Z"),
+			want:  []stdtime.Time{utc("2025-03-09T13:00:00Z"), utc("2025-03-10T13:00:00Z")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			s, err := Daily(tt.hour, tt.minute, ny, WithDSTPolicy(tt.policy))
+			require.NoError(t, err)
+			prev := tt.after
+			for _, want := range tt.want {
+				next := s.Next(prev)
+				assert.True(t, want.Equal(next), "want %s, got %s", want, next)
+				assert.Equal(t, ny, next.Location())
+				prev = next
+			}
+		})
+	}
+}
+
+// TestLocalSchedule 验证每周调度、NextOccurrence 与参数校验。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestLocalSchedule(t *testing.T) {
+	shanghai := loadLocation(t, "Asia/Shanghai")
+
+	t.Run("success/weekly", func(t *testing.T) {
+		t.Log("验证每周调度返回下一个指定星期的本地时刻。")
+
+		s, err := Weekly(stdtime.Monday, 9, 0, shanghai)
+		require.NoError(t, err)
+		// 2025-03-05 为星期三。
+		next := s.Next(stdtime.Date(2025, 3, 5, 10, 0, 0, 0, shanghai))
+		assert.Equal(t, stdtime.Date(2025, 3, 10, 9, 0, 0, 0, shanghai), next)
+		// 恰好等于触发时间时返回下一周。
+		assert.Equal(t, stdtime.Date(2025, 3, 17, 9, 0, 0, 0, shanghai), s.Next(next))
+		assert.Equal(t, "weekly Monday 09:00 Asia/Shanghai", s.String())
+	})
+
+	t.Run("success/next-occurrence", func(t *testing.T) {
+		t.Log("验证 NextOccurrence 在当天时刻未到时返回当天，已过时返回次日。")
+
+		got, err := NextOccurrence(stdtime.Date(2025, 3, 5, 1, 0, 0, 0, shanghai), 2, 30, shanghai)
+		require.NoError(t, err)
+		assert.Equal(t, stdtime.Date(2025, 3, 5, 2, 30, 0, 0, shanghai), got)
+
+		// 参照时间位于其它时区时按调度时区计算。
+		got, err = NextOccurrence(stdtime.Date(2025, 3, 5, 0, 0, 0, 0, stdtime.UTC), 2, 30, shanghai)
+		require.NoError(t, err)
+		assert.Equal(t, stdtime.Date(2025, 3, 6, 2, 30, 0, 0, shanghai), got)
+	})
+
+	t.Run("error/invalid", func(t *testing.T) {
+		t.Log("验证时、分、星期超出范围时返回 ErrInvalidSchedule。")
+
+		_, err := Daily(24, 0, nil)
+		assert.ErrorIs(t, err, ErrInvalidSchedule)
+		_, err = Weekly(stdtime.Weekday(7), 0, 0, nil)
+		assert.ErrorIs(t, err, ErrInvalidSchedule)
+		_, err = NextOccurrence(stdtime.Now(), 0, 60, nil)
+		assert.ErrorIs(t, err, ErrInvalidSchedule)
+	})
+}
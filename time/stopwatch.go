@@ -0,0 +1,371 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package time
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	stdtime "time"
+
+	kitlog "github.com/fsyyft-go/kit/log"
+)
+
+const (
+	// MeasureOperationField 是 Measure 输出日志中记录操作名称的字段名。
+	MeasureOperationField = "operation"
+	// MeasureElapsedField 是 Measure 输出日志中记录耗时的字段名。
+	MeasureElapsedField = "elapsed"
+	// MeasureErrorField 是 Measure 输出日志中记录错误的字段名。
+	MeasureErrorField = "error"
+)
+
+type (
+	// Stopwatch 是可暂停的秒表，用于测量一段或多段代码的累计耗时并记录分段（lap）耗时。
+	//
+	// Start 开始或继续计时，Stop 暂停计时并保留已累计的耗时，Lap 记录自上一次 Lap（或开始计时）以来的耗时，
+	// Reset 清零。零值是未开始计时的秒表，可直接使用。Stopwatch 可安全并发使用。
+	Stopwatch struct {
+		// mu 保护其余字段。
+		mu sync.Mutex
+		// now 返回当前时间，测试中可替换。
+		now func() stdtime.Time
+		// running 表示秒表正在计时。
+		running bool
+		// start 是本段计时的开始时间。
+		start stdtime.Time
+		// elapsed 是此前各段计时累计的耗时，不含正在进行的一段。
+		elapsed stdtime.Duration
+		// lapMark 是当前分段开始时的累计耗时。
+		lapMark stdtime.Duration
+		// laps 是已记录的分段耗时。
+		laps []stdtime.Duration
+	}
+
+	// measureOptions 包含 Measure 的配置选项。
+	measureOptions struct {
+		// logger 是输出耗时日志的 Logger；nil 表示不输出日志。
+		logger kitlog.Logger
+		// operation 是日志中的操作名称。
+		operation string
+		// threshold 是输出日志的最小耗时。
+		threshold stdtime.Duration
+		// level 是成功时输出日志的级别。
+		level kitlog.Level
+	}
+
+	// MeasureOption 定义了 Measure 的配置选项函数类型。
+	MeasureOption func(*measureOptions)
+)
+
+// NewStopwatch 创建未开始计时的秒表。
+//
+// 参数：无。
+//
+// 返回：
+//   - *Stopwatch: 未开始计时的秒表，需要调用 Start 开始计时。
+func NewStopwatch() *Stopwatch {
+	return &Stopwatch{}
+}
+
+// StartStopwatch 创建并立即开始计时的秒表。
+//
+// 参数：无。
+//
+// 返回：
+//   - *Stopwatch: 已开始计时的秒表。
+func StartStopwatch() *Stopwatch {
+	sw := NewStopwatch()
+	sw.Start()
+	return sw
+}
+
+// Start 开始或继续计时；秒表已在计时时不做任何操作。
+//
+// 参数：无。
+func (sw *Stopwatch) Start() {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+
+	if sw.running {
+		return
+	}
+	sw.running = true
+	sw.start = sw.clock()
+}
+
+// Stop 暂停计时并返回累计耗时；秒表未在计时时只返回累计耗时。
+//
+// 参数：无。
+//
+// 返回：
+//   - time.Duration: 截至暂停时的累计耗时。
+func (sw *Stopwatch) Stop() stdtime.Duration {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+
+	if sw.running {
+		sw.elapsed += sw.clock().Sub(sw.start)
+		sw.running = false
+	}
+	return sw.elapsed
+}
+
+// Lap 记录一个分段并返回其耗时，即自上一次 Lap 或首次开始计时以来的累计耗时，不含暂停的时间。
+//
+// 参数：无。
+//
+// 返回：
+//   - time.Duration: 本分段的耗时。
+func (sw *Stopwatch) Lap() stdtime.Duration {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+
+	total := sw.elapsedLocked()
+	lap := total - sw.lapMark
+	sw.lapMark = total
+	sw.laps = append(sw.laps, lap)
+	return lap
+}
+
+// Laps 返回已记录的分段耗时。
+//
+// 参数：无。
+//
+// 返回：
+//   - []time.Duration: 按记录顺序排列的分段耗时副本。
+func (sw *Stopwatch) Laps() []stdtime.Duration {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+
+	return append([]stdtime.Duration(nil), sw.laps...)
+}
+
+// Elapsed 返回累计耗时，秒表正在计时时包含当前这一段。
+//
+// 参数：无。
+//
+// 返回：
+//   - time.Duration: 累计耗时。
+func (sw *Stopwatch) Elapsed() stdtime.Duration {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+
+	return sw.elapsedLocked()
+}
+
+// Running 返回秒表是否正在计时。
+//
+// 参数：无。
+//
+// 返回：
+//   - bool: 正在计时时返回 true。
+func (sw *Stopwatch) Running() bool {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+
+	return sw.running
+}
+
+// Reset 清零累计耗时与分段记录；秒表正在计时时从当前时间重新开始计时。
+//
+// 参数：无。
+func (sw *Stopwatch) Reset() {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+
+	sw.elapsed = 0
+	sw.lapMark = 0
+	sw.laps = nil
+	if sw.running {
+		sw.start = sw.clock()
+	}
+}
+
+// String 以易读的单位返回累计耗时，格式见 FormatDuration。
+//
+// 参数：无。
+//
+// 返回：
+//   - string: 累计耗时的文本表示，例如 "12.5ms"。
+func (sw *Stopwatch) String() string {
+	return FormatDuration(sw.Elapsed())
+}
+
+// elapsedLocked 返回累计耗时，调用方必须持有 mu。
+//
+// 参数：无。
+//
+// 返回：
+//   - time.Duration: 累计耗时。
+func (sw *Stopwatch) elapsedLocked() stdtime.Duration {
+	if sw.running {
+		return sw.elapsed + sw.clock().Sub(sw.start)
+	}
+	return sw.elapsed
+}
+
+// clock 返回当前时间。
+//
+// 参数：无。
+//
+// 返回：
+//   - time.Time: 当前时间，包含单调时钟读数。
+func (sw *Stopwatch) clock() stdtime.Time {
+	if nil != sw.now {
+		return sw.now()
+	}
+	return stdtime.Now()
+}
+
+// FormatDuration 以易读的单位格式化耗时。
+//
+// 不足 1 分钟时选择 ns、µs、ms、s 中最大的适用单位，最多保留两位小数并去除末尾的 0，例如 "850ns"、
+// "12.35ms"、"1.5s"；1 分钟及以上时精确到 0.1 秒，例如 "2m3.5s"、"1h0m0s"。负数耗时带 "-" 前缀。
+//
+// 参数：
+//   - d: 要格式化的耗时。
+//
+// 返回：
+//   - string: 耗时的文本表示。
+func FormatDuration(d stdtime.Duration) string {
+	if d <= -stdtime.Minute {
+		return d.Round(100 * stdtime.Millisecond).String()
+	}
+	if d < 0 {
+		return "-" + FormatDuration(-d)
+	}
+
+	var unit stdtime.Duration
+	var suffix string
+	switch {
+	case d < stdtime.Microsecond:
+		return strconv.FormatInt(int64(d), 10) + "ns"
+	case d < stdtime.Millisecond:
+		unit, suffix = stdtime.Microsecond, "µs"
+	case d < stdtime.Second:
+		unit, suffix = stdtime.Millisecond, "ms"
+	case d < stdtime.Minute:
+		unit, suffix = stdtime.Second, "s"
+	default:
+		return d.Round(100 * stdtime.Millisecond).String()
+	}
+
+	value := strconv.FormatFloat(float64(d)/float64(unit), 'f', 2, 64)
+	for '0' == value[len(value)-1] {
+		value = value[:len(value)-1]
+	}
+	if '.' == value[len(value)-1] {
+		value = value[:len(value)-1]
+	}
+	return value + suffix
+}
+
+// WithMeasureLog 设置 Measure 在函数返回后通过 logger 输出 "operation took elapsed" 日志。
+//
+// 日志携带 MeasureOperationField 与 MeasureElapsedField 字段；函数返回错误时以 Error 级别输出，
+// 并附加 MeasureErrorField 字段。
+//
+// 参数：
+//   - logger: 输出日志的 Logger；nil 表示使用 log 包的全局 Logger。
+//   - operation: 操作名称。
+//
+// 返回：
+//   - MeasureOption: 应用于 Measure 的配置选项。
+func WithMeasureLog(logger kitlog.Logger, operation string) MeasureOption {
+	return func(o *measureOptions) {
+		if nil == logger {
+			logger = kitlog.GetLogger()
+		}
+		o.logger = logger
+		o.operation = operation
+	}
+}
+
+// WithMeasureLevel 设置函数成功返回时输出日志的级别，默认为 InfoLevel。
+//
+// 参数：
+//   - level: 日志级别，FatalLevel 按 InfoLevel 处理以免退出进程；函数返回错误时总是使用 ErrorLevel。
+//
+// 返回：
+//   - MeasureOption: 应用于 Measure 的配置选项。
+func WithMeasureLevel(level kitlog.Level) MeasureOption {
+	return func(o *measureOptions) {
+		o.level = level
+	}
+}
+
+// WithMeasureThreshold 设置输出日志的最小耗时，只记录慢操作。
+//
+// 参数：
+//   - threshold: 最小耗时；耗时低于该值且函数未返回错误时不输出日志，小于等于 0 表示总是输出。
+//
+// 返回：
+//   - MeasureOption: 应用于 Measure 的配置选项。
+func WithMeasureThreshold(threshold stdtime.Duration) MeasureOption {
+	return func(o *measureOptions) {
+		o.threshold = threshold
+	}
+}
+
+// Measure 执行 fn 并返回其耗时与错误。
+//
+// 默认只测量不输出日志；通过 WithMeasureLog 可在返回后输出 "operation took elapsed" 日志，用于替代
+// 分散在各处的 time.Since 计算。fn 发生 panic 时不会记录日志，panic 会继续向上传播。
+//
+// 参数：
+//   - fn: 要测量的函数，不能为 nil。
+//   - opts: 可选的配置选项。
+//
+// 返回：
+//   - time.Duration: fn 的执行耗时。
+//   - error: fn 返回的错误。
+func Measure(fn func() error, opts ...MeasureOption) (stdtime.Duration, error) {
+	options := measureOptions{
+		level: kitlog.InfoLevel,
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	start := stdtime.Now()
+	err := fn()
+	elapsed := stdtime.Since(start)
+
+	options.log(elapsed, err)
+	return elapsed, err
+}
+
+// log 按配置输出耗时日志。
+//
+// 参数：
+//   - elapsed: 执行耗时。
+//   - err: 函数返回的错误。
+func (o *measureOptions) log(elapsed stdtime.Duration, err error) {
+	if nil == o.logger || (nil == err && elapsed < o.threshold) {
+		return
+	}
+
+	logger := o.logger.WithFields(map[string]interface{}{
+		MeasureOperationField: o.operation,
+		MeasureElapsedField:   elapsed,
+	})
+	msg := fmt.Sprintf("%s took %s", o.operation, FormatDuration(elapsed))
+	if nil != err {
+		logger.WithField(MeasureErrorField, err).Error(msg)
+		return
+	}
+
+	switch o.level {
+	case kitlog.DebugLevel:
+		logger.Debug(msg)
+	case kitlog.WarnLevel:
+		logger.Warn(msg)
+	case kitlog.ErrorLevel:
+		logger.Error(msg)
+	default:
+		logger.Info(msg)
+	}
+}
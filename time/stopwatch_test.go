@@ -0,0 +1,261 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package time
+
+import (
+	"errors"
+	"testing"
+	stdtime "time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	kitlog "github.com/fsyyft-go/kit/log"
+)
+
+type (
+	// fakeClock 是手动推进的测试时钟。
+	fakeClock struct {
+		now stdtime.Time
+	}
+
+	// measureLogEntry 保存 measureLogger 记录的一条日志。
+	measureLogEntry struct {
+		level  kitlog.Level
+		msg    string
+		fields map[string]interface{}
+	}
+
+	// measureLogger 是记录日志内容的测试 Logger。
+	measureLogger struct {
+		kitlog.Logger
+
+		fields  map[string]interface{}
+		entries *[]measureLogEntry
+	}
+)
+
+// Now 返回当前测试时间。
+func (c *fakeClock) Now() stdtime.Time {
+	return c.now
+}
+
+// Advance 推进测试时间。
+func (c *fakeClock) Advance(d stdtime.Duration) {
+	c.now = c.now.Add(d)
+}
+
+// record 记录一条日志。
+func (l *measureLogger) record(level kitlog.Level, args ...interface{}) {
+	msg := ""
+	if len(args) > 0 {
+		msg, _ = args[0].(string)
+	}
+	*l.entries = append(*l.entries, measureLogEntry{level: level, msg: msg, fields: l.fields})
+}
+
+// Debug、Info、Warn、Error 按对应级别记录日志。
+func (l *measureLogger) Debug(args ...interface{}) { l.record(kitlog.DebugLevel, args...) }
+func (l *measureLogger) Info(args ...interface{})  { l.record(kitlog.InfoLevel, args...) }
+func (l *measureLogger) Warn(args ...interface{})  { l.record(kitlog.WarnLevel, args...) }
+func (l *measureLogger) Error(args ...interface{}) { l.record(kitlog.ErrorLevel, args...) }
+
+// WithField 返回包含新字段的 Logger。
+func (l *measureLogger) WithField(key string, value interface{}) kitlog.Logger {
+	return l.WithFields(map[string]interface{}{key: value})
+}
+
+// WithFields 返回包含所有字段的 Logger。
+func (l *measureLogger) WithFields(fields map[string]interface{}) kitlog.Logger {
+	newFields := make(map[string]interface{}, len(l.fields)+len(fields))
+	for k, v := range l.fields {
+		newFields[k] = v
+	}
+	for k, v := range fields {
+		newFields[k] = v
+	}
+	return &measureLogger{fields: newFields, entries: l.entries}
+}
+
+// TestStopwatch 验证秒表的开始、暂停、分段与重置。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestStopwatch(t *testing.T) {
+	clock := &fakeClock{now: stdtime.Unix(1700000000, 0)}
+	sw := NewStopwatch()
+	sw.now = clock.Now
+
+	assert.False(t, sw.Running())
+	assert.Equal(t, stdtime.Duration(0), sw.Elapsed())
+	assert.Equal(t, "0ns", sw.String())
+
+	sw.Start()
+	assert.True(t, sw.Running())
+	clock.Advance(100 * stdtime.Millisecond)
+	assert.Equal(t, 100*stdtime.Millisecond, sw.Lap())
+
+	// 重复 Start 不会重置本段的开始时间。
+	sw.Start()
+	clock.Advance(50 * stdtime.Millisecond)
+	assert.Equal(t, 150*stdtime.Millisecond, sw.Stop())
+	assert.False(t, sw.Running())
+
+	// 暂停期间的时间不计入耗时。
+	clock.Advance(stdtime.Hour)
+	assert.Equal(t, 150*stdtime.Millisecond, sw.Stop())
+	sw.Start()
+	clock.Advance(25 * stdtime.Millisecond)
+	assert.Equal(t, 75*stdtime.Millisecond, sw.Lap())
+	assert.Equal(t, []stdtime.Duration{100 * stdtime.Millisecond, 75 * stdtime.Millisecond}, sw.Laps())
+	assert.Equal(t, 175*stdtime.Millisecond, sw.Elapsed())
+	assert.Equal(t, "175ms", sw.String())
+
+	sw.Reset()
+	assert.True(t, sw.Running())
+	assert.Empty(t, sw.Laps())
+	clock.Advance(stdtime.Second)
+	assert.Equal(t, stdtime.Second, sw.Lap())
+
+	started := StartStopwatch()
+	assert.True(t, started.Running())
+	assert.GreaterOrEqual(t, started.Elapsed(), stdtime.Duration(0))
+}
+
+// TestFormatDuration 验证按易读单位格式化耗时。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestFormatDuration(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		d           stdtime.Duration
+		want        string
+	}{
+		{name: "boundary/zero", description: "验证 0 格式化为 0ns。", d: 0, want: "0ns"},
+		{name: "success/nanoseconds", description: "验证不足 1µs 时使用 ns。", d: 850, want: "850ns"},
+		{name: "success/microseconds", description: "验证不足 1ms 时使用 µs 并保留两位小数。", d: 12345, want: "12.35µs"},
+		{name: "success/milliseconds", description: "验证去除末尾的 0。", d: 12500 * stdtime.Microsecond, want: "12.5ms"},
+		{name: "success/seconds", description: "验证整数值不保留小数点。", d: 3 * stdtime.Second, want: "3s"},
+		{name: "boundary/just-below-minute", description: "验证不足 1 分钟时仍使用秒。", d: stdtime.Minute - stdtime.Millisecond, want: "60s"},
+		{name: "success/minutes", description: "验证 1 分钟及以上时精确到 0.1 秒。", d: 2*stdtime.Minute + 3540*stdtime.Millisecond, want: "2m3.5s"},
+		{name: "success/hours", description: "验证小时级耗时。", d: stdtime.Hour + 20*stdtime.Millisecond, want: "1h0m0s"},
+		{name: "success/negative", description: "验证负数耗时带 - 前缀。", d: -1500 * stdtime.Microsecond, want: "-1.5ms"},
+		{name: "boundary/negative-minutes", description: "验证 1 分钟以上的负数耗时。", d: -90 * stdtime.Second, want: "-1m30s"},
+		{name: "boundary/min-duration", description: "验证最小 Duration 不会溢出。", d: stdtime.Duration(-1 << 63), want: stdtime.Duration(-1 << 63).Round(100 * stdtime.Millisecond).String()},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			assert.Equal(t, tt.want, FormatDuration(tt.d))
+		})
+	}
+}
+
+// TestMeasure 验证 Measure 返回耗时与错误，并按配置输出日志。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestMeasure(t *testing.T) {
+	errFailed := errors.New("failed")
+
+	tests := []struct {
+		name        string
+		description string
+		err         error
+		opts        func(l kitlog.Logger) []MeasureOption
+		wantLevel   kitlog.Level
+		wantLogged  bool
+	}{
+		{
+			name:        "success/no-log",
+			description: "验证未设置 WithMeasureLog 时不输出日志。",
+			opts:        func(kitlog.Logger) []MeasureOption { return nil },
+		},
+		{
+			name:        "success/info",
+			description: "验证成功时默认以 Info 级别输出耗时日志。",
+			opts:        func(l kitlog.Logger) []MeasureOption { return []MeasureOption{WithMeasureLog(l, "load config")} },
+			wantLevel:   kitlog.InfoLevel,
+			wantLogged:  true,
+		},
+		{
+			name:        "success/level",
+			description: "验证 WithMeasureLevel 设置成功时的日志级别。",
+			opts: func(l kitlog.Logger) []MeasureOption {
+				return []MeasureOption{WithMeasureLog(l, "load config"), WithMeasureLevel(kitlog.DebugLevel)}
+			},
+			wantLevel:  kitlog.DebugLevel,
+			wantLogged: true,
+		},
+		{
+			name:        "boundary/fatal-level",
+			description: "验证 FatalLevel 按 Info 级别输出，不会退出进程。",
+			opts: func(l kitlog.Logger) []MeasureOption {
+				return []MeasureOption{WithMeasureLog(l, "load config"), WithMeasureLevel(kitlog.FatalLevel)}
+			},
+			wantLevel:  kitlog.InfoLevel,
+			wantLogged: true,
+		},
+		{
+			name:        "success/below-threshold",
+			description: "验证耗时低于阈值时不输出日志。",
+			opts: func(l kitlog.Logger) []MeasureOption {
+				return []MeasureOption{WithMeasureLog(l, "load config"), WithMeasureThreshold(stdtime.Hour)}
+			},
+		},
+		{
+			name:        "error/always-logged",
+			description: "验证返回错误时忽略阈值并以 Error 级别输出，附加错误字段。",
+			err:         errFailed,
+			opts: func(l kitlog.Logger) []MeasureOption {
+				return []MeasureOption{
+					WithMeasureLog(l, "load config"),
+					WithMeasureLevel(kitlog.WarnLevel),
+					WithMeasureThreshold(stdtime.Hour),
+				}
+			},
+			wantLevel:  kitlog.ErrorLevel,
+			wantLogged: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			var entries []measureLogEntry
+			logger := &measureLogger{entries: &entries}
+
+			elapsed, err := Measure(func() error {
+				stdtime.Sleep(stdtime.Millisecond)
+				return tt.err
+			}, tt.opts(logger)...)
+			assert.ErrorIs(t, err, tt.err)
+			assert.GreaterOrEqual(t, elapsed, stdtime.Millisecond)
+
+			if !tt.wantLogged {
+				assert.Empty(t, entries)
+				return
+			}
+			require.Len(t, entries, 1)
+			entry := entries[0]
+			assert.Equal(t, tt.wantLevel, entry.level)
+			assert.Equal(t, "load config took "+FormatDuration(elapsed), entry.msg)
+			assert.Equal(t, "load config", entry.fields[MeasureOperationField])
+			assert.Equal(t, elapsed, entry.fields[MeasureElapsedField])
+			if nil != tt.err {
+				assert.Equal(t, tt.err, entry.fields[MeasureErrorField])
+			} else {
+				assert.NotContains(t, entry.fields, MeasureErrorField)
+			}
+		})
+	}
+}
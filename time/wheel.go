@@ -0,0 +1,351 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package time
+
+import (
+	"container/list"
+	"errors"
+	"sync"
+	stdtime "time"
+)
+
+var (
+	// ErrInvalidTimingWheel 表示创建时间轮时 tick 或 wheelSize 不是正数。
+	ErrInvalidTimingWheel = errors.New("invalid timing wheel")
+)
+
+type (
+	// TimingWheel 是哈希时间轮，用于以较低成本管理大量精度要求不高的超时，例如连接空闲和心跳超时。
+	//
+	// 时间轮由 wheelSize 个槽组成，指针每隔 tick 前进一个槽；超过一圈的定时器记录剩余圈数。添加、停止和
+	// 重置定时器均为 O(1)，每个 tick 只处理当前槽中的定时器，因此数十万个定时器只需要一个底层 time.Ticker。
+	// 定时器的触发时间会延后不超过一个 tick，回调在独立的 goroutine 中执行。
+	//
+	// TimingWheel 可安全并发使用，不再使用时应调用 Stop 释放后台 goroutine。
+	TimingWheel struct {
+		// tick 是指针前进一个槽的时间间隔。
+		tick stdtime.Duration
+		// mu 保护 slots、pos、count 和 stopped。
+		mu sync.Mutex
+		// slots 是时间轮的槽，每个槽是 *WheelTimer 的双向链表。
+		slots []*list.List
+		// pos 是指针当前所在的槽。
+		pos int
+		// count 是等待触发的定时器数量。
+		count int
+		// stopped 表示时间轮已停止。
+		stopped bool
+		// done 在 Stop 时关闭，通知后台 goroutine 退出。
+		done chan struct{}
+	}
+
+	// WheelTimer 是 TimingWheel 中的定时器，由 AfterFunc 或 Schedule 返回。
+	WheelTimer struct {
+		// wheel 是定时器所属的时间轮。
+		wheel *TimingWheel
+		// fn 是到期时执行的回调。
+		fn func()
+		// scheduler 是周期定时器的调度器；AfterFunc 创建的定时器为 nil。
+		scheduler Scheduler
+		// slot 是定时器所在的槽。
+		slot int
+		// rounds 是到期前指针还需经过该槽的圈数。
+		rounds int
+		// elem 是定时器在槽链表中的元素；未等待触发时为 nil。
+		elem *list.Element
+		// stopped 表示周期定时器已被 Stop，不再安排下一次触发。
+		stopped bool
+	}
+
+	// Scheduler 决定周期定时器的下一次触发时间。
+	Scheduler interface {
+		// Next 返回 prev 之后的下一次触发时间。
+		//
+		// 参数：
+		//   - prev: 上一次触发（或首次调度）的时间。
+		//
+		// 返回：
+		//   - time.Time: 下一次触发时间；返回零值表示不再触发。
+		Next(prev stdtime.Time) stdtime.Time
+	}
+
+	// everyScheduler 是按固定间隔触发的 Scheduler。
+	everyScheduler stdtime.Duration
+)
+
+// NewTimingWheel 创建并启动时间轮。
+//
+// 参数：
+//   - tick: 指针前进一个槽的时间间隔，同时也是定时器的精度。
+//   - wheelSize: 槽的数量；tick*wheelSize 为一圈覆盖的时长，超出一圈的定时器按圈数计数。
+//
+// 返回：
+//   - *TimingWheel: 已启动的时间轮。
+//   - error: tick 或 wheelSize 不是正数时返回 ErrInvalidTimingWheel。
+func NewTimingWheel(tick stdtime.Duration, wheelSize int) (*TimingWheel, error) {
+	tw, err := newTimingWheel(tick, wheelSize)
+	if nil != err {
+		return nil, err
+	}
+	go tw.run()
+	return tw, nil
+}
+
+// newTimingWheel 创建未启动的时间轮。
+//
+// 参数：
+//   - tick: 指针前进一个槽的时间间隔。
+//   - wheelSize: 槽的数量。
+//
+// 返回：
+//   - *TimingWheel: 未启动后台 goroutine 的时间轮。
+//   - error: 参数不是正数时返回 ErrInvalidTimingWheel。
+func newTimingWheel(tick stdtime.Duration, wheelSize int) (*TimingWheel, error) {
+	if tick <= 0 || wheelSize <= 0 {
+		return nil, ErrInvalidTimingWheel
+	}
+	slots := make([]*list.List, wheelSize)
+	for i := range slots {
+		slots[i] = list.New()
+	}
+	return &TimingWheel{
+		tick:  tick,
+		slots: slots,
+		done:  make(chan struct{}),
+	}, nil
+}
+
+// Every 返回按固定间隔触发的 Scheduler。
+//
+// 参数：
+//   - interval: 触发间隔；小于等于 0 时 Next 返回零值，即只调度不触发。
+//
+// 返回：
+//   - Scheduler: 固定间隔调度器。
+func Every(interval stdtime.Duration) Scheduler {
+	return everyScheduler(interval)
+}
+
+// Next 返回 prev 加上固定间隔的时间。
+//
+// 参数：
+//   - prev: 上一次触发时间。
+//
+// 返回：
+//   - time.Time: 下一次触发时间；间隔小于等于 0 时返回零值。
+func (s everyScheduler) Next(prev stdtime.Time) stdtime.Time {
+	if s <= 0 {
+		return stdtime.Time{}
+	}
+	return prev.Add(stdtime.Duration(s))
+}
+
+// AfterFunc 在 d 之后于独立 goroutine 中执行 f。
+//
+// 参数：
+//   - d: 延迟时间，向上取整到 tick 的整数倍；小于等于 tick 时在下一个 tick 触发。
+//   - f: 到期时执行的回调。
+//
+// 返回：
+//   - *WheelTimer: 可用于 Stop 或 Reset 的定时器；时间轮已停止时返回的定时器不会触发。
+func (tw *TimingWheel) AfterFunc(d stdtime.Duration, f func()) *WheelTimer {
+	t := &WheelTimer{wheel: tw, fn: f}
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	tw.add(t, d)
+	return t
+}
+
+// Schedule 按 s 返回的时间周期性地在独立 goroutine 中执行 f。
+//
+// 下一次触发时间在本次到期时根据 s.Next 计算：返回零值时停止调度，返回的时间早于当前时间时在下一个 tick
+// 触发。回调执行时间较长时，同一周期定时器的多次回调可能并发执行。
+//
+// 参数：
+//   - s: 调度器，例如 Every(time.Second)。
+//   - f: 每次到期时执行的回调。
+//
+// 返回：
+//   - *WheelTimer: 调用 Stop 可终止后续触发；s 首次返回零值或时间轮已停止时返回的定时器不会触发。
+func (tw *TimingWheel) Schedule(s Scheduler, f func()) *WheelTimer {
+	t := &WheelTimer{wheel: tw, fn: f, scheduler: s}
+	now := stdtime.Now()
+	next := s.Next(now)
+	if next.IsZero() {
+		return t
+	}
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	tw.add(t, next.Sub(now))
+	return t
+}
+
+// Len 返回等待触发的定时器数量。
+//
+// 参数：无。
+//
+// 返回：
+//   - int: 等待触发的定时器数量。
+func (tw *TimingWheel) Len() int {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	return tw.count
+}
+
+// Stop 停止时间轮，未触发的定时器将不再触发，可重复调用。
+//
+// 参数：无。
+func (tw *TimingWheel) Stop() {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	if tw.stopped {
+		return
+	}
+	tw.stopped = true
+	close(tw.done)
+	for _, slot := range tw.slots {
+		for e := slot.Front(); nil != e; e = e.Next() {
+			e.Value.(*WheelTimer).elem = nil
+		}
+		slot.Init()
+	}
+	tw.count = 0
+}
+
+// Stop 停止定时器，周期定时器不再安排后续触发。
+//
+// 参数：无。
+//
+// 返回：
+//   - bool: 定时器处于等待触发状态并被成功停止时为 true；已触发、已停止或从未调度时为 false。
+func (t *WheelTimer) Stop() bool {
+	tw := t.wheel
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	t.stopped = true
+	return tw.remove(t)
+}
+
+// Reset 将定时器改为在 d 之后触发，适合在收到心跳时推迟连接超时。
+//
+// 对周期定时器调用 Reset 会把下一次触发推迟到 d 之后，并恢复被 Stop 终止的后续调度。
+//
+// 参数：
+//   - d: 新的延迟时间，取整规则同 AfterFunc。
+//
+// 返回：
+//   - bool: 重置前定时器处于等待触发状态时为 true。
+func (t *WheelTimer) Reset(d stdtime.Duration) bool {
+	tw := t.wheel
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	pending := tw.remove(t)
+	t.stopped = false
+	tw.add(t, d)
+	return pending
+}
+
+// run 按 tick 驱动时间轮前进，直到 Stop 被调用。
+//
+// 参数：无。
+func (tw *TimingWheel) run() {
+	ticker := stdtime.NewTicker(tw.tick)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			tw.advance()
+		case <-tw.done:
+			return
+		}
+	}
+}
+
+// advance 将指针前进一个槽，并触发该槽中剩余圈数为 0 的定时器。
+//
+// 参数：无。
+func (tw *TimingWheel) advance() {
+	tw.mu.Lock()
+	if tw.stopped {
+		tw.mu.Unlock()
+		return
+	}
+	tw.pos = (tw.pos + 1) % len(tw.slots)
+	slot := tw.slots[tw.pos]
+
+	var expired []*WheelTimer
+	for e := slot.Front(); nil != e; {
+		next := e.Next()
+		t := e.Value.(*WheelTimer)
+		if t.rounds > 0 {
+			t.rounds--
+		} else {
+			slot.Remove(e)
+			t.elem = nil
+			tw.count--
+			expired = append(expired, t)
+		}
+		e = next
+	}
+	tw.mu.Unlock()
+
+	for _, t := range expired {
+		go tw.fire(t)
+	}
+}
+
+// fire 执行定时器回调，周期定时器在回调前安排下一次触发。
+//
+// 参数：
+//   - t: 已到期的定时器。
+func (tw *TimingWheel) fire(t *WheelTimer) {
+	if nil != t.scheduler {
+		now := stdtime.Now()
+		next := t.scheduler.Next(now)
+		tw.mu.Lock()
+		if !t.stopped && nil == t.elem && !next.IsZero() {
+			tw.add(t, next.Sub(now))
+		}
+		tw.mu.Unlock()
+	}
+	t.fn()
+}
+
+// add 将定时器放入 d 之后到期的槽，调用方必须持有 tw.mu。
+//
+// 参数：
+//   - t: 未在任何槽中的定时器。
+//   - d: 延迟时间。
+func (tw *TimingWheel) add(t *WheelTimer, d stdtime.Duration) {
+	if tw.stopped {
+		return
+	}
+	ticks := int((d + tw.tick - 1) / tw.tick)
+	if ticks < 1 {
+		ticks = 1
+	}
+	size := len(tw.slots)
+	t.slot = (tw.pos + ticks) % size
+	t.rounds = (ticks - 1) / size
+	t.elem = tw.slots[t.slot].PushBack(t)
+	tw.count++
+}
+
+// remove 将定时器从所在槽中移除，调用方必须持有 tw.mu。
+//
+// 参数：
+//   - t: 待移除的定时器。
+//
+// 返回：
+//   - bool: 定时器处于等待触发状态并被移除时为 true。
+func (tw *TimingWheel) remove(t *WheelTimer) bool {
+	if nil == t.elem {
+		return false
+	}
+	tw.slots[t.slot].Remove(t.elem)
+	t.elem = nil
+	tw.count--
+	return true
+}
@@ -0,0 +1,164 @@
+// Copyright 2025 fsyyft-go
+//
+// Licensed under the MIT License. See LICENSE file in the project root for full license information.
+
+package time
+
+import (
+	"sync/atomic"
+	"testing"
+	stdtime "time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNewTimingWheel_InvalidArguments 验证非正数参数返回 ErrInvalidTimingWheel。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestNewTimingWheel_InvalidArguments(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		tick        stdtime.Duration
+		wheelSize   int
+	}{
+		{name: "error/zero-tick", description: "验证 tick 为 0 时返回错误。", tick: 0, wheelSize: 8},
+		{name: "error/negative-size", description: "验证 wheelSize 为负数时返回错误。", tick: stdtime.Millisecond, wheelSize: -1},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			tw, err := NewTimingWheel(tt.tick, tt.wheelSize)
+			assert.ErrorIs(t, err, ErrInvalidTimingWheel)
+			assert.Nil(t, tw)
+		})
+	}
+}
+
+// TestTimingWheel_AfterFuncTicks 通过手动推进验证定时器在预期的 tick 触发，包括跨越多圈的情况。
+//
+// 参数：
+//   - t: 测试上下文，用于运行子测试和报告断言失败。
+func TestTimingWheel_AfterFuncTicks(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		delay       stdtime.Duration
+		wantTicks   int
+	}{
+		{name: "boundary/shorter-than-tick", description: "验证小于一个 tick 的延迟在下一个 tick 触发。", delay: stdtime.Millisecond, wantTicks: 1},
+		{name: "success/round-up", description: "验证延迟向上取整到 tick 的整数倍。", delay: 25 * stdtime.Millisecond, wantTicks: 3},
+		{name: "boundary/one-round", description: "验证恰好一圈的延迟在回到同一槽时触发。", delay: 40 * stdtime.Millisecond, wantTicks: 4},
+		{name: "success/multiple-rounds", description: "验证超过一圈的延迟按圈数计数。", delay: 110 * stdtime.Millisecond, wantTicks: 11},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Log(tt.description)
+
+			tw, err := newTimingWheel(10*stdtime.Millisecond, 4)
+			require.NoError(t, err)
+			fired := make(chan struct{}, 1)
+			tw.AfterFunc(tt.delay, func() { fired <- struct{}{} })
+
+			for i := 1; i < tt.wantTicks; i++ {
+				tw.advance()
+			}
+			assert.Equal(t, 1, tw.Len())
+			tw.advance()
+			assert.Zero(t, tw.Len())
+
+			select {
+			case <-fired:
+			case <-stdtime.After(wheelWaitTimeout):
+				t.Fatal("定时器未触发")
+			}
+		})
+	}
+}
+
+// TestWheelTimer_StopAndReset 验证停止和重置定时器。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestWheelTimer_StopAndReset(t *testing.T) {
+	tw, err := newTimingWheel(stdtime.Second, 8)
+	require.NoError(t, err)
+
+	var fired atomic.Int32
+	timer := tw.AfterFunc(2*stdtime.Second, func() { fired.Add(1) })
+	assert.True(t, timer.Stop())
+	assert.False(t, timer.Stop())
+	for i := 0; i < 3; i++ {
+		tw.advance()
+	}
+	assert.Zero(t, fired.Load())
+
+	// 重置已停止的定时器后重新等待。
+	assert.False(t, timer.Reset(3*stdtime.Second))
+	tw.advance()
+	tw.advance()
+	// 心跳到达，推迟超时。
+	assert.True(t, timer.Reset(3*stdtime.Second))
+	tw.advance()
+	tw.advance()
+	assert.Equal(t, 1, tw.Len())
+	tw.advance()
+	assert.Zero(t, tw.Len())
+	assert.Eventually(t, func() bool { return 1 == fired.Load() }, wheelWaitTimeout, stdtime.Millisecond)
+}
+
+// TestTimingWheel_Schedule 验证周期定时器重复触发并可停止。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestTimingWheel_Schedule(t *testing.T) {
+	tw, err := NewTimingWheel(5*stdtime.Millisecond, 16)
+	require.NoError(t, err)
+	defer tw.Stop()
+
+	var fired atomic.Int32
+	timer := tw.Schedule(Every(10*stdtime.Millisecond), func() { fired.Add(1) })
+	assert.Eventually(t, func() bool { return fired.Load() >= 3 }, wheelWaitTimeout, stdtime.Millisecond)
+
+	timer.Stop()
+	stopped := fired.Load()
+	stdtime.Sleep(50 * stdtime.Millisecond)
+	assert.LessOrEqual(t, fired.Load(), stopped+1)
+
+	never := tw.Schedule(Every(0), func() { fired.Add(100) })
+	assert.False(t, never.Stop())
+}
+
+// TestTimingWheel_Stop 验证停止时间轮后定时器不再触发，且新定时器不会被调度。
+//
+// 参数：
+//   - t: 测试上下文，用于报告断言失败。
+func TestTimingWheel_Stop(t *testing.T) {
+	tw, err := NewTimingWheel(stdtime.Millisecond, 8)
+	require.NoError(t, err)
+
+	var fired atomic.Int32
+	for i := 0; i < 1000; i++ {
+		tw.AfterFunc(stdtime.Hour, func() { fired.Add(1) })
+	}
+	assert.Equal(t, 1000, tw.Len())
+
+	tw.Stop()
+	tw.Stop()
+	assert.Zero(t, tw.Len())
+
+	timer := tw.AfterFunc(stdtime.Millisecond, func() { fired.Add(1) })
+	assert.False(t, timer.Stop())
+	stdtime.Sleep(10 * stdtime.Millisecond)
+	assert.Zero(t, fired.Load())
+}
+
+// wheelWaitTimeout 是测试等待回调的最长时间。
+const wheelWaitTimeout = stdtime.Second